@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+	exportserver "kubevirt.io/kubevirt/pkg/virt-exportserver"
+)
+
+func main() {
+	listenAddr := pflag.String("listen", ":8443", "Address the export server listens on")
+	certFile := pflag.String("cert", "/cert/tls.crt", "TLS certificate of the export server")
+	keyFile := pflag.String("key", "/cert/tls.key", "TLS key of the export server")
+	tokenFile := pflag.String("token-file", "/token/token", "File containing the token clients have to present")
+	scratchDir := pflag.String("scratch-dir", "/scratch", "Directory used to store converted images")
+	volumes := pflag.StringArray("volume", nil, "Volume to export in the form name=path, can be repeated")
+	pushImages := pflag.StringArray("push-image", nil, "Push the volume as containerDisk image instead of serving it, in the form name=image, can be repeated")
+	registryAuthFile := pflag.String("registry-auth-file", "", "Docker config file holding the credentials of the registry")
+	insecureSkipTLSVerify := pflag.Bool("insecure-skip-tls-verify", false, "Skip the verification of the certificate of the registry")
+	terminationLog := pflag.String("termination-log", "/dev/termination-log", "File the pushed images are reported in")
+	pflag.Parse()
+
+	log.InitializeLogging("virt-exportserver")
+
+	config := exportserver.ExportServerConfig{
+		ListenAddr: *listenAddr,
+		CertFile:   *certFile,
+		KeyFile:    *keyFile,
+		TokenFile:  *tokenFile,
+		ScratchDir: *scratchDir,
+	}
+
+	for _, v := range *volumes {
+		name, path := splitPair(v, "name=path")
+		config.Volumes = append(config.Volumes, exportserver.ExportVolume{Name: name, Path: path})
+	}
+
+	if len(*pushImages) > 0 {
+		pushConfig := exportserver.ContainerDiskPushConfig{
+			ScratchDir:            *scratchDir,
+			AuthFile:              *registryAuthFile,
+			InsecureSkipTLSVerify: *insecureSkipTLSVerify,
+		}
+		for _, v := range *pushImages {
+			name, image := splitPair(v, "name=image")
+			pushConfig.Images = append(pushConfig.Images, exportserver.ContainerDiskImage{
+				Volume: findVolume(config.Volumes, name),
+				Image:  image,
+			})
+		}
+		pushContainerDisks(pushConfig, *terminationLog)
+		return
+	}
+
+	if err := exportserver.NewExportServer(config).Run(); err != nil {
+		log.Log.Reason(err).Error("Export server failed")
+		os.Exit(1)
+	}
+}
+
+func splitPair(v string, format string) (string, string) {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		log.Log.Errorf("Invalid argument %q, expected %s", v, format)
+		os.Exit(1)
+	}
+	return parts[0], parts[1]
+}
+
+func findVolume(volumes []exportserver.ExportVolume, name string) exportserver.ExportVolume {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return volume
+		}
+	}
+	log.Log.Errorf("No volume %s to push", name)
+	os.Exit(1)
+	return exportserver.ExportVolume{}
+}
+
+// pushContainerDisks reports the pushed images, or the error, in the termination log of the container
+func pushContainerDisks(config exportserver.ContainerDiskPushConfig, terminationLog string) {
+	pushed, err := exportserver.NewContainerDiskPusher(config).Push()
+	if err != nil {
+		log.Log.Reason(err).Error("Pushing the containerDisk images failed")
+		if err := ioutil.WriteFile(terminationLog, []byte(err.Error()), 0644); err != nil {
+			log.Log.Reason(err).Error("Failed to write the termination log")
+		}
+		os.Exit(1)
+	}
+
+	result, err := json.Marshal(pushed)
+	if err == nil {
+		err = ioutil.WriteFile(terminationLog, result, 0644)
+	}
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to report the pushed containerDisk images")
+		os.Exit(1)
+	}
+}
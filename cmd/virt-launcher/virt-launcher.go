@@ -22,6 +22,7 @@ package main
 import (
 	goflag "flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -47,9 +48,11 @@ import (
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
+	kutil "kubevirt.io/kubevirt/pkg/util"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	virtlauncher "kubevirt.io/kubevirt/pkg/virt-launcher"
 	notifyclient "kubevirt.io/kubevirt/pkg/virt-launcher/notify-client"
+	serialconsolelog "kubevirt.io/kubevirt/pkg/virt-launcher/serial-console-log"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap"
 	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -216,6 +219,11 @@ func initializeDirs(virtShareDir string,
 	if err != nil {
 		panic(err)
 	}
+
+	err = virtlauncher.InitializeDisksDirectories(config.DownwardMetricDisksDir)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func waitForDomainUUID(timeout time.Duration, events chan watch.Event, stop chan struct{}, domainManager virtwrap.DomainManager) *api.Domain {
@@ -327,6 +335,9 @@ func main() {
 	qemuAgentFileInterval := pflag.Duration("qemu-agent-file-interval", 300, "Interval in seconds between consecutive qemu agent calls for file command")
 	qemuAgentUserInterval := pflag.Duration("qemu-agent-user-interval", 10, "Interval in seconds between consecutive qemu agent calls for user command")
 	qemuAgentVersionInterval := pflag.Duration("qemu-agent-version-interval", 300, "Interval in seconds between consecutive qemu agent calls for version command")
+	logSerialConsole := pflag.Bool("log-serial-console", false, "Mirror the serial console of the guest to the virt-launcher log")
+	serialConsoleLogMaxFileSize := pflag.Int64("serial-console-log-max-file-size", serialconsolelog.DefaultMaxFileSize, "Size in bytes after which the serial console log on the PVC is rotated")
+	serialConsoleLogMaxFiles := pflag.Int("serial-console-log-max-files", serialconsolelog.DefaultMaxFiles, "Number of serial console log files which are kept on the PVC")
 	// set new default verbosity, was set to 0 by glog
 	goflag.Set("v", "2")
 
@@ -378,6 +389,10 @@ func main() {
 	domainName := api.VMINamespaceKeyFunc(vmi)
 	util.StartVirtlog(stopChan, domainName)
 
+	if *logSerialConsole {
+		startSerialConsoleLog(*uid, *serialConsoleLogMaxFileSize, *serialConsoleLogMaxFiles, stopChan)
+	}
+
 	domainConn := createLibvirtConnection()
 	defer domainConn.Close()
 
@@ -471,6 +486,23 @@ func main() {
 	log.Log.Info("Exiting...")
 }
 
+// startSerialConsoleLog mirrors the serial console log written by virtlogd to the
+// virt-launcher log and, if a PVC is mounted for it, to rotating files on the PVC
+func startSerialConsoleLog(uid string, maxFileSize int64, maxFiles int, stopChan chan struct{}) {
+	var out io.Writer = &serialconsolelog.LineLogger{}
+	if _, err := os.Stat(kutil.SerialConsoleLogDir); err == nil {
+		rotatingFile, err := serialconsolelog.NewRotatingFile(kutil.SerialConsoleLogDir, maxFileSize, maxFiles)
+		if err != nil {
+			log.Log.Reason(err).Error("failed to open the serial console log on the PVC")
+		} else {
+			out = io.MultiWriter(out, rotatingFile)
+		}
+	}
+
+	logFile := filepath.Join("/var/run/kubevirt-private", uid, kutil.SerialConsoleLogFile)
+	go serialconsolelog.Follow(logFile, out, time.Second, stopChan)
+}
+
 // ForkAndMonitor itself to give qemu an extra grace period to properly terminate
 // in case of virt-launcher crashes
 func ForkAndMonitor(containerDiskDir string) (int, error) {
@@ -0,0 +1,79 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+// virt-probe runs the guest agent based probes of a VirtualMachineInstance from within
+// the compute container. It exits non-zero when the probe fails.
+func main() {
+	os.Exit(probe())
+}
+
+func probe() int {
+	domainName := pflag.String("domainName", "", "Name of the domain to probe")
+	timeoutSeconds := pflag.Int32("timeoutSeconds", 1, "Number of seconds after which the probe times out")
+	guestAgentPing := pflag.Bool("guestAgentPing", false, "Ping the guest agent instead of executing a command on the guest")
+	pflag.Parse()
+
+	log.InitializeLogging("virt-probe")
+
+	if *domainName == "" {
+		log.Log.Error("no domain name given")
+		return 1
+	}
+
+	client, err := cmdclient.NewClient(cmdclient.SocketOnGuest())
+	if err != nil {
+		log.Log.Reason(err).Error("failed to connect to virt-launcher")
+		return 1
+	}
+	defer client.Close()
+
+	if *guestAgentPing {
+		if err := client.GuestPing(*domainName, *timeoutSeconds); err != nil {
+			log.Log.Reason(err).Error("guest agent ping failed")
+			return 1
+		}
+		return 0
+	}
+
+	command := pflag.Args()
+	if len(command) == 0 {
+		log.Log.Error("no command given")
+		return 1
+	}
+
+	exitCode, stdOut, err := client.Exec(*domainName, command[0], command[1:], *timeoutSeconds)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to execute %s on the guest", command[0])
+		return 1
+	}
+	fmt.Print(stdOut)
+	return exitCode
+}
@@ -0,0 +1,129 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package backendstorage
+
+import (
+	"context"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// PVCPrefix is prepended to the VMI name to form the name of its backend storage PVC
+	PVCPrefix = "persistent-state-for-"
+	// PVCSize is the size of the backend storage PVC, which only holds small state files
+	PVCSize = "10Mi"
+	// VolumeName is the name of the backend storage volume in the virt-launcher pod
+	VolumeName = "vm-state"
+	// SwtpmStateDir is where libvirt keeps the state of the emulated TPM devices
+	SwtpmStateDir = "/var/lib/libvirt/swtpm"
+	// NVRAMStateDir is where the EFI NVRAM of the VMI is kept
+	NVRAMStateDir = "/var/lib/libvirt/qemu/nvram"
+	// NVRAMSubPath is the directory of the backend storage volume holding the EFI NVRAM
+	NVRAMSubPath = "nvram"
+)
+
+// PVCForVMI returns the name of the backend storage PVC of the given VMI.
+// The name only depends on the VMI name so that the state survives VMI restarts.
+func PVCForVMI(vmi *v1.VirtualMachineInstance) string {
+	return PVCPrefix + vmi.Name
+}
+
+// HasPersistentTPMDevice returns true if the state of the TPM device of the VMI has to be kept
+func HasPersistentTPMDevice(vmi *v1.VirtualMachineInstance) bool {
+	tpm := vmi.Spec.Domain.Devices.TPM
+	return tpm != nil && tpm.Persistent != nil && *tpm.Persistent
+}
+
+// HasPersistentEFI returns true if the EFI NVRAM of the VMI has to be kept
+func HasPersistentEFI(vmi *v1.VirtualMachineInstance) bool {
+	firmware := vmi.Spec.Domain.Firmware
+	if firmware == nil || firmware.Bootloader == nil || firmware.Bootloader.EFI == nil {
+		return false
+	}
+	persistent := firmware.Bootloader.EFI.Persistent
+	return persistent != nil && *persistent
+}
+
+// IsBackendStorageNeeded returns true if the VMI has devices whose state
+// has to be kept outside of the virt-launcher pod.
+func IsBackendStorageNeeded(vmi *v1.VirtualMachineInstance) bool {
+	return HasPersistentTPMDevice(vmi) || HasPersistentEFI(vmi)
+}
+
+// CreateIfNeeded creates the backend storage PVC of the VMI if it requires one and
+// it does not exist yet. The PVC is owned by the controller of the VMI, usually a
+// VirtualMachine, so that it is only garbage collected together with the VM.
+func CreateIfNeeded(vmi *v1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig, client kubecli.KubevirtClient) error {
+	if !IsBackendStorageNeeded(vmi) {
+		return nil
+	}
+
+	_, err := client.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(context.Background(), PVCForVMI(vmi), metav1.GetOptions{})
+	if err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	ownerReferences := vmi.OwnerReferences
+	if len(ownerReferences) == 0 {
+		ownerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(vmi, v1.VirtualMachineInstanceGroupVersionKind),
+		}
+	}
+
+	var storageClass *string
+	if storageClassName := clusterConfig.GetVMStateStorageClass(); storageClassName != "" {
+		storageClass = &storageClassName
+	}
+	volumeMode := k8sv1.PersistentVolumeFilesystem
+
+	pvc := &k8sv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            PVCForVMI(vmi),
+			OwnerReferences: ownerReferences,
+		},
+		Spec: k8sv1.PersistentVolumeClaimSpec{
+			// The state has to be accessible from the migration target as well
+			AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+			Resources: k8sv1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{
+					k8sv1.ResourceStorage: resource.MustParse(PVCSize),
+				},
+			},
+			StorageClassName: storageClass,
+			VolumeMode:       &volumeMode,
+		},
+	}
+
+	_, err = client.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
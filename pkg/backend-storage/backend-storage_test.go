@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package backendstorage
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Backend storage", func() {
+	var virtClient *kubecli.MockKubevirtClient
+	var kubeClient *fake.Clientset
+
+	newVMIWithTPM := func(persistent bool) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Namespace = k8sv1.NamespaceDefault
+		vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: pointer.BoolPtr(persistent)}
+		return vmi
+	}
+
+	newClusterConfig := func(storageClass string) *virtconfig.ClusterConfig {
+		config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+			Data: map[string]string{virtconfig.VMStateStorageClassKey: storageClass},
+		})
+		return config
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	It("should only be needed for a persistent TPM or EFI", func() {
+		Expect(IsBackendStorageNeeded(v1.NewMinimalVMI("testvmi"))).To(BeFalse())
+		Expect(IsBackendStorageNeeded(newVMIWithTPM(false))).To(BeFalse())
+		Expect(IsBackendStorageNeeded(newVMIWithTPM(true))).To(BeTrue())
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Firmware = &v1.Firmware{Bootloader: &v1.Bootloader{EFI: &v1.EFI{}}}
+		Expect(IsBackendStorageNeeded(vmi)).To(BeFalse())
+		vmi.Spec.Domain.Firmware.Bootloader.EFI.Persistent = pointer.BoolPtr(true)
+		Expect(IsBackendStorageNeeded(vmi)).To(BeTrue())
+	})
+
+	It("should not create a PVC if no backend storage is needed", func() {
+		Expect(CreateIfNeeded(newVMIWithTPM(false), newClusterConfig(""), virtClient)).To(Succeed())
+		pvcs, err := kubeClient.CoreV1().PersistentVolumeClaims(k8sv1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvcs.Items).To(BeEmpty())
+	})
+
+	It("should create a PVC with the configured storage class", func() {
+		vmi := newVMIWithTPM(true)
+		Expect(CreateIfNeeded(vmi, newClusterConfig("rook-cephfs"), virtClient)).To(Succeed())
+		pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(k8sv1.NamespaceDefault).Get(context.Background(), "persistent-state-for-testvmi", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*pvc.Spec.StorageClassName).To(Equal("rook-cephfs"))
+		Expect(pvc.Spec.AccessModes).To(ConsistOf(k8sv1.ReadWriteMany))
+		Expect(pvc.OwnerReferences).To(HaveLen(1))
+		Expect(pvc.OwnerReferences[0].Kind).To(Equal("VirtualMachineInstance"))
+	})
+
+	It("should keep the owner of the VMI and tolerate an existing PVC", func() {
+		vmi := newVMIWithTPM(true)
+		vmi.OwnerReferences = []metav1.OwnerReference{{Kind: "VirtualMachine", Name: "testvm"}}
+		Expect(CreateIfNeeded(vmi, newClusterConfig(""), virtClient)).To(Succeed())
+		Expect(CreateIfNeeded(vmi, newClusterConfig(""), virtClient)).To(Succeed())
+		pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(k8sv1.NamespaceDefault).Get(context.Background(), PVCForVMI(vmi), metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvc.Spec.StorageClassName).To(BeNil())
+		Expect(pvc.OwnerReferences[0].Kind).To(Equal("VirtualMachine"))
+	})
+})
@@ -31,6 +31,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ghodss/yaml"
+
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
@@ -66,6 +68,7 @@ type CloudInitData struct {
 	ConfigDriveMetaData *ConfigDriveMetadata
 	UserData            string
 	NetworkData         string
+	VendorData          string
 	DevicesData         *[]DeviceData
 }
 
@@ -74,8 +77,9 @@ type PublicSSHKey struct {
 }
 
 type NoCloudMetadata struct {
-	InstanceID    string `json:"instance-id"`
-	LocalHostname string `json:"local-hostname,omitempty"`
+	InstanceID    string            `json:"instance-id"`
+	LocalHostname string            `json:"local-hostname,omitempty"`
+	PublicSSHKeys map[string]string `json:"public-keys,omitempty"`
 }
 
 type ConfigDriveMetadata struct {
@@ -96,11 +100,172 @@ type DeviceData struct {
 	Tags    []string           `json:"tags"`
 }
 
+// NetworkInterfaceData holds the addressing of a guest network interface,
+// it is used to generate the NoCloud networkdata.
+type NetworkInterfaceData struct {
+	Name          string
+	MAC           string
+	Addresses     []string
+	Gateway4      string
+	Gateway6      string
+	Routes        []NetworkRouteData
+	MTU           uint16
+	Nameservers   []string
+	SearchDomains []string
+}
+
+type NetworkRouteData struct {
+	To  string
+	Via string
+}
+
+// networkConfig is the cloud-init network configuration version 2
+// More info: https://cloudinit.readthedocs.io/en/latest/topics/network-config-format-v2.html
+type networkConfig struct {
+	Version   int                       `json:"version"`
+	Ethernets map[string]ethernetConfig `json:"ethernets"`
+}
+
+type ethernetConfig struct {
+	Match       ethernetMatch      `json:"match"`
+	Addresses   []string           `json:"addresses,omitempty"`
+	Gateway4    string             `json:"gateway4,omitempty"`
+	Gateway6    string             `json:"gateway6,omitempty"`
+	Routes      []routeConfig      `json:"routes,omitempty"`
+	MTU         uint16             `json:"mtu,omitempty"`
+	Nameservers *nameserversConfig `json:"nameservers,omitempty"`
+}
+
+type ethernetMatch struct {
+	MACAddress string `json:"macaddress"`
+}
+
+type routeConfig struct {
+	To  string `json:"to"`
+	Via string `json:"via"`
+}
+
+type nameserversConfig struct {
+	Addresses []string `json:"addresses,omitempty"`
+	Search    []string `json:"search,omitempty"`
+}
+
+// vendorConfig is the cloud-config KubeVirt passes as vendor data, the userdata takes precedence over it
+type vendorConfig struct {
+	BootCmd [][]string `json:"bootcmd,omitempty"`
+}
+
+// the serial of virtio disks is limited to 20 characters
+const maxSwapDiskSerialLength = 20
+
 // IsValidCloudInitData checks if the given CloudInitData object is valid in the sense that GenerateLocalData can be called with it.
 func IsValidCloudInitData(cloudInitData *CloudInitData) bool {
 	return cloudInitData != nil && cloudInitData.UserData != "" && (cloudInitData.NoCloudMetaData != nil || cloudInitData.ConfigDriveMetaData != nil)
 }
 
+// IsNetworkDataGenerated checks if the NoCloud networkdata of the given VMI should be generated
+// from the addressing of its networks.
+func IsNetworkDataGenerated(vmi *v1.VirtualMachineInstance) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.CloudInitNoCloud != nil && volume.CloudInitNoCloud.GenerateNetworkData {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateNetworkData generates NoCloud networkdata in the cloud-init network configuration
+// version 2 format. Interfaces are matched by their MAC address, interfaces without addresses are skipped.
+func GenerateNetworkData(interfaces []NetworkInterfaceData) (string, error) {
+	config := networkConfig{
+		Version:   2,
+		Ethernets: map[string]ethernetConfig{},
+	}
+	for _, iface := range interfaces {
+		if iface.MAC == "" || len(iface.Addresses) == 0 {
+			continue
+		}
+		ethernet := ethernetConfig{
+			Match:     ethernetMatch{MACAddress: iface.MAC},
+			Addresses: iface.Addresses,
+			Gateway4:  iface.Gateway4,
+			Gateway6:  iface.Gateway6,
+			MTU:       iface.MTU,
+		}
+		for _, route := range iface.Routes {
+			ethernet.Routes = append(ethernet.Routes, routeConfig{To: route.To, Via: route.Via})
+		}
+		if len(iface.Nameservers) > 0 || len(iface.SearchDomains) > 0 {
+			ethernet.Nameservers = &nameserversConfig{
+				Addresses: iface.Nameservers,
+				Search:    iface.SearchDomains,
+			}
+		}
+		config.Ethernets[iface.Name] = ethernet
+	}
+	networkData, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(networkData), nil
+}
+
+// SwapDiskSerial returns the serial of the swap disk of the given volume, if its disk has none.
+func SwapDiskSerial(volumeName string) string {
+	if len(volumeName) > maxSwapDiskSerialLength {
+		return volumeName[:maxSwapDiskSerialLength]
+	}
+	return volumeName
+}
+
+func swapDiskDevicePath(disk v1.Disk, volumeName string) string {
+	serial := disk.Serial
+	if serial == "" {
+		serial = SwapDiskSerial(volumeName)
+	}
+	if disk.Disk != nil {
+		switch disk.Disk.Bus {
+		case "scsi":
+			return "/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_" + serial
+		case "sata":
+			return "/dev/disk/by-id/ata-QEMU_HARDDISK_" + serial
+		case "usb":
+			return "/dev/disk/by-id/usb-QEMU_QEMU_HARDDISK_" + serial + "-0:0"
+		}
+	}
+	return "/dev/disk/by-id/virtio-" + serial
+}
+
+// GenerateSwapVendorData generates cloud-config vendor data which makes the guest use the swap disks of the VMI
+// as swap space. The disks are encrypted with dm-crypt using a random key on every boot, so their content can
+// not be read outside of the running guest. It returns an empty string if the VMI has no swap disks.
+func GenerateSwapVendorData(vmi *v1.VirtualMachineInstance) (string, error) {
+	config := vendorConfig{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.SwapDisk == nil {
+			continue
+		}
+		for _, disk := range vmi.Spec.Domain.Devices.Disks {
+			if disk.Name != volume.Name {
+				continue
+			}
+			mapping := "/dev/mapper/swap-" + volume.Name
+			script := fmt.Sprintf("udevadm settle; cryptsetup open --type plain --cipher aes-xts-plain64 --key-size 512 --key-file /dev/urandom %s swap-%s && mkswap %s && swapon %s",
+				swapDiskDevicePath(disk, volume.Name), volume.Name, mapping, mapping)
+			config.BootCmd = append(config.BootCmd, []string{"sh", "-c", script})
+		}
+	}
+	if len(config.BootCmd) == 0 {
+		return "", nil
+	}
+
+	vendorData, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return "#cloud-config\n" + string(vendorData), nil
+}
+
 // ReadCloudInitVolumeDataSource scans the given VMI for CloudInit volumes and
 // reads their content into a CloudInitData struct. Does not resolve secret refs.
 func ReadCloudInitVolumeDataSource(vmi *v1.VirtualMachineInstance, secretSourceDir string) (cloudInitData *CloudInitData, err error) {
@@ -115,8 +280,17 @@ func ReadCloudInitVolumeDataSource(vmi *v1.VirtualMachineInstance, secretSourceD
 				return nil, err
 			}
 
+			keys, err := resolveSSHPublicKeys(vmi, secretSourceDir, DataSourceNoCloud)
+			if err != nil {
+				return nil, err
+			}
+
 			cloudInitData, err = readCloudInitNoCloudSource(volume.CloudInitNoCloud)
-			cloudInitData.NoCloudMetaData = readCloudInitNoCloudMetaData(vmi.Name, hostname, vmi.Namespace)
+			cloudInitData.NoCloudMetaData = readCloudInitNoCloudMetaData(vmi.Name, hostname, vmi.Namespace, keys)
+			if err != nil {
+				return cloudInitData, err
+			}
+			cloudInitData.VendorData, err = GenerateSwapVendorData(vmi)
 			return cloudInitData, err
 		}
 		if volume.CloudInitConfigDrive != nil {
@@ -128,6 +302,10 @@ func ReadCloudInitVolumeDataSource(vmi *v1.VirtualMachineInstance, secretSourceD
 
 			cloudInitData, err = readCloudInitConfigDriveSource(volume.CloudInitConfigDrive)
 			cloudInitData.ConfigDriveMetaData = readCloudInitConfigDriveMetaData(string(vmi.UID), vmi.Name, hostname, vmi.Namespace, keys)
+			if err != nil {
+				return cloudInitData, err
+			}
+			cloudInitData.VendorData, err = GenerateSwapVendorData(vmi)
 			return cloudInitData, err
 		}
 	}
@@ -176,12 +354,66 @@ func resolveNoCloudSecrets(vmi *v1.VirtualMachineInstance, secretSourceDir strin
 //
 // Note: when using this function, make sure that your code can access the secret volumes.
 func resolveConfigDriveSecrets(vmi *v1.VirtualMachineInstance, secretSourceDir string) (map[string]string, error) {
+	keys, err := resolveSSHPublicKeys(vmi, secretSourceDir, DataSourceConfigDrive)
+	if err != nil {
+		return keys, err
+	}
+
+	volume := findCloudInitConfigDriveSecretVolume(vmi.Spec.Volumes)
+	if volume == nil {
+		return keys, nil
+	}
+
+	baseDir := filepath.Join(secretSourceDir, volume.Name)
+	userData, userDataError := readFileFromDir(baseDir, "userdata")
+	// If "userdata" was not found, try "userData"
+	if userDataError != nil {
+		userData, userDataError = readFileFromDir(baseDir, "userData")
+	}
+	networkData, networkDataError := readFileFromDir(baseDir, "networkdata")
+	// If "networkdata" was not found, try "networkData"
+	if networkDataError != nil {
+		networkData, networkDataError = readFileFromDir(baseDir, "networkData")
+	}
+	if userDataError != nil && networkDataError != nil {
+		return keys, fmt.Errorf("no cloud-init data-source found at volume: %s", volume.Name)
+	}
+
+	if userData != "" {
+		volume.CloudInitConfigDrive.UserData = userData
+	}
+	if networkData != "" {
+		volume.CloudInitConfigDrive.NetworkData = networkData
+	}
+
+	return keys, nil
+}
+
+// isPropagatedBy returns true if the ssh public key access credential is propagated by the given cloud-init data source
+func isPropagatedBy(accessCred v1.AccessCredential, dataSource DataSourceType) bool {
+	if accessCred.SSHPublicKey == nil {
+		return false
+	}
+	switch dataSource {
+	case DataSourceNoCloud:
+		return accessCred.SSHPublicKey.PropagationMethod.NoCloud != nil
+	case DataSourceConfigDrive:
+		return accessCred.SSHPublicKey.PropagationMethod.ConfigDrive != nil
+	}
+	return false
+}
+
+// resolveSSHPublicKeys reads the ssh public keys of the access credentials which are propagated
+// by the given cloud-init data source from their secret VolumeMounts.
+//
+// Note: when using this function, make sure that your code can access the secret volumes.
+func resolveSSHPublicKeys(vmi *v1.VirtualMachineInstance, secretSourceDir string, dataSource DataSourceType) (map[string]string, error) {
 	keys := make(map[string]string)
 	count := 0
 	for _, accessCred := range vmi.Spec.AccessCredentials {
 
-		// check to see if access credential is propagated by config drive or not
-		if accessCred.SSHPublicKey == nil || accessCred.SSHPublicKey.PropagationMethod.ConfigDrive == nil {
+		// check to see if access credential is propagated by the data source or not
+		if !isPropagatedBy(accessCred, dataSource) {
 			continue
 		}
 
@@ -218,33 +450,6 @@ func resolveConfigDriveSecrets(vmi *v1.VirtualMachineInstance, secretSourceDir s
 		}
 	}
 
-	volume := findCloudInitConfigDriveSecretVolume(vmi.Spec.Volumes)
-	if volume == nil {
-		return keys, nil
-	}
-
-	baseDir := filepath.Join(secretSourceDir, volume.Name)
-	userData, userDataError := readFileFromDir(baseDir, "userdata")
-	// If "userdata" was not found, try "userData"
-	if userDataError != nil {
-		userData, userDataError = readFileFromDir(baseDir, "userData")
-	}
-	networkData, networkDataError := readFileFromDir(baseDir, "networkdata")
-	// If "networkdata" was not found, try "networkData"
-	if networkDataError != nil {
-		networkData, networkDataError = readFileFromDir(baseDir, "networkData")
-	}
-	if userDataError != nil && networkDataError != nil {
-		return keys, fmt.Errorf("no cloud-init data-source found at volume: %s", volume.Name)
-	}
-
-	if userData != "" {
-		volume.CloudInitConfigDrive.UserData = userData
-	}
-	if networkData != "" {
-		volume.CloudInitConfigDrive.NetworkData = networkData
-	}
-
 	return keys, nil
 }
 
@@ -322,6 +527,18 @@ func readCloudInitData(userData, userDataBase64, networkData, networkDataBase64
 }
 
 func readCloudInitNoCloudSource(source *v1.CloudInitNoCloudSource) (*CloudInitData, error) {
+	// the networkdata is generated by virt-launcher, only the userdata is optional
+	if source.GenerateNetworkData {
+		userData, err := readRawOrBase64Data(source.UserData, source.UserDataBase64)
+		if err != nil {
+			return &CloudInitData{}, err
+		}
+		return &CloudInitData{
+			DataSource: DataSourceNoCloud,
+			UserData:   userData,
+		}, nil
+	}
+
 	userData, networkData, err := readCloudInitData(source.UserData,
 		source.UserDataBase64, source.NetworkData, source.NetworkDataBase64)
 	if err != nil {
@@ -349,10 +566,11 @@ func readCloudInitConfigDriveSource(source *v1.CloudInitConfigDriveSource) (*Clo
 	}, nil
 }
 
-func readCloudInitNoCloudMetaData(name, hostname, namespace string) *NoCloudMetadata {
+func readCloudInitNoCloudMetaData(name, hostname, namespace string, keys map[string]string) *NoCloudMetadata {
 	return &NoCloudMetadata{
 		InstanceID:    fmt.Sprintf("%s.%s", name, namespace),
 		LocalHostname: hostname,
+		PublicSSHKeys: keys,
 	}
 }
 
@@ -461,13 +679,15 @@ func GenerateLocalData(vmiName string, namespace string, data *CloudInitData) er
 	domainBasePath := getDomainBasePath(vmiName, namespace)
 	dataBasePath := fmt.Sprintf("%s/data", domainBasePath)
 
-	var dataPath, metaFile, userFile, networkFile, iso, isoStaging string
+	var dataPath, metaFile, userFile, networkFile, vendorFile, iso, isoStaging string
+	var vendorData []byte
 	switch data.DataSource {
 	case DataSourceNoCloud:
 		dataPath = dataBasePath
 		metaFile = fmt.Sprintf("%s/%s", dataPath, "meta-data")
 		userFile = fmt.Sprintf("%s/%s", dataPath, "user-data")
 		networkFile = fmt.Sprintf("%s/%s", dataPath, "network-config")
+		vendorFile = fmt.Sprintf("%s/%s", dataPath, "vendor-data")
 		iso = GetIsoFilePath(DataSourceNoCloud, vmiName, namespace)
 		isoStaging = fmt.Sprintf("%s.staging", iso)
 		if data.NoCloudMetaData == nil {
@@ -480,11 +700,15 @@ func GenerateLocalData(vmiName string, namespace string, data *CloudInitData) er
 		if err != nil {
 			return err
 		}
+		if data.VendorData != "" {
+			vendorData = []byte(data.VendorData)
+		}
 	case DataSourceConfigDrive:
 		dataPath = fmt.Sprintf("%s/openstack/latest", dataBasePath)
 		metaFile = fmt.Sprintf("%s/%s", dataPath, "meta_data.json")
 		userFile = fmt.Sprintf("%s/%s", dataPath, "user_data")
 		networkFile = fmt.Sprintf("%s/%s", dataPath, "network_data.json")
+		vendorFile = fmt.Sprintf("%s/%s", dataPath, "vendor_data.json")
 		iso = GetIsoFilePath(DataSourceConfigDrive, vmiName, namespace)
 		isoStaging = fmt.Sprintf("%s.staging", iso)
 		if data.ConfigDriveMetaData == nil {
@@ -499,6 +723,13 @@ func GenerateLocalData(vmiName string, namespace string, data *CloudInitData) er
 		if err != nil {
 			return err
 		}
+		if data.VendorData != "" {
+			// cloud-init reads the vendor data of config drives from the cloud-init key
+			vendorData, err = json.Marshal(map[string]string{"cloud-init": data.VendorData})
+			if err != nil {
+				return err
+			}
+		}
 
 	default:
 		return fmt.Errorf("Invalid cloud-init data source: '%v'", data.DataSource)
@@ -520,7 +751,7 @@ func GenerateLocalData(vmiName string, namespace string, data *CloudInitData) er
 		networkData = []byte(data.NetworkData)
 	}
 
-	err = diskutils.RemoveFilesIfExist(userFile, metaFile, networkFile, isoStaging)
+	err = diskutils.RemoveFilesIfExist(userFile, metaFile, networkFile, vendorFile, isoStaging)
 	if err != nil {
 		return err
 	}
@@ -545,6 +776,14 @@ func GenerateLocalData(vmiName string, namespace string, data *CloudInitData) er
 		defer os.Remove(networkFile)
 	}
 
+	if len(vendorData) > 0 {
+		err = ioutil.WriteFile(vendorFile, vendorData, 0644)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(vendorFile)
+	}
+
 	switch data.DataSource {
 	case DataSourceNoCloud:
 		err = cloudInitIsoFunc(isoStaging, "cidata", dataBasePath)
@@ -30,9 +30,12 @@ import (
 	"time"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"github.com/ghodss/yaml"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/precond"
@@ -152,6 +155,24 @@ var _ = Describe("CloudInit", func() {
 				Expect(err).To(BeNil())
 				Expect(string(buf)).To(Equal(exampleJSONParsed))
 			})
+			It("should match the generated nocloud metadata with ssh public keys", func() {
+				exampleJSONParsed := `{
+  "instance-id": "fake.fake-namespace",
+  "local-hostname": "fake",
+  "public-keys": {
+    "0": "somekey"
+  }
+}`
+
+				metadataStruct := NoCloudMetadata{
+					InstanceID:    "fake.fake-namespace",
+					LocalHostname: "fake",
+					PublicSSHKeys: map[string]string{"0": "somekey"},
+				}
+				buf, err := json.MarshalIndent(metadataStruct, "", "  ")
+				Expect(err).To(BeNil())
+				Expect(string(buf)).To(Equal(exampleJSONParsed))
+			})
 		})
 	})
 	Describe("Volume-based data source", func() {
@@ -354,6 +375,16 @@ var _ = Describe("CloudInit", func() {
 					Expect(err).Should(MatchError("userDataBase64, userData, networkDataBase64 or networkData is required for a cloud-init data source"))
 				})
 
+				It("should not require userData nor networkData if the networkData is generated", func() {
+					source := &v1.CloudInitNoCloudSource{
+						GenerateNetworkData: true,
+					}
+					cloudInitData, err := readCloudInitNoCloudSource(source)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(cloudInitData.UserData).To(BeEmpty())
+					Expect(cloudInitData.NetworkData).To(BeEmpty())
+				})
+
 				Context("with secretRefs", func() {
 					createCloudInitSecretRefVolume := func(name, secret string) *v1.Volume {
 						return &v1.Volume{
@@ -381,6 +412,46 @@ var _ = Describe("CloudInit", func() {
 						Expect(testVolume.CloudInitNoCloud.NetworkData).To(Equal("secret-networkdata"))
 					})
 
+					It("should only resolve the ssh public keys propagated by no-cloud", func() {
+						vmi := createEmptyVMIWithVolumes([]v1.Volume{})
+						vmi.Spec.AccessCredentials = []v1.AccessCredential{
+							{
+								SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+									Source: v1.SSHPublicKeyAccessCredentialSource{
+										Secret: &v1.AccessCredentialSecretSource{
+											SecretName: "my-pkey",
+										},
+									},
+									PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+										NoCloud: &v1.NoCloudSSHPublicKeyAccessCredentialPropagation{},
+									},
+								},
+							},
+							{
+								SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+									Source: v1.SSHPublicKeyAccessCredentialSource{
+										Secret: &v1.AccessCredentialSecretSource{
+											SecretName: "my-other-pkey",
+										},
+									},
+									PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+										ConfigDrive: &v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation{},
+									},
+								},
+							},
+						}
+						fakeVolumeMountDir("my-pkey-access-cred", map[string]string{
+							"somekey": "ssh-1234",
+						})
+						fakeVolumeMountDir("my-other-pkey-access-cred", map[string]string{
+							"someotherkey": "ssh-5678",
+						})
+						keys, err := resolveSSHPublicKeys(vmi, tmpDir, DataSourceNoCloud)
+						Expect(err).To(Not(HaveOccurred()), "could not resolve ssh public keys")
+						Expect(keys).To(HaveLen(1))
+						Expect(keys).To(ContainElement("ssh-1234"))
+					})
+
 					It("should resolve camel-case no-cloud data from volume", func() {
 						testVolume := createCloudInitSecretRefVolume("test-volume", "test-secret")
 						vmi := createEmptyVMIWithVolumes([]v1.Volume{*testVolume})
@@ -553,5 +624,152 @@ var _ = Describe("CloudInit", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		DescribeTable("should include the vendor data", func(dataSource DataSourceType, vendorFile string, expectedVendorData string) {
+			var vendorData []byte
+			SetIsoCreationFunction(func(isoOutFile, volumeID string, inDir string) error {
+				var err error
+				vendorData, err = ioutil.ReadFile(filepath.Join(inDir, vendorFile))
+				if err != nil {
+					return err
+				}
+				_, err = os.Create(isoOutFile)
+				return err
+			})
+
+			cloudInitData := &CloudInitData{
+				DataSource: dataSource,
+				UserData:   "fake\nuser\ndata\n",
+				VendorData: "#cloud-config\nbootcmd: []\n",
+			}
+			err := GenerateLocalData("fake-domain", "fake-namespace", cloudInitData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(vendorData)).To(Equal(expectedVendorData))
+		},
+			Entry("with NoCloud", DataSourceNoCloud, "vendor-data", "#cloud-config\nbootcmd: []\n"),
+			Entry("with ConfigDrive", DataSourceConfigDrive, "openstack/latest/vendor_data.json", `{"cloud-init":"#cloud-config\nbootcmd: []\n"}`),
+		)
+	})
+	Describe("GenerateSwapVendorData", func() {
+		createSwapDiskVMI := func(disks ...v1.Disk) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			for _, disk := range disks {
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, disk)
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: disk.Name,
+					VolumeSource: v1.VolumeSource{
+						SwapDisk: &v1.SwapDiskSource{Capacity: resource.MustParse("1Gi")},
+					},
+				})
+			}
+			return vmi
+		}
+
+		It("should not generate vendor data without swap disks", func() {
+			vendorData, err := GenerateSwapVendorData(v1.NewMinimalVMI("testvmi"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vendorData).To(BeEmpty())
+		})
+
+		It("should enable encrypted swap on the swap disks", func() {
+			vendorData, err := GenerateSwapVendorData(createSwapDiskVMI(
+				v1.Disk{Name: "swap", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				v1.Disk{Name: "swap-on-scsi-with-a-long-name", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}},
+				v1.Disk{Name: "swap-with-serial", Serial: "myserial", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "sata"}}},
+			))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vendorData).To(HavePrefix("#cloud-config\n"))
+
+			config := vendorConfig{}
+			Expect(yaml.Unmarshal([]byte(vendorData), &config)).To(Succeed())
+			Expect(config.BootCmd).To(Equal([][]string{
+				{"sh", "-c", "udevadm settle; cryptsetup open --type plain --cipher aes-xts-plain64 --key-size 512 --key-file /dev/urandom " +
+					"/dev/disk/by-id/virtio-swap swap-swap && mkswap /dev/mapper/swap-swap && swapon /dev/mapper/swap-swap"},
+				{"sh", "-c", "udevadm settle; cryptsetup open --type plain --cipher aes-xts-plain64 --key-size 512 --key-file /dev/urandom " +
+					"/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_swap-on-scsi-with-a- swap-swap-on-scsi-with-a-long-name && " +
+					"mkswap /dev/mapper/swap-swap-on-scsi-with-a-long-name && swapon /dev/mapper/swap-swap-on-scsi-with-a-long-name"},
+				{"sh", "-c", "udevadm settle; cryptsetup open --type plain --cipher aes-xts-plain64 --key-size 512 --key-file /dev/urandom " +
+					"/dev/disk/by-id/ata-QEMU_HARDDISK_myserial swap-swap-with-serial && " +
+					"mkswap /dev/mapper/swap-swap-with-serial && swapon /dev/mapper/swap-swap-with-serial"},
+			}))
+		})
+
+		It("should add the vendor data to the cloud-init data of the VMI", func() {
+			vmi := createSwapDiskVMI(v1.Disk{Name: "swap", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "cloudinit",
+				VolumeSource: v1.VolumeSource{
+					CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "fake\nuser\ndata\n"},
+				},
+			})
+			cloudInitData, err := ReadCloudInitVolumeDataSource(vmi, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloudInitData.VendorData).To(ContainSubstring("/dev/disk/by-id/virtio-swap"))
+		})
+	})
+	Describe("GenerateNetworkData", func() {
+		It("should generate a network configuration version 2", func() {
+			networkData, err := GenerateNetworkData([]NetworkInterfaceData{
+				{
+					Name:          "default",
+					MAC:           "02:00:00:00:00:01",
+					Addresses:     []string{"10.0.2.2/24", "fd10:0:2::2/120"},
+					Gateway4:      "10.0.2.1",
+					Gateway6:      "fd10:0:2::1",
+					MTU:           1410,
+					Nameservers:   []string{"10.96.0.10"},
+					SearchDomains: []string{"cluster.local"},
+				},
+				{
+					Name:      "secondary",
+					MAC:       "02:00:00:00:00:02",
+					Addresses: []string{"192.168.1.10/24"},
+					Routes:    []NetworkRouteData{{To: "192.168.2.0/24", Via: "192.168.1.1"}},
+				},
+				{
+					Name: "noaddresses",
+					MAC:  "02:00:00:00:00:03",
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(networkData).To(Equal(`ethernets:
+  default:
+    addresses:
+    - 10.0.2.2/24
+    - fd10:0:2::2/120
+    gateway4: 10.0.2.1
+    gateway6: fd10:0:2::1
+    match:
+      macaddress: "02:00:00:00:00:01"
+    mtu: 1410
+    nameservers:
+      addresses:
+      - 10.96.0.10
+      search:
+      - cluster.local
+  secondary:
+    addresses:
+    - 192.168.1.10/24
+    match:
+      macaddress: "02:00:00:00:00:02"
+    routes:
+    - to: 192.168.2.0/24
+      via: 192.168.1.1
+version: 2
+`))
+		})
+
+		It("should detect if the networkData is generated", func() {
+			vmi := createEmptyVMIWithVolumes([]v1.Volume{
+				{
+					Name: "cloudinit",
+					VolumeSource: v1.VolumeSource{
+						CloudInitNoCloud: &v1.CloudInitNoCloudSource{GenerateNetworkData: true},
+					},
+				},
+			})
+			Expect(IsNetworkDataGenerated(vmi)).To(BeTrue())
+			vmi.Spec.Volumes[0].CloudInitNoCloud.GenerateNetworkData = false
+			Expect(IsNetworkDataGenerated(vmi)).To(BeFalse())
+		})
 	})
 })
@@ -23,6 +23,8 @@ import (
 	"io/ioutil"
 	"os/exec"
 	"path/filepath"
+
+	v1 "kubevirt.io/client-go/api/v1"
 )
 
 type (
@@ -73,6 +75,10 @@ var (
 	ServiceAccountDiskDir = mountBaseDir + "/service-account-disk"
 	// ServiceAccountDiskName represents the name of the ServiceAccount iso image
 	ServiceAccountDiskName = "service-account.iso"
+	// DownwardMetricDisksDir represents a path to the DownwardMetrics disk
+	DownwardMetricDisksDir = mountBaseDir + "/downwardmetrics-disk"
+	// DownwardMetricDisk represents the path of the vhostmd compatible DownwardMetrics disk
+	DownwardMetricDisk = DownwardMetricDisksDir + "/vhostmd0"
 
 	createISOImage = defaultCreateIsoImage
 )
@@ -95,6 +101,17 @@ func getFilesLayout(dirPath string) ([]string, error) {
 	return filesPath, nil
 }
 
+// isSharedFilesystem returns true if the volume is shared into the guest with virtiofs. The guest sees the updates of
+// the source directory then, so no iso image, which would only be a snapshot of it, is created.
+func isSharedFilesystem(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	for _, fs := range vmi.Spec.Domain.Devices.Filesystems {
+		if fs.Name == volumeName {
+			return true
+		}
+	}
+	return false
+}
+
 func defaultCreateIsoImage(output string, volID string, files []string) error {
 
 	if volID == "" {
@@ -39,7 +39,7 @@ func GetDownwardAPIDiskPath(volumeName string) string {
 // CreateDownwardAPIDisks creates DownwardAPI iso disks which are attached to vmis
 func CreateDownwardAPIDisks(vmi *v1.VirtualMachineInstance) error {
 	for _, volume := range vmi.Spec.Volumes {
-		if volume.DownwardAPI != nil {
+		if volume.DownwardAPI != nil && !isSharedFilesystem(vmi, volume.Name) {
 
 			var filesPath []string
 			filesPath, err := getFilesLayout(GetDownwardAPISourcePath(volume.Name))
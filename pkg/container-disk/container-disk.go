@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	kubev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -187,7 +188,7 @@ func generateContainersHelper(vmi *v1.VirtualMachineInstance, podVolumeName stri
 
 			volumeMountDir := GetVolumeMountDirOnGuest(vmi)
 			diskContainerName := fmt.Sprintf("volume%s", volume.Name)
-			diskContainerImage := volume.ContainerDisk.Image
+			diskContainerImage := imageOfVolume(vmi, volume)
 			resources := kubev1.ResourceRequirements{}
 			resources.Limits = make(kubev1.ResourceList)
 			resources.Requests = make(kubev1.ResourceList)
@@ -236,6 +237,36 @@ func generateContainersHelper(vmi *v1.VirtualMachineInstance, podVolumeName stri
 	return containers
 }
 
+// imageOfVolume returns the image the containerDisk is pulled from. It is pinned to the digest in the volume status
+// once the digest was resolved, so that all the pods of the VMI, like migration targets, run the same image.
+func imageOfVolume(vmi *v1.VirtualMachineInstance, volume v1.Volume) string {
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.Name == volume.Name && status.ContainerDiskVolume != nil && status.ContainerDiskVolume.ImageDigest != "" {
+			return PinnedImage(volume.ContainerDisk.Image, status.ContainerDiskVolume.ImageDigest)
+		}
+	}
+	return volume.ContainerDisk.Image
+}
+
+// PinnedImage returns the reference of the image by the given digest, without its tag
+func PinnedImage(image string, digest string) string {
+	name := strings.SplitN(image, "@", 2)[0]
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name + "@" + digest
+}
+
+// DigestFromImageID returns the digest of the image ID the container runtime reports for a container,
+// like docker.io/kubevirt/cirros@sha256:<hex>, or an empty string if the ID does not contain the digest
+func DigestFromImageID(imageID string) string {
+	parts := strings.SplitN(imageID, "@", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], "sha256:") {
+		return ""
+	}
+	return parts[1]
+}
+
 func CreateEphemeralImages(vmi *v1.VirtualMachineInstance) error {
 	// The domain is setup to use the COW image instead of the base image. What we have
 	// to do here is only create the image where the domain expects it (GetDiskTargetPartFromLauncherView)
@@ -217,6 +217,38 @@ var _ = Describe("ContainerDisk", func() {
 				Expect(containers[1].ImagePullPolicy).To(Equal(k8sv1.PullAlways))
 			})
 
+			It("by verifying that the image is pinned to the digest in the volume status", func() {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				appendContainerDisk(vmi, "r0")
+				appendContainerDisk(vmi, "r1")
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+					Name:                "r1",
+					ContainerDiskVolume: &v1.ContainerDiskInfo{ImageDigest: "sha256:abcd"},
+				}}
+				containers := GenerateContainers(vmi, "libvirt-runtime", "bin-volume")
+
+				Expect(containers).To(HaveLen(2))
+				Expect(containers[0].Image).To(Equal("someimage:v1.2.3.4"))
+				Expect(containers[1].Image).To(Equal("someimage@sha256:abcd"))
+			})
+
+			table.DescribeTable("by pinning the image", func(image string, expected string) {
+				Expect(PinnedImage(image, "sha256:abcd")).To(Equal(expected))
+			},
+				table.Entry("with a tag", "quay.io/kubevirt/cirros:v1", "quay.io/kubevirt/cirros@sha256:abcd"),
+				table.Entry("without a tag", "quay.io/kubevirt/cirros", "quay.io/kubevirt/cirros@sha256:abcd"),
+				table.Entry("in a registry with a port", "registry:5000/cirros:v1", "registry:5000/cirros@sha256:abcd"),
+				table.Entry("already pinned", "registry:5000/cirros@sha256:0000", "registry:5000/cirros@sha256:abcd"),
+			)
+
+			table.DescribeTable("by extracting the digest of the image ID", func(imageID string, expected string) {
+				Expect(DigestFromImageID(imageID)).To(Equal(expected))
+			},
+				table.Entry("of containerd and CRI-O", "quay.io/kubevirt/cirros@sha256:abcd", "sha256:abcd"),
+				table.Entry("of docker", "docker-pullable://quay.io/kubevirt/cirros@sha256:abcd", "sha256:abcd"),
+				table.Entry("without a repository digest", "sha256:abcd", ""),
+			)
+
 			Context("which checks socket paths", func() {
 
 				var vmi *v1.VirtualMachineInstance
@@ -0,0 +1,342 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+// Package registry resolves the images of containerDisks to digests and verifies their cosign signatures,
+// talking to their registries with the OCI distribution API. Its references, credentials and sessions are
+// shared with the containerDisk pusher of the export server.
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	dockerHub        = "docker.io"
+	dockerHubAPIHost = "registry-1.docker.io"
+
+	// maxManifestSize bounds the manifests and signature payloads read from a registry
+	maxManifestSize = 4 << 20
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// Credentials authenticate to a registry
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Client resolves image references and verifies their signatures
+type Client struct {
+	httpClient *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{httpClient: httpClient}
+}
+
+// ResolveDigest returns the digest of the manifest the image reference points to, like sha256:<hex>.
+// The digest is computed from the manifest, so that it matches what the container runtime pulls.
+func (c *Client) ResolveDigest(image string, credentials *Credentials) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+
+	s := c.NewSession(ref, credentials, "pull")
+	manifest, err := s.fetch("manifests/"+ref.Tag, manifestMediaTypes...)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(manifest)), nil
+}
+
+// Reference is a parsed image reference, like registry.example.com/namespace/image:tag
+type Reference struct {
+	// Registry is the registry as written in the reference, or docker.io
+	Registry   string
+	Repository string
+	// Tag is latest if the reference has neither a tag nor a digest
+	Tag    string
+	Digest string
+}
+
+// ParseReference parses references of the form [registry/]repository[:tag][@digest]
+func ParseReference(image string) (*Reference, error) {
+	ref := &Reference{}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+		if !strings.HasPrefix(ref.Digest, "sha256:") {
+			return nil, fmt.Errorf("unsupported digest in image %s", image)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+		if ref.Tag == "" {
+			return nil, fmt.Errorf("invalid image %s", image)
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("invalid image %s", image)
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry, ref.Repository = parts[0], parts[1]
+	} else {
+		ref.Registry, ref.Repository = dockerHub, name
+		if len(parts) == 1 {
+			ref.Repository = "library/" + name
+		}
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref, nil
+}
+
+// APIHost returns the host serving the registry API
+func (r *Reference) APIHost() string {
+	if r.Registry == dockerHub {
+		return dockerHubAPIHost
+	}
+	return r.Registry
+}
+
+// Session holds the authorization of the requests for a single repository
+type Session struct {
+	client      *Client
+	ref         *Reference
+	credentials *Credentials
+	// actions are requested in the scope of the tokens, like pull or pull,push
+	actions       string
+	authorization string
+}
+
+// NewSession creates a session for the repository of the reference, which is authorized for the actions
+func (c *Client) NewSession(ref *Reference, credentials *Credentials, actions string) *Session {
+	return &Session{client: c, ref: ref, credentials: credentials, actions: actions}
+}
+
+// Authorization returns the value of the Authorization header of the requests, which is empty
+// until the session answered an authentication challenge
+func (s *Session) Authorization() string {
+	return s.authorization
+}
+
+// fetch reads the content of the repository at the path relative to /v2/<repository>/
+func (s *Session) fetch(path string, accept ...string) ([]byte, error) {
+	resp, err := s.get(path, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized && s.authorization == "" {
+		if err := s.Authorize(resp.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+		resp, err = s.get(path, accept)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{URL: resp.Request.URL.String(), StatusCode: resp.StatusCode}
+	}
+	return readLimited(resp.Body)
+}
+
+func (s *Session) get(path string, accept []string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s/v2/%s/%s", s.ref.APIHost(), s.ref.Repository, path), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if s.authorization != "" {
+		req.Header.Set("Authorization", s.authorization)
+	}
+	return s.client.httpClient.Do(req)
+}
+
+// Authorize answers the authentication challenge of the registry, with a bearer token
+// fetched from its token service or with basic authentication
+func (s *Session) Authorize(challenge string) error {
+	scheme := strings.ToLower(strings.SplitN(challenge, " ", 2)[0])
+	switch scheme {
+	case "basic":
+		if s.credentials == nil {
+			return fmt.Errorf("registry %s requires credentials", s.ref.Registry)
+		}
+		s.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(s.credentials.Username+":"+s.credentials.Password))
+		return nil
+	case "bearer":
+		params := map[string]string{}
+		for _, match := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+			params[match[1]] = match[2]
+		}
+		token, err := s.fetchToken(params)
+		if err != nil {
+			return err
+		}
+		s.authorization = "Bearer " + token
+		return nil
+	}
+	return fmt.Errorf("unsupported authentication challenge of registry %s: %q", s.ref.Registry, challenge)
+}
+
+func (s *Session) fetchToken(params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid token realm %q of registry %s", params["realm"], s.ref.Registry)
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:%s", s.ref.Repository, s.actions))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if s.credentials != nil {
+		req.SetBasicAuth(s.credentials.Username, s.credentials.Password)
+	}
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", &StatusError{URL: realm.String(), StatusCode: resp.StatusCode}
+	}
+	body, err := readLimited(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("no token returned by %s", realm.Host)
+}
+
+// StatusError is returned when the registry answers a request with an unexpected status
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d from %s", e.StatusCode, e.URL)
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxManifestSize {
+		return nil, fmt.Errorf("response exceeds %d bytes", maxManifestSize)
+	}
+	return data, nil
+}
+
+// CredentialsFromDockerConfig returns the credentials for the registry of the image in the content
+// of a kubernetes.io/dockerconfigjson Secret, or nil if it has none
+func CredentialsFromDockerConfig(dockerConfigJSON []byte, image string) (*Credentials, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	config := struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(dockerConfigJSON, &config); err != nil {
+		return nil, err
+	}
+
+	for server, auth := range config.Auths {
+		if registryOfServer(server) != ref.Registry {
+			continue
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, err
+			}
+			parts := strings.SplitN(string(decoded), ":", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid auth of %s", server)
+			}
+			return &Credentials{Username: parts[0], Password: parts[1]}, nil
+		}
+		return &Credentials{Username: auth.Username, Password: auth.Password}, nil
+	}
+	return nil, nil
+}
+
+// registryOfServer maps the server of a docker config, like https://index.docker.io/v1/, to a registry
+func registryOfServer(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	server = strings.SplitN(server, "/", 2)[0]
+	switch server {
+	case "index.docker.io", dockerHubAPIHost:
+		return dockerHub
+	}
+	return server
+}
@@ -0,0 +1,213 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package registry
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+const manifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","layers":[]}`
+
+var _ = Describe("Registry", func() {
+	var server *httptest.Server
+	var content map[string][]byte
+	var client *Client
+	var image string
+	var digest string
+
+	BeforeEach(func() {
+		content = map[string][]byte{
+			"/v2/kubevirt/cirros/manifests/latest": []byte(manifest),
+		}
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/token" {
+				Expect(r.URL.Query().Get("scope")).To(Equal("repository:kubevirt/cirros:pull"))
+				Expect(r.URL.Query().Get("service")).To(Equal("fake"))
+				w.Write([]byte(`{"token":"secret"}`))
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			data, ok := content[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		}))
+		client = NewClient(server.Client())
+		image = strings.TrimPrefix(server.URL, "https://") + "/kubevirt/cirros:latest"
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(manifest)))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("resolving digests", func() {
+		It("should resolve a tag to the digest of its manifest", func() {
+			resolved, err := client.ResolveDigest(image, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(Equal(digest))
+		})
+
+		It("should return the digest of an image referenced by digest", func() {
+			resolved, err := NewClient(nil).ResolveDigest("quay.io/kubevirt/cirros@sha256:abcd", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(Equal("sha256:abcd"))
+		})
+
+		It("should fail for a tag which does not exist", func() {
+			_, err := client.ResolveDigest(strings.Replace(image, ":latest", ":missing", 1), nil)
+			Expect(err).To(BeAssignableToTypeOf(&StatusError{}))
+			Expect(err.(*StatusError).StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("verifying signatures", func() {
+		var key *ecdsa.PrivateKey
+
+		sign := func(key *ecdsa.PrivateKey, signedDigest string) {
+			payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`,
+				strings.TrimSuffix(image, ":latest"), signedDigest))
+			hash := sha256.Sum256(payload)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+			Expect(err).ToNot(HaveOccurred())
+			payloadDigest := fmt.Sprintf("sha256:%x", hash)
+			sigManifest, err := json.Marshal(map[string]interface{}{
+				"schemaVersion": 2,
+				"layers": []map[string]interface{}{{
+					"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+					"digest":      payloadDigest,
+					"size":        len(payload),
+					"annotations": map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+				}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			content["/v2/kubevirt/cirros/manifests/"+strings.Replace(digest, ":", "-", 1)+".sig"] = sigManifest
+			content["/v2/kubevirt/cirros/blobs/"+payloadDigest] = payload
+		}
+
+		publicKeys := func(keys ...*ecdsa.PrivateKey) []*ecdsa.PublicKey {
+			var pems []string
+			for _, key := range keys {
+				der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+				Expect(err).ToNot(HaveOccurred())
+				pems = append(pems, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+			}
+			parsed, err := ParsePublicKeys(pems)
+			Expect(err).ToNot(HaveOccurred())
+			return parsed
+		}
+
+		BeforeEach(func() {
+			var err error
+			key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should accept an image signed with one of the keys", func() {
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			sign(key, digest)
+			Expect(client.VerifySignature(image, digest, publicKeys(otherKey, key), nil)).To(Succeed())
+		})
+
+		It("should reject an image signed with another key", func() {
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			sign(otherKey, digest)
+			Expect(client.VerifySignature(image, digest, publicKeys(key), nil)).To(MatchError(ContainSubstring("no signature of image")))
+		})
+
+		It("should reject a signature of another digest", func() {
+			sign(key, "sha256:0000")
+			Expect(client.VerifySignature(image, digest, publicKeys(key), nil)).To(MatchError(ContainSubstring("no signature of image")))
+		})
+
+		It("should reject an unsigned image", func() {
+			Expect(client.VerifySignature(image, digest, publicKeys(key), nil)).To(MatchError(ContainSubstring("is not signed")))
+		})
+
+		It("should reject signature keys which are not PEM encoded", func() {
+			_, err := ParsePublicKeys([]string{"not a key"})
+			Expect(err).To(MatchError("signature key 0 is not PEM encoded"))
+		})
+	})
+
+	table.DescribeTable("should parse the image reference", func(image string, expected Reference) {
+		ref, err := ParseReference(image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*ref).To(Equal(expected))
+	},
+		table.Entry("of a Docker Hub library image", "cirros", Reference{Registry: "docker.io", Repository: "library/cirros", Tag: "latest"}),
+		table.Entry("of a Docker Hub image", "kubevirt/cirros:v1", Reference{Registry: "docker.io", Repository: "kubevirt/cirros", Tag: "v1"}),
+		table.Entry("of an image in a registry", "quay.io/kubevirt/cirros:v1", Reference{Registry: "quay.io", Repository: "kubevirt/cirros", Tag: "v1"}),
+		table.Entry("of an image in a registry with a port", "registry:5000/cirros", Reference{Registry: "registry:5000", Repository: "cirros", Tag: "latest"}),
+		table.Entry("of an image on localhost", "localhost/cirros", Reference{Registry: "localhost", Repository: "cirros", Tag: "latest"}),
+		table.Entry("of an image referenced by digest", "quay.io/kubevirt/cirros@sha256:abcd", Reference{Registry: "quay.io", Repository: "kubevirt/cirros", Digest: "sha256:abcd"}),
+	)
+
+	table.DescribeTable("should reject the image reference", func(image string) {
+		_, err := ParseReference(image)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("when it is empty", ""),
+		table.Entry("when its tag is empty", "quay.io/kubevirt/cirros:"),
+		table.Entry("when its digest is not sha256", "quay.io/kubevirt/cirros@md5:abcd"),
+	)
+
+	It("should read the credentials of the registry of the image from a docker config", func() {
+		config := []byte(`{"auths":{
+			"https://index.docker.io/v1/":{"auth":"` + base64.StdEncoding.EncodeToString([]byte("hub:pass")) + `"},
+			"quay.io":{"username":"quay","password":"secret"}
+		}}`)
+
+		credentials, err := CredentialsFromDockerConfig(config, "kubevirt/cirros")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credentials).To(Equal(&Credentials{Username: "hub", Password: "pass"}))
+
+		credentials, err = CredentialsFromDockerConfig(config, "quay.io/kubevirt/cirros")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credentials).To(Equal(&Credentials{Username: "quay", Password: "secret"}))
+
+		credentials, err = CredentialsFromDockerConfig(config, "registry.example.com/cirros")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credentials).To(BeNil())
+	})
+})
@@ -0,0 +1,136 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package registry
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// cosignSignatureAnnotation holds the base64 encoded signature of a layer of a cosign signature manifest
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// cosignSignatureType is the critical type of the simple signing payloads of cosign
+	cosignSignatureType = "cosign container image signature"
+)
+
+type signatureManifest struct {
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+// simpleSigningPayload is the payload signed by cosign, it names the digest of the signed manifest
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// ParsePublicKeys parses PEM encoded ECDSA public keys, as generated by cosign
+func ParsePublicKeys(keys []string) ([]*ecdsa.PublicKey, error) {
+	var publicKeys []*ecdsa.PublicKey
+	for i, key := range keys {
+		block, _ := pem.Decode([]byte(key))
+		if block == nil {
+			return nil, fmt.Errorf("signature key %d is not PEM encoded", i)
+		}
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signature key %d: %v", i, err)
+		}
+		ecdsaKey, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("signature key %d is not an ECDSA key", i)
+		}
+		publicKeys = append(publicKeys, ecdsaKey)
+	}
+	return publicKeys, nil
+}
+
+// VerifySignature checks that the manifest with the given digest in the repository of the image has a cosign
+// signature made with one of the public keys. The signatures are looked up in the sha256-<hex>.sig tag
+// cosign pushes them to, next to the image.
+func (c *Client) VerifySignature(image string, digest string, publicKeys []*ecdsa.PublicKey, credentials *Credentials) error {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return err
+	}
+	s := c.NewSession(ref, credentials, "pull")
+
+	data, err := s.fetch("manifests/"+strings.Replace(digest, ":", "-", 1)+".sig",
+		"application/vnd.oci.image.manifest.v1+json", "application/vnd.docker.distribution.manifest.v2+json")
+	if statusErr, ok := err.(*StatusError); ok && statusErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("image %s is not signed", image)
+	} else if err != nil {
+		return err
+	}
+	manifest := signatureManifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return err
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		payload, err := s.fetch("blobs/" + layer.Digest)
+		if err != nil {
+			return err
+		}
+		if fmt.Sprintf("sha256:%x", sha256.Sum256(payload)) != layer.Digest {
+			return fmt.Errorf("signature payload of image %s does not match its digest %s", image, layer.Digest)
+		}
+		if verifyPayload(payload, signature, digest, publicKeys) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no signature of image %s was made with one of the signature keys", image)
+}
+
+// verifyPayload checks that the simple signing payload names the digest and was signed with one of the keys
+func verifyPayload(payload []byte, signature []byte, digest string, publicKeys []*ecdsa.PublicKey) bool {
+	signed := simpleSigningPayload{}
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return false
+	}
+	if signed.Critical.Type != cosignSignatureType || signed.Critical.Image.DockerManifestDigest != digest {
+		return false
+	}
+	hash := sha256.Sum256(payload)
+	for _, key := range publicKeys {
+		if ecdsa.VerifyASN1(key, hash[:], signature) {
+			return true
+		}
+	}
+	return false
+}
@@ -228,6 +228,46 @@ func ApplyVolumeRequestOnVMISpec(vmiSpec *v1.VirtualMachineInstanceSpec, request
 	return vmiSpec
 }
 
+// ApplyInterfaceRequestOnVMISpec adds a bridged virtio interface connected to the requested
+// Multus network to the VMI spec, unless a network with the same name already exists.
+func ApplyInterfaceRequestOnVMISpec(vmiSpec *v1.VirtualMachineInstanceSpec, request *v1.AddInterfaceOptions) *v1.VirtualMachineInstanceSpec {
+	for _, network := range vmiSpec.Networks {
+		if network.Name == request.Name {
+			return vmiSpec
+		}
+	}
+
+	vmiSpec.Networks = append(vmiSpec.Networks, v1.Network{
+		Name: request.Name,
+		NetworkSource: v1.NetworkSource{
+			Multus: &v1.MultusNetwork{
+				NetworkName: request.NetworkAttachmentDefinitionName,
+			},
+		},
+	})
+	vmiSpec.Domain.Devices.Interfaces = append(vmiSpec.Domain.Devices.Interfaces, v1.Interface{
+		Name:  request.Name,
+		Model: "virtio",
+		InterfaceBindingMethod: v1.InterfaceBindingMethod{
+			Bridge: &v1.InterfaceBridge{},
+		},
+	})
+
+	return vmiSpec
+}
+
+// ApplyRemoveInterfaceRequestOnVMISpec marks the requested interface as absent. The interface and its network
+// are kept in the spec, to keep the pod interface names of the other secondary networks stable.
+func ApplyRemoveInterfaceRequestOnVMISpec(vmiSpec *v1.VirtualMachineInstanceSpec, request *v1.RemoveInterfaceOptions) *v1.VirtualMachineInstanceSpec {
+	for i, iface := range vmiSpec.Domain.Devices.Interfaces {
+		if iface.Name == request.Name {
+			vmiSpec.Domain.Devices.Interfaces[i].State = v1.InterfaceStateAbsent
+		}
+	}
+
+	return vmiSpec
+}
+
 func CurrentVMIPod(vmi *v1.VirtualMachineInstance, podInformer cache.SharedIndexInformer) (*k8sv1.Pod, error) {
 
 	// current pod is the most recent pod created on the current VMI node
@@ -36,6 +36,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	extclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -50,6 +51,10 @@ import (
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 
 	kubev1 "kubevirt.io/client-go/api/v1"
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
@@ -107,6 +112,21 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineRestore objects
 	VirtualMachineRestore() cache.SharedIndexInformer
 
+	// Watches VirtualMachineSnapshotSchedule objects
+	VirtualMachineSnapshotSchedule() cache.SharedIndexInformer
+
+	// Watches VirtualMachineExport objects
+	VirtualMachineExport() cache.SharedIndexInformer
+
+	// Watches VirtualMachineClone objects
+	VirtualMachineClone() cache.SharedIndexInformer
+
+	// Watches VirtualMachinePool objects
+	VirtualMachinePool() cache.SharedIndexInformer
+
+	// Watches StorageCheckup objects
+	StorageCheckup() cache.SharedIndexInformer
+
 	// Watches for k8s extensions api configmap
 	ApiAuthConfigMap() cache.SharedIndexInformer
 
@@ -215,6 +235,9 @@ type KubeInformerFactory interface {
 	// Pod returns an informer for ALL Pods in the system
 	Pod() cache.SharedIndexInformer
 
+	// PriorityClass returns an informer for the pod PriorityClasses
+	PriorityClass() cache.SharedIndexInformer
+
 	K8SInformerFactory() informers.SharedInformerFactory
 }
 
@@ -471,6 +494,77 @@ func (f *kubeInformerFactory) VirtualMachineRestore() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachineSnapshotSchedule() cache.SharedIndexInformer {
+	return f.getInformer("vmSnapshotScheduleInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1alpha1().RESTClient(), "virtualmachinesnapshotschedules", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &snapshotv1.VirtualMachineSnapshotSchedule{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineExport() cache.SharedIndexInformer {
+	return f.getInformer("vmExportInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().ExportV1alpha1().RESTClient(), "virtualmachineexports", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &exportv1.VirtualMachineExport{}, f.defaultResync, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+			"vm": func(obj interface{}) ([]string, error) {
+				export, ok := obj.(*exportv1.VirtualMachineExport)
+				if !ok {
+					return nil, unexpectedObjectError
+				}
+
+				if export.Spec.Source.APIGroup != nil &&
+					*export.Spec.Source.APIGroup == kubev1.GroupName &&
+					export.Spec.Source.Kind == "VirtualMachine" {
+					return []string{export.Spec.Source.Name}, nil
+				}
+
+				return nil, nil
+			},
+		})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineClone() cache.SharedIndexInformer {
+	return f.getInformer("vmCloneInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().CloneV1alpha1().RESTClient(), "virtualmachineclones", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &clonev1.VirtualMachineClone{}, f.defaultResync, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+			"vmSource": func(obj interface{}) ([]string, error) {
+				vmClone, ok := obj.(*clonev1.VirtualMachineClone)
+				if !ok {
+					return nil, unexpectedObjectError
+				}
+
+				if vmClone.Spec.Source.APIGroup != nil &&
+					*vmClone.Spec.Source.APIGroup == kubev1.GroupName &&
+					vmClone.Spec.Source.Kind == "VirtualMachine" {
+					return []string{vmClone.Spec.Source.Name}, nil
+				}
+
+				return nil, nil
+			},
+		})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachinePool() cache.SharedIndexInformer {
+	return f.getInformer("vmPoolInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().PoolV1alpha1().RESTClient(), "virtualmachinepools", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &poolv1.VirtualMachinePool{}, f.defaultResync, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+	})
+}
+
+func (f *kubeInformerFactory) StorageCheckup() cache.SharedIndexInformer {
+	return f.getInformer("storageCheckupInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().CheckupV1alpha1().RESTClient(), "storagecheckups", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &checkupv1.StorageCheckup{}, f.defaultResync, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		})
+	})
+}
+
 func (f *kubeInformerFactory) DataVolume() cache.SharedIndexInformer {
 	return f.getInformer("dataVolumeInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.CdiClient().CdiV1alpha1().RESTClient(), "datavolumes", k8sv1.NamespaceAll, fields.Everything())
@@ -851,6 +945,14 @@ func (f *kubeInformerFactory) Pod() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) PriorityClass() cache.SharedIndexInformer {
+	return f.getInformer("priorityClassInformer", func() cache.SharedIndexInformer {
+		restClient := f.clientSet.SchedulingV1().RESTClient()
+		lw := cache.NewListWatchFromClient(restClient, "priorityclasses", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &schedulingv1.PriorityClass{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
 // VolumeSnapshotInformer returns an informer for VolumeSnapshots
 func VolumeSnapshotInformer(clientSet kubecli.KubevirtClient, resyncPeriod time.Duration) cache.SharedIndexInformer {
 	restClient := clientSet.KubernetesSnapshotClient().SnapshotV1beta1().RESTClient()
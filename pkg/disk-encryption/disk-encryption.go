@@ -0,0 +1,72 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package diskencryption
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/config"
+)
+
+// PassphraseKey is the key of the Secret holding the passphrase of an encrypted disk
+const PassphraseKey = "passphrase"
+
+const volumeNamePrefix = "luks-"
+
+// EncryptedDisks returns the disks of the VMI which are opened with a passphrase.
+func EncryptedDisks(vmi *v1.VirtualMachineInstance) []v1.Disk {
+	var disks []v1.Disk
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Encryption != nil {
+			disks = append(disks, disk)
+		}
+	}
+	return disks
+}
+
+// VolumeName returns the name of the pod volume the passphrase Secret of the disk is mounted from.
+func VolumeName(diskName string) string {
+	return volumeNamePrefix + diskName
+}
+
+// GetSecretDir returns where the passphrase Secret of the disk is mounted in the virt-launcher pod.
+func GetSecretDir(diskName string) string {
+	return filepath.Join(config.SecretSourceDir, VolumeName(diskName))
+}
+
+// SecretUsage returns the usage id under which libvirt keeps the passphrase of the disk.
+func SecretUsage(vmi *v1.VirtualMachineInstance, diskName string) string {
+	return fmt.Sprintf("kubevirt/%s/%s/%s", vmi.Namespace, vmi.Name, diskName)
+}
+
+// ReadPassphrase reads the passphrase of the disk from its mounted Secret.
+func ReadPassphrase(diskName string) ([]byte, error) {
+	passphrase, err := ioutil.ReadFile(filepath.Join(GetSecretDir(diskName), PassphraseKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the passphrase of disk %s: %v", diskName, err)
+	}
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("the passphrase of disk %s is empty", diskName)
+	}
+	return passphrase, nil
+}
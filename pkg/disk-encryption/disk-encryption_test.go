@@ -0,0 +1,90 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package diskencryption
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/config"
+)
+
+var _ = Describe("Disk encryption", func() {
+	var tmpDir string
+
+	writePassphrase := func(diskName string, passphrase string) {
+		dir := GetSecretDir(diskName)
+		Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, PassphraseKey), []byte(passphrase), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "disk-encryption")
+		Expect(err).ToNot(HaveOccurred())
+		config.SecretSourceDir = filepath.Join(tmpDir, "secret")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should only return the encrypted disks", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "plain"},
+			{Name: "encrypted", Encryption: &v1.DiskEncryption{SecretName: "luks"}},
+		}
+		disks := EncryptedDisks(vmi)
+		Expect(disks).To(HaveLen(1))
+		Expect(disks[0].Name).To(Equal("encrypted"))
+	})
+
+	It("should mount the passphrase of each disk into its own directory", func() {
+		Expect(GetSecretDir("disk0")).To(Equal(filepath.Join(config.SecretSourceDir, "luks-disk0")))
+	})
+
+	It("should make the secret usage unique per vmi and disk", func() {
+		vmi := v1.NewMinimalVMIWithNS("testns", "testvmi")
+		Expect(SecretUsage(vmi, "disk0")).To(Equal("kubevirt/testns/testvmi/disk0"))
+	})
+
+	It("should read the passphrase from the mounted secret", func() {
+		writePassphrase("disk0", "secret passphrase")
+		passphrase, err := ReadPassphrase("disk0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(passphrase)).To(Equal("secret passphrase"))
+	})
+
+	It("should fail if the passphrase is missing", func() {
+		_, err := ReadPassphrase("disk0")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail if the passphrase is empty", func() {
+		writePassphrase("disk0", "")
+		_, err := ReadPassphrase("disk0")
+		Expect(err).To(MatchError("the passphrase of disk disk0 is empty"))
+	})
+})
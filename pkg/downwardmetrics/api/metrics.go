@@ -0,0 +1,54 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package api
+
+import "encoding/xml"
+
+type MetricType string
+
+const (
+	MetricTypeReal64 MetricType = "real64"
+	MetricTypeUInt64 MetricType = "uint64"
+	MetricTypeUInt32 MetricType = "uint32"
+	MetricTypeString MetricType = "string"
+)
+
+type MetricContext string
+
+const (
+	MetricContextHost MetricContext = "host"
+	MetricContextVM   MetricContext = "vm"
+)
+
+// Metric is a single metric in the format of vhostmd
+type Metric struct {
+	Name    string        `xml:"name"`
+	Value   string        `xml:"value"`
+	Type    MetricType    `xml:"type,attr"`
+	Unit    string        `xml:"unit,attr,omitempty"`
+	Context MetricContext `xml:"context,attr"`
+}
+
+// Metrics is the XML document which vhostmd exposes to the guest
+type Metrics struct {
+	XMLName xml.Name `xml:"metrics"`
+	Text    string   `xml:",chardata"`
+	Metrics []Metric `xml:"metric,omitempty"`
+}
@@ -0,0 +1,58 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package downwardmetrics
+
+import (
+	"path/filepath"
+	"strconv"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/config"
+	"kubevirt.io/kubevirt/pkg/downwardmetrics/vhostmd"
+	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+)
+
+// HasDownwardMetricDisk returns true if the VMI has a downwardMetrics volume
+func HasDownwardMetricDisk(vmi *v1.VirtualMachineInstance) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.DownwardMetrics != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateDownwardMetricDisk creates the empty metrics disk of the VMI, which virt-handler keeps updating
+func CreateDownwardMetricDisk(vmi *v1.VirtualMachineInstance) error {
+	if !HasDownwardMetricDisk(vmi) {
+		return nil
+	}
+	if err := vhostmd.NewMetricsIODisk(config.DownwardMetricDisk).Create(); err != nil {
+		return err
+	}
+	return ephemeraldiskutils.DefaultOwnershipManager.SetFileOwnership(config.DownwardMetricDisk)
+}
+
+// FormatDownwardMetricPath returns the path of the metrics disk of the virt-launcher with the given pid,
+// as seen by virt-handler
+func FormatDownwardMetricPath(pid int) string {
+	return filepath.Join("/proc", strconv.Itoa(pid), "root", config.DownwardMetricDisk)
+}
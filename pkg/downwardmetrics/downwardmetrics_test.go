@@ -0,0 +1,126 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package downwardmetrics
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/downwardmetrics/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+var _ = Describe("Downward metrics", func() {
+
+	It("should detect the downwardMetrics volume", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		Expect(HasDownwardMetricDisk(vmi)).To(BeFalse())
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name:         "metrics",
+			VolumeSource: v1.VolumeSource{DownwardMetrics: &v1.DownwardMetricsVolumeSource{}},
+		})
+		Expect(HasDownwardMetricDisk(vmi)).To(BeTrue())
+	})
+
+	It("should find the metrics disk in the root of the virt-launcher process", func() {
+		Expect(FormatDownwardMetricPath(1234)).To(Equal("/proc/1234/root/var/run/kubevirt-private/downwardmetrics-disk/vhostmd0"))
+	})
+
+	Context("scraper", func() {
+		var tmpDir string
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "downwardmetrics")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		It("should read the CPU time and the CPUs of the host", func() {
+			path := filepath.Join(tmpDir, "stat")
+			Expect(ioutil.WriteFile(path, []byte(`cpu  1000 200 300 5000 100 50 50 0 400 0
+cpu0 500 100 150 2500 50 25 25 0 200 0
+cpu1 500 100 150 2500 50 25 25 0 200 0
+intr 12345
+`), 0644)).To(Succeed())
+
+			cpuTime, cpus, err := readHostCPUStats(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cpuTime).To(BeNumerically("==", 16))
+			Expect(cpus).To(Equal(2))
+		})
+
+		It("should fail without CPU times", func() {
+			path := filepath.Join(tmpDir, "stat")
+			Expect(ioutil.WriteFile(path, []byte("intr 12345\n"), 0644)).To(Succeed())
+			_, _, err := readHostCPUStats(path)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should report the host metrics", func() {
+			stat := filepath.Join(tmpDir, "stat")
+			Expect(ioutil.WriteFile(stat, []byte("cpu  100 0 100 0 0 0 0 0 0 0\ncpu0 100 0 100 0 0 0 0 0 0 0\n"), 0644)).To(Succeed())
+			meminfo := filepath.Join(tmpDir, "meminfo")
+			Expect(ioutil.WriteFile(meminfo, []byte("MemTotal:       16384000 kB\nMemAvailable:    8192000 kB\n"), 0644)).To(Succeed())
+
+			s := &Scraper{nodeName: "node01", procStatPath: stat, memInfoPath: meminfo}
+			metrics, err := s.hostMetrics()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metrics).To(ContainElement(api.Metric{Name: "HostName", Value: "node01", Type: api.MetricTypeString, Context: api.MetricContextHost}))
+			Expect(metrics).To(ContainElement(api.Metric{Name: "TotalCPUTime", Value: "2.000000", Type: api.MetricTypeReal64, Unit: "s", Context: api.MetricContextHost}))
+			Expect(metrics).To(ContainElement(api.Metric{Name: "NumberOfPhysicalCPUs", Value: "1", Type: api.MetricTypeUInt32, Context: api.MetricContextHost}))
+			Expect(metrics).To(ContainElement(api.Metric{Name: "FreePhysicalMemory", Value: "8192000", Type: api.MetricTypeUInt64, Unit: "KiB", Context: api.MetricContextHost}))
+		})
+
+		It("should report the VMI metrics", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("1Gi")}
+			guest := resource.MustParse("2Gi")
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest}
+
+			metrics := vmMetrics(vmi, &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{TimeSet: true, Time: 1500000000},
+				Vcpu:   []stats.DomainStatsVcpu{{}, {}},
+				Memory: &stats.DomainStatsMemory{ActualBalloonSet: true, ActualBalloon: 2097152},
+			})
+			Expect(metrics).To(Equal([]api.Metric{
+				{Name: "TotalCPUTime", Value: "1.500000", Type: api.MetricTypeReal64, Unit: "s", Context: api.MetricContextVM},
+				{Name: "ResourceProcessorLimit", Value: "2", Type: api.MetricTypeUInt32, Context: api.MetricContextVM},
+				{Name: "PhysicalMemoryAllocatedToVirtualSystem", Value: "2097152", Type: api.MetricTypeUInt64, Unit: "KiB", Context: api.MetricContextVM},
+				{Name: "ResourceMemoryLimit", Value: "2048", Type: api.MetricTypeUInt64, Unit: "MiB", Context: api.MetricContextVM},
+			}))
+		})
+
+		It("should only report the VMI metrics which are known", func() {
+			Expect(vmMetrics(&v1.VirtualMachineInstance{}, &stats.DomainStats{})).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,223 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package downwardmetrics
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/client-go/version"
+
+	"kubevirt.io/kubevirt/pkg/downwardmetrics/api"
+	"kubevirt.io/kubevirt/pkg/downwardmetrics/vhostmd"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+const (
+	// DefaultScrapePeriod is how often the metrics disks are updated
+	DefaultScrapePeriod = 5 * time.Second
+
+	procStatPath         = "/proc/stat"
+	virtualizationVendor = "kubevirt.io"
+	// The kernel reports the CPU times of /proc/stat in USER_HZ
+	userHZ = 100
+)
+
+// Scraper periodically writes the host metrics and the metrics of each VMI on the node
+// into the downward metrics disk of the VMI
+type Scraper struct {
+	nodeName          string
+	vmiInformer       cache.SharedIndexInformer
+	isolationDetector isolation.PodIsolationDetector
+	procStatPath      string
+	memInfoPath       string
+}
+
+func NewScraper(nodeName string, vmiInformer cache.SharedIndexInformer, isolationDetector isolation.PodIsolationDetector) *Scraper {
+	return &Scraper{
+		nodeName:          nodeName,
+		vmiInformer:       vmiInformer,
+		isolationDetector: isolationDetector,
+		procStatPath:      procStatPath,
+		memInfoPath:       hardware.MEMINFO_PATH,
+	}
+}
+
+// Run updates the metrics disks every period until stopCh is closed
+func (s *Scraper) Run(period time.Duration, stopCh <-chan struct{}) {
+	wait.Until(s.Scrape, period, stopCh)
+}
+
+// Scrape updates the metrics disks of all running VMIs on the node which have a downwardMetrics volume
+func (s *Scraper) Scrape() {
+	hostMetrics, err := s.hostMetrics()
+	if err != nil {
+		log.Log.Reason(err).Error("failed to collect the host metrics for the downward metrics")
+		return
+	}
+	for _, obj := range s.vmiInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if vmi.Status.NodeName != s.nodeName || !vmi.IsRunning() || !HasDownwardMetricDisk(vmi) {
+			continue
+		}
+		if err := s.scrapeVMI(vmi, hostMetrics); err != nil {
+			log.Log.Object(vmi).Reason(err).Warning("failed to update the downward metrics")
+		}
+	}
+}
+
+func (s *Scraper) scrapeVMI(vmi *v1.VirtualMachineInstance, hostMetrics []api.Metric) error {
+	res, err := s.isolationDetector.Detect(vmi)
+	if err != nil {
+		return err
+	}
+	socketFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		return err
+	}
+	client, err := cmdclient.NewClient(socketFile)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	vmStats, exists, err := client.GetDomainStats()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	metrics := &api.Metrics{
+		Metrics: append(append([]api.Metric{}, hostMetrics...), vmMetrics(vmi, vmStats)...),
+	}
+	return vhostmd.NewMetricsIODisk(FormatDownwardMetricPath(res.Pid())).Write(metrics)
+}
+
+func (s *Scraper) hostMetrics() ([]api.Metric, error) {
+	cpuTime, cpus, err := readHostCPUStats(s.procStatPath)
+	if err != nil {
+		return nil, err
+	}
+	_, available, err := hardware.ReadMemInfo(s.memInfoPath)
+	if err != nil {
+		return nil, err
+	}
+	return []api.Metric{
+		stringMetric("HostName", s.nodeName, api.MetricContextHost),
+		stringMetric("VirtualizationVendor", virtualizationVendor, api.MetricContextHost),
+		stringMetric("VirtProductInfo", version.Get().GitVersion, api.MetricContextHost),
+		realMetric("TotalCPUTime", cpuTime, "s", api.MetricContextHost),
+		uintMetric("NumberOfPhysicalCPUs", uint64(cpus), api.MetricTypeUInt32, "", api.MetricContextHost),
+		uintMetric("FreePhysicalMemory", available, api.MetricTypeUInt64, "KiB", api.MetricContextHost),
+		uintMetric("Time", uint64(time.Now().Unix()), api.MetricTypeUInt64, "s", api.MetricContextHost),
+	}, nil
+}
+
+// vmMetrics returns the metrics of the VMI which are known from its domain stats and its spec
+func vmMetrics(vmi *v1.VirtualMachineInstance, vmStats *stats.DomainStats) []api.Metric {
+	var metrics []api.Metric
+	if vmStats.Cpu != nil && vmStats.Cpu.TimeSet {
+		metrics = append(metrics, realMetric("TotalCPUTime", float64(vmStats.Cpu.Time)/float64(time.Second), "s", api.MetricContextVM))
+	}
+	if len(vmStats.Vcpu) > 0 {
+		metrics = append(metrics, uintMetric("ResourceProcessorLimit", uint64(len(vmStats.Vcpu)), api.MetricTypeUInt32, "", api.MetricContextVM))
+	}
+	if vmStats.Memory != nil && vmStats.Memory.ActualBalloonSet {
+		metrics = append(metrics, uintMetric("PhysicalMemoryAllocatedToVirtualSystem", vmStats.Memory.ActualBalloon, api.MetricTypeUInt64, "KiB", api.MetricContextVM))
+	}
+
+	memory := vmi.Spec.Domain.Resources.Requests.Memory()
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		memory = vmi.Spec.Domain.Memory.Guest
+	}
+	if !memory.IsZero() {
+		metrics = append(metrics, uintMetric("ResourceMemoryLimit", uint64(memory.Value()/1024/1024), api.MetricTypeUInt64, "MiB", api.MetricContextVM))
+	}
+	return metrics
+}
+
+// readHostCPUStats returns the CPU time spent by all CPUs of the host in seconds and the number of CPUs
+func readHostCPUStats(path string) (float64, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	var cpuTime float64
+	cpus := 0
+	found := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		if fields[0] != "cpu" {
+			cpus++
+			continue
+		}
+		// Sum up user, nice, system, irq, softirq and steal, idle and iowait are not CPU time
+		for i, field := range fields[1:] {
+			if i == 3 || i == 4 || i > 7 {
+				continue
+			}
+			ticks, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to parse the CPU times in %s: %v", path, err)
+			}
+			cpuTime += float64(ticks) / userHZ
+		}
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return 0, 0, fmt.Errorf("no CPU times found in %s", path)
+	}
+	return cpuTime, cpus, nil
+}
+
+func stringMetric(name, value string, context api.MetricContext) api.Metric {
+	return api.Metric{Name: name, Value: value, Type: api.MetricTypeString, Context: context}
+}
+
+func realMetric(name string, value float64, unit string, context api.MetricContext) api.Metric {
+	return api.Metric{Name: name, Value: strconv.FormatFloat(value, 'f', 6, 64), Type: api.MetricTypeReal64, Unit: unit, Context: context}
+}
+
+func uintMetric(name string, value uint64, metricType api.MetricType, unit string, context api.MetricContext) api.Metric {
+	return api.Metric{Name: name, Value: strconv.FormatUint(value, 10), Type: metricType, Unit: unit, Context: context}
+}
@@ -0,0 +1,165 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package vhostmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+
+	"kubevirt.io/kubevirt/pkg/downwardmetrics/api"
+)
+
+// The disk layout follows vhostmd: a header made of the signature, a busy flag, the checksum and
+// the length of the metrics, all in network byte order, directly followed by the metrics XML.
+const (
+	fileSize      = 262144
+	signature     = "mvbd"
+	headerSize    = 16
+	maxBodyLength = fileSize - headerSize
+)
+
+type header struct {
+	Signature [4]byte
+	Busy      uint32
+	Checksum  uint32
+	Length    uint32
+}
+
+// MetricsIO reads and writes the metrics of a vhostmd compatible disk
+type MetricsIO interface {
+	Create() error
+	Read() (*api.Metrics, error)
+	Write(metrics *api.Metrics) error
+}
+
+type vhostmd struct {
+	filePath string
+}
+
+func NewMetricsIODisk(filePath string) MetricsIO {
+	return &vhostmd{filePath: filePath}
+}
+
+// Create creates the disk with an empty metrics document, an existing disk is left untouched
+func (v *vhostmd) Create() error {
+	f, err := os.OpenFile(v.filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	if err := f.Truncate(fileSize); err != nil {
+		return err
+	}
+	return write(f, &api.Metrics{})
+}
+
+func (v *vhostmd) Read() (*api.Metrics, error) {
+	f, err := os.Open(v.filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := &header{}
+	if err := binary.Read(f, binary.BigEndian, h); err != nil {
+		return nil, fmt.Errorf("failed to read the metrics header: %v", err)
+	}
+	if string(h.Signature[:]) != signature {
+		return nil, fmt.Errorf("invalid metrics disk signature %q", string(h.Signature[:]))
+	}
+	if h.Busy != 0 {
+		return nil, fmt.Errorf("the metrics disk is being updated")
+	}
+	if h.Length > maxBodyLength {
+		return nil, fmt.Errorf("invalid metrics length %d", h.Length)
+	}
+
+	body := make([]byte, h.Length)
+	if _, err := io.ReadFull(f, body); err != nil {
+		return nil, fmt.Errorf("failed to read the metrics: %v", err)
+	}
+	if sum := checksum(body); sum != h.Checksum {
+		return nil, fmt.Errorf("metrics checksum mismatch: expected %d, got %d", h.Checksum, sum)
+	}
+
+	metrics := &api.Metrics{}
+	if err := xml.Unmarshal(body, metrics); err != nil {
+		return nil, fmt.Errorf("failed to parse the metrics: %v", err)
+	}
+	return metrics, nil
+}
+
+func (v *vhostmd) Write(metrics *api.Metrics) error {
+	f, err := os.OpenFile(v.filePath, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return write(f, metrics)
+}
+
+// write marks the disk as busy while the metrics are written, so that readers in the
+// guest never see partially written metrics
+func write(f *os.File, metrics *api.Metrics) error {
+	body, err := xml.MarshalIndent(metrics, "", "  ")
+	if err != nil {
+		return err
+	}
+	if len(body) > maxBodyLength {
+		return fmt.Errorf("the metrics exceed the maximum length of %d bytes", maxBodyLength)
+	}
+
+	if err := writeHeader(f, &header{Busy: 1}); err != nil {
+		return err
+	}
+	if _, err := f.WriteAt(body, headerSize); err != nil {
+		return err
+	}
+	return writeHeader(f, &header{
+		Checksum: checksum(body),
+		Length:   uint32(len(body)),
+	})
+}
+
+func writeHeader(f *os.File, h *header) error {
+	copy(h.Signature[:], signature)
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, binary.BigEndian, h); err != nil {
+		return err
+	}
+	_, err := f.WriteAt(buf.Bytes(), 0)
+	return err
+}
+
+func checksum(body []byte) uint32 {
+	var sum uint32
+	for _, b := range body {
+		sum += uint32(b)
+	}
+	return sum
+}
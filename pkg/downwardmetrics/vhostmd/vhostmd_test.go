@@ -0,0 +1,118 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package vhostmd
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/downwardmetrics/api"
+)
+
+var _ = Describe("vhostmd", func() {
+
+	var tmpDir string
+	var diskPath string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "vhostmd")
+		Expect(err).ToNot(HaveOccurred())
+		diskPath = filepath.Join(tmpDir, "vhostmd0")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should create an empty metrics disk of the vhostmd size", func() {
+		Expect(NewMetricsIODisk(diskPath).Create()).To(Succeed())
+
+		info, err := os.Stat(diskPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Size()).To(Equal(int64(fileSize)))
+
+		metrics, err := NewMetricsIODisk(diskPath).Read()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics.Metrics).To(BeEmpty())
+	})
+
+	It("should not overwrite an existing metrics disk", func() {
+		disk := NewMetricsIODisk(diskPath)
+		Expect(disk.Create()).To(Succeed())
+		Expect(disk.Write(&api.Metrics{Metrics: []api.Metric{
+			{Name: "HostName", Value: "node01", Type: api.MetricTypeString, Context: api.MetricContextHost},
+		}})).To(Succeed())
+		Expect(disk.Create()).To(Succeed())
+
+		metrics, err := disk.Read()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics.Metrics).To(HaveLen(1))
+	})
+
+	It("should read the written metrics", func() {
+		disk := NewMetricsIODisk(diskPath)
+		Expect(disk.Create()).To(Succeed())
+		written := []api.Metric{
+			{Name: "HostName", Value: "node01", Type: api.MetricTypeString, Context: api.MetricContextHost},
+			{Name: "TotalCPUTime", Value: "12.500000", Type: api.MetricTypeReal64, Unit: "s", Context: api.MetricContextVM},
+		}
+		Expect(disk.Write(&api.Metrics{Metrics: written})).To(Succeed())
+
+		metrics, err := disk.Read()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics.Metrics).To(Equal(written))
+	})
+
+	It("should write the header in network byte order", func() {
+		disk := NewMetricsIODisk(diskPath)
+		Expect(disk.Create()).To(Succeed())
+
+		content, err := ioutil.ReadFile(diskPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content[0:4])).To(Equal(signature))
+		Expect(binary.BigEndian.Uint32(content[4:8])).To(BeZero())
+		length := binary.BigEndian.Uint32(content[12:16])
+		Expect(binary.BigEndian.Uint32(content[8:12])).To(Equal(checksum(content[headerSize : headerSize+length])))
+	})
+
+	It("should refuse to read a disk which is being updated", func() {
+		disk := NewMetricsIODisk(diskPath)
+		Expect(disk.Create()).To(Succeed())
+		f, err := os.OpenFile(diskPath, os.O_WRONLY, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(writeHeader(f, &header{Busy: 1})).To(Succeed())
+		f.Close()
+
+		_, err = disk.Read()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should refuse to read a disk without the vhostmd signature", func() {
+		Expect(ioutil.WriteFile(diskPath, make([]byte, fileSize), 0644)).To(Succeed())
+		_, err := NewMetricsIODisk(diskPath).Read()
+		Expect(err).To(HaveOccurred())
+	})
+})
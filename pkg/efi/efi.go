@@ -0,0 +1,150 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package efi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/config"
+)
+
+// OwnerGUID identifies KubeVirt as the owner of the enrolled Secure Boot certificates
+const OwnerGUID = "a1b2e05c-6d2e-4a0b-9f3d-5b0ae3d2c4f1"
+
+const (
+	PKVolumeName  = "efi-pk"
+	KEKVolumeName = "efi-kek"
+	DBVolumeName  = "efi-db"
+)
+
+type EnrollFunc func(args []string) error
+
+var enrollFunc = defaultEnrollFunc
+
+func SetEnrollFunction(f EnrollFunc) {
+	enrollFunc = f
+}
+
+func defaultEnrollFunc(args []string) error {
+	// #nosec No risk for attacker injection. Parameters are predefined strings and mounted paths
+	cmd := exec.Command("virt-fw-vars", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("virt-fw-vars failed: %v: %s", err, string(output))
+	}
+	return nil
+}
+
+// GetSecureBootKeys returns the custom Secure Boot keys of the VMI, or nil if none are configured.
+func GetSecureBootKeys(vmi *v1.VirtualMachineInstance) *v1.SecureBootKeys {
+	firmware := vmi.Spec.Domain.Firmware
+	if firmware == nil || firmware.Bootloader == nil || firmware.Bootloader.EFI == nil {
+		return nil
+	}
+	return firmware.Bootloader.EFI.SecureBootKeys
+}
+
+// KeySource is a Secret with Secure Boot certificates together with the name
+// of the volume it is mounted from and the virt-fw-vars option enrolling it.
+type KeySource struct {
+	VolumeName string
+	Option     string
+	Source     *v1.SecureBootKeySource
+}
+
+// KeySources returns the Secrets referenced by the given keys, in the order they have to be enrolled.
+func KeySources(keys *v1.SecureBootKeys) []KeySource {
+	var sources []KeySource
+	if keys == nil {
+		return sources
+	}
+	if keys.PK != nil {
+		sources = append(sources, KeySource{VolumeName: PKVolumeName, Option: "--set-pk", Source: keys.PK})
+	}
+	if keys.KEK != nil {
+		sources = append(sources, KeySource{VolumeName: KEKVolumeName, Option: "--add-kek", Source: keys.KEK})
+	}
+	if keys.DB != nil {
+		sources = append(sources, KeySource{VolumeName: DBVolumeName, Option: "--add-db", Source: keys.DB})
+	}
+	return sources
+}
+
+// GetKeysDir returns where the Secret of the given volume is mounted in the virt-launcher pod.
+func GetKeysDir(volumeName string) string {
+	return filepath.Join(config.SecretSourceDir, volumeName)
+}
+
+// EnrollSecureBootKeys creates the NVRAM from its template and enrolls the custom
+// Secure Boot certificates. This only happens at first boot, an existing NVRAM is kept.
+func EnrollSecureBootKeys(vmi *v1.VirtualMachineInstance, nvram string, template string) error {
+	keys := GetSecureBootKeys(vmi)
+	if keys == nil {
+		return nil
+	}
+
+	if _, err := os.Stat(nvram); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	args := []string{"--input", template, "--output", nvram, "--secure-boot"}
+	for _, source := range KeySources(keys) {
+		certs, err := readCertificates(GetKeysDir(source.VolumeName))
+		if err != nil {
+			return err
+		}
+		if source.VolumeName == PKVolumeName && len(certs) != 1 {
+			return fmt.Errorf("exactly one platform key certificate is required, found %d", len(certs))
+		}
+		for _, cert := range certs {
+			args = append(args, source.Option, OwnerGUID, cert)
+		}
+	}
+
+	log.Log.Object(vmi).Infof("Enrolling custom Secure Boot keys into %s", nvram)
+	return enrollFunc(args)
+}
+
+// readCertificates returns the paths of all certificates in the mounted Secret, skipping
+// the hidden files and directories the kubelet uses to update Secrets atomically.
+func readCertificates(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var certs []string
+	for _, file := range files {
+		if file.IsDir() || file.Name()[0] == '.' {
+			continue
+		}
+		certs = append(certs, filepath.Join(dir, file.Name()))
+	}
+	sort.Strings(certs)
+	return certs, nil
+}
@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package efi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/config"
+)
+
+var _ = Describe("EFI", func() {
+	var tmpDir string
+	var enrollArgs []string
+
+	writeCertificates := func(volumeName string, names ...string) {
+		dir := GetKeysDir(volumeName)
+		Expect(os.MkdirAll(filepath.Join(dir, "..data"), 0755)).To(Succeed())
+		for _, name := range names {
+			Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte("cert"), 0644)).To(Succeed())
+		}
+	}
+
+	newVMI := func(keys *v1.SecureBootKeys) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Firmware = &v1.Firmware{
+			Bootloader: &v1.Bootloader{
+				EFI: &v1.EFI{SecureBootKeys: keys},
+			},
+		}
+		return vmi
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "efi")
+		Expect(err).ToNot(HaveOccurred())
+		config.SecretSourceDir = filepath.Join(tmpDir, "secret")
+
+		enrollArgs = nil
+		SetEnrollFunction(func(args []string) error {
+			enrollArgs = args
+			return nil
+		})
+	})
+
+	AfterEach(func() {
+		SetEnrollFunction(defaultEnrollFunc)
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should return the key sources in enrollment order", func() {
+		sources := KeySources(&v1.SecureBootKeys{
+			DB: &v1.SecureBootKeySource{SecretName: "db"},
+			PK: &v1.SecureBootKeySource{SecretName: "pk"},
+		})
+		Expect(sources).To(HaveLen(2))
+		Expect(sources[0].VolumeName).To(Equal(PKVolumeName))
+		Expect(sources[1].VolumeName).To(Equal(DBVolumeName))
+	})
+
+	It("should not enroll anything without custom keys", func() {
+		nvram := filepath.Join(tmpDir, "nvram")
+		Expect(EnrollSecureBootKeys(newVMI(nil), nvram, "template")).To(Succeed())
+		Expect(enrollArgs).To(BeNil())
+	})
+
+	It("should enroll all certificates of the referenced secrets", func() {
+		writeCertificates(PKVolumeName, "pk.pem")
+		writeCertificates(DBVolumeName, "b.pem", "a.pem")
+		nvram := filepath.Join(tmpDir, "nvram")
+
+		vmi := newVMI(&v1.SecureBootKeys{
+			PK: &v1.SecureBootKeySource{SecretName: "pk"},
+			DB: &v1.SecureBootKeySource{SecretName: "db"},
+		})
+		Expect(EnrollSecureBootKeys(vmi, nvram, "template")).To(Succeed())
+		Expect(enrollArgs).To(Equal([]string{
+			"--input", "template", "--output", nvram, "--secure-boot",
+			"--set-pk", OwnerGUID, filepath.Join(GetKeysDir(PKVolumeName), "pk.pem"),
+			"--add-db", OwnerGUID, filepath.Join(GetKeysDir(DBVolumeName), "a.pem"),
+			"--add-db", OwnerGUID, filepath.Join(GetKeysDir(DBVolumeName), "b.pem"),
+		}))
+	})
+
+	It("should keep an existing NVRAM", func() {
+		writeCertificates(DBVolumeName, "db.pem")
+		nvram := filepath.Join(tmpDir, "nvram")
+		Expect(ioutil.WriteFile(nvram, []byte{}, 0644)).To(Succeed())
+
+		vmi := newVMI(&v1.SecureBootKeys{DB: &v1.SecureBootKeySource{SecretName: "db"}})
+		Expect(EnrollSecureBootKeys(vmi, nvram, "template")).To(Succeed())
+		Expect(enrollArgs).To(BeNil())
+	})
+
+	It("should fail with more than one platform key", func() {
+		writeCertificates(PKVolumeName, "pk1.pem", "pk2.pem")
+		nvram := filepath.Join(tmpDir, "nvram")
+
+		vmi := newVMI(&v1.SecureBootKeys{PK: &v1.SecureBootKeySource{SecretName: "pk"}})
+		Expect(EnrollSecureBootKeys(vmi, nvram, "template")).To(MatchError("exactly one platform key certificate is required, found 2"))
+	})
+})
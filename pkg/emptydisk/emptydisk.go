@@ -6,6 +6,8 @@ import (
 	"path"
 	"strconv"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	v1 "kubevirt.io/client-go/api/v1"
 	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 )
@@ -17,21 +19,13 @@ func CreateTemporaryDisks(vmi *v1.VirtualMachineInstance) error {
 	for _, volume := range vmi.Spec.Volumes {
 
 		if volume.EmptyDisk != nil {
-			// qemu-img takes the size in bytes or in Kibibytes/Mebibytes/...; lets take bytes
-			size := strconv.FormatInt(volume.EmptyDisk.Capacity.ToDec().ScaledValue(0), 10)
-			file := FilePathForVolumeName(volume.Name)
-			if err := os.MkdirAll(EmptyDiskBaseDir, 0777); err != nil {
-				return err
-			}
-			if _, err := os.Stat(file); os.IsNotExist(err) {
-				// #nosec No risk for attacket injection. Parameters are predefined strings
-				if err := exec.Command("qemu-img", "create", "-f", "qcow2", file, size).Run(); err != nil {
-					return err
-				}
-			} else if err != nil {
+			if err := createTemporaryDisk(volume.Name, volume.EmptyDisk.Capacity); err != nil {
 				return err
 			}
-			if err := ephemeraldiskutils.DefaultOwnershipManager.SetFileOwnership(file); err != nil {
+		}
+		// swap disks are empty disks the guest encrypts and formats on every boot
+		if volume.SwapDisk != nil {
+			if err := createTemporaryDisk(volume.Name, volume.SwapDisk.Capacity); err != nil {
 				return err
 			}
 		}
@@ -40,6 +34,24 @@ func CreateTemporaryDisks(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
 
+func createTemporaryDisk(volumeName string, capacity resource.Quantity) error {
+	// qemu-img takes the size in bytes or in Kibibytes/Mebibytes/...; lets take bytes
+	size := strconv.FormatInt(capacity.ToDec().ScaledValue(0), 10)
+	file := FilePathForVolumeName(volumeName)
+	if err := os.MkdirAll(EmptyDiskBaseDir, 0777); err != nil {
+		return err
+	}
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		// #nosec No risk for attacket injection. Parameters are predefined strings
+		if err := exec.Command("qemu-img", "create", "-f", "qcow2", file, size).Run(); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	return ephemeraldiskutils.DefaultOwnershipManager.SetFileOwnership(file)
+}
+
 func FilePathForVolumeName(volumeName string) string {
 	return path.Join(EmptyDiskBaseDir, volumeName+".qcow2")
 }
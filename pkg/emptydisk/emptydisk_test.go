@@ -61,6 +61,21 @@ var _ = Describe("EmptyDisk", func() {
 			_, err = os.Stat(path.Join(EmptyDiskBaseDir, "testdisk.qcow2"))
 			Expect(err).ToNot(HaveOccurred())
 		})
+		It("should get a new qcow2 image for swap disks", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "swap",
+				VolumeSource: v1.VolumeSource{
+					SwapDisk: &v1.SwapDiskSource{
+						Capacity: resource.MustParse("1Gi"),
+					},
+				},
+			})
+			err := CreateTemporaryDisks(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = os.Stat(path.Join(EmptyDiskBaseDir, "swap.qcow2"))
+			Expect(err).ToNot(HaveOccurred())
+		})
 		It("should generate non-conflicting volume paths per disk", func() {
 			Expect(FilePathForVolumeName("volume1")).ToNot(Equal(FilePathForVolumeName("volume2")))
 		})
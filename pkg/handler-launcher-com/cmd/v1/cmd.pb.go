@@ -20,6 +20,18 @@ It has these top-level messages:
 	GuestInfoResponse
 	GuestUserListResponse
 	GuestFilesystemsResponse
+	SEVInfoResponse
+	LaunchMeasurementResponse
+	InjectLaunchSecretRequest
+	FreezeRequest
+	ExecRequest
+	ExecResponse
+	GuestPingRequest
+	GuestPingResponse
+	BackupRequest
+	MemoryDumpRequest
+	ExternalSnapshotRequest
+	BlockCommitRequest
 */
 package v1
 
@@ -113,6 +125,8 @@ func (m *SMBios) GetFamily() string {
 type VirtualMachineOptions struct {
 	VirtualMachineSMBios  *SMBios `protobuf:"bytes,1,opt,name=VirtualMachineSMBios" json:"VirtualMachineSMBios,omitempty"`
 	MemBalloonStatsPeriod uint32  `protobuf:"varint,2,opt,name=MemBalloonStatsPeriod" json:"MemBalloonStatsPeriod,omitempty"`
+	BalloonTarget         uint64  `protobuf:"varint,3,opt,name=BalloonTarget" json:"BalloonTarget,omitempty"`
+	ExpandDisksEnabled    bool    `protobuf:"varint,4,opt,name=ExpandDisksEnabled" json:"ExpandDisksEnabled,omitempty"`
 }
 
 func (m *VirtualMachineOptions) Reset()                    { *m = VirtualMachineOptions{} }
@@ -134,6 +148,20 @@ func (m *VirtualMachineOptions) GetMemBalloonStatsPeriod() uint32 {
 	return 0
 }
 
+func (m *VirtualMachineOptions) GetBalloonTarget() uint64 {
+	if m != nil {
+		return m.BalloonTarget
+	}
+	return 0
+}
+
+func (m *VirtualMachineOptions) GetExpandDisksEnabled() bool {
+	if m != nil {
+		return m.ExpandDisksEnabled
+	}
+	return false
+}
+
 type VMIRequest struct {
 	Vmi     *VMI                   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
 	Options *VirtualMachineOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
@@ -334,6 +362,310 @@ func (m *GuestFilesystemsResponse) GetGuestFilesystemsResponse() string {
 	return ""
 }
 
+type SEVInfoResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	SevInfo  []byte    `protobuf:"bytes,2,opt,name=sevInfo,proto3" json:"sevInfo,omitempty"`
+}
+
+func (m *SEVInfoResponse) Reset()                    { *m = SEVInfoResponse{} }
+func (m *SEVInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*SEVInfoResponse) ProtoMessage()               {}
+func (*SEVInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *SEVInfoResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SEVInfoResponse) GetSevInfo() []byte {
+	if m != nil {
+		return m.SevInfo
+	}
+	return nil
+}
+
+type LaunchMeasurementResponse struct {
+	Response          *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	LaunchMeasurement []byte    `protobuf:"bytes,2,opt,name=launchMeasurement,proto3" json:"launchMeasurement,omitempty"`
+}
+
+func (m *LaunchMeasurementResponse) Reset()                    { *m = LaunchMeasurementResponse{} }
+func (m *LaunchMeasurementResponse) String() string            { return proto.CompactTextString(m) }
+func (*LaunchMeasurementResponse) ProtoMessage()               {}
+func (*LaunchMeasurementResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *LaunchMeasurementResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *LaunchMeasurementResponse) GetLaunchMeasurement() []byte {
+	if m != nil {
+		return m.LaunchMeasurement
+	}
+	return nil
+}
+
+type InjectLaunchSecretRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *InjectLaunchSecretRequest) Reset()                    { *m = InjectLaunchSecretRequest{} }
+func (m *InjectLaunchSecretRequest) String() string            { return proto.CompactTextString(m) }
+func (*InjectLaunchSecretRequest) ProtoMessage()               {}
+func (*InjectLaunchSecretRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *InjectLaunchSecretRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *InjectLaunchSecretRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type FreezeRequest struct {
+	Vmi                    *VMI  `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	UnfreezeTimeoutSeconds int32 `protobuf:"varint,2,opt,name=unfreezeTimeoutSeconds" json:"unfreezeTimeoutSeconds,omitempty"`
+}
+
+func (m *FreezeRequest) Reset()                    { *m = FreezeRequest{} }
+func (m *FreezeRequest) String() string            { return proto.CompactTextString(m) }
+func (*FreezeRequest) ProtoMessage()               {}
+func (*FreezeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *FreezeRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *FreezeRequest) GetUnfreezeTimeoutSeconds() int32 {
+	if m != nil {
+		return m.UnfreezeTimeoutSeconds
+	}
+	return 0
+}
+
+type ExecRequest struct {
+	DomainName     string   `protobuf:"bytes,1,opt,name=domainName" json:"domainName,omitempty"`
+	Command        string   `protobuf:"bytes,2,opt,name=command" json:"command,omitempty"`
+	Args           []string `protobuf:"bytes,3,rep,name=args" json:"args,omitempty"`
+	TimeoutSeconds int32    `protobuf:"varint,4,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+}
+
+func (m *ExecRequest) Reset()                    { *m = ExecRequest{} }
+func (m *ExecRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExecRequest) ProtoMessage()               {}
+func (*ExecRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *ExecRequest) GetDomainName() string {
+	if m != nil {
+		return m.DomainName
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetTimeoutSeconds() int32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type ExecResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	ExitCode int32     `protobuf:"varint,2,opt,name=exitCode" json:"exitCode,omitempty"`
+	StdOut   string    `protobuf:"bytes,3,opt,name=stdOut" json:"stdOut,omitempty"`
+}
+
+func (m *ExecResponse) Reset()                    { *m = ExecResponse{} }
+func (m *ExecResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExecResponse) ProtoMessage()               {}
+func (*ExecResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ExecResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ExecResponse) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *ExecResponse) GetStdOut() string {
+	if m != nil {
+		return m.StdOut
+	}
+	return ""
+}
+
+type GuestPingRequest struct {
+	DomainName     string `protobuf:"bytes,1,opt,name=domainName" json:"domainName,omitempty"`
+	TimeoutSeconds int32  `protobuf:"varint,2,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+}
+
+func (m *GuestPingRequest) Reset()                    { *m = GuestPingRequest{} }
+func (m *GuestPingRequest) String() string            { return proto.CompactTextString(m) }
+func (*GuestPingRequest) ProtoMessage()               {}
+func (*GuestPingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *GuestPingRequest) GetDomainName() string {
+	if m != nil {
+		return m.DomainName
+	}
+	return ""
+}
+
+func (m *GuestPingRequest) GetTimeoutSeconds() int32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type GuestPingResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *GuestPingResponse) Reset()                    { *m = GuestPingResponse{} }
+func (m *GuestPingResponse) String() string            { return proto.CompactTextString(m) }
+func (*GuestPingResponse) ProtoMessage()               {}
+func (*GuestPingResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *GuestPingResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type BackupRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *BackupRequest) Reset()                    { *m = BackupRequest{} }
+func (m *BackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()               {}
+func (*BackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *BackupRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *BackupRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type MemoryDumpRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *MemoryDumpRequest) Reset()                    { *m = MemoryDumpRequest{} }
+func (m *MemoryDumpRequest) String() string            { return proto.CompactTextString(m) }
+func (*MemoryDumpRequest) ProtoMessage()               {}
+func (*MemoryDumpRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *MemoryDumpRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *MemoryDumpRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type ExternalSnapshotRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *ExternalSnapshotRequest) Reset()                    { *m = ExternalSnapshotRequest{} }
+func (m *ExternalSnapshotRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExternalSnapshotRequest) ProtoMessage()               {}
+func (*ExternalSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ExternalSnapshotRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *ExternalSnapshotRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type BlockCommitRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *BlockCommitRequest) Reset()                    { *m = BlockCommitRequest{} }
+func (m *BlockCommitRequest) String() string            { return proto.CompactTextString(m) }
+func (*BlockCommitRequest) ProtoMessage()               {}
+func (*BlockCommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *BlockCommitRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *BlockCommitRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
 	proto.RegisterType((*SMBios)(nil), "kubevirt.cmd.v1.SMBios")
@@ -347,6 +679,18 @@ func init() {
 	proto.RegisterType((*GuestInfoResponse)(nil), "kubevirt.cmd.v1.GuestInfoResponse")
 	proto.RegisterType((*GuestUserListResponse)(nil), "kubevirt.cmd.v1.GuestUserListResponse")
 	proto.RegisterType((*GuestFilesystemsResponse)(nil), "kubevirt.cmd.v1.GuestFilesystemsResponse")
+	proto.RegisterType((*SEVInfoResponse)(nil), "kubevirt.cmd.v1.SEVInfoResponse")
+	proto.RegisterType((*LaunchMeasurementResponse)(nil), "kubevirt.cmd.v1.LaunchMeasurementResponse")
+	proto.RegisterType((*InjectLaunchSecretRequest)(nil), "kubevirt.cmd.v1.InjectLaunchSecretRequest")
+	proto.RegisterType((*FreezeRequest)(nil), "kubevirt.cmd.v1.FreezeRequest")
+	proto.RegisterType((*ExecRequest)(nil), "kubevirt.cmd.v1.ExecRequest")
+	proto.RegisterType((*ExecResponse)(nil), "kubevirt.cmd.v1.ExecResponse")
+	proto.RegisterType((*GuestPingRequest)(nil), "kubevirt.cmd.v1.GuestPingRequest")
+	proto.RegisterType((*GuestPingResponse)(nil), "kubevirt.cmd.v1.GuestPingResponse")
+	proto.RegisterType((*BackupRequest)(nil), "kubevirt.cmd.v1.BackupRequest")
+	proto.RegisterType((*MemoryDumpRequest)(nil), "kubevirt.cmd.v1.MemoryDumpRequest")
+	proto.RegisterType((*ExternalSnapshotRequest)(nil), "kubevirt.cmd.v1.ExternalSnapshotRequest")
+	proto.RegisterType((*BlockCommitRequest)(nil), "kubevirt.cmd.v1.BlockCommitRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -376,6 +720,21 @@ type CmdClient interface {
 	GetUsers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestUserListResponse, error)
 	GetFilesystems(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestFilesystemsResponse, error)
 	Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
+	GetSEVInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SEVInfoResponse, error)
+	GetLaunchMeasurement(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*LaunchMeasurementResponse, error)
+	InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecretRequest, opts ...grpc.CallOption) (*Response, error)
+	FreezeVirtualMachine(ctx context.Context, in *FreezeRequest, opts ...grpc.CallOption) (*Response, error)
+	UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	SyncVirtualMachineCPUs(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	SyncVirtualMachineMemory(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	SetVirtualMachineMemoryBalloon(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
+	GuestPing(ctx context.Context, in *GuestPingRequest, opts ...grpc.CallOption) (*GuestPingResponse, error)
+	StartVirtualMachineBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error)
+	StopVirtualMachineBackup(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	VirtualMachineMemoryDump(ctx context.Context, in *MemoryDumpRequest, opts ...grpc.CallOption) (*Response, error)
+	VirtualMachineExternalSnapshot(ctx context.Context, in *ExternalSnapshotRequest, opts ...grpc.CallOption) (*Response, error)
+	VirtualMachineBlockCommit(ctx context.Context, in *BlockCommitRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type cmdClient struct {
@@ -467,63 +826,198 @@ func (c *cmdClient) CancelVirtualMachineMigration(ctx context.Context, in *VMIRe
 	return out, nil
 }
 
-func (c *cmdClient) SetVirtualMachineGuestTime(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
-	out := new(Response)
-	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SetVirtualMachineGuestTime", in, out, c.cc, opts...)
+func (c *cmdClient) SetVirtualMachineGuestTime(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SetVirtualMachineGuestTime", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetDomain(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
+	out := new(DomainResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetDomain", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetDomainStats(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainStatsResponse, error) {
+	out := new(DomainStatsResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetDomainStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetGuestInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestInfoResponse, error) {
+	out := new(GuestInfoResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetGuestInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetUsers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestUserListResponse, error) {
+	out := new(GuestUserListResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetUsers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetFilesystems(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestFilesystemsResponse, error) {
+	out := new(GuestFilesystemsResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetFilesystems", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/Ping", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetSEVInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SEVInfoResponse, error) {
+	out := new(SEVInfoResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetSEVInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetLaunchMeasurement(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*LaunchMeasurementResponse, error) {
+	out := new(LaunchMeasurementResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetLaunchMeasurement", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecretRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/InjectLaunchSecret", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) FreezeVirtualMachine(ctx context.Context, in *FreezeRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/FreezeVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/UnfreezeVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) SyncVirtualMachineCPUs(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SyncVirtualMachineCPUs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) SyncVirtualMachineMemory(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SyncVirtualMachineMemory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) SetVirtualMachineMemoryBalloon(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SetVirtualMachineMemoryBalloon", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error) {
+	out := new(ExecResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/Exec", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cmdClient) GetDomain(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
-	out := new(DomainResponse)
-	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetDomain", in, out, c.cc, opts...)
+func (c *cmdClient) GuestPing(ctx context.Context, in *GuestPingRequest, opts ...grpc.CallOption) (*GuestPingResponse, error) {
+	out := new(GuestPingResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GuestPing", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cmdClient) GetDomainStats(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainStatsResponse, error) {
-	out := new(DomainStatsResponse)
-	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetDomainStats", in, out, c.cc, opts...)
+func (c *cmdClient) StartVirtualMachineBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/StartVirtualMachineBackup", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cmdClient) GetGuestInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestInfoResponse, error) {
-	out := new(GuestInfoResponse)
-	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetGuestInfo", in, out, c.cc, opts...)
+func (c *cmdClient) StopVirtualMachineBackup(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/StopVirtualMachineBackup", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cmdClient) GetUsers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestUserListResponse, error) {
-	out := new(GuestUserListResponse)
-	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetUsers", in, out, c.cc, opts...)
+func (c *cmdClient) VirtualMachineMemoryDump(ctx context.Context, in *MemoryDumpRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/VirtualMachineMemoryDump", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cmdClient) GetFilesystems(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestFilesystemsResponse, error) {
-	out := new(GuestFilesystemsResponse)
-	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetFilesystems", in, out, c.cc, opts...)
+func (c *cmdClient) VirtualMachineExternalSnapshot(ctx context.Context, in *ExternalSnapshotRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/VirtualMachineExternalSnapshot", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cmdClient) Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *cmdClient) VirtualMachineBlockCommit(ctx context.Context, in *BlockCommitRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
-	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/Ping", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/VirtualMachineBlockCommit", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -549,6 +1043,21 @@ type CmdServer interface {
 	GetUsers(context.Context, *EmptyRequest) (*GuestUserListResponse, error)
 	GetFilesystems(context.Context, *EmptyRequest) (*GuestFilesystemsResponse, error)
 	Ping(context.Context, *EmptyRequest) (*Response, error)
+	GetSEVInfo(context.Context, *EmptyRequest) (*SEVInfoResponse, error)
+	GetLaunchMeasurement(context.Context, *VMIRequest) (*LaunchMeasurementResponse, error)
+	InjectLaunchSecret(context.Context, *InjectLaunchSecretRequest) (*Response, error)
+	FreezeVirtualMachine(context.Context, *FreezeRequest) (*Response, error)
+	UnfreezeVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	SyncVirtualMachineCPUs(context.Context, *VMIRequest) (*Response, error)
+	SyncVirtualMachineMemory(context.Context, *VMIRequest) (*Response, error)
+	SetVirtualMachineMemoryBalloon(context.Context, *VMIRequest) (*Response, error)
+	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
+	GuestPing(context.Context, *GuestPingRequest) (*GuestPingResponse, error)
+	StartVirtualMachineBackup(context.Context, *BackupRequest) (*Response, error)
+	StopVirtualMachineBackup(context.Context, *VMIRequest) (*Response, error)
+	VirtualMachineMemoryDump(context.Context, *MemoryDumpRequest) (*Response, error)
+	VirtualMachineExternalSnapshot(context.Context, *ExternalSnapshotRequest) (*Response, error)
+	VirtualMachineBlockCommit(context.Context, *BlockCommitRequest) (*Response, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -843,6 +1352,276 @@ func _Cmd_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_GetSEVInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetSEVInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetSEVInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetSEVInfo(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GetLaunchMeasurement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetLaunchMeasurement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetLaunchMeasurement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetLaunchMeasurement(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_InjectLaunchSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectLaunchSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).InjectLaunchSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/InjectLaunchSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).InjectLaunchSecret(ctx, req.(*InjectLaunchSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_FreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).FreezeVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/FreezeVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).FreezeVirtualMachine(ctx, req.(*FreezeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_UnfreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).UnfreezeVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/UnfreezeVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).UnfreezeVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_SyncVirtualMachineCPUs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).SyncVirtualMachineCPUs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/SyncVirtualMachineCPUs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).SyncVirtualMachineCPUs(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_SyncVirtualMachineMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).SyncVirtualMachineMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/SyncVirtualMachineMemory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).SyncVirtualMachineMemory(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_SetVirtualMachineMemoryBalloon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).SetVirtualMachineMemoryBalloon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/SetVirtualMachineMemoryBalloon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).SetVirtualMachineMemoryBalloon(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/Exec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).Exec(ctx, req.(*ExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GuestPing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GuestPingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GuestPing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GuestPing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GuestPing(ctx, req.(*GuestPingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_StartVirtualMachineBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).StartVirtualMachineBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/StartVirtualMachineBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).StartVirtualMachineBackup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_StopVirtualMachineBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).StopVirtualMachineBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/StopVirtualMachineBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).StopVirtualMachineBackup(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_VirtualMachineMemoryDump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryDumpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).VirtualMachineMemoryDump(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/VirtualMachineMemoryDump",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).VirtualMachineMemoryDump(ctx, req.(*MemoryDumpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_VirtualMachineExternalSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).VirtualMachineExternalSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/VirtualMachineExternalSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).VirtualMachineExternalSnapshot(ctx, req.(*ExternalSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_VirtualMachineBlockCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).VirtualMachineBlockCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/VirtualMachineBlockCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).VirtualMachineBlockCommit(ctx, req.(*BlockCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -911,6 +1690,66 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _Cmd_Ping_Handler,
 		},
+		{
+			MethodName: "GetSEVInfo",
+			Handler:    _Cmd_GetSEVInfo_Handler,
+		},
+		{
+			MethodName: "GetLaunchMeasurement",
+			Handler:    _Cmd_GetLaunchMeasurement_Handler,
+		},
+		{
+			MethodName: "InjectLaunchSecret",
+			Handler:    _Cmd_InjectLaunchSecret_Handler,
+		},
+		{
+			MethodName: "FreezeVirtualMachine",
+			Handler:    _Cmd_FreezeVirtualMachine_Handler,
+		},
+		{
+			MethodName: "UnfreezeVirtualMachine",
+			Handler:    _Cmd_UnfreezeVirtualMachine_Handler,
+		},
+		{
+			MethodName: "SyncVirtualMachineCPUs",
+			Handler:    _Cmd_SyncVirtualMachineCPUs_Handler,
+		},
+		{
+			MethodName: "SyncVirtualMachineMemory",
+			Handler:    _Cmd_SyncVirtualMachineMemory_Handler,
+		},
+		{
+			MethodName: "SetVirtualMachineMemoryBalloon",
+			Handler:    _Cmd_SetVirtualMachineMemoryBalloon_Handler,
+		},
+		{
+			MethodName: "Exec",
+			Handler:    _Cmd_Exec_Handler,
+		},
+		{
+			MethodName: "GuestPing",
+			Handler:    _Cmd_GuestPing_Handler,
+		},
+		{
+			MethodName: "StartVirtualMachineBackup",
+			Handler:    _Cmd_StartVirtualMachineBackup_Handler,
+		},
+		{
+			MethodName: "StopVirtualMachineBackup",
+			Handler:    _Cmd_StopVirtualMachineBackup_Handler,
+		},
+		{
+			MethodName: "VirtualMachineMemoryDump",
+			Handler:    _Cmd_VirtualMachineMemoryDump_Handler,
+		},
+		{
+			MethodName: "VirtualMachineExternalSnapshot",
+			Handler:    _Cmd_VirtualMachineExternalSnapshot_Handler,
+		},
+		{
+			MethodName: "VirtualMachineBlockCommit",
+			Handler:    _Cmd_VirtualMachineBlockCommit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -919,52 +1758,83 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 738 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x96, 0x61, 0x4f, 0xd3, 0x40,
-	0x18, 0xc7, 0x37, 0x86, 0x30, 0x1e, 0x26, 0xc2, 0xc1, 0xb0, 0x62, 0x08, 0x78, 0x31, 0x44, 0x12,
-	0x19, 0x01, 0xf1, 0x8d, 0x2f, 0x8c, 0x19, 0xe8, 0x82, 0x38, 0x98, 0x1d, 0xcc, 0x68, 0x4c, 0xcc,
-	0xd1, 0x1e, 0xdd, 0x85, 0xf6, 0x6e, 0xf6, 0xae, 0xd3, 0xbd, 0xf7, 0x95, 0x89, 0x5f, 0xc0, 0x4f,
-	0xe3, 0x47, 0x33, 0xbd, 0x76, 0x83, 0xad, 0x9d, 0x8b, 0xd9, 0x5e, 0xd1, 0xe7, 0x9e, 0xe7, 0x7e,
-	0xff, 0xe7, 0x9e, 0x6b, 0xff, 0x0c, 0xb6, 0x5b, 0xd7, 0xce, 0x6e, 0x93, 0x70, 0xdb, 0xa5, 0xfe,
-	0x8e, 0x4b, 0x02, 0x6e, 0x35, 0xa9, 0xbf, 0x63, 0x09, 0x6f, 0xd7, 0xf2, 0xec, 0xdd, 0xf6, 0x5e,
-	0xf8, 0xa7, 0xd4, 0xf2, 0x85, 0x12, 0xe8, 0xde, 0x75, 0x70, 0x49, 0xdb, 0xcc, 0x57, 0xa5, 0x70,
-	0xad, 0xbd, 0x87, 0x37, 0x20, 0xd7, 0xa8, 0x1e, 0x23, 0x03, 0x66, 0xdb, 0x1e, 0x7b, 0x2b, 0x05,
-	0x37, 0xb2, 0x9b, 0xd9, 0x27, 0x05, 0xb3, 0x1b, 0xe2, 0x9f, 0x59, 0x98, 0xa9, 0x57, 0xcb, 0x4c,
-	0x48, 0x84, 0xa1, 0xe0, 0x11, 0x1e, 0x5c, 0x11, 0x4b, 0x05, 0x3e, 0xf5, 0x75, 0xe5, 0x9c, 0xd9,
-	0xb7, 0x16, 0x82, 0x5a, 0xbe, 0xb0, 0x03, 0x4b, 0x19, 0x53, 0x3a, 0xdd, 0x0d, 0xb5, 0x04, 0xf5,
-	0x25, 0x13, 0xdc, 0xc8, 0x45, 0x99, 0x38, 0x44, 0x8b, 0x90, 0x93, 0xd7, 0x81, 0x31, 0xad, 0x57,
-	0xc3, 0x47, 0xb4, 0x0a, 0x33, 0x57, 0xc4, 0x63, 0x6e, 0xc7, 0xb8, 0xa3, 0x17, 0xe3, 0x08, 0xff,
-	0xce, 0x42, 0xb1, 0xc1, 0x7c, 0x15, 0x10, 0xb7, 0x4a, 0xac, 0x26, 0xe3, 0xf4, 0xac, 0xa5, 0x98,
-	0xe0, 0x12, 0x9d, 0xc0, 0x4a, 0x7f, 0x22, 0xea, 0x59, 0xf7, 0x38, 0xbf, 0x7f, 0xbf, 0x34, 0x70,
-	0xee, 0x52, 0x94, 0x36, 0x53, 0x37, 0xa1, 0x03, 0x28, 0x56, 0xa9, 0x57, 0x26, 0xae, 0x2b, 0x04,
-	0xaf, 0x2b, 0xa2, 0x64, 0x8d, 0xfa, 0x4c, 0xd8, 0xfa, 0x48, 0x77, 0xcd, 0xf4, 0x24, 0x6e, 0x03,
-	0x34, 0xaa, 0xc7, 0x26, 0xfd, 0x1a, 0x50, 0xa9, 0xd0, 0x16, 0xe4, 0xda, 0x1e, 0x8b, 0xf5, 0x57,
-	0x12, 0xfa, 0x61, 0x65, 0x58, 0x80, 0x5e, 0xc1, 0xac, 0x88, 0xce, 0xa0, 0xe9, 0xf3, 0xfb, 0x5b,
-	0xc9, 0xda, 0xb4, 0x13, 0x9b, 0xdd, 0x6d, 0xf8, 0x1c, 0x16, 0xab, 0xcc, 0xf1, 0x49, 0x18, 0xfd,
-	0xaf, 0xba, 0xd1, 0xaf, 0x5e, 0xb8, 0xa1, 0x2e, 0x40, 0xe1, 0xb5, 0xd7, 0x52, 0x9d, 0x98, 0x88,
-	0x5f, 0x42, 0xde, 0xa4, 0xb2, 0x25, 0xb8, 0xa4, 0xe1, 0x2e, 0x19, 0x58, 0x16, 0x95, 0xd1, 0x7c,
-	0xf3, 0x66, 0x37, 0x0c, 0x33, 0x1e, 0x95, 0x92, 0x38, 0xb4, 0x7b, 0xfd, 0x71, 0x88, 0xbf, 0xc0,
-	0xc2, 0x91, 0xf0, 0x08, 0xe3, 0x3d, 0xca, 0x73, 0xc8, 0xfb, 0xf1, 0x73, 0xdc, 0xe8, 0x83, 0x44,
-	0xa3, 0xdd, 0x62, 0xb3, 0x57, 0x1a, 0xbe, 0x1b, 0xb6, 0x06, 0xc5, 0x0a, 0x71, 0x84, 0x39, 0x2c,
-	0x47, 0x02, 0xfa, 0x4e, 0xc6, 0x55, 0xd9, 0x84, 0x79, 0xfb, 0x86, 0x16, 0x4b, 0xdd, 0x5e, 0xc2,
-	0xdf, 0x61, 0xa9, 0x12, 0x4e, 0xe6, 0x98, 0x5f, 0x89, 0x71, 0xd5, 0x9e, 0xc2, 0x92, 0x33, 0xc8,
-	0x8a, 0x35, 0x93, 0x09, 0xfc, 0x23, 0x0b, 0x45, 0x2d, 0x7d, 0x21, 0xa9, 0xff, 0x8e, 0x49, 0x35,
-	0xae, 0xfc, 0x01, 0x14, 0x9d, 0x34, 0x5e, 0xdc, 0x42, 0x7a, 0x12, 0xff, 0xca, 0x82, 0xa1, 0xdb,
-	0x78, 0xc3, 0x5c, 0x2a, 0x3b, 0x52, 0x51, 0x6f, 0xec, 0xb1, 0xbf, 0x00, 0xc3, 0x19, 0x82, 0x8c,
-	0x9b, 0x19, 0x9a, 0xdf, 0xff, 0x03, 0x90, 0x3b, 0xf4, 0x6c, 0x74, 0x0a, 0xa8, 0xde, 0xe1, 0x56,
-	0xff, 0x57, 0x83, 0x1e, 0xa6, 0x7e, 0x04, 0xd1, 0xcb, 0xbd, 0x36, 0xbc, 0x37, 0x9c, 0x41, 0x67,
-	0xb0, 0x5c, 0x23, 0x81, 0xa4, 0x13, 0x03, 0xbe, 0x87, 0xe2, 0x05, 0x6f, 0x4d, 0x14, 0x69, 0xc2,
-	0x6a, 0xbd, 0x19, 0x28, 0x5b, 0x7c, 0xe3, 0x13, 0x63, 0x9e, 0x02, 0x3a, 0x61, 0xae, 0x3b, 0x31,
-	0x5e, 0x0d, 0x56, 0x8e, 0xa8, 0x4b, 0xd5, 0xe4, 0x4e, 0xfd, 0x01, 0x8a, 0x91, 0xf3, 0x0d, 0x22,
-	0x1f, 0x25, 0x76, 0x0d, 0x3a, 0xe4, 0xc8, 0x2b, 0x0f, 0x5f, 0xa1, 0xde, 0xa6, 0x73, 0xe2, 0x3b,
-	0x54, 0x8d, 0xd1, 0xe9, 0x47, 0x58, 0x3f, 0x24, 0xdc, 0xa2, 0x03, 0xd3, 0xec, 0x09, 0x8c, 0x81,
-	0x6e, 0xc0, 0x5a, 0x9d, 0xaa, 0x7e, 0xae, 0xfe, 0x2c, 0xcf, 0x99, 0x37, 0xce, 0x70, 0xab, 0x30,
-	0x57, 0xa1, 0x2a, 0xb2, 0x54, 0xb4, 0x9e, 0xa8, 0xbc, 0xfd, 0xcf, 0x61, 0x6d, 0x23, 0x91, 0xee,
-	0xf7, 0x7a, 0x7d, 0x57, 0x0b, 0x3d, 0x9c, 0x36, 0xd0, 0x51, 0xcc, 0xc7, 0x43, 0x98, 0x7d, 0xf6,
-	0x8e, 0x33, 0xa8, 0x0e, 0x85, 0x0a, 0x55, 0x3d, 0x2b, 0x1e, 0x85, 0xc5, 0x89, 0x74, 0xc2, 0xc5,
-	0x35, 0x34, 0x5f, 0xa1, 0xda, 0xf2, 0x46, 0xf6, 0xb9, 0x95, 0x0e, 0x4c, 0xd8, 0x65, 0x06, 0x7d,
-	0xd6, 0x23, 0xb8, 0x65, 0x5d, 0xa3, 0xd0, 0xdb, 0xe9, 0xe8, 0x14, 0xf3, 0xc3, 0x19, 0x54, 0x86,
-	0xe9, 0x1a, 0xe3, 0xce, 0x28, 0xe6, 0xbf, 0xee, 0xbc, 0x3c, 0xfd, 0x69, 0xaa, 0xbd, 0x77, 0x39,
-	0xa3, 0x7f, 0x2b, 0x3e, 0xfb, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x5c, 0xa3, 0x1e, 0x96, 0x58, 0x0a,
-	0x00, 0x00,
+	// 1235 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x98, 0x5b, 0x53, 0x1b, 0x37,
+	0x14, 0xc7, 0x31, 0x76, 0x88, 0x39, 0x5c, 0x02, 0x8a, 0x21, 0x86, 0x16, 0x42, 0xb7, 0x19, 0x26,
+	0xe9, 0xb4, 0x66, 0xa0, 0x97, 0x87, 0x3e, 0x74, 0x3a, 0x80, 0x93, 0x21, 0x89, 0x03, 0x59, 0x03,
+	0x6d, 0x49, 0x33, 0x9d, 0x65, 0x57, 0xd8, 0x5b, 0xef, 0xc5, 0x5d, 0x69, 0x5d, 0xdc, 0xa7, 0xce,
+	0xb4, 0x4f, 0x9d, 0xe9, 0x77, 0xed, 0x43, 0x3f, 0x40, 0x25, 0xad, 0x6c, 0xbc, 0xab, 0x35, 0x6e,
+	0xb2, 0xfb, 0x64, 0x4b, 0x47, 0xfa, 0x9d, 0x73, 0x74, 0x39, 0xfa, 0xdb, 0xf0, 0xa4, 0xdb, 0x69,
+	0xed, 0xb4, 0x0d, 0xcf, 0x72, 0x70, 0xf0, 0x99, 0x63, 0x84, 0x9e, 0xd9, 0x66, 0x5f, 0x4c, 0xdf,
+	0xdd, 0x31, 0x5d, 0x6b, 0xa7, 0xb7, 0xcb, 0x3f, 0x6a, 0xdd, 0xc0, 0xa7, 0x3e, 0xba, 0xd7, 0x09,
+	0x2f, 0x71, 0xcf, 0x0e, 0x68, 0x8d, 0xf7, 0xf5, 0x76, 0xb5, 0x87, 0x50, 0x3c, 0x6f, 0x1c, 0xa1,
+	0x2a, 0xdc, 0xed, 0xb9, 0xf6, 0x73, 0xe2, 0x7b, 0xd5, 0xc2, 0x56, 0xe1, 0xf1, 0xbc, 0x3e, 0x68,
+	0x6a, 0x7f, 0x15, 0x60, 0xa6, 0xd9, 0xd8, 0xb7, 0x7d, 0x82, 0x34, 0x98, 0x77, 0x0d, 0x2f, 0xbc,
+	0x32, 0x4c, 0x1a, 0x06, 0x38, 0x10, 0x23, 0x67, 0xf5, 0x58, 0x1f, 0x07, 0x31, 0x4f, 0x56, 0x68,
+	0xd2, 0xea, 0xb4, 0x30, 0x0f, 0x9a, 0xc2, 0x05, 0x0e, 0x88, 0xcd, 0x5c, 0x14, 0x23, 0x8b, 0x6c,
+	0xa2, 0x25, 0x28, 0x92, 0x4e, 0x58, 0x2d, 0x89, 0x5e, 0xfe, 0x15, 0xad, 0xc2, 0xcc, 0x95, 0xe1,
+	0xda, 0x4e, 0xbf, 0x7a, 0x47, 0x74, 0xca, 0x96, 0xf6, 0x4f, 0x01, 0x56, 0xce, 0x59, 0xf4, 0xa1,
+	0xe1, 0x34, 0x0c, 0xb3, 0x6d, 0x7b, 0xf8, 0xb8, 0x4b, 0x19, 0x82, 0xa0, 0x17, 0x50, 0x89, 0x1b,
+	0xa2, 0x98, 0x45, 0x8c, 0x73, 0x7b, 0x0f, 0x6a, 0x89, 0xbc, 0x6b, 0x91, 0x59, 0x4f, 0x9d, 0x84,
+	0xbe, 0x80, 0x95, 0x06, 0x76, 0xf7, 0x0d, 0xc7, 0xf1, 0x7d, 0xaf, 0x49, 0x0d, 0x4a, 0x4e, 0x70,
+	0x60, 0xfb, 0x96, 0x48, 0x69, 0x41, 0x4f, 0x37, 0xa2, 0x47, 0xb0, 0x20, 0x7b, 0x4f, 0x8d, 0xa0,
+	0x85, 0xa9, 0x48, 0xb3, 0xa4, 0xc7, 0x3b, 0x51, 0x0d, 0x50, 0xfd, 0xba, 0xcb, 0x36, 0xeb, 0xd0,
+	0x26, 0x1d, 0x52, 0xf7, 0x8c, 0x4b, 0x07, 0x5b, 0x22, 0xf7, 0xb2, 0x9e, 0x62, 0xd1, 0x7a, 0x00,
+	0x6c, 0x83, 0x74, 0xfc, 0x4b, 0x88, 0x09, 0x45, 0xdb, 0x50, 0x64, 0x1b, 0x23, 0xb3, 0xaa, 0x28,
+	0x59, 0xf1, 0x91, 0x7c, 0x00, 0xfa, 0x16, 0xee, 0xfa, 0xd1, 0xca, 0x88, 0x98, 0xe7, 0xf6, 0xb6,
+	0xd5, 0xb1, 0x69, 0xeb, 0xa8, 0x0f, 0xa6, 0x69, 0xa7, 0xb0, 0xd4, 0xb0, 0x5b, 0x81, 0xc1, 0x5b,
+	0xef, 0xea, 0xbd, 0x1a, 0xf7, 0x3e, 0x7f, 0x43, 0x5d, 0x84, 0xf9, 0xba, 0xdb, 0xa5, 0x7d, 0x49,
+	0xd4, 0xbe, 0x81, 0xb2, 0x8e, 0x49, 0x97, 0x99, 0x30, 0x9f, 0x45, 0x42, 0xd3, 0xc4, 0x24, 0xda,
+	0xb5, 0xb2, 0x3e, 0x68, 0x72, 0x8b, 0xcb, 0x3e, 0x8d, 0x16, 0x1e, 0x1c, 0x2a, 0xd9, 0xd4, 0x7e,
+	0x82, 0xc5, 0x43, 0xdf, 0x35, 0x6c, 0x6f, 0x48, 0xf9, 0x12, 0xca, 0x81, 0xfc, 0x2e, 0x03, 0x5d,
+	0x53, 0x02, 0x1d, 0x0c, 0xd6, 0x87, 0x43, 0xf9, 0x89, 0xb3, 0x04, 0x48, 0x7a, 0x90, 0x2d, 0xcd,
+	0x83, 0xfb, 0x91, 0x03, 0xb1, 0xd3, 0x59, 0xbd, 0x6c, 0xc1, 0x9c, 0x75, 0x43, 0x93, 0xae, 0x46,
+	0xbb, 0xb4, 0x6b, 0x58, 0x7e, 0xc6, 0x57, 0xe6, 0xc8, 0xbb, 0xf2, 0xb3, 0x7a, 0xfb, 0x14, 0x96,
+	0x5b, 0x49, 0x96, 0xf4, 0xa9, 0x1a, 0xb4, 0x3f, 0xd9, 0xdd, 0x12, 0xae, 0xcf, 0x08, 0x0e, 0x5e,
+	0xda, 0x84, 0x66, 0x75, 0xcf, 0x6e, 0x51, 0x2b, 0x8d, 0x27, 0x43, 0x48, 0x37, 0x6a, 0x7f, 0x17,
+	0xa0, 0x2a, 0xc2, 0x78, 0x6a, 0x3b, 0x98, 0xf4, 0x09, 0xc5, 0x6e, 0xe6, 0x65, 0xff, 0x1a, 0xaa,
+	0xad, 0x31, 0x48, 0x19, 0xcc, 0x58, 0xbb, 0x76, 0x09, 0xf7, 0x9a, 0xf5, 0xf3, 0x3c, 0xb6, 0x83,
+	0x9f, 0x6f, 0xdc, 0xe3, 0xa4, 0xc1, 0xad, 0x90, 0x4d, 0xed, 0xf7, 0x02, 0xac, 0xbd, 0x14, 0x75,
+	0xbb, 0x81, 0x0d, 0xc2, 0xea, 0xa8, 0x8b, 0x3d, 0x9a, 0xc3, 0xee, 0x3b, 0x49, 0xa6, 0x74, 0xac,
+	0x1a, 0xb4, 0xb7, 0xb0, 0x76, 0xe4, 0xfd, 0x8c, 0x4d, 0x1a, 0xc5, 0xd1, 0xc4, 0x66, 0x80, 0x69,
+	0x7e, 0xf7, 0xde, 0x87, 0x85, 0xa7, 0x01, 0xc6, 0xbf, 0xe1, 0x77, 0x45, 0x7e, 0x05, 0xab, 0xa1,
+	0x77, 0x25, 0xa6, 0x9e, 0xda, 0x2e, 0xf6, 0x43, 0xca, 0x42, 0xf3, 0x3d, 0x2b, 0xf2, 0x70, 0x47,
+	0x1f, 0x63, 0xd5, 0xfe, 0x28, 0xc0, 0x5c, 0xfd, 0x1a, 0x9b, 0x03, 0x7f, 0x9b, 0x00, 0xd1, 0x35,
+	0x7b, 0x65, 0xb8, 0x58, 0xbe, 0x5c, 0x23, 0x3d, 0x3c, 0x74, 0xf6, 0x60, 0xb2, 0xa7, 0xcc, 0x1a,
+	0x94, 0x18, 0xd9, 0x44, 0x08, 0x4a, 0xac, 0x72, 0x13, 0x56, 0xcd, 0x8b, 0xac, 0x5b, 0x7c, 0x67,
+	0xd1, 0x2f, 0xd2, 0x78, 0x34, 0x25, 0x11, 0x4d, 0xa2, 0x57, 0xeb, 0xb3, 0x72, 0x27, 0x82, 0xc8,
+	0xb6, 0x95, 0xeb, 0x50, 0xc6, 0xd7, 0x36, 0x3d, 0xf0, 0x2d, 0x2c, 0xd3, 0x1e, 0xb6, 0x79, 0xe1,
+	0x22, 0xd4, 0x3a, 0x0e, 0xa9, 0x7c, 0x55, 0x65, 0x4b, 0xbb, 0x80, 0x25, 0x71, 0x8d, 0x4e, 0x6c,
+	0xaf, 0xf5, 0x7f, 0x17, 0x41, 0x4d, 0x6b, 0x3a, 0x35, 0xad, 0xe7, 0xb2, 0x48, 0x45, 0xec, 0x4c,
+	0xb9, 0x69, 0xaf, 0xf9, 0xab, 0x69, 0x76, 0xc2, 0x6e, 0x7e, 0x87, 0xed, 0x0c, 0x96, 0xd9, 0x0b,
+	0xed, 0x07, 0xfd, 0xc3, 0xd0, 0xcd, 0x11, 0xfb, 0x06, 0x1e, 0xd4, 0xaf, 0x29, 0x0e, 0x3c, 0xc3,
+	0x69, 0x7a, 0x46, 0x97, 0xb4, 0xfd, 0x1c, 0x2f, 0xc8, 0x39, 0xa0, 0x7d, 0xc7, 0x37, 0x3b, 0x07,
+	0xec, 0xd4, 0xd9, 0xf9, 0x71, 0xf7, 0xfe, 0xad, 0x40, 0xf1, 0xc0, 0xb5, 0xd0, 0x2b, 0x40, 0xcd,
+	0xbe, 0x67, 0xc6, 0x1f, 0x7d, 0xf4, 0x41, 0x2a, 0x32, 0x72, 0xbe, 0x3e, 0x7e, 0xfb, 0xb4, 0x29,
+	0x74, 0x0c, 0xf7, 0x4f, 0x8c, 0x90, 0xe0, 0xdc, 0x80, 0xaf, 0x61, 0xe5, 0xcc, 0xeb, 0xe6, 0x8a,
+	0xd4, 0x61, 0xb5, 0xd9, 0x0e, 0xa9, 0xe5, 0xff, 0xea, 0xe5, 0xc6, 0x64, 0xeb, 0xf8, 0xc2, 0x76,
+	0x9c, 0xdc, 0x78, 0x27, 0x50, 0x39, 0xc4, 0x0e, 0xa6, 0xf9, 0x65, 0xfd, 0x1d, 0x13, 0xaf, 0x42,
+	0xb8, 0x25, 0x91, 0x1f, 0x29, 0xb3, 0x92, 0x02, 0x6f, 0xe2, 0x96, 0xf3, 0x23, 0x34, 0x9c, 0x24,
+	0x05, 0xed, 0xfb, 0x47, 0xfa, 0x03, 0x6c, 0x1c, 0x18, 0x9e, 0x89, 0x13, 0xab, 0x39, 0x74, 0x90,
+	0x01, 0x7d, 0x0e, 0xeb, 0x4d, 0x4c, 0xe3, 0x5c, 0x51, 0xb2, 0xf8, 0x33, 0x91, 0x81, 0xdb, 0x80,
+	0xd9, 0x67, 0x98, 0x46, 0x8a, 0x10, 0x6d, 0x28, 0x23, 0x47, 0xb5, 0xed, 0xfa, 0x43, 0xc5, 0x1c,
+	0x97, 0xaa, 0x62, 0xaf, 0x16, 0x87, 0x38, 0xa1, 0xff, 0x26, 0x31, 0x1f, 0x8d, 0x61, 0xc6, 0xd4,
+	0x29, 0x03, 0x37, 0x61, 0x9e, 0x81, 0x87, 0x4a, 0x72, 0x12, 0x56, 0x53, 0xcc, 0x8a, 0x08, 0x15,
+	0xd0, 0x32, 0x83, 0x72, 0xc5, 0x36, 0x31, 0xce, 0xed, 0x74, 0xa0, 0xa2, 0xf6, 0xa6, 0xd0, 0x8f,
+	0x62, 0x09, 0x46, 0x94, 0xd7, 0x24, 0xf4, 0x93, 0x74, 0x74, 0x9a, 0x76, 0x9b, 0x42, 0xfb, 0x50,
+	0xe2, 0x8f, 0xd4, 0x24, 0xe6, 0x84, 0x73, 0x0f, 0x2c, 0x42, 0x29, 0x02, 0x27, 0x91, 0xb6, 0xd4,
+	0x5f, 0x9a, 0x71, 0xf5, 0xc8, 0x80, 0x06, 0x54, 0x18, 0x50, 0x11, 0x7c, 0xb7, 0x1f, 0xcb, 0x4f,
+	0x14, 0xe3, 0x58, 0xc5, 0xc8, 0x5c, 0xbc, 0x05, 0xa4, 0xca, 0x39, 0xa4, 0x32, 0xc6, 0x6a, 0xbe,
+	0xdb, 0x97, 0xa4, 0x09, 0x95, 0x48, 0xce, 0x25, 0x4a, 0xcc, 0xa6, 0x32, 0x29, 0xa6, 0xfa, 0x26,
+	0x96, 0xeb, 0x33, 0x29, 0xe6, 0x72, 0x7d, 0x02, 0x94, 0x67, 0xef, 0xe0, 0xe4, 0x8c, 0x64, 0x60,
+	0x9e, 0x42, 0x55, 0x65, 0x46, 0x82, 0x23, 0x03, 0xf5, 0x02, 0x36, 0x95, 0x8a, 0x15, 0x41, 0xe5,
+	0xff, 0x07, 0x19, 0xd8, 0x75, 0x28, 0x71, 0x19, 0x8a, 0x3e, 0x54, 0xcf, 0xee, 0x8d, 0x44, 0x5e,
+	0xdf, 0x18, 0x63, 0x1d, 0x49, 0x7c, 0x76, 0x28, 0xfb, 0x52, 0x5e, 0x93, 0xa4, 0xdc, 0x1c, 0x57,
+	0x55, 0x46, 0x55, 0x23, 0xa3, 0x7e, 0x0f, 0x6b, 0xac, 0x7a, 0x05, 0x89, 0xd4, 0x23, 0x4d, 0x98,
+	0x72, 0xa0, 0x62, 0x62, 0x71, 0xf2, 0x46, 0x51, 0xbf, 0x9b, 0x0a, 0x7e, 0xff, 0xc5, 0x7c, 0x03,
+	0xd5, 0xb4, 0x5d, 0xe2, 0x5a, 0x13, 0xa9, 0x19, 0x2b, 0x42, 0xf4, 0x76, 0x78, 0x0b, 0x36, 0xe3,
+	0xf0, 0xa4, 0xe2, 0x44, 0x8f, 0x53, 0x76, 0x29, 0x55, 0x94, 0xde, 0xee, 0x88, 0xfd, 0xde, 0x4b,
+	0xac, 0xcb, 0x8d, 0xfa, 0x44, 0x1f, 0xab, 0xab, 0xae, 0x68, 0xd3, 0x5b, 0xf1, 0xfb, 0xa5, 0x8b,
+	0xe9, 0xde, 0xee, 0xe5, 0x8c, 0xf8, 0xd3, 0xf1, 0xf3, 0xff, 0x00, 0xda, 0x01, 0xfc, 0x63, 0xa1,
+	0x14, 0x00, 0x00,
 }
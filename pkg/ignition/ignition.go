@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
@@ -35,6 +36,17 @@ var ignitionLocalDir = "/var/run/libvirt/ignition-dir"
 
 const IgnitionFile = "data.ign"
 
+// IgnitionSourceDir is where the Secret or ConfigMap referenced by spec.ignition is mounted in virt-launcher
+const IgnitionSourceDir = "/var/run/kubevirt-private/ignition"
+
+// IgnitionConfigKey is the key of the Ignition config in the referenced Secret or ConfigMap
+const IgnitionConfigKey = "config.ign"
+
+// GetIgnitionSourceConfigPath returns the path of the Ignition config referenced by spec.ignition
+func GetIgnitionSourceConfigPath() string {
+	return filepath.Join(IgnitionSourceDir, IgnitionConfigKey)
+}
+
 func GetIgnitionSource(vmi *v1.VirtualMachineInstance) string {
 	precond.MustNotBeNil(vmi)
 	return vmi.Annotations[v1.IgnitionAnnotation]
@@ -0,0 +1,520 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package instancetype
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	apiinstancetype "kubevirt.io/client-go/apis/instancetype"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+// Methods finds the instancetype and preference referenced by a VirtualMachine
+// and expands them into the VirtualMachineInstanceSpec of its template.
+type Methods interface {
+	FindInstancetypeSpec(vm *virtv1.VirtualMachine) (*instancetypev1alpha1.VirtualMachineInstancetypeSpec, error)
+	FindPreferenceSpec(vm *virtv1.VirtualMachine) (*instancetypev1alpha1.VirtualMachinePreferenceSpec, error)
+	ApplyToVmi(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts
+	StoreControllerRevisions(vm *virtv1.VirtualMachine) error
+}
+
+// Conflicts lists the VirtualMachineInstanceSpec fields which are already set
+// and would otherwise be overwritten by an instancetype.
+type Conflicts []*k8sfield.Path
+
+func (c Conflicts) String() string {
+	pathStrings := make([]string, 0, len(c))
+	for _, path := range c {
+		pathStrings = append(pathStrings, path.String())
+	}
+	return strings.Join(pathStrings, ", ")
+}
+
+type methods struct {
+	clientset kubecli.KubevirtClient
+}
+
+var _ Methods = &methods{}
+
+func NewMethods(clientset kubecli.KubevirtClient) Methods {
+	return &methods{
+		clientset: clientset,
+	}
+}
+
+// GetRevisionName returns the name of the ControllerRevision capturing the given
+// instancetype or preference for the VirtualMachine.
+func GetRevisionName(vmName, resourceName string, resourceUID types.UID, resourceGeneration int64) string {
+	return fmt.Sprintf("%s-%s-%s-%d", vmName, resourceName, resourceUID, resourceGeneration)
+}
+
+func (m *methods) FindInstancetypeSpec(vm *virtv1.VirtualMachine) (*instancetypev1alpha1.VirtualMachineInstancetypeSpec, error) {
+	if vm.Spec.Instancetype == nil {
+		return nil, nil
+	}
+
+	if vm.Spec.Instancetype.RevisionName != "" {
+		instancetypeSpec := &instancetypev1alpha1.VirtualMachineInstancetypeSpec{}
+		if err := m.getSpecFromControllerRevision(vm.Namespace, vm.Spec.Instancetype.RevisionName, instancetypeSpec); err != nil {
+			return nil, err
+		}
+		return instancetypeSpec, nil
+	}
+
+	_, instancetypeSpec, err := m.findInstancetype(vm)
+	return instancetypeSpec, err
+}
+
+func (m *methods) FindPreferenceSpec(vm *virtv1.VirtualMachine) (*instancetypev1alpha1.VirtualMachinePreferenceSpec, error) {
+	if vm.Spec.Preference == nil {
+		return nil, nil
+	}
+
+	if vm.Spec.Preference.RevisionName != "" {
+		preferenceSpec := &instancetypev1alpha1.VirtualMachinePreferenceSpec{}
+		if err := m.getSpecFromControllerRevision(vm.Namespace, vm.Spec.Preference.RevisionName, preferenceSpec); err != nil {
+			return nil, err
+		}
+		return preferenceSpec, nil
+	}
+
+	_, preferenceSpec, err := m.findPreference(vm)
+	return preferenceSpec, err
+}
+
+func (m *methods) findInstancetype(vm *virtv1.VirtualMachine) (metav1.Object, *instancetypev1alpha1.VirtualMachineInstancetypeSpec, error) {
+	name := vm.Spec.Instancetype.Name
+
+	switch strings.ToLower(vm.Spec.Instancetype.Kind) {
+	case apiinstancetype.SingularResourceName, apiinstancetype.PluralResourceName:
+		instancetype, err := m.clientset.VirtualMachineInstancetype(vm.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return instancetype, &instancetype.Spec, nil
+
+	case apiinstancetype.ClusterSingularResourceName, apiinstancetype.ClusterPluralResourceName, "":
+		instancetype, err := m.clientset.VirtualMachineClusterInstancetype().Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return instancetype, &instancetype.Spec, nil
+
+	default:
+		return nil, nil, fmt.Errorf("got unexpected kind in InstancetypeMatcher: %s", vm.Spec.Instancetype.Kind)
+	}
+}
+
+func (m *methods) findPreference(vm *virtv1.VirtualMachine) (metav1.Object, *instancetypev1alpha1.VirtualMachinePreferenceSpec, error) {
+	name := vm.Spec.Preference.Name
+
+	switch strings.ToLower(vm.Spec.Preference.Kind) {
+	case apiinstancetype.SingularPreferenceResourceName, apiinstancetype.PluralPreferenceResourceName:
+		preference, err := m.clientset.VirtualMachinePreference(vm.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return preference, &preference.Spec, nil
+
+	case apiinstancetype.ClusterSingularPreferenceResourceName, apiinstancetype.ClusterPluralPreferenceResourceName, "":
+		preference, err := m.clientset.VirtualMachineClusterPreference().Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return preference, &preference.Spec, nil
+
+	default:
+		return nil, nil, fmt.Errorf("got unexpected kind in PreferenceMatcher: %s", vm.Spec.Preference.Kind)
+	}
+}
+
+func (m *methods) getSpecFromControllerRevision(namespace, revisionName string, spec interface{}) error {
+	revision, err := m.clientset.AppsV1().ControllerRevisions(namespace).Get(context.Background(), revisionName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(revision.Data.Raw, spec)
+}
+
+// StoreControllerRevisions captures the instancetype and preference currently
+// referenced by the VirtualMachine in ControllerRevisions and records their
+// names in the VirtualMachine, so that later changes to either object do not
+// affect the VirtualMachine. The passed VirtualMachine is updated in place.
+func (m *methods) StoreControllerRevisions(vm *virtv1.VirtualMachine) error {
+	var patchOps []string
+
+	if vm.Spec.Instancetype != nil && vm.Spec.Instancetype.RevisionName == "" {
+		instancetype, instancetypeSpec, err := m.findInstancetype(vm)
+		if err != nil {
+			return err
+		}
+		revision, err := m.storeControllerRevision(vm, instancetype, instancetypeSpec)
+		if err != nil {
+			return err
+		}
+		vm.Spec.Instancetype.RevisionName = revision.Name
+		patchOps = append(patchOps, fmt.Sprintf(`{ "op": "add", "path": "/spec/instancetype/revisionName", "value": "%s" }`, revision.Name))
+	}
+
+	if vm.Spec.Preference != nil && vm.Spec.Preference.RevisionName == "" {
+		preference, preferenceSpec, err := m.findPreference(vm)
+		if err != nil {
+			return err
+		}
+		revision, err := m.storeControllerRevision(vm, preference, preferenceSpec)
+		if err != nil {
+			return err
+		}
+		vm.Spec.Preference.RevisionName = revision.Name
+		patchOps = append(patchOps, fmt.Sprintf(`{ "op": "add", "path": "/spec/preference/revisionName", "value": "%s" }`, revision.Name))
+	}
+
+	if len(patchOps) == 0 {
+		return nil
+	}
+
+	_, err := m.clientset.VirtualMachine(vm.Namespace).Patch(vm.Name, types.JSONPatchType, []byte(fmt.Sprintf("[ %s ]", strings.Join(patchOps, ", "))))
+	return err
+}
+
+func (m *methods) storeControllerRevision(vm *virtv1.VirtualMachine, object metav1.Object, spec interface{}) (*appsv1.ControllerRevision, error) {
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetRevisionName(vm.Name, object.GetName(), object.GetUID(), object.GetGeneration()),
+			Namespace: vm.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind),
+			},
+		},
+		Data: runtime.RawExtension{Raw: specBytes},
+	}
+
+	created, err := m.clientset.AppsV1().ControllerRevisions(vm.Namespace).Create(context.Background(), revision, metav1.CreateOptions{})
+	if err == nil {
+		return created, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create ControllerRevision: %v", err)
+	}
+
+	// A revision for this generation may have been stored by an earlier sync
+	// that failed to patch the VirtualMachine, reuse it if the contents match.
+	existing, err := m.clientset.AppsV1().ControllerRevisions(vm.Namespace).Get(context.Background(), revision.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ControllerRevision: %v", err)
+	}
+	var existingSpec, newSpec interface{}
+	if err := json.Unmarshal(existing.Data.Raw, &existingSpec); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(specBytes, &newSpec); err != nil {
+		return nil, err
+	}
+	if !equality.Semantic.DeepEqual(existingSpec, newSpec) {
+		return nil, fmt.Errorf("found existing ControllerRevision with unexpected data: %s", revision.Name)
+	}
+	return existing, nil
+}
+
+func (m *methods) ApplyToVmi(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts {
+	var conflicts Conflicts
+
+	if instancetypeSpec != nil {
+		conflicts = append(conflicts, applyCPU(field, instancetypeSpec, preferenceSpec, vmiSpec)...)
+		conflicts = append(conflicts, applyMemory(field, instancetypeSpec, vmiSpec)...)
+		conflicts = append(conflicts, applyIOThreadPolicy(field, instancetypeSpec, vmiSpec)...)
+		conflicts = append(conflicts, applyGPUs(field, instancetypeSpec, vmiSpec)...)
+		conflicts = append(conflicts, applyHostDevices(field, instancetypeSpec, vmiSpec)...)
+	}
+
+	if len(conflicts) > 0 {
+		return conflicts
+	}
+
+	if preferenceSpec != nil {
+		applyDevicePreferences(preferenceSpec, vmiSpec)
+		applyFeaturePreferences(preferenceSpec, vmiSpec)
+		applyFirmwarePreferences(preferenceSpec, vmiSpec)
+		applyMachinePreferences(preferenceSpec, vmiSpec)
+	}
+
+	return nil
+}
+
+func applyCPU(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts {
+	var conflicts Conflicts
+
+	if vmiSpec.Domain.CPU != nil {
+		conflicts = append(conflicts, field.Child("domain", "cpu"))
+	}
+	if _, hasCPURequests := vmiSpec.Domain.Resources.Requests[k8sv1.ResourceCPU]; hasCPURequests {
+		conflicts = append(conflicts, field.Child("domain", "resources", "requests", string(k8sv1.ResourceCPU)))
+	}
+	if _, hasCPULimits := vmiSpec.Domain.Resources.Limits[k8sv1.ResourceCPU]; hasCPULimits {
+		conflicts = append(conflicts, field.Child("domain", "resources", "limits", string(k8sv1.ResourceCPU)))
+	}
+	if len(conflicts) > 0 {
+		return conflicts
+	}
+
+	vmiSpec.Domain.CPU = &virtv1.CPU{
+		Sockets:               1,
+		Cores:                 1,
+		Threads:               1,
+		Model:                 instancetypeSpec.CPU.Model,
+		DedicatedCPUPlacement: instancetypeSpec.CPU.DedicatedCPUPlacement,
+		IsolateEmulatorThread: instancetypeSpec.CPU.IsolateEmulatorThread,
+	}
+
+	switch getPreferredTopology(preferenceSpec) {
+	case instancetypev1alpha1.PreferCores:
+		vmiSpec.Domain.CPU.Cores = instancetypeSpec.CPU.Guest
+	case instancetypev1alpha1.PreferThreads:
+		vmiSpec.Domain.CPU.Threads = instancetypeSpec.CPU.Guest
+	default:
+		vmiSpec.Domain.CPU.Sockets = instancetypeSpec.CPU.Guest
+	}
+
+	return nil
+}
+
+func getPreferredTopology(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec) instancetypev1alpha1.PreferredCPUTopology {
+	if preferenceSpec == nil || preferenceSpec.CPU == nil || preferenceSpec.CPU.PreferredCPUTopology == "" {
+		return instancetypev1alpha1.PreferSockets
+	}
+	return preferenceSpec.CPU.PreferredCPUTopology
+}
+
+func applyMemory(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts {
+	var conflicts Conflicts
+
+	if vmiSpec.Domain.Memory != nil {
+		conflicts = append(conflicts, field.Child("domain", "memory"))
+	}
+	if _, hasMemoryRequests := vmiSpec.Domain.Resources.Requests[k8sv1.ResourceMemory]; hasMemoryRequests {
+		conflicts = append(conflicts, field.Child("domain", "resources", "requests", string(k8sv1.ResourceMemory)))
+	}
+	if _, hasMemoryLimits := vmiSpec.Domain.Resources.Limits[k8sv1.ResourceMemory]; hasMemoryLimits {
+		conflicts = append(conflicts, field.Child("domain", "resources", "limits", string(k8sv1.ResourceMemory)))
+	}
+	if len(conflicts) > 0 {
+		return conflicts
+	}
+
+	instancetypeMemoryGuest := instancetypeSpec.Memory.Guest.DeepCopy()
+	vmiSpec.Domain.Memory = &virtv1.Memory{
+		Guest: &instancetypeMemoryGuest,
+	}
+	if instancetypeSpec.Memory.Hugepages != nil {
+		vmiSpec.Domain.Memory.Hugepages = instancetypeSpec.Memory.Hugepages.DeepCopy()
+	} else if overcommit := instancetypeSpec.Memory.OvercommitPercent; overcommit > 0 && overcommit < 100 {
+		// Request only the part of the guest memory which is not overcommitted
+		request := instancetypeMemoryGuest.Value() * int64(100-overcommit) / 100
+		if vmiSpec.Domain.Resources.Requests == nil {
+			vmiSpec.Domain.Resources.Requests = k8sv1.ResourceList{}
+		}
+		vmiSpec.Domain.Resources.Requests[k8sv1.ResourceMemory] = *resource.NewQuantity(request, instancetypeMemoryGuest.Format)
+	}
+
+	return nil
+}
+
+func applyIOThreadPolicy(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts {
+	if instancetypeSpec.IOThreadsPolicy == nil {
+		return nil
+	}
+
+	if vmiSpec.Domain.IOThreadsPolicy != nil {
+		return Conflicts{field.Child("domain", "ioThreadsPolicy")}
+	}
+
+	instancetypeIOThreadPolicy := *instancetypeSpec.IOThreadsPolicy
+	vmiSpec.Domain.IOThreadsPolicy = &instancetypeIOThreadPolicy
+
+	return nil
+}
+
+func applyGPUs(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts {
+	if len(instancetypeSpec.GPUs) == 0 {
+		return nil
+	}
+
+	if len(vmiSpec.Domain.Devices.GPUs) >= 1 {
+		return Conflicts{field.Child("domain", "devices", "gpus")}
+	}
+
+	vmiSpec.Domain.Devices.GPUs = make([]virtv1.GPU, len(instancetypeSpec.GPUs))
+	copy(vmiSpec.Domain.Devices.GPUs, instancetypeSpec.GPUs)
+
+	return nil
+}
+
+func applyHostDevices(field *k8sfield.Path, instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) Conflicts {
+	if len(instancetypeSpec.HostDevices) == 0 {
+		return nil
+	}
+
+	if len(vmiSpec.Domain.Devices.HostDevices) >= 1 {
+		return Conflicts{field.Child("domain", "devices", "hostDevices")}
+	}
+
+	vmiSpec.Domain.Devices.HostDevices = make([]virtv1.HostDevice, len(instancetypeSpec.HostDevices))
+	copy(vmiSpec.Domain.Devices.HostDevices, instancetypeSpec.HostDevices)
+
+	return nil
+}
+
+func applyDevicePreferences(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if preferenceSpec.Devices == nil {
+		return
+	}
+	devices := &vmiSpec.Domain.Devices
+
+	applyBoolPreference(&devices.AutoattachGraphicsDevice, preferenceSpec.Devices.PreferredAutoattachGraphicsDevice)
+	applyBoolPreference(&devices.AutoattachMemBalloon, preferenceSpec.Devices.PreferredAutoattachMemBalloon)
+	applyBoolPreference(&devices.AutoattachPodInterface, preferenceSpec.Devices.PreferredAutoattachPodInterface)
+	applyBoolPreference(&devices.AutoattachSerialConsole, preferenceSpec.Devices.PreferredAutoattachSerialConsole)
+	applyBoolPreference(&devices.UseVirtioTransitional, preferenceSpec.Devices.PreferredUseVirtioTransitional)
+	applyBoolPreference(&devices.BlockMultiQueue, preferenceSpec.Devices.PreferredBlockMultiQueue)
+	applyBoolPreference(&devices.NetworkInterfaceMultiQueue, preferenceSpec.Devices.PreferredNetworkInterfaceMultiQueue)
+
+	if preferenceSpec.Devices.PreferredDisableHotplug != nil && !devices.DisableHotplug {
+		devices.DisableHotplug = *preferenceSpec.Devices.PreferredDisableHotplug
+	}
+
+	if preferenceSpec.Devices.PreferredRng != nil && devices.Rng == nil {
+		devices.Rng = preferenceSpec.Devices.PreferredRng.DeepCopy()
+	}
+
+	for i := range devices.Disks {
+		disk := &devices.Disks[i]
+		if preferenceSpec.Devices.PreferredDiskBus != "" && disk.DiskDevice.Disk != nil && disk.DiskDevice.Disk.Bus == "" {
+			disk.DiskDevice.Disk.Bus = preferenceSpec.Devices.PreferredDiskBus
+		}
+		if preferenceSpec.Devices.PreferredCdromBus != "" && disk.DiskDevice.CDRom != nil && disk.DiskDevice.CDRom.Bus == "" {
+			disk.DiskDevice.CDRom.Bus = preferenceSpec.Devices.PreferredCdromBus
+		}
+	}
+
+	for i := range devices.Interfaces {
+		iface := &devices.Interfaces[i]
+		if preferenceSpec.Devices.PreferredInterfaceModel != "" && iface.Model == "" {
+			iface.Model = preferenceSpec.Devices.PreferredInterfaceModel
+		}
+	}
+}
+
+func applyBoolPreference(field **bool, preferred *bool) {
+	if preferred != nil && *field == nil {
+		value := *preferred
+		*field = &value
+	}
+}
+
+func applyFeaturePreferences(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if preferenceSpec.Features == nil {
+		return
+	}
+
+	if vmiSpec.Domain.Features == nil {
+		vmiSpec.Domain.Features = &virtv1.Features{}
+	}
+	features := vmiSpec.Domain.Features
+
+	if preferenceSpec.Features.PreferredAcpi != nil && features.ACPI.Enabled == nil {
+		features.ACPI = *preferenceSpec.Features.PreferredAcpi.DeepCopy()
+	}
+	if preferenceSpec.Features.PreferredApic != nil && features.APIC == nil {
+		features.APIC = preferenceSpec.Features.PreferredApic.DeepCopy()
+	}
+	if preferenceSpec.Features.PreferredHyperv != nil && features.Hyperv == nil {
+		features.Hyperv = preferenceSpec.Features.PreferredHyperv.DeepCopy()
+	}
+	if preferenceSpec.Features.PreferredKvm != nil && features.KVM == nil {
+		features.KVM = preferenceSpec.Features.PreferredKvm.DeepCopy()
+	}
+	if preferenceSpec.Features.PreferredPvspinlock != nil && features.Pvspinlock == nil {
+		features.Pvspinlock = preferenceSpec.Features.PreferredPvspinlock.DeepCopy()
+	}
+	if preferenceSpec.Features.PreferredSmm != nil && features.SMM == nil {
+		features.SMM = preferenceSpec.Features.PreferredSmm.DeepCopy()
+	}
+}
+
+func applyFirmwarePreferences(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if preferenceSpec.Firmware == nil {
+		return
+	}
+
+	if vmiSpec.Domain.Firmware == nil {
+		vmiSpec.Domain.Firmware = &virtv1.Firmware{}
+	}
+	firmware := vmiSpec.Domain.Firmware
+
+	if firmware.Bootloader != nil {
+		return
+	}
+
+	if preferenceSpec.Firmware.PreferredUseBios != nil && *preferenceSpec.Firmware.PreferredUseBios {
+		firmware.Bootloader = &virtv1.Bootloader{
+			BIOS: &virtv1.BIOS{},
+		}
+	}
+
+	if preferenceSpec.Firmware.PreferredUseEfi != nil && *preferenceSpec.Firmware.PreferredUseEfi {
+		firmware.Bootloader = &virtv1.Bootloader{
+			EFI: &virtv1.EFI{},
+		}
+		if preferenceSpec.Firmware.PreferredUseSecureBoot != nil {
+			secureBoot := *preferenceSpec.Firmware.PreferredUseSecureBoot
+			firmware.Bootloader.EFI.SecureBoot = &secureBoot
+		}
+	}
+}
+
+func applyMachinePreferences(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+	if preferenceSpec.Machine == nil {
+		return
+	}
+
+	if vmiSpec.Domain.Machine.Type == "" {
+		vmiSpec.Domain.Machine.Type = preferenceSpec.Machine.PreferredMachineType
+	}
+}
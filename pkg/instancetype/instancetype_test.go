@@ -0,0 +1,427 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package instancetype
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+)
+
+var _ = Describe("Instancetype and Preferences", func() {
+	const namespace = "default"
+
+	var (
+		ctrl           *gomock.Controller
+		virtClient     *kubecli.MockKubevirtClient
+		vmInterface    *kubecli.MockVirtualMachineInterface
+		kubevirtClient *kubevirtfake.Clientset
+		k8sClient      *k8sfake.Clientset
+		methods        Methods
+		vm             *virtv1.VirtualMachine
+		field          = k8sfield.NewPath("spec", "template", "spec")
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		k8sClient = k8sfake.NewSimpleClientset()
+
+		virtClient.EXPECT().VirtualMachine(namespace).Return(vmInterface).AnyTimes()
+		virtClient.EXPECT().AppsV1().Return(k8sClient.AppsV1()).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstancetype(namespace).Return(
+			kubevirtClient.InstancetypeV1alpha1().VirtualMachineInstancetypes(namespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(
+			kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes()).AnyTimes()
+		virtClient.EXPECT().VirtualMachinePreference(namespace).Return(
+			kubevirtClient.InstancetypeV1alpha1().VirtualMachinePreferences(namespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineClusterPreference().Return(
+			kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterPreferences()).AnyTimes()
+
+		methods = NewMethods(virtClient)
+
+		vm = &virtv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testvm",
+				Namespace: namespace,
+				UID:       "vm-uid",
+			},
+			Spec: virtv1.VirtualMachineSpec{
+				Template: &virtv1.VirtualMachineInstanceTemplateSpec{},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	createInstancetype := func() *instancetypev1alpha1.VirtualMachineInstancetype {
+		instancetype := &instancetypev1alpha1.VirtualMachineInstancetype{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "instancetype",
+				Namespace:  namespace,
+				UID:        "instancetype-uid",
+				Generation: 1,
+			},
+			Spec: instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+				CPU: instancetypev1alpha1.CPUInstancetype{
+					Guest: 2,
+				},
+				Memory: instancetypev1alpha1.MemoryInstancetype{
+					Guest: resource.MustParse("128Mi"),
+				},
+			},
+		}
+		_, err := kubevirtClient.InstancetypeV1alpha1().VirtualMachineInstancetypes(namespace).Create(context.Background(), instancetype, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return instancetype
+	}
+
+	createClusterPreference := func() *instancetypev1alpha1.VirtualMachineClusterPreference {
+		preference := &instancetypev1alpha1.VirtualMachineClusterPreference{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "preference",
+				UID:        "preference-uid",
+				Generation: 1,
+			},
+			Spec: instancetypev1alpha1.VirtualMachinePreferenceSpec{
+				CPU: &instancetypev1alpha1.CPUPreferences{
+					PreferredCPUTopology: instancetypev1alpha1.PreferCores,
+				},
+			},
+		}
+		_, err := kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterPreferences().Create(context.Background(), preference, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return preference
+	}
+
+	createControllerRevision := func(name string, spec interface{}) {
+		specBytes, err := json.Marshal(spec)
+		Expect(err).ToNot(HaveOccurred())
+		revision := &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Data: runtime.RawExtension{Raw: specBytes},
+		}
+		_, err = k8sClient.AppsV1().ControllerRevisions(namespace).Create(context.Background(), revision, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	Context("Find", func() {
+		It("should return nil when nothing is referenced", func() {
+			instancetypeSpec, err := methods.FindInstancetypeSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instancetypeSpec).To(BeNil())
+
+			preferenceSpec, err := methods.FindPreferenceSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preferenceSpec).To(BeNil())
+		})
+
+		It("should find a namespaced instancetype", func() {
+			instancetype := createInstancetype()
+			vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{
+				Name: instancetype.Name,
+				Kind: "VirtualMachineInstancetype",
+			}
+
+			instancetypeSpec, err := methods.FindInstancetypeSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*instancetypeSpec).To(Equal(instancetype.Spec))
+		})
+
+		It("should default to the cluster scoped preference", func() {
+			preference := createClusterPreference()
+			vm.Spec.Preference = &virtv1.PreferenceMatcher{
+				Name: preference.Name,
+			}
+
+			preferenceSpec, err := methods.FindPreferenceSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*preferenceSpec).To(Equal(preference.Spec))
+		})
+
+		It("should fail on an unknown kind", func() {
+			vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{
+				Name: "instancetype",
+				Kind: "Unknown",
+			}
+
+			_, err := methods.FindInstancetypeSpec(vm)
+			Expect(err).To(MatchError(ContainSubstring("got unexpected kind in InstancetypeMatcher")))
+		})
+
+		It("should prefer the spec stored in the ControllerRevision", func() {
+			instancetype := createInstancetype()
+			storedSpec := instancetype.Spec.DeepCopy()
+			storedSpec.CPU.Guest = 4
+			createControllerRevision("revision", storedSpec)
+
+			vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{
+				Name:         instancetype.Name,
+				Kind:         "VirtualMachineInstancetype",
+				RevisionName: "revision",
+			}
+
+			instancetypeSpec, err := methods.FindInstancetypeSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instancetypeSpec.CPU.Guest).To(Equal(uint32(4)))
+		})
+	})
+
+	Context("StoreControllerRevisions", func() {
+		var instancetype *instancetypev1alpha1.VirtualMachineInstancetype
+		var preference *instancetypev1alpha1.VirtualMachineClusterPreference
+
+		BeforeEach(func() {
+			instancetype = createInstancetype()
+			preference = createClusterPreference()
+			vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{
+				Name: instancetype.Name,
+				Kind: "VirtualMachineInstancetype",
+			}
+			vm.Spec.Preference = &virtv1.PreferenceMatcher{
+				Name: preference.Name,
+			}
+		})
+
+		It("should store revisions and patch the VirtualMachine", func() {
+			instancetypeRevisionName := GetRevisionName(vm.Name, instancetype.Name, instancetype.UID, instancetype.Generation)
+			preferenceRevisionName := GetRevisionName(vm.Name, preference.Name, preference.UID, preference.Generation)
+
+			vmInterface.EXPECT().Patch(vm.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(
+				func(name string, pt types.PatchType, data []byte, subresources ...string) (*virtv1.VirtualMachine, error) {
+					Expect(string(data)).To(ContainSubstring(`"path": "/spec/instancetype/revisionName", "value": "` + instancetypeRevisionName + `"`))
+					Expect(string(data)).To(ContainSubstring(`"path": "/spec/preference/revisionName", "value": "` + preferenceRevisionName + `"`))
+					return vm, nil
+				})
+
+			Expect(methods.StoreControllerRevisions(vm)).To(Succeed())
+			Expect(vm.Spec.Instancetype.RevisionName).To(Equal(instancetypeRevisionName))
+			Expect(vm.Spec.Preference.RevisionName).To(Equal(preferenceRevisionName))
+
+			revision, err := k8sClient.AppsV1().ControllerRevisions(namespace).Get(context.Background(), instancetypeRevisionName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revision.OwnerReferences).To(HaveLen(1))
+			Expect(revision.OwnerReferences[0].UID).To(Equal(vm.UID))
+
+			instancetypeSpec, err := methods.FindInstancetypeSpec(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*instancetypeSpec).To(Equal(instancetype.Spec))
+		})
+
+		It("should reuse an existing revision with matching data", func() {
+			createControllerRevision(GetRevisionName(vm.Name, instancetype.Name, instancetype.UID, instancetype.Generation), &instancetype.Spec)
+			vmInterface.EXPECT().Patch(vm.Name, types.JSONPatchType, gomock.Any()).Return(vm, nil)
+
+			Expect(methods.StoreControllerRevisions(vm)).To(Succeed())
+		})
+
+		It("should fail if an existing revision has different data", func() {
+			storedSpec := instancetype.Spec.DeepCopy()
+			storedSpec.CPU.Guest = 4
+			createControllerRevision(GetRevisionName(vm.Name, instancetype.Name, instancetype.UID, instancetype.Generation), storedSpec)
+
+			err := methods.StoreControllerRevisions(vm)
+			Expect(err).To(MatchError(ContainSubstring("found existing ControllerRevision with unexpected data")))
+		})
+
+		It("should not patch the VirtualMachine when revisions are already recorded", func() {
+			vm.Spec.Instancetype.RevisionName = "instancetype-revision"
+			vm.Spec.Preference.RevisionName = "preference-revision"
+
+			Expect(methods.StoreControllerRevisions(vm)).To(Succeed())
+		})
+	})
+
+	Context("ApplyToVmi", func() {
+		var instancetypeSpec *instancetypev1alpha1.VirtualMachineInstancetypeSpec
+		var preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec
+		var vmiSpec *virtv1.VirtualMachineInstanceSpec
+
+		BeforeEach(func() {
+			instancetypeSpec = &instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+				CPU: instancetypev1alpha1.CPUInstancetype{
+					Guest:                 2,
+					Model:                 "host-passthrough",
+					DedicatedCPUPlacement: true,
+				},
+				Memory: instancetypev1alpha1.MemoryInstancetype{
+					Guest: resource.MustParse("512Mi"),
+					Hugepages: &virtv1.Hugepages{
+						PageSize: "1Gi",
+					},
+				},
+			}
+			preferenceSpec = nil
+			vmiSpec = &virtv1.VirtualMachineInstanceSpec{}
+		})
+
+		It("should apply the CPU and memory of the instancetype", func() {
+			Expect(methods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, vmiSpec)).To(BeEmpty())
+
+			Expect(vmiSpec.Domain.CPU.Sockets).To(Equal(uint32(2)))
+			Expect(vmiSpec.Domain.CPU.Cores).To(Equal(uint32(1)))
+			Expect(vmiSpec.Domain.CPU.Threads).To(Equal(uint32(1)))
+			Expect(vmiSpec.Domain.CPU.Model).To(Equal("host-passthrough"))
+			Expect(vmiSpec.Domain.CPU.DedicatedCPUPlacement).To(BeTrue())
+			Expect(vmiSpec.Domain.Memory.Guest.String()).To(Equal("512Mi"))
+			Expect(vmiSpec.Domain.Memory.Hugepages.PageSize).To(Equal("1Gi"))
+		})
+
+		It("should request only the part of the guest memory which is not overcommitted", func() {
+			instancetypeSpec.Memory.Hugepages = nil
+			instancetypeSpec.Memory.OvercommitPercent = 25
+
+			Expect(methods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, vmiSpec)).To(BeEmpty())
+			Expect(vmiSpec.Domain.Memory.Guest.String()).To(Equal("512Mi"))
+			Expect(vmiSpec.Domain.Resources.Requests.Memory().String()).To(Equal("384Mi"))
+		})
+
+		It("should not overcommit memory backed by hugepages", func() {
+			instancetypeSpec.Memory.OvercommitPercent = 25
+
+			Expect(methods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, vmiSpec)).To(BeEmpty())
+			Expect(vmiSpec.Domain.Resources.Requests).ToNot(HaveKey(k8sv1.ResourceMemory))
+		})
+
+		table.DescribeTable("should apply the preferred CPU topology", func(topology instancetypev1alpha1.PreferredCPUTopology, sockets, cores, threads uint32) {
+			preferenceSpec = &instancetypev1alpha1.VirtualMachinePreferenceSpec{
+				CPU: &instancetypev1alpha1.CPUPreferences{
+					PreferredCPUTopology: topology,
+				},
+			}
+
+			Expect(methods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, vmiSpec)).To(BeEmpty())
+			Expect(vmiSpec.Domain.CPU.Sockets).To(Equal(sockets))
+			Expect(vmiSpec.Domain.CPU.Cores).To(Equal(cores))
+			Expect(vmiSpec.Domain.CPU.Threads).To(Equal(threads))
+		},
+			table.Entry("with preferSockets", instancetypev1alpha1.PreferSockets, uint32(2), uint32(1), uint32(1)),
+			table.Entry("with preferCores", instancetypev1alpha1.PreferCores, uint32(1), uint32(2), uint32(1)),
+			table.Entry("with preferThreads", instancetypev1alpha1.PreferThreads, uint32(1), uint32(1), uint32(2)),
+		)
+
+		table.DescribeTable("should report conflicts", func(update func(*virtv1.VirtualMachineInstanceSpec), conflict string) {
+			update(vmiSpec)
+
+			conflicts := methods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, vmiSpec)
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts.String()).To(Equal(conflict))
+		},
+			table.Entry("with CPU", func(vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+				vmiSpec.Domain.CPU = &virtv1.CPU{Sockets: 1}
+			}, "spec.template.spec.domain.cpu"),
+			table.Entry("with CPU requests", func(vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+				vmiSpec.Domain.Resources.Requests = k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("1")}
+			}, "spec.template.spec.domain.resources.requests.cpu"),
+			table.Entry("with memory", func(vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+				guest := resource.MustParse("1Gi")
+				vmiSpec.Domain.Memory = &virtv1.Memory{Guest: &guest}
+			}, "spec.template.spec.domain.memory"),
+			table.Entry("with memory limits", func(vmiSpec *virtv1.VirtualMachineInstanceSpec) {
+				vmiSpec.Domain.Resources.Limits = k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("1Gi")}
+			}, "spec.template.spec.domain.resources.limits.memory"),
+		)
+
+		It("should report conflicting devices", func() {
+			instancetypeSpec.GPUs = []virtv1.GPU{{Name: "gpu", DeviceName: "vendor.com/gpu"}}
+			vmiSpec.Domain.Devices.GPUs = []virtv1.GPU{{Name: "other", DeviceName: "vendor.com/other"}}
+
+			conflicts := methods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, vmiSpec)
+			Expect(conflicts.String()).To(Equal("spec.template.spec.domain.devices.gpus"))
+		})
+
+		It("should only apply preferences to unset fields", func() {
+			preferenceSpec = &instancetypev1alpha1.VirtualMachinePreferenceSpec{
+				Devices: &instancetypev1alpha1.DevicePreferences{
+					PreferredAutoattachSerialConsole: pointerBool(false),
+					PreferredDiskBus:                 "virtio",
+					PreferredInterfaceModel:          "virtio",
+				},
+				Features: &instancetypev1alpha1.FeaturePreferences{
+					PreferredSmm: &virtv1.FeatureState{Enabled: pointerBool(true)},
+				},
+				Firmware: &instancetypev1alpha1.FirmwarePreferences{
+					PreferredUseEfi:        pointerBool(true),
+					PreferredUseSecureBoot: pointerBool(true),
+				},
+				Machine: &instancetypev1alpha1.MachinePreferences{
+					PreferredMachineType: "q35",
+				},
+			}
+			vmiSpec.Domain.Devices.Disks = []virtv1.Disk{
+				{Name: "preferred", DiskDevice: virtv1.DiskDevice{Disk: &virtv1.DiskTarget{}}},
+				{Name: "explicit", DiskDevice: virtv1.DiskDevice{Disk: &virtv1.DiskTarget{Bus: "sata"}}},
+			}
+			vmiSpec.Domain.Devices.Interfaces = []virtv1.Interface{{Name: "default"}}
+			vmiSpec.Domain.Machine.Type = "pc"
+
+			Expect(methods.ApplyToVmi(field, nil, preferenceSpec, vmiSpec)).To(BeEmpty())
+
+			Expect(*vmiSpec.Domain.Devices.AutoattachSerialConsole).To(BeFalse())
+			Expect(vmiSpec.Domain.Devices.Disks[0].DiskDevice.Disk.Bus).To(Equal("virtio"))
+			Expect(vmiSpec.Domain.Devices.Disks[1].DiskDevice.Disk.Bus).To(Equal("sata"))
+			Expect(vmiSpec.Domain.Devices.Interfaces[0].Model).To(Equal("virtio"))
+			Expect(*vmiSpec.Domain.Features.SMM.Enabled).To(BeTrue())
+			Expect(*vmiSpec.Domain.Firmware.Bootloader.EFI.SecureBoot).To(BeTrue())
+			Expect(vmiSpec.Domain.Machine.Type).To(Equal("pc"))
+		})
+
+		It("should not apply preferences when the instancetype conflicts", func() {
+			preferenceSpec = &instancetypev1alpha1.VirtualMachinePreferenceSpec{
+				Machine: &instancetypev1alpha1.MachinePreferences{
+					PreferredMachineType: "q35",
+				},
+			}
+			vmiSpec.Domain.CPU = &virtv1.CPU{Sockets: 1}
+
+			Expect(methods.ApplyToVmi(field, instancetypeSpec, preferenceSpec, vmiSpec)).To(HaveLen(1))
+			Expect(vmiSpec.Domain.Machine.Type).To(BeEmpty())
+		})
+	})
+})
+
+func pointerBool(b bool) *bool {
+	return &b
+}
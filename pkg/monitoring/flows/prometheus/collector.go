@@ -0,0 +1,308 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util/net/pcap"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
+)
+
+const (
+	// DefaultSyncPeriod is how often the captured devices are reconciled with the VMIs and idle flows are expired
+	DefaultSyncPeriod = 10 * time.Second
+
+	flowIdleTimeout      = 30 * time.Second
+	flowActiveTimeout    = 5 * time.Minute
+	maxFlowsPerInterface = 1000
+	// headerSnapLen covers the ethernet, VLAN, IP and transport headers, the payload is not needed
+	headerSnapLen = 128
+)
+
+var (
+	flowLabels = []string{"node", "namespace", "name", "interface", "direction", "protocol", "src_address", "src_port", "dst_address", "dst_port"}
+
+	flowBytesDesc = prometheus.NewDesc(
+		"kubevirt_vmi_flow_traffic_bytes_total",
+		"Traffic of a network flow of the VMI in bytes.",
+		flowLabels,
+		nil,
+	)
+	flowPacketsDesc = prometheus.NewDesc(
+		"kubevirt_vmi_flow_traffic_packets_total",
+		"Traffic of a network flow of the VMI in packets.",
+		flowLabels,
+		nil,
+	)
+	untrackedPacketsDesc = prometheus.NewDesc(
+		"kubevirt_vmi_flow_untracked_packets_total",
+		"Packets of the VMI interface which were not accounted to a flow because too many flows are tracked.",
+		[]string{"node", "namespace", "name", "interface"},
+		nil,
+	)
+)
+
+// packetReader is the packet socket of a captured device
+type packetReader interface {
+	ReadPacket(buf []byte) (int, bool, error)
+	Close() error
+}
+
+// captureTarget is the tap device of a VMI interface
+type captureTarget struct {
+	vmi    *v1.VirtualMachineInstance
+	iface  string
+	device string
+}
+
+// capture accounts the flows of a VMI interface from the packets of its tap device
+type capture struct {
+	vmi    *v1.VirtualMachineInstance
+	iface  string
+	reader packetReader
+	flows  *flowTable
+	stop   chan struct{}
+}
+
+// Collector captures the headers of the packets on the tap devices of the VMIs on the node, and accounts them
+// to flows. It exposes the traffic of every flow as metrics and logs a record of the flows when they expire.
+type Collector struct {
+	nodeName          string
+	vmiInformer       cache.SharedIndexInformer
+	isolationDetector isolation.PodIsolationDetector
+	clusterConfig     *virtconfig.ClusterConfig
+	openDevice        func(vmi *v1.VirtualMachineInstance, device string) (packetReader, error)
+
+	lock     sync.Mutex
+	captures map[string]*capture
+}
+
+func NewCollector(nodeName string, vmiInformer cache.SharedIndexInformer, isolationDetector isolation.PodIsolationDetector, clusterConfig *virtconfig.ClusterConfig) *Collector {
+	c := &Collector{
+		nodeName:          nodeName,
+		vmiInformer:       vmiInformer,
+		isolationDetector: isolationDetector,
+		clusterConfig:     clusterConfig,
+		captures:          map[string]*capture{},
+	}
+	c.openDevice = c.openPacketSocket
+	return c
+}
+
+// SetupCollector returns a collector of the flows of the VMIs on the node, registered with prometheus
+func SetupCollector(nodeName string, vmiInformer cache.SharedIndexInformer, isolationDetector isolation.PodIsolationDetector, clusterConfig *virtconfig.ClusterConfig) *Collector {
+	c := NewCollector(nodeName, vmiInformer, isolationDetector, clusterConfig)
+	prometheus.MustRegister(c)
+	return c
+}
+
+// Run reconciles the captures every period until stopCh is closed, and stops all of them then
+func (c *Collector) Run(period time.Duration, stopCh <-chan struct{}) {
+	wait.Until(c.Sync, period, stopCh)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, capture := range c.captures {
+		c.stopCapture(key, capture)
+	}
+}
+
+// Sync starts capturing on the tap devices of the running VMIs on the node and stops capturing on the devices
+// of the VMIs and interfaces which are gone. Nothing is captured while the FlowMetrics feature gate is disabled.
+func (c *Collector) Sync() {
+	targets := map[string]captureTarget{}
+	if c.clusterConfig.FlowMetricsEnabled() {
+		for _, obj := range c.vmiInformer.GetStore().List() {
+			vmi := obj.(*v1.VirtualMachineInstance)
+			if vmi.Status.NodeName != c.nodeName || !vmi.IsRunning() {
+				continue
+			}
+			for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+				// only bridge and masquerade interfaces are connected to the guest through a tap device
+				if iface.Bridge == nil && iface.Masquerade == nil {
+					continue
+				}
+				device, err := network.GetCaptureDeviceName(vmi, iface.Name)
+				if err != nil {
+					continue
+				}
+				targets[captureKey(vmi, iface.Name)] = captureTarget{vmi: vmi, iface: iface.Name, device: device}
+			}
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for key, capture := range c.captures {
+		if _, exists := targets[key]; !exists {
+			c.stopCapture(key, capture)
+		}
+	}
+	now := time.Now()
+	for key, target := range targets {
+		if capture, exists := c.captures[key]; exists {
+			capture.vmi = target.vmi
+			capture.logRecords(capture.flows.expire(now, flowIdleTimeout, flowActiveTimeout))
+			continue
+		}
+		reader, err := c.openDevice(target.vmi, target.device)
+		if err != nil {
+			log.Log.Object(target.vmi).Reason(err).Warningf("failed to capture the flows of interface %s", target.iface)
+			continue
+		}
+		capture := &capture{
+			vmi:    target.vmi,
+			iface:  target.iface,
+			reader: reader,
+			flows:  newFlowTable(maxFlowsPerInterface),
+			stop:   make(chan struct{}),
+		}
+		c.captures[key] = capture
+		go capture.run()
+	}
+}
+
+func (c *Collector) stopCapture(key string, capture *capture) {
+	close(capture.stop)
+	capture.logRecords(capture.flows.flush(time.Now()))
+	delete(c.captures, key)
+}
+
+func (c *Collector) openPacketSocket(vmi *v1.VirtualMachineInstance, device string) (packetReader, error) {
+	result, err := c.isolationDetector.Detect(vmi)
+	if err != nil {
+		return nil, err
+	}
+	var socket *pcap.Socket
+	err = result.DoNetNS(func() error {
+		socket, err = pcap.Open(device, nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a packet socket on %s: %v", device, err)
+	}
+	return socket, nil
+}
+
+func captureKey(vmi *v1.VirtualMachineInstance, iface string) string {
+	return fmt.Sprintf("%s/%s", vmi.UID, iface)
+}
+
+// run accounts the packets of the device until the capture is stopped, and closes the socket then.
+// The socket is only closed here, so that its descriptor is not reused while a read is pending.
+func (c *capture) run() {
+	defer c.reader.Close()
+	buf := make([]byte, headerSnapLen)
+	for {
+		length, outgoing, err := c.reader.ReadPacket(buf)
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+		if err == unix.EAGAIN || err == unix.EINTR {
+			continue
+		} else if err != nil {
+			log.Log.Object(c.vmi).Reason(err).Errorf("failed to capture the flows of interface %s", c.iface)
+			return
+		}
+		data := buf
+		if length < len(buf) {
+			data = buf[:length]
+		}
+		key, ok := parsePacket(data)
+		if !ok {
+			continue
+		}
+		// the tap device sends the packets received by the guest and receives the ones the guest sends
+		key.Direction = DirectionEgress
+		if outgoing {
+			key.Direction = DirectionIngress
+		}
+		c.flows.add(key, length, time.Now())
+	}
+}
+
+// logRecords logs the flow records with the names of the IPFIX information elements
+func (c *capture) logRecords(records []FlowRecord) {
+	for _, record := range records {
+		log.Log.Object(c.vmi).With(
+			"interface", c.iface,
+			"flowDirection", record.Direction,
+			"protocolIdentifier", record.Protocol,
+			"sourceIPAddress", record.SrcIP,
+			"sourceTransportPort", record.SrcPort,
+			"destinationIPAddress", record.DstIP,
+			"destinationTransportPort", record.DstPort,
+			"octetDeltaCount", record.Bytes,
+			"packetDeltaCount", record.Packets,
+			"flowStartMilliseconds", record.Start.UnixNano()/int64(time.Millisecond),
+			"flowEndMilliseconds", record.End.UnixNano()/int64(time.Millisecond),
+			"flowEndReason", record.EndReason,
+		).Info("flow record")
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- flowBytesDesc
+	ch <- flowPacketsDesc
+	ch <- untrackedPacketsDesc
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, capture := range c.captures {
+		vmi := capture.vmi
+		flows, untrackedPackets := capture.flows.snapshot()
+		for key, counters := range flows {
+			labelValues := []string{
+				vmi.Status.NodeName, vmi.Namespace, vmi.Name, capture.iface, key.Direction, key.Protocol,
+				key.SrcIP, portLabel(key.SrcPort), key.DstIP, portLabel(key.DstPort),
+			}
+			ch <- prometheus.MustNewConstMetric(flowBytesDesc, prometheus.CounterValue, float64(counters.bytes), labelValues...)
+			ch <- prometheus.MustNewConstMetric(flowPacketsDesc, prometheus.CounterValue, float64(counters.packets), labelValues...)
+		}
+		ch <- prometheus.MustNewConstMetric(untrackedPacketsDesc, prometheus.CounterValue, float64(untrackedPackets),
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name, capture.iface)
+	}
+}
+
+// portLabel leaves the port label empty for protocols without ports
+func portLabel(port uint16) string {
+	if port == 0 {
+		return ""
+	}
+	return fmt.Sprint(port)
+}
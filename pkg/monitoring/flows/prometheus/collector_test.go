@@ -0,0 +1,198 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"golang.org/x/sys/unix"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+type fakePacket struct {
+	data     []byte
+	outgoing bool
+}
+
+type fakeReader struct {
+	packets chan fakePacket
+	closed  chan struct{}
+}
+
+func newFakeReader() *fakeReader {
+	return &fakeReader{
+		packets: make(chan fakePacket, 10),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (r *fakeReader) ReadPacket(buf []byte) (int, bool, error) {
+	select {
+	case packet := <-r.packets:
+		copy(buf, packet.data)
+		return len(packet.data), packet.outgoing, nil
+	case <-time.After(10 * time.Millisecond):
+		return 0, false, unix.EAGAIN
+	}
+}
+
+func (r *fakeReader) Close() error {
+	close(r.closed)
+	return nil
+}
+
+// collectMetrics returns the metrics of the collector with the label values of each metric
+func collectMetrics(collector prometheus.Collector) []*io_prometheus_client.Metric {
+	ch := make(chan prometheus.Metric, 100)
+	collector.Collect(ch)
+	close(ch)
+	var metrics []*io_prometheus_client.Metric
+	for metric := range ch {
+		dto := &io_prometheus_client.Metric{}
+		Expect(metric.Write(dto)).To(Succeed())
+		metrics = append(metrics, dto)
+	}
+	return metrics
+}
+
+func labelValues(metric *io_prometheus_client.Metric) map[string]string {
+	values := map[string]string{}
+	for _, label := range metric.Label {
+		values[label.GetName()] = label.GetValue()
+	}
+	return values
+}
+
+var _ = Describe("Collector", func() {
+
+	var (
+		vmiInformer cache.SharedIndexInformer
+		collector   *Collector
+		vmi         *v1.VirtualMachineInstance
+		reader      *fakeReader
+		devices     []string
+	)
+
+	newCollector := func(featureGates string) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+			Data: map[string]string{virtconfig.FeatureGatesKey: featureGates},
+		})
+		collector = NewCollector("testnode", vmiInformer, nil, clusterConfig)
+		collector.openDevice = func(_ *v1.VirtualMachineInstance, device string) (packetReader, error) {
+			devices = append(devices, device)
+			return reader, nil
+		}
+	}
+
+	BeforeEach(func() {
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.Namespace = "default"
+		vmi.UID = "1234"
+		vmi.Status.NodeName = "testnode"
+		vmi.Status.Phase = v1.Running
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+		reader = newFakeReader()
+		devices = nil
+	})
+
+	AfterEach(func() {
+		collector.Run(time.Millisecond, alreadyStopped())
+	})
+
+	It("should not capture anything while the feature gate is disabled", func() {
+		newCollector("")
+		collector.Sync()
+		Expect(devices).To(BeEmpty())
+		Expect(collectMetrics(collector)).To(BeEmpty())
+	})
+
+	It("should not capture the interfaces of VMIs on other nodes", func() {
+		newCollector(virtconfig.FlowMetricsGate)
+		vmi.Status.NodeName = "othernode"
+		collector.Sync()
+		Expect(devices).To(BeEmpty())
+	})
+
+	It("should expose the flows of the tap device of an interface", func() {
+		newCollector(virtconfig.FlowMetricsGate)
+		collector.Sync()
+		collector.Sync()
+		Expect(devices).To(Equal([]string{"tap0"}))
+
+		reader.packets <- fakePacket{data: ethernetFrame(false, "10.0.2.2", "10.0.2.1", 6, 40000, 443)}
+		reader.packets <- fakePacket{data: ethernetFrame(false, "10.0.2.1", "10.0.2.2", 6, 443, 40000), outgoing: true}
+		Eventually(func() int {
+			return len(collectMetrics(collector))
+		}).Should(Equal(5))
+
+		var egressBytes *io_prometheus_client.Metric
+		for _, metric := range collectMetrics(collector) {
+			labels := labelValues(metric)
+			if labels["direction"] == DirectionEgress && labels["src_port"] == "40000" {
+				egressBytes = metric
+				break
+			}
+		}
+		Expect(egressBytes).ToNot(BeNil())
+		Expect(labelValues(egressBytes)).To(Equal(map[string]string{
+			"node":        "testnode",
+			"namespace":   "default",
+			"name":        "testvmi",
+			"interface":   "default",
+			"direction":   DirectionEgress,
+			"protocol":    "tcp",
+			"src_address": "10.0.2.2",
+			"src_port":    "40000",
+			"dst_address": "10.0.2.1",
+			"dst_port":    "443",
+		}))
+		Expect(egressBytes.Counter.GetValue()).To(BeNumerically(">", 0))
+	})
+
+	It("should stop capturing when the VMI is gone", func() {
+		newCollector(virtconfig.FlowMetricsGate)
+		collector.Sync()
+		Expect(devices).To(HaveLen(1))
+
+		Expect(vmiInformer.GetStore().Delete(vmi)).To(Succeed())
+		collector.Sync()
+		Eventually(reader.closed).Should(BeClosed())
+		Expect(collectMetrics(collector)).To(BeEmpty())
+	})
+})
+
+func alreadyStopped() chan struct{} {
+	stop := make(chan struct{})
+	close(stop)
+	return stop
+}
@@ -0,0 +1,249 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"encoding/binary"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Direction of a flow as seen from the guest
+	DirectionIngress = "ingress"
+	DirectionEgress  = "egress"
+
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+	etherTypeVLAN = 0x8100
+	etherTypeQinQ = 0x88a8
+
+	ethernetHeaderLen = 14
+	vlanTagLen        = 4
+	ipv6HeaderLen     = 40
+)
+
+// Flow end reasons, named after the flowEndReason information element of IPFIX (RFC 7012)
+const (
+	FlowEndReasonIdleTimeout   = "idleTimeout"
+	FlowEndReasonActiveTimeout = "activeTimeout"
+	FlowEndReasonForcedEnd     = "forcedEnd"
+)
+
+// FlowKey identifies a unidirectional flow of a VMI interface
+type FlowKey struct {
+	Direction string
+	Protocol  string
+	SrcIP     string
+	DstIP     string
+	SrcPort   uint16
+	DstPort   uint16
+}
+
+// FlowRecord reports the traffic of a flow since its previous record, in the manner of a NetFlow or IPFIX record
+type FlowRecord struct {
+	FlowKey
+	Start     time.Time
+	End       time.Time
+	Bytes     uint64
+	Packets   uint64
+	EndReason string
+}
+
+type flowCounters struct {
+	bytes     uint64
+	packets   uint64
+	firstSeen time.Time
+	lastSeen  time.Time
+	// the counters and the time of the last record of the flow
+	exportedBytes   uint64
+	exportedPackets uint64
+	exportedAt      time.Time
+}
+
+// parsePacket returns the key of the flow an ethernet frame belongs to, without direction.
+// Frames which don't carry IP packets are ignored.
+func parsePacket(data []byte) (FlowKey, bool) {
+	key := FlowKey{}
+	if len(data) < ethernetHeaderLen {
+		return key, false
+	}
+	offset := ethernetHeaderLen
+	etherType := binary.BigEndian.Uint16(data[12:14])
+	for etherType == etherTypeVLAN || etherType == etherTypeQinQ {
+		if len(data) < offset+vlanTagLen {
+			return key, false
+		}
+		etherType = binary.BigEndian.Uint16(data[offset+2 : offset+4])
+		offset += vlanTagLen
+	}
+	packet := data[offset:]
+
+	var protocol uint8
+	var payload []byte
+	switch etherType {
+	case etherTypeIPv4:
+		if len(packet) < 20 {
+			return key, false
+		}
+		headerLen := int(packet[0]&0x0f) * 4
+		protocol = packet[9]
+		key.SrcIP = net.IP(packet[12:16]).String()
+		key.DstIP = net.IP(packet[16:20]).String()
+		// only the first fragment carries the transport header
+		if fragmentOffset := binary.BigEndian.Uint16(packet[6:8]) & 0x1fff; fragmentOffset == 0 && len(packet) >= headerLen {
+			payload = packet[headerLen:]
+		}
+	case etherTypeIPv6:
+		if len(packet) < ipv6HeaderLen {
+			return key, false
+		}
+		// extension headers are not followed, their packets are accounted to the protocol of the first one
+		protocol = packet[6]
+		key.SrcIP = net.IP(packet[8:24]).String()
+		key.DstIP = net.IP(packet[24:40]).String()
+		payload = packet[ipv6HeaderLen:]
+	default:
+		return key, false
+	}
+
+	key.Protocol = protocolName(protocol)
+	switch key.Protocol {
+	case "tcp", "udp", "sctp":
+		if len(payload) >= 4 {
+			key.SrcPort = binary.BigEndian.Uint16(payload[0:2])
+			key.DstPort = binary.BigEndian.Uint16(payload[2:4])
+		}
+	}
+	return key, true
+}
+
+func protocolName(protocol uint8) string {
+	switch protocol {
+	case 1:
+		return "icmp"
+	case 6:
+		return "tcp"
+	case 17:
+		return "udp"
+	case 58:
+		return "icmpv6"
+	case 132:
+		return "sctp"
+	default:
+		return strconv.Itoa(int(protocol))
+	}
+}
+
+// flowTable accounts the traffic of the flows of an interface. The number of flows is bounded,
+// packets of new flows are only counted as untracked when the table is full.
+type flowTable struct {
+	lock             sync.Mutex
+	maxFlows         int
+	flows            map[FlowKey]*flowCounters
+	untrackedPackets uint64
+}
+
+func newFlowTable(maxFlows int) *flowTable {
+	return &flowTable{
+		maxFlows: maxFlows,
+		flows:    map[FlowKey]*flowCounters{},
+	}
+}
+
+func (t *flowTable) add(key FlowKey, length int, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	counters, exists := t.flows[key]
+	if !exists {
+		if len(t.flows) >= t.maxFlows {
+			t.untrackedPackets++
+			return
+		}
+		counters = &flowCounters{firstSeen: now, exportedAt: now}
+		t.flows[key] = counters
+	}
+	counters.bytes += uint64(length)
+	counters.packets++
+	counters.lastSeen = now
+}
+
+// expire removes the flows which were idle for idleTimeout and returns their final records, along with
+// intermediate records of the flows which were not reported for activeTimeout
+func (t *flowTable) expire(now time.Time, idleTimeout, activeTimeout time.Duration) []FlowRecord {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var records []FlowRecord
+	for key, counters := range t.flows {
+		switch {
+		case now.Sub(counters.lastSeen) >= idleTimeout:
+			records = append(records, counters.record(key, now, FlowEndReasonIdleTimeout))
+			delete(t.flows, key)
+		case now.Sub(counters.exportedAt) >= activeTimeout && counters.packets > counters.exportedPackets:
+			records = append(records, counters.record(key, now, FlowEndReasonActiveTimeout))
+		}
+	}
+	return records
+}
+
+// flush removes all flows and returns their final records
+func (t *flowTable) flush(now time.Time) []FlowRecord {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var records []FlowRecord
+	for key, counters := range t.flows {
+		records = append(records, counters.record(key, now, FlowEndReasonForcedEnd))
+		delete(t.flows, key)
+	}
+	return records
+}
+
+// snapshot returns the total traffic of every flow and the number of untracked packets
+func (t *flowTable) snapshot() (map[FlowKey]flowCounters, uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	flows := make(map[FlowKey]flowCounters, len(t.flows))
+	for key, counters := range t.flows {
+		flows[key] = *counters
+	}
+	return flows, t.untrackedPackets
+}
+
+// record returns the traffic since the previous record and marks it as reported
+func (c *flowCounters) record(key FlowKey, now time.Time, reason string) FlowRecord {
+	record := FlowRecord{
+		FlowKey:   key,
+		Start:     c.exportedAt,
+		End:       c.lastSeen,
+		Bytes:     c.bytes - c.exportedBytes,
+		Packets:   c.packets - c.exportedPackets,
+		EndReason: reason,
+	}
+	c.exportedBytes = c.bytes
+	c.exportedPackets = c.packets
+	c.exportedAt = now
+	return record
+}
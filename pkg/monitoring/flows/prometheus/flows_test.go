@@ -0,0 +1,170 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// ethernetFrame builds the headers of a frame carrying an IP packet of the protocol with the given ports
+func ethernetFrame(vlan bool, src, dst string, protocol uint8, srcPort, dstPort uint16) []byte {
+	frame := make([]byte, 12)
+	if vlan {
+		frame = append(frame, 0x81, 0x00, 0x00, 0x0a)
+	}
+	srcIP, dstIP := net.ParseIP(src), net.ParseIP(dst)
+	if ipv4 := srcIP.To4(); ipv4 != nil {
+		header := make([]byte, 20)
+		header[0] = 0x45
+		header[9] = protocol
+		copy(header[12:16], ipv4)
+		copy(header[16:20], dstIP.To4())
+		frame = append(append(frame, 0x08, 0x00), header...)
+	} else {
+		header := make([]byte, 40)
+		header[0] = 0x60
+		header[6] = protocol
+		copy(header[8:24], srcIP)
+		copy(header[24:40], dstIP)
+		frame = append(append(frame, 0x86, 0xdd), header...)
+	}
+	ports := make([]byte, 4)
+	binary.BigEndian.PutUint16(ports[0:2], srcPort)
+	binary.BigEndian.PutUint16(ports[2:4], dstPort)
+	return append(frame, ports...)
+}
+
+var _ = Describe("Flows", func() {
+
+	table.DescribeTable("should parse the flow of a packet", func(frame []byte, expected FlowKey) {
+		key, ok := parsePacket(frame)
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal(expected))
+	},
+		table.Entry("IPv4 TCP", ethernetFrame(false, "10.0.2.2", "10.0.2.1", 6, 40000, 443),
+			FlowKey{Protocol: "tcp", SrcIP: "10.0.2.2", DstIP: "10.0.2.1", SrcPort: 40000, DstPort: 443}),
+		table.Entry("IPv4 UDP with a VLAN tag", ethernetFrame(true, "10.0.2.2", "10.0.2.1", 17, 5353, 53),
+			FlowKey{Protocol: "udp", SrcIP: "10.0.2.2", DstIP: "10.0.2.1", SrcPort: 5353, DstPort: 53}),
+		table.Entry("IPv6 UDP", ethernetFrame(false, "fd10:0:2::2", "fd10:0:2::1", 17, 546, 547),
+			FlowKey{Protocol: "udp", SrcIP: "fd10:0:2::2", DstIP: "fd10:0:2::1", SrcPort: 546, DstPort: 547}),
+		table.Entry("ICMP without ports", ethernetFrame(false, "10.0.2.2", "10.0.2.1", 1, 0x0800, 0x1234),
+			FlowKey{Protocol: "icmp", SrcIP: "10.0.2.2", DstIP: "10.0.2.1"}),
+		table.Entry("unknown protocol", ethernetFrame(false, "10.0.2.2", "10.0.2.1", 47, 1, 1),
+			FlowKey{Protocol: "47", SrcIP: "10.0.2.2", DstIP: "10.0.2.1"}),
+	)
+
+	It("should not read ports from non-first IPv4 fragments", func() {
+		frame := ethernetFrame(false, "10.0.2.2", "10.0.2.1", 17, 5353, 53)
+		binary.BigEndian.PutUint16(frame[20:22], 0x00b9)
+		key, ok := parsePacket(frame)
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal(FlowKey{Protocol: "udp", SrcIP: "10.0.2.2", DstIP: "10.0.2.1"}))
+	})
+
+	It("should ignore frames without IP packets", func() {
+		arp := make([]byte, 42)
+		arp[12], arp[13] = 0x08, 0x06
+		_, ok := parsePacket(arp)
+		Expect(ok).To(BeFalse())
+
+		_, ok = parsePacket(ethernetFrame(false, "10.0.2.2", "10.0.2.1", 6, 1, 2)[:30])
+		Expect(ok).To(BeFalse())
+	})
+
+	Context("table", func() {
+		var (
+			flows *flowTable
+			now   time.Time
+			key   FlowKey
+			other FlowKey
+		)
+
+		BeforeEach(func() {
+			flows = newFlowTable(2)
+			now = time.Unix(1000, 0)
+			key = FlowKey{Direction: DirectionEgress, Protocol: "tcp", SrcIP: "10.0.2.2", DstIP: "10.0.2.1", SrcPort: 40000, DstPort: 443}
+			other = FlowKey{Direction: DirectionIngress, Protocol: "tcp", SrcIP: "10.0.2.1", DstIP: "10.0.2.2", SrcPort: 443, DstPort: 40000}
+		})
+
+		It("should account packets to their flow", func() {
+			flows.add(key, 100, now)
+			flows.add(key, 50, now.Add(time.Second))
+			flows.add(other, 1500, now)
+
+			snapshot, untracked := flows.snapshot()
+			Expect(untracked).To(BeZero())
+			Expect(snapshot).To(HaveLen(2))
+			Expect(snapshot[key].bytes).To(BeEquivalentTo(150))
+			Expect(snapshot[key].packets).To(BeEquivalentTo(2))
+			Expect(snapshot[other].bytes).To(BeEquivalentTo(1500))
+		})
+
+		It("should count the packets of new flows as untracked when the table is full", func() {
+			flows.add(key, 100, now)
+			flows.add(other, 100, now)
+			third := key
+			third.SrcPort = 40001
+			flows.add(third, 100, now)
+			flows.add(key, 100, now)
+
+			snapshot, untracked := flows.snapshot()
+			Expect(untracked).To(BeEquivalentTo(1))
+			Expect(snapshot).To(HaveLen(2))
+			Expect(snapshot[key].packets).To(BeEquivalentTo(2))
+		})
+
+		It("should remove idle flows and report their final record", func() {
+			flows.add(key, 100, now)
+			flows.add(other, 100, now)
+			flows.add(other, 100, now.Add(20*time.Second))
+
+			records := flows.expire(now.Add(30*time.Second), 30*time.Second, 5*time.Minute)
+			Expect(records).To(Equal([]FlowRecord{
+				{FlowKey: key, Start: now, End: now, Bytes: 100, Packets: 1, EndReason: FlowEndReasonIdleTimeout},
+			}))
+			snapshot, _ := flows.snapshot()
+			Expect(snapshot).To(HaveKey(other))
+			Expect(snapshot).ToNot(HaveKey(key))
+		})
+
+		It("should report the traffic of long lived flows since their previous record", func() {
+			flows.add(key, 100, now)
+			flows.add(key, 100, now.Add(290*time.Second))
+			records := flows.expire(now.Add(5*time.Minute), 30*time.Second, 5*time.Minute)
+			Expect(records).To(Equal([]FlowRecord{
+				{FlowKey: key, Start: now, End: now.Add(290 * time.Second), Bytes: 200, Packets: 2, EndReason: FlowEndReasonActiveTimeout},
+			}))
+
+			flows.add(key, 50, now.Add(310*time.Second))
+			records = flows.flush(now.Add(320 * time.Second))
+			Expect(records).To(Equal([]FlowRecord{
+				{FlowKey: key, Start: now.Add(5 * time.Minute), End: now.Add(310 * time.Second), Bytes: 50, Packets: 1, EndReason: FlowEndReasonForcedEnd},
+			}))
+			snapshot, _ := flows.snapshot()
+			Expect(snapshot).To(BeEmpty())
+		})
+	})
+})
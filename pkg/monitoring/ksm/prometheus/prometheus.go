@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-handler/ksm"
+)
+
+var (
+	runningDesc = prometheus.NewDesc(
+		"kubevirt_ksm_running",
+		"Whether kernel samepage merging is running on the node.",
+		[]string{"node"},
+		nil,
+	)
+	pagesSharedDesc = prometheus.NewDesc(
+		"kubevirt_ksm_pages_shared",
+		"Number of shared pages which are in use on the node.",
+		[]string{"node"},
+		nil,
+	)
+	pagesSharingDesc = prometheus.NewDesc(
+		"kubevirt_ksm_pages_sharing",
+		"Number of pages on the node which are sharing a page with other pages.",
+		[]string{"node"},
+		nil,
+	)
+	savedBytesDesc = prometheus.NewDesc(
+		"kubevirt_ksm_saved_bytes",
+		"Memory saved on the node by kernel samepage merging, in bytes.",
+		[]string{"node"},
+		nil,
+	)
+)
+
+// Collector exposes the kernel samepage merging counters of the node
+type Collector struct {
+	ksmPath  string
+	nodeName string
+}
+
+func SetupCollector(nodeName string) *Collector {
+	co := &Collector{
+		ksmPath:  ksm.KSMPath,
+		nodeName: nodeName,
+	}
+	prometheus.MustRegister(co)
+	return co
+}
+
+func (co *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- runningDesc
+	ch <- pagesSharedDesc
+	ch <- pagesSharingDesc
+	ch <- savedBytesDesc
+}
+
+func (co *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := ksm.ReadStats(co.ksmPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Log.Reason(err).Error("failed to read the KSM counters")
+		}
+		return
+	}
+
+	running := 0.0
+	if stats.Running {
+		running = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(runningDesc, prometheus.GaugeValue, running, co.nodeName)
+	ch <- prometheus.MustNewConstMetric(pagesSharedDesc, prometheus.GaugeValue, float64(stats.PagesShared), co.nodeName)
+	ch <- prometheus.MustNewConstMetric(pagesSharingDesc, prometheus.GaugeValue, float64(stats.PagesSharing), co.nodeName)
+	ch <- prometheus.MustNewConstMetric(savedBytesDesc, prometheus.GaugeValue, float64(stats.PagesSharing*uint64(os.Getpagesize())), co.nodeName)
+}
@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+)
+
+var _ = Describe("KSM collector", func() {
+
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "ksm")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	collect := func() map[string]float64 {
+		co := &Collector{ksmPath: tmpDir, nodeName: "testnode"}
+		ch := make(chan prometheus.Metric, 10)
+		co.Collect(ch)
+		close(ch)
+
+		values := map[string]float64{}
+		for metric := range ch {
+			dto := &io_prometheus_client.Metric{}
+			Expect(metric.Write(dto)).To(Succeed())
+			values[metric.Desc().String()] = dto.GetGauge().GetValue()
+		}
+		return values
+	}
+
+	It("should expose the KSM counters", func() {
+		for name, value := range map[string]string{
+			"run":            "1",
+			"pages_shared":   "10",
+			"pages_sharing":  "200",
+			"pages_unshared": "30",
+			"full_scans":     "4",
+		} {
+			Expect(ioutil.WriteFile(filepath.Join(tmpDir, name), []byte(value), 0644)).To(Succeed())
+		}
+
+		values := collect()
+		Expect(values).To(HaveLen(4))
+		Expect(values[runningDesc.String()]).To(Equal(1.0))
+		Expect(values[pagesSharedDesc.String()]).To(Equal(10.0))
+		Expect(values[pagesSharingDesc.String()]).To(Equal(200.0))
+		Expect(values[savedBytesDesc.String()]).To(Equal(float64(200 * os.Getpagesize())))
+	})
+
+	It("should not expose anything if the kernel does not support KSM", func() {
+		Expect(collect()).To(BeEmpty())
+	})
+})
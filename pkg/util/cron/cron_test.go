@@ -0,0 +1,81 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+
+	// a Wednesday
+	start := time.Date(2022, time.March, 2, 10, 17, 30, 0, time.UTC)
+
+	table.DescribeTable("should find the next time matching", func(expression string, expected time.Time) {
+		schedule, err := Parse(expression)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(start)).To(Equal(expected))
+	},
+		table.Entry("every minute", "* * * * *", time.Date(2022, time.March, 2, 10, 18, 0, 0, time.UTC)),
+		table.Entry("every 15 minutes", "*/15 * * * *", time.Date(2022, time.March, 2, 10, 30, 0, 0, time.UTC)),
+		table.Entry("a list of hours", "0 3,12 * * *", time.Date(2022, time.March, 2, 12, 0, 0, 0, time.UTC)),
+		table.Entry("a range of hours", "30 1-5 * * *", time.Date(2022, time.March, 3, 1, 30, 0, 0, time.UTC)),
+		table.Entry("a step from an offset", "5/20 * * * *", time.Date(2022, time.March, 2, 10, 25, 0, 0, time.UTC)),
+		table.Entry("the day of the month", "0 0 1 * *", time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		table.Entry("the day of the week", "0 0 * * 1", time.Date(2022, time.March, 7, 0, 0, 0, 0, time.UTC)),
+		table.Entry("Sunday as 7", "0 0 * * 7", time.Date(2022, time.March, 6, 0, 0, 0, 0, time.UTC)),
+		table.Entry("either day if both are restricted", "0 0 15 * 5", time.Date(2022, time.March, 4, 0, 0, 0, 0, time.UTC)),
+		table.Entry("the month", "0 0 1 1 *", time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		table.Entry("a leap day", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		table.Entry("@hourly", "@hourly", time.Date(2022, time.March, 2, 11, 0, 0, 0, time.UTC)),
+		table.Entry("@daily", "@daily", time.Date(2022, time.March, 3, 0, 0, 0, 0, time.UTC)),
+		table.Entry("@weekly", "@weekly", time.Date(2022, time.March, 6, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should never match impossible dates", func() {
+		schedule, err := Parse("0 0 30 2 *")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(start).IsZero()).To(BeTrue())
+	})
+
+	It("should evaluate the schedule in UTC", func() {
+		schedule, err := Parse("0 3 * * *")
+		Expect(err).ToNot(HaveOccurred())
+		local := start.In(time.FixedZone("UTC+5", 5*3600))
+		Expect(schedule.Next(local)).To(Equal(time.Date(2022, time.March, 3, 3, 0, 0, 0, time.UTC)))
+	})
+
+	table.DescribeTable("should reject invalid expressions", func(expression string) {
+		_, err := Parse(expression)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("with too few fields", "* * * *"),
+		table.Entry("with an unknown macro", "@often"),
+		table.Entry("with a minute out of range", "60 * * * *"),
+		table.Entry("with a day of month out of range", "0 0 0 * *"),
+		table.Entry("with an inverted range", "0 5-1 * * *"),
+		table.Entry("with an invalid step", "*/0 * * * *"),
+		table.Entry("with names", "0 0 * * MON"),
+	)
+})
@@ -20,7 +20,11 @@
 package hardware
 
 import (
+	"bufio"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -29,7 +33,9 @@ import (
 )
 
 const (
-	CPUSET_PATH = "/sys/fs/cgroup/cpuset/cpuset.cpus"
+	CPUSET_PATH     = "/sys/fs/cgroup/cpuset/cpuset.cpus"
+	NUMA_NODES_PATH = "/sys/devices/system/node"
+	MEMINFO_PATH    = "/proc/meminfo"
 
 	PCI_ADDRESS_PATTERN = `^([\da-fA-F]{4}):([\da-fA-F]{2}):([\da-fA-F]{2})\.([0-7]{1})$`
 )
@@ -65,6 +71,123 @@ func ParseCPUSetLine(cpusetLine string) (cpusList []int, err error) {
 	return
 }
 
+// ParseCPUMask parses a libvirt cpuset mask expression like "0-3,^1" into the list of selected CPUs
+func ParseCPUMask(mask string) ([]int, error) {
+	var included, excluded []string
+	for _, item := range strings.Split(mask, ",") {
+		if strings.HasPrefix(item, "^") {
+			excluded = append(excluded, strings.TrimPrefix(item, "^"))
+		} else {
+			included = append(included, item)
+		}
+	}
+	if len(included) == 0 {
+		return nil, fmt.Errorf("mask %s does not select any CPU", mask)
+	}
+	cpus, err := ParseCPUSetLine(strings.Join(included, ","))
+	if err != nil {
+		return nil, err
+	}
+	if len(excluded) == 0 {
+		return cpus, nil
+	}
+	excludedCPUs, err := ParseCPUSetLine(strings.Join(excluded, ","))
+	if err != nil {
+		return nil, err
+	}
+	isExcluded := map[int]bool{}
+	for _, cpu := range excludedCPUs {
+		isExcluded[cpu] = true
+	}
+	var selected []int
+	for _, cpu := range cpus {
+		if !isExcluded[cpu] {
+			selected = append(selected, cpu)
+		}
+	}
+	return selected, nil
+}
+
+// GetCPUNUMANodes returns the host NUMA node of each host CPU
+func GetCPUNUMANodes() (map[int]int, error) {
+	return getCPUNUMANodes(NUMA_NODES_PATH)
+}
+
+func getCPUNUMANodes(nodesPath string) (map[int]int, error) {
+	nodeDirs, err := filepath.Glob(filepath.Join(nodesPath, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	cpuNodes := map[int]int{}
+	for _, nodeDir := range nodeDirs {
+		node, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(nodeDir), "node"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the NUMA node of %s: %v", nodeDir, err)
+		}
+		content, err := ioutil.ReadFile(filepath.Join(nodeDir, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		// NUMA nodes without CPUs, e.g. with persistent memory only, have an empty cpulist
+		cpulist := strings.TrimSpace(string(content))
+		if cpulist == "" {
+			continue
+		}
+		cpus, err := ParseCPUSetLine(cpulist)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the CPUs of NUMA node %d: %v", node, err)
+		}
+		for _, cpu := range cpus {
+			cpuNodes[cpu] = node
+		}
+	}
+	return cpuNodes, nil
+}
+
+// GetMemInfo returns the total and the available memory of the host in KiB
+func GetMemInfo() (uint64, uint64, error) {
+	return ReadMemInfo(MEMINFO_PATH)
+}
+
+// ReadMemInfo returns the total and the available memory in KiB from the meminfo file at path
+func ReadMemInfo(path string) (uint64, uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		key := strings.TrimSuffix(fields[0], ":")
+		if key != "MemTotal" && key != "MemAvailable" {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to parse %s in %s: %v", key, path, err)
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	total, ok := values["MemTotal"]
+	if !ok {
+		return 0, 0, fmt.Errorf("no MemTotal found in %s", path)
+	}
+	available, ok := values["MemAvailable"]
+	if !ok {
+		return 0, 0, fmt.Errorf("no MemAvailable found in %s", path)
+	}
+	return total, available, nil
+}
+
 //GetNumberOfVCPUs returns number of vCPUs
 //It counts sockets*cores*threads
 func GetNumberOfVCPUs(cpuSpec *v1.CPU) int64 {
@@ -20,6 +20,10 @@
 package hardware
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -39,6 +43,87 @@ var _ = Describe("Hardware utils test", func() {
 		})
 	})
 
+	Context("cpu mask parser", func() {
+		It("should parse a mask with exclusions", func() {
+			cpus, err := ParseCPUMask("0-3,^1,6")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cpus).To(Equal([]int{0, 2, 3, 6}))
+		})
+
+		It("should fail on a mask without included CPUs", func() {
+			_, err := ParseCPUMask("^1")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail on a malformed mask", func() {
+			_, err := ParseCPUMask("0-a")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("NUMA nodes of the host CPUs", func() {
+		var nodesPath string
+
+		BeforeEach(func() {
+			var err error
+			nodesPath, err = ioutil.TempDir("", "nodes")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(nodesPath)
+		})
+
+		writeCPUList := func(node, cpulist string) {
+			Expect(os.MkdirAll(filepath.Join(nodesPath, node), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(nodesPath, node, "cpulist"), []byte(cpulist+"\n"), 0644)).To(Succeed())
+		}
+
+		It("should map every CPU to its NUMA node", func() {
+			writeCPUList("node0", "0-1,4")
+			writeCPUList("node1", "2-3,5")
+			writeCPUList("node2", "")
+			cpuNodes, err := getCPUNUMANodes(nodesPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cpuNodes).To(Equal(map[int]int{0: 0, 1: 0, 4: 0, 2: 1, 3: 1, 5: 1}))
+		})
+
+		It("should fail on a malformed cpulist", func() {
+			writeCPUList("node0", "0-a")
+			_, err := getCPUNUMANodes(nodesPath)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("memory of the host", func() {
+		var memInfoPath string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "meminfo")
+			Expect(err).ToNot(HaveOccurred())
+			file.Close()
+			memInfoPath = file.Name()
+		})
+
+		AfterEach(func() {
+			os.Remove(memInfoPath)
+		})
+
+		It("should read the total and the available memory", func() {
+			Expect(ioutil.WriteFile(memInfoPath, []byte("MemTotal:       16303428 kB\nMemFree:         1022960 kB\nMemAvailable:    9543632 kB\n"), 0644)).To(Succeed())
+			total, available, err := ReadMemInfo(memInfoPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(Equal(uint64(16303428)))
+			Expect(available).To(Equal(uint64(9543632)))
+		})
+
+		It("should fail without the available memory", func() {
+			Expect(ioutil.WriteFile(memInfoPath, []byte("MemTotal:       16303428 kB\n"), 0644)).To(Succeed())
+			_, _, err := ReadMemInfo(memInfoPath)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context("count vCPUs", func() {
 		It("shoud count vCPUs correctly", func() {
 			vCPUs := GetNumberOfVCPUs(&v1.CPU{
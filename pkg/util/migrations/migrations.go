@@ -1,6 +1,10 @@
 package migrations
 
 import (
+	"fmt"
+	"net"
+
+	k8sv1 "k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
@@ -48,3 +52,70 @@ func IsMigrating(vmi *v1.VirtualMachineInstance) bool {
 	}
 	return running
 }
+
+// IsBlockMigration returns true if disks of the VMI are copied to the migration target, either because they are
+// local or because the migration moves them to other PVCs.
+func IsBlockMigration(vmi *v1.VirtualMachineInstance) bool {
+	if vmi.Status.MigrationMethod == v1.BlockMigration {
+		return true
+	}
+	return vmi.Status.MigrationState != nil && len(vmi.Status.MigrationState.MigratedVolumes) > 0
+}
+
+// ReplaceMigratedVolumes returns a copy of the volumes in which the migrated volumes refer to their destination claims.
+func ReplaceMigratedVolumes(volumes []v1.Volume, migratedVolumes []v1.MigratedVolume) []v1.Volume {
+	destinations := map[string]string{}
+	for _, migratedVolume := range migratedVolumes {
+		destinations[migratedVolume.VolumeName] = migratedVolume.DestinationClaimName
+	}
+
+	replaced := make([]v1.Volume, 0, len(volumes))
+	for _, volume := range volumes {
+		volume := *volume.DeepCopy()
+		if claimName, exists := destinations[volume.Name]; exists {
+			volume.VolumeSource = v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			}
+		}
+		replaced = append(replaced, volume)
+	}
+	return replaced
+}
+
+// MigrationInterfaceName is the interface of the virt-handler pod attached to the migration network
+const MigrationInterfaceName = "migration0"
+
+// FindMigrationIP returns the address of the migration network interface in the network namespace of the caller,
+// preferring the IP family of the pod address. The pod address is returned if the interface does not exist.
+func FindMigrationIP(podIP string) (string, error) {
+	iface, err := net.InterfaceByName(MigrationInterfaceName)
+	if err != nil {
+		return podIP, nil
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return "", fmt.Errorf("failed to list the addresses of %s: %v", MigrationInterfaceName, err)
+	}
+	return selectMigrationIP(addrs, net.ParseIP(podIP))
+}
+
+func selectMigrationIP(addrs []net.Addr, podIP net.IP) (string, error) {
+	isIPv4 := podIP == nil || podIP.To4() != nil
+	var fallback net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+		if (ipNet.IP.To4() != nil) == isIPv4 {
+			return ipNet.IP.String(), nil
+		}
+		if fallback == nil {
+			fallback = ipNet.IP
+		}
+	}
+	if fallback == nil {
+		return "", fmt.Errorf("%s has no global unicast address", MigrationInterfaceName)
+	}
+	return fallback.String(), nil
+}
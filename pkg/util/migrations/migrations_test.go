@@ -0,0 +1,111 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package migrations
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Migration network", func() {
+	addrs := func(cidrs ...string) []net.Addr {
+		var result []net.Addr
+		for _, cidr := range cidrs {
+			ip, ipNet, err := net.ParseCIDR(cidr)
+			Expect(err).ToNot(HaveOccurred())
+			ipNet.IP = ip
+			result = append(result, ipNet)
+		}
+		return result
+	}
+
+	It("should select the address of the pod IP family", func() {
+		ip, err := selectMigrationIP(addrs("fe80::1/64", "fd10::5/64", "172.16.0.5/24"), net.ParseIP("10.244.0.10"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ip).To(Equal("172.16.0.5"))
+
+		ip, err = selectMigrationIP(addrs("172.16.0.5/24", "fd10::5/64"), net.ParseIP("fd00::10"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ip).To(Equal("fd10::5"))
+	})
+
+	It("should fall back to an address of the other IP family", func() {
+		ip, err := selectMigrationIP(addrs("fd10::5/64"), net.ParseIP("10.244.0.10"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ip).To(Equal("fd10::5"))
+	})
+
+	It("should fail without a global unicast address", func() {
+		_, err := selectMigrationIP(addrs("fe80::1/64"), net.ParseIP("10.244.0.10"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Volume migration", func() {
+	It("should replace the migrated volumes by their destination claims", func() {
+		volumes := []v1.Volume{
+			{
+				Name: "pvc",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "source-pvc"},
+				},
+			},
+			{
+				Name: "dv",
+				VolumeSource: v1.VolumeSource{
+					DataVolume: &v1.DataVolumeSource{Name: "source-dv"},
+				},
+			},
+			{
+				Name: "other",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "other-pvc"},
+				},
+			},
+		}
+
+		replaced := ReplaceMigratedVolumes(volumes, []v1.MigratedVolume{
+			{VolumeName: "pvc", DestinationClaimName: "destination-pvc"},
+			{VolumeName: "dv", DestinationClaimName: "destination-dv"},
+		})
+		Expect(replaced).To(HaveLen(3))
+		Expect(replaced[0].PersistentVolumeClaim.ClaimName).To(Equal("destination-pvc"))
+		Expect(replaced[1].DataVolume).To(BeNil())
+		Expect(replaced[1].PersistentVolumeClaim.ClaimName).To(Equal("destination-dv"))
+		Expect(replaced[2].PersistentVolumeClaim.ClaimName).To(Equal("other-pvc"))
+		Expect(volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("source-pvc"))
+	})
+
+	It("should copy the disks of migrated volumes", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Status.MigrationMethod = v1.LiveMigration
+		Expect(IsBlockMigration(vmi)).To(BeFalse())
+
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+			MigratedVolumes: []v1.MigratedVolume{{VolumeName: "pvc", DestinationClaimName: "destination-pvc"}},
+		}
+		Expect(IsBlockMigration(vmi)).To(BeTrue())
+	})
+})
@@ -0,0 +1,135 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package multipath
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// uuidPrefix is the prefix of the device mapper uuid of the maps created by multipathd
+	uuidPrefix = "mpath-"
+	// runningState is the SCSI device state of a path which is able to serve I/O
+	runningState = "running"
+)
+
+var sysfsPath = "/sys"
+
+// Status describes the multipath map backing a block device and the health of its paths
+type Status struct {
+	// Map is the name of the multipath map, e.g. mpatha
+	Map string
+	// PathMember is true if the block device is one of the paths of the map instead of the map itself,
+	// I/O on it is not retried on the other paths
+	PathMember bool
+	// Paths are the names of the block devices of all the paths of the map, sorted
+	Paths []string
+	// FailedPaths are the names of the paths which are not able to serve I/O, sorted
+	FailedPaths []string
+}
+
+// ActivePaths returns the number of paths of the map which are able to serve I/O
+func (s *Status) ActivePaths() int {
+	return len(s.Paths) - len(s.FailedPaths)
+}
+
+// GetStatus returns the status of the multipath map backing the block device at devicePath,
+// or nil if the block device is not backed by a multipath map.
+func GetStatus(devicePath string) (*Status, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(devicePath, &stat); err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %v", devicePath, err)
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFBLK {
+		return nil, fmt.Errorf("%s is not a block device", devicePath)
+	}
+	return getStatus(unix.Major(uint64(stat.Rdev)), unix.Minor(uint64(stat.Rdev)))
+}
+
+func getStatus(major, minor uint32) (*Status, error) {
+	devPath, err := filepath.EvalSymlinks(filepath.Join(sysfsPath, "dev", "block", fmt.Sprintf("%d:%d", major, minor)))
+	if err != nil {
+		return nil, err
+	}
+	device := filepath.Base(devPath)
+
+	if isMultipathMap(device) {
+		return getMapStatus(device, false)
+	}
+	// The device may be one of the paths of a map, which then holds it
+	holders, err := ioutil.ReadDir(filepath.Join(sysfsPath, "block", device, "holders"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, holder := range holders {
+		if isMultipathMap(holder.Name()) {
+			return getMapStatus(holder.Name(), true)
+		}
+	}
+	return nil, nil
+}
+
+func isMultipathMap(device string) bool {
+	uuid, err := readAttribute(filepath.Join(sysfsPath, "block", device, "dm", "uuid"))
+	return err == nil && strings.HasPrefix(uuid, uuidPrefix)
+}
+
+func getMapStatus(device string, pathMember bool) (*Status, error) {
+	name, err := readAttribute(filepath.Join(sysfsPath, "block", device, "dm", "name"))
+	if err != nil {
+		return nil, err
+	}
+	slaves, err := ioutil.ReadDir(filepath.Join(sysfsPath, "block", device, "slaves"))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{Map: name, PathMember: pathMember}
+	for _, slave := range slaves {
+		path := slave.Name()
+		status.Paths = append(status.Paths, path)
+		// Paths which are not SCSI devices have no state, they are reported as active
+		state, err := readAttribute(filepath.Join(sysfsPath, "block", path, "device", "state"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil && state != runningState {
+			status.FailedPaths = append(status.FailedPaths, path)
+		}
+	}
+	sort.Strings(status.Paths)
+	sort.Strings(status.FailedPaths)
+	return status, nil
+}
+
+func readAttribute(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,131 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package multipath
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Multipath", func() {
+	var originalSysfsPath string
+
+	writeAttribute := func(path string, value string) {
+		path = filepath.Join(sysfsPath, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(value+"\n"), 0644)).To(Succeed())
+	}
+
+	addDevice := func(name string, devNum string) {
+		Expect(os.MkdirAll(filepath.Join(sysfsPath, "block", name), 0755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(sysfsPath, "dev", "block"), 0755)).To(Succeed())
+		Expect(os.Symlink(filepath.Join(sysfsPath, "block", name), filepath.Join(sysfsPath, "dev", "block", devNum))).To(Succeed())
+	}
+
+	link := func(from string, dir string, to string) {
+		Expect(os.MkdirAll(filepath.Join(sysfsPath, "block", from, dir), 0755)).To(Succeed())
+		Expect(os.Symlink(filepath.Join(sysfsPath, "block", to), filepath.Join(sysfsPath, "block", from, dir, to))).To(Succeed())
+	}
+
+	addMap := func(name string, uuid string, paths map[string]string) {
+		addDevice("dm-3", "253:3")
+		writeAttribute("block/dm-3/dm/name", name)
+		writeAttribute("block/dm-3/dm/uuid", uuid)
+		minor := 0
+		for path, state := range paths {
+			addDevice(path, fmt.Sprintf("8:%d", minor))
+			minor++
+			link("dm-3", "slaves", path)
+			link(path, "holders", "dm-3")
+			if state != "" {
+				writeAttribute("block/"+path+"/device/state", state)
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		originalSysfsPath = sysfsPath
+		var err error
+		sysfsPath, err = ioutil.TempDir("", "sysfs")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(sysfsPath)
+		sysfsPath = originalSysfsPath
+	})
+
+	It("should report the paths of a multipath map", func() {
+		addMap("mpatha", "mpath-3600a098038303053453f463045727a6b", map[string]string{"sdb": "running", "sdc": "offline", "sdd": "running"})
+
+		status, err := getStatus(253, 3)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(&Status{
+			Map:         "mpatha",
+			Paths:       []string{"sdb", "sdc", "sdd"},
+			FailedPaths: []string{"sdc"},
+		}))
+		Expect(status.ActivePaths()).To(Equal(2))
+	})
+
+	It("should report the map of a device which is one of its paths", func() {
+		addMap("mpatha", "mpath-3600a098038303053453f463045727a6b", map[string]string{"sdb": "running"})
+
+		status, err := getStatus(8, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(&Status{
+			Map:        "mpatha",
+			PathMember: true,
+			Paths:      []string{"sdb"},
+		}))
+	})
+
+	It("should report paths without a device state as active", func() {
+		addMap("mpatha", "mpath-3600a098038303053453f463045727a6b", map[string]string{"nvme0n1": ""})
+
+		status, err := getStatus(253, 3)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.ActivePaths()).To(Equal(1))
+	})
+
+	It("should ignore device mapper devices which are not multipath maps", func() {
+		addMap("vg-lv", "LVM-Wm2R4e5sbFw7ZL4vDNsTGlN9jkjkHKgR", map[string]string{"sdb": "running"})
+
+		status, err := getStatus(253, 3)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(BeNil())
+		status, err = getStatus(8, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(BeNil())
+	})
+
+	It("should ignore plain block devices", func() {
+		addDevice("sda", "8:0")
+
+		status, err := getStatus(8, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(BeNil())
+	})
+})
@@ -0,0 +1,468 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package nbd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+const (
+	// AllocationContext is the meta context reporting which blocks of an export are allocated or read as zeroes
+	AllocationContext = "base:allocation"
+	// DirtyBitmapContextPrefix is prepended to the name of a dirty bitmap to get the meta context QEMU reports it in
+	DirtyBitmapContextPrefix = "qemu:dirty-bitmap:"
+
+	// readChunkSize bounds the length of the read requests, QEMU refuses requests longer than 32MiB
+	readChunkSize = 4 << 20
+	// statusChunkSize bounds the length of the block status requests, whose length is a 32 bit field
+	statusChunkSize = 1 << 30
+
+	// handshake, see https://github.com/NetworkBlockDevice/nbd/blob/master/doc/proto.md
+	nbdMagic          = 0x4e42444d41474943
+	optMagic          = 0x49484156454f5054
+	optReplyMagic     = 0x3e889045565a9
+	flagFixedNewstyle = 1 << 0
+	flagNoZeroes      = 1 << 1
+
+	optGo              = 7
+	optStructuredReply = 8
+	optSetMetaContext  = 10
+
+	repAck         = 1
+	repInfo        = 3
+	repMetaContext = 4
+	repErrFlag     = 1 << 31
+
+	infoExport = 0
+
+	// transmission
+	requestMagic          = 0x25609513
+	simpleReplyMagic      = 0x67446698
+	structuredReplyMagic  = 0x668e33ef
+	replyFlagDone         = 1 << 0
+	replyTypeNone         = 0
+	replyTypeOffsetData   = 1
+	replyTypeOffsetHole   = 2
+	replyTypeBlockStatus  = 5
+	replyTypeErrorFlag    = 1 << 15
+	cmdRead               = 0
+	cmdDisconnect         = 2
+	cmdBlockStatus        = 7
+	stateHole             = 1 << 0
+	stateZero             = 1 << 1
+	stateDirty            = 1 << 0
+	errorChunkHeaderSize  = 6
+	offsetChunkHeaderSize = 8
+	holeChunkSize         = 12
+)
+
+// Extent is a range of an export whose blocks have the same status
+type Extent struct {
+	Offset uint64
+	Length uint64
+	// Flags are the block status flags of the meta context
+	Flags uint32
+}
+
+// Client reads the data and the block status of a single export of an NBD server, as started by libvirt for
+// pull mode backups. A client is not safe for concurrent use, and has to be closed after an error.
+type Client struct {
+	conn        net.Conn
+	size        uint64
+	metaContext string
+	contextID   uint32
+	handle      uint64
+}
+
+// Connect negotiates the export and the meta context its block status is reported in, on a connection to an
+// NBD server in the fixed newstyle.
+func Connect(conn net.Conn, export, metaContext string) (*Client, error) {
+	c := &Client{
+		conn:        conn,
+		metaContext: metaContext,
+	}
+	if err := c.handshake(export); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Size returns the size of the export in bytes
+func (c *Client) Size() uint64 {
+	return c.size
+}
+
+// Close disconnects from the server
+func (c *Client) Close() error {
+	c.request(cmdDisconnect, 0, 0)
+	return c.conn.Close()
+}
+
+func (c *Client) handshake(export string) error {
+	var greeting struct {
+		Magic    uint64
+		OptMagic uint64
+		Flags    uint16
+	}
+	if err := binary.Read(c.conn, binary.BigEndian, &greeting); err != nil {
+		return fmt.Errorf("failed to read the NBD greeting: %v", err)
+	}
+	if greeting.Magic != nbdMagic || greeting.OptMagic != optMagic || greeting.Flags&flagFixedNewstyle == 0 {
+		return fmt.Errorf("the server does not speak the fixed newstyle NBD protocol")
+	}
+	clientFlags := uint32(flagFixedNewstyle)
+	if greeting.Flags&flagNoZeroes != 0 {
+		clientFlags |= flagNoZeroes
+	}
+	if err := binary.Write(c.conn, binary.BigEndian, clientFlags); err != nil {
+		return err
+	}
+
+	if err := c.sendOption(optStructuredReply, nil); err != nil {
+		return err
+	}
+	if replyType, _, err := c.readOptionReply(optStructuredReply); err != nil {
+		return err
+	} else if replyType != repAck {
+		return fmt.Errorf("the NBD server does not support structured replies")
+	}
+
+	metaContext := append(nbdString(export), 0, 0, 0, 1)
+	metaContext = append(metaContext, nbdString(c.metaContext)...)
+	if err := c.sendOption(optSetMetaContext, metaContext); err != nil {
+		return err
+	}
+	found := false
+	for {
+		replyType, data, err := c.readOptionReply(optSetMetaContext)
+		if err != nil {
+			return err
+		}
+		if replyType == repAck {
+			break
+		}
+		if replyType == repMetaContext && len(data) >= 4 && string(data[4:]) == c.metaContext {
+			c.contextID = binary.BigEndian.Uint32(data[0:4])
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("the NBD export %s does not provide the meta context %s", export, c.metaContext)
+	}
+
+	// no information requests, the server always sends the size
+	if err := c.sendOption(optGo, append(nbdString(export), 0, 0)); err != nil {
+		return err
+	}
+	for {
+		replyType, data, err := c.readOptionReply(optGo)
+		if err != nil {
+			return err
+		}
+		if replyType == repAck {
+			return nil
+		}
+		if replyType == repInfo && len(data) >= 10 && binary.BigEndian.Uint16(data[0:2]) == infoExport {
+			c.size = binary.BigEndian.Uint64(data[2:10])
+		}
+	}
+}
+
+func (c *Client) sendOption(option uint32, data []byte) error {
+	header := make([]byte, 16, 16+len(data))
+	binary.BigEndian.PutUint64(header[0:8], optMagic)
+	binary.BigEndian.PutUint32(header[8:12], option)
+	binary.BigEndian.PutUint32(header[12:16], uint32(len(data)))
+	_, err := c.conn.Write(append(header, data...))
+	return err
+}
+
+func (c *Client) readOptionReply(option uint32) (uint32, []byte, error) {
+	var reply struct {
+		Magic  uint64
+		Option uint32
+		Type   uint32
+		Length uint32
+	}
+	if err := binary.Read(c.conn, binary.BigEndian, &reply); err != nil {
+		return 0, nil, fmt.Errorf("failed to read the reply to NBD option %d: %v", option, err)
+	}
+	if reply.Magic != optReplyMagic || reply.Option != option {
+		return 0, nil, fmt.Errorf("unexpected reply to NBD option %d", option)
+	}
+	data := make([]byte, reply.Length)
+	if _, err := io.ReadFull(c.conn, data); err != nil {
+		return 0, nil, err
+	}
+	if reply.Type&repErrFlag != 0 {
+		return 0, nil, fmt.Errorf("NBD option %d failed with error %d: %s", option, reply.Type&^repErrFlag, string(data))
+	}
+	return reply.Type, data, nil
+}
+
+func (c *Client) request(command uint16, offset uint64, length uint32) (uint64, error) {
+	c.handle++
+	request := make([]byte, 28)
+	binary.BigEndian.PutUint32(request[0:4], requestMagic)
+	binary.BigEndian.PutUint16(request[6:8], command)
+	binary.BigEndian.PutUint64(request[8:16], c.handle)
+	binary.BigEndian.PutUint64(request[16:24], offset)
+	binary.BigEndian.PutUint32(request[24:28], length)
+	_, err := c.conn.Write(request)
+	return c.handle, err
+}
+
+// readReplyChunk returns the type and the payload of the next chunk of the reply, and whether it is the last one
+func (c *Client) readReplyChunk(handle uint64) (uint16, []byte, bool, error) {
+	var magic uint32
+	if err := binary.Read(c.conn, binary.BigEndian, &magic); err != nil {
+		return 0, nil, false, err
+	}
+	switch magic {
+	case simpleReplyMagic:
+		var reply struct {
+			Error  uint32
+			Handle uint64
+		}
+		if err := binary.Read(c.conn, binary.BigEndian, &reply); err != nil {
+			return 0, nil, false, err
+		}
+		if reply.Handle != handle {
+			return 0, nil, false, fmt.Errorf("unexpected NBD reply handle %d", reply.Handle)
+		}
+		if reply.Error != 0 {
+			return 0, nil, false, fmt.Errorf("NBD request failed with error %d", reply.Error)
+		}
+		return replyTypeNone, nil, true, nil
+	case structuredReplyMagic:
+		var chunk struct {
+			Flags  uint16
+			Type   uint16
+			Handle uint64
+			Length uint32
+		}
+		if err := binary.Read(c.conn, binary.BigEndian, &chunk); err != nil {
+			return 0, nil, false, err
+		}
+		if chunk.Handle != handle {
+			return 0, nil, false, fmt.Errorf("unexpected NBD reply handle %d", chunk.Handle)
+		}
+		payload := make([]byte, chunk.Length)
+		if _, err := io.ReadFull(c.conn, payload); err != nil {
+			return 0, nil, false, err
+		}
+		if chunk.Type&replyTypeErrorFlag != 0 {
+			if len(payload) < errorChunkHeaderSize {
+				return 0, nil, false, fmt.Errorf("NBD request failed")
+			}
+			message := payload[errorChunkHeaderSize:]
+			if length := int(binary.BigEndian.Uint16(payload[4:6])); length < len(message) {
+				message = message[:length]
+			}
+			return 0, nil, false, fmt.Errorf("NBD request failed with error %d: %s", binary.BigEndian.Uint32(payload[0:4]), string(message))
+		}
+		return chunk.Type, payload, chunk.Flags&replyFlagDone != 0, nil
+	default:
+		return 0, nil, false, fmt.Errorf("unexpected NBD reply magic 0x%x", magic)
+	}
+}
+
+// BlockStatus returns the status of the blocks from the offset on, in the meta context of the client.
+// The server may report less than the requested length.
+func (c *Client) BlockStatus(offset uint64, length uint32) ([]Extent, error) {
+	handle, err := c.request(cmdBlockStatus, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	var extents []Extent
+	for done := false; !done; {
+		var chunkType uint16
+		var payload []byte
+		chunkType, payload, done, err = c.readReplyChunk(handle)
+		if err != nil {
+			return nil, err
+		}
+		if chunkType != replyTypeBlockStatus {
+			continue
+		}
+		if len(payload) < 4 || (len(payload)-4)%8 != 0 {
+			return nil, fmt.Errorf("malformed NBD block status reply")
+		}
+		if binary.BigEndian.Uint32(payload[0:4]) != c.contextID {
+			continue
+		}
+		position := offset
+		for i := 4; i < len(payload); i += 8 {
+			extent := Extent{
+				Offset: position,
+				Length: uint64(binary.BigEndian.Uint32(payload[i : i+4])),
+				Flags:  binary.BigEndian.Uint32(payload[i+4 : i+8]),
+			}
+			extents = append(extents, extent)
+			position += extent.Length
+		}
+	}
+	return extents, nil
+}
+
+// ReadAt reads len(p) bytes of the export from the offset on
+func (c *Client) ReadAt(p []byte, offset uint64) error {
+	handle, err := c.request(cmdRead, offset, uint32(len(p)))
+	if err != nil {
+		return err
+	}
+	for done := false; !done; {
+		var chunkType uint16
+		var payload []byte
+		chunkType, payload, done, err = c.readReplyChunk(handle)
+		if err != nil {
+			return err
+		}
+		switch chunkType {
+		case replyTypeOffsetData:
+			if len(payload) < offsetChunkHeaderSize {
+				return fmt.Errorf("malformed NBD data reply")
+			}
+			data := payload[offsetChunkHeaderSize:]
+			start, ok := chunkStart(payload, offset, uint64(len(data)), len(p))
+			if !ok {
+				return fmt.Errorf("NBD data reply outside of the requested range")
+			}
+			copy(p[start:], data)
+		case replyTypeOffsetHole:
+			if len(payload) != holeChunkSize {
+				return fmt.Errorf("malformed NBD hole reply")
+			}
+			length := uint64(binary.BigEndian.Uint32(payload[8:12]))
+			start, ok := chunkStart(payload, offset, length, len(p))
+			if !ok {
+				return fmt.Errorf("NBD hole reply outside of the requested range")
+			}
+			for i := start; i < start+length; i++ {
+				p[i] = 0
+			}
+		}
+	}
+	return nil
+}
+
+// chunkStart returns the position of a read reply chunk in the buffer of the request
+func chunkStart(payload []byte, offset uint64, length uint64, size int) (uint64, bool) {
+	chunkOffset := binary.BigEndian.Uint64(payload[0:8])
+	if chunkOffset < offset || chunkOffset-offset+length > uint64(size) {
+		return 0, false
+	}
+	return chunkOffset - offset, true
+}
+
+// Changed tells whether the blocks of the extent have to be backed up. With a dirty bitmap these are the blocks
+// written since the bitmap was created, otherwise all the blocks which don't read as zeroes.
+func (c *Client) Changed(extent Extent) bool {
+	if c.metaContext == AllocationContext {
+		return extent.Flags&stateZero == 0
+	}
+	return extent.Flags&stateDirty != 0
+}
+
+// ChangedExtents returns the changed extents of the whole export, adjacent extents are merged
+func (c *Client) ChangedExtents() ([]Extent, error) {
+	var changed []Extent
+	for offset := uint64(0); offset < c.size; {
+		length := c.size - offset
+		if length > statusChunkSize {
+			length = statusChunkSize
+		}
+		extents, err := c.BlockStatus(offset, uint32(length))
+		if err != nil {
+			return nil, err
+		}
+		if len(extents) == 0 {
+			return nil, fmt.Errorf("the NBD server reported no block status at offset %d", offset)
+		}
+		for _, extent := range extents {
+			if extent.Offset+extent.Length > c.size {
+				extent.Length = c.size - extent.Offset
+			}
+			offset = extent.Offset + extent.Length
+			if extent.Length == 0 || !c.Changed(extent) {
+				continue
+			}
+			if last := len(changed) - 1; last >= 0 && changed[last].Offset+changed[last].Length == extent.Offset {
+				changed[last].Length += extent.Length
+				continue
+			}
+			changed = append(changed, Extent{Offset: extent.Offset, Length: extent.Length, Flags: extent.Flags})
+		}
+	}
+	return changed, nil
+}
+
+// WriteChanged writes the changed extents of the export to w. The stream starts with the size of the export,
+// followed by the offset and the length of every changed extent and, if requested, the data of the extent.
+// All numbers are unsigned 64 bit integers in network byte order.
+func (c *Client) WriteChanged(w io.Writer, withData bool) error {
+	extents, err := c.ChangedExtents()
+	if err != nil {
+		return err
+	}
+	header := make([]byte, 16)
+	binary.BigEndian.PutUint64(header[0:8], c.size)
+	if _, err := w.Write(header[0:8]); err != nil {
+		return err
+	}
+	var buf []byte
+	if withData {
+		buf = make([]byte, readChunkSize)
+	}
+	for _, extent := range extents {
+		binary.BigEndian.PutUint64(header[0:8], extent.Offset)
+		binary.BigEndian.PutUint64(header[8:16], extent.Length)
+		if _, err := w.Write(header); err != nil {
+			return err
+		}
+		if !withData {
+			continue
+		}
+		end := extent.Offset + extent.Length
+		for offset := extent.Offset; offset < end; {
+			length := end - offset
+			if length > readChunkSize {
+				length = readChunkSize
+			}
+			if err := c.ReadAt(buf[:length], offset); err != nil {
+				return err
+			}
+			if _, err := w.Write(buf[:length]); err != nil {
+				return err
+			}
+			offset += length
+		}
+	}
+	return nil
+}
+
+func nbdString(s string) []byte {
+	data := make([]byte, 4, 4+len(s))
+	binary.BigEndian.PutUint32(data, uint32(len(s)))
+	return append(data, s...)
+}
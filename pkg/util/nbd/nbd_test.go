@@ -0,0 +1,270 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package nbd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testExport = "rootdisk"
+	testBitmap = DirtyBitmapContextPrefix + "backup-rootdisk"
+	kib        = 1024
+)
+
+// fakeServer serves a single export with the block status of its meta contexts
+type fakeServer struct {
+	data     []byte
+	contexts map[string][]Extent
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	binary.Write(conn, binary.BigEndian, struct {
+		Magic    uint64
+		OptMagic uint64
+		Flags    uint16
+	}{nbdMagic, optMagic, flagFixedNewstyle | flagNoZeroes})
+	var clientFlags uint32
+	if binary.Read(conn, binary.BigEndian, &clientFlags) != nil {
+		return
+	}
+
+	var contextName string
+	for negotiated := false; !negotiated; {
+		var option struct {
+			Magic  uint64
+			Option uint32
+			Length uint32
+		}
+		if binary.Read(conn, binary.BigEndian, &option) != nil {
+			return
+		}
+		data := make([]byte, option.Length)
+		if _, err := io.ReadFull(conn, data); err != nil {
+			return
+		}
+		switch option.Option {
+		case optStructuredReply:
+			s.optionReply(conn, option.Option, repAck, nil)
+		case optSetMetaContext:
+			exportLength := binary.BigEndian.Uint32(data[0:4])
+			query := string(data[4+exportLength+8:])
+			if _, exists := s.contexts[query]; exists {
+				contextName = query
+				s.optionReply(conn, option.Option, repMetaContext, append([]byte{0, 0, 0, 1}, query...))
+			}
+			s.optionReply(conn, option.Option, repAck, nil)
+		case optGo:
+			info := make([]byte, 12)
+			binary.BigEndian.PutUint64(info[2:10], uint64(len(s.data)))
+			s.optionReply(conn, option.Option, repInfo, info)
+			s.optionReply(conn, option.Option, repAck, nil)
+			negotiated = true
+		}
+	}
+
+	for {
+		var request struct {
+			Magic   uint32
+			Flags   uint16
+			Command uint16
+			Handle  uint64
+			Offset  uint64
+			Length  uint32
+		}
+		if binary.Read(conn, binary.BigEndian, &request) != nil {
+			return
+		}
+		end := request.Offset + uint64(request.Length)
+		switch request.Command {
+		case cmdDisconnect:
+			return
+		case cmdBlockStatus:
+			payload := []byte{0, 0, 0, 1}
+			for _, extent := range s.contexts[contextName] {
+				if extent.Offset+extent.Length <= request.Offset || extent.Offset >= end {
+					continue
+				}
+				start, stop := extent.Offset, extent.Offset+extent.Length
+				if start < request.Offset {
+					start = request.Offset
+				}
+				if stop > end {
+					stop = end
+				}
+				descriptor := make([]byte, 8)
+				binary.BigEndian.PutUint32(descriptor[0:4], uint32(stop-start))
+				binary.BigEndian.PutUint32(descriptor[4:8], extent.Flags)
+				payload = append(payload, descriptor...)
+			}
+			s.chunk(conn, request.Handle, replyTypeBlockStatus, true, payload)
+		case cmdRead:
+			// the first half is sent as data, the second half as a hole if it is all zeroes
+			middle := request.Offset + uint64(request.Length/2)
+			s.chunk(conn, request.Handle, replyTypeOffsetData, false, append(offsetBytes(request.Offset), s.data[request.Offset:middle]...))
+			if bytes.Count(s.data[middle:end], []byte{0}) == int(end-middle) {
+				hole := make([]byte, 4)
+				binary.BigEndian.PutUint32(hole, uint32(end-middle))
+				s.chunk(conn, request.Handle, replyTypeOffsetHole, true, append(offsetBytes(middle), hole...))
+			} else {
+				s.chunk(conn, request.Handle, replyTypeOffsetData, true, append(offsetBytes(middle), s.data[middle:end]...))
+			}
+		}
+	}
+}
+
+func (s *fakeServer) optionReply(conn net.Conn, option uint32, replyType uint32, data []byte) {
+	binary.Write(conn, binary.BigEndian, struct {
+		Magic  uint64
+		Option uint32
+		Type   uint32
+		Length uint32
+	}{optReplyMagic, option, replyType, uint32(len(data))})
+	// an empty write on a pipe blocks until the other end reads
+	if len(data) > 0 {
+		conn.Write(data)
+	}
+}
+
+func (s *fakeServer) chunk(conn net.Conn, handle uint64, chunkType uint16, done bool, payload []byte) {
+	var flags uint16
+	if done {
+		flags = replyFlagDone
+	}
+	binary.Write(conn, binary.BigEndian, struct {
+		Magic  uint32
+		Flags  uint16
+		Type   uint16
+		Handle uint64
+		Length uint32
+	}{structuredReplyMagic, flags, chunkType, handle, uint32(len(payload))})
+	conn.Write(payload)
+}
+
+func offsetBytes(offset uint64) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, offset)
+	return data
+}
+
+var _ = Describe("NBD client", func() {
+	var server *fakeServer
+
+	BeforeEach(func() {
+		data := make([]byte, 64*kib)
+		copy(data, bytes.Repeat([]byte("a"), 8*kib))
+		copy(data[16*kib:], bytes.Repeat([]byte("b"), 4*kib))
+		server = &fakeServer{
+			data: data,
+			contexts: map[string][]Extent{
+				AllocationContext: {
+					{Offset: 0, Length: 8 * kib, Flags: 0},
+					{Offset: 8 * kib, Length: 8 * kib, Flags: stateHole | stateZero},
+					{Offset: 16 * kib, Length: 4 * kib, Flags: 0},
+					{Offset: 20 * kib, Length: 44 * kib, Flags: stateHole | stateZero},
+				},
+				testBitmap: {
+					{Offset: 0, Length: 4 * kib, Flags: 0},
+					{Offset: 4 * kib, Length: 4 * kib, Flags: stateDirty},
+					{Offset: 8 * kib, Length: 4 * kib, Flags: stateDirty},
+					{Offset: 12 * kib, Length: 8 * kib, Flags: 0},
+					{Offset: 20 * kib, Length: 4 * kib, Flags: stateDirty},
+					{Offset: 24 * kib, Length: 40 * kib, Flags: 0},
+				},
+			},
+		}
+	})
+
+	connect := func(metaContext string) (*Client, error) {
+		clientConn, serverConn := net.Pipe()
+		go server.serve(serverConn)
+		client, err := Connect(clientConn, testExport, metaContext)
+		if err != nil {
+			clientConn.Close()
+		}
+		return client, err
+	}
+
+	It("should negotiate the export and report its size", func() {
+		client, err := connect(testBitmap)
+		Expect(err).ToNot(HaveOccurred())
+		defer client.Close()
+		Expect(client.Size()).To(Equal(uint64(64 * kib)))
+	})
+
+	It("should fail if the server does not provide the meta context", func() {
+		_, err := connect(DirtyBitmapContextPrefix + "unknown")
+		Expect(err).To(MatchError(ContainSubstring("does not provide the meta context")))
+	})
+
+	table.DescribeTable("should report the changed extents", func(metaContext string, expected []Extent) {
+		client, err := connect(metaContext)
+		Expect(err).ToNot(HaveOccurred())
+		defer client.Close()
+		Expect(client.ChangedExtents()).To(Equal(expected))
+	},
+		table.Entry("written since the checkpoint with a dirty bitmap", testBitmap, []Extent{
+			{Offset: 4 * kib, Length: 8 * kib, Flags: stateDirty},
+			{Offset: 20 * kib, Length: 4 * kib, Flags: stateDirty},
+		}),
+		table.Entry("not reading as zeroes without a dirty bitmap", AllocationContext, []Extent{
+			{Offset: 0, Length: 8 * kib, Flags: 0},
+			{Offset: 16 * kib, Length: 4 * kib, Flags: 0},
+		}),
+	)
+
+	It("should read data and holes", func() {
+		client, err := connect(AllocationContext)
+		Expect(err).ToNot(HaveOccurred())
+		defer client.Close()
+		buf := bytes.Repeat([]byte("x"), 12*kib)
+		Expect(client.ReadAt(buf, 2*kib)).To(Succeed())
+		Expect(buf).To(Equal(server.data[2*kib : 14*kib]))
+	})
+
+	table.DescribeTable("should write the changed extents", func(withData bool) {
+		client, err := connect(AllocationContext)
+		Expect(err).ToNot(HaveOccurred())
+		defer client.Close()
+		var out bytes.Buffer
+		Expect(client.WriteChanged(&out, withData)).To(Succeed())
+
+		expected := offsetBytes(64 * kib)
+		for _, extent := range []Extent{{Offset: 0, Length: 8 * kib}, {Offset: 16 * kib, Length: 4 * kib}} {
+			expected = append(expected, offsetBytes(extent.Offset)...)
+			expected = append(expected, offsetBytes(extent.Length)...)
+			if withData {
+				expected = append(expected, server.data[extent.Offset:extent.Offset+extent.Length]...)
+			}
+		}
+		Expect(out.Bytes()).To(Equal(expected))
+	},
+		table.Entry("with their data", true),
+		table.Entry("without their data", false),
+	)
+})
@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package istio
+
+import (
+	"strconv"
+	"strings"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// InjectSidecarAnnotation requests istio to inject its proxy sidecar into the pod
+	InjectSidecarAnnotation = "sidecar.istio.io/inject"
+	// KubeVirtTrafficAnnotation lists the virtual interfaces whose inbound traffic (from the VM) is treated as outbound traffic by envoy
+	KubeVirtTrafficAnnotation = "traffic.sidecar.istio.io/kubevirtInterfaces"
+
+	// EnvoyLoopbackAddress is the source address envoy uses to forward the inbound traffic to the workload
+	EnvoyLoopbackAddress = "127.0.0.6"
+	// EnvoyLoopbackAddressIPv6 is the IPv6 source address envoy uses to forward the inbound traffic to the workload
+	EnvoyLoopbackAddressIPv6 = "::6"
+)
+
+// reservedPorts are the ports used by the istio proxy in the pod
+var reservedPorts = []int32{15000, 15001, 15004, 15006, 15008, 15020, 15021, 15090}
+
+// ProxyInjectionEnabled returns true when the istio proxy is injected into the pod of the VMI.
+func ProxyInjectionEnabled(vmi *v1.VirtualMachineInstance) bool {
+	return ProxyInjectionRequested(vmi.Annotations)
+}
+
+// ProxyInjectionRequested returns true when the annotations request the injection of the istio proxy.
+func ProxyInjectionRequested(annotations map[string]string) bool {
+	return strings.ToLower(annotations[InjectSidecarAnnotation]) == "true"
+}
+
+// IsReservedPort returns true when the port is used by the istio proxy.
+func IsReservedPort(port int32) bool {
+	for _, reservedPort := range reservedPorts {
+		if port == reservedPort {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinReservedPorts returns the ports used by the istio proxy, joined by the separator.
+func JoinReservedPorts(separator string) string {
+	var ports []string
+	for _, port := range reservedPorts {
+		ports = append(ports, strconv.Itoa(int(port)))
+	}
+	return strings.Join(ports, separator)
+}
@@ -0,0 +1,290 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package macpool
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// MacAddressIndex indexes VirtualMachines by the MAC addresses of their interfaces
+	MacAddressIndex = "macAddress"
+
+	// ReservationsConfigMapName is the ConfigMap which holds the reserved addresses, so that all virt-api
+	// replicas see the addresses assigned by the others
+	ReservationsConfigMapName = "kubevirt-mac-pool-reservations"
+
+	// reservationTimeout is how long an assigned address is held back, so that it is not assigned
+	// again before the VirtualMachine using it shows up in the informer cache
+	reservationTimeout = 1 * time.Minute
+)
+
+// MacAddressIndexFunc is the cache.IndexFunc of MacAddressIndex
+func MacAddressIndexFunc(obj interface{}) ([]string, error) {
+	vm, ok := obj.(*v1.VirtualMachine)
+	if !ok || vm.Spec.Template == nil {
+		return nil, nil
+	}
+	var macAddresses []string
+	for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+		if mac, err := net.ParseMAC(iface.MacAddress); err == nil {
+			macAddresses = append(macAddresses, mac.String())
+		}
+	}
+	return macAddresses, nil
+}
+
+// Range is an inclusive range of MAC addresses
+type Range struct {
+	start uint64
+	end   uint64
+}
+
+// ParseRange validates the MAC pool configuration and returns the range it defines
+func ParseRange(config *v1.MacPoolConfiguration) (*Range, error) {
+	start, err := parseMacAddress(config.RangeStart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC pool range start: %v", err)
+	}
+	end, err := parseMacAddress(config.RangeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC pool range end: %v", err)
+	}
+	if start > end {
+		return nil, fmt.Errorf("the MAC pool range start %s is after the range end %s", config.RangeStart, config.RangeEnd)
+	}
+	return &Range{start: start, end: end}, nil
+}
+
+func parseMacAddress(macAddress string) (uint64, error) {
+	mac, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return 0, err
+	}
+	if len(mac) != 6 {
+		return 0, fmt.Errorf("%s is not a 48 bit MAC address", macAddress)
+	}
+	if isMulticast(mac) {
+		return 0, fmt.Errorf("%s is a multicast MAC address", macAddress)
+	}
+	return binary.BigEndian.Uint64(append([]byte{0, 0}, mac...)), nil
+}
+
+func toMacAddress(value uint64) net.HardwareAddr {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, value)
+	return net.HardwareAddr(buf[2:])
+}
+
+func isMulticast(mac net.HardwareAddr) bool {
+	return mac[0]&0x01 == 0x01
+}
+
+// Pool assigns the MAC addresses of a range which are not used by any VirtualMachine
+type Pool struct {
+	vmIndexer  cache.Indexer
+	configMaps corev1.ConfigMapInterface
+
+	lock sync.Mutex
+	// next is where the search for a free address starts
+	next uint64
+}
+
+// NewPool returns a pool which finds the used addresses through the MacAddressIndex of the VirtualMachine indexer
+// and keeps its reservations in the ReservationsConfigMapName ConfigMap
+func NewPool(vmIndexer cache.Indexer, configMaps corev1.ConfigMapInterface) *Pool {
+	return &Pool{
+		vmIndexer:  vmIndexer,
+		configMaps: configMaps,
+	}
+}
+
+// AssignMacAddresses sets a free MAC address of the range on every interface of the VirtualMachine without one
+func (p *Pool) AssignMacAddresses(macRange *Range, vm *v1.VirtualMachine) error {
+	if vm.Spec.Template == nil {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// Another replica reserving addresses at the same time makes the update of the reservations fail,
+	// the addresses are searched again with its reservations then
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		return p.assignMacAddresses(macRange, vm)
+	})
+}
+
+func (p *Pool) assignMacAddresses(macRange *Range, vm *v1.VirtualMachine) error {
+	reservations, err := p.configMaps.Get(context.Background(), ReservationsConfigMapName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		reservations = nil
+	} else if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reserved := map[string]string{}
+	if reservations != nil {
+		for mac, reservedAt := range reservations.Data {
+			if t, err := time.Parse(time.RFC3339, reservedAt); err == nil && now.Sub(t) <= reservationTimeout {
+				reserved[mac] = reservedAt
+			}
+		}
+	}
+
+	interfaces := vm.Spec.Template.Spec.Domain.Devices.Interfaces
+	usedByVM := map[string]bool{}
+	for _, iface := range interfaces {
+		if mac, err := net.ParseMAC(iface.MacAddress); err == nil {
+			usedByVM[mac.String()] = true
+		}
+	}
+
+	assigned := map[int]string{}
+	for idx := range interfaces {
+		if interfaces[idx].MacAddress != "" {
+			continue
+		}
+		mac, err := p.nextFreeMacAddress(macRange, usedByVM, reserved)
+		if err != nil {
+			return err
+		}
+		assigned[idx] = mac
+		usedByVM[mac] = true
+		reserved[mac] = now.Format(time.RFC3339)
+	}
+	if len(assigned) == 0 {
+		return nil
+	}
+
+	if err := p.storeReservations(reservations, reserved); err != nil {
+		return err
+	}
+	for idx, mac := range assigned {
+		interfaces[idx].MacAddress = mac
+	}
+	return nil
+}
+
+// storeReservations replaces the reservations of the ConfigMap, the update fails with a conflict when the
+// ConfigMap was changed since it was read
+func (p *Pool) storeReservations(reservations *k8sv1.ConfigMap, reserved map[string]string) error {
+	if reservations == nil {
+		_, err := p.configMaps.Create(context.Background(), &k8sv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ReservationsConfigMapName},
+			Data:       reserved,
+		}, metav1.CreateOptions{})
+		return err
+	}
+
+	data := map[string]interface{}{}
+	for mac := range reservations.Data {
+		data[mac] = nil
+	}
+	for mac, reservedAt := range reserved {
+		data[mac] = reservedAt
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": reservations.ResourceVersion},
+		"data":     data,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.configMaps.Patch(context.Background(), ReservationsConfigMapName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func (p *Pool) nextFreeMacAddress(macRange *Range, usedByVM map[string]bool, reserved map[string]string) (string, error) {
+	if p.next < macRange.start || p.next > macRange.end {
+		p.next = macRange.start
+	}
+	for i := uint64(0); i <= macRange.end-macRange.start; i++ {
+		candidate := toMacAddress(p.next)
+		if p.next == macRange.end {
+			p.next = macRange.start
+		} else {
+			p.next++
+		}
+
+		mac := candidate.String()
+		if isMulticast(candidate) || usedByVM[mac] {
+			continue
+		}
+		if _, isReserved := reserved[mac]; isReserved {
+			continue
+		}
+		vms, err := p.vmIndexer.ByIndex(MacAddressIndex, mac)
+		if err != nil {
+			return "", err
+		}
+		if len(vms) == 0 {
+			return mac, nil
+		}
+	}
+	return "", fmt.Errorf("the MAC pool %s-%s is exhausted", toMacAddress(macRange.start), toMacAddress(macRange.end))
+}
+
+// FindConflicts returns, by interface index, the key of another VirtualMachine which already uses the MAC address
+// of the interface
+func FindConflicts(vmIndexer cache.Indexer, vm *v1.VirtualMachine) (map[int]string, error) {
+	conflicts := map[int]string{}
+	if vm.Spec.Template == nil {
+		return conflicts, nil
+	}
+	for idx, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+		mac, err := net.ParseMAC(iface.MacAddress)
+		if err != nil {
+			continue
+		}
+		objs, err := vmIndexer.ByIndex(MacAddressIndex, mac.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			other := obj.(*v1.VirtualMachine)
+			if other.Namespace == vm.Namespace && other.Name == vm.Name {
+				continue
+			}
+			conflicts[idx] = fmt.Sprintf("%s/%s", other.Namespace, other.Name)
+			break
+		}
+	}
+	return conflicts, nil
+}
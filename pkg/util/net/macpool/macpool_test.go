@@ -0,0 +1,168 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package macpool
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("MAC pool", func() {
+	var vmIndexer cache.Indexer
+	var configMaps corev1.ConfigMapInterface
+
+	newVM := func(name string, macAddresses ...string) *v1.VirtualMachine {
+		vm := &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       v1.VirtualMachineSpec{Template: &v1.VirtualMachineInstanceTemplateSpec{}},
+		}
+		for idx, mac := range macAddresses {
+			iface := v1.DefaultBridgeNetworkInterface()
+			iface.Name = string(rune('a' + idx))
+			iface.MacAddress = mac
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = append(vm.Spec.Template.Spec.Domain.Devices.Interfaces, *iface)
+		}
+		return vm
+	}
+
+	newRange := func(start, end string) *Range {
+		macRange, err := ParseRange(&v1.MacPoolConfiguration{RangeStart: start, RangeEnd: end})
+		Expect(err).ToNot(HaveOccurred())
+		return macRange
+	}
+
+	BeforeEach(func() {
+		vmIndexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{MacAddressIndex: MacAddressIndexFunc})
+		configMaps = fake.NewSimpleClientset().CoreV1().ConfigMaps("kubevirt")
+	})
+
+	Context("ParseRange", func() {
+		It("should reject a range which ends before it starts", func() {
+			_, err := ParseRange(&v1.MacPoolConfiguration{RangeStart: "02:00:00:00:00:10", RangeEnd: "02:00:00:00:00:01"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a multicast address", func() {
+			_, err := ParseRange(&v1.MacPoolConfiguration{RangeStart: "01:00:00:00:00:00", RangeEnd: "02:00:00:00:00:01"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject an invalid address", func() {
+			_, err := ParseRange(&v1.MacPoolConfiguration{RangeStart: "02:00:00:00:00:00", RangeEnd: "invalid"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("AssignMacAddresses", func() {
+		It("should skip the addresses used by other VirtualMachines", func() {
+			Expect(vmIndexer.Add(newVM("other", "02:00:00:00:00:00", "02:00:00:00:00:02"))).To(Succeed())
+			pool := NewPool(vmIndexer, configMaps)
+
+			vm := newVM("testvm", "", "", "02:00:00:00:00:01")
+			Expect(pool.AssignMacAddresses(newRange("02:00:00:00:00:00", "02:00:00:00:00:ff"), vm)).To(Succeed())
+
+			interfaces := vm.Spec.Template.Spec.Domain.Devices.Interfaces
+			Expect(interfaces[0].MacAddress).To(Equal("02:00:00:00:00:03"))
+			Expect(interfaces[1].MacAddress).To(Equal("02:00:00:00:00:04"))
+			Expect(interfaces[2].MacAddress).To(Equal("02:00:00:00:00:01"))
+		})
+
+		It("should not assign the same address twice before the VirtualMachine is cached", func() {
+			pool := NewPool(vmIndexer, configMaps)
+			macRange := newRange("02:00:00:00:00:00", "02:00:00:00:00:01")
+
+			first := newVM("first", "")
+			Expect(pool.AssignMacAddresses(macRange, first)).To(Succeed())
+			second := newVM("second", "")
+			Expect(pool.AssignMacAddresses(macRange, second)).To(Succeed())
+
+			Expect(first.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:00"))
+			Expect(second.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:01"))
+		})
+
+		It("should not assign an address reserved by another pool", func() {
+			macRange := newRange("02:00:00:00:00:00", "02:00:00:00:00:01")
+
+			first := newVM("first", "")
+			Expect(NewPool(vmIndexer, configMaps).AssignMacAddresses(macRange, first)).To(Succeed())
+			second := newVM("second", "")
+			Expect(NewPool(vmIndexer, configMaps).AssignMacAddresses(macRange, second)).To(Succeed())
+
+			Expect(first.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:00"))
+			Expect(second.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:01"))
+
+			reservations, err := configMaps.Get(context.Background(), ReservationsConfigMapName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reservations.Data).To(HaveKey("02:00:00:00:00:00"))
+			Expect(reservations.Data).To(HaveKey("02:00:00:00:00:01"))
+		})
+
+		It("should assign an address again once its reservation expired", func() {
+			_, err := configMaps.Create(context.Background(), &k8sv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: ReservationsConfigMapName},
+				Data: map[string]string{
+					"02:00:00:00:00:00": time.Now().Add(-2 * reservationTimeout).Format(time.RFC3339),
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			vm := newVM("testvm", "")
+			Expect(NewPool(vmIndexer, configMaps).AssignMacAddresses(newRange("02:00:00:00:00:00", "02:00:00:00:00:00"), vm)).To(Succeed())
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:00"))
+		})
+
+		It("should fail when the pool is exhausted", func() {
+			Expect(vmIndexer.Add(newVM("other", "02:00:00:00:00:00"))).To(Succeed())
+			pool := NewPool(vmIndexer, configMaps)
+
+			err := pool.AssignMacAddresses(newRange("02:00:00:00:00:00", "02:00:00:00:00:00"), newVM("testvm", ""))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("FindConflicts", func() {
+		It("should report the VirtualMachine which uses the same address", func() {
+			Expect(vmIndexer.Add(newVM("other", "02:00:00:00:00:0a"))).To(Succeed())
+
+			conflicts, err := FindConflicts(vmIndexer, newVM("testvm", "02:00:00:00:00:01", "02:00:00:00:00:0A"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(Equal(map[int]string{1: "default/other"}))
+		})
+
+		It("should ignore the addresses of the VirtualMachine itself", func() {
+			vm := newVM("testvm", "02:00:00:00:00:01")
+			Expect(vmIndexer.Add(vm)).To(Succeed())
+
+			conflicts, err := FindConflicts(vmIndexer, vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,262 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	DefaultPacketCount = 1000
+	MaxPacketCount     = 100000
+	DefaultDuration    = 1 * time.Minute
+	MaxDuration        = 10 * time.Minute
+
+	// SnapLen is the maximum number of bytes captured from each packet
+	SnapLen = 65535
+
+	// pcap file format, see https://wiki.wireshark.org/Development/LibpcapFileFormat
+	magicNumber      = 0xa1b2c3d4
+	versionMajor     = 2
+	versionMinor     = 4
+	linkTypeEthernet = 1
+
+	// readTimeout bounds how long a read blocks, so the capture duration is enforced on idle interfaces
+	readTimeout = 1 * time.Second
+)
+
+// Options bound a packet capture
+type Options struct {
+	// Filter is a classic BPF program which selects the captured packets, all packets are captured if empty
+	Filter []bpf.RawInstruction
+	// Count is the number of packets after which the capture stops
+	Count int
+	// Duration is the time after which the capture stops
+	Duration time.Duration
+}
+
+// ParseOptions parses the capture options as passed in the query of the pcap subresource.
+// The count and the duration, in seconds, are optional and bounded by MaxPacketCount and MaxDuration.
+func ParseOptions(filter, count, duration string) (*Options, error) {
+	options := &Options{
+		Count:    DefaultPacketCount,
+		Duration: DefaultDuration,
+	}
+
+	var err error
+	if options.Filter, err = ParseFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if count != "" {
+		options.Count, err = strconv.Atoi(count)
+		if err != nil || options.Count <= 0 || options.Count > MaxPacketCount {
+			return nil, fmt.Errorf("the packet count must be between 1 and %d", MaxPacketCount)
+		}
+	}
+
+	if duration != "" {
+		seconds, err := strconv.Atoi(duration)
+		options.Duration = time.Duration(seconds) * time.Second
+		if err != nil || options.Duration <= 0 || options.Duration > MaxDuration {
+			return nil, fmt.Errorf("the capture duration must be between 1 and %d seconds", int(MaxDuration.Seconds()))
+		}
+	}
+
+	return options, nil
+}
+
+// ParseFilter parses a classic BPF program in the format printed by "tcpdump -ddd <expression>".
+// The leading instruction count is optional and the instructions may be separated by commas or newlines.
+func ParseFilter(filter string) ([]bpf.RawInstruction, error) {
+	lines := strings.FieldsFunc(filter, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ';'
+	})
+	var program []bpf.RawInstruction
+	for idx, line := range lines {
+		fields := strings.Fields(line)
+		if idx == 0 && len(fields) == 1 {
+			// instruction count
+			continue
+		}
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid BPF instruction %q, expected \"code jt jf k\"", line)
+		}
+		var values [4]uint64
+		for i, bits := range []int{16, 8, 8, 32} {
+			value, err := strconv.ParseUint(fields[i], 0, bits)
+			if err != nil {
+				return nil, fmt.Errorf("invalid BPF instruction %q: %v", line, err)
+			}
+			values[i] = value
+		}
+		program = append(program, bpf.RawInstruction{
+			Op: uint16(values[0]),
+			Jt: uint8(values[1]),
+			Jf: uint8(values[2]),
+			K:  uint32(values[3]),
+		})
+	}
+	if len(program) == 0 {
+		return nil, nil
+	}
+
+	instructions, allDecoded := bpf.Disassemble(program)
+	if !allDecoded {
+		return nil, fmt.Errorf("the BPF filter contains unknown instructions")
+	}
+	if _, err := bpf.NewVM(instructions); err != nil {
+		return nil, fmt.Errorf("invalid BPF filter: %v", err)
+	}
+	return program, nil
+}
+
+// Socket captures the packets sent and received on a network device
+type Socket struct {
+	fd int
+}
+
+// Open binds a packet socket to the device. Since devices are looked up in the network namespace of the caller,
+// it has to be called in the network namespace of the device.
+func Open(device string, filter []bpf.RawInstruction) (*Socket, error) {
+	iface, err := net.InterfaceByName(device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the device %s: %v", device, err)
+	}
+
+	// the socket receives no packets before it is bound, so the filter applies to all of them
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a packet socket: %v", err)
+	}
+	socket := &Socket{fd: fd}
+
+	if err := socket.attachFilter(filter); err != nil {
+		socket.Close()
+		return nil, err
+	}
+	tv := unix.NsecToTimeval(readTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		socket.Close()
+		return nil, fmt.Errorf("failed to set the read timeout of the packet socket: %v", err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ALL), Ifindex: iface.Index}); err != nil {
+		socket.Close()
+		return nil, fmt.Errorf("failed to bind the packet socket to %s: %v", device, err)
+	}
+	return socket, nil
+}
+
+func (s *Socket) attachFilter(filter []bpf.RawInstruction) error {
+	if len(filter) == 0 {
+		return nil
+	}
+	program := make([]unix.SockFilter, len(filter))
+	for i, instruction := range filter {
+		program[i] = unix.SockFilter{Code: instruction.Op, Jt: instruction.Jt, Jf: instruction.Jf, K: instruction.K}
+	}
+	fprog := unix.SockFprog{Len: uint16(len(program)), Filter: &program[0]}
+	if err := unix.SetsockoptSockFprog(s.fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, &fprog); err != nil {
+		return fmt.Errorf("failed to attach the BPF filter: %v", err)
+	}
+	return nil
+}
+
+func (s *Socket) Close() error {
+	return unix.Close(s.fd)
+}
+
+// Capture writes the captured packets to w in the pcap format. It returns when the packet count or the duration
+// of the options is reached, or when writing fails.
+func (s *Socket) Capture(w io.Writer, options *Options) error {
+	if err := writeHeader(w); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(options.Duration)
+	buf := make([]byte, SnapLen)
+	for captured := 0; captured < options.Count && time.Now().Before(deadline); {
+		// MSG_TRUNC returns the length of the packet, even if it is longer than the buffer
+		length, _, err := unix.Recvfrom(s.fd, buf, unix.MSG_TRUNC)
+		if err == unix.EAGAIN || err == unix.EINTR {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read from the packet socket: %v", err)
+		}
+		data := buf
+		if length < len(buf) {
+			data = buf[:length]
+		}
+		if err := writePacket(w, time.Now(), data, length); err != nil {
+			return err
+		}
+		captured++
+	}
+	return nil
+}
+
+// ReadPacket reads the next packet into buf. It returns the length of the packet, which may exceed the buffer, and
+// whether the packet was sent by the device rather than received. Reads time out on idle devices with unix.EAGAIN.
+func (s *Socket) ReadPacket(buf []byte) (int, bool, error) {
+	length, from, err := unix.Recvfrom(s.fd, buf, unix.MSG_TRUNC)
+	if err != nil {
+		return 0, false, err
+	}
+	outgoing := false
+	if linkLayer, ok := from.(*unix.SockaddrLinklayer); ok {
+		outgoing = linkLayer.Pkttype == unix.PACKET_OUTGOING
+	}
+	return length, outgoing, nil
+}
+
+func writeHeader(w io.Writer) error {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:4], magicNumber)
+	binary.LittleEndian.PutUint16(header[4:6], versionMajor)
+	binary.LittleEndian.PutUint16(header[6:8], versionMinor)
+	// the timezone offset and the timestamp accuracy are always zero
+	binary.LittleEndian.PutUint32(header[16:20], SnapLen)
+	binary.LittleEndian.PutUint32(header[20:24], linkTypeEthernet)
+	_, err := w.Write(header)
+	return err
+}
+
+func writePacket(w io.Writer, timestamp time.Time, data []byte, length int) error {
+	record := make([]byte, 16, 16+len(data))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(timestamp.Unix()))
+	binary.LittleEndian.PutUint32(record[4:8], uint32(timestamp.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(record[8:12], uint32(len(data)))
+	binary.LittleEndian.PutUint32(record[12:16], uint32(length))
+	_, err := w.Write(append(record, data...))
+	return err
+}
+
+func htons(value uint16) uint16 {
+	return value<<8 | value>>8
+}
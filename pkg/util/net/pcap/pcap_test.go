@@ -0,0 +1,150 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/bpf"
+)
+
+// arpFilter is the output of "tcpdump -ddd arp"
+const arpFilter = `4
+40 0 0 12
+21 0 1 2054
+6 0 0 262144
+6 0 0 0
+`
+
+var _ = Describe("Packet capture", func() {
+	Context("filter", func() {
+		It("should parse the tcpdump -ddd format", func() {
+			program, err := ParseFilter(arpFilter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(program).To(Equal([]bpf.RawInstruction{
+				{Op: 40, Jt: 0, Jf: 0, K: 12},
+				{Op: 21, Jt: 0, Jf: 1, K: 2054},
+				{Op: 6, Jt: 0, Jf: 0, K: 262144},
+				{Op: 6, Jt: 0, Jf: 0, K: 0},
+			}))
+		})
+
+		It("should accept comma separated instructions without the count", func() {
+			program, err := ParseFilter("40 0 0 12,21 0 1 0x806,6 0 0 262144,6 0 0 0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(program).To(HaveLen(4))
+			Expect(program[1].K).To(Equal(uint32(0x806)))
+		})
+
+		It("should select the matching packets", func() {
+			program, err := ParseFilter(arpFilter)
+			Expect(err).ToNot(HaveOccurred())
+			instructions, _ := bpf.Disassemble(program)
+			vm, err := bpf.NewVM(instructions)
+			Expect(err).ToNot(HaveOccurred())
+
+			frame := func(etherType uint16) []byte {
+				f := make([]byte, 60)
+				binary.BigEndian.PutUint16(f[12:14], etherType)
+				return f
+			}
+			Expect(vm.Run(frame(0x0806))).To(Equal(262144))
+			Expect(vm.Run(frame(0x0800))).To(Equal(0))
+		})
+
+		It("should capture all packets when empty", func() {
+			program, err := ParseFilter("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(program).To(BeEmpty())
+		})
+
+		table.DescribeTable("should reject", func(filter string) {
+			_, err := ParseFilter(filter)
+			Expect(err).To(HaveOccurred())
+		},
+			table.Entry("an instruction with missing fields", "40 0 12"),
+			table.Entry("a non numeric field", "40 0 0 twelve"),
+			table.Entry("a jump offset out of range", "21 0 300 2054"),
+			table.Entry("a jump beyond the program", "21 0 5 2054,6 0 0 0"),
+			table.Entry("a program which does not return", "40 0 0 12"),
+		)
+	})
+
+	Context("options", func() {
+		It("should default the count and the duration", func() {
+			options, err := ParseOptions("", "", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options.Count).To(Equal(DefaultPacketCount))
+			Expect(options.Duration).To(Equal(DefaultDuration))
+			Expect(options.Filter).To(BeEmpty())
+		})
+
+		It("should parse the count and the duration in seconds", func() {
+			options, err := ParseOptions(arpFilter, "10", "30")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options.Count).To(Equal(10))
+			Expect(options.Duration).To(Equal(30 * time.Second))
+			Expect(options.Filter).To(HaveLen(4))
+		})
+
+		table.DescribeTable("should reject", func(count, duration string) {
+			_, err := ParseOptions("", count, duration)
+			Expect(err).To(HaveOccurred())
+		},
+			table.Entry("a zero count", "0", ""),
+			table.Entry("a count above the maximum", "100001", ""),
+			table.Entry("a non numeric count", "ten", ""),
+			table.Entry("a negative duration", "", "-1"),
+			table.Entry("a duration above the maximum", "", "601"),
+		)
+	})
+
+	Context("pcap format", func() {
+		It("should write the global header", func() {
+			var buf bytes.Buffer
+			Expect(writeHeader(&buf)).To(Succeed())
+			header := buf.Bytes()
+			Expect(header).To(HaveLen(24))
+			Expect(binary.LittleEndian.Uint32(header[0:4])).To(Equal(uint32(0xa1b2c3d4)))
+			Expect(binary.LittleEndian.Uint16(header[4:6])).To(Equal(uint16(2)))
+			Expect(binary.LittleEndian.Uint16(header[6:8])).To(Equal(uint16(4)))
+			Expect(binary.LittleEndian.Uint32(header[16:20])).To(Equal(uint32(SnapLen)))
+			Expect(binary.LittleEndian.Uint32(header[20:24])).To(Equal(uint32(1)))
+		})
+
+		It("should write the packet records with the captured and the original length", func() {
+			var buf bytes.Buffer
+			timestamp := time.Unix(1600000000, 123456000)
+			Expect(writePacket(&buf, timestamp, []byte{1, 2, 3}, 1500)).To(Succeed())
+			record := buf.Bytes()
+			Expect(record).To(HaveLen(19))
+			Expect(binary.LittleEndian.Uint32(record[0:4])).To(Equal(uint32(1600000000)))
+			Expect(binary.LittleEndian.Uint32(record[4:8])).To(Equal(uint32(123456)))
+			Expect(binary.LittleEndian.Uint32(record[8:12])).To(Equal(uint32(3)))
+			Expect(binary.LittleEndian.Uint32(record[12:16])).To(Equal(uint32(1500)))
+			Expect(record[16:]).To(Equal([]byte{1, 2, 3}))
+		})
+	})
+})
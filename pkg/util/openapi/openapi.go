@@ -17,6 +17,11 @@ import (
 	"k8s.io/kube-openapi/pkg/common"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 )
 
@@ -108,6 +113,36 @@ func createConfig() *common.Config {
 					m[k] = v
 				}
 			}
+			m3 := exportv1.GetOpenAPIDefinitions(ref)
+			for k, v := range m3 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			m4 := clonev1.GetOpenAPIDefinitions(ref)
+			for k, v := range m4 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			m5 := instancetypev1alpha1.GetOpenAPIDefinitions(ref)
+			for k, v := range m5 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			m6 := poolv1.GetOpenAPIDefinitions(ref)
+			for k, v := range m6 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			m7 := checkupv1.GetOpenAPIDefinitions(ref)
+			for k, v := range m7 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
 			return m
 		},
 
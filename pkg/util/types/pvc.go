@@ -28,9 +28,19 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
+	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 )
 
+const (
+	// FilesystemOverhead is the fraction of a filesystem PVC which is kept free for the filesystem
+	// itself, when the disk image stored on it is sized to the capacity of the PVC.
+	FilesystemOverhead = 0.055
+
+	// diskSizeAlignment is the alignment of the disk sizes, which keeps them a multiple of any block size
+	diskSizeAlignment = 1 << 20
+)
+
 func IsPVCBlockFromStore(store cache.Store, namespace string, claimName string) (pvc *k8sv1.PersistentVolumeClaim, exists bool, isBlockDevice bool, err error) {
 	obj, exists, err := store.GetByKey(namespace + "/" + claimName)
 	if err != nil || !exists {
@@ -69,6 +79,19 @@ func IsPVCShared(pvc *k8sv1.PersistentVolumeClaim) bool {
 	return false
 }
 
+// IsSharedPVCInfo returns true if the PVC described by the volume status can be used by several nodes at once
+func IsSharedPVCInfo(pvcInfo *virtv1.PersistentVolumeClaimInfo) bool {
+	if pvcInfo == nil {
+		return false
+	}
+	for _, accessMode := range pvcInfo.AccessModes {
+		if accessMode == k8sv1.ReadWriteMany {
+			return true
+		}
+	}
+	return false
+}
+
 func IsSharedPVCFromClient(client kubecli.KubevirtClient, namespace string, claimName string) (pvc *k8sv1.PersistentVolumeClaim, isShared bool, err error) {
 	pvc, err = client.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), claimName, v1.GetOptions{})
 	if err == nil {
@@ -76,3 +99,21 @@ func IsSharedPVCFromClient(client kubecli.KubevirtClient, namespace string, clai
 	}
 	return
 }
+
+// GetDiskCapacity returns the size in bytes a disk backed by the PVC can grow to, or nil if the capacity
+// of the PVC is unknown. On filesystem PVCs, the FilesystemOverhead is left free.
+func GetDiskCapacity(pvcInfo *virtv1.PersistentVolumeClaimInfo) *int64 {
+	if pvcInfo == nil {
+		return nil
+	}
+	storage, ok := pvcInfo.Capacity[k8sv1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+	capacity := storage.Value()
+	if pvcInfo.VolumeMode == nil || *pvcInfo.VolumeMode != k8sv1.PersistentVolumeBlock {
+		capacity = int64(float64(capacity) * (1 - FilesystemOverhead))
+	}
+	capacity -= capacity % diskSizeAlignment
+	return &capacity
+}
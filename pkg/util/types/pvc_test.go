@@ -26,10 +26,12 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	kubev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 
+	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 )
 
@@ -154,6 +156,49 @@ var _ = Describe("PVC utils test", func() {
 			Expect(pvc.Name).To(Equal(blockName), "correct PVC was found")
 			Expect(isShared).To(BeTrue(), "Is PVC Shared")
 		})
+
+		It("should find out if the PVC of a volume status is shared", func() {
+			Expect(IsSharedPVCInfo(nil)).To(BeFalse())
+			Expect(IsSharedPVCInfo(&virtv1.PersistentVolumeClaimInfo{
+				AccessModes: []kubev1.PersistentVolumeAccessMode{kubev1.ReadWriteOnce},
+			})).To(BeFalse())
+			Expect(IsSharedPVCInfo(&virtv1.PersistentVolumeClaimInfo{
+				AccessModes: blockPvc.Spec.AccessModes,
+			})).To(BeTrue())
+		})
+	})
+
+	Context("disk capacity", func() {
+
+		capacity := kubev1.ResourceList{
+			kubev1.ResourceStorage: resource.MustParse("1Gi"),
+		}
+
+		It("should be unknown without PVC info or storage capacity", func() {
+			Expect(GetDiskCapacity(nil)).To(BeNil())
+			Expect(GetDiskCapacity(&virtv1.PersistentVolumeClaimInfo{})).To(BeNil())
+		})
+
+		It("should be the PVC capacity on block PVCs", func() {
+			diskCapacity := GetDiskCapacity(&virtv1.PersistentVolumeClaimInfo{
+				Capacity:   capacity,
+				VolumeMode: &modeBlock,
+			})
+			Expect(diskCapacity).ToNot(BeNil())
+			Expect(*diskCapacity).To(Equal(int64(1 << 30)))
+		})
+
+		It("should leave the filesystem overhead free on filesystem PVCs", func() {
+			for _, volumeMode := range []*kubev1.PersistentVolumeMode{nil, &modeFile} {
+				// 1GiB minus 5.5%, aligned down to 1MiB
+				diskCapacity := GetDiskCapacity(&virtv1.PersistentVolumeClaimInfo{
+					Capacity:   capacity,
+					VolumeMode: volumeMode,
+				})
+				Expect(diskCapacity).ToNot(BeNil())
+				Expect(*diskCapacity).To(Equal(int64(967 << 20)))
+			}
+		})
 	})
 
 })
@@ -1,8 +1,10 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	v1 "kubevirt.io/client-go/api/v1"
 )
@@ -16,6 +18,43 @@ const KubeletPodsDir = "/var/lib/kubelet/pods"
 const HostRootMount = "/proc/1/root/"
 const CPUManagerOS3Path = HostRootMount + "var/lib/origin/openshift.local.volumes/cpu_manager_state"
 const CPUManagerPath = HostRootMount + "var/lib/kubelet/cpu_manager_state"
+const SEVParameterPath = HostRootMount + "sys/module/kvm_amd/parameters/sev"
+const SEVESParameterPath = HostRootMount + "sys/module/kvm_amd/parameters/sev_es"
+const SEVSNPParameterPath = HostRootMount + "sys/module/kvm_amd/parameters/sev_snp"
+const TDXParameterPath = HostRootMount + "sys/module/kvm_intel/parameters/tdx"
+const RealtimeRuntimePath = HostRootMount + "proc/sys/kernel/sched_rt_runtime_us"
+const ConntrackBridgeModulePath = HostRootMount + "sys/module/nf_conntrack_bridge"
+
+// EphemeralDisksDir is the directory on the node which stores the ephemeral disk data of the VMIs if ephemeral disk backings are configured
+const EphemeralDisksDir = VirtLibDir + "/ephemeral-disks"
+
+// PanicMemoryDumpDir is where the PVC storing the memory dumps of panicked guests is mounted in virt-launcher
+const PanicMemoryDumpDir = VirtPrivateDir + "/panic-memory-dump"
+
+// MemoryDumpDir is where the PVC storing the memory dumps of the running guest is mounted in virt-launcher
+const MemoryDumpDir = VirtPrivateDir + "/memory-dump"
+
+// SerialConsoleLogDir is where the PVC storing the serial console log of the guest is mounted in virt-launcher
+const SerialConsoleLogDir = VirtPrivateDir + "/serial-console-log"
+
+// SerialConsoleLogFile is the file in the private directory of the VMI which libvirt mirrors the serial console to
+const SerialConsoleLogFile = "virt-serial0-log"
+
+// BackupSocketName is the unix socket in the private directory of the VMI on which libvirt serves the NBD exports of a running backup
+const BackupSocketName = "virt-backup"
+
+// DefaultBackupHookTimeout is how long the backup hooks may run on the guest if the VMI does not specify it
+const DefaultBackupHookTimeout = 30 * time.Second
+
+// PrHelperSocketDir is where the qemu-pr-helper daemon of virt-handler creates its socket on the host,
+// it is mounted at the same path into virt-launcher
+const PrHelperSocketDir = VirtShareDir + "/daemons/pr"
+
+// PrHelperSocketPath is the socket through which QEMU sends the persistent reservation commands of LUN disks to the host
+const PrHelperSocketPath = PrHelperSocketDir + "/pr-helper.sock"
+
+// VhostUserSocketDir is where the vhost-user sockets of the guest interfaces are created in virt-launcher
+const VhostUserSocketDir = "/var/run/vhostuser"
 
 func IsSRIOVVmi(vmi *v1.VirtualMachineInstance) bool {
 	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
@@ -26,6 +65,26 @@ func IsSRIOVVmi(vmi *v1.VirtualMachineInstance) bool {
 	return false
 }
 
+// IsPasstVmi checks if a VMI spec requests a passt interface
+func IsPasstVmi(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Passt != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsVhostUserVmi checks if a VMI spec requests a vhost-user interface
+func IsVhostUserVmi(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.VhostUser != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // Check if a VMI spec requests GPU
 func IsGPUVMI(vmi *v1.VirtualMachineInstance) bool {
 	if vmi.Spec.Domain.Devices.GPUs != nil && len(vmi.Spec.Domain.Devices.GPUs) != 0 {
@@ -63,6 +122,138 @@ func IsVFIOVMI(vmi *v1.VirtualMachineInstance) bool {
 	return false
 }
 
+// Check if a VMI spec requests AMD SEV-SNP
+func IsSEVSNPVMI(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.LaunchSecurity != nil && vmi.Spec.Domain.LaunchSecurity.SNP != nil
+}
+
+// Check if a VMI spec requests Intel TDX
+func IsTDXVMI(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.LaunchSecurity != nil && vmi.Spec.Domain.LaunchSecurity.TDX != nil
+}
+
+// Check if a VMI spec requests any kind of launch security, which encrypts the guest memory
+func IsConfidentialVMI(vmi *v1.VirtualMachineInstance) bool {
+	return IsSEVVMI(vmi) || IsSEVSNPVMI(vmi) || IsTDXVMI(vmi)
+}
+
+// Check if a VMI spec requests realtime tuning of the vCPUs
+func IsRealtimeVMI(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.Realtime != nil
+}
+
+// IsDefaultDenyFirewallVMI returns true if an interface of the VMI has a firewall which denies the packets no rule matches
+func IsDefaultDenyFirewallVMI(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Firewall != nil && iface.Firewall.DefaultAction == v1.FirewallActionDeny {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a VMI spec requests AMD SEV
+func IsSEVVMI(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.LaunchSecurity != nil && vmi.Spec.Domain.LaunchSecurity.SEV != nil
+}
+
+// Check if a VMI spec requests AMD SEV-ES
+func IsSEVESVMI(vmi *v1.VirtualMachineInstance) bool {
+	return IsSEVVMI(vmi) &&
+		vmi.Spec.Domain.LaunchSecurity.SEV.Policy != nil &&
+		vmi.Spec.Domain.LaunchSecurity.SEV.Policy.EncryptedState != nil &&
+		*vmi.Spec.Domain.LaunchSecurity.SEV.Policy.EncryptedState
+}
+
+// Check if a VMI spec requests the attestation of an AMD SEV guest
+func IsSEVAttestationRequested(vmi *v1.VirtualMachineInstance) bool {
+	return IsSEVVMI(vmi) && vmi.Spec.Domain.LaunchSecurity.SEV.Attestation != nil
+}
+
+// Check if a VMI spec requests a VSOCK device
+func IsAutoAttachVSOCK(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.Devices.AutoattachVSOCK != nil && *vmi.Spec.Domain.Devices.AutoattachVSOCK
+}
+
+// Check if a VMI spec requests a pvpanic device, which is the default
+func IsAutoAttachPanicDevice(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.Devices.AutoattachPanicDevice == nil || *vmi.Spec.Domain.Devices.AutoattachPanicDevice
+}
+
+// Check if the VMI is marked as a Windows guest
+func IsWindowsVMI(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Annotations[v1.GuestOSAnnotation] == v1.GuestOSWindows
+}
+
+// Check if a LUN disk of the VMI requests persistent reservations
+func HasPersistentReservation(vmi *v1.VirtualMachineInstance) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.LUN != nil && disk.LUN.Reservation {
+			return true
+		}
+	}
+	return false
+}
+
+func IsSerialConsoleLogEnabled(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.Devices.LogSerialConsole != nil && *vmi.Spec.Domain.Devices.LogSerialConsole
+}
+
+// SerialPortSocketName returns the name of the unix socket of the additional serial port with the given index.
+// virt-serial0 is reserved for the default serial console.
+func SerialPortSocketName(index int) string {
+	return fmt.Sprintf("virt-serial%d", index+1)
+}
+
+// BackupBitmapName returns the name under which libvirt exports the dirty bitmap of the disk during an incremental backup
+func BackupBitmapName(diskName string) string {
+	return "backup-" + diskName
+}
+
+// ExternalSnapshotOverlay returns the name of the qcow2 overlay the disk of a volume is switched to by the external
+// snapshot with the given name. The overlay is created next to the image of the volume.
+func ExternalSnapshotOverlay(snapshotName string) string {
+	return snapshotName + ".qcow2"
+}
+
+// ChannelSocketName returns the name of the unix socket of the channel with the given index
+// GetBackupHook returns the guest command and its arguments held by the given backup hook annotation,
+// or nil if the annotation is not set.
+func GetBackupHook(annotations map[string]string, annotation string) ([]string, error) {
+	value, exists := annotations[annotation]
+	if !exists {
+		return nil, nil
+	}
+	var command []string
+	if err := json.Unmarshal([]byte(value), &command); err != nil {
+		return nil, fmt.Errorf("%s must be a JSON array of the command and its arguments: %v", annotation, err)
+	}
+	if len(command) == 0 || command[0] == "" {
+		return nil, fmt.Errorf("%s must specify a command", annotation)
+	}
+	return command, nil
+}
+
+// GetBackupHookTimeout returns how long the backup hooks may run on the guest
+func GetBackupHookTimeout(annotations map[string]string) (time.Duration, error) {
+	value, exists := annotations[v1.BackupHookTimeoutAnnotation]
+	if !exists {
+		return DefaultBackupHookTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration: %v", v1.BackupHookTimeoutAnnotation, err)
+	}
+	if timeout < time.Second {
+		return 0, fmt.Errorf("%s must be at least 1s", v1.BackupHookTimeoutAnnotation)
+	}
+	return timeout, nil
+}
+
+func ChannelSocketName(index int) string {
+	return fmt.Sprintf("virt-channel%d", index)
+}
+
 func ResourceNameToEnvVar(prefix string, resourceName string) string {
 	varName := strings.ToUpper(resourceName)
 	varName = strings.Replace(varName, "/", "_", -1)
@@ -42,6 +42,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/client-go/subresources"
 	clientutil "kubevirt.io/client-go/util"
 	virtversion "kubevirt.io/client-go/version"
 	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
@@ -50,6 +51,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/rest/filter"
 	"kubevirt.io/kubevirt/pkg/service"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	"kubevirt.io/kubevirt/pkg/util/openapi"
 	webhooksutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/rest"
@@ -96,6 +98,7 @@ type virtAPIApp struct {
 	authorizor       rest.VirtApiAuthorizor
 	certsDirectory   string
 	clusterConfig    *virtconfig.ClusterConfig
+	macPool          *macpool.Pool
 
 	namespace               string
 	host                    string
@@ -218,6 +221,15 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("networkboot")).
+			To(subresourceApp.NetworkBootVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"NetworkBoot").
+			Doc("Boot a VirtualMachine from the network on its next start.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("start")).
 			To(subresourceApp.StartVMRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -254,18 +266,143 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("freeze")).
+			To(subresourceApp.FreezeVMIRequestHandler).
+			Reads(v1.FreezeUnfreezeTimeout{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Freeze").
+			Doc("Freeze a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("unfreeze")).
+			To(subresourceApp.UnfreezeVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Unfreeze").
+			Doc("Unfreeze a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("memorydump")).
+			To(subresourceApp.MemoryDumpVMIRequestHandler).
+			Reads(v1.MemoryDumpOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"MemoryDump").
+			Doc("Dump the memory of a VirtualMachineInstance object to its memory dump PVC.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("externalsnapshot")).
+			To(subresourceApp.ExternalSnapshotVMIRequestHandler).
+			Reads(v1.ExternalSnapshotOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"ExternalSnapshot").
+			Doc("Switch the disks of volumes of a VirtualMachineInstance object to qcow2 overlays, taking an external snapshot.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("blockcommit")).
+			To(subresourceApp.BlockCommitVMIRequestHandler).
+			Reads(v1.BlockCommitOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"BlockCommit").
+			Doc("Commit the qcow2 overlays of volumes of a VirtualMachineInstance object into their images.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("startbackup")).
+			To(subresourceApp.StartBackupVMIRequestHandler).
+			Reads(v1.BackupOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"StartBackup").
+			Doc("Begin a backup of the qcow2 disks of a VirtualMachineInstance object, creating a checkpoint for later incremental backups.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("stopbackup")).
+			To(subresourceApp.StopBackupVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"StopBackup").
+			Doc("End the running backup of a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("console")).
 			To(subresourceApp.ConsoleRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter(subresources.SessionTokenParam, "Token of a detached session to resume, as returned in the "+subresources.SessionTokenHeader+" response header")).
 			Operation(version.Version + "Console").
 			Doc("Open a websocket connection to a serial console on the specified VirtualMachineInstance."))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("console/log")).
+			To(subresourceApp.SerialConsoleLogRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces("text/plain").
+			Operation(version.Version+"SerialConsoleLog").
+			Doc("Get the serial console log of the specified VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("vnc")).
 			To(subresourceApp.VNCRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Operation(version.Version + "VNC").
 			Doc("Open a websocket connection to connect to VNC on the specified VirtualMachineInstance."))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("vsock")).
+			To(subresourceApp.VSOCKRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter(rest.VSOCKPortParam, "The port which the VSOCK application listens to.").DataType("integer").Required(true)).
+			Operation(version.Version + "VSOCK").
+			Doc("Open a websocket connection to connect to VSOCK on the specified VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("serial")).
+			To(subresourceApp.SerialPortRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter(rest.DeviceNameParam, "The name of the serial port.").Required(true)).
+			Operation(version.Version + "SerialPort").
+			Doc("Open a websocket connection to an additional serial port of the specified VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("channel")).
+			To(subresourceApp.ChannelRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter(rest.DeviceNameParam, "The name of the channel.").Required(true)).
+			Operation(version.Version + "Channel").
+			Doc("Open a websocket connection to a virtio channel of the specified VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("pcap")).
+			To(subresourceApp.PcapRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter(rest.InterfaceNameParam, "The name of the interface to capture packets on.").Required(true)).
+			Param(subws.QueryParameter(rest.PcapFilterParam, "The BPF program selecting the captured packets, as printed by tcpdump -ddd.")).
+			Param(subws.QueryParameter(rest.PcapCountParam, "The number of packets after which the capture stops.").DataType("integer")).
+			Param(subws.QueryParameter(rest.PcapDurationParam, "The number of seconds after which the capture stops.").DataType("integer")).
+			Operation(version.Version + "Pcap").
+			Doc("Open a websocket connection streaming a packet capture, in the pcap format, of an interface of the specified VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("portforward")).
+			To(subresourceApp.PortForwardRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter(rest.PortForwardPortParam, "The port of the guest to connect to.").DataType("integer").Required(true)).
+			Param(subws.QueryParameter(rest.PortForwardProtocolParam, "The protocol of the port, tcp or udp. Defaults to tcp.")).
+			Operation(version.Version + "PortForward").
+			Doc("Open a websocket connection to a TCP or UDP port of the specified VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("backup")).
+			To(subresourceApp.BackupRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter(rest.BackupDiskParam, "The name of the disk to stream the changed blocks of.").Required(true)).
+			Param(subws.QueryParameter(rest.BackupExtentsOnlyParam, "Stream only the offsets and lengths of the changed extents, without their data.").DataType("boolean")).
+			Operation(version.Version + "Backup").
+			Doc("Open a websocket connection streaming the blocks of a disk of the specified VirtualMachineInstance which changed since the checkpoint the running backup started from."))
+
 		// An empty handler function would respond with HTTP OK by default
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("test")).
 			To(func(request *restful.Request, response *restful.Response) {}).
@@ -323,6 +460,14 @@ func (app *virtAPIApp) composeSubresources() {
 			Writes(v1.VirtualMachineInstanceFileSystemList{}).
 			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("addinterface")).
+			To(subresourceApp.VMIAddInterfaceRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vmi-addinterface").
+			Doc("Add a network interface to a running Virtual Machine Instance").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("addvolume")).
 			To(subresourceApp.VMIAddVolumeRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -339,6 +484,46 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("removeinterface")).
+			To(subresourceApp.VMIRemoveInterfaceRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vmi-removeinterface").
+			Doc("Removes a network interface from a running Virtual Machine Instance").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("setlinkstate")).
+			To(subresourceApp.VMISetLinkStateRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vmi-setlinkstate").
+			Doc("Sets the link state of a network interface of a running Virtual Machine Instance").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("insertmedia")).
+			To(subresourceApp.VMIInsertMediaRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vmi-insertmedia").
+			Doc("Inserts media into a CD-ROM of a running Virtual Machine Instance").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("ejectmedia")).
+			To(subresourceApp.VMIEjectMediaRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vmi-ejectmedia").
+			Doc("Ejects the media from a CD-ROM of a running Virtual Machine Instance").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("removeinterface")).
+			To(subresourceApp.VMRemoveInterfaceRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vm-removeinterface").
+			Doc("Removes a network interface from a Virtual Machine").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("addvolume")).
 			To(subresourceApp.VMAddVolumeRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -355,6 +540,35 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("sev/fetchcertchain")).
+			To(subresourceApp.SEVFetchCertChainRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"SEVFetchCertChain").
+			Doc("Fetch SEV certificate chain from the node where Virtual Machine is scheduled").
+			Writes(v1.SEVPlatformInfo{}).
+			Returns(http.StatusOK, "OK", v1.SEVPlatformInfo{}))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("sev/querylaunchmeasurement")).
+			To(subresourceApp.SEVQueryLaunchMeasurementRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"SEVQueryLaunchMeasurement").
+			Doc("Query SEV launch measurement from a Virtual Machine").
+			Writes(v1.SEVMeasurementInfo{}).
+			Returns(http.StatusOK, "OK", v1.SEVMeasurementInfo{}))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("sev/injectlaunchsecret")).
+			To(subresourceApp.SEVInjectLaunchSecretRequestHandler).
+			Reads(v1.SEVSecretOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"SEVInjectLaunchSecret").
+			Doc("Inject SEV launch secret into a Virtual Machine").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		// Return empty api resource list.
 		// K8s expects to be able to retrieve a resource list for each aggregated
 		// app in order to discover what resources it provides. Without returning
@@ -377,6 +591,30 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/console",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/console/log",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/vsock",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/serial",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/channel",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/pcap",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/portforward",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/pause",
 						Namespaced: true,
@@ -385,6 +623,38 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/unpause",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/freeze",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/unfreeze",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/memorydump",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/externalsnapshot",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/blockcommit",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/startbackup",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/stopbackup",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/backup",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/start",
 						Namespaced: true,
@@ -401,6 +671,14 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/migrate",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/networkboot",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/removeinterface",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/guestosinfo",
 						Namespaced: true,
@@ -417,6 +695,26 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/filesystemlist",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/addinterface",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/removeinterface",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/setlinkstate",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/insertmedia",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/ejectmedia",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/addvolume",
 						Namespaced: true,
@@ -425,6 +723,18 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/removevolume",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/sev/fetchcertchain",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/sev/querylaunchmeasurement",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/sev/injectlaunchsecret",
+						Namespaced: true,
+					},
 				}
 
 				response.WriteAsJson(list)
@@ -631,6 +941,18 @@ func (app *virtAPIApp) registerValidatingWebhooks() {
 	http.HandleFunc(components.VMRestoreValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMRestores(w, r, app.clusterConfig, app.virtCli)
 	})
+	http.HandleFunc(components.VMCloneValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMClones(w, r)
+	})
+	http.HandleFunc(components.VMPoolValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMPools(w, r, app.clusterConfig)
+	})
+	http.HandleFunc(components.VMSnapshotScheduleValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMSnapshotSchedules(w, r, app.clusterConfig)
+	})
+	http.HandleFunc(components.StorageCheckupValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeStorageCheckups(w, r)
+	})
 	http.HandleFunc(components.StatusValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeStatusValidation(w, r, app.clusterConfig, app.virtCli)
 	})
@@ -642,7 +964,7 @@ func (app *virtAPIApp) registerValidatingWebhooks() {
 func (app *virtAPIApp) registerMutatingWebhook() {
 
 	http.HandleFunc(components.VMMutatePath, func(w http.ResponseWriter, r *http.Request) {
-		mutating_webhook.ServeVMs(w, r, app.clusterConfig)
+		mutating_webhook.ServeVMs(w, r, app.clusterConfig, app.virtCli, app.macPool)
 	})
 	http.HandleFunc(components.VMIMutatePath, func(w http.ResponseWriter, r *http.Request) {
 		mutating_webhook.ServeVMIs(w, r, app.clusterConfig)
@@ -738,6 +1060,7 @@ func (app *virtAPIApp) Run() {
 	go webhookInformers.VMIPresetInformer.Run(stopChan)
 	go webhookInformers.NamespaceLimitsInformer.Run(stopChan)
 	go webhookInformers.VMRestoreInformer.Run(stopChan)
+	go webhookInformers.VMInformer.Run(stopChan)
 	go kubeVirtInformer.Run(stopChan)
 	go configMapInformer.Run(stopChan)
 	go crdInformer.Run(stopChan)
@@ -751,9 +1074,11 @@ func (app *virtAPIApp) Run() {
 		webhookInformers.VMIInformer.HasSynced,
 		webhookInformers.VMIPresetInformer.HasSynced,
 		webhookInformers.NamespaceLimitsInformer.HasSynced,
+		webhookInformers.VMInformer.HasSynced,
 		configMapInformer.HasSynced)
 
 	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, kubeVirtInformer, app.namespace)
+	app.macPool = macpool.NewPool(webhookInformers.VMInformer.GetIndexer(), app.virtCli.CoreV1().ConfigMaps(app.namespace))
 	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeLogVerbosity)
 
 	go app.certmanager.Start()
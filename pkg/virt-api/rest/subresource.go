@@ -20,14 +20,18 @@
 package rest
 
 import (
+	"bytes"
 	"context"
 
 	"crypto/tls"
 	goerror "errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	neturl "net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -44,12 +48,42 @@ import (
 	"kubevirt.io/kubevirt/pkg/util/status"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/client-go/subresources"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/net/pcap"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
+// VSOCKPortParam is the query parameter selecting the guest port of a VSOCK connection
+const VSOCKPortParam = "port"
+
+// DeviceNameParam is the query parameter selecting the serial port or channel to connect to
+const DeviceNameParam = "device"
+
+// Query parameters of the packet capture of a VMI interface
+const (
+	InterfaceNameParam = "interface"
+	PcapFilterParam    = "filter"
+	PcapCountParam     = "count"
+	PcapDurationParam  = "duration"
+)
+
+// Query parameters of the backup stream of a VMI disk
+const (
+	BackupDiskParam        = "disk"
+	BackupExtentsOnlyParam = "extentsOnly"
+)
+
+// Query parameters of the port forwarding to a VMI
+const (
+	PortForwardPortParam     = "port"
+	PortForwardProtocolParam = "protocol"
+)
+
 type SubresourceAPIApp struct {
 	virtCli                 kubecli.KubevirtClient
 	consoleServerPort       int
@@ -112,24 +146,40 @@ func (app *SubresourceAPIApp) streamRequestHandler(request *restful.Request, res
 		return
 	}
 
-	upgrader := kubecli.NewUpgrader()
-	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
-	if err != nil {
-		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
-		writeError(errors.NewBadRequest(err.Error()), response)
-		return
+	// The session is kept by virt-handler, which is the only one streaming from the VMI, so that the
+	// client can resume it through any virt-api replica
+	token := request.QueryParameter(subresources.SessionTokenParam)
+	if token != "" {
+		if url, err = withSessionToken(url, token); err != nil {
+			writeError(errors.NewInternalError(err), response)
+			return
+		}
 	}
-	defer clientSocket.Close()
-
-	conn, _, err := kubecli.Dial(url, app.handlerTLSConfiguration)
+	conn, handlerResponse, err := kubecli.Dial(url, app.handlerTLSConfiguration)
 	if err != nil {
+		if token != "" && handlerResponse != nil && handlerResponse.StatusCode == http.StatusNotFound {
+			writeError(errors.NewNotFound(v1.Resource("virtualmachineinstance"), fmt.Sprintf("%s session %s", vmi.Name, token)), response)
+			return
+		}
 		log.Log.Object(vmi).Reason(err).Error("failed to dial virt-handler for a console connection")
 		writeError(errors.NewInternalError(err), response)
 		return
 	}
 	defer conn.Close()
 
-	copyErr := make(chan error)
+	var header http.Header
+	if token = handlerResponse.Header.Get(subresources.SessionTokenHeader); token != "" {
+		header = http.Header{subresources.SessionTokenHeader: []string{token}}
+	}
+	upgrader := kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, header)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		return
+	}
+	defer clientSocket.Close()
+
+	copyErr := make(chan error, 2)
 	go func() {
 		_, err := kubecli.Copy(clientSocket, conn)
 		log.Log.Object(vmi).Reason(err).Error("error encountered reading from virt-handler stream")
@@ -145,12 +195,22 @@ func (app *SubresourceAPIApp) streamRequestHandler(request *restful.Request, res
 	// wait for copy to finish and check the result
 	if err = <-copyErr; err != nil && err != io.EOF {
 		log.Log.Object(vmi).Reason(err).Error("Error in websocket proxy")
-		writeError(errors.NewInternalError(err), response)
-		return
 	}
 }
 
-func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, response *restful.Response, validate validation, getVirtHandlerURL URLResolver) {
+// withSessionToken adds the token of the session to resume to the virt-handler URL of the stream
+func withSessionToken(handlerURL string, token string) (string, error) {
+	u, err := neturl.Parse(handlerURL)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set(subresources.SessionTokenParam, token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, response *restful.Response, validate validation, getVirtHandlerURL URLResolver, body io.ReadCloser) {
 
 	_, url, conn, statusErr := app.prepareConnection(request, validate, getVirtHandlerURL)
 	if statusErr != nil {
@@ -158,7 +218,7 @@ func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, respon
 		return
 	}
 
-	err := conn.Put(url, app.handlerTLSConfiguration)
+	err := conn.Put(url, app.handlerTLSConfiguration, body)
 	if err != nil {
 		writeError(errors.NewInternalError(err), response)
 		return
@@ -185,6 +245,162 @@ func (app *SubresourceAPIApp) VNCRequestHandler(request *restful.Request, respon
 	app.streamRequestHandler(request, response, validate, getConsoleURL)
 }
 
+func (app *SubresourceAPIApp) VSOCKRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if !app.clusterConfig.VSOCKEnabled() {
+			return errors.NewBadRequest(fmt.Sprintf("Unable to connect to VSOCK because %s feature gate is not enabled.", virtconfig.VSOCKGate))
+		}
+		if !util.IsAutoAttachVSOCK(vmi) {
+			return errors.NewBadRequest("VSOCK is not attached.")
+		}
+		return nil
+	}
+	port, err := strconv.ParseUint(request.QueryParameter(VSOCKPortParam), 10, 32)
+	if err != nil || port == 0 {
+		writeError(errors.NewBadRequest(fmt.Sprintf("A valid %s query parameter is required.", VSOCKPortParam)), response)
+		return
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.VSOCKURI(vmi, uint32(port))
+	}
+	app.streamRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) SerialPortRequestHandler(request *restful.Request, response *restful.Response) {
+	device := request.QueryParameter(DeviceNameParam)
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		for _, serial := range vmi.Spec.Domain.Devices.Serials {
+			if serial.Name == device {
+				return nil
+			}
+		}
+		return errors.NewBadRequest(fmt.Sprintf("Serial port %q does not exist.", device))
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SerialPortURI(vmi, device)
+	}
+	app.streamRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) ChannelRequestHandler(request *restful.Request, response *restful.Response) {
+	device := request.QueryParameter(DeviceNameParam)
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		for _, channel := range vmi.Spec.Domain.Devices.Channels {
+			if channel.Name == device {
+				return nil
+			}
+		}
+		return errors.NewBadRequest(fmt.Sprintf("Channel %q does not exist.", device))
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.ChannelURI(vmi, device)
+	}
+	app.streamRequestHandler(request, response, validate, getURL)
+}
+
+// PcapRequestHandler streams a packet capture of a bridge, masquerade or macvtap interface of a VMI
+func (app *SubresourceAPIApp) PcapRequestHandler(request *restful.Request, response *restful.Response) {
+	ifaceName := request.QueryParameter(InterfaceNameParam)
+	options, err := pcap.ParseOptions(request.QueryParameter(PcapFilterParam), request.QueryParameter(PcapCountParam), request.QueryParameter(PcapDurationParam))
+	if err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Invalid packet capture options: %v", err)), response)
+		return
+	}
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+			if iface.Name != ifaceName || iface.State == v1.InterfaceStateAbsent {
+				continue
+			}
+			if iface.Bridge == nil && iface.Masquerade == nil && iface.Macvtap == nil {
+				return errors.NewBadRequest(fmt.Sprintf("Packet capture is only supported on bridge, masquerade and macvtap interfaces, %q is not one of them.", ifaceName))
+			}
+			return nil
+		}
+		return errors.NewBadRequest(fmt.Sprintf("Interface %q does not exist.", ifaceName))
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.PcapURI(vmi, &kubecli.PcapOptions{
+			Interface: ifaceName,
+			Filter:    request.QueryParameter(PcapFilterParam),
+			Count:     uint32(options.Count),
+			Duration:  options.Duration,
+		})
+	}
+	app.streamRequestHandler(request, response, validate, getURL)
+}
+
+// PortForwardRequestHandler connects to a TCP or UDP port of a VMI attached to the pod network through masquerade,
+// slirp or passt
+func (app *SubresourceAPIApp) PortForwardRequestHandler(request *restful.Request, response *restful.Response) {
+	port, err := strconv.ParseUint(request.QueryParameter(PortForwardPortParam), 10, 16)
+	if err != nil || port == 0 {
+		writeError(errors.NewBadRequest(fmt.Sprintf("A valid %s query parameter is required.", PortForwardPortParam)), response)
+		return
+	}
+	protocol := request.QueryParameter(PortForwardProtocolParam)
+	if protocol != "" && protocol != "tcp" && protocol != "udp" {
+		writeError(errors.NewBadRequest(fmt.Sprintf("The %s query parameter must be either tcp or udp.", PortForwardProtocolParam)), response)
+		return
+	}
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		for _, network := range vmi.Spec.Networks {
+			if network.Pod == nil {
+				continue
+			}
+			for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+				if iface.Name != network.Name {
+					continue
+				}
+				if iface.Masquerade == nil && iface.Slirp == nil && iface.Passt == nil {
+					return errors.NewBadRequest(fmt.Sprintf("Port forwarding is only supported on masquerade, slirp and passt interfaces, %q is not one of them.", iface.Name))
+				}
+				return nil
+			}
+		}
+		return errors.NewBadRequest("The VMI is not connected to the pod network.")
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.PortForwardURI(vmi, &kubecli.PortForwardOptions{
+			Port:     uint16(port),
+			Protocol: protocol,
+		})
+	}
+	app.streamRequestHandler(request, response, validate, getURL)
+}
+
+// SerialConsoleLogRequestHandler handles the subresource for retrieving the serial console log of a VMI
+func (app *SubresourceAPIApp) SerialConsoleLogRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if !util.IsSerialConsoleLogEnabled(vmi) {
+			return errors.NewBadRequest("Serial console logging is not enabled.")
+		}
+		if !vmi.IsRunning() {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SerialConsoleLogURI(vmi)
+	}
+
+	_, url, conn, statusErr := app.prepareConnection(request, validate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	consoleLog, err := conn.Get(url, app.handlerTLSConfiguration)
+	if err != nil {
+		log.Log.Reason(err).Error("Cannot retrieve the serial console log")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.AddHeader("Content-Type", "text/plain")
+	response.WriteHeader(http.StatusOK)
+	response.Write([]byte(consoleLog))
+}
+
 func (app *SubresourceAPIApp) getVirtHandlerConnForVMI(vmi *v1.VirtualMachineInstance) (kubecli.VirtHandlerConn, error) {
 	if !vmi.IsRunning() {
 		return nil, goerror.New(fmt.Sprintf("Unable to connect to VirtualMachineInstance because phase is %s instead of %s", vmi.Status.Phase, v1.Running))
@@ -319,11 +535,37 @@ func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request,
 	response.WriteHeader(http.StatusAccepted)
 }
 
+func (app *SubresourceAPIApp) NetworkBootVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	if len(vm.Spec.Template.Spec.Domain.Devices.Interfaces) == 0 {
+		writeError(errors.NewBadRequest("VM has no network interfaces to boot from"), response)
+		return
+	}
+
+	bodyString := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"true"}}}`, v1.NetworkBootNextStartAnnotation)
+	log.Log.Object(vm).V(4).Infof("Patching VM: %s", bodyString)
+	if _, err := app.virtCli.VirtualMachine(namespace).Patch(vm.GetName(), types.MergePatchType, []byte(bodyString)); err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
 func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request, response *restful.Response) {
 	// RunStrategyHalted         -> doesn't make sense
 	// RunStrategyManual         -> send restart request
 	// RunStrategyAlways         -> send restart request
 	// RunStrategyRerunOnFailure -> send restart request
+	// RunStrategyOnce           -> doesn't make sense
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
@@ -367,8 +609,8 @@ func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request,
 		writeError(errors.NewInternalError(err), response)
 		return
 	}
-	if runStrategy == v1.RunStrategyHalted {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual restart requests", v1.RunStrategyHalted)), response)
+	if runStrategy == v1.RunStrategyHalted || runStrategy == v1.RunStrategyOnce {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual restart requests", runStrategy)), response)
 		return
 	}
 
@@ -591,6 +833,7 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 	// RunStrategyManual         -> send start request
 	// RunStrategyAlways         -> doesn't make sense
 	// RunStrategyRerunOnFailure -> doesn't make sense
+	// RunStrategyOnce           -> doesn't make sense
 	switch runStrategy {
 	case v1.RunStrategyHalted:
 		bodyString := getRunningJson(vm, true)
@@ -623,8 +866,8 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 		}
 		log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
 		patchErr = app.statusUpdater.PatchStatus(vm, patchType, []byte(bodyString))
-	case v1.RunStrategyAlways:
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", v1.RunStrategyAlways)), response)
+	case v1.RunStrategyAlways, v1.RunStrategyOnce:
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", runStrategy)), response)
 		return
 	}
 
@@ -645,6 +888,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 	// RunStrategyManual         -> send stop request
 	// RunStrategyAlways         -> spec.running = false
 	// RunStrategyRerunOnFailure -> spec.running = false
+	// RunStrategyOnce           -> send stop request
 
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
@@ -681,9 +925,9 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 	case v1.RunStrategyHalted:
 		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual stop requests", v1.RunStrategyHalted)), response)
 		return
-	case v1.RunStrategyManual:
+	case v1.RunStrategyManual, v1.RunStrategyOnce:
 		// pass the buck and ask virt-controller to stop the VM. this way the
-		// VM will retain RunStrategy = manual
+		// VM will retain RunStrategy = manual or once
 		patchType = types.JSONPatchType
 		bodyString, err := getChangeRequestJson(vm,
 			v1.VirtualMachineStateChangeRequest{Action: v1.StopRequest, UID: &vmi.UID})
@@ -731,7 +975,7 @@ func (app *SubresourceAPIApp) PauseVMIRequestHandler(request *restful.Request, r
 		return conn.PauseURI(vmi)
 	}
 
-	app.putRequestHandler(request, response, validate, getURL)
+	app.putRequestHandler(request, response, validate, getURL, nil)
 }
 
 func (app *SubresourceAPIApp) UnpauseVMIRequestHandler(request *restful.Request, response *restful.Response) {
@@ -749,289 +993,1270 @@ func (app *SubresourceAPIApp) UnpauseVMIRequestHandler(request *restful.Request,
 	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 		return conn.UnpauseURI(vmi)
 	}
-	app.putRequestHandler(request, response, validate, getURL)
+	app.putRequestHandler(request, response, validate, getURL, nil)
 
 }
 
-func (app *SubresourceAPIApp) fetchVirtualMachine(name string, namespace string) (*v1.VirtualMachine, *errors.StatusError) {
-
-	vm, err := app.virtCli.VirtualMachine(namespace).Get(name, &k8smetav1.GetOptions{})
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil, errors.NewNotFound(v1.Resource("virtualmachine"), name)
-		}
-		return nil, errors.NewInternalError(fmt.Errorf("unable to retrieve vm [%s]: %v", name, err))
+func (app *SubresourceAPIApp) FreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	unfreezeTimeout := &v1.FreezeUnfreezeTimeout{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, unfreeze timeout is expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(unfreezeTimeout)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
 	}
-	return vm, nil
-}
 
-func (app *SubresourceAPIApp) fetchVirtualMachineInstance(name string, namespace string) (*v1.VirtualMachineInstance, *errors.StatusError) {
+	if unfreezeTimeout.UnfreezeTimeout == nil {
+		writeError(errors.NewBadRequest("Unfreeze timeout must be specified"), response)
+		return
+	} else if unfreezeTimeout.UnfreezeTimeout.Duration < 0 {
+		writeError(errors.NewBadRequest("Unfreeze timeout must not be negative"), response)
+		return
+	}
 
-	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
+	body, err := json.Marshal(unfreezeTimeout)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), name)
-		}
-		return nil, errors.NewInternalError(fmt.Errorf("unable to retrieve vmi [%s]: %v", name, err))
+		writeError(errors.NewInternalError(err), response)
+		return
 	}
-	return vmi, nil
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.FreezeURI(vmi)
+	}
+
+	app.putRequestHandler(request, response, freezeValidate, getURL, ioutil.NopCloser(bytes.NewReader(body)))
 }
 
-func writeError(error *errors.StatusError, response *restful.Response) {
-	errStatus := error.ErrStatus.DeepCopy()
-	errStatus.Kind = "Status"
-	errStatus.APIVersion = "v1"
-	err := response.WriteHeaderAndJson(int(error.Status().Code), errStatus, restful.MIME_JSON)
-	if err != nil {
-		log.Log.Reason(err).Error("Failed to write http response.")
+func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.UnfreezeURI(vmi)
 	}
+
+	app.putRequestHandler(request, response, freezeValidate, getURL, nil)
 }
 
-// GuestOSInfo handles the subresource for providing VM guest agent information
-func (app *SubresourceAPIApp) GuestOSInfo(request *restful.Request, response *restful.Response) {
-	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
-		if vmi == nil || vmi.Status.Phase != v1.Running {
-			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
-		}
-		condManager := controller.NewVirtualMachineInstanceConditionManager()
-		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
-			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
-		}
-		return nil
+func freezeValidate(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if vmi.Status.Phase != v1.Running {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
 	}
-	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
-		return conn.GuestInfoURI(vmi)
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
 	}
+	return nil
+}
 
-	_, url, conn, err := app.prepareConnection(request, validate, getURL)
-	if err != nil {
-		log.Log.Errorf("Cannot prepare connection %s", err.Error())
-		response.WriteError(http.StatusInternalServerError, err)
+// StartBackupVMIRequestHandler begins a backup of the qcow2 disks of a running VMI, which creates a checkpoint
+// the blocks changed since can be backed up from later
+func (app *SubresourceAPIApp) StartBackupVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	backupOptions := &v1.BackupOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, backup options are expected as the request body"), response)
 		return
 	}
-
-	resp, conErr := conn.Get(url, app.handlerTLSConfiguration)
-	if conErr != nil {
-		log.Log.Errorf("Cannot GET request %s", conErr.Error())
-		response.WriteError(http.StatusInternalServerError, conErr)
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(backupOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
 		return
 	}
 
-	guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{}
-	if err := json.Unmarshal([]byte(resp), &guestInfo); err != nil {
-		log.Log.Reason(err).Error("error unmarshalling guest agent response")
-		response.WriteError(http.StatusInternalServerError, err)
+	if backupOptions.Checkpoint == "" {
+		writeError(errors.NewBadRequest("Checkpoint must be specified"), response)
+		return
+	}
+	if backupOptions.Checkpoint == backupOptions.Incremental {
+		writeError(errors.NewBadRequest("Checkpoint must differ from the incremental checkpoint"), response)
 		return
 	}
 
-	response.WriteEntity(guestInfo)
-}
-
-// UserList handles the subresource for providing VM guest user list
-func (app *SubresourceAPIApp) UserList(request *restful.Request, response *restful.Response) {
 	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
-		if vmi == nil || vmi.Status.Phase != v1.Running {
-			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		if statusErr := app.backupValidate(vmi); statusErr != nil {
+			return statusErr
 		}
-		condManager := controller.NewVirtualMachineInstanceConditionManager()
-		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
-			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
+		backup := vmi.Status.Backup
+		if backup != nil && backup.Checkpoint != "" {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("backup to checkpoint %s is still running", backup.Checkpoint))
+		}
+		if backupOptions.Incremental != "" && (backup == nil || !containsString(backup.Checkpoints, backupOptions.Incremental)) {
+			return errors.NewBadRequest(fmt.Sprintf("Checkpoint %s does not exist.", backupOptions.Incremental))
+		}
+		if backup != nil && containsString(backup.Checkpoints, backupOptions.Checkpoint) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("checkpoint %s already exists", backupOptions.Checkpoint))
+		}
+		if volumes := rawBackupVolumes(vmi); len(volumes) > 0 {
+			return errors.NewBadRequest(fmt.Sprintf("Unable to back up the VMI because volumes %s are raw images, only qcow2 disks can track their changed blocks.", strings.Join(volumes, ", ")))
 		}
 		return nil
 	}
-	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
-		return conn.UserListURI(vmi)
-	}
 
-	_, url, conn, err := app.prepareConnection(request, validate, getURL)
+	body, err := json.Marshal(backupOptions)
 	if err != nil {
-		log.Log.Errorf("Cannot prepare connection %s", err.Error())
-		response.WriteError(http.StatusInternalServerError, err)
-		return
-	}
-
-	resp, conErr := conn.Get(url, app.handlerTLSConfiguration)
-	if conErr != nil {
-		log.Log.Errorf("Cannot GET request %s", conErr.Error())
-		response.WriteError(http.StatusInternalServerError, conErr)
+		writeError(errors.NewInternalError(err), response)
 		return
 	}
 
-	userList := v1.VirtualMachineInstanceGuestOSUserList{}
-	if err := json.Unmarshal([]byte(resp), &userList); err != nil {
-		log.Log.Reason(err).Error("error unmarshalling user list response")
-		response.WriteError(http.StatusInternalServerError, err)
-		return
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.StartBackupURI(vmi)
 	}
 
-	response.WriteEntity(userList)
+	app.putRequestHandler(request, response, validate, getURL, ioutil.NopCloser(bytes.NewReader(body)))
 }
 
-// FilesystemList handles the subresource for providing guest filesystem list
-func (app *SubresourceAPIApp) FilesystemList(request *restful.Request, response *restful.Response) {
+// StopBackupVMIRequestHandler ends the running backup of a VMI
+func (app *SubresourceAPIApp) StopBackupVMIRequestHandler(request *restful.Request, response *restful.Response) {
 	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
-		if vmi == nil || vmi.Status.Phase != v1.Running {
-			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		if statusErr := app.backupValidate(vmi); statusErr != nil {
+			return statusErr
 		}
-		condManager := controller.NewVirtualMachineInstanceConditionManager()
-		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
-			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
+		if vmi.Status.Backup == nil || vmi.Status.Backup.Checkpoint == "" {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("no backup is running"))
 		}
 		return nil
 	}
 	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
-		return conn.FilesystemListURI(vmi)
+		return conn.StopBackupURI(vmi)
 	}
 
-	_, url, conn, err := app.prepareConnection(request, validate, getURL)
-	if err != nil {
-		log.Log.Errorf("Cannot prepare connection %s", err.Error())
-		response.WriteError(http.StatusInternalServerError, err)
-		return
-	}
+	app.putRequestHandler(request, response, validate, getURL, nil)
+}
 
-	resp, conErr := conn.Get(url, app.handlerTLSConfiguration)
-	if conErr != nil {
-		log.Log.Errorf("Cannot GET request %s", conErr.Error())
-		response.WriteError(http.StatusInternalServerError, conErr)
+// BackupRequestHandler streams the changed blocks of a disk of the running backup of a VMI
+func (app *SubresourceAPIApp) BackupRequestHandler(request *restful.Request, response *restful.Response) {
+	disk := request.QueryParameter(BackupDiskParam)
+	if disk == "" {
+		writeError(errors.NewBadRequest(fmt.Sprintf("The %s query parameter is required.", BackupDiskParam)), response)
 		return
 	}
-
-	filesystemList := v1.VirtualMachineInstanceFileSystemList{}
-	if err := json.Unmarshal([]byte(resp), &filesystemList); err != nil {
-		log.Log.Reason(err).Error("error unmarshalling file system list response")
-		response.WriteError(http.StatusInternalServerError, err)
+	extentsOnly := false
+	if param := request.QueryParameter(BackupExtentsOnlyParam); param != "" {
+		var err error
+		if extentsOnly, err = strconv.ParseBool(param); err != nil {
+			writeError(errors.NewBadRequest(fmt.Sprintf("The %s query parameter must be a boolean.", BackupExtentsOnlyParam)), response)
+			return
+		}
+	}
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if statusErr := app.backupValidate(vmi); statusErr != nil {
+			return statusErr
+		}
+		if vmi.Status.Backup == nil || vmi.Status.Backup.Checkpoint == "" {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("no backup is running"))
+		}
+		if !containsString(vmi.Status.Backup.Disks, disk) {
+			return errors.NewBadRequest(fmt.Sprintf("Disk %q is not part of the backup.", disk))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.BackupURI(vmi, &kubecli.BackupStreamOptions{
+			Disk:        disk,
+			ExtentsOnly: extentsOnly,
+		})
+	}
+	app.streamRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) backupValidate(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if !app.clusterConfig.IncrementalBackupEnabled() {
+		return errors.NewBadRequest(fmt.Sprintf("Unable to back up the VMI because %s feature gate is not enabled.", virtconfig.IncrementalBackupGate))
+	}
+	if vmi.Status.Phase != v1.Running {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+	}
+	return nil
+}
+
+// rawBackupVolumes returns the volumes of the writable disks of the VMI whose images are raw. The changed
+// blocks of raw images can't be tracked, the backup would silently leave them out.
+func rawBackupVolumes(vmi *v1.VirtualMachineInstance) []string {
+	writableDisks := map[string]bool{}
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.CDRom == nil && (disk.Disk == nil || !disk.Disk.ReadOnly) && (disk.LUN == nil || !disk.LUN.ReadOnly) {
+			writableDisks[disk.Name] = true
+		}
+	}
+	var volumes []string
+	for _, volume := range vmi.Spec.Volumes {
+		if !writableDisks[volume.Name] {
+			continue
+		}
+		if volume.PersistentVolumeClaim != nil || volume.DataVolume != nil || volume.HostDisk != nil {
+			volumes = append(volumes, volume.Name)
+		}
+	}
+	return volumes
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// MemoryDumpVMIRequestHandler starts dumping the memory of a running VMI to its memory dump PVC
+func (app *SubresourceAPIApp) MemoryDumpVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	memoryDumpOptions := &v1.MemoryDumpOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, memory dump options are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(memoryDumpOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
 		return
 	}
 
-	response.WriteEntity(filesystemList)
+	fileName := memoryDumpOptions.FileName
+	if fileName == "" {
+		writeError(errors.NewBadRequest("File name must be specified"), response)
+		return
+	}
+	if strings.Contains(fileName, "/") || fileName == "." || fileName == ".." {
+		writeError(errors.NewBadRequest(fmt.Sprintf("File name %q must not be a path", fileName)), response)
+		return
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		if vmi.Spec.Domain.Devices.MemoryDump == nil {
+			return errors.NewBadRequest("VMI has no memoryDump device to store the memory dump on")
+		}
+		if dump := vmi.Status.MemoryDump; dump != nil && dump.Phase == v1.MemoryDumpInProgress {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("memory dump to %s is still running", dump.FileName))
+		}
+		return nil
+	}
+
+	body, err := json.Marshal(memoryDumpOptions)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.MemoryDumpURI(vmi)
+	}
+
+	app.putRequestHandler(request, response, validate, getURL, ioutil.NopCloser(bytes.NewReader(body)))
 }
 
-func generateVMVolumeRequestPatch(vm *v1.VirtualMachine, volumeRequest *v1.VirtualMachineVolumeRequest) (string, error) {
-	verb := "add"
-	if len(vm.Status.VolumeRequests) > 0 {
-		verb = "replace"
+// ExternalSnapshotVMIRequestHandler switches the disks of filesystem volumes of a running VMI to qcow2 overlays
+func (app *SubresourceAPIApp) ExternalSnapshotVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	snapshotOptions := &v1.ExternalSnapshotOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, external snapshot options are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(snapshotOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
 	}
 
-	vmCopy := vm.DeepCopy()
+	name := snapshotOptions.Name
+	if name == "" {
+		writeError(errors.NewBadRequest("Snapshot name must be specified"), response)
+		return
+	}
+	if strings.Contains(name, "/") || name == "." || name == ".." {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Snapshot name %q must not be a path", name)), response)
+		return
+	}
+	if len(snapshotOptions.Volumes) == 0 {
+		writeError(errors.NewBadRequest("At least one volume must be specified"), response)
+		return
+	}
 
-	// We only validate the list against other items in the list at this point.
-	// The VM validation webhook will validate the list against the VMI spec
-	// during the Patch command
-	if volumeRequest.AddVolumeOptions != nil {
-		name := volumeRequest.AddVolumeOptions.Name
-		for _, request := range vm.Status.VolumeRequests {
-			if request.AddVolumeOptions != nil && request.AddVolumeOptions.Name == name {
-				return "", fmt.Errorf("Add volume request for volume [%s] already exists", name)
-			} else if request.RemoveVolumeOptions != nil && request.RemoveVolumeOptions.Name == name {
-				return "", fmt.Errorf("Unable to add volume. A remove volume request for volume [%s] already exists and is still being processed.", name)
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if !app.clusterConfig.ExternalSnapshotsEnabled() {
+			return errors.NewBadRequest(fmt.Sprintf("Unable to take an external snapshot because %s feature gate is not enabled.", virtconfig.ExternalSnapshotsGate))
+		}
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		for _, volumeName := range snapshotOptions.Volumes {
+			var volume *v1.Volume
+			for i := range vmi.Spec.Volumes {
+				if vmi.Spec.Volumes[i].Name == volumeName {
+					volume = &vmi.Spec.Volumes[i]
+					break
+				}
+			}
+			if volume == nil {
+				return errors.NewBadRequest(fmt.Sprintf("Volume %q does not exist.", volumeName))
+			}
+			if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil {
+				return errors.NewBadRequest(fmt.Sprintf("Volume %q is not a PVC or DataVolume.", volumeName))
 			}
 		}
-		vmCopy.Status.VolumeRequests = append(vm.Status.VolumeRequests, *volumeRequest)
-	} else if volumeRequest.RemoveVolumeOptions != nil {
-		name := volumeRequest.RemoveVolumeOptions.Name
-		volumeRequestsList := []v1.VirtualMachineVolumeRequest{}
-		for _, request := range vm.Status.VolumeRequests {
-			if request.AddVolumeOptions != nil && request.AddVolumeOptions.Name == name {
-				// Filter matching AddVolume requests from the new list.
+		return nil
+	}
+
+	body, err := json.Marshal(snapshotOptions)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.ExternalSnapshotURI(vmi)
+	}
+
+	app.putRequestHandler(request, response, validate, getURL, ioutil.NopCloser(bytes.NewReader(body)))
+}
+
+// BlockCommitVMIRequestHandler starts merging the qcow2 overlays of volumes of a running VMI into their images
+func (app *SubresourceAPIApp) BlockCommitVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	commitOptions := &v1.BlockCommitOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, block commit options are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(commitOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if len(commitOptions.Volumes) == 0 {
+		writeError(errors.NewBadRequest("At least one volume must be specified"), response)
+		return
+	}
+
+	// the options are sent once they were validated against the VMI
+	body := &bytes.Buffer{}
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if !app.clusterConfig.ExternalSnapshotsEnabled() {
+			return errors.NewBadRequest(fmt.Sprintf("Unable to commit overlays because %s feature gate is not enabled.", virtconfig.ExternalSnapshotsGate))
+		}
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		if vmi.Status.BlockCommit != nil && vmi.Status.BlockCommit.Phase == v1.BlockCommitInProgress {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("block commit of volumes %v is still running", vmi.Status.BlockCommit.Volumes))
+		}
+		var activeFiles []string
+		for _, volumeName := range commitOptions.Volumes {
+			var volumeStatus *v1.VolumeStatus
+			for i := range vmi.Status.VolumeStatus {
+				if vmi.Status.VolumeStatus[i].Name == volumeName {
+					volumeStatus = &vmi.Status.VolumeStatus[i]
+					break
+				}
+			}
+			if volumeStatus == nil || volumeStatus.ExternalSnapshot == nil || len(volumeStatus.ExternalSnapshot.BackingChain) == 0 {
+				return errors.NewBadRequest(fmt.Sprintf("Volume %q has no external snapshot overlays to commit.", volumeName))
+			}
+			if overwrittenFiles(volumeStatus.ExternalSnapshot, commitOptions.Overlay) == nil {
+				return errors.NewBadRequest(fmt.Sprintf("Volume %q has no overlay %q to commit.", volumeName, commitOptions.Overlay))
+			}
+			activeFiles = append(activeFiles, volumeStatus.ExternalSnapshot.ActiveFile)
+		}
+
+		// the VirtualMachineSnapshotContent of a snapshot is only created after its overlays were taken,
+		// the VM stays locked by the snapshot until then
+		vm, err := app.virtCli.VirtualMachine(vmi.Namespace).Get(vmi.Name, &k8smetav1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.NewInternalError(fmt.Errorf("unable to retrieve vm: %v", err))
+		}
+		if err == nil && vm.Status.SnapshotInProgress != nil {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name,
+				fmt.Errorf("snapshot %q of the VM is in progress", *vm.Status.SnapshotInProgress))
+		}
+
+		// the commit overwrites or removes files of the chain, snapshots kept in them must be deleted first
+		contents, err := app.virtCli.VirtualMachineSnapshotContent(vmi.Namespace).List(context.Background(), k8smetav1.ListOptions{})
+		if err != nil {
+			return errors.NewInternalError(fmt.Errorf("unable to list VirtualMachineSnapshotContents: %v", err))
+		}
+		for _, volumeName := range commitOptions.Volumes {
+			if contentName := externalSnapshotContentOfChain(vmi, volumeName, commitOptions.Overlay, contents.Items); contentName != "" {
+				return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name,
+					fmt.Errorf("the overlays of volume %q hold the external snapshot of VirtualMachineSnapshotContent %q, delete its snapshot first", volumeName, contentName))
+			}
+		}
+
+		// a snapshot taken after these checks switches the disks to new overlays, virt-launcher refuses the commit then
+		commitOptions.ActiveFiles = activeFiles
+		if err := json.NewEncoder(body).Encode(commitOptions); err != nil {
+			return errors.NewInternalError(err)
+		}
+		return nil
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.BlockCommitURI(vmi)
+	}
+
+	app.putRequestHandler(request, response, validate, getURL, ioutil.NopCloser(body))
+}
+
+// overwrittenFiles returns the files of the chain whose content is lost by committing the overlay, or all files of
+// the chain by committing all overlays into the image if overlay is empty. It returns nil if the overlay is not in
+// the chain or is its image.
+func overwrittenFiles(chain *v1.ExternalSnapshotVolumeStatus, overlay string) map[string]bool {
+	files := append([]string{chain.ActiveFile}, chain.BackingChain...)
+	if overlay == "" {
+		overwritten := map[string]bool{}
+		for _, file := range files {
+			overwritten[file] = true
+		}
+		return overwritten
+	}
+	// the overlay is merged into the file below it, the files above the overlay are backed by that file then
+	for i := 0; i < len(files)-1; i++ {
+		if files[i] == overlay {
+			return map[string]bool{files[i+1]: true}
+		}
+	}
+	return nil
+}
+
+// externalSnapshotContentOfChain returns the name of a VirtualMachineSnapshotContent holding an external snapshot
+// of the volume in a file overwritten by the commit, or an empty string if there is none. Contents being deleted
+// are skipped, they wait for the commit of their overlays.
+func externalSnapshotContentOfChain(vmi *v1.VirtualMachineInstance, volumeName, overlay string, contents []snapshotv1.VirtualMachineSnapshotContent) string {
+	var claimName string
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name != volumeName {
+			continue
+		}
+		if volume.PersistentVolumeClaim != nil {
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		} else if volume.DataVolume != nil {
+			claimName = volume.DataVolume.Name
+		}
+	}
+
+	var overwritten map[string]bool
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.Name == volumeName && volumeStatus.ExternalSnapshot != nil {
+			overwritten = overwrittenFiles(volumeStatus.ExternalSnapshot, overlay)
+		}
+	}
+
+	for _, content := range contents {
+		if content.DeletionTimestamp != nil {
+			continue
+		}
+		for _, volumeBackup := range content.Spec.VolumeBackups {
+			if volumeBackup.ExternalSnapshot == nil || volumeBackup.PersistentVolumeClaim.Name != claimName {
 				continue
-			} else if request.RemoveVolumeOptions != nil && request.RemoveVolumeOptions.Name == name {
-				return "", fmt.Errorf("A remove volume request for volume [%s] already exists and is still being processed.", name)
 			}
+			if overwritten[volumeBackup.ExternalSnapshot.File] {
+				return content.Name
+			}
+		}
+	}
+	return ""
+}
 
-			volumeRequestsList = append(volumeRequestsList, request)
+func (app *SubresourceAPIApp) fetchVirtualMachine(name string, namespace string) (*v1.VirtualMachine, *errors.StatusError) {
+
+	vm, err := app.virtCli.VirtualMachine(namespace).Get(name, &k8smetav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFound(v1.Resource("virtualmachine"), name)
 		}
-		volumeRequestsList = append(volumeRequestsList, *volumeRequest)
-		vmCopy.Status.VolumeRequests = volumeRequestsList
+		return nil, errors.NewInternalError(fmt.Errorf("unable to retrieve vm [%s]: %v", name, err))
 	}
+	return vm, nil
+}
 
-	oldJson, err := json.Marshal(vm.Status.VolumeRequests)
+func (app *SubresourceAPIApp) fetchVirtualMachineInstance(name string, namespace string) (*v1.VirtualMachineInstance, *errors.StatusError) {
+
+	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
 	if err != nil {
-		return "", err
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), name)
+		}
+		return nil, errors.NewInternalError(fmt.Errorf("unable to retrieve vmi [%s]: %v", name, err))
+	}
+	return vmi, nil
+}
+
+func writeError(error *errors.StatusError, response *restful.Response) {
+	errStatus := error.ErrStatus.DeepCopy()
+	errStatus.Kind = "Status"
+	errStatus.APIVersion = "v1"
+	err := response.WriteHeaderAndJson(int(error.Status().Code), errStatus, restful.MIME_JSON)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
 	}
+}
 
-	newJson, err := json.Marshal(vmCopy.Status.VolumeRequests)
+// GuestOSInfo handles the subresource for providing VM guest agent information
+func (app *SubresourceAPIApp) GuestOSInfo(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.GuestInfoURI(vmi)
+	}
+
+	_, url, conn, err := app.prepareConnection(request, validate, getURL)
 	if err != nil {
-		return "", err
+		log.Log.Errorf("Cannot prepare connection %s", err.Error())
+		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
 
-	test := fmt.Sprintf(`{ "op": "test", "path": "/status/volumeRequests", "value": %s}`, string(oldJson))
-	update := fmt.Sprintf(`{ "op": "%s", "path": "/status/volumeRequests", "value": %s}`, verb, string(newJson))
-	patch := fmt.Sprintf("[%s, %s]", test, update)
+	resp, conErr := conn.Get(url, app.handlerTLSConfiguration)
+	if conErr != nil {
+		log.Log.Errorf("Cannot GET request %s", conErr.Error())
+		response.WriteError(http.StatusInternalServerError, conErr)
+		return
+	}
+
+	guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{}
+	if err := json.Unmarshal([]byte(resp), &guestInfo); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling guest agent response")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(guestInfo)
+}
+
+// UserList handles the subresource for providing VM guest user list
+func (app *SubresourceAPIApp) UserList(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.UserListURI(vmi)
+	}
+
+	_, url, conn, err := app.prepareConnection(request, validate, getURL)
+	if err != nil {
+		log.Log.Errorf("Cannot prepare connection %s", err.Error())
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	resp, conErr := conn.Get(url, app.handlerTLSConfiguration)
+	if conErr != nil {
+		log.Log.Errorf("Cannot GET request %s", conErr.Error())
+		response.WriteError(http.StatusInternalServerError, conErr)
+		return
+	}
+
+	userList := v1.VirtualMachineInstanceGuestOSUserList{}
+	if err := json.Unmarshal([]byte(resp), &userList); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling user list response")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(userList)
+}
+
+// FilesystemList handles the subresource for providing guest filesystem list
+func (app *SubresourceAPIApp) FilesystemList(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.FilesystemListURI(vmi)
+	}
+
+	_, url, conn, err := app.prepareConnection(request, validate, getURL)
+	if err != nil {
+		log.Log.Errorf("Cannot prepare connection %s", err.Error())
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	resp, conErr := conn.Get(url, app.handlerTLSConfiguration)
+	if conErr != nil {
+		log.Log.Errorf("Cannot GET request %s", conErr.Error())
+		response.WriteError(http.StatusInternalServerError, conErr)
+		return
+	}
+
+	filesystemList := v1.VirtualMachineInstanceFileSystemList{}
+	if err := json.Unmarshal([]byte(resp), &filesystemList); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling file system list response")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(filesystemList)
+}
+
+func sevValidate(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if !vmi.IsRunning() {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+	}
+	if !util.IsSEVVMI(vmi) {
+		return errors.NewBadRequest(fmt.Sprintf("VMI %s does not have SEV enabled", vmi.Name))
+	}
+	return nil
+}
+
+func sevAttestationValidate(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if statusErr := sevValidate(vmi); statusErr != nil {
+		return statusErr
+	}
+	if !util.IsSEVAttestationRequested(vmi) {
+		return errors.NewBadRequest(fmt.Sprintf("VMI %s did not request the SEV attestation", vmi.Name))
+	}
+	return nil
+}
+
+// SEVFetchCertChainRequestHandler handles the subresource for fetching the SEV certificate chain of the VMI's node
+func (app *SubresourceAPIApp) SEVFetchCertChainRequestHandler(request *restful.Request, response *restful.Response) {
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SEVFetchCertChainURI(vmi)
+	}
+
+	_, url, conn, statusErr := app.prepareConnection(request, sevValidate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	resp, err := conn.Get(url, app.handlerTLSConfiguration)
+	if err != nil {
+		log.Log.Reason(err).Error("Cannot fetch SEV certificate chain")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	sevPlatformInfo := v1.SEVPlatformInfo{}
+	if err := json.Unmarshal([]byte(resp), &sevPlatformInfo); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling SEV platform info response")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.WriteEntity(sevPlatformInfo)
+}
+
+// SEVQueryLaunchMeasurementRequestHandler handles the subresource for querying the launch measurement of a SEV guest
+func (app *SubresourceAPIApp) SEVQueryLaunchMeasurementRequestHandler(request *restful.Request, response *restful.Response) {
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SEVQueryLaunchMeasurementURI(vmi)
+	}
+
+	_, url, conn, statusErr := app.prepareConnection(request, sevValidate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	resp, err := conn.Get(url, app.handlerTLSConfiguration)
+	if err != nil {
+		log.Log.Reason(err).Error("Cannot query SEV launch measurement")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	sevMeasurementInfo := v1.SEVMeasurementInfo{}
+	if err := json.Unmarshal([]byte(resp), &sevMeasurementInfo); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling SEV measurement info response")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.WriteEntity(sevMeasurementInfo)
+}
+
+// SEVInjectLaunchSecretRequestHandler handles the subresource for injecting a launch secret into a SEV guest
+func (app *SubresourceAPIApp) SEVInjectLaunchSecretRequestHandler(request *restful.Request, response *restful.Response) {
+	opts := &v1.SEVSecretOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, SEV secret options are expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if opts.Header == "" {
+		writeError(errors.NewBadRequest("SEVSecretOptions requires header to be set"), response)
+		return
+	} else if opts.Secret == "" {
+		writeError(errors.NewBadRequest("SEVSecretOptions requires secret to be set"), response)
+		return
+	}
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SEVInjectLaunchSecretURI(vmi)
+	}
+
+	app.putRequestHandler(request, response, sevAttestationValidate, getURL, ioutil.NopCloser(bytes.NewReader(body)))
+}
+
+func generateVMVolumeRequestPatch(vm *v1.VirtualMachine, volumeRequest *v1.VirtualMachineVolumeRequest) (string, error) {
+	verb := "add"
+	if len(vm.Status.VolumeRequests) > 0 {
+		verb = "replace"
+	}
+
+	vmCopy := vm.DeepCopy()
+
+	// We only validate the list against other items in the list at this point.
+	// The VM validation webhook will validate the list against the VMI spec
+	// during the Patch command
+	if volumeRequest.AddVolumeOptions != nil {
+		name := volumeRequest.AddVolumeOptions.Name
+		for _, request := range vm.Status.VolumeRequests {
+			if request.AddVolumeOptions != nil && request.AddVolumeOptions.Name == name {
+				return "", fmt.Errorf("Add volume request for volume [%s] already exists", name)
+			} else if request.RemoveVolumeOptions != nil && request.RemoveVolumeOptions.Name == name {
+				return "", fmt.Errorf("Unable to add volume. A remove volume request for volume [%s] already exists and is still being processed.", name)
+			}
+		}
+		vmCopy.Status.VolumeRequests = append(vm.Status.VolumeRequests, *volumeRequest)
+	} else if volumeRequest.RemoveVolumeOptions != nil {
+		name := volumeRequest.RemoveVolumeOptions.Name
+		volumeRequestsList := []v1.VirtualMachineVolumeRequest{}
+		for _, request := range vm.Status.VolumeRequests {
+			if request.AddVolumeOptions != nil && request.AddVolumeOptions.Name == name {
+				// Filter matching AddVolume requests from the new list.
+				continue
+			} else if request.RemoveVolumeOptions != nil && request.RemoveVolumeOptions.Name == name {
+				return "", fmt.Errorf("A remove volume request for volume [%s] already exists and is still being processed.", name)
+			}
+
+			volumeRequestsList = append(volumeRequestsList, request)
+		}
+		volumeRequestsList = append(volumeRequestsList, *volumeRequest)
+		vmCopy.Status.VolumeRequests = volumeRequestsList
+	}
+
+	oldJson, err := json.Marshal(vm.Status.VolumeRequests)
+	if err != nil {
+		return "", err
+	}
+
+	newJson, err := json.Marshal(vmCopy.Status.VolumeRequests)
+	if err != nil {
+		return "", err
+	}
+
+	test := fmt.Sprintf(`{ "op": "test", "path": "/status/volumeRequests", "value": %s}`, string(oldJson))
+	update := fmt.Sprintf(`{ "op": "%s", "path": "/status/volumeRequests", "value": %s}`, verb, string(newJson))
+	patch := fmt.Sprintf("[%s, %s]", test, update)
+
+	return patch, nil
+}
+
+func generateVMIVolumeRequestPatch(vmi *v1.VirtualMachineInstance, volumeRequest *v1.VirtualMachineVolumeRequest) (string, error) {
+
+	volumeVerb := "add"
+	diskVerb := "add"
+
+	if len(vmi.Spec.Volumes) > 0 {
+		volumeVerb = "replace"
+	}
+
+	if len(vmi.Spec.Domain.Devices.Disks) > 0 {
+		diskVerb = "replace"
+	}
+
+	foundRemoveVol := false
+	for _, volume := range vmi.Spec.Volumes {
+		if volumeRequest.AddVolumeOptions != nil && volume.Name == volumeRequest.AddVolumeOptions.Name {
+			return "", fmt.Errorf("Unable to add volume [%s] because it already exists", volume.Name)
+		} else if volumeRequest.RemoveVolumeOptions != nil && volume.Name == volumeRequest.RemoveVolumeOptions.Name {
+			foundRemoveVol = true
+		}
+	}
+
+	if volumeRequest.RemoveVolumeOptions != nil && !foundRemoveVol {
+		return "", fmt.Errorf("Unable to remove volume [%s] because it does not exist", volumeRequest.RemoveVolumeOptions.Name)
+	}
+
+	vmiCopy := vmi.DeepCopy()
+	vmiCopy.Spec = *controller.ApplyVolumeRequestOnVMISpec(&vmiCopy.Spec, volumeRequest)
+
+	oldVolumesJson, err := json.Marshal(vmi.Spec.Volumes)
+	if err != nil {
+		return "", err
+	}
+
+	newVolumesJson, err := json.Marshal(vmiCopy.Spec.Volumes)
+	if err != nil {
+		return "", err
+	}
+
+	oldDisksJson, err := json.Marshal(vmi.Spec.Domain.Devices.Disks)
+	if err != nil {
+		return "", err
+	}
+
+	newDisksJson, err := json.Marshal(vmiCopy.Spec.Domain.Devices.Disks)
+	if err != nil {
+		return "", err
+	}
+
+	testVolumes := fmt.Sprintf(`{ "op": "test", "path": "/spec/volumes", "value": %s}`, string(oldVolumesJson))
+	updateVolumes := fmt.Sprintf(`{ "op": "%s", "path": "/spec/volumes", "value": %s}`, volumeVerb, string(newVolumesJson))
+
+	testDisks := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/devices/disks", "value": %s}`, string(oldDisksJson))
+	updateDisks := fmt.Sprintf(`{ "op": "%s", "path": "/spec/domain/devices/disks", "value": %s}`, diskVerb, string(newDisksJson))
+
+	patch := fmt.Sprintf("[%s, %s, %s, %s]", testVolumes, testDisks, updateVolumes, updateDisks)
+
+	return patch, nil
+}
+
+// findCDRom returns the CD-ROM disk of the given name and the volume holding its media, which is nil if the CD-ROM is empty.
+func findCDRom(vmiSpec *v1.VirtualMachineInstanceSpec, name string) (*v1.Disk, *v1.Volume, error) {
+	var cdrom *v1.Disk
+	for i, disk := range vmiSpec.Domain.Devices.Disks {
+		if disk.Name == name {
+			cdrom = &vmiSpec.Domain.Devices.Disks[i]
+			break
+		}
+	}
+	if cdrom == nil {
+		return nil, nil, fmt.Errorf("CD-ROM [%s] does not exist", name)
+	}
+	if cdrom.CDRom == nil {
+		return nil, nil, fmt.Errorf("disk [%s] is not a CD-ROM", name)
+	}
+	for i, volume := range vmiSpec.Volumes {
+		if volume.Name == name {
+			return cdrom, &vmiSpec.Volumes[i], nil
+		}
+	}
+	return cdrom, nil, nil
+}
+
+// generateVolumesPatch generates a JSON patch which replaces the volumes of the VMI with the given ones.
+func generateVolumesPatch(oldVolumes, newVolumes []v1.Volume) (string, error) {
+	verb := "add"
+	if len(oldVolumes) > 0 {
+		verb = "replace"
+	}
+
+	oldVolumesJson, err := json.Marshal(oldVolumes)
+	if err != nil {
+		return "", err
+	}
+
+	newVolumesJson, err := json.Marshal(newVolumes)
+	if err != nil {
+		return "", err
+	}
+
+	test := fmt.Sprintf(`{ "op": "test", "path": "/spec/volumes", "value": %s}`, string(oldVolumesJson))
+	update := fmt.Sprintf(`{ "op": "%s", "path": "/spec/volumes", "value": %s}`, verb, string(newVolumesJson))
+
+	return fmt.Sprintf("[%s, %s]", test, update), nil
+}
+
+func generateVMIInsertMediaPatch(vmi *v1.VirtualMachineInstance, insertRequest *v1.InsertMediaOptions) (string, error) {
+	_, media, err := findCDRom(&vmi.Spec, insertRequest.Name)
+	if err != nil {
+		return "", fmt.Errorf("Unable to insert media: %v", err)
+	}
+	if media != nil {
+		return "", fmt.Errorf("Unable to insert media into CD-ROM [%s] because it is not empty, eject its media first", insertRequest.Name)
+	}
+
+	newVolume := v1.Volume{
+		Name: insertRequest.Name,
+	}
+	if insertRequest.VolumeSource.PersistentVolumeClaim != nil {
+		newVolume.VolumeSource.PersistentVolumeClaim = insertRequest.VolumeSource.PersistentVolumeClaim
+	} else if insertRequest.VolumeSource.DataVolume != nil {
+		newVolume.VolumeSource.DataVolume = insertRequest.VolumeSource.DataVolume
+	}
+	newVolumes := append(append([]v1.Volume{}, vmi.Spec.Volumes...), newVolume)
+
+	return generateVolumesPatch(vmi.Spec.Volumes, newVolumes)
+}
+
+func generateVMIEjectMediaPatch(vmi *v1.VirtualMachineInstance, ejectRequest *v1.EjectMediaOptions) (string, error) {
+	_, media, err := findCDRom(&vmi.Spec, ejectRequest.Name)
+	if err != nil {
+		return "", fmt.Errorf("Unable to eject media: %v", err)
+	}
+	if media == nil {
+		return "", fmt.Errorf("Unable to eject media from CD-ROM [%s] because it is empty", ejectRequest.Name)
+	}
+
+	newVolumes := []v1.Volume{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name != ejectRequest.Name {
+			newVolumes = append(newVolumes, volume)
+		}
+	}
+
+	return generateVolumesPatch(vmi.Spec.Volumes, newVolumes)
+}
+
+func (app *SubresourceAPIApp) addVolumeRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to Add Volume because HotplugVolumes feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.AddVolumeOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, a new name is expected as the request body"), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("AddVolumeOptions requires name to be set"), response)
+		return
+	} else if opts.Disk == nil {
+		writeError(errors.NewBadRequest("AddVolumeOptions requires disk to not be nil"), response)
+		return
+	} else if opts.VolumeSource == nil {
+		writeError(errors.NewBadRequest("AddVolumeOptions requires VolumeSource to not be nil"), response)
+		return
+	}
+
+	opts.Disk.Name = opts.Name
+	volumeRequest := v1.VirtualMachineVolumeRequest{
+		AddVolumeOptions: opts,
+	}
+
+	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
+	if ephemeral {
+		vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+		if statErr != nil {
+			writeError(statErr, response)
+			return
+		}
+
+		if !vmi.IsRunning() {
+			writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+			return
+		}
+
+		patch, err := generateVMIVolumeRequestPatch(vmi, &volumeRequest)
+		if err != nil {
+			writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err), response)
+			return
+		}
+
+		log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+		_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+		if err != nil {
+			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi during volume add: %v", err)), response)
+			return
+		}
+
+	} else {
+		vm, statErr := app.fetchVirtualMachine(name, namespace)
+		if statErr != nil {
+			writeError(statErr, response)
+			return
+		}
+
+		patch, err := generateVMVolumeRequestPatch(vm, &volumeRequest)
+		if err != nil {
+			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
+			return
+		}
+
+		err = app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(patch))
+		if err != nil {
+			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vm status during volume add: %v", err)), response)
+			return
+		}
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (app *SubresourceAPIApp) removeVolumeRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to Remove Volume because HotplugVolumes feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.RemoveVolumeOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s",
+				err)), response)
+			return
+		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, a new name is expected as the request body"),
+			response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("RemoveVolumeOptions requires name to be set"), response)
+		return
+	}
+	volumeRequest := v1.VirtualMachineVolumeRequest{
+		RemoveVolumeOptions: opts,
+	}
+
+	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
+	if ephemeral {
+		vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+		if statErr != nil {
+			writeError(statErr, response)
+			return
+		}
+
+		if !vmi.IsRunning() {
+			writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+			return
+		}
+
+		patch, err := generateVMIVolumeRequestPatch(vmi, &volumeRequest)
+		if err != nil {
+			writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err), response)
+			return
+		}
+
+		log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+		_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+		if err != nil {
+			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi during volume remove: %v", err)), response)
+			return
+		}
+	} else {
+		vm, statErr := app.fetchVirtualMachine(name, namespace)
+		if statErr != nil {
+			writeError(statErr, response)
+			return
+		}
+
+		patch, err := generateVMVolumeRequestPatch(vm, &volumeRequest)
+		if err != nil {
+			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
+			return
+		}
+
+		err = app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(patch))
+		if err != nil {
+			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vm status during volume remove: %v", err)), response)
+			return
+		}
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func generateVMIInterfaceRequestPatch(vmi *v1.VirtualMachineInstance, interfaceRequest *v1.AddInterfaceOptions) (string, error) {
+	for _, network := range vmi.Spec.Networks {
+		if network.Name == interfaceRequest.Name {
+			return "", fmt.Errorf("Unable to add interface [%s] because it already exists", network.Name)
+		}
+	}
+
+	vmiCopy := vmi.DeepCopy()
+	vmiCopy.Spec = *controller.ApplyInterfaceRequestOnVMISpec(&vmiCopy.Spec, interfaceRequest)
+
+	return generateInterfacesPatch("/spec", &vmi.Spec, &vmiCopy.Spec)
+}
+
+func generateVMIRemoveInterfacePatch(vmi *v1.VirtualMachineInstance, removeRequest *v1.RemoveInterfaceOptions) (string, error) {
+	iface := findInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, removeRequest.Name)
+	if iface == nil {
+		return "", fmt.Errorf("Unable to remove interface [%s] because it does not exist", removeRequest.Name)
+	}
+	if iface.State == v1.InterfaceStateAbsent {
+		return "", fmt.Errorf("Unable to remove interface [%s] because it is already being removed", removeRequest.Name)
+	}
 
-	return patch, nil
+	vmiCopy := vmi.DeepCopy()
+	vmiCopy.Spec = *controller.ApplyRemoveInterfaceRequestOnVMISpec(&vmiCopy.Spec, removeRequest)
+
+	return generateInterfacesPatch("/spec", &vmi.Spec, &vmiCopy.Spec)
 }
 
-func generateVMIVolumeRequestPatch(vmi *v1.VirtualMachineInstance, volumeRequest *v1.VirtualMachineVolumeRequest) (string, error) {
+func generateVMISetLinkStatePatch(vmi *v1.VirtualMachineInstance, linkStateRequest *v1.SetLinkStateOptions) (string, error) {
+	iface := findInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, linkStateRequest.Name)
+	if iface == nil {
+		return "", fmt.Errorf("Unable to set the link state of interface [%s] because it does not exist", linkStateRequest.Name)
+	}
+	if iface.State == v1.InterfaceStateAbsent {
+		return "", fmt.Errorf("Unable to set the link state of interface [%s] because it is being removed", linkStateRequest.Name)
+	}
+	if iface.SRIOV != nil {
+		return "", fmt.Errorf("Unable to set the link state of interface [%s] because it is an SR-IOV interface", linkStateRequest.Name)
+	}
 
-	volumeVerb := "add"
-	diskVerb := "add"
+	newSpec := vmi.Spec.DeepCopy()
+	findInterfaceByName(newSpec.Domain.Devices.Interfaces, linkStateRequest.Name).State = linkStateRequest.State
 
-	if len(vmi.Spec.Volumes) > 0 {
-		volumeVerb = "replace"
+	return generateInterfacesPatch("/spec", &vmi.Spec, newSpec)
+}
+
+func generateVMRemoveInterfacePatch(vm *v1.VirtualMachine, removeRequest *v1.RemoveInterfaceOptions) (string, error) {
+	if vm.Spec.Template == nil || findInterfaceByName(vm.Spec.Template.Spec.Domain.Devices.Interfaces, removeRequest.Name) == nil {
+		return "", fmt.Errorf("Unable to remove interface [%s] because it does not exist", removeRequest.Name)
 	}
 
-	if len(vmi.Spec.Domain.Devices.Disks) > 0 {
-		diskVerb = "replace"
+	newSpec := vm.Spec.Template.Spec.DeepCopy()
+	newSpec.Networks = []v1.Network{}
+	for _, network := range vm.Spec.Template.Spec.Networks {
+		if network.Name != removeRequest.Name {
+			newSpec.Networks = append(newSpec.Networks, network)
+		}
+	}
+	newSpec.Domain.Devices.Interfaces = []v1.Interface{}
+	for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+		if iface.Name != removeRequest.Name {
+			newSpec.Domain.Devices.Interfaces = append(newSpec.Domain.Devices.Interfaces, iface)
+		}
 	}
 
-	foundRemoveVol := false
-	for _, volume := range vmi.Spec.Volumes {
-		if volumeRequest.AddVolumeOptions != nil && volume.Name == volumeRequest.AddVolumeOptions.Name {
-			return "", fmt.Errorf("Unable to add volume [%s] because it already exists", volume.Name)
-		} else if volumeRequest.RemoveVolumeOptions != nil && volume.Name == volumeRequest.RemoveVolumeOptions.Name {
-			foundRemoveVol = true
+	return generateInterfacesPatch("/spec/template/spec", &vm.Spec.Template.Spec, newSpec)
+}
+
+func findInterfaceByName(interfaces []v1.Interface, name string) *v1.Interface {
+	for i := range interfaces {
+		if interfaces[i].Name == name {
+			return &interfaces[i]
 		}
 	}
+	return nil
+}
 
-	if volumeRequest.RemoveVolumeOptions != nil && !foundRemoveVol {
-		return "", fmt.Errorf("Unable to remove volume [%s] because it does not exist", volumeRequest.RemoveVolumeOptions.Name)
+// generateInterfacesPatch generates a JSON patch which replaces the networks and interfaces of oldSpec,
+// found at specPath, with the ones of newSpec.
+func generateInterfacesPatch(specPath string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) (string, error) {
+	networkVerb := "add"
+	interfaceVerb := "add"
+
+	if len(oldSpec.Networks) > 0 {
+		networkVerb = "replace"
 	}
 
-	vmiCopy := vmi.DeepCopy()
-	vmiCopy.Spec = *controller.ApplyVolumeRequestOnVMISpec(&vmiCopy.Spec, volumeRequest)
+	if len(oldSpec.Domain.Devices.Interfaces) > 0 {
+		interfaceVerb = "replace"
+	}
 
-	oldVolumesJson, err := json.Marshal(vmi.Spec.Volumes)
+	oldNetworksJson, err := json.Marshal(oldSpec.Networks)
 	if err != nil {
 		return "", err
 	}
 
-	newVolumesJson, err := json.Marshal(vmiCopy.Spec.Volumes)
+	newNetworksJson, err := json.Marshal(newSpec.Networks)
 	if err != nil {
 		return "", err
 	}
 
-	oldDisksJson, err := json.Marshal(vmi.Spec.Domain.Devices.Disks)
+	oldInterfacesJson, err := json.Marshal(oldSpec.Domain.Devices.Interfaces)
 	if err != nil {
 		return "", err
 	}
 
-	newDisksJson, err := json.Marshal(vmiCopy.Spec.Domain.Devices.Disks)
+	newInterfacesJson, err := json.Marshal(newSpec.Domain.Devices.Interfaces)
 	if err != nil {
 		return "", err
 	}
 
-	testVolumes := fmt.Sprintf(`{ "op": "test", "path": "/spec/volumes", "value": %s}`, string(oldVolumesJson))
-	updateVolumes := fmt.Sprintf(`{ "op": "%s", "path": "/spec/volumes", "value": %s}`, volumeVerb, string(newVolumesJson))
+	testNetworks := fmt.Sprintf(`{ "op": "test", "path": "%s/networks", "value": %s}`, specPath, string(oldNetworksJson))
+	updateNetworks := fmt.Sprintf(`{ "op": "%s", "path": "%s/networks", "value": %s}`, networkVerb, specPath, string(newNetworksJson))
 
-	testDisks := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/devices/disks", "value": %s}`, string(oldDisksJson))
-	updateDisks := fmt.Sprintf(`{ "op": "%s", "path": "/spec/domain/devices/disks", "value": %s}`, diskVerb, string(newDisksJson))
+	testInterfaces := fmt.Sprintf(`{ "op": "test", "path": "%s/domain/devices/interfaces", "value": %s}`, specPath, string(oldInterfacesJson))
+	updateInterfaces := fmt.Sprintf(`{ "op": "%s", "path": "%s/domain/devices/interfaces", "value": %s}`, interfaceVerb, specPath, string(newInterfacesJson))
 
-	patch := fmt.Sprintf("[%s, %s, %s, %s]", testVolumes, testDisks, updateVolumes, updateDisks)
+	patch := fmt.Sprintf("[%s, %s, %s, %s]", testNetworks, testInterfaces, updateNetworks, updateInterfaces)
 
 	return patch, nil
 }
 
-func (app *SubresourceAPIApp) addVolumeRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+// VMIAddInterfaceRequestHandler handles the subresource for hot plugging a network interface.
+func (app *SubresourceAPIApp) VMIAddInterfaceRequestHandler(request *restful.Request, response *restful.Response) {
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
-	if !app.clusterConfig.HotplugVolumesEnabled() {
-		writeError(errors.NewBadRequest("Unable to Add Volume because HotplugVolumes feature gate is not enabled."), response)
+	if !app.clusterConfig.HotplugNetworkInterfacesEnabled() {
+		writeError(errors.NewBadRequest("Unable to Add Interface because HotplugNICs feature gate is not enabled."), response)
 		return
 	}
 
-	opts := &v1.AddVolumeOptions{}
+	opts := &v1.AddInterfaceOptions{}
 	if request.Request.Body != nil {
 		defer request.Request.Body.Close()
 		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
@@ -1048,80 +2273,204 @@ func (app *SubresourceAPIApp) addVolumeRequestHandler(request *restful.Request,
 	}
 
 	if opts.Name == "" {
-		writeError(errors.NewBadRequest("AddVolumeOptions requires name to be set"), response)
+		writeError(errors.NewBadRequest("AddInterfaceOptions requires name to be set"), response)
 		return
-	} else if opts.Disk == nil {
-		writeError(errors.NewBadRequest("AddVolumeOptions requires disk to not be nil"), response)
+	} else if opts.NetworkAttachmentDefinitionName == "" {
+		writeError(errors.NewBadRequest("AddInterfaceOptions requires networkAttachmentDefinitionName to be set"), response)
 		return
-	} else if opts.VolumeSource == nil {
-		writeError(errors.NewBadRequest("AddVolumeOptions requires VolumeSource to not be nil"), response)
+	}
+
+	vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+	if statErr != nil {
+		writeError(statErr, response)
 		return
 	}
 
-	opts.Disk.Name = opts.Name
-	volumeRequest := v1.VirtualMachineVolumeRequest{
-		AddVolumeOptions: opts,
+	if !vmi.IsRunning() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+		return
 	}
 
-	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
-	if ephemeral {
-		vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
-		if statErr != nil {
-			writeError(statErr, response)
-			return
-		}
+	patch, err := generateVMIInterfaceRequestPatch(vmi, opts)
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err), response)
+		return
+	}
 
-		if !vmi.IsRunning() {
-			writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
-			return
-		}
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi during interface add: %v", err)), response)
+		return
+	}
 
-		patch, err := generateVMIVolumeRequestPatch(vmi, &volumeRequest)
-		if err != nil {
-			writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err), response)
-			return
-		}
+	response.WriteHeader(http.StatusAccepted)
+}
 
-		log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
-		_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
-		if err != nil {
-			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi during volume add: %v", err)), response)
+func (app *SubresourceAPIApp) removeInterfaceRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugNetworkInterfacesEnabled() {
+		writeError(errors.NewBadRequest("Unable to Remove Interface because HotplugNICs feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.RemoveInterfaceOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
 			return
 		}
-
 	} else {
+		writeError(errors.NewBadRequest("Request with no body, a new name is expected as the request body"), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("RemoveInterfaceOptions requires name to be set"), response)
+		return
+	}
+
+	// remove the interface from the VM template to make the removal permanent, and unplug it from the running VMI.
+	if !ephemeral {
 		vm, statErr := app.fetchVirtualMachine(name, namespace)
 		if statErr != nil {
 			writeError(statErr, response)
 			return
 		}
 
-		patch, err := generateVMVolumeRequestPatch(vm, &volumeRequest)
+		patch, err := generateVMRemoveInterfacePatch(vm, opts)
 		if err != nil {
 			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
 			return
 		}
 
-		err = app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(patch))
+		log.Log.Object(vm).V(4).Infof("Patching VM: %s", patch)
+		_, err = app.virtCli.VirtualMachine(vm.Namespace).Patch(vm.Name, types.JSONPatchType, []byte(patch))
 		if err != nil {
-			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vm status during volume add: %v", err)), response)
+			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vm during interface remove: %v", err)), response)
 			return
 		}
 	}
 
+	vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+	if statErr != nil {
+		if !ephemeral && errors.IsNotFound(statErr) {
+			response.WriteHeader(http.StatusAccepted)
+			return
+		}
+		writeError(statErr, response)
+		return
+	}
+
+	if !vmi.IsRunning() {
+		if !ephemeral {
+			response.WriteHeader(http.StatusAccepted)
+			return
+		}
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+		return
+	}
+
+	patch, err := generateVMIRemoveInterfacePatch(vmi, opts)
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err), response)
+		return
+	}
+
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi during interface remove: %v", err)), response)
+		return
+	}
+
 	response.WriteHeader(http.StatusAccepted)
 }
 
-func (app *SubresourceAPIApp) removeVolumeRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
+// VMIRemoveInterfaceRequestHandler handles the subresource for hot unplugging a network interface.
+func (app *SubresourceAPIApp) VMIRemoveInterfaceRequestHandler(request *restful.Request, response *restful.Response) {
+	app.removeInterfaceRequestHandler(request, response, true)
+}
+
+// VMRemoveInterfaceRequestHandler handles the subresource for removing a network interface from the VM,
+// and hot unplugging it from the running VMI.
+func (app *SubresourceAPIApp) VMRemoveInterfaceRequestHandler(request *restful.Request, response *restful.Response) {
+	app.removeInterfaceRequestHandler(request, response, false)
+}
+
+// VMISetLinkStateRequestHandler handles the subresource for setting the link state of a network interface,
+// which takes the link down or up at the hypervisor level without unplugging the interface.
+func (app *SubresourceAPIApp) VMISetLinkStateRequestHandler(request *restful.Request, response *restful.Response) {
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
+	opts := &v1.SetLinkStateOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, the interface name and link state are expected as the request body"), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("SetLinkStateOptions requires name to be set"), response)
+		return
+	} else if opts.State != v1.InterfaceStateLinkUp && opts.State != v1.InterfaceStateLinkDown {
+		writeError(errors.NewBadRequest(fmt.Sprintf("SetLinkStateOptions requires state to be %s or %s", v1.InterfaceStateLinkUp, v1.InterfaceStateLinkDown)), response)
+		return
+	}
+
+	vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+	if statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+
+	if !vmi.IsRunning() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+		return
+	}
+
+	patch, err := generateVMISetLinkStatePatch(vmi, opts)
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err), response)
+		return
+	}
+
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi during link state change: %v", err)), response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// VMIInsertMediaRequestHandler handles the subresource for inserting media into an empty CD-ROM of a running VMI.
+// The media is hotplugged like a volume, under the name of the CD-ROM.
+func (app *SubresourceAPIApp) VMIInsertMediaRequestHandler(request *restful.Request, response *restful.Response) {
 	if !app.clusterConfig.HotplugVolumesEnabled() {
-		writeError(errors.NewBadRequest("Unable to Remove Volume because HotplugVolumes feature gate is not enabled."), response)
+		writeError(errors.NewBadRequest("Unable to insert media because HotplugVolumes feature gate is not enabled."), response)
 		return
 	}
 
-	opts := &v1.RemoveVolumeOptions{}
+	opts := &v1.InsertMediaOptions{}
 	if request.Request.Body != nil {
 		defer request.Request.Body.Close()
 		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
@@ -1129,67 +2478,87 @@ func (app *SubresourceAPIApp) removeVolumeRequestHandler(request *restful.Reques
 		case io.EOF, nil:
 			break
 		default:
-			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s",
-				err)), response)
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
 			return
 		}
 	} else {
-		writeError(errors.NewBadRequest("Request with no body, a new name is expected as the request body"),
-			response)
+		writeError(errors.NewBadRequest("Request with no body, the CD-ROM name and the media are expected as the request body"), response)
 		return
 	}
 
 	if opts.Name == "" {
-		writeError(errors.NewBadRequest("RemoveVolumeOptions requires name to be set"), response)
+		writeError(errors.NewBadRequest("InsertMediaOptions requires name to be set"), response)
+		return
+	} else if opts.VolumeSource == nil || (opts.VolumeSource.PersistentVolumeClaim == nil && opts.VolumeSource.DataVolume == nil) {
+		writeError(errors.NewBadRequest("InsertMediaOptions requires a PersistentVolumeClaim or DataVolume as VolumeSource"), response)
 		return
 	}
-	volumeRequest := v1.VirtualMachineVolumeRequest{
-		RemoveVolumeOptions: opts,
+
+	app.patchVMIMedia(request, response, func(vmi *v1.VirtualMachineInstance) (string, error) {
+		return generateVMIInsertMediaPatch(vmi, opts)
+	})
+}
+
+// VMIEjectMediaRequestHandler handles the subresource for ejecting the media from a CD-ROM of a running VMI.
+// The drive stays attached to the guest, empty.
+func (app *SubresourceAPIApp) VMIEjectMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to eject media because HotplugVolumes feature gate is not enabled."), response)
+		return
 	}
 
-	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
-	if ephemeral {
-		vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
-		if statErr != nil {
-			writeError(statErr, response)
+	opts := &v1.EjectMediaOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
 			return
 		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, the CD-ROM name is expected as the request body"), response)
+		return
+	}
 
-		if !vmi.IsRunning() {
-			writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
-			return
-		}
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("EjectMediaOptions requires name to be set"), response)
+		return
+	}
 
-		patch, err := generateVMIVolumeRequestPatch(vmi, &volumeRequest)
-		if err != nil {
-			writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err), response)
-			return
-		}
+	app.patchVMIMedia(request, response, func(vmi *v1.VirtualMachineInstance) (string, error) {
+		return generateVMIEjectMediaPatch(vmi, opts)
+	})
+}
 
-		log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
-		_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
-		if err != nil {
-			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi during volume remove: %v", err)), response)
-			return
-		}
-	} else {
-		vm, statErr := app.fetchVirtualMachine(name, namespace)
-		if statErr != nil {
-			writeError(statErr, response)
-			return
-		}
+func (app *SubresourceAPIApp) patchVMIMedia(request *restful.Request, response *restful.Response, generatePatch func(vmi *v1.VirtualMachineInstance) (string, error)) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
 
-		patch, err := generateVMVolumeRequestPatch(vm, &volumeRequest)
-		if err != nil {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
-			return
-		}
+	vmi, statErr := app.fetchVirtualMachineInstance(name, namespace)
+	if statErr != nil {
+		writeError(statErr, response)
+		return
+	}
 
-		err = app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(patch))
-		if err != nil {
-			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vm status during volume remove: %v", err)), response)
-			return
-		}
+	if !vmi.IsRunning() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+		return
+	}
+
+	patch, err := generatePatch(vmi)
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err), response)
+		return
+	}
+
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi during media change: %v", err)), response)
+		return
 	}
 
 	response.WriteHeader(http.StatusAccepted)
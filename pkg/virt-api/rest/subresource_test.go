@@ -29,9 +29,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	. "github.com/onsi/ginkgo"
@@ -47,6 +49,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/uuid"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/testutils"
@@ -357,6 +360,162 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			close(done)
 		}, 5)
 
+		Context("VSOCK", func() {
+			expectVSOCKVMI := func(autoattach bool) {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Status.Phase = v1.Running
+				vmi.ObjectMeta.SetUID(uuid.NewUUID())
+				vmi.Spec.Domain.Devices.AutoattachVSOCK = &autoattach
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+			}
+
+			BeforeEach(func() {
+				request.PathParameters()["name"] = "testvmi"
+				request.PathParameters()["namespace"] = "default"
+				request.Request.URL = &url.URL{RawQuery: "port=1234"}
+			})
+
+			AfterEach(func() {
+				disableFeatureGates()
+			})
+
+			It("should fail without a port", func(done Done) {
+				enableFeatureGate(virtconfig.VSOCKGate)
+				request.Request.URL = &url.URL{}
+
+				app.VSOCKRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				close(done)
+			}, 5)
+
+			It("should fail if the feature gate is disabled", func(done Done) {
+				expectVSOCKVMI(true)
+
+				app.VSOCKRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				close(done)
+			}, 5)
+
+			It("should fail if VSOCK is not attached", func(done Done) {
+				enableFeatureGate(virtconfig.VSOCKGate)
+				expectVSOCKVMI(false)
+
+				app.VSOCKRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				close(done)
+			}, 5)
+		})
+
+		Context("serial ports and channels", func() {
+			BeforeEach(func() {
+				request.PathParameters()["name"] = "testvmi"
+				request.PathParameters()["namespace"] = "default"
+				request.Request.URL = &url.URL{RawQuery: "device=management"}
+
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Status.Phase = v1.Running
+				vmi.ObjectMeta.SetUID(uuid.NewUUID())
+				vmi.Spec.Domain.Devices.Serials = []v1.SerialPort{{Name: "console"}}
+				vmi.Spec.Domain.Devices.Channels = []v1.Channel{{Name: "agent", TargetName: "org.example.agent.0"}}
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+			})
+
+			It("should fail if the serial port does not exist", func(done Done) {
+				app.SerialPortRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				close(done)
+			}, 5)
+
+			It("should fail if the channel does not exist", func(done Done) {
+				app.ChannelRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				close(done)
+			}, 5)
+		})
+
+		Context("packet capture", func() {
+			BeforeEach(func() {
+				request.PathParameters()["name"] = "testvmi"
+				request.PathParameters()["namespace"] = "default"
+
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Status.Phase = v1.Running
+				vmi.ObjectMeta.SetUID(uuid.NewUUID())
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					*v1.DefaultBridgeNetworkInterface(),
+					{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+				}
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+			})
+
+			table.DescribeTable("should fail", func(query string) {
+				request.Request.URL = &url.URL{RawQuery: query}
+				app.PcapRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			},
+				table.Entry("if the interface does not exist", "interface=unknown"),
+				table.Entry("if the interface is passed to the guest", "interface=sriov"),
+				table.Entry("with an invalid filter", "interface=default&filter=40+0+0+12"),
+				table.Entry("with a packet count above the maximum", "interface=default&count=1000000"),
+				table.Entry("with a duration above the maximum", "interface=default&duration=3600"),
+			)
+		})
+
+		Context("port forwarding", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				request.PathParameters()["name"] = "testvmi"
+				request.PathParameters()["namespace"] = "default"
+
+				vmi = v1.NewMinimalVMI("testvmi")
+				vmi.Status.Phase = v1.Running
+				vmi.ObjectMeta.SetUID(uuid.NewUUID())
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			})
+
+			table.DescribeTable("should fail", func(query string, validatesVMI bool) {
+				if validatesVMI {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+						),
+					)
+				}
+				request.Request.URL = &url.URL{RawQuery: query}
+				app.PortForwardRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				if !validatesVMI {
+					Expect(server.ReceivedRequests()).To(BeEmpty())
+				}
+			},
+				table.Entry("without a port", "", false),
+				table.Entry("with a port out of range", "port=70000", false),
+				table.Entry("with an unknown protocol", "port=80&protocol=sctp", false),
+				table.Entry("if the pod network interface is a bridge", "port=80", true),
+			)
+		})
+
 		It("should fail if VirtualMachine not exists", func(done Done) {
 			request.PathParameters()["name"] = "testvm"
 			request.PathParameters()["namespace"] = "default"
@@ -923,6 +1082,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Manual", v1.RunStrategyManual, "VM is not running"),
 			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure, "VM is not running"),
 			table.Entry("Halted", v1.RunStrategyHalted, "Halted does not support manual restart requests"),
+			table.Entry("Once", v1.RunStrategyOnce, "Once does not support manual restart requests"),
 		)
 
 		It("should fail on a VM that is scheduled to be renamed", func() {
@@ -1245,608 +1405,1907 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
-	Context("Subresource api - error handling for StartVMRequestHandler", func() {
+	Context("Add Interface Subresource api", func() {
+
+		newAddInterfaceBody := func(opts *v1.AddInterfaceOptions) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		newRunningVMI := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(request.PathParameter("name"))
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			return vmi
+		}
+
 		BeforeEach(func() {
-			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["name"] = "testvmi"
 			request.PathParameters()["namespace"] = "default"
 		})
 
-		table.DescribeTable("should fail on VM with RunStrategy",
-			func(runStrategy v1.VirtualMachineRunStrategy, phase v1.VirtualMachineInstancePhase, status int, msg string) {
-				vm := newVirtualMachineWithRunStrategy(runStrategy)
-				var vmi *v1.VirtualMachineInstance
-				if phase != v1.VmPhaseUnset {
-					vmi = newVirtualMachineInstanceInPhase(phase)
-				}
-
-				server.AppendHandlers(
-					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
-					),
-				)
-
-				server.AppendHandlers(
-					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
-						ghttp.RespondWithJSONEncoded(status, vmi),
-					),
-				)
+		table.DescribeTable("Should handle the add interface request", func(opts *v1.AddInterfaceOptions, code int, enableGate bool) {
+			if enableGate {
+				enableFeatureGate(virtconfig.HotplugNetworkIfacesGate)
+			}
+			request.Request.Body = newAddInterfaceBody(opts)
 
-				app.StartVMRequestHandler(request, response)
+			vmi := newRunningVMI()
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
 
-				statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
-				// check the msg string that would be presented to virtctl output
-				Expect(statusErr.Error()).To(ContainSubstring(msg))
-			},
-			table.Entry("Always without VMI", v1.RunStrategyAlways, v1.VmPhaseUnset, http.StatusNotFound, "Always does not support manual start requests"),
-			table.Entry("Always with VMI in phase Running", v1.RunStrategyAlways, v1.Running, http.StatusOK, "VM is already running"),
-			table.Entry("RerunOnFailure with VMI in phase Failed", v1.RunStrategyRerunOnFailure, v1.Failed, http.StatusOK, "RerunOnFailure does not support starting VM from failed state"),
+			app.VMIAddInterfaceRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("with a valid request", &v1.AddInterfaceOptions{
+				Name:                            "blue",
+				NetworkAttachmentDefinitionName: "blue-net",
+			}, http.StatusAccepted, true),
+			table.Entry("with a request missing the name", &v1.AddInterfaceOptions{
+				NetworkAttachmentDefinitionName: "blue-net",
+			}, http.StatusBadRequest, true),
+			table.Entry("with a request missing the network attachment definition", &v1.AddInterfaceOptions{
+				Name: "blue",
+			}, http.StatusBadRequest, true),
+			table.Entry("with a request for an existing network", &v1.AddInterfaceOptions{
+				Name:                            "default",
+				NetworkAttachmentDefinitionName: "blue-net",
+			}, http.StatusConflict, true),
+			table.Entry("with a valid request but no feature gate", &v1.AddInterfaceOptions{
+				Name:                            "blue",
+				NetworkAttachmentDefinitionName: "blue-net",
+			}, http.StatusBadRequest, false),
 		)
 
-		table.DescribeTable("should not fail on VM with RunStrategy ",
-			func(runStrategy v1.VirtualMachineRunStrategy, phase v1.VirtualMachineInstancePhase, status int) {
-				vm := newVirtualMachineWithRunStrategy(runStrategy)
-				var vmi *v1.VirtualMachineInstance
-				if phase != v1.VmPhaseUnset {
-					vmi = newVirtualMachineInstanceInPhase(phase)
-				}
-
-				server.AppendHandlers(
-					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
-					),
-				)
+		It("Should generate a patch appending the network and the interface", func() {
+			patch, err := generateVMIInterfaceRequestPatch(newRunningVMI(), &v1.AddInterfaceOptions{
+				Name:                            "blue",
+				NetworkAttachmentDefinitionName: "blue-net",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch).To(Equal("[" +
+				`{ "op": "test", "path": "/spec/networks", "value": [{"name":"default","pod":{}}]}, ` +
+				`{ "op": "test", "path": "/spec/domain/devices/interfaces", "value": [{"name":"default","bridge":{}}]}, ` +
+				`{ "op": "replace", "path": "/spec/networks", "value": [{"name":"default","pod":{}},{"name":"blue","multus":{"networkName":"blue-net"}}]}, ` +
+				`{ "op": "replace", "path": "/spec/domain/devices/interfaces", "value": [{"name":"default","bridge":{}},{"name":"blue","model":"virtio","bridge":{}}]}` +
+				"]"))
+		})
+	})
 
-				server.AppendHandlers(
-					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
-						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
-					),
-				)
+	Context("Remove Interface Subresource api", func() {
 
-				server.AppendHandlers(
-					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
-						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
-					),
-				)
+		newRemoveInterfaceBody := func(opts *v1.RemoveInterfaceOptions) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
 
-				app.StartVMRequestHandler(request, response)
+		newVMISpec := func() v1.VirtualMachineInstanceSpec {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+			return vmi.Spec
+		}
 
-				Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
-			},
-			table.Entry("RerunOnFailure with VMI in state Succeeded", v1.RunStrategyRerunOnFailure, v1.Succeeded, http.StatusOK),
-			table.Entry("Manual with VMI in state Succeeded", v1.RunStrategyManual, v1.Succeeded, http.StatusOK),
-			table.Entry("Manual with VMI in state Failed", v1.RunStrategyManual, v1.Failed, http.StatusOK),
-		)
+		newRunningVMI := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(request.PathParameter("name"))
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec = newVMISpec()
+			return vmi
+		}
 
-		It("should fail on a VM that is scheduled to be renamed", func() {
-			vm := newMinimalVM(request.PathParameter("name"))
-			vm.Status.StateChangeRequests = []v1.VirtualMachineStateChangeRequest{
-				{
-					Action: v1.RenameRequest,
-					Data: map[string]string{
-						"newName": "newvm",
-					},
+		newVM := func() *v1.VirtualMachine {
+			return &v1.VirtualMachine{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: request.PathParameter("name"), Namespace: "default"},
+				Spec: v1.VirtualMachineSpec{
+					Template: &v1.VirtualMachineInstanceTemplateSpec{Spec: newVMISpec()},
 				},
 			}
+		}
 
-			server.AppendHandlers(
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", getVMPath("v1alpha3", k8sv1.NamespaceDefault, vm.Name)),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
-				),
-			)
-
-			app.StartVMRequestHandler(request, response)
-			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
-			// check the msg string that would be presented to virtctl output
-			Expect(status.Error()).To(ContainSubstring("rename"))
-		})
-	})
-
-	Context("Subresource api - error handling for StopVMRequestHandler", func() {
 		BeforeEach(func() {
-			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["name"] = "testvmi"
 			request.PathParameters()["namespace"] = "default"
 		})
 
-		table.DescribeTable("should fail with any strategy if VMI does not exist", func(runStrategy v1.VirtualMachineRunStrategy, msg string) {
-			vm := newVirtualMachineWithRunStrategy(runStrategy)
+		table.DescribeTable("Should handle the remove interface request on a VMI", func(opts *v1.RemoveInterfaceOptions, code int, enableGate bool) {
+			if enableGate {
+				enableFeatureGate(virtconfig.HotplugNetworkIfacesGate)
+			}
+			request.Request.Body = newRemoveInterfaceBody(opts)
 
+			vmi := newRunningVMI()
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
 				),
-			)
-
-			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
 				),
 			)
 
-			app.StopVMRequestHandler(request, response)
-
-			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
-			// check the msg string that would be presented to virtctl output
-			Expect(statusErr.Error()).To(ContainSubstring(msg))
+			app.VMIRemoveInterfaceRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(code))
 		},
-			table.Entry("RunStrategyAlways", v1.RunStrategyAlways, "VM is not running"),
-			table.Entry("RunStrategyManual", v1.RunStrategyManual, "VM is not running"),
-			table.Entry("RunStrategyRerunOnFailure", v1.RunStrategyRerunOnFailure, "VM is not running"),
-			table.Entry("RunStrategyHalted", v1.RunStrategyHalted, "VM is not running"),
+			table.Entry("with a valid request", &v1.RemoveInterfaceOptions{Name: "blue"}, http.StatusAccepted, true),
+			table.Entry("with a request missing the name", &v1.RemoveInterfaceOptions{}, http.StatusBadRequest, true),
+			table.Entry("with a request for an unknown interface", &v1.RemoveInterfaceOptions{Name: "red"}, http.StatusConflict, true),
+			table.Entry("with a valid request but no feature gate", &v1.RemoveInterfaceOptions{Name: "blue"}, http.StatusBadRequest, false),
 		)
 
-		It("should fail on VM with RunStrategyHalted", func() {
-			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyHalted)
-			vmi := newVirtualMachineInstanceInPhase(v1.Unknown)
+		It("Should remove the interface from the VM and unplug it from the running VMI", func() {
+			enableFeatureGate(virtconfig.HotplugNetworkIfacesGate)
+			request.Request.Body = newRemoveInterfaceBody(&v1.RemoveInterfaceOptions{Name: "blue"})
 
+			vm := newVM()
+			vmi := newRunningVMI()
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvmi"),
 					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
 				),
-			)
-
-			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
 					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
 				),
 			)
 
-			app.StopVMRequestHandler(request, response)
-
-			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
-			// check the msg string that would be presented to virtctl output
-			Expect(statusErr.Error()).To(ContainSubstring("VM is not running"))
+			app.VMRemoveInterfaceRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+			Expect(server.ReceivedRequests()).To(HaveLen(4))
 		})
 
-		table.DescribeTable("should not fail on VM with RunStrategy", func(runStrategy v1.VirtualMachineRunStrategy) {
-			vm := newVirtualMachineWithRunStrategy(runStrategy)
-			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+		It("Should remove the interface from the VM when the VMI does not exist", func() {
+			enableFeatureGate(virtconfig.HotplugNetworkIfacesGate)
+			request.Request.Body = newRemoveInterfaceBody(&v1.RemoveInterfaceOptions{Name: "blue"})
 
+			vm := newVM()
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvmi"),
 					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
 				),
-			)
-
-			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
 				),
 			)
 
-			if runStrategy == v1.RunStrategyManual {
-				server.AppendHandlers(
+			app.VMRemoveInterfaceRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("Should generate a patch marking the VMI interface as absent", func() {
+			patch, err := generateVMIRemoveInterfacePatch(newRunningVMI(), &v1.RemoveInterfaceOptions{Name: "blue"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch).To(ContainSubstring(`{ "op": "replace", "path": "/spec/domain/devices/interfaces", "value": [{"name":"default","bridge":{}},{"name":"blue","bridge":{},"state":"absent"}]}`))
+			Expect(patch).To(ContainSubstring(`{ "op": "replace", "path": "/spec/networks", "value": [{"name":"default","pod":{}},{"name":"blue","multus":{"networkName":"blue-net"}}]}`))
+		})
+
+		It("Should generate a patch removing the interface and network from the VM template", func() {
+			patch, err := generateVMRemoveInterfacePatch(newVM(), &v1.RemoveInterfaceOptions{Name: "blue"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch).To(ContainSubstring(`{ "op": "replace", "path": "/spec/template/spec/networks", "value": [{"name":"default","pod":{}}]}`))
+			Expect(patch).To(ContainSubstring(`{ "op": "replace", "path": "/spec/template/spec/domain/devices/interfaces", "value": [{"name":"default","bridge":{}}]}`))
+		})
+	})
+
+	Context("Set Link State Subresource api", func() {
+
+		newSetLinkStateBody := func(opts *v1.SetLinkStateOptions) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		newRunningVMI := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(request.PathParameter("name"))
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{Name: "sriov", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "sriov-net"}}},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+			}
+			return vmi
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		table.DescribeTable("Should handle the set link state request", func(opts *v1.SetLinkStateOptions, code int) {
+			request.Request.Body = newSetLinkStateBody(opts)
+
+			vmi := newRunningVMI()
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.VMISetLinkStateRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("with a request taking the link down", &v1.SetLinkStateOptions{Name: "default", State: v1.InterfaceStateLinkDown}, http.StatusAccepted),
+			table.Entry("with a request taking the link up", &v1.SetLinkStateOptions{Name: "default", State: v1.InterfaceStateLinkUp}, http.StatusAccepted),
+			table.Entry("with a request missing the name", &v1.SetLinkStateOptions{State: v1.InterfaceStateLinkDown}, http.StatusBadRequest),
+			table.Entry("with a request for an unsupported state", &v1.SetLinkStateOptions{Name: "default", State: v1.InterfaceStateAbsent}, http.StatusBadRequest),
+			table.Entry("with a request for an unknown interface", &v1.SetLinkStateOptions{Name: "red", State: v1.InterfaceStateLinkDown}, http.StatusConflict),
+			table.Entry("with a request for an SR-IOV interface", &v1.SetLinkStateOptions{Name: "sriov", State: v1.InterfaceStateLinkDown}, http.StatusConflict),
+		)
+
+		It("Should generate a patch setting the link state of the VMI interface", func() {
+			patch, err := generateVMISetLinkStatePatch(newRunningVMI(), &v1.SetLinkStateOptions{Name: "default", State: v1.InterfaceStateLinkDown})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch).To(ContainSubstring(`{ "op": "replace", "path": "/spec/domain/devices/interfaces", "value": [{"name":"default","bridge":{},"state":"down"},{"name":"sriov","sriov":{}}]}`))
+		})
+	})
+
+	Context("Insert/Eject Media Subresource api", func() {
+
+		newMediaBody := func(opts interface{}) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		newRunningVMI := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(request.PathParameter("name"))
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				{Name: "empty-cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}},
+				{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "rootdisk", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fedora"}}},
+				{Name: "cdrom", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "iso"}}},
+			}
+			return vmi
+		}
+
+		pvcMedia := &v1.HotplugVolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "iso2"},
+		}
+
+		expectVMIPatch := func(vmi *v1.VirtualMachineInstance) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("Should fail to insert media if the HotplugVolumes feature gate is not enabled", func() {
+			request.Request.Body = newMediaBody(&v1.InsertMediaOptions{Name: "empty-cdrom", VolumeSource: pvcMedia})
+			app.VMIInsertMediaRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		It("Should fail to eject media if the HotplugVolumes feature gate is not enabled", func() {
+			request.Request.Body = newMediaBody(&v1.EjectMediaOptions{Name: "cdrom"})
+			app.VMIEjectMediaRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		table.DescribeTable("Should handle the insert media request", func(opts *v1.InsertMediaOptions, code int) {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMediaBody(opts)
+			expectVMIPatch(newRunningVMI())
+
+			app.VMIInsertMediaRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("with a request inserting a PVC into an empty CD-ROM", &v1.InsertMediaOptions{Name: "empty-cdrom", VolumeSource: pvcMedia}, http.StatusAccepted),
+			table.Entry("with a request inserting a DataVolume into an empty CD-ROM",
+				&v1.InsertMediaOptions{Name: "empty-cdrom", VolumeSource: &v1.HotplugVolumeSource{DataVolume: &v1.DataVolumeSource{Name: "iso-dv"}}}, http.StatusAccepted),
+			table.Entry("with a request missing the name", &v1.InsertMediaOptions{VolumeSource: pvcMedia}, http.StatusBadRequest),
+			table.Entry("with a request missing the media", &v1.InsertMediaOptions{Name: "empty-cdrom"}, http.StatusBadRequest),
+			table.Entry("with a request for a CD-ROM which is not empty", &v1.InsertMediaOptions{Name: "cdrom", VolumeSource: pvcMedia}, http.StatusConflict),
+			table.Entry("with a request for a disk which is not a CD-ROM", &v1.InsertMediaOptions{Name: "rootdisk", VolumeSource: pvcMedia}, http.StatusConflict),
+			table.Entry("with a request for an unknown CD-ROM", &v1.InsertMediaOptions{Name: "unknown", VolumeSource: pvcMedia}, http.StatusConflict),
+		)
+
+		table.DescribeTable("Should handle the eject media request", func(opts *v1.EjectMediaOptions, code int) {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMediaBody(opts)
+			expectVMIPatch(newRunningVMI())
+
+			app.VMIEjectMediaRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("with a request ejecting the media of a CD-ROM", &v1.EjectMediaOptions{Name: "cdrom"}, http.StatusAccepted),
+			table.Entry("with a request missing the name", &v1.EjectMediaOptions{}, http.StatusBadRequest),
+			table.Entry("with a request for an empty CD-ROM", &v1.EjectMediaOptions{Name: "empty-cdrom"}, http.StatusConflict),
+			table.Entry("with a request for a disk which is not a CD-ROM", &v1.EjectMediaOptions{Name: "rootdisk"}, http.StatusConflict),
+		)
+
+		It("Should fail to change the media of a VMI which is not running", func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMediaBody(&v1.EjectMediaOptions{Name: "cdrom"})
+			vmi := newRunningVMI()
+			vmi.Status.Phase = v1.Scheduled
+			expectVMIPatch(vmi)
+
+			app.VMIEjectMediaRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should generate a patch adding the media as a volume named like the CD-ROM", func() {
+			patch, err := generateVMIInsertMediaPatch(newRunningVMI(), &v1.InsertMediaOptions{Name: "empty-cdrom", VolumeSource: pvcMedia})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch).To(ContainSubstring(`{"name":"empty-cdrom","persistentVolumeClaim":{"claimName":"iso2"}}]}`))
+		})
+
+		It("Should generate a patch removing the volume of the ejected media", func() {
+			patch, err := generateVMIEjectMediaPatch(newRunningVMI(), &v1.EjectMediaOptions{Name: "cdrom"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch).To(ContainSubstring(`{ "op": "replace", "path": "/spec/volumes", "value": [{"name":"rootdisk","containerDisk":{"image":"fedora"}}]}`))
+		})
+	})
+
+	Context("Subresource api - error handling for StartVMRequestHandler", func() {
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		table.DescribeTable("should fail on VM with RunStrategy",
+			func(runStrategy v1.VirtualMachineRunStrategy, phase v1.VirtualMachineInstancePhase, status int, msg string) {
+				vm := newVirtualMachineWithRunStrategy(runStrategy)
+				var vmi *v1.VirtualMachineInstance
+				if phase != v1.VmPhaseUnset {
+					vmi = newVirtualMachineInstanceInPhase(phase)
+				}
+
+				server.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
 						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
 					),
 				)
-			} else {
+
 				server.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+						ghttp.RespondWithJSONEncoded(status, vmi),
+					),
+				)
+
+				app.StartVMRequestHandler(request, response)
+
+				statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+				// check the msg string that would be presented to virtctl output
+				Expect(statusErr.Error()).To(ContainSubstring(msg))
+			},
+			table.Entry("Always without VMI", v1.RunStrategyAlways, v1.VmPhaseUnset, http.StatusNotFound, "Always does not support manual start requests"),
+			table.Entry("Always with VMI in phase Running", v1.RunStrategyAlways, v1.Running, http.StatusOK, "VM is already running"),
+			table.Entry("RerunOnFailure with VMI in phase Failed", v1.RunStrategyRerunOnFailure, v1.Failed, http.StatusOK, "RerunOnFailure does not support starting VM from failed state"),
+			table.Entry("Once without VMI", v1.RunStrategyOnce, v1.VmPhaseUnset, http.StatusNotFound, "Once does not support manual start requests"),
+			table.Entry("Once with VMI in phase Succeeded", v1.RunStrategyOnce, v1.Succeeded, http.StatusOK, "Once does not support manual start requests"),
+		)
+
+		table.DescribeTable("should not fail on VM with RunStrategy ",
+			func(runStrategy v1.VirtualMachineRunStrategy, phase v1.VirtualMachineInstancePhase, status int) {
+				vm := newVirtualMachineWithRunStrategy(runStrategy)
+				var vmi *v1.VirtualMachineInstance
+				if phase != v1.VmPhaseUnset {
+					vmi = newVirtualMachineInstanceInPhase(phase)
+				}
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					),
+				)
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
 						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
 					),
 				)
+
+				app.StartVMRequestHandler(request, response)
+
+				Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+			},
+			table.Entry("RerunOnFailure with VMI in state Succeeded", v1.RunStrategyRerunOnFailure, v1.Succeeded, http.StatusOK),
+			table.Entry("Manual with VMI in state Succeeded", v1.RunStrategyManual, v1.Succeeded, http.StatusOK),
+			table.Entry("Manual with VMI in state Failed", v1.RunStrategyManual, v1.Failed, http.StatusOK),
+		)
+
+		It("should fail on a VM that is scheduled to be renamed", func() {
+			vm := newMinimalVM(request.PathParameter("name"))
+			vm.Status.StateChangeRequests = []v1.VirtualMachineStateChangeRequest{
+				{
+					Action: v1.RenameRequest,
+					Data: map[string]string{
+						"newName": "newvm",
+					},
+				},
 			}
 
-			app.StopVMRequestHandler(request, response)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", getVMPath("v1alpha3", k8sv1.NamespaceDefault, vm.Name)),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.StartVMRequestHandler(request, response)
+			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			// check the msg string that would be presented to virtctl output
+			Expect(status.Error()).To(ContainSubstring("rename"))
+		})
+	})
+
+	Context("Subresource api - error handling for StopVMRequestHandler", func() {
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		table.DescribeTable("should fail with any strategy if VMI does not exist", func(runStrategy v1.VirtualMachineRunStrategy, msg string) {
+			vm := newVirtualMachineWithRunStrategy(runStrategy)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.StopVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			// check the msg string that would be presented to virtctl output
+			Expect(statusErr.Error()).To(ContainSubstring(msg))
+		},
+			table.Entry("RunStrategyAlways", v1.RunStrategyAlways, "VM is not running"),
+			table.Entry("RunStrategyManual", v1.RunStrategyManual, "VM is not running"),
+			table.Entry("RunStrategyRerunOnFailure", v1.RunStrategyRerunOnFailure, "VM is not running"),
+			table.Entry("RunStrategyHalted", v1.RunStrategyHalted, "VM is not running"),
+		)
+
+		It("should fail on VM with RunStrategyHalted", func() {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyHalted)
+			vmi := newVirtualMachineInstanceInPhase(v1.Unknown)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.StopVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			// check the msg string that would be presented to virtctl output
+			Expect(statusErr.Error()).To(ContainSubstring("VM is not running"))
+		})
+
+		table.DescribeTable("should not fail on VM with RunStrategy", func(runStrategy v1.VirtualMachineRunStrategy) {
+			vm := newVirtualMachineWithRunStrategy(runStrategy)
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			if runStrategy == v1.RunStrategyManual || runStrategy == v1.RunStrategyOnce {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					),
+				)
+			} else {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					),
+				)
+			}
+
+			app.StopVMRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		},
+			table.Entry("Always", v1.RunStrategyAlways),
+			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure),
+			table.Entry("Manual", v1.RunStrategyManual),
+			table.Entry("Once", v1.RunStrategyOnce),
+		)
+	})
+
+	Context("Subresource api - MigrateVMRequestHandler", func() {
+		It("should fail if VirtualMachine not exists", func(done Done) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+			close(done)
+		}, 5)
+
+		It("should fail if VirtualMachine is not running", func(done Done) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vm := v1.VirtualMachine{}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(status.Error()).To(ContainSubstring("VM is not running"))
+			close(done)
+		})
+
+		It("should fail if migration is not posted", func(done Done) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vm := v1.VirtualMachine{
+				Status: v1.VirtualMachineStatus{
+					Ready: true,
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
+					ghttp.RespondWithJSONEncoded(http.StatusInternalServerError, nil),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusInternalServerError)
+			close(done)
+		})
+
+		It("should migrate VirtualMachine", func(done Done) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vm := v1.VirtualMachine{
+				Status: v1.VirtualMachineStatus{
+					Ready: true,
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			migration := v1.VirtualMachineInstanceMigration{}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, migration),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+			close(done)
+		})
+	})
+
+	Context("Subresource api - NetworkBootVMRequestHandler", func() {
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should fail if VirtualMachine has no interfaces", func(done Done) {
+			vm := v1.VirtualMachine{
+				Spec: v1.VirtualMachineSpec{
+					Template: &v1.VirtualMachineInstanceTemplateSpec{},
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.NetworkBootVMRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(status.Error()).To(ContainSubstring("VM has no network interfaces"))
+			close(done)
+		})
+
+		It("should request the VirtualMachine to boot from the network", func(done Done) {
+			vm := v1.VirtualMachine{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "testvm"},
+				Spec: v1.VirtualMachineSpec{
+					Template: &v1.VirtualMachineInstanceTemplateSpec{},
+				},
+			}
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.VerifyBody([]byte(`{"metadata":{"annotations":{"kubevirt.io/network-boot-next-start":"true"}}}`)),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.NetworkBootVMRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+			close(done)
+		})
+	})
+
+	Context("Subresource api - Guest OS Info", func() {
+		type subRes func(request *restful.Request, response *restful.Response)
+
+		table.DescribeTable("should fail when the VMI does not exist", func(fn subRes) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			fn(request, response)
+
+			Expect(response.Error()).To(HaveOccurred(), "Response should indicate VM not found")
+			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+
+		},
+			table.Entry("for GuestOSInfo", app.GuestOSInfo),
+			table.Entry("for UserList", app.UserList),
+			table.Entry("for Filesystem", app.FilesystemList),
+		)
+
+		table.DescribeTable("should fail when the VMI is not running", func(fn subRes) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.VirtualMachineInstance{}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			fn(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+			Expect(response.Error().Error()).To(ContainSubstring("VMI is not running"))
+		},
+			table.Entry("for GuestOSInfo", app.GuestOSInfo),
+			table.Entry("for UserList", app.UserList),
+			table.Entry("for FilesystemList", app.FilesystemList),
+		)
+
+		table.DescribeTable("should fail when VMI does not have agent connected", func(fn subRes) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.VirtualMachineInstance{
+				Status: v1.VirtualMachineInstanceStatus{
+					Phase:      v1.Running,
+					Conditions: []v1.VirtualMachineInstanceCondition{},
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			fn(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+			Expect(response.Error().Error()).To(ContainSubstring("VMI does not have guest agent connected"))
+		},
+			table.Entry("for GuestOSInfo", app.GuestOSInfo),
+			table.Entry("for UserList", app.UserList),
+			table.Entry("for FilesystemList", app.FilesystemList),
+		)
+	})
+
+	Context("StateChange JSON", func() {
+		It("should create a stop request if status exists", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			vm.Status.Created = true
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+
+			res, err := getChangeRequestJson(vm, stopRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"}]}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a stop request if status doesn't exist", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+
+			res, err := getChangeRequestJson(vm, stopRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Stop","uid":"%s"}]}}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a restart request if status exists", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			vm.Status.Created = true
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+
+			res, err := getChangeRequestJson(vm, stopRequest, startRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"},{"action":"Start"}]}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a restart request if status doesn't exist", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+
+			res, err := getChangeRequestJson(vm, stopRequest, startRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Stop","uid":"%s"},{"action":"Start"}]}}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a start request if status exists", func() {
+			vm := newMinimalVM("testvm")
+			vm.Status.Created = true
+
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+
+			res, err := getChangeRequestJson(vm, startRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Start"}]}]`)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a start request if status doesn't exist", func() {
+			vm := newMinimalVM("testvm")
+
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+
+			res, err := getChangeRequestJson(vm, startRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Start"}]}}]`)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should force a stop request to override", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, startRequest)
+
+			res, err := getChangeRequestJson(vm, stopRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": [{"action":"Start"}]}, { "op": "replace", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"}]}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should error on start request if other requests exist", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, stopRequest)
+
+			_, err := getChangeRequestJson(vm, startRequest)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should error on restart request if other requests exist", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, startRequest)
+
+			_, err := getChangeRequestJson(vm, stopRequest, startRequest)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Pausing", func() {
+		It("Should pause a running, not paused VMI", func() {
+
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/pause"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMI(true, false)
+
+			app.PauseVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail pausing a not running VMI", func() {
+
+			expectVMI(false, false)
+
+			app.PauseVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail pausing a running but paused VMI", func() {
+
+			expectVMI(true, true)
+
+			app.PauseVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail unpausing a running, not paused VMI", func() {
+
+			expectVMI(true, false)
+
+			app.UnpauseVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail unpausing a not running VMI", func() {
+
+			expectVMI(false, false)
+
+			app.UnpauseVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should unpause a running, paused VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/unpause"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMI(true, true)
+
+			app.UnpauseVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Freezing", func() {
+		expectVMIWithAgent := func(agentConnected bool) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+				},
+				Status: v1.VirtualMachineInstanceStatus{
+					Phase: v1.Running,
+				},
+			}
+
+			if agentConnected {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstanceAgentConnected,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			expectHandlerPod()
+		}
+
+		newUnfreezeTimeoutBody := func(unfreezeTimeout *v1.FreezeUnfreezeTimeout) io.ReadCloser {
+			unfreezeTimeoutJson, _ := json.Marshal(unfreezeTimeout)
+			return ioutil.NopCloser(bytes.NewReader(unfreezeTimeoutJson))
+		}
+
+		It("Should freeze a running VMI with guest agent", func() {
+			unfreezeTimeout := &v1.FreezeUnfreezeTimeout{
+				UnfreezeTimeout: &k8smetav1.Duration{Duration: 5 * time.Minute},
+			}
+			unfreezeTimeoutJson, _ := json.Marshal(unfreezeTimeout)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/freeze"),
+					ghttp.VerifyJSON(string(unfreezeTimeoutJson)),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMIWithAgent(true)
+			request.Request.Body = newUnfreezeTimeoutBody(unfreezeTimeout)
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail freezing without unfreeze timeout", func() {
+			request.Request.Body = newUnfreezeTimeoutBody(&v1.FreezeUnfreezeTimeout{})
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		It("Should fail freezing a VMI without guest agent", func() {
+			expectVMIWithAgent(false)
+			request.Request.Body = newUnfreezeTimeoutBody(&v1.FreezeUnfreezeTimeout{
+				UnfreezeTimeout: &k8smetav1.Duration{Duration: 5 * time.Minute},
+			})
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail freezing a not running VMI", func() {
+			expectVMI(false, false)
+			request.Request.Body = newUnfreezeTimeoutBody(&v1.FreezeUnfreezeTimeout{
+				UnfreezeTimeout: &k8smetav1.Duration{Duration: 5 * time.Minute},
+			})
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should unfreeze a running VMI with guest agent", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/unfreeze"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMIWithAgent(true)
+
+			app.UnfreezeVMIRequestHandler(request, response)
 
-			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
-		},
-			table.Entry("Always", v1.RunStrategyAlways),
-			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure),
-			table.Entry("Manual", v1.RunStrategyManual),
-		)
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
 	})
 
-	Context("Subresource api - MigrateVMRequestHandler", func() {
-		It("should fail if VirtualMachine not exists", func(done Done) {
-			request.PathParameters()["name"] = "testvm"
+	Context("Backup", func() {
+		expectBackupVMI := func(backup *v1.VirtualMachineInstanceBackupStatus, handlerExpected bool) {
+			request.PathParameters()["name"] = "testvmi"
 			request.PathParameters()["namespace"] = "default"
 
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Backup = backup
+
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
 				),
 			)
 
-			app.MigrateVMRequestHandler(request, response)
+			if handlerExpected {
+				expectHandlerPod()
+			}
+		}
 
-			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
-			close(done)
-		}, 5)
+		newBackupOptionsBody := func(backupOptions *v1.BackupOptions) io.ReadCloser {
+			backupOptionsJson, _ := json.Marshal(backupOptions)
+			return ioutil.NopCloser(bytes.NewReader(backupOptionsJson))
+		}
 
-		It("should fail if VirtualMachine is not running", func(done Done) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+		runningBackup := &v1.VirtualMachineInstanceBackupStatus{
+			Checkpoints: []string{"backup-1", "backup-2"},
+			Checkpoint:  "backup-2",
+			Incremental: "backup-1",
+			Disks:       []string{"rootdisk"},
+		}
 
-			vm := v1.VirtualMachine{}
+		AfterEach(func() {
+			disableFeatureGates()
+		})
 
-			server.AppendHandlers(
+		It("Should start an incremental backup", func() {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			backupOptions := &v1.BackupOptions{Checkpoint: "backup-2", Incremental: "backup-1"}
+			backupOptionsJson, _ := json.Marshal(backupOptions)
+			backend.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/startbackup"),
+					ghttp.VerifyJSON(string(backupOptionsJson)),
+					ghttp.RespondWith(http.StatusOK, ""),
 				),
 			)
+			expectBackupVMI(&v1.VirtualMachineInstanceBackupStatus{Checkpoints: []string{"backup-1"}}, true)
+			request.Request.Body = newBackupOptionsBody(backupOptions)
 
-			app.MigrateVMRequestHandler(request, response)
+			app.StartBackupVMIRequestHandler(request, response)
 
-			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
-			Expect(status.Error()).To(ContainSubstring("VM is not running"))
-			close(done)
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
 		})
 
-		It("should fail if migration is not posted", func(done Done) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+		It("Should fail starting a backup without checkpoint", func() {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			request.Request.Body = newBackupOptionsBody(&v1.BackupOptions{})
 
-			vm := v1.VirtualMachine{
-				Status: v1.VirtualMachineStatus{
-					Ready: true,
-				},
+			app.StartBackupVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		table.DescribeTable("Should fail starting a backup", func(featureGate bool, backup *v1.VirtualMachineInstanceBackupStatus, backupOptions *v1.BackupOptions, code int) {
+			if featureGate {
+				enableFeatureGate(virtconfig.IncrementalBackupGate)
 			}
+			expectBackupVMI(backup, false)
+			request.Request.Body = newBackupOptionsBody(backupOptions)
+
+			app.StartBackupVMIRequestHandler(request, response)
 
+			ExpectStatusErrorWithCode(recorder, code)
+		},
+			table.Entry("if the feature gate is disabled", false, nil, &v1.BackupOptions{Checkpoint: "backup-1"}, http.StatusBadRequest),
+			table.Entry("if a backup is running", true, runningBackup, &v1.BackupOptions{Checkpoint: "backup-3"}, http.StatusConflict),
+			table.Entry("if the checkpoint exists", true, &v1.VirtualMachineInstanceBackupStatus{Checkpoints: []string{"backup-1"}}, &v1.BackupOptions{Checkpoint: "backup-1"}, http.StatusConflict),
+			table.Entry("if the incremental checkpoint does not exist", true, nil, &v1.BackupOptions{Checkpoint: "backup-2", Incremental: "backup-1"}, http.StatusBadRequest),
+		)
+
+		It("Should fail starting a backup of a VMI with a disk on a PVC", func() {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "rootdisk"},
+				{Name: "datadisk"},
+				{Name: "installdisk", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "rootdisk", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fedora"}}},
+				{Name: "datadisk", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+				{Name: "installdisk", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "install"}}},
+			}
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
 				),
 			)
+			request.Request.Body = newBackupOptionsBody(&v1.BackupOptions{Checkpoint: "backup-1"})
 
-			server.AppendHandlers(
+			app.StartBackupVMIRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(status.ErrStatus.Message).To(ContainSubstring("volumes datadisk are raw images"))
+		})
+
+		It("Should stop a running backup", func() {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			backend.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
-					ghttp.RespondWithJSONEncoded(http.StatusInternalServerError, nil),
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/stopbackup"),
+					ghttp.RespondWith(http.StatusOK, ""),
 				),
 			)
+			expectBackupVMI(runningBackup, true)
 
-			app.MigrateVMRequestHandler(request, response)
+			app.StopBackupVMIRequestHandler(request, response)
 
-			ExpectStatusErrorWithCode(recorder, http.StatusInternalServerError)
-			close(done)
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
 		})
 
-		It("should migrate VirtualMachine", func(done Done) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+		It("Should fail stopping a backup if none is running", func() {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			expectBackupVMI(&v1.VirtualMachineInstanceBackupStatus{Checkpoints: []string{"backup-1"}}, false)
 
-			vm := v1.VirtualMachine{
-				Status: v1.VirtualMachineStatus{
-					Ready: true,
-				},
+			app.StopBackupVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		table.DescribeTable("Should fail streaming a backup", func(query string, backup *v1.VirtualMachineInstanceBackupStatus, code int) {
+			enableFeatureGate(virtconfig.IncrementalBackupGate)
+			request.Request.URL = &url.URL{RawQuery: query}
+			if backup != nil {
+				expectBackupVMI(backup, false)
 			}
 
+			app.BackupRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, code)
+		},
+			table.Entry("without a disk", "", nil, http.StatusBadRequest),
+			table.Entry("with an invalid extentsOnly value", "disk=rootdisk&extentsOnly=maybe", nil, http.StatusBadRequest),
+			table.Entry("if no backup is running", "disk=rootdisk", &v1.VirtualMachineInstanceBackupStatus{}, http.StatusConflict),
+			table.Entry("if the disk is not part of the backup", "disk=datadisk", runningBackup, http.StatusBadRequest),
+		)
+	})
+
+	Context("Memory dump", func() {
+		expectMemoryDumpVMI := func(memoryDump *v1.MemoryDump, status *v1.VirtualMachineInstanceMemoryDumpStatus, handlerExpected bool) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.MemoryDump = memoryDump
+			vmi.Status.MemoryDump = status
+
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
 				),
 			)
 
-			migration := v1.VirtualMachineInstanceMigration{}
+			if handlerExpected {
+				expectHandlerPod()
+			}
+		}
 
-			server.AppendHandlers(
+		newMemoryDumpOptionsBody := func(fileName string) io.ReadCloser {
+			memoryDumpOptionsJson, _ := json.Marshal(&v1.MemoryDumpOptions{FileName: fileName})
+			return ioutil.NopCloser(bytes.NewReader(memoryDumpOptionsJson))
+		}
+
+		It("Should dump the memory", func() {
+			backend.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, migration),
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/memorydump"),
+					ghttp.VerifyJSON(`{"fileName": "testvmi.dump"}`),
+					ghttp.RespondWith(http.StatusOK, ""),
 				),
 			)
+			completed := &v1.VirtualMachineInstanceMemoryDumpStatus{FileName: "old.dump", Phase: v1.MemoryDumpCompleted}
+			expectMemoryDumpVMI(&v1.MemoryDump{ClaimName: "dumps"}, completed, true)
+			request.Request.Body = newMemoryDumpOptionsBody("testvmi.dump")
 
-			app.MigrateVMRequestHandler(request, response)
+			app.MemoryDumpVMIRequestHandler(request, response)
 
-			Expect(response.Error()).ToNot(HaveOccurred())
-			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
-			close(done)
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
 		})
-	})
 
-	Context("Subresource api - Guest OS Info", func() {
-		type subRes func(request *restful.Request, response *restful.Response)
+		table.DescribeTable("Should reject the file name", func(fileName string) {
+			request.Request.Body = newMemoryDumpOptionsBody(fileName)
 
-		table.DescribeTable("should fail when the VMI does not exist", func(fn subRes) {
-			request.PathParameters()["name"] = "testvm"
+			app.MemoryDumpVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		},
+			table.Entry("if it is empty", ""),
+			table.Entry("if it is a path", "../testvmi.dump"),
+			table.Entry("if it is the parent directory", ".."),
+		)
+
+		table.DescribeTable("Should fail dumping the memory", func(memoryDump *v1.MemoryDump, status *v1.VirtualMachineInstanceMemoryDumpStatus, code int) {
+			expectMemoryDumpVMI(memoryDump, status, false)
+			request.Request.Body = newMemoryDumpOptionsBody("testvmi.dump")
+
+			app.MemoryDumpVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, code)
+		},
+			table.Entry("if the VMI has no memory dump PVC", nil, nil, http.StatusBadRequest),
+			table.Entry("if a memory dump is running", &v1.MemoryDump{ClaimName: "dumps"},
+				&v1.VirtualMachineInstanceMemoryDumpStatus{FileName: "old.dump", Phase: v1.MemoryDumpInProgress}, http.StatusConflict),
+		)
+	})
+
+	Context("External snapshot", func() {
+		expectExternalSnapshotVMI := func(phase v1.VirtualMachineInstancePhase, handlerExpected bool) {
+			request.PathParameters()["name"] = "testvmi"
 			request.PathParameters()["namespace"] = "default"
 
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = phase
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "rootdisk",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "rootdisk"},
+					},
+				},
+				{
+					Name: "cloudinit",
+					VolumeSource: v1.VolumeSource{
+						CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+					},
+				},
+			}
+
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
 				),
 			)
 
-			fn(request, response)
+			if handlerExpected {
+				expectHandlerPod()
+			}
+		}
 
-			Expect(response.Error()).To(HaveOccurred(), "Response should indicate VM not found")
-			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+		newExternalSnapshotOptionsBody := func(name string, volumes ...string) io.ReadCloser {
+			optionsJson, _ := json.Marshal(&v1.ExternalSnapshotOptions{Name: name, Volumes: volumes})
+			return ioutil.NopCloser(bytes.NewReader(optionsJson))
+		}
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("Should take an external snapshot", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/externalsnapshot"),
+					ghttp.VerifyJSON(`{"name": "snap-1", "volumes": ["rootdisk"]}`),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectExternalSnapshotVMI(v1.Running, true)
+			request.Request.Body = newExternalSnapshotOptionsBody("snap-1", "rootdisk")
+
+			app.ExternalSnapshotVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		table.DescribeTable("Should reject the options", func(name string, volumes ...string) {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			request.Request.Body = newExternalSnapshotOptionsBody(name, volumes...)
+
+			app.ExternalSnapshotVMIRequestHandler(request, response)
 
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
 		},
-			table.Entry("for GuestOSInfo", app.GuestOSInfo),
-			table.Entry("for UserList", app.UserList),
-			table.Entry("for Filesystem", app.FilesystemList),
+			table.Entry("if the name is empty", "", "rootdisk"),
+			table.Entry("if the name is a path", "../snap-1", "rootdisk"),
+			table.Entry("if no volume is given", "snap-1"),
 		)
 
-		table.DescribeTable("should fail when the VMI is not running", func(fn subRes) {
-			request.PathParameters()["name"] = "testvm"
+		table.DescribeTable("Should fail taking an external snapshot", func(featureGate bool, phase v1.VirtualMachineInstancePhase, volume string, code int) {
+			if featureGate {
+				enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			}
+			expectExternalSnapshotVMI(phase, false)
+			request.Request.Body = newExternalSnapshotOptionsBody("snap-1", volume)
+
+			app.ExternalSnapshotVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, code)
+		},
+			table.Entry("if the feature gate is disabled", false, v1.Running, "rootdisk", http.StatusBadRequest),
+			table.Entry("if the VMI is not running", true, v1.Scheduled, "rootdisk", http.StatusConflict),
+			table.Entry("if the volume does not exist", true, v1.Running, "datadisk", http.StatusBadRequest),
+			table.Entry("if the volume is not a PVC", true, v1.Running, "cloudinit", http.StatusBadRequest),
+		)
+	})
+
+	Context("Block commit", func() {
+		expectBlockCommitVMI := func(phase v1.VirtualMachineInstancePhase, blockCommitStatus *v1.VirtualMachineInstanceBlockCommitStatus, contents *snapshotv1.VirtualMachineSnapshotContentList, handlerExpected bool) {
+			request.PathParameters()["name"] = "testvmi"
 			request.PathParameters()["namespace"] = "default"
 
-			vmi := v1.VirtualMachineInstance{}
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = phase
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "rootdisk", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "rootdisk-pvc"}}},
+			}
+			vmi.Status.BlockCommit = blockCommitStatus
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name: "rootdisk",
+					ExternalSnapshot: &v1.ExternalSnapshotVolumeStatus{
+						ActiveFile:   "vmsnapshot-2.qcow2",
+						BackingChain: []string{"vmsnapshot-1.qcow2", "disk.img"},
+					},
+				},
+				{
+					Name: "datadisk",
+				},
+			}
 
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
 					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
 				),
 			)
 
-			fn(request, response)
-
-			Expect(response.Error()).To(HaveOccurred())
-			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
-			Expect(response.Error().Error()).To(ContainSubstring("VMI is not running"))
-		},
-			table.Entry("for GuestOSInfo", app.GuestOSInfo),
-			table.Entry("for UserList", app.UserList),
-			table.Entry("for FilesystemList", app.FilesystemList),
-		)
+			if contents != nil {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, newMinimalVM("testvmi")),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/snapshot.kubevirt.io/v1alpha1/namespaces/default/virtualmachinesnapshotcontents"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, contents),
+					),
+				)
+			}
 
-		table.DescribeTable("should fail when VMI does not have agent connected", func(fn subRes) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+			if handlerExpected {
+				expectHandlerPod()
+			}
+		}
 
-			vmi := v1.VirtualMachineInstance{
-				Status: v1.VirtualMachineInstanceStatus{
-					Phase:      v1.Running,
-					Conditions: []v1.VirtualMachineInstanceCondition{},
+		newSnapshotContents := func(file string) *snapshotv1.VirtualMachineSnapshotContentList {
+			content := snapshotv1.VirtualMachineSnapshotContent{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "vmsnapshot-content-1", Namespace: "default"},
+				Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+					VolumeBackups: []snapshotv1.VolumeBackup{
+						{
+							VolumeName: "rootdisk",
+							PersistentVolumeClaim: snapshotv1.PersistentVolumeClaim{
+								ObjectMeta: k8smetav1.ObjectMeta{Name: "rootdisk-pvc"},
+							},
+							ExternalSnapshot: &snapshotv1.ExternalSnapshotBackup{
+								File:    file,
+								Overlay: "vmsnapshot-1.qcow2",
+							},
+						},
+					},
 				},
 			}
+			return &snapshotv1.VirtualMachineSnapshotContentList{Items: []snapshotv1.VirtualMachineSnapshotContent{content}}
+		}
+
+		newBlockCommitOptionsBody := func(volumes ...string) io.ReadCloser {
+			optionsJson, _ := json.Marshal(&v1.BlockCommitOptions{Volumes: volumes})
+			return ioutil.NopCloser(bytes.NewReader(optionsJson))
+		}
+
+		newBlockCommitOverlayOptionsBody := func(overlay string, volumes ...string) io.ReadCloser {
+			optionsJson, _ := json.Marshal(&v1.BlockCommitOptions{Volumes: volumes, Overlay: overlay})
+			return ioutil.NopCloser(bytes.NewReader(optionsJson))
+		}
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("Should start a block commit", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/blockcommit"),
+					ghttp.VerifyJSON(`{"volumes": ["rootdisk"], "activeFiles": ["vmsnapshot-2.qcow2"]}`),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectBlockCommitVMI(v1.Running, &v1.VirtualMachineInstanceBlockCommitStatus{Volumes: []string{"rootdisk"}, Phase: v1.BlockCommitCompleted}, &snapshotv1.VirtualMachineSnapshotContentList{}, true)
+			request.Request.Body = newBlockCommitOptionsBody("rootdisk")
+
+			app.BlockCommitVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should start a block commit if snapshot contents only reference files of an earlier chain", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/blockcommit"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectBlockCommitVMI(v1.Running, nil, newSnapshotContents("vmsnapshot-0.qcow2"), true)
+			request.Request.Body = newBlockCommitOptionsBody("rootdisk")
+
+			app.BlockCommitVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
 
+		It("Should fail starting a block commit while a snapshot of the VM is in progress", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			expectBlockCommitVMI(v1.Running, nil, nil, false)
+			vm := newMinimalVM("testvmi")
+			snapshotName := "vmsnapshot-3"
+			vm.Status.SnapshotInProgress = &snapshotName
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
 				),
 			)
+			request.Request.Body = newBlockCommitOptionsBody("rootdisk")
 
-			fn(request, response)
+			app.BlockCommitVMIRequestHandler(request, response)
 
-			Expect(response.Error()).To(HaveOccurred())
-			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
-			Expect(response.Error().Error()).To(ContainSubstring("VMI does not have guest agent connected"))
-		},
-			table.Entry("for GuestOSInfo", app.GuestOSInfo),
-			table.Entry("for UserList", app.UserList),
-			table.Entry("for FilesystemList", app.FilesystemList),
-		)
-	})
+			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(status.ErrStatus.Message).To(ContainSubstring(`snapshot "vmsnapshot-3" of the VM is in progress`))
+		})
 
-	Context("StateChange JSON", func() {
-		It("should create a stop request if status exists", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			vm.Status.Created = true
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
+		It("Should fail starting a block commit if a snapshot content references the chain", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			expectBlockCommitVMI(v1.Running, nil, newSnapshotContents("disk.img"), false)
+			request.Request.Body = newBlockCommitOptionsBody("rootdisk")
 
-			res, err := getChangeRequestJson(vm, stopRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.BlockCommitVMIRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"}]}]`, uid)
-			Expect(res).To(Equal(ref))
+			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(status.ErrStatus.Message).To(ContainSubstring(`VirtualMachineSnapshotContent "vmsnapshot-content-1"`))
 		})
 
-		It("should create a stop request if status doesn't exist", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
+		It("Should start committing the overlay of a deleted snapshot content", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/blockcommit"),
+					ghttp.VerifyJSON(`{"volumes": ["rootdisk"], "overlay": "vmsnapshot-1.qcow2", "activeFiles": ["vmsnapshot-2.qcow2"]}`),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			contents := newSnapshotContents("disk.img")
+			now := k8smetav1.Now()
+			contents.Items[0].DeletionTimestamp = &now
+			expectBlockCommitVMI(v1.Running, nil, contents, true)
+			request.Request.Body = newBlockCommitOverlayOptionsBody("vmsnapshot-1.qcow2", "rootdisk")
 
-			res, err := getChangeRequestJson(vm, stopRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.BlockCommitVMIRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Stop","uid":"%s"}]}}]`, uid)
-			Expect(res).To(Equal(ref))
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
 		})
 
-		It("should create a restart request if status exists", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			vm.Status.Created = true
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
+		It("Should start committing an overlay if snapshot contents only reference files it is not committed into", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/blockcommit"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectBlockCommitVMI(v1.Running, nil, newSnapshotContents("disk.img"), true)
+			request.Request.Body = newBlockCommitOverlayOptionsBody("vmsnapshot-2.qcow2", "rootdisk")
 
-			res, err := getChangeRequestJson(vm, stopRequest, startRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.BlockCommitVMIRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"},{"action":"Start"}]}]`, uid)
-			Expect(res).To(Equal(ref))
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
 		})
 
-		It("should create a restart request if status doesn't exist", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
+		It("Should fail committing an overlay if a snapshot content references the file it is committed into", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			expectBlockCommitVMI(v1.Running, nil, newSnapshotContents("vmsnapshot-1.qcow2"), false)
+			request.Request.Body = newBlockCommitOverlayOptionsBody("vmsnapshot-2.qcow2", "rootdisk")
 
-			res, err := getChangeRequestJson(vm, stopRequest, startRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.BlockCommitVMIRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Stop","uid":"%s"},{"action":"Start"}]}}]`, uid)
-			Expect(res).To(Equal(ref))
+			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(status.ErrStatus.Message).To(ContainSubstring(`VirtualMachineSnapshotContent "vmsnapshot-content-1"`))
 		})
 
-		It("should create a start request if status exists", func() {
-			vm := newMinimalVM("testvm")
-			vm.Status.Created = true
-
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
-
-			res, err := getChangeRequestJson(vm, startRequest)
-			Expect(err).ToNot(HaveOccurred())
+		table.DescribeTable("Should fail committing an overlay", func(overlay string) {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			expectBlockCommitVMI(v1.Running, nil, nil, false)
+			request.Request.Body = newBlockCommitOverlayOptionsBody(overlay, "rootdisk")
 
-			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Start"}]}]`)
-			Expect(res).To(Equal(ref))
-		})
+			app.BlockCommitVMIRequestHandler(request, response)
 
-		It("should create a start request if status doesn't exist", func() {
-			vm := newMinimalVM("testvm")
+			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(status.ErrStatus.Message).To(ContainSubstring(fmt.Sprintf("has no overlay %q to commit", overlay)))
+		},
+			table.Entry("if it is not in the chain", "vmsnapshot-0.qcow2"),
+			table.Entry("if it is the image", "disk.img"),
+		)
 
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
+		It("Should reject the options if no volume is given", func() {
+			enableFeatureGate(virtconfig.ExternalSnapshotsGate)
+			request.Request.Body = newBlockCommitOptionsBody()
 
-			res, err := getChangeRequestJson(vm, startRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.BlockCommitVMIRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Start"}]}}]`)
-			Expect(res).To(Equal(ref))
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
 		})
 
-		It("should force a stop request to override", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
+		table.DescribeTable("Should fail starting a block commit", func(featureGate bool, phase v1.VirtualMachineInstancePhase, blockCommitStatus *v1.VirtualMachineInstanceBlockCommitStatus, volume string, code int) {
+			if featureGate {
+				enableFeatureGate(virtconfig.ExternalSnapshotsGate)
 			}
-			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, startRequest)
-
-			res, err := getChangeRequestJson(vm, stopRequest)
-			Expect(err).ToNot(HaveOccurred())
+			expectBlockCommitVMI(phase, blockCommitStatus, nil, false)
+			request.Request.Body = newBlockCommitOptionsBody(volume)
 
-			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": [{"action":"Start"}]}, { "op": "replace", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"}]}]`, uid)
-			Expect(res).To(Equal(ref))
-		})
+			app.BlockCommitVMIRequestHandler(request, response)
 
-		It("should error on start request if other requests exist", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
-			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, stopRequest)
+			ExpectStatusErrorWithCode(recorder, code)
+		},
+			table.Entry("if the feature gate is disabled", false, v1.Running, nil, "rootdisk", http.StatusBadRequest),
+			table.Entry("if the VMI is not running", true, v1.Scheduled, nil, "rootdisk", http.StatusConflict),
+			table.Entry("if a block commit is running", true, v1.Running,
+				&v1.VirtualMachineInstanceBlockCommitStatus{Volumes: []string{"rootdisk"}, Phase: v1.BlockCommitInProgress}, "rootdisk", http.StatusConflict),
+			table.Entry("if the volume has no overlays", true, v1.Running, nil, "datadisk", http.StatusBadRequest),
+			table.Entry("if the volume does not exist", true, v1.Running, nil, "otherdisk", http.StatusBadRequest),
+		)
+	})
 
-			_, err := getChangeRequestJson(vm, startRequest)
-			Expect(err).To(HaveOccurred())
-		})
+	Context("Serial console log", func() {
+		expectConsoleLogVMI := func(logEnabled bool, phase v1.VirtualMachineInstancePhase) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
 
-		It("should error on restart request if other requests exist", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+				},
+				Status: v1.VirtualMachineInstanceStatus{
+					Phase: phase,
+				},
 			}
-			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, startRequest)
+			vmi.Spec.Domain.Devices.LogSerialConsole = &logEnabled
 
-			_, err := getChangeRequestJson(vm, stopRequest, startRequest)
-			Expect(err).To(HaveOccurred())
-		})
-	})
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
 
-	Context("Pausing", func() {
-		It("Should pause a running, not paused VMI", func() {
+			expectHandlerPod()
+		}
 
+		It("Should return the serial console log of a running VMI", func() {
+			consoleLog := "Booting from Hard Disk...\nlogin: "
 			backend.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/pause"),
-					ghttp.RespondWith(http.StatusOK, ""),
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/console/log"),
+					ghttp.RespondWith(http.StatusOK, consoleLog),
 				),
 			)
-			expectVMI(true, false)
+			expectConsoleLogVMI(true, v1.Running)
 
-			app.PauseVMIRequestHandler(request, response)
+			app.SerialConsoleLogRequestHandler(request, response)
 
 			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("text/plain"))
+			Expect(recorder.Body.String()).To(Equal(consoleLog))
 		})
 
-		It("Should fail pausing a not running VMI", func() {
+		It("Should fail if serial console logging is not enabled", func() {
+			expectConsoleLogVMI(false, v1.Running)
 
-			expectVMI(false, false)
+			app.SerialConsoleLogRequestHandler(request, response)
 
-			app.PauseVMIRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		It("Should fail on a not running VMI", func() {
+			expectConsoleLogVMI(true, v1.Failed)
+
+			app.SerialConsoleLogRequestHandler(request, response)
 
 			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
 		})
+	})
 
-		It("Should fail pausing a running but paused VMI", func() {
+	Context("SEV", func() {
+		newSEVSecretBody := func(options *v1.SEVSecretOptions) io.ReadCloser {
+			optionsJson, _ := json.Marshal(options)
+			return ioutil.NopCloser(bytes.NewReader(optionsJson))
+		}
 
-			expectVMI(true, true)
+		expectSEVVMI := func(sev *v1.SEV) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
 
-			app.PauseVMIRequestHandler(request, response)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			if sev != nil {
+				vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SEV: sev}
+			}
 
-			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
-		})
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
 
-		It("Should fail unpausing a running, not paused VMI", func() {
+			expectHandlerPod()
+		}
 
-			expectVMI(true, false)
+		It("Should fetch the certificate chain of a running VMI", func() {
+			sevPlatformInfo := v1.SEVPlatformInfo{PDH: "pdh", CertChain: "certChain"}
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/sev/fetchcertchain"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, sevPlatformInfo),
+				),
+			)
+			expectSEVVMI(&v1.SEV{})
+			response.SetRequestAccepts(restful.MIME_JSON)
 
-			app.UnpauseVMIRequestHandler(request, response)
+			app.SEVFetchCertChainRequestHandler(request, response)
 
-			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			result := v1.SEVPlatformInfo{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &result)).To(Succeed())
+			Expect(result).To(Equal(sevPlatformInfo))
 		})
 
-		It("Should fail unpausing a not running VMI", func() {
-
-			expectVMI(false, false)
+		It("Should query the launch measurement of a running VMI", func() {
+			sevMeasurementInfo := v1.SEVMeasurementInfo{Measurement: "measurement", APIMajor: 1}
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/sev/querylaunchmeasurement"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, sevMeasurementInfo),
+				),
+			)
+			expectSEVVMI(&v1.SEV{})
+			response.SetRequestAccepts(restful.MIME_JSON)
 
-			app.UnpauseVMIRequestHandler(request, response)
+			app.SEVQueryLaunchMeasurementRequestHandler(request, response)
 
-			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			result := v1.SEVMeasurementInfo{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &result)).To(Succeed())
+			Expect(result).To(Equal(sevMeasurementInfo))
 		})
 
-		It("Should unpause a running, paused VMI", func() {
+		It("Should inject the launch secret into a running VMI", func() {
+			options := &v1.SEVSecretOptions{Header: "header", Secret: "secret"}
+			optionsJson, _ := json.Marshal(options)
 			backend.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/unpause"),
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/sev/injectlaunchsecret"),
+					ghttp.VerifyJSON(string(optionsJson)),
 					ghttp.RespondWith(http.StatusOK, ""),
 				),
 			)
-			expectVMI(true, true)
+			expectSEVVMI(&v1.SEV{Attestation: &v1.SEVAttestation{}})
+			request.Request.Body = newSEVSecretBody(options)
 
-			app.UnpauseVMIRequestHandler(request, response)
+			app.SEVInjectLaunchSecretRequestHandler(request, response)
 
 			Expect(response.StatusCode()).To(Equal(http.StatusOK))
 		})
+
+		table.DescribeTable("Should fail injecting incomplete launch secret options", func(options *v1.SEVSecretOptions, msg string) {
+			request.Request.Body = newSEVSecretBody(options)
+
+			app.SEVInjectLaunchSecretRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(status.Error()).To(ContainSubstring(msg))
+		},
+			table.Entry("without header", &v1.SEVSecretOptions{Secret: "secret"}, "requires header"),
+			table.Entry("without secret", &v1.SEVSecretOptions{Header: "header"}, "requires secret"),
+		)
+
+		type subRes func(request *restful.Request, response *restful.Response)
+		table.DescribeTable("Should fail on a not running VMI", func(fn subRes) {
+			expectVMI(false, false)
+			request.Request.Body = newSEVSecretBody(&v1.SEVSecretOptions{Header: "header", Secret: "secret"})
+
+			fn(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		},
+			table.Entry("for SEVFetchCertChainRequestHandler", app.SEVFetchCertChainRequestHandler),
+			table.Entry("for SEVQueryLaunchMeasurementRequestHandler", app.SEVQueryLaunchMeasurementRequestHandler),
+			table.Entry("for SEVInjectLaunchSecretRequestHandler", app.SEVInjectLaunchSecretRequestHandler),
+		)
+
+		table.DescribeTable("Should fail on a VMI without SEV", func(fn subRes) {
+			expectSEVVMI(nil)
+			request.Request.Body = newSEVSecretBody(&v1.SEVSecretOptions{Header: "header", Secret: "secret"})
+
+			fn(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(status.Error()).To(ContainSubstring("does not have SEV enabled"))
+		},
+			table.Entry("for SEVFetchCertChainRequestHandler", app.SEVFetchCertChainRequestHandler),
+			table.Entry("for SEVQueryLaunchMeasurementRequestHandler", app.SEVQueryLaunchMeasurementRequestHandler),
+			table.Entry("for SEVInjectLaunchSecretRequestHandler", app.SEVInjectLaunchSecretRequestHandler),
+		)
+
+		It("Should fail injecting the launch secret into a VMI which did not request the attestation", func() {
+			expectSEVVMI(&v1.SEV{})
+			request.Request.Body = newSEVSecretBody(&v1.SEVSecretOptions{Header: "header", Secret: "secret"})
+
+			app.SEVInjectLaunchSecretRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(status.Error()).To(ContainSubstring("did not request the SEV attestation"))
+		})
 	})
 
 	AfterEach(func() {
@@ -182,3 +182,36 @@ func SetVirtualMachineInstanceHypervFeatureDependencies(vmi *v1.VirtualMachineIn
 
 	return nil
 }
+
+// SetVirtualMachineInstanceWindowsHypervDefaults enables the recommended Hyper-V enlightenments
+// for Windows guests. Enlightenments which are explicitly configured are left untouched.
+func SetVirtualMachineInstanceWindowsHypervDefaults(vmi *v1.VirtualMachineInstance) {
+	if vmi.Spec.Domain.Features == nil {
+		vmi.Spec.Domain.Features = &v1.Features{}
+	}
+	if vmi.Spec.Domain.Features.Hyperv == nil {
+		vmi.Spec.Domain.Features.Hyperv = &v1.FeatureHyperv{}
+	}
+	hyperv := vmi.Spec.Domain.Features.Hyperv // shortcut
+
+	enableMissing := func(states ...**v1.FeatureState) {
+		for _, fs := range states {
+			if isFeatureStateMissing(fs) {
+				enableFeatureState(fs)
+			}
+		}
+	}
+
+	enableMissing(&hyperv.Relaxed, &hyperv.VAPIC, &hyperv.VPIndex, &hyperv.Runtime, &hyperv.Reset, &hyperv.Frequencies, &hyperv.Reenlightenment)
+	if hyperv.Spinlocks == nil {
+		retries := uint32(8191)
+		hyperv.Spinlocks = &v1.FeatureSpinlocks{Enabled: &_true, Retries: &retries}
+	}
+	// Leave out the enlightenments whose dependencies were explicitly disabled
+	if isFeatureStateEnabled(&hyperv.VPIndex) {
+		enableMissing(&hyperv.SyNIC, &hyperv.TLBFlush, &hyperv.IPI)
+	}
+	if isFeatureStateEnabled(&hyperv.SyNIC) && hyperv.SyNICTimer == nil {
+		hyperv.SyNICTimer = &v1.SyNICTimer{Enabled: &_true}
+	}
+}
@@ -26,7 +26,10 @@ import (
 	"k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook/mutators"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -67,8 +70,12 @@ func serve(resp http.ResponseWriter, req *http.Request, m mutator) {
 	}
 }
 
-func ServeVMs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
-	serve(resp, req, &mutators.VMsMutator{ClusterConfig: clusterConfig})
+func ServeVMs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient, macPool *macpool.Pool) {
+	serve(resp, req, &mutators.VMsMutator{
+		ClusterConfig:       clusterConfig,
+		InstancetypeMethods: instancetype.NewMethods(virtCli),
+		MacPool:             macPool,
+	})
 }
 
 func ServeVMIs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
@@ -24,14 +24,19 @@ import (
 	"k8s.io/api/admission/v1beta1"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 type VMsMutator struct {
-	ClusterConfig *virtconfig.ClusterConfig
+	ClusterConfig       *virtconfig.ClusterConfig
+	InstancetypeMethods instancetype.Methods
+	MacPool             *macpool.Pool
 }
 
 // until the minimum supported version is kubernetes 1.15 (see https://github.com/kubernetes/kubernetes/commit/c2fcdc818be1441dd788cae22648c04b1650d3af#diff-e057ec5b2ec27b4ba1e1a3915f715262)
@@ -64,7 +69,16 @@ func (mutator *VMsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissio
 
 	// Set VM defaults
 	log.Log.Object(&vm).V(4).Info("Apply defaults")
-	mutator.setDefaultMachineType(&vm)
+	preferenceSpec, err := mutator.InstancetypeMethods.FindPreferenceSpec(&vm)
+	if err != nil {
+		// the validating webhook reports a missing preference
+		log.Log.Object(&vm).V(4).Reason(err).Info("vm-mutator: unable to find preference")
+	}
+	mutator.setDefaultArchitecture(&vm)
+	mutator.setDefaultMachineType(&vm, preferenceSpec)
+	if ar.Request.Operation == v1beta1.Create {
+		mutator.assignMacAddresses(&vm)
+	}
 
 	var patch []patchOperation
 	var value interface{}
@@ -96,12 +110,43 @@ func (mutator *VMsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissio
 	}
 }
 
-func (mutator *VMsMutator) setDefaultMachineType(vm *v1.VirtualMachine) {
+func (mutator *VMsMutator) setDefaultMachineType(vm *v1.VirtualMachine, preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec) {
 	if vm.Spec.Template == nil {
 		// nothing to do, let's the validating webhook fail later
 		return
 	}
-	if vm.Spec.Template.Spec.Domain.Machine.Type == "" {
-		vm.Spec.Template.Spec.Domain.Machine.Type = mutator.ClusterConfig.GetMachineType()
+	if vm.Spec.Template.Spec.Domain.Machine.Type != "" {
+		return
+	}
+	if preferenceSpec != nil && preferenceSpec.Machine != nil && preferenceSpec.Machine.PreferredMachineType != "" {
+		vm.Spec.Template.Spec.Domain.Machine.Type = preferenceSpec.Machine.PreferredMachineType
+		return
+	}
+	vm.Spec.Template.Spec.Domain.Machine.Type = mutator.ClusterConfig.GetMachineType(vm.Spec.Template.Spec.Architecture)
+}
+
+func (mutator *VMsMutator) setDefaultArchitecture(vm *v1.VirtualMachine) {
+	if vm.Spec.Template == nil {
+		// nothing to do, let's the validating webhook fail later
+		return
+	}
+	if vm.Spec.Template.Spec.Architecture == "" {
+		vm.Spec.Template.Spec.Architecture = mutator.ClusterConfig.GetDefaultArchitecture()
+	}
+}
+
+func (mutator *VMsMutator) assignMacAddresses(vm *v1.VirtualMachine) {
+	macPoolConfig := mutator.ClusterConfig.GetMacPoolConfiguration()
+	if mutator.MacPool == nil || macPoolConfig == nil {
+		return
+	}
+	macRange, err := macpool.ParseRange(macPoolConfig)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Error("vm-mutator: invalid MAC pool configuration")
+		return
+	}
+	// like without a MAC pool, interfaces left without an address get a random one when the VMI starts
+	if err := mutator.MacPool.AssignMacAddresses(macRange, vm); err != nil {
+		log.Log.Object(vm).Reason(err).Warning("vm-mutator: unable to assign MAC addresses")
 	}
 }
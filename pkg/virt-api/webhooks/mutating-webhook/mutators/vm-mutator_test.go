@@ -22,16 +22,23 @@ import (
 	"encoding/json"
 	rt "runtime"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"k8s.io/api/admission/v1beta1"
 	k8sv1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -39,6 +46,9 @@ var _ = Describe("VirtualMachine Mutator", func() {
 	var vm *v1.VirtualMachine
 	var configMapInformer cache.SharedIndexInformer
 	var mutator *VMsMutator
+	var ctrl *gomock.Controller
+	var virtClient *kubecli.MockKubevirtClient
+	var operation v1beta1.Operation
 
 	machineTypeFromConfig := "pc-q35-3.0"
 
@@ -48,7 +58,8 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		By("Creating the test admissions review from the VM")
 		ar := &v1beta1.AdmissionReview{
 			Request: &v1beta1.AdmissionRequest{
-				Resource: k8smetav1.GroupVersionResource{Group: v1.VirtualMachineGroupVersionKind.Group, Version: v1.VirtualMachineGroupVersionKind.Version, Resource: "virtualmachines"},
+				Resource:  k8smetav1.GroupVersionResource{Group: v1.VirtualMachineGroupVersionKind.Group, Version: v1.VirtualMachineGroupVersionKind.Version, Resource: "virtualmachines"},
+				Operation: operation,
 				Object: runtime.RawExtension{
 					Raw: vmBytes,
 				},
@@ -79,11 +90,21 @@ var _ = Describe("VirtualMachine Mutator", func() {
 			},
 		}
 		vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{}
+		operation = v1beta1.Create
 
-		mutator = &VMsMutator{}
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+
+		mutator = &VMsMutator{
+			InstancetypeMethods: instancetype.NewMethods(virtClient),
+		}
 		mutator.ClusterConfig, configMapInformer, _, _ = testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
 	})
 
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
 	It("should apply defaults on VM create", func() {
 		vmSpec, _ := getVMSpecMetaFromResponse()
 		if rt.GOARCH == "ppc64le" {
@@ -93,6 +114,13 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		}
 	})
 
+	It("should apply the machine type of the template architecture on VM create", func() {
+		vm.Spec.Template.Spec.Architecture = "s390x"
+		vmSpec, _ := getVMSpecMetaFromResponse()
+		Expect(vmSpec.Template.Spec.Architecture).To(Equal("s390x"))
+		Expect(vmSpec.Template.Spec.Domain.Machine.Type).To(Equal(virtconfig.DefaultS390XMachineType))
+	})
+
 	It("should apply configurable defaults on VM create", func() {
 		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
 			Data: map[string]string{
@@ -103,6 +131,28 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		Expect(vmSpec.Template.Spec.Domain.Machine.Type).To(Equal(machineTypeFromConfig))
 	})
 
+	It("should apply the machine type preferred by the preference on VM create", func() {
+		kubevirtClient := kubevirtfake.NewSimpleClientset(&instancetypev1alpha1.VirtualMachineClusterPreference{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name: "preference",
+			},
+			Spec: instancetypev1alpha1.VirtualMachinePreferenceSpec{
+				Machine: &instancetypev1alpha1.MachinePreferences{
+					PreferredMachineType: machineTypeFromConfig,
+				},
+			},
+		})
+		virtClient.EXPECT().VirtualMachineClusterPreference().Return(
+			kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterPreferences()).AnyTimes()
+
+		vm.Spec.Preference = &v1.PreferenceMatcher{
+			Name: "preference",
+		}
+
+		vmSpec, _ := getVMSpecMetaFromResponse()
+		Expect(vmSpec.Template.Spec.Domain.Machine.Type).To(Equal(machineTypeFromConfig))
+	})
+
 	It("should not override specified properties with defaults on VM create", func() {
 		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
 			Data: map[string]string{
@@ -115,4 +165,50 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		vmSpec, _ := getVMSpecMetaFromResponse()
 		Expect(vmSpec.Template.Spec.Domain.Machine.Type).To(Equal(vm.Spec.Template.Spec.Domain.Machine.Type))
 	})
+
+	Context("with a MAC pool", func() {
+		var vmIndexer cache.Indexer
+
+		BeforeEach(func() {
+			mutator.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						NetworkConfiguration: &v1.NetworkConfiguration{
+							MacPool: &v1.MacPoolConfiguration{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:00:00:00:00:ff"},
+						},
+					},
+				},
+				Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeploying},
+			})
+			vmIndexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{macpool.MacAddressIndex: macpool.MacAddressIndexFunc})
+			mutator.MacPool = macpool.NewPool(vmIndexer, k8sfake.NewSimpleClientset().CoreV1().ConfigMaps("kubevirt"))
+
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "default"},
+				{Name: "secondary", MacAddress: "02:00:00:00:00:aa"},
+			}
+		})
+
+		It("should assign the free MAC addresses of the pool on VM create", func() {
+			Expect(vmIndexer.Add(&v1.VirtualMachine{
+				ObjectMeta: k8smetav1.ObjectMeta{Namespace: "default", Name: "othervm"},
+				Spec: v1.VirtualMachineSpec{Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{Domain: v1.DomainSpec{Devices: v1.Devices{
+						Interfaces: []v1.Interface{{Name: "default", MacAddress: "02:00:00:00:00:00"}},
+					}}},
+				}},
+			})).To(Succeed())
+
+			vmSpec, _ := getVMSpecMetaFromResponse()
+			Expect(vmSpec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:01"))
+			Expect(vmSpec.Template.Spec.Domain.Devices.Interfaces[1].MacAddress).To(Equal("02:00:00:00:00:aa"))
+		})
+
+		It("should not assign MAC addresses on VM update", func() {
+			operation = v1beta1.Update
+			vmSpec, _ := getVMSpecMetaFromResponse()
+			Expect(vmSpec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(BeEmpty())
+		})
+	})
 })
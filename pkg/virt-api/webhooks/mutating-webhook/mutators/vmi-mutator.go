@@ -33,6 +33,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -79,17 +80,26 @@ func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 
 		// Set VMI defaults
 		log.Log.Object(newVMI).V(4).Info("Apply defaults")
+		mutator.setDefaultArchitecture(newVMI)
+		mutator.setDefaultArchitectureSpecificConfig(newVMI)
 		mutator.setDefaultCPUModel(newVMI)
 		mutator.setDefaultMachineType(newVMI)
 		mutator.setDefaultResourceRequests(newVMI)
 		mutator.setDefaultGuestCPUTopology(newVMI)
 		mutator.setDefaultPullPoliciesOnContainerDisks(newVMI)
+		mutator.setDefaultSysprepDisks(newVMI)
+		mutator.setDefaultDownwardMetricsDisks(newVMI)
 		err = mutator.setDefaultNetworkInterface(newVMI)
 		if err != nil {
 			return webhookutils.ToAdmissionResponseError(err)
 		}
 		v1.SetObjectDefaults_VirtualMachineInstance(newVMI)
 
+		if util.IsWindowsVMI(newVMI) {
+			log.Log.V(4).Info("Set Windows HyperV defaults")
+			webhooks.SetVirtualMachineInstanceWindowsHypervDefaults(newVMI)
+		}
+
 		// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
 		// Until that time, we need to handle the hyperv deps to avoid obscure rejections from QEMU later on
 		log.Log.V(4).Info("Set HyperV dependencies")
@@ -120,6 +130,16 @@ func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 			Path:  "/metadata",
 			Value: value,
 		})
+
+		// Only our service accounts write the status, e.g. the verified digests of containerDisk images,
+		// a status passed by a user on creation is dropped like on updates
+		if !webhooks.IsKubeVirtServiceAccount(ar.Request.UserInfo.Username) && !reflect.DeepEqual(newVMI.Status, v1.VirtualMachineInstanceStatus{}) {
+			patch = append(patch, patchOperation{
+				Op:    "replace",
+				Path:  "/status",
+				Value: v1.VirtualMachineInstanceStatus{},
+			})
+		}
 	} else if ar.Request.Operation == v1beta1.Update {
 		// Ignore status updates if they are not coming from our service accounts
 		// TODO: As soon as CRDs support field selectors we can remove this and just enable
@@ -220,7 +240,37 @@ func (mutator *VMIsMutator) setDefaultGuestCPUTopology(vmi *v1.VirtualMachineIns
 
 func (mutator *VMIsMutator) setDefaultMachineType(vmi *v1.VirtualMachineInstance) {
 	if vmi.Spec.Domain.Machine.Type == "" {
-		vmi.Spec.Domain.Machine.Type = mutator.ClusterConfig.GetMachineType()
+		vmi.Spec.Domain.Machine.Type = mutator.ClusterConfig.GetMachineType(vmi.Spec.Architecture)
+	}
+}
+
+func (mutator *VMIsMutator) setDefaultArchitecture(vmi *v1.VirtualMachineInstance) {
+	if vmi.Spec.Architecture == "" {
+		vmi.Spec.Architecture = mutator.ClusterConfig.GetDefaultArchitecture()
+	}
+}
+
+// setDefaultArchitectureSpecificConfig sets the firmware and CPU defaults required by the guest architecture
+func (mutator *VMIsMutator) setDefaultArchitectureSpecificConfig(vmi *v1.VirtualMachineInstance) {
+	if vmi.Spec.Architecture != "arm64" {
+		return
+	}
+	// arm64 guests boot with UEFI only, secure boot is not available
+	if vmi.Spec.Domain.Firmware == nil {
+		vmi.Spec.Domain.Firmware = &v1.Firmware{}
+	}
+	if vmi.Spec.Domain.Firmware.Bootloader == nil {
+		secureBoot := false
+		vmi.Spec.Domain.Firmware.Bootloader = &v1.Bootloader{
+			EFI: &v1.EFI{SecureBoot: &secureBoot},
+		}
+	}
+	// the host-model CPU mode is not supported on arm64
+	if vmi.Spec.Domain.CPU == nil {
+		vmi.Spec.Domain.CPU = &v1.CPU{}
+	}
+	if vmi.Spec.Domain.CPU.Model == "" {
+		vmi.Spec.Domain.CPU.Model = v1.CPUModeHostPassthrough
 	}
 }
 
@@ -236,10 +286,53 @@ func (mutator *VMIsMutator) setDefaultPullPoliciesOnContainerDisks(vmi *v1.Virtu
 	}
 }
 
+// Windows setup only looks for the answer file on removable media, so
+// Sysprep volumes are attached as CD-ROM unless a device type is set explicitly
+func (mutator *VMIsMutator) setDefaultSysprepDisks(vmi *v1.VirtualMachineInstance) {
+	sysprepVolumes := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Sysprep != nil {
+			sysprepVolumes[volume.Name] = true
+		}
+	}
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		disk := &vmi.Spec.Domain.Devices.Disks[i]
+		if sysprepVolumes[disk.Name] && disk.Disk == nil && disk.LUN == nil && disk.Floppy == nil && disk.CDRom == nil {
+			disk.CDRom = &v1.CDRomTarget{}
+		}
+	}
+}
+
+// The downward metrics disk is always attached with virtio
+func (mutator *VMIsMutator) setDefaultDownwardMetricsDisks(vmi *v1.VirtualMachineInstance) {
+	metricsVolumes := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.DownwardMetrics != nil {
+			metricsVolumes[volume.Name] = true
+		}
+	}
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		disk := &vmi.Spec.Domain.Devices.Disks[i]
+		if !metricsVolumes[disk.Name] || disk.LUN != nil || disk.Floppy != nil || disk.CDRom != nil {
+			continue
+		}
+		if disk.Disk == nil {
+			disk.Disk = &v1.DiskTarget{}
+		}
+		if disk.Disk.Bus == "" {
+			disk.Disk.Bus = "virtio"
+		}
+	}
+}
+
 func (mutator *VMIsMutator) setDefaultResourceRequests(vmi *v1.VirtualMachineInstance) {
 
 	resources := &vmi.Spec.Domain.Resources
 
+	if mutator.ClusterConfig.IsGuestOverheadOvercommitted() {
+		resources.OvercommitGuestOverhead = true
+	}
+
 	if !resources.Limits.Cpu().IsZero() && resources.Requests.Cpu().IsZero() {
 		if resources.Requests == nil {
 			resources.Requests = k8sv1.ResourceList{}
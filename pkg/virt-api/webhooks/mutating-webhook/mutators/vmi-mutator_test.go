@@ -215,6 +215,20 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(vmiSpec.Domain.Resources.Requests.Memory().Value()).To(Equal(int64(0)))
 	})
 
+	It("should default the architecture to the one of the cluster", func() {
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Architecture).To(Equal(rt.GOARCH))
+	})
+
+	It("should apply the arm64 defaults on VMI create", func() {
+		vmi.Spec.Architecture = "arm64"
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Machine.Type).To(Equal(virtconfig.DefaultARM64MachineType))
+		Expect(vmiSpec.Domain.CPU.Model).To(Equal(v1.CPUModeHostPassthrough))
+		Expect(vmiSpec.Domain.Firmware.Bootloader.EFI).ToNot(BeNil())
+		Expect(*vmiSpec.Domain.Firmware.Bootloader.EFI.SecureBoot).To(BeFalse())
+	})
+
 	It("should apply configurable defaults on VMI create", func() {
 		// no limits wanted on this test, to not copy the limit to requests
 		namespaceLimitInformer, _ = testutils.NewFakeInformerFor(&k8sv1.LimitRange{})
@@ -238,6 +252,43 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(vmiSpec.Domain.Resources.Requests.Cpu().String()).To(Equal(cpuRequestFromConfig))
 	})
 
+	It("should attach Sysprep volumes as CD-ROM by default", func() {
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "sysprep"},
+			{Name: "sysprep-disk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}},
+			{Name: "other"},
+		}
+		for _, name := range []string{"sysprep", "sysprep-disk"} {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: name,
+				VolumeSource: v1.VolumeSource{
+					Sysprep: &v1.SysprepSource{ConfigMap: &k8sv1.LocalObjectReference{Name: "answers"}},
+				},
+			})
+		}
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Devices.Disks[0].CDRom).ToNot(BeNil())
+		Expect(vmiSpec.Domain.Devices.Disks[0].Disk).To(BeNil())
+		Expect(vmiSpec.Domain.Devices.Disks[1].CDRom).To(BeNil())
+		Expect(vmiSpec.Domain.Devices.Disks[2].Disk).ToNot(BeNil())
+	})
+
+	It("should attach downward metrics volumes with virtio by default", func() {
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "metrics"},
+			{Name: "other"},
+		}
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: "metrics",
+			VolumeSource: v1.VolumeSource{
+				DownwardMetrics: &v1.DownwardMetricsVolumeSource{},
+			},
+		})
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("virtio"))
+		Expect(vmiSpec.Domain.Devices.Disks[1].Disk.Bus).To(Equal("sata"))
+	})
+
 	table.DescribeTable("it should", func(given []v1.Volume, expected []v1.Volume) {
 		vmi.Spec.Volumes = given
 		vmiSpec, _ := getVMISpecMetaFromResponse()
@@ -542,6 +593,50 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(vmiSpec.Domain.Resources.Requests.Memory().String()).To(Equal("3072M"))
 	})
 
+	Context("with a memory overcommit policy", func() {
+		BeforeEach(func() {
+			// no limits wanted on this test, to not copy the limit to requests
+			namespaceLimitInformer, _ = testutils.NewFakeInformerFor(&k8sv1.LimitRange{})
+			webhooks.SetInformers(
+				&webhooks.Informers{
+					VMIPresetInformer:       presetInformer,
+					NamespaceLimitsInformer: namespaceLimitInformer,
+				},
+			)
+			mutator.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{},
+						MemoryOvercommitPolicy: &v1.MemoryOvercommitPolicy{
+							Ratio:                   200,
+							OvercommitGuestOverhead: true,
+						},
+					},
+				},
+				Status: v1.KubeVirtStatus{
+					Phase: v1.KubeVirtPhaseDeploying,
+				},
+			})
+		})
+
+		It("should apply the overcommit ratio when guest-memory is set and memory-request is not set", func() {
+			guestMemory := resource.MustParse("4096M")
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guestMemory}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Memory.Guest.String()).To(Equal("4096M"))
+			Expect(vmiSpec.Domain.Resources.Requests.Memory().String()).To(Equal("2048M"))
+		})
+
+		It("should overcommit the guest overhead", func() {
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Resources.OvercommitGuestOverhead).To(BeTrue())
+		})
+	})
+
 	It("should not apply memory overcommit when memory-request and guest-memory are set", func() {
 		vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
 			k8sv1.ResourceMemory: resource.MustParse("512M"),
@@ -596,6 +691,44 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(*(vmiSpec.Domain.Features.Hyperv.SyNICTimer.Enabled)).To(BeTrue())
 	})
 
+	It("should enable the recommended hyperv features for Windows guests", func() {
+		vmi.Annotations = map[string]string{v1.GuestOSAnnotation: v1.GuestOSWindows}
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		hyperv := vmiSpec.Domain.Features.Hyperv
+		for _, fs := range []*v1.FeatureState{hyperv.Relaxed, hyperv.VAPIC, hyperv.VPIndex, hyperv.Runtime, hyperv.SyNIC,
+			hyperv.Reset, hyperv.Frequencies, hyperv.Reenlightenment, hyperv.TLBFlush, hyperv.IPI} {
+			Expect(*fs.Enabled).To(BeTrue())
+		}
+		Expect(*hyperv.Spinlocks.Enabled).To(BeTrue())
+		Expect(*hyperv.Spinlocks.Retries).To(Equal(uint32(8191)))
+		Expect(*hyperv.SyNICTimer.Enabled).To(BeTrue())
+		Expect(hyperv.EVMCS).To(BeNil())
+	})
+
+	It("should keep explicitly configured hyperv features of Windows guests", func() {
+		vmi.Annotations = map[string]string{v1.GuestOSAnnotation: v1.GuestOSWindows}
+		vmi.Spec.Domain.Features = &v1.Features{
+			Hyperv: &v1.FeatureHyperv{
+				VPIndex: &v1.FeatureState{Enabled: &_false},
+				Runtime: &v1.FeatureState{Enabled: &_false},
+			},
+		}
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		hyperv := vmiSpec.Domain.Features.Hyperv
+		Expect(*hyperv.VPIndex.Enabled).To(BeFalse())
+		Expect(*hyperv.Runtime.Enabled).To(BeFalse())
+		Expect(*hyperv.Relaxed.Enabled).To(BeTrue())
+		Expect(hyperv.SyNIC).To(BeNil())
+		Expect(hyperv.SyNICTimer).To(BeNil())
+		Expect(hyperv.TLBFlush).To(BeNil())
+		Expect(hyperv.IPI).To(BeNil())
+	})
+
+	It("should not enable hyperv features for guests which are not marked as Windows", func() {
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Features.Hyperv).To(BeNil())
+	})
+
 	It("Should not mutate VMIs without HyperV configuration", func() {
 		vmi := v1.NewMinimalVMI("testvmi")
 		Expect(vmi.Spec.Domain.Features).To(BeNil())
@@ -756,4 +889,53 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		table.Entry("if our service accounts modfies it", privilegedUser, true),
 		table.Entry("not if the user is not one of ours", "unknown", false),
 	)
+
+	table.DescribeTable("on creation with a status", func(user string, shouldKeep bool) {
+		vmi.Status = v1.VirtualMachineInstanceStatus{
+			VolumeStatus: []v1.VolumeStatus{{
+				Name:                "disk0",
+				ContainerDiskVolume: &v1.ContainerDiskInfo{ImageDigest: "sha256:0123", SignatureVerified: true},
+			}},
+		}
+		vmiBytes, err := json.Marshal(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				UserInfo: v12.UserInfo{
+					Username: user,
+				},
+				Operation: v1beta1.Create,
+				Resource:  k8smetav1.GroupVersionResource{Group: v1.VirtualMachineInstanceGroupVersionKind.Group, Version: v1.VirtualMachineInstanceGroupVersionKind.Version, Resource: "virtualmachineinstances"},
+				Object: runtime.RawExtension{
+					Raw: vmiBytes,
+				},
+			},
+		}
+
+		resp := mutator.Mutate(ar)
+		Expect(resp.Allowed).To(BeTrue())
+
+		var patch []patchOperation
+		Expect(json.Unmarshal(resp.Patch, &patch)).To(Succeed())
+		var statusPatch *patchOperation
+		for i := range patch {
+			if patch[i].Path == "/status" {
+				statusPatch = &patch[i]
+			}
+		}
+		if shouldKeep {
+			Expect(statusPatch).To(BeNil())
+		} else {
+			Expect(statusPatch).ToNot(BeNil())
+			Expect(statusPatch.Op).To(Equal("replace"))
+			statusBytes, err := json.Marshal(statusPatch.Value)
+			Expect(err).ToNot(HaveOccurred())
+			status := v1.VirtualMachineInstanceStatus{}
+			Expect(json.Unmarshal(statusBytes, &status)).To(Succeed())
+			Expect(status.VolumeStatus).To(BeEmpty())
+		}
+	},
+		table.Entry("should keep it if our service accounts create the VMI", privilegedUser, true),
+		table.Entry("should drop a forged containerDisk digest if the user is not one of ours", "unknown", false),
+	)
 })
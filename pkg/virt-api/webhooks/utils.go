@@ -34,6 +34,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	clientutil "kubevirt.io/client-go/util"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	"kubevirt.io/kubevirt/pkg/util/openapi"
 	"kubevirt.io/kubevirt/pkg/virt-api/rest"
 )
@@ -83,6 +84,7 @@ type Informers struct {
 	NamespaceLimitsInformer cache.SharedIndexInformer
 	VMIInformer             cache.SharedIndexInformer
 	VMRestoreInformer       cache.SharedIndexInformer
+	VMInformer              cache.SharedIndexInformer
 }
 
 // XXX fix this, this is a huge mess. Move informers to Admitter and Mutator structs.
@@ -114,11 +116,16 @@ func newInformers() *Informers {
 		glog.Fatalf("Error searching for namespace: %v", err)
 	}
 	kubeInformerFactory := controller.NewKubeInformerFactory(kubeClient.RestClient(), kubeClient, nil, namespace)
+	vmInformer := kubeInformerFactory.VirtualMachine()
+	if err := vmInformer.AddIndexers(cache.Indexers{macpool.MacAddressIndex: macpool.MacAddressIndexFunc}); err != nil {
+		panic(err)
+	}
 	return &Informers{
 		VMIInformer:             kubeInformerFactory.VMI(),
 		VMIPresetInformer:       kubeInformerFactory.VirtualMachinePreset(),
 		NamespaceLimitsInformer: kubeInformerFactory.LimitRanges(),
 		VMRestoreInformer:       kubeInformerFactory.VirtualMachineRestore(),
+		VMInformer:              vmInformer,
 	}
 }
 
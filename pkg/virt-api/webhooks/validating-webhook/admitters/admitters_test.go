@@ -25,16 +25,22 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 )
 
 var _ = Describe("Validating Webhook", func() {
 	var vmiInformer cache.SharedIndexInformer
+	var vmInformer cache.SharedIndexInformer
 
 	BeforeSuite(func() {
 		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		vmInformer, _ = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, cache.Indexers{
+			macpool.MacAddressIndex: macpool.MacAddressIndexFunc,
+		})
 		webhooks.SetInformers(&webhooks.Informers{
 			VMIInformer: vmiInformer,
+			VMInformer:  vmInformer,
 		})
 	})
 })
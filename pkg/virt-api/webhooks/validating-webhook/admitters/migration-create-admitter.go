@@ -75,10 +75,21 @@ func (admitter *MigrationCreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1b
 		return webhookutils.ToAdmissionResponseError(fmt.Errorf("Cannot migrated VMI in finalized state."))
 	}
 
-	// Reject migration jobs for non-migratable VMIs
+	if len(migration.Spec.Volumes) > 0 {
+		if !admitter.ClusterConfig.VolumeMigrationEnabled() {
+			return webhookutils.ToAdmissionResponseError(fmt.Errorf("%s feature gate is not enabled in kubevirt-config", virtconfig.VolumeMigrationGate))
+		}
+		if causes := validateMigratedVolumes(k8sfield.NewPath("spec", "volumes"), migration.Spec.Volumes, vmi); len(causes) > 0 {
+			return webhookutils.ToAdmissionResponse(causes)
+		}
+	}
+
+	// Reject migration jobs for non-migratable VMIs. Disks on PVCs which are not shared can still be moved to other
+	// PVCs, the target pod fails to start if the remaining ones can not be mounted on another node.
 	for _, c := range vmi.Status.Conditions {
 		if c.Type == v1.VirtualMachineInstanceIsMigratable &&
-			c.Status == k8sv1.ConditionFalse {
+			c.Status == k8sv1.ConditionFalse &&
+			!(c.Reason == v1.VirtualMachineInstanceReasonDisksNotMigratable && len(migration.Spec.Volumes) > 0) {
 			errMsg := fmt.Errorf("Cannot migrate VMI, Reason: %s, Message: %s",
 				c.Reason, c.Message)
 			return webhookutils.ToAdmissionResponseError(errMsg)
@@ -95,6 +106,11 @@ func (admitter *MigrationCreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1b
 		return webhookutils.ToAdmissionResponseError(fmt.Errorf("in-flight migration detected. Active migration job (%s) is currently already in progress for VMI %s.", string(vmi.Status.MigrationState.MigrationUID), vmi.Name))
 	}
 
+	// The NBD exports of a running backup are served by the source domain only
+	if vmi.Status.Backup != nil && vmi.Status.Backup.Checkpoint != "" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("backup to checkpoint %s is running for VMI %s, it has to be stopped first", vmi.Status.Backup.Checkpoint, vmi.Name))
+	}
+
 	reviewResponse := v1beta1.AdmissionResponse{}
 	reviewResponse.Allowed = true
 	return &reviewResponse
@@ -138,5 +154,89 @@ func ValidateVirtualMachineInstanceMigrationSpec(field *k8sfield.Path, spec *v1.
 		})
 	}
 
+	volumeNames := map[string]bool{}
+	for idx, migratedVolume := range spec.Volumes {
+		if migratedVolume.VolumeName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("volumeName is missing"),
+				Field:   field.Child("volumes").Index(idx).Child("volumeName").String(),
+			})
+		} else if volumeNames[migratedVolume.VolumeName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("volume %s is migrated more than once", migratedVolume.VolumeName),
+				Field:   field.Child("volumes").Index(idx).Child("volumeName").String(),
+			})
+		}
+		volumeNames[migratedVolume.VolumeName] = true
+		if migratedVolume.DestinationClaimName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("destinationClaimName is missing"),
+				Field:   field.Child("volumes").Index(idx).Child("destinationClaimName").String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+// validateMigratedVolumes ensures that the migrated volumes are persistent PVC or DataVolume volumes of the VMI, which
+// are not copied to the claim they already use
+func validateMigratedVolumes(field *k8sfield.Path, migratedVolumes []v1.MigratedVolume, vmi *v1.VirtualMachineInstance) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	volumes := map[string]v1.Volume{}
+	for _, volume := range vmi.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	hotplugged := map[string]bool{}
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.HotplugVolume != nil {
+			hotplugged[volumeStatus.Name] = true
+		}
+	}
+
+	for idx, migratedVolume := range migratedVolumes {
+		volume, exists := volumes[migratedVolume.VolumeName]
+		if !exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %s does not exist in VMI %s", migratedVolume.VolumeName, vmi.Name),
+				Field:   field.Index(idx).Child("volumeName").String(),
+			})
+			continue
+		}
+
+		var claimName string
+		if volume.PersistentVolumeClaim != nil {
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		} else if volume.DataVolume != nil {
+			claimName = volume.DataVolume.Name
+		} else {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %s is not a PVC or DataVolume", volume.Name),
+				Field:   field.Index(idx).Child("volumeName").String(),
+			})
+			continue
+		}
+
+		if hotplugged[volume.Name] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("hotplugged volume %s can not be migrated", volume.Name),
+				Field:   field.Index(idx).Child("volumeName").String(),
+			})
+		} else if claimName == migratedVolume.DestinationClaimName {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %s already uses the claim %s", volume.Name, claimName),
+				Field:   field.Index(idx).Child("destinationClaimName").String(),
+			})
+		}
+	}
+
 	return causes
 }
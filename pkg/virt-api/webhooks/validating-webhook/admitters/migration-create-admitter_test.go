@@ -292,6 +292,134 @@ var _ = Describe("Validating MigrationCreate Admitter", func() {
 		Expect(resp.Result.Message).To(ContainSubstring("DisksNotLiveMigratable"))
 	})
 
+	It("should reject Migration spec for VMIs with a running backup", func() {
+		vmi := v1.NewMinimalVMI("testmigratevmi5")
+		vmi.Status.Phase = v1.Running
+		vmi.Status.Backup = &v1.VirtualMachineInstanceBackupStatus{
+			Checkpoints: []string{"backup-1", "backup-2"},
+			Checkpoint:  "backup-2",
+			Incremental: "backup-1",
+			Disks:       []string{"rootdisk"},
+		}
+
+		informers := webhooks.GetInformers()
+		informers.VMIInformer.GetIndexer().Add(vmi)
+
+		migration := v1.VirtualMachineInstanceMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: v1.VirtualMachineInstanceMigrationSpec{
+				VMIName: "testmigratevmi5",
+			},
+		}
+		migrationBytes, _ := json.Marshal(&migration)
+
+		enableFeatureGate(virtconfig.LiveMigrationGate)
+
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.MigrationGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: migrationBytes,
+				},
+			},
+		}
+
+		resp := migrationCreateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("backup to checkpoint backup-2 is running"))
+	})
+
+	Context("with migrated volumes", func() {
+		newVolumeMigrationVMI := func(name string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(name)
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "rootdisk",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "rwo-pvc"},
+					},
+				},
+				{
+					Name: "cloudinit",
+					VolumeSource: v1.VolumeSource{
+						CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+					},
+				},
+			}
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:    v1.VirtualMachineInstanceIsMigratable,
+					Status:  k8sv1.ConditionFalse,
+					Reason:  v1.VirtualMachineInstanceReasonDisksNotMigratable,
+					Message: "cannot migrate VMI: PVC rwo-pvc is not shared",
+				},
+			}
+			return vmi
+		}
+
+		admit := func(vmi *v1.VirtualMachineInstance, volumes []v1.MigratedVolume) *v1beta1.AdmissionResponse {
+			informers := webhooks.GetInformers()
+			informers.VMIInformer.GetIndexer().Add(vmi)
+
+			migration := v1.VirtualMachineInstanceMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: vmi.Namespace,
+				},
+				Spec: v1.VirtualMachineInstanceMigrationSpec{
+					VMIName: vmi.Name,
+					Volumes: volumes,
+				},
+			}
+			migrationBytes, _ := json.Marshal(&migration)
+
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.MigrationGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: migrationBytes,
+					},
+				},
+			}
+			return migrationCreateAdmitter.Admit(ar)
+		}
+
+		It("should accept to move disks on non shared PVCs", func() {
+			enableFeatureGate(virtconfig.LiveMigrationGate + "," + virtconfig.VolumeMigrationGate)
+
+			resp := admit(newVolumeMigrationVMI("testvolumemigration1"), []v1.MigratedVolume{
+				{VolumeName: "rootdisk", DestinationClaimName: "rwx-pvc"},
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject to move disks when the feature gate isn't enabled", func() {
+			enableFeatureGate(virtconfig.LiveMigrationGate)
+
+			resp := admit(newVolumeMigrationVMI("testvolumemigration2"), []v1.MigratedVolume{
+				{VolumeName: "rootdisk", DestinationClaimName: "rwx-pvc"},
+			})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring(virtconfig.VolumeMigrationGate))
+		})
+
+		table.DescribeTable("should reject invalid migrated volumes", func(migratedVolume v1.MigratedVolume, field string) {
+			enableFeatureGate(virtconfig.LiveMigrationGate + "," + virtconfig.VolumeMigrationGate)
+
+			resp := admit(newVolumeMigrationVMI("testvolumemigration3"), []v1.MigratedVolume{migratedVolume})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("without destination claim", v1.MigratedVolume{VolumeName: "rootdisk"}, "spec.volumes[0].destinationClaimName"),
+			table.Entry("with an unknown volume", v1.MigratedVolume{VolumeName: "unknown", DestinationClaimName: "rwx-pvc"}, "spec.volumes[0].volumeName"),
+			table.Entry("with a volume which is not a PVC", v1.MigratedVolume{VolumeName: "cloudinit", DestinationClaimName: "rwx-pvc"}, "spec.volumes[0].volumeName"),
+			table.Entry("with the claim used by the volume", v1.MigratedVolume{VolumeName: "rootdisk", DestinationClaimName: "rwo-pvc"}, "spec.volumes[0].destinationClaimName"),
+		)
+	})
+
 	table.DescribeTable("should reject documents containing unknown or missing fields for", func(data string, validationResult string, gvr metav1.GroupVersionResource, review func(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse) {
 		input := map[string]interface{}{}
 		json.Unmarshal([]byte(data), &input)
@@ -0,0 +1,133 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+)
+
+// StorageCheckupAdmitter validates StorageCheckups
+type StorageCheckupAdmitter struct {
+}
+
+// Admit validates an AdmissionReview
+func (admitter *StorageCheckupAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	if ar.Request.Resource.Group != checkupv1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "storagecheckups" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	checkup := &checkupv1.StorageCheckup{}
+	// TODO ideally use UniversalDeserializer here
+	err := json.Unmarshal(ar.Request.Object.Raw, checkup)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	var causes []metav1.StatusCause
+
+	switch ar.Request.Operation {
+	case v1beta1.Create:
+		causes = validateStorageCheckupSpec(k8sfield.NewPath("spec"), &checkup.Spec)
+	case v1beta1.Update:
+		prevObj := &checkupv1.StorageCheckup{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		if !reflect.DeepEqual(prevObj.Spec, checkup.Spec) {
+			causes = []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "spec in immutable after creation",
+					Field:   k8sfield.NewPath("spec").String(),
+				},
+			}
+		}
+	default:
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func validateStorageCheckupSpec(field *k8sfield.Path, spec *checkupv1.StorageCheckupSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if spec.StorageClassName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "missing storageClassName",
+			Field:   field.Child("storageClassName").String(),
+		})
+	}
+
+	if spec.VolumeMode != nil &&
+		*spec.VolumeMode != corev1.PersistentVolumeBlock && *spec.VolumeMode != corev1.PersistentVolumeFilesystem {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("volumeMode must be %s or %s", corev1.PersistentVolumeBlock, corev1.PersistentVolumeFilesystem),
+			Field:   field.Child("volumeMode").String(),
+		})
+	}
+
+	if spec.Size != nil && spec.Size.Sign() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "size must be positive",
+			Field:   field.Child("size").String(),
+		})
+	}
+
+	if spec.VolumeSnapshotClassName != nil && *spec.VolumeSnapshotClassName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "volumeSnapshotClassName must not be empty",
+			Field:   field.Child("volumeSnapshotClassName").String(),
+		})
+	}
+
+	if spec.Timeout != nil && spec.Timeout.Duration <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "timeout must be positive",
+			Field:   field.Child("timeout").String(),
+		})
+	}
+
+	return causes
+}
@@ -0,0 +1,159 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package admitters
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating StorageCheckup Admitter", func() {
+	admitter := &StorageCheckupAdmitter{}
+
+	newCheckup := func() *checkupv1.StorageCheckup {
+		volumeMode := corev1.PersistentVolumeBlock
+		size := resource.MustParse("2Gi")
+		return &checkupv1.StorageCheckup{
+			Spec: checkupv1.StorageCheckupSpec{
+				StorageClassName: "rook-ceph-block",
+				VolumeMode:       &volumeMode,
+				Size:             &size,
+				Timeout:          &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		}
+	}
+
+	It("should reject invalid request resource", func() {
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineGroupVersionResource,
+			},
+		}
+
+		resp := admitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+	})
+
+	It("should accept a valid checkup", func() {
+		resp := admitter.Admit(createStorageCheckupAdmissionReview(newCheckup(), nil))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should accept a checkup with only a storage class", func() {
+		checkup := &checkupv1.StorageCheckup{
+			Spec: checkupv1.StorageCheckupSpec{
+				StorageClassName: "rook-ceph-block",
+			},
+		}
+
+		resp := admitter.Admit(createStorageCheckupAdmissionReview(checkup, nil))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	table.DescribeTable("should reject an invalid spec", func(update func(*checkupv1.StorageCheckup), field string) {
+		checkup := newCheckup()
+		update(checkup)
+
+		resp := admitter.Admit(createStorageCheckupAdmissionReview(checkup, nil))
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+	},
+		table.Entry("with a missing storage class", func(checkup *checkupv1.StorageCheckup) {
+			checkup.Spec.StorageClassName = ""
+		}, "spec.storageClassName"),
+		table.Entry("with an unknown volume mode", func(checkup *checkupv1.StorageCheckup) {
+			volumeMode := corev1.PersistentVolumeMode("Object")
+			checkup.Spec.VolumeMode = &volumeMode
+		}, "spec.volumeMode"),
+		table.Entry("with a zero size", func(checkup *checkupv1.StorageCheckup) {
+			size := resource.MustParse("0")
+			checkup.Spec.Size = &size
+		}, "spec.size"),
+		table.Entry("with an empty volume snapshot class", func(checkup *checkupv1.StorageCheckup) {
+			volumeSnapshotClassName := ""
+			checkup.Spec.VolumeSnapshotClassName = &volumeSnapshotClassName
+		}, "spec.volumeSnapshotClassName"),
+		table.Entry("with a negative timeout", func(checkup *checkupv1.StorageCheckup) {
+			checkup.Spec.Timeout = &metav1.Duration{Duration: -time.Minute}
+		}, "spec.timeout"),
+	)
+
+	It("should reject a spec update", func() {
+		oldCheckup := newCheckup()
+		checkup := newCheckup()
+		checkup.Spec.StorageClassName = "local"
+
+		resp := admitter.Admit(createStorageCheckupAdmissionReview(checkup, oldCheckup))
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+	})
+
+	It("should accept a status update", func() {
+		oldCheckup := newCheckup()
+		checkup := newCheckup()
+		checkup.Status = &checkupv1.StorageCheckupStatus{Phase: checkupv1.Running}
+
+		resp := admitter.Admit(createStorageCheckupAdmissionReview(checkup, oldCheckup))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+})
+
+func createStorageCheckupAdmissionReview(checkup, oldCheckup *checkupv1.StorageCheckup) *v1beta1.AdmissionReview {
+	bytes, _ := json.Marshal(checkup)
+
+	ar := &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Create,
+			Namespace: "default",
+			Resource: metav1.GroupVersionResource{
+				Group:    "checkup.kubevirt.io",
+				Resource: "storagecheckups",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+
+	if oldCheckup != nil {
+		oldBytes, _ := json.Marshal(oldCheckup)
+		ar.Request.Operation = v1beta1.Update
+		ar.Request.OldObject = runtime.RawExtension{
+			Raw: oldBytes,
+		}
+	}
+
+	return ar
+}
@@ -0,0 +1,169 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+)
+
+// VMCloneAdmitter validates VirtualMachineClones
+type VMCloneAdmitter struct {
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMCloneAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	if ar.Request.Resource.Group != clonev1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "virtualmachineclones" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	vmClone := &clonev1.VirtualMachineClone{}
+	// TODO ideally use UniversalDeserializer here
+	err := json.Unmarshal(ar.Request.Object.Raw, vmClone)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	var causes []metav1.StatusCause
+
+	switch ar.Request.Operation {
+	case v1beta1.Create:
+		causes = validateCloneSpec(k8sfield.NewPath("spec"), &vmClone.Spec)
+	case v1beta1.Update:
+		prevObj := &clonev1.VirtualMachineClone{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		if !reflect.DeepEqual(prevObj.Spec, vmClone.Spec) {
+			causes = []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "spec in immutable after creation",
+					Field:   k8sfield.NewPath("spec").String(),
+				},
+			}
+		}
+	default:
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func validateCloneSpec(field *k8sfield.Path, spec *clonev1.VirtualMachineCloneSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	sourceField := field.Child("source")
+	if spec.Source.APIGroup == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotFound,
+			Message: "missing apiGroup",
+			Field:   sourceField.Child("apiGroup").String(),
+		})
+	} else {
+		switch {
+		case *spec.Source.APIGroup == v1.GroupName && spec.Source.Kind == "VirtualMachine":
+		case *spec.Source.APIGroup == snapshotv1.SchemeGroupVersion.Group && spec.Source.Kind == "VirtualMachineSnapshot":
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("unsupported source %s/%s", *spec.Source.APIGroup, spec.Source.Kind),
+				Field:   sourceField.Child("kind").String(),
+			})
+		}
+	}
+
+	if spec.Source.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "missing name",
+			Field:   sourceField.Child("name").String(),
+		})
+	}
+
+	if spec.Target != nil {
+		targetField := field.Child("target")
+		if spec.Target.APIGroup != nil && *spec.Target.APIGroup != v1.GroupName {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "invalid apiGroup",
+				Field:   targetField.Child("apiGroup").String(),
+			})
+		}
+		if spec.Target.Kind != "" && spec.Target.Kind != "VirtualMachine" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "invalid kind",
+				Field:   targetField.Child("kind").String(),
+			})
+		}
+	}
+
+	causes = append(causes, validateCloneFilters(field.Child("labelFilters"), spec.LabelFilters)...)
+	causes = append(causes, validateCloneFilters(field.Child("annotationFilters"), spec.AnnotationFilters)...)
+
+	for name, mac := range spec.NewMacAddresses {
+		if _, err := net.ParseMAC(mac); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid mac address %q", mac),
+				Field:   field.Child("newMacAddresses").Key(name).String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+func validateCloneFilters(field *k8sfield.Path, filters []string) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for i, filter := range filters {
+		if strings.TrimPrefix(filter, "!") == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "empty filter",
+				Field:   field.Index(i).String(),
+			})
+		}
+	}
+	return causes
+}
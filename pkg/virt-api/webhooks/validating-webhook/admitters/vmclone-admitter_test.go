@@ -0,0 +1,144 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating VirtualMachineClone Admitter", func() {
+	vmAPIGroup := "kubevirt.io"
+	snapshotAPIGroup := "snapshot.kubevirt.io"
+
+	admitter := &VMCloneAdmitter{}
+
+	newClone := func() *clonev1.VirtualMachineClone {
+		return &clonev1.VirtualMachineClone{
+			Spec: clonev1.VirtualMachineCloneSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: &vmAPIGroup,
+					Kind:     "VirtualMachine",
+					Name:     "vm",
+				},
+			},
+		}
+	}
+
+	It("should reject invalid request resource", func() {
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineGroupVersionResource,
+			},
+		}
+
+		resp := admitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+	})
+
+	It("should accept a VirtualMachine source", func() {
+		resp := admitter.Admit(createCloneAdmissionReview(newClone()))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should accept a VirtualMachineSnapshot source", func() {
+		vmClone := newClone()
+		vmClone.Spec.Source.APIGroup = &snapshotAPIGroup
+		vmClone.Spec.Source.Kind = "VirtualMachineSnapshot"
+
+		resp := admitter.Admit(createCloneAdmissionReview(vmClone))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	table.DescribeTable("should reject an invalid spec", func(update func(*clonev1.VirtualMachineClone), field string) {
+		vmClone := newClone()
+		update(vmClone)
+
+		resp := admitter.Admit(createCloneAdmissionReview(vmClone))
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+	},
+		table.Entry("with a missing source apiGroup", func(vmClone *clonev1.VirtualMachineClone) {
+			vmClone.Spec.Source.APIGroup = nil
+		}, "spec.source.apiGroup"),
+		table.Entry("with an unsupported source kind", func(vmClone *clonev1.VirtualMachineClone) {
+			vmClone.Spec.Source.Kind = "VirtualMachineInstance"
+		}, "spec.source.kind"),
+		table.Entry("with a missing source name", func(vmClone *clonev1.VirtualMachineClone) {
+			vmClone.Spec.Source.Name = ""
+		}, "spec.source.name"),
+		table.Entry("with an unsupported target kind", func(vmClone *clonev1.VirtualMachineClone) {
+			vmClone.Spec.Target = &corev1.TypedLocalObjectReference{Kind: "Pod", Name: "target"}
+		}, "spec.target.kind"),
+		table.Entry("with an empty label filter", func(vmClone *clonev1.VirtualMachineClone) {
+			vmClone.Spec.LabelFilters = []string{"*", "!"}
+		}, "spec.labelFilters[1]"),
+		table.Entry("with an invalid mac address", func(vmClone *clonev1.VirtualMachineClone) {
+			vmClone.Spec.NewMacAddresses = map[string]string{"default": "not-a-mac"}
+		}, "spec.newMacAddresses[default]"),
+	)
+
+	It("should reject spec update", func() {
+		oldClone := newClone()
+		vmClone := newClone()
+		vmClone.Spec.Source.Name = "other"
+
+		oldBytes, _ := json.Marshal(oldClone)
+		ar := createCloneAdmissionReview(vmClone)
+		ar.Request.Operation = v1beta1.Update
+		ar.Request.OldObject = runtime.RawExtension{Raw: oldBytes}
+
+		resp := admitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+	})
+})
+
+func createCloneAdmissionReview(vmClone *clonev1.VirtualMachineClone) *v1beta1.AdmissionReview {
+	bytes, _ := json.Marshal(vmClone)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Create,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "clone.kubevirt.io",
+				Resource: "virtualmachineclones",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
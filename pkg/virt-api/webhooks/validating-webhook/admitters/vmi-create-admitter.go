@@ -23,6 +23,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net"
+	"path"
 	"regexp"
 	"strings"
 
@@ -34,8 +35,11 @@ import (
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	"kubevirt.io/kubevirt/pkg/util"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/util/net/istio"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -56,11 +60,23 @@ const (
 	maxDNSNameservers     = 3
 	maxDNSSearchPaths     = 6
 	maxDNSSearchListChars = 256
+
+	// virtio-mem plugs memory in blocks of 2Mi
+	memoryHotplugBlockSize = 2 * 1024 * 1024
+
+	// the default serial console occupies the first of the four ISA serial ports
+	maxSerialPorts = 3
+
+	guestAgentChannelName = "org.qemu.guest_agent.0"
+
+	// the minimal IPv4 MTU, see RFC 791
+	minimumDHCPMTU = 68
 )
 
 var validInterfaceModels = map[string]*struct{}{"e1000": nil, "e1000e": nil, "ne2k_pci": nil, "pcnet": nil, "rtl8139": nil, "virtio": nil}
 var validIOThreadsPolicies = []v1.IOThreadsPolicy{v1.IOThreadsPolicyShared, v1.IOThreadsPolicyAuto}
 var validCPUFeaturePolicies = map[string]*struct{}{"": nil, "force": nil, "require": nil, "optional": nil, "disable": nil, "forbid": nil}
+var supportedArchitectures = []string{"amd64", "arm64", "ppc64le", "s390x"}
 
 var restriectedVmiLabels = map[string]bool{
 	v1.CreatedByLabel:               true,
@@ -95,6 +111,12 @@ func (admitter *VMICreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, admitter.ClusterConfig)
 	causes = append(causes, ValidateVirtualMachineInstanceMandatoryFields(k8sfield.NewPath("spec"), &vmi.Spec)...)
 	causes = append(causes, ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, admitter.ClusterConfig, accountName)...)
+	causes = append(causes, ValidateServiceMeshCompatibility(k8sfield.NewPath("spec"), &vmi.ObjectMeta, &vmi.Spec)...)
+	namespace := vmi.Namespace
+	if namespace == "" {
+		namespace = ar.Request.Namespace
+	}
+	causes = append(causes, ValidateNetworkAttachmentPolicy(k8sfield.NewPath("spec"), namespace, &vmi.Spec, admitter.ClusterConfig)...)
 	// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
 	causes = append(causes, webhooks.ValidateVirtualMachineInstanceHypervFeatureDependencies(k8sfield.NewPath("spec"), &vmi.Spec)...)
 
@@ -128,6 +150,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateMemoryLimitsNegativeOrNull(field, spec)...)
 	causes = append(causes, validateHugepagesMemoryRequests(field, spec)...)
 	causes = append(causes, validateGuestMemoryLimit(field, spec)...)
+	causes = append(causes, validateArchitecture(field, spec)...)
 	causes = append(causes, validateEmulatedMachine(field, spec, config)...)
 	causes = append(causes, validateFirmwareSerial(field, spec)...)
 	causes = append(causes, validateCPURequestNotNegative(field, spec)...)
@@ -135,6 +158,24 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateCpuRequestDoesNotExceedLimit(field, spec)...)
 	causes = append(causes, validateCpuPinning(field, spec)...)
 	causes = append(causes, validateCPUIsolatorThread(field, spec)...)
+	causes = append(causes, validateCPUHotplug(field, spec, config)...)
+	causes = append(causes, validateMemoryHotplug(field, spec, config)...)
+	causes = append(causes, validateNUMA(field, spec, config)...)
+	causes = append(causes, validateRealtime(field, spec, config)...)
+	causes = append(causes, validateTPM(field, spec, config)...)
+	causes = append(causes, validatePersistentEFI(field, spec, config)...)
+	causes = append(causes, validateVSOCK(field, spec, config)...)
+	causes = append(causes, validatePersistentReservation(field, spec, config)...)
+	causes = append(causes, validateExpandGuestFilesystem(field, spec, config)...)
+	causes = append(causes, validateWatchdog(field, spec)...)
+	causes = append(causes, validatePanicMemoryDump(field, spec)...)
+	causes = append(causes, validateMemoryDump(field, spec)...)
+	causes = append(causes, validateSwapDisks(field, spec)...)
+	causes = append(causes, validateSerialConsoleLog(field, spec)...)
+	causes = append(causes, validateSerialPorts(field, spec)...)
+	causes = append(causes, validateChannels(field, spec)...)
+	causes = append(causes, validateIgnition(field, spec, config)...)
+	causes = append(causes, validateLaunchSecurity(field, spec, config)...)
 	causes = append(causes, validateCPUFeaturePolicies(field, spec)...)
 
 	maxNumberOfInterfacesExceeded := len(spec.Domain.Devices.Interfaces) > arrayLenMax
@@ -173,8 +214,8 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 
 	causes = append(causes, validateInputDevices(field, spec)...)
 	causes = append(causes, validateIOThreadsPolicy(field, spec)...)
-	causes = append(causes, validateReadinessProbe(field, spec)...)
-	causes = append(causes, validateLivenessProbe(field, spec)...)
+	causes = append(causes, validateProbe(field.Child("readinessProbe"), spec.ReadinessProbe)...)
+	causes = append(causes, validateProbe(field.Child("livenessProbe"), spec.LivenessProbe)...)
 
 	if getNumberOfPodInterfaces(spec) < 1 {
 		causes = appendStatusCauseForLivenessProbeNotAllowedWithNoPodNetworkPresent(field, spec, causes)
@@ -228,6 +269,12 @@ func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachi
 		causes = append(causes, validateMacAddress(field, iface, idx)...)
 		causes = append(causes, validateInterfaceBootOrder(field, iface, idx, bootOrderMap)...)
 		causes = append(causes, validateInterfacePciAddress(field, iface, idx)...)
+		causes = append(causes, validateInterfaceState(field, iface, idx)...)
+		causes = append(causes, validateInterfaceBandwidth(field, iface, idx)...)
+		causes = append(causes, validateInterfaceRSS(field, iface, idx, vifMQ)...)
+		causes = append(causes, validateInterfaceOffloads(field, iface, idx)...)
+		causes = append(causes, validateInterfaceFirewall(field, iface, idx)...)
+		causes = append(causes, validateInterfaceSRIOVFailover(field, spec.Domain.Devices.Interfaces, iface, idx)...)
 
 		newCauses, newDone := validateDHCPExtraOptions(field, iface)
 		causes = append(causes, newCauses...)
@@ -241,6 +288,8 @@ func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachi
 		}
 
 		causes = append(causes, validateDHCPNTPServersAreValidIPv4Addresses(field, iface, idx)...)
+		causes = append(causes, validateDHCPMTU(field, iface, idx)...)
+		causes = append(causes, validateDHCPRoutes(field, iface, idx)...)
 	}
 	return networkInterfaceMap, vifMQ, isVirtioNicRequested, causes, done
 }
@@ -260,8 +309,35 @@ func validateInterfaceNetworkBasics(field *k8sfield.Path, networkExists bool, id
 		causes = appendStatusCauseForMacvtapFeatureGateNotEnabled(field, causes, idx)
 	} else if iface.InterfaceBindingMethod.Macvtap != nil && networkData.NetworkSource.Multus == nil {
 		causes = appendStatusCauseForMacvtapOnlyAllowedWithMultus(field, causes, idx)
-	}
-	return causes
+	} else if iface.InterfaceBindingMethod.Passt != nil && !config.PasstEnabled() {
+		causes = appendStatusCauseForPasstFeatureGateNotEnabled(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.Passt != nil && networkData.NetworkSource.Pod == nil {
+		causes = appendStatusCauseForPasstWithoutPodNetwork(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.Vdpa != nil && !config.VDPAEnabled() {
+		causes = appendStatusCauseForVdpaFeatureGateNotEnabled(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.Vdpa != nil && networkData.NetworkSource.Multus == nil {
+		causes = appendStatusCauseForVdpaOnlyAllowedWithMultus(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.Vdpa != nil && iface.Model != "" && iface.Model != "virtio" {
+		causes = appendStatusCauseForVdpaWithoutVirtioModel(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.VhostUser != nil && !config.VhostUserEnabled() {
+		causes = appendStatusCauseForVhostUserFeatureGateNotEnabled(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.VhostUser != nil && networkData.NetworkSource.Multus == nil {
+		causes = appendStatusCauseForVhostUserOnlyAllowedWithMultus(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.VhostUser != nil && iface.Model != "" && iface.Model != "virtio" {
+		causes = appendStatusCauseForVhostUserWithoutVirtioModel(field, causes, idx)
+	} else if iface.Binding != nil && !config.NetworkBindingPluginsEnabled() {
+		causes = appendStatusCauseForBindingPluginsFeatureGateNotEnabled(field, causes, idx)
+	} else if iface.Binding != nil && iface.InterfaceBindingMethod != (v1.InterfaceBindingMethod{}) {
+		causes = appendStatusCauseForBindingPluginWithBindingMethod(field, causes, idx)
+	} else if iface.Binding != nil && !isBindingPluginRegistered(iface.Binding.Name, config) {
+		causes = appendStatusCauseForBindingPluginNotRegistered(field, causes, idx, iface.Binding.Name)
+	}
+	return causes
+}
+
+func isBindingPluginRegistered(name string, config *virtconfig.ClusterConfig) bool {
+	_, exists := config.GetNetworkBindings()[name]
+	return exists
 }
 
 func validateDHCPExtraOptions(field *k8sfield.Path, iface v1.Interface) (causes []metav1.StatusCause, done bool) {
@@ -295,6 +371,40 @@ func validateDHCPNTPServersAreValidIPv4Addresses(field *k8sfield.Path, iface v1.
 	return causes
 }
 
+func validateDHCPMTU(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.DHCPOptions != nil && iface.DHCPOptions.MTU != nil && *iface.DHCPOptions.MTU < minimumDHCPMTU {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("DHCP MTU must be at least %d.", minimumDHCPMTU),
+			Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions", "mtu").String(),
+		})
+	}
+	return causes
+}
+
+func validateDHCPRoutes(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.DHCPOptions != nil {
+		for index, route := range iface.DHCPOptions.Routes {
+			routeField := field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions", "routes").Index(index)
+			if _, dst, err := net.ParseCIDR(route.Destination); err != nil || dst.IP.To4() == nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "DHCP route destination must be a valid IPv4 CIDR.",
+					Field:   routeField.Child("destination").String(),
+				})
+			}
+			if net.ParseIP(route.Gateway).To4() == nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "DHCP route gateway must be a valid IPv4 address.",
+					Field:   routeField.Child("gateway").String(),
+				})
+			}
+		}
+	}
+	return causes
+}
+
 func validateDHCPPrivateOptionsWithinRange(field *k8sfield.Path, DHCPPrivateOption v1.DHCPPrivateOptions) (causes []metav1.StatusCause) {
 	if !(DHCPPrivateOption.Option >= 224 && DHCPPrivateOption.Option <= 254) {
 		causes = append(causes, metav1.StatusCause{
@@ -329,6 +439,266 @@ func validateInterfacePciAddress(field *k8sfield.Path, iface v1.Interface, idx i
 	return causes
 }
 
+func validateInterfaceState(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	switch iface.State {
+	case "", v1.InterfaceStateAbsent:
+	case v1.InterfaceStateLinkUp, v1.InterfaceStateLinkDown:
+		if iface.SRIOV != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("interface %s can not set its link state with the SR-IOV binding.", field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String()),
+				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("state").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("interface %s has an unsupported state (%s).", field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(), iface.State),
+			Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("state").String(),
+		})
+	}
+	return causes
+}
+
+func validateInterfaceBandwidth(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.Bandwidth == nil {
+		return causes
+	}
+	bandwidthField := field.Child("domain", "devices", "interfaces").Index(idx).Child("bandwidth")
+	if iface.Bridge == nil && iface.Masquerade == nil && iface.Macvtap == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "bandwidth limits are only supported with the bridge, masquerade and macvtap bindings",
+			Field:   bandwidthField.String(),
+		})
+	}
+	causes = append(causes, validateBandwidthLimit(bandwidthField.Child("inbound"), iface.Bandwidth.Inbound)...)
+	causes = append(causes, validateBandwidthLimit(bandwidthField.Child("outbound"), iface.Bandwidth.Outbound)...)
+	return causes
+}
+
+func validateInterfaceRSS(field *k8sfield.Path, iface v1.Interface, idx int, vifMQ *bool) (causes []metav1.StatusCause) {
+	if iface.RSS == nil {
+		return causes
+	}
+	rssField := field.Child("domain", "devices", "interfaces").Index(idx).Child("rss")
+	if iface.Bridge == nil && iface.Masquerade == nil && iface.Macvtap == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "RSS is only supported with the bridge, masquerade and macvtap bindings",
+			Field:   rssField.String(),
+		})
+	}
+	if iface.Model != "" && iface.Model != "virtio" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "RSS is only supported with the virtio model",
+			Field:   rssField.String(),
+		})
+	}
+	if vifMQ == nil || !*vifMQ {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "RSS requires networkInterfaceMultiqueue",
+			Field:   rssField.String(),
+		})
+	}
+	return causes
+}
+
+func validateInterfaceOffloads(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	offloads := iface.Offloads
+	if offloads == nil {
+		return causes
+	}
+	offloadsField := field.Child("domain", "devices", "interfaces").Index(idx).Child("offloads")
+	if iface.Bridge == nil && iface.Masquerade == nil && iface.Macvtap == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "Offloads are only supported with the bridge, masquerade and macvtap bindings",
+			Field:   offloadsField.String(),
+		})
+	}
+	if iface.Model != "" && iface.Model != "virtio" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "Offloads are only supported with the virtio model",
+			Field:   offloadsField.String(),
+		})
+	}
+	if offloads.Checksum != nil && !*offloads.Checksum {
+		if offloads.TSO != nil && *offloads.TSO {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "TSO can't be enabled without the checksum offload",
+				Field:   offloadsField.Child("tso").String(),
+			})
+		}
+		if offloads.GSO != nil && *offloads.GSO {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "GSO can't be enabled without the checksum offload",
+				Field:   offloadsField.Child("gso").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateInterfaceFirewall(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	firewall := iface.Firewall
+	if firewall == nil {
+		return causes
+	}
+	firewallField := field.Child("domain", "devices", "interfaces").Index(idx).Child("firewall")
+	if iface.Bridge == nil && iface.Masquerade == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "firewall rules are only supported with the bridge and masquerade bindings",
+			Field:   firewallField.String(),
+		})
+	}
+	if firewall.DefaultAction != "" {
+		causes = append(causes, validateFirewallAction(firewallField.Child("defaultAction"), firewall.DefaultAction)...)
+	}
+	for ruleIdx, rule := range firewall.Rules {
+		causes = append(causes, validateFirewallRule(firewallField.Child("rules").Index(ruleIdx), rule)...)
+	}
+	return causes
+}
+
+func validateInterfaceSRIOVFailover(field *k8sfield.Path, ifaces []v1.Interface, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.SRIOV == nil || iface.SRIOV.Failover == "" {
+		return causes
+	}
+	failoverField := field.Child("domain", "devices", "interfaces").Index(idx).Child("sriov", "failover")
+	var failover *v1.Interface
+	for i := range ifaces {
+		if ifaces[i].Name == iface.SRIOV.Failover {
+			failover = &ifaces[i]
+		} else if i != idx && ifaces[i].SRIOV != nil && ifaces[i].SRIOV.Failover == iface.SRIOV.Failover {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("interface %s is the failover of more than one SR-IOV interface", iface.SRIOV.Failover),
+				Field:   failoverField.String(),
+			})
+		}
+	}
+	if failover == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s names interface %s which does not exist", failoverField.String(), iface.SRIOV.Failover),
+			Field:   failoverField.String(),
+		})
+	}
+	if failover.Bridge == nil && failover.Macvtap == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "the failover of an SR-IOV interface must use the bridge or the macvtap binding",
+			Field:   failoverField.String(),
+		})
+	}
+	if failover.Model != "" && failover.Model != "virtio" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "the failover of an SR-IOV interface must use the virtio model",
+			Field:   failoverField.String(),
+		})
+	}
+	if iface.MacAddress == "" || !strings.EqualFold(iface.MacAddress, failover.MacAddress) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("interface %s and its failover %s must have the same MAC address", iface.Name, failover.Name),
+			Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("macAddress").String(),
+		})
+	}
+	return causes
+}
+
+func validateFirewallRule(field *k8sfield.Path, rule v1.FirewallRule) (causes []metav1.StatusCause) {
+	causes = append(causes, validateFirewallAction(field.Child("action"), rule.Action)...)
+	if rule.Direction != "" && rule.Direction != v1.FirewallDirectionIngress && rule.Direction != v1.FirewallDirectionEgress {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be %s or %s", field.Child("direction").String(), v1.FirewallDirectionIngress, v1.FirewallDirectionEgress),
+			Field:   field.Child("direction").String(),
+		})
+	}
+	if rule.CIDR != "" {
+		if _, _, err := net.ParseCIDR(rule.CIDR); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not a valid CIDR", field.Child("cidr").String()),
+				Field:   field.Child("cidr").String(),
+			})
+		}
+	}
+	switch rule.Protocol {
+	case "", "ICMP":
+		if rule.Port != 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires the TCP, UDP or SCTP protocol", field.Child("port").String()),
+				Field:   field.Child("port").String(),
+			})
+		}
+	case "TCP", "UDP", "SCTP":
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be TCP, UDP, SCTP or ICMP", field.Child("protocol").String()),
+			Field:   field.Child("protocol").String(),
+		})
+	}
+	if rule.Port < 0 || rule.Port > 65535 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be between 1 and 65535", field.Child("port").String()),
+			Field:   field.Child("port").String(),
+		})
+	}
+	return causes
+}
+
+func validateFirewallAction(field *k8sfield.Path, action v1.FirewallAction) (causes []metav1.StatusCause) {
+	if action != v1.FirewallActionAllow && action != v1.FirewallActionDeny {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be %s or %s", field.String(), v1.FirewallActionAllow, v1.FirewallActionDeny),
+			Field:   field.String(),
+		})
+	}
+	return causes
+}
+
+func validateBandwidthLimit(field *k8sfield.Path, limit *v1.BandwidthLimit) (causes []metav1.StatusCause) {
+	if limit == nil {
+		return causes
+	}
+	if limit.Average.Sign() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than 0", field.Child("average").String()),
+			Field:   field.Child("average").String(),
+		})
+	}
+	if limit.Peak != nil && limit.Peak.Cmp(limit.Average) < 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be lower than the average rate", field.Child("peak").String()),
+			Field:   field.Child("peak").String(),
+		})
+	}
+	if limit.Burst != nil && limit.Burst.Sign() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than 0", field.Child("burst").String()),
+			Field:   field.Child("burst").String(),
+		})
+	}
+	return causes
+}
+
 func validateInterfaceBootOrder(field *k8sfield.Path, iface v1.Interface, idx int, bootOrderMap map[uint]bool) (causes []metav1.StatusCause) {
 	if iface.BootOrder != nil {
 		order := *iface.BootOrder
@@ -395,7 +765,7 @@ func validatePortConfiguration(field *k8sfield.Path, networkExists bool, network
 		for portIdx, forwardPort := range iface.Ports {
 			causes = append(causes, validateForwardPortNonZero(field, forwardPort, idx, portIdx)...)
 			causes = append(causes, validateForwardPortInRange(field, forwardPort, idx, portIdx)...)
-			causes = append(causes, validateForwardPortProtocol(field, forwardPort, idx, portIdx)...)
+			causes = append(causes, validateForwardPortProtocol(field, iface, forwardPort, idx, portIdx)...)
 			causes = append(causes, validateForwardPortName(field, forwardPort, portForwardMap, idx, portIdx)...)
 		}
 	}
@@ -425,12 +795,18 @@ func validateForwardPortName(field *k8sfield.Path, forwardPort v1.Port, portForw
 	return causes
 }
 
-func validateForwardPortProtocol(field *k8sfield.Path, forwardPort v1.Port, idx int, portIdx int) (causes []metav1.StatusCause) {
+func validateForwardPortProtocol(field *k8sfield.Path, iface v1.Interface, forwardPort v1.Port, idx int, portIdx int) (causes []metav1.StatusCause) {
 	if forwardPort.Protocol != "" {
-		if forwardPort.Protocol != "TCP" && forwardPort.Protocol != "UDP" {
+		if forwardPort.Protocol != "TCP" && forwardPort.Protocol != "UDP" && forwardPort.Protocol != "SCTP" {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: "Unknown protocol, only TCP or UDP allowed",
+				Message: "Unknown protocol, only TCP, UDP or SCTP allowed",
+				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("ports").Index(portIdx).Child("protocol").String(),
+			})
+		} else if forwardPort.Protocol == "SCTP" && iface.Passt != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "Passt interface can only forward TCP or UDP ports",
 				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("ports").Index(portIdx).Child("protocol").String(),
 			})
 		}
@@ -480,6 +856,105 @@ func appendStatusCauseForMacvtapFeatureGateNotEnabled(field *k8sfield.Path, caus
 	return causes
 }
 
+func appendStatusCauseForPasstFeatureGateNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "Passt feature gate is not enabled",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForVdpaFeatureGateNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "VDPA feature gate is not enabled",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForVdpaOnlyAllowedWithMultus(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "vDPA interface only implemented with Multus network",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForVdpaWithoutVirtioModel(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "vDPA interface only supports the virtio model",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("model").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForVhostUserFeatureGateNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "VhostUser feature gate is not enabled",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForVhostUserOnlyAllowedWithMultus(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "vhost-user interface only implemented with Multus network",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForVhostUserWithoutVirtioModel(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "vhost-user interface only supports the virtio model",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("model").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForBindingPluginsFeatureGateNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "NetworkBindingPlugins feature gate is not enabled",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("binding").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForBindingPluginWithBindingMethod(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "binding plugin and binding method are mutually exclusive",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("binding").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForBindingPluginNotRegistered(field *k8sfield.Path, causes []metav1.StatusCause, idx int, name string) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("binding plugin %s is not registered in the KubeVirt CR", name),
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("binding", "name").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForPasstWithoutPodNetwork(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "Passt interface only implemented with pod network",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
 func appendStatusCauseForBridgeNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
 	causes = append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
@@ -621,57 +1096,87 @@ func validateIOThreadsPolicy(field *k8sfield.Path, spec *v1.VirtualMachineInstan
 			})
 		}
 	}
-	return causes
-}
 
-func validateReadinessProbe(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
-	if spec.ReadinessProbe != nil {
-		if spec.ReadinessProbe.HTTPGet != nil && spec.ReadinessProbe.TCPSocket != nil {
-			causes = append(causes, metav1.StatusCause{
-				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s must have exactly one probe type set", field.Child("readinessProbe").String()),
-				Field:   field.Child("readinessProbe").String(),
-			})
-		} else if spec.ReadinessProbe.HTTPGet == nil && spec.ReadinessProbe.TCPSocket == nil {
-			causes = append(causes, metav1.StatusCause{
-				Type: metav1.CauseTypeFieldValueRequired,
-				Message: fmt.Sprintf("either %s or %s must be set if a %s is specified",
-					field.Child("readinessProbe", "tcpSocket").String(),
-					field.Child("readinessProbe", "httpGet").String(),
-					field.Child("readinessProbe").String(),
-				),
-				Field: field.Child("readinessProbe").String(),
+	ioThreads := spec.Domain.IOThreads
+	if ioThreads == nil {
+		return causes
+	}
+	ioThreadsField := field.Child("domain", "ioThreads")
+	if spec.Domain.IOThreadsPolicy == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires %s", ioThreadsField.String(), field.Child("domain", "ioThreadsPolicy").String()),
+			Field:   ioThreadsField.String(),
+		})
+	}
+	if ioThreads.SharedPoolSize != nil && *ioThreads.SharedPoolSize == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than 0", ioThreadsField.Child("sharedPoolSize").String()),
+			Field:   ioThreadsField.Child("sharedPoolSize").String(),
+		})
+	}
+	switch ioThreads.DedicatedPinning {
+	case "", v1.DedicatedIOThreadsPinningEmulator:
+	case v1.DedicatedIOThreadsPinningVCPUs:
+		if spec.Domain.CPU == nil || !spec.Domain.CPU.DedicatedCPUPlacement || !spec.Domain.CPU.IsolateEmulatorThread {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires dedicated CPUs with an isolated emulator thread", ioThreadsField.Child("dedicatedPinning").String()),
+				Field:   ioThreadsField.Child("dedicatedPinning").String(),
 			})
 		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is not supported. Supported values are: emulator, vcpus.", ioThreadsField.Child("dedicatedPinning").String()),
+			Field:   ioThreadsField.Child("dedicatedPinning").String(),
+		})
 	}
 	return causes
 }
 
-func validateLivenessProbe(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
-	if spec.LivenessProbe != nil {
-		if spec.LivenessProbe.HTTPGet != nil && spec.LivenessProbe.TCPSocket != nil {
-			causes = append(causes, metav1.StatusCause{
-				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s must have exactly one probe type set", field.Child("livenessProbe").String()),
-				Field:   field.Child("livenessProbe").String(),
-			})
-		} else if spec.LivenessProbe.HTTPGet == nil && spec.LivenessProbe.TCPSocket == nil {
-			causes = append(causes, metav1.StatusCause{
-				Type: metav1.CauseTypeFieldValueRequired,
-				Message: fmt.Sprintf("either %s or %s must be set if a %s is specified",
-					field.Child("livenessProbe", "tcpSocket").String(),
-					field.Child("livenessProbe", "httpGet").String(),
-					field.Child("livenessProbe").String(),
-				),
-				Field: field.Child("livenessProbe").String(),
-			})
+func validateProbe(field *k8sfield.Path, probe *v1.Probe) (causes []metav1.StatusCause) {
+	if probe == nil {
+		return causes
+	}
+
+	numHandlers := 0
+	for _, set := range []bool{probe.HTTPGet != nil, probe.TCPSocket != nil, probe.Exec != nil, probe.GuestAgentPing != nil} {
+		if set {
+			numHandlers++
 		}
 	}
+
+	if numHandlers > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must have exactly one probe type set", field.String()),
+			Field:   field.String(),
+		})
+	} else if numHandlers < 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("either %s, %s, %s or %s must be set if a %s is specified",
+				field.Child("tcpSocket").String(),
+				field.Child("exec").String(),
+				field.Child("guestAgentPing").String(),
+				field.Child("httpGet").String(),
+				field.String(),
+			),
+			Field: field.String(),
+		})
+	}
 	return causes
 }
 
+// isNetworkProbe tells whether the probe reaches the guest through the pod network
+func isNetworkProbe(probe *v1.Probe) bool {
+	return probe != nil && (probe.HTTPGet != nil || probe.TCPSocket != nil)
+}
+
 func appendStatusCauseForReadinessProbeNotAllowedWithNoPodNetworkPresent(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, causes []metav1.StatusCause) []metav1.StatusCause {
-	if spec.ReadinessProbe != nil {
+	if isNetworkProbe(spec.ReadinessProbe) {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
 			Message: fmt.Sprintf("%s is only allowed if the Pod Network is attached", field.Child("readinessProbe").String()),
@@ -682,7 +1187,7 @@ func appendStatusCauseForReadinessProbeNotAllowedWithNoPodNetworkPresent(field *
 }
 
 func appendStatusCauseForLivenessProbeNotAllowedWithNoPodNetworkPresent(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, causes []metav1.StatusCause) []metav1.StatusCause {
-	if spec.LivenessProbe != nil {
+	if isNetworkProbe(spec.LivenessProbe) {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
 			Message: fmt.Sprintf("%s is only allowed if the Pod Network is attached", field.Child("livenessProbe").String()),
@@ -731,6 +1236,61 @@ func validateFilesystemsWithVirtIOFSEnabled(field *k8sfield.Path, spec *v1.Virtu
 			Field:   field.Child("Filesystems").String(),
 		})
 	}
+
+	volumes := map[string]*v1.Volume{}
+	for i := range spec.Volumes {
+		volumes[spec.Volumes[i].Name] = &spec.Volumes[i]
+	}
+	for idx, fs := range spec.Domain.Devices.Filesystems {
+		volume, exists := volumes[fs.Name]
+		if !exists {
+			continue
+		}
+		if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil && volume.ConfigMap == nil &&
+			volume.Secret == nil && volume.DownwardAPI == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("volume %s of filesystem %s must be a PersistentVolumeClaim, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume", volume.Name, fs.Name),
+				Field:   field.Child("domain", "devices", "filesystems").Index(idx).Child("name").String(),
+			})
+		}
+		if fs.Virtiofs != nil {
+			causes = append(causes, validateVirtiofs(field.Child("domain", "devices", "filesystems").Index(idx).Child("virtiofs"), fs.Virtiofs, volume)...)
+		}
+	}
+	return causes
+}
+
+func validateVirtiofs(field *k8sfield.Path, virtiofs *v1.FilesystemVirtiofs, volume *v1.Volume) (causes []metav1.StatusCause) {
+	if virtiofs.SubPath != "" {
+		if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s is only supported for PersistentVolumeClaim and DataVolume volumes", field.Child("subPath").String()),
+				Field:   field.Child("subPath").String(),
+			})
+		}
+		if subPath := path.Clean(virtiofs.SubPath); path.IsAbs(subPath) || subPath == ".." || strings.HasPrefix(subPath, "../") {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be a relative path within the volume", field.Child("subPath").String()),
+				Field:   field.Child("subPath").String(),
+			})
+		}
+	}
+	causes = append(causes, validateFilesystemIDMap(field.Child("uidMap"), virtiofs.UIDMap)...)
+	causes = append(causes, validateFilesystemIDMap(field.Child("gidMap"), virtiofs.GIDMap)...)
+	return causes
+}
+
+func validateFilesystemIDMap(field *k8sfield.Path, idMap *v1.FilesystemIDMap) (causes []metav1.StatusCause) {
+	if idMap != nil && idMap.Count == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than 0", field.Child("count").String()),
+			Field:   field.Child("count").String(),
+		})
+	}
 	return causes
 }
 
@@ -755,6 +1315,9 @@ func validatePermittedHostDevices(field *k8sfield.Path, spec *v1.VirtualMachineI
 		for _, dev := range hostDevs.MediatedDevices {
 			supportedHostDevicesMap[dev.ResourceName] = true
 		}
+		for _, dev := range hostDevs.USB {
+			supportedHostDevicesMap[dev.ResourceName] = true
+		}
 		for _, hostDev := range spec.Domain.Devices.GPUs {
 			if _, exist := supportedHostDevicesMap[hostDev.DeviceName]; !exist {
 				causes = append(causes, metav1.StatusCause{
@@ -807,6 +1370,7 @@ func validateNetworks(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec,
 		if network.Pod != nil {
 			cniTypesCount++
 			podExists = true
+			causes = append(causes, validatePodNetworkIPv6CIDR(field, network.Pod, idx)...)
 		}
 
 		if network.NetworkSource.Multus != nil {
@@ -815,6 +1379,7 @@ func validateNetworks(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec,
 			if network.NetworkSource.Multus.Default {
 				multusDefaultCount++
 			}
+			causes = append(causes, validateMultusPersistentIPs(field, spec, network, idx)...)
 		}
 
 		causes = validateNetworkHasOnlyOneType(field, cniTypesCount, causes, idx)
@@ -828,6 +1393,32 @@ func validateNetworks(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec,
 	return podExists, multusDefaultCount, causes
 }
 
+// validateMultusPersistentIPs allows persistent IPs only on the secondary networks of bridge interfaces, since
+// only there the guest takes the addresses which the IPAM allocates to the pod.
+func validateMultusPersistentIPs(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, network v1.Network, idx int) (causes []metav1.StatusCause) {
+	if !network.Multus.PersistentIPs {
+		return causes
+	}
+	persistentIPsField := field.Child("networks").Index(idx).Child("multus", "persistentIPs").String()
+	if network.Multus.Default {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "persistent IPs are not supported on the multus default network",
+			Field:   persistentIPsField,
+		})
+	}
+	for _, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Name == network.Name && iface.Bridge == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("persistent IPs are only supported on bridge interfaces, %s is not one", iface.Name),
+				Field:   persistentIPsField,
+			})
+		}
+	}
+	return causes
+}
+
 func appendStatusCauseForCNIPluginHasNoNetworkName(field *k8sfield.Path, incomingCauses []metav1.StatusCause, idx int) (causes []metav1.StatusCause) {
 	causes = append(incomingCauses, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueRequired,
@@ -837,6 +1428,21 @@ func appendStatusCauseForCNIPluginHasNoNetworkName(field *k8sfield.Path, incomin
 	return causes
 }
 
+func validatePodNetworkIPv6CIDR(field *k8sfield.Path, podNetwork *v1.PodNetwork, idx int) (causes []metav1.StatusCause) {
+	if podNetwork.VMIPv6NetworkCIDR == "" {
+		return causes
+	}
+	ip, _, err := net.ParseCIDR(podNetwork.VMIPv6NetworkCIDR)
+	if err != nil || ip.To4() != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is not a valid IPv6 CIDR", podNetwork.VMIPv6NetworkCIDR),
+			Field:   field.Child("networks").Index(idx).Child("pod", "vmIPv6NetworkCIDR").String(),
+		})
+	}
+	return causes
+}
+
 func validateNetworkHasOnlyOneType(field *k8sfield.Path, cniTypesCount int, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
 	if cniTypesCount == 0 {
 		causes = append(causes, metav1.StatusCause{
@@ -854,6 +1460,16 @@ func validateNetworkHasOnlyOneType(field *k8sfield.Path, cniTypesCount int, caus
 	return causes
 }
 
+// isCDRomOnly returns true if the disk is a CD-ROM and has no other target set.
+func isCDRomOnly(disk v1.Disk) bool {
+	return disk.CDRom != nil && disk.Disk == nil && disk.LUN == nil && disk.Floppy == nil
+}
+
+// isEncryptableVolume returns true if qemu opens the image of the volume directly, so that it can decrypt it.
+func isEncryptableVolume(volume *v1.Volume) bool {
+	return volume.PersistentVolumeClaim != nil || volume.DataVolume != nil || volume.HostDisk != nil
+}
+
 func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, volumeNameMap map[string]*v1.Volume) (bootOrderMap map[uint]bool, causes []metav1.StatusCause) {
 	// used to validate uniqueness of boot orders among disks and interfaces
 	bootOrderMap = make(map[uint]bool)
@@ -868,7 +1484,8 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 
 		matchingVolume, volumeExists := volumeNameMap[disk.Name]
 
-		if !volumeExists {
+		// A CD-ROM without a volume is an empty drive, media can be inserted into it later on
+		if !volumeExists && !isCDRomOnly(disk) {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf(nameOfTypeNotFoundMessagePattern, field.Child("domain", "devices", "disks").Index(idx).Child("Name").String(), disk.Name),
@@ -876,6 +1493,24 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 			})
 		}
 
+		// Verify Sysprep volumes are attached where Windows setup looks for the answer file.
+		if volumeExists && matchingVolume.Sysprep != nil && disk.CDRom == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be a CD-ROM because it is mapped to a Sysprep volume.", field.Child("domain", "devices", "disks").Index(idx).String()),
+				Field:   field.Child("domain", "devices", "disks").Index(idx).Child("cdrom").String(),
+			})
+		}
+
+		// Verify the downward metrics disk is a virtio disk.
+		if volumeExists && matchingVolume.DownwardMetrics != nil && (disk.Disk == nil || disk.Disk.Bus != "virtio") {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be a virtio disk because it is mapped to a DownwardMetrics volume.", field.Child("domain", "devices", "disks").Index(idx).String()),
+				Field:   field.Child("domain", "devices", "disks").Index(idx).String(),
+			})
+		}
+
 		// Verify Lun disks are only mapped to network/block devices.
 		if disk.LUN != nil && volumeExists && matchingVolume.PersistentVolumeClaim == nil {
 			causes = append(causes, metav1.StatusCause{
@@ -885,6 +1520,15 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 			})
 		}
 
+		// Verify encrypted disks are mapped to volumes qemu opens directly.
+		if disk.Encryption != nil && volumeExists && !isEncryptableVolume(matchingVolume) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s can only be mapped to a PersistentVolumeClaim, DataVolume or HostDisk volume.", field.Child("domain", "devices", "disks").Index(idx).Child("encryption").String()),
+				Field:   field.Child("domain", "devices", "disks").Index(idx).Child("encryption").String(),
+			})
+		}
+
 		// verify that there are no duplicate boot orders
 		if disk.BootOrder != nil {
 			order := *disk.BootOrder
@@ -925,29 +1569,627 @@ func appendStatusCauseForMaxNumberOfInterfacesExceeded(field *k8sfield.Path, cau
 	})
 }
 
-func validateCPUFeaturePolicies(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
-	if spec.Domain.CPU != nil && spec.Domain.CPU.Features != nil {
-		for idx, feature := range spec.Domain.CPU.Features {
-			if _, exists := validCPUFeaturePolicies[feature.Policy]; !exists {
+func validateCPUFeaturePolicies(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU != nil && spec.Domain.CPU.Features != nil {
+		for idx, feature := range spec.Domain.CPU.Features {
+			if _, exists := validCPUFeaturePolicies[feature.Policy]; !exists {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("CPU feature %s uses policy %s that is not supported.", feature.Name, feature.Policy),
+					Field:   field.Child("domain", "cpu", "features").Index(idx).Child("policy").String(),
+				})
+			}
+		}
+	}
+	return causes
+}
+
+func validateCPUIsolatorThread(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU != nil && spec.Domain.CPU.IsolateEmulatorThread && !spec.Domain.CPU.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("IsolateEmulatorThread should be only set in combination with DedicatedCPUPlacement"),
+			Field:   field.Child("domain", "cpu", "isolateEmulatorThread").String(),
+		})
+	}
+	return causes
+}
+
+func validateCPUHotplug(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU == nil || spec.Domain.CPU.MaxSockets == 0 {
+		return causes
+	}
+
+	maxSocketsField := field.Child("domain", "cpu", "maxSockets").String()
+	if !config.CPUHotplugEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.CPUHotplugGate),
+			Field:   maxSocketsField,
+		})
+	}
+	if spec.Domain.CPU.MaxSockets < spec.Domain.CPU.Sockets {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be smaller than %s",
+				maxSocketsField,
+				field.Child("domain", "cpu", "sockets").String(),
+			),
+			Field: maxSocketsField,
+		})
+	}
+	if spec.Domain.CPU.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is not supported in combination with DedicatedCPUPlacement", maxSocketsField),
+			Field:   maxSocketsField,
+		})
+	}
+	return causes
+}
+
+func validateMemoryHotplug(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.Memory == nil || spec.Domain.Memory.MaxGuest == nil {
+		return causes
+	}
+
+	maxGuestField := field.Child("domain", "memory", "maxGuest").String()
+	if !config.MemoryHotplugEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.MemoryHotplugGate),
+			Field:   maxGuestField,
+		})
+	}
+
+	guest := spec.Domain.Memory.Guest
+	if guest == nil {
+		if limit, ok := spec.Domain.Resources.Limits[k8sv1.ResourceMemory]; ok {
+			guest = &limit
+		} else {
+			request := spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+			guest = &request
+		}
+	}
+	if spec.Domain.Memory.MaxGuest.Cmp(*guest) < 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be smaller than the guest memory",
+				maxGuestField,
+			),
+			Field: maxGuestField,
+		})
+	}
+	if spec.Domain.Memory.MaxGuest.Value()%memoryHotplugBlockSize != 0 || guest.Value()%memoryHotplugBlockSize != 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s and the guest memory must be aligned to %d bytes", maxGuestField, memoryHotplugBlockSize),
+			Field:   maxGuestField,
+		})
+	}
+	if spec.Domain.Memory.Hugepages != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is not supported in combination with hugepages", maxGuestField),
+			Field:   maxGuestField,
+		})
+	}
+	return causes
+}
+
+func validateTPM(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	tpm := spec.Domain.Devices.TPM
+	if tpm == nil || tpm.Persistent == nil || !*tpm.Persistent {
+		return causes
+	}
+	if !config.VMPersistentStateEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.VMPersistentStateGate),
+			Field:   field.Child("domain", "devices", "tpm", "persistent").String(),
+		})
+	}
+	return causes
+}
+
+func validatePersistentEFI(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	firmware := spec.Domain.Firmware
+	if firmware == nil || firmware.Bootloader == nil || firmware.Bootloader.EFI == nil {
+		return causes
+	}
+	persistent := firmware.Bootloader.EFI.Persistent
+	if persistent == nil || !*persistent {
+		return causes
+	}
+	if !config.VMPersistentStateEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.VMPersistentStateGate),
+			Field:   field.Child("domain", "firmware", "bootloader", "efi", "persistent").String(),
+		})
+	}
+	return causes
+}
+
+func validateNUMA(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU == nil || spec.Domain.CPU.NUMA == nil || spec.Domain.CPU.NUMA.GuestMappingPassthrough == nil {
+		return causes
+	}
+
+	passthroughField := field.Child("domain", "cpu", "numa", "guestMappingPassthrough").String()
+	if !config.NUMAEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.NUMAFeatureGate),
+			Field:   passthroughField,
+		})
+	}
+	if !spec.Domain.CPU.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be set in combination with DedicatedCPUPlacement", passthroughField),
+			Field:   passthroughField,
+		})
+	}
+	if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be set in combination with hugepages", passthroughField),
+			Field:   passthroughField,
+		})
+	}
+	return causes
+}
+
+func validateRealtime(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU == nil || spec.Domain.CPU.Realtime == nil {
+		return causes
+	}
+
+	realtimeField := field.Child("domain", "cpu", "realtime")
+	if !config.VMRealtimeEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.VMRealtimeGate),
+			Field:   realtimeField.String(),
+		})
+	}
+	if !spec.Domain.CPU.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be set in combination with DedicatedCPUPlacement", realtimeField.String()),
+			Field:   realtimeField.String(),
+		})
+	}
+	mask := spec.Domain.CPU.Realtime.Mask
+	if mask == "" {
+		return causes
+	}
+	vcpus, err := hwutil.ParseCPUMask(mask)
+	if err != nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is not a valid vCPU mask: %v", mask, err),
+			Field:   realtimeField.Child("mask").String(),
+		})
+	}
+	// the number of vCPUs is only known here if the topology is given explicitly
+	if count := hwutil.GetNumberOfVCPUs(spec.Domain.CPU); count > 0 {
+		for _, vcpu := range vcpus {
+			if int64(vcpu) >= count {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("vCPU %d of the mask %s does not exist, the VMI has %d vCPUs", vcpu, mask, count),
+					Field:   realtimeField.Child("mask").String(),
+				})
+				break
+			}
+		}
+	}
+	return causes
+}
+
+func validateVSOCK(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	autoattach := spec.Domain.Devices.AutoattachVSOCK
+	if autoattach == nil || !*autoattach {
+		return causes
+	}
+	if !config.VSOCKEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.VSOCKGate),
+			Field:   field.Child("domain", "devices", "autoattachVSOCK").String(),
+		})
+	}
+	return causes
+}
+
+func validatePersistentReservation(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.LUN == nil || !disk.LUN.Reservation {
+			continue
+		}
+		if !config.PersistentReservationEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.PersistentReservationGate),
+				Field:   field.Child("domain", "devices", "disks").Index(idx).Child("lun", "reservation").String(),
+			})
+		}
+		if disk.LUN.Bus != "scsi" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("persistent reservations of disk %s require the scsi bus", disk.Name),
+				Field:   field.Child("domain", "devices", "disks").Index(idx).Child("lun", "bus").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateExpandGuestFilesystem(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if config.ExpandDisksEnabled() {
+		return nil
+	}
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.ExpandGuestFilesystem != nil && *disk.ExpandGuestFilesystem {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.ExpandDisksGate),
+				Field:   field.Child("domain", "devices", "disks").Index(idx).Child("expandGuestFilesystem").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateWatchdog(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	watchdog := spec.Domain.Devices.Watchdog
+	if watchdog == nil {
+		return causes
+	}
+	if watchdog.I6300ESB != nil && watchdog.Diag288 != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "only one watchdog device type can be set",
+			Field:   field.Child("domain", "devices", "watchdog").String(),
+		})
+	}
+	return causes
+}
+
+func validatePanicMemoryDump(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	dump := spec.Domain.Devices.PanicMemoryDump
+	if dump == nil {
+		return causes
+	}
+	if dump.ClaimName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "a claimName is required to store memory dumps of panicked guests",
+			Field:   field.Child("domain", "devices", "panicMemoryDump", "claimName").String(),
+		})
+	}
+	autoattach := spec.Domain.Devices.AutoattachPanicDevice
+	if autoattach != nil && !*autoattach {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "memory dumps of panicked guests require the panic device",
+			Field:   field.Child("domain", "devices", "autoattachPanicDevice").String(),
+		})
+	}
+	return causes
+}
+
+func validateMemoryDump(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	dump := spec.Domain.Devices.MemoryDump
+	if dump != nil && dump.ClaimName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "a claimName is required to store memory dumps",
+			Field:   field.Child("domain", "devices", "memoryDump", "claimName").String(),
+		})
+	}
+	return causes
+}
+
+// validateSwapDisks checks that swap disks are attached as disks to a guest which is told to use them through cloud-init
+func validateSwapDisks(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	hasCloudInit := false
+	for _, volume := range spec.Volumes {
+		if volume.CloudInitNoCloud != nil || volume.CloudInitConfigDrive != nil {
+			hasCloudInit = true
+		}
+	}
+
+	for idx, volume := range spec.Volumes {
+		if volume.SwapDisk == nil {
+			continue
+		}
+		if volume.SwapDisk.Capacity.Sign() <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "the capacity of a swap disk must be greater than zero",
+				Field:   field.Child("volumes").Index(idx).Child("swapDisk", "capacity").String(),
+			})
+		}
+		if !hasCloudInit {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "swap disks are enabled through cloud-init, a cloudInitNoCloud or cloudInitConfigDrive volume is required",
+				Field:   field.Child("volumes").Index(idx).Child("swapDisk").String(),
+			})
+		}
+		for diskIdx, disk := range spec.Domain.Devices.Disks {
+			if disk.Name == volume.Name && disk.Disk == nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("swap disk %s must be attached as a disk", volume.Name),
+					Field:   field.Child("domain", "devices", "disks").Index(diskIdx).String(),
+				})
+			}
+		}
+	}
+	return causes
+}
+
+func validateSerialConsoleLog(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	devices := spec.Domain.Devices
+	logSerialConsole := devices.LogSerialConsole != nil && *devices.LogSerialConsole
+	if logSerialConsole && devices.AutoattachSerialConsole != nil && !*devices.AutoattachSerialConsole {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "logging the serial console requires the serial console",
+			Field:   field.Child("domain", "devices", "autoattachSerialConsole").String(),
+		})
+	}
+
+	consoleLog := devices.SerialConsoleLog
+	if consoleLog == nil {
+		return causes
+	}
+	if !logSerialConsole {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "storing the serial console log requires logSerialConsole to be enabled",
+			Field:   field.Child("domain", "devices", "logSerialConsole").String(),
+		})
+	}
+	if consoleLog.ClaimName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "a claimName is required to store the serial console log",
+			Field:   field.Child("domain", "devices", "serialConsoleLog", "claimName").String(),
+		})
+	}
+	if consoleLog.MaxFileSize != nil && consoleLog.MaxFileSize.Value() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "the maximum size of the serial console log files must be positive",
+			Field:   field.Child("domain", "devices", "serialConsoleLog", "maxFileSize").String(),
+		})
+	}
+	if consoleLog.MaxFiles != nil && *consoleLog.MaxFiles < 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "at least one serial console log file has to be kept",
+			Field:   field.Child("domain", "devices", "serialConsoleLog", "maxFiles").String(),
+		})
+	}
+	return causes
+}
+
+func validateSerialPorts(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	serials := spec.Domain.Devices.Serials
+	if len(serials) > maxSerialPorts {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("domain", "devices", "serials").String(), maxSerialPorts),
+			Field:   field.Child("domain", "devices", "serials").String(),
+		})
+		return causes
+	}
+	names := map[string]bool{}
+	for idx, serial := range serials {
+		causes = append(causes, validateDeviceName(field.Child("domain", "devices", "serials").Index(idx).Child("name"), serial.Name, names)...)
+	}
+	return causes
+}
+
+func validateChannels(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	names := map[string]bool{}
+	targetNames := map[string]bool{}
+	for idx, channel := range spec.Domain.Devices.Channels {
+		channelField := field.Child("domain", "devices", "channels").Index(idx)
+		causes = append(causes, validateDeviceName(channelField.Child("name"), channel.Name, names)...)
+		switch {
+		case channel.TargetName == "":
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "a targetName is required for the channel",
+				Field:   channelField.Child("targetName").String(),
+			})
+		case channel.TargetName == guestAgentChannelName:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is reserved for the guest agent", guestAgentChannelName),
+				Field:   channelField.Child("targetName").String(),
+			})
+		case targetNames[channel.TargetName]:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("targetName %s is used by more than one channel", channel.TargetName),
+				Field:   channelField.Child("targetName").String(),
+			})
+		}
+		targetNames[channel.TargetName] = true
+	}
+	return causes
+}
+
+func validateIgnition(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	source := spec.Ignition
+	if source == nil {
+		return causes
+	}
+	if !config.IgnitionEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.IgnitionGate),
+			Field:   field.Child("ignition").String(),
+		})
+	}
+	sourceCount := 0
+	if source.SecretRef != nil {
+		sourceCount++
+		if source.SecretRef.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "a name is required for the Ignition Secret",
+				Field:   field.Child("ignition", "secretRef", "name").String(),
+			})
+		}
+	}
+	if source.ConfigMapRef != nil {
+		sourceCount++
+		if source.ConfigMapRef.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "a name is required for the Ignition ConfigMap",
+				Field:   field.Child("ignition", "configMapRef", "name").String(),
+			})
+		}
+	}
+	if sourceCount != 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Ignition must have exactly one of secretRef or configMapRef set",
+			Field:   field.Child("ignition").String(),
+		})
+	}
+	return causes
+}
+
+func validateDeviceName(field *k8sfield.Path, name string, names map[string]bool) (causes []metav1.StatusCause) {
+	for _, msg := range validation.IsDNS1123Label(name) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: msg,
+			Field:   field.String(),
+		})
+	}
+	if names[name] {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueDuplicate,
+			Message: fmt.Sprintf("name %s is used by more than one device", name),
+			Field:   field.String(),
+		})
+	}
+	names[name] = true
+	return causes
+}
+
+func validateLaunchSecurity(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	launchSecurity := spec.Domain.LaunchSecurity
+	if launchSecurity == nil {
+		return causes
+	}
+	launchSecurityField := field.Child("domain", "launchSecurity")
+
+	var technologies []string
+	var featureGate string
+	var featureGateEnabled bool
+	if launchSecurity.SEV != nil {
+		technologies = append(technologies, "SEV")
+		featureGate, featureGateEnabled = virtconfig.WorkloadEncryptionSEV, config.WorkloadEncryptionSEVEnabled()
+	}
+	if launchSecurity.SNP != nil {
+		technologies = append(technologies, "SEV-SNP")
+		featureGate, featureGateEnabled = virtconfig.WorkloadEncryptionSEV, config.WorkloadEncryptionSEVEnabled()
+	}
+	if launchSecurity.TDX != nil {
+		technologies = append(technologies, "TDX")
+		featureGate, featureGateEnabled = virtconfig.WorkloadEncryptionTDX, config.WorkloadEncryptionTDXEnabled()
+	}
+	if len(technologies) == 0 {
+		return causes
+	}
+	if len(technologies) > 1 {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must set only one of sev, snp and tdx", launchSecurityField.String()),
+			Field:   launchSecurityField.String(),
+		})
+	}
+	technology := technologies[0]
+
+	if !featureGateEnabled {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", featureGate),
+			Field:   launchSecurityField.String(),
+		})
+	}
+
+	firmware := spec.Domain.Firmware
+	if firmware == nil || firmware.Bootloader == nil || firmware.Bootloader.EFI == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires OVMF (UEFI)", technology),
+			Field:   launchSecurityField.String(),
+		})
+	} else if firmware.Bootloader.EFI.SecureBoot == nil || *firmware.Bootloader.EFI.SecureBoot {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s does not work along with SMM which is required by SecureBoot", technology),
+			Field:   launchSecurityField.String(),
+		})
+	}
+
+	if spec.EvictionStrategy != nil && *spec.EvictionStrategy == v1.EvictionStrategyLiveMigrate {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s does not support live migration", technology),
+			Field:   field.Child("evictionStrategy").String(),
+		})
+	}
+
+	if spec.Domain.Devices.AutoattachMemBalloon != nil && *spec.Domain.Devices.AutoattachMemBalloon {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s does not support memory ballooning", technology),
+			Field:   field.Child("domain", "devices", "autoattachMemBalloon").String(),
+		})
+	}
+
+	// the devices can not access the private memory of SNP and TDX guests
+	if launchSecurity.SEV == nil {
+		devicesField := field.Child("domain", "devices")
+		if len(spec.Domain.Devices.GPUs) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s does not support GPU passthrough", technology),
+				Field:   devicesField.Child("gpus").String(),
+			})
+		}
+		if len(spec.Domain.Devices.HostDevices) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s does not support host device passthrough", technology),
+				Field:   devicesField.Child("hostDevices").String(),
+			})
+		}
+		for idx, iface := range spec.Domain.Devices.Interfaces {
+			if iface.SRIOV != nil {
 				causes = append(causes, metav1.StatusCause{
-					Type:    metav1.CauseTypeFieldValueNotSupported,
-					Message: fmt.Sprintf("CPU feature %s uses policy %s that is not supported.", feature.Name, feature.Policy),
-					Field:   field.Child("domain", "cpu", "features").Index(idx).Child("policy").String(),
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s does not support SR-IOV interfaces", technology),
+					Field:   devicesField.Child("interfaces").Index(idx).Child("sriov").String(),
 				})
 			}
 		}
 	}
-	return causes
-}
 
-func validateCPUIsolatorThread(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
-	if spec.Domain.CPU != nil && spec.Domain.CPU.IsolateEmulatorThread && !spec.Domain.CPU.DedicatedCPUPlacement {
-		causes = append(causes, metav1.StatusCause{
-			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf("IsolateEmulatorThread should be only set in combination with DedicatedCPUPlacement"),
-			Field:   field.Child("domain", "cpu", "isolateEmulatorThread").String(),
-		})
-	}
 	return causes
 }
 
@@ -1124,10 +2366,66 @@ func validateFirmwareSerial(field *k8sfield.Path, spec *v1.VirtualMachineInstanc
 	return causes
 }
 
+// validateArchitecture checks that the guest architecture is supported along with the requested firmware
+func validateArchitecture(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	if spec.Architecture == "" {
+		return causes
+	}
+	supported := false
+	for _, arch := range supportedArchitectures {
+		if spec.Architecture == arch {
+			supported = true
+		}
+	}
+	if !supported {
+		return append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is not supported: %s (allowed values: %v)",
+				field.Child("architecture").String(),
+				spec.Architecture,
+				supportedArchitectures,
+			),
+			Field: field.Child("architecture").String(),
+		})
+	}
+
+	if spec.Domain.Firmware == nil || spec.Domain.Firmware.Bootloader == nil {
+		return causes
+	}
+	bootloader := spec.Domain.Firmware.Bootloader
+	bootloaderField := field.Child("domain", "firmware", "bootloader")
+	switch spec.Architecture {
+	case "arm64":
+		if bootloader.BIOS != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not supported on arm64, guests boot with EFI only", bootloaderField.Child("bios").String()),
+				Field:   bootloaderField.Child("bios").String(),
+			})
+		}
+		if bootloader.EFI != nil && (bootloader.EFI.SecureBoot == nil || *bootloader.EFI.SecureBoot) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not supported on arm64", bootloaderField.Child("efi", "secureBoot").String()),
+				Field:   bootloaderField.Child("efi", "secureBoot").String(),
+			})
+		}
+	case "s390x":
+		if bootloader.EFI != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not supported on s390x", bootloaderField.Child("efi").String()),
+				Field:   bootloaderField.Child("efi").String(),
+			})
+		}
+	}
+	return causes
+}
+
 func validateEmulatedMachine(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
 	if len(spec.Domain.Machine.Type) > 0 {
 		machine := spec.Domain.Machine.Type
-		supportedMachines := config.GetEmulatedMachines()
+		supportedMachines := config.GetEmulatedMachines(spec.Architecture)
 		var match = false
 		for _, val := range supportedMachines {
 			if regexp.MustCompile(val).MatchString(machine) {
@@ -1363,9 +2661,130 @@ func ValidateVirtualMachineInstanceMetadata(field *k8sfield.Path, metadata *meta
 		})
 	}
 
+	// Validate the backup hooks, which run on the guest when its filesystems are frozen and thawed
+	for _, annotation := range []string{v1.PreBackupHookAnnotation, v1.PostBackupHookAnnotation} {
+		if _, err := util.GetBackupHook(annotations, annotation); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: err.Error(),
+				Field:   field.Child("annotations", annotation).String(),
+			})
+		}
+	}
+	if _, err := util.GetBackupHookTimeout(annotations); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: err.Error(),
+			Field:   field.Child("annotations", v1.BackupHookTimeoutAnnotation).String(),
+		})
+	}
+
+	return causes
+}
+
+// ValidateServiceMeshCompatibility rejects the network configurations which can't work with the istio proxy,
+// when it is injected into the pod of the VMI.
+func ValidateServiceMeshCompatibility(field *k8sfield.Path, metadata *metav1.ObjectMeta, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if !istio.ProxyInjectionRequested(metadata.Annotations) {
+		return causes
+	}
+
+	podNetworkName := ""
+	for _, network := range spec.Networks {
+		if network.Pod != nil {
+			podNetworkName = network.Name
+		}
+	}
+
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Name != podNetworkName {
+			continue
+		}
+		if iface.Masquerade == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "the istio proxy is only compatible with the masquerade binding on the pod network",
+				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+			})
+		}
+		for portIdx, port := range iface.Ports {
+			if istio.IsReservedPort(port.Port) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("port %d is reserved for the istio proxy", port.Port),
+					Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("ports").Index(portIdx).Child("port").String(),
+				})
+			}
+		}
+	}
+	return causes
+}
+
+// ValidateNetworkAttachmentPolicy rejects the Multus networks referencing a NetworkAttachmentDefinition which the
+// attachment policy doesn't allow in the namespace of the VMI.
+func ValidateNetworkAttachmentPolicy(field *k8sfield.Path, namespace string, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	policy := config.GetNetworkAttachmentPolicy()
+	if policy == nil {
+		return causes
+	}
+
+	for idx, network := range spec.Networks {
+		if network.Multus == nil {
+			continue
+		}
+		nadNamespace, nadName := splitNetworkAttachmentReference(namespace, network.Multus.NetworkName)
+		if !networkAttachmentAllowed(policy, namespace, nadNamespace, nadName) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("the NetworkAttachmentDefinition %s/%s is not allowed in the namespace %s", nadNamespace, nadName, namespace),
+				Field:   field.Child("networks").Index(idx).Child("multus", "networkName").String(),
+			})
+		}
+	}
 	return causes
 }
 
+func networkAttachmentAllowed(policy *v1.NetworkAttachmentPolicy, namespace, nadNamespace, nadName string) bool {
+	for _, rule := range policy.Rules {
+		if !matchesAnyPattern(rule.Namespaces, namespace) {
+			continue
+		}
+		for _, allowed := range rule.NetworkAttachmentDefinitions {
+			allowedNamespace, allowedName := splitNetworkAttachmentReference(namespace, allowed)
+			if matchesPattern(allowedNamespace, nadNamespace) && matchesPattern(allowedName, nadName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitNetworkAttachmentReference splits a <namespace>/<name> reference, a reference without namespace refers to
+// the default namespace
+func splitNetworkAttachmentReference(defaultNamespace, reference string) (string, string) {
+	if parts := strings.SplitN(reference, "/", 2); len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return defaultNamespace, reference
+}
+
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchesPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern reports whether the name matches the shell pattern, malformed patterns never match
+func matchesPattern(pattern, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
 func ValidateDuplicateDHCPPrivateOptions(PrivateOptions []v1.DHCPPrivateOptions) error {
 	isUnique := map[int]bool{}
 	for _, DHCPPrivateOption := range PrivateOptions {
@@ -1485,6 +2904,51 @@ func validateBootloader(field *k8sfield.Path, bootloader *v1.Bootloader) []metav
 		})
 	}
 
+	if bootloader != nil && bootloader.EFI != nil {
+		causes = append(causes, validateSecureBootKeys(field.Child("efi"), bootloader.EFI)...)
+	}
+
+	return causes
+}
+
+func validateSecureBootKeys(field *k8sfield.Path, efi *v1.EFI) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	keys := efi.SecureBootKeys
+	if keys == nil {
+		return causes
+	}
+
+	if efi.SecureBoot != nil && !*efi.SecureBoot {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires SecureBoot to be enabled.", field.Child("secureBootKeys").String()),
+			Field:   field.Child("secureBootKeys").String(),
+		})
+	}
+
+	if keys.PK == nil && keys.KEK == nil && keys.DB == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must reference at least one of pk, kek or db.", field.Child("secureBootKeys").String()),
+			Field:   field.Child("secureBootKeys").String(),
+		})
+	}
+
+	sources := []struct {
+		name   string
+		source *v1.SecureBootKeySource
+	}{{"pk", keys.PK}, {"kek", keys.KEK}, {"db", keys.DB}}
+	for _, s := range sources {
+		if s.source != nil && s.source.SecretName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must not be empty.", field.Child("secureBootKeys", s.name, "secretName").String()),
+				Field:   field.Child("secureBootKeys", s.name, "secretName").String(),
+			})
+		}
+	}
+
 	return causes
 }
 
@@ -1530,11 +2994,14 @@ func validateAccessCredentials(field *k8sfield.Path, accessCredentials []v1.Acce
 		return causes
 	}
 
+	hasNoCloudVolume := false
 	hasConfigDriveVolume := false
 	for _, volume := range volumes {
+		if volume.CloudInitNoCloud != nil {
+			hasNoCloudVolume = true
+		}
 		if volume.CloudInitConfigDrive != nil {
 			hasConfigDriveVolume = true
-			break
 		}
 	}
 
@@ -1551,6 +3018,16 @@ func validateAccessCredentials(field *k8sfield.Path, accessCredentials []v1.Acce
 				sourceCount++
 			}
 
+			if accessCred.SSHPublicKey.PropagationMethod.NoCloud != nil {
+				methodCount++
+				if !hasNoCloudVolume {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("%s requires a noCloud volume to exist when the noCloud propagationMethod is in use.", field.Index(idx).String()),
+						Field:   field.Index(idx).Child("sshPublicKey", "propagationMethod").String(),
+					})
+				}
+			}
 			if accessCred.SSHPublicKey.PropagationMethod.ConfigDrive != nil {
 				methodCount++
 				if !hasConfigDriveVolume {
@@ -1649,6 +3126,8 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 
 	// check that we have max 1 serviceAccount volume
 	serviceAccountVolumeCount := 0
+	// check that we have max 1 downwardMetrics volume
+	downwardMetricVolumeCount := 0
 
 	for idx, volume := range volumes {
 		// verify name is unique
@@ -1686,6 +3165,9 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 		if volume.EmptyDisk != nil {
 			volumeSourceSetCount++
 		}
+		if volume.SwapDisk != nil {
+			volumeSourceSetCount++
+		}
 		if volume.HostDisk != nil {
 			volumeSourceSetCount++
 		}
@@ -1720,6 +3202,17 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			volumeSourceSetCount++
 			serviceAccountVolumeCount++
 		}
+		if volume.DownwardMetrics != nil {
+			if !config.DownwardMetricsEnabled() {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.DownwardMetricsFeatureGate),
+					Field:   field.Index(idx).Child("downwardMetrics").String(),
+				})
+			}
+			volumeSourceSetCount++
+			downwardMetricVolumeCount++
+		}
 
 		if volumeSourceSetCount != 1 {
 			causes = append(causes, metav1.StatusCause{
@@ -1733,6 +3226,7 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 		if volume.CloudInitNoCloud != nil || volume.CloudInitConfigDrive != nil {
 			var userDataSecretRef, networkDataSecretRef *k8sv1.LocalObjectReference
 			var dataSourceType, userData, userDataBase64, networkData, networkDataBase64 string
+			var generateNetworkData bool
 			if volume.CloudInitNoCloud != nil {
 				dataSourceType = "cloudInitNoCloud"
 				userDataSecretRef = volume.CloudInitNoCloud.UserDataSecretRef
@@ -1741,6 +3235,7 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 				networkDataSecretRef = volume.CloudInitNoCloud.NetworkDataSecretRef
 				networkDataBase64 = volume.CloudInitNoCloud.NetworkDataBase64
 				networkData = volume.CloudInitNoCloud.NetworkData
+				generateNetworkData = volume.CloudInitNoCloud.GenerateNetworkData
 			} else if volume.CloudInitConfigDrive != nil {
 				dataSourceType = "cloudInitConfigDrive"
 				userDataSecretRef = volume.CloudInitConfigDrive.UserDataSecretRef
@@ -1811,6 +3306,9 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 				networkDataSourceCount++
 				networkDataLen = len(networkData)
 			}
+			if generateNetworkData {
+				networkDataSourceCount++
+			}
 
 			if networkDataSourceCount > 1 {
 				causes = append(causes, metav1.StatusCause{
@@ -1837,6 +3335,38 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			}
 		}
 
+		// Verify Sysprep references exactly one answer file source
+		if sysprep := volume.Sysprep; sysprep != nil {
+			sysprepSourceCount := 0
+			if sysprep.ConfigMap != nil {
+				sysprepSourceCount++
+				if sysprep.ConfigMap.Name == "" {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueRequired,
+						Message: fmt.Sprintf("%s is required for a sysprep ConfigMap", field.Index(idx).Child("sysprep", "configMap", "name").String()),
+						Field:   field.Index(idx).Child("sysprep", "configMap", "name").String(),
+					})
+				}
+			}
+			if sysprep.Secret != nil {
+				sysprepSourceCount++
+				if sysprep.Secret.Name == "" {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueRequired,
+						Message: fmt.Sprintf("%s is required for a sysprep Secret", field.Index(idx).Child("sysprep", "secret", "name").String()),
+						Field:   field.Index(idx).Child("sysprep", "secret", "name").String(),
+					})
+				}
+			}
+			if sysprepSourceCount != 1 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must have exactly one of secret or configMap set", field.Index(idx).Child("sysprep").String()),
+					Field:   field.Index(idx).Child("sysprep").String(),
+				})
+			}
+		}
+
 		// validate HostDisk data
 		if hostDisk := volume.HostDisk; hostDisk != nil {
 			if !config.HostDiskEnabled() {
@@ -1911,6 +3441,14 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 		})
 	}
 
+	if downwardMetricVolumeCount > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must have max one downwardMetric volume set", field.String()),
+			Field:   field.String(),
+		})
+	}
+
 	return causes
 }
 
@@ -1935,6 +3473,84 @@ func getNumberOfPodInterfaces(spec *v1.VirtualMachineInstanceSpec) int {
 	return nPodInterfaces
 }
 
+func validateDiskEncryption(field *k8sfield.Path, disk v1.Disk) (causes []metav1.StatusCause) {
+	if disk.Encryption.SecretName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must not be empty", field.Child("encryption", "secretName").String()),
+			Field:   field.Child("encryption", "secretName").String(),
+		})
+	}
+	if format := disk.Encryption.Format; format != "" && format != v1.DiskEncryptionFormatRaw && format != v1.DiskEncryptionFormatQcow2 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s has invalid value %s, supported formats are: raw, qcow2", field.Child("encryption", "format").String(), format),
+			Field:   field.Child("encryption", "format").String(),
+		})
+	}
+	if disk.LUN != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is not supported for LUN disks", field.Child("encryption").String()),
+			Field:   field.Child("encryption").String(),
+		})
+	}
+	// The passphrase Secret is mounted from a pod volume named after the disk
+	if maxLength := validation.DNS1123LabelMaxLength - len(diskencryption.VolumeName("")); len(disk.Name) > maxLength {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be no more than %d characters for an encrypted disk", field.Child("name").String(), maxLength),
+			Field:   field.Child("name").String(),
+		})
+	}
+	return causes
+}
+
+func validateDiskIOLimits(field *k8sfield.Path, limits *v1.DiskIOLimits) (causes []metav1.StatusCause) {
+	if limits == nil {
+		return causes
+	}
+	if limits.Total != 0 && (limits.Read != 0 || limits.Write != 0) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can not be combined with %s or %s", field.Child("total").String(), field.Child("read").String(), field.Child("write").String()),
+			Field:   field.Child("total").String(),
+		})
+	}
+	if limits.TotalBurst != 0 && (limits.ReadBurst != 0 || limits.WriteBurst != 0) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can not be combined with %s or %s", field.Child("totalBurst").String(), field.Child("readBurst").String(), field.Child("writeBurst").String()),
+			Field:   field.Child("totalBurst").String(),
+		})
+	}
+	for _, burst := range []struct {
+		name  string
+		limit uint64
+		burst uint64
+	}{
+		{"total", limits.Total, limits.TotalBurst},
+		{"read", limits.Read, limits.ReadBurst},
+		{"write", limits.Write, limits.WriteBurst},
+	} {
+		if burst.burst != 0 && burst.burst < burst.limit {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be lower than %s", field.Child(burst.name+"Burst").String(), field.Child(burst.name).String()),
+				Field:   field.Child(burst.name + "Burst").String(),
+			})
+		}
+	}
+	if limits.BurstLength != 0 && limits.TotalBurst == 0 && limits.ReadBurst == 0 && limits.WriteBurst == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires a burst", field.Child("burstLength").String()),
+			Field:   field.Child("burstLength").String(),
+		})
+	}
+	return causes
+}
+
 func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	nameMap := make(map[string]int)
@@ -2041,7 +3657,7 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					Field:   field.Index(idx).Child(diskType, "bus").String(),
 				})
 			} else {
-				buses := []string{"virtio", "sata", "scsi"}
+				buses := []string{"virtio", "sata", "scsi", "usb"}
 				validBus := false
 				for _, b := range buses {
 					if b == bus {
@@ -2056,6 +3672,15 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					})
 				}
 
+				// usb mass storage devices can not pass through SCSI commands
+				if diskType == "lun" && bus == "usb" {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("Bus type %s is invalid for LUN device", bus),
+						Field:   field.Index(idx).Child("lun", "bus").String(),
+					})
+				}
+
 				// special case. virtio is incompatible with CD-ROM for q35 machine types
 				if diskType == "cdrom" && bus == "virtio" {
 					causes = append(causes, metav1.StatusCause{
@@ -2078,6 +3703,17 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					Field:   field.Child("domain", "devices", "disks").Index(idx).String(),
 				})
 			}
+
+			// Same for the USB bus, usb-storage devices can not be assigned to an IOThread.
+			isIOThreadsWithUSBBus := disk.DedicatedIOThread != nil && *disk.DedicatedIOThread &&
+				(disk.DiskDevice.Disk != nil) && strings.EqualFold(disk.DiskDevice.Disk.Bus, "usb")
+			if isIOThreadsWithUSBBus {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: "IOThreads are not supported for disks on a USB bus",
+					Field:   field.Child("domain", "devices", "disks").Index(idx).String(),
+				})
+			}
 		}
 
 		// Verify serial number is made up of valid characters for libvirt, if provided
@@ -2100,7 +3736,7 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 		}
 
 		// Verify if cache mode is valid
-		if disk.Cache != "" && disk.Cache != v1.CacheNone && disk.Cache != v1.CacheWriteThrough {
+		if disk.Cache != "" && disk.Cache != v1.CacheNone && disk.Cache != v1.CacheWriteThrough && disk.Cache != v1.CacheWriteBack {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("%s has invalid value %s", field.Index(idx).Child("cache").String(), disk.Cache),
@@ -2117,6 +3753,37 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		if disk.Discard != "" && disk.Discard != v1.DiscardUnmap && disk.Discard != v1.DiscardIgnore {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("Discard mode for %s is not supported. Supported modes are: unmap, ignore.", field.Index(idx).Child("discard").String()),
+				Field:   field.Index(idx).Child("discard").String(),
+			})
+		}
+
+		if disk.DetectZeroes != "" && disk.DetectZeroes != v1.DetectZeroesOff && disk.DetectZeroes != v1.DetectZeroesOn && disk.DetectZeroes != v1.DetectZeroesUnmap {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("Detect zeroes mode for %s is not supported. Supported modes are: off, on, unmap.", field.Index(idx).Child("detectZeroes").String()),
+				Field:   field.Index(idx).Child("detectZeroes").String(),
+			})
+		} else if disk.DetectZeroes == v1.DetectZeroesUnmap && disk.Discard == v1.DiscardIgnore {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can only be unmap if %s is unmap", field.Index(idx).Child("detectZeroes").String(), field.Index(idx).Child("discard").String()),
+				Field:   field.Index(idx).Child("detectZeroes").String(),
+			})
+		}
+
+		if disk.IOTune != nil {
+			causes = append(causes, validateDiskIOLimits(field.Index(idx).Child("ioTune", "iops"), disk.IOTune.IOPS)...)
+			causes = append(causes, validateDiskIOLimits(field.Index(idx).Child("ioTune", "bandwidth"), disk.IOTune.Bandwidth)...)
+		}
+
+		if disk.Encryption != nil {
+			causes = append(causes, validateDiskEncryption(field.Index(idx), disk)...)
+		}
+
 		// Verify disk and volume name can be a valid container name since disk
 		// name can become a container name which will fail to schedule if invalid
 		errs := validation.IsDNS1123Label(disk.Name)
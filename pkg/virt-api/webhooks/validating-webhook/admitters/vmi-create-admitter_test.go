@@ -195,7 +195,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			}
 			resp := vmiCreateAdmitter.Admit(ar)
 			Expect(resp.Allowed).To(BeFalse())
-			Expect(resp.Result.Message).To(Equal(`either spec.readinessProbe.tcpSocket or spec.readinessProbe.httpGet must be set if a spec.readinessProbe is specified, either spec.livenessProbe.tcpSocket or spec.livenessProbe.httpGet must be set if a spec.livenessProbe is specified`))
+			Expect(resp.Result.Message).To(Equal(`either spec.readinessProbe.tcpSocket, spec.readinessProbe.exec, spec.readinessProbe.guestAgentPing or spec.readinessProbe.httpGet must be set if a spec.readinessProbe is specified, either spec.livenessProbe.tcpSocket, spec.livenessProbe.exec, spec.livenessProbe.guestAgentPing or spec.livenessProbe.httpGet must be set if a spec.livenessProbe is specified`))
 		})
 		It("should reject probes with more than one action per probe configured", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -289,6 +289,55 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(resp.Allowed).To(BeFalse())
 			Expect(resp.Result.Message).To(Equal(`spec.livenessProbe is only allowed if the Pod Network is attached, spec.readinessProbe is only allowed if the Pod Network is attached`))
 		})
+		It("should accept guest agent based probes if no Pod Network is present", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.ReadinessProbe = &v1.Probe{
+				Handler: v1.Handler{
+					Exec: &k8sv1.ExecAction{Command: []string{"cat", "/tmp/ready"}},
+				},
+			}
+			vmi.Spec.LivenessProbe = &v1.Probe{
+				Handler: v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+				},
+			}
+
+			vmiBytes, _ := json.Marshal(&vmi)
+
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			resp := vmiCreateAdmitter.Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+		It("should reject guest agent based probes combined with another action", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.LivenessProbe = &v1.Probe{
+				Handler: v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+					Exec:           &k8sv1.ExecAction{Command: []string{"cat", "/tmp/ready"}},
+				},
+			}
+
+			vmiBytes, _ := json.Marshal(&vmi)
+
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			resp := vmiCreateAdmitter.Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal(`spec.livenessProbe must have exactly one probe type set`))
+		})
 	})
 
 	It("should accept valid vmi spec on create", func() {
@@ -445,6 +494,40 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				virtconfig.SidecarGate,
 			),
 		)
+
+		table.DescribeTable("should validate the backup hook annotations", func(annotations map[string]string, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.ObjectMeta = metav1.ObjectMeta{
+				Annotations: annotations,
+			}
+
+			causes := ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, config, "fake-account")
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueInvalid))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("and accept hooks with a timeout", map[string]string{
+				v1.PreBackupHookAnnotation:     `["/usr/bin/fsfreeze-hook", "freeze"]`,
+				v1.PostBackupHookAnnotation:    `["/usr/bin/fsfreeze-hook", "thaw"]`,
+				v1.BackupHookTimeoutAnnotation: "2m",
+			}, ""),
+			table.Entry("and reject a hook which is no JSON array", map[string]string{
+				v1.PreBackupHookAnnotation: "/usr/bin/fsfreeze-hook freeze",
+			}, "metadata.annotations."+v1.PreBackupHookAnnotation),
+			table.Entry("and reject a hook without command", map[string]string{
+				v1.PostBackupHookAnnotation: "[]",
+			}, "metadata.annotations."+v1.PostBackupHookAnnotation),
+			table.Entry("and reject an invalid timeout", map[string]string{
+				v1.BackupHookTimeoutAnnotation: "30",
+			}, "metadata.annotations."+v1.BackupHookTimeoutAnnotation),
+			table.Entry("and reject a timeout below a second", map[string]string{
+				v1.BackupHookTimeoutAnnotation: "500ms",
+			}, "metadata.annotations."+v1.BackupHookTimeoutAnnotation),
+		)
 	})
 
 	Context("with VirtualMachineInstance spec", func() {
@@ -470,6 +553,36 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(ContainSubstring("fake.domain.machine.type is not supported: test (allowed values:"))
 		})
 
+		It("should reject an unsupported architecture", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Architecture = "riscv64"
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.architecture"))
+			Expect(causes[0].Message).To(ContainSubstring("fake.architecture is not supported: riscv64"))
+		})
+
+		table.DescribeTable("should validate the bootloader of the architecture", func(arch string, bootloader *v1.Bootloader, field string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Architecture = arch
+			vmi.Spec.Domain.Firmware = &v1.Firmware{Bootloader: bootloader}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if field == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).ToNot(BeEmpty())
+				Expect(causes[0].Field).To(Equal(field))
+			}
+		},
+			table.Entry("and accept EFI without secure boot on arm64", "arm64", &v1.Bootloader{EFI: &v1.EFI{SecureBoot: pointer.BoolPtr(false)}}, ""),
+			table.Entry("and reject EFI secure boot on arm64", "arm64", &v1.Bootloader{EFI: &v1.EFI{}}, "fake.domain.firmware.bootloader.efi.secureBoot"),
+			table.Entry("and reject BIOS on arm64", "arm64", &v1.Bootloader{BIOS: &v1.BIOS{}}, "fake.domain.firmware.bootloader.bios"),
+			table.Entry("and accept BIOS on s390x", "s390x", &v1.Bootloader{BIOS: &v1.BIOS{}}, ""),
+			table.Entry("and reject EFI on s390x", "s390x", &v1.Bootloader{EFI: &v1.EFI{SecureBoot: pointer.BoolPtr(false)}}, "fake.domain.firmware.bootloader.efi"),
+		)
+
 		It("should accept valid hostname", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Hostname = "test"
@@ -606,6 +719,19 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].name"))
 			Expect(causes[1].Field).To(Equal("fake.domain.devices.disks[0]"))
 		})
+		It("should accept a CD-ROM without a volume as an empty drive", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "empty-cdrom",
+				DiskDevice: v1.DiskDevice{
+					CDRom: &v1.CDRomTarget{Bus: "sata"},
+				},
+			})
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
 
 		table.DescribeTable("should verify input device",
 			func(input v1.Input, expectedErrors int, expectedErrorTypes []string, expectMessage string) {
@@ -944,6 +1070,23 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(0))
 		})
 
+		table.DescribeTable("should validate the IPv6 CIDR of the pod network", func(cidr string, expectedErrors int) {
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+			vm.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vm.Spec.Networks[0].Pod.VMIPv6NetworkCIDR = cidr
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(causes).To(HaveLen(expectedErrors))
+			if expectedErrors > 0 {
+				Expect(causes[0].Field).To(Equal("fake.networks[0].pod.vmIPv6NetworkCIDR"))
+			}
+		},
+			table.Entry("and accept an IPv6 CIDR", "fd10:10:10::/120", 0),
+			table.Entry("and reject an IPv4 CIDR", "10.10.10.0/24", 1),
+			table.Entry("and reject a malformed CIDR", "fd10:10:10::", 1),
+		)
+
 		It("should accept interface and network lists equal to max element length", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
@@ -1306,6 +1449,63 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.networks"))
 			Expect(causes[0].Message).To(Equal("Multus CNI should only have one default network"))
 		})
+		It("should accept persistent IPs on a bridged secondary network", func() {
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				*v1.DefaultBridgeNetworkInterface(),
+			}
+			vm.Spec.Domain.Devices.Interfaces[1].Name = "multus1"
+			vm.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{
+					Name: "multus1",
+					NetworkSource: v1.NetworkSource{
+						Multus: &v1.MultusNetwork{NetworkName: "multus-net1", PersistentIPs: true},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject persistent IPs on a network which is not bridged", func() {
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				{Name: "multus1", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+			}
+			vm.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{
+					Name: "multus1",
+					NetworkSource: v1.NetworkSource{
+						Multus: &v1.MultusNetwork{NetworkName: "multus-net1", PersistentIPs: true},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueInvalid))
+			Expect(causes[0].Field).To(Equal("fake.networks[1].multus.persistentIPs"))
+		})
+		It("should reject persistent IPs on the multus default network", func() {
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vm.Spec.Networks = []v1.Network{
+				{
+					Name: "default",
+					NetworkSource: v1.NetworkSource{
+						Multus: &v1.MultusNetwork{NetworkName: "multus-net1", Default: true, PersistentIPs: true},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.networks[0].multus.persistentIPs"))
+		})
 		It("should reject pod network with a multus default", func() {
 			vm := v1.NewMinimalVMI("testvm")
 			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{
@@ -1589,121 +1789,536 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
 			Expect(causes).To(HaveLen(0))
 		})
-		It("should reject port out of range", func() {
-			enableSlirpInterface()
+		table.DescribeTable("should validate a vDPA interface", func(model string, networkSource v1.NetworkSource, featureGateEnabled bool, expectedField, expectedMessage string) {
 			vm := v1.NewMinimalVMI("testvm")
-			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{v1.Interface{
-				Name: "default",
-				InterfaceBindingMethod: v1.InterfaceBindingMethod{
-					Slirp: &v1.InterfaceSlirp{},
-				},
-				Ports: []v1.Port{{Port: 80000}}}}
-
-			vm.Spec.Networks = []v1.Network{
-				v1.Network{
-					Name:          "default",
-					NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
-				},
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "default",
+				Model:                  model,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Vdpa: &v1.InterfaceVdpa{}},
+			}}
+			vm.Spec.Networks = []v1.Network{{Name: "default", NetworkSource: networkSource}}
+			if featureGateEnabled {
+				enableFeatureGate(virtconfig.VDPAGate)
 			}
 
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
-			Expect(len(causes)).To(Equal(1))
-			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].ports[0]"))
-		})
-		It("should reject interface with two ports with the same name", func() {
-			enableSlirpInterface()
+			if expectedMessage == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+				Expect(causes[0].Message).To(Equal(expectedMessage))
+			}
+		},
+			table.Entry("and accept it on a Multus network", "",
+				v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}}, true, "", ""),
+			table.Entry("and reject it when the feature gate is disabled", "",
+				v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}}, false,
+				"fake.domain.devices.interfaces[0].name", "VDPA feature gate is not enabled"),
+			table.Entry("and reject it on the pod network", "",
+				v1.NetworkSource{Pod: &v1.PodNetwork{}}, true,
+				"fake.domain.devices.interfaces[0].name", "vDPA interface only implemented with Multus network"),
+			table.Entry("and reject a non virtio model", "e1000",
+				v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}}, true,
+				"fake.domain.devices.interfaces[0].model", "vDPA interface only supports the virtio model"),
+		)
+		table.DescribeTable("should validate a vhost-user interface", func(model string, networkSource v1.NetworkSource, featureGateEnabled bool, expectedField, expectedMessage string) {
 			vm := v1.NewMinimalVMI("testvm")
-			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{v1.Interface{
-				Name: "default",
-				InterfaceBindingMethod: v1.InterfaceBindingMethod{
-					Slirp: &v1.InterfaceSlirp{},
-				},
-				Ports: []v1.Port{{Name: "testport", Port: 80}, {Name: "testport", Protocol: "UDP", Port: 80}}}}
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "default",
+				Model:                  model,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+			}}
+			vm.Spec.Networks = []v1.Network{{Name: "default", NetworkSource: networkSource}}
+			if featureGateEnabled {
+				enableFeatureGate(virtconfig.VhostUserGate)
+			}
 
-			vm.Spec.Networks = []v1.Network{
-				v1.Network{
-					Name:          "default",
-					NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
-				},
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			if expectedMessage == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+				Expect(causes[0].Message).To(Equal(expectedMessage))
 			}
+		},
+			table.Entry("and accept it on a Multus network", "",
+				v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}}, true, "", ""),
+			table.Entry("and reject it when the feature gate is disabled", "",
+				v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}}, false,
+				"fake.domain.devices.interfaces[0].name", "VhostUser feature gate is not enabled"),
+			table.Entry("and reject it on the pod network", "",
+				v1.NetworkSource{Pod: &v1.PodNetwork{}}, true,
+				"fake.domain.devices.interfaces[0].name", "vhost-user interface only implemented with Multus network"),
+			table.Entry("and reject a non virtio model", "e1000",
+				v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}}, true,
+				"fake.domain.devices.interfaces[0].model", "vhost-user interface only supports the virtio model"),
+		)
+		It("should reject a passt interface when the feature is inactive", func() {
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultPasstNetworkInterface()}
+			vm.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
 
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
-			Expect(len(causes)).To(Equal(1))
-			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].ports[1].name"))
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].name"))
+			Expect(causes[0].Message).To(Equal("Passt feature gate is not enabled"))
 		})
-		It("should reject two interfaces with same port name", func() {
-			enableSlirpInterface()
+		It("should reject a passt interface on a network different than the pod network", func() {
 			vm := v1.NewMinimalVMI("testvm")
-			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{v1.Interface{
-				Name: "default",
-				InterfaceBindingMethod: v1.InterfaceBindingMethod{
-					Slirp: &v1.InterfaceSlirp{},
-				},
-				Ports: []v1.Port{{Name: "testport", Port: 80}}},
-				v1.Interface{
-					Name: "default",
-					InterfaceBindingMethod: v1.InterfaceBindingMethod{
-						Slirp: &v1.InterfaceSlirp{},
-					},
-					Ports: []v1.Port{{Name: "testport", Protocol: "UDP", Port: 80}}}}
-
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultPasstNetworkInterface()}
 			vm.Spec.Networks = []v1.Network{
 				v1.Network{
 					Name:          "default",
-					NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}},
 				},
 			}
 
+			enableFeatureGate(virtconfig.PasstGate)
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
-			Expect(len(causes)).To(Equal(2))
-			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[1].name"))
-			Expect(causes[1].Field).To(Equal("fake.domain.devices.interfaces[1].ports[0].name"))
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].name"))
+			Expect(causes[0].Message).To(Equal("Passt interface only implemented with pod network"))
 		})
-		It("should allow interface with two same ports and protocol", func() {
-			enableSlirpInterface()
+		It("should accept a passt interface forwarding TCP and UDP ports when the feature is active", func() {
 			vm := v1.NewMinimalVMI("testvm")
-			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{v1.Interface{
-				Name: "default",
-				InterfaceBindingMethod: v1.InterfaceBindingMethod{
-					Slirp: &v1.InterfaceSlirp{},
-				},
-				Ports: []v1.Port{{Port: 80}, {Protocol: "UDP", Port: 80}, {Protocol: "TCP", Port: 80}}}}
-
-			vm.Spec.Networks = []v1.Network{
-				v1.Network{
-					Name:          "default",
-					NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
-				},
-			}
+			iface := v1.DefaultPasstNetworkInterface()
+			iface.Ports = []v1.Port{{Name: "http", Port: 80}, {Name: "dns", Port: 53, Protocol: "UDP"}}
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vm.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
 
+			enableFeatureGate(virtconfig.PasstGate)
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
-			Expect(len(causes)).To(Equal(0))
+			Expect(causes).To(BeEmpty())
 		})
-		It("should reject specs with multiple pod interfaces", func() {
+		It("should reject a passt interface forwarding SCTP ports", func() {
 			vm := v1.NewMinimalVMI("testvm")
-			for i := 1; i < 3; i++ {
-				iface := v1.DefaultBridgeNetworkInterface()
-				net := v1.DefaultPodNetwork()
-
-				// make sure whatever the error we receive is not related to duplicate names
-				name := fmt.Sprintf("podnet%d", i)
-				iface.Name = name
-				net.Name = name
+			iface := v1.DefaultPasstNetworkInterface()
+			iface.Ports = []v1.Port{{Name: "sigtran", Port: 2905, Protocol: "SCTP"}}
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vm.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
 
-				vm.Spec.Domain.Devices.Interfaces = append(vm.Spec.Domain.Devices.Interfaces, *iface)
-				vm.Spec.Networks = append(vm.Spec.Networks, *net)
-			}
+			enableFeatureGate(virtconfig.PasstGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].ports[0].protocol"))
+		})
+		It("should accept a masquerade interface forwarding a port over TCP, UDP and SCTP", func() {
+			vm := v1.NewMinimalVMI("testvm")
+			iface := v1.DefaultMasqueradeNetworkInterface()
+			iface.Ports = []v1.Port{{Port: 2905}, {Protocol: "UDP", Port: 2905}, {Protocol: "SCTP", Port: 2905}}
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vm.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
 
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
-			Expect(len(causes)).To(Equal(1))
-			Expect(causes[0].Field).To(Equal("fake.interfaces"))
+			Expect(causes).To(BeEmpty())
 		})
+		Context("with bandwidth limits", func() {
+			newBandwidthLimit := func(average, peak, burst string) *v1.BandwidthLimit {
+				limit := &v1.BandwidthLimit{Average: resource.MustParse(average)}
+				if peak != "" {
+					q := resource.MustParse(peak)
+					limit.Peak = &q
+				}
+				if burst != "" {
+					q := resource.MustParse(burst)
+					limit.Burst = &q
+				}
+				return limit
+			}
 
-		It("should accept valid MAC address", func() {
+			table.DescribeTable("should validate the limits", func(iface *v1.Interface, bandwidth v1.InterfaceBandwidth, expectedFields ...string) {
+				enableSlirpInterface()
+				vmi := v1.NewMinimalVMI("testvm")
+				iface.Bandwidth = &bandwidth
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				fields := []string{}
+				for _, cause := range causes {
+					fields = append(fields, cause.Field)
+				}
+				Expect(fields).To(ConsistOf(expectedFields))
+			},
+				table.Entry("accepting inbound and outbound limits on a masquerade interface",
+					v1.DefaultMasqueradeNetworkInterface(),
+					v1.InterfaceBandwidth{Inbound: newBandwidthLimit("1Mi", "2Mi", "512Ki"), Outbound: newBandwidthLimit("1Mi", "", "")},
+				),
+				table.Entry("accepting a limit on a bridge interface",
+					v1.DefaultBridgeNetworkInterface(),
+					v1.InterfaceBandwidth{Outbound: newBandwidthLimit("100Ki", "", "")},
+				),
+				table.Entry("rejecting limits on a slirp interface",
+					v1.DefaultSlirpNetworkInterface(),
+					v1.InterfaceBandwidth{Inbound: newBandwidthLimit("1Mi", "", "")},
+					"fake.domain.devices.interfaces[0].bandwidth",
+				),
+				table.Entry("rejecting a zero average rate",
+					v1.DefaultMasqueradeNetworkInterface(),
+					v1.InterfaceBandwidth{Inbound: newBandwidthLimit("0", "", "")},
+					"fake.domain.devices.interfaces[0].bandwidth.inbound.average",
+				),
+				table.Entry("rejecting a peak rate lower than the average one and a zero burst",
+					v1.DefaultMasqueradeNetworkInterface(),
+					v1.InterfaceBandwidth{Outbound: newBandwidthLimit("2Mi", "1Mi", "0")},
+					"fake.domain.devices.interfaces[0].bandwidth.outbound.peak",
+					"fake.domain.devices.interfaces[0].bandwidth.outbound.burst",
+				),
+			)
+		})
+		table.DescribeTable("should validate RSS", func(iface *v1.Interface, multiQueue bool, expectedFields ...string) {
+			enableSlirpInterface()
 			vmi := v1.NewMinimalVMI("testvm")
-			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			iface.RSS = &v1.InterfaceRSS{HashReport: true}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = &multiQueue
 			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
-			for _, macAddress := range []string{"de:ad:00:00:be:af", "de-ad-00-00-be-af"} {
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ConsistOf(expectedFields))
+		},
+			table.Entry("accepting a multi-queue masquerade interface", v1.DefaultMasqueradeNetworkInterface(), true),
+			table.Entry("rejecting a single queue interface", v1.DefaultBridgeNetworkInterface(), false,
+				"fake.domain.devices.interfaces[0].rss",
+			),
+			table.Entry("rejecting a slirp interface", v1.DefaultSlirpNetworkInterface(), true,
+				"fake.domain.devices.interfaces[0].rss",
+			),
+			table.Entry("rejecting a non virtio model",
+				&v1.Interface{Name: "default", Model: "e1000", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}}, true,
+				"fake.domain.devices.interfaces[0].rss",
+				"fake.domain.devices.networkInterfaceMultiqueue",
+			),
+		)
+		table.DescribeTable("should validate offloads", func(iface *v1.Interface, offloads v1.InterfaceOffloads, expectedFields ...string) {
+			enableSlirpInterface()
+			vmi := v1.NewMinimalVMI("testvm")
+			iface.Offloads = &offloads
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ConsistOf(expectedFields))
+		},
+			table.Entry("accepting disabled offloads on a masquerade interface", v1.DefaultMasqueradeNetworkInterface(),
+				v1.InterfaceOffloads{Checksum: pointer.BoolPtr(false), TSO: pointer.BoolPtr(false), GSO: pointer.BoolPtr(false)}),
+			table.Entry("accepting disabled segmentation offloads with the checksum offload", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceOffloads{Checksum: pointer.BoolPtr(true), TSO: pointer.BoolPtr(false)}),
+			table.Entry("rejecting segmentation offloads without the checksum offload", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceOffloads{Checksum: pointer.BoolPtr(false), TSO: pointer.BoolPtr(true), GSO: pointer.BoolPtr(true)},
+				"fake.domain.devices.interfaces[0].offloads.tso",
+				"fake.domain.devices.interfaces[0].offloads.gso",
+			),
+			table.Entry("rejecting a slirp interface", v1.DefaultSlirpNetworkInterface(),
+				v1.InterfaceOffloads{Checksum: pointer.BoolPtr(false)},
+				"fake.domain.devices.interfaces[0].offloads",
+			),
+			table.Entry("rejecting a non virtio model",
+				&v1.Interface{Name: "default", Model: "e1000", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				v1.InterfaceOffloads{Checksum: pointer.BoolPtr(false)},
+				"fake.domain.devices.interfaces[0].offloads",
+			),
+		)
+		table.DescribeTable("should validate firewall rules", func(iface *v1.Interface, firewall v1.InterfaceFirewall, expectedFields ...string) {
+			enableSlirpInterface()
+			vmi := v1.NewMinimalVMI("testvm")
+			iface.Firewall = &firewall
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ConsistOf(expectedFields))
+		},
+			table.Entry("accepting rules on a masquerade interface", v1.DefaultMasqueradeNetworkInterface(),
+				v1.InterfaceFirewall{
+					Rules: []v1.FirewallRule{
+						{Action: v1.FirewallActionAllow, Direction: v1.FirewallDirectionIngress, Protocol: "TCP", Port: 22},
+						{Action: v1.FirewallActionAllow, Direction: v1.FirewallDirectionEgress, CIDR: "fd10::/64"},
+						{Action: v1.FirewallActionDeny, Protocol: "ICMP"},
+					},
+					DefaultAction: v1.FirewallActionDeny,
+				}),
+			table.Entry("accepting rules on a bridge interface", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceFirewall{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, CIDR: "10.0.0.0/8"}}}),
+			table.Entry("rejecting a slirp interface", v1.DefaultSlirpNetworkInterface(),
+				v1.InterfaceFirewall{DefaultAction: v1.FirewallActionDeny},
+				"fake.domain.devices.interfaces[0].firewall",
+			),
+			table.Entry("rejecting unknown actions", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceFirewall{Rules: []v1.FirewallRule{{Action: "Reject"}}, DefaultAction: "Drop"},
+				"fake.domain.devices.interfaces[0].firewall.rules[0].action",
+				"fake.domain.devices.interfaces[0].firewall.defaultAction",
+			),
+			table.Entry("rejecting an unknown direction", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceFirewall{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Direction: "Both"}}},
+				"fake.domain.devices.interfaces[0].firewall.rules[0].direction",
+			),
+			table.Entry("rejecting an invalid CIDR", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceFirewall{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, CIDR: "10.0.0.1"}}},
+				"fake.domain.devices.interfaces[0].firewall.rules[0].cidr",
+			),
+			table.Entry("rejecting an unknown protocol", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceFirewall{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Protocol: "GRE"}}},
+				"fake.domain.devices.interfaces[0].firewall.rules[0].protocol",
+			),
+			table.Entry("rejecting a port without a protocol", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceFirewall{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Port: 80}}},
+				"fake.domain.devices.interfaces[0].firewall.rules[0].port",
+			),
+			table.Entry("rejecting a port out of range", v1.DefaultBridgeNetworkInterface(),
+				v1.InterfaceFirewall{Rules: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Protocol: "UDP", Port: 70000}}},
+				"fake.domain.devices.interfaces[0].firewall.rules[0].port",
+			),
+		)
+		table.DescribeTable("should validate the failover of an SR-IOV interface", func(failover v1.Interface, failoverName, macAddress string, expectedFields ...string) {
+			sriovIface := v1.Interface{
+				Name:                   "sriov",
+				MacAddress:             macAddress,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{Failover: failoverName}},
+			}
+			ifaces := []v1.Interface{sriovIface, failover}
+
+			causes := validateInterfaceSRIOVFailover(k8sfield.NewPath("fake"), ifaces, sriovIface, 0)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ConsistOf(expectedFields))
+		},
+			table.Entry("accepting a virtio bridge interface with the same MAC address",
+				v1.Interface{Name: "backup", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				"backup", "02:00:00:00:00:01",
+			),
+			table.Entry("rejecting an interface which does not exist",
+				v1.Interface{Name: "backup", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				"missing", "02:00:00:00:00:01",
+				"fake.domain.devices.interfaces[0].sriov.failover",
+			),
+			table.Entry("rejecting a masquerade interface",
+				v1.Interface{Name: "backup", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+				"backup", "02:00:00:00:00:01",
+				"fake.domain.devices.interfaces[0].sriov.failover",
+			),
+			table.Entry("rejecting a non virtio model",
+				v1.Interface{Name: "backup", Model: "e1000", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				"backup", "02:00:00:00:00:01",
+				"fake.domain.devices.interfaces[0].sriov.failover",
+			),
+			table.Entry("rejecting a different MAC address",
+				v1.Interface{Name: "backup", MacAddress: "02:00:00:00:00:02", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				"backup", "02:00:00:00:00:01",
+				"fake.domain.devices.interfaces[0].macAddress",
+			),
+			table.Entry("rejecting interfaces without a MAC address",
+				v1.Interface{Name: "backup", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				"backup", "",
+				"fake.domain.devices.interfaces[0].macAddress",
+			),
+		)
+		It("should reject two SR-IOV interfaces failing over to the same interface", func() {
+			ifaces := []v1.Interface{
+				{Name: "sriov1", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{Failover: "backup"}}},
+				{Name: "sriov2", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{Failover: "backup"}}},
+				{Name: "backup", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+
+			causes := validateInterfaceSRIOVFailover(k8sfield.NewPath("fake"), ifaces, ifaces[0], 0)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueDuplicate))
+		})
+		table.DescribeTable("should validate the interface state", func(iface v1.Interface, state v1.InterfaceState, expectCause bool) {
+			iface.State = state
+			causes := validateInterfaceState(k8sfield.NewPath("fake"), iface, 0)
+			if expectCause {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].state"))
+			} else {
+				Expect(causes).To(BeEmpty())
+			}
+		},
+			table.Entry("accepting a link which is down", *v1.DefaultBridgeNetworkInterface(), v1.InterfaceStateLinkDown, false),
+			table.Entry("accepting a link which is up", *v1.DefaultMasqueradeNetworkInterface(), v1.InterfaceStateLinkUp, false),
+			table.Entry("accepting an absent interface", *v1.DefaultBridgeNetworkInterface(), v1.InterfaceStateAbsent, false),
+			table.Entry("rejecting an unknown state", *v1.DefaultBridgeNetworkInterface(), v1.InterfaceState("unknown"), true),
+			table.Entry("rejecting the link state of an SR-IOV interface",
+				v1.Interface{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}}, v1.InterfaceStateLinkDown, true),
+		)
+		Context("with a network binding plugin", func() {
+			registerBindingPlugin := func(name string) {
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.NetworkBindingPluginsGate}
+				kvConfig.Spec.Configuration.NetworkConfiguration = &v1.NetworkConfiguration{
+					Binding: map[string]v1.InterfaceBindingPlugin{
+						name: {SidecarImage: "registry:5000/binding-sidecar:devel"},
+					},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			}
+
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vmi = v1.NewMinimalVMI("testvm")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", Binding: &v1.PluginBinding{Name: "mybinding"}}}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			})
+
+			It("should reject the interface when the feature is inactive", func() {
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].binding"))
+				Expect(causes[0].Message).To(Equal("NetworkBindingPlugins feature gate is not enabled"))
+			})
+			It("should reject the interface when the plugin is not registered", func() {
+				registerBindingPlugin("otherbinding")
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].binding.name"))
+				Expect(causes[0].Message).To(Equal("binding plugin mybinding is not registered in the KubeVirt CR"))
+			})
+			It("should reject the interface when a binding method is set as well", func() {
+				registerBindingPlugin("mybinding")
+				vmi.Spec.Domain.Devices.Interfaces[0].Masquerade = &v1.InterfaceMasquerade{}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].binding"))
+				Expect(causes[0].Message).To(Equal("binding plugin and binding method are mutually exclusive"))
+			})
+			It("should accept the interface when the plugin is registered", func() {
+				registerBindingPlugin("mybinding")
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+		})
+		It("should reject port out of range", func() {
+			enableSlirpInterface()
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{v1.Interface{
+				Name: "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Slirp: &v1.InterfaceSlirp{},
+				},
+				Ports: []v1.Port{{Port: 80000}}}}
+
+			vm.Spec.Networks = []v1.Network{
+				v1.Network{
+					Name:          "default",
+					NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].ports[0]"))
+		})
+		It("should reject interface with two ports with the same name", func() {
+			enableSlirpInterface()
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{v1.Interface{
+				Name: "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Slirp: &v1.InterfaceSlirp{},
+				},
+				Ports: []v1.Port{{Name: "testport", Port: 80}, {Name: "testport", Protocol: "UDP", Port: 80}}}}
+
+			vm.Spec.Networks = []v1.Network{
+				v1.Network{
+					Name:          "default",
+					NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].ports[1].name"))
+		})
+		It("should reject two interfaces with same port name", func() {
+			enableSlirpInterface()
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{v1.Interface{
+				Name: "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Slirp: &v1.InterfaceSlirp{},
+				},
+				Ports: []v1.Port{{Name: "testport", Port: 80}}},
+				v1.Interface{
+					Name: "default",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{
+						Slirp: &v1.InterfaceSlirp{},
+					},
+					Ports: []v1.Port{{Name: "testport", Protocol: "UDP", Port: 80}}}}
+
+			vm.Spec.Networks = []v1.Network{
+				v1.Network{
+					Name:          "default",
+					NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(len(causes)).To(Equal(2))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[1].name"))
+			Expect(causes[1].Field).To(Equal("fake.domain.devices.interfaces[1].ports[0].name"))
+		})
+		It("should allow interface with two same ports and protocol", func() {
+			enableSlirpInterface()
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{v1.Interface{
+				Name: "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Slirp: &v1.InterfaceSlirp{},
+				},
+				Ports: []v1.Port{{Port: 80}, {Protocol: "UDP", Port: 80}, {Protocol: "TCP", Port: 80}}}}
+
+			vm.Spec.Networks = []v1.Network{
+				v1.Network{
+					Name:          "default",
+					NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(len(causes)).To(Equal(0))
+		})
+		It("should reject specs with multiple pod interfaces", func() {
+			vm := v1.NewMinimalVMI("testvm")
+			for i := 1; i < 3; i++ {
+				iface := v1.DefaultBridgeNetworkInterface()
+				net := v1.DefaultPodNetwork()
+
+				// make sure whatever the error we receive is not related to duplicate names
+				name := fmt.Sprintf("podnet%d", i)
+				iface.Name = name
+				net.Name = name
+
+				vm.Spec.Domain.Devices.Interfaces = append(vm.Spec.Domain.Devices.Interfaces, *iface)
+				vm.Spec.Networks = append(vm.Spec.Networks, *net)
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.interfaces"))
+		})
+
+		It("should accept valid MAC address", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			for _, macAddress := range []string{"de:ad:00:00:be:af", "de-ad-00-00-be-af"} {
 				vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = macAddress // missing octet
 				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 				// if this is processed correctly, it should not result in any error
@@ -1746,6 +2361,16 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			}
 		})
 
+		It("should reject unsupported interface states", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].State = "unknown"
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].state"))
+		})
+
 		It("should accept valid NTP servers", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
@@ -1768,6 +2393,122 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(2))
 		})
 
+		It("should accept valid DHCP MTU and routes", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			mtu := uint16(1400)
+			vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions = &v1.DHCPOptions{
+				MTU:    &mtu,
+				Routes: []v1.DHCPRoute{{Destination: "10.0.0.0/8", Gateway: "10.1.2.3"}},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject a DHCP MTU below the IPv4 minimum", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			mtu := uint16(60)
+			vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions = &v1.DHCPOptions{MTU: &mtu}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].dhcpOptions.mtu"))
+		})
+
+		It("should reject non-IPv4 DHCP routes", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions = &v1.DHCPOptions{
+				Routes: []v1.DHCPRoute{{Destination: "fd10::/64", Gateway: "hostname"}},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(2))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].dhcpOptions.routes[0].destination"))
+			Expect(causes[1].Field).To(Equal("fake.domain.devices.interfaces[0].dhcpOptions.routes[0].gateway"))
+		})
+
+		table.DescribeTable("with the istio proxy injected", func(iface v1.Interface, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Annotations = map[string]string{"sidecar.istio.io/inject": "true"}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			causes := ValidateServiceMeshCompatibility(k8sfield.NewPath("fake"), &vmi.ObjectMeta, &vmi.Spec)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("should accept the masquerade binding", *v1.DefaultMasqueradeNetworkInterface()),
+			table.Entry("should reject the bridge binding on the pod network", *v1.DefaultBridgeNetworkInterface(),
+				"fake.domain.devices.interfaces[0].name"),
+			table.Entry("should reject a port reserved for the proxy",
+				v1.Interface{
+					Name:                   "default",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+					Ports:                  []v1.Port{{Port: 80}, {Port: 15001}},
+				},
+				"fake.domain.devices.interfaces[0].ports[1].port"),
+		)
+
+		It("should accept the bridge binding on the pod network without the istio proxy", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			causes := ValidateServiceMeshCompatibility(k8sfield.NewPath("fake"), &vmi.ObjectMeta, &vmi.Spec)
+			Expect(causes).To(BeEmpty())
+		})
+
+		Context("with a network attachment policy", func() {
+			BeforeEach(func() {
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.NetworkConfiguration = &v1.NetworkConfiguration{
+					AttachmentPolicy: &v1.NetworkAttachmentPolicy{
+						Rules: []v1.NetworkAttachmentPolicyRule{
+							{Namespaces: []string{"*"}, NetworkAttachmentDefinitions: []string{"*", "shared/public"}},
+							{Namespaces: []string{"infra-*"}, NetworkAttachmentDefinitions: []string{"storage/*"}},
+						},
+					},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			})
+
+			table.DescribeTable("should validate the Multus networks", func(namespace, networkName string, allowed bool) {
+				vmi := v1.NewMinimalVMI("testvm")
+				vmi.Spec.Networks = []v1.Network{
+					*v1.DefaultPodNetwork(),
+					{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: networkName}}},
+				}
+				causes := ValidateNetworkAttachmentPolicy(k8sfield.NewPath("fake"), namespace, &vmi.Spec, config)
+				if allowed {
+					Expect(causes).To(BeEmpty())
+				} else {
+					Expect(causes).To(HaveLen(1))
+					Expect(causes[0].Field).To(Equal("fake.networks[1].multus.networkName"))
+				}
+			},
+				table.Entry("allowing the NetworkAttachmentDefinitions of the VMI namespace", "tenant-a", "vlan10", true),
+				table.Entry("allowing the VMI namespace explicitly", "tenant-a", "tenant-a/vlan10", true),
+				table.Entry("allowing a shared NetworkAttachmentDefinition", "tenant-a", "shared/public", true),
+				table.Entry("rejecting another NetworkAttachmentDefinition of the shared namespace", "tenant-a", "shared/mgmt", false),
+				table.Entry("rejecting the NetworkAttachmentDefinitions of another tenant", "tenant-a", "tenant-b/vlan10", false),
+				table.Entry("rejecting the storage networks in tenant namespaces", "tenant-a", "storage/iscsi", false),
+				table.Entry("allowing the storage networks in infra namespaces", "infra-ceph", "storage/iscsi", true),
+			)
+
+			It("should allow all networks without a policy", func() {
+				disableFeatureGates()
+				vmi := v1.NewMinimalVMI("testvm")
+				vmi.Spec.Networks = []v1.Network{
+					{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "storage/iscsi"}}},
+				}
+				causes := ValidateNetworkAttachmentPolicy(k8sfield.NewPath("fake"), "tenant-a", &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+		})
+
 		It("should accept valid DHCPPrivateOptions", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
@@ -1886,6 +2627,41 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(Equal(fmt.Sprintf("Invalid IOThreadsPolicy (%s)", ioThreadPolicy)))
 		})
 
+		table.DescribeTable("should validate the ioThreads", func(policy *v1.IOThreadsPolicy, cpu *v1.CPU, ioThreads *v1.DiskIOThreads, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.IOThreadsPolicy = policy
+			vmi.Spec.Domain.CPU = cpu
+			vmi.Spec.Domain.IOThreads = ioThreads
+			causes := validateIOThreadsPolicy(k8sfield.NewPath("fake"), &vmi.Spec)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("accept a shared pool size",
+				ioThreadsPolicy(v1.IOThreadsPolicyAuto), nil, &v1.DiskIOThreads{SharedPoolSize: uint32Ptr(2)},
+			),
+			table.Entry("accept pinning the dedicated iothreads on the vcpus with an isolated emulator thread",
+				ioThreadsPolicy(v1.IOThreadsPolicyShared), &v1.CPU{DedicatedCPUPlacement: true, IsolateEmulatorThread: true}, &v1.DiskIOThreads{DedicatedPinning: v1.DedicatedIOThreadsPinningVCPUs},
+			),
+			table.Entry("reject ioThreads without an ioThreadsPolicy",
+				nil, nil, &v1.DiskIOThreads{SharedPoolSize: uint32Ptr(2)},
+				"fake.domain.ioThreads",
+			),
+			table.Entry("reject an empty shared pool",
+				ioThreadsPolicy(v1.IOThreadsPolicyAuto), nil, &v1.DiskIOThreads{SharedPoolSize: uint32Ptr(0)},
+				"fake.domain.ioThreads.sharedPoolSize",
+			),
+			table.Entry("reject pinning the dedicated iothreads on the vcpus without an isolated emulator thread",
+				ioThreadsPolicy(v1.IOThreadsPolicyShared), &v1.CPU{DedicatedCPUPlacement: true}, &v1.DiskIOThreads{DedicatedPinning: v1.DedicatedIOThreadsPinningVCPUs},
+				"fake.domain.ioThreads.dedicatedPinning",
+			),
+			table.Entry("reject an unknown pinning",
+				ioThreadsPolicy(v1.IOThreadsPolicyShared), nil, &v1.DiskIOThreads{DedicatedPinning: "nowhere"},
+				"fake.domain.ioThreads.dedicatedPinning",
+			),
+		)
+
 		It("should reject GPU devices when feature gate is disabled", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{
@@ -1946,7 +2722,85 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(0))
 		})
-
+		It("should reject virtiofs filesystems of unsupported volume types", func() {
+			enableFeatureGate(virtconfig.VirtIOFSGate)
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{
+					Name:     "configmap",
+					Virtiofs: &v1.FilesystemVirtiofs{},
+				},
+				{
+					Name:     "emptydisk",
+					Virtiofs: &v1.FilesystemVirtiofs{},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "configmap",
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{},
+					},
+				},
+				{
+					Name: "emptydisk",
+					VolumeSource: v1.VolumeSource{
+						EmptyDisk: &v1.EmptyDiskSource{},
+					},
+				},
+			}
+
+			causes := validateFilesystemsWithVirtIOFSEnabled(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.filesystems[1].name"))
+		})
+
+		table.DescribeTable("should validate the sub path and id mapping of virtiofs filesystems", func(volumeSource v1.VolumeSource, virtiofs *v1.FilesystemVirtiofs, expectedFields ...string) {
+			enableFeatureGate(virtconfig.VirtIOFSGate)
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{{Name: "shared", Virtiofs: virtiofs}}
+			vmi.Spec.Volumes = []v1.Volume{{Name: "shared", VolumeSource: volumeSource}}
+
+			causes := validateFilesystemsWithVirtIOFSEnabled(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("and accept a sub path of a PVC",
+				v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "dataset"}},
+				&v1.FilesystemVirtiofs{SubPath: "training/images"}),
+			table.Entry("and accept a sub path of a DataVolume",
+				v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "dataset"}},
+				&v1.FilesystemVirtiofs{SubPath: "training"}),
+			table.Entry("and reject a sub path of a ConfigMap",
+				v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}},
+				&v1.FilesystemVirtiofs{SubPath: "training"},
+				"fake.domain.devices.filesystems[0].virtiofs.subPath"),
+			table.Entry("and reject an absolute sub path",
+				v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "dataset"}},
+				&v1.FilesystemVirtiofs{SubPath: "/training"},
+				"fake.domain.devices.filesystems[0].virtiofs.subPath"),
+			table.Entry("and reject a sub path leaving the volume",
+				v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "dataset"}},
+				&v1.FilesystemVirtiofs{SubPath: "training/../../etc"},
+				"fake.domain.devices.filesystems[0].virtiofs.subPath"),
+			table.Entry("and accept uid and gid mappings",
+				v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "dataset"}},
+				&v1.FilesystemVirtiofs{
+					UIDMap: &v1.FilesystemIDMap{GuestID: 0, HostID: 1000, Count: 1},
+					GIDMap: &v1.FilesystemIDMap{GuestID: 0, HostID: 1000, Count: 1},
+				}),
+			table.Entry("and reject empty uid and gid mappings",
+				v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "dataset"}},
+				&v1.FilesystemVirtiofs{
+					UIDMap: &v1.FilesystemIDMap{GuestID: 0, HostID: 1000},
+					GIDMap: &v1.FilesystemIDMap{GuestID: 0, HostID: 1000},
+				},
+				"fake.domain.devices.filesystems[0].virtiofs.uidMap.count",
+				"fake.domain.devices.filesystems[0].virtiofs.gidMap.count"),
+		)
+
 		It("should reject GPU devices that are not permitted in the hostdev config", func() {
 			kvConfig := kv.DeepCopy()
 			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.GPUGate}
@@ -2067,6 +2921,28 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(0))
 		})
+		It("should accept permitted USB host devices", func() {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.HostDevicesGate}
+			kvConfig.Spec.Configuration.PermittedHostDevices = &v1.PermittedHostDevices{
+				USB: []v1.USBHostDevice{
+					{
+						USBVendorSelector: "0529:0001",
+						ResourceName:      "example.org/dongle",
+					},
+				},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{
+				v1.HostDevice{
+					Name:       "dongle",
+					DeviceName: "example.org/dongle",
+				},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(0))
+		})
 		table.DescribeTable("Should accept valid DNSPolicy and DNSConfig",
 			func(dnsPolicy k8sv1.DNSPolicy, dnsConfig *k8sv1.PodDNSConfig) {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -2214,13 +3090,669 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				k8sv1.ResourceMemory: resource.MustParse("4Mi"),
 			}
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
-			Expect(len(causes)).To(Equal(1))
-			Expect(causes[0].Field).To(Equal("fake.domain.resources.requests.memory"))
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.resources.requests.memory"))
+		})
+	})
+
+	Context("with cpu hotplug", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2, MaxSockets: 4}
+		})
+		It("should reject maxSockets when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.maxSockets"))
+			Expect(causes[0].Message).To(Equal("CPUHotplug feature gate is not enabled"))
+		})
+		It("should accept maxSockets when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.CPUHotplugGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject maxSockets smaller than sockets", func() {
+			enableFeatureGate(virtconfig.CPUHotplugGate)
+			vmi.Spec.Domain.CPU.Sockets = 6
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.maxSockets"))
+		})
+		It("should reject maxSockets in combination with dedicated cpus", func() {
+			enableFeatureGate(virtconfig.CPUHotplugGate)
+			vmi.Spec.Domain.CPU.DedicatedCPUPlacement = true
+			vmi.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse("2"),
+				k8sv1.ResourceMemory: resource.MustParse("8Mi"),
+			}
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse("2"),
+				k8sv1.ResourceMemory: resource.MustParse("8Mi"),
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.maxSockets"))
+		})
+	})
+	Context("with memory hotplug", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			maxGuest := resource.MustParse("16Mi")
+			vmi.Spec.Domain.Memory = &v1.Memory{MaxGuest: &maxGuest}
+		})
+		It("should reject maxGuest when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.memory.maxGuest"))
+			Expect(causes[0].Message).To(Equal("MemoryHotplug feature gate is not enabled"))
+		})
+		It("should accept maxGuest when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.MemoryHotplugGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject maxGuest smaller than the guest memory", func() {
+			enableFeatureGate(virtconfig.MemoryHotplugGate)
+			maxGuest := resource.MustParse("4Mi")
+			vmi.Spec.Domain.Memory.MaxGuest = &maxGuest
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.memory.maxGuest"))
+		})
+		It("should reject maxGuest which is not aligned to the virtio-mem block size", func() {
+			enableFeatureGate(virtconfig.MemoryHotplugGate)
+			maxGuest := resource.MustParse("17Mi")
+			vmi.Spec.Domain.Memory.MaxGuest = &maxGuest
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring("must be aligned"))
+		})
+	})
+	Context("with guest NUMA mapping passthrough", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Cores:                 2,
+				DedicatedCPUPlacement: true,
+				NUMA:                  &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}},
+			}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+			}
+		})
+		It("should reject guestMappingPassthrough when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.numa.guestMappingPassthrough"))
+			Expect(causes[0].Message).To(Equal("NUMA feature gate is not enabled"))
+		})
+		It("should accept guestMappingPassthrough when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.NUMAFeatureGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject guestMappingPassthrough without dedicated CPUs and hugepages", func() {
+			enableFeatureGate(virtconfig.NUMAFeatureGate)
+			vmi.Spec.Domain.CPU.DedicatedCPUPlacement = false
+			vmi.Spec.Domain.Memory = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(2))
+			Expect(causes[0].Message).To(ContainSubstring("DedicatedCPUPlacement"))
+			Expect(causes[1].Message).To(ContainSubstring("hugepages"))
+		})
+	})
+	Context("with realtime", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Cores:                 4,
+				DedicatedCPUPlacement: true,
+				Realtime:              &v1.Realtime{Mask: "0-3,^1"},
+			}
+		})
+		It("should reject realtime when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.realtime"))
+			Expect(causes[0].Message).To(Equal("VMRealtime feature gate is not enabled"))
+		})
+		It("should accept realtime when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.VMRealtimeGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject realtime without dedicated CPUs", func() {
+			enableFeatureGate(virtconfig.VMRealtimeGate)
+			vmi.Spec.Domain.CPU.DedicatedCPUPlacement = false
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring("DedicatedCPUPlacement"))
+		})
+		table.DescribeTable("should reject an invalid mask", func(mask string) {
+			enableFeatureGate(virtconfig.VMRealtimeGate)
+			vmi.Spec.Domain.CPU.Realtime.Mask = mask
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.realtime.mask"))
+		},
+			table.Entry("which is malformed", "0-a"),
+			table.Entry("which only excludes vCPUs", "^1"),
+			table.Entry("which selects missing vCPUs", "2-4"),
+		)
+	})
+	Context("with a downward metrics volume", func() {
+		var vmi *v1.VirtualMachineInstance
+		addMetricsVolume := func(name string) {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name:       name,
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         name,
+				VolumeSource: v1.VolumeSource{DownwardMetrics: &v1.DownwardMetricsVolumeSource{}},
+			})
+		}
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			addMetricsVolume("metrics")
+		})
+		It("should reject the volume when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.volumes[0].downwardMetrics"))
+			Expect(causes[0].Message).To(Equal("DownwardMetrics feature gate is not enabled"))
+		})
+		It("should accept the volume when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.DownwardMetricsFeatureGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject more than one volume", func() {
+			enableFeatureGate(virtconfig.DownwardMetricsFeatureGate)
+			addMetricsVolume("metrics2")
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.volumes"))
+		})
+		table.DescribeTable("should reject a disk which is not a virtio disk", func(device v1.DiskDevice) {
+			enableFeatureGate(virtconfig.DownwardMetricsFeatureGate)
+			vmi.Spec.Domain.Devices.Disks[0].DiskDevice = device
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0]"))
+		},
+			table.Entry("with a sata bus", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "sata"}}),
+			table.Entry("as a CD-ROM", v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}),
+		)
+	})
+	Context("with a TPM device", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{}
+		})
+		It("should accept a non-persistent TPM without the feature gate", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject a persistent TPM when the feature gate is disabled", func() {
+			vmi.Spec.Domain.Devices.TPM.Persistent = pointer.BoolPtr(true)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.tpm.persistent"))
+			Expect(causes[0].Message).To(Equal("VMPersistentState feature gate is not enabled"))
+		})
+		It("should accept a persistent TPM when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.VMPersistentStateGate)
+			vmi.Spec.Domain.Devices.TPM.Persistent = pointer.BoolPtr(true)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+	})
+	Context("with a persistent EFI", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{SecureBoot: pointer.BoolPtr(false), Persistent: pointer.BoolPtr(true)},
+				},
+			}
+		})
+		It("should reject it when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.firmware.bootloader.efi.persistent"))
+			Expect(causes[0].Message).To(Equal("VMPersistentState feature gate is not enabled"))
+		})
+		It("should accept it when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.VMPersistentStateGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+	})
+	Context("with VSOCK", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = pointer.BoolPtr(true)
+		})
+		It("should reject VSOCK when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.autoattachVSOCK"))
+			Expect(causes[0].Message).To(Equal("VSOCK feature gate is not enabled"))
+		})
+		It("should accept VSOCK when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.VSOCKGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+	})
+	Context("with persistent reservation", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "shared",
+				DiskDevice: v1.DiskDevice{
+					LUN: &v1.LunTarget{Bus: "scsi", Reservation: true},
+				},
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "shared",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "shared"},
+				},
+			})
+		})
+		It("should reject a reservation when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].lun.reservation"))
+			Expect(causes[0].Message).To(Equal("PersistentReservation feature gate is not enabled"))
+		})
+		It("should accept a reservation when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.PersistentReservationGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject a reservation without the scsi bus", func() {
+			enableFeatureGate(virtconfig.PersistentReservationGate)
+			vmi.Spec.Domain.Devices.Disks[0].LUN.Bus = "sata"
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].lun.bus"))
+		})
+	})
+	Context("with guest filesystem expansion", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name:                  "data",
+				ExpandGuestFilesystem: pointer.BoolPtr(true),
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "data",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+				},
+			})
+		})
+		It("should reject it when the ExpandDisks feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].expandGuestFilesystem"))
+			Expect(causes[0].Message).To(Equal("ExpandDisks feature gate is not enabled"))
+		})
+		It("should accept it when the ExpandDisks feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.ExpandDisksGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+	})
+	Context("with a watchdog", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Watchdog = &v1.Watchdog{
+				Name: "watchdog",
+				WatchdogDevice: v1.WatchdogDevice{
+					Diag288: &v1.Diag288Watchdog{Action: v1.WatchdogActionPoweroff},
+				},
+			}
+		})
+		It("should accept a single watchdog device type", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject multiple watchdog device types", func() {
+			vmi.Spec.Domain.Devices.Watchdog.I6300ESB = &v1.I6300ESBWatchdog{Action: v1.WatchdogActionReset}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.watchdog"))
+		})
+	})
+	Context("with a panic memory dump", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.PanicMemoryDump = &v1.PanicMemoryDump{ClaimName: "dumps"}
+		})
+		It("should accept a memory dump PVC", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject a memory dump without a claim name", func() {
+			vmi.Spec.Domain.Devices.PanicMemoryDump.ClaimName = ""
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.panicMemoryDump.claimName"))
+		})
+		It("should reject a memory dump if the panic device is disabled", func() {
+			vmi.Spec.Domain.Devices.AutoattachPanicDevice = pointer.BoolPtr(false)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.autoattachPanicDevice"))
+		})
+	})
+	Context("with a memory dump PVC", func() {
+		It("should accept a memory dump PVC", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.MemoryDump = &v1.MemoryDump{ClaimName: "dumps"}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject a memory dump PVC without a claim name", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.MemoryDump = &v1.MemoryDump{}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.memoryDump.claimName"))
+		})
+	})
+	Context("with a swap disk", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "swap", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				{Name: "cloudinit", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "swap", VolumeSource: v1.VolumeSource{SwapDisk: &v1.SwapDiskSource{Capacity: resource.MustParse("1Gi")}}},
+				{Name: "cloudinit", VolumeSource: v1.VolumeSource{CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"}}},
+			}
+		})
+		It("should accept a swap disk", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject a swap disk without capacity", func() {
+			vmi.Spec.Volumes[0].SwapDisk.Capacity = resource.MustParse("0")
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.volumes[0].swapDisk.capacity"))
+		})
+		It("should reject a swap disk without cloud-init", func() {
+			vmi.Spec.Domain.Devices.Disks = vmi.Spec.Domain.Devices.Disks[:1]
+			vmi.Spec.Volumes = vmi.Spec.Volumes[:1]
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.volumes[0].swapDisk"))
+		})
+		It("should reject a swap disk attached as a cdrom", func() {
+			vmi.Spec.Domain.Devices.Disks[0].DiskDevice = v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0]"))
+		})
+	})
+	Context("with a serial console log", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.LogSerialConsole = pointer.BoolPtr(true)
+		})
+		It("should accept a serial console log stored on a PVC", func() {
+			vmi.Spec.Domain.Devices.SerialConsoleLog = &v1.SerialConsoleLog{ClaimName: "console-logs"}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject logging a disabled serial console", func() {
+			vmi.Spec.Domain.Devices.AutoattachSerialConsole = pointer.BoolPtr(false)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.autoattachSerialConsole"))
+		})
+		It("should reject a log PVC if the serial console is not logged", func() {
+			vmi.Spec.Domain.Devices.LogSerialConsole = nil
+			vmi.Spec.Domain.Devices.SerialConsoleLog = &v1.SerialConsoleLog{ClaimName: "console-logs"}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.logSerialConsole"))
+		})
+		It("should reject invalid log PVC settings", func() {
+			maxFileSize := resource.MustParse("0")
+			maxFiles := int32(0)
+			vmi.Spec.Domain.Devices.SerialConsoleLog = &v1.SerialConsoleLog{MaxFileSize: &maxFileSize, MaxFiles: &maxFiles}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(3))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.serialConsoleLog.claimName"))
+			Expect(causes[1].Field).To(Equal("fake.domain.devices.serialConsoleLog.maxFileSize"))
+			Expect(causes[2].Field).To(Equal("fake.domain.devices.serialConsoleLog.maxFiles"))
+		})
+	})
+	Context("with additional serial ports and channels", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+		})
+		It("should accept uniquely named serial ports and channels", func() {
+			vmi.Spec.Domain.Devices.Serials = []v1.SerialPort{{Name: "management"}, {Name: "debug"}}
+			vmi.Spec.Domain.Devices.Channels = []v1.Channel{{Name: "agent", TargetName: "org.example.agent.0"}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject too many serial ports", func() {
+			vmi.Spec.Domain.Devices.Serials = []v1.SerialPort{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.serials"))
+		})
+		It("should reject invalid and duplicate serial port names", func() {
+			vmi.Spec.Domain.Devices.Serials = []v1.SerialPort{{Name: "Management"}, {Name: "debug"}, {Name: "debug"}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(2))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.serials[0].name"))
+			Expect(causes[1].Field).To(Equal("fake.domain.devices.serials[2].name"))
+			Expect(causes[1].Type).To(Equal(metav1.CauseTypeFieldValueDuplicate))
+		})
+		table.DescribeTable("should reject a channel", func(channels []v1.Channel, field string) {
+			vmi.Spec.Domain.Devices.Channels = channels
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(field))
+		},
+			table.Entry("without a targetName", []v1.Channel{{Name: "agent"}}, "fake.domain.devices.channels[0].targetName"),
+			table.Entry("using the guest agent targetName", []v1.Channel{{Name: "agent", TargetName: "org.qemu.guest_agent.0"}}, "fake.domain.devices.channels[0].targetName"),
+			table.Entry("with a duplicate targetName",
+				[]v1.Channel{{Name: "agent", TargetName: "org.example.agent.0"}, {Name: "other", TargetName: "org.example.agent.0"}},
+				"fake.domain.devices.channels[1].targetName"),
+			table.Entry("with a duplicate name",
+				[]v1.Channel{{Name: "agent", TargetName: "org.example.agent.0"}, {Name: "agent", TargetName: "org.example.agent.1"}},
+				"fake.domain.devices.channels[1].name"),
+		)
+	})
+	Context("with an Ignition config", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+		})
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+		It("should reject an Ignition config when the feature gate is disabled", func() {
+			vmi.Spec.Ignition = &v1.IgnitionSource{SecretRef: &k8sv1.LocalObjectReference{Name: "ignition"}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.ignition"))
+		})
+		table.DescribeTable("should validate the Ignition config source", func(source *v1.IgnitionSource, fields ...string) {
+			enableFeatureGate(virtconfig.IgnitionGate)
+			vmi.Spec.Ignition = source
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(len(fields)))
+			for i, field := range fields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("and accept a Secret", &v1.IgnitionSource{SecretRef: &k8sv1.LocalObjectReference{Name: "ignition"}}),
+			table.Entry("and accept a ConfigMap", &v1.IgnitionSource{ConfigMapRef: &k8sv1.LocalObjectReference{Name: "ignition"}}),
+			table.Entry("and reject a missing source", &v1.IgnitionSource{}, "fake.ignition"),
+			table.Entry("and reject both sources", &v1.IgnitionSource{
+				SecretRef:    &k8sv1.LocalObjectReference{Name: "ignition"},
+				ConfigMapRef: &k8sv1.LocalObjectReference{Name: "ignition"},
+			}, "fake.ignition"),
+			table.Entry("and reject an unnamed Secret", &v1.IgnitionSource{SecretRef: &k8sv1.LocalObjectReference{}}, "fake.ignition.secretRef.name"),
+		)
+	})
+	Context("with SEV launch security", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SEV: &v1.SEV{}}
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{SecureBoot: pointer.BoolPtr(false)},
+				},
+			}
+		})
+		It("should reject SEV when the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.launchSecurity"))
+			Expect(causes[0].Message).To(Equal("WorkloadEncryptionSEV feature gate is not enabled"))
+		})
+		It("should accept SEV with EFI and without SecureBoot when the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject SEV without EFI", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			vmi.Spec.Domain.Firmware = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.launchSecurity"))
+			Expect(causes[0].Message).To(Equal("SEV requires OVMF (UEFI)"))
+		})
+		It("should reject SEV with SecureBoot", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(ContainElement(metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "SEV does not work along with SMM which is required by SecureBoot",
+				Field:   "fake.domain.launchSecurity",
+			}))
+		})
+		It("should reject SEV with the LiveMigrate eviction strategy", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			evictionStrategy := v1.EvictionStrategyLiveMigrate
+			vmi.Spec.EvictionStrategy = &evictionStrategy
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(ContainElement(metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "SEV does not support live migration",
+				Field:   "fake.evictionStrategy",
+			}))
+		})
+		It("should reject SEV with memory ballooning", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			vmi.Spec.Domain.Devices.AutoattachMemBalloon = pointer.BoolPtr(true)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.autoattachMemBalloon"))
+			Expect(causes[0].Message).To(Equal("SEV does not support memory ballooning"))
+		})
+		It("should reject more than one launch security technology", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			vmi.Spec.Domain.LaunchSecurity.TDX = &v1.TDX{}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.launchSecurity"))
+			Expect(causes[0].Message).To(Equal("fake.domain.launchSecurity must set only one of sev, snp and tdx"))
+		})
+		It("should accept SEV-SNP when the SEV feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject TDX when the TDX feature gate is disabled", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(Equal("WorkloadEncryptionTDX feature gate is not enabled"))
+		})
+		It("should accept TDX when the TDX feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionTDX)
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject host device passthrough for TDX", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionTDX)
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+			vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{{Name: "hostdev", DeviceName: "example.org/dev"}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(ContainElement(metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "TDX does not support host device passthrough",
+				Field:   "fake.domain.devices.hostDevices",
+			}))
+		})
+	})
+	Context("with AccessCredentials", func() {
+		It("should accept a valid ssh access credential with configdrive propagation", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk",
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testdisk",
+				VolumeSource: v1.VolumeSource{
+					CloudInitConfigDrive: &v1.CloudInitConfigDriveSource{UserData: " "},
+				},
+			})
+
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{
+					SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+						Source: v1.SSHPublicKeyAccessCredentialSource{
+							Secret: &v1.AccessCredentialSecretSource{
+								SecretName: "my-pkey",
+							},
+						},
+						PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+							ConfigDrive: &v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation{},
+						},
+					},
+				},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(0))
 		})
-	})
 
-	Context("with AccessCredentials", func() {
-		It("should accept a valid ssh access credential with configdrive propagation", func() {
+		It("should accept a valid ssh access credential with noCloud propagation", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
 				Name: "testdisk",
@@ -2228,7 +3760,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
 				Name: "testdisk",
 				VolumeSource: v1.VolumeSource{
-					CloudInitConfigDrive: &v1.CloudInitConfigDriveSource{UserData: " "},
+					CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: " "},
 				},
 			})
 
@@ -2241,13 +3773,13 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 							},
 						},
 						PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
-							ConfigDrive: &v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation{},
+							NoCloud: &v1.NoCloudSSHPublicKeyAccessCredentialPropagation{},
 						},
 					},
 				},
 			}
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
-			Expect(len(causes)).To(Equal(0))
+			Expect(causes).To(BeEmpty())
 		})
 
 		It("should accept a valid ssh access credential with qemu agent propagation", func() {
@@ -2324,6 +3856,36 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(1))
 		})
+		It("should reject a noCloud ssh access credential when no noCloud volume exists", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk",
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testdisk",
+				VolumeSource: v1.VolumeSource{
+					CloudInitConfigDrive: &v1.CloudInitConfigDriveSource{UserData: " "},
+				},
+			})
+
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{
+					SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+						Source: v1.SSHPublicKeyAccessCredentialSource{
+							Secret: &v1.AccessCredentialSecretSource{
+								SecretName: "my-pkey",
+							},
+						},
+						PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+							NoCloud: &v1.NoCloudSSHPublicKeyAccessCredentialPropagation{},
+						},
+					},
+				},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring("requires a noCloud volume"))
+		})
 		It("should reject a ssh access credential without a source", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -2713,6 +4275,48 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[1].Field).To(Equal("fake[1].lun.bus"))
 		})
 
+		It("should accept disks and cdroms on the usb bus", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk1",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{
+						Bus: "usb",
+					},
+				},
+			})
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk2",
+				DiskDevice: v1.DiskDevice{
+					CDRom: &v1.CDRomTarget{
+						Bus: "usb",
+					},
+				},
+			})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject LUNs on the usb bus", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk1",
+				DiskDevice: v1.DiskDevice{
+					LUN: &v1.LunTarget{
+						Bus: "usb",
+					},
+				},
+			})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake[0].lun.bus"))
+			Expect(causes[0].Message).To(Equal("Bus type usb is invalid for LUN device"))
+		})
+
 		It("should reject disks with unsupported I/O modes", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
@@ -2730,6 +4334,18 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[1].io"))
 		})
 
+		It("should accept disks with supported cache modes", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			for _, mode := range []v1.DriverCache{v1.CacheNone, v1.CacheWriteThrough, v1.CacheWriteBack} {
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+					Name: "testdisk" + string(mode), Cache: mode, DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{}}})
+			}
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		})
+
 		It("should reject disk with invalid cache mode", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -2743,6 +4359,132 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(Equal("fake[0].cache has invalid value unspported"))
 		})
 
+		table.DescribeTable("should validate the I/O limits of a disk", func(ioTune *v1.DiskIOTune, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", IOTune: ioTune, DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{}}})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("accept read and write limits with bursts",
+				&v1.DiskIOTune{
+					IOPS:      &v1.DiskIOLimits{Read: 100, Write: 50, ReadBurst: 200, BurstLength: 10},
+					Bandwidth: &v1.DiskIOLimits{Total: 1048576},
+				},
+			),
+			table.Entry("reject a total limit combined with a read limit",
+				&v1.DiskIOTune{IOPS: &v1.DiskIOLimits{Total: 100, Read: 50}},
+				"fake[0].ioTune.iops.total",
+			),
+			table.Entry("reject a total burst combined with a write burst",
+				&v1.DiskIOTune{Bandwidth: &v1.DiskIOLimits{TotalBurst: 100, WriteBurst: 50}},
+				"fake[0].ioTune.bandwidth.totalBurst",
+			),
+			table.Entry("reject a burst lower than its limit",
+				&v1.DiskIOTune{Bandwidth: &v1.DiskIOLimits{Write: 100, WriteBurst: 50}},
+				"fake[0].ioTune.bandwidth.writeBurst",
+			),
+			table.Entry("reject a burst length without a burst",
+				&v1.DiskIOTune{IOPS: &v1.DiskIOLimits{Total: 100, BurstLength: 10}},
+				"fake[0].ioTune.iops.burstLength",
+			),
+		)
+
+		table.DescribeTable("should validate the discard and detect zeroes modes of a disk", func(discard v1.DriverDiscard, detectZeroes v1.DriverDetectZeroes, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", Discard: discard, DetectZeroes: detectZeroes, DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{}}})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("accept the defaults", v1.DriverDiscard(""), v1.DriverDetectZeroes("")),
+			table.Entry("accept unmapping zeroes", v1.DiscardUnmap, v1.DetectZeroesUnmap),
+			table.Entry("accept unmapping zeroes with the default discard mode", v1.DriverDiscard(""), v1.DetectZeroesUnmap),
+			table.Entry("accept detecting zeroes while ignoring discards", v1.DiscardIgnore, v1.DetectZeroesOn),
+			table.Entry("reject an unknown discard mode", v1.DriverDiscard("trim"), v1.DriverDetectZeroes(""), "fake[0].discard"),
+			table.Entry("reject an unknown detect zeroes mode", v1.DriverDiscard(""), v1.DriverDetectZeroes("yes"), "fake[0].detectZeroes"),
+			table.Entry("reject unmapping zeroes while ignoring discards", v1.DiscardIgnore, v1.DetectZeroesUnmap, "fake[0].detectZeroes"),
+		)
+
+		table.DescribeTable("should validate the encryption of a disk", func(name string, diskDevice v1.DiskDevice, encryption *v1.DiskEncryption, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: name, Encryption: encryption, DiskDevice: diskDevice})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("accept a raw LUKS volume",
+				"testdisk", v1.DiskDevice{Disk: &v1.DiskTarget{}},
+				&v1.DiskEncryption{SecretName: "luks"},
+			),
+			table.Entry("accept a qcow2 image with LUKS encryption",
+				"testdisk", v1.DiskDevice{Disk: &v1.DiskTarget{}},
+				&v1.DiskEncryption{SecretName: "luks", Format: v1.DiskEncryptionFormatQcow2},
+			),
+			table.Entry("reject a missing secret name",
+				"testdisk", v1.DiskDevice{Disk: &v1.DiskTarget{}},
+				&v1.DiskEncryption{},
+				"fake[0].encryption.secretName",
+			),
+			table.Entry("reject an unknown format",
+				"testdisk", v1.DiskDevice{Disk: &v1.DiskTarget{}},
+				&v1.DiskEncryption{SecretName: "luks", Format: "vmdk"},
+				"fake[0].encryption.format",
+			),
+			table.Entry("reject a LUN",
+				"testdisk", v1.DiskDevice{LUN: &v1.LunTarget{}},
+				&v1.DiskEncryption{SecretName: "luks"},
+				"fake[0].encryption",
+			),
+			table.Entry("reject a name too long for the volume of the passphrase",
+				strings.Repeat("a", 59), v1.DiskDevice{Disk: &v1.DiskTarget{}},
+				&v1.DiskEncryption{SecretName: "luks"},
+				"fake[0].name",
+			),
+		)
+
+		table.DescribeTable("should only accept encrypted disks on volumes qemu opens directly", func(volumeSource v1.VolumeSource, expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name:       "testdisk",
+				Encryption: &v1.DiskEncryption{SecretName: "luks"},
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}},
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "testdisk",
+				VolumeSource: volumeSource,
+			})
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			for _, cause := range causes {
+				Expect(cause.Field).To(Equal("fake.domain.devices.disks[0].encryption"))
+			}
+		},
+			table.Entry("accept a PersistentVolumeClaim",
+				v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testclaim"}}, 0),
+			table.Entry("accept a DataVolume",
+				v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "testdv"}}, 0),
+			table.Entry("reject a ContainerDisk",
+				v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake"}}, 1),
+			table.Entry("reject an EmptyDisk",
+				v1.VolumeSource{EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")}}, 1),
+		)
+
 		It("should reject disk count > arrayLenMax", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			for i := 0; i <= arrayLenMax; i++ {
@@ -2851,6 +4593,26 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 
 		})
 
+		It("Should reject disk with DedicatedIOThread and USB bus", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			_true := true
+
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks,
+				v1.Disk{
+					Name:              "disk-with-dedicated-io-thread-and-usb",
+					DedicatedIOThread: &_true,
+					DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{
+						Bus: "usb",
+					}},
+				},
+			)
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(string(causes[0].Type)).To(Equal("FieldValueNotSupported"))
+			Expect(causes[0].Message).To(Equal("IOThreads are not supported for disks on a USB bus"))
+		})
+
 	})
 
 	Context("with volume", func() {
@@ -2906,6 +4668,47 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(BeEmpty())
 		})
 
+		table.DescribeTable("should reject a sysprep volume", func(sysprep *v1.SysprepSource, field string) {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "sysprep",
+				VolumeSource: v1.VolumeSource{Sysprep: sysprep},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(field))
+		},
+			table.Entry("without a source", &v1.SysprepSource{}, "fake[0].sysprep"),
+			table.Entry("with both a ConfigMap and a Secret", &v1.SysprepSource{
+				ConfigMap: &k8sv1.LocalObjectReference{Name: "test-config"},
+				Secret:    &k8sv1.LocalObjectReference{Name: "test-secret"},
+			}, "fake[0].sysprep"),
+			table.Entry("with an unnamed Secret", &v1.SysprepSource{
+				Secret: &k8sv1.LocalObjectReference{},
+			}, "fake[0].sysprep.secret.name"),
+		)
+
+		It("should reject a sysprep volume which is not attached as CD-ROM", func() {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name:       "sysprep",
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "sysprep",
+				VolumeSource: v1.VolumeSource{
+					Sysprep: &v1.SysprepSource{
+						ConfigMap: &k8sv1.LocalObjectReference{Name: "test-config"},
+					},
+				},
+			})
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].cdrom"))
+		})
+
 		It("should reject CloudInitNoCloud volume if either userData or networkData is missing", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -2970,6 +4773,39 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(BeEmpty())
 		})
 
+		It("should accept CloudInitNoCloud volume if it only generates the networkData", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk",
+			})
+
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testdisk",
+				VolumeSource: v1.VolumeSource{
+					CloudInitNoCloud: &v1.CloudInitNoCloudSource{GenerateNetworkData: true},
+				},
+			})
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject CloudInitNoCloud volume if it generates the networkData and has a networkData source", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk",
+			})
+
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testdisk",
+				VolumeSource: v1.VolumeSource{
+					CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: " ", NetworkData: " ", GenerateNetworkData: true},
+				},
+			})
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring("must have only one networkdata source set"))
+		})
+
 	})
 
 	Context("with bootloader", func() {
@@ -3071,6 +4907,75 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 		})
 
+		It("should accept custom secure boot keys", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			_true := true
+			vmi.Spec.Domain.Features = &v1.Features{
+				SMM: &v1.FeatureState{
+					Enabled: &_true,
+				},
+			}
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{
+						SecureBootKeys: &v1.SecureBootKeys{
+							PK:  &v1.SecureBootKeySource{SecretName: "my-pk"},
+							KEK: &v1.SecureBootKeySource{SecretName: "my-kek"},
+							DB:  &v1.SecureBootKeySource{SecretName: "my-db"},
+						},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should not accept custom secure boot keys without secureBoot", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			_false := false
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{
+						SecureBoot: &_false,
+						SecureBootKeys: &v1.SecureBootKeys{
+							DB: &v1.SecureBootKeySource{SecretName: "my-db"},
+						},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.firmware.bootloader.efi.secureBootKeys"))
+		})
+
+		It("should not accept custom secure boot keys without secrets", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			_true := true
+			vmi.Spec.Domain.Features = &v1.Features{
+				SMM: &v1.FeatureState{
+					Enabled: &_true,
+				},
+			}
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{
+						SecureBootKeys: &v1.SecureBootKeys{
+							KEK: &v1.SecureBootKeySource{},
+						},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.firmware.bootloader.efi.secureBootKeys.kek.secretName"))
+		})
+
 		It("should reject disk without a valid DNS-1123 name", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
@@ -3388,3 +5293,11 @@ var _ = Describe("Function getNumberOfPodInterfaces()", func() {
 	})
 
 })
+
+func uint32Ptr(i uint32) *uint32 {
+	return &i
+}
+
+func ioThreadsPolicy(policy v1.IOThreadsPolicy) *v1.IOThreadsPolicy {
+	return &policy
+}
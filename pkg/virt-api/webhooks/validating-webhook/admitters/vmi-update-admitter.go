@@ -31,6 +31,7 @@ import (
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 )
 
@@ -53,7 +54,24 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	if !reflect.DeepEqual(newVMI.Spec, oldVMI.Spec) {
 		// Only allow the KubeVirt SA to modify the VMI spec, since that means it went through the sub resource.
 		if webhooks.IsKubeVirtServiceAccount(ar.Request.UserInfo.Username) {
-			hotplugResponse := admitHotplug(newVMI.Spec.Volumes, oldVMI.Spec.Volumes, newVMI.Spec.Domain.Devices.Disks, oldVMI.Spec.Domain.Devices.Disks, oldVMI.Status.VolumeStatus, newVMI, admitter.ClusterConfig)
+			if interfacesResponse := admitInterfacesHotplug(newVMI, oldVMI, admitter.ClusterConfig); interfacesResponse != nil {
+				return interfacesResponse
+			}
+			oldVolumes := oldVMI.Spec.Volumes
+			if state := oldVMI.Status.MigrationState; state != nil && state.Completed && !state.Failed && len(state.MigratedVolumes) > 0 {
+				// after a volume migration, the migrated volumes are switched to their destination claims
+				if migratedVolumes := migrations.ReplaceMigratedVolumes(oldVolumes, state.MigratedVolumes); reflect.DeepEqual(newVMI.Spec.Volumes, migratedVolumes) {
+					oldVolumes = migratedVolumes
+				}
+			}
+			if mediaResponse := admitMediaChange(newVMI.Spec.Volumes, oldVolumes, newVMI.Spec.Domain.Devices.Disks, oldVMI.Spec.Domain.Devices.Disks); mediaResponse != nil {
+				return mediaResponse
+			}
+			// The media of the CD-ROMs is inserted and ejected independently of the hotplugged disks
+			cdroms := getCDRoms(oldVMI.Spec.Domain.Devices.Disks)
+			newVolumes, newDisks := withoutCDRoms(newVMI.Spec.Volumes, newVMI.Spec.Domain.Devices.Disks, cdroms)
+			oldVolumes, oldDisks := withoutCDRoms(oldVolumes, oldVMI.Spec.Domain.Devices.Disks, cdroms)
+			hotplugResponse := admitHotplug(newVolumes, oldVolumes, newDisks, oldDisks, oldVMI.Status.VolumeStatus, newVMI, admitter.ClusterConfig)
 			if hotplugResponse != nil {
 				return hotplugResponse
 			}
@@ -76,6 +94,172 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	return &reviewResponse
 }
 
+// admitInterfacesHotplug ensures that networks and interfaces are only ever appended to a running VMI,
+// or marked as absent to unplug them, and that the changed ones can be plugged dynamically.
+// The link state of the plugged interfaces may change freely.
+func admitInterfacesHotplug(newVMI, oldVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *v1beta1.AdmissionResponse {
+	newNetworks, oldNetworks := newVMI.Spec.Networks, oldVMI.Spec.Networks
+	newInterfaces, oldInterfaces := newVMI.Spec.Domain.Devices.Interfaces, oldVMI.Spec.Domain.Devices.Interfaces
+	if reflect.DeepEqual(newNetworks, oldNetworks) && len(newInterfaces) == len(oldInterfaces) && equalIgnoringUnplug(newInterfaces, oldInterfaces, false) {
+		return nil
+	}
+
+	if !config.HotplugNetworkInterfacesEnabled() {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("network interface hotplug is not allowed without the %s feature gate", virtconfig.HotplugNetworkIfacesGate),
+			},
+		})
+	}
+
+	if len(newNetworks) < len(oldNetworks) || !reflect.DeepEqual(newNetworks[:len(oldNetworks)], oldNetworks) ||
+		len(newInterfaces) < len(oldInterfaces) || !equalIgnoringUnplug(newInterfaces[:len(oldInterfaces)], oldInterfaces, true) {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "existing networks and interfaces can not be changed or removed",
+				Field:   k8sfield.NewPath("spec", "networks").String(),
+			},
+		})
+	}
+
+	networks := map[string]v1.Network{}
+	for _, network := range newNetworks {
+		networks[network.Name] = network
+	}
+	for i, iface := range newInterfaces[:len(oldInterfaces)] {
+		if iface.State != v1.InterfaceStateAbsent || oldInterfaces[i].State == v1.InterfaceStateAbsent {
+			continue
+		}
+		network := networks[iface.Name]
+		if network.Multus == nil || network.Multus.Default || iface.Bridge == nil {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("only bridge interfaces of non default multus networks can be unplugged, %s can not", iface.Name),
+					Field:   k8sfield.NewPath("spec", "domain", "devices", "interfaces").Index(i).Child("state").String(),
+				},
+			})
+		}
+	}
+
+	for i, network := range newNetworks[len(oldNetworks):] {
+		if network.Multus == nil || network.Multus.Default {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("hotplugged network %s must be a non default multus network", network.Name),
+					Field:   k8sfield.NewPath("spec", "networks").Index(len(oldNetworks) + i).String(),
+				},
+			})
+		}
+	}
+
+	for i, iface := range newInterfaces[len(oldInterfaces):] {
+		if iface.Bridge == nil {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("hotplugged interface %s must use the bridge binding", iface.Name),
+					Field:   k8sfield.NewPath("spec", "domain", "devices", "interfaces").Index(len(oldInterfaces) + i).String(),
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+// equalIgnoringUnplug compares the interfaces, allowing their link state to change and, if unplug is set,
+// them to be marked as absent.
+func equalIgnoringUnplug(newInterfaces, oldInterfaces []v1.Interface, unplug bool) bool {
+	for i := range newInterfaces {
+		newIface := newInterfaces[i].DeepCopy()
+		if isLinkState(oldInterfaces[i].State) && (isLinkState(newIface.State) || unplug && newIface.State == v1.InterfaceStateAbsent) {
+			newIface.State = oldInterfaces[i].State
+		}
+		if !reflect.DeepEqual(*newIface, oldInterfaces[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLinkState returns whether the state only sets the link state of a plugged interface.
+func isLinkState(state v1.InterfaceState) bool {
+	return state == "" || state == v1.InterfaceStateLinkUp || state == v1.InterfaceStateLinkDown
+}
+
+// getCDRoms returns the names of the CD-ROM disks.
+func getCDRoms(disks []v1.Disk) map[string]bool {
+	cdroms := make(map[string]bool)
+	for _, disk := range disks {
+		if disk.CDRom != nil {
+			cdroms[disk.Name] = true
+		}
+	}
+	return cdroms
+}
+
+// withoutCDRoms returns the volumes and disks without the given CD-ROMs and their media.
+func withoutCDRoms(volumes []v1.Volume, disks []v1.Disk, cdroms map[string]bool) ([]v1.Volume, []v1.Disk) {
+	filteredVolumes := make([]v1.Volume, 0)
+	for _, volume := range volumes {
+		if !cdroms[volume.Name] {
+			filteredVolumes = append(filteredVolumes, volume)
+		}
+	}
+	filteredDisks := make([]v1.Disk, 0)
+	for _, disk := range disks {
+		if !cdroms[disk.Name] {
+			filteredDisks = append(filteredDisks, disk)
+		}
+	}
+	return filteredVolumes, filteredDisks
+}
+
+// admitMediaChange ensures that the CD-ROMs of the running VMI are not changed, and that only
+// PVCs or DataVolumes are inserted as their media. The media itself may be ejected freely.
+func admitMediaChange(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Disk) *v1beta1.AdmissionResponse {
+	newDiskMap := getDiskMap(newDisks)
+	newVolumeMap := make(map[string]v1.Volume)
+	for _, volume := range newVolumes {
+		newVolumeMap[volume.Name] = volume
+	}
+	oldVolumeMap := make(map[string]v1.Volume)
+	for _, volume := range oldVolumes {
+		oldVolumeMap[volume.Name] = volume
+	}
+
+	for _, oldDisk := range oldDisks {
+		if oldDisk.CDRom == nil {
+			continue
+		}
+		if newDisk, ok := newDiskMap[oldDisk.Name]; !ok || !reflect.DeepEqual(newDisk, oldDisk) {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("CD-ROM %s, changed", oldDisk.Name),
+				},
+			})
+		}
+		newVolume, inserted := newVolumeMap[oldDisk.Name]
+		if oldVolume, ok := oldVolumeMap[oldDisk.Name]; !inserted || ok && reflect.DeepEqual(newVolume, oldVolume) {
+			continue
+		}
+		if newVolume.PersistentVolumeClaim == nil && newVolume.DataVolume == nil {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("media of CD-ROM %s is not a PVC or DataVolume", oldDisk.Name),
+				},
+			})
+		}
+	}
+	return nil
+}
+
 // admitHotplug compares the old and new volumes and disks, and ensures that they match and are valid.
 func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Disk, volumeStatuses []v1.VolumeStatus, newVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *v1beta1.AdmissionResponse {
 	if len(newVolumes) != len(newDisks) {
@@ -99,7 +283,8 @@ func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Di
 		return permanentAr
 	}
 
-	hotplugAr := verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap, newDiskMap, oldDiskMap)
+	usbControllerPresent := hasUSBController(newVMI.Spec.Domain.Devices.Inputs, oldDisks)
+	hotplugAr := verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap, newDiskMap, oldDiskMap, usbControllerPresent)
 	if hotplugAr != nil {
 		return hotplugAr
 	}
@@ -112,7 +297,26 @@ func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Di
 	return nil
 }
 
-func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1.Volume, newDisks, oldDisks map[string]v1.Disk) *v1beta1.AdmissionResponse {
+// hasUSBController returns true if the VMI was started with devices on the usb bus, only then its domain
+// has a USB controller which disks can be hotplugged to.
+func hasUSBController(inputs []v1.Input, disks []v1.Disk) bool {
+	for _, input := range inputs {
+		if input.Bus == "usb" || input.Bus == "" {
+			return true
+		}
+	}
+	for _, disk := range disks {
+		if disk.Disk != nil && disk.Disk.Bus == "usb" {
+			return true
+		}
+		if disk.CDRom != nil && disk.CDRom.Bus == "usb" {
+			return true
+		}
+	}
+	return false
+}
+
+func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1.Volume, newDisks, oldDisks map[string]v1.Disk, usbControllerPresent bool) *v1beta1.AdmissionResponse {
 	for k, v := range newHotplugVolumeMap {
 		if _, ok := oldHotplugVolumeMap[k]; ok {
 			// New and old have same volume, ensure they are the same
@@ -150,7 +354,7 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 					},
 				})
 			}
-			// Also ensure the matching new disk exists and is of type scsi
+			// Also ensure the matching new disk exists and is of type scsi or usb
 			if _, ok := newDisks[k]; !ok {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
@@ -160,15 +364,39 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 				})
 			}
 			disk := newDisks[k]
-			if disk.Disk == nil || disk.Disk.Bus != "scsi" {
+			if disk.Disk != nil && disk.Disk.Bus == "sata" {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
 						Type:    metav1.CauseTypeFieldValueInvalid,
-						Message: fmt.Sprintf("hotplugged Disk %s does not use a scsi bus", k),
+						Message: fmt.Sprintf("hotplugged Disk %s uses a sata bus, SATA disks can not be hotplugged, use the usb bus for guests without virtio-scsi drivers", k),
+					},
+				})
+			}
+			if disk.Disk == nil || (disk.Disk.Bus != "scsi" && disk.Disk.Bus != "usb") {
+				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("hotplugged Disk %s does not use a scsi or usb bus", k),
 					},
 				})
 
 			}
+			if disk.Disk.Bus == "usb" && !usbControllerPresent {
+				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("hotplugged Disk %s uses a usb bus, but the VMI was started without a USB controller", k),
+					},
+				})
+			}
+			if disk.Encryption != nil {
+				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("hotplugged Disk %s is encrypted, the passphrase of encrypted disks is only available at VMI start", k),
+					},
+				})
+			}
 		}
 	}
 	return nil
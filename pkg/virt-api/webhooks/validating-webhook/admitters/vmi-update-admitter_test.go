@@ -30,6 +30,7 @@ import (
 	"github.com/onsi/gomega/types"
 	"k8s.io/api/admission/v1beta1"
 	authv1 "k8s.io/api/authentication/v1"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -37,6 +38,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/testutils"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/rbac"
 )
 
@@ -337,6 +339,26 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		return res
 	}
 
+	makeDisksWithBusLastDisk := func(bus string, indexes ...int) []v1.Disk {
+		res := makeDisks(indexes...)
+		res[len(res)-1].Disk.Bus = bus
+		return res
+	}
+
+	makeDisksEncryptedLastDisk := func(indexes ...int) []v1.Disk {
+		res := makeDisks(indexes...)
+		res[len(res)-1].Encryption = &v1.DiskEncryption{SecretName: "luks"}
+		return res
+	}
+
+	makeUSBDisks := func(indexes ...int) []v1.Disk {
+		res := makeDisks(indexes...)
+		for i := range res {
+			res[i].Disk.Bus = "usb"
+		}
+		return res
+	}
+
 	makeDisksInvalidBootOrder := func(indexes ...int) []v1.Disk {
 		res := makeDisks(indexes...)
 		bootOrder := uint(0)
@@ -476,7 +498,35 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			makeDisksInvalidBusLastDisk(0, 1),
 			makeDisks(0),
 			makeStatus(1, 0),
-			makeExpected("hotplugged Disk volume-name-1 does not use a scsi bus", "")),
+			makeExpected("hotplugged Disk volume-name-1 does not use a scsi or usb bus", "")),
+		table.Entry("Should reject if we add disk with sata bus",
+			makeVolumes(0, 1),
+			makeVolumes(0),
+			makeDisksWithBusLastDisk("sata", 0, 1),
+			makeDisks(0),
+			makeStatus(1, 0),
+			makeExpected("hotplugged Disk volume-name-1 uses a sata bus, SATA disks can not be hotplugged, use the usb bus for guests without virtio-scsi drivers", "")),
+		table.Entry("Should reject if we add disk with usb bus to a VMI without USB controller",
+			makeVolumes(0, 1),
+			makeVolumes(0),
+			makeDisksWithBusLastDisk("usb", 0, 1),
+			makeDisks(0),
+			makeStatus(1, 0),
+			makeExpected("hotplugged Disk volume-name-1 uses a usb bus, but the VMI was started without a USB controller", "")),
+		table.Entry("Should reject if we add an encrypted disk",
+			makeVolumes(0, 1),
+			makeVolumes(0),
+			makeDisksEncryptedLastDisk(0, 1),
+			makeDisks(0),
+			makeStatus(1, 0),
+			makeExpected("hotplugged Disk volume-name-1 is encrypted, the passphrase of encrypted disks is only available at VMI start", "")),
+		table.Entry("Should accept if we add disk with usb bus to a VMI with a USB controller",
+			makeVolumes(0, 1),
+			makeVolumes(0),
+			makeUSBDisks(0, 1),
+			makeUSBDisks(0),
+			makeStatus(1, 0),
+			nil),
 		table.Entry("Should reject if we add disk with invalid boot order",
 			makeVolumes(0, 1),
 			makeVolumes(0),
@@ -486,6 +536,105 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			makeExpected("spec.domain.devices.disks[1] must have a boot order > 0, if supplied", "spec.domain.devices.disks[1].bootOrder")),
 	)
 
+	table.DescribeTable("Admit or deny interface hotplug", func(gateEnabled bool, network v1.Network, iface v1.Interface, replaceExisting bool, expectedMessage string) {
+		kvConfig := kv.DeepCopy()
+		if gateEnabled {
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.HotplugNetworkIfacesGate}
+		}
+		hotplugConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(kvConfig)
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		updateVmi := vmi.DeepCopy()
+		if replaceExisting {
+			updateVmi.Spec.Networks = []v1.Network{network}
+			updateVmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+		} else {
+			updateVmi.Spec.Networks = append(updateVmi.Spec.Networks, network)
+			updateVmi.Spec.Domain.Devices.Interfaces = append(updateVmi.Spec.Domain.Devices.Interfaces, iface)
+		}
+
+		resp := admitInterfacesHotplug(updateVmi, vmi, hotplugConfig)
+		if expectedMessage == "" {
+			Expect(resp).To(BeNil())
+		} else {
+			Expect(resp).ToNot(BeNil())
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring(expectedMessage))
+		}
+	},
+		table.Entry("Should accept an appended multus bridge interface",
+			true, v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}}, false, ""),
+		table.Entry("Should reject an appended interface without the feature gate",
+			false, v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}}, false, virtconfig.HotplugNetworkIfacesGate),
+		table.Entry("Should reject replacing an existing interface",
+			true, v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}}, true, "can not be changed or removed"),
+		table.Entry("Should reject an appended pod network",
+			true, v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+			v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}}, false, "non default multus network"),
+		table.Entry("Should reject an appended masquerade interface",
+			true, v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}}, false, "bridge binding"),
+	)
+
+	table.DescribeTable("Admit or deny interface unplug", func(network v1.Network, iface v1.Interface, expectedMessage string) {
+		kvConfig := kv.DeepCopy()
+		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.HotplugNetworkIfacesGate}
+		hotplugConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(kvConfig)
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Networks = []v1.Network{network}
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+		updateVmi := vmi.DeepCopy()
+		updateVmi.Spec.Domain.Devices.Interfaces[0].State = v1.InterfaceStateAbsent
+
+		resp := admitInterfacesHotplug(updateVmi, vmi, hotplugConfig)
+		if expectedMessage == "" {
+			Expect(resp).To(BeNil())
+		} else {
+			Expect(resp).ToNot(BeNil())
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring(expectedMessage))
+		}
+	},
+		table.Entry("Should accept unplugging a multus bridge interface",
+			v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}}, ""),
+		table.Entry("Should reject unplugging the pod network interface",
+			*v1.DefaultPodNetwork(), *v1.DefaultBridgeNetworkInterface(), "can be unplugged"),
+		table.Entry("Should reject unplugging a multus masquerade interface",
+			v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}}, "can be unplugged"),
+	)
+
+	table.DescribeTable("Admit or deny a link state change", func(oldState, newState v1.InterfaceState, expectedMessage string) {
+		linkStateConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(kv.DeepCopy())
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		vmi.Spec.Domain.Devices.Interfaces[0].State = oldState
+		updateVmi := vmi.DeepCopy()
+		updateVmi.Spec.Domain.Devices.Interfaces[0].State = newState
+
+		resp := admitInterfacesHotplug(updateVmi, vmi, linkStateConfig)
+		if expectedMessage == "" {
+			Expect(resp).To(BeNil())
+		} else {
+			Expect(resp).ToNot(BeNil())
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring(expectedMessage))
+		}
+	},
+		table.Entry("Should accept taking the link down without the hotplug feature gate", v1.InterfaceState(""), v1.InterfaceStateLinkDown, ""),
+		table.Entry("Should accept taking the link up without the hotplug feature gate", v1.InterfaceStateLinkDown, v1.InterfaceStateLinkUp, ""),
+		table.Entry("Should reject unplugging without the hotplug feature gate", v1.InterfaceStateLinkDown, v1.InterfaceStateAbsent, virtconfig.HotplugNetworkIfacesGate),
+	)
+
 	table.DescribeTable("Admit or deny based on user", func(user string, expected types.GomegaMatcher) {
 		vmi := v1.NewMinimalVMI("testvmi")
 		vmi.Spec.Volumes = makeVolumes(1)
@@ -517,4 +666,122 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		table.Entry("Should admit internal sa", "system:serviceaccount:kubevirt:"+rbac.ApiServiceAccountName, BeTrue()),
 		table.Entry("Should reject regular user", "system:serviceaccount:someNamespace:someUser", BeFalse()),
 	)
+
+	table.DescribeTable("Admit or deny switching volumes to other claims", func(migrationState *v1.VirtualMachineInstanceMigrationState, expected types.GomegaMatcher) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Volumes = []v1.Volume{
+			{
+				Name: "rootdisk",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "source-pvc"},
+				},
+			},
+		}
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "rootdisk"}}
+		vmi.Status.MigrationState = migrationState
+		updateVmi := vmi.DeepCopy()
+		updateVmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "destination-pvc"
+
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "system:serviceaccount:kubevirt:" + rbac.ControllerServiceAccountName},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: v1beta1.Update,
+			},
+		}
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(expected)
+	},
+		table.Entry("Should admit the destination claims of a completed volume migration",
+			&v1.VirtualMachineInstanceMigrationState{
+				Completed:       true,
+				MigratedVolumes: []v1.MigratedVolume{{VolumeName: "rootdisk", DestinationClaimName: "destination-pvc"}},
+			}, BeTrue()),
+		table.Entry("Should reject the destination claims of a failed volume migration",
+			&v1.VirtualMachineInstanceMigrationState{
+				Completed:       true,
+				Failed:          true,
+				MigratedVolumes: []v1.MigratedVolume{{VolumeName: "rootdisk", DestinationClaimName: "destination-pvc"}},
+			}, BeFalse()),
+		table.Entry("Should reject other claims than the destination ones",
+			&v1.VirtualMachineInstanceMigrationState{
+				Completed:       true,
+				MigratedVolumes: []v1.MigratedVolume{{VolumeName: "rootdisk", DestinationClaimName: "other-pvc"}},
+			}, BeFalse()),
+		table.Entry("Should reject other claims without a volume migration", nil, BeFalse()),
+	)
+
+	table.DescribeTable("Admit or deny a CD-ROM media change", func(changeMedia func(vmi *v1.VirtualMachineInstance), expectedMessage string) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Volumes = []v1.Volume{
+			{Name: "rootdisk", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fedora"}}},
+			{Name: "cdrom", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "iso"}}},
+		}
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+			{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}},
+			{Name: "empty-cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}},
+		}
+		updateVmi := vmi.DeepCopy()
+		changeMedia(updateVmi)
+
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "system:serviceaccount:kubevirt:" + rbac.ApiServiceAccountName},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: v1beta1.Update,
+			},
+		}
+		resp := vmiUpdateAdmitter.Admit(ar)
+		if expectedMessage == "" {
+			Expect(resp.Allowed).To(BeTrue())
+		} else {
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring(expectedMessage))
+		}
+	},
+		table.Entry("Should accept inserting a PVC into an empty CD-ROM", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "empty-cdrom",
+				VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "iso2"}},
+			})
+		}, ""),
+		table.Entry("Should accept inserting a DataVolume into an empty CD-ROM", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "empty-cdrom",
+				VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "iso-dv"}},
+			})
+		}, ""),
+		table.Entry("Should accept ejecting the media of a CD-ROM", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Volumes = vmi.Spec.Volumes[:1]
+		}, ""),
+		table.Entry("Should reject inserting a containerDisk into an empty CD-ROM", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "empty-cdrom",
+				VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "iso"}},
+			})
+		}, "media of CD-ROM empty-cdrom is not a PVC or DataVolume"),
+		table.Entry("Should reject changing a CD-ROM", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Disks[2].CDRom.Bus = "scsi"
+		}, "CD-ROM empty-cdrom, changed"),
+		table.Entry("Should reject removing a CD-ROM", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Disks = vmi.Spec.Domain.Devices.Disks[:2]
+		}, "CD-ROM empty-cdrom, changed"),
+	)
 })
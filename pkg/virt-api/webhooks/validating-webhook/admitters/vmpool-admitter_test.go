@@ -0,0 +1,130 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating VirtualMachinePool Admitter", func() {
+	config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+	admitter := &VMPoolAdmitter{ClusterConfig: config}
+
+	newPool := func() *poolv1.VirtualMachinePool {
+		running := false
+		replicas := int32(2)
+		return &poolv1.VirtualMachinePool{
+			Spec: poolv1.VirtualMachinePoolSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"match": "me"},
+				},
+				VirtualMachineTemplate: &poolv1.VirtualMachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"match": "me"},
+					},
+					Spec: v1.VirtualMachineSpec{
+						Running:  &running,
+						Template: newVirtualMachineBuilder().WithLabel("match", "me").BuildTemplate(),
+					},
+				},
+			},
+		}
+	}
+
+	It("should reject invalid request resource", func() {
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineGroupVersionResource,
+			},
+		}
+
+		resp := admitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+	})
+
+	It("should accept a valid pool", func() {
+		resp := admitter.Admit(createPoolAdmissionReview(newPool()))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	table.DescribeTable("should reject an invalid spec", func(update func(*poolv1.VirtualMachinePool), field string) {
+		pool := newPool()
+		update(pool)
+
+		resp := admitter.Admit(createPoolAdmissionReview(pool))
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+	},
+		table.Entry("with negative replicas", func(pool *poolv1.VirtualMachinePool) {
+			replicas := int32(-1)
+			pool.Spec.Replicas = &replicas
+		}, "spec.replicas"),
+		table.Entry("with a missing template", func(pool *poolv1.VirtualMachinePool) {
+			pool.Spec.VirtualMachineTemplate = nil
+		}, "spec.virtualMachineTemplate"),
+		table.Entry("with an invalid vm spec", func(pool *poolv1.VirtualMachinePool) {
+			pool.Spec.VirtualMachineTemplate.Spec.Template = nil
+		}, "spec.virtualMachineTemplate.spec.template"),
+		table.Entry("with a missing selector", func(pool *poolv1.VirtualMachinePool) {
+			pool.Spec.Selector = nil
+		}, "spec.selector"),
+		table.Entry("with an empty selector", func(pool *poolv1.VirtualMachinePool) {
+			pool.Spec.Selector = &metav1.LabelSelector{}
+		}, "spec.selector"),
+		table.Entry("with mismatching label selectors", func(pool *poolv1.VirtualMachinePool) {
+			pool.Spec.Selector.MatchLabels = map[string]string{"match": "not"}
+		}, "spec.selector"),
+	)
+})
+
+func createPoolAdmissionReview(pool *poolv1.VirtualMachinePool) *v1beta1.AdmissionReview {
+	bytes, _ := json.Marshal(pool)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Create,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "pool.kubevirt.io",
+				Resource: "virtualmachinepools",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"reflect"
 	"strings"
 
@@ -38,19 +39,22 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/util/net/macpool"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
-var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1.RunStrategyManual, v1.RunStrategyAlways, v1.RunStrategyRerunOnFailure}
+var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1.RunStrategyManual, v1.RunStrategyAlways, v1.RunStrategyRerunOnFailure, v1.RunStrategyOnce}
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
 
 type VMsAdmitter struct {
-	ClusterConfig *virtconfig.ClusterConfig
-	cloneAuthFunc CloneAuthFunc
-	virtClient    kubecli.KubevirtClient
+	ClusterConfig       *virtconfig.ClusterConfig
+	InstancetypeMethods instancetype.Methods
+	cloneAuthFunc       CloneAuthFunc
+	virtClient          kubecli.KubevirtClient
 }
 
 type sarProxy struct {
@@ -65,8 +69,9 @@ func NewVMsAdmitter(clusterConfig *virtconfig.ClusterConfig, client kubecli.Kube
 	proxy := &sarProxy{client: client}
 
 	return &VMsAdmitter{
-		ClusterConfig: clusterConfig,
-		virtClient:    client,
+		ClusterConfig:       clusterConfig,
+		InstancetypeMethods: instancetype.NewMethods(client),
+		virtClient:          client,
 		cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
@@ -92,11 +97,24 @@ func (admitter *VMsAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 
-	causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, admitter.ClusterConfig, accountName)
+	// Validate the VM as it will be started, with its instancetype and preference expanded
+	causes := admitter.applyInstancetypeToVm(&vm)
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	causes = ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, admitter.ClusterConfig, accountName)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	causes, err = admitter.validateMacAddressConflicts(ar.Request, &vm)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	} else if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	causes, err = admitter.authorizeVirtualMachineSpec(ar.Request, &vm)
 	if err != nil {
 		return webhookutils.ToAdmissionResponseError(err)
@@ -127,6 +145,42 @@ func (admitter *VMsAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 	return &reviewResponse
 }
 
+func (admitter *VMsAdmitter) applyInstancetypeToVm(vm *v1.VirtualMachine) []metav1.StatusCause {
+	if vm.Spec.Template == nil || (vm.Spec.Instancetype == nil && vm.Spec.Preference == nil) {
+		return nil
+	}
+
+	instancetypeSpec, err := admitter.InstancetypeMethods.FindInstancetypeSpec(vm)
+	if err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueNotFound,
+			Message: fmt.Sprintf("Failure to find instancetype: %v", err),
+			Field:   k8sfield.NewPath("spec", "instancetype").String(),
+		}}
+	}
+
+	preferenceSpec, err := admitter.InstancetypeMethods.FindPreferenceSpec(vm)
+	if err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueNotFound,
+			Message: fmt.Sprintf("Failure to find preference: %v", err),
+			Field:   k8sfield.NewPath("spec", "preference").String(),
+		}}
+	}
+
+	conflicts := admitter.InstancetypeMethods.ApplyToVmi(k8sfield.NewPath("spec", "template", "spec"), instancetypeSpec, preferenceSpec, &vm.Spec.Template.Spec)
+
+	var causes []metav1.StatusCause
+	for _, conflict := range conflicts {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "VM field conflicts with selected instancetype",
+			Field:   conflict.String(),
+		})
+	}
+	return causes
+}
+
 func (admitter *VMsAdmitter) AdmitStatus(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
 	vm, _, err := webhookutils.GetVMFromAdmissionReview(ar)
 	if err != nil {
@@ -155,6 +209,54 @@ func (admitter *VMsAdmitter) AdmitStatus(ar *v1beta1.AdmissionReview) *v1beta1.A
 	return &reviewResponse
 }
 
+// validateMacAddressConflicts rejects the MAC addresses which are already used by other VMs, when the MAC pool is enabled.
+// On update only the addresses which the VM did not have before are checked.
+func (admitter *VMsAdmitter) validateMacAddressConflicts(ar *v1beta1.AdmissionRequest, vm *v1.VirtualMachine) ([]metav1.StatusCause, error) {
+	if admitter.ClusterConfig.GetMacPoolConfiguration() == nil || vm.Spec.Template == nil {
+		return nil, nil
+	}
+
+	previousMacAddresses := map[string]bool{}
+	if ar.Operation == v1beta1.Update {
+		oldVM := &v1.VirtualMachine{}
+		if err := json.Unmarshal(ar.OldObject.Raw, oldVM); err != nil {
+			return nil, err
+		}
+		macAddresses, _ := macpool.MacAddressIndexFunc(oldVM)
+		for _, mac := range macAddresses {
+			previousMacAddresses[mac] = true
+		}
+	}
+
+	var changed []int
+	for idx, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+		if mac, err := net.ParseMAC(iface.MacAddress); err == nil && !previousMacAddresses[mac.String()] {
+			changed = append(changed, idx)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	conflicts, err := macpool.FindConflicts(webhooks.GetInformers().VMInformer.GetIndexer(), vm)
+	if err != nil {
+		return nil, err
+	}
+
+	var causes []metav1.StatusCause
+	for _, idx := range changed {
+		iface := vm.Spec.Template.Spec.Domain.Devices.Interfaces[idx]
+		if owner, exists := conflicts[idx]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("MAC address %s is already used by VirtualMachine %s", iface.MacAddress, owner),
+				Field:   k8sfield.NewPath("spec", "template", "spec", "domain", "devices", "interfaces").Index(idx).Child("macAddress").String(),
+			})
+		}
+	}
+	return causes, nil
+}
+
 func (admitter *VMsAdmitter) authorizeVirtualMachineSpec(ar *v1beta1.AdmissionRequest, vm *v1.VirtualMachine) ([]metav1.StatusCause, error) {
 	var causes []metav1.StatusCause
 
@@ -221,6 +323,7 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 
 	causes = append(causes, ValidateVirtualMachineInstanceMetadata(field.Child("template", "metadata"), &spec.Template.ObjectMeta, config, accountName)...)
 	causes = append(causes, ValidateVirtualMachineInstanceSpec(field.Child("template", "spec"), &spec.Template.Spec, config)...)
+	causes = append(causes, ValidateServiceMeshCompatibility(field.Child("template", "spec"), &spec.Template.ObjectMeta, &spec.Template.Spec)...)
 
 	if len(spec.DataVolumeTemplates) > 0 {
 
@@ -289,6 +392,22 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 		}
 	}
 
+	if spec.TTLSecondsAfterCompletion != nil {
+		if spec.RunStrategy == nil || *spec.RunStrategy != v1.RunStrategyOnce {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("TTLSecondsAfterCompletion is only supported with RunStrategy %s", v1.RunStrategyOnce),
+				Field:   field.Child("ttlSecondsAfterCompletion").String(),
+			})
+		} else if *spec.TTLSecondsAfterCompletion < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "TTLSecondsAfterCompletion must not be negative",
+				Field:   field.Child("ttlSecondsAfterCompletion").String(),
+			})
+		}
+	}
+
 	return causes
 }
 
@@ -364,10 +483,10 @@ func (admitter *VMsAdmitter) validateVolumeRequests(ar *v1beta1.AdmissionRequest
 					Message: fmt.Sprintf("AddVolume request for [%s] requires diskDevice of type 'disk' to be used.", name),
 					Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
 				}}, nil
-			} else if volumeRequest.AddVolumeOptions.Disk.DiskDevice.Disk.Bus != "scsi" {
+			} else if bus := volumeRequest.AddVolumeOptions.Disk.DiskDevice.Disk.Bus; bus != "scsi" && bus != "usb" {
 				return []metav1.StatusCause{{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("AddVolume request for [%s] requires disk bus to be 'scsi'. [%s] is not permitted", name, volumeRequest.AddVolumeOptions.Disk.DiskDevice.Disk.Bus),
+					Message: fmt.Sprintf("AddVolume request for [%s] requires disk bus to be 'scsi' or 'usb'. [%s] is not permitted", name, bus),
 					Field:   k8sfield.NewPath("Status", "volumeRequests").String(),
 				}}, nil
 			}
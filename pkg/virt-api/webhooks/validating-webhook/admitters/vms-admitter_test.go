@@ -42,8 +42,11 @@ import (
 	authv1 "k8s.io/api/authentication/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -73,7 +76,8 @@ var _ = Describe("Validating VM Admitter", func() {
 		virtClient = kubecli.NewMockKubevirtClient(ctrl)
 		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 		vmsAdmitter = &VMsAdmitter{
-			ClusterConfig: config,
+			ClusterConfig:       config,
+			InstancetypeMethods: instancetype.NewMethods(virtClient),
 			cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 				return true, "", nil
 			},
@@ -116,6 +120,77 @@ var _ = Describe("Validating VM Admitter", func() {
 		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.template.spec.domain.devices.disks[0].name"))
 	})
 
+	Context("with an instancetype", func() {
+		var vm *v1.VirtualMachine
+
+		admitVM := func() *v1beta1.AdmissionResponse {
+			vmBytes, _ := json.Marshal(vm)
+			return vmsAdmitter.Admit(&v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmBytes,
+					},
+				},
+			})
+		}
+
+		BeforeEach(func() {
+			kubevirtClient := kubevirtfake.NewSimpleClientset(&instancetypev1alpha1.VirtualMachineClusterInstancetype{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "instancetype",
+				},
+				Spec: instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+					CPU: instancetypev1alpha1.CPUInstancetype{
+						Guest: 2,
+					},
+					Memory: instancetypev1alpha1.MemoryInstancetype{
+						Guest: resource.MustParse("128Mi"),
+					},
+				},
+			})
+			virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(
+				kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes()).AnyTimes()
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Resources = v1.ResourceRequirements{}
+			vm = &v1.VirtualMachine{
+				Spec: v1.VirtualMachineSpec{
+					Running: &notRunning,
+					Instancetype: &v1.InstancetypeMatcher{
+						Name: "instancetype",
+					},
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: vmi.Spec,
+					},
+				},
+			}
+		})
+
+		It("should accept a VM referencing an existing instancetype", func() {
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject a VM referencing a missing instancetype", func() {
+			vm.Spec.Instancetype.Name = "missing"
+
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.instancetype"))
+		})
+
+		It("should reject a VM conflicting with the instancetype", func() {
+			vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Cores: 4}
+
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.template.spec.domain.cpu"))
+		})
+	})
+
 	It("should accept valid vmi spec", func() {
 		vmi := v1.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -151,6 +226,32 @@ var _ = Describe("Validating VM Admitter", func() {
 		Expect(resp.Allowed).To(BeTrue())
 	})
 
+	table.DescribeTable("should validate the TTL after completion", func(runStrategy v1.VirtualMachineRunStrategy, ttl int32, expectedCause string) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				RunStrategy:               &runStrategy,
+				TTLSecondsAfterCompletion: &ttl,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+		}
+
+		causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, config, "fake-account")
+		if expectedCause == "" {
+			Expect(causes).To(BeEmpty())
+		} else {
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.ttlSecondsAfterCompletion"))
+			Expect(causes[0].Message).To(ContainSubstring(expectedCause))
+		}
+	},
+		table.Entry("accept it with RunStrategy Once", v1.RunStrategyOnce, int32(60), ""),
+		table.Entry("reject it with another RunStrategy", v1.RunStrategyManual, int32(60), "only supported with RunStrategy Once"),
+		table.Entry("reject a negative TTL", v1.RunStrategyOnce, int32(-1), "must not be negative"),
+	)
+
 	table.DescribeTable("should validate VolumeRequest on running vm", func(requests []v1.VirtualMachineVolumeRequest, isValid bool) {
 		vmi := v1.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -988,6 +1089,116 @@ var _ = Describe("Validating VM Admitter", func() {
 		})
 	})
 
+	Context("with a MAC pool", func() {
+		var vm *v1.VirtualMachine
+		var otherVM *v1.VirtualMachine
+
+		newVM := func(name, macAddress string) *v1.VirtualMachine {
+			vmi := v1.NewMinimalVMI(name)
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = macAddress
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			return &v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+				Spec: v1.VirtualMachineSpec{
+					Running:  &notRunning,
+					Template: &v1.VirtualMachineInstanceTemplateSpec{Spec: vmi.Spec},
+				},
+			}
+		}
+
+		admitVM := func() *v1beta1.AdmissionResponse {
+			vmBytes, _ := json.Marshal(vm)
+			return vmsAdmitter.Admit(&v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmBytes,
+					},
+				},
+			})
+		}
+
+		updateVM := func(oldVM *v1.VirtualMachine) *v1beta1.AdmissionResponse {
+			oldVMBytes, _ := json.Marshal(oldVM)
+			vmBytes, _ := json.Marshal(vm)
+			return vmsAdmitter.Admit(&v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Operation: v1beta1.Update,
+					Resource:  webhooks.VirtualMachineGroupVersionResource,
+					Object:    runtime.RawExtension{Raw: vmBytes},
+					OldObject: runtime.RawExtension{Raw: oldVMBytes},
+				},
+			})
+		}
+
+		enableMacPool := func() {
+			vmsAdmitter.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						NetworkConfiguration: &v1.NetworkConfiguration{
+							MacPool: &v1.MacPoolConfiguration{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:00:00:ff:ff:ff"},
+						},
+					},
+				},
+				Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeploying},
+			})
+		}
+
+		BeforeEach(func() {
+			otherVM = newVM("othervm", "02:00:00:00:00:01")
+			Expect(webhooks.GetInformers().VMInformer.GetIndexer().Add(otherVM)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(webhooks.GetInformers().VMInformer.GetIndexer().Delete(otherVM)).To(Succeed())
+		})
+
+		It("should reject a MAC address used by another VM", func() {
+			enableMacPool()
+			vm = newVM("testvm", "02:00:00:00:00:01")
+			resp := admitVM()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.template.spec.domain.devices.interfaces[0].macAddress"))
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("default/othervm"))
+		})
+
+		It("should accept a MAC address which is not used by another VM", func() {
+			enableMacPool()
+			vm = newVM("testvm", "02:00:00:00:00:02")
+			Expect(admitVM().Allowed).To(BeTrue())
+		})
+
+		It("should accept the MAC address of the VM itself on update", func() {
+			enableMacPool()
+			vm = otherVM
+			Expect(admitVM().Allowed).To(BeTrue())
+		})
+
+		It("should accept a MAC address the VM already had before the update", func() {
+			enableMacPool()
+			oldVM := newVM("testvm", "02:00:00:00:00:01")
+			vm = newVM("testvm", "02:00:00:00:00:01")
+			Expect(updateVM(oldVM).Allowed).To(BeTrue())
+		})
+
+		It("should reject a MAC address used by another VM which is added by an update", func() {
+			enableMacPool()
+			oldVM := newVM("testvm", "02:00:00:00:00:02")
+			vm = newVM("testvm", "02:00:00:00:00:01")
+			resp := updateVM(oldVM)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		})
+
+		It("should not check the MAC addresses when the MAC pool is disabled", func() {
+			vm = newVM("testvm", "02:00:00:00:00:01")
+			Expect(admitVM().Allowed).To(BeTrue())
+		})
+	})
+
 	Context("with Volume", func() {
 
 		BeforeEach(func() {
@@ -89,7 +89,7 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.
 		case v1.GroupName:
 			switch vmSnapshot.Spec.Source.Kind {
 			case "VirtualMachine":
-				causes, err = admitter.validateCreateVM(sourceField.Child("name"), ar.Request.Namespace, vmSnapshot.Spec.Source.Name)
+				causes, err = admitter.validateCreateVM(sourceField.Child("name"), ar.Request.Namespace, vmSnapshot)
 				if err != nil {
 					return webhookutils.ToAdmissionResponseError(err)
 				}
@@ -142,7 +142,8 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.
 	return &reviewResponse
 }
 
-func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, namespace, name string) ([]metav1.StatusCause, error) {
+func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, namespace string, vmSnapshot *snapshotv1.VirtualMachineSnapshot) ([]metav1.StatusCause, error) {
+	name := vmSnapshot.Spec.Source.Name
 	vm, err := admitter.Client.VirtualMachine(namespace).Get(name, &metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		return []metav1.StatusCause{
@@ -158,21 +159,23 @@ func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, names
 		return nil, err
 	}
 
-	var causes []metav1.StatusCause
-
-	rs, err := vm.RunStrategy()
-	if err != nil {
+	// running VMs are snapshotted online, the guest filesystems
+	// are frozen if the guest agent is connected
+	if _, err := vm.RunStrategy(); err != nil {
 		return nil, err
 	}
 
-	if rs != v1.RunStrategyHalted {
-		cause := metav1.StatusCause{
-			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf("VirtualMachine %q is running", name),
-			Field:   field.String(),
-		}
-		causes = append(causes, cause)
+	includeMemoryDump := vmSnapshot.Spec.IncludeMemoryDump
+	if includeMemoryDump != nil && *includeMemoryDump &&
+		(vm.Spec.Template == nil || vm.Spec.Template.Spec.Domain.Devices.MemoryDump == nil) {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("VirtualMachine %q has no memoryDump device to store the memory dump on", name),
+				Field:   k8sfield.NewPath("spec", "includeMemoryDump").String(),
+			},
+		}, nil
 	}
 
-	return causes, nil
+	return nil, nil
 }
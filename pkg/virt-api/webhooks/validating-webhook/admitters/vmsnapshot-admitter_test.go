@@ -189,7 +189,7 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 				}
 			})
 
-			It("should reject when VM is running", func() {
+			It("should accept when VM is running", func() {
 				snapshot := &snapshotv1.VirtualMachineSnapshot{
 					Spec: snapshotv1.VirtualMachineSnapshotSpec{
 						Source: corev1.TypedLocalObjectReference{
@@ -205,9 +205,7 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 
 				ar := createSnapshotAdmissionReview(snapshot)
 				resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
-				Expect(resp.Allowed).To(BeFalse())
-				Expect(len(resp.Result.Details.Causes)).To(Equal(1))
-				Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source.name"))
+				Expect(resp.Allowed).To(BeTrue())
 			})
 
 			It("should reject invalid kind", func() {
@@ -271,6 +269,42 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 				resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
 				Expect(resp.Allowed).To(BeTrue())
 			})
+
+			Context("with a memory dump", func() {
+				var snapshot *snapshotv1.VirtualMachineSnapshot
+
+				BeforeEach(func() {
+					t := true
+					snapshot = &snapshotv1.VirtualMachineSnapshot{
+						Spec: snapshotv1.VirtualMachineSnapshotSpec{
+							Source: corev1.TypedLocalObjectReference{
+								APIGroup: &apiGroup,
+								Kind:     "VirtualMachine",
+								Name:     vmName,
+							},
+							IncludeMemoryDump: &t,
+						},
+					}
+					vm.Spec.Running = &t
+					vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{}
+				})
+
+				It("should accept when the VM has a memory dump PVC", func() {
+					vm.Spec.Template.Spec.Domain.Devices.MemoryDump = &v1.MemoryDump{ClaimName: "dumps"}
+
+					ar := createSnapshotAdmissionReview(snapshot)
+					resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+
+				It("should reject when the VM has no memory dump PVC", func() {
+					ar := createSnapshotAdmissionReview(snapshot)
+					resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.includeMemoryDump"))
+				})
+			})
 		})
 	})
 })
@@ -0,0 +1,155 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineSnapshotSchedule Admitter", func() {
+	config, configMapInformer, _, _ := testutils.NewFakeClusterConfig(&corev1.ConfigMap{})
+	admitter := &VMSnapshotScheduleAdmitter{Config: config}
+
+	newSchedule := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+		apiGroup := "kubevirt.io"
+		retention := int32(3)
+		policy := snapshotv1.QuiesceRequired
+		return &snapshotv1.VirtualMachineSnapshotSchedule{
+			Spec: snapshotv1.VirtualMachineSnapshotScheduleSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "VirtualMachine",
+					Name:     "vm",
+				},
+				Schedule:      "0 3 * * *",
+				Retention:     &retention,
+				QuiescePolicy: &policy,
+			},
+		}
+	}
+
+	It("should reject anything without feature gate enabled", func() {
+		resp := admitter.Admit(createSnapshotScheduleAdmissionReview(newSchedule()))
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).Should(Equal("snapshot feature gate not enabled"))
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: "Snapshot"},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := admitter.Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		table.DescribeTable("should accept a valid schedule", func(expression string) {
+			schedule := newSchedule()
+			schedule.Spec.Schedule = expression
+
+			resp := admitter.Admit(createSnapshotScheduleAdmissionReview(schedule))
+			Expect(resp.Allowed).To(BeTrue())
+		},
+			table.Entry("with a cron expression", "0 3 * * *"),
+			table.Entry("with lists, ranges and steps", "*/15 8-18 * * 1-5,6"),
+			table.Entry("with a macro", "@daily"),
+		)
+
+		table.DescribeTable("should reject an invalid spec", func(update func(*snapshotv1.VirtualMachineSnapshotSchedule), field string) {
+			schedule := newSchedule()
+			update(schedule)
+
+			resp := admitter.Admit(createSnapshotScheduleAdmissionReview(schedule))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("with a missing apiGroup", func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+				schedule.Spec.Source.APIGroup = nil
+			}, "spec.source.apiGroup"),
+			table.Entry("with an invalid kind", func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+				schedule.Spec.Source.Kind = "VirtualMachineInstance"
+			}, "spec.source.kind"),
+			table.Entry("with an invalid cron expression", func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+				schedule.Spec.Schedule = "0 25 * * *"
+			}, "spec.schedule"),
+			table.Entry("with an empty cron expression", func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+				schedule.Spec.Schedule = ""
+			}, "spec.schedule"),
+			table.Entry("with zero retention", func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+				retention := int32(0)
+				schedule.Spec.Retention = &retention
+			}, "spec.retention"),
+			table.Entry("with an unknown quiesce policy", func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+				policy := snapshotv1.QuiescePolicy("Never")
+				schedule.Spec.QuiescePolicy = &policy
+			}, "spec.quiescePolicy"),
+			table.Entry("with an unknown deletion policy", func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+				policy := snapshotv1.DeletionPolicy("Keep")
+				schedule.Spec.DeletionPolicy = &policy
+			}, "spec.deletionPolicy"),
+		)
+	})
+})
+
+func createSnapshotScheduleAdmissionReview(schedule *snapshotv1.VirtualMachineSnapshotSchedule) *v1beta1.AdmissionReview {
+	bytes, _ := json.Marshal(schedule)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: v1beta1.Create,
+			Namespace: "default",
+			Resource: metav1.GroupVersionResource{
+				Group:    "snapshot.kubevirt.io",
+				Resource: "virtualmachinesnapshotschedules",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
@@ -64,6 +64,22 @@ func ServeVMRestores(resp http.ResponseWriter, req *http.Request, clusterConfig
 	validating_webhooks.Serve(resp, req, admitters.NewVMRestoreAdmitter(clusterConfig, virtCli))
 }
 
+func ServeVMClones(resp http.ResponseWriter, req *http.Request) {
+	validating_webhooks.Serve(resp, req, &admitters.VMCloneAdmitter{})
+}
+
+func ServeVMPools(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
+	validating_webhooks.Serve(resp, req, &admitters.VMPoolAdmitter{ClusterConfig: clusterConfig})
+}
+
+func ServeVMSnapshotSchedules(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
+	validating_webhooks.Serve(resp, req, &admitters.VMSnapshotScheduleAdmitter{Config: clusterConfig})
+}
+
+func ServeStorageCheckups(resp http.ResponseWriter, req *http.Request) {
+	validating_webhooks.Serve(resp, req, &admitters.StorageCheckupAdmitter{})
+}
+
 func ServeStatusValidation(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient) {
 	validating_webhooks.Serve(resp, req, &admitters.StatusAdmitter{
 		VmsAdmitter: admitters.NewVMsAdmitter(clusterConfig, virtCli),
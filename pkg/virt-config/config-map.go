@@ -22,6 +22,7 @@ package virtconfig
 import (
 	"encoding/json"
 	"fmt"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -61,6 +62,7 @@ const (
 	MemBalloonStatsPeriod             = "memBalloonStatsPeriod"
 	CPUAllocationRatio                = "cpu-allocation-ratio"
 	PermittedHostDevicesKey           = "permittedHostDevices"
+	VMStateStorageClassKey            = "vmStateStorageClass"
 )
 
 type ConfigModifiedFn func()
@@ -169,7 +171,6 @@ func defaultClusterConfig() *v1.KubeVirtConfiguration {
 	progressTimeout := MigrationProgressTimeout
 	completionTimeoutPerGiB := MigrationCompletionTimeoutPerGiB
 	cpuRequestDefault := resource.MustParse(DefaultCPURequest)
-	emulatedMachinesDefault := strings.Split(DefaultEmulatedMachines, ",")
 	nodeSelectorsDefault, _ := parseNodeSelectors(DefaultNodeSelectors)
 	defaultNetworkInterface := DefaultNetworkInterface
 	defaultMemBalloonStatsPeriod := DefaultMemBalloonStatsPeriod
@@ -207,9 +208,7 @@ func defaultClusterConfig() *v1.KubeVirtConfiguration {
 			AllowAutoConverge:                 &allowAutoConverge,
 			AllowPostCopy:                     &allowPostCopy,
 		},
-		MachineType:      DefaultMachineType,
-		CPURequest:       &cpuRequestDefault,
-		EmulatedMachines: emulatedMachinesDefault,
+		CPURequest: &cpuRequestDefault,
 		NetworkConfiguration: &v1.NetworkConfiguration{
 			NetworkInterface:                  defaultNetworkInterface,
 			PermitSlirpInterface:              pointer.BoolPtr(DefaultPermitSlirpInterface),
@@ -218,8 +217,23 @@ func defaultClusterConfig() *v1.KubeVirtConfiguration {
 		SMBIOSConfig:                SmbiosDefaultConfig,
 		SELinuxLauncherType:         DefaultSELinuxLauncherType,
 		SupportedGuestAgentVersions: supportedQEMUGuestAgentVersions,
-		OVMFPath:                    DefaultOVMFPath,
 		MemBalloonStatsPeriod:       &defaultMemBalloonStatsPeriod,
+		ArchitectureConfiguration: &v1.ArchConfiguration{
+			Amd64:               defaultArchConfiguration("amd64", DefaultOVMFPath),
+			Arm64:               defaultArchConfiguration("arm64", DefaultARM64OVMFPath),
+			Ppc64le:             defaultArchConfiguration("ppc64le", DefaultOVMFPath),
+			S390x:               defaultArchConfiguration("s390x", ""),
+			DefaultArchitecture: runtime.GOARCH,
+		},
+	}
+}
+
+func defaultArchConfiguration(arch string, ovmfPath string) *v1.ArchSpecificConfiguration {
+	machineType, emulatedMachines := getDefaultMachinesForArch(arch)
+	return &v1.ArchSpecificConfiguration{
+		MachineType:      machineType,
+		EmulatedMachines: strings.Split(emulatedMachines, ","),
+		OVMFPath:         ovmfPath,
 	}
 }
 
@@ -256,6 +270,8 @@ type migrationConfiguration struct {
 	ProgressTimeout                   *int64             `json:"progressTimeout,string,omitempty"`
 	UnsafeMigrationOverride           *bool              `json:"unsafeMigrationOverride,string,omitempty"`
 	AllowPostCopy                     *bool              `json:"allowPostCopy,string,omitempty"`
+	// Network only keeps the struct convertible to v1.MigrationConfiguration, it is meant to be set on the KubeVirt CR
+	Network *string `json:"-"`
 }
 
 // setConfigFromConfigMap parses the provided config map and updates the provided config.
@@ -429,6 +445,10 @@ func setConfigFromConfigMap(config *v1.KubeVirtConfiguration, configMap *k8sv1.C
 		config.OVMFPath = ovmfPath
 	}
 
+	if vmStateStorageClass := strings.TrimSpace(configMap.Data[VMStateStorageClassKey]); vmStateStorageClass != "" {
+		config.VMStateStorageClass = vmStateStorageClass
+	}
+
 	if memBalloonStatsPeriod := strings.TrimSpace(configMap.Data[MemBalloonStatsPeriod]); memBalloonStatsPeriod != "" {
 		i, err := strconv.Atoi(memBalloonStatsPeriod)
 		if err != nil {
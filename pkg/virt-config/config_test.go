@@ -2,6 +2,7 @@ package virtconfig_test
 
 import (
 	"encoding/json"
+	"runtime"
 	"strings"
 
 	. "github.com/onsi/ginkgo"
@@ -128,7 +129,7 @@ var _ = Describe("ConfigMap", func() {
 		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
 			Data: map[string]string{virtconfig.MachineTypeKey: value},
 		})
-		Expect(clusterConfig.GetMachineType()).To(Equal(result))
+		Expect(clusterConfig.GetMachineType("")).To(Equal(result))
 	},
 		table.Entry("when set, GetMachineType should return the value", "pc-q35-3.0", "pc-q35-3.0"),
 		table.Entry("when unset, GetMachineType should return the default", "", virtconfig.DefaultMachineType),
@@ -165,11 +166,36 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when unset, GetMemoryOvercommit should return the default", "", virtconfig.DefaultMemoryOvercommit),
 	)
 
+	table.DescribeTable("when the memory overcommit policy", func(policy *v1.MemoryOvercommitPolicy, ratio int, overcommitGuestOverhead bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{MemoryOvercommit: 120},
+					MemoryOvercommitPolicy: policy,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.GetMemoryOvercommit()).To(Equal(ratio))
+		Expect(clusterConfig.IsGuestOverheadOvercommitted()).To(Equal(overcommitGuestOverhead))
+	},
+		table.Entry("is not set, should use the developer configuration", nil, 120, false),
+		table.Entry("sets a ratio, should take precedence", &v1.MemoryOvercommitPolicy{Ratio: 150}, 150, false),
+		table.Entry("sets an invalid ratio, should ignore it", &v1.MemoryOvercommitPolicy{Ratio: 50}, 120, false),
+		table.Entry("overcommits the guest overhead", &v1.MemoryOvercommitPolicy{OvercommitGuestOverhead: true}, 120, true),
+	)
+
 	table.DescribeTable(" when emulatedMachines", func(value string, result []string) {
 		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
 			Data: map[string]string{virtconfig.EmulatedMachinesKey: value},
 		})
-		emulatedMachines := clusterConfig.GetEmulatedMachines()
+		emulatedMachines := clusterConfig.GetEmulatedMachines("")
 		Expect(emulatedMachines).To(ConsistOf(result))
 	},
 		table.Entry("when set, GetEmulatedMachines should return the value", "q35, i440*", []string{"q35", "i440*"}),
@@ -286,7 +312,7 @@ var _ = Describe("ConfigMap", func() {
 
 	It("should contain a default machine type that is supported by default", func() {
 		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{})
-		Expect(clusterConfig.GetMachineType()).To(testutils.SatisfyAnyRegexp(clusterConfig.GetEmulatedMachines()))
+		Expect(clusterConfig.GetMachineType("")).To(testutils.SatisfyAnyRegexp(clusterConfig.GetEmulatedMachines("")))
 	})
 
 	table.DescribeTable("SMBIOS values from kubevirt-config", func(value string, result *cmdv1.SMBios) {
@@ -318,17 +344,70 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when unset, GetSELinuxLauncherType should return the default", virtconfig.DefaultSELinuxLauncherType, virtconfig.DefaultSELinuxLauncherType),
 	)
 
+	table.DescribeTable(" when VMStateStorageClass", func(value string, result string) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.VMStateStorageClassKey: value},
+		})
+		Expect(clusterConfig.GetVMStateStorageClass()).To(Equal(result))
+	},
+		table.Entry("when set, GetVMStateStorageClass should return the value", "rook-cephfs", "rook-cephfs"),
+		table.Entry("when unset, GetVMStateStorageClass should return an empty string", "", ""),
+	)
+
 	table.DescribeTable(" when OVMFPath", func(value string, result string) {
 		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
 			Data: map[string]string{virtconfig.OVMFPathKey: value},
 		})
-		ovmfPath := clusterConfig.GetOVMFPath()
+		ovmfPath := clusterConfig.GetOVMFPath("")
 		Expect(ovmfPath).To(Equal(result))
 	},
 		table.Entry("when set, GetOVMFPath should return the value", "/usr/share/ovmf/x64", "/usr/share/ovmf/x64"),
 		table.Entry("when unset, GetOVMFPath should return the default", "", virtconfig.DefaultOVMFPath),
 	)
 
+	table.DescribeTable("architecture specific defaults", func(arch string, machineType string, emulatedMachines []string, ovmfPath string) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{})
+		Expect(clusterConfig.GetMachineType(arch)).To(Equal(machineType))
+		Expect(clusterConfig.GetEmulatedMachines(arch)).To(Equal(emulatedMachines))
+		Expect(clusterConfig.GetOVMFPath(arch)).To(Equal(ovmfPath))
+		Expect(clusterConfig.GetMachineType(arch)).To(testutils.SatisfyAnyRegexp(clusterConfig.GetEmulatedMachines(arch)))
+	},
+		table.Entry("for amd64", "amd64", "q35", []string{"q35*", "pc-q35*"}, virtconfig.DefaultOVMFPath),
+		table.Entry("for arm64", "arm64", "virt", []string{"virt*"}, virtconfig.DefaultARM64OVMFPath),
+		table.Entry("for ppc64le", "ppc64le", "pseries", []string{"pseries*"}, virtconfig.DefaultOVMFPath),
+		table.Entry("for s390x", "s390x", "s390-ccw-virtio", []string{"s390-ccw-virtio*"}, ""),
+	)
+
+	table.DescribeTable("when the architecture configuration", func(config v1.KubeVirtConfiguration, defaultArch string, arm64MachineType string, arm64EmulatedMachines []string) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: config,
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.GetDefaultArchitecture()).To(Equal(defaultArch))
+		Expect(clusterConfig.GetMachineType("arm64")).To(Equal(arm64MachineType))
+		Expect(clusterConfig.GetEmulatedMachines("arm64")).To(Equal(arm64EmulatedMachines))
+	},
+		table.Entry("overrides single values, the other defaults should stay intact",
+			v1.KubeVirtConfiguration{
+				ArchitectureConfiguration: &v1.ArchConfiguration{
+					Arm64:               &v1.ArchSpecificConfiguration{MachineType: "virt-6.2"},
+					DefaultArchitecture: "arm64",
+				},
+			}, "arm64", "virt-6.2", []string{"virt*"}),
+		table.Entry("is combined with the deprecated machine type, the deprecated machine type should take precedence",
+			v1.KubeVirtConfiguration{
+				MachineType: "pc-q35-rhel8.2.0",
+			}, runtime.GOARCH, "pc-q35-rhel8.2.0", []string{"virt*"}),
+	)
+
 	It("verifies that SetConfigModifiedCallback works as expected ", func() {
 		var callbackSet1, callbackSet2 bool
 		callback1 := func() {
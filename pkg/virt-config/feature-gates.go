@@ -28,17 +28,37 @@ const (
 	IgnitionGate      = "ExperimentalIgnitionSupport"
 	LiveMigrationGate = "LiveMigration"
 	// SRIOVLiveMigrationGate enable's Live Migration for VM's with SRIOV interfaces.
-	SRIOVLiveMigrationGate = "SRIOVLiveMigration"
-	CPUNodeDiscoveryGate   = "CPUNodeDiscovery"
-	HypervStrictCheckGate  = "HypervStrictCheck"
-	SidecarGate            = "Sidecar"
-	GPUGate                = "GPU"
-	HostDevicesGate        = "HostDevices"
-	SnapshotGate           = "Snapshot"
-	HotplugVolumesGate     = "HotplugVolumes"
-	HostDiskGate           = "HostDisk"
-	VirtIOFSGate           = "ExperimentalVirtiofsSupport"
-	MacvtapGate            = "Macvtap"
+	SRIOVLiveMigrationGate     = "SRIOVLiveMigration"
+	CPUNodeDiscoveryGate       = "CPUNodeDiscovery"
+	HypervStrictCheckGate      = "HypervStrictCheck"
+	SidecarGate                = "Sidecar"
+	GPUGate                    = "GPU"
+	HostDevicesGate            = "HostDevices"
+	SnapshotGate               = "Snapshot"
+	HotplugVolumesGate         = "HotplugVolumes"
+	HostDiskGate               = "HostDisk"
+	VirtIOFSGate               = "ExperimentalVirtiofsSupport"
+	MacvtapGate                = "Macvtap"
+	CPUHotplugGate             = "CPUHotplug"
+	MemoryHotplugGate          = "MemoryHotplug"
+	VMPersistentStateGate      = "VMPersistentState"
+	WorkloadEncryptionSEV      = "WorkloadEncryptionSEV"
+	WorkloadEncryptionTDX      = "WorkloadEncryptionTDX"
+	VSOCKGate                  = "VSOCK"
+	NUMAFeatureGate            = "NUMA"
+	VMRealtimeGate             = "VMRealtime"
+	DownwardMetricsFeatureGate = "DownwardMetrics"
+	HotplugNetworkIfacesGate   = "HotplugNICs"
+	PasstGate                  = "Passt"
+	NetworkBindingPluginsGate  = "NetworkBindingPlugins"
+	VDPAGate                   = "VDPA"
+	FlowMetricsGate            = "FlowMetrics"
+	VhostUserGate              = "VhostUser"
+	ExpandDisksGate            = "ExpandDisks"
+	VolumeMigrationGate        = "VolumeMigration"
+	IncrementalBackupGate      = "IncrementalBackup"
+	PersistentReservationGate  = "PersistentReservation"
+	ExternalSnapshotsGate      = "ExternalSnapshots"
 )
 
 func (c *ClusterConfig) isFeatureGateEnabled(featureGate string) bool {
@@ -103,6 +123,86 @@ func (config *ClusterConfig) MacvtapEnabled() bool {
 	return config.isFeatureGateEnabled(MacvtapGate)
 }
 
+func (config *ClusterConfig) PasstEnabled() bool {
+	return config.isFeatureGateEnabled(PasstGate)
+}
+
+func (config *ClusterConfig) NetworkBindingPluginsEnabled() bool {
+	return config.isFeatureGateEnabled(NetworkBindingPluginsGate)
+}
+
+func (config *ClusterConfig) VDPAEnabled() bool {
+	return config.isFeatureGateEnabled(VDPAGate)
+}
+
+func (config *ClusterConfig) FlowMetricsEnabled() bool {
+	return config.isFeatureGateEnabled(FlowMetricsGate)
+}
+
+func (config *ClusterConfig) VhostUserEnabled() bool {
+	return config.isFeatureGateEnabled(VhostUserGate)
+}
+
+func (config *ClusterConfig) ExpandDisksEnabled() bool {
+	return config.isFeatureGateEnabled(ExpandDisksGate)
+}
+
+func (config *ClusterConfig) VolumeMigrationEnabled() bool {
+	return config.isFeatureGateEnabled(VolumeMigrationGate)
+}
+
+func (config *ClusterConfig) IncrementalBackupEnabled() bool {
+	return config.isFeatureGateEnabled(IncrementalBackupGate)
+}
+
+func (config *ClusterConfig) PersistentReservationEnabled() bool {
+	return config.isFeatureGateEnabled(PersistentReservationGate)
+}
+
+func (config *ClusterConfig) ExternalSnapshotsEnabled() bool {
+	return config.isFeatureGateEnabled(ExternalSnapshotsGate)
+}
+
 func (config *ClusterConfig) HostDevicesPassthroughEnabled() bool {
 	return config.isFeatureGateEnabled(HostDevicesGate)
 }
+
+func (config *ClusterConfig) CPUHotplugEnabled() bool {
+	return config.isFeatureGateEnabled(CPUHotplugGate)
+}
+
+func (config *ClusterConfig) MemoryHotplugEnabled() bool {
+	return config.isFeatureGateEnabled(MemoryHotplugGate)
+}
+
+func (config *ClusterConfig) VMPersistentStateEnabled() bool {
+	return config.isFeatureGateEnabled(VMPersistentStateGate)
+}
+
+func (config *ClusterConfig) WorkloadEncryptionSEVEnabled() bool {
+	return config.isFeatureGateEnabled(WorkloadEncryptionSEV)
+}
+
+func (config *ClusterConfig) WorkloadEncryptionTDXEnabled() bool {
+	return config.isFeatureGateEnabled(WorkloadEncryptionTDX)
+}
+
+func (config *ClusterConfig) VSOCKEnabled() bool {
+	return config.isFeatureGateEnabled(VSOCKGate)
+}
+
+func (config *ClusterConfig) NUMAEnabled() bool {
+	return config.isFeatureGateEnabled(NUMAFeatureGate)
+}
+
+func (config *ClusterConfig) VMRealtimeEnabled() bool {
+	return config.isFeatureGateEnabled(VMRealtimeGate)
+}
+
+func (config *ClusterConfig) DownwardMetricsEnabled() bool {
+	return config.isFeatureGateEnabled(DownwardMetricsFeatureGate)
+}
+
+func (config *ClusterConfig) HotplugNetworkInterfacesEnabled() bool {
+	return config.isFeatureGateEnabled(HotplugNetworkIfacesGate)
+}
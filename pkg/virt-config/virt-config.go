@@ -42,10 +42,14 @@ const (
 	MigrationCompletionTimeoutPerGiB         int64  = 800
 	DefaultAMD64MachineType                         = "q35"
 	DefaultPPC64LEMachineType                       = "pseries"
+	DefaultARM64MachineType                         = "virt"
+	DefaultS390XMachineType                         = "s390-ccw-virtio"
 	DefaultCPURequest                               = "100m"
 	DefaultMemoryOvercommit                         = 100
 	DefaultAMD64EmulatedMachines                    = "q35*,pc-q35*"
 	DefaultPPC64LEEmulatedMachines                  = "pseries*"
+	DefaultARM64EmulatedMachines                    = "virt*"
+	DefaultS390XEmulatedMachines                    = "s390-ccw-virtio*"
 	DefaultLessPVCSpaceToleration                   = 10
 	DefaultNodeSelectors                            = ""
 	DefaultNetworkInterface                         = "bridge"
@@ -60,6 +64,7 @@ const (
 	DefaultSELinuxLauncherType                      = "virt_launcher.process"
 	SupportedGuestAgentVersions                     = "2.*,3.*,4.*"
 	DefaultOVMFPath                                 = "/usr/share/OVMF"
+	DefaultARM64OVMFPath                            = "/usr/share/AAVMF"
 	DefaultMemBalloonStatsPeriod             uint32 = 10
 	DefaultCPUAllocationRatio                       = 10
 	DefaultVirtAPILogVerbosity                      = 2
@@ -70,14 +75,19 @@ const (
 )
 
 // Set default machine type and supported emulated machines based on architecture
-func getDefaultMachinesForArch() (string, string) {
-	if runtime.GOARCH == "ppc64le" {
+func getDefaultMachinesForArch(arch string) (string, string) {
+	switch arch {
+	case "ppc64le":
 		return DefaultPPC64LEMachineType, DefaultPPC64LEEmulatedMachines
+	case "arm64":
+		return DefaultARM64MachineType, DefaultARM64EmulatedMachines
+	case "s390x":
+		return DefaultS390XMachineType, DefaultS390XEmulatedMachines
 	}
 	return DefaultAMD64MachineType, DefaultAMD64EmulatedMachines
 }
 
-var DefaultMachineType, DefaultEmulatedMachines = getDefaultMachinesForArch()
+var DefaultMachineType, DefaultEmulatedMachines = getDefaultMachinesForArch(runtime.GOARCH)
 
 func (c *ClusterConfig) GetMemBalloonStatsPeriod() uint32 {
 	return *c.GetConfig().MemBalloonStatsPeriod
@@ -101,8 +111,50 @@ func (c *ClusterConfig) GetResourceVersion() string {
 	return c.lastValidConfigResourceVersion
 }
 
-func (c *ClusterConfig) GetMachineType() string {
-	return c.GetConfig().MachineType
+// GetMachineType returns the default machine type of VMIs of the given architecture
+func (c *ClusterConfig) GetMachineType(arch string) string {
+	config := c.GetConfig()
+	if config.MachineType != "" {
+		return config.MachineType
+	}
+	return getArchConfiguration(config, arch).MachineType
+}
+
+// GetDefaultArchitecture returns the architecture of VMIs which do not request one
+func (c *ClusterConfig) GetDefaultArchitecture() string {
+	return getDefaultArchitecture(c.GetConfig())
+}
+
+func getDefaultArchitecture(config *v1.KubeVirtConfiguration) string {
+	if config.ArchitectureConfiguration != nil && config.ArchitectureConfiguration.DefaultArchitecture != "" {
+		return config.ArchitectureConfiguration.DefaultArchitecture
+	}
+	return runtime.GOARCH
+}
+
+// getArchConfiguration returns the configuration of the given architecture,
+// VMIs without an architecture use the configuration of the default architecture
+func getArchConfiguration(config *v1.KubeVirtConfiguration, arch string) *v1.ArchSpecificConfiguration {
+	if arch == "" {
+		arch = getDefaultArchitecture(config)
+	}
+	var archConfig *v1.ArchSpecificConfiguration
+	if config.ArchitectureConfiguration != nil {
+		switch arch {
+		case "amd64":
+			archConfig = config.ArchitectureConfiguration.Amd64
+		case "arm64":
+			archConfig = config.ArchitectureConfiguration.Arm64
+		case "ppc64le":
+			archConfig = config.ArchitectureConfiguration.Ppc64le
+		case "s390x":
+			archConfig = config.ArchitectureConfiguration.S390x
+		}
+	}
+	if archConfig == nil {
+		return &v1.ArchSpecificConfiguration{}
+	}
+	return archConfig
 }
 
 func (c *ClusterConfig) GetCPUModel() string {
@@ -114,11 +166,25 @@ func (c *ClusterConfig) GetCPURequest() *resource.Quantity {
 }
 
 func (c *ClusterConfig) GetMemoryOvercommit() int {
+	if policy := c.GetConfig().MemoryOvercommitPolicy; policy != nil && policy.Ratio >= 100 {
+		return policy.Ratio
+	}
 	return c.GetConfig().DeveloperConfiguration.MemoryOvercommit
 }
 
-func (c *ClusterConfig) GetEmulatedMachines() []string {
-	return c.GetConfig().EmulatedMachines
+// IsGuestOverheadOvercommitted returns true if the memory overhead of the VMIs should not be requested by their pods
+func (c *ClusterConfig) IsGuestOverheadOvercommitted() bool {
+	policy := c.GetConfig().MemoryOvercommitPolicy
+	return policy != nil && policy.OvercommitGuestOverhead
+}
+
+// GetEmulatedMachines returns the machine types VMIs of the given architecture may request
+func (c *ClusterConfig) GetEmulatedMachines(arch string) []string {
+	config := c.GetConfig()
+	if len(config.EmulatedMachines) > 0 {
+		return config.EmulatedMachines
+	}
+	return getArchConfiguration(config, arch).EmulatedMachines
 }
 
 func (c *ClusterConfig) GetLessPVCSpaceToleration() int {
@@ -145,6 +211,21 @@ func (c *ClusterConfig) IsBridgeInterfaceOnPodNetworkEnabled() bool {
 	return *c.GetConfig().NetworkConfiguration.PermitBridgeInterfaceOnPodNetwork
 }
 
+func (c *ClusterConfig) GetNetworkBindings() map[string]v1.InterfaceBindingPlugin {
+	return c.GetConfig().NetworkConfiguration.Binding
+}
+
+// GetMacPoolConfiguration returns the range MAC addresses are assigned from, or nil if the MAC pool is disabled
+func (c *ClusterConfig) GetMacPoolConfiguration() *v1.MacPoolConfiguration {
+	return c.GetConfig().NetworkConfiguration.MacPool
+}
+
+// GetNetworkAttachmentPolicy returns the policy restricting the NetworkAttachmentDefinitions of each namespace,
+// or nil if all of them are allowed
+func (c *ClusterConfig) GetNetworkAttachmentPolicy() *v1.NetworkAttachmentPolicy {
+	return c.GetConfig().NetworkConfiguration.AttachmentPolicy
+}
+
 func (c *ClusterConfig) GetDefaultClusterConfig() *v1.KubeVirtConfiguration {
 	return c.defaultConfig
 }
@@ -157,18 +238,54 @@ func (c *ClusterConfig) GetSupportedAgentVersions() []string {
 	return c.GetConfig().SupportedGuestAgentVersions
 }
 
-func (c *ClusterConfig) GetOVMFPath() string {
-	return c.GetConfig().OVMFPath
+// GetOVMFPath returns the location of the UEFI firmware for VMIs of the given architecture
+func (c *ClusterConfig) GetOVMFPath(arch string) string {
+	config := c.GetConfig()
+	if config.OVMFPath != "" {
+		return config.OVMFPath
+	}
+	return getArchConfiguration(config, arch).OVMFPath
+}
+
+func (c *ClusterConfig) GetVMStateStorageClass() string {
+	return c.GetConfig().VMStateStorageClass
 }
 
 func (c *ClusterConfig) GetCPUAllocationRatio() int {
 	return c.GetConfig().DeveloperConfiguration.CPUAllocationRatio
 }
 
+func (c *ClusterConfig) GetClusterCommonCPUNodeSelector() map[string]string {
+	if commonCPU := c.GetConfig().ClusterCommonCPU; commonCPU != nil {
+		return commonCPU.NodeSelector
+	}
+	return nil
+}
+
+func (c *ClusterConfig) GetKSMConfiguration() *v1.KSMConfiguration {
+	return c.GetConfig().KSMConfiguration
+}
+
+func (c *ClusterConfig) GetContainerDiskCacheConfiguration() *v1.ContainerDiskCacheConfiguration {
+	return c.GetConfig().ContainerDiskCache
+}
+
+func (c *ClusterConfig) GetEphemeralDiskBackings() []v1.EphemeralDiskBacking {
+	return c.GetConfig().EphemeralDiskBackings
+}
+
+func (c *ClusterConfig) GetContainerDiskConfiguration() *v1.ContainerDiskConfiguration {
+	return c.GetConfig().ContainerDisks
+}
+
 func (c *ClusterConfig) GetPermittedHostDevices() *v1.PermittedHostDevices {
 	return c.GetConfig().PermittedHostDevices
 }
 
+func (c *ClusterConfig) GetMediatedDevicesConfiguration() *v1.MediatedDevicesConfiguration {
+	return c.GetConfig().MediatedDevicesConfiguration
+}
+
 func (c *ClusterConfig) GetVirtHandlerVerbosity(nodeName string) uint {
 	logConf := c.GetConfig().DeveloperConfiguration.LogVerbosity
 	if level := logConf.NodeVerbosity[nodeName]; level != 0 {
@@ -23,16 +23,33 @@ import (
 	"encoding/json"
 	"fmt"
 
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
 )
 
+// MultusNetworkStatusAnnotation is set by multus on the pod, with the attachments of its networks
+const MultusNetworkStatusAnnotation = "k8s.v1.cni.cncf.io/network-status"
+
 type multusNetworkAnnotation struct {
-	InterfaceName string `json:"interface"`
-	Mac           string `json:"mac,omitempty"`
-	NetworkName   string `json:"name"`
-	Namespace     string `json:"namespace"`
+	InterfaceName string            `json:"interface"`
+	Mac           string            `json:"mac,omitempty"`
+	NetworkName   string            `json:"name"`
+	Namespace     string            `json:"namespace"`
+	CniArgs       map[string]string `json:"cni-args,omitempty"`
+	IPRequest     []string          `json:"ips,omitempty"`
+}
+
+type multusNetworkStatus struct {
+	InterfaceName string   `json:"interface"`
+	IPs           []string `json:"ips,omitempty"`
 }
 
+// IPClaims are the addresses allocated on the networks with persistent IPs, by network name
+type IPClaims map[string][]string
+
 type multusNetworkAnnotationPool struct {
 	pool []multusNetworkAnnotation
 }
@@ -53,13 +70,34 @@ func (mnap multusNetworkAnnotationPool) toString() (string, error) {
 	return string(multusNetworksAnnotation), nil
 }
 
-func generateMultusCNIAnnotation(vmi *v1.VirtualMachineInstance) (string, error) {
+// GenerateMultusCNIAnnotation returns the multus networks annotation of the VMI pod. Pod interface names are
+// assigned in network order, so networks appended to the VMI spec do not rename already attached interfaces.
+// Interfaces bound by a network binding plugin which comes with a NetworkAttachmentDefinition get an extra
+// attachment, through which the CNI plugin of the binding configures the pod network of the interface.
+func GenerateMultusCNIAnnotation(vmi *v1.VirtualMachineInstance, bindings map[string]v1.InterfaceBindingPlugin) (string, error) {
 	multusNetworkAnnotationPool := multusNetworkAnnotationPool{}
 
+	ipClaims := GetIPClaims(vmi)
 	multusNonDefaultNetworks := filterMultusNonDefaultNetworks(vmi.Spec.Networks)
 	for i, network := range multusNonDefaultNetworks {
-		multusNetworkAnnotationPool.add(
-			newMultusAnnotationData(vmi, network, fmt.Sprintf("net%d", i+1)))
+		if isUnpluggedFromDomain(vmi, network.Name) {
+			continue
+		}
+		multusNetworkAnnotation := newMultusAnnotationData(vmi, network, fmt.Sprintf("net%d", i+1))
+		if network.Multus.PersistentIPs {
+			multusNetworkAnnotation.IPRequest = ipClaims[network.Name]
+		}
+		multusNetworkAnnotationPool.add(multusNetworkAnnotation)
+	}
+
+	for i, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Binding == nil {
+			continue
+		}
+		if plugin, exists := bindings[iface.Binding.Name]; exists && plugin.NetworkAttachmentDefinition != "" {
+			multusNetworkAnnotationPool.add(
+				newBindingPluginAnnotationData(vmi, iface, plugin, fmt.Sprintf("binding%d", i+1)))
+		}
 	}
 
 	if !multusNetworkAnnotationPool.isEmpty() {
@@ -68,6 +106,71 @@ func generateMultusCNIAnnotation(vmi *v1.VirtualMachineInstance) (string, error)
 	return "", nil
 }
 
+// GetIPClaims returns the addresses claimed in the IP claims annotation of a VM or a VMI
+func GetIPClaims(obj metav1.Object) IPClaims {
+	ipClaims := IPClaims{}
+	annotation, exists := obj.GetAnnotations()[v1.IPClaimsAnnotation]
+	if !exists {
+		return ipClaims
+	}
+	if err := json.Unmarshal([]byte(annotation), &ipClaims); err != nil {
+		log.Log.Reason(err).Warningf("Ignoring the invalid IP claims of %s/%s", obj.GetNamespace(), obj.GetName())
+		return IPClaims{}
+	}
+	return ipClaims
+}
+
+// EncodeIPClaims returns the value of the IP claims annotation
+func EncodeIPClaims(ipClaims IPClaims) (string, error) {
+	annotation, err := json.Marshal(ipClaims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the IP claims %v: %v", ipClaims, err)
+	}
+	return string(annotation), nil
+}
+
+// GetPodIPClaims returns the addresses which multus reports for the attachments of the VMI networks with
+// persistent IPs. The attachments are found by their pod interface name.
+func GetPodIPClaims(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) (IPClaims, error) {
+	ipClaims := IPClaims{}
+	annotation, exists := pod.Annotations[MultusNetworkStatusAnnotation]
+	if !exists {
+		return ipClaims, nil
+	}
+	var networkStatuses []multusNetworkStatus
+	if err := json.Unmarshal([]byte(annotation), &networkStatuses); err != nil {
+		return nil, fmt.Errorf("failed to parse the network status of pod %s: %v", pod.Name, err)
+	}
+	ipsByPodInterfaceName := map[string][]string{}
+	for _, networkStatus := range networkStatuses {
+		ipsByPodInterfaceName[networkStatus.InterfaceName] = networkStatus.IPs
+	}
+	for i, network := range filterMultusNonDefaultNetworks(vmi.Spec.Networks) {
+		if !network.Multus.PersistentIPs {
+			continue
+		}
+		if ips := ipsByPodInterfaceName[fmt.Sprintf("net%d", i+1)]; len(ips) > 0 {
+			ipClaims[network.Name] = ips
+		}
+	}
+	return ipClaims, nil
+}
+
+// isUnpluggedFromDomain tells if the interface of the network was hot unplugged, and is not reported
+// as attached to the domain anymore. Its multus attachment can be released then.
+func isUnpluggedFromDomain(vmi *v1.VirtualMachineInstance, networkName string) bool {
+	iface := getIfaceByName(vmi, networkName)
+	if iface == nil || iface.State != v1.InterfaceStateAbsent {
+		return false
+	}
+	for _, ifaceStatus := range vmi.Status.Interfaces {
+		if ifaceStatus.Name == networkName {
+			return false
+		}
+	}
+	return true
+}
+
 func filterMultusNonDefaultNetworks(networks []v1.Network) []v1.Network {
 	var multusNetworks []v1.Network
 	for _, network := range networks {
@@ -87,6 +190,9 @@ func newMultusAnnotationData(vmi *v1.VirtualMachineInstance, network v1.Network,
 	var multusIfaceMac string
 	if multusIface != nil {
 		multusIfaceMac = multusIface.MacAddress
+		if multusIfaceMac == "" && multusIface.Macvtap != nil {
+			multusIfaceMac = getIfaceStatusMac(vmi, multusIface.Name)
+		}
 	}
 	return multusNetworkAnnotation{
 		InterfaceName: podInterfaceName,
@@ -96,6 +202,30 @@ func newMultusAnnotationData(vmi *v1.VirtualMachineInstance, network v1.Network,
 	}
 }
 
+// newBindingPluginAnnotationData returns the attachment of the binding plugin CNI. The logical network
+// of the interface is passed to the plugin as a CNI argument.
+func newBindingPluginAnnotationData(vmi *v1.VirtualMachineInstance, iface v1.Interface, plugin v1.InterfaceBindingPlugin, podInterfaceName string) multusNetworkAnnotation {
+	namespace, networkName := getNamespaceAndNetworkName(vmi, plugin.NetworkAttachmentDefinition)
+	return multusNetworkAnnotation{
+		InterfaceName: podInterfaceName,
+		Namespace:     namespace,
+		NetworkName:   networkName,
+		CniArgs:       map[string]string{"logicNetworkName": iface.Name},
+	}
+}
+
+// getIfaceStatusMac returns the MAC address reported for the interface of a running VMI.
+// A macvtap device takes the MAC of the host link it is created on, so a migration target
+// pod has to request the MAC the guest is already using.
+func getIfaceStatusMac(vmi *v1.VirtualMachineInstance, name string) string {
+	for _, ifaceStatus := range vmi.Status.Interfaces {
+		if ifaceStatus.Name == name {
+			return ifaceStatus.MAC
+		}
+	}
+	return ""
+}
+
 func getIfaceByName(vmi *v1.VirtualMachineInstance, name string) *v1.Interface {
 	for i, iface := range vmi.Spec.Domain.Devices.Interfaces {
 		if iface.Name == name {
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -84,4 +85,132 @@ var _ = Describe("Multus annotations", func() {
 			Expect(multusAnnotationPool.toString()).To(BeIdenticalTo(expectedString))
 		})
 	})
+
+	Context("with hot unplugged interfaces", func() {
+		BeforeEach(func() {
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+				{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "red", State: v1.InterfaceStateAbsent},
+				{Name: "blue"},
+			}
+		})
+
+		It("keeps the network while its interface is attached to the domain", func() {
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "red"}, {Name: "blue"}}
+			Expect(GenerateMultusCNIAnnotation(&vmi, nil)).To(Equal(
+				`[{"interface":"net1","name":"red-net","namespace":"namespace1"},{"interface":"net2","name":"blue-net","namespace":"namespace1"}]`))
+		})
+
+		It("releases the network once its interface is detached, keeping the names of the other interfaces", func() {
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "blue"}}
+			Expect(GenerateMultusCNIAnnotation(&vmi, nil)).To(Equal(
+				`[{"interface":"net2","name":"blue-net","namespace":"namespace1"}]`))
+		})
+	})
+
+	Context("with macvtap interfaces", func() {
+		BeforeEach(func() {
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "red", InterfaceBindingMethod: v1.InterfaceBindingMethod{Macvtap: &v1.InterfaceMacvtap{}}},
+			}
+		})
+
+		It("does not request a MAC address before the VMI reports one", func() {
+			Expect(GenerateMultusCNIAnnotation(&vmi, nil)).To(Equal(
+				`[{"interface":"net1","name":"red-net","namespace":"namespace1"}]`))
+		})
+
+		It("requests the MAC address reported by the running VMI", func() {
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "red", MAC: "de:ad:00:00:be:af"}}
+			Expect(GenerateMultusCNIAnnotation(&vmi, nil)).To(Equal(
+				`[{"interface":"net1","mac":"de:ad:00:00:be:af","name":"red-net","namespace":"namespace1"}]`))
+		})
+
+		It("prefers the MAC address set in the spec", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "de:ad:00:00:be:ef"
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "red", MAC: "de:ad:00:00:be:af"}}
+			Expect(GenerateMultusCNIAnnotation(&vmi, nil)).To(Equal(
+				`[{"interface":"net1","mac":"de:ad:00:00:be:ef","name":"red-net","namespace":"namespace1"}]`))
+		})
+	})
+
+	Context("with a network binding plugin", func() {
+		BeforeEach(func() {
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "default", Binding: &v1.PluginBinding{Name: "mybinding"}},
+				{Name: "red", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+		})
+
+		It("adds the attachment of the plugin CNI", func() {
+			bindings := map[string]v1.InterfaceBindingPlugin{"mybinding": {NetworkAttachmentDefinition: "binding-nad"}}
+			Expect(GenerateMultusCNIAnnotation(&vmi, bindings)).To(Equal(
+				`[{"interface":"net1","name":"red-net","namespace":"namespace1"},{"interface":"binding1","name":"binding-nad","namespace":"namespace1","cni-args":{"logicNetworkName":"default"}}]`))
+		})
+
+		It("adds no attachment for a plugin without a NetworkAttachmentDefinition", func() {
+			bindings := map[string]v1.InterfaceBindingPlugin{"mybinding": {SidecarImage: "binding-sidecar"}}
+			Expect(GenerateMultusCNIAnnotation(&vmi, bindings)).To(Equal(
+				`[{"interface":"net1","name":"red-net","namespace":"namespace1"}]`))
+		})
+	})
+
+	Context("with persistent IPs", func() {
+		BeforeEach(func() {
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net", PersistentIPs: true}}},
+				{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+				{Name: "red", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+		})
+
+		It("requests no address before one is claimed", func() {
+			Expect(GenerateMultusCNIAnnotation(&vmi, nil)).To(Equal(
+				`[{"interface":"net1","name":"red-net","namespace":"namespace1"},{"interface":"net2","name":"blue-net","namespace":"namespace1"}]`))
+		})
+
+		It("requests the claimed addresses of the networks with persistent IPs", func() {
+			vmi.Annotations = map[string]string{v1.IPClaimsAnnotation: `{"red":["10.10.10.5"],"blue":["10.10.20.5"]}`}
+			Expect(GenerateMultusCNIAnnotation(&vmi, nil)).To(Equal(
+				`[{"interface":"net1","name":"red-net","namespace":"namespace1","ips":["10.10.10.5"]},{"interface":"net2","name":"blue-net","namespace":"namespace1"}]`))
+		})
+
+		It("ignores invalid claims", func() {
+			vmi.Annotations = map[string]string{v1.IPClaimsAnnotation: `["10.10.10.5"]`}
+			Expect(GetIPClaims(&vmi)).To(BeEmpty())
+		})
+
+		It("claims the addresses multus reports for the networks with persistent IPs", func() {
+			pod := &k8sv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "virt-launcher-testvmi",
+					Annotations: map[string]string{
+						MultusNetworkStatusAnnotation: `[{"name":"kindnet","interface":"eth0","ips":["10.244.0.5"],"default":true},` +
+							`{"name":"namespace1/red-net","interface":"net1","ips":["10.10.10.5","fd10::5"]},` +
+							`{"name":"namespace1/blue-net","interface":"net2","ips":["10.10.20.5"]}]`,
+					},
+				},
+			}
+			Expect(GetPodIPClaims(&vmi, pod)).To(Equal(IPClaims{"red": {"10.10.10.5", "fd10::5"}}))
+		})
+
+		It("claims nothing before multus reports the network status", func() {
+			Expect(GetPodIPClaims(&vmi, &k8sv1.Pod{})).To(BeEmpty())
+		})
+	})
 })
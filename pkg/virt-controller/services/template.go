@@ -23,7 +23,6 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
-	"runtime"
 	"strconv"
 	"strings"
 
@@ -41,19 +40,26 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
+	backendstorage "kubevirt.io/kubevirt/pkg/backend-storage"
 	"kubevirt.io/kubevirt/pkg/config"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
+	"kubevirt.io/kubevirt/pkg/efi"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	"kubevirt.io/kubevirt/pkg/ignition"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
+	"kubevirt.io/kubevirt/pkg/util/net/istio"
 	"kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
 const KvmDevice = "devices.kubevirt.io/kvm"
 const TunDevice = "devices.kubevirt.io/tun"
 const VhostNetDevice = "devices.kubevirt.io/vhost-net"
+const VhostVsockDevice = "devices.kubevirt.io/vhost-vsock"
 
 const debugLogs = "debugLogs"
 const logVerbosity = "logVerbosity"
@@ -62,6 +68,7 @@ const virtiofsDebugLogs = "virtiofsdDebugLogs"
 const MultusNetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
 
 const CAP_NET_ADMIN = "NET_ADMIN"
+const CAP_NET_BIND_SERVICE = "NET_BIND_SERVICE"
 const CAP_NET_RAW = "NET_RAW"
 const CAP_SYS_ADMIN = "SYS_ADMIN"
 const CAP_SYS_NICE = "SYS_NICE"
@@ -80,8 +87,11 @@ const NFD_KVM_INFO_PREFIX = "feature.node.kubernetes.io/kvm-info-cap-hyperv-"
 const MULTUS_RESOURCE_NAME_ANNOTATION = "k8s.v1.cni.cncf.io/resourceName"
 const MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION = "v1.multus-cni.io/default-network"
 
+// VhostUserMappedDirAnnotation tells the userspace CNI of the switch where the vhost-user sockets are created in the pod
+const VhostUserMappedDirAnnotation = "userspace/mappedDir"
+
 // Istio list of virtual interfaces whose inbound traffic (from VM) will be treated as outbound traffic in envoy
-const ISTIO_KUBEVIRT_ANNOTATION = "traffic.sidecar.istio.io/kubevirtInterfaces"
+const ISTIO_KUBEVIRT_ANNOTATION = istio.KubeVirtTrafficAnnotation
 
 const ENV_VAR_LIBVIRT_DEBUG_LOGS = "LIBVIRT_DEBUG_LOGS"
 const ENV_VAR_VIRTIOFSD_DEBUG_LOGS = "VIRTIOFSD_DEBUG_LOGS"
@@ -92,6 +102,14 @@ const EXT_LOG_VERBOSITY_THRESHOLD = 5
 
 const ephemeralStorageOverheadSize = "50M"
 
+const panicMemoryDumpVolumeName = "panic-memory-dump"
+
+const memoryDumpVolumeName = "memory-dump"
+
+const serialConsoleLogVolumeName = "serial-console-log"
+
+const ignitionVolumeName = "ignition-config"
+
 type TemplateService interface {
 	RenderLaunchManifest(*v1.VirtualMachineInstance) (*k8sv1.Pod, error)
 	RenderHotplugAttachmentPodTemplate(volume *v1.Volume, ownerPod *k8sv1.Pod, vmi *v1.VirtualMachineInstance, pvcName string, isBlock bool, tempPod bool) (*k8sv1.Pod, error)
@@ -189,6 +207,23 @@ func makeHVFeatureLabelTable(vmi *v1.VirtualMachineInstance) []hvFeatureLabel {
 	}
 }
 
+// ephemeralDiskBackingSelects returns whether a backing selects all nodes matching the node selector of a pod
+func ephemeralDiskBackingSelects(backings []v1.EphemeralDiskBacking, nodeSelector map[string]string) bool {
+	for _, backing := range backings {
+		selected := true
+		for k, v := range backing.NodeSelector {
+			if value, ok := nodeSelector[k]; !ok || value != v {
+				selected = false
+				break
+			}
+		}
+		if selected {
+			return true
+		}
+	}
+	return false
+}
+
 func getHypervNodeSelectors(vmi *v1.VirtualMachineInstance) map[string]string {
 	nodeSelectors := make(map[string]string)
 	if vmi.Spec.Domain.Features == nil || vmi.Spec.Domain.Features.Hyperv == nil {
@@ -296,6 +331,23 @@ func sysprepVolumeSource(sysprepVolume v1.SysprepSource) (k8sv1.VolumeSource, er
 	return k8sv1.VolumeSource{}, fmt.Errorf(errorStr)
 }
 
+func ignitionVolumeSource(source *v1.IgnitionSource) (k8sv1.VolumeSource, error) {
+	if source.SecretRef != nil {
+		return k8sv1.VolumeSource{
+			Secret: &k8sv1.SecretVolumeSource{
+				SecretName: source.SecretRef.Name,
+			},
+		}, nil
+	} else if source.ConfigMapRef != nil {
+		return k8sv1.VolumeSource{
+			ConfigMap: &k8sv1.ConfigMapVolumeSource{
+				LocalObjectReference: *source.ConfigMapRef,
+			},
+		}, nil
+	}
+	return k8sv1.VolumeSource{}, fmt.Errorf("Ignition must have a Secret or ConfigMap reference set")
+}
+
 // Request a resource by name. This function bumps the number of resources,
 // both its limits and requests attributes.
 //
@@ -368,8 +420,13 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	var volumeMounts []k8sv1.VolumeMount
 	var imagePullSecrets []k8sv1.LocalObjectReference
 
+	arch := vmi.Spec.Architecture
+	if arch == "" {
+		arch = t.clusterConfig.GetDefaultArchitecture()
+	}
+
 	// Need to run in privileged mode in Power or libvirt will fail to lock memory for VMI
-	if runtime.GOARCH == "ppc64le" {
+	if arch == "ppc64le" {
 		privileged = true
 	}
 
@@ -414,6 +471,24 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		},
 	})
 
+	// socket of the qemu-pr-helper daemon of virt-handler, which executes the persistent reservation commands
+	if util.HasPersistentReservation(vmi) {
+		hostPathType := k8sv1.HostPathDirectoryOrCreate
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      "pr-helper-socket",
+			MountPath: util.PrHelperSocketDir,
+		})
+		volumes = append(volumes, k8sv1.Volume{
+			Name: "pr-helper-socket",
+			VolumeSource: k8sv1.VolumeSource{
+				HostPath: &k8sv1.HostPathVolumeSource{
+					Path: util.PrHelperSocketDir,
+					Type: &hostPathType,
+				},
+			},
+		})
+	}
+
 	serviceAccountName := ""
 
 	for _, volume := range vmi.Spec.Volumes {
@@ -439,6 +514,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 				}
 				volumeDevices = append(volumeDevices, device)
 			} else {
+				volumeMount.SubPath = getVirtiofsSubPath(vmi, volume.Name)
 				volumeMounts = append(volumeMounts, volumeMount)
 			}
 			volumes = append(volumes, k8sv1.Volume{
@@ -504,6 +580,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 				}
 				volumeDevices = append(volumeDevices, device)
 			} else {
+				volumeMount.SubPath = getVirtiofsSubPath(vmi, volume.Name)
 				volumeMounts = append(volumeMounts, volumeMount)
 			}
 
@@ -721,6 +798,127 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		})
 	}
 
+	if backendstorage.IsBackendStorageNeeded(vmi) {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: backendstorage.VolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: backendstorage.PVCForVMI(vmi),
+				},
+			},
+		})
+		if backendstorage.HasPersistentTPMDevice(vmi) {
+			volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+				Name:      backendstorage.VolumeName,
+				MountPath: backendstorage.SwtpmStateDir,
+			})
+		}
+		if backendstorage.HasPersistentEFI(vmi) {
+			volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+				Name:      backendstorage.VolumeName,
+				MountPath: backendstorage.NVRAMStateDir,
+				SubPath:   backendstorage.NVRAMSubPath,
+			})
+		}
+	}
+
+	if dump := vmi.Spec.Domain.Devices.PanicMemoryDump; dump != nil && util.IsAutoAttachPanicDevice(vmi) {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: panicMemoryDumpVolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: dump.ClaimName,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      panicMemoryDumpVolumeName,
+			MountPath: util.PanicMemoryDumpDir,
+		})
+	}
+
+	if dump := vmi.Spec.Domain.Devices.MemoryDump; dump != nil {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: memoryDumpVolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: dump.ClaimName,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      memoryDumpVolumeName,
+			MountPath: util.MemoryDumpDir,
+		})
+	}
+
+	if consoleLog := vmi.Spec.Domain.Devices.SerialConsoleLog; consoleLog != nil && util.IsSerialConsoleLogEnabled(vmi) {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: serialConsoleLogVolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: consoleLog.ClaimName,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      serialConsoleLogVolumeName,
+			MountPath: util.SerialConsoleLogDir,
+		})
+	}
+
+	if vmi.Spec.Ignition != nil {
+		volumeSource, err := ignitionVolumeSource(vmi.Spec.Ignition)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, k8sv1.Volume{
+			Name:         ignitionVolumeName,
+			VolumeSource: volumeSource,
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      ignitionVolumeName,
+			MountPath: ignition.IgnitionSourceDir,
+			ReadOnly:  true,
+		})
+	}
+
+	for _, keySource := range efi.KeySources(efi.GetSecureBootKeys(vmi)) {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: keySource.VolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				Secret: &k8sv1.SecretVolumeSource{
+					SecretName: keySource.Source.SecretName,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      keySource.VolumeName,
+			MountPath: efi.GetKeysDir(keySource.VolumeName),
+			ReadOnly:  true,
+		})
+	}
+
+	for _, disk := range diskencryption.EncryptedDisks(vmi) {
+		volumeName := diskencryption.VolumeName(disk.Name)
+		volumes = append(volumes, k8sv1.Volume{
+			Name: volumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				Secret: &k8sv1.SecretVolumeSource{
+					SecretName: disk.Encryption.SecretName,
+					Items: []k8sv1.KeyToPath{
+						{Key: diskencryption.PassphraseKey, Path: diskencryption.PassphraseKey},
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      volumeName,
+			MountPath: diskencryption.GetSecretDir(disk.Name),
+			ReadOnly:  true,
+		})
+	}
+
 	if t.imagePullSecret != "" {
 		imagePullSecrets = appendUniqueImagePullSecret(imagePullSecrets, k8sv1.LocalObjectReference{
 			Name: t.imagePullSecret,
@@ -847,15 +1045,41 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 
 		if memoryLimit, ok := resources.Limits[k8sv1.ResourceMemory]; ok {
 			memoryLimit.Add(*memoryOverhead)
+			// Leave room for the memory which can be hotplugged via virtio-mem
+			if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.MaxGuest != nil {
+				guest := vmi.Spec.Domain.Resources.Limits.Memory()
+				if vmi.Spec.Domain.Memory.Guest != nil {
+					guest = vmi.Spec.Domain.Memory.Guest
+				}
+				if vmi.Spec.Domain.Memory.MaxGuest.Cmp(*guest) > 0 {
+					memoryLimit.Add(*vmi.Spec.Domain.Memory.MaxGuest)
+					memoryLimit.Sub(*guest)
+				}
+			}
 			resources.Limits[k8sv1.ResourceMemory] = memoryLimit
 		}
 	}
 
+	// The userspace switch of the node connects to the vhost-user sockets through a shared directory
+	if util.IsVhostUserVmi(vmi) {
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      "vhostuser-sockets",
+			MountPath: util.VhostUserSocketDir,
+		})
+		volumes = append(volumes, k8sv1.Volume{
+			Name: "vhostuser-sockets",
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	// Read requested hookSidecars from VMI meta
 	requestedHookSidecarList, err := hooks.UnmarshalHookSidecarList(vmi)
 	if err != nil {
 		return nil, err
 	}
+	requestedHookSidecarList = append(requestedHookSidecarList, networkBindingPluginSidecars(vmi, t.clusterConfig)...)
 
 	if len(requestedHookSidecarList) != 0 {
 		volumes = append(volumes, k8sv1.Volume{
@@ -870,6 +1094,28 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		})
 	}
 
+	// schedule only on nodes which support the requested memory encryption
+	if util.IsSEVVMI(vmi) {
+		nodeSelector[v1.SEVLabel] = "true"
+		if util.IsSEVESVMI(vmi) {
+			nodeSelector[v1.SEVESLabel] = "true"
+		}
+	} else if util.IsSEVSNPVMI(vmi) {
+		nodeSelector[v1.SEVSNPLabel] = "true"
+	} else if util.IsTDXVMI(vmi) {
+		nodeSelector[v1.TDXLabel] = "true"
+	}
+
+	// schedule only on nodes whose kernel does not throttle the realtime vCPUs
+	if util.IsRealtimeVMI(vmi) {
+		nodeSelector[v1.RealtimeLabel] = "true"
+	}
+
+	// schedule only on nodes which track the connections of bridged traffic, the replies to the guest are dropped otherwise
+	if util.IsDefaultDenyFirewallVMI(vmi) {
+		nodeSelector[v1.ConntrackBridgeLabel] = "true"
+	}
+
 	// Handle CPU pinning
 	if vmi.IsCPUDedicated() {
 		// schedule only on nodes with a running cpu manager
@@ -902,7 +1148,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	}
 
 	lessPVCSpaceToleration := t.clusterConfig.GetLessPVCSpaceToleration()
-	ovmfPath := t.clusterConfig.GetOVMFPath()
+	ovmfPath := t.clusterConfig.GetOVMFPath(arch)
 
 	var command []string
 	if tempPod {
@@ -945,6 +1191,18 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		resources.Limits[KvmDevice] = resource.MustParse("1")
 	}
 
+	if !tempPod && util.IsSerialConsoleLogEnabled(vmi) {
+		command = append(command, "--log-serial-console")
+		if consoleLog := vmi.Spec.Domain.Devices.SerialConsoleLog; consoleLog != nil {
+			if consoleLog.MaxFileSize != nil {
+				command = append(command, "--serial-console-log-max-file-size", strconv.FormatInt(consoleLog.MaxFileSize.Value(), 10))
+			}
+			if consoleLog.MaxFiles != nil {
+				command = append(command, "--serial-console-log-max-files", strconv.Itoa(int(*consoleLog.MaxFiles)))
+			}
+		}
+	}
+
 	// Add ports from interfaces to the pod manifest
 	ports := getPortsFromVMI(vmi)
 
@@ -996,12 +1254,12 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	}
 
 	if vmi.Spec.ReadinessProbe != nil {
-		compute.ReadinessProbe = copyProbe(vmi.Spec.ReadinessProbe)
+		compute.ReadinessProbe = copyProbe(vmi, vmi.Spec.ReadinessProbe)
 		compute.ReadinessProbe.InitialDelaySeconds = compute.ReadinessProbe.InitialDelaySeconds + LibvirtStartupDelay
 	}
 
 	if vmi.Spec.LivenessProbe != nil {
-		compute.LivenessProbe = copyProbe(vmi.Spec.LivenessProbe)
+		compute.LivenessProbe = copyProbe(vmi, vmi.Spec.LivenessProbe)
 		compute.LivenessProbe.InitialDelaySeconds = compute.LivenessProbe.InitialDelaySeconds + LibvirtStartupDelay
 	}
 
@@ -1048,6 +1306,8 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 			EmptyDir: &k8sv1.EmptyDirVolumeSource{},
 		},
 	})
+	// the ephemeral disks are moved to the node below, once the node selector of the pod is known
+	ephemeralDisksVolumeIndex := len(volumes)
 	volumes = append(volumes, k8sv1.Volume{
 		Name: "ephemeral-disks",
 		VolumeSource: k8sv1.VolumeSource{
@@ -1084,7 +1344,8 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		}
 	}
 
-	if t.clusterConfig.HypervStrictCheckEnabled() {
+	// The Hyper-V enlightenments enabled automatically for Windows guests are always checked
+	if t.clusterConfig.HypervStrictCheckEnabled() || util.IsWindowsVMI(vmi) {
 		hvNodeSelectors := getHypervNodeSelectors(vmi)
 		for k, v := range hvNodeSelectors {
 			nodeSelector[k] = v
@@ -1092,11 +1353,25 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	}
 
 	nodeSelector[v1.NodeSchedulable] = "true"
+	nodeSelector[k8sv1.LabelArchStable] = arch
 	nodeSelectors := t.clusterConfig.GetNodeSelectors()
 	for k, v := range nodeSelectors {
 		nodeSelector[k] = v
 	}
 
+	if ephemeralDiskBackingSelects(t.clusterConfig.GetEphemeralDiskBackings(), nodeSelector) {
+		// virt-handler mounts the backing of the node on the directory and removes the data of the VMI once it is gone.
+		// The pod waits for the mount, so that the data never ends up on the root filesystem of the node.
+		hostPathType := k8sv1.HostPathDirectoryOrCreate
+		volumes[ephemeralDisksVolumeIndex].VolumeSource = k8sv1.VolumeSource{
+			HostPath: &k8sv1.HostPathVolumeSource{
+				Path: filepath.Join(util.EphemeralDisksDir, string(vmi.UID)),
+				Type: &hostPathType,
+			},
+		}
+		nodeSelector[v1.EphemeralDiskBackingLabel] = "true"
+	}
+
 	podLabels := map[string]string{}
 
 	for k, v := range vmi.Labels {
@@ -1133,7 +1408,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 
 	hostName := dns.SanitizeHostname(vmi)
 
-	podAnnotations, err := generatePodAnnotations(vmi)
+	podAnnotations, err := generatePodAnnotations(vmi, t.clusterConfig.GetNetworkBindings())
 	if err != nil {
 		return nil, err
 	}
@@ -1392,6 +1667,11 @@ func getRequiredCapabilities(vmi *v1.VirtualMachineInstance, config *virtconfig.
 		capabilities = append(capabilities, CAP_SYS_RESOURCE)
 	}
 
+	// add CAP_NET_BIND_SERVICE capability to allow passt to forward privileged ports
+	if util.IsPasstVmi(vmi) {
+		capabilities = append(capabilities, CAP_NET_BIND_SERVICE)
+	}
+
 	return capabilities
 }
 
@@ -1411,6 +1691,9 @@ func getRequiredResources(vmi *v1.VirtualMachineInstance, useEmulation bool) k8s
 			res[VhostNetDevice] = resource.MustParse("1")
 		}
 	}
+	if util.IsAutoAttachVSOCK(vmi) {
+		res[VhostVsockDevice] = resource.MustParse("1")
+	}
 	return res
 }
 
@@ -1488,6 +1771,12 @@ func getMemoryOverhead(vmi *v1.VirtualMachineInstance) *resource.Quantity {
 		overhead.Add(resource.MustParse("1Gi"))
 	}
 
+	// Additional overhead for confidential guests, which have to lock their whole memory
+	// and need some unencrypted memory for the communication with the host.
+	if util.IsConfidentialVMI(vmi) {
+		overhead.Add(resource.MustParse("256Mi"))
+	}
+
 	return overhead
 }
 
@@ -1532,6 +1821,17 @@ func HaveContainerDiskVolume(volumes []v1.Volume) bool {
 	return false
 }
 
+// getVirtiofsSubPath returns the path within the volume which is shared into the guest with virtiofs,
+// or an empty string if the whole volume is mounted
+func getVirtiofsSubPath(vmi *v1.VirtualMachineInstance, volumeName string) string {
+	for _, fs := range vmi.Spec.Domain.Devices.Filesystems {
+		if fs.Name == volumeName && fs.Virtiofs != nil {
+			return fs.Virtiofs.SubPath
+		}
+	}
+	return ""
+}
+
 func getResourceNameForNetwork(network *networkv1.NetworkAttachmentDefinition) string {
 	resourceName, ok := network.Annotations[MULTUS_RESOURCE_NAME_ANNOTATION]
 	if ok {
@@ -1595,11 +1895,13 @@ func NewTemplateService(launcherImage string,
 	return &svc
 }
 
-func copyProbe(probe *v1.Probe) *k8sv1.Probe {
+// copyProbe translates a VMI probe into a probe of the compute container. Guest agent based
+// probes are run by virt-probe, which executes them on the guest through virt-launcher.
+func copyProbe(vmi *v1.VirtualMachineInstance, probe *v1.Probe) *k8sv1.Probe {
 	if probe == nil {
 		return nil
 	}
-	return &k8sv1.Probe{
+	podProbe := &k8sv1.Probe{
 		InitialDelaySeconds: probe.InitialDelaySeconds,
 		TimeoutSeconds:      probe.TimeoutSeconds,
 		PeriodSeconds:       probe.PeriodSeconds,
@@ -1610,6 +1912,30 @@ func copyProbe(probe *v1.Probe) *k8sv1.Probe {
 			TCPSocket: probe.TCPSocket,
 		},
 	}
+	if probe.Exec != nil || probe.GuestAgentPing != nil {
+		timeoutSeconds := probe.TimeoutSeconds
+		if timeoutSeconds < 1 {
+			timeoutSeconds = 1
+		}
+		podProbe.Handler.Exec = &k8sv1.ExecAction{
+			Command: virtProbeCommand(vmi, probe, timeoutSeconds),
+		}
+		// Give virt-probe the time to report the result before kubelet gives up on it
+		podProbe.TimeoutSeconds = timeoutSeconds + 1
+	}
+	return podProbe
+}
+
+func virtProbeCommand(vmi *v1.VirtualMachineInstance, probe *v1.Probe, timeoutSeconds int32) []string {
+	command := []string{
+		"virt-probe",
+		"--domainName", api.VMINamespaceKeyFunc(vmi),
+		"--timeoutSeconds", strconv.Itoa(int(timeoutSeconds)),
+	}
+	if probe.GuestAgentPing != nil {
+		return append(command, "--guestAgentPing")
+	}
+	return append(append(command, "--"), probe.Exec.Command...)
 }
 
 func alignPodMultiCategorySecurity(pod *k8sv1.Pod, selinuxType string) {
@@ -1635,7 +1961,7 @@ func generateContainerSecurityContext(selinuxType string) *k8sv1.SecurityContext
 	}
 }
 
-func generatePodAnnotations(vmi *v1.VirtualMachineInstance) (map[string]string, error) {
+func generatePodAnnotations(vmi *v1.VirtualMachineInstance, bindings map[string]v1.InterfaceBindingPlugin) (map[string]string, error) {
 	annotationsSet := map[string]string{
 		v1.DomainAnnotation: vmi.GetObjectMeta().GetName(),
 	}
@@ -1643,7 +1969,7 @@ func generatePodAnnotations(vmi *v1.VirtualMachineInstance) (map[string]string,
 		annotationsSet[k] = v
 	}
 
-	multusAnnotation, err := generateMultusCNIAnnotation(vmi)
+	multusAnnotation, err := GenerateMultusCNIAnnotation(vmi, bindings)
 	if err != nil {
 		return nil, err
 	}
@@ -1658,9 +1984,37 @@ func generatePodAnnotations(vmi *v1.VirtualMachineInstance) (map[string]string,
 	if HaveMasqueradeInterface(vmi.Spec.Domain.Devices.Interfaces) {
 		annotationsSet[ISTIO_KUBEVIRT_ANNOTATION] = "k6t-eth0"
 	}
+
+	if util.IsVhostUserVmi(vmi) {
+		annotationsSet[VhostUserMappedDirAnnotation] = util.VhostUserSocketDir
+	}
 	return annotationsSet, nil
 }
 
+// networkBindingPluginSidecars returns a hook sidecar for every network binding plugin, used by the VMI
+// interfaces, which is deployed with a sidecar image.
+func networkBindingPluginSidecars(vmi *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) hooks.HookSidecarList {
+	var sidecars hooks.HookSidecarList
+	bindings := config.GetNetworkBindings()
+	addedPlugins := map[string]struct{}{}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Binding == nil {
+			continue
+		}
+		if _, added := addedPlugins[iface.Binding.Name]; added {
+			continue
+		}
+		addedPlugins[iface.Binding.Name] = struct{}{}
+		if plugin, exists := bindings[iface.Binding.Name]; exists && plugin.SidecarImage != "" {
+			sidecars = append(sidecars, hooks.HookSidecar{
+				Image:           plugin.SidecarImage,
+				ImagePullPolicy: config.GetImagePullPolicy(),
+			})
+		}
+	}
+	return sidecars
+}
+
 func lookupMultusDefaultNetworkName(networks []v1.Network) string {
 	for _, network := range networks {
 		if network.Multus != nil && network.Multus.Default {
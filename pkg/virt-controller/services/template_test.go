@@ -22,6 +22,7 @@ package services
 import (
 	"errors"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -240,7 +241,8 @@ var _ = Describe("Template", func() {
 				}}))
 				Expect(pod.ObjectMeta.GenerateName).To(Equal("virt-launcher-testvmi-"))
 				Expect(pod.Spec.NodeSelector).To(Equal(map[string]string{
-					v1.NodeSchedulable: "true",
+					v1.NodeSchedulable:     "true",
+					kubev1.LabelArchStable: runtime.GOARCH,
 				}))
 				Expect(pod.Spec.Containers[0].Command).To(Equal([]string{"/usr/bin/virt-launcher",
 					"--qemu-timeout", "5m",
@@ -513,6 +515,426 @@ var _ = Describe("Template", func() {
 				}
 				Expect(volumeMountFound).To(BeTrue(), "could not find ssh key secret volume mount")
 			})
+			It("should add the backend storage volume for a persistent TPM", func() {
+				persistent := true
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								TPM: &v1.TPMDevice{Persistent: &persistent},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "vm-state",
+					VolumeSource: kubev1.VolumeSource{
+						PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "persistent-state-for-testvmi",
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "vm-state",
+					MountPath: "/var/lib/libvirt/swtpm",
+				}))
+			})
+			It("should mount the NVRAM directory of the backend storage volume for a persistent EFI", func() {
+				persistent := true
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Firmware: &v1.Firmware{
+								Bootloader: &v1.Bootloader{
+									EFI: &v1.EFI{Persistent: &persistent},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "vm-state",
+					VolumeSource: kubev1.VolumeSource{
+						PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "persistent-state-for-testvmi",
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "vm-state",
+					MountPath: "/var/lib/libvirt/qemu/nvram",
+					SubPath:   "nvram",
+				}))
+				for _, volumeMount := range pod.Spec.Containers[0].VolumeMounts {
+					Expect(volumeMount.MountPath).ToNot(Equal("/var/lib/libvirt/swtpm"))
+				}
+			})
+			It("should mount the PVC for memory dumps of panicked guests", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								PanicMemoryDump: &v1.PanicMemoryDump{ClaimName: "dumps"},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "panic-memory-dump",
+					VolumeSource: kubev1.VolumeSource{
+						PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "dumps",
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "panic-memory-dump",
+					MountPath: "/var/run/kubevirt-private/panic-memory-dump",
+				}))
+			})
+			It("should mount the PVC for memory dumps of the running guest", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								MemoryDump: &v1.MemoryDump{ClaimName: "dumps"},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "memory-dump",
+					VolumeSource: kubev1.VolumeSource{
+						PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "dumps",
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "memory-dump",
+					MountPath: "/var/run/kubevirt-private/memory-dump",
+				}))
+			})
+			It("should pass the serial console log options to virt-launcher and mount the log PVC", func() {
+				logSerialConsole := true
+				maxFileSize := resource.MustParse("1Mi")
+				maxFiles := int32(5)
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								LogSerialConsole: &logSerialConsole,
+								SerialConsoleLog: &v1.SerialConsoleLog{
+									ClaimName:   "console-logs",
+									MaxFileSize: &maxFileSize,
+									MaxFiles:    &maxFiles,
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Command).To(ContainElement("--log-serial-console"))
+				Expect(strings.Join(pod.Spec.Containers[0].Command, " ")).To(ContainSubstring("--serial-console-log-max-file-size 1048576 --serial-console-log-max-files 5"))
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "serial-console-log",
+					VolumeSource: kubev1.VolumeSource{
+						PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "console-logs",
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "serial-console-log",
+					MountPath: "/var/run/kubevirt-private/serial-console-log",
+				}))
+			})
+			It("should mount the pr-helper socket if a LUN disk requests persistent reservations", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								Disks: []v1.Disk{{
+									Name: "shared",
+									DiskDevice: v1.DiskDevice{
+										LUN: &v1.LunTarget{Bus: "scsi", Reservation: true},
+									},
+								}},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				hostPathType := kubev1.HostPathDirectoryOrCreate
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "pr-helper-socket",
+					VolumeSource: kubev1.VolumeSource{
+						HostPath: &kubev1.HostPathVolumeSource{
+							Path: "/var/run/kubevirt/daemons/pr",
+							Type: &hostPathType,
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "pr-helper-socket",
+					MountPath: "/var/run/kubevirt/daemons/pr",
+				}))
+			})
+			It("should not log the serial console by default", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Command).ToNot(ContainElement("--log-serial-console"))
+			})
+			It("should schedule SEV VMIs only on nodes supporting SEV", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							LaunchSecurity: &v1.LaunchSecurity{
+								SEV: &v1.SEV{},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.SEVLabel, "true"))
+				Expect(pod.Spec.NodeSelector).ToNot(HaveKey(v1.SEVESLabel))
+
+				encryptedState := true
+				vmi.Spec.Domain.LaunchSecurity.SEV.Policy = &v1.SEVPolicy{EncryptedState: &encryptedState}
+				pod, err = svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.SEVESLabel, "true"))
+			})
+
+			It("should schedule SEV-SNP and TDX VMIs only on nodes supporting them", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							LaunchSecurity: &v1.LaunchSecurity{
+								SNP: &v1.SEVSNP{},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.SEVSNPLabel, "true"))
+				Expect(pod.Spec.NodeSelector).ToNot(HaveKey(v1.SEVLabel))
+
+				vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+				pod, err = svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.TDXLabel, "true"))
+			})
+			It("should schedule realtime VMIs only on nodes with an unthrottled realtime kernel", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							CPU: &v1.CPU{Realtime: &v1.Realtime{}},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.RealtimeLabel, "true"))
+			})
+			table.DescribeTable("should schedule VMIs with interface firewalls", func(defaultAction v1.FirewallAction, conntrackBridgeRequired bool) {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								Interfaces: []v1.Interface{
+									{
+										Name:                   "default",
+										InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+										Firewall:               &v1.InterfaceFirewall{DefaultAction: defaultAction},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				if conntrackBridgeRequired {
+					Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.ConntrackBridgeLabel, "true"))
+				} else {
+					Expect(pod.Spec.NodeSelector).ToNot(HaveKey(v1.ConntrackBridgeLabel))
+				}
+			},
+				table.Entry("only on nodes with nf_conntrack_bridge if they deny by default", v1.FirewallActionDeny, true),
+				table.Entry("on any node if they allow by default", v1.FirewallActionAllow, false),
+			)
+			It("should add volumes with the secrets referenced by the secure boot keys", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Firmware: &v1.Firmware{
+								Bootloader: &v1.Bootloader{
+									EFI: &v1.EFI{
+										SecureBootKeys: &v1.SecureBootKeys{
+											PK: &v1.SecureBootKeySource{SecretName: "my-pk"},
+											DB: &v1.SecureBootKeySource{SecretName: "my-db"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "efi-pk",
+					VolumeSource: kubev1.VolumeSource{
+						Secret: &kubev1.SecretVolumeSource{SecretName: "my-pk"},
+					},
+				}))
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "efi-db",
+					VolumeSource: kubev1.VolumeSource{
+						Secret: &kubev1.SecretVolumeSource{SecretName: "my-db"},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "efi-db",
+					MountPath: "/var/run/kubevirt-private/secret/efi-db",
+					ReadOnly:  true,
+				}))
+			})
+			It("should only mount the passphrase of the secrets referenced by encrypted disks", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								Disks: []v1.Disk{
+									{Name: "encrypted", Encryption: &v1.DiskEncryption{SecretName: "my-luks"}},
+									{Name: "plain"},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "luks-encrypted",
+					VolumeSource: kubev1.VolumeSource{
+						Secret: &kubev1.SecretVolumeSource{
+							SecretName: "my-luks",
+							Items:      []kubev1.KeyToPath{{Key: "passphrase", Path: "passphrase"}},
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "luks-encrypted",
+					MountPath: "/var/run/kubevirt-private/secret/luks-encrypted",
+					ReadOnly:  true,
+				}))
+				for _, volume := range pod.Spec.Volumes {
+					Expect(volume.Name).ToNot(Equal("luks-plain"))
+				}
+			})
 			It("should add volume with secret referenced by user/password", func() {
 				vmi := v1.VirtualMachineInstance{
 					ObjectMeta: metav1.ObjectMeta{
@@ -861,6 +1283,40 @@ var _ = Describe("Template", func() {
 				Expect(value).To(Equal("k6t-eth0"))
 			})
 		})
+		Context("with vhost-user interface", func() {
+			It("should share the vhost-user socket directory with the userspace switch", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+								Interfaces: []v1.Interface{
+									{Name: "dpdk",
+										InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}}},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Annotations).To(HaveKeyWithValue(VhostUserMappedDirAnnotation, "/var/run/vhostuser"))
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name:         "vhostuser-sockets",
+					VolumeSource: kubev1.VolumeSource{EmptyDir: &kubev1.EmptyDirVolumeSource{}},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "vhostuser-sockets",
+					MountPath: "/var/run/vhostuser",
+				}))
+			})
+		})
 		Context("with node selectors", func() {
 			It("should add node selectors to template", func() {
 
@@ -890,6 +1346,7 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.NodeSelector).To(Equal(map[string]string{
 					"kubernetes.io/hostname": "master",
 					v1.NodeSchedulable:       "true",
+					kubev1.LabelArchStable:   runtime.GOARCH,
 				}))
 				Expect(pod.Spec.Containers[0].Command).To(Equal([]string{"/usr/bin/virt-launcher",
 					"--qemu-timeout", "5m",
@@ -917,6 +1374,16 @@ var _ = Describe("Template", func() {
 				Expect(*pod.Spec.TerminationGracePeriodSeconds).To(Equal(int64(60)))
 			})
 
+			It("should schedule the VMI on a node of its architecture", func() {
+				vmi := v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: "default", UID: "1234"}, Spec: v1.VirtualMachineInstanceSpec{Architecture: "arm64", Domain: v1.DomainSpec{}}}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(kubev1.LabelArchStable, "arm64"))
+				Expect(pod.Spec.Containers[0].Command).To(ContainElements("--ovmf-path", virtconfig.DefaultARM64OVMFPath))
+			})
+
 			It("should add node selector for node discovery feature to template", func() {
 				enableFeatureGate(virtconfig.CPUNodeDiscoveryGate)
 
@@ -1089,6 +1556,41 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(NFD_KVM_INFO_PREFIX+"ipi", "true"))
 			})
 
+			It("should add node selector for hyperv nodes for Windows guests even if feature gate is disabled", func() {
+				enabled := true
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "testvmi",
+						Namespace:   "default",
+						UID:         "1234",
+						Annotations: map[string]string{v1.GuestOSAnnotation: v1.GuestOSWindows},
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+							Features: &v1.Features{
+								Hyperv: &v1.FeatureHyperv{
+									VPIndex: &v1.FeatureState{
+										Enabled: &enabled,
+									},
+									Reenlightenment: &v1.FeatureState{
+										Enabled: &enabled,
+									},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(NFD_KVM_INFO_PREFIX+"vpindex", "true"))
+				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(NFD_KVM_INFO_PREFIX+"reenlightenment", "true"))
+			})
+
 			It("should not add node selector for hyperv nodes if VMI requests hyperv features which do not depend on host kernel", func() {
 				enableFeatureGate(virtconfig.HypervStrictCheckGate)
 
@@ -1437,6 +1939,40 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().String()).To(Equal("1G"))
 				Expect(pod.Spec.Containers[0].Resources.Limits.Memory().String()).To(Equal("2180211045"))
 			})
+			It("should add the hotpluggable memory to the memory limit", func() {
+				guest := resource.MustParse("1G")
+				maxGuest := resource.MustParse("3G")
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+							Memory: &v1.Memory{Guest: &guest, MaxGuest: &maxGuest},
+							Resources: v1.ResourceRequirements{
+								OvercommitGuestOverhead: true,
+								Requests: kubev1.ResourceList{
+									kubev1.ResourceMemory: resource.MustParse("1G"),
+								},
+								Limits: kubev1.ResourceList{
+									kubev1.ResourceMemory: resource.MustParse("2G"),
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().String()).To(Equal("1G"))
+				Expect(pod.Spec.Containers[0].Resources.Limits.Memory().String()).To(Equal("4180211045"))
+			})
 			It("should not add unset resources", func() {
 
 				vmi := v1.VirtualMachineInstance{
@@ -1769,6 +2305,50 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Volumes[1].PersistentVolumeClaim).ToNot(BeNil(), "Found PVC volume")
 				Expect(pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal(pvcName), "Found PVC volume with correct name")
 			})
+
+			It("should only mount the sub path which is shared with virtiofs", func() {
+				namespace := "testns"
+				pvcName := "pvcFile"
+				pvc := kubev1.PersistentVolumeClaim{
+					TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: pvcName},
+				}
+				Expect(pvcCache.Add(&pvc)).To(Succeed())
+
+				volumeName := "dataset"
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: namespace, UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: volumeName,
+								VolumeSource: v1.VolumeSource{
+									PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+								},
+							},
+						},
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+								Filesystems: []v1.Filesystem{
+									{Name: volumeName, Virtiofs: &v1.FilesystemVirtiofs{SubPath: "training/images"}},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      volumeName,
+					MountPath: "/var/run/kubevirt-private/vmi-disks/dataset",
+					SubPath:   "training/images",
+				}))
+			})
 		})
 
 		Context("with blockdevice mode pvc source", func() {
@@ -1939,6 +2519,162 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with passt interface", func() {
+			const capNetBindService = kubev1.Capability(CAP_NET_BIND_SERVICE)
+
+			It("should run with CAP_NET_BIND_SERVICE capability", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultPasstNetworkInterface()}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].SecurityContext.Capabilities.Add).To(ContainElement(capNetBindService))
+			})
+			It("should run without CAP_NET_BIND_SERVICE capability with a masquerade interface", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].SecurityContext.Capabilities.Add).ToNot(ContainElement(capNetBindService))
+			})
+		})
+
+		Context("with a network binding plugin", func() {
+			BeforeEach(func() {
+				kvConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+					ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+					Spec: v1.KubeVirtSpec{
+						Configuration: v1.KubeVirtConfiguration{
+							NetworkConfiguration: &v1.NetworkConfiguration{
+								Binding: map[string]v1.InterfaceBindingPlugin{
+									"mybinding": {
+										SidecarImage:                "registry:5000/binding-sidecar:devel",
+										NetworkAttachmentDefinition: "default/binding-nad",
+									},
+								},
+							},
+						},
+					},
+					Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeploying},
+				})
+				svc = NewTemplateService("kubevirt/virt-launcher",
+					"/var/run/kubevirt",
+					"/var/lib/kubevirt",
+					"/var/run/kubevirt-ephemeral-disks",
+					"/var/run/kubevirt/container-disks",
+					"/var/run/kubevirt/hotplug-disks",
+					"pull-secret-1",
+					pvcCache,
+					virtClient,
+					kvConfig,
+					qemuGid,
+				)
+			})
+
+			It("should add the plugin sidecar and CNI attachment once", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Namespace = "testns"
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{Name: "default", Binding: &v1.PluginBinding{Name: "mybinding"}},
+				}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers).To(HaveLen(2))
+				Expect(pod.Spec.Containers[1].Name).To(Equal("hook-sidecar-0"))
+				Expect(pod.Spec.Containers[1].Image).To(Equal("registry:5000/binding-sidecar:devel"))
+				Expect(pod.Spec.Containers[0].Command).To(ContainElements("--hook-sidecars", "1"))
+				Expect(pod.Annotations[MultusNetworksAnnotation]).To(Equal(
+					`[{"interface":"binding1","name":"binding-nad","namespace":"default","cni-args":{"logicNetworkName":"default"}}]`))
+			})
+			It("should not add a sidecar for an interface with a binding method", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers).To(HaveLen(1))
+				Expect(pod.Annotations).ToNot(HaveKey(MultusNetworksAnnotation))
+			})
+		})
+
+		Context("with ephemeral disk backings", func() {
+			newTemplateService := func(backings ...v1.EphemeralDiskBacking) TemplateService {
+				kvConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+					ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+					Spec: v1.KubeVirtSpec{
+						Configuration: v1.KubeVirtConfiguration{
+							EphemeralDiskBackings: backings,
+						},
+					},
+					Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeploying},
+				})
+				return NewTemplateService("kubevirt/virt-launcher",
+					"/var/run/kubevirt",
+					"/var/lib/kubevirt",
+					"/var/run/kubevirt-ephemeral-disks",
+					"/var/run/kubevirt/container-disks",
+					"/var/run/kubevirt/hotplug-disks",
+					"pull-secret-1",
+					pvcCache,
+					virtClient,
+					kvConfig,
+					qemuGid,
+				)
+			}
+
+			It("should store the ephemeral disk data in the directory of the VMI on the node", func() {
+				svc = newTemplateService(v1.EphemeralDiskBacking{HostPath: "/mnt/scratch"})
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.UID = "1234"
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				hostPathType := kubev1.HostPathDirectoryOrCreate
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "ephemeral-disks",
+					VolumeSource: kubev1.VolumeSource{
+						HostPath: &kubev1.HostPathVolumeSource{
+							Path: "/var/lib/kubevirt/ephemeral-disks/1234",
+							Type: &hostPathType,
+						},
+					},
+				}))
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.EphemeralDiskBackingLabel, "true"))
+			})
+
+			It("should store the ephemeral disk data in the pod if the VMI can run on nodes without backing", func() {
+				svc = newTemplateService(v1.EphemeralDiskBacking{NodeSelector: map[string]string{"storage": "fast"}, HostPath: "/mnt/scratch"})
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.UID = "1234"
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "ephemeral-disks",
+					VolumeSource: kubev1.VolumeSource{
+						EmptyDir: &kubev1.EmptyDirVolumeSource{},
+					},
+				}))
+				Expect(pod.Spec.NodeSelector).ToNot(HaveKey(v1.EphemeralDiskBackingLabel))
+
+				vmi.Spec.NodeSelector = map[string]string{"storage": "fast"}
+				pod, err = svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.EphemeralDiskBackingLabel, "true"))
+			})
+		})
+
 		Context("with sriov interface", func() {
 			const capSysResource = kubev1.Capability(CAP_SYS_RESOURCE)
 
@@ -2189,6 +2925,40 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with VSOCK", func() {
+			It("Should require the vhost-vsock device if requested", func() {
+				domain := v1.DomainSpec{}
+				autoAttach := true
+				domain.Devices.AutoattachVSOCK = &autoAttach
+
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{Domain: domain},
+				}
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				vsock, ok := pod.Spec.Containers[0].Resources.Limits[VhostVsockDevice]
+				Expect(ok).To(BeTrue())
+				Expect(int(vsock.Value())).To(Equal(1))
+			})
+
+			It("Should not require the vhost-vsock device by default", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, ok := pod.Spec.Containers[0].Resources.Limits[VhostVsockDevice]
+				Expect(ok).To(BeFalse())
+			})
+		})
+
 		Context("with a configMap volume source", func() {
 			It("Should add the ConfigMap to template", func() {
 				volumes := []v1.Volume{
@@ -2287,6 +3057,35 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with an Ignition config", func() {
+			table.DescribeTable("should mount the referenced Ignition config", func(source *v1.IgnitionSource, expected kubev1.VolumeSource) {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{Domain: v1.DomainSpec{}, Ignition: source},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{Name: "ignition-config", VolumeSource: expected}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "ignition-config",
+					MountPath: "/var/run/kubevirt-private/ignition",
+					ReadOnly:  true,
+				}))
+			},
+				table.Entry("from a Secret",
+					&v1.IgnitionSource{SecretRef: &kubev1.LocalObjectReference{Name: "ignition-secret"}},
+					kubev1.VolumeSource{Secret: &kubev1.SecretVolumeSource{SecretName: "ignition-secret"}},
+				),
+				table.Entry("from a ConfigMap",
+					&v1.IgnitionSource{ConfigMapRef: &kubev1.LocalObjectReference{Name: "ignition-config"}},
+					kubev1.VolumeSource{ConfigMap: &kubev1.ConfigMapVolumeSource{LocalObjectReference: kubev1.LocalObjectReference{Name: "ignition-config"}}},
+				),
+			)
+		})
+
 		Context("with a secret volume source", func() {
 			It("should add the Secret to template", func() {
 				volumes := []v1.Volume{
@@ -2396,6 +3195,42 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(pod.Spec.Containers[0].ReadinessProbe).To(BeNil())
 			})
+
+			It("should run guest agent based probes through virt-probe", func() {
+				vmi.Spec.ReadinessProbe.Handler = v1.Handler{
+					Exec: &kubev1.ExecAction{Command: []string{"cat", "/tmp/ready"}},
+				}
+				vmi.Spec.LivenessProbe.Handler = v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				livenessProbe := pod.Spec.Containers[0].LivenessProbe
+				readinessProbe := pod.Spec.Containers[0].ReadinessProbe
+
+				Expect(readinessProbe.Handler.Exec.Command).To(Equal([]string{
+					"virt-probe", "--domainName", "default_testvmi", "--timeoutSeconds", "3", "--", "cat", "/tmp/ready",
+				}))
+				Expect(readinessProbe.TimeoutSeconds).To(Equal(vmi.Spec.ReadinessProbe.TimeoutSeconds + 1))
+
+				Expect(livenessProbe.Handler.Exec.Command).To(Equal([]string{
+					"virt-probe", "--domainName", "default_testvmi", "--timeoutSeconds", "13", "--guestAgentPing",
+				}))
+				Expect(livenessProbe.TimeoutSeconds).To(Equal(vmi.Spec.LivenessProbe.TimeoutSeconds + 1))
+				Expect(livenessProbe.InitialDelaySeconds).To(Equal(vmi.Spec.LivenessProbe.InitialDelaySeconds + LibvirtStartupDelay))
+			})
+
+			It("should give guest agent based probes at least one second to run", func() {
+				vmi.Spec.LivenessProbe.TimeoutSeconds = 0
+				vmi.Spec.LivenessProbe.Handler = v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				livenessProbe := pod.Spec.Containers[0].LivenessProbe
+				Expect(livenessProbe.Handler.Exec.Command).To(ContainElement("1"))
+				Expect(livenessProbe.TimeoutSeconds).To(Equal(int32(2)))
+			})
 		})
 
 		Context("with GPU device interface", func() {
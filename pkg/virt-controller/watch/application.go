@@ -43,6 +43,10 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/healthz"
 
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
@@ -56,8 +60,12 @@ import (
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/leaderelectionconfig"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/checkup"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/clone"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/disruptionbudget"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/evacuation"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/export"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/pool"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/snapshot"
 	workloadupdater "kubevirt.io/kubevirt/pkg/virt-controller/watch/workload-updater"
 )
@@ -116,6 +124,8 @@ type VirtControllerApp struct {
 	nodeInformer   cache.SharedIndexInformer
 	nodeController *NodeController
 
+	subdomainController *SubdomainController
+
 	vmiCache      cache.Store
 	vmiController *VMIController
 	vmiInformer   cache.SharedIndexInformer
@@ -141,13 +151,28 @@ type VirtControllerApp struct {
 
 	workloadUpdateController *workloadupdater.WorkloadUpdateController
 
-	snapshotController        *snapshot.VMSnapshotController
-	restoreController         *snapshot.VMRestoreController
-	vmSnapshotInformer        cache.SharedIndexInformer
-	vmSnapshotContentInformer cache.SharedIndexInformer
-	vmRestoreInformer         cache.SharedIndexInformer
-	storageClassInformer      cache.SharedIndexInformer
-	allPodInformer            cache.SharedIndexInformer
+	snapshotController         *snapshot.VMSnapshotController
+	restoreController          *snapshot.VMRestoreController
+	snapshotScheduleController *snapshot.VMSnapshotScheduleController
+	vmSnapshotInformer         cache.SharedIndexInformer
+	vmSnapshotContentInformer  cache.SharedIndexInformer
+	vmRestoreInformer          cache.SharedIndexInformer
+	vmSnapshotScheduleInformer cache.SharedIndexInformer
+	storageClassInformer       cache.SharedIndexInformer
+	allPodInformer             cache.SharedIndexInformer
+	priorityClassInformer      cache.SharedIndexInformer
+
+	exportController *export.VMExportController
+	vmExportInformer cache.SharedIndexInformer
+
+	cloneController *clone.VMCloneController
+	vmCloneInformer cache.SharedIndexInformer
+
+	poolController *pool.PoolController
+	vmPoolInformer cache.SharedIndexInformer
+
+	storageCheckupController *checkup.StorageCheckupController
+	storageCheckupInformer   cache.SharedIndexInformer
 
 	crdInformer cache.SharedIndexInformer
 
@@ -184,6 +209,12 @@ type VirtControllerApp struct {
 	launcherSubGid                    int64
 	snapshotControllerThreads         int
 	restoreControllerThreads          int
+	snapshotScheduleControllerThreads int
+	exportControllerThreads           int
+	cloneControllerThreads            int
+	poolControllerThreads             int
+	storageCheckupControllerThreads   int
+	subdomainControllerThreads        int
 	snapshotControllerResyncPeriod    time.Duration
 
 	caConfigMapName  string
@@ -196,6 +227,10 @@ var _ service.Service = &VirtControllerApp{}
 func init() {
 	vsv1beta1.AddToScheme(scheme.Scheme)
 	snapshotv1.AddToScheme(scheme.Scheme)
+	exportv1.AddToScheme(scheme.Scheme)
+	clonev1.AddToScheme(scheme.Scheme)
+	poolv1.AddToScheme(scheme.Scheme)
+	checkupv1.AddToScheme(scheme.Scheme)
 
 	prometheus.MustRegister(leaderGauge)
 	prometheus.MustRegister(readyGauge)
@@ -279,8 +314,14 @@ func Execute() {
 	app.vmSnapshotInformer = app.informerFactory.VirtualMachineSnapshot()
 	app.vmSnapshotContentInformer = app.informerFactory.VirtualMachineSnapshotContent()
 	app.vmRestoreInformer = app.informerFactory.VirtualMachineRestore()
+	app.vmSnapshotScheduleInformer = app.informerFactory.VirtualMachineSnapshotSchedule()
 	app.storageClassInformer = app.informerFactory.StorageClass()
 	app.allPodInformer = app.informerFactory.Pod()
+	app.priorityClassInformer = app.informerFactory.PriorityClass()
+	app.vmExportInformer = app.informerFactory.VirtualMachineExport()
+	app.vmCloneInformer = app.informerFactory.VirtualMachineClone()
+	app.vmPoolInformer = app.informerFactory.VirtualMachinePool()
+	app.storageCheckupInformer = app.informerFactory.StorageCheckup()
 
 	if app.hasCDI {
 		app.dataVolumeInformer = app.informerFactory.DataVolume()
@@ -300,6 +341,11 @@ func Execute() {
 	app.initEvacuationController()
 	app.initSnapshotController()
 	app.initRestoreController()
+	app.initSnapshotScheduleController()
+	app.initExportController()
+	app.initCloneController()
+	app.initPoolController()
+	app.initStorageCheckupController()
 	app.initWorkloadUpdaterController()
 	go app.Run()
 
@@ -409,6 +455,12 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 		go vca.migrationController.Run(vca.migrationControllerThreads, stop)
 		go vca.snapshotController.Run(vca.snapshotControllerThreads, stop)
 		go vca.restoreController.Run(vca.restoreControllerThreads, stop)
+		go vca.snapshotScheduleController.Run(vca.snapshotScheduleControllerThreads, stop)
+		go vca.exportController.Run(vca.exportControllerThreads, stop)
+		go vca.cloneController.Run(vca.cloneControllerThreads, stop)
+		go vca.poolController.Run(vca.poolControllerThreads, stop)
+		go vca.storageCheckupController.Run(vca.storageCheckupControllerThreads, stop)
+		go vca.subdomainController.Run(vca.subdomainControllerThreads, stop)
 		go vca.workloadUpdateController.Run(stop)
 		cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced)
 		close(vca.readyChan)
@@ -444,10 +496,11 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.launcherSubGid,
 	)
 
-	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.persistentVolumeClaimInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer)
+	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.persistentVolumeClaimInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer, vca.nodeInformer, vca.clusterConfig)
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "node-controller")
 	vca.nodeController = NewNodeController(vca.clientSet, vca.nodeInformer, vca.vmiInformer, recorder)
 	vca.migrationController = NewMigrationController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.migrationInformer, vca.vmiRecorder, vca.clientSet, vca.clusterConfig)
+	vca.subdomainController = NewSubdomainController(vca.clientSet, vca.vmiInformer, vca.kvPodInformer)
 }
 
 func (vca *VirtControllerApp) initReplicaSet() {
@@ -497,6 +550,7 @@ func (vca *VirtControllerApp) initEvacuationController() {
 		vca.vmiInformer,
 		vca.migrationInformer,
 		vca.nodeInformer,
+		vca.priorityClassInformer,
 		recorder,
 		vca.clientSet,
 		vca.clusterConfig,
@@ -518,6 +572,7 @@ func (vca *VirtControllerApp) initSnapshotController() {
 		DVInformer:                vca.dataVolumeInformer,
 		Recorder:                  recorder,
 		ResyncPeriod:              vca.snapshotControllerResyncPeriod,
+		ClusterConfig:             vca.clusterConfig,
 	}
 	vca.snapshotController.Init()
 }
@@ -526,6 +581,7 @@ func (vca *VirtControllerApp) initRestoreController() {
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "restore-controller")
 	vca.restoreController = &snapshot.VMRestoreController{
 		Client:                    vca.clientSet,
+		CopyImage:                 vca.launcherImage,
 		VMRestoreInformer:         vca.vmRestoreInformer,
 		VMSnapshotInformer:        vca.vmSnapshotInformer,
 		VMSnapshotContentInformer: vca.vmSnapshotContentInformer,
@@ -534,11 +590,84 @@ func (vca *VirtControllerApp) initRestoreController() {
 		DataVolumeInformer:        vca.dataVolumeInformer,
 		PVCInformer:               vca.persistentVolumeClaimInformer,
 		StorageClassInformer:      vca.storageClassInformer,
+		PodInformer:               vca.allPodInformer,
 		Recorder:                  recorder,
 	}
 	vca.restoreController.Init()
 }
 
+func (vca *VirtControllerApp) initSnapshotScheduleController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "snapshot-schedule-controller")
+	vca.snapshotScheduleController = &snapshot.VMSnapshotScheduleController{
+		Client:                     vca.clientSet,
+		VMSnapshotScheduleInformer: vca.vmSnapshotScheduleInformer,
+		VMSnapshotInformer:         vca.vmSnapshotInformer,
+		VMInformer:                 vca.vmInformer,
+		VMIInformer:                vca.vmiInformer,
+		Recorder:                   recorder,
+	}
+	vca.snapshotScheduleController.Init()
+}
+
+func (vca *VirtControllerApp) initExportController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "export-controller")
+	vca.exportController = &export.VMExportController{
+		Client:                    vca.clientSet,
+		ExporterImage:             vca.launcherImage,
+		VMExportInformer:          vca.vmExportInformer,
+		PVCInformer:               vca.persistentVolumeClaimInformer,
+		PodInformer:               vca.allPodInformer,
+		VMInformer:                vca.vmInformer,
+		VMIInformer:               vca.vmiInformer,
+		VMSnapshotInformer:        vca.vmSnapshotInformer,
+		VMSnapshotContentInformer: vca.vmSnapshotContentInformer,
+		Recorder:                  recorder,
+	}
+	vca.exportController.Init()
+}
+
+func (vca *VirtControllerApp) initCloneController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "clone-controller")
+	vca.cloneController = &clone.VMCloneController{
+		Client:                    vca.clientSet,
+		VMCloneInformer:           vca.vmCloneInformer,
+		VMInformer:                vca.vmInformer,
+		VMIInformer:               vca.vmiInformer,
+		VMSnapshotInformer:        vca.vmSnapshotInformer,
+		VMSnapshotContentInformer: vca.vmSnapshotContentInformer,
+		PVCInformer:               vca.persistentVolumeClaimInformer,
+		Recorder:                  recorder,
+	}
+	vca.cloneController.Init()
+}
+
+func (vca *VirtControllerApp) initPoolController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "pool-controller")
+	vca.poolController = &pool.PoolController{
+		Client:         vca.clientSet,
+		VMPoolInformer: vca.vmPoolInformer,
+		VMInformer:     vca.vmInformer,
+		Recorder:       recorder,
+		BurstReplicas:  controller.BurstReplicas,
+	}
+	vca.poolController.Init()
+}
+
+func (vca *VirtControllerApp) initStorageCheckupController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "checkup-controller")
+	vca.storageCheckupController = &checkup.StorageCheckupController{
+		Client:                 vca.clientSet,
+		BenchImage:             vca.launcherImage,
+		StorageCheckupInformer: vca.storageCheckupInformer,
+		PVCInformer:            vca.persistentVolumeClaimInformer,
+		PodInformer:            vca.allPodInformer,
+		VMIInformer:            vca.vmiInformer,
+		StorageClassInformer:   vca.storageClassInformer,
+		Recorder:               recorder,
+	}
+	vca.storageCheckupController.Init()
+}
+
 func (vca *VirtControllerApp) leaderProbe(_ *restful.Request, response *restful.Response) {
 	res := map[string]interface{}{}
 
@@ -617,6 +746,24 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.restoreControllerThreads, "restore-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for restore controller")
 
+	flag.IntVar(&vca.snapshotScheduleControllerThreads, "snapshot-schedule-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for snapshot schedule controller")
+
+	flag.IntVar(&vca.exportControllerThreads, "export-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for export controller")
+
+	flag.IntVar(&vca.cloneControllerThreads, "clone-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for clone controller")
+
+	flag.IntVar(&vca.poolControllerThreads, "pool-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for pool controller")
+
+	flag.IntVar(&vca.storageCheckupControllerThreads, "storage-checkup-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for storage checkup controller")
+
+	flag.IntVar(&vca.subdomainControllerThreads, "subdomain-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for subdomain controller")
+
 	flag.DurationVar(&vca.snapshotControllerResyncPeriod, "snapshot-controller-resync-period", defaultSnapshotControllerResyncPeriod,
 		"Number of goroutines to run for snapshot controller")
 
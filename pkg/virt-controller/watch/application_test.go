@@ -41,6 +41,10 @@ import (
 	io_prometheus_client "github.com/prometheus/client_model/go"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
@@ -48,10 +52,15 @@ import (
 	"kubevirt.io/kubevirt/pkg/rest"
 	testutils "kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/checkup"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/clone"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/disruptionbudget"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/evacuation"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/export"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/pool"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/snapshot"
 
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 )
@@ -85,14 +94,20 @@ var _ = Describe("Application", func() {
 		dataVolumeInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 		rsInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceReplicaSet{})
 		storageClassInformer, _ := testutils.NewFakeInformerFor(&storagev1.StorageClass{})
+		priorityClassInformer, _ := testutils.NewFakeInformerFor(&schedulingv1.PriorityClass{})
 		crdInformer, _ := testutils.NewFakeInformerFor(&extv1beta1.CustomResourceDefinition{})
 		vmRestoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
+		vmSnapshotScheduleInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotSchedule{})
 		dvInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		vmExportInformer, _ := testutils.NewFakeInformerFor(&exportv1.VirtualMachineExport{})
+		vmCloneInformer, _ := testutils.NewFakeInformerFor(&clonev1.VirtualMachineClone{})
+		vmPoolInformer, _ := testutils.NewFakeInformerFor(&poolv1.VirtualMachinePool{})
+		storageCheckupInformer, _ := testutils.NewFakeInformerFor(&checkupv1.StorageCheckup{})
 
 		var qemuGid int64 = 107
 
 		app.informerFactory = controller.NewKubeInformerFactory(nil, nil, nil, "test")
-		app.evacuationController = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, recorder, virtClient, config)
+		app.evacuationController = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, priorityClassInformer, recorder, virtClient, config)
 		app.disruptionBudgetController = disruptionbudget.NewDisruptionBudgetController(vmiInformer, pdbInformer, recorder, virtClient)
 		app.nodeController = NewNodeController(virtClient, nodeInformer, vmiInformer, recorder)
 		app.vmiController = NewVMIController(services.NewTemplateService("a", "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
@@ -102,6 +117,8 @@ var _ = Describe("Application", func() {
 			recorder,
 			virtClient,
 			dataVolumeInformer,
+			nodeInformer,
+			config,
 		)
 		app.rsController = NewVMIReplicaSet(vmiInformer, rsInformer, recorder, virtClient, uint(10))
 		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, recorder, virtClient)
@@ -126,6 +143,7 @@ var _ = Describe("Application", func() {
 			DVInformer:                dvInformer,
 			Recorder:                  recorder,
 			ResyncPeriod:              60 * time.Second,
+			ClusterConfig:             config,
 		}
 		app.snapshotController.Init()
 		app.restoreController = &snapshot.VMRestoreController{
@@ -138,9 +156,61 @@ var _ = Describe("Application", func() {
 			PVCInformer:               pvcInformer,
 			StorageClassInformer:      storageClassInformer,
 			DataVolumeInformer:        dataVolumeInformer,
+			PodInformer:               podInformer,
 			Recorder:                  recorder,
 		}
 		app.restoreController.Init()
+		app.snapshotScheduleController = &snapshot.VMSnapshotScheduleController{
+			Client:                     virtClient,
+			VMSnapshotScheduleInformer: vmSnapshotScheduleInformer,
+			VMSnapshotInformer:         vmSnapshotInformer,
+			VMInformer:                 vmInformer,
+			VMIInformer:                vmiInformer,
+			Recorder:                   recorder,
+		}
+		app.snapshotScheduleController.Init()
+		app.exportController = &export.VMExportController{
+			Client:                    virtClient,
+			VMExportInformer:          vmExportInformer,
+			PVCInformer:               pvcInformer,
+			PodInformer:               podInformer,
+			VMInformer:                vmInformer,
+			VMIInformer:               vmiInformer,
+			VMSnapshotInformer:        vmSnapshotInformer,
+			VMSnapshotContentInformer: vmSnapshotContentInformer,
+			Recorder:                  recorder,
+		}
+		app.exportController.Init()
+		app.cloneController = &clone.VMCloneController{
+			Client:                    virtClient,
+			VMCloneInformer:           vmCloneInformer,
+			VMInformer:                vmInformer,
+			VMIInformer:               vmiInformer,
+			VMSnapshotInformer:        vmSnapshotInformer,
+			VMSnapshotContentInformer: vmSnapshotContentInformer,
+			PVCInformer:               pvcInformer,
+			Recorder:                  recorder,
+		}
+		app.cloneController.Init()
+		app.poolController = &pool.PoolController{
+			Client:         virtClient,
+			VMPoolInformer: vmPoolInformer,
+			VMInformer:     vmInformer,
+			Recorder:       recorder,
+			BurstReplicas:  controller.BurstReplicas,
+		}
+		app.poolController.Init()
+		app.storageCheckupController = &checkup.StorageCheckupController{
+			Client:                 virtClient,
+			StorageCheckupInformer: storageCheckupInformer,
+			PVCInformer:            pvcInformer,
+			PodInformer:            podInformer,
+			VMIInformer:            vmiInformer,
+			StorageClassInformer:   storageClassInformer,
+			Recorder:               recorder,
+		}
+		app.storageCheckupController.Init()
+		app.subdomainController = NewSubdomainController(virtClient, vmiInformer, podInformer)
 		app.persistentVolumeClaimInformer = pvcInformer
 
 		app.readyChan = make(chan bool)
@@ -0,0 +1,899 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package checkup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// storageCheckupLabel links the scratch objects to the StorageCheckup
+	storageCheckupLabel = "checkup.kubevirt.io/storage-checkup"
+
+	benchContainerName = "bench"
+	benchVolumeName    = "scratch"
+	benchVolumePath    = "/scratch"
+	// benchImageSize is the size of the image benchmarked on filesystem volumes
+	benchImageSize = "128M"
+
+	defaultVolumeSnapshotClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
+
+	defaultTimeout = 10 * time.Minute
+
+	// snapshotPollInterval is the interval the scratch VolumeSnapshot is checked at, VolumeSnapshots are not watched
+	snapshotPollInterval = 5 * time.Second
+
+	checkupStartedEvent   = "StorageCheckupStarted"
+	checkupSucceededEvent = "StorageCheckupSucceeded"
+	checkupFailedEvent    = "StorageCheckupFailed"
+)
+
+var defaultSize = resource.MustParse("1Gi")
+
+// variable so can be overridden in tests
+var currentTime = func() *metav1.Time {
+	t := metav1.Now()
+	return &t
+}
+
+// benchmark is one run of qemu-img bench on the scratch volume
+type benchmark struct {
+	write bool
+	count int64
+	depth int64
+	size  int64
+}
+
+// benchmarks are run in this order, the results are parsed in the same order
+var benchmarks = []benchmark{
+	{write: true, count: 256, depth: 16, size: 1 << 20},
+	{write: false, count: 256, depth: 16, size: 1 << 20},
+	{write: true, count: 1000, depth: 1, size: 4 << 10},
+	{write: false, count: 1000, depth: 1, size: 4 << 10},
+}
+
+var benchResultRegexp = regexp.MustCompile(`Run completed in ([0-9.]+) seconds`)
+
+// StorageCheckupController runs the StorageCheckups
+type StorageCheckupController struct {
+	Client kubecli.KubevirtClient
+
+	// BenchImage is the image the benchmark pod runs in, it has to provide qemu-img
+	BenchImage string
+
+	StorageCheckupInformer cache.SharedIndexInformer
+	PVCInformer            cache.SharedIndexInformer
+	PodInformer            cache.SharedIndexInformer
+	VMIInformer            cache.SharedIndexInformer
+	StorageClassInformer   cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	checkupQueue workqueue.RateLimitingInterface
+}
+
+// stepState is the outcome of reconciling one step of a checkup
+type stepState struct {
+	// waiting describes what the step waits for, it is empty once the step completed
+	waiting string
+	// failure is set when the step failed
+	failure string
+}
+
+var completed = &stepState{}
+
+func waitingFor(format string, args ...interface{}) *stepState {
+	return &stepState{waiting: fmt.Sprintf(format, args...)}
+}
+
+func failed(format string, args ...interface{}) *stepState {
+	return &stepState{failure: fmt.Sprintf(format, args...)}
+}
+
+// Init initializes the checkup controller
+func (ctrl *StorageCheckupController) Init() {
+	ctrl.checkupQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "checkup-controller-storagecheckup")
+
+	ctrl.StorageCheckupInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleStorageCheckup,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleStorageCheckup(newObj) },
+		},
+	)
+
+	for _, informer := range []cache.SharedIndexInformer{ctrl.PVCInformer, ctrl.PodInformer, ctrl.VMIInformer} {
+		informer.AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    ctrl.handleScratchObject,
+				UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleScratchObject(newObj) },
+				DeleteFunc: ctrl.handleScratchObject,
+			},
+		)
+	}
+}
+
+// Run the controller
+func (ctrl *StorageCheckupController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.checkupQueue.ShutDown()
+
+	log.Log.Info("Starting checkup controller.")
+	defer log.Log.Info("Shutting down checkup controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.StorageCheckupInformer.HasSynced,
+		ctrl.PVCInformer.HasSynced,
+		ctrl.PodInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.StorageClassInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.checkupWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *StorageCheckupController) checkupWorker() {
+	for ctrl.processCheckupWorkItem() {
+	}
+}
+
+func (ctrl *StorageCheckupController) processCheckupWorkItem() bool {
+	obj, shutdown := ctrl.checkupQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ctrl.checkupQueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		ctrl.checkupQueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	log.Log.V(3).Infof("storageCheckup worker processing key [%s]", key)
+
+	if err := ctrl.execute(key); err != nil {
+		utilruntime.HandleError(err)
+		ctrl.checkupQueue.AddRateLimited(key)
+		return true
+	}
+
+	ctrl.checkupQueue.Forget(obj)
+	return true
+}
+
+func (ctrl *StorageCheckupController) execute(key string) error {
+	storeObj, exists, err := ctrl.StorageCheckupInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	checkup, ok := storeObj.(*checkupv1.StorageCheckup)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateStorageCheckup(checkup.DeepCopy())
+}
+
+func (ctrl *StorageCheckupController) handleStorageCheckup(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if checkup, ok := obj.(*checkupv1.StorageCheckup); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(checkup)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, checkup)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.checkupQueue.Add(objName)
+	}
+}
+
+func (ctrl *StorageCheckupController) handleScratchObject(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if o, ok := obj.(metav1.Object); ok {
+		checkupName, ok := o.GetLabels()[storageCheckupLabel]
+		if !ok {
+			return
+		}
+
+		ctrl.checkupQueue.Add(cacheKeyFunc(o.GetNamespace(), checkupName))
+	}
+}
+
+func cacheKeyFunc(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// scratchName is the name of the scratch PVC, pod, VirtualMachineInstance and VolumeSnapshot of the checkup
+func scratchName(checkup *checkupv1.StorageCheckup) string {
+	return fmt.Sprintf("storage-checkup-%s", checkup.Name)
+}
+
+func restoreName(checkup *checkupv1.StorageCheckup) string {
+	return fmt.Sprintf("%s-restore", scratchName(checkup))
+}
+
+func ownerReference(checkup *checkupv1.StorageCheckup) metav1.OwnerReference {
+	t := true
+	return metav1.OwnerReference{
+		APIVersion:         checkupv1.SchemeGroupVersion.String(),
+		Kind:               "StorageCheckup",
+		Name:               checkup.Name,
+		UID:                checkup.UID,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}
+}
+
+func scratchObjectMeta(checkup *checkupv1.StorageCheckup, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       checkup.Namespace,
+		OwnerReferences: []metav1.OwnerReference{ownerReference(checkup)},
+		Labels: map[string]string{
+			storageCheckupLabel: checkup.Name,
+		},
+	}
+}
+
+func checkupTimeout(checkup *checkupv1.StorageCheckup) time.Duration {
+	if checkup.Spec.Timeout != nil {
+		return checkup.Spec.Timeout.Duration
+	}
+	return defaultTimeout
+}
+
+func since(t metav1.Time) *metav1.Duration {
+	return &metav1.Duration{Duration: currentTime().Sub(t.Time)}
+}
+
+func (ctrl *StorageCheckupController) updateStorageCheckup(checkup *checkupv1.StorageCheckup) error {
+	log.Log.V(3).Infof("Updating StorageCheckup %s/%s", checkup.Namespace, checkup.Name)
+
+	// the scratch objects are garbage collected
+	if checkup.DeletionTimestamp != nil {
+		return nil
+	}
+
+	checkupCopy := checkup.DeepCopy()
+	if checkupCopy.Status == nil {
+		checkupCopy.Status = &checkupv1.StorageCheckupStatus{
+			Phase:          checkupv1.Running,
+			StartTimestamp: currentTime(),
+			Results:        &checkupv1.StorageCheckupResults{},
+		}
+		if err := ctrl.updateStatus(checkup, checkupCopy); err != nil {
+			return err
+		}
+		ctrl.Recorder.Eventf(checkup, corev1.EventTypeNormal, checkupStartedEvent, "Started checkup of storage class %s", checkup.Spec.StorageClassName)
+		return nil
+	}
+
+	if checkupCopy.Status.Phase == checkupv1.Succeeded || checkupCopy.Status.Phase == checkupv1.Failed {
+		return nil
+	}
+
+	if checkupCopy.Status.Results == nil {
+		checkupCopy.Status.Results = &checkupv1.StorageCheckupResults{}
+	}
+
+	timeout := checkupTimeout(checkup)
+	remaining := checkupCopy.Status.StartTimestamp.Add(timeout).Sub(currentTime().Time)
+	if remaining <= 0 {
+		return ctrl.finish(checkup, checkupCopy, checkupv1.Failed, fmt.Sprintf("checkup did not complete within %s", timeout))
+	}
+
+	steps := []func(*checkupv1.StorageCheckup) (*stepState, error){
+		ctrl.provision,
+		ctrl.bench,
+		ctrl.attach,
+		ctrl.snapshotRestore,
+	}
+	for _, step := range steps {
+		state, err := step(checkupCopy)
+		if err != nil {
+			return err
+		}
+
+		if state.failure != "" {
+			return ctrl.finish(checkup, checkupCopy, checkupv1.Failed, state.failure)
+		}
+
+		if state.waiting != "" {
+			checkupCopy.Status.Message = state.waiting
+			// the checkup times out even if none of the scratch objects change
+			ctrl.checkupQueue.AddAfter(cacheKeyFunc(checkup.Namespace, checkup.Name), remaining)
+			return ctrl.updateStatus(checkup, checkupCopy)
+		}
+	}
+
+	return ctrl.finish(checkup, checkupCopy, checkupv1.Succeeded, "")
+}
+
+// finish deletes the scratch objects and completes the checkup
+func (ctrl *StorageCheckupController) finish(checkup, checkupCopy *checkupv1.StorageCheckup, phase checkupv1.StorageCheckupPhase, message string) error {
+	if err := ctrl.deleteScratchObjects(checkup); err != nil {
+		return err
+	}
+
+	checkupCopy.Status.Phase = phase
+	checkupCopy.Status.Message = message
+	checkupCopy.Status.CompletionTimestamp = currentTime()
+	if err := ctrl.updateStatus(checkup, checkupCopy); err != nil {
+		return err
+	}
+
+	if phase == checkupv1.Succeeded {
+		ctrl.Recorder.Eventf(checkup, corev1.EventTypeNormal, checkupSucceededEvent, "Checkup of storage class %s succeeded", checkup.Spec.StorageClassName)
+	} else {
+		ctrl.Recorder.Eventf(checkup, corev1.EventTypeWarning, checkupFailedEvent, "Checkup of storage class %s failed: %s", checkup.Spec.StorageClassName, message)
+	}
+
+	return nil
+}
+
+func (ctrl *StorageCheckupController) updateStatus(checkup, checkupCopy *checkupv1.StorageCheckup) error {
+	if reflect.DeepEqual(checkup.Status, checkupCopy.Status) {
+		return nil
+	}
+
+	_, err := ctrl.Client.StorageCheckup(checkupCopy.Namespace).UpdateStatus(context.Background(), checkupCopy, metav1.UpdateOptions{})
+	return err
+}
+
+func (ctrl *StorageCheckupController) getPVC(namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*corev1.PersistentVolumeClaim), nil
+}
+
+func (ctrl *StorageCheckupController) getPod(namespace, name string) (*corev1.Pod, error) {
+	obj, exists, err := ctrl.PodInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*corev1.Pod), nil
+}
+
+func (ctrl *StorageCheckupController) getVMI(namespace, name string) (*kubevirtv1.VirtualMachineInstance, error) {
+	obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*kubevirtv1.VirtualMachineInstance), nil
+}
+
+// waitForFirstConsumer returns true if volumes of the storage class are only bound once a pod uses them
+func (ctrl *StorageCheckupController) waitForFirstConsumer(storageClassName string) (bool, error) {
+	obj, exists, err := ctrl.StorageClassInformer.GetStore().GetByKey(storageClassName)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	storageClass := obj.(*storagev1.StorageClass)
+	return storageClass.VolumeBindingMode != nil && *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer, nil
+}
+
+func newScratchPVC(checkup *checkupv1.StorageCheckup, name string) *corev1.PersistentVolumeClaim {
+	size := defaultSize
+	if checkup.Spec.Size != nil {
+		size = *checkup.Spec.Size
+	}
+	storageClassName := checkup.Spec.StorageClassName
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: scratchObjectMeta(checkup, name),
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &storageClassName,
+			VolumeMode:       checkup.Spec.VolumeMode,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+}
+
+// provision creates the scratch PVC and measures the time until it is bound
+func (ctrl *StorageCheckupController) provision(checkup *checkupv1.StorageCheckup) (*stepState, error) {
+	name := scratchName(checkup)
+	pvc, err := ctrl.getPVC(checkup.Namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if pvc == nil {
+		if _, err := ctrl.Client.CoreV1().PersistentVolumeClaims(checkup.Namespace).Create(context.Background(), newScratchPVC(checkup, name), metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		return waitingFor("waiting for PersistentVolumeClaim %s to be bound", name), nil
+	}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		if checkup.Status.Results.ProvisionDuration == nil {
+			checkup.Status.Results.ProvisionDuration = since(pvc.CreationTimestamp)
+		}
+		return completed, nil
+	case corev1.ClaimLost:
+		return failed("PersistentVolumeClaim %s lost its volume", name), nil
+	}
+
+	// the volume is bound once the benchmark pod is scheduled, the duration is measured until then
+	waitForFirstConsumer, err := ctrl.waitForFirstConsumer(checkup.Spec.StorageClassName)
+	if err != nil {
+		return nil, err
+	}
+	if waitForFirstConsumer {
+		return completed, nil
+	}
+
+	return waitingFor("waiting for PersistentVolumeClaim %s to be bound", name), nil
+}
+
+func isBlock(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock
+}
+
+// benchScript returns the script running the benchmarks on the volume at path.
+// The output of the benchmarks, or the error of the failing one, is the termination message.
+func benchScript(path string, block bool) string {
+	var script strings.Builder
+	script.WriteString("set -e\n")
+	if !block {
+		fmt.Fprintf(&script, "qemu-img create -f raw %s %s > /dev/null\n", path, benchImageSize)
+	}
+
+	for _, b := range benchmarks {
+		args := fmt.Sprintf("-f raw -n -t none -c %d -d %d -s %d", b.count, b.depth, b.size)
+		if b.write {
+			args = "-w " + args
+		}
+		fmt.Fprintf(&script, "qemu-img bench %s %s >> %s 2>&1\n", args, path, corev1.TerminationMessagePathDefault)
+	}
+
+	return script.String()
+}
+
+func (ctrl *StorageCheckupController) newBenchPod(checkup *checkupv1.StorageCheckup, pvc *corev1.PersistentVolumeClaim) *corev1.Pod {
+	container := corev1.Container{
+		Name:                     benchContainerName,
+		Image:                    ctrl.BenchImage,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		Command:                  []string{"/bin/bash", "-c"},
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+	}
+
+	if isBlock(pvc) {
+		container.VolumeDevices = []corev1.VolumeDevice{{Name: benchVolumeName, DevicePath: benchVolumePath}}
+		container.Args = []string{benchScript(benchVolumePath, true)}
+	} else {
+		container.VolumeMounts = []corev1.VolumeMount{{Name: benchVolumeName, MountPath: benchVolumePath}}
+		container.Args = []string{benchScript(benchVolumePath+"/disk.img", false)}
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: scratchObjectMeta(checkup, scratchName(checkup)),
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers:    []corev1.Container{container},
+			Volumes: []corev1.Volume{
+				{
+					Name: benchVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: pvc.Name,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func terminationMessage(pod *corev1.Pod) string {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == benchContainerName && containerStatus.State.Terminated != nil {
+			return containerStatus.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
+// parseBenchResults fills the results from the output of the benchmarks
+func parseBenchResults(message string, results *checkupv1.StorageCheckupResults) error {
+	matches := benchResultRegexp.FindAllStringSubmatch(message, -1)
+	if len(matches) != len(benchmarks) {
+		return fmt.Errorf("expected %d benchmark results, found %d", len(benchmarks), len(matches))
+	}
+
+	var throughputs []*resource.Quantity
+	var latencies []*metav1.Duration
+	for i, match := range matches {
+		seconds, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return err
+		}
+		// qemu-img reports milliseconds, a faster run is accounted as one
+		if seconds < 0.001 {
+			seconds = 0.001
+		}
+
+		b := benchmarks[i]
+		throughputs = append(throughputs, resource.NewQuantity(int64(float64(b.count*b.size)/seconds), resource.BinarySI))
+		latencies = append(latencies, &metav1.Duration{Duration: time.Duration(seconds * float64(time.Second) / float64(b.count))})
+	}
+
+	results.WriteThroughput = throughputs[0]
+	results.ReadThroughput = throughputs[1]
+	results.WriteLatency = latencies[2]
+	results.ReadLatency = latencies[3]
+	return nil
+}
+
+// bench runs qemu-img bench on the scratch PVC in a pod
+func (ctrl *StorageCheckupController) bench(checkup *checkupv1.StorageCheckup) (*stepState, error) {
+	name := scratchName(checkup)
+	pod, err := ctrl.getPod(checkup.Namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkup.Status.Results.WriteThroughput != nil {
+		return completed, ctrl.deletePod(pod)
+	}
+
+	if pod == nil {
+		pvc, err := ctrl.getPVC(checkup.Namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if pvc == nil {
+			return waitingFor("waiting for PersistentVolumeClaim %s", name), nil
+		}
+
+		if _, err := ctrl.Client.CoreV1().Pods(checkup.Namespace).Create(context.Background(), ctrl.newBenchPod(checkup, pvc), metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		return waitingFor("running the benchmarks in pod %s", name), nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		if err := parseBenchResults(terminationMessage(pod), checkup.Status.Results); err != nil {
+			return failed("invalid result of pod %s: %v", name, err), nil
+		}
+		return completed, ctrl.deletePod(pod)
+	case corev1.PodFailed:
+		message := terminationMessage(pod)
+		if message == "" {
+			message = fmt.Sprintf("pod %s failed", name)
+		}
+		return failed("benchmarks failed: %s", strings.TrimSpace(message)), nil
+	}
+
+	return waitingFor("running the benchmarks in pod %s", name), nil
+}
+
+func newScratchVMI(checkup *checkupv1.StorageCheckup, pvcName string) *kubevirtv1.VirtualMachineInstance {
+	vmi := kubevirtv1.NewMinimalVMIWithNS(checkup.Namespace, scratchName(checkup))
+	vmi.ObjectMeta = scratchObjectMeta(checkup, vmi.Name)
+	vmi.Spec.Domain.Devices.Disks = []kubevirtv1.Disk{
+		{
+			Name: benchVolumeName,
+			DiskDevice: kubevirtv1.DiskDevice{
+				Disk: &kubevirtv1.DiskTarget{Bus: "virtio"},
+			},
+		},
+	}
+	vmi.Spec.Volumes = []kubevirtv1.Volume{
+		{
+			Name: benchVolumeName,
+			VolumeSource: kubevirtv1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvcName,
+				},
+			},
+		},
+	}
+	return vmi
+}
+
+// attach runs a VirtualMachineInstance using the scratch PVC and measures the time until it is running
+func (ctrl *StorageCheckupController) attach(checkup *checkupv1.StorageCheckup) (*stepState, error) {
+	name := scratchName(checkup)
+	vmi, err := ctrl.getVMI(checkup.Namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkup.Status.Results.AttachDuration != nil {
+		return completed, ctrl.deleteVMI(vmi)
+	}
+
+	if vmi == nil {
+		if _, err := ctrl.Client.VirtualMachineInstance(checkup.Namespace).Create(newScratchVMI(checkup, name)); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		return waitingFor("waiting for VirtualMachineInstance %s to be running", name), nil
+	}
+
+	switch vmi.Status.Phase {
+	case kubevirtv1.Running:
+		checkup.Status.Results.AttachDuration = since(vmi.CreationTimestamp)
+		return completed, ctrl.deleteVMI(vmi)
+	case kubevirtv1.Failed:
+		return failed("VirtualMachineInstance %s failed", name), nil
+	}
+
+	return waitingFor("waiting for VirtualMachineInstance %s to be running", name), nil
+}
+
+// volumeSnapshotClass returns the VolumeSnapshotClass of the checkup, or an empty string if the storage class has none
+func (ctrl *StorageCheckupController) volumeSnapshotClass(checkup *checkupv1.StorageCheckup) (string, error) {
+	if checkup.Spec.VolumeSnapshotClassName != nil {
+		return *checkup.Spec.VolumeSnapshotClassName, nil
+	}
+
+	obj, exists, err := ctrl.StorageClassInformer.GetStore().GetByKey(checkup.Spec.StorageClassName)
+	if err != nil || !exists {
+		return "", err
+	}
+	storageClass := obj.(*storagev1.StorageClass)
+
+	volumeSnapshotClasses, err := ctrl.Client.KubernetesSnapshotClient().SnapshotV1beta1().VolumeSnapshotClasses().List(context.Background(), metav1.ListOptions{})
+	if errors.IsNotFound(err) {
+		// the snapshot API is not installed
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var matches []vsv1beta1.VolumeSnapshotClass
+	for _, volumeSnapshotClass := range volumeSnapshotClasses.Items {
+		if volumeSnapshotClass.Driver == storageClass.Provisioner {
+			matches = append(matches, volumeSnapshotClass)
+		}
+	}
+
+	if len(matches) == 1 {
+		return matches[0].Name, nil
+	}
+
+	for _, volumeSnapshotClass := range matches {
+		if _, ok := volumeSnapshotClass.Annotations[defaultVolumeSnapshotClassAnnotation]; ok {
+			return volumeSnapshotClass.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+func newScratchVolumeSnapshot(checkup *checkupv1.StorageCheckup, volumeSnapshotClass string) *vsv1beta1.VolumeSnapshot {
+	pvcName := scratchName(checkup)
+	return &vsv1beta1.VolumeSnapshot{
+		ObjectMeta: scratchObjectMeta(checkup, scratchName(checkup)),
+		Spec: vsv1beta1.VolumeSnapshotSpec{
+			Source: vsv1beta1.VolumeSnapshotSource{
+				PersistentVolumeClaimName: &pvcName,
+			},
+			VolumeSnapshotClassName: &volumeSnapshotClass,
+		},
+	}
+}
+
+func newRestorePVC(checkup *checkupv1.StorageCheckup) *corev1.PersistentVolumeClaim {
+	apiGroup := vsv1beta1.GroupName
+	pvc := newScratchPVC(checkup, restoreName(checkup))
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     "VolumeSnapshot",
+		Name:     scratchName(checkup),
+	}
+	return pvc
+}
+
+// snapshotRestore snapshots the scratch PVC and restores the snapshot to a new PVC.
+// It is skipped if there is no VolumeSnapshotClass for the storage class. For storage classes binding
+// volumes to their first consumer, the duration is measured until the restored PVC is created.
+func (ctrl *StorageCheckupController) snapshotRestore(checkup *checkupv1.StorageCheckup) (*stepState, error) {
+	if checkup.Status.Results.SnapshotRestoreDuration != nil {
+		return completed, nil
+	}
+
+	volumeSnapshotClass, err := ctrl.volumeSnapshotClass(checkup)
+	if err != nil {
+		return nil, err
+	}
+	if volumeSnapshotClass == "" {
+		log.Log.V(3).Infof("No VolumeSnapshotClass for StorageCheckup %s/%s, skipping the snapshot", checkup.Namespace, checkup.Name)
+		return completed, nil
+	}
+
+	key := cacheKeyFunc(checkup.Namespace, checkup.Name)
+	name := scratchName(checkup)
+	volumeSnapshots := ctrl.Client.KubernetesSnapshotClient().SnapshotV1beta1().VolumeSnapshots(checkup.Namespace)
+	volumeSnapshot, err := volumeSnapshots.Get(context.Background(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := volumeSnapshots.Create(context.Background(), newScratchVolumeSnapshot(checkup, volumeSnapshotClass), metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		ctrl.checkupQueue.AddAfter(key, snapshotPollInterval)
+		return waitingFor("waiting for VolumeSnapshot %s to be ready", name), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if status := volumeSnapshot.Status; status != nil && status.Error != nil && status.Error.Message != nil {
+		return failed("VolumeSnapshot %s failed: %s", name, *status.Error.Message), nil
+	}
+	if status := volumeSnapshot.Status; status == nil || status.ReadyToUse == nil || !*status.ReadyToUse {
+		ctrl.checkupQueue.AddAfter(key, snapshotPollInterval)
+		return waitingFor("waiting for VolumeSnapshot %s to be ready", name), nil
+	}
+
+	restoreName := restoreName(checkup)
+	pvc, err := ctrl.getPVC(checkup.Namespace, restoreName)
+	if err != nil {
+		return nil, err
+	}
+
+	if pvc == nil {
+		if _, err := ctrl.Client.CoreV1().PersistentVolumeClaims(checkup.Namespace).Create(context.Background(), newRestorePVC(checkup), metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		return waitingFor("waiting for PersistentVolumeClaim %s to be bound", restoreName), nil
+	}
+
+	waitForFirstConsumer, err := ctrl.waitForFirstConsumer(checkup.Spec.StorageClassName)
+	if err != nil {
+		return nil, err
+	}
+	if pvc.Status.Phase != corev1.ClaimBound && !waitForFirstConsumer {
+		return waitingFor("waiting for PersistentVolumeClaim %s to be bound", restoreName), nil
+	}
+
+	checkup.Status.Results.SnapshotRestoreDuration = since(volumeSnapshot.CreationTimestamp)
+	return completed, nil
+}
+
+func (ctrl *StorageCheckupController) deletePod(pod *corev1.Pod) error {
+	if pod == nil || pod.DeletionTimestamp != nil {
+		return nil
+	}
+
+	err := ctrl.Client.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (ctrl *StorageCheckupController) deleteVMI(vmi *kubevirtv1.VirtualMachineInstance) error {
+	if vmi == nil || vmi.DeletionTimestamp != nil {
+		return nil
+	}
+
+	err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).Delete(vmi.Name, &metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (ctrl *StorageCheckupController) deletePVC(pvc *corev1.PersistentVolumeClaim) error {
+	if pvc == nil || pvc.DeletionTimestamp != nil {
+		return nil
+	}
+
+	err := ctrl.Client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(context.Background(), pvc.Name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// deleteScratchObjects deletes the objects created by the checkup once it completed
+func (ctrl *StorageCheckupController) deleteScratchObjects(checkup *checkupv1.StorageCheckup) error {
+	name := scratchName(checkup)
+
+	pod, err := ctrl.getPod(checkup.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if err := ctrl.deletePod(pod); err != nil {
+		return err
+	}
+
+	vmi, err := ctrl.getVMI(checkup.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if err := ctrl.deleteVMI(vmi); err != nil {
+		return err
+	}
+
+	for _, pvcName := range []string{name, restoreName(checkup)} {
+		pvc, err := ctrl.getPVC(checkup.Namespace, pvcName)
+		if err != nil {
+			return err
+		}
+		if err := ctrl.deletePVC(pvc); err != nil {
+			return err
+		}
+	}
+
+	// the VolumeSnapshot is deleted after the PVC restored from it
+	err = ctrl.Client.KubernetesSnapshotClient().SnapshotV1beta1().VolumeSnapshots(checkup.Namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
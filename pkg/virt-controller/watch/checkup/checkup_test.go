@@ -0,0 +1,517 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package checkup
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	framework "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	k8ssnapshotfake "kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned/fake"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+const benchOutput = `Sending 256 write requests, 1048576 bytes each, 16 in parallel (starting at offset 0, step size 1048576)
+Run completed in 2.000 seconds.
+Sending 256 read requests, 1048576 bytes each, 16 in parallel (starting at offset 0, step size 1048576)
+Run completed in 1.000 seconds.
+Sending 1000 write requests, 4096 bytes each, 1 in parallel (starting at offset 0, step size 4096)
+Run completed in 2.000 seconds.
+Sending 1000 read requests, 4096 bytes each, 1 in parallel (starting at offset 0, step size 4096)
+Run completed in 0.500 seconds.
+`
+
+var _ = Describe("Storage checkup controller", func() {
+	const (
+		testNamespace    = "default"
+		checkupName      = "checkup"
+		scratch          = "storage-checkup-checkup"
+		storageClassName = "standard"
+		provisioner      = "csi.example.com"
+	)
+
+	var (
+		startTime = metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+		timeStamp metav1.Time
+	)
+
+	timeFunc := func() *metav1.Time {
+		return &timeStamp
+	}
+
+	createCheckup := func() *checkupv1.StorageCheckup {
+		return &checkupv1.StorageCheckup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      checkupName,
+				Namespace: testNamespace,
+				UID:       "uid",
+			},
+			Spec: checkupv1.StorageCheckupSpec{
+				StorageClassName: storageClassName,
+			},
+		}
+	}
+
+	createRunningCheckup := func(results checkupv1.StorageCheckupResults) *checkupv1.StorageCheckup {
+		checkup := createCheckup()
+		checkup.Status = &checkupv1.StorageCheckupStatus{
+			Phase:          checkupv1.Running,
+			StartTimestamp: &startTime,
+			Results:        &results,
+		}
+		return checkup
+	}
+
+	createPVC := func(name string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+		filesystem := corev1.PersistentVolumeFilesystem
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         testNamespace,
+				CreationTimestamp: startTime,
+				Labels:            map[string]string{storageCheckupLabel: checkupName},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				VolumeMode: &filesystem,
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	createPod := func(phase corev1.PodPhase, message string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      scratch,
+				Namespace: testNamespace,
+				Labels:    map[string]string{storageCheckupLabel: checkupName},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: benchContainerName,
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Message: message},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	createVMI := func(phase v1.VirtualMachineInstancePhase) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMIWithNS(testNamespace, scratch)
+		vmi.CreationTimestamp = metav1.NewTime(startTime.Add(2 * time.Minute))
+		vmi.Labels = map[string]string{storageCheckupLabel: checkupName}
+		vmi.Status.Phase = phase
+		return vmi
+	}
+
+	benchResults := func() checkupv1.StorageCheckupResults {
+		results := checkupv1.StorageCheckupResults{
+			ProvisionDuration: &metav1.Duration{Duration: time.Minute},
+		}
+		Expect(parseBenchResults(benchOutput, &results)).To(Succeed())
+		return results
+	}
+
+	Context("One valid StorageCheckup controller given", func() {
+		var (
+			ctrl                   *gomock.Controller
+			storageCheckupInformer cache.SharedIndexInformer
+			storageCheckupSource   *framework.FakeControllerSource
+			pvcInformer            cache.SharedIndexInformer
+			pvcSource              *framework.FakeControllerSource
+			podInformer            cache.SharedIndexInformer
+			podSource              *framework.FakeControllerSource
+			vmiInformer            cache.SharedIndexInformer
+			vmiSource              *framework.FakeControllerSource
+			storageClassInformer   cache.SharedIndexInformer
+			storageClassSource     *framework.FakeControllerSource
+			stop                   chan struct{}
+			controller             *StorageCheckupController
+			recorder               *record.FakeRecorder
+			mockCheckupQueue       *testutils.MockWorkQueue
+			vmiInterface           *kubecli.MockVirtualMachineInstanceInterface
+			kubevirtClient         *kubevirtfake.Clientset
+			k8sClient              *k8sfake.Clientset
+			k8sSnapshotClient      *k8ssnapshotfake.Clientset
+			createdPVCs            []*corev1.PersistentVolumeClaim
+			createdPods            []*corev1.Pod
+			deleted                []string
+			updatedCheckups        []*checkupv1.StorageCheckup
+		)
+
+		syncCaches := func(stop chan struct{}) {
+			go storageCheckupInformer.Run(stop)
+			go pvcInformer.Run(stop)
+			go podInformer.Run(stop)
+			go vmiInformer.Run(stop)
+			go storageClassInformer.Run(stop)
+			Expect(cache.WaitForCacheSync(
+				stop,
+				storageCheckupInformer.HasSynced,
+				pvcInformer.HasSynced,
+				podInformer.HasSynced,
+				vmiInformer.HasSynced,
+				storageClassInformer.HasSynced,
+			)).To(BeTrue())
+		}
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+			storageCheckupInformer, storageCheckupSource = testutils.NewFakeInformerFor(&checkupv1.StorageCheckup{})
+			pvcInformer, pvcSource = testutils.NewFakeInformerFor(&corev1.PersistentVolumeClaim{})
+			podInformer, podSource = testutils.NewFakeInformerFor(&corev1.Pod{})
+			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+			storageClassInformer, storageClassSource = testutils.NewFakeInformerFor(&storagev1.StorageClass{})
+
+			recorder = record.NewFakeRecorder(100)
+
+			controller = &StorageCheckupController{
+				Client:                 virtClient,
+				BenchImage:             "virt-launcher",
+				StorageCheckupInformer: storageCheckupInformer,
+				PVCInformer:            pvcInformer,
+				PodInformer:            podInformer,
+				VMIInformer:            vmiInformer,
+				StorageClassInformer:   storageClassInformer,
+				Recorder:               recorder,
+			}
+			controller.Init()
+
+			// Wrap our workqueue to have a way to detect when we are done processing updates
+			mockCheckupQueue = testutils.NewMockWorkQueue(controller.checkupQueue)
+			controller.checkupQueue = mockCheckupQueue
+
+			createdPVCs = nil
+			createdPods = nil
+			deleted = nil
+			updatedCheckups = nil
+
+			kubevirtClient = kubevirtfake.NewSimpleClientset()
+			virtClient.EXPECT().StorageCheckup(testNamespace).
+				Return(kubevirtClient.CheckupV1alpha1().StorageCheckups(testNamespace)).AnyTimes()
+			virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
+
+			k8sClient = k8sfake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+			k8sSnapshotClient = k8ssnapshotfake.NewSimpleClientset()
+			virtClient.EXPECT().KubernetesSnapshotClient().Return(k8sSnapshotClient).AnyTimes()
+
+			k8sClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action).To(BeNil())
+				return true, nil, nil
+			})
+			k8sClient.Fake.PrependReactor("create", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				create, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+
+				switch o := create.GetObject().(type) {
+				case *corev1.PersistentVolumeClaim:
+					createdPVCs = append(createdPVCs, o)
+				case *corev1.Pod:
+					createdPods = append(createdPods, o)
+				default:
+					Fail("unexpected create")
+				}
+
+				return true, create.GetObject(), nil
+			})
+			k8sClient.Fake.PrependReactor("delete", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				deleteAction, ok := action.(testing.DeleteAction)
+				Expect(ok).To(BeTrue())
+
+				deleted = append(deleted, action.GetResource().Resource+"/"+deleteAction.GetName())
+				return true, nil, nil
+			})
+			k8sSnapshotClient.Fake.PrependReactor("delete", "volumesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				deleteAction, ok := action.(testing.DeleteAction)
+				Expect(ok).To(BeTrue())
+
+				deleted = append(deleted, "volumesnapshots/"+deleteAction.GetName())
+				return true, nil, nil
+			})
+			kubevirtClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action).To(BeNil())
+				return true, nil, nil
+			})
+			kubevirtClient.Fake.PrependReactor("update", "storagecheckups", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				update, ok := action.(testing.UpdateAction)
+				Expect(ok).To(BeTrue())
+				Expect(update.GetSubresource()).To(Equal("status"))
+
+				updatedCheckups = append(updatedCheckups, update.GetObject().(*checkupv1.StorageCheckup))
+				return true, update.GetObject(), nil
+			})
+
+			timeStamp = metav1.NewTime(startTime.Add(5 * time.Minute))
+			currentTime = timeFunc
+		})
+
+		AfterEach(func() {
+			close(stop)
+		})
+
+		addStorageCheckup := func(checkup *checkupv1.StorageCheckup) {
+			syncCaches(stop)
+			mockCheckupQueue.ExpectAdds(1)
+			storageCheckupSource.Add(checkup)
+			mockCheckupQueue.Wait()
+		}
+
+		It("should start a new checkup", func() {
+			addStorageCheckup(createCheckup())
+			controller.processCheckupWorkItem()
+
+			Expect(createdPVCs).To(BeEmpty())
+			Expect(updatedCheckups).To(HaveLen(1))
+			status := updatedCheckups[0].Status
+			Expect(status.Phase).To(Equal(checkupv1.Running))
+			Expect(status.StartTimestamp).To(Equal(&timeStamp))
+			Expect(recorder.Events).To(Receive(ContainSubstring(checkupStartedEvent)))
+		})
+
+		It("should create the scratch PVC", func() {
+			checkup := createRunningCheckup(checkupv1.StorageCheckupResults{})
+			block := corev1.PersistentVolumeBlock
+			checkup.Spec.VolumeMode = &block
+			addStorageCheckup(checkup)
+			controller.processCheckupWorkItem()
+
+			Expect(createdPVCs).To(HaveLen(1))
+			pvc := createdPVCs[0]
+			Expect(pvc.Name).To(Equal(scratch))
+			Expect(pvc.Labels).To(HaveKeyWithValue(storageCheckupLabel, checkupName))
+			Expect(pvc.OwnerReferences).To(HaveLen(1))
+			Expect(*pvc.Spec.StorageClassName).To(Equal(storageClassName))
+			Expect(*pvc.Spec.VolumeMode).To(Equal(corev1.PersistentVolumeBlock))
+			Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("1Gi")))
+
+			Expect(updatedCheckups).To(HaveLen(1))
+			Expect(updatedCheckups[0].Status.Message).To(Equal("waiting for PersistentVolumeClaim storage-checkup-checkup to be bound"))
+			Expect(mockCheckupQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+		})
+
+		It("should measure the provisioning and start the benchmarks once the PVC is bound", func() {
+			pvcSource.Add(createPVC(scratch, corev1.ClaimBound))
+			addStorageCheckup(createRunningCheckup(checkupv1.StorageCheckupResults{}))
+			controller.processCheckupWorkItem()
+
+			Expect(createdPods).To(HaveLen(1))
+			container := createdPods[0].Spec.Containers[0]
+			Expect(container.Image).To(Equal("virt-launcher"))
+			Expect(container.TerminationMessagePolicy).To(Equal(corev1.TerminationMessageReadFile))
+			Expect(container.VolumeMounts).To(HaveLen(1))
+			Expect(container.Args[0]).To(ContainSubstring("qemu-img create -f raw /scratch/disk.img 128M"))
+			Expect(container.Args[0]).To(ContainSubstring("qemu-img bench -w -f raw -n -t none -c 256 -d 16 -s 1048576 /scratch/disk.img"))
+
+			Expect(updatedCheckups).To(HaveLen(1))
+			Expect(updatedCheckups[0].Status.Results.ProvisionDuration.Duration).To(Equal(5 * time.Minute))
+		})
+
+		It("should not wait for the binding of the PVC if the storage class waits for the first consumer", func() {
+			waitForFirstConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+			storageClassSource.Add(&storagev1.StorageClass{
+				ObjectMeta:        metav1.ObjectMeta{Name: storageClassName},
+				Provisioner:       provisioner,
+				VolumeBindingMode: &waitForFirstConsumer,
+			})
+			pvcSource.Add(createPVC(scratch, corev1.ClaimPending))
+			addStorageCheckup(createRunningCheckup(checkupv1.StorageCheckupResults{}))
+			controller.processCheckupWorkItem()
+
+			Expect(createdPods).To(HaveLen(1))
+			Expect(updatedCheckups).To(HaveLen(1))
+			Expect(updatedCheckups[0].Status.Results.ProvisionDuration).To(BeNil())
+		})
+
+		It("should record the benchmark results and create the scratch VMI", func() {
+			pvcSource.Add(createPVC(scratch, corev1.ClaimBound))
+			podSource.Add(createPod(corev1.PodSucceeded, benchOutput))
+			addStorageCheckup(createRunningCheckup(checkupv1.StorageCheckupResults{ProvisionDuration: &metav1.Duration{Duration: time.Minute}}))
+
+			vmiInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstance, error) {
+				Expect(vmi.Name).To(Equal(scratch))
+				Expect(vmi.Labels).To(HaveKeyWithValue(storageCheckupLabel, checkupName))
+				Expect(vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(scratch))
+				return vmi, nil
+			})
+			controller.processCheckupWorkItem()
+
+			Expect(deleted).To(ConsistOf("pods/" + scratch))
+			Expect(updatedCheckups).To(HaveLen(1))
+			results := updatedCheckups[0].Status.Results
+			Expect(results.WriteThroughput.Value()).To(Equal(int64(128 << 20)))
+			Expect(results.ReadThroughput.Value()).To(Equal(int64(256 << 20)))
+			Expect(results.WriteLatency.Duration).To(Equal(2 * time.Millisecond))
+			Expect(results.ReadLatency.Duration).To(Equal(500 * time.Microsecond))
+			Expect(updatedCheckups[0].Status.Message).To(Equal("waiting for VirtualMachineInstance storage-checkup-checkup to be running"))
+		})
+
+		It("should fail if the benchmarks fail", func() {
+			pvcSource.Add(createPVC(scratch, corev1.ClaimBound))
+			podSource.Add(createPod(corev1.PodFailed, "qemu-img: Could not open '/scratch/disk.img': Invalid argument\n"))
+			addStorageCheckup(createRunningCheckup(checkupv1.StorageCheckupResults{}))
+			controller.processCheckupWorkItem()
+
+			Expect(deleted).To(ConsistOf("pods/"+scratch, "persistentvolumeclaims/"+scratch, "volumesnapshots/"+scratch))
+			Expect(updatedCheckups).To(HaveLen(1))
+			status := updatedCheckups[0].Status
+			Expect(status.Phase).To(Equal(checkupv1.Failed))
+			Expect(status.Message).To(Equal("benchmarks failed: qemu-img: Could not open '/scratch/disk.img': Invalid argument"))
+			Expect(status.CompletionTimestamp).To(Equal(&timeStamp))
+			Expect(recorder.Events).To(Receive(ContainSubstring(checkupFailedEvent)))
+		})
+
+		It("should succeed without a snapshot if there is no VolumeSnapshotClass", func() {
+			storageClassSource.Add(&storagev1.StorageClass{
+				ObjectMeta:  metav1.ObjectMeta{Name: storageClassName},
+				Provisioner: provisioner,
+			})
+			pvcSource.Add(createPVC(scratch, corev1.ClaimBound))
+			vmiSource.Add(createVMI(v1.Running))
+			addStorageCheckup(createRunningCheckup(benchResults()))
+
+			vmiInterface.EXPECT().Delete(scratch, gomock.Any()).Return(nil).Times(2)
+			controller.processCheckupWorkItem()
+
+			Expect(deleted).To(ConsistOf("persistentvolumeclaims/"+scratch, "volumesnapshots/"+scratch))
+			Expect(updatedCheckups).To(HaveLen(1))
+			status := updatedCheckups[0].Status
+			Expect(status.Phase).To(Equal(checkupv1.Succeeded))
+			Expect(status.Message).To(BeEmpty())
+			Expect(status.Results.AttachDuration.Duration).To(Equal(3 * time.Minute))
+			Expect(status.Results.SnapshotRestoreDuration).To(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring(checkupSucceededEvent)))
+		})
+
+		It("should snapshot the scratch PVC with the VolumeSnapshotClass of the storage class", func() {
+			storageClassSource.Add(&storagev1.StorageClass{
+				ObjectMeta:  metav1.ObjectMeta{Name: storageClassName},
+				Provisioner: provisioner,
+			})
+			_, err := k8sSnapshotClient.SnapshotV1beta1().VolumeSnapshotClasses().Create(context.Background(), &vsv1beta1.VolumeSnapshotClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "snapclass"},
+				Driver:     provisioner,
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			pvcSource.Add(createPVC(scratch, corev1.ClaimBound))
+			results := benchResults()
+			results.AttachDuration = &metav1.Duration{Duration: time.Minute}
+			addStorageCheckup(createRunningCheckup(results))
+			controller.processCheckupWorkItem()
+
+			volumeSnapshot, err := k8sSnapshotClient.SnapshotV1beta1().VolumeSnapshots(testNamespace).Get(context.Background(), scratch, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*volumeSnapshot.Spec.VolumeSnapshotClassName).To(Equal("snapclass"))
+			Expect(*volumeSnapshot.Spec.Source.PersistentVolumeClaimName).To(Equal(scratch))
+			Expect(updatedCheckups).To(HaveLen(1))
+			Expect(updatedCheckups[0].Status.Message).To(Equal("waiting for VolumeSnapshot storage-checkup-checkup to be ready"))
+			Expect(mockCheckupQueue.GetAddAfterEnqueueCount()).To(Equal(2))
+		})
+
+		It("should restore the ready snapshot and measure until the restored PVC is bound", func() {
+			snapshotClass := "snapclass"
+			ready := true
+			_, err := k8sSnapshotClient.SnapshotV1beta1().VolumeSnapshots(testNamespace).Create(context.Background(), &vsv1beta1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              scratch,
+					Namespace:         testNamespace,
+					CreationTimestamp: metav1.NewTime(startTime.Add(3 * time.Minute)),
+				},
+				Status: &vsv1beta1.VolumeSnapshotStatus{ReadyToUse: &ready},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			pvcSource.Add(createPVC(scratch, corev1.ClaimBound))
+			pvcSource.Add(createPVC(scratch+"-restore", corev1.ClaimBound))
+			results := benchResults()
+			results.AttachDuration = &metav1.Duration{Duration: time.Minute}
+			checkup := createRunningCheckup(results)
+			checkup.Spec.VolumeSnapshotClassName = &snapshotClass
+			addStorageCheckup(checkup)
+			controller.processCheckupWorkItem()
+
+			Expect(updatedCheckups).To(HaveLen(1))
+			status := updatedCheckups[0].Status
+			Expect(status.Phase).To(Equal(checkupv1.Succeeded))
+			Expect(status.Results.SnapshotRestoreDuration.Duration).To(Equal(2 * time.Minute))
+			Expect(deleted).To(ConsistOf("persistentvolumeclaims/"+scratch, "persistentvolumeclaims/"+scratch+"-restore", "volumesnapshots/"+scratch))
+		})
+
+		It("should fail the checkup once it timed out", func() {
+			pvcSource.Add(createPVC(scratch, corev1.ClaimPending))
+			addStorageCheckup(createRunningCheckup(checkupv1.StorageCheckupResults{}))
+			timeStamp = metav1.NewTime(startTime.Add(11 * time.Minute))
+			controller.processCheckupWorkItem()
+
+			Expect(updatedCheckups).To(HaveLen(1))
+			status := updatedCheckups[0].Status
+			Expect(status.Phase).To(Equal(checkupv1.Failed))
+			Expect(status.Message).To(Equal("checkup did not complete within 10m0s"))
+			Expect(deleted).To(ConsistOf("persistentvolumeclaims/"+scratch, "volumesnapshots/"+scratch))
+		})
+
+		It("should ignore completed checkups", func() {
+			checkup := createRunningCheckup(checkupv1.StorageCheckupResults{})
+			checkup.Status.Phase = checkupv1.Succeeded
+			addStorageCheckup(checkup)
+			controller.processCheckupWorkItem()
+
+			Expect(createdPVCs).To(BeEmpty())
+			Expect(updatedCheckups).To(BeEmpty())
+		})
+
+		It("should enqueue the checkup of a scratch object", func() {
+			syncCaches(stop)
+			mockCheckupQueue.ExpectAdds(1)
+			podSource.Add(createPod(corev1.PodRunning, ""))
+			mockCheckupQueue.Wait()
+			Expect(mockCheckupQueue.Len()).To(Equal(1))
+			key, _ := mockCheckupQueue.Get()
+			Expect(key).To(Equal(testNamespace + "/" + checkupName))
+		})
+	})
+})
@@ -0,0 +1,760 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+const (
+	// cloneSourceAnnotation is set on the target VirtualMachine and names the clone that created it
+	cloneSourceAnnotation = "clone.kubevirt.io/clone"
+
+	targetCreatedEvent = "SuccessfulTargetCreate"
+
+	sourceNotFoundReason = "SourceDoesNotExist"
+	sourceRunningReason  = "SourceRunning"
+	sourceNotReadyReason = "SourceNotReady"
+	targetExistsReason   = "TargetExists"
+	invalidSpecReason    = "InvalidSpec"
+	succeededReason      = "Succeeded"
+)
+
+// variable so can be overridden in tests
+var currentTime = func() *metav1.Time {
+	t := metav1.Now()
+	return &t
+}
+
+// VMCloneController creates VirtualMachines from existing VirtualMachines or VirtualMachineSnapshots
+type VMCloneController struct {
+	Client kubecli.KubevirtClient
+
+	VMCloneInformer           cache.SharedIndexInformer
+	VMInformer                cache.SharedIndexInformer
+	VMIInformer               cache.SharedIndexInformer
+	VMSnapshotInformer        cache.SharedIndexInformer
+	VMSnapshotContentInformer cache.SharedIndexInformer
+	PVCInformer               cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	vmCloneQueue workqueue.RateLimitingInterface
+}
+
+// cloneSource is the VirtualMachine spec and metadata a clone is made from
+type cloneSource struct {
+	vm *kubevirtv1.VirtualMachine
+	// volumeBackups holds the volumes to restore, by volume name, when cloning a snapshot
+	volumeBackups map[string]snapshotv1.VolumeBackup
+	// reason is set when the source can't be cloned right now
+	reason  string
+	message string
+}
+
+// Init initializes the clone controller
+func (ctrl *VMCloneController) Init() {
+	ctrl.vmCloneQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "clone-controller-vmclone")
+
+	ctrl.VMCloneInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMClone,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMClone(newObj) },
+		},
+	)
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVM,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVM(newObj) },
+			DeleteFunc: ctrl.handleVM,
+		},
+	)
+
+	ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMI,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMI(newObj) },
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+
+	ctrl.VMSnapshotInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMSnapshot,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMSnapshot(newObj) },
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *VMCloneController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmCloneQueue.ShutDown()
+
+	log.Log.Info("Starting clone controller.")
+	defer log.Log.Info("Shutting down clone controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMCloneInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.VMSnapshotInformer.HasSynced,
+		ctrl.VMSnapshotContentInformer.HasSynced,
+		ctrl.PVCInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmCloneWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMCloneController) vmCloneWorker() {
+	for ctrl.processVMCloneWorkItem() {
+	}
+}
+
+func (ctrl *VMCloneController) processVMCloneWorkItem() bool {
+	obj, shutdown := ctrl.vmCloneQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ctrl.vmCloneQueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		ctrl.vmCloneQueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	log.Log.V(3).Infof("vmClone worker processing key [%s]", key)
+
+	if err := ctrl.execute(key); err != nil {
+		utilruntime.HandleError(err)
+		ctrl.vmCloneQueue.AddRateLimited(key)
+		return true
+	}
+
+	ctrl.vmCloneQueue.Forget(obj)
+	return true
+}
+
+func (ctrl *VMCloneController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMCloneInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	vmClone, ok := storeObj.(*clonev1.VirtualMachineClone)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateVMClone(vmClone.DeepCopy())
+}
+
+func (ctrl *VMCloneController) handleVMClone(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmClone, ok := obj.(*clonev1.VirtualMachineClone); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmClone)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, vmClone)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmCloneQueue.Add(objName)
+	}
+}
+
+// enqueueSource enqueues the clones in namespace whose source matches kind and name
+func (ctrl *VMCloneController) enqueueSource(namespace, kind, name string) {
+	objs, err := ctrl.VMCloneInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, obj := range objs {
+		vmClone := obj.(*clonev1.VirtualMachineClone)
+		if vmClone.Spec.Source.Kind == kind && vmClone.Spec.Source.Name == name {
+			ctrl.vmCloneQueue.Add(cacheKeyFunc(vmClone.Namespace, vmClone.Name))
+		}
+	}
+}
+
+func (ctrl *VMCloneController) handleVM(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+		ctrl.enqueueSource(vm.Namespace, "VirtualMachine", vm.Name)
+	}
+}
+
+func (ctrl *VMCloneController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmi, ok := obj.(*kubevirtv1.VirtualMachineInstance); ok {
+		ctrl.enqueueSource(vmi.Namespace, "VirtualMachine", vmi.Name)
+	}
+}
+
+func (ctrl *VMCloneController) handleVMSnapshot(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmSnapshot, ok := obj.(*snapshotv1.VirtualMachineSnapshot); ok {
+		ctrl.enqueueSource(vmSnapshot.Namespace, "VirtualMachineSnapshot", vmSnapshot.Name)
+	}
+}
+
+func cacheKeyFunc(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+func targetName(vmClone *clonev1.VirtualMachineClone) string {
+	if vmClone.Status != nil && vmClone.Status.TargetName != nil {
+		return *vmClone.Status.TargetName
+	}
+
+	if vmClone.Spec.Target != nil && vmClone.Spec.Target.Name != "" {
+		return vmClone.Spec.Target.Name
+	}
+
+	uid := string(vmClone.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	return fmt.Sprintf("%s-clone-%s", vmClone.Spec.Source.Name, uid)
+}
+
+func (ctrl *VMCloneController) updateVMClone(vmClone *clonev1.VirtualMachineClone) error {
+	log.Log.V(3).Infof("Updating VirtualMachineClone %s/%s", vmClone.Namespace, vmClone.Name)
+
+	if vmClone.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if vmClone.Status != nil && (vmClone.Status.Phase == clonev1.Succeeded || vmClone.Status.Phase == clonev1.Failed) {
+		return nil
+	}
+
+	target := targetName(vmClone)
+
+	if vmClone.Spec.Target != nil && vmClone.Spec.Target.Kind != "" && vmClone.Spec.Target.Kind != "VirtualMachine" {
+		return ctrl.updateVMCloneStatus(vmClone, target, clonev1.Failed, invalidSpecReason,
+			fmt.Sprintf("unsupported target kind %s", vmClone.Spec.Target.Kind))
+	}
+
+	existing, err := ctrl.getTargetVM(vmClone.Namespace, target)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		if existing.Annotations[cloneSourceAnnotation] != vmClone.Name {
+			return ctrl.updateVMCloneStatus(vmClone, target, clonev1.Failed, targetExistsReason,
+				fmt.Sprintf("VirtualMachine %s already exists", target))
+		}
+		return ctrl.updateVMCloneStatus(vmClone, target, clonev1.Succeeded, succeededReason, "")
+	}
+
+	source, err := ctrl.getSource(vmClone)
+	if err != nil {
+		return err
+	}
+
+	if source.reason != "" {
+		return ctrl.updateVMCloneStatus(vmClone, target, clonev1.Pending, source.reason, source.message)
+	}
+
+	vm, err := ctrl.newTargetVM(vmClone, target, source)
+	if err != nil {
+		return err
+	}
+
+	vm, err = ctrl.Client.VirtualMachine(vmClone.Namespace).Create(vm)
+	if err != nil {
+		return err
+	}
+
+	if len(source.volumeBackups) > 0 {
+		if err := ctrl.createRestorePVCs(vm, source.volumeBackups); err != nil {
+			return err
+		}
+	}
+
+	ctrl.Recorder.Eventf(
+		vmClone,
+		corev1.EventTypeNormal,
+		targetCreatedEvent,
+		"Successfully created VirtualMachine %s",
+		vm.Name,
+	)
+
+	return ctrl.updateVMCloneStatus(vmClone, target, clonev1.Succeeded, succeededReason, "")
+}
+
+func (ctrl *VMCloneController) getTargetVM(namespace, name string) (*kubevirtv1.VirtualMachine, error) {
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*kubevirtv1.VirtualMachine), nil
+}
+
+func (ctrl *VMCloneController) getSource(vmClone *clonev1.VirtualMachineClone) (*cloneSource, error) {
+	source := vmClone.Spec.Source
+	group := ""
+	if source.APIGroup != nil {
+		group = *source.APIGroup
+	}
+
+	switch {
+	case group == kubevirtv1.GroupName && source.Kind == "VirtualMachine":
+		return ctrl.getVMSource(vmClone)
+	case group == snapshotv1.SchemeGroupVersion.Group && source.Kind == "VirtualMachineSnapshot":
+		return ctrl.getVMSnapshotSource(vmClone)
+	}
+
+	return &cloneSource{
+		reason:  invalidSpecReason,
+		message: fmt.Sprintf("unsupported source kind %s", source.Kind),
+	}, nil
+}
+
+func (ctrl *VMCloneController) getVMSource(vmClone *clonev1.VirtualMachineClone) (*cloneSource, error) {
+	key := cacheKeyFunc(vmClone.Namespace, vmClone.Spec.Source.Name)
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return &cloneSource{
+			reason:  sourceNotFoundReason,
+			message: fmt.Sprintf("VirtualMachine %s does not exist", vmClone.Spec.Source.Name),
+		}, nil
+	}
+	vm := obj.(*kubevirtv1.VirtualMachine)
+
+	_, vmiExists, err := ctrl.VMIInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// the disks are copied, so the guest must not write to them
+	if vmiExists {
+		return &cloneSource{
+			reason:  sourceRunningReason,
+			message: fmt.Sprintf("waiting for VirtualMachine %s to stop", vm.Name),
+		}, nil
+	}
+
+	if vm.Spec.Template != nil {
+		for _, volume := range vm.Spec.Template.Spec.Volumes {
+			claimName := volumeClaimName(volume)
+			if claimName == "" {
+				continue
+			}
+
+			_, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(vm.Namespace, claimName))
+			if err != nil {
+				return nil, err
+			}
+
+			if !exists {
+				return &cloneSource{
+					reason:  sourceNotReadyReason,
+					message: fmt.Sprintf("PersistentVolumeClaim %s does not exist", claimName),
+				}, nil
+			}
+		}
+	}
+
+	return &cloneSource{vm: vm.DeepCopy()}, nil
+}
+
+func (ctrl *VMCloneController) getVMSnapshotSource(vmClone *clonev1.VirtualMachineClone) (*cloneSource, error) {
+	obj, exists, err := ctrl.VMSnapshotInformer.GetStore().GetByKey(cacheKeyFunc(vmClone.Namespace, vmClone.Spec.Source.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return &cloneSource{
+			reason:  sourceNotFoundReason,
+			message: fmt.Sprintf("VirtualMachineSnapshot %s does not exist", vmClone.Spec.Source.Name),
+		}, nil
+	}
+	vmSnapshot := obj.(*snapshotv1.VirtualMachineSnapshot)
+
+	if vmSnapshot.Status == nil || vmSnapshot.Status.ReadyToUse == nil || !*vmSnapshot.Status.ReadyToUse ||
+		vmSnapshot.Status.VirtualMachineSnapshotContentName == nil {
+		return &cloneSource{
+			reason:  sourceNotReadyReason,
+			message: fmt.Sprintf("VirtualMachineSnapshot %s is not ready to use", vmSnapshot.Name),
+		}, nil
+	}
+
+	obj, exists, err = ctrl.VMSnapshotContentInformer.GetStore().GetByKey(cacheKeyFunc(vmClone.Namespace, *vmSnapshot.Status.VirtualMachineSnapshotContentName))
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return &cloneSource{
+			reason:  sourceNotReadyReason,
+			message: fmt.Sprintf("VirtualMachineSnapshotContent %s does not exist", *vmSnapshot.Status.VirtualMachineSnapshotContentName),
+		}, nil
+	}
+	content := obj.(*snapshotv1.VirtualMachineSnapshotContent)
+
+	if content.Spec.Source.VirtualMachine == nil {
+		return &cloneSource{
+			reason:  invalidSpecReason,
+			message: fmt.Sprintf("VirtualMachineSnapshot %s has no VirtualMachine source", vmSnapshot.Name),
+		}, nil
+	}
+
+	source := &cloneSource{
+		vm: &kubevirtv1.VirtualMachine{
+			ObjectMeta: *content.Spec.Source.VirtualMachine.ObjectMeta.DeepCopy(),
+			Spec:       *content.Spec.Source.VirtualMachine.Spec.DeepCopy(),
+		},
+		volumeBackups: map[string]snapshotv1.VolumeBackup{},
+	}
+
+	for _, volumeBackup := range content.Spec.VolumeBackups {
+		if volumeBackup.VolumeSnapshotName == nil {
+			continue
+		}
+		source.volumeBackups[volumeBackup.VolumeName] = volumeBackup
+	}
+
+	return source, nil
+}
+
+func volumeClaimName(volume kubevirtv1.Volume) string {
+	switch {
+	case volume.PersistentVolumeClaim != nil:
+		return volume.PersistentVolumeClaim.ClaimName
+	case volume.DataVolume != nil:
+		return volume.DataVolume.Name
+	}
+	return ""
+}
+
+func targetVolumeName(target, volumeName string) string {
+	return fmt.Sprintf("%s-%s", target, volumeName)
+}
+
+// newTargetVM builds the target VirtualMachine. The volumes of a VirtualMachine source
+// are cloned by CDI, which uses a CSI clone or snapshot where the storage supports it
+// and falls back to a host assisted copy otherwise. The volumes of a snapshot source
+// are restored from their VolumeSnapshots.
+func (ctrl *VMCloneController) newTargetVM(vmClone *clonev1.VirtualMachineClone, target string, source *cloneSource) (*kubevirtv1.VirtualMachine, error) {
+	vm := &kubevirtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        target,
+			Namespace:   vmClone.Namespace,
+			Labels:      filterKeys(source.vm.Labels, vmClone.Spec.LabelFilters),
+			Annotations: filterKeys(source.vm.Annotations, vmClone.Spec.AnnotationFilters),
+		},
+		Spec: *source.vm.Spec.DeepCopy(),
+	}
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[cloneSourceAnnotation] = vmClone.Name
+
+	if vm.Spec.Template == nil {
+		return vm, nil
+	}
+
+	template := vm.Spec.Template
+	template.ObjectMeta.Labels = filterKeys(template.ObjectMeta.Labels, vmClone.Spec.LabelFilters)
+	template.ObjectMeta.Annotations = filterKeys(template.ObjectMeta.Annotations, vmClone.Spec.AnnotationFilters)
+
+	replacedTemplates := map[string]bool{}
+	var dataVolumeTemplates []kubevirtv1.DataVolumeTemplateSpec
+	for i, volume := range template.Spec.Volumes {
+		claimName := volumeClaimName(volume)
+		if claimName == "" {
+			continue
+		}
+		newName := targetVolumeName(target, volume.Name)
+
+		if volume.DataVolume != nil {
+			replacedTemplates[volume.DataVolume.Name] = true
+		}
+
+		if source.volumeBackups != nil {
+			if _, ok := source.volumeBackups[volume.Name]; !ok {
+				return nil, fmt.Errorf("VirtualMachineSnapshot %s has no backup of volume %s", vmClone.Spec.Source.Name, volume.Name)
+			}
+			template.Spec.Volumes[i].VolumeSource = kubevirtv1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: newName},
+			}
+			continue
+		}
+
+		obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(source.vm.Namespace, claimName))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("PersistentVolumeClaim %s does not exist", claimName)
+		}
+		pvc := obj.(*corev1.PersistentVolumeClaim)
+
+		pvcSpec := pvc.Spec.DeepCopy()
+		pvcSpec.VolumeName = ""
+		pvcSpec.DataSource = nil
+		pvcSpec.Selector = nil
+
+		dataVolumeTemplates = append(dataVolumeTemplates, kubevirtv1.DataVolumeTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Name: newName},
+			Spec: cdiv1.DataVolumeSpec{
+				Source: cdiv1.DataVolumeSource{
+					PVC: &cdiv1.DataVolumeSourcePVC{
+						Namespace: pvc.Namespace,
+						Name:      pvc.Name,
+					},
+				},
+				PVC: pvcSpec,
+			},
+		})
+		template.Spec.Volumes[i].VolumeSource = kubevirtv1.VolumeSource{
+			DataVolume: &kubevirtv1.DataVolumeSource{Name: newName},
+		}
+	}
+
+	for _, dvt := range vm.Spec.DataVolumeTemplates {
+		if !replacedTemplates[dvt.Name] {
+			dataVolumeTemplates = append(dataVolumeTemplates, dvt)
+		}
+	}
+	vm.Spec.DataVolumeTemplates = dataVolumeTemplates
+
+	// cleared addresses and serials are generated again when the VMI is created
+	for i := range template.Spec.Domain.Devices.Interfaces {
+		iface := &template.Spec.Domain.Devices.Interfaces[i]
+		iface.MacAddress = vmClone.Spec.NewMacAddresses[iface.Name]
+	}
+
+	if template.Spec.Domain.Firmware != nil {
+		template.Spec.Domain.Firmware.UUID = ""
+		template.Spec.Domain.Firmware.Serial = ""
+	}
+	if vmClone.Spec.NewSMBiosSerial != nil {
+		if template.Spec.Domain.Firmware == nil {
+			template.Spec.Domain.Firmware = &kubevirtv1.Firmware{}
+		}
+		template.Spec.Domain.Firmware.Serial = *vmClone.Spec.NewSMBiosSerial
+	}
+
+	return vm, nil
+}
+
+func vmOwnerReference(vm *kubevirtv1.VirtualMachine) metav1.OwnerReference {
+	t := true
+	return metav1.OwnerReference{
+		APIVersion:         kubevirtv1.GroupVersion.String(),
+		Kind:               "VirtualMachine",
+		Name:               vm.Name,
+		UID:                vm.UID,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}
+}
+
+// createRestorePVCs restores the snapshot volumes into claims owned by the target VirtualMachine
+func (ctrl *VMCloneController) createRestorePVCs(vm *kubevirtv1.VirtualMachine, volumeBackups map[string]snapshotv1.VolumeBackup) error {
+	apiGroup := vsv1beta1.GroupName
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		volumeBackup, ok := volumeBackups[volume.Name]
+		if !ok {
+			continue
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            targetVolumeName(vm.Name, volume.Name),
+				Namespace:       vm.Namespace,
+				OwnerReferences: []metav1.OwnerReference{vmOwnerReference(vm)},
+			},
+			Spec: *volumeBackup.PersistentVolumeClaim.Spec.DeepCopy(),
+		}
+		pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: &apiGroup,
+			Kind:     "VolumeSnapshot",
+			Name:     *volumeBackup.VolumeSnapshotName,
+		}
+		pvc.Spec.VolumeName = ""
+
+		_, err := ctrl.Client.CoreV1().PersistentVolumeClaims(vm.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filterKeys returns the entries of m whose keys pass filters. A filter is a glob
+// pattern, a leading "!" excludes matching keys and the last matching filter wins.
+func filterKeys(m map[string]string, filters []string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	result := map[string]string{}
+	for k, v := range m {
+		if keyIncluded(k, filters) {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+func keyIncluded(key string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	included := false
+	for _, filter := range filters {
+		exclude := strings.HasPrefix(filter, "!")
+		pattern := strings.TrimPrefix(filter, "!")
+		if globMatch(pattern, key) {
+			included = !exclude
+		}
+	}
+
+	return included
+}
+
+// globMatch matches key against a pattern where "*" matches any sequence of
+// characters, including "/", and "?" matches a single character
+func globMatch(pattern, key string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	matched, err := regexp.MatchString("^"+expr+"$", key)
+	return err == nil && matched
+}
+
+func newCondition(t clonev1.ConditionType, status corev1.ConditionStatus, reason, message string) clonev1.Condition {
+	return clonev1.Condition{
+		Type:               t,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
+func updateCondition(conditions []clonev1.Condition, c clonev1.Condition) []clonev1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == c.Type {
+			if conditions[i].Status != c.Status || conditions[i].Reason != c.Reason || conditions[i].Message != c.Message {
+				conditions[i] = c
+			}
+			return conditions
+		}
+	}
+
+	return append(conditions, c)
+}
+
+func (ctrl *VMCloneController) updateVMCloneStatus(vmClone *clonev1.VirtualMachineClone, target string, phase clonev1.VirtualMachineClonePhase, reason, message string) error {
+	vmCloneCopy := vmClone.DeepCopy()
+	if vmCloneCopy.Status == nil {
+		vmCloneCopy.Status = &clonev1.VirtualMachineCloneStatus{}
+	}
+	status := vmCloneCopy.Status
+
+	status.Phase = phase
+	status.TargetName = &target
+
+	switch phase {
+	case clonev1.Succeeded:
+		if status.CreationTime == nil {
+			status.CreationTime = currentTime()
+		}
+		status.Conditions = updateCondition(status.Conditions, newCondition(clonev1.ConditionProgressing, corev1.ConditionFalse, reason, message))
+		status.Conditions = updateCondition(status.Conditions, newCondition(clonev1.ConditionReady, corev1.ConditionTrue, reason, message))
+	case clonev1.Failed:
+		status.Conditions = updateCondition(status.Conditions, newCondition(clonev1.ConditionProgressing, corev1.ConditionFalse, reason, message))
+		status.Conditions = updateCondition(status.Conditions, newCondition(clonev1.ConditionReady, corev1.ConditionFalse, reason, message))
+	default:
+		status.Conditions = updateCondition(status.Conditions, newCondition(clonev1.ConditionProgressing, corev1.ConditionTrue, reason, message))
+		status.Conditions = updateCondition(status.Conditions, newCondition(clonev1.ConditionReady, corev1.ConditionFalse, reason, message))
+	}
+
+	if !reflect.DeepEqual(vmClone, vmCloneCopy) {
+		if _, err := ctrl.Client.VirtualMachineClone(vmCloneCopy.Namespace).Update(context.Background(), vmCloneCopy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,492 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package clone
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	framework "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Clone controller", func() {
+	const (
+		testNamespace = "default"
+		cloneName     = "clone"
+		targetVMName  = "target"
+		pvcName       = "pvc"
+		vmName        = "testvm"
+		snapshotName  = "snapshot"
+		contentName   = "content"
+	)
+
+	var (
+		vmAPIGroup       = "kubevirt.io"
+		snapshotAPIGroup = "snapshot.kubevirt.io"
+		timeStamp        = metav1.Now()
+	)
+
+	timeFunc := func() *metav1.Time {
+		return &timeStamp
+	}
+
+	createClone := func(source corev1.TypedLocalObjectReference) *clonev1.VirtualMachineClone {
+		return &clonev1.VirtualMachineClone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cloneName,
+				Namespace: testNamespace,
+				UID:       "0123456789",
+			},
+			Spec: clonev1.VirtualMachineCloneSpec{
+				Source: source,
+				Target: &corev1.TypedLocalObjectReference{
+					APIGroup: &vmAPIGroup,
+					Kind:     "VirtualMachine",
+					Name:     targetVMName,
+				},
+			},
+		}
+	}
+
+	createVMClone := func() *clonev1.VirtualMachineClone {
+		return createClone(corev1.TypedLocalObjectReference{
+			APIGroup: &vmAPIGroup,
+			Kind:     "VirtualMachine",
+			Name:     vmName,
+		})
+	}
+
+	createSnapshotClone := func() *clonev1.VirtualMachineClone {
+		return createClone(corev1.TypedLocalObjectReference{
+			APIGroup: &snapshotAPIGroup,
+			Kind:     "VirtualMachineSnapshot",
+			Name:     snapshotName,
+		})
+	}
+
+	createPVC := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("1Gi"),
+					},
+				},
+				VolumeName: "pv",
+			},
+		}
+	}
+
+	createVM := func() *v1.VirtualMachine {
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					"app":              "test",
+					"example.com/keep": "true",
+					"example.com/drop": "true",
+				},
+			},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Firmware: &v1.Firmware{
+								UUID:   "uuid",
+								Serial: "serial",
+							},
+							Devices: v1.Devices{
+								Interfaces: []v1.Interface{
+									{Name: "default", MacAddress: "de:ad:00:00:be:ef"},
+									{Name: "secondary", MacAddress: "de:ad:00:00:be:f0"},
+								},
+							},
+						},
+						Volumes: []v1.Volume{
+							{
+								Name: "disk0",
+								VolumeSource: v1.VolumeSource{
+									PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+										ClaimName: pvcName,
+									},
+								},
+							},
+							{
+								Name: "cloudinit",
+								VolumeSource: v1.VolumeSource{
+									CloudInitNoCloud: &v1.CloudInitNoCloudSource{
+										UserData: "#cloud-config",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	Context("One valid VirtualMachineClone controller given", func() {
+		var (
+			ctrl                      *gomock.Controller
+			vmInterface               *kubecli.MockVirtualMachineInterface
+			vmCloneInformer           cache.SharedIndexInformer
+			vmCloneSource             *framework.FakeControllerSource
+			vmInformer                cache.SharedIndexInformer
+			vmSource                  *framework.FakeControllerSource
+			vmiInformer               cache.SharedIndexInformer
+			vmiSource                 *framework.FakeControllerSource
+			vmSnapshotInformer        cache.SharedIndexInformer
+			vmSnapshotSource          *framework.FakeControllerSource
+			vmSnapshotContentInformer cache.SharedIndexInformer
+			vmSnapshotContentSource   *framework.FakeControllerSource
+			pvcInformer               cache.SharedIndexInformer
+			pvcSource                 *framework.FakeControllerSource
+			stop                      chan struct{}
+			controller                *VMCloneController
+			recorder                  *record.FakeRecorder
+			mockVMCloneQueue          *testutils.MockWorkQueue
+			kubevirtClient            *kubevirtfake.Clientset
+			k8sClient                 *k8sfake.Clientset
+			createdPVCs               []*corev1.PersistentVolumeClaim
+			updatedClones             []*clonev1.VirtualMachineClone
+		)
+
+		syncCaches := func(stop chan struct{}) {
+			go vmCloneInformer.Run(stop)
+			go vmInformer.Run(stop)
+			go vmiInformer.Run(stop)
+			go vmSnapshotInformer.Run(stop)
+			go vmSnapshotContentInformer.Run(stop)
+			go pvcInformer.Run(stop)
+			Expect(cache.WaitForCacheSync(
+				stop,
+				vmCloneInformer.HasSynced,
+				vmInformer.HasSynced,
+				vmiInformer.HasSynced,
+				vmSnapshotInformer.HasSynced,
+				vmSnapshotContentInformer.HasSynced,
+				pvcInformer.HasSynced,
+			)).To(BeTrue())
+		}
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+
+			vmCloneInformer, vmCloneSource = testutils.NewFakeInformerFor(&clonev1.VirtualMachineClone{})
+			vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+			vmSnapshotInformer, vmSnapshotSource = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
+			vmSnapshotContentInformer, vmSnapshotContentSource = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
+			pvcInformer, pvcSource = testutils.NewFakeInformerFor(&corev1.PersistentVolumeClaim{})
+
+			recorder = record.NewFakeRecorder(100)
+
+			controller = &VMCloneController{
+				Client:                    virtClient,
+				VMCloneInformer:           vmCloneInformer,
+				VMInformer:                vmInformer,
+				VMIInformer:               vmiInformer,
+				VMSnapshotInformer:        vmSnapshotInformer,
+				VMSnapshotContentInformer: vmSnapshotContentInformer,
+				PVCInformer:               pvcInformer,
+				Recorder:                  recorder,
+			}
+			controller.Init()
+
+			// Wrap our workqueue to have a way to detect when we are done processing updates
+			mockVMCloneQueue = testutils.NewMockWorkQueue(controller.vmCloneQueue)
+			controller.vmCloneQueue = mockVMCloneQueue
+
+			createdPVCs = nil
+			updatedClones = nil
+
+			kubevirtClient = kubevirtfake.NewSimpleClientset()
+			virtClient.EXPECT().VirtualMachineClone(testNamespace).
+				Return(kubevirtClient.CloneV1alpha1().VirtualMachineClones(testNamespace)).AnyTimes()
+
+			k8sClient = k8sfake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+			k8sClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action).To(BeNil())
+				return true, nil, nil
+			})
+			k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				create, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+
+				createdPVCs = append(createdPVCs, create.GetObject().(*corev1.PersistentVolumeClaim))
+				return true, create.GetObject(), nil
+			})
+			kubevirtClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action).To(BeNil())
+				return true, nil, nil
+			})
+			kubevirtClient.Fake.PrependReactor("update", "virtualmachineclones", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				update, ok := action.(testing.UpdateAction)
+				Expect(ok).To(BeTrue())
+
+				updatedClones = append(updatedClones, update.GetObject().(*clonev1.VirtualMachineClone))
+				return true, update.GetObject(), nil
+			})
+
+			currentTime = timeFunc
+		})
+
+		AfterEach(func() {
+			close(stop)
+		})
+
+		addVirtualMachineClone := func(vmClone *clonev1.VirtualMachineClone) {
+			syncCaches(stop)
+			mockVMCloneQueue.ExpectAdds(1)
+			vmCloneSource.Add(vmClone)
+			mockVMCloneQueue.Wait()
+		}
+
+		expectCondition := func(vmClone *clonev1.VirtualMachineClone, t clonev1.ConditionType, status corev1.ConditionStatus, reason string) {
+			for _, c := range vmClone.Status.Conditions {
+				if c.Type == t {
+					Expect(c.Status).To(Equal(status))
+					Expect(c.Reason).To(Equal(reason))
+					return
+				}
+			}
+			Fail("condition not found")
+		}
+
+		It("should wait for a missing source VM", func() {
+			addVirtualMachineClone(createVMClone())
+			controller.processVMCloneWorkItem()
+
+			Expect(updatedClones).To(HaveLen(1))
+			Expect(updatedClones[0].Status.Phase).To(Equal(clonev1.Pending))
+			Expect(*updatedClones[0].Status.TargetName).To(Equal(targetVMName))
+			expectCondition(updatedClones[0], clonev1.ConditionReady, corev1.ConditionFalse, sourceNotFoundReason)
+			expectCondition(updatedClones[0], clonev1.ConditionProgressing, corev1.ConditionTrue, sourceNotFoundReason)
+		})
+
+		It("should wait for a running source VM to stop", func() {
+			pvcSource.Add(createPVC(pvcName))
+			vmSource.Add(createVM())
+			vmiSource.Add(&v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      vmName,
+					Namespace: testNamespace,
+				},
+			})
+			addVirtualMachineClone(createVMClone())
+			controller.processVMCloneWorkItem()
+
+			Expect(updatedClones).To(HaveLen(1))
+			Expect(updatedClones[0].Status.Phase).To(Equal(clonev1.Pending))
+			expectCondition(updatedClones[0], clonev1.ConditionReady, corev1.ConditionFalse, sourceRunningReason)
+		})
+
+		It("should clone a stopped VM", func() {
+			pvcSource.Add(createPVC(pvcName))
+			vmSource.Add(createVM())
+			vmClone := createVMClone()
+			vmClone.Spec.LabelFilters = []string{"*", "!example.com/*", "example.com/keep"}
+			vmClone.Spec.NewMacAddresses = map[string]string{"default": "de:ad:00:00:be:aa"}
+			serial := "new-serial"
+			vmClone.Spec.NewSMBiosSerial = &serial
+			addVirtualMachineClone(vmClone)
+
+			var created *v1.VirtualMachine
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				created = vm
+				return vm, nil
+			})
+			controller.processVMCloneWorkItem()
+
+			Expect(created).ToNot(BeNil())
+			Expect(created.Name).To(Equal(targetVMName))
+			Expect(created.Annotations).To(HaveKeyWithValue(cloneSourceAnnotation, cloneName))
+			Expect(created.Labels).To(Equal(map[string]string{
+				"app":              "test",
+				"example.com/keep": "true",
+			}))
+
+			Expect(created.Spec.DataVolumeTemplates).To(HaveLen(1))
+			dvt := created.Spec.DataVolumeTemplates[0]
+			Expect(dvt.Name).To(Equal("target-disk0"))
+			Expect(dvt.Spec.Source.PVC.Namespace).To(Equal(testNamespace))
+			Expect(dvt.Spec.Source.PVC.Name).To(Equal(pvcName))
+			Expect(dvt.Spec.PVC.VolumeName).To(BeEmpty())
+			Expect(dvt.Spec.PVC.Resources.Requests).To(HaveKey(corev1.ResourceStorage))
+
+			spec := created.Spec.Template.Spec
+			Expect(spec.Volumes[0].DataVolume.Name).To(Equal("target-disk0"))
+			Expect(spec.Volumes[0].PersistentVolumeClaim).To(BeNil())
+			Expect(spec.Volumes[1].CloudInitNoCloud).ToNot(BeNil())
+			Expect(spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("de:ad:00:00:be:aa"))
+			Expect(spec.Domain.Devices.Interfaces[1].MacAddress).To(BeEmpty())
+			Expect(spec.Domain.Firmware.Serial).To(Equal(serial))
+			Expect(string(spec.Domain.Firmware.UUID)).To(BeEmpty())
+			Expect(recorder.Events).To(HaveLen(1))
+
+			Expect(updatedClones).To(HaveLen(1))
+			status := updatedClones[0].Status
+			Expect(status.Phase).To(Equal(clonev1.Succeeded))
+			Expect(status.CreationTime).To(Equal(timeFunc()))
+			expectCondition(updatedClones[0], clonev1.ConditionReady, corev1.ConditionTrue, succeededReason)
+			expectCondition(updatedClones[0], clonev1.ConditionProgressing, corev1.ConditionFalse, succeededReason)
+		})
+
+		It("should generate a target name", func() {
+			vmClone := createVMClone()
+			vmClone.Spec.Target = nil
+			addVirtualMachineClone(vmClone)
+			controller.processVMCloneWorkItem()
+
+			Expect(updatedClones).To(HaveLen(1))
+			Expect(*updatedClones[0].Status.TargetName).To(Equal("testvm-clone-01234567"))
+		})
+
+		It("should fail when the target VM already exists", func() {
+			vmSource.Add(&v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      targetVMName,
+					Namespace: testNamespace,
+				},
+			})
+			addVirtualMachineClone(createVMClone())
+			controller.processVMCloneWorkItem()
+
+			Expect(updatedClones).To(HaveLen(1))
+			Expect(updatedClones[0].Status.Phase).To(Equal(clonev1.Failed))
+			expectCondition(updatedClones[0], clonev1.ConditionReady, corev1.ConditionFalse, targetExistsReason)
+		})
+
+		It("should restore the volumes of a snapshot", func() {
+			t := true
+			contentNameRef := contentName
+			volumeSnapshotName := "volumesnapshot"
+			vmSnapshotSource.Add(&snapshotv1.VirtualMachineSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      snapshotName,
+					Namespace: testNamespace,
+				},
+				Status: &snapshotv1.VirtualMachineSnapshotStatus{
+					ReadyToUse:                        &t,
+					VirtualMachineSnapshotContentName: &contentNameRef,
+				},
+			})
+			pvc := createPVC(pvcName)
+			vmSnapshotContentSource.Add(&snapshotv1.VirtualMachineSnapshotContent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      contentName,
+					Namespace: testNamespace,
+				},
+				Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+					Source: snapshotv1.SourceSpec{
+						VirtualMachine: createVM(),
+					},
+					VolumeBackups: []snapshotv1.VolumeBackup{
+						{
+							VolumeName: "disk0",
+							PersistentVolumeClaim: snapshotv1.PersistentVolumeClaim{
+								ObjectMeta: pvc.ObjectMeta,
+								Spec:       pvc.Spec,
+							},
+							VolumeSnapshotName: &volumeSnapshotName,
+						},
+					},
+				},
+			})
+			addVirtualMachineClone(createSnapshotClone())
+
+			var created *v1.VirtualMachine
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				created = vm
+				return vm, nil
+			})
+			controller.processVMCloneWorkItem()
+
+			Expect(created).ToNot(BeNil())
+			Expect(created.Spec.DataVolumeTemplates).To(BeEmpty())
+			Expect(created.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("target-disk0"))
+
+			Expect(createdPVCs).To(HaveLen(1))
+			Expect(createdPVCs[0].Name).To(Equal("target-disk0"))
+			Expect(createdPVCs[0].Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+			Expect(createdPVCs[0].Spec.DataSource.Name).To(Equal(volumeSnapshotName))
+			Expect(createdPVCs[0].Spec.VolumeName).To(BeEmpty())
+			Expect(createdPVCs[0].OwnerReferences[0].Name).To(Equal(targetVMName))
+
+			Expect(updatedClones).To(HaveLen(1))
+			Expect(updatedClones[0].Status.Phase).To(Equal(clonev1.Succeeded))
+		})
+	})
+
+	table.DescribeTable("should filter keys", func(filters []string, expected map[string]string) {
+		m := map[string]string{
+			"app":              "test",
+			"example.com/keep": "true",
+			"example.com/drop": "true",
+		}
+		Expect(filterKeys(m, filters)).To(Equal(expected))
+	},
+		table.Entry("keeping all keys without filters", nil, map[string]string{
+			"app":              "test",
+			"example.com/keep": "true",
+			"example.com/drop": "true",
+		}),
+		table.Entry("keeping matching keys", []string{"example.com/*"}, map[string]string{
+			"example.com/keep": "true",
+			"example.com/drop": "true",
+		}),
+		table.Entry("dropping excluded keys", []string{"*", "!example.com/drop"}, map[string]string{
+			"app":              "test",
+			"example.com/keep": "true",
+		}),
+		table.Entry("using the last matching filter", []string{"!example.com/*", "example.com/keep"}, map[string]string{
+			"example.com/keep": "true",
+		}),
+	)
+})
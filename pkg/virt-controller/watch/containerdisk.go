@@ -0,0 +1,261 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	"kubevirt.io/kubevirt/pkg/container-disk/registry"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+// containerDiskRegistryTimeout bounds each request to the registries of containerDisk images
+const containerDiskRegistryTimeout = 30 * time.Second
+
+// containerDiskRegistry resolves and verifies the images of containerDisks
+type containerDiskRegistry interface {
+	ResolveDigest(image string, credentials *registry.Credentials) (string, error)
+	VerifySignature(image string, digest string, publicKeys []*ecdsa.PublicKey, credentials *registry.Credentials) error
+}
+
+func newContainerDiskRegistry() containerDiskRegistry {
+	return registry.NewClient(&http.Client{Timeout: containerDiskRegistryTimeout})
+}
+
+// containerDiskResolution resolves the containerDisk images of a VMI in the background, so that slow registries
+// don't block the sync of other VMIs
+type containerDiskResolution struct {
+	uid      types.UID
+	done     bool
+	resolved map[string]*virtv1.ContainerDiskInfo
+	err      error
+}
+
+// containerDiskResolutions keeps the resolutions of the containerDisk images by the key of their VMI
+type containerDiskResolutions struct {
+	lock        sync.Mutex
+	resolutions map[string]*containerDiskResolution
+}
+
+func newContainerDiskResolutions() *containerDiskResolutions {
+	return &containerDiskResolutions{resolutions: map[string]*containerDiskResolution{}}
+}
+
+func (r *containerDiskResolutions) Remove(key string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.resolutions, key)
+}
+
+// resolveContainerDiskDigests resolves the images of the containerDisks of the VMI to digests, and verifies
+// their signatures if signature keys are configured, before the virt-launcher pod is created. The images are
+// resolved in the background and the VMI is enqueued again once they are. The digests are then recorded in the
+// volume status, from where the pod template takes them. It returns true while the pod has to wait for the
+// resolution or for the patched VMI.
+func (c *VMIController) resolveContainerDiskDigests(vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	config := c.clusterConfig.GetContainerDiskConfiguration()
+	if config == nil || (!config.ResolveDigests && len(config.SignatureKeys) == 0) {
+		return false, nil
+	}
+	publicKeys, err := registry.ParsePublicKeys(config.SignatureKeys)
+	if err != nil {
+		return false, err
+	}
+	key, err := controller.KeyFunc(vmi)
+	if err != nil {
+		return false, err
+	}
+
+	var pending []virtv1.Volume
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.ContainerDisk == nil {
+			continue
+		}
+		// a digest recorded before signature keys were configured is verified again
+		if info := containerDiskInfo(vmi, volume.Name); info != nil && (len(publicKeys) == 0 || info.SignatureVerified) {
+			continue
+		}
+		pending = append(pending, volume)
+	}
+	if len(pending) == 0 {
+		c.containerDiskResolutions.Remove(key)
+		return false, nil
+	}
+
+	resolutions := c.containerDiskResolutions
+	resolutions.lock.Lock()
+	resolution, exists := resolutions.resolutions[key]
+	if !exists || resolution.uid != vmi.UID {
+		resolutions.resolutions[key] = &containerDiskResolution{uid: vmi.UID}
+		resolutions.lock.Unlock()
+		go c.runContainerDiskResolution(key, vmi.DeepCopy(), pending, publicKeys)
+		return true, nil
+	}
+	if !resolution.done {
+		resolutions.lock.Unlock()
+		return true, nil
+	}
+	delete(resolutions.resolutions, key)
+	resolutions.lock.Unlock()
+
+	if resolution.err != nil {
+		return false, resolution.err
+	}
+	resolved := map[string]*virtv1.ContainerDiskInfo{}
+	for _, volume := range pending {
+		if info, ok := resolution.resolved[volume.Name]; ok {
+			resolved[volume.Name] = info
+		}
+	}
+	if len(resolved) == 0 {
+		return false, nil
+	}
+	return true, c.patchContainerDiskInfo(vmi, resolved)
+}
+
+// runContainerDiskResolution resolves the images of the volumes and enqueues the VMI with the result
+func (c *VMIController) runContainerDiskResolution(key string, vmi *virtv1.VirtualMachineInstance, volumes []virtv1.Volume, publicKeys []*ecdsa.PublicKey) {
+	resolved, err := c.resolveContainerDiskImages(vmi, volumes, publicKeys)
+
+	resolutions := c.containerDiskResolutions
+	resolutions.lock.Lock()
+	if resolution, exists := resolutions.resolutions[key]; exists && resolution.uid == vmi.UID {
+		resolution.done = true
+		resolution.resolved = resolved
+		resolution.err = err
+	}
+	resolutions.lock.Unlock()
+
+	c.Queue.Add(key)
+}
+
+func (c *VMIController) resolveContainerDiskImages(vmi *virtv1.VirtualMachineInstance, volumes []virtv1.Volume, publicKeys []*ecdsa.PublicKey) (map[string]*virtv1.ContainerDiskInfo, error) {
+	resolved := map[string]*virtv1.ContainerDiskInfo{}
+	for _, volume := range volumes {
+		image := volume.ContainerDisk.Image
+		credentials, err := c.containerDiskCredentials(vmi.Namespace, volume.ContainerDisk)
+		if err != nil {
+			return nil, err
+		}
+		digest, err := c.containerDiskRegistry.ResolveDigest(image, credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the digest of image %s: %v", image, err)
+		}
+		info := &virtv1.ContainerDiskInfo{ImageDigest: digest}
+		if len(publicKeys) > 0 {
+			if err := c.containerDiskRegistry.VerifySignature(image, digest, publicKeys, credentials); err != nil {
+				return nil, fmt.Errorf("failed to verify the signature of image %s: %v", image, err)
+			}
+			info.SignatureVerified = true
+		}
+		resolved[volume.Name] = info
+	}
+	return resolved, nil
+}
+
+// patchContainerDiskInfo records the resolved images in the volume status of the VMI
+func (c *VMIController) patchContainerDiskInfo(vmi *virtv1.VirtualMachineInstance, resolved map[string]*virtv1.ContainerDiskInfo) error {
+	volumeStatus := []virtv1.VolumeStatus{}
+	for _, status := range vmi.Status.VolumeStatus {
+		if info, ok := resolved[status.Name]; ok {
+			status.ContainerDiskVolume = info
+			delete(resolved, status.Name)
+		}
+		volumeStatus = append(volumeStatus, status)
+	}
+	for name, info := range resolved {
+		volumeStatus = append(volumeStatus, virtv1.VolumeStatus{Name: name, ContainerDiskVolume: info})
+	}
+	sort.SliceStable(volumeStatus, func(i, j int) bool {
+		return strings.Compare(volumeStatus[i].Name, volumeStatus[j].Name) == -1
+	})
+
+	newVolumeStatus, err := json.Marshal(volumeStatus)
+	if err != nil {
+		return err
+	}
+	var patch string
+	if vmi.Status.VolumeStatus == nil {
+		patch = fmt.Sprintf(`[{ "op": "add", "path": "/status/volumeStatus", "value": %s }]`, string(newVolumeStatus))
+	} else {
+		oldVolumeStatus, err := json.Marshal(vmi.Status.VolumeStatus)
+		if err != nil {
+			return err
+		}
+		patch = fmt.Sprintf(`[{ "op": "test", "path": "/status/volumeStatus", "value": %s }, { "op": "replace", "path": "/status/volumeStatus", "value": %s }]`,
+			string(oldVolumeStatus), string(newVolumeStatus))
+	}
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	return err
+}
+
+// containerDiskCredentials returns the credentials for the registry of the image from its pull secret, if it has one
+func (c *VMIController) containerDiskCredentials(namespace string, containerDisk *virtv1.ContainerDiskSource) (*registry.Credentials, error) {
+	if containerDisk.ImagePullSecret == "" {
+		return nil, nil
+	}
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(context.Background(), containerDisk.ImagePullSecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the pull secret of image %s: %v", containerDisk.Image, err)
+	}
+	if config, ok := secret.Data[k8sv1.DockerConfigJsonKey]; ok {
+		return registry.CredentialsFromDockerConfig(config, containerDisk.Image)
+	}
+	if config, ok := secret.Data[k8sv1.DockerConfigKey]; ok {
+		// The legacy format is the map of the auths of the current one
+		return registry.CredentialsFromDockerConfig([]byte(`{"auths":`+string(config)+`}`), containerDisk.Image)
+	}
+	return nil, nil
+}
+
+// containerDiskDigestFromPod returns the digest of the image the container of the containerDisk volume
+// runs in the virt-launcher pod, as reported by the container runtime
+func containerDiskDigestFromPod(pod *k8sv1.Pod, volumeName string) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == fmt.Sprintf("volume%s", volumeName) {
+			return containerdisk.DigestFromImageID(status.ImageID)
+		}
+	}
+	return ""
+}
+
+func containerDiskInfo(vmi *virtv1.VirtualMachineInstance, volumeName string) *virtv1.ContainerDiskInfo {
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.Name == volumeName {
+			return status.ContainerDiskVolume
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+)
+
+// cpuModelRanking orders the libvirt x86 CPU models of each vendor from the most
+// to the least capable one. It is used to pick the widest model among the ones
+// usable on all the nodes.
+var cpuModelRanking = []string{
+	"Icelake-Server", "Icelake-Server-noTSX", "Icelake-Client", "Icelake-Client-noTSX",
+	"Cooperlake",
+	"Cascadelake-Server", "Cascadelake-Server-noTSX",
+	"Skylake-Server-IBRS", "Skylake-Server", "Skylake-Server-noTSX-IBRS",
+	"Skylake-Client-IBRS", "Skylake-Client", "Skylake-Client-noTSX-IBRS",
+	"Broadwell-IBRS", "Broadwell", "Broadwell-noTSX-IBRS", "Broadwell-noTSX",
+	"Haswell-IBRS", "Haswell", "Haswell-noTSX-IBRS", "Haswell-noTSX",
+	"IvyBridge-IBRS", "IvyBridge",
+	"SandyBridge-IBRS", "SandyBridge",
+	"Westmere-IBRS", "Westmere",
+	"Nehalem-IBRS", "Nehalem",
+	"Penryn",
+	"Conroe",
+	"EPYC-Milan", "EPYC-Rome", "EPYC-IBPB", "EPYC",
+	"Opteron_G5", "Opteron_G4", "Opteron_G3", "Opteron_G2", "Opteron_G1",
+}
+
+func cpuModelRank(model string) int {
+	for i, m := range cpuModelRanking {
+		if m == model {
+			return i
+		}
+	}
+	return len(cpuModelRanking)
+}
+
+// commonLabels returns the suffixes of the labels with the given prefix which are set on all the nodes
+func commonLabels(nodes []*k8sv1.Node, prefix string) []string {
+	counts := map[string]int{}
+	for _, node := range nodes {
+		for label, value := range node.Labels {
+			if strings.HasPrefix(label, prefix) && value == "true" {
+				counts[strings.TrimPrefix(label, prefix)]++
+			}
+		}
+	}
+	var common []string
+	for name, count := range counts {
+		if count == len(nodes) {
+			common = append(common, name)
+		}
+	}
+	sort.Strings(common)
+	return common
+}
+
+// clusterCommonCPU returns the widest CPU model and the CPU features which the node-labeller
+// reported as supported on all the given nodes
+func clusterCommonCPU(nodes []*k8sv1.Node) (string, []string, error) {
+	if len(nodes) == 0 {
+		return "", nil, fmt.Errorf("no schedulable nodes to compute the cluster-common CPU model from")
+	}
+	models := commonLabels(nodes, services.NFD_CPU_MODEL_PREFIX)
+	if len(models) == 0 {
+		return "", nil, fmt.Errorf("no CPU model is supported on all the %d schedulable nodes", len(nodes))
+	}
+	// Unknown models are ranked last, ties are broken by the name to keep the choice stable
+	sort.SliceStable(models, func(i, j int) bool {
+		return cpuModelRank(models[i]) < cpuModelRank(models[j])
+	})
+	return models[0], commonLabels(nodes, services.NFD_CPU_FEATURE_PREFIX), nil
+}
+
+func (c *VMIController) listClusterCommonCPUNodes() ([]*k8sv1.Node, error) {
+	selector := labels.SelectorFromSet(c.clusterConfig.GetClusterCommonCPUNodeSelector())
+	var nodes []*k8sv1.Node
+	for _, obj := range c.nodeInformer.GetStore().List() {
+		node, ok := obj.(*k8sv1.Node)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object in the node store: %T", obj)
+		}
+		if node.Labels[virtv1.NodeSchedulable] != "true" || !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// applyClusterCommonCPU replaces the cluster-common CPU model of the VMI with the widest
+// model common to the selected nodes. The common CPU features are required in addition
+// to the features already requested by the VMI.
+func (c *VMIController) applyClusterCommonCPU(vmi *virtv1.VirtualMachineInstance) error {
+	nodes, err := c.listClusterCommonCPUNodes()
+	if err != nil {
+		return err
+	}
+	model, features, err := clusterCommonCPU(nodes)
+	if err != nil {
+		return err
+	}
+
+	cpuFeatures := append([]virtv1.CPUFeature{}, vmi.Spec.Domain.CPU.Features...)
+	requested := map[string]bool{}
+	for _, feature := range cpuFeatures {
+		requested[feature.Name] = true
+	}
+	for _, feature := range features {
+		if !requested[feature] {
+			cpuFeatures = append(cpuFeatures, virtv1.CPUFeature{Name: feature, Policy: "require"})
+		}
+	}
+
+	commonModel, err := json.Marshal(virtv1.CPUModelClusterCommon)
+	if err != nil {
+		return err
+	}
+	modelBytes, err := json.Marshal(model)
+	if err != nil {
+		return err
+	}
+	patchOps := []string{
+		fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/cpu/model", "value": %s }`, string(commonModel)),
+		fmt.Sprintf(`{ "op": "replace", "path": "/spec/domain/cpu/model", "value": %s }`, string(modelBytes)),
+	}
+	if len(cpuFeatures) > 0 {
+		featureBytes, err := json.Marshal(cpuFeatures)
+		if err != nil {
+			return err
+		}
+		patchOps = append(patchOps, fmt.Sprintf(`{ "op": "add", "path": "/spec/domain/cpu/features", "value": %s }`, string(featureBytes)))
+	}
+
+	patch := fmt.Sprintf("[ %s ]", strings.Join(patchOps, ", "))
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	return err
+}
@@ -0,0 +1,93 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+)
+
+var _ = Describe("Cluster-common CPU model", func() {
+
+	newNode := func(models []string, features ...string) *k8sv1.Node {
+		node := &k8sv1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}}}
+		for _, model := range models {
+			node.Labels[services.NFD_CPU_MODEL_PREFIX+model] = "true"
+		}
+		for _, feature := range features {
+			node.Labels[services.NFD_CPU_FEATURE_PREFIX+feature] = "true"
+		}
+		return node
+	}
+
+	table.DescribeTable("should pick the widest model supported on all nodes", func(nodes []*k8sv1.Node, expectedModel string) {
+		model, _, err := clusterCommonCPU(nodes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(model).To(Equal(expectedModel))
+	},
+		table.Entry("with a single node",
+			[]*k8sv1.Node{newNode([]string{"Haswell", "Skylake-Client", "Broadwell"})}, "Skylake-Client"),
+		table.Entry("with nodes of different generations",
+			[]*k8sv1.Node{
+				newNode([]string{"Haswell", "Skylake-Client", "Broadwell"}),
+				newNode([]string{"Haswell", "Broadwell-noTSX"}),
+			}, "Haswell"),
+		table.Entry("with models unknown to the ranking",
+			[]*k8sv1.Node{newNode([]string{"qemu64", "kvm64", "Penryn"})}, "Penryn"),
+		table.Entry("with only models unknown to the ranking",
+			[]*k8sv1.Node{newNode([]string{"qemu64", "kvm64"})}, "kvm64"),
+	)
+
+	It("should return the features supported on all nodes", func() {
+		_, features, err := clusterCommonCPU([]*k8sv1.Node{
+			newNode([]string{"Haswell"}, "pcid", "invtsc", "avx512f"),
+			newNode([]string{"Haswell"}, "pcid", "invtsc"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(features).To(Equal([]string{"invtsc", "pcid"}))
+	})
+
+	It("should ignore labels which are not set to true", func() {
+		node := newNode([]string{"Haswell"}, "pcid")
+		node.Labels[services.NFD_CPU_MODEL_PREFIX+"Skylake-Client"] = "false"
+		model, features, err := clusterCommonCPU([]*k8sv1.Node{node})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(model).To(Equal("Haswell"))
+		Expect(features).To(Equal([]string{"pcid"}))
+	})
+
+	It("should fail without nodes", func() {
+		_, _, err := clusterCommonCPU(nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail if no model is supported on all nodes", func() {
+		_, _, err := clusterCommonCPU([]*k8sv1.Node{
+			newNode([]string{"Haswell"}),
+			newNode([]string{"EPYC"}),
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
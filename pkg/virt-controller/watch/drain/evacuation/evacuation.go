@@ -3,10 +3,12 @@ package evacuation
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
 	k8sv1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -38,6 +40,7 @@ type EvacuationController struct {
 	recorder              record.EventRecorder
 	migrationExpectations *controller.UIDTrackingControllerExpectations
 	nodeInformer          cache.SharedIndexInformer
+	priorityClassInformer cache.SharedIndexInformer
 	clusterConfig         *virtconfig.ClusterConfig
 }
 
@@ -45,6 +48,7 @@ func NewEvacuationController(
 	vmiInformer cache.SharedIndexInformer,
 	migrationInformer cache.SharedIndexInformer,
 	nodeInformer cache.SharedIndexInformer,
+	priorityClassInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
 	clusterConfig *virtconfig.ClusterConfig,
@@ -55,6 +59,7 @@ func NewEvacuationController(
 		vmiInformer:           vmiInformer,
 		migrationInformer:     migrationInformer,
 		nodeInformer:          nodeInformer,
+		priorityClassInformer: priorityClassInformer,
 		recorder:              recorder,
 		clientset:             clientset,
 		migrationExpectations: controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
@@ -261,7 +266,7 @@ func (c *EvacuationController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting evacuation controller.")
 
 	// Wait for cache sync before we start the node controller
-	cache.WaitForCacheSync(stopCh, c.migrationInformer.HasSynced, c.vmiInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.migrationInformer.HasSynced, c.vmiInformer.HasSynced, c.priorityClassInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -401,7 +406,9 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 		return nil
 	}
 
-	// TODO: should the order be randomized?
+	// Migrate the VMIs with the lowest priority first, to free resources on the node
+	// before the more important workloads are disrupted
+	c.sortByPriority(migrationCandidates)
 	selectedCandidates := migrationCandidates[0:diff]
 
 	log.DefaultLogger().Infof("node: %v, migrations: %v, candidates: %v, selected: %v", node.Name, len(activeMigrations), len(migrationCandidates), len(selectedCandidates))
@@ -437,6 +444,31 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 	return nil
 }
 
+// sortByPriority orders the VMIs by the value of their priority class, lowest priority first.
+// VMIs without a priority class get the priority of the global default priority class.
+func (c *EvacuationController) sortByPriority(vmis []*virtv1.VirtualMachineInstance) {
+	priorities := map[string]int32{}
+	for _, vmi := range vmis {
+		priorities[vmi.Spec.PriorityClassName] = c.priority(vmi.Spec.PriorityClassName)
+	}
+	sort.SliceStable(vmis, func(i, j int) bool {
+		return priorities[vmis[i].Spec.PriorityClassName] < priorities[vmis[j].Spec.PriorityClassName]
+	})
+}
+
+func (c *EvacuationController) priority(priorityClassName string) int32 {
+	for _, obj := range c.priorityClassInformer.GetStore().List() {
+		priorityClass := obj.(*schedulingv1.PriorityClass)
+		if priorityClassName == "" && priorityClass.GlobalDefault {
+			return priorityClass.Value
+		}
+		if priorityClassName != "" && priorityClass.Name == priorityClassName {
+			return priorityClass.Value
+		}
+	}
+	return 0
+}
+
 func hasMigratedOnEviction(vmi *virtv1.VirtualMachineInstance) bool {
 	return vmi.Status.NodeName != vmi.Status.EvacuationNodeName
 }
@@ -3,6 +3,7 @@ package evacuation_test
 import (
 	"github.com/golang/mock/gomock"
 	v12 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
@@ -31,6 +32,8 @@ var _ = Describe("Evacuation", func() {
 	var nodeInformer cache.SharedIndexInformer
 	var migrationInformer cache.SharedIndexInformer
 	var migrationSource *framework.FakeControllerSource
+	var priorityClassInformer cache.SharedIndexInformer
+	var priorityClassSource *framework.FakeControllerSource
 	var recorder *record.FakeRecorder
 	var mockQueue *testutils.MockWorkQueue
 	var kubeClient *fake.Clientset
@@ -43,11 +46,13 @@ var _ = Describe("Evacuation", func() {
 		go vmiInformer.Run(stop)
 		go migrationInformer.Run(stop)
 		go nodeInformer.Run(stop)
+		go priorityClassInformer.Run(stop)
 
 		Expect(cache.WaitForCacheSync(stop,
 			vmiInformer.HasSynced,
 			migrationInformer.HasSynced,
 			nodeInformer.HasSynced,
+			priorityClassInformer.HasSynced,
 		)).To(BeTrue())
 	}
 
@@ -71,10 +76,11 @@ var _ = Describe("Evacuation", func() {
 		})
 		migrationInformer, migrationSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceMigration{})
 		nodeInformer, nodeSource = testutils.NewFakeInformerFor(&v12.Node{})
+		priorityClassInformer, priorityClassSource = testutils.NewFakeInformerFor(&schedulingv1.PriorityClass{})
 		recorder = record.NewFakeRecorder(100)
 		config, _, _, _ := testutils.NewFakeClusterConfig(&v12.ConfigMap{})
 
-		controller = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, recorder, virtClient, config)
+		controller = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, priorityClassInformer, recorder, virtClient, config)
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 		controller.Queue = mockQueue
 		migrationFeeder = testutils.NewMigrationFeeder(mockQueue, migrationSource)
@@ -221,6 +227,41 @@ var _ = Describe("Evacuation", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
 		})
+
+		It("should migrate the VMI with the lowest priority first", func() {
+			node := newNode("testnode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+
+			priorityClassSource.Add(newPriorityClass("high", 1000, false))
+			priorityClassSource.Add(newPriorityClass("default", 10, true))
+			Eventually(func() int {
+				return len(priorityClassInformer.GetStore().List())
+			}).Should(Equal(2))
+
+			for _, name := range []string{"testvm", "testvm2", "testvm3", "testvm4"} {
+				vmi := newVirtualMachine(name, node.Name)
+				vmi.Spec.EvictionStrategy = newEvictionStrategy()
+				vmi.Spec.PriorityClassName = "high"
+				vmiFeeder.Add(vmi)
+			}
+			vmi1 := newVirtualMachine("testvm1", node.Name)
+			vmi1.Spec.EvictionStrategy = newEvictionStrategy()
+			vmiFeeder.Add(vmi1)
+			vmiFeeder.Add(newVirtualMachine("othervm", "anothernode"))
+
+			migrationFeeder.Add(newMigration("mig1", "othervm", v1.MigrationRunning))
+			migrationFeeder.Add(newMigration("mig2", "othervm", v1.MigrationRunning))
+			migrationFeeder.Add(newMigration("mig3", "othervm", v1.MigrationRunning))
+			migrationFeeder.Add(newMigration("mig4", "othervm", v1.MigrationRunning))
+
+			migrationInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(migration *v1.VirtualMachineInstanceMigration) (*v1.VirtualMachineInstanceMigration, error) {
+				Expect(migration.Spec.VMIName).To(Equal(vmi1.Name))
+				return &v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil
+			})
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		})
 	})
 
 	Context("VMIs marked for eviction", func() {
@@ -383,6 +424,16 @@ func newMigration(name string, vmi string, phase v1.VirtualMachineInstanceMigrat
 	return migration
 }
 
+func newPriorityClass(name string, value int32, globalDefault bool) *schedulingv1.PriorityClass {
+	return &schedulingv1.PriorityClass{
+		ObjectMeta: v13.ObjectMeta{
+			Name: name,
+		},
+		Value:         value,
+		GlobalDefault: globalDefault,
+	}
+}
+
 func newEvictionStrategy() *v1.EvictionStrategy {
 	strategy := v1.EvictionStrategyLiveMigrate
 	return &strategy
@@ -0,0 +1,1078 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	exportv1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
+	certutil "kubevirt.io/kubevirt/pkg/certificates/triple/cert"
+	exportserver "kubevirt.io/kubevirt/pkg/virt-exportserver"
+)
+
+const (
+	exporterAppLabelValue = "virt-exporter"
+
+	// exportNameLabel links the exporter pod and service to the VirtualMachineExport
+	exportNameLabel = "export.kubevirt.io/export"
+
+	// caCertAnnotation holds the CA certificate the exporter pod serves with
+	caCertAnnotation = "export.kubevirt.io/ca-cert"
+
+	exporterContainerName = "exporter"
+	exporterPort          = 8443
+
+	certVolumeName    = "cert"
+	certMountPath     = "/cert"
+	tokenVolumeName   = "token"
+	tokenMountPath    = "/token"
+	tokenKey          = "token"
+	scratchVolumeName = "scratch"
+	scratchMountPath  = "/scratch"
+	volumesMountPath  = "/export-volumes"
+
+	registryAuthVolumeName = "registry-auth"
+	registryAuthMountPath  = "/registry-auth"
+
+	certDuration = 7 * 24 * time.Hour
+
+	exporterPodCreateEvent = "SuccessfulExporterPodCreate"
+
+	sourceNotFoundReason = "SourceNotFound"
+	sourceInUseReason    = "InUse"
+	sourceNotReadyReason = "NotReady"
+	podPendingReason     = "PodPending"
+	podReadyReason       = "PodReady"
+	pushSucceededReason  = "PushSucceeded"
+	pushFailedReason     = "PushFailed"
+)
+
+// variable so can be overridden in tests
+var currentTime = func() *metav1.Time {
+	t := metav1.Now()
+	return &t
+}
+
+// VMExportController is responsible for exporting volumes out of the cluster
+type VMExportController struct {
+	Client kubecli.KubevirtClient
+
+	// ExporterImage is the image the exporter pod runs in
+	ExporterImage string
+
+	VMExportInformer          cache.SharedIndexInformer
+	PVCInformer               cache.SharedIndexInformer
+	PodInformer               cache.SharedIndexInformer
+	VMInformer                cache.SharedIndexInformer
+	VMIInformer               cache.SharedIndexInformer
+	VMSnapshotInformer        cache.SharedIndexInformer
+	VMSnapshotContentInformer cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	vmExportQueue workqueue.RateLimitingInterface
+}
+
+type exportVolume struct {
+	name string
+	pvc  *corev1.PersistentVolumeClaim
+}
+
+// sourceState describes whether the source of an export can be served
+type sourceState struct {
+	volumes []exportVolume
+	// reason is set when the source can't be exported right now
+	reason  string
+	message string
+}
+
+// Init initializes the export controller
+func (ctrl *VMExportController) Init() {
+	ctrl.vmExportQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "export-controller-vmexport")
+
+	ctrl.VMExportInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMExport,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMExport(newObj) },
+		},
+	)
+
+	ctrl.PodInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handlePod,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handlePod(newObj) },
+			DeleteFunc: ctrl.handlePod,
+		},
+	)
+
+	ctrl.PVCInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handlePVC,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handlePVC(newObj) },
+		},
+	)
+
+	ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMI,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMI(newObj) },
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *VMExportController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmExportQueue.ShutDown()
+
+	log.Log.Info("Starting export controller.")
+	defer log.Log.Info("Shutting down export controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMExportInformer.HasSynced,
+		ctrl.PVCInformer.HasSynced,
+		ctrl.PodInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.VMSnapshotInformer.HasSynced,
+		ctrl.VMSnapshotContentInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmExportWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMExportController) vmExportWorker() {
+	for ctrl.processVMExportWorkItem() {
+	}
+}
+
+func (ctrl *VMExportController) processVMExportWorkItem() bool {
+	obj, shutdown := ctrl.vmExportQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ctrl.vmExportQueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		ctrl.vmExportQueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	log.Log.V(3).Infof("vmExport worker processing key [%s]", key)
+
+	if err := ctrl.execute(key); err != nil {
+		utilruntime.HandleError(err)
+		ctrl.vmExportQueue.AddRateLimited(key)
+		return true
+	}
+
+	ctrl.vmExportQueue.Forget(obj)
+	return true
+}
+
+func (ctrl *VMExportController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMExportInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	vmExport, ok := storeObj.(*exportv1.VirtualMachineExport)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateVMExport(vmExport.DeepCopy())
+}
+
+func (ctrl *VMExportController) handleVMExport(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmExport, ok := obj.(*exportv1.VirtualMachineExport); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmExport)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, vmExport)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmExportQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMExportController) handlePod(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if pod, ok := obj.(*corev1.Pod); ok {
+		exportName, ok := pod.Labels[exportNameLabel]
+		if !ok {
+			return
+		}
+
+		ctrl.vmExportQueue.Add(cacheKeyFunc(pod.Namespace, exportName))
+	}
+}
+
+func (ctrl *VMExportController) handlePVC(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+		keys, err := ctrl.VMExportInformer.GetIndexer().IndexKeys(cache.NamespaceIndex, pvc.Namespace)
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+
+		for _, k := range keys {
+			ctrl.vmExportQueue.Add(k)
+		}
+	}
+}
+
+func (ctrl *VMExportController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmi, ok := obj.(*kubevirtv1.VirtualMachineInstance); ok {
+		keys, err := ctrl.VMExportInformer.GetIndexer().IndexKeys("vm", vmi.Name)
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+
+		for _, k := range keys {
+			ctrl.vmExportQueue.Add(k)
+		}
+	}
+}
+
+func cacheKeyFunc(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+func exporterName(vmExport *exportv1.VirtualMachineExport) string {
+	return fmt.Sprintf("virt-export-%s", vmExport.Name)
+}
+
+func certSecretName(vmExport *exportv1.VirtualMachineExport) string {
+	return fmt.Sprintf("%s-certs", exporterName(vmExport))
+}
+
+func ownerReference(vmExport *exportv1.VirtualMachineExport) metav1.OwnerReference {
+	t := true
+	return metav1.OwnerReference{
+		APIVersion:         exportv1.SchemeGroupVersion.String(),
+		Kind:               "VirtualMachineExport",
+		Name:               vmExport.Name,
+		UID:                vmExport.UID,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}
+}
+
+func (ctrl *VMExportController) updateVMExport(vmExport *exportv1.VirtualMachineExport) error {
+	log.Log.V(3).Infof("Updating VirtualMachineExport %s/%s", vmExport.Namespace, vmExport.Name)
+
+	// the exporter pod, service and secrets are garbage collected
+	if vmExport.DeletionTimestamp != nil {
+		return nil
+	}
+
+	source, err := ctrl.getSourceState(vmExport)
+	if err != nil {
+		return err
+	}
+
+	pod, err := ctrl.getExporterPod(vmExport)
+	if err != nil {
+		return err
+	}
+
+	if pod == nil && source.reason == "" {
+		inUse, err := ctrl.volumesInUse(vmExport.Namespace, source.volumes)
+		if err != nil {
+			return err
+		}
+		if inUse != "" {
+			source.reason = sourceInUseReason
+			source.message = inUse
+		}
+	}
+
+	if pod == nil && source.reason == "" {
+		pod, err = ctrl.createExporter(vmExport, source.volumes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctrl.updateVMExportStatus(vmExport, source, pod)
+}
+
+func (ctrl *VMExportController) getSourceState(vmExport *exportv1.VirtualMachineExport) (*sourceState, error) {
+	source := vmExport.Spec.Source
+	group := ""
+	if source.APIGroup != nil {
+		group = *source.APIGroup
+	}
+
+	switch {
+	case group == "" && source.Kind == "PersistentVolumeClaim":
+		return ctrl.getPVCSourceState(vmExport)
+	case group == kubevirtv1.GroupName && source.Kind == "VirtualMachine":
+		return ctrl.getVMSourceState(vmExport)
+	case group == snapshotv1.SchemeGroupVersion.Group && source.Kind == "VirtualMachineSnapshot":
+		return ctrl.getVMSnapshotSourceState(vmExport)
+	}
+
+	return &sourceState{
+		reason:  sourceNotFoundReason,
+		message: fmt.Sprintf("unsupported source kind %s", source.Kind),
+	}, nil
+}
+
+func (ctrl *VMExportController) getPVC(namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*corev1.PersistentVolumeClaim).DeepCopy(), nil
+}
+
+func pvcNotFound(name string) *sourceState {
+	return &sourceState{
+		reason:  sourceNotFoundReason,
+		message: fmt.Sprintf("PersistentVolumeClaim %s does not exist", name),
+	}
+}
+
+func (ctrl *VMExportController) getPVCSourceState(vmExport *exportv1.VirtualMachineExport) (*sourceState, error) {
+	pvc, err := ctrl.getPVC(vmExport.Namespace, vmExport.Spec.Source.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if pvc == nil {
+		return pvcNotFound(vmExport.Spec.Source.Name), nil
+	}
+
+	return &sourceState{volumes: []exportVolume{{name: pvc.Name, pvc: pvc}}}, nil
+}
+
+func (ctrl *VMExportController) getVMSourceState(vmExport *exportv1.VirtualMachineExport) (*sourceState, error) {
+	key := cacheKeyFunc(vmExport.Namespace, vmExport.Spec.Source.Name)
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return &sourceState{
+			reason:  sourceNotFoundReason,
+			message: fmt.Sprintf("VirtualMachine %s does not exist", vmExport.Spec.Source.Name),
+		}, nil
+	}
+	vm := obj.(*kubevirtv1.VirtualMachine)
+
+	_, vmiExists, err := ctrl.VMIInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if vmiExists {
+		return &sourceState{
+			reason:  sourceInUseReason,
+			message: fmt.Sprintf("VirtualMachine %s is running", vm.Name),
+		}, nil
+	}
+
+	state := &sourceState{}
+	if vm.Spec.Template == nil {
+		return state, nil
+	}
+
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.DataVolume != nil:
+			claimName = volume.DataVolume.Name
+		default:
+			continue
+		}
+
+		pvc, err := ctrl.getPVC(vmExport.Namespace, claimName)
+		if err != nil {
+			return nil, err
+		}
+
+		if pvc == nil {
+			return pvcNotFound(claimName), nil
+		}
+
+		state.volumes = append(state.volumes, exportVolume{name: volume.Name, pvc: pvc})
+	}
+
+	return state, nil
+}
+
+// getVMSnapshotSourceState restores the volumes of the snapshot into PVCs owned by the export
+func (ctrl *VMExportController) getVMSnapshotSourceState(vmExport *exportv1.VirtualMachineExport) (*sourceState, error) {
+	obj, exists, err := ctrl.VMSnapshotInformer.GetStore().GetByKey(cacheKeyFunc(vmExport.Namespace, vmExport.Spec.Source.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return &sourceState{
+			reason:  sourceNotFoundReason,
+			message: fmt.Sprintf("VirtualMachineSnapshot %s does not exist", vmExport.Spec.Source.Name),
+		}, nil
+	}
+	vmSnapshot := obj.(*snapshotv1.VirtualMachineSnapshot)
+
+	if vmSnapshot.Status == nil || vmSnapshot.Status.ReadyToUse == nil || !*vmSnapshot.Status.ReadyToUse ||
+		vmSnapshot.Status.VirtualMachineSnapshotContentName == nil {
+		return &sourceState{
+			reason:  sourceNotReadyReason,
+			message: fmt.Sprintf("VirtualMachineSnapshot %s is not ready to use", vmSnapshot.Name),
+		}, nil
+	}
+
+	obj, exists, err = ctrl.VMSnapshotContentInformer.GetStore().GetByKey(cacheKeyFunc(vmExport.Namespace, *vmSnapshot.Status.VirtualMachineSnapshotContentName))
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return &sourceState{
+			reason:  sourceNotReadyReason,
+			message: fmt.Sprintf("VirtualMachineSnapshotContent %s does not exist", *vmSnapshot.Status.VirtualMachineSnapshotContentName),
+		}, nil
+	}
+	content := obj.(*snapshotv1.VirtualMachineSnapshotContent)
+
+	state := &sourceState{}
+	for _, volumeBackup := range content.Spec.VolumeBackups {
+		if volumeBackup.VolumeSnapshotName == nil {
+			continue
+		}
+
+		pvcName := fmt.Sprintf("%s-%s", vmExport.Name, volumeBackup.VolumeName)
+		pvc, err := ctrl.getPVC(vmExport.Namespace, pvcName)
+		if err != nil {
+			return nil, err
+		}
+
+		if pvc == nil {
+			pvc, err = ctrl.createRestorePVC(vmExport, pvcName, volumeBackup)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		state.volumes = append(state.volumes, exportVolume{name: volumeBackup.VolumeName, pvc: pvc})
+	}
+
+	return state, nil
+}
+
+func (ctrl *VMExportController) createRestorePVC(vmExport *exportv1.VirtualMachineExport, name string, volumeBackup snapshotv1.VolumeBackup) (*corev1.PersistentVolumeClaim, error) {
+	apiGroup := vsv1beta1.GroupName
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       vmExport.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(vmExport)},
+		},
+		Spec: *volumeBackup.PersistentVolumeClaim.Spec.DeepCopy(),
+	}
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     "VolumeSnapshot",
+		Name:     *volumeBackup.VolumeSnapshotName,
+	}
+	pvc.Spec.VolumeName = ""
+
+	return ctrl.Client.CoreV1().PersistentVolumeClaims(vmExport.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+}
+
+// volumesInUse returns a message naming the pod that uses one of the volumes, if any
+func (ctrl *VMExportController) volumesInUse(namespace string, volumes []exportVolume) (string, error) {
+	claims := map[string]bool{}
+	for _, volume := range volumes {
+		claims[volume.pvc.Name] = true
+	}
+
+	objs, err := ctrl.PodInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	for _, obj := range objs {
+		pod := obj.(*corev1.Pod)
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && claims[volume.PersistentVolumeClaim.ClaimName] {
+				return fmt.Sprintf("PersistentVolumeClaim %s is in use by pod %s", volume.PersistentVolumeClaim.ClaimName, pod.Name), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func (ctrl *VMExportController) getExporterPod(vmExport *exportv1.VirtualMachineExport) (*corev1.Pod, error) {
+	obj, exists, err := ctrl.PodInformer.GetStore().GetByKey(cacheKeyFunc(vmExport.Namespace, exporterName(vmExport)))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	pod := obj.(*corev1.Pod)
+	if !metav1.IsControlledBy(pod, vmExport) {
+		return nil, fmt.Errorf("pod %s/%s is not owned by VirtualMachineExport %s", pod.Namespace, pod.Name, vmExport.Name)
+	}
+
+	return pod, nil
+}
+
+// createExporter creates the certificate secret, the service and the pod serving the volumes,
+// or only the pod pushing the volumes if they are exported as containerDisk images
+func (ctrl *VMExportController) createExporter(vmExport *exportv1.VirtualMachineExport, volumes []exportVolume) (*corev1.Pod, error) {
+	var pod *corev1.Pod
+	if vmExport.Spec.ContainerDisk != nil {
+		pod = ctrl.newPusherPod(vmExport, volumes)
+	} else {
+		caCert, err := ctrl.createCertSecret(vmExport)
+		if err != nil {
+			return nil, err
+		}
+
+		service := ctrl.newExporterService(vmExport)
+		if _, err := ctrl.Client.CoreV1().Services(vmExport.Namespace).Create(context.Background(), service, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+
+		pod = ctrl.newExporterPod(vmExport, volumes, caCert)
+	}
+
+	pod, err := ctrl.Client.CoreV1().Pods(vmExport.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.Recorder.Eventf(
+		vmExport,
+		corev1.EventTypeNormal,
+		exporterPodCreateEvent,
+		"Successfully created exporter pod %s",
+		pod.Name,
+	)
+
+	return pod, nil
+}
+
+// createCertSecret creates a fresh CA and server certificate for the exporter and returns the CA in PEM format
+func (ctrl *VMExportController) createCertSecret(vmExport *exportv1.VirtualMachineExport) (string, error) {
+	name := exporterName(vmExport)
+	ca, err := triple.NewCA(name, certDuration)
+	if err != nil {
+		return "", err
+	}
+
+	keyPair, err := triple.NewServerKeyPair(ca, name, name, vmExport.Namespace, "cluster.local", nil, nil, certDuration)
+	if err != nil {
+		return "", err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            certSecretName(vmExport),
+			Namespace:       vmExport.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(vmExport)},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certutil.EncodeCertPEM(keyPair.Cert),
+			corev1.TLSPrivateKeyKey: certutil.EncodePrivateKeyPEM(keyPair.Key),
+		},
+	}
+
+	if _, err := ctrl.Client.CoreV1().Secrets(vmExport.Namespace).Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return "", err
+		}
+
+		// a previous attempt created the secret but failed to create the pod
+		if err := ctrl.Client.CoreV1().Secrets(vmExport.Namespace).Delete(context.Background(), secret.Name, metav1.DeleteOptions{}); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("replacing stale certificate secret %s", secret.Name)
+	}
+
+	return string(certutil.EncodeCertPEM(ca.Cert)), nil
+}
+
+func (ctrl *VMExportController) newExporterService(vmExport *exportv1.VirtualMachineExport) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            exporterName(vmExport),
+			Namespace:       vmExport.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(vmExport)},
+			Labels: map[string]string{
+				kubevirtv1.AppLabel: exporterAppLabelValue,
+				exportNameLabel:     vmExport.Name,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "export",
+					Port:       443,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(exporterPort),
+				},
+			},
+			Selector: map[string]string{
+				exportNameLabel: vmExport.Name,
+			},
+		},
+	}
+}
+
+func isBlock(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock
+}
+
+func (ctrl *VMExportController) newExporterPod(vmExport *exportv1.VirtualMachineExport, volumes []exportVolume, caCert string) *corev1.Pod {
+	container := corev1.Container{
+		Name:            exporterContainerName,
+		Image:           ctrl.ExporterImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"virt-exportserver"},
+		Args: []string{
+			"--listen", fmt.Sprintf(":%d", exporterPort),
+			"--cert", filepath.Join(certMountPath, corev1.TLSCertKey),
+			"--key", filepath.Join(certMountPath, corev1.TLSPrivateKeyKey),
+			"--token-file", filepath.Join(tokenMountPath, tokenKey),
+			"--scratch-dir", scratchMountPath,
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: "export", ContainerPort: exporterPort, Protocol: corev1.ProtocolTCP},
+		},
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/healthz",
+					Port:   intstr.FromInt(exporterPort),
+					Scheme: corev1.URISchemeHTTPS,
+				},
+			},
+			InitialDelaySeconds: 5,
+			PeriodSeconds:       5,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: certVolumeName, MountPath: certMountPath, ReadOnly: true},
+			{Name: tokenVolumeName, MountPath: tokenMountPath, ReadOnly: true},
+			{Name: scratchVolumeName, MountPath: scratchMountPath},
+		},
+	}
+
+	podVolumes := []corev1.Volume{
+		{
+			Name: certVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: certSecretName(vmExport)},
+			},
+		},
+		{
+			Name: tokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: vmExport.Spec.TokenSecretRef,
+					Items:      []corev1.KeyToPath{{Key: tokenKey, Path: tokenKey}},
+				},
+			},
+		},
+		{
+			Name: scratchVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	podVolumes = append(podVolumes, addExportVolumes(&container, volumes)...)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            exporterName(vmExport),
+			Namespace:       vmExport.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(vmExport)},
+			Labels: map[string]string{
+				kubevirtv1.AppLabel: exporterAppLabelValue,
+				exportNameLabel:     vmExport.Name,
+			},
+			Annotations: map[string]string{
+				caCertAnnotation: caCert,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyAlways,
+			Containers:    []corev1.Container{container},
+			Volumes:       podVolumes,
+		},
+	}
+}
+
+// addExportVolumes mounts the PVCs of the volumes read-only into the container and passes their paths to it
+func addExportVolumes(container *corev1.Container, volumes []exportVolume) []corev1.Volume {
+	var podVolumes []corev1.Volume
+	for _, volume := range volumes {
+		podVolumeName := fmt.Sprintf("volume-%s", volume.name)
+		podVolumes = append(podVolumes, corev1.Volume{
+			Name: podVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volume.pvc.Name,
+					ReadOnly:  true,
+				},
+			},
+		})
+
+		path := filepath.Join(volumesMountPath, volume.name)
+		if isBlock(volume.pvc) {
+			container.VolumeDevices = append(container.VolumeDevices, corev1.VolumeDevice{
+				Name:       podVolumeName,
+				DevicePath: path,
+			})
+		} else {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      podVolumeName,
+				MountPath: path,
+				ReadOnly:  true,
+			})
+			path = filepath.Join(path, "disk.img")
+		}
+		container.Args = append(container.Args, "--volume", fmt.Sprintf("%s=%s", volume.name, path))
+	}
+
+	return podVolumes
+}
+
+// containerDiskImage returns the image the volume is pushed to. The tag is suffixed
+// with the name of the volume if more than one volume is pushed.
+func containerDiskImage(image, volume string, volumeCount int) string {
+	if volumeCount <= 1 {
+		return image
+	}
+
+	name, tag := image, "latest"
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+	return fmt.Sprintf("%s:%s-%s", name, tag, volume)
+}
+
+// newPusherPod creates the pod packaging the volumes into containerDisk images and pushing them.
+// The pod reports the pushed images in its termination message.
+func (ctrl *VMExportController) newPusherPod(vmExport *exportv1.VirtualMachineExport, volumes []exportVolume) *corev1.Pod {
+	containerDisk := vmExport.Spec.ContainerDisk
+	container := corev1.Container{
+		Name:            exporterContainerName,
+		Image:           ctrl.ExporterImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"virt-exportserver"},
+		Args: []string{
+			"--scratch-dir", scratchMountPath,
+		},
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: scratchVolumeName, MountPath: scratchMountPath},
+		},
+	}
+
+	scratch := &corev1.EmptyDirVolumeSource{}
+	if size := pusherScratchSize(volumes); size != nil {
+		scratch.SizeLimit = size
+		container.Resources.Requests = corev1.ResourceList{corev1.ResourceEphemeralStorage: *size}
+	}
+	podVolumes := []corev1.Volume{
+		{
+			Name: scratchVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: scratch,
+			},
+		},
+	}
+
+	if containerDisk.SecretRef != "" {
+		podVolumes = append(podVolumes, corev1.Volume{
+			Name: registryAuthVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: containerDisk.SecretRef,
+					Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: corev1.DockerConfigJsonKey}},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      registryAuthVolumeName,
+			MountPath: registryAuthMountPath,
+			ReadOnly:  true,
+		})
+		container.Args = append(container.Args, "--registry-auth-file", filepath.Join(registryAuthMountPath, corev1.DockerConfigJsonKey))
+	}
+
+	if containerDisk.InsecureSkipTLSVerify {
+		container.Args = append(container.Args, "--insecure-skip-tls-verify")
+	}
+
+	for _, volume := range volumes {
+		container.Args = append(container.Args, "--push-image",
+			fmt.Sprintf("%s=%s", volume.name, containerDiskImage(containerDisk.Image, volume.name, len(volumes))))
+	}
+	podVolumes = append(podVolumes, addExportVolumes(&container, volumes)...)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            exporterName(vmExport),
+			Namespace:       vmExport.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(vmExport)},
+			Labels: map[string]string{
+				kubevirtv1.AppLabel: exporterAppLabelValue,
+				exportNameLabel:     vmExport.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers:    []corev1.Container{container},
+			Volumes:       podVolumes,
+		},
+	}
+}
+
+// pusherScratchSize returns the scratch space the pusher needs, or nil if the size of the volumes is unknown.
+// The volumes are pushed one after the other, each needs room for its qcow2 image and for the compressed
+// layer holding it, which are both about as large as the volume at most. 10% more are added for the qcow2
+// metadata and the tar and gzip framing.
+func pusherScratchSize(volumes []exportVolume) *resource.Quantity {
+	var largest int64
+	for _, volume := range volumes {
+		size, ok := volume.pvc.Status.Capacity[corev1.ResourceStorage]
+		if !ok {
+			size, ok = volume.pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		}
+		if ok && size.Value() > largest {
+			largest = size.Value()
+		}
+	}
+	if largest == 0 {
+		return nil
+	}
+	return resource.NewQuantity(largest*2+largest/10, resource.BinarySI)
+}
+
+func newCondition(t exportv1.ConditionType, status corev1.ConditionStatus, reason, message string) exportv1.Condition {
+	return exportv1.Condition{
+		Type:               t,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
+func updateCondition(conditions []exportv1.Condition, c exportv1.Condition) []exportv1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == c.Type {
+			if conditions[i].Status != c.Status || conditions[i].Reason != c.Reason || conditions[i].Message != c.Message {
+				conditions[i] = c
+			}
+			return conditions
+		}
+	}
+
+	return append(conditions, c)
+}
+
+func podReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func (ctrl *VMExportController) updateVMExportStatus(vmExport *exportv1.VirtualMachineExport, source *sourceState, pod *corev1.Pod) error {
+	vmExportCopy := vmExport.DeepCopy()
+	if vmExportCopy.Status == nil {
+		vmExportCopy.Status = &exportv1.VirtualMachineExportStatus{}
+	}
+	status := vmExportCopy.Status
+
+	switch {
+	case pod == nil:
+		status.Phase = exportv1.Pending
+		if source.reason == sourceInUseReason {
+			status.Phase = exportv1.Skipped
+		}
+		status.Links = nil
+		status.ServiceName = ""
+		status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionPVC, corev1.ConditionFalse, source.reason, source.message))
+		status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionReady, corev1.ConditionFalse, source.reason, source.message))
+	case vmExport.Spec.ContainerDisk != nil:
+		status.Links = nil
+		status.ServiceName = ""
+		status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionPVC, corev1.ConditionTrue, "", ""))
+		updatePushStatus(status, pod)
+	default:
+		status.ServiceName = exporterName(vmExport)
+		status.Links = &exportv1.VirtualMachineExportLinks{
+			Internal: ctrl.internalLink(vmExport, source.volumes, pod),
+		}
+		status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionPVC, corev1.ConditionTrue, "", ""))
+		if podReady(pod) {
+			status.Phase = exportv1.Ready
+			status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionReady, corev1.ConditionTrue, podReadyReason, ""))
+		} else {
+			status.Phase = exportv1.Pending
+			status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionReady, corev1.ConditionFalse, podPendingReason, fmt.Sprintf("exporter pod %s is not ready", pod.Name)))
+		}
+	}
+
+	if !reflect.DeepEqual(vmExport, vmExportCopy) {
+		if _, err := ctrl.Client.VirtualMachineExport(vmExportCopy.Namespace).Update(context.Background(), vmExportCopy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updatePushStatus reflects the state of the pod pushing the containerDisk images
+func updatePushStatus(status *exportv1.VirtualMachineExportStatus, pod *corev1.Pod) {
+	message := terminationMessage(pod)
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		var images []exportv1.VirtualMachineExportContainerDiskImage
+		if err := json.Unmarshal([]byte(message), &images); err != nil {
+			status.Phase = exportv1.Failed
+			status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionReady, corev1.ConditionFalse, pushFailedReason,
+				fmt.Sprintf("invalid result of pod %s: %v", pod.Name, err)))
+			return
+		}
+		status.Phase = exportv1.Succeeded
+		status.ContainerDisks = images
+		status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionReady, corev1.ConditionTrue, pushSucceededReason, ""))
+	case corev1.PodFailed:
+		if message == "" {
+			message = fmt.Sprintf("pod %s failed", pod.Name)
+		}
+		status.Phase = exportv1.Failed
+		status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionReady, corev1.ConditionFalse, pushFailedReason, message))
+	default:
+		status.Phase = exportv1.Pending
+		status.Conditions = updateCondition(status.Conditions, newCondition(exportv1.ConditionReady, corev1.ConditionFalse, podPendingReason,
+			fmt.Sprintf("pod %s is pushing the containerDisk images", pod.Name)))
+	}
+}
+
+func terminationMessage(pod *corev1.Pod) string {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == exporterContainerName && containerStatus.State.Terminated != nil {
+			return containerStatus.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
+func (ctrl *VMExportController) internalLink(vmExport *exportv1.VirtualMachineExport, volumes []exportVolume, pod *corev1.Pod) *exportv1.VirtualMachineExportLink {
+	host := fmt.Sprintf("https://%s.%s.svc", exporterName(vmExport), vmExport.Namespace)
+	link := &exportv1.VirtualMachineExportLink{
+		Cert: pod.Annotations[caCertAnnotation],
+	}
+
+	for _, volume := range volumes {
+		link.Volumes = append(link.Volumes, exportv1.VirtualMachineExportVolume{
+			Name: volume.name,
+			Formats: []exportv1.VirtualMachineExportVolumeFormat{
+				{Format: exportv1.KubeVirtRaw, Url: host + exportserver.RawURI(volume.name)},
+				{Format: exportv1.KubeVirtGz, Url: host + exportserver.GzipURI(volume.name)},
+				{Format: exportv1.KubeVirtQcow2, Url: host + exportserver.Qcow2URI(volume.name)},
+			},
+		})
+	}
+
+	return link
+}
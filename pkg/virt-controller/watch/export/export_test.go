@@ -0,0 +1,548 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	framework "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	exportv1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Export controller", func() {
+	const (
+		testNamespace = "default"
+		exportName    = "export"
+		pvcName       = "pvc"
+		vmName        = "testvm"
+	)
+
+	var (
+		vmAPIGroup = "kubevirt.io"
+		timeStamp  = metav1.Now()
+	)
+
+	timeFunc := func() *metav1.Time {
+		return &timeStamp
+	}
+
+	createExport := func(source corev1.TypedLocalObjectReference) *exportv1.VirtualMachineExport {
+		return &exportv1.VirtualMachineExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      exportName,
+				Namespace: testNamespace,
+				UID:       "uid",
+			},
+			Spec: exportv1.VirtualMachineExportSpec{
+				Source:         source,
+				TokenSecretRef: "token",
+			},
+		}
+	}
+
+	createPVCExport := func() *exportv1.VirtualMachineExport {
+		return createExport(corev1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: pvcName,
+		})
+	}
+
+	createVMExport := func() *exportv1.VirtualMachineExport {
+		return createExport(corev1.TypedLocalObjectReference{
+			APIGroup: &vmAPIGroup,
+			Kind:     "VirtualMachine",
+			Name:     vmName,
+		})
+	}
+
+	createPVC := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+		}
+	}
+
+	createVM := func() *v1.VirtualMachine {
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: testNamespace,
+			},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "disk0",
+								VolumeSource: v1.VolumeSource{
+									PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+										ClaimName: pvcName,
+									},
+								},
+							},
+							{
+								Name: "cloudinit",
+								VolumeSource: v1.VolumeSource{
+									CloudInitNoCloud: &v1.CloudInitNoCloudSource{
+										UserData: "#cloud-config",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	Context("One valid VirtualMachineExport controller given", func() {
+		var (
+			ctrl                      *gomock.Controller
+			vmExportInformer          cache.SharedIndexInformer
+			vmExportSource            *framework.FakeControllerSource
+			pvcInformer               cache.SharedIndexInformer
+			pvcSource                 *framework.FakeControllerSource
+			podInformer               cache.SharedIndexInformer
+			podSource                 *framework.FakeControllerSource
+			vmInformer                cache.SharedIndexInformer
+			vmSource                  *framework.FakeControllerSource
+			vmiInformer               cache.SharedIndexInformer
+			vmiSource                 *framework.FakeControllerSource
+			vmSnapshotInformer        cache.SharedIndexInformer
+			vmSnapshotContentInformer cache.SharedIndexInformer
+			stop                      chan struct{}
+			controller                *VMExportController
+			recorder                  *record.FakeRecorder
+			mockVMExportQueue         *testutils.MockWorkQueue
+			kubevirtClient            *kubevirtfake.Clientset
+			k8sClient                 *k8sfake.Clientset
+			createdPods               []*corev1.Pod
+			createdServices           []*corev1.Service
+			createdSecrets            []*corev1.Secret
+			updatedExports            []*exportv1.VirtualMachineExport
+		)
+
+		syncCaches := func(stop chan struct{}) {
+			go vmExportInformer.Run(stop)
+			go pvcInformer.Run(stop)
+			go podInformer.Run(stop)
+			go vmInformer.Run(stop)
+			go vmiInformer.Run(stop)
+			go vmSnapshotInformer.Run(stop)
+			go vmSnapshotContentInformer.Run(stop)
+			Expect(cache.WaitForCacheSync(
+				stop,
+				vmExportInformer.HasSynced,
+				pvcInformer.HasSynced,
+				podInformer.HasSynced,
+				vmInformer.HasSynced,
+				vmiInformer.HasSynced,
+				vmSnapshotInformer.HasSynced,
+				vmSnapshotContentInformer.HasSynced,
+			)).To(BeTrue())
+		}
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+
+			vmExportInformer, vmExportSource = testutils.NewFakeInformerWithIndexersFor(&exportv1.VirtualMachineExport{}, cache.Indexers{
+				cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+				"vm": func(obj interface{}) ([]string, error) {
+					vmExport := obj.(*exportv1.VirtualMachineExport)
+					if vmExport.Spec.Source.APIGroup != nil &&
+						*vmExport.Spec.Source.APIGroup == v1.GroupName &&
+						vmExport.Spec.Source.Kind == "VirtualMachine" {
+						return []string{vmExport.Spec.Source.Name}, nil
+					}
+					return nil, nil
+				},
+			})
+			pvcInformer, pvcSource = testutils.NewFakeInformerFor(&corev1.PersistentVolumeClaim{})
+			podInformer, podSource = testutils.NewFakeInformerFor(&corev1.Pod{})
+			vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+			vmSnapshotInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
+			vmSnapshotContentInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
+
+			recorder = record.NewFakeRecorder(100)
+
+			controller = &VMExportController{
+				Client:                    virtClient,
+				ExporterImage:             "virt-launcher",
+				VMExportInformer:          vmExportInformer,
+				PVCInformer:               pvcInformer,
+				PodInformer:               podInformer,
+				VMInformer:                vmInformer,
+				VMIInformer:               vmiInformer,
+				VMSnapshotInformer:        vmSnapshotInformer,
+				VMSnapshotContentInformer: vmSnapshotContentInformer,
+				Recorder:                  recorder,
+			}
+			controller.Init()
+
+			// Wrap our workqueue to have a way to detect when we are done processing updates
+			mockVMExportQueue = testutils.NewMockWorkQueue(controller.vmExportQueue)
+			controller.vmExportQueue = mockVMExportQueue
+
+			createdPods = nil
+			createdServices = nil
+			createdSecrets = nil
+			updatedExports = nil
+
+			kubevirtClient = kubevirtfake.NewSimpleClientset()
+			virtClient.EXPECT().VirtualMachineExport(testNamespace).
+				Return(kubevirtClient.ExportV1alpha1().VirtualMachineExports(testNamespace)).AnyTimes()
+
+			k8sClient = k8sfake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+			k8sClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action).To(BeNil())
+				return true, nil, nil
+			})
+			k8sClient.Fake.PrependReactor("create", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				create, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+
+				switch o := create.GetObject().(type) {
+				case *corev1.Pod:
+					createdPods = append(createdPods, o)
+				case *corev1.Service:
+					createdServices = append(createdServices, o)
+				case *corev1.Secret:
+					createdSecrets = append(createdSecrets, o)
+				default:
+					Fail("unexpected create")
+				}
+
+				return true, create.GetObject(), nil
+			})
+			kubevirtClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action).To(BeNil())
+				return true, nil, nil
+			})
+			kubevirtClient.Fake.PrependReactor("update", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				update, ok := action.(testing.UpdateAction)
+				Expect(ok).To(BeTrue())
+
+				updatedExports = append(updatedExports, update.GetObject().(*exportv1.VirtualMachineExport))
+				return true, update.GetObject(), nil
+			})
+
+			currentTime = timeFunc
+		})
+
+		AfterEach(func() {
+			close(stop)
+		})
+
+		addVirtualMachineExport := func(vmExport *exportv1.VirtualMachineExport) {
+			syncCaches(stop)
+			mockVMExportQueue.ExpectAdds(1)
+			vmExportSource.Add(vmExport)
+			mockVMExportQueue.Wait()
+		}
+
+		expectCondition := func(vmExport *exportv1.VirtualMachineExport, t exportv1.ConditionType, status corev1.ConditionStatus, reason string) {
+			for _, c := range vmExport.Status.Conditions {
+				if c.Type == t {
+					Expect(c.Status).To(Equal(status))
+					Expect(c.Reason).To(Equal(reason))
+					return
+				}
+			}
+			Fail("condition not found")
+		}
+
+		It("should wait for a missing PVC", func() {
+			addVirtualMachineExport(createPVCExport())
+			controller.processVMExportWorkItem()
+
+			Expect(createdPods).To(BeEmpty())
+			Expect(updatedExports).To(HaveLen(1))
+			Expect(updatedExports[0].Status.Phase).To(Equal(exportv1.Pending))
+			expectCondition(updatedExports[0], exportv1.ConditionPVC, corev1.ConditionFalse, sourceNotFoundReason)
+			expectCondition(updatedExports[0], exportv1.ConditionReady, corev1.ConditionFalse, sourceNotFoundReason)
+		})
+
+		It("should create the exporter for a PVC", func() {
+			pvcSource.Add(createPVC(pvcName))
+			addVirtualMachineExport(createPVCExport())
+			controller.processVMExportWorkItem()
+
+			Expect(createdSecrets).To(HaveLen(1))
+			Expect(createdSecrets[0].Name).To(Equal("virt-export-export-certs"))
+			Expect(createdSecrets[0].Data).To(HaveKey(corev1.TLSCertKey))
+			Expect(createdSecrets[0].Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+
+			Expect(createdServices).To(HaveLen(1))
+			Expect(createdServices[0].Name).To(Equal("virt-export-export"))
+			Expect(createdServices[0].Spec.Selector).To(HaveKeyWithValue(exportNameLabel, exportName))
+
+			Expect(createdPods).To(HaveLen(1))
+			pod := createdPods[0]
+			Expect(pod.Name).To(Equal("virt-export-export"))
+			Expect(pod.Labels).To(HaveKeyWithValue(exportNameLabel, exportName))
+			Expect(pod.Annotations[caCertAnnotation]).To(ContainSubstring("BEGIN CERTIFICATE"))
+			Expect(pod.Spec.Containers[0].Image).To(Equal("virt-launcher"))
+			Expect(pod.Spec.Containers[0].Args).To(ContainElement("pvc=/export-volumes/pvc/disk.img"))
+			Expect(pod.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: "volume-pvc",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvcName,
+						ReadOnly:  true,
+					},
+				},
+			}))
+			Expect(recorder.Events).To(HaveLen(1))
+
+			Expect(updatedExports).To(HaveLen(1))
+			status := updatedExports[0].Status
+			Expect(status.Phase).To(Equal(exportv1.Pending))
+			Expect(status.ServiceName).To(Equal("virt-export-export"))
+			Expect(status.Links.Internal.Cert).To(Equal(pod.Annotations[caCertAnnotation]))
+			Expect(status.Links.Internal.Volumes).To(HaveLen(1))
+			Expect(status.Links.Internal.Volumes[0].Formats).To(ContainElement(exportv1.VirtualMachineExportVolumeFormat{
+				Format: exportv1.KubeVirtRaw,
+				Url:    "https://virt-export-export.default.svc/volumes/pvc/disk.img",
+			}))
+		})
+
+		It("should use the device of a block PVC", func() {
+			pvc := createPVC(pvcName)
+			block := corev1.PersistentVolumeBlock
+			pvc.Spec.VolumeMode = &block
+			pvcSource.Add(pvc)
+			addVirtualMachineExport(createPVCExport())
+			controller.processVMExportWorkItem()
+
+			Expect(createdPods).To(HaveLen(1))
+			container := createdPods[0].Spec.Containers[0]
+			Expect(container.VolumeDevices).To(ConsistOf(corev1.VolumeDevice{
+				Name:       "volume-pvc",
+				DevicePath: "/export-volumes/pvc",
+			}))
+			Expect(container.Args).To(ContainElement("pvc=/export-volumes/pvc"))
+		})
+
+		It("should skip a PVC that is in use", func() {
+			pvcSource.Add(createPVC(pvcName))
+			podSource.Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "user",
+					Namespace: testNamespace,
+				},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "disk",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvcName,
+								},
+							},
+						},
+					},
+				},
+			})
+			addVirtualMachineExport(createPVCExport())
+			controller.processVMExportWorkItem()
+
+			Expect(createdPods).To(BeEmpty())
+			Expect(updatedExports).To(HaveLen(1))
+			Expect(updatedExports[0].Status.Phase).To(Equal(exportv1.Skipped))
+			expectCondition(updatedExports[0], exportv1.ConditionReady, corev1.ConditionFalse, sourceInUseReason)
+		})
+
+		It("should be ready when the exporter pod is ready", func() {
+			vmExport := createPVCExport()
+			pvcSource.Add(createPVC(pvcName))
+			pod := controller.newExporterPod(vmExport, []exportVolume{{name: pvcName, pvc: createPVC(pvcName)}}, "cert")
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.Conditions = []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			}
+			podSource.Add(pod)
+			addVirtualMachineExport(vmExport)
+			controller.processVMExportWorkItem()
+
+			Expect(createdPods).To(BeEmpty())
+			Expect(updatedExports).To(HaveLen(1))
+			Expect(updatedExports[0].Status.Phase).To(Equal(exportv1.Ready))
+			Expect(updatedExports[0].Status.Links.Internal.Cert).To(Equal("cert"))
+			expectCondition(updatedExports[0], exportv1.ConditionReady, corev1.ConditionTrue, podReadyReason)
+		})
+
+		It("should export the PVCs of a stopped VM", func() {
+			pvcSource.Add(createPVC(pvcName))
+			vmSource.Add(createVM())
+			addVirtualMachineExport(createVMExport())
+			controller.processVMExportWorkItem()
+
+			Expect(createdPods).To(HaveLen(1))
+			Expect(createdPods[0].Spec.Containers[0].Args).To(ContainElement("disk0=/export-volumes/disk0/disk.img"))
+			Expect(updatedExports).To(HaveLen(1))
+			Expect(updatedExports[0].Status.Links.Internal.Volumes).To(HaveLen(1))
+			Expect(updatedExports[0].Status.Links.Internal.Volumes[0].Name).To(Equal("disk0"))
+		})
+
+		createPushExport := func() *exportv1.VirtualMachineExport {
+			vmExport := createPVCExport()
+			vmExport.Spec.ContainerDisk = &exportv1.VirtualMachineExportContainerDisk{
+				Image:     "registry.example.com/images/fedora:36",
+				SecretRef: "registry-secret",
+			}
+			return vmExport
+		}
+
+		It("should create the pusher for a containerDisk export", func() {
+			pvcSource.Add(createPVC(pvcName))
+			addVirtualMachineExport(createPushExport())
+			controller.processVMExportWorkItem()
+
+			Expect(createdSecrets).To(BeEmpty())
+			Expect(createdServices).To(BeEmpty())
+			Expect(createdPods).To(HaveLen(1))
+			pod := createdPods[0]
+			Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			Expect(pod.Spec.Containers[0].Args).To(ContainElements(
+				"pvc=registry.example.com/images/fedora:36",
+				"/registry-auth/.dockerconfigjson",
+				"pvc=/export-volumes/pvc/disk.img",
+			))
+			Expect(pod.Spec.Containers[0].Args).ToNot(ContainElement("--insecure-skip-tls-verify"))
+			Expect(pod.Spec.Containers[0].ReadinessProbe).To(BeNil())
+			Expect(pod.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: registryAuthVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "registry-secret",
+						Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: corev1.DockerConfigJsonKey}},
+					},
+				},
+			}))
+
+			Expect(updatedExports).To(HaveLen(1))
+			Expect(updatedExports[0].Status.Phase).To(Equal(exportv1.Pending))
+			Expect(updatedExports[0].Status.Links).To(BeNil())
+			Expect(updatedExports[0].Status.ServiceName).To(BeEmpty())
+			expectCondition(updatedExports[0], exportv1.ConditionReady, corev1.ConditionFalse, podPendingReason)
+		})
+
+		It("should size the scratch space of the pusher for the largest volume", func() {
+			small := createPVC("small")
+			small.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}
+			large := createPVC("large")
+			large.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")}
+			large.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+
+			pod := controller.newPusherPod(createPushExport(), []exportVolume{{name: "small", pvc: small}, {name: "large", pvc: large}})
+
+			expectedSize := resource.MustParse("21Gi")
+			Expect(pod.Spec.Volumes[0].Name).To(Equal(scratchVolumeName))
+			Expect(pod.Spec.Volumes[0].EmptyDir.SizeLimit.Cmp(expectedSize)).To(BeZero())
+			request := pod.Spec.Containers[0].Resources.Requests[corev1.ResourceEphemeralStorage]
+			Expect(request.Cmp(expectedSize)).To(BeZero())
+		})
+
+		table.DescribeTable("should suffix the tag with the volume if more than one volume is pushed", func(image string, volumeCount int, expected string) {
+			Expect(containerDiskImage(image, "disk0", volumeCount)).To(Equal(expected))
+		},
+			table.Entry("with a single volume", "registry.example.com:5000/fedora", 1, "registry.example.com:5000/fedora"),
+			table.Entry("with a tag", "registry.example.com:5000/fedora:36", 2, "registry.example.com:5000/fedora:36-disk0"),
+			table.Entry("without a tag", "registry.example.com:5000/fedora", 2, "registry.example.com:5000/fedora:latest-disk0"),
+		)
+
+		table.DescribeTable("should reflect the result of the pusher", func(phase corev1.PodPhase, message string, expectedPhase exportv1.VirtualMachineExportPhase, expectedReason string) {
+			vmExport := createPushExport()
+			pvcSource.Add(createPVC(pvcName))
+			pod := controller.newPusherPod(vmExport, []exportVolume{{name: pvcName, pvc: createPVC(pvcName)}})
+			pod.Status.Phase = phase
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name: exporterContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: message},
+					},
+				},
+			}
+			podSource.Add(pod)
+			addVirtualMachineExport(vmExport)
+			controller.processVMExportWorkItem()
+
+			Expect(createdPods).To(BeEmpty())
+			Expect(updatedExports).To(HaveLen(1))
+			Expect(updatedExports[0].Status.Phase).To(Equal(expectedPhase))
+			if expectedPhase == exportv1.Succeeded {
+				expectCondition(updatedExports[0], exportv1.ConditionReady, corev1.ConditionTrue, expectedReason)
+				Expect(updatedExports[0].Status.ContainerDisks).To(ConsistOf(exportv1.VirtualMachineExportContainerDiskImage{
+					Name:  pvcName,
+					Image: "registry.example.com/images/fedora@sha256:1234",
+				}))
+			} else {
+				expectCondition(updatedExports[0], exportv1.ConditionReady, corev1.ConditionFalse, expectedReason)
+				Expect(updatedExports[0].Status.ContainerDisks).To(BeEmpty())
+			}
+		},
+			table.Entry("when the images were pushed", corev1.PodSucceeded,
+				`[{"name": "pvc", "image": "registry.example.com/images/fedora@sha256:1234"}]`, exportv1.Succeeded, pushSucceededReason),
+			table.Entry("when pushing failed", corev1.PodFailed, "unauthorized", exportv1.Failed, pushFailedReason),
+			table.Entry("when the result is invalid", corev1.PodSucceeded, "garbage", exportv1.Failed, pushFailedReason),
+		)
+
+		It("should skip a running VM", func() {
+			pvcSource.Add(createPVC(pvcName))
+			vmSource.Add(createVM())
+			vmiSource.Add(&v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      vmName,
+					Namespace: testNamespace,
+				},
+			})
+			addVirtualMachineExport(createVMExport())
+			controller.processVMExportWorkItem()
+
+			Expect(createdPods).To(BeEmpty())
+			Expect(updatedExports).To(HaveLen(1))
+			Expect(updatedExports[0].Status.Phase).To(Equal(exportv1.Skipped))
+		})
+	})
+})
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -355,7 +356,7 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 				migrationCopy.Status.Phase = virtv1.MigrationRunning
 			}
 		case virtv1.MigrationRunning:
-			if vmi.Status.MigrationState.Completed {
+			if vmi.Status.MigrationState.Completed && volumesMigrated(migration, vmi) {
 				migrationCopy.Status.Phase = virtv1.MigrationSucceeded
 				c.recorder.Eventf(migration, k8sv1.EventTypeNormal, SuccessfulMigrationReason, "Source node reported migration succeeded")
 				log.Log.Object(migration).Infof("VMI reported migration succeeded.")
@@ -380,6 +381,12 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 
 func (c *MigrationController) createTargetPod(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
 
+	if len(migration.Spec.Volumes) > 0 {
+		// the target pod mounts the claims the migrated volumes are copied to
+		vmi = vmi.DeepCopy()
+		vmi.Spec.Volumes = migrations.ReplaceMigratedVolumes(vmi.Spec.Volumes, migration.Spec.Volumes)
+	}
+
 	templatePod, err := c.templateService.RenderLaunchManifest(vmi)
 	if err != nil {
 		return fmt.Errorf("failed to render launch manifest: %v", err)
@@ -461,6 +468,10 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 		return nil
 	}
 
+	if migration.Status.Phase == virtv1.MigrationRunning && migrationCompleted(migration, vmi) && !volumesMigrated(migration, vmi) {
+		return c.switchMigratedVolumes(migration, vmi)
+	}
+
 	vmiDeleted := vmi == nil || vmi.DeletionTimestamp != nil
 	migrationDone := vmi.Status.MigrationState != nil && vmi.Status.MigrationState.MigrationUID == migration.UID && vmi.Status.MigrationState.EndTimestamp != nil
 
@@ -532,10 +543,11 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 		if podExists && !podIsDown(pod) {
 			vmiCopy := vmi.DeepCopy()
 			vmiCopy.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{
-				MigrationUID: migration.UID,
-				TargetNode:   pod.Spec.NodeName,
-				SourceNode:   vmi.Status.NodeName,
-				TargetPod:    pod.Name,
+				MigrationUID:    migration.UID,
+				TargetNode:      pod.Spec.NodeName,
+				SourceNode:      vmi.Status.NodeName,
+				TargetPod:       pod.Name,
+				MigratedVolumes: migration.Spec.Volumes,
 			}
 
 			// By setting this label, virt-handler on the target node will receive
@@ -556,6 +568,59 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 	return nil
 }
 
+func migrationCompleted(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) bool {
+	return vmi.Status.MigrationState != nil &&
+		vmi.Status.MigrationState.MigrationUID == migration.UID &&
+		vmi.Status.MigrationState.Completed &&
+		!vmi.Status.MigrationState.Failed
+}
+
+// volumesMigrated returns true once the VMI uses the destination claims of the migrated volumes
+func volumesMigrated(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) bool {
+	return len(migration.Spec.Volumes) == 0 ||
+		equality.Semantic.DeepEqual(vmi.Spec.Volumes, migrations.ReplaceMigratedVolumes(vmi.Spec.Volumes, migration.Spec.Volumes))
+}
+
+// switchMigratedVolumes makes the VMI and its VirtualMachine use the claims the volumes were copied to. The
+// VirtualMachine is updated first, so that it is never left behind once the VMI uses the destination claims.
+func (c *MigrationController) switchMigratedVolumes(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
+	if owner := v1.GetControllerOf(vmi); owner != nil && owner.Kind == virtv1.VirtualMachineGroupVersionKind.Kind {
+		vm, err := c.clientset.VirtualMachine(vmi.Namespace).Get(owner.Name, &v1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get the VM of the migrated VMI: %v", err)
+		}
+		if vm.UID == owner.UID && vm.Spec.Template != nil {
+			volumes := migrations.ReplaceMigratedVolumes(vm.Spec.Template.Spec.Volumes, migration.Spec.Volumes)
+			if !equality.Semantic.DeepEqual(vm.Spec.Template.Spec.Volumes, volumes) {
+				vm = vm.DeepCopy()
+				vm.Spec.Template.Spec.Volumes = volumes
+				if _, err := c.clientset.VirtualMachine(vm.Namespace).Update(vm); err != nil {
+					c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrateVolumesReason, "Failed to switch the volumes of the VM to the destination claims: %v", err)
+					return err
+				}
+			}
+		}
+	}
+
+	oldVolumes, err := json.Marshal(vmi.Spec.Volumes)
+	if err != nil {
+		return err
+	}
+	newVolumes, err := json.Marshal(migrations.ReplaceMigratedVolumes(vmi.Spec.Volumes, migration.Spec.Volumes))
+	if err != nil {
+		return err
+	}
+	test := fmt.Sprintf(`{ "op": "test", "path": "/spec/volumes", "value": %s }`, string(oldVolumes))
+	patch := fmt.Sprintf(`{ "op": "replace", "path": "/spec/volumes", "value": %s }`, string(newVolumes))
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(fmt.Sprintf("[ %s, %s ]", test, patch)))
+	if err != nil {
+		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrateVolumesReason, "Failed to switch the volumes of the VMI to the destination claims: %v", err)
+		return err
+	}
+	c.recorder.Eventf(migration, k8sv1.EventTypeNormal, SuccessfulMigrateVolumesReason, "Switched the migrated volumes to the destination claims")
+	return nil
+}
+
 func (c *MigrationController) listMatchingTargetPods(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) ([]*k8sv1.Pod, error) {
 
 	selector, err := v1.LabelSelectorAsSelector(&v1.LabelSelector{
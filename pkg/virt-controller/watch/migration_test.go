@@ -537,6 +537,26 @@ var _ = Describe("Migration watcher", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
 		})
+		It("should hand the migrated volumes over to virt-handler", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduled)
+			migration.Spec.Volumes = []v1.MigratedVolume{{VolumeName: "rootdisk", DestinationClaimName: "destination-pvc"}}
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodPending)
+			pod.Spec.NodeName = "node01"
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(arg interface{}) (interface{}, interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.MigrationState.MigratedVolumes).To(Equal(migration.Spec.Volumes))
+				return arg, nil
+			})
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
+		})
 		It("should hand pod over to target virt-handler with migration config", func() {
 			vmi := newVirtualMachine("testvmi", v1.Running)
 			vmi.Status.NodeName = "node02"
@@ -666,6 +686,81 @@ var _ = Describe("Migration watcher", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, SuccessfulMigrationReason)
 		})
+		Context("with migrated volumes", func() {
+			newVolumeMigration := func(claimName string) (*v1.VirtualMachineInstance, *v1.VirtualMachineInstanceMigration) {
+				vmi := newVirtualMachine("testvmi", v1.Running)
+				vmi.Status.NodeName = "node02"
+				vmi.Spec.Volumes = []v1.Volume{
+					{
+						Name: "rootdisk",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+						},
+					},
+				}
+				migration := newMigration("testmigration", vmi.Name, v1.MigrationRunning)
+				migration.Spec.Volumes = []v1.MigratedVolume{{VolumeName: "rootdisk", DestinationClaimName: "destination-pvc"}}
+				vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+					MigrationUID:      migration.UID,
+					TargetNode:        "node01",
+					SourceNode:        "node02",
+					TargetNodeAddress: "10.10.10.10:1234",
+					StartTimestamp:    now(),
+					EndTimestamp:      now(),
+					Completed:         true,
+					MigratedVolumes:   migration.Spec.Volumes,
+				}
+				return vmi, migration
+			}
+
+			It("should switch the VM and the VMI to the destination claims", func() {
+				vmi, migration := newVolumeMigration("source-pvc")
+				vm := &v1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: vmi.Namespace, UID: "vm-uid"},
+					Spec: v1.VirtualMachineSpec{
+						Template: &v1.VirtualMachineInstanceTemplateSpec{
+							Spec: *vmi.Spec.DeepCopy(),
+						},
+					},
+				}
+				vmi.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)}
+				pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+				pod.Spec.NodeName = "node01"
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+				podFeeder.Add(pod)
+
+				vmInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
+				virtClient.EXPECT().VirtualMachine(k8sv1.NamespaceDefault).Return(vmInterface).AnyTimes()
+				vmInterface.EXPECT().Get(vm.Name, gomock.Any()).Return(vm, nil)
+				vmInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(arg interface{}) (interface{}, interface{}) {
+					Expect(arg.(*v1.VirtualMachine).Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("destination-pvc"))
+					return arg, nil
+				})
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, _ types.PatchType, data []byte) (*v1.VirtualMachineInstance, error) {
+					Expect(string(data)).To(ContainSubstring(`"op": "test", "path": "/spec/volumes"`))
+					Expect(string(data)).To(ContainSubstring(`"claimName":"destination-pvc"`))
+					return vmi, nil
+				})
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulMigrateVolumesReason)
+			})
+
+			It("should transition to completed phase once the VMI uses the destination claims", func() {
+				vmi, migration := newVolumeMigration("destination-pvc")
+				pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+				pod.Spec.NodeName = "node01"
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+				podFeeder.Add(pod)
+
+				shouldExpectMigrationCompletedState(migration)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulMigrationReason)
+			})
+		})
 		It("should delete itself if VMI no longer exists", func() {
 			migration := newMigration("testmigration", "somevmi", v1.MigrationRunning)
 			addMigration(migration)
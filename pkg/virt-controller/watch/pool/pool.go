@@ -0,0 +1,637 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package pool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+// Reasons for pool events
+const (
+	// FailedCreateVirtualMachineReason is added in an event and in a pool condition
+	// when a VirtualMachine of the pool failed to be created.
+	FailedCreateVirtualMachineReason = "FailedCreate"
+	// SuccessfulCreateVirtualMachineReason is added in an event when a VirtualMachine of the pool
+	// is successfully created.
+	SuccessfulCreateVirtualMachineReason = "SuccessfulCreate"
+	// FailedDeleteVirtualMachineReason is added in an event and in a pool condition
+	// when a VirtualMachine of the pool failed to be deleted.
+	FailedDeleteVirtualMachineReason = "FailedDelete"
+	// SuccessfulDeleteVirtualMachineReason is added in an event when a VirtualMachine of the pool
+	// is successfully deleted.
+	SuccessfulDeleteVirtualMachineReason = "SuccessfulDelete"
+	// SuccessfulPausedPoolReason is added in an event when the pool discovered that it
+	// should be paused.
+	SuccessfulPausedPoolReason = "SuccessfulPaused"
+	// SuccessfulResumedPoolReason is added in an event when the pool discovered that it
+	// should be resumed.
+	SuccessfulResumedPoolReason = "SuccessfulResumed"
+)
+
+// PoolController keeps the number of VirtualMachines of a VirtualMachinePool
+// in line with the requested replicas
+type PoolController struct {
+	Client kubecli.KubevirtClient
+
+	VMPoolInformer cache.SharedIndexInformer
+	VMInformer     cache.SharedIndexInformer
+
+	Recorder      record.EventRecorder
+	BurstReplicas uint
+
+	queue        workqueue.RateLimitingInterface
+	expectations *controller.UIDTrackingControllerExpectations
+}
+
+// Init initializes the pool controller
+func (c *PoolController) Init() {
+	c.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "pool-controller-vmpool")
+	c.expectations = controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations())
+
+	c.VMPoolInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueuePool,
+		DeleteFunc: c.enqueuePool,
+		UpdateFunc: func(oldObj, newObj interface{}) { c.enqueuePool(newObj) },
+	})
+
+	c.VMInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addVirtualMachine,
+		DeleteFunc: c.deleteVirtualMachine,
+		UpdateFunc: c.updateVirtualMachine,
+	})
+}
+
+// Run the controller
+func (c *PoolController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+	log.Log.Info("Starting pool controller.")
+
+	// Wait for cache sync before we start the controller
+	cache.WaitForCacheSync(stopCh, c.VMPoolInformer.HasSynced, c.VMInformer.HasSynced)
+
+	// Start the actual work
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping pool controller.")
+}
+
+func (c *PoolController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *PoolController) Execute() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+	if err := c.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("re-enqueuing VirtualMachinePool %v", key)
+		c.queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachinePool %v", key)
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *PoolController) execute(key string) error {
+	obj, exists, err := c.VMPoolInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		c.expectations.DeleteExpectations(key)
+		return nil
+	}
+	pool := obj.(*poolv1.VirtualMachinePool)
+
+	logger := log.Log.Object(pool)
+
+	if pool.Spec.VirtualMachineTemplate == nil || pool.Spec.Selector == nil {
+		logger.Error("Invalid pool spec, will not re-enqueue.")
+		return nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector)
+	if err != nil {
+		logger.Reason(err).Error("Invalid selector on pool, will not re-enqueue.")
+		return nil
+	}
+
+	if !selector.Matches(labels.Set(pool.Spec.VirtualMachineTemplate.ObjectMeta.Labels)) {
+		logger.Error("Selector does not match template labels, will not re-enqueue.")
+		return nil
+	}
+
+	needsSync := c.expectations.SatisfiedExpectations(key)
+
+	vms, err := c.listVMsFromPool(pool)
+	if err != nil {
+		logger.Reason(err).Error("Failed to fetch vms for namespace from cache.")
+		return err
+	}
+
+	var scaleErr error
+	if needsSync && !pool.Spec.Paused && pool.DeletionTimestamp == nil {
+		scaleErr = c.scale(pool, vms)
+		if scaleErr != nil {
+			logger.Reason(scaleErr).Error("Scaling the pool failed.")
+		}
+	}
+
+	if err := c.updateStatus(pool.DeepCopy(), vms, scaleErr); err != nil {
+		logger.Reason(err).Error("Updating the pool status failed.")
+		return err
+	}
+
+	return scaleErr
+}
+
+// listVMsFromPool returns the VirtualMachines of the namespace which are controlled by the pool and not being deleted
+func (c *PoolController) listVMsFromPool(pool *poolv1.VirtualMachinePool) ([]*virtv1.VirtualMachine, error) {
+	objs, err := c.VMInformer.GetIndexer().ByIndex(cache.NamespaceIndex, pool.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	vms := []*virtv1.VirtualMachine{}
+	for _, obj := range objs {
+		vm := obj.(*virtv1.VirtualMachine)
+		controllerRef := metav1.GetControllerOf(vm)
+		if controllerRef == nil || controllerRef.UID != pool.UID || vm.DeletionTimestamp != nil {
+			continue
+		}
+		vms = append(vms, vm)
+	}
+	return vms, nil
+}
+
+func (c *PoolController) scale(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) error {
+	diff := calcDiff(pool, vms)
+	if diff == 0 {
+		return nil
+	}
+
+	poolKey, err := controller.KeyFunc(pool)
+	if err != nil {
+		return err
+	}
+
+	// Make sure that we don't overload the cluster
+	diff = limit(diff, c.BurstReplicas)
+
+	// Every request can fail, give the channel enough room, to not block the go routines
+	errChan := make(chan error, abs(diff))
+	var wg sync.WaitGroup
+	wg.Add(abs(diff))
+
+	if diff > 0 {
+		// Delete the VirtualMachines with the highest indexes first, to keep the naming dense
+		sort.Slice(vms, func(i, j int) bool {
+			return vmIndex(pool, vms[i]) > vmIndex(pool, vms[j])
+		})
+		deleteCandidates := vms[0:diff]
+		c.expectations.ExpectDeletions(poolKey, vmKeys(deleteCandidates))
+		for _, vm := range deleteCandidates {
+			go func(vm *virtv1.VirtualMachine) {
+				defer wg.Done()
+				err := c.Client.VirtualMachine(pool.Namespace).Delete(vm.Name, &metav1.DeleteOptions{})
+				if err != nil {
+					// We can't observe a delete if it was not accepted by the server
+					c.expectations.DeletionObserved(poolKey, vmKey(vm))
+					c.Recorder.Eventf(pool, corev1.EventTypeWarning, FailedDeleteVirtualMachineReason, "Error deleting virtual machine %s: %v", vm.Name, err)
+					errChan <- err
+					return
+				}
+				c.Recorder.Eventf(pool, corev1.EventTypeNormal, SuccessfulDeleteVirtualMachineReason, "Deleted virtual machine %s", vm.Name)
+			}(vm)
+		}
+	} else {
+		indexes := c.freeIndexes(pool, abs(diff))
+		c.expectations.ExpectCreations(poolKey, len(indexes))
+		for _, idx := range indexes {
+			go func(idx int) {
+				defer wg.Done()
+				vm := newVMForIndex(pool, idx)
+				vm, err := c.Client.VirtualMachine(pool.Namespace).Create(vm)
+				if err != nil {
+					c.expectations.CreationObserved(poolKey)
+					c.Recorder.Eventf(pool, corev1.EventTypeWarning, FailedCreateVirtualMachineReason, "Error creating virtual machine: %v", err)
+					errChan <- err
+					return
+				}
+				c.Recorder.Eventf(pool, corev1.EventTypeNormal, SuccessfulCreateVirtualMachineReason, "Created virtual machine %s", vm.Name)
+			}(idx)
+		}
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errChan:
+		// Only return the first error which occurred, the others will most likely be equal errors
+		return err
+	default:
+	}
+	return nil
+}
+
+// vmName returns the stable name of the VirtualMachine with the given sequence index
+func vmName(pool *poolv1.VirtualMachinePool, idx int) string {
+	return fmt.Sprintf("%s-%d", pool.Name, idx)
+}
+
+// vmIndex returns the sequence index of a VirtualMachine of the pool, or -1 if the name does not carry one
+func vmIndex(pool *poolv1.VirtualMachinePool, vm *virtv1.VirtualMachine) int {
+	prefix := pool.Name + "-"
+	if !strings.HasPrefix(vm.Name, prefix) {
+		return -1
+	}
+	idx, err := strconv.Atoi(strings.TrimPrefix(vm.Name, prefix))
+	if err != nil || idx < 0 {
+		return -1
+	}
+	return idx
+}
+
+// freeIndexes returns the lowest count sequence indexes whose names are not taken by any VirtualMachine of the namespace
+func (c *PoolController) freeIndexes(pool *poolv1.VirtualMachinePool, count int) []int {
+	indexes := []int{}
+	for idx := 0; len(indexes) < count; idx++ {
+		_, exists, err := c.VMInformer.GetStore().GetByKey(pool.Namespace + "/" + vmName(pool, idx))
+		if err == nil && !exists {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes
+}
+
+// newVMForIndex creates the VirtualMachine with the given sequence index from the pool template
+func newVMForIndex(pool *poolv1.VirtualMachinePool, idx int) *virtv1.VirtualMachine {
+	template := pool.Spec.VirtualMachineTemplate
+	t := true
+
+	vm := &virtv1.VirtualMachine{
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *indexVMSpec(pool, idx),
+	}
+	vm.Name = vmName(pool, idx)
+	vm.GenerateName = ""
+	vm.Namespace = pool.Namespace
+	vm.ResourceVersion = ""
+	vm.UID = ""
+	vm.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion:         poolv1.SchemeGroupVersion.String(),
+		Kind:               "VirtualMachinePool",
+		Name:               pool.Name,
+		UID:                pool.UID,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}}
+	return vm
+}
+
+// indexVMSpec returns a copy of the template spec with the sequence index appended to the
+// names of the objects which have to be unique for every VirtualMachine of the pool
+func indexVMSpec(pool *poolv1.VirtualMachinePool, idx int) *virtv1.VirtualMachineSpec {
+	spec := pool.Spec.VirtualMachineTemplate.Spec.DeepCopy()
+	indexName := func(name string) string {
+		return fmt.Sprintf("%s-%d", name, idx)
+	}
+
+	appendIndexToConfigMapRefs := false
+	appendIndexToSecretRefs := false
+	if pool.Spec.NameGeneration != nil {
+		if pool.Spec.NameGeneration.AppendIndexToConfigMapRefs != nil {
+			appendIndexToConfigMapRefs = *pool.Spec.NameGeneration.AppendIndexToConfigMapRefs
+		}
+		if pool.Spec.NameGeneration.AppendIndexToSecretRefs != nil {
+			appendIndexToSecretRefs = *pool.Spec.NameGeneration.AppendIndexToSecretRefs
+		}
+	}
+
+	// DataVolumes created from the templates always have to be unique
+	dvNames := map[string]string{}
+	for i := range spec.DataVolumeTemplates {
+		name := spec.DataVolumeTemplates[i].Name
+		dvNames[name] = indexName(name)
+		spec.DataVolumeTemplates[i].Name = dvNames[name]
+	}
+
+	if spec.Template == nil {
+		return spec
+	}
+
+	for i := range spec.Template.Spec.Volumes {
+		source := &spec.Template.Spec.Volumes[i].VolumeSource
+		switch {
+		case source.DataVolume != nil:
+			if name, ok := dvNames[source.DataVolume.Name]; ok {
+				source.DataVolume.Name = name
+			}
+		case source.PersistentVolumeClaim != nil:
+			if name, ok := dvNames[source.PersistentVolumeClaim.ClaimName]; ok {
+				source.PersistentVolumeClaim.ClaimName = name
+			}
+		case source.ConfigMap != nil:
+			if appendIndexToConfigMapRefs {
+				source.ConfigMap.Name = indexName(source.ConfigMap.Name)
+			}
+		case source.Secret != nil:
+			if appendIndexToSecretRefs {
+				source.Secret.SecretName = indexName(source.Secret.SecretName)
+			}
+		case source.CloudInitNoCloud != nil:
+			if appendIndexToSecretRefs {
+				indexSecretRef(source.CloudInitNoCloud.UserDataSecretRef, indexName)
+				indexSecretRef(source.CloudInitNoCloud.NetworkDataSecretRef, indexName)
+			}
+		case source.CloudInitConfigDrive != nil:
+			if appendIndexToSecretRefs {
+				indexSecretRef(source.CloudInitConfigDrive.UserDataSecretRef, indexName)
+				indexSecretRef(source.CloudInitConfigDrive.NetworkDataSecretRef, indexName)
+			}
+		}
+	}
+
+	return spec
+}
+
+func indexSecretRef(ref *corev1.LocalObjectReference, indexName func(string) string) {
+	if ref != nil {
+		ref.Name = indexName(ref.Name)
+	}
+}
+
+func calcDiff(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) int {
+	wantedReplicas := int32(1)
+	if pool.Spec.Replicas != nil {
+		wantedReplicas = *pool.Spec.Replicas
+	}
+
+	return len(vms) - int(wantedReplicas)
+}
+
+func (c *PoolController) updateStatus(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine, scaleErr error) error {
+	labelSelector, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	readyReplicas := int32(0)
+	for _, vm := range vms {
+		if vm.Status.Ready {
+			readyReplicas++
+		}
+	}
+
+	oldStatus := pool.Status.DeepCopy()
+	wasPaused := hasCondition(pool, poolv1.VirtualMachinePoolReplicaPaused)
+
+	pool.Status.LabelSelector = labelSelector.String()
+	pool.Status.Replicas = int32(len(vms))
+	pool.Status.ReadyReplicas = readyReplicas
+
+	if pool.Spec.Paused && !wasPaused {
+		pool.Status.Conditions = append(pool.Status.Conditions, poolv1.VirtualMachinePoolCondition{
+			Type:               poolv1.VirtualMachinePoolReplicaPaused,
+			Reason:             "Paused",
+			Message:            "Controller got paused",
+			LastTransitionTime: metav1.Now(),
+			Status:             corev1.ConditionTrue,
+		})
+	} else if !pool.Spec.Paused && wasPaused {
+		removeCondition(pool, poolv1.VirtualMachinePoolReplicaPaused)
+	}
+
+	if scaleErr != nil && !hasCondition(pool, poolv1.VirtualMachinePoolReplicaFailure) {
+		reason := FailedDeleteVirtualMachineReason
+		if calcDiff(pool, vms) < 0 {
+			reason = FailedCreateVirtualMachineReason
+		}
+		pool.Status.Conditions = append(pool.Status.Conditions, poolv1.VirtualMachinePoolCondition{
+			Type:               poolv1.VirtualMachinePoolReplicaFailure,
+			Reason:             reason,
+			Message:            scaleErr.Error(),
+			LastTransitionTime: metav1.Now(),
+			Status:             corev1.ConditionTrue,
+		})
+	} else if scaleErr == nil && hasCondition(pool, poolv1.VirtualMachinePoolReplicaFailure) {
+		removeCondition(pool, poolv1.VirtualMachinePoolReplicaFailure)
+	}
+
+	if equality.Semantic.DeepEqual(oldStatus, &pool.Status) {
+		return nil
+	}
+
+	_, err = c.Client.VirtualMachinePool(pool.Namespace).UpdateStatus(context.Background(), pool, metav1.UpdateOptions{})
+	if err != nil {
+		if errors.IsConflict(err) {
+			// the next update of the pool re-enqueues it
+			return nil
+		}
+		return err
+	}
+
+	if pool.Spec.Paused && !wasPaused {
+		c.Recorder.Eventf(pool, corev1.EventTypeNormal, SuccessfulPausedPoolReason, "Paused")
+	} else if !pool.Spec.Paused && wasPaused {
+		c.Recorder.Eventf(pool, corev1.EventTypeNormal, SuccessfulResumedPoolReason, "Resumed")
+	}
+
+	return nil
+}
+
+func hasCondition(pool *poolv1.VirtualMachinePool, cond poolv1.VirtualMachinePoolConditionType) bool {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == cond {
+			return true
+		}
+	}
+	return false
+}
+
+func removeCondition(pool *poolv1.VirtualMachinePool, cond poolv1.VirtualMachinePoolConditionType) {
+	var conds []poolv1.VirtualMachinePoolCondition
+	for _, c := range pool.Status.Conditions {
+		if c.Type == cond {
+			continue
+		}
+		conds = append(conds, c)
+	}
+	pool.Status.Conditions = conds
+}
+
+// When a VirtualMachine is created, enqueue the pool that manages it and update its expectations.
+func (c *PoolController) addVirtualMachine(obj interface{}) {
+	vm := obj.(*virtv1.VirtualMachine)
+
+	if vm.DeletionTimestamp != nil {
+		c.deleteVirtualMachine(vm)
+		return
+	}
+
+	pool := c.resolveControllerRef(vm)
+	if pool == nil {
+		return
+	}
+	poolKey, err := controller.KeyFunc(pool)
+	if err != nil {
+		return
+	}
+	c.expectations.CreationObserved(poolKey)
+	c.enqueuePool(pool)
+}
+
+func (c *PoolController) updateVirtualMachine(old, cur interface{}) {
+	curVM := cur.(*virtv1.VirtualMachine)
+	oldVM := old.(*virtv1.VirtualMachine)
+	if curVM.ResourceVersion == oldVM.ResourceVersion {
+		return
+	}
+
+	if curVM.DeletionTimestamp != nil {
+		c.deleteVirtualMachine(curVM)
+		return
+	}
+
+	if pool := c.resolveControllerRef(curVM); pool != nil {
+		c.enqueuePool(pool)
+	}
+}
+
+// When a VirtualMachine is deleted, enqueue the pool that manages it and update its expectations.
+// obj could be a *virtv1.VirtualMachine, or a DeletionFinalStateUnknown marker item.
+func (c *PoolController) deleteVirtualMachine(obj interface{}) {
+	vm, ok := obj.(*virtv1.VirtualMachine)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		vm, ok = tombstone.Obj.(*virtv1.VirtualMachine)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("tombstone contained object that is not a vm %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+
+	pool := c.resolveControllerRef(vm)
+	if pool == nil {
+		return
+	}
+	poolKey, err := controller.KeyFunc(pool)
+	if err != nil {
+		return
+	}
+	c.expectations.DeletionObserved(poolKey, vmKey(vm))
+	c.enqueuePool(pool)
+}
+
+// resolveControllerRef returns the pool referenced by the ControllerRef of the VirtualMachine,
+// or nil if the ControllerRef could not be resolved to a matching pool.
+func (c *PoolController) resolveControllerRef(vm *virtv1.VirtualMachine) *poolv1.VirtualMachinePool {
+	controllerRef := metav1.GetControllerOf(vm)
+	if controllerRef == nil || controllerRef.Kind != "VirtualMachinePool" {
+		return nil
+	}
+	obj, exists, err := c.VMPoolInformer.GetStore().GetByKey(vm.Namespace + "/" + controllerRef.Name)
+	if err != nil || !exists {
+		return nil
+	}
+	pool := obj.(*poolv1.VirtualMachinePool)
+	if pool.UID != controllerRef.UID {
+		// The controller we found with this Name is not the same one that the
+		// ControllerRef points to.
+		return nil
+	}
+	return pool
+}
+
+func (c *PoolController) enqueuePool(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from pool.")
+		return
+	}
+	c.queue.Add(key)
+}
+
+func vmKey(vm *virtv1.VirtualMachine) string {
+	return fmt.Sprintf("%v/%v", vm.Namespace, vm.Name)
+}
+
+func vmKeys(vms []*virtv1.VirtualMachine) []string {
+	keys := []string{}
+	for _, vm := range vms {
+		keys = append(keys, vmKey(vm))
+	}
+	return keys
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func limit(x int, burstReplicas uint) int {
+	replicas := int(burstReplicas)
+	if x <= 0 && x < -replicas {
+		return -replicas
+	}
+	if x > 0 && x > replicas {
+		return replicas
+	}
+	return x
+}
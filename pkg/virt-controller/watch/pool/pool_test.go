@@ -0,0 +1,272 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package pool
+
+import (
+	"sync"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Pool controller", func() {
+	const (
+		testNamespace = "default"
+		poolName      = "pool"
+	)
+
+	newPool := func(replicas int32) *poolv1.VirtualMachinePool {
+		return &poolv1.VirtualMachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      poolName,
+				Namespace: testNamespace,
+				UID:       "pool-uid",
+			},
+			Spec: poolv1.VirtualMachinePoolSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "pool"},
+				},
+				VirtualMachineTemplate: &poolv1.VirtualMachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "pool"},
+					},
+					Spec: v1.VirtualMachineSpec{
+						Template: &v1.VirtualMachineInstanceTemplateSpec{
+							Spec: v1.VirtualMachineInstanceSpec{
+								Volumes: []v1.Volume{
+									{
+										Name: "cloudinit",
+										VolumeSource: v1.VolumeSource{
+											CloudInitNoCloud: &v1.CloudInitNoCloudSource{
+												UserDataSecretRef: &corev1.LocalObjectReference{Name: "userdata"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	Context("One valid VirtualMachinePool controller given", func() {
+		var (
+			ctrl           *gomock.Controller
+			vmInterface    *kubecli.MockVirtualMachineInterface
+			vmPoolInformer cache.SharedIndexInformer
+			vmInformer     cache.SharedIndexInformer
+			controller     *PoolController
+			recorder       *record.FakeRecorder
+			kubevirtClient *kubevirtfake.Clientset
+			updatedPools   []*poolv1.VirtualMachinePool
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+
+			vmPoolInformer, _ = testutils.NewFakeInformerFor(&poolv1.VirtualMachinePool{})
+			vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+			recorder = record.NewFakeRecorder(100)
+
+			controller = &PoolController{
+				Client:         virtClient,
+				VMPoolInformer: vmPoolInformer,
+				VMInformer:     vmInformer,
+				Recorder:       recorder,
+				BurstReplicas:  10,
+			}
+			controller.Init()
+
+			updatedPools = nil
+			kubevirtClient = kubevirtfake.NewSimpleClientset()
+			virtClient.EXPECT().VirtualMachinePool(testNamespace).
+				Return(kubevirtClient.PoolV1alpha1().VirtualMachinePools(testNamespace)).AnyTimes()
+			kubevirtClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action).To(BeNil())
+				return true, nil, nil
+			})
+			kubevirtClient.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				update, ok := action.(testing.UpdateAction)
+				Expect(ok).To(BeTrue())
+				Expect(update.GetSubresource()).To(Equal("status"))
+
+				updatedPools = append(updatedPools, update.GetObject().(*poolv1.VirtualMachinePool))
+				return true, update.GetObject(), nil
+			})
+		})
+
+		addVM := func(pool *poolv1.VirtualMachinePool, idx int, ready bool) {
+			vm := newVMForIndex(pool, idx)
+			vm.Status.Ready = ready
+			Expect(vmInformer.GetIndexer().Add(vm)).To(Succeed())
+		}
+
+		expectCreates := func() *[]string {
+			var lock sync.Mutex
+			names := []string{}
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				Expect(metav1.IsControlledBy(vm, newPool(0))).To(BeTrue())
+				Expect(vm.Labels).To(HaveKeyWithValue("app", "pool"))
+				names = append(names, vm.Name)
+				return vm, nil
+			}).AnyTimes()
+			return &names
+		}
+
+		expectDeletes := func() *[]string {
+			var lock sync.Mutex
+			names := []string{}
+			vmInterface.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(name string, _ *metav1.DeleteOptions) error {
+				lock.Lock()
+				defer lock.Unlock()
+				names = append(names, name)
+				return nil
+			}).AnyTimes()
+			return &names
+		}
+
+		It("should create VirtualMachines with stable names", func() {
+			pool := newPool(3)
+			Expect(vmPoolInformer.GetIndexer().Add(pool)).To(Succeed())
+			created := expectCreates()
+
+			Expect(controller.execute(testNamespace + "/" + poolName)).To(Succeed())
+			Expect(*created).To(ConsistOf("pool-0", "pool-1", "pool-2"))
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should fill the lowest free indexes", func() {
+			pool := newPool(3)
+			Expect(vmPoolInformer.GetIndexer().Add(pool)).To(Succeed())
+			addVM(pool, 0, true)
+			addVM(pool, 2, true)
+			created := expectCreates()
+
+			Expect(controller.execute(testNamespace + "/" + poolName)).To(Succeed())
+			Expect(*created).To(ConsistOf("pool-1"))
+		})
+
+		It("should delete the VirtualMachines with the highest indexes when scaling down", func() {
+			pool := newPool(1)
+			Expect(vmPoolInformer.GetIndexer().Add(pool)).To(Succeed())
+			addVM(pool, 0, true)
+			addVM(pool, 1, true)
+			addVM(pool, 2, true)
+			deleted := expectDeletes()
+
+			Expect(controller.execute(testNamespace + "/" + poolName)).To(Succeed())
+			Expect(*deleted).To(ConsistOf("pool-1", "pool-2"))
+			testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+		})
+
+		It("should ignore VirtualMachines not controlled by the pool", func() {
+			pool := newPool(1)
+			Expect(vmPoolInformer.GetIndexer().Add(pool)).To(Succeed())
+			vm := newVMForIndex(pool, 0)
+			vm.OwnerReferences = nil
+			Expect(vmInformer.GetIndexer().Add(vm)).To(Succeed())
+			created := expectCreates()
+
+			Expect(controller.execute(testNamespace + "/" + poolName)).To(Succeed())
+			Expect(*created).To(ConsistOf("pool-1"))
+		})
+
+		It("should update the status with the ready replicas and the label selector", func() {
+			pool := newPool(2)
+			Expect(vmPoolInformer.GetIndexer().Add(pool)).To(Succeed())
+			addVM(pool, 0, true)
+			addVM(pool, 1, false)
+
+			Expect(controller.execute(testNamespace + "/" + poolName)).To(Succeed())
+			Expect(updatedPools).To(HaveLen(1))
+			Expect(updatedPools[0].Status.Replicas).To(Equal(int32(2)))
+			Expect(updatedPools[0].Status.ReadyReplicas).To(Equal(int32(1)))
+			Expect(updatedPools[0].Status.LabelSelector).To(Equal("app=pool"))
+		})
+
+		It("should not scale a paused pool", func() {
+			pool := newPool(2)
+			pool.Spec.Paused = true
+			Expect(vmPoolInformer.GetIndexer().Add(pool)).To(Succeed())
+
+			Expect(controller.execute(testNamespace + "/" + poolName)).To(Succeed())
+			Expect(updatedPools).To(HaveLen(1))
+			Expect(hasCondition(updatedPools[0], poolv1.VirtualMachinePoolReplicaPaused)).To(BeTrue())
+			testutils.ExpectEvent(recorder, SuccessfulPausedPoolReason)
+		})
+	})
+
+	Context("indexVMSpec", func() {
+		t := true
+
+		table.DescribeTable("should append the sequence index", func(nameGeneration *poolv1.VirtualMachinePoolNameGeneration, expectedSecret, expectedConfigMap, expectedUserData string) {
+			pool := newPool(1)
+			pool.Spec.NameGeneration = nameGeneration
+			pool.Spec.VirtualMachineTemplate.Spec.DataVolumeTemplates = []v1.DataVolumeTemplateSpec{{
+				ObjectMeta: metav1.ObjectMeta{Name: "dv"},
+			}}
+			volumes := &pool.Spec.VirtualMachineTemplate.Spec.Template.Spec.Volumes
+			*volumes = append(*volumes,
+				v1.Volume{Name: "dv", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "dv"}}},
+				v1.Volume{Name: "secret", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "secret"}}},
+				v1.Volume{Name: "configmap", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "configmap"},
+				}}},
+			)
+
+			spec := indexVMSpec(pool, 3)
+			Expect(spec.DataVolumeTemplates[0].Name).To(Equal("dv-3"))
+			specVolumes := spec.Template.Spec.Volumes
+			Expect(specVolumes[0].CloudInitNoCloud.UserDataSecretRef.Name).To(Equal(expectedUserData))
+			Expect(specVolumes[1].DataVolume.Name).To(Equal("dv-3"))
+			Expect(specVolumes[2].Secret.SecretName).To(Equal(expectedSecret))
+			Expect(specVolumes[3].ConfigMap.Name).To(Equal(expectedConfigMap))
+
+			// the pool template must not be modified
+			Expect(pool.Spec.VirtualMachineTemplate.Spec.DataVolumeTemplates[0].Name).To(Equal("dv"))
+		},
+			table.Entry("only to DataVolumes by default", nil, "secret", "configmap", "userdata"),
+			table.Entry("to secrets", &poolv1.VirtualMachinePoolNameGeneration{AppendIndexToSecretRefs: &t}, "secret-3", "configmap", "userdata-3"),
+			table.Entry("to config maps", &poolv1.VirtualMachinePoolNameGeneration{AppendIndexToConfigMapRefs: &t}, "secret", "configmap-3", "userdata"),
+		)
+	})
+})
@@ -22,6 +22,7 @@ package snapshot
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
@@ -29,6 +30,7 @@ import (
 	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -36,6 +38,7 @@ import (
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 )
 
 const (
@@ -48,6 +51,14 @@ const (
 	restoreCompleteEvent = "VirtualMachineRestoreComplete"
 
 	restoreErrorEvent = "VirtualMachineRestoreError"
+
+	externalSnapshotCopyContainerName = "copy"
+
+	externalSnapshotCopySourceVolume = "source"
+
+	externalSnapshotCopyTargetVolume = "target"
+
+	externalSnapshotCopyTargetPath = "/restore"
 )
 
 type restoreTarget interface {
@@ -133,6 +144,19 @@ func (ctrl *VMRestoreController) updateVMRestore(vmRestoreIn *snapshotv1.Virtual
 		}
 
 		if ready {
+			var copying bool
+			copying, err = ctrl.copyExternalSnapshots(vmRestoreOut, target)
+			if err != nil {
+				logger.Reason(err).Error("Error copying external snapshots")
+				return 0, ctrl.doUpdateError(vmRestoreIn, err)
+			}
+
+			if copying {
+				updateRestoreCondition(vmRestoreOut, newProgressingCondition(corev1.ConditionTrue, "Copying external snapshots"))
+				updateRestoreCondition(vmRestoreOut, newReadyCondition(corev1.ConditionFalse, "Waiting for external snapshots to be copied"))
+				return 0, ctrl.doUpdate(vmRestoreIn, vmRestoreOut)
+			}
+
 			updated, err = target.Reconcile()
 			if err != nil {
 				logger.Reason(err).Error("Error reconciling target")
@@ -225,14 +249,17 @@ func (ctrl *VMRestoreController) reconcileVolumeRestores(vmRestore *snapshotv1.V
 		}
 
 		if !found {
-			if vb.VolumeSnapshotName == nil {
+			if vb.VolumeSnapshotName == nil && vb.ExternalSnapshot == nil {
 				return false, fmt.Errorf("VolumeSnapshotName missing %+v", vb)
 			}
 
 			vr := snapshotv1.VolumeRestore{
 				VolumeName:                vb.VolumeName,
 				PersistentVolumeClaimName: restorePVCName(vmRestore, vb.VolumeName),
-				VolumeSnapshotName:        *vb.VolumeSnapshotName,
+			}
+			// external snapshots are copied into the PVC once the VM is halted
+			if vb.VolumeSnapshotName != nil {
+				vr.VolumeSnapshotName = *vb.VolumeSnapshotName
 			}
 			restores = append(restores, vr)
 		}
@@ -434,10 +461,15 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 		}
 	}
 
+	newVolumes, newTemplates = t.keepExcludedVolumes(content.Spec.ExcludedVolumes, newVolumes, newTemplates)
+
 	if updatedStatus {
+		// DataVolumes holding external snapshots are kept, the snapshots are still restored from them
+		externalSnapshotClaims := t.controller.getExternalSnapshotClaims(t.vmRestore.Namespace)
+
 		// find DataVolumes that will no longer exist
 		for _, cdv := range t.vm.Spec.DataVolumeTemplates {
-			found := false
+			found := externalSnapshotClaims[cdv.Name]
 			for _, ndv := range newTemplates {
 				if cdv.Name == ndv.Name {
 					found = true
@@ -470,6 +502,54 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 	return true, nil
 }
 
+// keepExcludedVolumes replaces the volumes excluded from the snapshot with the current
+// volumes of the VM, so the restored VM keeps using them
+func (t *vmRestoreTarget) keepExcludedVolumes(excluded []string, volumes []kubevirtv1.Volume, templates []kubevirtv1.DataVolumeTemplateSpec) ([]kubevirtv1.Volume, []kubevirtv1.DataVolumeTemplateSpec) {
+	for _, name := range excluded {
+		var current *kubevirtv1.Volume
+		for i, v := range t.vm.Spec.Template.Spec.Volumes {
+			if v.Name == name {
+				current = &t.vm.Spec.Template.Spec.Volumes[i]
+				break
+			}
+		}
+		if current == nil {
+			continue
+		}
+
+		for i, v := range volumes {
+			if v.Name != name {
+				continue
+			}
+			if v.DataVolume != nil {
+				templates = removeDataVolumeTemplate(templates, v.DataVolume.Name)
+			}
+			current.DeepCopyInto(&volumes[i])
+		}
+
+		if current.DataVolume != nil {
+			for _, dvt := range t.vm.Spec.DataVolumeTemplates {
+				if dvt.Name == current.DataVolume.Name {
+					templates = append(removeDataVolumeTemplate(templates, dvt.Name), *dvt.DeepCopy())
+					break
+				}
+			}
+		}
+	}
+
+	return volumes, templates
+}
+
+func removeDataVolumeTemplate(templates []kubevirtv1.DataVolumeTemplateSpec, name string) []kubevirtv1.DataVolumeTemplateSpec {
+	var result []kubevirtv1.DataVolumeTemplateSpec
+	for _, dvt := range templates {
+		if dvt.Name != name {
+			result = append(result, dvt)
+		}
+	}
+	return result
+}
+
 func (t *vmRestoreTarget) Own(obj metav1.Object) {
 	b := true
 	obj.SetOwnerReferences([]metav1.OwnerReference{
@@ -485,6 +565,20 @@ func (t *vmRestoreTarget) Own(obj metav1.Object) {
 }
 
 func (t *vmRestoreTarget) Cleanup() error {
+	for _, vr := range t.vmRestore.Status.Restores {
+		pod, err := t.controller.getPod(t.vmRestore.Namespace, vr.PersistentVolumeClaimName)
+		if err != nil {
+			return err
+		}
+
+		if pod != nil && pod.DeletionTimestamp == nil {
+			err = t.controller.Client.CoreV1().Pods(t.vmRestore.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
 	for _, dvName := range t.vmRestore.Status.DeletedDataVolumes {
 		objKey := cacheKeyFunc(t.vmRestore.Namespace, dvName)
 		_, exists, err := t.controller.DataVolumeInformer.GetStore().GetByKey(objKey)
@@ -574,6 +668,39 @@ func (ctrl *VMRestoreController) getPVC(namespace, name string) (*corev1.Persist
 	return obj.(*corev1.PersistentVolumeClaim).DeepCopy(), nil
 }
 
+func (ctrl *VMRestoreController) getPod(namespace, name string) (*corev1.Pod, error) {
+	objKey := cacheKeyFunc(namespace, name)
+	obj, exists, err := ctrl.PodInformer.GetStore().GetByKey(objKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	return obj.(*corev1.Pod).DeepCopy(), nil
+}
+
+// getExternalSnapshotClaims returns the names of the PVCs holding the external snapshots of the
+// VirtualMachineSnapshotContents in the namespace
+func (ctrl *VMRestoreController) getExternalSnapshotClaims(namespace string) map[string]bool {
+	claims := make(map[string]bool)
+	for _, obj := range ctrl.VMSnapshotContentInformer.GetStore().List() {
+		content := obj.(*snapshotv1.VirtualMachineSnapshotContent)
+		if content.Namespace != namespace || content.DeletionTimestamp != nil {
+			continue
+		}
+
+		for _, volumeBackup := range content.Spec.VolumeBackups {
+			if volumeBackup.ExternalSnapshot != nil {
+				claims[volumeBackup.PersistentVolumeClaim.Name] = true
+			}
+		}
+	}
+	return claims
+}
+
 func (ctrl *VMRestoreController) getTarget(vmRestore *snapshotv1.VirtualMachineRestore) (restoreTarget, error) {
 	vmRestore.Spec.Target.DeepCopy()
 	switch vmRestore.Spec.Target.Kind {
@@ -609,7 +736,7 @@ func (ctrl *VMRestoreController) createRestorePVC(
 		Spec: sourcePVC.Spec,
 	}
 
-	if volumeBackup.VolumeSnapshotName == nil {
+	if volumeBackup.VolumeSnapshotName == nil && volumeBackup.ExternalSnapshot == nil {
 		log.Log.Errorf("VolumeSnapshot name missing %+v", volumeBackup)
 		return fmt.Errorf("missing VolumeSnapshot name")
 	}
@@ -627,11 +754,16 @@ func (ctrl *VMRestoreController) createRestorePVC(
 	}
 	pvc.Annotations[pvcRestoreAnnotation] = vmRestore.Name
 
-	apiGroup := vsv1beta1.GroupName
-	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
-		APIGroup: &apiGroup,
-		Kind:     "VolumeSnapshot",
-		Name:     *volumeBackup.VolumeSnapshotName,
+	if volumeBackup.VolumeSnapshotName != nil {
+		apiGroup := vsv1beta1.GroupName
+		pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: &apiGroup,
+			Kind:     "VolumeSnapshot",
+			Name:     *volumeBackup.VolumeSnapshotName,
+		}
+	} else {
+		// the PVC is empty until the external snapshot is copied into it
+		pvc.Spec.DataSource = nil
 	}
 	pvc.Spec.VolumeName = ""
 
@@ -645,6 +777,144 @@ func (ctrl *VMRestoreController) createRestorePVC(
 	return nil
 }
 
+// copyExternalSnapshots copies the files holding the external snapshots of the content into the restore PVCs,
+// with a pod per volume. It returns true while the pods are copying.
+func (ctrl *VMRestoreController) copyExternalSnapshots(vmRestore *snapshotv1.VirtualMachineRestore, target restoreTarget) (bool, error) {
+	content, err := ctrl.getSnapshotContent(vmRestore, target.UID())
+	if err != nil {
+		return false, err
+	}
+
+	copying := false
+	for _, volumeBackup := range content.Spec.VolumeBackups {
+		if volumeBackup.ExternalSnapshot == nil {
+			continue
+		}
+
+		var volumeRestore *snapshotv1.VolumeRestore
+		for i, vr := range vmRestore.Status.Restores {
+			if vr.VolumeName == volumeBackup.VolumeName {
+				volumeRestore = &vmRestore.Status.Restores[i]
+				break
+			}
+		}
+		if volumeRestore == nil {
+			return false, fmt.Errorf("no VolumeRestore for volume %s", volumeBackup.VolumeName)
+		}
+
+		pod, err := ctrl.getPod(vmRestore.Namespace, volumeRestore.PersistentVolumeClaimName)
+		if err != nil {
+			return false, err
+		}
+
+		if pod == nil {
+			sourcePVC, err := ctrl.getPVC(vmRestore.Namespace, volumeBackup.PersistentVolumeClaim.Name)
+			if err != nil {
+				return false, err
+			}
+
+			if sourcePVC == nil {
+				return false, fmt.Errorf("PVC %s/%s holding the external snapshot of volume %s does not exist",
+					vmRestore.Namespace, volumeBackup.PersistentVolumeClaim.Name, volumeBackup.VolumeName)
+			}
+
+			pod = ctrl.newExternalSnapshotCopyPod(vmRestore, volumeBackup, *volumeRestore)
+			target.Own(pod)
+
+			_, err = ctrl.Client.CoreV1().Pods(vmRestore.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+			if err != nil && !errors.IsAlreadyExists(err) {
+				return false, err
+			}
+
+			copying = true
+			continue
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+		case corev1.PodFailed:
+			return false, fmt.Errorf("copying external snapshot %s of volume %s failed: %s",
+				volumeBackup.ExternalSnapshot.File, volumeBackup.VolumeName, externalSnapshotCopyMessage(pod))
+		default:
+			copying = true
+		}
+	}
+
+	return copying, nil
+}
+
+// newExternalSnapshotCopyPod returns a pod converting the file holding the external snapshot into the image of
+// the restore PVC. The PVC holding the snapshot is mounted where virt-launcher mounts it, so the absolute paths
+// of the backing files in the overlays resolve.
+func (ctrl *VMRestoreController) newExternalSnapshotCopyPod(
+	vmRestore *snapshotv1.VirtualMachineRestore,
+	volumeBackup snapshotv1.VolumeBackup,
+	volumeRestore snapshotv1.VolumeRestore,
+) *corev1.Pod {
+	sourceDir := hostdisk.GetMountedHostDiskDir(volumeBackup.VolumeName)
+	script := fmt.Sprintf("qemu-img convert -O raw %s %s 2> %s",
+		filepath.Join(sourceDir, volumeBackup.ExternalSnapshot.File),
+		filepath.Join(externalSnapshotCopyTargetPath, "disk.img"),
+		corev1.TerminationMessagePathDefault,
+	)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volumeRestore.PersistentVolumeClaimName,
+			Namespace: vmRestore.Namespace,
+			Annotations: map[string]string{
+				pvcRestoreAnnotation: vmRestore.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:                     externalSnapshotCopyContainerName,
+					Image:                    ctrl.CopyImage,
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					Command:                  []string{"/bin/bash", "-c"},
+					Args:                     []string{script},
+					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: externalSnapshotCopySourceVolume, MountPath: sourceDir, ReadOnly: true},
+						{Name: externalSnapshotCopyTargetVolume, MountPath: externalSnapshotCopyTargetPath},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: externalSnapshotCopySourceVolume,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: volumeBackup.PersistentVolumeClaim.Name,
+							ReadOnly:  true,
+						},
+					},
+				},
+				{
+					Name: externalSnapshotCopyTargetVolume,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: volumeRestore.PersistentVolumeClaimName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func externalSnapshotCopyMessage(pod *corev1.Pod) string {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == externalSnapshotCopyContainerName && containerStatus.State.Terminated != nil {
+			return containerStatus.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
 func updateRestoreCondition(r *snapshotv1.VirtualMachineRestore, c snapshotv1.Condition) {
 	r.Status.Conditions = updateCondition(r.Status.Conditions, c, true)
 }
@@ -40,6 +40,9 @@ import (
 type VMRestoreController struct {
 	Client kubecli.KubevirtClient
 
+	// CopyImage is the image of the pods copying external snapshots, it has to provide qemu-img
+	CopyImage string
+
 	VMRestoreInformer         cache.SharedIndexInformer
 	VMSnapshotInformer        cache.SharedIndexInformer
 	VMSnapshotContentInformer cache.SharedIndexInformer
@@ -48,6 +51,7 @@ type VMRestoreController struct {
 	DataVolumeInformer        cache.SharedIndexInformer
 	PVCInformer               cache.SharedIndexInformer
 	StorageClassInformer      cache.SharedIndexInformer
+	PodInformer               cache.SharedIndexInformer
 
 	Recorder record.EventRecorder
 
@@ -72,6 +76,13 @@ func (ctrl *VMRestoreController) Init() {
 		},
 	)
 
+	ctrl.PodInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handlePod,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handlePod(newObj) },
+		},
+	)
+
 	ctrl.VMInformer.AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    ctrl.handleVM,
@@ -97,6 +108,7 @@ func (ctrl *VMRestoreController) Run(threadiness int, stopCh <-chan struct{}) er
 		ctrl.VMIInformer.HasSynced,
 		ctrl.DataVolumeInformer.HasSynced,
 		ctrl.PVCInformer.HasSynced,
+		ctrl.PodInformer.HasSynced,
 	) {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
@@ -168,6 +180,24 @@ func (ctrl *VMRestoreController) handlePVC(obj interface{}) {
 	}
 }
 
+func (ctrl *VMRestoreController) handlePod(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if pod, ok := obj.(*corev1.Pod); ok {
+		restoreName, ok := pod.Annotations[pvcRestoreAnnotation]
+		if !ok {
+			return
+		}
+
+		objName := cacheKeyFunc(pod.Namespace, restoreName)
+
+		log.Log.V(3).Infof("Handling Pod %s/%s, Restore %s", pod.Namespace, pod.Name, objName)
+		ctrl.vmRestoreQueue.Add(objName)
+	}
+}
+
 func (ctrl *VMRestoreController) handleVM(obj interface{}) {
 	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
 		obj = unknown.Obj
@@ -189,6 +189,9 @@ var _ = Describe("Restore controlleer", func() {
 		var storageClassInformer cache.SharedIndexInformer
 		var storageClassSource *framework.FakeControllerSource
 
+		var podInformer cache.SharedIndexInformer
+		var podSource *framework.FakeControllerSource
+
 		var stop chan struct{}
 		var controller *VMRestoreController
 		var recorder *record.FakeRecorder
@@ -207,6 +210,7 @@ var _ = Describe("Restore controlleer", func() {
 			go vmiInformer.Run(stop)
 			go dataVolumeInformer.Run(stop)
 			go storageClassInformer.Run(stop)
+			go podInformer.Run(stop)
 			Expect(cache.WaitForCacheSync(
 				stop,
 				vmRestoreInformer.HasSynced,
@@ -217,6 +221,7 @@ var _ = Describe("Restore controlleer", func() {
 				vmiInformer.HasSynced,
 				dataVolumeInformer.HasSynced,
 				storageClassInformer.HasSynced,
+				podInformer.HasSynced,
 			)).To(BeTrue())
 		}
 
@@ -244,6 +249,7 @@ var _ = Describe("Restore controlleer", func() {
 			dataVolumeInformer, dataVolumeSource = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 			pvcInformer, pvcSource = testutils.NewFakeInformerFor(&corev1.PersistentVolumeClaim{})
 			storageClassInformer, storageClassSource = testutils.NewFakeInformerFor(&storagev1.StorageClass{})
+			podInformer, podSource = testutils.NewFakeInformerFor(&corev1.Pod{})
 
 			recorder = record.NewFakeRecorder(100)
 
@@ -257,6 +263,8 @@ var _ = Describe("Restore controlleer", func() {
 				PVCInformer:               pvcInformer,
 				StorageClassInformer:      storageClassInformer,
 				DataVolumeInformer:        dataVolumeInformer,
+				PodInformer:               podInformer,
+				CopyImage:                 "virt-launcher",
 				Recorder:                  recorder,
 			}
 			controller.Init()
@@ -546,6 +554,22 @@ var _ = Describe("Restore controlleer", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should keep the current volumes excluded from the snapshot", func() {
+				vm := createModifiedVM()
+				vm.Spec.DataVolumeTemplates[0].Name = "current-disk1"
+				vm.Spec.Template.Spec.Volumes[0].DataVolume.Name = "current-disk1"
+				snapshotVM := createSnapshotVM()
+				target := &vmRestoreTarget{controller: controller, vmRestore: createRestore(), vm: vm}
+
+				volumes, templates := target.keepExcludedVolumes(nil, snapshotVM.Spec.Template.Spec.Volumes, snapshotVM.Spec.DataVolumeTemplates)
+				Expect(volumes).To(Equal(createSnapshotVM().Spec.Template.Spec.Volumes))
+				Expect(templates).To(Equal(createSnapshotVM().Spec.DataVolumeTemplates))
+
+				volumes, templates = target.keepExcludedVolumes([]string{"disk1"}, snapshotVM.Spec.Template.Spec.Volumes, snapshotVM.Spec.DataVolumeTemplates)
+				Expect(volumes).To(Equal(vm.Spec.Template.Spec.Volumes))
+				Expect(templates).To(Equal(vm.Spec.DataVolumeTemplates))
+			})
+
 			It("should cleanup and complete", func() {
 				r := createRestoreWithOwner()
 				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
@@ -609,6 +633,267 @@ var _ = Describe("Restore controlleer", func() {
 				testutils.ExpectEvent(recorder, "VirtualMachineRestoreComplete")
 			})
 		})
+
+		Context("with initialized snapshot and content with external snapshots", func() {
+			const copyPodName = "restore-uid-disk1"
+
+			addExternalVolumeRestores := func(r *snapshotv1.VirtualMachineRestore) {
+				r.Status.Restores = []snapshotv1.VolumeRestore{
+					{
+						VolumeName:                "disk1",
+						PersistentVolumeClaimName: "restore-uid-disk1",
+					},
+				}
+			}
+
+			createCopyPod := func(phase corev1.PodPhase, message string) *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        copyPodName,
+						Namespace:   testNamespace,
+						Annotations: map[string]string{"restore.kubevirt.io/name": "restore"},
+					},
+					Status: corev1.PodStatus{
+						Phase: phase,
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Name: "copy",
+								State: corev1.ContainerState{
+									Terminated: &corev1.ContainerStateTerminated{Message: message},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			addBoundPVCs := func(r *snapshotv1.VirtualMachineRestore) {
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+				for _, pvc := range createPVCsForVM(createSnapshotVM()) {
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+			}
+
+			BeforeEach(func() {
+				s := createSnapshot()
+				vm := createSnapshotVM()
+				sc := createVirtualMachineSnapshotContent(s, vm)
+				sc.Spec.VolumeBackups[0].VolumeSnapshotName = nil
+				sc.Spec.VolumeBackups[0].ExternalSnapshot = &snapshotv1.ExternalSnapshotBackup{
+					File:         "vmsnapshot-earlier.qcow2",
+					BackingChain: []string{"disk.img"},
+					Overlay:      "vmsnapshot-snapshot-uid.qcow2",
+				}
+				s.Status.VirtualMachineSnapshotContentName = &sc.Name
+				sc.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					CreationTime: timeFunc(),
+					ReadyToUse:   &t,
+				}
+				vmSnapshotSource.Add(s)
+				vmSnapshotContentSource.Add(sc)
+				storageClassSource.Add(createStorageClass())
+			})
+
+			It("should update restore status with VolumeRestores without VolumeSnapshots", func() {
+				r := createRestoreWithOwner()
+				rc := r.DeepCopy()
+				rc.ResourceVersion = "1"
+				rc.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Creating new PVCs"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for new PVCs"),
+				}
+				addExternalVolumeRestores(rc)
+
+				vmSource.Add(createModifiedVM())
+				expectVMRestoreUpdate(kubevirtClient, rc)
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should create empty restore PVCs", func() {
+				r := createRestoreWithOwner()
+				r.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Creating new PVCs"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for new PVCs"),
+				}
+				addExternalVolumeRestores(r)
+
+				vmSource.Add(createModifiedVM())
+				k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					create, ok := action.(testing.CreateAction)
+					Expect(ok).To(BeTrue())
+
+					pvc := create.GetObject().(*corev1.PersistentVolumeClaim)
+					Expect(pvc.Name).To(Equal("restore-uid-disk1"))
+					Expect(pvc.Spec.DataSource).To(BeNil())
+
+					return true, create.GetObject(), nil
+				})
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should create pods copying the external snapshots when the VM is halted", func() {
+				r := createRestoreWithOwner()
+				r.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionFalse, "Waiting for target to be ready"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for target to be ready"),
+				}
+				addExternalVolumeRestores(r)
+				ur := r.DeepCopy()
+				ur.ResourceVersion = "1"
+				ur.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Copying external snapshots"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for external snapshots to be copied"),
+				}
+
+				vmSource.Add(createModifiedVM())
+				vmRestoreSource.Add(r)
+				addBoundPVCs(r)
+				k8sClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					create, ok := action.(testing.CreateAction)
+					Expect(ok).To(BeTrue())
+
+					pod := create.GetObject().(*corev1.Pod)
+					Expect(pod.Name).To(Equal(copyPodName))
+					Expect(pod.Annotations).To(HaveKeyWithValue("restore.kubevirt.io/name", "restore"))
+					Expect(pod.OwnerReferences).To(HaveLen(1))
+					Expect(pod.OwnerReferences[0].UID).To(Equal(vmUID))
+					Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+					Expect(pod.Spec.Containers).To(HaveLen(1))
+					Expect(pod.Spec.Containers[0].Image).To(Equal("virt-launcher"))
+					Expect(pod.Spec.Containers[0].Args).To(ConsistOf(
+						"qemu-img convert -O raw /var/run/kubevirt-private/vmi-disks/disk1/vmsnapshot-earlier.qcow2 /restore/disk.img 2> /dev/termination-log",
+					))
+					Expect(pod.Spec.Containers[0].VolumeMounts).To(ConsistOf(
+						corev1.VolumeMount{Name: "source", MountPath: "/var/run/kubevirt-private/vmi-disks/disk1", ReadOnly: true},
+						corev1.VolumeMount{Name: "target", MountPath: "/restore"},
+					))
+					Expect(pod.Spec.Volumes).To(HaveLen(2))
+					Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("alpine-dv"))
+					Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly).To(BeTrue())
+					Expect(pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("restore-uid-disk1"))
+
+					return true, create.GetObject(), nil
+				})
+				expectVMRestoreUpdate(kubevirtClient, ur)
+				syncCaches(stop)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should wait for the pods copying the external snapshots", func() {
+				r := createRestoreWithOwner()
+				r.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Copying external snapshots"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for external snapshots to be copied"),
+				}
+				addExternalVolumeRestores(r)
+
+				vmSource.Add(createModifiedVM())
+				vmRestoreSource.Add(r)
+				addBoundPVCs(r)
+				podSource.Add(createCopyPod(corev1.PodRunning, ""))
+				syncCaches(stop)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should fail if copying an external snapshot fails", func() {
+				r := createRestoreWithOwner()
+				r.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Copying external snapshots"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for external snapshots to be copied"),
+				}
+				addExternalVolumeRestores(r)
+				message := "copying external snapshot vmsnapshot-earlier.qcow2 of volume disk1 failed: No space left on device"
+				ur := r.DeepCopy()
+				ur.ResourceVersion = "1"
+				ur.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionFalse, message),
+					newReadyCondition(corev1.ConditionFalse, message),
+				}
+
+				vmSource.Add(createModifiedVM())
+				vmRestoreSource.Add(r)
+				addBoundPVCs(r)
+				podSource.Add(createCopyPod(corev1.PodFailed, "No space left on device"))
+				expectVMRestoreUpdate(kubevirtClient, ur)
+				syncCaches(stop)
+				controller.processVMRestoreWorkItem()
+				testutils.ExpectEvent(recorder, "VirtualMachineRestoreError")
+			})
+
+			It("should update PVCs and restores once the external snapshots are copied and keep their DataVolumes", func() {
+				r := createRestoreWithOwner()
+				r.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Copying external snapshots"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for external snapshots to be copied"),
+				}
+				addExternalVolumeRestores(r)
+				ur := r.DeepCopy()
+				ur.ResourceVersion = "1"
+				ur.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+				}
+				for i := range ur.Status.Restores {
+					ur.Status.Restores[i].DataVolumeName = &ur.Status.Restores[i].PersistentVolumeClaimName
+				}
+
+				vmSource.Add(createModifiedVM())
+				vmRestoreSource.Add(r)
+				addBoundPVCs(r)
+				podSource.Add(createCopyPod(corev1.PodSucceeded, ""))
+				expectPVCUpdates(k8sClient, ur)
+				expectVMRestoreUpdate(kubevirtClient, ur)
+				syncCaches(stop)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should delete the copy pods on cleanup", func() {
+				r := createRestoreWithOwner()
+				r.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+					newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+				}
+				addExternalVolumeRestores(r)
+				for i := range r.Status.Restores {
+					r.Status.Restores[i].DataVolumeName = &r.Status.Restores[i].PersistentVolumeClaimName
+				}
+				ur := r.DeepCopy()
+				ur.ResourceVersion = "1"
+				ur.Status.Complete = &t
+				ur.Status.RestoreTime = timeFunc()
+				ur.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionFalse, "Operation complete"),
+					newReadyCondition(corev1.ConditionTrue, "Operation complete"),
+				}
+
+				vm := createSnapshotVM()
+				vm.Annotations = map[string]string{"restore.kubevirt.io/lastRestoreUID": "restore-uid"}
+				vmSource.Add(vm)
+				vmRestoreSource.Add(r)
+				addBoundPVCs(r)
+				podSource.Add(createCopyPod(corev1.PodSucceeded, ""))
+				deleted := false
+				k8sClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					a, ok := action.(testing.DeleteAction)
+					Expect(ok).To(BeTrue())
+					Expect(a.GetName()).To(Equal(copyPodName))
+					deleted = true
+
+					return true, nil, nil
+				})
+				expectVMRestoreUpdate(kubevirtClient, ur)
+				syncCaches(stop)
+				controller.processVMRestoreWorkItem()
+				Expect(deleted).To(BeTrue())
+				testutils.ExpectEvent(recorder, "VirtualMachineRestoreComplete")
+			})
+		})
 	})
 })
 
@@ -0,0 +1,390 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/cron"
+)
+
+const (
+	// scheduleLabel holds the name of the schedule which created a snapshot
+	scheduleLabel = "snapshot.kubevirt.io/schedule"
+
+	scheduleSnapshotTimeFormat = "20060102150405"
+
+	defaultScheduleRetention = 7
+
+	scheduledSnapshotCreateEvent = "SuccessfulVirtualMachineSnapshotCreate"
+
+	scheduledSnapshotSkippedEvent = "VirtualMachineSnapshotSkipped"
+
+	scheduledSnapshotDeleteEvent = "SuccessfulVirtualMachineSnapshotDelete"
+)
+
+// VMSnapshotScheduleController creates and prunes VirtualMachineSnapshots on a schedule
+type VMSnapshotScheduleController struct {
+	Client kubecli.KubevirtClient
+
+	VMSnapshotScheduleInformer cache.SharedIndexInformer
+	VMSnapshotInformer         cache.SharedIndexInformer
+	VMInformer                 cache.SharedIndexInformer
+	VMIInformer                cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	vmSnapshotScheduleQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the schedule controller
+func (ctrl *VMSnapshotScheduleController) Init() {
+	ctrl.vmSnapshotScheduleQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "snapshot-schedule-controller-vmsnapshotschedule")
+
+	ctrl.VMSnapshotScheduleInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMSnapshotSchedule,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMSnapshotSchedule(newObj) },
+		},
+	)
+
+	ctrl.VMSnapshotInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMSnapshot,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMSnapshot(newObj) },
+			DeleteFunc: ctrl.handleVMSnapshot,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *VMSnapshotScheduleController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmSnapshotScheduleQueue.ShutDown()
+
+	log.Log.Info("Starting snapshot schedule controller.")
+	defer log.Log.Info("Shutting down snapshot schedule controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMSnapshotScheduleInformer.HasSynced,
+		ctrl.VMSnapshotInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmSnapshotScheduleWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMSnapshotScheduleController) vmSnapshotScheduleWorker() {
+	for ctrl.processVMSnapshotScheduleWorkItem() {
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) processVMSnapshotScheduleWorkItem() bool {
+	return processWorkItem(ctrl.vmSnapshotScheduleQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmSnapshotSchedule worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMSnapshotScheduleInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return 0, err
+		}
+
+		schedule, ok := storeObj.(*snapshotv1.VirtualMachineSnapshotSchedule)
+		if !ok {
+			return 0, fmt.Errorf("unexpected resource %+v", storeObj)
+		}
+
+		return ctrl.updateVMSnapshotSchedule(schedule.DeepCopy())
+	})
+}
+
+func (ctrl *VMSnapshotScheduleController) handleVMSnapshotSchedule(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if schedule, ok := obj.(*snapshotv1.VirtualMachineSnapshotSchedule); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(schedule)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, schedule)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmSnapshotScheduleQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) handleVMSnapshot(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmSnapshot, ok := obj.(*snapshotv1.VirtualMachineSnapshot); ok {
+		scheduleName, ok := vmSnapshot.Labels[scheduleLabel]
+		if !ok {
+			return
+		}
+
+		objName := cacheKeyFunc(vmSnapshot.Namespace, scheduleName)
+
+		log.Log.V(3).Infof("Handling VMSnapshot %s/%s, Schedule %s", vmSnapshot.Namespace, vmSnapshot.Name, objName)
+		ctrl.vmSnapshotScheduleQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) updateVMSnapshotSchedule(schedule *snapshotv1.VirtualMachineSnapshotSchedule) (time.Duration, error) {
+	log.Log.V(3).Infof("Updating VirtualMachineSnapshotSchedule %s/%s", schedule.Namespace, schedule.Name)
+
+	if schedule.DeletionTimestamp != nil {
+		return 0, nil
+	}
+
+	status := &snapshotv1.VirtualMachineSnapshotScheduleStatus{}
+	if schedule.Status != nil {
+		status = schedule.Status.DeepCopy()
+	}
+
+	cronSchedule, err := cron.Parse(schedule.Spec.Schedule)
+	if err != nil {
+		setScheduleError(status, err.Error())
+		status.NextScheduleTime = nil
+		return 0, ctrl.updateStatus(schedule, status)
+	}
+	if status.NextScheduleTime == nil {
+		// the schedule was invalid before, so the error is outdated
+		status.Error = nil
+	}
+
+	now := currentTime().Time
+	last := schedule.CreationTimestamp.Time
+	if status.LastScheduleTime != nil {
+		last = status.LastScheduleTime.Time
+	}
+
+	// only the latest missed run is caught up on, like CronJobs do
+	var due time.Time
+	for next := cronSchedule.Next(last); !next.IsZero() && !next.After(now); next = cronSchedule.Next(next) {
+		due = next
+	}
+
+	if !due.IsZero() {
+		status.LastScheduleTime = &metav1.Time{Time: due}
+		name, skipReason, err := ctrl.createScheduledSnapshot(schedule, due)
+		if err != nil {
+			return 0, err
+		}
+		if skipReason != "" {
+			setScheduleError(status, skipReason)
+			ctrl.Recorder.Event(schedule, corev1.EventTypeWarning, scheduledSnapshotSkippedEvent, skipReason)
+		} else {
+			status.LastSnapshotName = &name
+			status.Error = nil
+		}
+	}
+
+	if err := ctrl.pruneScheduledSnapshots(schedule); err != nil {
+		return 0, err
+	}
+
+	next := cronSchedule.Next(now)
+	if next.IsZero() {
+		setScheduleError(status, fmt.Sprintf("schedule %q never matches", schedule.Spec.Schedule))
+		status.NextScheduleTime = nil
+		return 0, ctrl.updateStatus(schedule, status)
+	}
+	status.NextScheduleTime = &metav1.Time{Time: next}
+
+	if err := ctrl.updateStatus(schedule, status); err != nil {
+		return 0, err
+	}
+
+	return next.Sub(now), nil
+}
+
+func setScheduleError(status *snapshotv1.VirtualMachineSnapshotScheduleStatus, message string) {
+	if status.Error != nil && status.Error.Message != nil && *status.Error.Message == message {
+		return
+	}
+	status.Error = newError(message)
+}
+
+func (ctrl *VMSnapshotScheduleController) updateStatus(schedule *snapshotv1.VirtualMachineSnapshotSchedule, status *snapshotv1.VirtualMachineSnapshotScheduleStatus) error {
+	if schedule.Status != nil && equality.Semantic.DeepEqual(schedule.Status, status) {
+		return nil
+	}
+
+	scheduleCpy := schedule.DeepCopy()
+	scheduleCpy.Status = status
+	_, err := ctrl.Client.VirtualMachineSnapshotSchedule(scheduleCpy.Namespace).Update(context.Background(), scheduleCpy, metav1.UpdateOptions{})
+	return err
+}
+
+// createScheduledSnapshot creates the snapshot due at the given time, or returns why it was skipped
+func (ctrl *VMSnapshotScheduleController) createScheduledSnapshot(schedule *snapshotv1.VirtualMachineSnapshotSchedule, due time.Time) (string, string, error) {
+	vmName := schedule.Spec.Source.Name
+	_, exists, err := ctrl.VMInformer.GetStore().GetByKey(cacheKeyFunc(schedule.Namespace, vmName))
+	if err != nil {
+		return "", "", err
+	}
+	if !exists {
+		return "", fmt.Sprintf("VirtualMachine %s does not exist", vmName), nil
+	}
+
+	snapshots, err := ctrl.scheduledSnapshots(schedule)
+	if err != nil {
+		return "", "", err
+	}
+	for _, s := range snapshots {
+		if vmSnapshotProgressing(s) {
+			return "", fmt.Sprintf("VirtualMachineSnapshot %s is still in progress", s.Name), nil
+		}
+	}
+
+	if schedule.Spec.QuiescePolicy != nil && *schedule.Spec.QuiescePolicy == snapshotv1.QuiesceRequired {
+		obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(cacheKeyFunc(schedule.Namespace, vmName))
+		if err != nil {
+			return "", "", err
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if exists && !condManager.HasCondition(obj.(*kubevirtv1.VirtualMachineInstance), kubevirtv1.VirtualMachineInstanceAgentConnected) {
+			return "", fmt.Sprintf("VirtualMachine %s is running without a connected guest agent", vmName), nil
+		}
+	}
+
+	vmSnapshot := &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", schedule.Name, due.UTC().Format(scheduleSnapshotTimeFormat)),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				scheduleLabel: schedule.Name,
+			},
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source:         schedule.Spec.Source,
+			DeletionPolicy: schedule.Spec.DeletionPolicy,
+		},
+	}
+
+	// snapshots are not owned by the schedule, so they are kept when it is deleted
+	_, err = ctrl.Client.VirtualMachineSnapshot(schedule.Namespace).Create(context.Background(), vmSnapshot, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return "", "", err
+	}
+	if err == nil {
+		ctrl.Recorder.Eventf(
+			schedule,
+			corev1.EventTypeNormal,
+			scheduledSnapshotCreateEvent,
+			"Successfully created VirtualMachineSnapshot %s",
+			vmSnapshot.Name,
+		)
+	}
+
+	return vmSnapshot.Name, "", nil
+}
+
+func (ctrl *VMSnapshotScheduleController) scheduledSnapshots(schedule *snapshotv1.VirtualMachineSnapshotSchedule) ([]*snapshotv1.VirtualMachineSnapshot, error) {
+	var snapshots []*snapshotv1.VirtualMachineSnapshot
+	for _, obj := range ctrl.VMSnapshotInformer.GetStore().List() {
+		vmSnapshot, ok := obj.(*snapshotv1.VirtualMachineSnapshot)
+		if !ok {
+			return nil, fmt.Errorf("unexpected resource %+v", obj)
+		}
+		if vmSnapshot.Namespace == schedule.Namespace && vmSnapshot.Labels[scheduleLabel] == schedule.Name {
+			snapshots = append(snapshots, vmSnapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// pruneScheduledSnapshots keeps the newest ready snapshots up to the retention count,
+// deleting all finished snapshots older than those
+func (ctrl *VMSnapshotScheduleController) pruneScheduledSnapshots(schedule *snapshotv1.VirtualMachineSnapshotSchedule) error {
+	retention := defaultScheduleRetention
+	if schedule.Spec.Retention != nil {
+		retention = int(*schedule.Spec.Retention)
+	}
+
+	snapshots, err := ctrl.scheduledSnapshots(schedule)
+	if err != nil {
+		return err
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		ti, tj := snapshots[i].CreationTimestamp, snapshots[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return snapshots[i].Name > snapshots[j].Name
+	})
+
+	ready := 0
+	for _, s := range snapshots {
+		if s.DeletionTimestamp != nil || vmSnapshotProgressing(s) {
+			continue
+		}
+		if ready < retention {
+			if vmSnapshotReady(s) {
+				ready++
+			}
+			continue
+		}
+
+		err := ctrl.Client.VirtualMachineSnapshot(s.Namespace).Delete(context.Background(), s.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		ctrl.Recorder.Eventf(
+			schedule,
+			corev1.EventTypeNormal,
+			scheduledSnapshotDeleteEvent,
+			"Successfully deleted VirtualMachineSnapshot %s",
+			s.Name,
+		)
+	}
+
+	return nil
+}
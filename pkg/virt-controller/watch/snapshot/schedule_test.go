@@ -0,0 +1,341 @@
+package snapshot
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	framework "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Snapshot schedule controller", func() {
+	const (
+		scheduleName = "daily"
+		vmName       = "testvm"
+	)
+
+	var (
+		ctrl               *gomock.Controller
+		controller         *VMSnapshotScheduleController
+		recorder           *record.FakeRecorder
+		kubevirtClient     *kubevirtfake.Clientset
+		vmSnapshotSource   *framework.FakeControllerSource
+		vmSource           *framework.FakeControllerSource
+		vmiSource          *framework.FakeControllerSource
+		vmSnapshotInformer cache.SharedIndexInformer
+		vmInformer         cache.SharedIndexInformer
+		vmiInformer        cache.SharedIndexInformer
+		created            []*snapshotv1.VirtualMachineSnapshot
+		deleted            []string
+		updated            *snapshotv1.VirtualMachineSnapshotSchedule
+		stop               chan struct{}
+	)
+
+	now := time.Date(2022, 6, 1, 3, 30, 0, 0, time.UTC)
+
+	createSchedule := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+		return &snapshotv1.VirtualMachineSnapshotSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              scheduleName,
+				Namespace:         testNamespace,
+				CreationTimestamp: metav1.NewTime(time.Date(2022, 5, 31, 12, 0, 0, 0, time.UTC)),
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotScheduleSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: &vmAPIGroup,
+					Kind:     "VirtualMachine",
+					Name:     vmName,
+				},
+				Schedule: "0 3 * * *",
+			},
+		}
+	}
+
+	createScheduledSnapshot := func(name string, created time.Time, ready bool) *snapshotv1.VirtualMachineSnapshot {
+		s := createVirtualMachineSnapshot(testNamespace, name, vmName)
+		s.Labels = map[string]string{scheduleLabel: scheduleName}
+		s.CreationTimestamp = metav1.NewTime(created)
+		s.Status = &snapshotv1.VirtualMachineSnapshotStatus{ReadyToUse: &ready}
+		return s
+	}
+
+	BeforeEach(func() {
+		stop = make(chan struct{})
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+
+		vmSnapshotScheduleInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotSchedule{})
+		vmSnapshotInformer, vmSnapshotSource = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
+		vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = &VMSnapshotScheduleController{
+			Client:                     virtClient,
+			VMSnapshotScheduleInformer: vmSnapshotScheduleInformer,
+			VMSnapshotInformer:         vmSnapshotInformer,
+			VMInformer:                 vmInformer,
+			VMIInformer:                vmiInformer,
+			Recorder:                   recorder,
+		}
+		controller.Init()
+
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		virtClient.EXPECT().VirtualMachineSnapshotSchedule(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotSchedules(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineSnapshot(testNamespace).
+			Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace)).AnyTimes()
+
+		created, deleted, updated = nil, nil, nil
+		kubevirtClient.Fake.PrependReactor("*", "*", func(action testing.Action) (bool, runtime.Object, error) {
+			switch action.GetVerb() {
+			case "create":
+				created = append(created, action.(testing.CreateAction).GetObject().(*snapshotv1.VirtualMachineSnapshot))
+			case "delete":
+				deleted = append(deleted, action.(testing.DeleteAction).GetName())
+			case "update":
+				Expect(action.GetSubresource()).To(BeEmpty())
+				updated = action.(testing.UpdateAction).GetObject().(*snapshotv1.VirtualMachineSnapshotSchedule)
+			default:
+				Expect(action).To(BeNil())
+			}
+			return true, nil, nil
+		})
+
+		currentTime = func() *metav1.Time {
+			t := metav1.NewTime(now)
+			return &t
+		}
+
+		go vmSnapshotInformer.Run(stop)
+		go vmInformer.Run(stop)
+		go vmiInformer.Run(stop)
+		Expect(cache.WaitForCacheSync(stop, vmSnapshotInformer.HasSynced, vmInformer.HasSynced, vmiInformer.HasSynced)).To(BeTrue())
+	})
+
+	AfterEach(func() {
+		close(stop)
+		ctrl.Finish()
+	})
+
+	addVM := func() {
+		vm := createVirtualMachine(testNamespace, vmName)
+		vmSource.Add(vm)
+		Eventually(func() bool {
+			_, exists, _ := vmInformer.GetStore().Get(vm)
+			return exists
+		}).Should(BeTrue())
+	}
+
+	addSnapshots := func(snapshots ...*snapshotv1.VirtualMachineSnapshot) {
+		for _, s := range snapshots {
+			vmSnapshotSource.Add(s)
+		}
+		Eventually(func() int {
+			return len(vmSnapshotInformer.GetStore().List())
+		}).Should(Equal(len(snapshots)))
+	}
+
+	addRunningVMI := func(agentConnected bool) {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: vmName},
+			Status:     v1.VirtualMachineInstanceStatus{Phase: v1.Running},
+		}
+		if agentConnected {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstanceAgentConnected, Status: corev1.ConditionTrue},
+			}
+		}
+		vmiSource.Add(vmi)
+		Eventually(func() bool {
+			_, exists, _ := vmiInformer.GetStore().Get(vmi)
+			return exists
+		}).Should(BeTrue())
+	}
+
+	expectSkipped := func(schedule *snapshotv1.VirtualMachineSnapshotSchedule, message string) {
+		_, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeEmpty())
+		Expect(updated.Status.LastScheduleTime.Time).To(Equal(time.Date(2022, 6, 1, 3, 0, 0, 0, time.UTC)))
+		Expect(*updated.Status.Error.Message).To(Equal(message))
+		testutils.ExpectEvent(recorder, scheduledSnapshotSkippedEvent)
+	}
+
+	It("should create a snapshot when it is due", func() {
+		addVM()
+		policy := snapshotv1.VirtualMachineSnapshotContentRetain
+		schedule := createSchedule()
+		schedule.Spec.DeletionPolicy = &policy
+
+		requeue, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(Equal(23*time.Hour + 30*time.Minute))
+
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Name).To(Equal("daily-20220601030000"))
+		Expect(created[0].Labels).To(HaveKeyWithValue(scheduleLabel, scheduleName))
+		Expect(created[0].OwnerReferences).To(BeEmpty())
+		Expect(created[0].Spec.Source).To(Equal(schedule.Spec.Source))
+		Expect(*created[0].Spec.DeletionPolicy).To(Equal(policy))
+		testutils.ExpectEvent(recorder, scheduledSnapshotCreateEvent)
+
+		Expect(updated.Status.LastScheduleTime.Time).To(Equal(time.Date(2022, 6, 1, 3, 0, 0, 0, time.UTC)))
+		Expect(updated.Status.NextScheduleTime.Time).To(Equal(time.Date(2022, 6, 2, 3, 0, 0, 0, time.UTC)))
+		Expect(*updated.Status.LastSnapshotName).To(Equal("daily-20220601030000"))
+		Expect(updated.Status.Error).To(BeNil())
+	})
+
+	It("should only catch up on the latest missed run", func() {
+		addVM()
+		schedule := createSchedule()
+		schedule.Spec.Schedule = "*/10 * * * *"
+
+		requeue, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(Equal(10 * time.Minute))
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Name).To(Equal("daily-20220601033000"))
+	})
+
+	It("should wait until the snapshot is due", func() {
+		addVM()
+		schedule := createSchedule()
+		schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+			LastScheduleTime: &metav1.Time{Time: time.Date(2022, 6, 1, 3, 0, 0, 0, time.UTC)},
+		}
+
+		requeue, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(Equal(23*time.Hour + 30*time.Minute))
+		Expect(created).To(BeEmpty())
+		Expect(updated.Status.NextScheduleTime.Time).To(Equal(time.Date(2022, 6, 2, 3, 0, 0, 0, time.UTC)))
+	})
+
+	It("should not update an unchanged status", func() {
+		addVM()
+		schedule := createSchedule()
+		schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+			LastScheduleTime: &metav1.Time{Time: time.Date(2022, 6, 1, 3, 0, 0, 0, time.UTC)},
+			NextScheduleTime: &metav1.Time{Time: time.Date(2022, 6, 2, 3, 0, 0, 0, time.UTC)},
+		}
+
+		_, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated).To(BeNil())
+	})
+
+	It("should report an invalid schedule", func() {
+		schedule := createSchedule()
+		schedule.Spec.Schedule = "0 3 * *"
+
+		requeue, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeZero())
+		Expect(created).To(BeEmpty())
+		Expect(*updated.Status.Error.Message).To(ContainSubstring("invalid cron expression"))
+		Expect(updated.Status.NextScheduleTime).To(BeNil())
+	})
+
+	It("should skip the snapshot if the VM does not exist", func() {
+		expectSkipped(createSchedule(), "VirtualMachine testvm does not exist")
+	})
+
+	It("should skip the snapshot if the previous one is still in progress", func() {
+		addVM()
+		addSnapshots(createScheduledSnapshot("daily-20220531030000", now.Add(-time.Hour), false))
+		expectSkipped(createSchedule(), "VirtualMachineSnapshot daily-20220531030000 is still in progress")
+	})
+
+	It("should skip the snapshot if quiescing is required and the guest agent is not connected", func() {
+		addVM()
+		addRunningVMI(false)
+		schedule := createSchedule()
+		policy := snapshotv1.QuiesceRequired
+		schedule.Spec.QuiescePolicy = &policy
+		expectSkipped(schedule, "VirtualMachine testvm is running without a connected guest agent")
+	})
+
+	It("should create the snapshot if quiescing is required and the guest agent is connected", func() {
+		addVM()
+		addRunningVMI(true)
+		schedule := createSchedule()
+		policy := snapshotv1.QuiesceRequired
+		schedule.Spec.QuiescePolicy = &policy
+
+		_, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(HaveLen(1))
+	})
+
+	It("should create the snapshot without guest agent on best effort", func() {
+		addVM()
+		addRunningVMI(false)
+
+		_, err := controller.updateVMSnapshotSchedule(createSchedule())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(HaveLen(1))
+	})
+
+	It("should delete the snapshots beyond the retention count", func() {
+		addVM()
+		day := 24 * time.Hour
+		failed := createScheduledSnapshot("daily-20220527030000", now.Add(-5*day), false)
+		failed.Status.Error = newError("failed")
+		addSnapshots(
+			createScheduledSnapshot("daily-20220526030000", now.Add(-6*day), true),
+			failed,
+			createScheduledSnapshot("daily-20220528030000", now.Add(-4*day), true),
+			createScheduledSnapshot("daily-20220529030000", now.Add(-3*day), true),
+			createScheduledSnapshot("daily-20220531030000", now.Add(-day), true),
+		)
+		retention := int32(2)
+		schedule := createSchedule()
+		schedule.Spec.Retention = &retention
+		schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+			LastScheduleTime: &metav1.Time{Time: time.Date(2022, 6, 1, 3, 0, 0, 0, time.UTC)},
+		}
+
+		_, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted).To(ConsistOf("daily-20220528030000", "daily-20220527030000", "daily-20220526030000"))
+		testutils.ExpectEvents(recorder, scheduledSnapshotDeleteEvent, scheduledSnapshotDeleteEvent, scheduledSnapshotDeleteEvent)
+	})
+
+	It("should not delete snapshots of other schedules", func() {
+		addVM()
+		other := createScheduledSnapshot("weekly-20220501030000", now.Add(-30*24*time.Hour), true)
+		other.Labels[scheduleLabel] = "weekly"
+		addSnapshots(other, createVirtualMachineSnapshot(testNamespace, "manual", vmName))
+		retention := int32(1)
+		schedule := createSchedule()
+		schedule.Spec.Retention = &retention
+
+		_, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(HaveLen(1))
+		Expect(deleted).To(BeEmpty())
+	})
+
+	It("should enqueue the schedule of a snapshot", func() {
+		controller.handleVMSnapshot(createScheduledSnapshot("daily-20220531030000", now, true))
+		controller.handleVMSnapshot(createVirtualMachineSnapshot(testNamespace, "manual", vmName))
+		Expect(controller.vmSnapshotScheduleQueue.Len()).To(Equal(1))
+		key, _ := controller.vmSnapshotScheduleQueue.Get()
+		Expect(key).To(Equal(testNamespace + "/" + scheduleName))
+	})
+})
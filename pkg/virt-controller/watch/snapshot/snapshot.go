@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -40,6 +41,7 @@ import (
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util"
 )
 
 const (
@@ -49,6 +51,10 @@ const (
 
 	vmSnapshotContentFinalizer = "snapshot.kubevirt.io/vmsnapshotcontent-protection"
 
+	// externalSnapshotFinalizer keeps a deleted VirtualMachineSnapshotContent until the overlays of its
+	// external snapshots were committed
+	externalSnapshotFinalizer = "snapshot.kubevirt.io/external-snapshot-protection"
+
 	defaultVolumeSnapshotClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
 
 	vmSnapshotContentCreateEvent = "SuccessfulVirtualMachineSnapshotContentCreate"
@@ -57,7 +63,16 @@ const (
 
 	volumeSnapshotMissingEvent = "VolumeSnapshotMissing"
 
+	externalSnapshotCommitFailedEvent = "ExternalSnapshotCommitFailed"
+
 	snapshotRetryInterval = 5 * time.Second
+
+	// externalSnapshotWaitInterval is how often a deleted VirtualMachineSnapshotContent checks whether
+	// the VM runs again to commit the overlays of its external snapshots
+	externalSnapshotWaitInterval = time.Minute
+
+	// unfreezeTimeout is how long the guest stays frozen if the controller never gets to unfreeze it
+	unfreezeTimeout = 5 * time.Minute
 )
 
 type snapshotSource interface {
@@ -66,7 +81,15 @@ type snapshotSource interface {
 	Lock() (bool, error)
 	Unlock() (bool, error)
 	Spec() snapshotv1.SourceSpec
+	Online() (bool, error)
+	GuestAgent() (bool, error)
+	Freeze() error
+	Unfreeze() error
+	MemoryDump(fileName string) (*kubevirtv1.VirtualMachineInstanceMemoryDumpStatus, error)
+	MemoryDumpClaimName() string
+	ExternalSnapshot(name string, volumes []string) (map[string]*kubevirtv1.ExternalSnapshotVolumeStatus, error)
 	PersistentVolumeClaims() map[string]string
+	ExcludedVolumes() []string
 }
 
 type vmSnapshotSource struct {
@@ -95,6 +118,10 @@ func vmSnapshotProgressing(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
 		(vmSnapshot.Status == nil || vmSnapshot.Status.ReadyToUse == nil || !*vmSnapshot.Status.ReadyToUse)
 }
 
+func vmSnapshotContentCreated(vmSnapshotContent *snapshotv1.VirtualMachineSnapshotContent) bool {
+	return vmSnapshotContent.Status != nil && vmSnapshotContent.Status.CreationTime != nil
+}
+
 func getVMSnapshotContentName(vmSnapshot *snapshotv1.VirtualMachineSnapshot) string {
 	if vmSnapshot.Status != nil && vmSnapshot.Status.VirtualMachineSnapshotContentName != nil {
 		return *vmSnapshot.Status.VirtualMachineSnapshotContentName
@@ -129,6 +156,12 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 
 	// unlock the source if done/error
 	if !vmSnapshotProgressing(vmSnapshot) && source != nil {
+		if source.Locked() {
+			if err := source.Unfreeze(); err != nil {
+				return 0, err
+			}
+		}
+
 		if updated, err := source.Unlock(); updated || err != nil {
 			return 0, err
 		}
@@ -166,7 +199,46 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 
 		// create content if does not exist
 		if content == nil {
-			return 0, ctrl.createContent(vmSnapshot)
+			if err := source.Freeze(); err != nil {
+				return 0, err
+			}
+
+			// the memory is dumped while the guest is frozen, so it matches the volume snapshots
+			memoryDump, err := source.MemoryDump(getMemoryDumpFileName(vmSnapshot))
+			if err != nil {
+				return 0, err
+			}
+			if memoryDump != nil {
+				switch memoryDump.Phase {
+				case kubevirtv1.MemoryDumpCompleted:
+				case kubevirtv1.MemoryDumpFailed:
+					return 0, ctrl.setVMSnapshotError(vmSnapshot, fmt.Sprintf("Memory dump failed: %s", memoryDump.Message))
+				default:
+					return snapshotRetryInterval, nil
+				}
+			}
+
+			// volumes without a VolumeSnapshotClass are switched to qcow2 overlays while the guest is frozen too
+			externalSnapshotVolumes, err := ctrl.getExternalSnapshotVolumes(vmSnapshot.Namespace, source)
+			if err != nil {
+				return 0, err
+			}
+			externalSnapshots, err := source.ExternalSnapshot(getExternalSnapshotName(vmSnapshot), externalSnapshotVolumes)
+			if err != nil {
+				return 0, err
+			}
+			if externalSnapshots == nil {
+				return snapshotRetryInterval, nil
+			}
+
+			return 0, ctrl.createContent(vmSnapshot, memoryDump != nil, externalSnapshots)
+		}
+
+		// the guest can be thawed as soon as all volume snapshots are taken
+		if vmSnapshotContentCreated(content) && vmSnapshot.Status.CreationTime == nil {
+			if err := source.Unfreeze(); err != nil {
+				return 0, err
+			}
 		}
 	}
 
@@ -180,6 +252,10 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.VirtualMachineSnapshotContent) (time.Duration, error) {
 	log.Log.V(3).Infof("Updating VirtualMachineSnapshotContent %s/%s", content.Namespace, content.Name)
 
+	if content.DeletionTimestamp != nil && controller.HasFinalizer(content, externalSnapshotFinalizer) {
+		return ctrl.commitExternalSnapshots(content)
+	}
+
 	var volueSnapshotStatus []snapshotv1.VolumeSnapshotStatus
 	var deletedSnapshots, skippedSnapshots []string
 
@@ -238,7 +314,7 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 		volueSnapshotStatus = append(volueSnapshotStatus, vss)
 	}
 
-	ready := true
+	created, ready := true, true
 	errorMessage := ""
 	contentCpy := content.DeepCopy()
 	if contentCpy.Status == nil {
@@ -247,21 +323,26 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 	contentCpy.Status.Error = nil
 
 	if len(deletedSnapshots) > 0 {
-		ready = false
+		created, ready = false, false
 		errorMessage = fmt.Sprintf("VolumeSnapshots (%s) missing", strings.Join(deletedSnapshots, ","))
 	} else if len(skippedSnapshots) > 0 {
-		ready = false
+		created, ready = false, false
 		errorMessage = fmt.Sprintf("VolumeSnapshots (%s) skipped because in error state", strings.Join(skippedSnapshots, ","))
 	} else {
 		for _, vss := range volueSnapshotStatus {
+			if vss.CreationTime == nil {
+				created = false
+			}
+
 			if vss.ReadyToUse == nil || !*vss.ReadyToUse {
 				ready = false
-				break
 			}
 		}
 	}
 
-	if ready && (contentCpy.Status.ReadyToUse == nil || !*contentCpy.Status.ReadyToUse) {
+	// the content is created once every VolumeSnapshot has been cut,
+	// it may take a while longer until they are all ready to use
+	if (created || ready) && contentCpy.Status.CreationTime == nil {
 		contentCpy.Status.CreationTime = currentTime()
 	}
 
@@ -284,6 +365,174 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 	return 0, nil
 }
 
+// commitExternalSnapshots commits the overlays of the external snapshots of a deleted VirtualMachineSnapshotContent
+// into the files holding the snapshots, which are not needed anymore. The commit needs the VM to run, the
+// contents holding later snapshots in the overlays are updated to the files the overlays were committed into.
+// The files are left alone if the VM is gone, its volumes were switched to other PVCs or external snapshots
+// were disabled.
+func (ctrl *VMSnapshotController) commitExternalSnapshots(content *snapshotv1.VirtualMachineSnapshotContent) (time.Duration, error) {
+	var committed []snapshotv1.VolumeBackup
+	if vm := content.Spec.Source.VirtualMachine; vm != nil && ctrl.ClusterConfig.ExternalSnapshotsEnabled() {
+		obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(cacheKeyFunc(content.Namespace, vm.Name))
+		if err != nil {
+			return 0, err
+		}
+
+		if exists && obj.(*kubevirtv1.VirtualMachine).DeletionTimestamp == nil {
+			obj, exists, err = ctrl.VMIInformer.GetStore().GetByKey(cacheKeyFunc(content.Namespace, vm.Name))
+			if err != nil {
+				return 0, err
+			}
+
+			if !exists || obj.(*kubevirtv1.VirtualMachineInstance).Status.Phase != kubevirtv1.Running {
+				log.Log.V(3).Infof("Waiting for vm %s/%s to run to commit the external snapshots of %s", content.Namespace, vm.Name, content.Name)
+				return externalSnapshotWaitInterval, nil
+			}
+
+			vmi := obj.(*kubevirtv1.VirtualMachineInstance)
+			var retry time.Duration
+			committed, retry, err = ctrl.commitExternalSnapshotOverlays(content, vmi)
+			if retry > 0 || err != nil {
+				return retry, err
+			}
+		}
+	}
+
+	for _, obj := range ctrl.VMSnapshotContentInformer.GetStore().List() {
+		other := obj.(*snapshotv1.VirtualMachineSnapshotContent)
+		if other.Namespace != content.Namespace || other.Name == content.Name {
+			continue
+		}
+
+		otherCpy := other.DeepCopy()
+		for i := range otherCpy.Spec.VolumeBackups {
+			volumeBackup := &otherCpy.Spec.VolumeBackups[i]
+			if volumeBackup.ExternalSnapshot == nil {
+				continue
+			}
+			for _, deleted := range committed {
+				if deleted.PersistentVolumeClaim.Name == volumeBackup.PersistentVolumeClaim.Name {
+					moveExternalSnapshot(volumeBackup.ExternalSnapshot, deleted.ExternalSnapshot)
+				}
+			}
+		}
+
+		if !reflect.DeepEqual(other, otherCpy) {
+			log.Log.V(2).Infof("Updating external snapshots of vmsnapshotcontent %s/%s to committed overlays", otherCpy.Namespace, otherCpy.Name)
+			if _, err := ctrl.Client.VirtualMachineSnapshotContent(otherCpy.Namespace).Update(context.Background(), otherCpy, metav1.UpdateOptions{}); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	contentCpy := content.DeepCopy()
+	controller.RemoveFinalizer(contentCpy, externalSnapshotFinalizer)
+	_, err := ctrl.Client.VirtualMachineSnapshotContent(contentCpy.Namespace).Update(context.Background(), contentCpy, metav1.UpdateOptions{})
+	return 0, err
+}
+
+// commitExternalSnapshotOverlays requests the commit of the overlays of the content which are still in the chains
+// of the volumes of the running VMI, one overlay after the other. It returns the volume backups whose overlays
+// are committed once there are none left.
+func (ctrl *VMSnapshotController) commitExternalSnapshotOverlays(
+	content *snapshotv1.VirtualMachineSnapshotContent,
+	vmi *kubevirtv1.VirtualMachineInstance,
+) ([]snapshotv1.VolumeBackup, time.Duration, error) {
+	claims := getPVCsFromVolumes(vmi.Spec.Volumes)
+
+	var committed []snapshotv1.VolumeBackup
+	var overlay string
+	var volumes []string
+	for _, volumeBackup := range content.Spec.VolumeBackups {
+		if volumeBackup.ExternalSnapshot == nil || claims[volumeBackup.VolumeName] != volumeBackup.PersistentVolumeClaim.Name {
+			continue
+		}
+
+		volumeStatus := getVolumeStatus(vmi, volumeBackup.VolumeName)
+		if volumeStatus == nil || volumeStatus.ExternalSnapshot == nil || !externalSnapshotInChain(volumeStatus.ExternalSnapshot, volumeBackup.ExternalSnapshot.Overlay) {
+			committed = append(committed, volumeBackup)
+			continue
+		}
+
+		if overlay == "" || overlay == volumeBackup.ExternalSnapshot.Overlay {
+			overlay = volumeBackup.ExternalSnapshot.Overlay
+			volumes = append(volumes, volumeBackup.VolumeName)
+		}
+	}
+
+	if len(volumes) == 0 {
+		return committed, 0, nil
+	}
+
+	var failed error
+	if blockCommit := vmi.Status.BlockCommit; blockCommit != nil {
+		switch blockCommit.Phase {
+		case kubevirtv1.BlockCommitInProgress:
+			log.Log.V(3).Infof("Waiting for the block commit of volumes %v of vmi %s/%s", blockCommit.Volumes, vmi.Namespace, vmi.Name)
+			return nil, snapshotRetryInterval, nil
+		case kubevirtv1.BlockCommitFailed:
+			// a commit started since the content was deleted was most likely requested by the controller
+			if blockCommit.StartTimestamp != nil && !blockCommit.StartTimestamp.Before(content.DeletionTimestamp) {
+				ctrl.Recorder.Eventf(
+					content,
+					corev1.EventTypeWarning,
+					externalSnapshotCommitFailedEvent,
+					"Committing the overlays of volumes %v failed: %s",
+					blockCommit.Volumes,
+					blockCommit.Message,
+				)
+				failed = fmt.Errorf("committing the overlays of volumes %v failed: %s", blockCommit.Volumes, blockCommit.Message)
+			}
+		}
+	}
+
+	log.Log.V(2).Infof("Committing overlay %s of volumes %v of vmi %s/%s", overlay, volumes, vmi.Namespace, vmi.Name)
+
+	options := &kubevirtv1.BlockCommitOptions{Volumes: volumes, Overlay: overlay}
+	if err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).BlockCommit(vmi.Name, options); err != nil {
+		return nil, 0, err
+	}
+
+	// retry with a backoff if the commit keeps failing
+	if failed != nil {
+		return nil, 0, failed
+	}
+
+	return nil, snapshotRetryInterval, nil
+}
+
+func externalSnapshotInChain(chain *kubevirtv1.ExternalSnapshotVolumeStatus, file string) bool {
+	if chain.ActiveFile == file {
+		return true
+	}
+	for _, backingFile := range chain.BackingChain {
+		if backingFile == file {
+			return true
+		}
+	}
+	return false
+}
+
+// moveExternalSnapshot updates an external snapshot once the overlay of the deleted snapshot was committed into
+// the file holding the deleted snapshot, the overlay is replaced by that file in its chain
+func moveExternalSnapshot(externalSnapshot, deleted *snapshotv1.ExternalSnapshotBackup) {
+	if externalSnapshot.File == deleted.Overlay {
+		externalSnapshot.File = deleted.File
+		// the file was the first file of the backing chain
+		if len(externalSnapshot.BackingChain) > 0 {
+			externalSnapshot.BackingChain = externalSnapshot.BackingChain[1:]
+		}
+		return
+	}
+
+	for i, file := range externalSnapshot.BackingChain {
+		if file == deleted.Overlay {
+			externalSnapshot.BackingChain = append(externalSnapshot.BackingChain[:i:i], externalSnapshot.BackingChain[i+1:]...)
+			return
+		}
+	}
+}
+
 func (ctrl *VMSnapshotController) createVolumeSnapshot(
 	content *snapshotv1.VirtualMachineSnapshotContent,
 	volumeBackup snapshotv1.VolumeBackup,
@@ -430,7 +679,11 @@ func (ctrl *VMSnapshotController) cleanupVMSnapshot(vmSnapshot *snapshotv1.Virtu
 	return nil
 }
 
-func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMachineSnapshot) error {
+func (ctrl *VMSnapshotController) createContent(
+	vmSnapshot *snapshotv1.VirtualMachineSnapshot,
+	memoryDumped bool,
+	externalSnapshots map[string]*kubevirtv1.ExternalSnapshotVolumeStatus,
+) error {
 	source, err := ctrl.getSnapshotSource(vmSnapshot)
 	if err != nil {
 		return err
@@ -438,6 +691,15 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 
 	var volumeBackups []snapshotv1.VolumeBackup
 	for volumeName, pvcName := range source.PersistentVolumeClaims() {
+		if externalSnapshot, exists := externalSnapshots[volumeName]; exists {
+			vb, err := ctrl.getExternalSnapshotBackup(vmSnapshot.Namespace, volumeName, pvcName, externalSnapshot)
+			if err != nil {
+				return err
+			}
+			volumeBackups = append(volumeBackups, *vb)
+			continue
+		}
+
 		pvc, err := ctrl.getSnapshotPVC(vmSnapshot.Namespace, pvcName)
 		if err != nil {
 			return err
@@ -471,9 +733,24 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 			VirtualMachineSnapshotName: &vmSnapshot.Name,
 			Source:                     source.Spec(),
 			VolumeBackups:              volumeBackups,
+			ExcludedVolumes:            source.ExcludedVolumes(),
 		},
 	}
 
+	for _, vb := range volumeBackups {
+		if vb.ExternalSnapshot != nil {
+			controller.AddFinalizer(content, externalSnapshotFinalizer)
+			break
+		}
+	}
+
+	if memoryDumped {
+		content.Spec.MemoryDump = &snapshotv1.MemoryDumpBackup{
+			ClaimName: source.MemoryDumpClaimName(),
+			FileName:  getMemoryDumpFileName(vmSnapshot),
+		}
+	}
+
 	_, err = ctrl.Client.VirtualMachineSnapshotContent(content.Namespace).Create(context.Background(), content, metav1.CreateOptions{})
 	if err != nil && !errors.IsAlreadyExists(err) {
 		return err
@@ -490,8 +767,98 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 	return nil
 }
 
-func (ctrl *VMSnapshotController) getSnapshotPVC(namespace, volumeName string) (*corev1.PersistentVolumeClaim, error) {
-	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(namespace, volumeName))
+func (ctrl *VMSnapshotController) setVMSnapshotError(vmSnapshot *snapshotv1.VirtualMachineSnapshot, reason string) error {
+	vmSnapshotCpy := vmSnapshot.DeepCopy()
+	vmSnapshotCpy.Status.Error = newError(reason)
+	updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, reason))
+	updateSnapshotCondition(vmSnapshotCpy, newReadyCondition(corev1.ConditionFalse, reason))
+
+	_, err := ctrl.Client.VirtualMachineSnapshot(vmSnapshotCpy.Namespace).Update(context.Background(), vmSnapshotCpy, metav1.UpdateOptions{})
+	return err
+}
+
+func getMemoryDumpFileName(vmSnapshot *snapshotv1.VirtualMachineSnapshot) string {
+	return fmt.Sprintf("vmsnapshot-%s.memory.dump", vmSnapshot.UID)
+}
+
+func getExternalSnapshotName(vmSnapshot *snapshotv1.VirtualMachineSnapshot) string {
+	return fmt.Sprintf("vmsnapshot-%s", vmSnapshot.UID)
+}
+
+// getExternalSnapshotVolumes returns the volumes of a running source which are snapshotted by switching their
+// disks to qcow2 overlays, because their PVCs are filesystem volumes without a VolumeSnapshotClass
+func (ctrl *VMSnapshotController) getExternalSnapshotVolumes(namespace string, source snapshotSource) ([]string, error) {
+	if !ctrl.ClusterConfig.ExternalSnapshotsEnabled() {
+		return nil, nil
+	}
+
+	online, err := source.Online()
+	if err != nil || !online {
+		return nil, err
+	}
+
+	var volumes []string
+	for volumeName, pvcName := range source.PersistentVolumeClaims() {
+		pvc, err := ctrl.getBoundPVC(namespace, pvcName)
+		if err != nil {
+			return nil, err
+		}
+
+		if pvc == nil || isBlockPVC(pvc) {
+			continue
+		}
+
+		if pvc.Spec.StorageClassName != nil {
+			volumeSnapshotClass, err := ctrl.getVolumeSnapshotClass(*pvc.Spec.StorageClassName)
+			if err != nil {
+				return nil, err
+			}
+
+			if volumeSnapshotClass != "" {
+				continue
+			}
+		}
+
+		volumes = append(volumes, volumeName)
+	}
+	sort.Strings(volumes)
+
+	return volumes, nil
+}
+
+func (ctrl *VMSnapshotController) getExternalSnapshotBackup(
+	namespace, volumeName, pvcName string,
+	externalSnapshot *kubevirtv1.ExternalSnapshotVolumeStatus,
+) (*snapshotv1.VolumeBackup, error) {
+	pvc, err := ctrl.getBoundPVC(namespace, pvcName)
+	if err != nil {
+		return nil, err
+	}
+
+	if pvc == nil || len(externalSnapshot.BackingChain) == 0 {
+		return nil, fmt.Errorf("external snapshot of volume %s not found", volumeName)
+	}
+
+	return &snapshotv1.VolumeBackup{
+		VolumeName: volumeName,
+		PersistentVolumeClaim: snapshotv1.PersistentVolumeClaim{
+			ObjectMeta: *pvc.ObjectMeta.DeepCopy(),
+			Spec:       *pvc.Spec.DeepCopy(),
+		},
+		ExternalSnapshot: &snapshotv1.ExternalSnapshotBackup{
+			File:         externalSnapshot.BackingChain[0],
+			BackingChain: externalSnapshot.BackingChain[1:],
+			Overlay:      externalSnapshot.ActiveFile,
+		},
+	}, nil
+}
+
+func isBlockPVC(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock
+}
+
+func (ctrl *VMSnapshotController) getBoundPVC(namespace, pvcName string) (*corev1.PersistentVolumeClaim, error) {
+	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(namespace, pvcName))
 	if err != nil {
 		return nil, err
 	}
@@ -507,6 +874,15 @@ func (ctrl *VMSnapshotController) getSnapshotPVC(namespace, volumeName string) (
 		return nil, nil
 	}
 
+	return pvc, nil
+}
+
+func (ctrl *VMSnapshotController) getSnapshotPVC(namespace, volumeName string) (*corev1.PersistentVolumeClaim, error) {
+	pvc, err := ctrl.getBoundPVC(namespace, volumeName)
+	if err != nil || pvc == nil {
+		return nil, err
+	}
+
 	if pvc.Spec.StorageClassName == nil {
 		log.Log.Warningf("No storage class for PVC %s/%s", pvc.Namespace, pvc.Name)
 		return nil, nil
@@ -605,6 +981,13 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 
 		if source != nil {
 			if source.Locked() {
+				if vmSnapshotCpy.Status.Indications == nil {
+					indications, err := getSnapshotIndications(vmSnapshot, source)
+					if err != nil {
+						return err
+					}
+					vmSnapshotCpy.Status.Indications = indications
+				}
 				updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionTrue, "Source locked and operation in progress"))
 			} else {
 				updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, "Source not locked"))
@@ -633,6 +1016,32 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 	return nil
 }
 
+func getSnapshotIndications(vmSnapshot *snapshotv1.VirtualMachineSnapshot, source snapshotSource) ([]snapshotv1.Indication, error) {
+	online, err := source.Online()
+	if err != nil || !online {
+		return nil, err
+	}
+
+	indications := []snapshotv1.Indication{snapshotv1.VMSnapshotOnlineSnapshotIndication}
+
+	guestAgent, err := source.GuestAgent()
+	if err != nil {
+		return nil, err
+	}
+
+	if guestAgent {
+		indications = append(indications, snapshotv1.VMSnapshotGuestAgentIndication)
+	} else {
+		indications = append(indications, snapshotv1.VMSnapshotNoGuestAgentIndication)
+	}
+
+	if vmSnapshot.Spec.IncludeMemoryDump != nil && *vmSnapshot.Spec.IncludeMemoryDump {
+		indications = append(indications, snapshotv1.VMSnapshotMemoryDumpIndication)
+	}
+
+	return indications, nil
+}
+
 func (ctrl *VMSnapshotController) updateVolumeSnapshotStatuses(vm *kubevirtv1.VirtualMachine) error {
 	log.Log.V(3).Infof("Update volume snapshot status for VM [%s/%s]", vm.Namespace, vm.Name)
 
@@ -656,11 +1065,25 @@ func (ctrl *VMSnapshotController) getVolumeSnapshotStatus(vm *kubevirtv1.Virtual
 			Reason:  fmt.Sprintf("Volume is nil [%s]", volume.Name),
 		}
 	}
+	if isVolumeExcluded(&vm.Spec.Template.Spec, volume.Name) {
+		return kubevirtv1.VolumeSnapshotStatus{
+			Name:    volume.Name,
+			Enabled: false,
+			Reason:  fmt.Sprintf("Volume [%s] is excluded from snapshots", volume.Name),
+		}
+	}
 	sc, err := ctrl.getVolumeStorageClassForVolume(vm.Namespace, volume)
 	if err != nil {
 		return kubevirtv1.VolumeSnapshotStatus{Name: volume.Name, Enabled: false, Reason: err.Error()}
 	}
 	if sc == "" {
+		if ctrl.ClusterConfig.ExternalSnapshotsEnabled() && ctrl.isFilesystemVolume(vm.Namespace, volume) {
+			return kubevirtv1.VolumeSnapshotStatus{
+				Name:    volume.Name,
+				Enabled: true,
+				Reason:  fmt.Sprintf("No Volume Snapshot Storage Class found for volume [%s], it is snapshotted with external snapshots while the VM is running", volume.Name),
+			}
+		}
 		return kubevirtv1.VolumeSnapshotStatus{
 			Name:    volume.Name,
 			Enabled: false,
@@ -703,6 +1126,25 @@ func (ctrl *VMSnapshotController) getVolumeStorageClassForVolume(namespace strin
 	return "", fmt.Errorf("Volume type does not suport snapshots")
 }
 
+// isFilesystemVolume returns true if the volume is a PVC or DataVolume whose PVC is a filesystem volume
+func (ctrl *VMSnapshotController) isFilesystemVolume(namespace string, volume *kubevirtv1.Volume) bool {
+	var pvcName string
+	if volume.VolumeSource.PersistentVolumeClaim != nil {
+		pvcName = volume.VolumeSource.PersistentVolumeClaim.ClaimName
+	} else if volume.VolumeSource.DataVolume != nil {
+		pvcName = volume.VolumeSource.DataVolume.Name
+	} else {
+		return false
+	}
+
+	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(namespace, pvcName))
+	if err != nil || !exists {
+		return false
+	}
+
+	return !isBlockPVC(obj.(*corev1.PersistentVolumeClaim))
+}
+
 func (ctrl *VMSnapshotController) getStorageClassNameForDV(namespace string, dvName string) (string, error) {
 	// First, look up DV's StorageClass
 	key := cacheKeyFunc(namespace, dvName)
@@ -814,40 +1256,38 @@ func (s *vmSnapshotSource) Lock() (bool, error) {
 		return true, nil
 	}
 
-	rs, err := s.vm.RunStrategy()
-	if err != nil {
-		return false, err
-	}
-
-	if rs != kubevirtv1.RunStrategyHalted {
-		log.Log.V(3).Infof("Snapshottting a running VM is not supported yet")
-		return false, nil
-	}
-
-	key, err := controller.KeyFunc(s.vm)
+	vmi, exists, err := s.getVMI()
 	if err != nil {
 		return false, err
 	}
 
-	_, exists, err := s.controller.VMIInformer.GetStore().GetByKey(key)
-	if err != nil {
-		return false, err
-	}
+	if exists && !vmi.IsFinal() {
+		// online snapshot, the volumes are in use by the VMI
+		if !vmi.IsRunning() {
+			log.Log.V(3).Infof("VMI %s not running yet", vmi.Name)
+			return false, nil
+		}
+	} else {
+		rs, err := s.vm.RunStrategy()
+		if err != nil {
+			return false, err
+		}
 
-	if exists {
-		log.Log.V(3).Infof("VMI still running")
-		return false, nil
-	}
+		if rs == kubevirtv1.RunStrategyAlways {
+			log.Log.V(3).Infof("Waiting for VM %s to start", s.vm.Name)
+			return false, nil
+		}
 
-	pvcNames := s.pvcNames()
-	pods, err := podsUsingPVCs(s.controller.PodInformer, s.vm.Namespace, pvcNames)
-	if err != nil {
-		return false, err
-	}
+		pvcNames := s.pvcNames()
+		pods, err := podsUsingPVCs(s.controller.PodInformer, s.vm.Namespace, pvcNames)
+		if err != nil {
+			return false, err
+		}
 
-	if len(pods) > 0 {
-		log.Log.V(3).Infof("%d pods using PVCs %+v", len(pods), pvcNames)
-		return false, nil
+		if len(pods) > 0 {
+			log.Log.V(3).Infof("%d pods using PVCs %+v", len(pods), pvcNames)
+			return false, nil
+		}
 	}
 
 	if s.vm.Status.SnapshotInProgress != nil && *s.vm.Status.SnapshotInProgress != s.snapshot.Name {
@@ -910,8 +1350,188 @@ func (s *vmSnapshotSource) Spec() snapshotv1.SourceSpec {
 	}
 }
 
+func (s *vmSnapshotSource) getVMI() (*kubevirtv1.VirtualMachineInstance, bool, error) {
+	key, err := controller.KeyFunc(s.vm)
+	if err != nil {
+		return nil, false, err
+	}
+
+	obj, exists, err := s.controller.VMIInformer.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	return obj.(*kubevirtv1.VirtualMachineInstance), true, nil
+}
+
+func (s *vmSnapshotSource) Online() (bool, error) {
+	vmi, exists, err := s.getVMI()
+	if err != nil {
+		return false, err
+	}
+
+	return exists && vmi.IsRunning(), nil
+}
+
+func (s *vmSnapshotSource) GuestAgent() (bool, error) {
+	vmi, exists, err := s.getVMI()
+	if err != nil || !exists {
+		return false, err
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	return condManager.HasCondition(vmi, kubevirtv1.VirtualMachineInstanceAgentConnected), nil
+}
+
+// Freeze quiesces the guest filesystems through the guest agent, if the VM is running and has one connected
+func (s *vmSnapshotSource) Freeze() error {
+	if !s.Locked() {
+		return fmt.Errorf("attempting to freeze unlocked VM")
+	}
+
+	online, err := s.Online()
+	if err != nil || !online {
+		return err
+	}
+
+	guestAgent, err := s.GuestAgent()
+	if err != nil || !guestAgent {
+		return err
+	}
+
+	log.Log.V(3).Infof("Freezing vm %s file system before taking the snapshot", s.vm.Name)
+
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Freeze(s.vm.Name, unfreezeTimeout)
+}
+
+// Unfreeze thaws the guest filesystems frozen by Freeze
+func (s *vmSnapshotSource) Unfreeze() error {
+	if !s.Locked() {
+		return nil
+	}
+
+	online, err := s.Online()
+	if err != nil || !online {
+		return err
+	}
+
+	guestAgent, err := s.GuestAgent()
+	if err != nil || !guestAgent {
+		return err
+	}
+
+	log.Log.V(3).Infof("Unfreezing vm %s file system after taking the snapshot", s.vm.Name)
+
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Unfreeze(s.vm.Name)
+}
+
+// MemoryDump starts dumping the guest memory to fileName, unless the VMI already reports that dump, and returns
+// its status. It returns nil if the snapshot does not include a memory dump or the VM is not running.
+func (s *vmSnapshotSource) MemoryDump(fileName string) (*kubevirtv1.VirtualMachineInstanceMemoryDumpStatus, error) {
+	if s.snapshot.Spec.IncludeMemoryDump == nil || !*s.snapshot.Spec.IncludeMemoryDump {
+		return nil, nil
+	}
+
+	vmi, exists, err := s.getVMI()
+	if err != nil || !exists || !vmi.IsRunning() {
+		return nil, err
+	}
+
+	if vmi.Status.MemoryDump != nil && vmi.Status.MemoryDump.FileName == fileName {
+		return vmi.Status.MemoryDump, nil
+	}
+
+	log.Log.V(3).Infof("Dumping vm %s memory to %s before taking the snapshot", s.vm.Name, fileName)
+
+	err = s.controller.Client.VirtualMachineInstance(s.vm.Namespace).MemoryDump(s.vm.Name, &kubevirtv1.MemoryDumpOptions{FileName: fileName})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubevirtv1.VirtualMachineInstanceMemoryDumpStatus{
+		FileName: fileName,
+		Phase:    kubevirtv1.MemoryDumpInProgress,
+	}, nil
+}
+
+// ExternalSnapshot switches the disks of the given volumes of the running VM to the qcow2 overlays of the
+// snapshot name, unless the VMI already reports them on these overlays. It returns the external snapshots of
+// the volumes once all of them are switched, and nil while they are being switched. Hotplugged volumes are skipped.
+func (s *vmSnapshotSource) ExternalSnapshot(name string, volumes []string) (map[string]*kubevirtv1.ExternalSnapshotVolumeStatus, error) {
+	externalSnapshots := map[string]*kubevirtv1.ExternalSnapshotVolumeStatus{}
+	if len(volumes) == 0 {
+		return externalSnapshots, nil
+	}
+
+	vmi, exists, err := s.getVMI()
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists || !vmi.IsRunning() {
+		return nil, fmt.Errorf("vm %s is not running, its volumes can not be snapshotted", s.vm.Name)
+	}
+
+	overlay := util.ExternalSnapshotOverlay(name)
+	var pending []string
+	for _, volume := range volumes {
+		volumeStatus := getVolumeStatus(vmi, volume)
+		if volumeStatus != nil && volumeStatus.HotplugVolume != nil {
+			continue
+		}
+
+		if volumeStatus != nil && volumeStatus.ExternalSnapshot != nil && volumeStatus.ExternalSnapshot.ActiveFile == overlay {
+			externalSnapshots[volume] = volumeStatus.ExternalSnapshot.DeepCopy()
+			continue
+		}
+
+		pending = append(pending, volume)
+	}
+
+	if len(pending) == 0 {
+		return externalSnapshots, nil
+	}
+
+	log.Log.V(3).Infof("Switching volumes %v of vm %s to overlay %s", pending, s.vm.Name, overlay)
+
+	options := &kubevirtv1.ExternalSnapshotOptions{Name: name, Volumes: pending}
+	return nil, s.controller.Client.VirtualMachineInstance(s.vm.Namespace).ExternalSnapshot(s.vm.Name, options)
+}
+
+func getVolumeStatus(vmi *kubevirtv1.VirtualMachineInstance, volumeName string) *kubevirtv1.VolumeStatus {
+	for i := range vmi.Status.VolumeStatus {
+		if vmi.Status.VolumeStatus[i].Name == volumeName {
+			return &vmi.Status.VolumeStatus[i]
+		}
+	}
+	return nil
+}
+
+// MemoryDumpClaimName returns the PVC the memory dumps of the VM are written to
+func (s *vmSnapshotSource) MemoryDumpClaimName() string {
+	if memoryDump := s.vm.Spec.Template.Spec.Domain.Devices.MemoryDump; memoryDump != nil {
+		return memoryDump.ClaimName
+	}
+	return ""
+}
+
 func (s *vmSnapshotSource) PersistentVolumeClaims() map[string]string {
-	return getPVCsFromVolumes(s.vm.Spec.Template.Spec.Volumes)
+	pvcs := getPVCsFromVolumes(s.vm.Spec.Template.Spec.Volumes)
+	for _, volumeName := range s.ExcludedVolumes() {
+		delete(pvcs, volumeName)
+	}
+	return pvcs
+}
+
+// ExcludedVolumes returns the volumes whose disks are excluded from snapshots
+func (s *vmSnapshotSource) ExcludedVolumes() []string {
+	var volumes []string
+	for _, volume := range s.vm.Spec.Template.Spec.Volumes {
+		if isVolumeExcluded(&s.vm.Spec.Template.Spec, volume.Name) {
+			volumes = append(volumes, volume.Name)
+		}
+	}
+	return volumes
 }
 
 func (s *vmSnapshotSource) pvcNames() sets.String {
@@ -923,6 +1543,15 @@ func (s *vmSnapshotSource) pvcNames() sets.String {
 	return ss
 }
 
+func isVolumeExcluded(spec *kubevirtv1.VirtualMachineInstanceSpec, volumeName string) bool {
+	for _, disk := range spec.Domain.Devices.Disks {
+		if disk.Name == volumeName {
+			return disk.ExcludeFromSnapshot != nil && *disk.ExcludeFromSnapshot
+		}
+	}
+	return false
+}
+
 func getPVCsFromVolumes(volumes []kubevirtv1.Volume) map[string]string {
 	pvcs := map[string]string{}
 
@@ -39,6 +39,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/util/status"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
@@ -74,6 +75,8 @@ type VMSnapshotController struct {
 
 	ResyncPeriod time.Duration
 
+	ClusterConfig *virtconfig.ClusterConfig
+
 	vmSnapshotQueue        workqueue.RateLimitingInterface
 	vmSnapshotContentQueue workqueue.RateLimitingInterface
 	crdQueue               workqueue.RateLimitingInterface
@@ -32,6 +32,7 @@ import (
 	cdiv1alpha1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/util/status"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
@@ -106,6 +107,20 @@ var _ = Describe("Snapshot controlleer", func() {
 		}
 	}
 
+	createRunningVMI := func(vm *v1.VirtualMachine, agentConnected bool) *v1.VirtualMachineInstance {
+		vmi := createVMI(vm)
+		vmi.Status.Phase = v1.Running
+		if agentConnected {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: corev1.ConditionTrue,
+				},
+			}
+		}
+		return vmi
+	}
+
 	createPersistentVolumeClaims := func() []corev1.PersistentVolumeClaim {
 		return createPVCsForVM(createLockedVM())
 	}
@@ -174,6 +189,7 @@ var _ = Describe("Snapshot controlleer", func() {
 
 		var ctrl *gomock.Controller
 		var vmInterface *kubecli.MockVirtualMachineInterface
+		var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
 		var vmSnapshotSource *framework.FakeControllerSource
 		var vmSnapshotInformer cache.SharedIndexInformer
 		var vmSnapshotContentSource *framework.FakeControllerSource
@@ -196,6 +212,7 @@ var _ = Describe("Snapshot controlleer", func() {
 		var crdSource *framework.FakeControllerSource
 		var dvInformer cache.SharedIndexInformer
 		var dvSource *framework.FakeControllerSource
+		var configMapInformer cache.SharedIndexInformer
 		var stop chan struct{}
 		var controller *VMSnapshotController
 		var recorder *record.FakeRecorder
@@ -237,6 +254,7 @@ var _ = Describe("Snapshot controlleer", func() {
 			ctrl = gomock.NewController(GinkgoT())
 			virtClient := kubecli.NewMockKubevirtClient(ctrl)
 			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 
 			vmSnapshotInformer, vmSnapshotSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshot{}, cache.Indexers{
 				"vm": func(obj interface{}) ([]string, error) {
@@ -273,6 +291,9 @@ var _ = Describe("Snapshot controlleer", func() {
 
 			recorder = record.NewFakeRecorder(100)
 
+			var config *virtconfig.ClusterConfig
+			config, configMapInformer, _, _ = testutils.NewFakeClusterConfig(&corev1.ConfigMap{})
+
 			controller = &VMSnapshotController{
 				Client:                    virtClient,
 				VMSnapshotInformer:        vmSnapshotInformer,
@@ -286,6 +307,7 @@ var _ = Describe("Snapshot controlleer", func() {
 				DVInformer:                dvInformer,
 				Recorder:                  recorder,
 				ResyncPeriod:              60 * time.Second,
+				ClusterConfig:             config,
 				vmStatusUpdater:           status.NewVMStatusUpdater(virtClient),
 			}
 			controller.Init()
@@ -305,6 +327,7 @@ var _ = Describe("Snapshot controlleer", func() {
 
 			// Set up mock client
 			virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
 
 			vmSnapshotClient = kubevirtfake.NewSimpleClientset()
 			virtClient.EXPECT().VirtualMachineSnapshot(testNamespace).
@@ -435,6 +458,71 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			DescribeTable("should initialize VirtualMachineSnapshot status of a running VM", func(agentConnected bool, indication snapshotv1.Indication) {
+				vmSnapshot := createVMSnapshot()
+				vm := createLockedVM()
+				updatedSnapshot := vmSnapshot.DeepCopy()
+				updatedSnapshot.ResourceVersion = "1"
+				updatedSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{
+					ReadyToUse: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Source locked and operation in progress"),
+						newReadyCondition(corev1.ConditionFalse, "Not ready"),
+					},
+					Indications: []snapshotv1.Indication{snapshotv1.VMSnapshotOnlineSnapshotIndication, indication},
+				}
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, agentConnected))
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			},
+				Entry("with guest agent", true, snapshotv1.VMSnapshotGuestAgentIndication),
+				Entry("without guest agent", false, snapshotv1.VMSnapshotNoGuestAgentIndication),
+			)
+
+			It("should indicate a memory dump in the VirtualMachineSnapshot status of a running VM", func() {
+				vmSnapshot := createVMSnapshot()
+				vmSnapshot.Spec.IncludeMemoryDump = &t
+				vm := createLockedVM()
+				updatedSnapshot := vmSnapshot.DeepCopy()
+				updatedSnapshot.ResourceVersion = "1"
+				updatedSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{
+					ReadyToUse: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Source locked and operation in progress"),
+						newReadyCondition(corev1.ConditionFalse, "Not ready"),
+					},
+					Indications: []snapshotv1.Indication{
+						snapshotv1.VMSnapshotOnlineSnapshotIndication,
+						snapshotv1.VMSnapshotGuestAgentIndication,
+						snapshotv1.VMSnapshotMemoryDumpIndication,
+					},
+				}
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
+			It("should unfreeze and unlock source VirtualMachine", func() {
+				vmSnapshot := createVMSnapshotSuccess()
+				vm := createLockedVM()
+				updatedVM := vm.DeepCopy()
+				updatedVM.Finalizers = []string{}
+				updatedVM.ResourceVersion = "1"
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+				vmInterface.EXPECT().Update(updatedVM).Return(updatedVM, nil)
+				statusUpdate := updatedVM.DeepCopy()
+				statusUpdate.Status.SnapshotInProgress = nil
+				vmInterface.EXPECT().UpdateStatus(statusUpdate).Return(statusUpdate, nil)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
 			It("should unlock source VirtualMachine", func() {
 				vmSnapshot := createVMSnapshotSuccess()
 				vm := createLockedVM()
@@ -588,6 +676,27 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			It("should lock source if VMI is running", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createVM()
+				vm.Spec.Running = &t
+				vmStatusUpdate := vm.DeepCopy()
+				vmStatusUpdate.ResourceVersion = "1"
+				vmStatusUpdate.Status.SnapshotInProgress = &vmSnapshotName
+				vmUpdate := vmStatusUpdate.DeepCopy()
+				vmUpdate.Finalizers = []string{"snapshot.kubevirt.io/snapshot-source-protection"}
+
+				vmiSource.Add(createRunningVMI(vm, true))
+				// the virt-launcher pod of the running VMI is not blocking the lock
+				pods := createPodsUsingPVCs(vm)
+				podSource.Add(&pods[0])
+				vmSource.Add(vm)
+				vmInterface.EXPECT().UpdateStatus(vmStatusUpdate).Return(vmStatusUpdate, nil)
+				vmInterface.EXPECT().Update(vmUpdate).Return(vmUpdate, nil)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
 			It("should not lock source if pods using PVCs", func() {
 				vmSnapshot := createVMSnapshotInProgress()
 				vm := createVM()
@@ -645,6 +754,421 @@ var _ = Describe("Snapshot controlleer", func() {
 				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
 			})
 
+			It("should leave volumes excluded from snapshots out of VirtualMachineSnapshotContent", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createLockedVM()
+				vm.Spec.Template.Spec.Domain.Devices.Disks[0].ExcludeFromSnapshot = &t
+				storageClass := createStorageClass()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.Spec.Source.VirtualMachine.Spec.Template.Spec.Domain.Devices.Disks[0].ExcludeFromSnapshot = &t
+				vmSnapshotContent.Spec.VolumeBackups = nil
+				vmSnapshotContent.Spec.ExcludedVolumes = []string{"disk1"}
+
+				vmSource.Add(vm)
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+			})
+
+			It("should freeze VMI before creating VirtualMachineSnapshotContent", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Status.Indications = []snapshotv1.Indication{
+					snapshotv1.VMSnapshotOnlineSnapshotIndication,
+					snapshotv1.VMSnapshotGuestAgentIndication,
+				}
+				vm := createLockedVM()
+				storageClass := createStorageClass()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVMSnapshotContent()
+
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				vmiInterface.EXPECT().Freeze(vm.Name, unfreezeTimeout).Return(nil)
+				expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+			})
+
+			It("should not create VirtualMachineSnapshotContent if freeze fails", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createLockedVM()
+
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmiInterface.EXPECT().Freeze(vm.Name, unfreezeTimeout).Return(fmt.Errorf("freeze failed"))
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
+			Context("with a memory dump", func() {
+				createMemoryDumpVM := func() *v1.VirtualMachine {
+					vm := createLockedVM()
+					vm.Spec.Template.Spec.Domain.Devices.MemoryDump = &v1.MemoryDump{ClaimName: "memory-dump"}
+					return vm
+				}
+
+				createMemoryDumpVMI := func(vm *v1.VirtualMachine, fileName string, phase v1.MemoryDumpPhase) *v1.VirtualMachineInstance {
+					vmi := createRunningVMI(vm, true)
+					vmi.Status.MemoryDump = &v1.VirtualMachineInstanceMemoryDumpStatus{
+						FileName: fileName,
+						Phase:    phase,
+						Message:  "no space left on device",
+					}
+					return vmi
+				}
+
+				It("should dump the memory before creating VirtualMachineSnapshotContent", func() {
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.IncludeMemoryDump = &t
+					vm := createMemoryDumpVM()
+
+					vmSource.Add(vm)
+					vmiSource.Add(createRunningVMI(vm, true))
+					vmiInterface.EXPECT().Freeze(vm.Name, unfreezeTimeout).Return(nil)
+					vmiInterface.EXPECT().MemoryDump(vm.Name, &v1.MemoryDumpOptions{FileName: getMemoryDumpFileName(vmSnapshot)}).Return(nil)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(vmSnapshotClient.Actions()).To(BeEmpty())
+				})
+
+				It("should wait for the memory dump in progress", func() {
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.IncludeMemoryDump = &t
+					vm := createMemoryDumpVM()
+
+					vmSource.Add(vm)
+					vmiSource.Add(createMemoryDumpVMI(vm, getMemoryDumpFileName(vmSnapshot), v1.MemoryDumpInProgress))
+					vmiInterface.EXPECT().Freeze(vm.Name, unfreezeTimeout).Return(nil)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(vmSnapshotClient.Actions()).To(BeEmpty())
+				})
+
+				It("should create VirtualMachineSnapshotContent with the completed memory dump", func() {
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.IncludeMemoryDump = &t
+					vm := createMemoryDumpVM()
+					storageClass := createStorageClass()
+					volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+					pvcs := createPersistentVolumeClaims()
+					vmSnapshotContent := createVirtualMachineSnapshotContent(vmSnapshot, createMemoryDumpVM())
+					vmSnapshotContent.Spec.MemoryDump = &snapshotv1.MemoryDumpBackup{
+						ClaimName: "memory-dump",
+						FileName:  getMemoryDumpFileName(vmSnapshot),
+					}
+
+					vmSource.Add(vm)
+					vmiSource.Add(createMemoryDumpVMI(vm, getMemoryDumpFileName(vmSnapshot), v1.MemoryDumpCompleted))
+					storageClassSource.Add(storageClass)
+					volumeSnapshotClassSource.Add(volumeSnapshotClass)
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+					vmiInterface.EXPECT().Freeze(vm.Name, unfreezeTimeout).Return(nil)
+					expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+				})
+
+				It("should fail the VirtualMachineSnapshot if the memory dump failed", func() {
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.IncludeMemoryDump = &t
+					vm := createMemoryDumpVM()
+					reason := "Memory dump failed: no space left on device"
+					updatedSnapshot := vmSnapshot.DeepCopy()
+					updatedSnapshot.ResourceVersion = "1"
+					updatedSnapshot.Status.Error = newError(reason)
+					updatedSnapshot.Status.Conditions = []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionFalse, reason),
+						newReadyCondition(corev1.ConditionFalse, reason),
+					}
+
+					vmSource.Add(vm)
+					vmiSource.Add(createMemoryDumpVMI(vm, getMemoryDumpFileName(vmSnapshot), v1.MemoryDumpFailed))
+					vmiInterface.EXPECT().Freeze(vm.Name, unfreezeTimeout).Return(nil)
+					expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+				})
+			})
+
+			Context("with external snapshots", func() {
+				enableExternalSnapshots := func() {
+					testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+						Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.ExternalSnapshotsGate},
+					})
+				}
+
+				createExternalSnapshotVMI := func(vm *v1.VirtualMachine, activeFile string, backingChain ...string) *v1.VirtualMachineInstance {
+					vmi := createRunningVMI(vm, false)
+					vmi.Status.VolumeStatus = []v1.VolumeStatus{
+						{
+							Name: "disk1",
+							ExternalSnapshot: &v1.ExternalSnapshotVolumeStatus{
+								ActiveFile:   activeFile,
+								BackingChain: backingChain,
+							},
+						},
+					}
+					return vmi
+				}
+
+				It("should switch volumes without VolumeSnapshotClass to overlays before creating VirtualMachineSnapshotContent", func() {
+					enableExternalSnapshots()
+					vmSnapshot := createVMSnapshotInProgress()
+					vm := createLockedVM()
+					pvcs := createPersistentVolumeClaims()
+
+					vmSource.Add(vm)
+					vmiSource.Add(createRunningVMI(vm, false))
+					storageClassSource.Add(createStorageClass())
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+					vmiInterface.EXPECT().ExternalSnapshot(vm.Name, &v1.ExternalSnapshotOptions{
+						Name:    getExternalSnapshotName(vmSnapshot),
+						Volumes: []string{"disk1"},
+					}).Return(nil)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(vmSnapshotClient.Actions()).To(BeEmpty())
+				})
+
+				createExternalSnapshotContent := func(file, overlay string, backingChain ...string) *snapshotv1.VirtualMachineSnapshotContent {
+					content := createVMSnapshotContent()
+					content.Spec.VolumeBackups[0].VolumeSnapshotName = nil
+					content.Spec.VolumeBackups[0].ExternalSnapshot = &snapshotv1.ExternalSnapshotBackup{
+						File:         file,
+						BackingChain: backingChain,
+						Overlay:      overlay,
+					}
+					return content
+				}
+
+				createDeletedExternalSnapshotContent := func() *snapshotv1.VirtualMachineSnapshotContent {
+					content := createExternalSnapshotContent("vmsnapshot-earlier.qcow2", "vmsnapshot-snapshot-uid.qcow2", "disk.img")
+					content.Finalizers = append(content.Finalizers, "snapshot.kubevirt.io/external-snapshot-protection")
+					content.DeletionTimestamp = timeFunc()
+					return content
+				}
+
+				It("should create VirtualMachineSnapshotContent with the external snapshots", func() {
+					enableExternalSnapshots()
+					vmSnapshot := createVMSnapshotInProgress()
+					vm := createLockedVM()
+					pvcs := createPersistentVolumeClaims()
+					vmSnapshotContent := createVMSnapshotContent()
+					vmSnapshotContent.Finalizers = append(vmSnapshotContent.Finalizers, "snapshot.kubevirt.io/external-snapshot-protection")
+					vmSnapshotContent.Spec.VolumeBackups[0].VolumeSnapshotName = nil
+					vmSnapshotContent.Spec.VolumeBackups[0].ExternalSnapshot = &snapshotv1.ExternalSnapshotBackup{
+						File:         "vmsnapshot-earlier.qcow2",
+						BackingChain: []string{"disk.img"},
+						Overlay:      "vmsnapshot-snapshot-uid.qcow2",
+					}
+
+					vmSource.Add(vm)
+					vmiSource.Add(createExternalSnapshotVMI(vm, "vmsnapshot-snapshot-uid.qcow2", "vmsnapshot-earlier.qcow2", "disk.img"))
+					storageClassSource.Add(createStorageClass())
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+					expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+				})
+
+				It("should commit the overlay of a deleted VirtualMachineSnapshotContent", func() {
+					enableExternalSnapshots()
+					vm := createLockedVM()
+					vmi := createExternalSnapshotVMI(vm, "vmsnapshot-snapshot-uid.qcow2", "vmsnapshot-earlier.qcow2", "disk.img")
+					vmi.Spec.Volumes = vm.Spec.Template.Spec.Volumes
+
+					vmSource.Add(vm)
+					vmiSource.Add(vmi)
+					vmiInterface.EXPECT().BlockCommit(vm.Name, &v1.BlockCommitOptions{
+						Volumes: []string{"disk1"},
+						Overlay: "vmsnapshot-snapshot-uid.qcow2",
+					}).Return(nil)
+					addVirtualMachineSnapshotContent(createDeletedExternalSnapshotContent())
+					controller.processVMSnapshotContentWorkItem()
+					Expect(vmSnapshotClient.Actions()).To(BeEmpty())
+					Expect(mockVMSnapshotContentQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+				})
+
+				It("should wait for the VM to run to commit the overlay of a deleted VirtualMachineSnapshotContent", func() {
+					enableExternalSnapshots()
+					vmSource.Add(createLockedVM())
+					addVirtualMachineSnapshotContent(createDeletedExternalSnapshotContent())
+					controller.processVMSnapshotContentWorkItem()
+					Expect(vmSnapshotClient.Actions()).To(BeEmpty())
+					Expect(mockVMSnapshotContentQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+				})
+
+				It("should move later snapshots to the committed file and remove the finalizer", func() {
+					enableExternalSnapshots()
+					vm := createLockedVM()
+					vmi := createExternalSnapshotVMI(vm, "vmsnapshot-later.qcow2", "vmsnapshot-earlier.qcow2", "disk.img")
+					vmi.Spec.Volumes = vm.Spec.Template.Spec.Volumes
+					content := createDeletedExternalSnapshotContent()
+					laterContent := createExternalSnapshotContent("vmsnapshot-snapshot-uid.qcow2", "vmsnapshot-later.qcow2", "vmsnapshot-earlier.qcow2", "disk.img")
+					laterContent.Name = "vmsnapshot-content-later"
+
+					updatedLaterContent := createExternalSnapshotContent("vmsnapshot-earlier.qcow2", "vmsnapshot-later.qcow2", "disk.img")
+					updatedLaterContent.Name = laterContent.Name
+					updatedContent := content.DeepCopy()
+					updatedContent.ResourceVersion = "1"
+					updatedContent.Finalizers = []string{"snapshot.kubevirt.io/vmsnapshotcontent-protection"}
+
+					vmSource.Add(vm)
+					vmiSource.Add(vmi)
+					var updates []*snapshotv1.VirtualMachineSnapshotContent
+					vmSnapshotClient.Fake.PrependReactor("update", "virtualmachinesnapshotcontents", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						update, ok := action.(testing.UpdateAction)
+						Expect(ok).To(BeTrue())
+
+						updates = append(updates, update.GetObject().(*snapshotv1.VirtualMachineSnapshotContent))
+						return true, update.GetObject(), nil
+					})
+					addVirtualMachineSnapshotContent(content)
+					// the later content is only in the store, so it is not processed itself
+					Expect(vmSnapshotContentInformer.GetStore().Add(laterContent)).To(Succeed())
+					controller.processVMSnapshotContentWorkItem()
+					Expect(updates).To(Equal([]*snapshotv1.VirtualMachineSnapshotContent{updatedLaterContent, updatedContent}))
+				})
+
+				It("should switch volumes on the overlay of an earlier snapshot to a new overlay", func() {
+					enableExternalSnapshots()
+					vmSnapshot := createVMSnapshotInProgress()
+					vm := createLockedVM()
+					pvcs := createPersistentVolumeClaims()
+
+					vmSource.Add(vm)
+					vmiSource.Add(createExternalSnapshotVMI(vm, "vmsnapshot-earlier.qcow2", "disk.img"))
+					storageClassSource.Add(createStorageClass())
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+					vmiInterface.EXPECT().ExternalSnapshot(vm.Name, &v1.ExternalSnapshotOptions{
+						Name:    getExternalSnapshotName(vmSnapshot),
+						Volumes: []string{"disk1"},
+					}).Return(nil)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(vmSnapshotClient.Actions()).To(BeEmpty())
+				})
+
+				It("should not switch volumes with a VolumeSnapshotClass to overlays", func() {
+					enableExternalSnapshots()
+					vmSnapshot := createVMSnapshotInProgress()
+					vm := createLockedVM()
+					pvcs := createPersistentVolumeClaims()
+					vmSnapshotContent := createVMSnapshotContent()
+
+					vmSource.Add(vm)
+					vmiSource.Add(createRunningVMI(vm, false))
+					storageClassSource.Add(createStorageClass())
+					volumeSnapshotClassSource.Add(&createVolumeSnapshotClasses()[0])
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+					expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+				})
+
+				It("should not switch volumes to overlays without the feature gate", func() {
+					vmSnapshot := createVMSnapshotInProgress()
+					vm := createLockedVM()
+					pvcs := createPersistentVolumeClaims()
+					vmSnapshotContent := createVMSnapshotContent()
+					vmSnapshotContent.Spec.VolumeBackups = nil
+
+					vmSource.Add(vm)
+					vmiSource.Add(createRunningVMI(vm, false))
+					storageClassSource.Add(createStorageClass())
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+					expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+				})
+
+				It("should not switch block volumes to overlays", func() {
+					enableExternalSnapshots()
+					vmSnapshot := createVMSnapshotInProgress()
+					vm := createLockedVM()
+					pvcs := createPersistentVolumeClaims()
+					blockMode := corev1.PersistentVolumeBlock
+					pvcs[0].Spec.VolumeMode = &blockMode
+					vmSnapshotContent := createVMSnapshotContent()
+					vmSnapshotContent.Spec.VolumeBackups = nil
+
+					vmSource.Add(vm)
+					vmiSource.Add(createRunningVMI(vm, false))
+					storageClassSource.Add(createStorageClass())
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+					expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+				})
+			})
+
+			It("should unfreeze VMI once all VolumeSnapshots are created", func() {
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					CreationTime: timeFunc(),
+					ReadyToUse:   &f,
+				}
+
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Status.Indications = []snapshotv1.Indication{
+					snapshotv1.VMSnapshotOnlineSnapshotIndication,
+					snapshotv1.VMSnapshotGuestAgentIndication,
+				}
+				updatedSnapshot := vmSnapshot.DeepCopy()
+				updatedSnapshot.ResourceVersion = "1"
+				updatedSnapshot.Status.SourceUID = &vmUID
+				updatedSnapshot.Status.VirtualMachineSnapshotContentName = &vmSnapshotContent.Name
+				updatedSnapshot.Status.CreationTime = timeFunc()
+				updatedSnapshot.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Source locked and operation in progress"),
+					newReadyCondition(corev1.ConditionFalse, "Not ready"),
+				}
+
+				vm := createLockedVM()
+
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+				vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
 			It("should update VirtualMachineSnapshotStatus", func() {
 				vmSnapshotContent := createVMSnapshotContent()
 				vmSnapshotContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
@@ -920,6 +1444,47 @@ var _ = Describe("Snapshot controlleer", func() {
 				Expect(updateCalled).To(BeTrue())
 			})
 
+			It("should set volume snapshot status to true for filesystem volumes without VolumeSnapshotClass with external snapshots", func() {
+				testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+					Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.ExternalSnapshotsGate},
+				})
+				vm := createVM()
+				pvcs := createPVCsForVM(vm)
+
+				updateCalled := false
+				vmInterface.EXPECT().
+					UpdateStatus(gomock.Any()).
+					Do(func(objs ...interface{}) {
+						vm := objs[0].(*v1.VirtualMachine)
+
+						Expect(vm.Status.VolumeSnapshotStatuses).To(HaveLen(1))
+						Expect(vm.Status.VolumeSnapshotStatuses[0].Enabled).To(BeTrue())
+						Expect(vm.Status.VolumeSnapshotStatuses[0].Reason).To(ContainSubstring("external snapshots"))
+						updateCalled = true
+					})
+
+				dv := cdiv1alpha1.DataVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "alpine-dv",
+						Namespace: testNamespace,
+					},
+					Spec: vm.Spec.DataVolumeTemplates[0].Spec,
+				}
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				dvSource.Add(&dv)
+				storageClassSource.Add(createStorageClass())
+				vmSource.Add(vm)
+				mockVMQueue.Add(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name))
+				syncCaches(stop)
+
+				controller.processVMWorkItem()
+				controller.processVMSnapshotStatusWorkItem()
+
+				Expect(updateCalled).To(BeTrue())
+			})
+
 			It("should set volume snapshot status to true for each supported volume type", func() {
 				vm := createVM()
 				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
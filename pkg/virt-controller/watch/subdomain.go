@@ -0,0 +1,273 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/net/dns"
+)
+
+// subdomainAppLabelValue marks the headless services which are managed by the SubdomainController
+const subdomainAppLabelValue = "virt-subdomain"
+
+// SubdomainController publishes the VMIs which set a subdomain in the cluster DNS, as
+// <hostname>.<subdomain>.<namespace>.svc. It manages a headless service per subdomain, named
+// after it, and fills its endpoints with the address of the current pod of each VMI, so the
+// record follows the VMI across live migrations, whose target pods don't keep the hostname.
+// A service with the name of the subdomain which is not managed by KubeVirt is left alone.
+type SubdomainController struct {
+	clientset   kubecli.KubevirtClient
+	Queue       workqueue.RateLimitingInterface
+	vmiInformer cache.SharedIndexInformer
+	podInformer cache.SharedIndexInformer
+}
+
+func NewSubdomainController(clientset kubecli.KubevirtClient, vmiInformer cache.SharedIndexInformer, podInformer cache.SharedIndexInformer) *SubdomainController {
+	c := &SubdomainController{
+		clientset:   clientset,
+		Queue:       workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		vmiInformer: vmiInformer,
+		podInformer: podInformer,
+	}
+
+	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueVMI,
+		DeleteFunc: c.enqueueVMI,
+		UpdateFunc: c.updateVMI,
+	})
+
+	return c
+}
+
+func (c *SubdomainController) updateVMI(old, curr interface{}) {
+	c.enqueueVMI(old)
+	c.enqueueVMI(curr)
+}
+
+func (c *SubdomainController) enqueueVMI(obj interface{}) {
+	vmi, ok := obj.(*virtv1.VirtualMachineInstance)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		vmi, ok = tombstone.Obj.(*virtv1.VirtualMachineInstance)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("tombstone contained object that is not a vmi %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+	if vmi.Spec.Subdomain != "" {
+		c.Queue.Add(vmi.Namespace + "/" + vmi.Spec.Subdomain)
+	}
+}
+
+// Run runs the passed in SubdomainController.
+func (c *SubdomainController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting subdomain controller.")
+
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.podInformer.HasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping subdomain controller.")
+}
+
+func (c *SubdomainController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *SubdomainController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+
+	if err := c.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing subdomain %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed subdomain %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *SubdomainController) execute(key string) error {
+	namespace, subdomain, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := c.subdomainAddresses(namespace, subdomain)
+	if err != nil {
+		return err
+	}
+
+	service, err := c.clientset.CoreV1().Services(namespace).Get(context.Background(), subdomain, v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if len(addresses) == 0 {
+			return nil
+		}
+		service, err = c.clientset.CoreV1().Services(namespace).Create(context.Background(), newSubdomainService(namespace, subdomain), v1.CreateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	if service.Labels[virtv1.AppLabel] != subdomainAppLabelValue {
+		log.Log.V(4).Infof("service %s is not managed by KubeVirt, leaving subdomain %s to it", key, subdomain)
+		return nil
+	}
+
+	if len(addresses) == 0 {
+		// the endpoints are owned by the service and garbage collected with it
+		err = c.clientset.CoreV1().Services(namespace).Delete(context.Background(), subdomain, v1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return c.syncEndpoints(service, addresses)
+}
+
+// subdomainAddresses returns the addresses of the current pods of the VMIs in the subdomain, sorted by IP
+func (c *SubdomainController) subdomainAddresses(namespace, subdomain string) ([]k8sv1.EndpointAddress, error) {
+	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []k8sv1.EndpointAddress{}
+	for _, obj := range objs {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		if vmi.Spec.Subdomain != subdomain || vmi.IsFinal() || vmi.DeletionTimestamp != nil {
+			continue
+		}
+		pod, err := controller.CurrentVMIPod(vmi, c.podInformer)
+		if err != nil {
+			return nil, err
+		}
+		if pod == nil {
+			continue
+		}
+		for _, ip := range podIPs(pod) {
+			addresses = append(addresses, k8sv1.EndpointAddress{
+				IP:       ip,
+				Hostname: dns.SanitizeHostname(vmi),
+				NodeName: &pod.Spec.NodeName,
+				TargetRef: &k8sv1.ObjectReference{
+					Kind:      "VirtualMachineInstance",
+					Namespace: vmi.Namespace,
+					Name:      vmi.Name,
+					UID:       vmi.UID,
+				},
+			})
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].IP < addresses[j].IP
+	})
+	return addresses, nil
+}
+
+func podIPs(pod *k8sv1.Pod) []string {
+	ips := []string{}
+	for _, podIP := range pod.Status.PodIPs {
+		ips = append(ips, podIP.IP)
+	}
+	if len(ips) == 0 && pod.Status.PodIP != "" {
+		ips = append(ips, pod.Status.PodIP)
+	}
+	return ips
+}
+
+func (c *SubdomainController) syncEndpoints(service *k8sv1.Service, addresses []k8sv1.EndpointAddress) error {
+	subsets := []k8sv1.EndpointSubset{{Addresses: addresses}}
+
+	endpoints, err := c.clientset.CoreV1().Endpoints(service.Namespace).Get(context.Background(), service.Name, v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		endpoints = &k8sv1.Endpoints{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      service.Name,
+				Namespace: service.Namespace,
+				Labels:    service.Labels,
+				OwnerReferences: []v1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "Service",
+					Name:       service.Name,
+					UID:        service.UID,
+				}},
+			},
+			Subsets: subsets,
+		}
+		_, err = c.clientset.CoreV1().Endpoints(service.Namespace).Create(context.Background(), endpoints, v1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(endpoints.Subsets, subsets) {
+		return nil
+	}
+	endpoints = endpoints.DeepCopy()
+	endpoints.Subsets = subsets
+	_, err = c.clientset.CoreV1().Endpoints(service.Namespace).Update(context.Background(), endpoints, v1.UpdateOptions{})
+	return err
+}
+
+// newSubdomainService returns a headless service without selector, whose endpoints are set by the controller
+func newSubdomainService(namespace, subdomain string) *k8sv1.Service {
+	return &k8sv1.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      subdomain,
+			Namespace: namespace,
+			Labels: map[string]string{
+				virtv1.AppLabel: subdomainAppLabelValue,
+			},
+		},
+		Spec: k8sv1.ServiceSpec{
+			ClusterIP: k8sv1.ClusterIPNone,
+		},
+	}
+}
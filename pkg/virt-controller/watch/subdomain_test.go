@@ -0,0 +1,162 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package watch
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Subdomain controller", func() {
+	const (
+		namespace = "default"
+		subdomain = "mysubdomain"
+	)
+
+	var ctrl *gomock.Controller
+	var vmiInformer cache.SharedIndexInformer
+	var podInformer cache.SharedIndexInformer
+	var kubeClient *fake.Clientset
+	var controller *SubdomainController
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+		vmiInformer, _ = testutils.NewFakeInformerFor(&virtv1.VirtualMachineInstance{})
+		podInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Pod{})
+		controller = NewSubdomainController(virtClient, vmiInformer, podInformer)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	newVMI := func(name, nodeName string) *virtv1.VirtualMachineInstance {
+		vmi := virtv1.NewMinimalVMIWithNS(namespace, name)
+		vmi.UID = types.UID(name)
+		vmi.Spec.Subdomain = subdomain
+		vmi.Status.Phase = virtv1.Running
+		vmi.Status.NodeName = nodeName
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+		return vmi
+	}
+
+	newPod := func(vmi *virtv1.VirtualMachineInstance, name, nodeName, ip string) {
+		pod := &k8sv1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				OwnerReferences: []v1.OwnerReference{*v1.NewControllerRef(vmi, virtv1.VirtualMachineInstanceGroupVersionKind)},
+			},
+			Spec:   k8sv1.PodSpec{NodeName: nodeName},
+			Status: k8sv1.PodStatus{PodIP: ip},
+		}
+		Expect(podInformer.GetStore().Add(pod)).To(Succeed())
+	}
+
+	getEndpoints := func() *k8sv1.Endpoints {
+		endpoints, err := kubeClient.CoreV1().Endpoints(namespace).Get(context.Background(), subdomain, v1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return endpoints
+	}
+
+	hostnamesAndIPs := func(endpoints *k8sv1.Endpoints) map[string]string {
+		Expect(endpoints.Subsets).To(HaveLen(1))
+		result := map[string]string{}
+		for _, address := range endpoints.Subsets[0].Addresses {
+			result[address.Hostname] = address.IP
+		}
+		return result
+	}
+
+	It("should publish the VMIs of the subdomain in a headless service", func() {
+		vmi1 := newVMI("vmi1", "node1")
+		newPod(vmi1, "pod1", "node1", "10.0.0.1")
+		vmi2 := newVMI("vmi2", "node2")
+		vmi2.Spec.Hostname = "myhost"
+		newPod(vmi2, "pod2", "node2", "10.0.0.2")
+		other := newVMI("other", "node1")
+		other.Spec.Subdomain = "othersubdomain"
+		newPod(other, "pod3", "node1", "10.0.0.3")
+
+		Expect(controller.execute(namespace + "/" + subdomain)).To(Succeed())
+
+		service, err := kubeClient.CoreV1().Services(namespace).Get(context.Background(), subdomain, v1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(service.Spec.ClusterIP).To(Equal(k8sv1.ClusterIPNone))
+		Expect(service.Spec.Selector).To(BeEmpty())
+		Expect(hostnamesAndIPs(getEndpoints())).To(Equal(map[string]string{
+			"vmi1":   "10.0.0.1",
+			"myhost": "10.0.0.2",
+		}))
+	})
+
+	It("should follow the VMI to its migration target pod", func() {
+		vmi := newVMI("vmi1", "node1")
+		newPod(vmi, "source", "node1", "10.0.0.1")
+		Expect(controller.execute(namespace + "/" + subdomain)).To(Succeed())
+		Expect(hostnamesAndIPs(getEndpoints())).To(Equal(map[string]string{"vmi1": "10.0.0.1"}))
+
+		newPod(vmi, "target", "node2", "10.0.0.2")
+		vmi.Status.NodeName = "node2"
+		Expect(controller.execute(namespace + "/" + subdomain)).To(Succeed())
+		Expect(hostnamesAndIPs(getEndpoints())).To(Equal(map[string]string{"vmi1": "10.0.0.2"}))
+	})
+
+	It("should delete the service when no VMI is left in the subdomain", func() {
+		vmi := newVMI("vmi1", "node1")
+		newPod(vmi, "pod1", "node1", "10.0.0.1")
+		Expect(controller.execute(namespace + "/" + subdomain)).To(Succeed())
+
+		Expect(vmiInformer.GetStore().Delete(vmi)).To(Succeed())
+		Expect(controller.execute(namespace + "/" + subdomain)).To(Succeed())
+		_, err := kubeClient.CoreV1().Services(namespace).Get(context.Background(), subdomain, v1.GetOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should leave a service which is not managed by KubeVirt alone", func() {
+		_, err := kubeClient.CoreV1().Services(namespace).Create(context.Background(), &k8sv1.Service{
+			ObjectMeta: v1.ObjectMeta{Name: subdomain, Namespace: namespace},
+			Spec:       k8sv1.ServiceSpec{ClusterIP: k8sv1.ClusterIPNone, Selector: map[string]string{"expose": "me"}},
+		}, v1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		vmi := newVMI("vmi1", "node1")
+		newPod(vmi, "pod1", "node1", "10.0.0.1")
+
+		Expect(controller.execute(namespace + "/" + subdomain)).To(Succeed())
+		_, err = kubeClient.CoreV1().Endpoints(namespace).Get(context.Background(), subdomain, v1.GetOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -21,8 +21,11 @@ package watch
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,19 +33,23 @@ import (
 	authv1 "k8s.io/api/authorization/v1"
 	k8score "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/util/status"
 )
 
@@ -57,6 +64,18 @@ const (
 	failureDeletingVmiErrFormat           = "Failure attempting to delete VMI: %v"
 )
 
+const (
+	// SuccessfulCPUHotplugReason is added in an event when new vCPU sockets are
+	// requested on a running VirtualMachineInstance.
+	SuccessfulCPUHotplugReason = "SuccessfulCPUHotplug"
+	// CPUHotplugMigrationReason is added in an event when a vCPU change could not be
+	// hotplugged and a migration was created to apply it instead.
+	CPUHotplugMigrationReason = "CPUHotplugMigration"
+	// SuccessfulMemoryHotplugReason is added in an event when additional guest memory
+	// is requested on a running VirtualMachineInstance.
+	SuccessfulMemoryHotplugReason = "SuccessfulMemoryHotplug"
+)
+
 func NewVMController(vmiInformer cache.SharedIndexInformer,
 	vmiVMInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
@@ -79,7 +98,8 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 		cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
-		statusUpdater: status.NewVMStatusUpdater(clientset),
+		statusUpdater:       status.NewVMStatusUpdater(clientset),
+		instancetypeMethods: instancetype.NewMethods(clientset),
 	}
 
 	c.vmiVMInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -123,6 +143,7 @@ type VMController struct {
 	dataVolumeExpectations *controller.UIDTrackingControllerExpectations
 	cloneAuthFunc          CloneAuthFunc
 	statusUpdater          *status.VMStatusUpdater
+	instancetypeMethods    instancetype.Methods
 }
 
 func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -262,8 +283,10 @@ func (c *VMController) execute(key string) error {
 	// Scale up or down, if all expected creates and deletes were report by the listener
 	if c.needsSync(key) && vm.ObjectMeta.DeletionTimestamp == nil {
 
-		dataVolumesReady, err := c.handleDataVolumes(vm, dataVolumes)
-		if err != nil {
+		vm, createErr = c.storeInstancetypeRevisions(vm)
+		if createErr != nil {
+			logger.Reason(createErr).Error("Failed to store instancetype and preference revisions.")
+		} else if dataVolumesReady, err := c.handleDataVolumes(vm, dataVolumes); err != nil {
 			createErr = err
 		} else if dataVolumesReady == true {
 			createErr = c.startStop(vm, vmi)
@@ -278,6 +301,18 @@ func (c *VMController) execute(key string) error {
 
 			createErr = c.handleVolumeRequests(vm, vmi)
 		}
+
+		if createErr == nil {
+			createErr = c.handleCPUHotplug(vm, vmi)
+		}
+
+		if createErr == nil {
+			createErr = c.handleMemoryHotplug(vm, vmi)
+		}
+
+		if createErr == nil {
+			createErr = c.syncIPClaims(vm, vmi)
+		}
 	}
 
 	// If the controller is going to be deleted and the orphan finalizer is the next one, release the VMIs. Don't update the status
@@ -300,6 +335,12 @@ func (c *VMController) execute(key string) error {
 		return err
 	}
 
+	err = c.handleCompletionTTL(vm)
+	if err != nil {
+		logger.Reason(err).Error("Deleting the completed VirtualMachine failed.")
+		return err
+	}
+
 	if createErr != nil {
 		return createErr
 	}
@@ -589,6 +630,205 @@ func (c *VMController) handleVolumeRequests(vm *virtv1.VirtualMachine, vmi *virt
 	return nil
 }
 
+// handleCPUHotplug propagates an increase of the CPU sockets in the VM template to
+// the running VMI. When plugging in the vCPUs live fails, the change is applied by
+// migrating the VMI instead.
+func (c *VMController) handleCPUHotplug(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil || vmi.DeletionTimestamp != nil || !vmi.IsRunning() {
+		return nil
+	}
+	if vmi.Spec.Domain.CPU == nil || vmi.Spec.Domain.CPU.MaxSockets == 0 || vm.Spec.Template.Spec.Domain.CPU == nil {
+		return nil
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	vmiCopy := vmi.DeepCopy()
+	desiredSockets := vm.Spec.Template.Spec.Domain.CPU.Sockets
+
+	cond := condManager.GetCondition(vmiCopy, virtv1.VirtualMachineInstanceVCPUChange)
+	switch {
+	case cond == nil:
+		if desiredSockets <= vmi.Spec.Domain.CPU.Sockets || desiredSockets > vmi.Spec.Domain.CPU.MaxSockets {
+			return nil
+		}
+		vmiCopy.Spec.Domain.CPU.Sockets = desiredSockets
+		vmiCopy.Status.Conditions = append(vmiCopy.Status.Conditions, virtv1.VirtualMachineInstanceCondition{
+			Type:               virtv1.VirtualMachineInstanceVCPUChange,
+			Status:             k8score.ConditionTrue,
+			LastProbeTime:      v1.Now(),
+			LastTransitionTime: v1.Now(),
+		})
+	case cond.Status == k8score.ConditionFalse && cond.Reason == virtv1.VirtualMachineInstanceReasonCPUHotplugFailed:
+		if !condManager.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceIsMigratable, k8score.ConditionTrue) {
+			return nil
+		}
+		if err := c.createCPUHotplugMigration(vmi); err != nil {
+			return err
+		}
+		cond.Status = k8score.ConditionTrue
+		cond.Reason = virtv1.VirtualMachineInstanceReasonCPUHotplugMigration
+		cond.Message = ""
+		cond.LastTransitionTime = v1.Now()
+		condManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceVCPUChange)
+		vmiCopy.Status.Conditions = append(vmiCopy.Status.Conditions, *cond)
+	default:
+		return nil
+	}
+
+	if err := c.patchVMIHotplug(vmi, vmiCopy, "/spec/domain/cpu", vmi.Spec.Domain.CPU, vmiCopy.Spec.Domain.CPU); err != nil {
+		return err
+	}
+
+	if cond == nil {
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulCPUHotplugReason, "Requested %d CPU sockets on virtual machine instance %s", desiredSockets, vmi.Name)
+	} else {
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, CPUHotplugMigrationReason, "Migrating virtual machine instance %s to apply the CPU change", vmi.Name)
+	}
+	return nil
+}
+
+func (c *VMController) createCPUHotplugMigration(vmi *virtv1.VirtualMachineInstance) error {
+	migration := &virtv1.VirtualMachineInstanceMigration{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fmt.Sprintf("kubevirt-cpu-hotplug-%s-%d", vmi.Name, vmi.Spec.Domain.CPU.Sockets),
+			Namespace: vmi.Namespace,
+		},
+		Spec: virtv1.VirtualMachineInstanceMigrationSpec{
+			VMIName: vmi.Name,
+		},
+	}
+	migrationClient := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace)
+	_, err := migrationClient.Create(migration)
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	// an earlier change to the same number of sockets left a migration with this name behind,
+	// only a migration which did not finish yet applies the current change
+	existing, err := migrationClient.Get(migration.Name, &v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !existing.IsFinal() {
+		return nil
+	}
+	if err := migrationClient.Delete(migration.Name, &v1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return fmt.Errorf("replacing the finished CPU hotplug migration %s", migration.Name)
+}
+
+// handleMemoryHotplug propagates an increase of the guest memory in the VM template
+// to the running VMI.
+func (c *VMController) handleMemoryHotplug(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil || vmi.DeletionTimestamp != nil || !vmi.IsRunning() {
+		return nil
+	}
+	if vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.MaxGuest == nil {
+		return nil
+	}
+	templateMemory := vm.Spec.Template.Spec.Domain.Memory
+	if templateMemory == nil || templateMemory.Guest == nil {
+		return nil
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if condManager.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceMemoryChange, k8score.ConditionTrue) {
+		return nil
+	}
+
+	desiredGuest := templateMemory.Guest
+	if desiredGuest.Cmp(*getVMIGuestMemory(vmi)) <= 0 || desiredGuest.Cmp(*vmi.Spec.Domain.Memory.MaxGuest) > 0 {
+		return nil
+	}
+
+	vmiCopy := vmi.DeepCopy()
+	guest := desiredGuest.DeepCopy()
+	vmiCopy.Spec.Domain.Memory.Guest = &guest
+	condManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceMemoryChange)
+	vmiCopy.Status.Conditions = append(vmiCopy.Status.Conditions, virtv1.VirtualMachineInstanceCondition{
+		Type:               virtv1.VirtualMachineInstanceMemoryChange,
+		Status:             k8score.ConditionTrue,
+		LastProbeTime:      v1.Now(),
+		LastTransitionTime: v1.Now(),
+	})
+
+	if err := c.patchVMIHotplug(vmi, vmiCopy, "/spec/domain/memory", vmi.Spec.Domain.Memory, vmiCopy.Spec.Domain.Memory); err != nil {
+		return err
+	}
+
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulMemoryHotplugReason, "Requested %s of guest memory on virtual machine instance %s", desiredGuest.String(), vmi.Name)
+	return nil
+}
+
+// getVMIGuestMemory returns the memory visible to the guest, which defaults to the
+// memory limit or request if not specified explicitly.
+func getVMIGuestMemory(vmi *virtv1.VirtualMachineInstance) *resource.Quantity {
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		return vmi.Spec.Domain.Memory.Guest
+	}
+	if limit, ok := vmi.Spec.Domain.Resources.Limits[k8score.ResourceMemory]; ok {
+		return &limit
+	}
+	request := vmi.Spec.Domain.Resources.Requests[k8score.ResourceMemory]
+	return &request
+}
+
+// patchVMIHotplug replaces the given part of the VMI spec together with the status,
+// guarded by tests against concurrent modifications.
+// syncIPClaims keeps the addresses claimed by the VirtualMachineInstance on the VirtualMachine, so that they are
+// requested again once the VirtualMachine restarts.
+func (c *VMController) syncIPClaims(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil {
+		return nil
+	}
+	ipClaims, exists := vmi.Annotations[virtv1.IPClaimsAnnotation]
+	if !exists || vm.Annotations[virtv1.IPClaimsAnnotation] == ipClaims {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{virtv1.IPClaimsAnnotation: ipClaims},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.clientset.VirtualMachine(vm.Namespace).Patch(vm.Name, types.MergePatchType, patch); err != nil {
+		log.Log.Object(vm).Reason(err).Error("Failed to store the IP claims")
+		return err
+	}
+	return nil
+}
+
+func (c *VMController) patchVMIHotplug(vmi, vmiCopy *virtv1.VirtualMachineInstance, specPath string, oldSpec, newSpec interface{}) error {
+	oldValue, err := json.Marshal(oldSpec)
+	if err != nil {
+		return err
+	}
+	newValue, err := json.Marshal(newSpec)
+	if err != nil {
+		return err
+	}
+	oldStatus, err := json.Marshal(vmi.Status)
+	if err != nil {
+		return err
+	}
+	newStatus, err := json.Marshal(vmiCopy.Status)
+	if err != nil {
+		return err
+	}
+
+	ops := []string{
+		fmt.Sprintf(`{ "op": "test", "path": "%s", "value": %s }`, specPath, string(oldValue)),
+		fmt.Sprintf(`{ "op": "replace", "path": "%s", "value": %s }`, specPath, string(newValue)),
+		fmt.Sprintf(`{ "op": "test", "path": "/status", "value": %s }`, string(oldStatus)),
+		fmt.Sprintf(`{ "op": "replace", "path": "/status", "value": %s }`, string(newStatus)),
+	}
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(fmt.Sprintf("[ %s ]", strings.Join(ops, ", "))))
+	return err
+}
+
 func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
@@ -717,6 +957,34 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 		}
 		return nil
 
+	case virtv1.RunStrategyOnce:
+		// For this RunStrategy, a VMI is started once and never restarted, regardless
+		// if it succeeded or failed. It can only be stopped using api endpoints.
+		if vmi != nil {
+			if len(vm.Status.StateChangeRequests) != 0 {
+				stateChange := vm.Status.StateChangeRequests[0]
+				if stateChange.Action == virtv1.StopRequest &&
+					stateChange.UID != nil &&
+					*stateChange.UID == vmi.UID {
+					log.Log.Object(vm).V(4).Info(stoppingVmiMsg)
+					err := c.stopVMI(vm, vmi)
+					if err != nil {
+						log.Log.Object(vm).Errorf(failureDeletingVmiErrFormat, err)
+						return err
+					}
+				}
+			}
+			return nil
+		}
+
+		if controller.NewVirtualMachineConditionManager().HasCondition(vm, virtv1.VirtualMachineCompleted) {
+			log.Log.Object(vm).V(4).Info("VMI already completed, not starting it again")
+			return nil
+		}
+
+		log.Log.Object(vm).V(4).Info(startingVmiMsg)
+		return c.startVMI(vm)
+
 	case virtv1.RunStrategyHalted:
 		// For this runStrategy, no VMI should be running under any circumstances.
 		log.Log.Object(vm).V(4).Info("VMI should be deleted")
@@ -741,6 +1009,11 @@ func (c *VMController) startVMI(vm *virtv1.VirtualMachine) error {
 	// start it
 	vmi := c.setupVMIFromVM(vm)
 
+	if err := c.applyInstancetypeToVmi(vm, vmi); err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateVirtualMachineReason, "Error applying instancetype to virtual machine instance: %v", err)
+		return err
+	}
+
 	c.expectations.ExpectCreations(vmKey, 1)
 	vmi, err = c.clientset.VirtualMachineInstance(vm.ObjectMeta.Namespace).Create(vmi)
 	if err != nil {
@@ -751,6 +1024,14 @@ func (c *VMController) startVMI(vm *virtv1.VirtualMachine) error {
 	}
 	c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulCreateVirtualMachineReason, "Started the virtual machine by creating the new virtual machine instance %v", vmi.ObjectMeta.Name)
 
+	if _, exists := vm.Annotations[virtv1.NetworkBootNextStartAnnotation]; exists {
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":null}}}`, virtv1.NetworkBootNextStartAnnotation)
+		if _, err := c.clientset.VirtualMachine(vm.Namespace).Patch(vm.Name, types.MergePatchType, []byte(patch)); err != nil {
+			log.Log.Object(vm).Reason(err).Error("Failed to remove the network boot request")
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -784,6 +1065,67 @@ func (c *VMController) stopVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMac
 	return nil
 }
 
+// storeInstancetypeRevisions captures the referenced instancetype and preference
+// in ControllerRevisions, returning a copy of the VM pointing at them.
+func (c *VMController) storeInstancetypeRevisions(vm *virtv1.VirtualMachine) (*virtv1.VirtualMachine, error) {
+	if (vm.Spec.Instancetype == nil || vm.Spec.Instancetype.RevisionName != "") &&
+		(vm.Spec.Preference == nil || vm.Spec.Preference.RevisionName != "") {
+		return vm, nil
+	}
+
+	vmCopy := vm.DeepCopy()
+	if err := c.instancetypeMethods.StoreControllerRevisions(vmCopy); err != nil {
+		return vm, err
+	}
+	return vmCopy, nil
+}
+
+// applyInstancetypeToVmi expands the instancetype and preference referenced by the VM into the VMI spec.
+func (c *VMController) applyInstancetypeToVmi(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vm.Spec.Instancetype == nil && vm.Spec.Preference == nil {
+		return nil
+	}
+
+	instancetypeSpec, err := c.instancetypeMethods.FindInstancetypeSpec(vm)
+	if err != nil {
+		return err
+	}
+
+	preferenceSpec, err := c.instancetypeMethods.FindPreferenceSpec(vm)
+	if err != nil {
+		return err
+	}
+
+	// The VMI spec is shallow copied from the VM template, make sure the cached VM is left untouched
+	vmi.Spec = *vmi.Spec.DeepCopy()
+
+	conflicts := c.instancetypeMethods.ApplyToVmi(k8sfield.NewPath("spec"), instancetypeSpec, preferenceSpec, &vmi.Spec)
+	if len(conflicts) > 0 {
+		return fmt.Errorf("VMI conflicts with instancetype spec in fields: [%s]", conflicts.String())
+	}
+
+	applyGuestOSPreference(preferenceSpec, vmi)
+
+	return nil
+}
+
+// applyGuestOSPreference marks the operating system of the guest, unless the VMI template already does
+func applyGuestOSPreference(preferenceSpec *instancetypev1alpha1.VirtualMachinePreferenceSpec, vmi *virtv1.VirtualMachineInstance) {
+	if preferenceSpec == nil || preferenceSpec.GuestOS == nil || preferenceSpec.GuestOS.PreferredType == "" {
+		return
+	}
+	if _, exists := vmi.Annotations[virtv1.GuestOSAnnotation]; exists {
+		return
+	}
+	// The VMI annotations are shallow copied from the VM template, make sure the cached VM is left untouched
+	annotations := make(map[string]string, len(vmi.Annotations)+1)
+	for k, v := range vmi.Annotations {
+		annotations[k] = v
+	}
+	annotations[virtv1.GuestOSAnnotation] = preferenceSpec.GuestOS.PreferredType
+	vmi.Annotations = annotations
+}
+
 // setupVMIfromVM creates a VirtualMachineInstance object from one VirtualMachine object.
 func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.VirtualMachineInstance {
 
@@ -796,6 +1138,20 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 
 	setupStableFirmwareUUID(vm, vmi)
 
+	if _, exists := vm.Annotations[virtv1.NetworkBootNextStartAnnotation]; exists {
+		setupNetworkBoot(vm, vmi)
+	}
+
+	if ipClaims, exists := vm.Annotations[virtv1.IPClaimsAnnotation]; exists {
+		// The annotations are still shared with the VirtualMachine template
+		annotations := map[string]string{}
+		for key, value := range vmi.Annotations {
+			annotations[key] = value
+		}
+		annotations[virtv1.IPClaimsAnnotation] = ipClaims
+		vmi.Annotations = annotations
+	}
+
 	// TODO check if vmi labels exist, and when make sure that they match. For now just override them
 	vmi.ObjectMeta.Labels = vm.Spec.Template.ObjectMeta.Labels
 	vmi.ObjectMeta.OwnerReferences = []v1.OwnerReference{
@@ -805,6 +1161,79 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 	return vmi
 }
 
+// setupNetworkBoot reorders the boot devices of the VirtualMachineInstance so that it boots from the
+// network first. Interfaces with a boot order are tried first, or, if none has one, the first interface
+// able to boot from the network. All other boot devices follow in their previous order. If no device had
+// a boot order before, the disks follow in the order they are declared, so that the guest still boots
+// from its disks when booting from the network fails.
+func setupNetworkBoot(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	// The devices are still shared with the VirtualMachine template
+	vmi.Spec.Domain.Devices = *vmi.Spec.Domain.Devices.DeepCopy()
+	interfaces := vmi.Spec.Domain.Devices.Interfaces
+	disks := vmi.Spec.Domain.Devices.Disks
+
+	hasBootOrder := false
+	var networkBootDevices []*uint
+	for i := range interfaces {
+		if interfaces[i].BootOrder == nil {
+			continue
+		}
+		hasBootOrder = true
+		if isNetworkBootCapable(interfaces[i]) {
+			networkBootDevices = append(networkBootDevices, interfaces[i].BootOrder)
+		}
+	}
+	sortBootOrders(networkBootDevices)
+	if len(networkBootDevices) == 0 {
+		for i := range interfaces {
+			if isNetworkBootCapable(interfaces[i]) {
+				interfaces[i].BootOrder = new(uint)
+				networkBootDevices = append(networkBootDevices, interfaces[i].BootOrder)
+				break
+			}
+		}
+	}
+	if len(networkBootDevices) == 0 {
+		log.Log.Object(vm).Warning("Ignoring the network boot request, the virtual machine has no interface able to boot from the network")
+		return
+	}
+
+	var otherBootDevices []*uint
+	for i := range interfaces {
+		if !isNetworkBootCapable(interfaces[i]) && interfaces[i].BootOrder != nil {
+			otherBootDevices = append(otherBootDevices, interfaces[i].BootOrder)
+		}
+	}
+	for i := range disks {
+		if disks[i].BootOrder != nil {
+			hasBootOrder = true
+			otherBootDevices = append(otherBootDevices, disks[i].BootOrder)
+		}
+	}
+	sortBootOrders(otherBootDevices)
+	if !hasBootOrder {
+		for i := range disks {
+			disks[i].BootOrder = new(uint)
+			otherBootDevices = append(otherBootDevices, disks[i].BootOrder)
+		}
+	}
+
+	for i, bootOrder := range append(networkBootDevices, otherBootDevices...) {
+		*bootOrder = uint(i + 1)
+	}
+}
+
+// isNetworkBootCapable returns whether the guest can boot from the network through the interface
+func isNetworkBootCapable(iface virtv1.Interface) bool {
+	return iface.Bridge != nil || iface.Masquerade != nil || iface.Macvtap != nil
+}
+
+func sortBootOrders(bootOrders []*uint) {
+	sort.SliceStable(bootOrders, func(i, j int) bool {
+		return *bootOrders[i] < *bootOrders[j]
+	})
+}
+
 // no special meaning, randomly generated on my box.
 // TODO: do we want to use another constants? see examples in RFC4122
 const magicUUID = "6a1a24a1-4061-4607-8bf4-a3963d0c5895"
@@ -1300,6 +1729,13 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 
 	c.syncReadyConditionFromVMI(vm, vmi)
 
+	if runStrategy == virtv1.RunStrategyOnce {
+		syncCompletedConditionFromVMI(vm, vmi)
+	} else {
+		// the VM is run again with any other RunStrategy, switching back to Once starts a new run
+		controller.NewVirtualMachineConditionManager().RemoveCondition(vm, virtv1.VirtualMachineCompleted)
+	}
+
 	// Add/Remove Failure condition if necessary
 	vmCondManager := controller.NewVirtualMachineConditionManager()
 	errMatch := (createErr != nil) == vmCondManager.HasCondition(vm, virtv1.VirtualMachineFailure)
@@ -1356,6 +1792,78 @@ func (c *VMController) syncReadyConditionFromVMI(vm *virtv1.VirtualMachine, vmi
 	}
 }
 
+// syncCompletedConditionFromVMI marks a VM with RunStrategy Once as completed once its VMI
+// reached a final phase, or is about to be stopped on request. The condition is kept as long as
+// the RunStrategy is Once, so that the VMI is not started again.
+func syncCompletedConditionFromVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	vmCondManager := controller.NewVirtualMachineConditionManager()
+	if vmi == nil || vmCondManager.HasCondition(vm, virtv1.VirtualMachineCompleted) {
+		return
+	}
+
+	// a final VMI which is deleted without a stop request belongs to an earlier run, which was
+	// restarted with another RunStrategy, it does not complete the current run
+	var reason, message string
+	switch {
+	case vmi.DeletionTimestamp == nil && vmi.Status.Phase == virtv1.Succeeded:
+		reason = string(virtv1.Succeeded)
+		message = "VMI was shut down by the guest"
+	case vmi.DeletionTimestamp == nil && vmi.Status.Phase == virtv1.Failed:
+		reason = string(virtv1.Failed)
+		message = "VMI failed"
+	case len(vm.Status.StateChangeRequests) != 0 &&
+		vm.Status.StateChangeRequests[0].Action == virtv1.StopRequest &&
+		vm.Status.StateChangeRequests[0].UID != nil &&
+		*vm.Status.StateChangeRequests[0].UID == vmi.UID:
+		reason = string(virtv1.Failed)
+		message = "VMI was stopped before it completed"
+	default:
+		return
+	}
+
+	log.Log.Object(vm).V(3).Infof("Adding completed condition: %s", reason)
+	now := v1.NewTime(time.Now())
+	vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
+		Type:               virtv1.VirtualMachineCompleted,
+		Status:             k8score.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// handleCompletionTTL deletes a completed VM with RunStrategy Once when its TTL expired,
+// otherwise the VM is re-enqueued for when the TTL expires.
+func (c *VMController) handleCompletionTTL(vm *virtv1.VirtualMachine) error {
+	if vm.Spec.TTLSecondsAfterCompletion == nil || vm.ObjectMeta.DeletionTimestamp != nil {
+		return nil
+	}
+	completedCond := controller.NewVirtualMachineConditionManager().GetCondition(vm, virtv1.VirtualMachineCompleted)
+	if completedCond == nil {
+		return nil
+	}
+
+	expiry := completedCond.LastTransitionTime.Add(time.Duration(*vm.Spec.TTLSecondsAfterCompletion) * time.Second)
+	if remaining := time.Until(expiry); remaining > 0 {
+		vmKey, err := controller.KeyFunc(vm)
+		if err != nil {
+			return err
+		}
+		c.Queue.AddAfter(vmKey, remaining)
+		return nil
+	}
+
+	log.Log.Object(vm).Infof("Deleting the VirtualMachine, %d seconds passed since its completion", *vm.Spec.TTLSecondsAfterCompletion)
+	err := c.clientset.VirtualMachine(vm.Namespace).Delete(vm.Name, &v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedDeleteVirtualMachineReason, "Error deleting the completed virtual machine: %v", err)
+		return err
+	}
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulDeleteVirtualMachineReason, "Deleted the virtual machine %d seconds after its completion", *vm.Spec.TTLSecondsAfterCompletion)
+	return nil
+}
+
 func copyConditionDetails(source *virtv1.VirtualMachineInstanceCondition, dest *virtv1.VirtualMachineCondition) {
 	dest.Status = source.Status
 	dest.LastProbeTime = source.LastProbeTime
@@ -1,7 +1,9 @@
 package watch
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-openapi/errors"
 	"github.com/golang/mock/gomock"
@@ -10,9 +12,12 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pborman/uuid"
 	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
@@ -20,10 +25,13 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	virtv1 "kubevirt.io/client-go/api/v1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
 	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/testutils"
 )
 
@@ -48,6 +56,7 @@ var _ = Describe("VirtualMachine", func() {
 		var vmiFeeder *testutils.VirtualMachineFeeder
 		var dataVolumeFeeder *testutils.DataVolumeFeeder
 		var cdiClient *cdifake.Clientset
+		var virtClient *kubecli.MockKubevirtClient
 
 		syncCaches := func(stop chan struct{}) {
 			go vmiInformer.Run(stop)
@@ -59,7 +68,7 @@ var _ = Describe("VirtualMachine", func() {
 		BeforeEach(func() {
 			stop = make(chan struct{})
 			ctrl = gomock.NewController(GinkgoT())
-			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			virtClient = kubecli.NewMockKubevirtClient(ctrl)
 			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
 
@@ -120,6 +129,168 @@ var _ = Describe("VirtualMachine", func() {
 			mockQueue.Wait()
 		}
 
+		Context("with CPU hotplug", func() {
+			var vm *v1.VirtualMachine
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vm, vmi = DefaultVirtualMachine(true)
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Sockets: 2, MaxSockets: 4}
+				vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 1, MaxSockets: 4}
+				vmi.Status.Phase = v1.Running
+				markAsReady(vmi)
+			})
+
+			It("should request the new sockets on the running VMI", func() {
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, pt types.PatchType, data []byte, _ ...string) (*v1.VirtualMachineInstance, error) {
+					Expect(string(data)).To(ContainSubstring(`"sockets":2`))
+					Expect(string(data)).To(ContainSubstring(string(v1.VirtualMachineInstanceVCPUChange)))
+					return vmi, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulCPUHotplugReason)
+			})
+
+			It("should ignore sockets beyond maxSockets", func() {
+				vm.Spec.Template.Spec.Domain.CPU.Sockets = 8
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+
+			It("should fall back to a migration when the hotplug failed", func() {
+				migrationInterface := kubecli.NewMockVirtualMachineInstanceMigrationInterface(ctrl)
+				virtClient.EXPECT().VirtualMachineInstanceMigration(metav1.NamespaceDefault).Return(migrationInterface)
+
+				vmi.Spec.Domain.CPU.Sockets = 2
+				vmi.Status.Conditions = append(vmi.Status.Conditions,
+					v1.VirtualMachineInstanceCondition{
+						Type:   v1.VirtualMachineInstanceIsMigratable,
+						Status: k8sv1.ConditionTrue,
+					},
+					v1.VirtualMachineInstanceCondition{
+						Type:   v1.VirtualMachineInstanceVCPUChange,
+						Status: k8sv1.ConditionFalse,
+						Reason: v1.VirtualMachineInstanceReasonCPUHotplugFailed,
+					},
+				)
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				migrationInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(migration *v1.VirtualMachineInstanceMigration) (*v1.VirtualMachineInstanceMigration, error) {
+					Expect(migration.Spec.VMIName).To(Equal(vmi.Name))
+					return migration, nil
+				})
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, pt types.PatchType, data []byte, _ ...string) (*v1.VirtualMachineInstance, error) {
+					Expect(string(data)).To(ContainSubstring(v1.VirtualMachineInstanceReasonCPUHotplugMigration))
+					return vmi, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, CPUHotplugMigrationReason)
+			})
+
+			It("should replace a finished migration of an earlier CPU change", func() {
+				migrationInterface := kubecli.NewMockVirtualMachineInstanceMigrationInterface(ctrl)
+				virtClient.EXPECT().VirtualMachineInstanceMigration(metav1.NamespaceDefault).Return(migrationInterface)
+
+				vmi.Spec.Domain.CPU.Sockets = 2
+				vmi.Status.Conditions = append(vmi.Status.Conditions,
+					v1.VirtualMachineInstanceCondition{
+						Type:   v1.VirtualMachineInstanceIsMigratable,
+						Status: k8sv1.ConditionTrue,
+					},
+					v1.VirtualMachineInstanceCondition{
+						Type:   v1.VirtualMachineInstanceVCPUChange,
+						Status: k8sv1.ConditionFalse,
+						Reason: v1.VirtualMachineInstanceReasonCPUHotplugFailed,
+					},
+				)
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				migrationName := fmt.Sprintf("kubevirt-cpu-hotplug-%s-2", vmi.Name)
+				finished := &v1.VirtualMachineInstanceMigration{
+					ObjectMeta: metav1.ObjectMeta{Name: migrationName, Namespace: vmi.Namespace},
+					Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationSucceeded},
+				}
+				migrationInterface.EXPECT().Create(gomock.Any()).Return(nil, k8serrors.NewAlreadyExists(v1.Resource("virtualmachineinstancemigrations"), migrationName))
+				migrationInterface.EXPECT().Get(migrationName, gomock.Any()).Return(finished, nil)
+				migrationInterface.EXPECT().Delete(migrationName, gomock.Any()).Return(nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+		})
+
+		Context("with memory hotplug", func() {
+			var vm *v1.VirtualMachine
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vm, vmi = DefaultVirtualMachine(true)
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				guest := resource.MustParse("1Gi")
+				maxGuest := resource.MustParse("4Gi")
+				desiredGuest := resource.MustParse("2Gi")
+				vm.Spec.Template.Spec.Domain.Memory = &v1.Memory{Guest: &desiredGuest, MaxGuest: &maxGuest}
+				vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+				vmi.Status.Phase = v1.Running
+				markAsReady(vmi)
+			})
+
+			It("should request the additional guest memory on the running VMI", func() {
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, pt types.PatchType, data []byte, _ ...string) (*v1.VirtualMachineInstance, error) {
+					Expect(string(data)).To(ContainSubstring(`"guest":"2Gi"`))
+					Expect(string(data)).To(ContainSubstring(string(v1.VirtualMachineInstanceMemoryChange)))
+					return vmi, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulMemoryHotplugReason)
+			})
+
+			It("should not request memory while a memory change is in progress", func() {
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceMemoryChange,
+					Status: k8sv1.ConditionTrue,
+				})
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+
+			It("should ignore guest memory beyond maxGuest", func() {
+				desiredGuest := resource.MustParse("8Gi")
+				vm.Spec.Template.Spec.Domain.Memory.Guest = &desiredGuest
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+		})
+
 		It("should create missing DataVolume for VirtualMachineInstance", func() {
 			vm, _ := DefaultVirtualMachine(true)
 			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
@@ -748,6 +919,109 @@ var _ = Describe("VirtualMachine", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
 		})
 
+		Context("with an instancetype", func() {
+			var instancetypeObj *instancetypev1alpha1.VirtualMachineClusterInstancetype
+			var k8sClient *k8sfake.Clientset
+
+			BeforeEach(func() {
+				instancetypeObj = &instancetypev1alpha1.VirtualMachineClusterInstancetype{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "instancetype",
+						UID:        "instancetype-uid",
+						Generation: 1,
+					},
+					Spec: instancetypev1alpha1.VirtualMachineInstancetypeSpec{
+						CPU: instancetypev1alpha1.CPUInstancetype{
+							Guest: 2,
+						},
+						Memory: instancetypev1alpha1.MemoryInstancetype{
+							Guest: resource.MustParse("128Mi"),
+						},
+					},
+				}
+				kubevirtClient := kubevirtfake.NewSimpleClientset(instancetypeObj)
+				virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(
+					kubevirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes()).AnyTimes()
+
+				k8sClient = k8sfake.NewSimpleClientset()
+				virtClient.EXPECT().AppsV1().Return(k8sClient.AppsV1()).AnyTimes()
+			})
+
+			It("should store a ControllerRevision and apply the instancetype to the VirtualMachineInstance", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Spec.Template.Spec.Domain.Resources = v1.ResourceRequirements{}
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					Name: instancetypeObj.Name,
+				}
+				revisionName := instancetype.GetRevisionName(vm.Name, instancetypeObj.Name, instancetypeObj.UID, instancetypeObj.Generation)
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().Patch(vm.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(
+					func(name string, pt types.PatchType, data []byte, subresources ...string) (*v1.VirtualMachine, error) {
+						Expect(string(data)).To(ContainSubstring(revisionName))
+						return vm, nil
+					})
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+					createdVMI := arg.(*v1.VirtualMachineInstance)
+					Expect(createdVMI.Spec.Domain.CPU.Sockets).To(Equal(uint32(2)))
+					Expect(createdVMI.Spec.Domain.Memory.Guest.String()).To(Equal("128Mi"))
+				}).Return(vmi, nil)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+
+				_, err := k8sClient.AppsV1().ControllerRevisions(vm.Namespace).Get(context.Background(), revisionName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Spec.Instancetype.RevisionName).To(BeEmpty())
+				Expect(vm.Spec.Template.Spec.Domain.CPU).To(BeNil())
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should not create the VirtualMachineInstance when the template conflicts with the instancetype", func() {
+				vm, _ := DefaultVirtualMachine(true)
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					Name: instancetypeObj.Name,
+				}
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().Patch(vm.Name, types.JSONPatchType, gomock.Any()).Return(vm, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedCreateVirtualMachineReason)
+			})
+
+			It("should mark the guest OS of the VirtualMachineInstance from the preference", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				preferenceSpec := &instancetypev1alpha1.VirtualMachinePreferenceSpec{
+					GuestOS: &instancetypev1alpha1.GuestOSPreferences{PreferredType: v1.GuestOSWindows},
+				}
+				vmi.Annotations = vm.Spec.Template.ObjectMeta.Annotations
+
+				applyGuestOSPreference(preferenceSpec, vmi)
+
+				Expect(vmi.Annotations).To(HaveKeyWithValue(v1.GuestOSAnnotation, v1.GuestOSWindows))
+				Expect(vm.Spec.Template.ObjectMeta.Annotations).ToNot(HaveKey(v1.GuestOSAnnotation))
+			})
+
+			It("should keep the guest OS marked on the VirtualMachineInstance template", func() {
+				_, vmi := DefaultVirtualMachine(true)
+				preferenceSpec := &instancetypev1alpha1.VirtualMachinePreferenceSpec{
+					GuestOS: &instancetypev1alpha1.GuestOSPreferences{PreferredType: v1.GuestOSWindows},
+				}
+				vmi.Annotations = map[string]string{v1.GuestOSAnnotation: "linux"}
+
+				applyGuestOSPreference(preferenceSpec, vmi)
+
+				Expect(vmi.Annotations).To(HaveKeyWithValue(v1.GuestOSAnnotation, "linux"))
+			})
+		})
+
 		It("should ignore the name of a VirtualMachineInstance templates", func() {
 			vm, vmi := DefaultVirtualMachineWithNames(true, "vmname", "vminame")
 
@@ -826,6 +1100,255 @@ var _ = Describe("VirtualMachine", func() {
 			Expect(string(vmi1.Spec.Domain.Firmware.UUID)).To(Equal(uid))
 		})
 
+		Context("with a network boot request", func() {
+			bootOrder := func(order uint) *uint {
+				return &order
+			}
+
+			newNetworkBootVM := func() *v1.VirtualMachine {
+				vm, _ := DefaultVirtualMachine(true)
+				vm.Annotations[v1.NetworkBootNextStartAnnotation] = "true"
+				vm.Spec.Template.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "disk0"}, {Name: "disk1"}}
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+					*v1.DefaultBridgeNetworkInterface(),
+				}
+				return vm
+			}
+
+			It("should boot from the first interface able to boot from the network and then from the disks", func() {
+				vm := newNetworkBootVM()
+
+				vmi := controller.setupVMIFromVM(vm)
+				Expect(vmi.Spec.Domain.Devices.Interfaces[0].BootOrder).To(BeNil())
+				Expect(vmi.Spec.Domain.Devices.Interfaces[1].BootOrder).To(Equal(bootOrder(1)))
+				Expect(vmi.Spec.Domain.Devices.Disks[0].BootOrder).To(Equal(bootOrder(2)))
+				Expect(vmi.Spec.Domain.Devices.Disks[1].BootOrder).To(Equal(bootOrder(3)))
+
+				By("keeping the VirtualMachine template untouched")
+				Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[1].BootOrder).To(BeNil())
+				Expect(vm.Spec.Template.Spec.Domain.Devices.Disks[0].BootOrder).To(BeNil())
+			})
+
+			It("should move the bootable interfaces in front of the other boot devices", func() {
+				vm := newNetworkBootVM()
+				vm.Spec.Template.Spec.Domain.Devices.Disks[1].BootOrder = bootOrder(1)
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].BootOrder = bootOrder(5)
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces[1].BootOrder = bootOrder(3)
+
+				vmi := controller.setupVMIFromVM(vm)
+				Expect(vmi.Spec.Domain.Devices.Interfaces[1].BootOrder).To(Equal(bootOrder(1)))
+				Expect(vmi.Spec.Domain.Devices.Disks[1].BootOrder).To(Equal(bootOrder(2)))
+				Expect(vmi.Spec.Domain.Devices.Interfaces[0].BootOrder).To(Equal(bootOrder(3)))
+				Expect(vmi.Spec.Domain.Devices.Disks[0].BootOrder).To(BeNil())
+			})
+
+			It("should keep the boot order without an interface able to boot from the network", func() {
+				vm := newNetworkBootVM()
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces = vm.Spec.Template.Spec.Domain.Devices.Interfaces[:1]
+
+				vmi := controller.setupVMIFromVM(vm)
+				Expect(vmi.Spec.Domain.Devices.Interfaces[0].BootOrder).To(BeNil())
+				Expect(vmi.Spec.Domain.Devices.Disks[0].BootOrder).To(BeNil())
+			})
+
+			It("should remove the network boot request once the VirtualMachineInstance is created", func() {
+				vm := newNetworkBootVM()
+				vmi := controller.setupVMIFromVM(vm)
+
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+				vmInterface.EXPECT().Patch(vm.Name, types.MergePatchType, gomock.Any()).DoAndReturn(
+					func(name string, pt types.PatchType, data []byte, subresources ...string) (*v1.VirtualMachine, error) {
+						Expect(string(data)).To(Equal(`{"metadata":{"annotations":{"kubevirt.io/network-boot-next-start":null}}}`))
+						return vm, nil
+					})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+		})
+
+		Context("with persistent IPs", func() {
+			const ipClaims = `{"red":["10.10.10.5"]}`
+
+			It("should request the addresses claimed by the previous VirtualMachineInstance", func() {
+				vm, _ := DefaultVirtualMachine(true)
+				vm.Annotations[v1.IPClaimsAnnotation] = ipClaims
+
+				vmi := controller.setupVMIFromVM(vm)
+				Expect(vmi.Annotations).To(HaveKeyWithValue(v1.IPClaimsAnnotation, ipClaims))
+
+				By("keeping the VirtualMachine template untouched")
+				Expect(vm.Spec.Template.ObjectMeta.Annotations).ToNot(HaveKey(v1.IPClaimsAnnotation))
+			})
+
+			It("should store the addresses claimed by the VirtualMachineInstance", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vmi.Status.Phase = v1.Running
+				vmi.Annotations = map[string]string{v1.IPClaimsAnnotation: ipClaims}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().Patch(vm.Name, types.MergePatchType, gomock.Any()).DoAndReturn(
+					func(name string, pt types.PatchType, data []byte, subresources ...string) (*v1.VirtualMachine, error) {
+						Expect(string(data)).To(Equal(`{"metadata":{"annotations":{"kubevirt.io/ip-claims":"{\"red\":[\"10.10.10.5\"]}"}}}`))
+						return vm, nil
+					})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+
+			It("should not store the addresses again", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Annotations[v1.IPClaimsAnnotation] = ipClaims
+				vmi.Status.Phase = v1.Running
+				vmi.Annotations = map[string]string{v1.IPClaimsAnnotation: ipClaims}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+		})
+
+		Context("with RunStrategy Once", func() {
+			newOnceVM := func() (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(false)
+				runStrategy := v1.RunStrategyOnce
+				vm.Spec.Running = nil
+				vm.Spec.RunStrategy = &runStrategy
+				return vm, vmi
+			}
+
+			completedCondition := func(reason string, since time.Duration) v1.VirtualMachineCondition {
+				return v1.VirtualMachineCondition{
+					Type:               v1.VirtualMachineCompleted,
+					Status:             k8sv1.ConditionTrue,
+					Reason:             reason,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+				}
+			}
+
+			It("should start the VirtualMachineInstance if it did not complete yet", func() {
+				vm, vmi := newOnceVM()
+
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should not start the VirtualMachineInstance again once it completed", func() {
+				vm, _ := newOnceVM()
+				vm.Status.Conditions = []v1.VirtualMachineCondition{completedCondition("Succeeded", 0)}
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+
+			table.DescribeTable("should mark the VirtualMachine as completed when the VirtualMachineInstance", func(phase v1.VirtualMachineInstancePhase, reason string) {
+				vm, vmi := newOnceVM()
+				vmi.Status.Phase = phase
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(objVM, v1.VirtualMachineCompleted)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+					Expect(cond.Reason).To(Equal(reason))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			},
+				table.Entry("succeeded", v1.Succeeded, "Succeeded"),
+				table.Entry("failed", v1.Failed, "Failed"),
+			)
+
+			It("should delete the VirtualMachine once the TTL after its completion expired", func() {
+				vm, _ := newOnceVM()
+				ttl := int32(60)
+				vm.Spec.TTLSecondsAfterCompletion = &ttl
+				vm.Status.Conditions = []v1.VirtualMachineCondition{completedCondition("Succeeded", 2*time.Minute)}
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+				vmInterface.EXPECT().Delete(vm.Name, gomock.Any()).Return(nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			})
+
+			It("should requeue the VirtualMachine until the TTL after its completion expired", func() {
+				vm, _ := newOnceVM()
+				ttl := int32(600)
+				vm.Spec.TTLSecondsAfterCompletion = &ttl
+				vm.Status.Conditions = []v1.VirtualMachineCondition{completedCondition("Succeeded", time.Minute)}
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+			})
+
+			It("should not mark the VirtualMachine as completed when the VirtualMachineInstance of an earlier run is deleted", func() {
+				vm, vmi := newOnceVM()
+				vmi.Status.Phase = v1.Succeeded
+				now := metav1.Now()
+				vmi.DeletionTimestamp = &now
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(virtcontroller.NewVirtualMachineConditionManager().HasCondition(objVM, v1.VirtualMachineCompleted)).To(BeFalse())
+				}).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+			})
+
+			It("should remove the completed condition when the RunStrategy is not Once anymore", func() {
+				vm, vmi := newOnceVM()
+				runStrategy := v1.RunStrategyManual
+				vm.Spec.RunStrategy = &runStrategy
+				vm.Status.Conditions = []v1.VirtualMachineCondition{completedCondition("Succeeded", 0)}
+				vmi.Status.Phase = v1.Running
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(virtcontroller.NewVirtualMachineConditionManager().HasCondition(objVM, v1.VirtualMachineCompleted)).To(BeFalse())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+		})
+
 		It("should delete VirtualMachineInstance when stopped", func() {
 			vm, vmi := DefaultVirtualMachine(false)
 
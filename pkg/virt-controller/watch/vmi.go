@@ -29,6 +29,7 @@ import (
 	"time"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -41,8 +42,11 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	backendstorage "kubevirt.io/kubevirt/pkg/backend-storage"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util"
 	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
@@ -102,12 +106,28 @@ const (
 	SuccessfulAbortMigrationReason = "SuccessfulAbortMigration"
 	// FailedAbortMigrationReason is added when an attempt to abort migration fails
 	FailedAbortMigrationReason = "FailedAbortMigration"
+	// SuccessfulMigrateVolumesReason is added when the volumes moved by a migration are switched to their destination claims
+	SuccessfulMigrateVolumesReason = "SuccessfulMigrateVolumes"
+	// FailedMigrateVolumesReason is added when the volumes moved by a migration could not be switched to their destination claims
+	FailedMigrateVolumesReason = "FailedMigrateVolumes"
 	// MissingAttachmentPodReason is set when we have a hotplugged volume, but the attachment pod is missing
 	MissingAttachmentPodReason = "MissingAttachmentPod"
 	// PVCNotReadyReason is set when the PVC is not ready to be hot plugged.
 	PVCNotReadyReason = "PVCNotReady"
 	// FailedHotplugSyncReason is set when a hotplug specific failure occurs during sync
 	FailedHotplugSyncReason = "FailedHotplugSync"
+	// FailedBackendStorageCreateReason is added when the PVC holding the persistent
+	// state of the VMI devices could not be created
+	FailedBackendStorageCreateReason = "FailedBackendStorageCreate"
+	// FailedClusterCommonCPUReason is added when the cluster-common CPU model of a vmi
+	// could not be resolved
+	FailedClusterCommonCPUReason = "FailedClusterCommonCPU"
+	// FailedHotplugInterfaceReason is added when the pod networks could not be updated
+	// with a hotplugged network interface
+	FailedHotplugInterfaceReason = "FailedHotplugInterface"
+	// FailedContainerDiskVerificationReason is added when the images of the containerDisks of a vmi
+	// could not be resolved to digests or their signatures could not be verified
+	FailedContainerDiskVerificationReason = "FailedContainerDiskVerification"
 )
 
 const failedToRenderLaunchManifestErrFormat = "failed to render launch manifest: %v"
@@ -118,18 +138,25 @@ func NewVMIController(templateService services.TemplateService,
 	pvcInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
-	dataVolumeInformer cache.SharedIndexInformer) *VMIController {
+	dataVolumeInformer cache.SharedIndexInformer,
+	nodeInformer cache.SharedIndexInformer,
+	clusterConfig *virtconfig.ClusterConfig) *VMIController {
 
 	c := &VMIController{
-		templateService:    templateService,
-		Queue:              workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
-		vmiInformer:        vmiInformer,
-		podInformer:        podInformer,
-		pvcInformer:        pvcInformer,
-		recorder:           recorder,
-		clientset:          clientset,
-		podExpectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		dataVolumeInformer: dataVolumeInformer,
+		templateService:          templateService,
+		Queue:                    workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		vmiInformer:              vmiInformer,
+		podInformer:              podInformer,
+		pvcInformer:              pvcInformer,
+		recorder:                 recorder,
+		clientset:                clientset,
+		podExpectations:          controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		dataVolumeInformer:       dataVolumeInformer,
+		nodeInformer:             nodeInformer,
+		clusterConfig:            clusterConfig,
+		cidsMap:                  newCIDsMap(),
+		containerDiskRegistry:    newContainerDiskRegistry(),
+		containerDiskResolutions: newContainerDiskResolutions(),
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -150,6 +177,10 @@ func NewVMIController(templateService services.TemplateService,
 		UpdateFunc: c.updateDataVolume,
 	})
 
+	c.pvcInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.updatePVC,
+	})
+
 	return c
 }
 
@@ -172,15 +203,20 @@ func (e *syncErrorImpl) Reason() string {
 }
 
 type VMIController struct {
-	templateService    services.TemplateService
-	clientset          kubecli.KubevirtClient
-	Queue              workqueue.RateLimitingInterface
-	vmiInformer        cache.SharedIndexInformer
-	podInformer        cache.SharedIndexInformer
-	pvcInformer        cache.SharedIndexInformer
-	recorder           record.EventRecorder
-	podExpectations    *controller.UIDTrackingControllerExpectations
-	dataVolumeInformer cache.SharedIndexInformer
+	templateService          services.TemplateService
+	clientset                kubecli.KubevirtClient
+	Queue                    workqueue.RateLimitingInterface
+	vmiInformer              cache.SharedIndexInformer
+	podInformer              cache.SharedIndexInformer
+	pvcInformer              cache.SharedIndexInformer
+	recorder                 record.EventRecorder
+	podExpectations          *controller.UIDTrackingControllerExpectations
+	dataVolumeInformer       cache.SharedIndexInformer
+	nodeInformer             cache.SharedIndexInformer
+	clusterConfig            *virtconfig.ClusterConfig
+	cidsMap                  *cidsMap
+	containerDiskRegistry    containerDiskRegistry
+	containerDiskResolutions *containerDiskResolutions
 }
 
 func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -191,6 +227,13 @@ func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
 	// Wait for cache sync before we start the pod controller
 	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.dataVolumeInformer.HasSynced)
 
+	// Sync the CIDs of the VSOCK devices already in use before handing out new ones
+	var vmis []*virtv1.VirtualMachineInstance
+	for _, obj := range c.vmiInformer.GetStore().List() {
+		vmis = append(vmis, obj.(*virtv1.VirtualMachineInstance))
+	}
+	c.cidsMap.Sync(vmis)
+
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
@@ -235,6 +278,8 @@ func (c *VMIController) execute(key string) error {
 	// Once all finalizers are removed the vmi gets deleted and we can clean all expectations
 	if !exists {
 		c.podExpectations.DeleteExpectations(key)
+		c.cidsMap.Remove(key)
+		c.containerDiskResolutions.Remove(key)
 		return nil
 	}
 	vmi := obj.(*virtv1.VirtualMachineInstance)
@@ -357,6 +402,14 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 		return fmt.Errorf("Error detecting vmi pods: %v", err)
 	}
 
+	if util.IsAutoAttachVSOCK(vmiCopy) && !vmiCopy.IsFinal() {
+		cid, err := c.cidsMap.Allocate(vmiCopy)
+		if err != nil {
+			return fmt.Errorf("Error assigning a VSOCK CID: %v", err)
+		}
+		vmiCopy.Status.VSOCKCID = &cid
+	}
+
 	switch {
 	case vmi.IsUnprocessed():
 		if vmiPodExists {
@@ -438,6 +491,12 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 					}
 					vmiCopy.ObjectMeta.Labels[virtv1.NodeNameLabel] = pod.Spec.NodeName
 					vmiCopy.Status.NodeName = pod.Spec.NodeName
+					if c.isKSMEnabledOnNode(pod.Spec.NodeName) {
+						if vmiCopy.Annotations == nil {
+							vmiCopy.Annotations = map[string]string{}
+						}
+						vmiCopy.Annotations[virtv1.KSMEnabledAnnotation] = "true"
+					}
 				}
 			} else if isPodDownOrGoingDown(pod) {
 				vmiCopy.Status.Phase = virtv1.Failed
@@ -585,6 +644,14 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 
 				}
 			}
+
+			ipClaimsPatch, err := ipClaimsPatchOp(vmi, pod)
+			if err != nil {
+				return err
+			}
+			if ipClaimsPatch != "" {
+				patchOps = append(patchOps, ipClaimsPatch)
+			}
 		}
 
 		if len(patchOps) > 0 {
@@ -633,6 +700,43 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 	return nil
 }
 
+// ipClaimsPatchOp returns the patch operation which records, in the IP claims annotation of the VMI, the addresses
+// allocated to the pod on the networks with persistent IPs, so that the next pods of the VMI request them again.
+func ipClaimsPatchOp(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) (string, error) {
+	podIPClaims, err := services.GetPodIPClaims(vmi, pod)
+	if err != nil || len(podIPClaims) == 0 {
+		return "", err
+	}
+	ipClaims := services.GetIPClaims(vmi)
+	changed := false
+	for networkName, ips := range podIPClaims {
+		if !reflect.DeepEqual(ipClaims[networkName], ips) {
+			ipClaims[networkName] = ips
+			changed = true
+		}
+	}
+	if !changed {
+		return "", nil
+	}
+	annotation, err := services.EncodeIPClaims(ipClaims)
+	if err != nil {
+		return "", err
+	}
+	if vmi.Annotations == nil {
+		value, err := json.Marshal(map[string]string{virtv1.IPClaimsAnnotation: annotation})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`{ "op": "add", "path": "/metadata/annotations", "value": %s }`, string(value)), nil
+	}
+	value, err := json.Marshal(annotation)
+	if err != nil {
+		return "", err
+	}
+	path := "/metadata/annotations/" + strings.ReplaceAll(virtv1.IPClaimsAnnotation, "/", "~1")
+	return fmt.Sprintf(`{ "op": "add", "path": "%s", "value": %s }`, path, string(value)), nil
+}
+
 // isPodReady treats the pod as ready to be handed over to virt-handler, as soon as all pods except
 // the compute pod are ready.
 func isPodReady(pod *k8sv1.Pod) bool {
@@ -715,6 +819,26 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 			log.Log.V(3).Object(vmi).Infof("Delaying pod creation while DataVolume populates")
 			return nil
 		}
+		if vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.Model == virtv1.CPUModelClusterCommon {
+			if err := c.applyClusterCommonCPU(vmi); err != nil {
+				c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedClusterCommonCPUReason, "Error resolving the cluster-common CPU model: %v", err)
+				return &syncErrorImpl{fmt.Errorf("failed to resolve the cluster-common CPU model: %v", err), FailedClusterCommonCPUReason}
+			}
+			// The pod is created once the VMI with the resolved CPU model is observed
+			return nil
+		}
+		if waiting, err := c.resolveContainerDiskDigests(vmi); err != nil {
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedContainerDiskVerificationReason, "Error resolving the containerDisk images: %v", err)
+			return &syncErrorImpl{fmt.Errorf("failed to resolve the containerDisk images: %v", err), FailedContainerDiskVerificationReason}
+		} else if waiting {
+			// The pod is created once the VMI with the resolved digests is observed
+			return nil
+		}
+		if err := backendstorage.CreateIfNeeded(vmi, c.clusterConfig, c.clientset); err != nil {
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedBackendStorageCreateReason, "Error creating backend storage: %v", err)
+			return &syncErrorImpl{fmt.Errorf("failed to create backend storage: %v", err), FailedBackendStorageCreateReason}
+		}
+
 		var templatePod *k8sv1.Pod
 		var err error
 		if isWaitForFirstConsumer {
@@ -768,10 +892,44 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 				}
 			}
 		}
+
+		if pod.DeletionTimestamp == nil && c.clusterConfig.HotplugNetworkInterfacesEnabled() {
+			if err := c.syncPodNetworksAnnotation(vmi, pod); err != nil {
+				return &syncErrorImpl{fmt.Errorf("failed to update the pod networks: %v", err), FailedHotplugInterfaceReason}
+			}
+		}
 	}
 	return nil
 }
 
+// syncPodNetworksAnnotation updates the multus networks annotation of the virt-launcher pod, so that
+// networks which were hotplugged to the VMI get attached to the pod, and unplugged ones get released.
+func (c *VMIController) syncPodNetworksAnnotation(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) error {
+	multusAnnotation, err := services.GenerateMultusCNIAnnotation(vmi, c.clusterConfig.GetNetworkBindings())
+	if err != nil {
+		return err
+	}
+	currentAnnotation := pod.Annotations[services.MultusNetworksAnnotation]
+	if multusAnnotation == "" {
+		// all the secondary networks were unplugged, release their attachments
+		if currentAnnotation == "" || currentAnnotation == "[]" {
+			return nil
+		}
+		multusAnnotation = "[]"
+	}
+	if currentAnnotation == multusAnnotation {
+		return nil
+	}
+
+	podCopy := pod.DeepCopy()
+	if podCopy.Annotations == nil {
+		podCopy.Annotations = map[string]string{}
+	}
+	podCopy.Annotations[services.MultusNetworksAnnotation] = multusAnnotation
+	_, err = c.clientset.CoreV1().Pods(pod.Namespace).Update(context.Background(), podCopy, v1.UpdateOptions{})
+	return err
+}
+
 func (c *VMIController) handleSyncDataVolumes(vmi *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume) (bool, bool, syncError) {
 
 	ready := true
@@ -890,6 +1048,29 @@ func (c *VMIController) deleteDataVolume(obj interface{}) {
 	}
 }
 
+// When the capacity of a PVC changes, e.g. because it was expanded, enqueue the vmis using it
+// so the new capacity is reported in their volume status.
+func (c *VMIController) updatePVC(old, cur interface{}) {
+	curPVC := cur.(*k8sv1.PersistentVolumeClaim)
+	oldPVC := old.(*k8sv1.PersistentVolumeClaim)
+	if curPVC.ResourceVersion == oldPVC.ResourceVersion {
+		return
+	}
+	if equality.Semantic.DeepEqual(curPVC.Status.Capacity, oldPVC.Status.Capacity) {
+		return
+	}
+
+	vmis, err := c.listVMIsMatchingPVC(curPVC.Namespace, curPVC.Name)
+	if err != nil {
+		log.Log.V(4).Object(curPVC).Errorf("Error encountered during pvc update: %v", err)
+		return
+	}
+	for _, vmi := range vmis {
+		log.Log.V(4).Object(curPVC).Infof("PVC capacity updated for vmi %s", vmi.Name)
+		c.enqueueVirtualMachine(vmi)
+	}
+}
+
 // When a pod is created, enqueue the vmi that manages it and update its podExpectations.
 func (c *VMIController) addPod(obj interface{}) {
 	pod := obj.(*k8sv1.Pod)
@@ -1074,6 +1255,24 @@ func (c *VMIController) listVMIsMatchingDataVolume(namespace string, dataVolumeN
 	return vmis, nil
 }
 
+func (c *VMIController) listVMIsMatchingPVC(namespace string, claimName string) ([]*virtv1.VirtualMachineInstance, error) {
+	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+	vmis := []*virtv1.VirtualMachineInstance{}
+	for _, obj := range objs {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		for i := range vmi.Spec.Volumes {
+			if volumeClaimName(&vmi.Spec.Volumes[i]) == claimName {
+				vmis = append(vmis, vmi)
+				break
+			}
+		}
+	}
+	return vmis, nil
+}
+
 func (c *VMIController) listMatchingDataVolumes(vmi *virtv1.VirtualMachineInstance) ([]*cdiv1.DataVolume, error) {
 
 	dataVolumes := []*cdiv1.DataVolume{}
@@ -1113,6 +1312,16 @@ func (c *VMIController) getDataVolumeName(namespace string, volume virtv1.Volume
 	return nil
 }
 
+// isKSMEnabledOnNode returns true if virt-handler reported that kernel samepage merging runs on the node
+func (c *VMIController) isKSMEnabledOnNode(nodeName string) bool {
+	obj, exists, err := c.nodeInformer.GetStore().GetByKey(nodeName)
+	if err != nil || !exists {
+		return false
+	}
+	node, ok := obj.(*k8sv1.Node)
+	return ok && node.Labels[virtv1.KSMEnabledLabel] == "true"
+}
+
 func (c *VMIController) allPodsDeleted(vmi *virtv1.VirtualMachineInstance) (bool, error) {
 	pods, err := c.listPodsFromNamespace(vmi.Namespace)
 	if err != nil {
@@ -1214,7 +1423,13 @@ func (c *VMIController) getHotplugVolumes(vmi *virtv1.VirtualMachineInstance, vi
 		podVolumeMap[podVolume.Name] = podVolume
 	}
 	for _, vmiVolume := range vmiVolumes {
-		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil) {
+		if vmiVolume.DataVolume == nil && vmiVolume.PersistentVolumeClaim == nil {
+			continue
+		}
+		// The media inserted into a CD-ROM takes the name of the CD-ROM, a pod volume of the same name
+		// backed by another claim belongs to the media the VMI was started with.
+		if podVolume, ok := podVolumeMap[vmiVolume.Name]; !ok || isCDRom(vmi, vmiVolume.Name) &&
+			(podVolume.PersistentVolumeClaim == nil || podVolume.PersistentVolumeClaim.ClaimName != volumeClaimName(&vmiVolume)) {
 			hotplugVolumes = append(hotplugVolumes, vmiVolume.DeepCopy())
 		}
 	}
@@ -1640,6 +1855,15 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 					status.Reason = SuccessfulCreatePodReason
 				}
 			}
+		} else if status.HotplugVolume != nil && isCDRom(vmi, volume.Name) {
+			// The media the VMI was started with got inserted into the CD-ROM again
+			status = virtv1.VolumeStatus{Name: volume.Name, Target: status.Target}
+		}
+		status.PersistentVolumeClaimInfo = c.getPersistentVolumeClaimInfo(&vmi.Spec.Volumes[i], vmi.Namespace)
+		if volume.ContainerDisk != nil && status.ContainerDiskVolume == nil {
+			if digest := containerDiskDigestFromPod(virtlauncherPod, volume.Name); digest != "" {
+				status.ContainerDiskVolume = &virtv1.ContainerDiskInfo{ImageDigest: digest}
+			}
 		}
 		newStatus = append(newStatus, status)
 	}
@@ -1683,15 +1907,52 @@ func (c *VMIController) findAttachmentPodByVolumeName(volumeName string, attachm
 	return nil
 }
 
-func (c *VMIController) getVolumePhaseMessageReason(volume *virtv1.Volume, namespace string) (virtv1.VolumePhase, string, string) {
-	claimName := ""
+// isCDRom returns true if the volume is the media of a CD-ROM of the VMI
+func isCDRom(vmi *virtv1.VirtualMachineInstance, volumeName string) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name == volumeName {
+			return disk.CDRom != nil
+		}
+	}
+	return false
+}
+
+// volumeClaimName returns the name of the PVC backing the volume, or an empty string if it is not backed by a PVC
+func volumeClaimName(volume *virtv1.Volume) string {
 	if volume.DataVolume != nil {
 		// Using fact that PVC name = DV name.
-		claimName = volume.DataVolume.Name
+		return volume.DataVolume.Name
 	}
 	if volume.PersistentVolumeClaim != nil {
-		claimName = volume.PersistentVolumeClaim.ClaimName
+		return volume.PersistentVolumeClaim.ClaimName
 	}
+	return ""
+}
+
+// getPersistentVolumeClaimInfo returns the capacity, the volume mode and the access modes of the PVC backing the volume, or nil if the
+// volume is not backed by a PVC or the PVC has no capacity yet
+func (c *VMIController) getPersistentVolumeClaimInfo(volume *virtv1.Volume, namespace string) *virtv1.PersistentVolumeClaimInfo {
+	claimName := volumeClaimName(volume)
+	if claimName == "" {
+		return nil
+	}
+	pvcInterface, pvcExists, _ := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, claimName))
+	if !pvcExists {
+		return nil
+	}
+	pvc := pvcInterface.(*k8sv1.PersistentVolumeClaim)
+	if len(pvc.Status.Capacity) == 0 {
+		return nil
+	}
+	return &virtv1.PersistentVolumeClaimInfo{
+		Capacity:    pvc.Status.Capacity,
+		VolumeMode:  pvc.Spec.VolumeMode,
+		AccessModes: pvc.Spec.AccessModes,
+	}
+}
+
+func (c *VMIController) getVolumePhaseMessageReason(volume *virtv1.Volume, namespace string) (virtv1.VolumePhase, string, string) {
+	claimName := volumeClaimName(volume)
 	pvcInterface, pvcExists, _ := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, claimName))
 	if !pvcExists {
 		return virtv1.VolumePending, FailedPvcNotFoundReason, "Unable to determine PVC name"
@@ -20,6 +20,7 @@
 package watch
 
 import (
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -50,6 +51,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/container-disk/registry"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
@@ -76,6 +78,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 	var dataVolumeSource *framework.FakeControllerSource
 	var dataVolumeInformer cache.SharedIndexInformer
 	var dataVolumeFeeder *testutils.DataVolumeFeeder
+	var nodeInformer cache.SharedIndexInformer
 	var qemuGid int64 = 107
 	controllerOf := true
 
@@ -193,6 +196,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 		config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
 		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		nodeInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Node{})
 		controller = NewVMIController(
 			services.NewTemplateService("a", "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
 			vmiInformer,
@@ -201,6 +205,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			recorder,
 			virtClient,
 			dataVolumeInformer,
+			nodeInformer,
+			config,
 		)
 		// Wrap our workqueue to have a way to detect when we are done processing updates
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
@@ -265,6 +271,235 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
 		})
 
+		It("should create the backend storage PVC before the Pod for a persistent TPM", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			persistent := true
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: &persistent}
+
+			kubeClient.Fake.PrependReactor("get", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				return true, nil, k8serrors.NewNotFound(k8sv1.Resource("persistentvolumeclaims"), action.(testing.GetAction).GetName())
+			})
+			pvcCreated := false
+			kubeClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				pvc := action.(testing.CreateAction).GetObject().(*k8sv1.PersistentVolumeClaim)
+				Expect(pvc.Name).To(Equal("persistent-state-for-testvmi"))
+				Expect(pvc.Spec.AccessModes).To(ConsistOf(k8sv1.ReadWriteMany))
+				pvcCreated = true
+				return true, pvc, nil
+			})
+
+			addVirtualMachine(vmi)
+			shouldExpectPodCreation(vmi.UID)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			Expect(pvcCreated).To(BeTrue())
+		})
+
+		It("should assign a VSOCK CID to the VMI if VSOCK is requested", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			autoattach := true
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = &autoattach
+
+			addVirtualMachine(vmi)
+			shouldExpectPodCreation(vmi.UID)
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.VSOCKCID).ToNot(BeNil())
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		It("should resolve the cluster-common CPU model before creating the Pod", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Model:    v1.CPUModelClusterCommon,
+				Features: []v1.CPUFeature{{Name: "pcid", Policy: "disable"}},
+			}
+			for _, name := range []string{"node01", "node02"} {
+				nodeInformer.GetIndexer().Add(&k8sv1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+						Labels: map[string]string{
+							v1.NodeSchedulable:                               "true",
+							services.NFD_CPU_MODEL_PREFIX + "Haswell":        "true",
+							services.NFD_CPU_MODEL_PREFIX + "Skylake-Client": "true",
+							services.NFD_CPU_FEATURE_PREFIX + "pcid":         "true",
+							services.NFD_CPU_FEATURE_PREFIX + "invtsc":       "true",
+							services.NFD_CPU_FEATURE_PREFIX + "vmx-" + name:  "true",
+						},
+					},
+				})
+			}
+
+			addVirtualMachine(vmi)
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, pt types.PatchType, data []byte) (*v1.VirtualMachineInstance, error) {
+				Expect(string(data)).To(Equal(`[ { "op": "test", "path": "/spec/domain/cpu/model", "value": "cluster-common" }, ` +
+					`{ "op": "replace", "path": "/spec/domain/cpu/model", "value": "Skylake-Client" }, ` +
+					`{ "op": "add", "path": "/spec/domain/cpu/features", "value": [{"name":"pcid","policy":"disable"},{"name":"invtsc","policy":"require"}] } ]`))
+				return vmi, nil
+			})
+
+			controller.Execute()
+		})
+
+		It("should not create the Pod if no CPU model is common to all nodes", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Model: v1.CPUModelClusterCommon}
+			for name, model := range map[string]string{"node01": "Haswell", "node02": "EPYC"} {
+				nodeInformer.GetIndexer().Add(&k8sv1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+						Labels: map[string]string{
+							v1.NodeSchedulable:                    "true",
+							services.NFD_CPU_MODEL_PREFIX + model: "true",
+						},
+					},
+				})
+			}
+
+			addVirtualMachine(vmi)
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions[0].Reason).To(Equal(FailedClusterCommonCPUReason))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, FailedClusterCommonCPUReason)
+		})
+
+		Context("with containerDisk digest resolution", func() {
+			var fakeRegistry *fakeContainerDiskRegistry
+
+			BeforeEach(func() {
+				config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "kubevirt",
+						Namespace: "kubevirt",
+					},
+					Spec: v1.KubeVirtSpec{
+						Configuration: v1.KubeVirtConfiguration{
+							ContainerDisks: &v1.ContainerDiskConfiguration{
+								ResolveDigests: true,
+								SignatureKeys:  []string{containerDiskSignatureKey},
+							},
+						},
+					},
+					Status: v1.KubeVirtStatus{
+						Phase: v1.KubeVirtPhaseDeploying,
+					},
+				})
+				controller.clusterConfig = config
+				fakeRegistry = &fakeContainerDiskRegistry{
+					digests: map[string]string{"quay.io/kubevirt/cirros:latest": "sha256:abcd"},
+				}
+				controller.containerDiskRegistry = fakeRegistry
+			})
+
+			It("should record the verified digest of the image before creating the Pod", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/kubevirt/cirros:latest"},
+					},
+				})
+
+				addVirtualMachine(vmi)
+				// the images are resolved in the background, the VMI is enqueued again once they are
+				mockQueue.ExpectAdds(1)
+				controller.Execute()
+				mockQueue.Wait()
+				Expect(fakeRegistry.verified).To(ConsistOf("quay.io/kubevirt/cirros:latest@sha256:abcd"))
+
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, pt types.PatchType, data []byte) (*v1.VirtualMachineInstance, error) {
+					Expect(string(data)).To(Equal(`[{ "op": "test", "path": "/status/volumeStatus", "value": [] }, { "op": "replace", "path": "/status/volumeStatus", "value": ` +
+						`[{"name":"disk0","target":"","containerDiskVolume":{"imageDigest":"sha256:abcd","signatureVerified":true}}] }]`))
+					return vmi, nil
+				})
+				controller.Execute()
+			})
+
+			It("should verify a recorded digest whose signature was not verified", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/kubevirt/cirros:latest"},
+					},
+				})
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+					Name:                "disk0",
+					ContainerDiskVolume: &v1.ContainerDiskInfo{ImageDigest: "sha256:0123"},
+				}}
+
+				addVirtualMachine(vmi)
+				mockQueue.ExpectAdds(1)
+				controller.Execute()
+				mockQueue.Wait()
+				Expect(fakeRegistry.verified).To(ConsistOf("quay.io/kubevirt/cirros:latest@sha256:abcd"))
+
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, pt types.PatchType, data []byte) (*v1.VirtualMachineInstance, error) {
+					Expect(string(data)).To(HaveSuffix(`{ "op": "replace", "path": "/status/volumeStatus", "value": ` +
+						`[{"name":"disk0","target":"","containerDiskVolume":{"imageDigest":"sha256:abcd","signatureVerified":true}}] }]`))
+					return vmi, nil
+				})
+				controller.Execute()
+			})
+
+			It("should not resolve the image again once its digest is recorded", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/kubevirt/cirros:latest"},
+					},
+				})
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+					Name:                "disk0",
+					ContainerDiskVolume: &v1.ContainerDiskInfo{ImageDigest: "sha256:abcd", SignatureVerified: true},
+				}}
+				fakeRegistry.digests = nil
+
+				addVirtualMachine(vmi)
+				shouldExpectMatchingPodCreation(vmi.UID, WithTransform(func(pod *k8sv1.Pod) string {
+					for _, container := range pod.Spec.Containers {
+						if container.Name == "volumedisk0" {
+							return container.Image
+						}
+					}
+					return ""
+				}, Equal("quay.io/kubevirt/cirros@sha256:abcd")))
+
+				controller.Execute()
+				Expect(fakeRegistry.verified).To(BeEmpty())
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			})
+
+			It("should not create the Pod if the signature of the image can't be verified", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/kubevirt/cirros:latest"},
+					},
+				})
+				fakeRegistry.verifyErr = fmt.Errorf("image quay.io/kubevirt/cirros:latest is not signed")
+
+				addVirtualMachine(vmi)
+				mockQueue.ExpectAdds(1)
+				controller.Execute()
+				mockQueue.Wait()
+
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions[0].Reason).To(Equal(FailedContainerDiskVerificationReason))
+				}).Return(vmi, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, FailedContainerDiskVerificationReason)
+			})
+		})
+
 		It("should create a doppleganger Pod on VMI creation when DataVolume is in WaitForFirstConsumer state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 
@@ -993,6 +1228,40 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				}, {Name: "istio-proxy", Ready: false}},
 			),
 		)
+		table.DescribeTable("should annotate the vmi on hand over if KSM is enabled on the node", func(ksmLabel string, expectAnnotation bool) {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Scheduling
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Spec.NodeName = "testnode"
+			pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+				Name: "compute", Ready: true, State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}},
+			}}
+			nodeInformer.GetIndexer().Add(&k8sv1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "testnode",
+					Labels: map[string]string{v1.KSMEnabledLabel: ksmLabel},
+				},
+			})
+
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+			addActivePods(vmi, pod.UID, "testnode")
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				updated := arg.(*v1.VirtualMachineInstance)
+				Expect(updated.Status.Phase).To(Equal(v1.Scheduled))
+				if expectAnnotation {
+					Expect(updated.Annotations).To(HaveKeyWithValue(v1.KSMEnabledAnnotation, "true"))
+				} else {
+					Expect(updated.Annotations).ToNot(HaveKey(v1.KSMEnabledAnnotation))
+				}
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		},
+			table.Entry("with KSM enabled on the node", "true", true),
+			table.Entry("with KSM disabled on the node", "false", false),
+		)
 		table.DescribeTable("should not hand over pod to virt-handler if pod is ready and running", func(containerStatus []k8sv1.ContainerStatus) {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Scheduling
@@ -1311,6 +1580,39 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			controller.Execute()
 		})
 
+		It("should claim the addresses of the networks with persistent IPs if the VMI is in running state", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net", PersistentIPs: true}}},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "red", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Annotations[services.MultusNetworkStatusAnnotation] = `[{"name":"default/red-net","interface":"net1","ips":["10.10.10.5"]}]`
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(_ string, _ interface{}, patchBytes []byte) (*v1.VirtualMachineInstance, error) {
+				patch, err := jsonpatch.DecodePatch(patchBytes)
+				Expect(err).ToNot(HaveOccurred())
+				vmiBytes, err := json.Marshal(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				vmiBytes, err = patch.Apply(vmiBytes)
+				Expect(err).ToNot(HaveOccurred())
+				patchedVMI := &v1.VirtualMachineInstance{}
+				err = json.Unmarshal(vmiBytes, patchedVMI)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(patchedVMI.Annotations).To(HaveKeyWithValue(v1.IPClaimsAnnotation, `{"red":["10.10.10.5"]}`))
+				return patchedVMI, nil
+			})
+
+			controller.Execute()
+		})
+
 		It("should indicate on the ready condition if the pod is terminating", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Running
@@ -1420,6 +1722,69 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		)
 	})
 
+	Context("hotplug interface", func() {
+		It("should add hotplugged networks to the pod multus annotation", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+			}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			annotation, err := services.GenerateMultusCNIAnnotation(vmi, nil)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Annotations[services.MultusNetworksAnnotation] = annotation
+
+			vmi.Spec.Networks = append(vmi.Spec.Networks,
+				v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}})
+			kubeClient.Fake.PrependReactor("update", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				update, ok := action.(testing.UpdateAction)
+				Expect(ok).To(BeTrue())
+				annotation := update.GetObject().(*k8sv1.Pod).Annotations[services.MultusNetworksAnnotation]
+				Expect(annotation).To(ContainSubstring(`{"interface":"net1","name":"red-net"`))
+				Expect(annotation).To(ContainSubstring(`{"interface":"net2","name":"blue-net"`))
+				return true, update.GetObject(), nil
+			})
+
+			Expect(controller.syncPodNetworksAnnotation(vmi, pod)).To(Succeed())
+			Expect(kubeClient.Actions()).To(HaveLen(1))
+		})
+
+		It("should release the multus attachment of the last unplugged network", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+			}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			annotation, err := services.GenerateMultusCNIAnnotation(vmi, nil)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Annotations[services.MultusNetworksAnnotation] = annotation
+
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "red", State: v1.InterfaceStateAbsent}}
+			kubeClient.Fake.PrependReactor("update", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				update, ok := action.(testing.UpdateAction)
+				Expect(ok).To(BeTrue())
+				Expect(update.GetObject().(*k8sv1.Pod).Annotations[services.MultusNetworksAnnotation]).To(Equal("[]"))
+				return true, update.GetObject(), nil
+			})
+
+			Expect(controller.syncPodNetworksAnnotation(vmi, pod)).To(Succeed())
+			Expect(kubeClient.Actions()).To(HaveLen(1))
+		})
+
+		It("should not update the pod when the networks did not change", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Networks = []v1.Network{
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+			}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			annotation, err := services.GenerateMultusCNIAnnotation(vmi, nil)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Annotations[services.MultusNetworksAnnotation] = annotation
+
+			Expect(controller.syncPodNetworksAnnotation(vmi, pod)).To(Succeed())
+			Expect(kubeClient.Actions()).To(BeEmpty())
+		})
+	})
+
 	Context("hotplug volume", func() {
 		It("Should find vmi, from virt-launcher pod", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
@@ -2028,6 +2393,25 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			table.Entry("should return multiple volumes if vmi has multiple more than virtlauncher, with matching volumes", makeK8sVolumes(1, 3), makeVolumes(1, 2, 3, 4, 5), 2, 4, 5),
 		)
 
+		table.DescribeTable("getHotplugVolumes with media inserted into a CD-ROM", func(diskDevice v1.DiskDevice, expectHotplug bool) {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{*makeVolumes(1)[0]}
+			vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "other-claim"
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "volume1", DiskDevice: diskDevice}}
+			virtlauncherPod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			virtlauncherPod.Spec.Volumes = makeK8sVolumes(1)
+			res := controller.getHotplugVolumes(vmi, virtlauncherPod)
+			if expectHotplug {
+				Expect(res).To(HaveLen(1))
+				Expect(res[0].Name).To(Equal("volume1"))
+			} else {
+				Expect(res).To(BeEmpty())
+			}
+		},
+			table.Entry("should return the media if the launcher pod has another claim for the CD-ROM", v1.DiskDevice{CDRom: &v1.CDRomTarget{}}, true),
+			table.Entry("should not return a disk with a volume matching the launcher pod by name", v1.DiskDevice{Disk: &v1.DiskTarget{}}, false),
+		)
+
 		truncateSprintf := func(str string, args ...interface{}) string {
 			n := strings.Count(str, "%d")
 			return fmt.Sprintf(str, args[:n]...)
@@ -2115,6 +2499,75 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				makeVolumeStatusesForUpdate()),
 		)
 
+		It("should report the capacity, the volume mode and the access modes of the PVC backing a volume", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			for _, volume := range makeVolumes(0, 1) {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, *volume)
+			}
+			virtlauncherPod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			virtlauncherPod.Spec.Volumes = makeK8sVolumes(0, 1)
+			blockMode := k8sv1.PersistentVolumeBlock
+			pvc := NewPvc(vmi.Namespace, "claim0")
+			pvc.Spec.VolumeMode = &blockMode
+			pvc.Spec.AccessModes = []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany}
+			pvc.Status.Capacity = k8sv1.ResourceList{
+				k8sv1.ResourceStorage: resource.MustParse("2Gi"),
+			}
+			pvcInformer.GetIndexer().Add(pvc)
+			// PVCs without capacity are not bound yet and are not reported
+			pvcInformer.GetIndexer().Add(NewPvc(vmi.Namespace, "claim1"))
+
+			Expect(controller.updateVolumeStatus(vmi, virtlauncherPod)).To(Succeed())
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(2))
+			Expect(vmi.Status.VolumeStatus[0].PersistentVolumeClaimInfo).To(Equal(&v1.PersistentVolumeClaimInfo{
+				Capacity:    pvc.Status.Capacity,
+				VolumeMode:  &blockMode,
+				AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+			}))
+			Expect(vmi.Status.VolumeStatus[1].PersistentVolumeClaimInfo).To(BeNil())
+		})
+
+		It("should report the digest of the image a containerDisk runs", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "disk0",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/kubevirt/cirros:latest"},
+				},
+			})
+			virtlauncherPod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			virtlauncherPod.Status.ContainerStatuses = append(virtlauncherPod.Status.ContainerStatuses, k8sv1.ContainerStatus{
+				Name:    "volumedisk0",
+				ImageID: "quay.io/kubevirt/cirros@sha256:abcd",
+			})
+
+			Expect(controller.updateVolumeStatus(vmi, virtlauncherPod)).To(Succeed())
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(vmi.Status.VolumeStatus[0].ContainerDiskVolume).To(Equal(&v1.ContainerDiskInfo{ImageDigest: "sha256:abcd"}))
+		})
+
+		It("should enqueue the vmis using a PVC when its capacity changes", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			for _, volume := range makeVolumes(0) {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, *volume)
+			}
+			vmiInformer.GetIndexer().Add(vmi)
+			oldPVC := NewPvc(vmi.Namespace, "claim0")
+			oldPVC.ResourceVersion = "1"
+			oldPVC.Status.Capacity = k8sv1.ResourceList{
+				k8sv1.ResourceStorage: resource.MustParse("1Gi"),
+			}
+
+			curPVC := oldPVC.DeepCopy()
+			curPVC.ResourceVersion = "2"
+			controller.updatePVC(oldPVC, curPVC)
+			Expect(controller.Queue.Len()).To(Equal(0))
+
+			curPVC.Status.Capacity[k8sv1.ResourceStorage] = resource.MustParse("2Gi")
+			controller.updatePVC(oldPVC, curPVC)
+			Expect(controller.Queue.Len()).To(Equal(1))
+		})
+
 		It("Should properly create attachmentpod, if correct volume and disk are added", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			volumes := make([]v1.Volume, 0)
@@ -2348,6 +2801,34 @@ func NewPendingVirtualMachine(name string) *v1.VirtualMachineInstance {
 	return vmi
 }
 
+// containerDiskSignatureKey is a cosign public key, the fake registry does not check signatures with it
+const containerDiskSignatureKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEYpditlhRCzuGEQd+xCdtTml/xKL4
+/RngjbU6+ABPl0d1L8YuKspsCTnmw4jLEWZl46WnoO6hDT1WfokU2AAs2Q==
+-----END PUBLIC KEY-----`
+
+type fakeContainerDiskRegistry struct {
+	digests   map[string]string
+	verifyErr error
+	verified  []string
+}
+
+func (r *fakeContainerDiskRegistry) ResolveDigest(image string, _ *registry.Credentials) (string, error) {
+	digest, ok := r.digests[image]
+	if !ok {
+		return "", fmt.Errorf("image %s not found", image)
+	}
+	return digest, nil
+}
+
+func (r *fakeContainerDiskRegistry) VerifySignature(image string, digest string, _ []*ecdsa.PublicKey, _ *registry.Credentials) error {
+	if r.verifyErr != nil {
+		return r.verifyErr
+	}
+	r.verified = append(r.verified, image+"@"+digest)
+	return nil
+}
+
 func NewPodForVirtualMachine(vmi *v1.VirtualMachineInstance, phase k8sv1.PodPhase) *k8sv1.Pod {
 	return &k8sv1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
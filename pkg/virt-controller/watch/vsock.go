@@ -0,0 +1,122 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+// The CIDs 0, 1 and 2 are reserved for the hypervisor, the loopback and the host
+const minCID = 3
+
+// cidsMap keeps track of the VSOCK CIDs assigned to the VMIs of the cluster.
+// A CID only has to be unique per host, but VMIs can migrate, so the CIDs are
+// kept unique across the whole cluster.
+type cidsMap struct {
+	mu      sync.Mutex
+	cids    map[string]uint32
+	reverse map[uint32]string
+	randCID func() uint32
+}
+
+func newCIDsMap() *cidsMap {
+	return &cidsMap{
+		cids:    make(map[string]uint32),
+		reverse: make(map[uint32]string),
+		randCID: func() uint32 {
+			return uint32(rand.Int63n(math.MaxUint32-minCID) + minCID)
+		},
+	}
+}
+
+// Sync loads the CIDs already assigned to the given VMIs, so that they are not handed out again.
+func (m *cidsMap) Sync(vmis []*virtv1.VirtualMachineInstance) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, vmi := range vmis {
+		if vmi.Status.VSOCKCID == nil {
+			continue
+		}
+		key, err := controller.KeyFunc(vmi)
+		if err != nil {
+			continue
+		}
+		m.cids[key] = *vmi.Status.VSOCKCID
+		m.reverse[*vmi.Status.VSOCKCID] = key
+	}
+}
+
+// Allocate returns the CID of the VMI, assigning a free one if the VMI does not have one yet.
+func (m *cidsMap) Allocate(vmi *virtv1.VirtualMachineInstance) (uint32, error) {
+	key, err := controller.KeyFunc(vmi)
+	if err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cid, exists := m.cids[key]; exists {
+		return cid, nil
+	}
+	// Keep the CID which is already reported, e.g. after a controller restart
+	if vmi.Status.VSOCKCID != nil {
+		if owner, taken := m.reverse[*vmi.Status.VSOCKCID]; !taken || owner == key {
+			m.assign(key, *vmi.Status.VSOCKCID)
+			return *vmi.Status.VSOCKCID, nil
+		}
+	}
+
+	// Start from a random CID to avoid reusing the CID of a VMI which was just removed
+	start := m.randCID()
+	for cid := start; cid < math.MaxUint32; cid++ {
+		if _, taken := m.reverse[cid]; !taken {
+			m.assign(key, cid)
+			return cid, nil
+		}
+	}
+	for cid := uint32(minCID); cid < start; cid++ {
+		if _, taken := m.reverse[cid]; !taken {
+			m.assign(key, cid)
+			return cid, nil
+		}
+	}
+	return 0, fmt.Errorf("no VSOCK CIDs left to assign")
+}
+
+func (m *cidsMap) assign(key string, cid uint32) {
+	m.cids[key] = cid
+	m.reverse[cid] = key
+}
+
+// Remove releases the CID assigned to the VMI with the given key.
+func (m *cidsMap) Remove(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cid, exists := m.cids[key]; exists {
+		delete(m.reverse, cid)
+		delete(m.cids, key)
+	}
+}
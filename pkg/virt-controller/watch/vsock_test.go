@@ -0,0 +1,91 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("VSOCK CIDs map", func() {
+
+	var m *cidsMap
+
+	newVMI := func(name string) *virtv1.VirtualMachineInstance {
+		return &virtv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		}
+	}
+
+	BeforeEach(func() {
+		m = newCIDsMap()
+	})
+
+	It("should assign a stable CID to a VMI", func() {
+		vmi := newVMI("testvmi")
+		cid, err := m.Allocate(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cid).To(BeNumerically(">=", minCID))
+
+		again, err := m.Allocate(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(again).To(Equal(cid))
+	})
+
+	It("should assign different CIDs to different VMIs", func() {
+		m.randCID = func() uint32 { return minCID }
+		cid1, err := m.Allocate(newVMI("testvmi1"))
+		Expect(err).ToNot(HaveOccurred())
+		cid2, err := m.Allocate(newVMI("testvmi2"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cid1).ToNot(Equal(cid2))
+	})
+
+	It("should not hand out the CIDs of synced VMIs", func() {
+		m.randCID = func() uint32 { return minCID }
+		cid := uint32(minCID)
+		synced := newVMI("synced")
+		synced.Status.VSOCKCID = &cid
+		m.Sync([]*virtv1.VirtualMachineInstance{synced})
+
+		newCID, err := m.Allocate(newVMI("testvmi"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(newCID).ToNot(Equal(cid))
+
+		syncedCID, err := m.Allocate(synced)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(syncedCID).To(Equal(cid))
+	})
+
+	It("should release the CID of a removed VMI", func() {
+		m.randCID = func() uint32 { return minCID }
+		vmi := newVMI("testvmi")
+		cid, err := m.Allocate(vmi)
+		Expect(err).ToNot(HaveOccurred())
+
+		m.Remove(metav1.NamespaceDefault + "/testvmi")
+		newCID, err := m.Allocate(newVMI("othervmi"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(newCID).To(Equal(cid))
+	})
+})
@@ -0,0 +1,430 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package exportserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/container-disk/registry"
+)
+
+const (
+	manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	configMediaType   = "application/vnd.oci.image.config.v1+json"
+	layerMediaType    = "application/vnd.oci.image.layer.v1.tar+gzip"
+
+	// the containerDisk image is expected in /disk, readable by the qemu user
+	containerDiskDir = "disk"
+	qemuUserID       = 107
+)
+
+// ContainerDiskImage is a volume packaged into the containerDisk image Image
+type ContainerDiskImage struct {
+	Volume ExportVolume
+	// Image is the reference the image is pushed to, it must not contain a digest
+	Image string
+}
+
+// PushedContainerDisk is the containerDisk image a volume was pushed to
+type PushedContainerDisk struct {
+	Name string `json:"name"`
+	// Image is the reference of the pushed image including its digest
+	Image string `json:"image"`
+}
+
+// ContainerDiskPushConfig holds the configuration of the containerDisk pusher
+type ContainerDiskPushConfig struct {
+	// ScratchDir is used to store the converted qcow2 images and the image layers
+	ScratchDir string
+	// AuthFile is a .dockerconfigjson file holding the credentials of the registry
+	AuthFile              string
+	InsecureSkipTLSVerify bool
+	Images                []ContainerDiskImage
+}
+
+// ContainerDiskPusher packages volumes into containerDisk images and pushes them to a registry
+type ContainerDiskPusher interface {
+	Push() ([]PushedContainerDisk, error)
+}
+
+type containerDiskPusher struct {
+	ContainerDiskPushConfig
+
+	client *http.Client
+	// overridden in tests
+	convertToQcow2 func(src, dst string) error
+}
+
+// NewContainerDiskPusher creates a new containerDisk pusher
+func NewContainerDiskPusher(config ContainerDiskPushConfig) ContainerDiskPusher {
+	return &containerDiskPusher{
+		ContainerDiskPushConfig: config,
+		client: &http.Client{
+			Timeout: time.Hour,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				// #nosec The user explicitly asked to skip the verification of the registry
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: config.InsecureSkipTLSVerify},
+			},
+		},
+		convertToQcow2: qemuImgConvert,
+	}
+}
+
+// Push pushes all images and returns the references including their digests
+func (p *containerDiskPusher) Push() ([]PushedContainerDisk, error) {
+	var pushed []PushedContainerDisk
+	for _, image := range p.Images {
+		ref, err := registry.ParseReference(image.Image)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Digest != "" {
+			return nil, fmt.Errorf("invalid image reference %q, expected [registry/]repository[:tag]", image.Image)
+		}
+
+		digest, err := p.push(image.Volume, image.Image, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to push volume %s to %s: %v", image.Volume.Name, image.Image, err)
+		}
+
+		pushed = append(pushed, PushedContainerDisk{
+			Name:  image.Volume.Name,
+			Image: fmt.Sprintf("%s/%s@%s", ref.Registry, ref.Repository, digest),
+		})
+		log.Log.Infof("Pushed volume %s to %s", image.Volume.Name, pushed[len(pushed)-1].Image)
+	}
+
+	return pushed, nil
+}
+
+// push builds the image of the volume and pushes its layer, config and manifest
+func (p *containerDiskPusher) push(volume ExportVolume, image string, ref *registry.Reference) (string, error) {
+	qcow2 := filepath.Join(p.ScratchDir, volume.Name+".qcow2")
+	if err := p.convertToQcow2(volume.Path, qcow2); err != nil {
+		return "", err
+	}
+	defer os.Remove(qcow2)
+
+	layerFile := filepath.Join(p.ScratchDir, volume.Name+".tar.gz")
+	layer, diffID, err := buildLayer(qcow2, layerFile)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(layerFile)
+
+	config, err := json.Marshal(map[string]interface{}{
+		"architecture": runtime.GOARCH,
+		"os":           "linux",
+		"config":       map[string]interface{}{},
+		"rootfs": map[string]interface{}{
+			"type":     "layers",
+			"diff_ids": []string{diffID},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	configDescriptor := descriptor{MediaType: configMediaType, Digest: sha256Digest(config), Size: int64(len(config))}
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     manifestMediaType,
+		"config":        configDescriptor,
+		"layers":        []descriptor{*layer},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	client, err := p.newRegistryClient(image, ref)
+	if err != nil {
+		return "", err
+	}
+
+	if err := client.pushBlob(layer.Digest, layer.Size, func() (io.ReadCloser, error) { return os.Open(layerFile) }); err != nil {
+		return "", err
+	}
+	if err := client.pushBlob(configDescriptor.Digest, configDescriptor.Size, func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(config)), nil
+	}); err != nil {
+		return "", err
+	}
+	if err := client.pushManifest(ref.Tag, manifest); err != nil {
+		return "", err
+	}
+
+	return sha256Digest(manifest), nil
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func hashDigest(h hash.Hash) string {
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// buildLayer writes the gzip compressed layer holding the qcow2 image to layerFile.
+// It returns the descriptor of the layer and the digest of the uncompressed layer.
+func buildLayer(qcow2, layerFile string) (*descriptor, string, error) {
+	image, err := os.Open(qcow2)
+	if err != nil {
+		return nil, "", err
+	}
+	defer image.Close()
+
+	info, err := image.Stat()
+	if err != nil {
+		return nil, "", err
+	}
+
+	out, err := os.Create(layerFile)
+	if err != nil {
+		return nil, "", err
+	}
+	defer out.Close()
+
+	compressedHash := sha256.New()
+	compressedSize := &countingWriter{}
+	gw := gzip.NewWriter(io.MultiWriter(out, compressedHash, compressedSize))
+	diffHash := sha256.New()
+	tw := tar.NewWriter(io.MultiWriter(gw, diffHash))
+
+	modTime := time.Now()
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     containerDiskDir + "/",
+		Mode:     0555,
+		Uid:      qemuUserID,
+		Gid:      qemuUserID,
+		ModTime:  modTime,
+	}); err != nil {
+		return nil, "", err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     containerDiskDir + "/" + qcow2ImageName,
+		Mode:     0440,
+		Uid:      qemuUserID,
+		Gid:      qemuUserID,
+		Size:     info.Size(),
+		ModTime:  modTime,
+	}); err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(tw, image); err != nil {
+		return nil, "", err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, "", err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, "", err
+	}
+	if err := out.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return &descriptor{
+		MediaType: layerMediaType,
+		Digest:    hashDigest(compressedHash),
+		Size:      compressedSize.n,
+	}, hashDigest(diffHash), nil
+}
+
+type registryClient struct {
+	client     *http.Client
+	baseURL    *url.URL
+	repository string
+	session    *registry.Session
+}
+
+func (p *containerDiskPusher) newRegistryClient(image string, ref *registry.Reference) (*registryClient, error) {
+	credentials, err := p.credentials(image)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &registryClient{
+		client:     p.client,
+		baseURL:    &url.URL{Scheme: "https", Host: ref.APIHost()},
+		repository: ref.Repository,
+		session:    registry.NewClient(p.client).NewSession(ref, credentials, "pull,push"),
+	}
+	if err := r.authorize(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// credentials looks up the credentials of the registry of the image in the auth file
+func (p *containerDiskPusher) credentials(image string) (*registry.Credentials, error) {
+	if p.AuthFile == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(p.AuthFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry credentials: %v", err)
+	}
+	credentials, err := registry.CredentialsFromDockerConfig(content, image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse registry credentials: %v", err)
+	}
+
+	return credentials, nil
+}
+
+// authorize answers the challenge of the registry, either with the credentials
+// directly or with a token issued for pushing to the repository
+func (r *registryClient) authorize() error {
+	resp, err := r.client.Get(r.url("/v2/").String())
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil
+	}
+
+	return r.session.Authorize(resp.Header.Get("WWW-Authenticate"))
+}
+
+func (r *registryClient) url(path string) *url.URL {
+	return r.baseURL.ResolveReference(&url.URL{Path: path})
+}
+
+func (r *registryClient) do(method string, u *url.URL, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+		req.Header.Set("Content-Type", contentType)
+	}
+	if authorization := r.session.Authorization(); authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	return r.client.Do(req)
+}
+
+func unexpectedStatus(action string, resp *http.Response) error {
+	message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%s failed: %s: %s", action, resp.Status, strings.TrimSpace(string(message)))
+}
+
+// pushBlob uploads the blob in a single request, unless the registry has it already
+func (r *registryClient) pushBlob(digest string, size int64, open func() (io.ReadCloser, error)) error {
+	resp, err := r.do(http.MethodHead, r.url(fmt.Sprintf("/v2/%s/blobs/%s", r.repository, digest)), nil, 0, "")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = r.do(http.MethodPost, r.url(fmt.Sprintf("/v2/%s/blobs/uploads/", r.repository)), nil, 0, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return unexpectedStatus("starting the upload of blob "+digest, resp)
+	}
+
+	location, err := r.baseURL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return err
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	blob, err := open()
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	resp, err = r.do(http.MethodPut, location, blob, size, "application/octet-stream")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return unexpectedStatus("uploading blob "+digest, resp)
+	}
+
+	return nil
+}
+
+func (r *registryClient) pushManifest(tag string, manifest []byte) error {
+	resp, err := r.do(http.MethodPut, r.url(fmt.Sprintf("/v2/%s/manifests/%s", r.repository, tag)), bytes.NewReader(manifest), int64(len(manifest)), manifestMediaType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return unexpectedStatus("pushing the manifest", resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package exportserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testRegistryUser     = "user"
+	testRegistryPassword = "password"
+	testRegistryToken    = "registry-token"
+)
+
+// fakeRegistry implements the parts of the registry API used by the pusher
+type fakeRegistry struct {
+	lock      sync.Mutex
+	server    *httptest.Server
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+}
+
+func newFakeRegistry() *fakeRegistry {
+	r := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+	r.server = httptest.NewTLSServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+func (r *fakeRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "https://")
+}
+
+func (r *fakeRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req.URL.Path == "/token" {
+		user, password, ok := req.BasicAuth()
+		if !ok || user != testRegistryUser || password != testRegistryPassword ||
+			req.URL.Query().Get("scope") != "repository:images/fedora:pull,push" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"token": %q}`, testRegistryToken)
+		return
+	}
+
+	if req.Header.Get("Authorization") != "Bearer "+testRegistryToken {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	const prefix = "/v2/images/fedora/"
+	path := strings.TrimPrefix(req.URL.Path, prefix)
+	switch {
+	case req.URL.Path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+	case req.Method == http.MethodHead && strings.HasPrefix(path, "blobs/sha256:"):
+		if _, ok := r.blobs[strings.TrimPrefix(path, "blobs/")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case req.Method == http.MethodPost && path == "blobs/uploads/":
+		r.uploads++
+		w.Header().Set("Location", fmt.Sprintf("%sblobs/uploads/%d?state=abc", prefix, r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodPut && strings.HasPrefix(path, "blobs/uploads/"):
+		data, _ := ioutil.ReadAll(req.Body)
+		digest := req.URL.Query().Get("digest")
+		if sha256Digest(data) != digest || req.URL.Query().Get("state") != "abc" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+	case req.Method == http.MethodPut && strings.HasPrefix(path, "manifests/"):
+		if req.Header.Get("Content-Type") != manifestMediaType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := ioutil.ReadAll(req.Body)
+		r.manifests[strings.TrimPrefix(path, "manifests/")] = data
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("ContainerDisk pusher", func() {
+	var tmpDir string
+	var registry *fakeRegistry
+	var authFile string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "containerdisk")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.Mkdir(filepath.Join(tmpDir, "scratch"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(tmpDir, "disk.img"), []byte(testContent), 0644)).To(Succeed())
+
+		registry = newFakeRegistry()
+		authFile = filepath.Join(tmpDir, "auth.json")
+		auth := fmt.Sprintf(`{"auths": {%q: {"username": %q, "password": %q}}}`, registry.host(), testRegistryUser, testRegistryPassword)
+		Expect(ioutil.WriteFile(authFile, []byte(auth), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		registry.server.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	newPusher := func(tags ...string) *containerDiskPusher {
+		config := ContainerDiskPushConfig{
+			ScratchDir:            filepath.Join(tmpDir, "scratch"),
+			AuthFile:              authFile,
+			InsecureSkipTLSVerify: true,
+		}
+		for i, tag := range tags {
+			config.Images = append(config.Images, ContainerDiskImage{
+				Volume: ExportVolume{Name: fmt.Sprintf("disk%d", i), Path: filepath.Join(tmpDir, "disk.img")},
+				Image:  registry.host() + "/images/fedora:" + tag,
+			})
+		}
+		pusher := NewContainerDiskPusher(config).(*containerDiskPusher)
+		pusher.convertToQcow2 = func(src, dst string) error {
+			data, err := ioutil.ReadFile(src)
+			if err != nil {
+				return err
+			}
+			return ioutil.WriteFile(dst, append([]byte("QFI"), data...), 0644)
+		}
+		return pusher
+	}
+
+	blob := func(d descriptor) []byte {
+		data, ok := registry.blobs[d.Digest]
+		Expect(ok).To(BeTrue(), "blob %s was not pushed", d.Digest)
+		Expect(int64(len(data))).To(Equal(d.Size))
+		return data
+	}
+
+	It("should push the volume as containerDisk image", func() {
+		pushed, err := newPusher("36").Push()
+		Expect(err).ToNot(HaveOccurred())
+
+		manifestData, ok := registry.manifests["36"]
+		Expect(ok).To(BeTrue())
+		Expect(pushed).To(Equal([]PushedContainerDisk{
+			{Name: "disk0", Image: registry.host() + "/images/fedora@" + sha256Digest(manifestData)},
+		}))
+
+		manifest := struct {
+			MediaType string       `json:"mediaType"`
+			Config    descriptor   `json:"config"`
+			Layers    []descriptor `json:"layers"`
+		}{}
+		Expect(json.Unmarshal(manifestData, &manifest)).To(Succeed())
+		Expect(manifest.MediaType).To(Equal(manifestMediaType))
+		Expect(manifest.Layers).To(HaveLen(1))
+
+		gr, err := gzip.NewReader(bytes.NewReader(blob(manifest.Layers[0])))
+		Expect(err).ToNot(HaveOccurred())
+		layer, err := ioutil.ReadAll(gr)
+		Expect(err).ToNot(HaveOccurred())
+
+		config := struct {
+			RootFS struct {
+				DiffIDs []string `json:"diff_ids"`
+			} `json:"rootfs"`
+		}{}
+		Expect(json.Unmarshal(blob(manifest.Config), &config)).To(Succeed())
+		Expect(config.RootFS.DiffIDs).To(Equal([]string{sha256Digest(layer)}))
+
+		tr := tar.NewReader(bytes.NewReader(layer))
+		header, err := tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(header.Name).To(Equal("disk/"))
+		header, err = tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(header.Name).To(Equal("disk/disk.qcow2"))
+		Expect(header.Uid).To(Equal(107))
+		Expect(header.Mode).To(Equal(int64(0440)))
+		image, err := ioutil.ReadAll(tr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(image)).To(Equal("QFI" + testContent))
+		_, err = tr.Next()
+		Expect(err).To(Equal(io.EOF))
+
+		files, err := ioutil.ReadDir(filepath.Join(tmpDir, "scratch"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(BeEmpty())
+	})
+
+	It("should not upload blobs the registry has already", func() {
+		_, err := newPusher("36-disk0", "36-disk1").Push()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(registry.manifests).To(HaveLen(2))
+		Expect(registry.uploads).To(Equal(2))
+	})
+
+	It("should fail without valid credentials", func() {
+		Expect(ioutil.WriteFile(authFile, []byte(`{"auths": {}}`), 0644)).To(Succeed())
+		_, err := newPusher("36").Push()
+		Expect(err).To(MatchError(ContainSubstring("unexpected status 401")))
+		Expect(registry.manifests).To(BeEmpty())
+	})
+
+	It("should reject an image referenced by digest", func() {
+		pusher := newPusher("36")
+		pusher.Images[0].Image = registry.host() + "/images/fedora@sha256:1234"
+		_, err := pusher.Push()
+		Expect(err).To(MatchError(ContainSubstring("invalid image reference")))
+		Expect(registry.manifests).To(BeEmpty())
+	})
+})
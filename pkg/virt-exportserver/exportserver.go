@@ -0,0 +1,293 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package exportserver
+
+import (
+	"compress/gzip"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// TokenHeader is the header clients pass the export token in
+	TokenHeader = "x-kubevirt-export-token"
+	// TokenQueryParam is the query parameter clients can pass the export token in
+	TokenQueryParam = "x-kubevirt-export-token"
+
+	healthzPath = "/healthz"
+
+	rawImageName   = "disk.img"
+	gzipImageName  = "disk.img.gz"
+	qcow2ImageName = "disk.qcow2"
+)
+
+// RawURI returns the path the raw image of a volume is served under
+func RawURI(volume string) string {
+	return volumeURI(volume, rawImageName)
+}
+
+// GzipURI returns the path the gzip compressed image of a volume is served under
+func GzipURI(volume string) string {
+	return volumeURI(volume, gzipImageName)
+}
+
+// Qcow2URI returns the path the qcow2 image of a volume is served under
+func Qcow2URI(volume string) string {
+	return volumeURI(volume, qcow2ImageName)
+}
+
+func volumeURI(volume, image string) string {
+	return fmt.Sprintf("/volumes/%s/%s", volume, image)
+}
+
+// ExportVolume is a volume served by the export server
+type ExportVolume struct {
+	Name string
+	// Path is either the disk image file of a filesystem PVC
+	// or the device of a block PVC
+	Path string
+}
+
+// ExportServerConfig holds the configuration of the export server
+type ExportServerConfig struct {
+	ListenAddr string
+	CertFile   string
+	KeyFile    string
+	TokenFile  string
+	// ScratchDir is used to store the converted qcow2 images
+	ScratchDir string
+	Volumes    []ExportVolume
+}
+
+// ExportServer serves the exported volumes
+type ExportServer interface {
+	Run() error
+}
+
+type exportServer struct {
+	ExportServerConfig
+
+	token string
+
+	qcow2Lock  sync.Mutex
+	qcow2Locks map[string]*sync.Mutex
+
+	// overridden in tests
+	convertToQcow2 func(src, dst string) error
+}
+
+// NewExportServer creates a new export server
+func NewExportServer(config ExportServerConfig) ExportServer {
+	return &exportServer{
+		ExportServerConfig: config,
+		qcow2Locks:         map[string]*sync.Mutex{},
+		convertToQcow2:     qemuImgConvert,
+	}
+}
+
+// Run reads the token and serves the volumes until an error occurs
+func (s *exportServer) Run() error {
+	token, err := ioutil.ReadFile(s.TokenFile)
+	if err != nil {
+		return fmt.Errorf("failed to read token: %v", err)
+	}
+	s.token = strings.TrimSpace(string(token))
+	if s.token == "" {
+		return fmt.Errorf("token file %s is empty", s.TokenFile)
+	}
+
+	server := &http.Server{
+		Addr:    s.ListenAddr,
+		Handler: s.handler(),
+	}
+
+	log.Log.Infof("Serving %d volumes on %s", len(s.Volumes), s.ListenAddr)
+	return server.ListenAndServeTLS(s.CertFile, s.KeyFile)
+}
+
+func (s *exportServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthzPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, volume := range s.Volumes {
+		mux.Handle(RawURI(volume.Name), s.authorized(s.rawHandler(volume)))
+		mux.Handle(GzipURI(volume.Name), s.authorized(s.gzipHandler(volume)))
+		mux.Handle(Qcow2URI(volume.Name), s.authorized(s.qcow2Handler(volume)))
+	}
+
+	return mux
+}
+
+func (s *exportServer) authorized(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(TokenHeader)
+		if token == "" {
+			token = r.URL.Query().Get(TokenQueryParam)
+		}
+
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func allowedMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func (s *exportServer) rawHandler(volume ExportVolume) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowedMethod(w, r) {
+			return
+		}
+
+		f, err := os.Open(volume.Path)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to open volume %s", volume.Name)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		// ServeContent seeks to the end to determine the size,
+		// which works for image files as well as block devices
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, rawImageName, time.Time{}, f)
+	})
+}
+
+func (s *exportServer) gzipHandler(volume ExportVolume) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowedMethod(w, r) {
+			return
+		}
+
+		f, err := os.Open(volume.Path)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to open volume %s", volume.Name)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		w.Header().Set("Content-Type", "application/gzip")
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		gw := gzip.NewWriter(w)
+		if _, err := io.Copy(gw, f); err != nil {
+			log.Log.Reason(err).Errorf("Failed to stream volume %s", volume.Name)
+			return
+		}
+		if err := gw.Close(); err != nil {
+			log.Log.Reason(err).Errorf("Failed to finish stream of volume %s", volume.Name)
+		}
+	})
+}
+
+func (s *exportServer) qcow2Handler(volume ExportVolume) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowedMethod(w, r) {
+			return
+		}
+
+		path, err := s.qcow2Image(volume)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to convert volume %s to qcow2", volume.Name)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to open qcow2 image of volume %s", volume.Name)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, qcow2ImageName, time.Time{}, f)
+	})
+}
+
+// qcow2Image converts the volume on first use and returns the path of the
+// converted image. qcow2 can't be written to a stream, so the image has to
+// be stored in the scratch space.
+func (s *exportServer) qcow2Image(volume ExportVolume) (string, error) {
+	s.qcow2Lock.Lock()
+	lock, ok := s.qcow2Locks[volume.Name]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.qcow2Locks[volume.Name] = lock
+	}
+	s.qcow2Lock.Unlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	dst := filepath.Join(s.ScratchDir, volume.Name+".qcow2")
+	if _, err := os.Stat(dst); err == nil {
+		return dst, nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	tmp := dst + ".tmp"
+	if err := s.convertToQcow2(volume.Path, tmp); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		return "", err
+	}
+
+	return dst, nil
+}
+
+func qemuImgConvert(src, dst string) error {
+	out, err := exec.Command("qemu-img", "convert", "-f", "raw", "-O", "qcow2", src, dst).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("qemu-img convert failed: %v: %s", err, string(out))
+	}
+	return nil
+}
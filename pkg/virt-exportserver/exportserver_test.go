@@ -0,0 +1,173 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package exportserver
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testToken   = "secret-token"
+	testContent = "this is a disk image"
+)
+
+var _ = Describe("Export server", func() {
+	var tmpDir string
+	var server *exportServer
+	var httpServer *httptest.Server
+	var conversions int
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "exportserver")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.Mkdir(filepath.Join(tmpDir, "scratch"), 0755)).To(Succeed())
+
+		diskPath := filepath.Join(tmpDir, "disk.img")
+		Expect(ioutil.WriteFile(diskPath, []byte(testContent), 0644)).To(Succeed())
+
+		conversions = 0
+		server = NewExportServer(ExportServerConfig{
+			ScratchDir: filepath.Join(tmpDir, "scratch"),
+			Volumes: []ExportVolume{
+				{Name: "disk0", Path: diskPath},
+			},
+		}).(*exportServer)
+		server.token = testToken
+		server.convertToQcow2 = func(src, dst string) error {
+			conversions++
+			data, err := ioutil.ReadFile(src)
+			if err != nil {
+				return err
+			}
+			return ioutil.WriteFile(dst, append([]byte("QFI"), data...), 0644)
+		}
+		httpServer = httptest.NewServer(server.handler())
+	})
+
+	AfterEach(func() {
+		httpServer.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	get := func(path string, token string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, httpServer.URL+path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		if token != "" {
+			req.Header.Set(TokenHeader, token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	table.DescribeTable("should reject requests without a valid token", func(uri string, token string) {
+		resp := get(uri, token)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	},
+		table.Entry("raw without token", RawURI("disk0"), ""),
+		table.Entry("raw with wrong token", RawURI("disk0"), "wrong"),
+		table.Entry("gzip with wrong token", GzipURI("disk0"), "wrong"),
+		table.Entry("qcow2 with wrong token", Qcow2URI("disk0"), "wrong"),
+	)
+
+	It("should serve healthz without a token", func() {
+		resp := get(healthzPath, "")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should return not found for unknown volumes", func() {
+		resp := get(RawURI("unknown"), testToken)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should serve the raw image", func() {
+		resp := get(RawURI("disk0"), testToken)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(testContent))
+	})
+
+	It("should accept the token as query parameter", func() {
+		resp := get(fmt.Sprintf("%s?%s=%s", RawURI("disk0"), TokenQueryParam, testToken), "")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should serve the gzip compressed image", func() {
+		resp := get(GzipURI("disk0"), testToken)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("application/gzip"))
+		gr, err := gzip.NewReader(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		body, err := ioutil.ReadAll(gr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(testContent))
+	})
+
+	It("should convert the image to qcow2 only once", func() {
+		for i := 0; i < 2; i++ {
+			resp := get(Qcow2URI("disk0"), testToken)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("QFI" + testContent))
+		}
+		Expect(conversions).To(Equal(1))
+	})
+
+	It("should fail when the qcow2 conversion fails", func() {
+		server.convertToQcow2 = func(src, dst string) error {
+			return fmt.Errorf("conversion failed")
+		}
+		resp := get(Qcow2URI("disk0"), testToken)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		_, err := os.Stat(filepath.Join(tmpDir, "scratch", "disk0.qcow2"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("should reject methods other than GET and HEAD", func() {
+		req, err := http.NewRequest(http.MethodPut, httpServer.URL+RawURI("disk0"), nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(TokenHeader, testToken)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
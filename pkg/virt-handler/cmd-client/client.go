@@ -93,6 +93,21 @@ type LauncherClient interface {
 	GetGuestInfo() (*v1.VirtualMachineInstanceGuestAgentInfo, error)
 	GetUsers() (v1.VirtualMachineInstanceGuestOSUserList, error)
 	GetFilesystems() (v1.VirtualMachineInstanceFileSystemList, error)
+	GetSEVInfo() (*v1.SEVPlatformInfo, error)
+	GetLaunchMeasurement(vmi *v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
+	InjectLaunchSecret(vmi *v1.VirtualMachineInstance, options *v1.SEVSecretOptions) error
+	FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error
+	UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	SyncVirtualMachineCPUs(vmi *v1.VirtualMachineInstance) error
+	SyncVirtualMachineMemory(vmi *v1.VirtualMachineInstance) error
+	SetVirtualMachineMemoryBalloon(vmi *v1.VirtualMachineInstance, target uint64) error
+	Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error)
+	GuestPing(domainName string, timeoutSeconds int32) error
+	StartVirtualMachineBackup(vmi *v1.VirtualMachineInstance, options *v1.BackupOptions) error
+	StopVirtualMachineBackup(vmi *v1.VirtualMachineInstance) error
+	VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, options *v1.MemoryDumpOptions) error
+	VirtualMachineExternalSnapshot(vmi *v1.VirtualMachineInstance, options *v1.ExternalSnapshotOptions) error
+	VirtualMachineBlockCommit(vmi *v1.VirtualMachineInstance, options *v1.BlockCommitOptions) error
 	Ping() error
 	Close()
 }
@@ -414,6 +429,43 @@ func (c *VirtLauncherClient) UnpauseVirtualMachine(vmi *v1.VirtualMachineInstanc
 	return c.genericSendVMICmd("Unpause", c.v1client.UnpauseVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
 
+func (c *VirtLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.FreezeRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		UnfreezeTimeoutSeconds: unfreezeTimeoutSeconds,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+	response, err := c.v1client.FreezeVirtualMachine(ctx, request)
+
+	err = handleError(err, "Freeze", response)
+	return err
+}
+
+func (c *VirtLauncherClient) UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("Unfreeze", c.v1client.UnfreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) SyncVirtualMachineCPUs(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("SyncVirtualMachineCPUs", c.v1client.SyncVirtualMachineCPUs, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) SyncVirtualMachineMemory(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("SyncVirtualMachineMemory", c.v1client.SyncVirtualMachineMemory, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) SetVirtualMachineMemoryBalloon(vmi *v1.VirtualMachineInstance, target uint64) error {
+	return c.genericSendVMICmd("SetVirtualMachineMemoryBalloon", c.v1client.SetVirtualMachineMemoryBalloon, vmi, &cmdv1.VirtualMachineOptions{BalloonTarget: target})
+}
+
 func (c *VirtLauncherClient) ShutdownVirtualMachine(vmi *v1.VirtualMachineInstance) error {
 	return c.genericSendVMICmd("Shutdown", c.v1client.ShutdownVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
@@ -524,6 +576,240 @@ func (c *VirtLauncherClient) GetDomainStats() (*stats.DomainStats, bool, error)
 	return stats, exists, nil
 }
 
+func (c *VirtLauncherClient) GetSEVInfo() (*v1.SEVPlatformInfo, error) {
+	request := &cmdv1.EmptyRequest{}
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	sevInfoResponse, err := c.v1client.GetSEVInfo(ctx, request)
+	var response *cmdv1.Response
+	if sevInfoResponse != nil {
+		response = sevInfoResponse.Response
+	}
+
+	if err = handleError(err, "GetSEVInfo", response); err != nil {
+		return nil, err
+	}
+
+	sevPlatformInfo := &v1.SEVPlatformInfo{}
+	if err := json.Unmarshal(sevInfoResponse.GetSevInfo(), sevPlatformInfo); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling SEV info response")
+		return nil, err
+	}
+
+	return sevPlatformInfo, nil
+}
+
+func (c *VirtLauncherClient) GetLaunchMeasurement(vmi *v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error) {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &cmdv1.VMIRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	launchMeasurementResponse, err := c.v1client.GetLaunchMeasurement(ctx, request)
+	var response *cmdv1.Response
+	if launchMeasurementResponse != nil {
+		response = launchMeasurementResponse.Response
+	}
+
+	if err = handleError(err, "GetLaunchMeasurement", response); err != nil {
+		return nil, err
+	}
+
+	sevMeasurementInfo := &v1.SEVMeasurementInfo{}
+	if err := json.Unmarshal(launchMeasurementResponse.GetLaunchMeasurement(), sevMeasurementInfo); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling launch measurement response")
+		return nil, err
+	}
+
+	return sevMeasurementInfo, nil
+}
+
+func (c *VirtLauncherClient) InjectLaunchSecret(vmi *v1.VirtualMachineInstance, options *v1.SEVSecretOptions) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.InjectLaunchSecretRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+	response, err := c.v1client.InjectLaunchSecret(ctx, request)
+
+	err = handleError(err, "InjectLaunchSecret", response)
+	return err
+}
+
+func (c *VirtLauncherClient) StartVirtualMachineBackup(vmi *v1.VirtualMachineInstance, options *v1.BackupOptions) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.BackupRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+	response, err := c.v1client.StartVirtualMachineBackup(ctx, request)
+
+	err = handleError(err, "StartVirtualMachineBackup", response)
+	return err
+}
+
+func (c *VirtLauncherClient) StopVirtualMachineBackup(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("StopBackup", c.v1client.StopVirtualMachineBackup, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, options *v1.MemoryDumpOptions) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.MemoryDumpRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+	response, err := c.v1client.VirtualMachineMemoryDump(ctx, request)
+
+	err = handleError(err, "VirtualMachineMemoryDump", response)
+	return err
+}
+
+func (c *VirtLauncherClient) VirtualMachineExternalSnapshot(vmi *v1.VirtualMachineInstance, options *v1.ExternalSnapshotOptions) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.ExternalSnapshotRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+	response, err := c.v1client.VirtualMachineExternalSnapshot(ctx, request)
+
+	err = handleError(err, "VirtualMachineExternalSnapshot", response)
+	return err
+}
+
+func (c *VirtLauncherClient) VirtualMachineBlockCommit(vmi *v1.VirtualMachineInstance, options *v1.BlockCommitOptions) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.BlockCommitRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+	response, err := c.v1client.VirtualMachineBlockCommit(ctx, request)
+
+	err = handleError(err, "VirtualMachineBlockCommit", response)
+	return err
+}
+
+// Exec runs the command with its arguments on the guest through the guest agent and returns
+// its exit code and standard output
+func (c *VirtLauncherClient) Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error) {
+	request := &cmdv1.ExecRequest{
+		DomainName:     domainName,
+		Command:        command,
+		Args:           args,
+		TimeoutSeconds: timeoutSeconds,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	resp, err := c.v1client.Exec(ctx, request)
+	var response *cmdv1.Response
+	if resp != nil {
+		response = resp.Response
+	}
+
+	if err = handleError(err, "Exec", response); err != nil {
+		return -1, "", err
+	}
+	return int(resp.ExitCode), resp.StdOut, nil
+}
+
+// GuestPing checks whether the guest agent of the domain responds within the timeout
+func (c *VirtLauncherClient) GuestPing(domainName string, timeoutSeconds int32) error {
+	request := &cmdv1.GuestPingRequest{
+		DomainName:     domainName,
+		TimeoutSeconds: timeoutSeconds,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	resp, err := c.v1client.GuestPing(ctx, request)
+	var response *cmdv1.Response
+	if resp != nil {
+		response = resp.Response
+	}
+
+	return handleError(err, "GuestPing", response)
+}
+
 func (c *VirtLauncherClient) Ping() error {
 	request := &cmdv1.EmptyRequest{}
 	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
@@ -0,0 +1,152 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package container_disk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const tempCacheFilePrefix = ".tmp-"
+
+// DefaultCacheSizeLimit is the size of the containerDisk cache if the KubeVirt configuration does not set it
+var DefaultCacheSizeLimit = resource.MustParse("20Gi")
+
+// diskCache stores the images of containerDisks on the node under the sha256 digest of their content,
+// so that all VMIs using the same image bind mount a single copy of it.
+type diskCache struct {
+	dir string
+}
+
+func newDiskCache(dir string) *diskCache {
+	return &diskCache{dir: dir}
+}
+
+// Add stores the image at sourceFile in the cache, unless an image with the same content is cached already,
+// and returns the path of the cached image.
+func (c *diskCache) Add(sourceFile string) (string, error) {
+	digest, err := fileDigest(sourceFile)
+	if err != nil {
+		return "", err
+	}
+	cacheFile := filepath.Join(c.dir, digest)
+
+	if _, err := os.Stat(cacheFile); err == nil {
+		// mark the image as recently used
+		now := time.Now()
+		return cacheFile, os.Chtimes(cacheFile, now, now)
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return "", err
+	}
+	tmpFile, err := ioutil.TempFile(c.dir, tempCacheFilePrefix)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	// #nosec No risk for path injection. The source is the image found in the containerDisk
+	source, err := os.Open(sourceFile)
+	if err != nil {
+		tmpFile.Close()
+		return "", err
+	}
+	defer source.Close()
+
+	if _, err := io.Copy(tmpFile, source); err != nil {
+		tmpFile.Close()
+		return "", err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0444); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpFile.Name(), cacheFile); err != nil {
+		return "", err
+	}
+	return cacheFile, nil
+}
+
+// GC removes the least recently used images which are not in use, until the cache fits into sizeLimit.
+func (c *diskCache) GC(sizeLimit int64, inUse map[string]bool) error {
+	files, err := ioutil.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var size int64
+	var unused []os.FileInfo
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), tempCacheFilePrefix) {
+			// left over by an interrupted Add
+			os.Remove(filepath.Join(c.dir, file.Name()))
+			continue
+		}
+		size += file.Size()
+		if !inUse[filepath.Join(c.dir, file.Name())] {
+			unused = append(unused, file)
+		}
+	}
+
+	sort.Slice(unused, func(i, j int) bool {
+		return unused[i].ModTime().Before(unused[j].ModTime())
+	})
+	for _, file := range unused {
+		if size <= sizeLimit {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, file.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		size -= file.Size()
+	}
+	return nil
+}
+
+func fileDigest(path string) (string, error) {
+	// #nosec No risk for path injection. The source is the image found in the containerDisk
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
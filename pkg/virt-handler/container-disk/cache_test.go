@@ -0,0 +1,127 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package container_disk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerDisk cache", func() {
+	var tmpDir string
+	var cache *diskCache
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "containerdiskcachetest")
+		Expect(err).ToNot(HaveOccurred())
+		cache = newDiskCache(filepath.Join(tmpDir, "cache"))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	writeImage := func(name string, content string) string {
+		path := filepath.Join(tmpDir, name)
+		Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+		return path
+	}
+
+	cacheImage := func(name string, content string, lastUsed time.Time) string {
+		cacheFile, err := cache.Add(writeImage(name, content))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.Chtimes(cacheFile, lastUsed, lastUsed)).To(Succeed())
+		return cacheFile
+	}
+
+	It("should store images with the same content once", func() {
+		first, err := cache.Add(writeImage("first.img", "image"))
+		Expect(err).ToNot(HaveOccurred())
+		second, err := cache.Add(writeImage("second.img", "image"))
+		Expect(err).ToNot(HaveOccurred())
+		other, err := cache.Add(writeImage("other.img", "other image"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(second).To(Equal(first))
+		Expect(other).ToNot(Equal(first))
+		files, err := ioutil.ReadDir(cache.dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(HaveLen(2))
+
+		content, err := ioutil.ReadFile(first)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("image"))
+		info, err := os.Stat(first)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0444)))
+	})
+
+	It("should mark cached images as recently used when they are added again", func() {
+		lastUsed := time.Now().Add(-time.Hour)
+		cacheFile := cacheImage("first.img", "image", lastUsed)
+		_, err := cache.Add(writeImage("second.img", "image"))
+		Expect(err).ToNot(HaveOccurred())
+
+		info, err := os.Stat(cacheFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.ModTime()).To(BeTemporally(">", lastUsed))
+	})
+
+	It("should remove the least recently used images which are not in use until the cache fits", func() {
+		now := time.Now()
+		oldest := cacheImage("oldest.img", "1111", now.Add(-3*time.Hour))
+		inUse := cacheImage("in-use.img", "2222", now.Add(-2*time.Hour))
+		older := cacheImage("older.img", "3333", now.Add(-time.Hour))
+		newest := cacheImage("newest.img", "4444", now)
+
+		Expect(cache.GC(8, map[string]bool{inUse: true})).To(Succeed())
+
+		Expect(oldest).ToNot(BeAnExistingFile())
+		Expect(older).ToNot(BeAnExistingFile())
+		Expect(inUse).To(BeAnExistingFile())
+		Expect(newest).To(BeAnExistingFile())
+	})
+
+	It("should keep images in use even if the cache exceeds its size limit", func() {
+		inUse := cacheImage("in-use.img", "image", time.Now())
+
+		Expect(cache.GC(0, map[string]bool{inUse: true})).To(Succeed())
+		Expect(inUse).To(BeAnExistingFile())
+	})
+
+	It("should remove left over temporary files", func() {
+		Expect(os.MkdirAll(cache.dir, 0755)).To(Succeed())
+		tmpFile := filepath.Join(cache.dir, tempCacheFilePrefix+"123")
+		Expect(ioutil.WriteFile(tmpFile, []byte("partial"), 0644)).To(Succeed())
+
+		Expect(cache.GC(DefaultCacheSizeLimit.Value(), nil)).To(Succeed())
+		Expect(tmpFile).ToNot(BeAnExistingFile())
+	})
+
+	It("should not fail if nothing is cached", func() {
+		Expect(cache.GC(0, nil)).To(Succeed())
+	})
+})
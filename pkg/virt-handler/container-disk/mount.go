@@ -15,6 +15,8 @@ import (
 	"kubevirt.io/client-go/log"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	"kubevirt.io/kubevirt/pkg/util"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -31,6 +33,9 @@ type mounter struct {
 	mountRecordsLock       sync.Mutex
 	suppressWarningTimeout time.Duration
 	pathGetter             containerdisk.SocketPathGetter
+	clusterConfig          *virtconfig.ClusterConfig
+	diskCache              *diskCache
+	diskCacheLock          sync.Mutex
 }
 
 type Mounter interface {
@@ -42,19 +47,22 @@ type Mounter interface {
 type vmiMountTargetEntry struct {
 	TargetFile string `json:"targetFile"`
 	SocketFile string `json:"socketFile"`
+	CacheFile  string `json:"cacheFile,omitempty"`
 }
 
 type vmiMountTargetRecord struct {
 	MountTargetEntries []vmiMountTargetEntry `json:"mountTargetEntries"`
 }
 
-func NewMounter(isoDetector isolation.PodIsolationDetector, mountStateDir string) Mounter {
+func NewMounter(isoDetector isolation.PodIsolationDetector, mountStateDir string, clusterConfig *virtconfig.ClusterConfig) Mounter {
 	return &mounter{
 		mountRecords:           make(map[types.UID]*vmiMountTargetRecord),
 		podIsolationDetector:   isoDetector,
 		mountStateDir:          mountStateDir,
 		suppressWarningTimeout: 1 * time.Minute,
 		pathGetter:             containerdisk.NewSocketPathGetter(""),
+		clusterConfig:          clusterConfig,
+		diskCache:              newDiskCache(filepath.Join(util.VirtLibDir, "container-disk-cache")),
 	}
 }
 
@@ -181,6 +189,17 @@ func (m *mounter) setMountTargetRecord(vmi *v1.VirtualMachineInstance, record *v
 func (m *mounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) error {
 	record := vmiMountTargetRecord{}
 
+	existingRecord, err := m.getMountTargetRecord(vmi)
+	if err != nil {
+		return err
+	}
+	cacheFiles := map[string]string{}
+	if existingRecord != nil {
+		for _, entry := range existingRecord.MountTargetEntries {
+			cacheFiles[entry.TargetFile] = entry.CacheFile
+		}
+	}
+
 	for i, volume := range vmi.Spec.Volumes {
 		if volume.ContainerDisk != nil {
 			targetFile, err := containerdisk.GetDiskTargetPathFromHostView(vmi, i)
@@ -196,6 +215,7 @@ func (m *mounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) error {
 			record.MountTargetEntries = append(record.MountTargetEntries, vmiMountTargetEntry{
 				TargetFile: targetFile,
 				SocketFile: sock,
+				CacheFile:  cacheFiles[targetFile],
 			})
 		}
 	}
@@ -240,15 +260,23 @@ func (m *mounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) error {
 				if err != nil {
 					return fmt.Errorf("failed to find a sourceFile in containerDisk %v: %v", volume.Name, err)
 				}
+				mountSource := strings.TrimPrefix(sourceFile, nodeRes.MountRoot())
+				if m.diskCacheEnabled() {
+					// the cache lives in the same directory on the node and in virt-handler
+					mountSource, err = m.addToDiskCache(vmi, targetFile, sourceFile)
+					if err != nil {
+						return fmt.Errorf("failed to cache containerDisk %v: %v", volume.Name, err)
+					}
+				}
 				f, err := os.Create(targetFile)
 				if err != nil {
 					return fmt.Errorf("failed to create mount point target %v: %v", targetFile, err)
 				}
 				f.Close()
 
-				log.DefaultLogger().Object(vmi).Infof("Bind mounting container disk at %s to %s", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile)
+				log.DefaultLogger().Object(vmi).Infof("Bind mounting container disk at %s to %s", mountSource, targetFile)
 				// #nosec g204 no risk to TrimPref as argument as it just trims two fixed strings
-				out, err := exec.Command("/usr/bin/virt-chroot", "--mount", "/proc/1/ns/mnt", "mount", "-o", "ro,bind", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile).CombinedOutput()
+				out, err := exec.Command("/usr/bin/virt-chroot", "--mount", "/proc/1/ns/mnt", "mount", "-o", "ro,bind", mountSource, targetFile).CombinedOutput()
 				if err != nil {
 					return fmt.Errorf("failed to bindmount containerDisk %v: %v : %v", volume.Name, string(out), err)
 				}
@@ -347,10 +375,104 @@ func (m *mounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 		if err != nil {
 			return err
 		}
+		m.diskCacheLock.Lock()
+		defer m.diskCacheLock.Unlock()
+		m.collectDiskCacheGarbage()
 	}
 	return nil
 }
 
+func (m *mounter) diskCacheEnabled() bool {
+	return m.clusterConfig != nil && m.clusterConfig.GetContainerDiskCacheConfiguration() != nil
+}
+
+// diskCacheSizeLimit returns the size limit of the containerDisk cache, which is zero if the cache is disabled
+// so that the images cached while it was enabled are removed once they are not in use anymore.
+func (m *mounter) diskCacheSizeLimit() int64 {
+	if !m.diskCacheEnabled() {
+		return 0
+	}
+	if sizeLimit := m.clusterConfig.GetContainerDiskCacheConfiguration().SizeLimit; sizeLimit != nil {
+		return sizeLimit.Value()
+	}
+	return DefaultCacheSizeLimit.Value()
+}
+
+// addToDiskCache caches the image of a containerDisk and records the cached image as in use by the VMI,
+// before the cache is garbage collected.
+func (m *mounter) addToDiskCache(vmi *v1.VirtualMachineInstance, targetFile string, sourceFile string) (string, error) {
+	m.diskCacheLock.Lock()
+	defer m.diskCacheLock.Unlock()
+
+	cacheFile, err := m.diskCache.Add(sourceFile)
+	if err != nil {
+		return "", err
+	}
+
+	existingRecord, err := m.getMountTargetRecord(vmi)
+	if err != nil {
+		return "", err
+	} else if existingRecord == nil {
+		return "", fmt.Errorf("unable to find the container disk mount record of the vmi")
+	}
+	record := vmiMountTargetRecord{
+		MountTargetEntries: make([]vmiMountTargetEntry, len(existingRecord.MountTargetEntries)),
+	}
+	copy(record.MountTargetEntries, existingRecord.MountTargetEntries)
+	for i := range record.MountTargetEntries {
+		if record.MountTargetEntries[i].TargetFile == targetFile {
+			record.MountTargetEntries[i].CacheFile = cacheFile
+		}
+	}
+	if err := m.setMountTargetRecord(vmi, &record); err != nil {
+		return "", err
+	}
+
+	m.collectDiskCacheGarbage()
+	return cacheFile, nil
+}
+
+// collectDiskCacheGarbage removes the cached images which are not in use by any VMI on the node, if the cache
+// exceeds its size limit. The caller must hold diskCacheLock.
+func (m *mounter) collectDiskCacheGarbage() {
+	inUse, err := m.cacheFilesInUse()
+	if err == nil {
+		err = m.diskCache.GC(m.diskCacheSizeLimit(), inUse)
+	}
+	if err != nil {
+		log.DefaultLogger().Reason(err).Warning("failed to garbage collect the container disk cache")
+	}
+}
+
+// cacheFilesInUse returns the cached images of the mount records of all VMIs on the node
+func (m *mounter) cacheFilesInUse() (map[string]bool, error) {
+	inUse := map[string]bool{}
+	files, err := ioutil.ReadDir(m.mountStateDir)
+	if os.IsNotExist(err) {
+		return inUse, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		record := vmiMountTargetRecord{}
+		// #nosec No risk for path injection. Using static base and the listed filenames
+		bytes, err := ioutil.ReadFile(filepath.Join(m.mountStateDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bytes, &record); err != nil {
+			return nil, err
+		}
+		for _, entry := range record.MountTargetEntries {
+			if entry.CacheFile != "" {
+				inUse[entry.CacheFile] = true
+			}
+		}
+	}
+	return inUse, nil
+}
+
 func (m *mounter) ContainerDisksReady(vmi *v1.VirtualMachineInstance, notInitializedSince time.Time) (bool, error) {
 	for i, volume := range vmi.Spec.Volumes {
 		if volume.ContainerDisk != nil {
@@ -36,6 +36,7 @@ import (
 
 type DeviceHandler interface {
 	GetDeviceIOMMUGroup(basepath string, pciAddress string) (string, error)
+	GetIOMMUGroupDevices(basepath string, pciAddress string) ([]string, error)
 	GetDeviceDriver(basepath string, pciAddress string) (string, error)
 	GetDeviceNumaNode(basepath string, pciAddress string) (numaNode int)
 	GetDevicePCIID(basepath string, pciAddress string) (string, error)
@@ -59,6 +60,22 @@ func (h *DeviceUtilsHandler) GetDeviceIOMMUGroup(basepath string, pciAddress str
 	return iommuGroup, nil
 }
 
+// GetIOMMUGroupDevices lists the PCI addresses of all devices in the iommu_group of the device
+// e.g. /sys/bus/pci/devices/0000\:65\:00.0/iommu_group/devices/0000\:65\:00.1
+func (h *DeviceUtilsHandler) GetIOMMUGroupDevices(basepath string, pciAddress string) ([]string, error) {
+	devicesPath := filepath.Join(basepath, pciAddress, "iommu_group", "devices")
+	devices, err := ioutil.ReadDir(devicesPath)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to read the iommu_group devices %s of device %s", devicesPath, pciAddress)
+		return nil, err
+	}
+	var addresses []string
+	for _, device := range devices {
+		addresses = append(addresses, device.Name())
+	}
+	return addresses, nil
+}
+
 // gets device driver
 func (h *DeviceUtilsHandler) GetDeviceDriver(basepath string, pciAddress string) (string, error) {
 	driverLink := filepath.Join(basepath, pciAddress, "driver")
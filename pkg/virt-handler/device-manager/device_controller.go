@@ -31,11 +31,14 @@ import (
 )
 
 var permanentDevicePluginPaths = map[string]string{
-	"kvm":       "/dev/kvm",
-	"tun":       "/dev/net/tun",
-	"vhost-net": "/dev/vhost-net",
+	"kvm":         "/dev/kvm",
+	"tun":         "/dev/net/tun",
+	"vhost-net":   "/dev/vhost-net",
+	"vhost-vsock": "/dev/vhost-vsock",
 }
 
+const mdevRefreshInterval = 1 * time.Minute
+
 type DeviceController struct {
 	devicePlugins      map[string]ControlledDevice
 	devicePluginsMutex sync.Mutex
@@ -43,6 +46,7 @@ type DeviceController struct {
 	maxDevices         int
 	backoff            []time.Duration
 	virtConfig         *virtconfig.ClusterConfig
+	nodeLabels         func() (map[string]string, error)
 	stop               chan struct{}
 }
 
@@ -62,13 +66,14 @@ func getPermanentHostDevicePlugins(maxDevices int) map[string]ControlledDevice {
 	return ret
 }
 
-func NewDeviceController(host string, maxDevices int, clusterConfig *virtconfig.ClusterConfig) *DeviceController {
+func NewDeviceController(host string, maxDevices int, clusterConfig *virtconfig.ClusterConfig, nodeLabels func() (map[string]string, error)) *DeviceController {
 	controller := &DeviceController{
 		devicePlugins: getPermanentHostDevicePlugins(maxDevices),
 		host:          host,
 		maxDevices:    maxDevices,
 		backoff:       []time.Duration{1 * time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second},
 		virtConfig:    clusterConfig,
+		nodeLabels:    nodeLabels,
 	}
 
 	return controller
@@ -176,6 +181,43 @@ func (c *DeviceController) updatePermittedHostDevicePlugins() (map[string]Contro
 				}
 			}
 		}
+		if len(hostDevs.USB) != 0 {
+			supportedUSBVendorMap := make(map[string]string)
+			supportedUSBPortMap := make(map[string]string)
+			for _, usbDev := range hostDevs.USB {
+				log.Log.V(4).Infof("Permitted USB device in the cluster, ID: %s, port: %s, resourceName: %s, externalProvider: %t",
+					strings.ToLower(usbDev.USBVendorSelector),
+					usbDev.USBPortSelector,
+					usbDev.ResourceName,
+					usbDev.ExternalResourceProvider)
+				// do not add a device plugin for this resource if it's being provided via an external device plugin
+				if usbDev.ExternalResourceProvider {
+					continue
+				}
+				if (usbDev.USBVendorSelector == "") == (usbDev.USBPortSelector == "") {
+					log.Log.Warningf("Ignoring USB device %s, exactly one of usbVendorSelector and usbPortSelector has to be set", usbDev.ResourceName)
+					continue
+				}
+				if usbDev.USBVendorSelector != "" {
+					supportedUSBVendorMap[strings.ToLower(usbDev.USBVendorSelector)] = usbDev.ResourceName
+				} else {
+					supportedUSBPortMap[usbDev.USBPortSelector] = usbDev.ResourceName
+				}
+			}
+			usbHostDevices := discoverPermittedHostUSBDevices(supportedUSBVendorMap, supportedUSBPortMap)
+			for usbResourceName, usbDevices := range usbHostDevices {
+				log.Log.V(4).Infof("Discovered USB devices on the node, resourceName: %s", usbResourceName)
+				// add a device plugin only for new devices
+				if _, isRunning := c.devicePlugins[usbResourceName]; !isRunning {
+					devicePluginsToRun[usbResourceName] = ControlledDevice{
+						devicePlugin: NewUSBDevicePlugin(usbDevices, usbResourceName),
+						stopChan:     make(chan struct{}),
+					}
+				} else {
+					delete(devicePluginsToStop, usbResourceName)
+				}
+			}
+		}
 	}
 	return devicePluginsToRun, devicePluginsToStop
 }
@@ -200,6 +242,7 @@ func (c *DeviceController) refreshPermittedDevices() {
 	//   c.updatePermittedHostDevicePlugins() and write to below.
 	c.devicePluginsMutex.Lock()
 
+	c.refreshMediatedDeviceTypes()
 	enabledDevicePlugins, disabledDevicePlugins := c.updatePermittedHostDevicePlugins()
 
 	// start device plugin for newly permitted devices
@@ -222,6 +265,25 @@ func (c *DeviceController) refreshPermittedDevices() {
 	logger.Infof("disabled device-pluings for: %v", debugDevRemoved)
 }
 
+// refreshMediatedDeviceTypes creates the mediated devices configured for the node, before
+// they are discovered for the device plugins. Without a configuration mediated devices are
+// left untouched, to not interfere with mediated devices created by the admin.
+func (c *DeviceController) refreshMediatedDeviceTypes() {
+	config := c.virtConfig.GetMediatedDevicesConfiguration()
+	if config == nil {
+		return
+	}
+	var nodeLabels map[string]string
+	if c.nodeLabels != nil {
+		var err error
+		if nodeLabels, err = c.nodeLabels(); err != nil {
+			log.DefaultLogger().Reason(err).Error("failed to get the node labels to configure mediated devices")
+			return
+		}
+	}
+	configureMDEVTypes(desiredMDEVTypes(config, nodeLabels))
+}
+
 func (c *DeviceController) Run(stop chan struct{}) error {
 	logger := log.DefaultLogger()
 	// start the permanent DevicePlugins
@@ -231,8 +293,19 @@ func (c *DeviceController) Run(stop chan struct{}) error {
 	c.virtConfig.SetConfigModifiedCallback(c.refreshPermittedDevices)
 	c.refreshPermittedDevices()
 
-	// keep running until stop
-	<-stop
+	// keep running until stop, retrying to remove mediated devices which were still assigned to VMIs
+	ticker := time.NewTicker(mdevRefreshInterval)
+	defer ticker.Stop()
+	for running := true; running; {
+		select {
+		case <-stop:
+			running = false
+		case <-ticker.C:
+			c.devicePluginsMutex.Lock()
+			c.refreshMediatedDeviceTypes()
+			c.devicePluginsMutex.Unlock()
+		}
+	}
 
 	// stop all device plugins
 	c.devicePluginsMutex.Lock()
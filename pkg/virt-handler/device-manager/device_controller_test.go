@@ -72,7 +72,7 @@ var _ = Describe("Device Controller", func() {
 
 	Context("Basic Tests", func() {
 		It("Should indicate if node has device", func() {
-			deviceController := NewDeviceController(host, 10, fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, fakeConfigMap, nil)
 			devicePath := path.Join(workDir, "fake-device")
 			res := deviceController.nodeHasDevice(devicePath)
 			Expect(res).To(BeFalse())
@@ -108,7 +108,7 @@ var _ = Describe("Device Controller", func() {
 		})
 
 		It("should restart the device plugin immediately without delays", func() {
-			deviceController := NewDeviceController(host, 10, fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, fakeConfigMap, nil)
 			deviceController.backoff = []time.Duration{10 * time.Millisecond, 10 * time.Second}
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
@@ -126,7 +126,7 @@ var _ = Describe("Device Controller", func() {
 		It("should restart the device plugin with delays if it returns errors", func() {
 			plugin2 = NewFakePlugin("fake-device2", devicePath2)
 			plugin2.Error = fmt.Errorf("failing")
-			deviceController := NewDeviceController(host, 10, fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, fakeConfigMap, nil)
 			deviceController.backoff = []time.Duration{10 * time.Millisecond, 300 * time.Millisecond}
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
@@ -142,7 +142,7 @@ var _ = Describe("Device Controller", func() {
 		})
 
 		It("Should not block on other plugins", func() {
-			deviceController := NewDeviceController(host, 10, fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, fakeConfigMap, nil)
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
 			deviceController.devicePlugins[deviceName1] = ControlledDevice{
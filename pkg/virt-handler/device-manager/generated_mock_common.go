@@ -39,6 +39,17 @@ func (_mr *_MockDeviceHandlerRecorder) GetDeviceIOMMUGroup(arg0, arg1 interface{
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDeviceIOMMUGroup", arg0, arg1)
 }
 
+func (_m *MockDeviceHandler) GetIOMMUGroupDevices(basepath string, pciAddress string) ([]string, error) {
+	ret := _m.ctrl.Call(_m, "GetIOMMUGroupDevices", basepath, pciAddress)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDeviceHandlerRecorder) GetIOMMUGroupDevices(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetIOMMUGroupDevices", arg0, arg1)
+}
+
 func (_m *MockDeviceHandler) GetDeviceDriver(basepath string, pciAddress string) (string, error) {
 	ret := _m.ctrl.Call(_m, "GetDeviceDriver", basepath, pciAddress)
 	ret0, _ := ret[0].(string)
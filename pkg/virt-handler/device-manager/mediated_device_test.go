@@ -135,7 +135,7 @@ mediatedDevices:
 		fakeClusterConfig, _, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(kv)
 
 		By("creating an empty device controller")
-		deviceController := NewDeviceController("master", 10, fakeClusterConfig)
+		deviceController := NewDeviceController("master", 10, fakeClusterConfig, nil)
 		deviceController.devicePlugins = make(map[string]ControlledDevice)
 
 		By("adding a host device to the cluster config")
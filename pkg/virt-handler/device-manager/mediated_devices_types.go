@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package device_manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// Not a const for static test purposes
+var mdevClassBusPath string = "/sys/class/mdev_bus"
+
+// desiredMDEVTypes returns the mediated device types which should be created on the node.
+// The types of the node specific configurations matching the node labels replace the cluster-wide types.
+func desiredMDEVTypes(config *v1.MediatedDevicesConfiguration, nodeLabels map[string]string) []string {
+	var nodeTypes []string
+	matched := false
+	for _, nodeConfig := range config.NodeMediatedDeviceTypes {
+		if labels.SelectorFromSet(nodeConfig.NodeSelector).Matches(labels.Set(nodeLabels)) {
+			nodeTypes = append(nodeTypes, nodeConfig.MediatedDevicesTypes...)
+			matched = true
+		}
+	}
+	if matched {
+		return nodeTypes
+	}
+	return config.MediatedDevicesTypes
+}
+
+// configureMDEVTypes configures every parent device on the node with the first desired type
+// it supports. Mediated devices of other types are removed first to free the capacity of the
+// parent device, then the parent device is filled up with mediated devices of the desired type.
+// Mediated devices which are still assigned to a VMI can't be removed, their removal is retried
+// periodically by the device controller.
+func configureMDEVTypes(desiredTypes []string) {
+	logger := log.DefaultLogger()
+	parents, err := ioutil.ReadDir(mdevClassBusPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Reason(err).Error("failed to discover mediated device parents")
+		}
+		return
+	}
+	for _, parent := range parents {
+		typesPath := filepath.Join(mdevClassBusPath, parent.Name(), "mdev_supported_types")
+		supportedTypes, err := ioutil.ReadDir(typesPath)
+		if err != nil {
+			logger.Reason(err).Errorf("failed to read the supported mediated device types of %s", parent.Name())
+			continue
+		}
+		selectedType := selectMDEVType(desiredTypes, supportedTypes)
+		for _, supportedType := range supportedTypes {
+			if supportedType.Name() != selectedType {
+				removeMDEVs(filepath.Join(typesPath, supportedType.Name()))
+			}
+		}
+		if selectedType != "" {
+			createMDEVs(filepath.Join(typesPath, selectedType))
+		}
+	}
+}
+
+func selectMDEVType(desiredTypes []string, supportedTypes []os.FileInfo) string {
+	for _, desiredType := range desiredTypes {
+		for _, supportedType := range supportedTypes {
+			if supportedType.Name() == desiredType {
+				return desiredType
+			}
+		}
+	}
+	return ""
+}
+
+// removeMDEVs removes all mediated devices of the type found at typePath
+func removeMDEVs(typePath string) {
+	devices, err := ioutil.ReadDir(filepath.Join(typePath, "devices"))
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to discover the mediated devices of type %s", typePath)
+		return
+	}
+	for _, device := range devices {
+		// #nosec No risk for path injection. Path is composed from static base "mdevBasePath" and the UUID of a discovered mdev
+		err := ioutil.WriteFile(filepath.Join(mdevBasePath, device.Name(), "remove"), []byte("1"), 0200)
+		if err != nil {
+			log.DefaultLogger().Reason(err).Errorf("failed to remove mediated device %s", device.Name())
+			continue
+		}
+		log.DefaultLogger().Infof("removed mediated device %s", device.Name())
+	}
+}
+
+// createMDEVs creates as many mediated devices of the type found at typePath as the parent device allows
+func createMDEVs(typePath string) {
+	rawInstances, err := ioutil.ReadFile(filepath.Join(typePath, "available_instances"))
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to read the available instances of type %s", typePath)
+		return
+	}
+	instances, err := strconv.Atoi(strings.TrimSpace(string(rawInstances)))
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to parse the available instances of type %s", typePath)
+		return
+	}
+	for i := 0; i < instances; i++ {
+		mdevUUID := string(uuid.NewUUID())
+		err := ioutil.WriteFile(filepath.Join(typePath, "create"), []byte(mdevUUID), 0200)
+		if err != nil {
+			log.DefaultLogger().Reason(err).Errorf("failed to create a mediated device of type %s", typePath)
+			return
+		}
+		log.DefaultLogger().Infof("created mediated device %s of type %s", mdevUUID, filepath.Base(typePath))
+	}
+}
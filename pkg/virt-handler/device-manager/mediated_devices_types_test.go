@@ -0,0 +1,94 @@
+package device_manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Mediated Device Types", func() {
+	var fakeMdevClassBusPath string
+	var fakeMdevBasePath string
+	var origMdevClassBusPath string
+	var origMdevBasePath string
+
+	createFakeType := func(parent, mdevType, availableInstances string, mdevUUIDs ...string) {
+		typePath := filepath.Join(fakeMdevClassBusPath, parent, "mdev_supported_types", mdevType)
+		Expect(os.MkdirAll(filepath.Join(typePath, "devices"), 0700)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(typePath, "available_instances"), []byte(availableInstances+"\n"), 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(typePath, "create"), []byte{}, 0600)).To(Succeed())
+		for _, mdevUUID := range mdevUUIDs {
+			Expect(os.MkdirAll(filepath.Join(typePath, "devices", mdevUUID), 0700)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(fakeMdevBasePath, mdevUUID), 0700)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(fakeMdevBasePath, mdevUUID, "remove"), []byte{}, 0600)).To(Succeed())
+		}
+	}
+
+	readFile := func(path ...string) string {
+		content, err := ioutil.ReadFile(filepath.Join(path...))
+		Expect(err).ToNot(HaveOccurred())
+		return string(content)
+	}
+
+	BeforeEach(func() {
+		var err error
+		fakeMdevClassBusPath, err = ioutil.TempDir("", "mdev_bus")
+		Expect(err).ToNot(HaveOccurred())
+		fakeMdevBasePath, err = ioutil.TempDir("", "mdevs")
+		Expect(err).ToNot(HaveOccurred())
+		origMdevClassBusPath, origMdevBasePath = mdevClassBusPath, mdevBasePath
+		mdevClassBusPath, mdevBasePath = fakeMdevClassBusPath, fakeMdevBasePath
+	})
+
+	AfterEach(func() {
+		mdevClassBusPath, mdevBasePath = origMdevClassBusPath, origMdevBasePath
+		os.RemoveAll(fakeMdevClassBusPath)
+		os.RemoveAll(fakeMdevBasePath)
+	})
+
+	It("should use the cluster-wide types on nodes without a matching node configuration", func() {
+		config := &v1.MediatedDevicesConfiguration{
+			MediatedDevicesTypes: []string{"nvidia-222"},
+			NodeMediatedDeviceTypes: []v1.NodeMediatedDeviceTypesConfig{
+				{
+					NodeSelector:         map[string]string{"gpu": "a100"},
+					MediatedDevicesTypes: []string{"nvidia-471"},
+				},
+			},
+		}
+		Expect(desiredMDEVTypes(config, map[string]string{"gpu": "t4"})).To(Equal([]string{"nvidia-222"}))
+		Expect(desiredMDEVTypes(config, nil)).To(Equal([]string{"nvidia-222"}))
+		Expect(desiredMDEVTypes(config, map[string]string{"gpu": "a100"})).To(Equal([]string{"nvidia-471"}))
+	})
+
+	It("should create mediated devices of the first desired type supported by the parent", func() {
+		createFakeType("0000:65:00.0", "nvidia-222", "2")
+		createFakeType("0000:65:00.0", "nvidia-223", "1")
+
+		configureMDEVTypes([]string{"nvidia-231", "nvidia-222", "nvidia-223"})
+
+		typesPath := filepath.Join(fakeMdevClassBusPath, "0000:65:00.0", "mdev_supported_types")
+		Expect(readFile(typesPath, "nvidia-222", "create")).To(HaveLen(36))
+		Expect(readFile(typesPath, "nvidia-223", "create")).To(BeEmpty())
+	})
+
+	It("should remove mediated devices of types which are not desired anymore", func() {
+		createFakeType("0000:65:00.0", "nvidia-222", "0", "53764d0e-85a0-42b4-af5c-2046b460b1dc")
+		createFakeType("0000:65:00.0", "nvidia-223", "0", "0f2f8b1e-7c6a-4b3e-9b0e-5a1d2c3b4a59")
+
+		configureMDEVTypes([]string{"nvidia-222"})
+
+		Expect(readFile(fakeMdevBasePath, "53764d0e-85a0-42b4-af5c-2046b460b1dc", "remove")).To(BeEmpty())
+		Expect(readFile(fakeMdevBasePath, "0f2f8b1e-7c6a-4b3e-9b0e-5a1d2c3b4a59", "remove")).To(Equal("1"))
+	})
+
+	It("should not fail on nodes without mediated device parents", func() {
+		mdevClassBusPath = filepath.Join(fakeMdevClassBusPath, "missing")
+		configureMDEVTypes([]string{"nvidia-222"})
+	})
+})
@@ -378,6 +378,8 @@ func discoverPermittedHostPCIDevices(supportedPCIDeviceMap map[string]string) ma
 	initHandler()
 
 	pciDevicesMap := make(map[string][]*PCIDevice)
+	// devices are allocated by their IOMMU group, which is a single allocatable unit
+	iommuGroupDevices := make(map[string]string)
 	err := filepath.Walk(pciBasePath, func(path string, info os.FileInfo, err error) error {
 		if info.IsDir() {
 			return nil
@@ -405,6 +407,14 @@ func discoverPermittedHostPCIDevices(supportedPCIDeviceMap map[string]string) ma
 			pcidev.iommuGroup = iommuGroup
 			pcidev.driver = driver
 			pcidev.numaNode = Handler.GetDeviceNumaNode(pciBasePath, info.Name())
+			if other, exists := iommuGroupDevices[iommuGroup]; exists {
+				log.DefaultLogger().Warningf("device %s shares the IOMMU group %s with device %s, only one of them can be passed through", info.Name(), iommuGroup, other)
+				return nil
+			}
+			if !isIOMMUGroupViable(info.Name()) {
+				return nil
+			}
+			iommuGroupDevices[iommuGroup] = info.Name()
 			pciDevicesMap[pciID] = append(pciDevicesMap[pciID], pcidev)
 		}
 		return nil
@@ -415,6 +425,30 @@ func discoverPermittedHostPCIDevices(supportedPCIDeviceMap map[string]string) ma
 	return pciDevicesMap
 }
 
+// isIOMMUGroupViable checks that no other device of the IOMMU group of the device is used by a host driver,
+// VFIO refuses to hand out the group otherwise
+func isIOMMUGroupViable(pciAddress string) bool {
+	groupDevices, err := Handler.GetIOMMUGroupDevices(pciBasePath, pciAddress)
+	if err != nil {
+		return false
+	}
+	for _, groupDevice := range groupDevices {
+		if groupDevice == pciAddress {
+			continue
+		}
+		driver, err := Handler.GetDeviceDriver(pciBasePath, groupDevice)
+		if err != nil {
+			// the device is not bound to any driver
+			continue
+		}
+		if driver != "vfio-pci" && driver != "pcieport" {
+			log.DefaultLogger().Warningf("device %s can't be passed through, device %s of its IOMMU group is bound to the host driver %s", pciAddress, groupDevice, driver)
+			return false
+		}
+	}
+	return true
+}
+
 func (dpi *PCIDevicePlugin) GetInitialized() bool {
 	dpi.lock.Lock()
 	defer dpi.lock.Unlock()
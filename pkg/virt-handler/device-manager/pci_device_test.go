@@ -32,6 +32,7 @@ var _ = Describe("PCI Device", func() {
 	var fakePermittedHostDevicesConfig string
 	var fakePermittedHostDevices v1.PermittedHostDevices
 	var ctrl *gomock.Controller
+	var fakeIommuGroupDevices []string
 
 	BeforeEach(func() {
 		By("making sure the environment has a PCI device at " + fakeAddress)
@@ -49,6 +50,10 @@ var _ = Describe("PCI Device", func() {
 		mockPCI.EXPECT().GetDeviceDriver(pciBasePath, fakeAddress).Return(fakeDriver, nil).Times(1)
 		mockPCI.EXPECT().GetDeviceNumaNode(pciBasePath, fakeAddress).Return(fakeNumaNode).Times(1)
 		mockPCI.EXPECT().GetDevicePCIID(pciBasePath, fakeAddress).Return(fakeID, nil).Times(1)
+		fakeIommuGroupDevices = []string{fakeAddress}
+		mockPCI.EXPECT().GetIOMMUGroupDevices(pciBasePath, fakeAddress).DoAndReturn(func(_, _ string) ([]string, error) {
+			return fakeIommuGroupDevices, nil
+		}).Times(1)
 		// Allow the regular functions to be called for all the other devices, they're harmless.
 		// Just force the driver to NOT vfio-pci to ensure they all get ignored.
 		mockPCI.EXPECT().GetDeviceIOMMUGroup(pciBasePath, gomock.Any()).AnyTimes()
@@ -93,6 +98,13 @@ pciHostDevices:
 		Expect(devices[fakeID][0].numaNode).To(Equal(fakeNumaNode))
 	})
 
+	It("Should not find a PCI device which shares its IOMMU group with a device bound to a host driver", func() {
+		supportedPCIDeviceMap := map[string]string{fakeID: fakeName}
+		fakeIommuGroupDevices = []string{fakeAddress, "0000:00:00.1"}
+		devices := discoverPermittedHostPCIDevices(supportedPCIDeviceMap)
+		Expect(devices).To(BeEmpty())
+	})
+
 	It("Should validate DPI devices", func() {
 		iommuToPCIMap := make(map[string]string)
 		supportedPCIDeviceMap := make(map[string]string)
@@ -128,7 +140,7 @@ pciHostDevices:
 		fakeClusterConfig, _, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(kv)
 
 		By("creating an empty device controller")
-		deviceController := NewDeviceController("master", 10, fakeClusterConfig)
+		deviceController := NewDeviceController("master", 10, fakeClusterConfig, nil)
 		deviceController.devicePlugins = make(map[string]ControlledDevice)
 
 		By("adding a host device to the cluster config")
@@ -0,0 +1,408 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package device_manager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+const (
+	usbDevicePath       = "/dev/bus/usb"
+	USB_RESOURCE_PREFIX = "USB_RESOURCE"
+)
+
+// Not a const for static test purposes
+var usbBasePath string = "/sys/bus/usb/devices"
+
+type USBDevice struct {
+	vendorProduct string
+	port          string
+	bus           int
+	device        int
+}
+
+// address returns the bus and device number of the USB device, e.g. 001:004
+func (dev *USBDevice) address() string {
+	return fmt.Sprintf("%03d:%03d", dev.bus, dev.device)
+}
+
+// devicePath returns the path of the device node of the USB device, e.g. /dev/bus/usb/001/004
+func (dev *USBDevice) devicePath() string {
+	return filepath.Join(usbDevicePath, fmt.Sprintf("%03d", dev.bus), fmt.Sprintf("%03d", dev.device))
+}
+
+type USBDevicePlugin struct {
+	devs         []*pluginapi.Device
+	server       *grpc.Server
+	socketPath   string
+	stop         chan struct{}
+	devicePath   string
+	deviceName   string
+	resourceName string
+	done         chan struct{}
+	deviceRoot   string
+	healthy      chan string
+	unhealthy    chan string
+	portToUSBMap map[string]*USBDevice
+	initialized  bool
+	lock         *sync.Mutex
+}
+
+func NewUSBDevicePlugin(usbDevices []*USBDevice, resourceName string) *USBDevicePlugin {
+	s := strings.Split(resourceName, "/")
+	serverSock := SocketPath("usb-" + s[len(s)-1])
+	portToUSBMap := make(map[string]*USBDevice)
+
+	devs := constructDPIdevicesFromUSB(usbDevices, portToUSBMap)
+	dpi := &USBDevicePlugin{
+		devs:         devs,
+		socketPath:   serverSock,
+		deviceName:   resourceName,
+		resourceName: resourceName,
+		devicePath:   usbDevicePath,
+		deviceRoot:   util.HostRootMount,
+		portToUSBMap: portToUSBMap,
+		healthy:      make(chan string),
+		unhealthy:    make(chan string),
+		initialized:  false,
+		lock:         &sync.Mutex{},
+	}
+	return dpi
+}
+
+// constructDPIdevicesFromUSB uses the port of the USB devices as device ID, the port doesn't
+// change when the device re-enumerates, unlike the device number
+func constructDPIdevicesFromUSB(usbDevices []*USBDevice, portToUSBMap map[string]*USBDevice) (devs []*pluginapi.Device) {
+	for _, usbDevice := range usbDevices {
+		portToUSBMap[usbDevice.port] = usbDevice
+		devs = append(devs, &pluginapi.Device{
+			ID:     usbDevice.port,
+			Health: pluginapi.Healthy,
+		})
+	}
+	return
+}
+
+// Start starts the device plugin
+func (dpi *USBDevicePlugin) Start(stop chan struct{}) (err error) {
+	logger := log.DefaultLogger()
+	dpi.stop = stop
+	dpi.done = make(chan struct{})
+
+	err = dpi.cleanup()
+	if err != nil {
+		return err
+	}
+
+	sock, err := net.Listen("unix", dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("error creating GRPC server socket: %v", err)
+	}
+
+	dpi.server = grpc.NewServer([]grpc.ServerOption{}...)
+	defer dpi.Stop()
+
+	pluginapi.RegisterDevicePluginServer(dpi.server, dpi)
+	err = dpi.Register()
+	if err != nil {
+		return fmt.Errorf("error registering with device plugin manager: %v", err)
+	}
+
+	errChan := make(chan error, 2)
+
+	go func() {
+		errChan <- dpi.server.Serve(sock)
+	}()
+
+	err = waitForGrpcServer(dpi.socketPath, connectionTimeout)
+	if err != nil {
+		return fmt.Errorf("error starting the GRPC server: %v", err)
+	}
+
+	go func() {
+		errChan <- dpi.healthCheck()
+	}()
+
+	dpi.setInitialized(true)
+	logger.Infof("%s device plugin started", dpi.deviceName)
+	err = <-errChan
+
+	return err
+}
+
+func (dpi *USBDevicePlugin) ListAndWatch(e *pluginapi.Empty, s pluginapi.DevicePlugin_ListAndWatchServer) error {
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+
+	for {
+		select {
+		case unhealthy := <-dpi.unhealthy:
+			for _, dev := range dpi.devs {
+				if unhealthy == dev.ID {
+					dev.Health = pluginapi.Unhealthy
+				}
+			}
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+		case healthy := <-dpi.healthy:
+			for _, dev := range dpi.devs {
+				if healthy == dev.ID {
+					dev.Health = pluginapi.Healthy
+				}
+			}
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+		case <-dpi.stop:
+			return nil
+		case <-dpi.done:
+			return nil
+		}
+	}
+}
+
+func (dpi *USBDevicePlugin) Allocate(ctx context.Context, r *pluginapi.AllocateRequest) (*pluginapi.AllocateResponse, error) {
+	resourceNameEnvVar := util.ResourceNameToEnvVar(USB_RESOURCE_PREFIX, dpi.resourceName)
+	resp := new(pluginapi.AllocateResponse)
+
+	for _, request := range r.ContainerRequests {
+		allocatedDevices := []string{}
+		deviceSpecs := make([]*pluginapi.DeviceSpec, 0)
+		for _, devID := range request.DevicesIDs {
+			usbDevice, exist := dpi.portToUSBMap[devID]
+			if !exist {
+				continue
+			}
+			allocatedDevices = append(allocatedDevices, usbDevice.address())
+			deviceSpecs = append(deviceSpecs, &pluginapi.DeviceSpec{
+				HostPath:      usbDevice.devicePath(),
+				ContainerPath: usbDevice.devicePath(),
+				Permissions:   "mrw",
+			})
+		}
+		containerResponse := &pluginapi.ContainerAllocateResponse{
+			Devices: deviceSpecs,
+			Envs: map[string]string{
+				resourceNameEnvVar: strings.Join(allocatedDevices, ","),
+			},
+		}
+		resp.ContainerResponses = append(resp.ContainerResponses, containerResponse)
+	}
+	return resp, nil
+}
+
+func (dpi *USBDevicePlugin) healthCheck() error {
+	logger := log.DefaultLogger()
+	monitoredDevices := make(map[string]string)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to creating a fsnotify watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	// The device nodes are removed when the USB devices are unplugged.
+	// This way we don't have to mount /dev from the node
+	for _, dev := range dpi.devs {
+		devicePath := filepath.Join(dpi.deviceRoot, dpi.portToUSBMap[dev.ID].devicePath())
+		err = watcher.Add(filepath.Dir(devicePath))
+		if err != nil {
+			return fmt.Errorf("failed to add the device bus path to the watcher: %v", err)
+		}
+		monitoredDevices[devicePath] = dev.ID
+	}
+
+	dirName := filepath.Dir(dpi.socketPath)
+	err = watcher.Add(dirName)
+	if err != nil {
+		return fmt.Errorf("failed to add the device-plugin kubelet path to the watcher: %v", err)
+	}
+	_, err = os.Stat(dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat the device-plugin socket: %v", err)
+	}
+
+	for {
+		select {
+		case <-dpi.stop:
+			return nil
+		case err := <-watcher.Errors:
+			logger.Reason(err).Errorf("error watching devices and device plugin directory")
+		case event := <-watcher.Events:
+			logger.V(4).Infof("health Event: %v", event)
+			if monDevId, exist := monitoredDevices[event.Name]; exist {
+				// Health in this case is if the device path actually exists
+				if event.Op == fsnotify.Create {
+					logger.Infof("monitored device %s appeared", dpi.deviceName)
+					dpi.healthy <- monDevId
+				} else if (event.Op == fsnotify.Remove) || (event.Op == fsnotify.Rename) {
+					logger.Infof("monitored device %s disappeared", dpi.deviceName)
+					dpi.unhealthy <- monDevId
+				}
+			} else if event.Name == dpi.socketPath && event.Op == fsnotify.Remove {
+				logger.Infof("device socket file for device %s was removed, kubelet probably restarted.", dpi.deviceName)
+				return nil
+			}
+		}
+	}
+}
+
+func (dpi *USBDevicePlugin) GetDevicePath() string {
+	return dpi.devicePath
+}
+
+func (dpi *USBDevicePlugin) GetDeviceName() string {
+	return dpi.deviceName
+}
+
+// Stop stops the gRPC server
+func (dpi *USBDevicePlugin) Stop() error {
+	defer func() {
+		if !IsChanClosed(dpi.done) {
+			close(dpi.done)
+		}
+	}()
+	dpi.server.Stop()
+	dpi.setInitialized(false)
+	return dpi.cleanup()
+}
+
+// Register registers the device plugin for the given resourceName with Kubelet.
+func (dpi *USBDevicePlugin) Register() error {
+	conn, err := connect(pluginapi.KubeletSocket, connectionTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pluginapi.NewRegistrationClient(conn)
+	reqt := &pluginapi.RegisterRequest{
+		Version:      pluginapi.Version,
+		Endpoint:     path.Base(dpi.socketPath),
+		ResourceName: dpi.resourceName,
+	}
+
+	_, err = client.Register(context.Background(), reqt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dpi *USBDevicePlugin) cleanup() error {
+	if err := os.Remove(dpi.socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (dpi *USBDevicePlugin) GetDevicePluginOptions(ctx context.Context, e *pluginapi.Empty) (*pluginapi.DevicePluginOptions, error) {
+	options := &pluginapi.DevicePluginOptions{
+		PreStartRequired: false,
+	}
+	return options, nil
+}
+
+func (dpi *USBDevicePlugin) PreStartContainer(ctx context.Context, in *pluginapi.PreStartContainerRequest) (*pluginapi.PreStartContainerResponse, error) {
+	res := &pluginapi.PreStartContainerResponse{}
+	return res, nil
+}
+
+// discoverPermittedHostUSBDevices returns the USB devices of the node by resource name.
+// The devices are selected either by their vendor:product ID or by the port they are connected to.
+func discoverPermittedHostUSBDevices(supportedUSBVendorMap map[string]string, supportedUSBPortMap map[string]string) map[string][]*USBDevice {
+	usbDevicesMap := make(map[string][]*USBDevice)
+	files, err := ioutil.ReadDir(usbBasePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.DefaultLogger().Reason(err).Errorf("failed to discover USB devices")
+		}
+		return usbDevicesMap
+	}
+	for _, info := range files {
+		// Skip the root hubs (usbN) and the interfaces of the devices (1-2:1.0)
+		if strings.HasPrefix(info.Name(), "usb") || strings.Contains(info.Name(), ":") {
+			continue
+		}
+		usbDevice, err := readUSBDevice(info.Name())
+		if err != nil {
+			log.DefaultLogger().Reason(err).Errorf("failed to read USB device %s", info.Name())
+			continue
+		}
+		resourceName, supported := supportedUSBPortMap[usbDevice.port]
+		if !supported {
+			resourceName, supported = supportedUSBVendorMap[usbDevice.vendorProduct]
+		}
+		if supported {
+			usbDevicesMap[resourceName] = append(usbDevicesMap[resourceName], usbDevice)
+		}
+	}
+	return usbDevicesMap
+}
+
+func readUSBDevice(port string) (*USBDevice, error) {
+	attrs := map[string]string{}
+	for _, attr := range []string{"idVendor", "idProduct", "busnum", "devnum"} {
+		// #nosec No risk for path injection. Reading static path of USB data
+		value, err := ioutil.ReadFile(filepath.Join(usbBasePath, port, attr))
+		if err != nil {
+			return nil, err
+		}
+		attrs[attr] = strings.TrimSpace(string(value))
+	}
+	bus, err := strconv.Atoi(attrs["busnum"])
+	if err != nil {
+		return nil, err
+	}
+	device, err := strconv.Atoi(attrs["devnum"])
+	if err != nil {
+		return nil, err
+	}
+	return &USBDevice{
+		vendorProduct: strings.ToLower(attrs["idVendor"] + ":" + attrs["idProduct"]),
+		port:          port,
+		bus:           bus,
+		device:        device,
+	}, nil
+}
+
+func (dpi *USBDevicePlugin) GetInitialized() bool {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return dpi.initialized
+}
+
+func (dpi *USBDevicePlugin) setInitialized(initialized bool) {
+	dpi.lock.Lock()
+	dpi.initialized = initialized
+	dpi.lock.Unlock()
+}
@@ -0,0 +1,151 @@
+package device_manager
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+const (
+	fakeUSBName     = "example.org/dongle"
+	fakeUSBID       = "0529:0001"
+	fakeUSBPort     = "1-2.3"
+	fakeUSBPortName = "example.org/serial"
+)
+
+var _ = Describe("USB Device", func() {
+	var fakeUSBBasePath string
+	var origUSBBasePath string
+
+	createFakeUSBDevice := func(name, vendor, product, bus, device string) {
+		devicePath := filepath.Join(fakeUSBBasePath, name)
+		Expect(os.MkdirAll(devicePath, 0700)).To(Succeed())
+		for attr, value := range map[string]string{"idVendor": vendor, "idProduct": product, "busnum": bus, "devnum": device} {
+			Expect(ioutil.WriteFile(filepath.Join(devicePath, attr), []byte(value+"\n"), 0600)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		fakeUSBBasePath, err = ioutil.TempDir("", "usb")
+		Expect(err).ToNot(HaveOccurred())
+		origUSBBasePath = usbBasePath
+		usbBasePath = fakeUSBBasePath
+
+		createFakeUSBDevice("usb1", "1d6b", "0002", "1", "1")
+		createFakeUSBDevice("1-1", "0529", "0001", "1", "4")
+		createFakeUSBDevice("1-2.3", "0403", "6001", "1", "12")
+		createFakeUSBDevice("2-1", "0403", "6001", "2", "2")
+		Expect(os.MkdirAll(filepath.Join(fakeUSBBasePath, "1-1:1.0"), 0700)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		usbBasePath = origUSBBasePath
+		os.RemoveAll(fakeUSBBasePath)
+	})
+
+	It("Should find the USB devices by vendor:product ID and by port", func() {
+		devices := discoverPermittedHostUSBDevices(
+			map[string]string{fakeUSBID: fakeUSBName},
+			map[string]string{fakeUSBPort: fakeUSBPortName},
+		)
+		Expect(devices).To(HaveLen(2))
+		Expect(devices[fakeUSBName]).To(Equal([]*USBDevice{
+			{vendorProduct: fakeUSBID, port: "1-1", bus: 1, device: 4},
+		}))
+		Expect(devices[fakeUSBPortName]).To(Equal([]*USBDevice{
+			{vendorProduct: "0403:6001", port: fakeUSBPort, bus: 1, device: 12},
+		}))
+	})
+
+	It("Should allocate the device nodes of the USB devices", func() {
+		devices := discoverPermittedHostUSBDevices(map[string]string{"0403:6001": fakeUSBName}, nil)
+		dpi := NewUSBDevicePlugin(devices[fakeUSBName], fakeUSBName)
+		Expect(dpi.devs).To(HaveLen(2))
+
+		resp, err := dpi.Allocate(context.Background(), &pluginapi.AllocateRequest{
+			ContainerRequests: []*pluginapi.ContainerAllocateRequest{
+				{DevicesIDs: []string{fakeUSBPort}},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ContainerResponses).To(HaveLen(1))
+		Expect(resp.ContainerResponses[0].Envs).To(HaveKeyWithValue("USB_RESOURCE_EXAMPLE_ORG_DONGLE", "001:012"))
+		Expect(resp.ContainerResponses[0].Devices).To(Equal([]*pluginapi.DeviceSpec{
+			{
+				HostPath:      "/dev/bus/usb/001/012",
+				ContainerPath: "/dev/bus/usb/001/012",
+				Permissions:   "mrw",
+			},
+		}))
+	})
+
+	It("Should update the device list according to the configmap", func() {
+		By("creating a cluster config")
+		kv := &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		}
+		fakeClusterConfig, _, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(kv)
+
+		By("creating an empty device controller")
+		deviceController := NewDeviceController("master", 10, fakeClusterConfig, nil)
+		deviceController.devicePlugins = make(map[string]ControlledDevice)
+
+		By("adding USB devices to the cluster config")
+		kvConfig := kv.DeepCopy()
+		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.HostDevicesGate}
+		kvConfig.Spec.Configuration.PermittedHostDevices = &v1.PermittedHostDevices{
+			USB: []v1.USBHostDevice{
+				{
+					USBVendorSelector: fakeUSBID,
+					ResourceName:      fakeUSBName,
+				},
+				{
+					USBVendorSelector: fakeUSBID,
+					USBPortSelector:   fakeUSBPort,
+					ResourceName:      fakeUSBPortName,
+				},
+			},
+		}
+		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+		By("ensuring a device plugin gets created only for the device with a single selector")
+		enabledDevicePlugins, disabledDevicePlugins := deviceController.updatePermittedHostDevicePlugins()
+		Expect(enabledDevicePlugins).To(HaveLen(1))
+		Expect(enabledDevicePlugins).To(HaveKey(fakeUSBName))
+		Expect(disabledDevicePlugins).To(BeEmpty())
+		deviceController.devicePlugins[fakeUSBName] = enabledDevicePlugins[fakeUSBName]
+
+		By("deleting the devices from the configmap")
+		kvConfig.Spec.Configuration.PermittedHostDevices = &v1.PermittedHostDevices{}
+		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+		By("ensuring the device plugin gets stopped")
+		enabledDevicePlugins, disabledDevicePlugins = deviceController.updatePermittedHostDevicePlugins()
+		Expect(enabledDevicePlugins).To(BeEmpty())
+		Expect(disabledDevicePlugins).To(HaveKey(fakeUSBName))
+	})
+})
@@ -0,0 +1,206 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package ephemeral_backing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+)
+
+// StateFile records the backing mounted on the node, as seen from virt-handler
+const StateFile = util.VirtPrivateDir + "/ephemeral-disk-backing"
+
+// Handler mounts the ephemeral disk backing selected for the node of virt-handler on util.EphemeralDisksDir.
+// The mount is done in the mount namespace of the node, where the pods of the VMIs pick it up.
+type Handler struct {
+	hostRoot  string
+	stateFile string
+	mount     func(args ...string) error
+	isMounted func(path string) (bool, error)
+}
+
+func NewHandler() *Handler {
+	return &Handler{
+		hostRoot:  util.HostRootMount,
+		stateFile: StateFile,
+		mount:     mountOnNode,
+		isMounted: isolation.NodeIsolationResult().IsMounted,
+	}
+}
+
+// Reconcile mounts the first backing selecting the node. A mounted backing is only changed while no VMI
+// stores its ephemeral disk data on the node, since the VMIs keep using the backing they were started with.
+// Nothing is mounted on the node before the first backing selects it, so the directories found then are
+// leftovers which are not in use anymore, and the backing is mounted over them.
+func (h *Handler) Reconcile(nodeLabels map[string]string, backings []v1.EphemeralDiskBacking) error {
+	desired, err := selectBacking(nodeLabels, backings)
+	if err != nil {
+		return err
+	}
+	applied, err := h.appliedBacking()
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(desired, applied) {
+		return nil
+	}
+
+	dir := filepath.Join(h.hostRoot, util.EphemeralDisksDir)
+	if applied != nil {
+		vmiDirs, err := ioutil.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if len(vmiDirs) > 0 {
+			log.Log.V(3).Infof("Not changing the ephemeral disk backing while %d VMIs use it", len(vmiDirs))
+			return nil
+		}
+		if err := h.mount("umount", util.EphemeralDisksDir); err != nil {
+			return fmt.Errorf("failed to unmount the ephemeral disk backing: %v", err)
+		}
+	}
+	if desired != nil {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if desired.HostPath != "" {
+			if err := os.MkdirAll(filepath.Join(h.hostRoot, desired.HostPath), 0755); err != nil {
+				return err
+			}
+		}
+		if err := h.mount(mountArgs(desired)...); err != nil {
+			return fmt.Errorf("failed to mount the ephemeral disk backing: %v", err)
+		}
+		log.Log.Infof("Mounted the ephemeral disk backing %s", mountArgs(desired))
+	}
+	return h.setAppliedBacking(desired)
+}
+
+// Mounted returns whether a backing is mounted on the node, so that the pods storing their ephemeral
+// disk data on the node can be scheduled to it
+func (h *Handler) Mounted() (bool, error) {
+	applied, err := h.appliedBacking()
+	return applied != nil, err
+}
+
+// RemoveVMIData removes the ephemeral disk data which the VMI left on the node
+func (h *Handler) RemoveVMIData(uid types.UID) error {
+	if uid == "" {
+		return nil
+	}
+	return os.RemoveAll(filepath.Join(h.hostRoot, util.EphemeralDisksDir, string(uid)))
+}
+
+// selectBacking returns the first backing selecting the node without its node selector,
+// so that changes of the selectors alone do not change the mounted backing
+func selectBacking(nodeLabels map[string]string, backings []v1.EphemeralDiskBacking) (*v1.EphemeralDiskBacking, error) {
+	for _, backing := range backings {
+		if !labels.SelectorFromSet(backing.NodeSelector).Matches(labels.Set(nodeLabels)) {
+			continue
+		}
+		set := 0
+		for _, isSet := range []bool{backing.HostPath != "", backing.BlockDevice != "", backing.Tmpfs != nil} {
+			if isSet {
+				set++
+			}
+		}
+		if set != 1 {
+			return nil, fmt.Errorf("the ephemeral disk backing must set exactly one of hostPath, blockDevice and tmpfs")
+		}
+		selected := backing.DeepCopy()
+		selected.NodeSelector = nil
+		return selected, nil
+	}
+	return nil, nil
+}
+
+func mountArgs(backing *v1.EphemeralDiskBacking) []string {
+	switch {
+	case backing.HostPath != "":
+		return []string{"mount", "--bind", backing.HostPath, util.EphemeralDisksDir}
+	case backing.BlockDevice != "":
+		return []string{"mount", backing.BlockDevice, util.EphemeralDisksDir}
+	default:
+		args := []string{"mount", "-t", "tmpfs"}
+		if backing.Tmpfs.SizeLimit != nil {
+			args = append(args, "-o", fmt.Sprintf("size=%d", backing.Tmpfs.SizeLimit.Value()))
+		}
+		return append(args, "tmpfs", util.EphemeralDisksDir)
+	}
+}
+
+// appliedBacking returns the backing mounted by virt-handler, if it is still mounted
+func (h *Handler) appliedBacking() (*v1.EphemeralDiskBacking, error) {
+	content, err := ioutil.ReadFile(h.stateFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if mounted, err := h.isMounted(util.EphemeralDisksDir); err != nil {
+		return nil, err
+	} else if !mounted {
+		// the node was rebooted
+		return nil, nil
+	}
+
+	backing := &v1.EphemeralDiskBacking{}
+	if err := json.Unmarshal(content, backing); err != nil {
+		return nil, err
+	}
+	return backing, nil
+}
+
+func (h *Handler) setAppliedBacking(backing *v1.EphemeralDiskBacking) error {
+	if backing == nil {
+		if err := os.Remove(h.stateFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	content, err := json.Marshal(backing)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(h.stateFile, content, 0644)
+}
+
+func mountOnNode(args ...string) error {
+	// #nosec No risk for attacker injection. The arguments are taken from the KubeVirt configuration
+	out, err := exec.Command("/usr/bin/virt-chroot", append([]string{"--mount", "/proc/1/ns/mnt"}, args...)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package ephemeral_backing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+var _ = Describe("Ephemeral disk backing", func() {
+
+	var tmpDir string
+	var handler *Handler
+	var mounts []string
+	var mounted bool
+	nodeLabels := map[string]string{"storage": "fast"}
+
+	tmpfsBacking := func(nodeSelector map[string]string) v1.EphemeralDiskBacking {
+		size := resource.MustParse("1Gi")
+		return v1.EphemeralDiskBacking{
+			NodeSelector: nodeSelector,
+			Tmpfs:        &v1.EphemeralDiskTmpfs{SizeLimit: &size},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "ephemeral-backing")
+		Expect(err).ToNot(HaveOccurred())
+		mounts = nil
+		mounted = false
+		handler = &Handler{
+			hostRoot:  tmpDir,
+			stateFile: filepath.Join(tmpDir, "state"),
+			mount: func(args ...string) error {
+				mounts = append(mounts, strings.Join(args, " "))
+				mounted = args[0] == "mount"
+				return nil
+			},
+			isMounted: func(path string) (bool, error) {
+				Expect(path).To(Equal(util.EphemeralDisksDir))
+				return mounted, nil
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	addVMIData := func(uid string) {
+		Expect(os.MkdirAll(filepath.Join(tmpDir, util.EphemeralDisksDir, uid), 0755)).To(Succeed())
+	}
+
+	table.DescribeTable("should mount the first backing selecting the node", func(backings []v1.EphemeralDiskBacking, expected string) {
+		Expect(handler.Reconcile(nodeLabels, backings)).To(Succeed())
+		Expect(mounts).To(ConsistOf(expected))
+		Expect(filepath.Join(tmpDir, util.EphemeralDisksDir)).To(BeADirectory())
+	},
+		table.Entry("with a tmpfs", []v1.EphemeralDiskBacking{tmpfsBacking(nil)},
+			"mount -t tmpfs -o size=1073741824 tmpfs "+util.EphemeralDisksDir),
+		table.Entry("with a host path", []v1.EphemeralDiskBacking{
+			{NodeSelector: map[string]string{"storage": "slow"}, BlockDevice: "/dev/sdb"},
+			{NodeSelector: nodeLabels, HostPath: "/mnt/scratch"},
+			tmpfsBacking(nil),
+		}, "mount --bind /mnt/scratch "+util.EphemeralDisksDir),
+		table.Entry("with a block device", []v1.EphemeralDiskBacking{{BlockDevice: "/dev/sdb"}},
+			"mount /dev/sdb "+util.EphemeralDisksDir),
+	)
+
+	It("should create the host path", func() {
+		Expect(handler.Reconcile(nodeLabels, []v1.EphemeralDiskBacking{{HostPath: "/mnt/scratch"}})).To(Succeed())
+		Expect(filepath.Join(tmpDir, "mnt/scratch")).To(BeADirectory())
+	})
+
+	It("should not mount anything if no backing selects the node", func() {
+		Expect(handler.Reconcile(nodeLabels, []v1.EphemeralDiskBacking{tmpfsBacking(map[string]string{"storage": "slow"})})).To(Succeed())
+		Expect(mounts).To(BeEmpty())
+	})
+
+	It("should not mount the backing again", func() {
+		backings := []v1.EphemeralDiskBacking{tmpfsBacking(nil)}
+		Expect(handler.Reconcile(nodeLabels, backings)).To(Succeed())
+		Expect(handler.Reconcile(nodeLabels, []v1.EphemeralDiskBacking{tmpfsBacking(nodeLabels)})).To(Succeed())
+		Expect(mounts).To(HaveLen(1))
+	})
+
+	It("should mount the backing again after a reboot", func() {
+		backings := []v1.EphemeralDiskBacking{tmpfsBacking(nil)}
+		Expect(handler.Reconcile(nodeLabels, backings)).To(Succeed())
+		mounted = false
+		Expect(handler.Reconcile(nodeLabels, backings)).To(Succeed())
+		Expect(mounts).To(HaveLen(2))
+		Expect(mounts[1]).To(HavePrefix("mount"))
+	})
+
+	It("should change the backing once no VMI uses it", func() {
+		Expect(handler.Reconcile(nodeLabels, []v1.EphemeralDiskBacking{tmpfsBacking(nil)})).To(Succeed())
+		addVMIData("1234")
+
+		backings := []v1.EphemeralDiskBacking{{BlockDevice: "/dev/sdb"}}
+		Expect(handler.Reconcile(nodeLabels, backings)).To(Succeed())
+		Expect(mounts).To(HaveLen(1))
+
+		Expect(handler.RemoveVMIData("1234")).To(Succeed())
+		Expect(handler.Reconcile(nodeLabels, backings)).To(Succeed())
+		Expect(mounts[1:]).To(Equal([]string{
+			"umount " + util.EphemeralDisksDir,
+			"mount /dev/sdb " + util.EphemeralDisksDir,
+		}))
+	})
+
+	It("should mount the backing over the directories left on the node", func() {
+		addVMIData("1234")
+		Expect(handler.Reconcile(nodeLabels, []v1.EphemeralDiskBacking{tmpfsBacking(nil)})).To(Succeed())
+		Expect(mounts).To(ConsistOf("mount -t tmpfs -o size=1073741824 tmpfs " + util.EphemeralDisksDir))
+	})
+
+	It("should report whether a backing is mounted", func() {
+		Expect(handler.Mounted()).To(BeFalse())
+		Expect(handler.Reconcile(nodeLabels, []v1.EphemeralDiskBacking{tmpfsBacking(nil)})).To(Succeed())
+		Expect(handler.Mounted()).To(BeTrue())
+		mounted = false
+		Expect(handler.Mounted()).To(BeFalse())
+	})
+
+	It("should unmount the backing once no backing selects the node", func() {
+		Expect(handler.Reconcile(nodeLabels, []v1.EphemeralDiskBacking{tmpfsBacking(nil)})).To(Succeed())
+		Expect(handler.Reconcile(nodeLabels, nil)).To(Succeed())
+		Expect(mounts).To(HaveLen(2))
+		Expect(mounts[1]).To(Equal("umount " + util.EphemeralDisksDir))
+		Expect(handler.stateFile).ToNot(BeAnExistingFile())
+	})
+
+	It("should reject backings which do not set exactly one medium", func() {
+		err := handler.Reconcile(nodeLabels, []v1.EphemeralDiskBacking{{HostPath: "/mnt/scratch", BlockDevice: "/dev/sdb"}})
+		Expect(err).To(MatchError(ContainSubstring("exactly one of hostPath, blockDevice and tmpfs")))
+		Expect(mounts).To(BeEmpty())
+	})
+})
@@ -142,8 +142,8 @@ func prLimit(pid int, limit uintptr, rlimit *unix.Rlimit) error {
 }
 
 func (s *socketBasedIsolationDetector) AdjustResources(vm *v1.VirtualMachineInstance) error {
-	// only VFIO attached domains require MEMLOCK adjustment
-	if !util.IsVFIOVMI(vm) {
+	// only VFIO attached, confidential and realtime domains require MEMLOCK adjustment
+	if !util.IsVFIOVMI(vm) && !util.IsConfidentialVMI(vm) && !util.IsRealtimeVMI(vm) {
 		return nil
 	}
 
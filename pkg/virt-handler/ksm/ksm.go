@@ -0,0 +1,231 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package ksm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+)
+
+const (
+	// KSMPath is the sysfs directory of the kernel samepage merging, as seen from virt-handler
+	KSMPath = util.HostRootMount + "sys/kernel/mm/ksm/"
+	// MemInfoPath is the memory information of the node
+	MemInfoPath = hardware.MEMINFO_PATH
+)
+
+// The tuning follows the approach of ksmtuned: the scan rate is increased while the
+// node runs low on free memory and slowly decreased again once there is enough of it.
+const (
+	pagesBoost           = 300
+	pagesDecay           = 50
+	pagesMin             = 64
+	pagesMax             = 1250
+	sleepBaselineMs      = 10
+	freeThresholdPercent = 20
+)
+
+// Stats are the counters which KSM exposes in sysfs
+type Stats struct {
+	Running       bool
+	PagesShared   uint64
+	PagesSharing  uint64
+	PagesUnshared uint64
+	FullScans     uint64
+}
+
+// Handler enables, tunes and disables KSM on the node of virt-handler
+type Handler struct {
+	ksmPath     string
+	memInfoPath string
+	// managed records that this handler enabled KSM, in case recording it on the node failed
+	managed bool
+}
+
+func NewHandler() *Handler {
+	return &Handler{
+		ksmPath:     KSMPath,
+		memInfoPath: MemInfoPath,
+	}
+}
+
+// Reconcile enables and tunes KSM if the node is selected by the configuration. KSM which was
+// enabled on the node by other means is left untouched. If the node is not selected anymore,
+// KSM is only disabled if virt-handler enabled it before, which is recorded on the node with
+// the KSMHandlerManagedAnnotation.
+// It returns whether KSM is running and whether virt-handler manages it.
+func (h *Handler) Reconcile(node *k8sv1.Node, config *v1.KSMConfiguration) (bool, bool, error) {
+	if _, err := os.Stat(filepath.Join(h.ksmPath, "run")); err != nil {
+		if os.IsNotExist(err) {
+			// The kernel does not support KSM
+			return false, false, nil
+		}
+		return false, false, err
+	}
+
+	selected, err := nodeSelected(node, config)
+	if err != nil {
+		return false, false, err
+	}
+	managed := h.managed || node.Annotations[v1.KSMHandlerManagedAnnotation] == "true"
+	if !selected {
+		if managed {
+			if err := h.write("run", 0); err != nil {
+				return false, true, err
+			}
+			h.managed = false
+		}
+		running, err := h.read("run")
+		if err != nil {
+			return false, false, err
+		}
+		return running == 1, false, nil
+	}
+
+	if !managed {
+		running, err := h.read("run")
+		if err != nil {
+			return false, false, err
+		}
+		if running == 1 {
+			// KSM was enabled by the admin, it is neither tuned nor disabled later
+			return true, false, nil
+		}
+	}
+
+	pages, sleepMs, err := h.tune()
+	if err != nil {
+		return false, false, err
+	}
+	if err := h.write("pages_to_scan", pages); err != nil {
+		return false, true, err
+	}
+	if err := h.write("sleep_millisecs", sleepMs); err != nil {
+		return false, true, err
+	}
+	if err := h.write("run", 1); err != nil {
+		return false, true, err
+	}
+	h.managed = true
+	return true, true, nil
+}
+
+// nodeSelected returns whether KSM should be enabled on the node. Without a configuration
+// KSM is not managed at all, an empty node label selector selects all nodes.
+func nodeSelected(node *k8sv1.Node, config *v1.KSMConfiguration) (bool, error) {
+	if config == nil {
+		return false, nil
+	}
+	if config.NodeLabelSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(config.NodeLabelSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid KSM node label selector: %v", err)
+	}
+	return selector.Matches(labels.Set(node.Labels)), nil
+}
+
+// tune computes the number of pages to scan and the sleep between the scans
+// from the current memory pressure of the node
+func (h *Handler) tune() (uint64, uint64, error) {
+	total, available, err := hardware.ReadMemInfo(h.memInfoPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	pages, err := h.read("pages_to_scan")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if available*100 < total*freeThresholdPercent {
+		pages += pagesBoost
+		if pages > pagesMax {
+			pages = pagesMax
+		}
+	} else if pages < pagesMin+pagesDecay {
+		pages = pagesMin
+	} else {
+		pages -= pagesDecay
+	}
+
+	// Sleep longer between the scans on nodes with less memory, based on 16GiB
+	sleepMs := uint64(sleepBaselineMs)
+	if total > 0 {
+		sleepMs = sleepBaselineMs * 16 * 1024 * 1024 / total
+		if sleepMs < sleepBaselineMs {
+			sleepMs = sleepBaselineMs
+		}
+	}
+	return pages, sleepMs, nil
+}
+
+func (h *Handler) read(name string) (uint64, error) {
+	return readUint(filepath.Join(h.ksmPath, name))
+}
+
+func (h *Handler) write(name string, value uint64) error {
+	return ioutil.WriteFile(filepath.Join(h.ksmPath, name), []byte(strconv.FormatUint(value, 10)), 0644)
+}
+
+func readUint(path string) (uint64, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return value, nil
+}
+
+// ReadStats returns the KSM counters found in the given sysfs directory
+func ReadStats(ksmPath string) (*Stats, error) {
+	running, err := readUint(filepath.Join(ksmPath, "run"))
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{Running: running == 1}
+	for name, field := range map[string]*uint64{
+		"pages_shared":   &stats.PagesShared,
+		"pages_sharing":  &stats.PagesSharing,
+		"pages_unshared": &stats.PagesUnshared,
+		"full_scans":     &stats.FullScans,
+	} {
+		if *field, err = readUint(filepath.Join(ksmPath, name)); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
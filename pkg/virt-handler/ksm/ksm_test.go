@@ -0,0 +1,220 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package ksm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("KSM", func() {
+
+	var tmpDir string
+	var handler *Handler
+	var node *k8sv1.Node
+
+	writeFile := func(name string, content string) {
+		Expect(ioutil.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644)).To(Succeed())
+	}
+
+	readFile := func(name string) string {
+		content, err := ioutil.ReadFile(filepath.Join(tmpDir, name))
+		Expect(err).ToNot(HaveOccurred())
+		return string(content)
+	}
+
+	setMemInfo := func(totalKiB, availableKiB uint64) {
+		writeFile("meminfo", fmt.Sprintf("MemTotal:       %d kB\nMemFree:        %d kB\nMemAvailable:   %d kB\n", totalKiB, availableKiB, availableKiB))
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "ksm")
+		Expect(err).ToNot(HaveOccurred())
+		handler = &Handler{
+			ksmPath:     tmpDir,
+			memInfoPath: filepath.Join(tmpDir, "meminfo"),
+		}
+		writeFile("run", "0\n")
+		writeFile("pages_to_scan", "100\n")
+		writeFile("sleep_millisecs", "20\n")
+		setMemInfo(16*1024*1024, 8*1024*1024)
+		node = &k8sv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"ksm": "true"},
+				Annotations: map[string]string{},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should not touch KSM without a configuration", func() {
+		enabled, managed, err := handler.Reconcile(node, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeFalse())
+		Expect(managed).To(BeFalse())
+		Expect(readFile("run")).To(Equal("0\n"))
+	})
+
+	It("should enable KSM on all nodes with an empty configuration", func() {
+		enabled, managed, err := handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+		Expect(managed).To(BeTrue())
+		Expect(readFile("run")).To(Equal("1"))
+	})
+
+	It("should enable KSM on nodes matching the selector", func() {
+		config := &v1.KSMConfiguration{
+			NodeLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ksm": "true"}},
+		}
+		enabled, managed, err := handler.Reconcile(node, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+		Expect(managed).To(BeTrue())
+		Expect(readFile("run")).To(Equal("1"))
+	})
+
+	It("should disable KSM on nodes not matching the selector anymore if virt-handler enabled it", func() {
+		writeFile("run", "1")
+		node.Annotations[v1.KSMHandlerManagedAnnotation] = "true"
+		config := &v1.KSMConfiguration{
+			NodeLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ksm": "false"}},
+		}
+		enabled, managed, err := handler.Reconcile(node, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeFalse())
+		Expect(managed).To(BeFalse())
+		Expect(readFile("run")).To(Equal("0"))
+	})
+
+	It("should leave KSM running if it was not enabled by virt-handler", func() {
+		writeFile("run", "1")
+		config := &v1.KSMConfiguration{
+			NodeLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ksm": "false"}},
+		}
+		enabled, managed, err := handler.Reconcile(node, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+		Expect(managed).To(BeFalse())
+		Expect(readFile("run")).To(Equal("1"))
+	})
+
+	It("should not take over KSM which was enabled by the admin", func() {
+		writeFile("run", "1")
+		enabled, managed, err := handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+		Expect(managed).To(BeFalse())
+		Expect(readFile("pages_to_scan")).To(Equal("100\n"))
+
+		config := &v1.KSMConfiguration{
+			NodeLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ksm": "false"}},
+		}
+		enabled, managed, err = handler.Reconcile(node, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+		Expect(managed).To(BeFalse())
+		Expect(readFile("run")).To(Equal("1"))
+	})
+
+	It("should keep managing KSM it enabled even if the node was not annotated", func() {
+		enabled, managed, err := handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+		Expect(managed).To(BeTrue())
+
+		enabled, managed, err = handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+		Expect(managed).To(BeTrue())
+	})
+
+	It("should report KSM as disabled if the kernel does not support it", func() {
+		Expect(os.Remove(filepath.Join(tmpDir, "run"))).To(Succeed())
+		enabled, managed, err := handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeFalse())
+		Expect(managed).To(BeFalse())
+	})
+
+	It("should scan more pages under memory pressure", func() {
+		setMemInfo(16*1024*1024, 1024*1024)
+		_, _, err := handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile("pages_to_scan")).To(Equal("400"))
+		Expect(readFile("sleep_millisecs")).To(Equal("10"))
+	})
+
+	It("should not scan more than the maximum number of pages", func() {
+		setMemInfo(16*1024*1024, 1024*1024)
+		writeFile("pages_to_scan", "1200")
+		_, _, err := handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile("pages_to_scan")).To(Equal("1250"))
+	})
+
+	It("should scan less pages without memory pressure", func() {
+		_, _, err := handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile("pages_to_scan")).To(Equal("64"))
+
+		writeFile("pages_to_scan", "500")
+		_, _, err = handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile("pages_to_scan")).To(Equal("450"))
+	})
+
+	It("should sleep longer between the scans on nodes with less memory", func() {
+		setMemInfo(4*1024*1024, 3*1024*1024)
+		_, _, err := handler.Reconcile(node, &v1.KSMConfiguration{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile("sleep_millisecs")).To(Equal("40"))
+	})
+
+	It("should read the KSM counters", func() {
+		writeFile("run", "1")
+		writeFile("pages_shared", "10")
+		writeFile("pages_sharing", "200")
+		writeFile("pages_unshared", "30")
+		writeFile("full_scans", "4")
+		stats, err := ReadStats(tmpDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stats).To(Equal(&Stats{
+			Running:       true,
+			PagesShared:   10,
+			PagesSharing:  200,
+			PagesUnshared: 30,
+			FullScans:     4,
+		}))
+	})
+})
@@ -20,7 +20,9 @@
 package rest
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
@@ -29,31 +31,45 @@ import (
 	"sync"
 
 	"github.com/emicklei/go-restful"
+	"github.com/gorilla/websocket"
+	"golang.org/x/sys/unix"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/client-go/subresources"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/nbd"
+	"kubevirt.io/kubevirt/pkg/util/net/pcap"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+	serialconsolelog "kubevirt.io/kubevirt/pkg/virt-launcher/serial-console-log"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 )
 
 type ConsoleHandler struct {
 	podIsolationDetector isolation.PodIsolationDetector
+	sessions             *sessionStore
 	serialStopChans      map[types.UID](chan struct{})
 	vncStopChans         map[types.UID](chan struct{})
+	deviceStopChans      map[types.UID](chan struct{})
 	serialLock           *sync.Mutex
 	vncLock              *sync.Mutex
+	deviceLock           *sync.Mutex
 	vmiInformer          cache.SharedIndexInformer
 }
 
 func NewConsoleHandler(podIsolationDetector isolation.PodIsolationDetector, vmiInformer cache.SharedIndexInformer) *ConsoleHandler {
 	return &ConsoleHandler{
 		podIsolationDetector: podIsolationDetector,
+		sessions:             newSessionStore(defaultSessionGracePeriod),
 		serialStopChans:      make(map[types.UID](chan struct{})),
 		vncStopChans:         make(map[types.UID](chan struct{})),
+		deviceStopChans:      make(map[types.UID](chan struct{})),
 		serialLock:           &sync.Mutex{},
 		vncLock:              &sync.Mutex{},
+		deviceLock:           &sync.Mutex{},
 		vmiInformer:          vmiInformer,
 	}
 }
@@ -72,11 +88,13 @@ func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.
 		return
 	}
 	uid := vmi.GetUID()
-	stopChn := newStopChan(uid, t.vncLock, t.vncStopChans)
-	cleanup := func() {
-		deleteStopChan(uid, stopChn, t.vncLock, t.vncStopChans)
+	stop := func() (chan struct{}, func()) {
+		stopChn := newStopChan(uid, t.vncLock, t.vncStopChans)
+		return stopChn, func() {
+			deleteStopChan(uid, stopChn, t.vncLock, t.vncStopChans)
+		}
 	}
-	t.stream(vmi, request, response, unixSocketPath, stopChn, cleanup)
+	t.stream(vmi, request, response, unixSocketPath, dialUnixSocket(unixSocketPath), stop)
 }
 
 func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restful.Response) {
@@ -93,11 +111,368 @@ func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restf
 		return
 	}
 	uid := vmi.GetUID()
-	stopCh := newStopChan(uid, t.serialLock, t.serialStopChans)
-	cleanup := func() {
-		deleteStopChan(uid, stopCh, t.serialLock, t.serialStopChans)
+	stop := func() (chan struct{}, func()) {
+		stopCh := newStopChan(uid, t.serialLock, t.serialStopChans)
+		return stopCh, func() {
+			deleteStopChan(uid, stopCh, t.serialLock, t.serialStopChans)
+		}
+	}
+	t.resumableStream(vmi, request, response, unixSocketPath, dialUnixSocket(unixSocketPath), stop)
+}
+
+func (t *ConsoleHandler) VSOCKHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	if !util.IsAutoAttachVSOCK(vmi) || vmi.Status.VSOCKCID == nil {
+		err = fmt.Errorf("VSOCK is not attached to the VMI")
+		log.Log.Object(vmi).Reason(err).Error("Can't establish a VSOCK connection")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	port, err := strconv.ParseUint(request.QueryParameter("port"), 10, 32)
+	if err != nil || port == 0 {
+		err = fmt.Errorf("invalid VSOCK port %q", request.QueryParameter("port"))
+		log.Log.Object(vmi).Reason(err).Error("Can't establish a VSOCK connection")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	cid := *vmi.Status.VSOCKCID
+	// Several VSOCK connections to the same VMI may be open at the same time
+	target := fmt.Sprintf("vsock %d:%d", cid, port)
+	t.stream(vmi, request, response, target, dialVSOCK(cid, uint32(port)), nil)
+}
+
+func (t *ConsoleHandler) SerialPortHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	device := request.QueryParameter("device")
+	for i, serial := range vmi.Spec.Domain.Devices.Serials {
+		if serial.Name == device {
+			t.streamDevice(vmi, request, response, util.SerialPortSocketName(i))
+			return
+		}
+	}
+	err = fmt.Errorf("serial port %q does not exist", device)
+	log.Log.Object(vmi).Reason(err).Error("Can't connect to the serial port")
+	response.WriteError(http.StatusBadRequest, err)
+}
+
+func (t *ConsoleHandler) ChannelHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	device := request.QueryParameter("device")
+	for i, channel := range vmi.Spec.Domain.Devices.Channels {
+		if channel.Name == device {
+			t.streamDevice(vmi, request, response, util.ChannelSocketName(i))
+			return
+		}
+	}
+	err = fmt.Errorf("channel %q does not exist", device)
+	log.Log.Object(vmi).Reason(err).Error("Can't connect to the channel")
+	response.WriteError(http.StatusBadRequest, err)
+}
+
+// streamDevice connects to the unix socket of an additional serial port or channel.
+// Only one connection per device is kept, a new connection closes the previous one.
+func (t *ConsoleHandler) streamDevice(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, socketName string) {
+	unixSocketPath, err := t.getUnixSocketPath(vmi, socketName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed finding unix socket %s", socketName)
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	key := types.UID(path.Join(string(vmi.GetUID()), socketName))
+	stop := func() (chan struct{}, func()) {
+		stopCh := newStopChan(key, t.deviceLock, t.deviceStopChans)
+		return stopCh, func() {
+			deleteStopChan(key, stopCh, t.deviceLock, t.deviceStopChans)
+		}
+	}
+	t.stream(vmi, request, response, unixSocketPath, dialUnixSocket(unixSocketPath), stop)
+}
+
+// PcapHandler streams a packet capture, in the pcap format, of the device carrying the traffic of a VMI interface.
+// The capture ends after the requested packet count or duration, or when the client disconnects.
+func (t *ConsoleHandler) PcapHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	device, err := network.GetCaptureDeviceName(vmi, request.QueryParameter("interface"))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Can't capture packets")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	options, err := pcap.ParseOptions(request.QueryParameter("filter"), request.QueryParameter("count"), request.QueryParameter("duration"))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Can't capture packets")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	result, err := t.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the virt-launcher pod")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	// Several captures of the same VMI may run at the same time
+	target := fmt.Sprintf("packet capture on %s", device)
+	t.stream(vmi, request, response, target, dialCapture(result, device, options), nil)
+}
+
+// BackupHandler streams the blocks of a disk which changed since the checkpoint the running backup started from,
+// or all its allocated blocks for a full backup, as exported by libvirt over NBD.
+func (t *ConsoleHandler) BackupHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	backup := vmi.Status.Backup
+	if backup == nil || backup.Checkpoint == "" {
+		err = fmt.Errorf("no backup is running")
+		log.Log.Object(vmi).Reason(err).Error("Can't stream the backup")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	disk := request.QueryParameter("disk")
+	found := false
+	for _, name := range backup.Disks {
+		if name == disk {
+			found = true
+			break
+		}
+	}
+	if !found {
+		err = fmt.Errorf("disk %q is not part of the backup", disk)
+		log.Log.Object(vmi).Reason(err).Error("Can't stream the backup")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	metaContext := nbd.AllocationContext
+	if backup.Incremental != "" {
+		metaContext = nbd.DirtyBitmapContextPrefix + util.BackupBitmapName(disk)
+	}
+	withData := request.QueryParameter("extentsOnly") != "true"
+	unixSocketPath, err := t.getUnixSocketPath(vmi, util.BackupSocketName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed finding unix socket for the backup")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	// Several disks of the same backup may be streamed at the same time
+	target := fmt.Sprintf("backup of disk %s", disk)
+	t.stream(vmi, request, response, target, dialBackup(unixSocketPath, disk, metaContext, withData), nil)
+}
+
+// PortForwardHandler connects to a TCP or UDP port of the guest from the virt-launcher pod network namespace.
+func (t *ConsoleHandler) PortForwardHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	port, err := strconv.ParseUint(request.QueryParameter("port"), 10, 16)
+	if err != nil || port == 0 {
+		err = fmt.Errorf("invalid port %q", request.QueryParameter("port"))
+		log.Log.Object(vmi).Reason(err).Error("Can't forward the port")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	protocol := request.QueryParameter("protocol")
+	if protocol == "" {
+		protocol = "tcp"
+	} else if protocol != "tcp" && protocol != "udp" {
+		err = fmt.Errorf("invalid protocol %q", protocol)
+		log.Log.Object(vmi).Reason(err).Error("Can't forward the port")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	address, err := network.GetPortForwardAddress(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Can't forward the port")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	result, err := t.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the virt-launcher pod")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	// Several connections to the same port may be open at the same time
+	target := fmt.Sprintf("%s port %d", protocol, port)
+	t.stream(vmi, request, response, target, dialNetNS(result, protocol, net.JoinHostPort(address, strconv.FormatUint(port, 10))), nil)
+}
+
+func (t *ConsoleHandler) SerialConsoleLogHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	if !util.IsSerialConsoleLogEnabled(vmi) {
+		err = fmt.Errorf("serial console logging is not enabled")
+		log.Log.Object(vmi).Reason(err).Error("Can't retrieve the serial console log")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	logFiles, err := t.getSerialConsoleLogFiles(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed finding the serial console log")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", "text/plain")
+	response.WriteHeader(http.StatusOK)
+	for _, logFile := range logFiles {
+		if err := copyFile(response, logFile); err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("Failed to send the serial console log %s", logFile)
+			return
+		}
+	}
+}
+
+// getSerialConsoleLogFiles returns the serial console log files of the VMI from the oldest to the newest one.
+// If the log is stored on a PVC, it also contains the output of previous runs of the VMI.
+func (t *ConsoleHandler) getSerialConsoleLogFiles(vmi *v1.VirtualMachineInstance) ([]string, error) {
+	result, err := t.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return nil, err
+	}
+	root := path.Join("proc", strconv.Itoa(result.Pid()), "root")
+	if vmi.Spec.Domain.Devices.SerialConsoleLog != nil {
+		return serialconsolelog.LogFiles(path.Join(root, util.SerialConsoleLogDir)), nil
+	}
+	logFile := path.Join(root, util.VirtPrivateDir, string(vmi.GetUID()), util.SerialConsoleLogFile)
+	if _, err = os.Stat(logFile); os.IsNotExist(err) {
+		// Nothing was written to the serial console yet
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return []string{logFile}, nil
+}
+
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+type dialer func() (net.Conn, error)
+
+func dialUnixSocket(unixSocketPath string) dialer {
+	return func() (net.Conn, error) {
+		return net.Dial("unix", unixSocketPath)
+	}
+}
+
+func dialVSOCK(cid uint32, port uint32) dialer {
+	return func() (net.Conn, error) {
+		fd, err := unix.Socket(unix.AF_VSOCK, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+		if err != nil {
+			return nil, err
+		}
+		if err = unix.Connect(fd, &unix.SockaddrVM{CID: cid, Port: port}); err != nil {
+			unix.Close(fd)
+			return nil, err
+		}
+		f := os.NewFile(uintptr(fd), fmt.Sprintf("vsock:%d:%d", cid, port))
+		defer f.Close()
+		return net.FileConn(f)
+	}
+}
+
+// dialNetNS connects to the address from the network namespace of the isolation result.
+// The socket is created in the namespace, and stays in it once the thread leaves it.
+func dialNetNS(result isolation.IsolationResult, protocol string, address string) dialer {
+	return func() (conn net.Conn, err error) {
+		err = result.DoNetNS(func() error {
+			conn, err = net.Dial(protocol, address)
+			return err
+		})
+		return conn, err
+	}
+}
+
+// dialCapture opens a capture socket on the device in the network namespace of the isolation result and
+// returns a connection from which its pcap stream is read. The socket is closed when the capture ends.
+func dialCapture(result isolation.IsolationResult, device string, options *pcap.Options) dialer {
+	return func() (net.Conn, error) {
+		var socket *pcap.Socket
+		err := result.DoNetNS(func() (err error) {
+			socket, err = pcap.Open(device, options.Filter)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		local, remote := net.Pipe()
+		go func() {
+			defer socket.Close()
+			defer remote.Close()
+			if err := socket.Capture(remote, options); err != nil {
+				log.Log.Reason(err).V(4).Info("packet capture ended")
+			}
+		}()
+		go func() {
+			// Nothing is expected from the client, but its input must not block the stream
+			io.Copy(ioutil.Discard, remote)
+		}()
+		return local, nil
+	}
+}
+
+// dialBackup returns a connection from which the changed extents of the NBD export of the disk are read
+func dialBackup(unixSocketPath string, export string, metaContext string, withData bool) dialer {
+	return func() (net.Conn, error) {
+		conn, err := net.Dial("unix", unixSocketPath)
+		if err != nil {
+			return nil, err
+		}
+		client, err := nbd.Connect(conn, export, metaContext)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		local, remote := net.Pipe()
+		go func() {
+			defer client.Close()
+			defer remote.Close()
+			if err := client.WriteChanged(remote, withData); err != nil {
+				log.Log.Reason(err).V(4).Info("backup stream ended")
+			}
+		}()
+		go func() {
+			// Nothing is expected from the client, but its input must not block the stream
+			io.Copy(ioutil.Discard, remote)
+		}()
+		return local, nil
 	}
-	t.stream(vmi, request, response, unixSocketPath, stopCh, cleanup)
 }
 
 func newStopChan(uid types.UID, lock *sync.Mutex, stopChans map[types.UID](chan struct{})) chan struct{} {
@@ -137,35 +512,43 @@ func (t *ConsoleHandler) getUnixSocketPath(vmi *v1.VirtualMachineInstance, socke
 	return socketPath, nil
 }
 
-type cleanupOnError func()
+// stopper creates the stop channel of a stream only one client may use at a time, which closes the stream
+// of the previous client, together with the cleanup of the channel
+type stopper func() (chan struct{}, func())
 
-func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, unixSocketPath string, stopCh chan struct{}, cleanup cleanupOnError) {
-	var upgrader = kubecli.NewUpgrader()
-	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+// stream proxies between the client and the target until either goes away or the stream is stopped
+func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, target string, dial dialer, stop stopper) {
+	var stopCh chan struct{}
+	cleanup := func() {}
+	if stop != nil {
+		stopCh, cleanup = stop()
+	}
+
+	log.Log.Object(vmi).Infof("Connecting to %s", target)
+	fd, err := dial()
 	if err != nil {
-		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		cleanup()
+		log.Log.Object(vmi).Reason(err).Errorf("failed to dial %s", target)
 		response.WriteError(http.StatusInternalServerError, err)
 		return
 	}
-	defer clientSocket.Close()
-
-	log.Log.Object(vmi).Infof("Websocket connection upgraded")
-	log.Log.Object(vmi).Infof("Connecting to %s", unixSocketPath)
+	defer fd.Close()
+	log.Log.Object(vmi).Infof("Connected to %s", target)
 
-	fd, err := net.Dial("unix", unixSocketPath)
+	var upgrader = kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
 	if err != nil {
-		log.Log.Object(vmi).Reason(err).Errorf("failed to dial unix socket %s", unixSocketPath)
-		response.WriteHeader(http.StatusInternalServerError)
+		cleanup()
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
 		return
 	}
-	defer fd.Close()
-
-	log.Log.Object(vmi).Infof("Connected to %s", unixSocketPath)
+	defer clientSocket.Close()
+	log.Log.Object(vmi).Infof("Websocket connection upgraded")
 
-	errCh := make(chan error)
+	errCh := make(chan error, 2)
 	go func() {
 		_, err := kubecli.CopyTo(clientSocket, fd)
-		log.Log.Object(vmi).Reason(err).Error("error encountered reading from unix socket")
+		log.Log.Object(vmi).Reason(err).Errorf("error encountered reading from %s", target)
 		errCh <- err
 	}()
 
@@ -180,10 +563,70 @@ func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful
 		break
 	case err := <-errCh:
 		if err != nil && err != io.EOF {
-			log.Log.Object(vmi).Reason(err).Error("Error in proxing websocket and unix socket")
-			response.WriteHeader(http.StatusInternalServerError)
+			log.Log.Object(vmi).Reason(err).Errorf("Error in proxing websocket and %s", target)
 		}
 
 		cleanup()
 	}
 }
+
+// resumableStream connects the client to the target like stream, but the connection to the target is kept as a
+// session while the client is away, a client which passes the token of the session back is attached to it again.
+// Output of a detached session is dropped once its buffer is full, so only text streams like the serial console,
+// which can afford to lose output, are resumable.
+func (t *ConsoleHandler) resumableStream(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, target string, dial dialer, stop stopper) {
+	key := path.Join(string(vmi.GetUID()), target)
+
+	var session *streamSession
+	if token := request.QueryParameter(subresources.SessionTokenParam); token != "" {
+		// the client is reconnecting, reattach it to the stream it lost
+		if session = t.sessions.get(token, key); session == nil {
+			err := fmt.Errorf("session %s of %s does not exist", token, target)
+			log.Log.Object(vmi).Reason(err).Error("Can't resume the session")
+			response.WriteError(http.StatusNotFound, err)
+			return
+		}
+	} else {
+		var stopCh chan struct{}
+		cleanup := func() {}
+		if stop != nil {
+			stopCh, cleanup = stop()
+		}
+
+		log.Log.Object(vmi).Infof("Connecting to %s", target)
+		fd, err := dial()
+		if err != nil {
+			cleanup()
+			log.Log.Object(vmi).Reason(err).Errorf("failed to dial %s", target)
+			response.WriteError(http.StatusInternalServerError, err)
+			return
+		}
+		log.Log.Object(vmi).Infof("Connected to %s", target)
+
+		if session, err = t.sessions.newSession(key, fd, stopCh, cleanup); err != nil {
+			fd.Close()
+			cleanup()
+			log.Log.Object(vmi).Reason(err).Errorf("failed to create a session for %s", target)
+			response.WriteError(http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	var upgrader = kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, http.Header{subresources.SessionTokenHeader: []string{session.token}})
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		return
+	}
+	log.Log.Object(vmi).Infof("Websocket connection upgraded")
+
+	if !session.attach(clientSocket) {
+		log.Log.Object(vmi).Errorf("session of %s was closed before the client could attach", target)
+		clientSocket.Close()
+		return
+	}
+
+	if err = session.pumpClient(clientSocket); err != nil && !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+		log.Log.Object(vmi).Reason(err).Error("error encountered reading from client (virt-api) websocket")
+	}
+}
@@ -19,12 +19,15 @@
 package rest
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/emicklei/go-restful"
 
+	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/tools/cache"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 )
@@ -97,6 +100,61 @@ func (lh *LifecycleHandler) UnpauseHandler(request *restful.Request, response *r
 	response.WriteHeader(http.StatusAccepted)
 }
 
+func (lh *LifecycleHandler) FreezeHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	unfreezeTimeout := &v1.FreezeUnfreezeTimeout{}
+	if request.Request.Body == nil {
+		log.Log.Object(vmi).Error("Request with no body: unfreeze timeout is required")
+		response.WriteErrorString(http.StatusBadRequest, "Request with no body: unfreeze timeout is required")
+		return
+	}
+	defer request.Request.Body.Close()
+	err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(unfreezeTimeout)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal unfreeze timeout")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	if unfreezeTimeout.UnfreezeTimeout == nil {
+		log.Log.Object(vmi).Error("Unfreeze timeout is required")
+		response.WriteErrorString(http.StatusBadRequest, "Unfreeze timeout is required")
+		return
+	}
+
+	unfreezeTimeoutSeconds := int32(unfreezeTimeout.UnfreezeTimeout.Seconds())
+	if err := client.FreezeVirtualMachine(vmi, unfreezeTimeoutSeconds); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to freeze VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) UnfreezeHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	if err := client.UnfreezeVirtualMachine(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to unfreeze VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
 func (lh *LifecycleHandler) GetGuestInfo(request *restful.Request, response *restful.Response) {
 	log.Log.Info("Retreiving guestinfo")
 	vmi, code, err := getVMI(request, lh.vmiInformer)
@@ -188,3 +246,242 @@ func (lh *LifecycleHandler) GetFilesystems(request *restful.Request, response *r
 
 	response.WriteEntity(fsList)
 }
+
+func (lh *LifecycleHandler) getVMILauncherClient(request *restful.Request, response *restful.Response) (*v1.VirtualMachineInstance, cmdclient.LauncherClient, error) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return nil, nil, err
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return nil, nil, err
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return nil, nil, err
+	}
+
+	return vmi, client, nil
+}
+
+func (lh *LifecycleHandler) SEVFetchCertChainHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	sevPlatformInfo, err := client.GetSEVInfo()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get SEV platform info")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(sevPlatformInfo)
+}
+
+func (lh *LifecycleHandler) SEVQueryLaunchMeasurementHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	sevMeasurementInfo, err := client.GetLaunchMeasurement(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get launch measurement")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(sevMeasurementInfo)
+}
+
+func (lh *LifecycleHandler) SEVInjectLaunchSecretHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	sevSecretOptions := &v1.SEVSecretOptions{}
+	if request.Request.Body == nil {
+		log.Log.Object(vmi).Error("Request with no body: SEV secret options are required")
+		response.WriteErrorString(http.StatusBadRequest, "Request with no body: SEV secret options are required")
+		return
+	}
+	defer request.Request.Body.Close()
+	err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(sevSecretOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal SEV secret options")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := client.InjectLaunchSecret(vmi, sevSecretOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to inject SEV launch secret")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) StartBackupHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	backupOptions := &v1.BackupOptions{}
+	if request.Request.Body == nil {
+		log.Log.Object(vmi).Error("Request with no body: backup options are required")
+		response.WriteErrorString(http.StatusBadRequest, "Request with no body: backup options are required")
+		return
+	}
+	defer request.Request.Body.Close()
+	err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(backupOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal backup options")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := client.StartVirtualMachineBackup(vmi, backupOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to start the backup")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) StopBackupHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	if err := client.StopVirtualMachineBackup(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to stop the backup")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) MemoryDumpHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	memoryDumpOptions := &v1.MemoryDumpOptions{}
+	if request.Request.Body == nil {
+		log.Log.Object(vmi).Error("Request with no body: memory dump options are required")
+		response.WriteErrorString(http.StatusBadRequest, "Request with no body: memory dump options are required")
+		return
+	}
+	defer request.Request.Body.Close()
+	err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(memoryDumpOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal memory dump options")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := client.VirtualMachineMemoryDump(vmi, memoryDumpOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to start the memory dump")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) ExternalSnapshotHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	snapshotOptions := &v1.ExternalSnapshotOptions{}
+	if request.Request.Body == nil {
+		log.Log.Object(vmi).Error("Request with no body: external snapshot options are required")
+		response.WriteErrorString(http.StatusBadRequest, "Request with no body: external snapshot options are required")
+		return
+	}
+	defer request.Request.Body.Close()
+	err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(snapshotOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal external snapshot options")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := client.VirtualMachineExternalSnapshot(vmi, snapshotOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to take the external snapshot")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) BlockCommitHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	commitOptions := &v1.BlockCommitOptions{}
+	if request.Request.Body == nil {
+		log.Log.Object(vmi).Error("Request with no body: block commit options are required")
+		response.WriteErrorString(http.StatusBadRequest, "Request with no body: block commit options are required")
+		return
+	}
+	defer request.Request.Body.Close()
+	err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(commitOptions)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal block commit options")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := client.VirtualMachineBlockCommit(vmi, commitOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to start the block commit")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,318 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// defaultSessionGracePeriod is how long a detached session waits for its client to come back
+	defaultSessionGracePeriod = 30 * time.Second
+	// maxDetachedBufferSize caps the stream output kept for a detached client, older output is dropped first
+	maxDetachedBufferSize = 1024 * 1024
+)
+
+// streamSession keeps the connection to the serial console open while its client is away, so that a
+// reconnecting client is attached to the same stream. virt-handler is the only one streaming from a VMI,
+// every virt-api replica can resume its sessions.
+type streamSession struct {
+	token       string
+	key         string
+	gracePeriod time.Duration
+	serverConn  net.Conn
+	onClose     func(*streamSession)
+	done        chan struct{}
+
+	// serverWriteLock serializes writes to the target while an old and a new client overlap
+	serverWriteLock sync.Mutex
+
+	// lock protects the fields below, no I/O is done while holding it
+	lock   sync.Mutex
+	client *websocket.Conn
+	// flushing is set while a goroutine writes the buffer to the client, it is the only writer of the client
+	flushing bool
+	// flushed is signaled when flushing is cleared
+	flushed     *sync.Cond
+	buffer      [][]byte
+	bufferSize  int
+	expiryTimer *time.Timer
+	closed      bool
+}
+
+// attach makes clientConn the receiver of the stream, replacing any client attached before.
+// Output buffered while the session was detached is flushed to the new client first.
+func (s *streamSession) attach(clientConn *websocket.Conn) bool {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return false
+	}
+	if s.expiryTimer != nil {
+		s.expiryTimer.Stop()
+		s.expiryTimer = nil
+	}
+	previous := s.client
+	s.client = clientConn
+	flush := !s.flushing
+	s.flushing = true
+	s.lock.Unlock()
+
+	if previous != nil {
+		// unblocks a pending write to the previous client
+		previous.Close()
+	}
+	if flush {
+		go s.flush()
+	}
+	return true
+}
+
+// detach releases clientConn and starts the grace period, if it is still the attached client
+func (s *streamSession) detach(clientConn *websocket.Conn) {
+	s.lock.Lock()
+	s.detachLocked(clientConn)
+	s.lock.Unlock()
+	clientConn.Close()
+}
+
+func (s *streamSession) detachLocked(clientConn *websocket.Conn) {
+	if s.closed || s.client != clientConn {
+		return
+	}
+	s.client = nil
+	s.expiryTimer = time.AfterFunc(s.gracePeriod, s.close)
+}
+
+// close tears down the session together with the connection to the target
+func (s *streamSession) close() {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return
+	}
+	s.closed = true
+	if s.expiryTimer != nil {
+		s.expiryTimer.Stop()
+	}
+	client := s.client
+	s.client = nil
+	s.buffer = nil
+	s.flushed.Broadcast()
+	s.lock.Unlock()
+
+	if client != nil {
+		client.Close()
+	}
+	s.serverConn.Close()
+	close(s.done)
+	if s.onClose != nil {
+		s.onClose(s)
+	}
+}
+
+func (s *streamSession) bufferLocked(data []byte) {
+	s.buffer = append(s.buffer, data)
+	s.bufferSize += len(data)
+	for s.bufferSize > maxDetachedBufferSize && len(s.buffer) > 0 {
+		s.bufferSize -= len(s.buffer[0])
+		s.buffer = s.buffer[1:]
+	}
+}
+
+// flush writes the buffered output to the attached client until the buffer is drained or no client is
+// attached. The caller must have set flushing, which is cleared when flush returns.
+func (s *streamSession) flush() {
+	for {
+		s.lock.Lock()
+		client := s.client
+		if s.closed || client == nil || len(s.buffer) == 0 {
+			s.flushing = false
+			s.flushed.Broadcast()
+			s.lock.Unlock()
+			return
+		}
+		data := s.buffer[0]
+		s.buffer = s.buffer[1:]
+		s.bufferSize -= len(data)
+		s.lock.Unlock()
+
+		if err := client.WriteMessage(websocket.BinaryMessage, data); err != nil {
+			log.Log.Reason(err).V(3).Info("Client of the session went away, waiting for it to reconnect")
+			s.lock.Lock()
+			s.buffer = append([][]byte{data}, s.buffer...)
+			s.bufferSize += len(data)
+			s.detachLocked(client)
+			s.lock.Unlock()
+			client.Close()
+		}
+	}
+}
+
+// pumpServer forwards the output of the target to the attached client until the target goes away
+func (s *streamSession) pumpServer() {
+	defer s.close()
+	for {
+		data := make([]byte, kubecli.WebsocketMessageBufferSize)
+		n, err := s.serverConn.Read(data)
+		if n > 0 {
+			s.lock.Lock()
+			// output is only dropped while no client is attached, an attached client slows the stream down
+			for s.flushing && s.client != nil && !s.closed {
+				s.flushed.Wait()
+			}
+			s.bufferLocked(data[:n])
+			flush := s.client != nil && !s.flushing
+			if flush {
+				s.flushing = true
+			}
+			s.lock.Unlock()
+
+			if flush {
+				s.flush()
+			}
+		}
+		if err != nil {
+			log.Log.Reason(err).V(3).Info("stream of the session ended")
+			s.lock.Lock()
+			client := s.client
+			s.lock.Unlock()
+			if client != nil {
+				// tells the client that the stream ended, rather than the connection was lost
+				client.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			}
+			return
+		}
+	}
+}
+
+// pumpClient forwards the client input to the target until the client goes away. A client closing the
+// connection normally ends the session, otherwise the session waits for the client to come back.
+func (s *streamSession) pumpClient(clientConn *websocket.Conn) error {
+	defer s.detach(clientConn)
+	for {
+		messageType, data, err := clientConn.ReadMessage()
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			s.close()
+			return err
+		} else if err != nil {
+			return err
+		}
+		if messageType != websocket.BinaryMessage {
+			continue
+		}
+
+		s.serverWriteLock.Lock()
+		_, err = s.serverConn.Write(data)
+		s.serverWriteLock.Unlock()
+		if err != nil {
+			s.close()
+			return err
+		}
+	}
+}
+
+type sessionStore struct {
+	lock        sync.Mutex
+	sessions    map[string]*streamSession
+	gracePeriod time.Duration
+}
+
+func newSessionStore(gracePeriod time.Duration) *sessionStore {
+	return &sessionStore{
+		sessions:    map[string]*streamSession{},
+		gracePeriod: gracePeriod,
+	}
+}
+
+// newSession registers a session for serverConn, the connection to the stream target identified by key.
+// The session is closed when stopCh is closed, cleanup runs once the session is closed.
+func (store *sessionStore) newSession(key string, serverConn net.Conn, stopCh chan struct{}, cleanup func()) (*streamSession, error) {
+	token, err := newSessionToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &streamSession{
+		token:       token,
+		key:         key,
+		gracePeriod: store.gracePeriod,
+		serverConn:  serverConn,
+		done:        make(chan struct{}),
+		onClose: func(session *streamSession) {
+			store.remove(session)
+			cleanup()
+		},
+	}
+	session.flushed = sync.NewCond(&session.lock)
+	// the session is closed if its first client never attaches
+	session.expiryTimer = time.AfterFunc(store.gracePeriod, session.close)
+
+	store.lock.Lock()
+	store.sessions[token] = session
+	store.lock.Unlock()
+
+	go session.pumpServer()
+	if stopCh != nil {
+		go func() {
+			select {
+			case <-stopCh:
+				session.close()
+			case <-session.done:
+			}
+		}()
+	}
+	return session, nil
+}
+
+// get returns the live session with the given token, if it streams from the target identified by key
+func (store *sessionStore) get(token string, key string) *streamSession {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	session, exists := store.sessions[token]
+	if !exists || session.key != key {
+		return nil
+	}
+	return session
+}
+
+func (store *sessionStore) remove(session *streamSession) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	delete(store.sessions, session.token)
+}
+
+func newSessionToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,216 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stream session", func() {
+
+	const sessionKey = "6f3a1c2e/var/run/kubevirt-private/6f3a1c2e/virt-serial0"
+
+	var store *sessionStore
+	var clientConns chan *websocket.Conn
+	var clientServer *httptest.Server
+	var cleanups chan struct{}
+
+	// newSession returns a session together with the target side of its stream
+	newSession := func(stopCh chan struct{}) (*streamSession, net.Conn) {
+		target, serverConn := net.Pipe()
+		cleanedUp := cleanups
+		session, err := store.newSession(sessionKey, serverConn, stopCh, func() { cleanedUp <- struct{}{} })
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		return session, target
+	}
+
+	// connectClient returns the virt-handler side and the remote side of a client connection
+	connectClient := func() (*websocket.Conn, *websocket.Conn) {
+		remote, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(clientServer.URL, "http"), nil)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		return <-clientConns, remote
+	}
+
+	expectMessage := func(conn *websocket.Conn, expected string) {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, data, err := conn.ReadMessage()
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		ExpectWithOffset(1, string(data)).To(Equal(expected))
+	}
+
+	expectInput := func(target net.Conn, expected string) {
+		target.SetReadDeadline(time.Now().Add(5 * time.Second))
+		data := make([]byte, len(expected))
+		_, err := target.Read(data)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		ExpectWithOffset(1, string(data)).To(Equal(expected))
+	}
+
+	isDetached := func(session *streamSession) func() bool {
+		return func() bool {
+			session.lock.Lock()
+			defer session.lock.Unlock()
+			return session.client == nil
+		}
+	}
+
+	BeforeEach(func() {
+		store = newSessionStore(time.Second)
+		clientConns = make(chan *websocket.Conn, 1)
+		cleanups = make(chan struct{}, 1)
+		upgrader := websocket.Upgrader{}
+		clientServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			Expect(err).ToNot(HaveOccurred())
+			clientConns <- conn
+		}))
+	})
+
+	AfterEach(func() {
+		clientServer.Close()
+	})
+
+	It("should proxy the stream in both directions", func() {
+		session, target := newSession(nil)
+
+		client, remoteClient := connectClient()
+		Expect(session.attach(client)).To(BeTrue())
+		go session.pumpClient(client)
+
+		Expect(remoteClient.WriteMessage(websocket.BinaryMessage, []byte("input"))).To(Succeed())
+		expectInput(target, "input")
+
+		_, err := target.Write([]byte("output"))
+		Expect(err).ToNot(HaveOccurred())
+		expectMessage(remoteClient, "output")
+	})
+
+	It("should reattach a reconnecting client and replay the output it missed", func() {
+		session, target := newSession(nil)
+
+		client, remoteClient := connectClient()
+		Expect(session.attach(client)).To(BeTrue())
+		go session.pumpClient(client)
+
+		remoteClient.Close()
+		Eventually(isDetached(session), 5*time.Second).Should(BeTrue())
+
+		_, err := target.Write([]byte("missed output"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(store.get(session.token, sessionKey)).To(Equal(session))
+		client, remoteClient = connectClient()
+		Expect(session.attach(client)).To(BeTrue())
+		go session.pumpClient(client)
+
+		expectMessage(remoteClient, "missed output")
+		Expect(remoteClient.WriteMessage(websocket.BinaryMessage, []byte("input"))).To(Succeed())
+		expectInput(target, "input")
+	})
+
+	It("should close the session when the client does not come back within the grace period", func() {
+		session, target := newSession(nil)
+
+		client, remoteClient := connectClient()
+		Expect(session.attach(client)).To(BeTrue())
+		go session.pumpClient(client)
+		remoteClient.Close()
+
+		Eventually(func() *streamSession {
+			return store.get(session.token, sessionKey)
+		}, 5*time.Second).Should(BeNil())
+		Eventually(cleanups).Should(Receive())
+
+		target.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, err := target.Read(make([]byte, 1))
+		Expect(err).To(HaveOccurred())
+
+		client, _ = connectClient()
+		Expect(session.attach(client)).To(BeFalse())
+	})
+
+	It("should close the session when its first client never attaches", func() {
+		session, _ := newSession(nil)
+
+		Eventually(func() *streamSession {
+			return store.get(session.token, sessionKey)
+		}, 5*time.Second).Should(BeNil())
+	})
+
+	It("should close the session when the client closes the connection normally", func() {
+		session, _ := newSession(nil)
+
+		client, remoteClient := connectClient()
+		Expect(session.attach(client)).To(BeTrue())
+		go session.pumpClient(client)
+
+		Expect(remoteClient.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))).To(Succeed())
+		Eventually(cleanups).Should(Receive())
+		Expect(store.get(session.token, sessionKey)).To(BeNil())
+	})
+
+	It("should close the session when a new stream stops it", func() {
+		stopCh := make(chan struct{})
+		session, _ := newSession(stopCh)
+
+		close(stopCh)
+		Eventually(cleanups).Should(Receive())
+		Expect(store.get(session.token, sessionKey)).To(BeNil())
+	})
+
+	It("should tell the client that the stream ended", func() {
+		session, target := newSession(nil)
+
+		client, remoteClient := connectClient()
+		Expect(session.attach(client)).To(BeTrue())
+		go session.pumpClient(client)
+
+		target.Close()
+		remoteClient.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, _, err := remoteClient.ReadMessage()
+		Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+	})
+
+	It("should only resume sessions of the same stream", func() {
+		session, _ := newSession(nil)
+
+		Expect(store.get(session.token, sessionKey)).To(Equal(session))
+		Expect(store.get(session.token, strings.Replace(sessionKey, "virt-serial0", "virt-vnc", 1))).To(BeNil())
+		Expect(store.get("unknown", sessionKey)).To(BeNil())
+	})
+
+	It("should keep only the most recent output of a detached session", func() {
+		session := &streamSession{}
+		chunk := make([]byte, maxDetachedBufferSize/2)
+		for i := 0; i < 3; i++ {
+			session.bufferLocked(chunk)
+		}
+		Expect(session.buffer).To(HaveLen(2))
+		Expect(session.bufferSize).To(Equal(maxDetachedBufferSize))
+	})
+})
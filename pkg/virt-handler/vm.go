@@ -33,12 +33,15 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -48,10 +51,12 @@ import (
 
 	netcache "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache"
 
+	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/migrations"
 
 	container_disk "kubevirt.io/kubevirt/pkg/virt-handler/container-disk"
 	device_manager "kubevirt.io/kubevirt/pkg/virt-handler/device-manager"
+	ephemeral_backing "kubevirt.io/kubevirt/pkg/virt-handler/ephemeral-backing"
 	hotplug_volume "kubevirt.io/kubevirt/pkg/virt-handler/hotplug-disk"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -68,12 +73,35 @@ import (
 	virtcache "kubevirt.io/kubevirt/pkg/virt-handler/cache"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+	"kubevirt.io/kubevirt/pkg/virt-handler/ksm"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/watchdog"
 )
 
+var (
+	guestPanicsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_vmi_guest_panics_total",
+			Help: "The number of guest kernel panics reported by the VMI.",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(guestPanicsTotal)
+}
+
+const (
+	// The overcommitted memory of the VMIs is reclaimed below this percentage of available memory on the node
+	memoryReclaimThresholdPercent = 10
+	// The reclaimed memory is released again above this percentage of available memory on the node
+	memoryReleaseThresholdPercent = 20
+)
+
 type launcherClientInfo struct {
 	client              cmdclient.LauncherClient
 	socketFile          string
@@ -118,11 +146,13 @@ func NewController(
 		watchdogTimeoutSeconds:      watchdogTimeoutSeconds,
 		migrationProxy:              migrationproxy.NewMigrationProxyManager(serverTLSConfig, clientTLSConfig),
 		podIsolationDetector:        podIsolationDetector,
-		containerDiskMounter:        container_disk.NewMounter(podIsolationDetector, virtPrivateDir+"/container-disk-mount-state"),
+		containerDiskMounter:        container_disk.NewMounter(podIsolationDetector, virtPrivateDir+"/container-disk-mount-state", clusterConfig),
 		hotplugVolumeMounter:        hotplug_volume.NewVolumeMounter(podIsolationDetector, virtPrivateDir+"/hotplug-volume-mount-state"),
 		clusterConfig:               clusterConfig,
 		networkCacheStoreFactory:    netcache.NewInterfaceCacheFactory(),
 		virtLauncherFSRunDirPattern: "/proc/%d/root/var/run",
+		ksmHandler:                  ksm.NewHandler(),
+		ephemeralBackingHandler:     ephemeral_backing.NewHandler(),
 	}
 
 	vmiSourceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -152,10 +182,11 @@ func NewController(
 	c.launcherClients = make(map[types.UID]*launcherClientInfo)
 	c.phase1NetworkSetupCache = make(map[types.UID]int)
 	c.podInterfaceCache = make(map[string]*netcache.PodCacheInterface)
+	c.memoryReclaimed = make(map[types.UID]bool)
 
 	c.domainNotifyPipes = make(map[string]string)
 
-	c.deviceManagerController = device_manager.NewDeviceController(c.host, maxDevices, clusterConfig)
+	c.deviceManagerController = device_manager.NewDeviceController(c.host, maxDevices, clusterConfig, c.nodeLabels)
 
 	return c
 }
@@ -198,6 +229,12 @@ type VirtualMachineController struct {
 	domainNotifyPipes           map[string]string
 	networkCacheStoreFactory    netcache.InterfaceCacheFactory
 	virtLauncherFSRunDirPattern string
+	ksmHandler                  *ksm.Handler
+	ephemeralBackingHandler     *ephemeral_backing.Handler
+
+	// memoryReclaimed records the VMIs whose memory balloon was inflated to their memory request.
+	// It is only accessed by the heartbeat.
+	memoryReclaimed map[types.UID]bool
 }
 
 type virtLauncherCriticalNetworkError struct {
@@ -212,6 +249,18 @@ type virtLauncherCriticalSecurebootError struct {
 
 func (e *virtLauncherCriticalSecurebootError) Error() string { return e.msg }
 
+type cpuHotplugError struct {
+	msg string
+}
+
+func (e *cpuHotplugError) Error() string { return e.msg }
+
+type memoryHotplugError struct {
+	msg string
+}
+
+func (e *memoryHotplugError) Error() string { return e.msg }
+
 func handleDomainNotifyPipe(domainPipeStopChan chan struct{}, ln net.Listener, virtShareDir string, vmi *v1.VirtualMachineInstance) {
 
 	fdChan := make(chan net.Conn, 100)
@@ -460,6 +509,80 @@ func (d *VirtualMachineController) setPodNetworkPhase1(vmi *v1.VirtualMachineIns
 	return false, nil
 }
 
+// setupHotplugPodNetworks runs phase1 for interfaces which were hotplugged to a running VMI
+// and are not yet reported in its status. Interfaces already configured are skipped by phase1
+// based on their cached configuration.
+func (d *VirtualMachineController) setupHotplugPodNetworks(vmi *v1.VirtualMachineInstance) error {
+	if !d.clusterConfig.HotplugNetworkInterfacesEnabled() || !hasPendingHotplugInterfaces(vmi) {
+		return nil
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf("failed to detect isolation for launcher pod: %v", err)
+	}
+	pid := res.Pid()
+	return res.DoNetNS(func() error { return network.SetupPodNetworkPhase1(vmi, pid, d.networkCacheStoreFactory) })
+}
+
+// updateInterfaceUnplugStatus reports the interfaces which were hot unplugged from the VMI,
+// but are still attached to the domain, because the guest did not release them yet.
+func updateInterfaceUnplugStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+
+	absentInterfaces := map[string]struct{}{}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.State == v1.InterfaceStateAbsent {
+			absentInterfaces[iface.Name] = struct{}{}
+		}
+	}
+	var pending []string
+	for _, iface := range domain.Spec.Devices.Interfaces {
+		if iface.Alias == nil {
+			continue
+		}
+		if _, absent := absentInterfaces[iface.Alias.GetName()]; absent {
+			pending = append(pending, iface.Alias.GetName())
+		}
+	}
+
+	if len(pending) == 0 {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceInterfaceUnplugPending)
+		return
+	}
+
+	message := fmt.Sprintf("The guest did not release the unplugged interfaces %s yet", strings.Join(pending, ", "))
+	if cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceInterfaceUnplugPending); cond != nil && cond.Message == message {
+		return
+	}
+	condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceInterfaceUnplugPending)
+	now := metav1.NewTime(time.Now())
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		Type:               v1.VirtualMachineInstanceInterfaceUnplugPending,
+		Status:             k8sv1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             v1.VirtualMachineInstanceReasonGuestInterfaceUnplugPending,
+		Message:            message,
+	})
+}
+
+func hasPendingHotplugInterfaces(vmi *v1.VirtualMachineInstance) bool {
+	statusInterfaces := map[string]struct{}{}
+	for _, iface := range vmi.Status.Interfaces {
+		statusInterfaces[iface.Name] = struct{}{}
+	}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.SRIOV != nil || iface.State == v1.InterfaceStateAbsent {
+			continue
+		}
+		if _, exists := statusInterfaces[iface.Name]; !exists {
+			return true
+		}
+	}
+	return false
+}
+
 func domainMigrated(domain *api.Domain) bool {
 	if domain != nil && domain.Status.Status == api.Shutoff && domain.Status.Reason == api.ReasonMigrated {
 		return true
@@ -534,8 +657,24 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 
 		if len(vmi.Status.VolumeStatus) > 0 {
 			diskDeviceMap := make(map[string]string)
+			diskIOTuneMap := make(map[string]*api.IOTune)
 			for _, disk := range domain.Spec.Devices.Disks {
 				diskDeviceMap[disk.Alias.GetName()] = disk.Target.Device
+				if disk.IOTune != nil {
+					diskIOTuneMap[disk.Alias.GetName()] = disk.IOTune
+				}
+			}
+			diskSizeMap := make(map[string]int64)
+			for _, diskSize := range domain.Spec.Metadata.KubeVirt.DiskSizes {
+				diskSizeMap[diskSize.Name] = diskSize.Size
+			}
+			multipathMap := make(map[string]api.MultipathMetadata)
+			for _, multipath := range domain.Spec.Metadata.KubeVirt.Multipath {
+				multipathMap[multipath.Name] = multipath
+			}
+			externalSnapshotMap := make(map[string]api.ExternalSnapshotMetadata)
+			for _, externalSnapshot := range domain.Spec.Metadata.KubeVirt.ExternalSnapshots {
+				externalSnapshotMap[externalSnapshot.Name] = externalSnapshot
 			}
 			specVolumeMap := make(map[string]v1.Volume)
 			for _, volume := range vmi.Spec.Volumes {
@@ -547,6 +686,28 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 				if _, ok := diskDeviceMap[volumeStatus.Name]; ok {
 					volumeStatus.Target = diskDeviceMap[volumeStatus.Name]
 				}
+				if size, ok := diskSizeMap[volumeStatus.Name]; ok {
+					volumeStatus.Size = size
+				}
+				volumeStatus.IOTune = nil
+				if ioTune, ok := diskIOTuneMap[volumeStatus.Name]; ok {
+					volumeStatus.IOTune = converter.Convert_api_IOTune_To_v1_DiskIOTune(ioTune)
+				}
+				volumeStatus.Multipath = nil
+				if multipath, ok := multipathMap[volumeStatus.Name]; ok {
+					volumeStatus.Multipath = &v1.VolumeMultipathStatus{
+						Paths:       multipath.Paths,
+						ActivePaths: multipath.ActivePaths,
+						FailedPaths: multipath.FailedPaths,
+					}
+				}
+				volumeStatus.ExternalSnapshot = nil
+				if externalSnapshot, ok := externalSnapshotMap[volumeStatus.Name]; ok {
+					volumeStatus.ExternalSnapshot = &v1.ExternalSnapshotVolumeStatus{
+						ActiveFile:   externalSnapshot.ActiveFile,
+						BackingChain: externalSnapshot.BackingChain,
+					}
+				}
 				if volumeStatus.HotplugVolume != nil {
 					hasHotplug = true
 					if volumeStatus.Target == "" {
@@ -586,6 +747,48 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 			vmi.Status.VolumeStatus = newStatuses
 		}
 
+		if backup := domain.Spec.Metadata.KubeVirt.Backup; backup != nil {
+			vmi.Status.Backup = &v1.VirtualMachineInstanceBackupStatus{
+				Checkpoints: backup.Checkpoints,
+				Checkpoint:  backup.Checkpoint,
+				Incremental: backup.Incremental,
+				Disks:       backup.Disks,
+			}
+		}
+
+		if memoryDump := domain.Spec.Metadata.KubeVirt.MemoryDump; memoryDump != nil {
+			memoryDumpStatus := &v1.VirtualMachineInstanceMemoryDumpStatus{
+				FileName:       memoryDump.FileName,
+				Phase:          v1.MemoryDumpInProgress,
+				StartTimestamp: memoryDump.StartTimestamp,
+				EndTimestamp:   memoryDump.EndTimestamp,
+			}
+			if memoryDump.Completed {
+				memoryDumpStatus.Phase = v1.MemoryDumpCompleted
+			} else if memoryDump.Failed {
+				memoryDumpStatus.Phase = v1.MemoryDumpFailed
+				memoryDumpStatus.Message = memoryDump.FailureReason
+			}
+			vmi.Status.MemoryDump = memoryDumpStatus
+		}
+
+		if blockCommit := domain.Spec.Metadata.KubeVirt.BlockCommit; blockCommit != nil {
+			blockCommitStatus := &v1.VirtualMachineInstanceBlockCommitStatus{
+				Volumes:        blockCommit.Volumes,
+				Phase:          v1.BlockCommitInProgress,
+				Progress:       blockCommit.Progress,
+				StartTimestamp: blockCommit.StartTimestamp,
+				EndTimestamp:   blockCommit.EndTimestamp,
+			}
+			if blockCommit.Completed {
+				blockCommitStatus.Phase = v1.BlockCommitCompleted
+			} else if blockCommit.Failed {
+				blockCommitStatus.Phase = v1.BlockCommitFailed
+				blockCommitStatus.Message = blockCommit.FailureReason
+			}
+			vmi.Status.BlockCommit = blockCommitStatus
+		}
+
 		if len(vmi.Status.Interfaces) == 0 {
 			// Set Pod Interface
 			interfaces := make([]v1.VirtualMachineInstanceNetworkInterface, 0)
@@ -622,10 +825,7 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 				}
 			}
 
-			domainInterfaceStatusByMac := map[string]api.InterfaceStatus{}
-			for _, domainInterfaceStatus := range domain.Status.Interfaces {
-				domainInterfaceStatusByMac[domainInterfaceStatus.Mac] = domainInterfaceStatus
-			}
+			domainInterfaceStatusByMac := mapInterfaceStatusesByMac(domain.Status.Interfaces)
 
 			existingInterfacesSpecByName := map[string]v1.Interface{}
 			for _, existingInterfaceSpec := range vmi.Spec.Domain.Devices.Interfaces {
@@ -673,9 +873,12 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 					}
 				}
 
+				newInterface.Bandwidth = converter.InterfaceBandwidthFromDomain(domainInterface.BandWidth)
+				newInterface.QueueCount = converter.InterfaceQueueCountFromDomain(domainInterface.Driver)
+
 				// Update IP info based on information from domain.Status.Interfaces (Qemu guest)
 				// Remove the interface from domainInterfaceStatusByMac to mark it as handled
-				if interfaceStatus, exists := domainInterfaceStatusByMac[interfaceMAC]; exists {
+				if interfaceStatus, exists := domainInterfaceStatusByMac[normalizeMac(interfaceMAC)]; exists {
 					newInterface.InterfaceName = interfaceStatus.InterfaceName
 					// Do not update if interface has Masquerede binding
 					// virt-controller should update VMI status interface with Pod IP instead
@@ -683,7 +886,7 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 						newInterface.IP = interfaceStatus.Ip
 						newInterface.IPs = interfaceStatus.IPs
 					}
-					delete(domainInterfaceStatusByMac, interfaceMAC)
+					delete(domainInterfaceStatusByMac, normalizeMac(interfaceMAC))
 				}
 				newInterfaces = append(newInterfaces, newInterface)
 			}
@@ -692,10 +895,10 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 			// interfaces not defined in domain.Spec.Devices.Interfaces (most likely added by user on VM or a SRIOV interface)
 			// Add them to vmi.Status.Interfaces
 			setMissingSRIOVInterfacesNames(existingInterfacesSpecByName, domainInterfaceStatusByMac)
-			for interfaceMAC, domainInterfaceStatus := range domainInterfaceStatusByMac {
+			for _, domainInterfaceStatus := range domainInterfaceStatusByMac {
 				newInterface := v1.VirtualMachineInstanceNetworkInterface{
 					Name:          domainInterfaceStatus.Name,
-					MAC:           interfaceMAC,
+					MAC:           domainInterfaceStatus.Mac,
 					IP:            domainInterfaceStatus.Ip,
 					IPs:           domainInterfaceStatus.IPs,
 					InterfaceName: domainInterfaceStatus.InterfaceName,
@@ -935,6 +1138,70 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		condManager.RemoveCondition(vmi, v1.VirtualMachineInstancePaused)
 	}
 
+	// Report guest kernel panics
+	if domain != nil && domain.Status.GuestPanicked && !condManager.HasCondition(vmi, v1.VirtualMachineInstanceGuestPanicked) {
+		log.Log.Object(vmi).V(3).Info("Adding guest panicked condition")
+		now := metav1.NewTime(time.Now())
+		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+			Type:               v1.VirtualMachineInstanceGuestPanicked,
+			Status:             k8sv1.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Reason:             "GuestPanicked",
+			Message:            "The guest kernel panicked",
+		})
+		guestPanicsTotal.WithLabelValues(vmi.Namespace, vmi.Name).Inc()
+	}
+
+	// Report the expiry of the watchdog, the action taken by the hypervisor is used as reason
+	if domain != nil && domain.Status.WatchdogAction != "" {
+		cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceWatchdogExpired)
+		if cond == nil || cond.Reason != domain.Status.WatchdogAction {
+			log.Log.Object(vmi).V(3).Info("Adding watchdog expired condition")
+			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceWatchdogExpired)
+			now := metav1.NewTime(time.Now())
+			vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+				Type:               v1.VirtualMachineInstanceWatchdogExpired,
+				Status:             k8sv1.ConditionTrue,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+				Reason:             domain.Status.WatchdogAction,
+				Message:            fmt.Sprintf("The watchdog expired and the hypervisor performed the %s action", domain.Status.WatchdogAction),
+			})
+		}
+	}
+
+	// Report whether the guest filesystems are frozen, as recorded by virt-launcher when freezing and thawing them
+	if domain != nil && domain.Spec.Metadata.KubeVirt.Quiesce != nil {
+		quiesce := domain.Spec.Metadata.KubeVirt.Quiesce
+		status := k8sv1.ConditionFalse
+		if quiesce.Frozen {
+			status = k8sv1.ConditionTrue
+		}
+		cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceFrozen)
+		if cond == nil || cond.Status != status || cond.Reason != quiesce.Reason || cond.Message != quiesce.Message {
+			log.Log.Object(vmi).V(3).Infof("Updating frozen condition, reason %s", quiesce.Reason)
+			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceFrozen)
+			now := metav1.NewTime(time.Now())
+			transitionTime := now
+			if quiesce.Timestamp != nil {
+				transitionTime = *quiesce.Timestamp
+			}
+			vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+				Type:               v1.VirtualMachineInstanceFrozen,
+				Status:             status,
+				LastProbeTime:      now,
+				LastTransitionTime: transitionTime,
+				Reason:             quiesce.Reason,
+				Message:            quiesce.Message,
+			})
+		}
+	}
+
+	if domain != nil {
+		updateInterfaceUnplugStatus(vmi, domain)
+	}
+
 	if _, ok := syncError.(*virtLauncherCriticalNetworkError); ok {
 		log.Log.Errorf("virt-launcher crashed due to a network error. Updating VMI %s status to Failed", vmi.Name)
 		vmi.Status.Phase = v1.Failed
@@ -943,6 +1210,35 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		log.Log.Errorf("virt-launcher does not support the Secure Boot setting. Updating VMI %s status to Failed", vmi.Name)
 		vmi.Status.Phase = v1.Failed
 	}
+	if domain != nil {
+		d.updateCPUHotplugStatus(vmi, domain)
+		d.updateMemoryHotplugStatus(vmi, domain)
+	}
+
+	if hotplugErr, ok := syncError.(*cpuHotplugError); ok {
+		cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceVCPUChange)
+		if cond != nil && cond.Status == k8sv1.ConditionTrue {
+			cond.Status = k8sv1.ConditionFalse
+			cond.Reason = v1.VirtualMachineInstanceReasonCPUHotplugFailed
+			cond.Message = hotplugErr.Error()
+			cond.LastTransitionTime = metav1.Now()
+			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceVCPUChange)
+			vmi.Status.Conditions = append(vmi.Status.Conditions, *cond)
+		}
+		syncError = nil
+	}
+	if hotplugErr, ok := syncError.(*memoryHotplugError); ok {
+		cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceMemoryChange)
+		if cond != nil && cond.Status == k8sv1.ConditionTrue {
+			cond.Status = k8sv1.ConditionFalse
+			cond.Reason = v1.VirtualMachineInstanceReasonMemoryHotplugFailed
+			cond.Message = hotplugErr.Error()
+			cond.LastTransitionTime = metav1.Now()
+			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceMemoryChange)
+			vmi.Status.Conditions = append(vmi.Status.Conditions, *cond)
+		}
+		syncError = nil
+	}
 	condManager.CheckFailure(vmi, syncError, "Synchronizing with the Domain failed.")
 
 	if !reflect.DeepEqual(oldStatus, vmi.Status) {
@@ -997,6 +1293,24 @@ func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.Virtu
 		}
 		return &liveMigrationCondition, isBlockMigration
 	}
+	if virtutil.IsSEVVMI(vmi) || virtutil.IsSEVSNPVMI(vmi) {
+		liveMigrationCondition = v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstanceIsMigratable,
+			Status:  k8sv1.ConditionFalse,
+			Message: "VMI uses SEV",
+			Reason:  v1.VirtualMachineInstanceReasonSEVNotMigratable,
+		}
+		return &liveMigrationCondition, isBlockMigration
+	}
+	if virtutil.IsTDXVMI(vmi) {
+		liveMigrationCondition = v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstanceIsMigratable,
+			Status:  k8sv1.ConditionFalse,
+			Message: "VMI uses TDX",
+			Reason:  v1.VirtualMachineInstanceReasonTDXNotMigratable,
+		}
+		return &liveMigrationCondition, isBlockMigration
+	}
 	return &liveMigrationCondition, isBlockMigration
 }
 
@@ -1576,6 +1890,9 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 	if err := d.hotplugVolumeMounter.UnmountAll(vmi); err != nil {
 		return err
 	}
+	if err := d.ephemeralBackingHandler.RemoveVMIData(vmi.UID); err != nil {
+		return err
+	}
 
 	d.clearPodNetworkPhase1(vmi)
 
@@ -1900,6 +2217,16 @@ func (d *VirtualMachineController) isPreMigrationTarget(vmi *v1.VirtualMachineIn
 	return false
 }
 
+// replaceMigratedVolumes makes the volumes moved by a migration to this node refer to their destination claims, which
+// are the ones mounted by the target pod
+func (d *VirtualMachineController) replaceMigratedVolumes(vmi *v1.VirtualMachineInstance) {
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil || migrationState.Failed || migrationState.TargetNode != d.host || len(migrationState.MigratedVolumes) == 0 {
+		return
+	}
+	vmi.Spec.Volumes = migrations.ReplaceMigratedVolumes(vmi.Spec.Volumes, migrationState.MigratedVolumes)
+}
+
 func (d *VirtualMachineController) checkNetworkInterfacesForMigration(vmi *v1.VirtualMachineInstance) error {
 	err := validatePodNetworkInterfaceUsesMasqueradeBinding(vmi)
 	if err != nil {
@@ -1911,6 +2238,11 @@ func (d *VirtualMachineController) checkNetworkInterfacesForMigration(vmi *v1.Vi
 		return err
 	}
 
+	err = validateVdpaInterfacesForMigration(vmi)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1948,6 +2280,16 @@ func (d *VirtualMachineController) validateSRIOVInterfacesForMigration(vmi *v1.V
 	return nil
 }
 
+func validateVdpaInterfacesForMigration(vmi *v1.VirtualMachineInstance) error {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Vdpa != nil {
+			return fmt.Errorf("cannot migrate VMI with vDPA interfaces")
+		}
+	}
+
+	return nil
+}
+
 func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachineInstance) (blockMigrate bool, err error) {
 	// Check if all VMI volumes can be shared between the source and the destination
 	// of a live migration. blockMigrate will be returned as false, only if all volumes
@@ -2012,7 +2354,7 @@ func (d *VirtualMachineController) handlePostSyncMigrationProxy(vmi *v1.VirtualM
 	baseDir := fmt.Sprintf(filepath.Join(d.virtLauncherFSRunDirPattern, "kubevirt"), res.Pid())
 	migrationTargetSockets = append(migrationTargetSockets, socketFile)
 
-	isBlockMigration := vmi.Status.MigrationMethod == v1.BlockMigration
+	isBlockMigration := migrations.IsBlockMigration(vmi)
 	migrationPortsRange := migrationproxy.GetMigrationPortsList(isBlockMigration)
 	for _, port := range migrationPortsRange {
 		key := migrationproxy.ConstructProxyKey(string(vmi.UID), port)
@@ -2080,6 +2422,7 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 		return goerror.New(fmt.Sprintf("Can not update a VirtualMachineInstance with unresponsive command server."))
 	}
 
+	d.replaceMigratedVolumes(vmi)
 	err = hostdisk.ReplacePVCByHostDisk(vmi, d.clientset)
 	if err != nil {
 		return err
@@ -2199,6 +2542,9 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 			if err := d.hotplugVolumeMounter.Mount(vmi); err != nil {
 				return err
 			}
+			if err := d.setupHotplugPodNetworks(vmi); err != nil {
+				return fmt.Errorf("failed to configure hotplugged vmi network: %v", err)
+			}
 		}
 
 		smbios := d.clusterConfig.GetSMBIOS()
@@ -2213,6 +2559,7 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 				Version:      smbios.Version,
 			},
 			MemBalloonStatsPeriod: period,
+			ExpandDisksEnabled:    d.clusterConfig.ExpandDisksEnabled(),
 		}
 
 		err = client.SyncVirtualMachine(vmi, options)
@@ -2229,12 +2576,139 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 			if err := d.hotplugVolumeMounter.Unmount(vmi); err != nil {
 				return err
 			}
+			if err := d.hotplugCPUs(vmi, client); err != nil {
+				return err
+			}
+			if err := d.hotplugMemory(vmi, client); err != nil {
+				return err
+			}
 		}
 	}
 
 	return err
 }
 
+// hotplugCPUs asks virt-launcher to plug in the vCPUs requested by the VMI spec
+// while the VMI carries an active vCPU change condition.
+func (d *VirtualMachineController) hotplugCPUs(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) error {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceVCPUChange)
+	if cond == nil || cond.Status != k8sv1.ConditionTrue {
+		return nil
+	}
+
+	if cond.Reason == v1.VirtualMachineInstanceReasonCPUHotplugMigration {
+		// The new topology is applied by the target domain of the migration
+		return nil
+	}
+
+	if err := client.SyncVirtualMachineCPUs(vmi); err != nil {
+		return &cpuHotplugError{fmt.Sprintf("failed to hotplug vCPUs: %v", err)}
+	}
+	d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Created.String(), "vCPUs hotplugged.")
+	return nil
+}
+
+// hotplugMemory asks virt-launcher to plug in the guest memory requested by the
+// VMI spec while the VMI carries an active memory change condition.
+func (d *VirtualMachineController) hotplugMemory(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) error {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceMemoryChange, k8sv1.ConditionTrue) {
+		return nil
+	}
+
+	if err := client.SyncVirtualMachineMemory(vmi); err != nil {
+		return &memoryHotplugError{fmt.Sprintf("failed to hotplug memory: %v", err)}
+	}
+	d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Created.String(), "Memory hotplugged.")
+	return nil
+}
+
+// updateMemoryHotplugStatus reflects the memory plugged into the domain by its
+// virtio-mem device and clears the memory change condition once the guest sees
+// the requested memory.
+func (d *VirtualMachineController) updateMemoryHotplugStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	memoryDevice := domain.Spec.Devices.Memory
+	if memoryDevice == nil || memoryDevice.Target == nil || domain.Spec.CPU.NUMA == nil {
+		return
+	}
+
+	bootMemory, err := domain.Spec.CPU.NUMA.TotalMemory()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to determine the boot memory of the domain")
+		return
+	}
+	requested, err := memoryDevice.Target.Requested.Bytes()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to determine the requested memory of the domain")
+		return
+	}
+	current := requested
+	if memoryDevice.Target.Current != nil {
+		if current, err = memoryDevice.Target.Current.Bytes(); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Failed to determine the current memory of the domain")
+			return
+		}
+	}
+
+	if vmi.Status.Memory == nil {
+		vmi.Status.Memory = &v1.MemoryStatus{}
+	}
+	setMemoryQuantity(&vmi.Status.Memory.GuestAtBoot, bootMemory)
+	setMemoryQuantity(&vmi.Status.Memory.GuestRequested, bootMemory+requested)
+	setMemoryQuantity(&vmi.Status.Memory.GuestCurrent, bootMemory+current)
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceMemoryChange, k8sv1.ConditionTrue) ||
+		vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Guest == nil {
+		return
+	}
+	if vmi.Spec.Domain.Memory.Guest.Value() == vmi.Status.Memory.GuestCurrent.Value() {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceMemoryChange)
+	}
+}
+
+// setMemoryQuantity only replaces the quantity when its value changed, to avoid
+// needless status updates caused by a different quantity format.
+func setMemoryQuantity(quantity **resource.Quantity, bytes uint64) {
+	if *quantity == nil || (*quantity).Value() != int64(bytes) {
+		*quantity = resource.NewQuantity(int64(bytes), resource.BinarySI)
+	}
+}
+
+// updateCPUHotplugStatus reflects the vCPU topology currently plugged into the
+// domain and clears the vCPU change condition once the requested topology is reached.
+func (d *VirtualMachineController) updateCPUHotplugStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain.Spec.CPU.Topology == nil || domain.Spec.VCPU == nil {
+		return
+	}
+
+	topology := domain.Spec.CPU.Topology
+	current := domain.Spec.VCPU.Current
+	if current == 0 {
+		current = domain.Spec.VCPU.CPUs
+	}
+	coresPerSocket := topology.Cores * topology.Threads
+	if coresPerSocket == 0 {
+		return
+	}
+
+	vmi.Status.CurrentCPUTopology = &v1.CPUTopology{
+		Cores:   topology.Cores,
+		Sockets: current / coresPerSocket,
+		Threads: topology.Threads,
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceVCPUChange)
+	if cond == nil || cond.Status != k8sv1.ConditionTrue || vmi.Spec.Domain.CPU == nil {
+		return
+	}
+	if vmi.Spec.Domain.CPU.Sockets == vmi.Status.CurrentCPUTopology.Sockets {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceVCPUChange)
+	}
+}
+
 func (d *VirtualMachineController) setVmPhaseForStatusReason(domain *api.Domain, vmi *v1.VirtualMachineInstance) error {
 	phase, err := d.calculateVmPhaseForStatusReason(domain, vmi)
 	if err != nil {
@@ -2273,6 +2747,10 @@ func (d *VirtualMachineController) calculateVmPhaseForStatusReason(domain *api.D
 
 		switch domain.Status.Status {
 		case api.Shutoff, api.Crashed:
+			// A guest which got stopped by its watchdog did not terminate on its own
+			if isStoppedByWatchdog(domain) {
+				return v1.Failed, nil
+			}
 			switch domain.Status.Reason {
 			case api.ReasonCrashed, api.ReasonPanicked:
 				return v1.Failed, nil
@@ -2297,6 +2775,14 @@ func (d *VirtualMachineController) calculateVmPhaseForStatusReason(domain *api.D
 	return vmi.Status.Phase, nil
 }
 
+func isStoppedByWatchdog(domain *api.Domain) bool {
+	if domain.Status.Reason == api.ReasonMigrated {
+		return false
+	}
+	action := v1.WatchdogAction(domain.Status.WatchdogAction)
+	return action == v1.WatchdogActionPoweroff || action == v1.WatchdogActionShutdown
+}
+
 func (d *VirtualMachineController) addFunc(obj interface{}) {
 	key, err := controller.KeyFunc(obj)
 	if err == nil {
@@ -2396,6 +2882,27 @@ func (d *VirtualMachineController) heartBeat(interval time.Duration, stopCh chan
 			if d.clusterConfig.CPUManagerEnabled() {
 				d.updateNodeCpuManagerLabel(cpuManagerPath)
 			}
+			// Label the node if it supports memory encryption
+			if d.clusterConfig.WorkloadEncryptionSEVEnabled() {
+				d.updateNodeSEVLabels(virtutil.SEVParameterPath, virtutil.SEVESParameterPath, virtutil.SEVSNPParameterPath)
+			}
+			if d.clusterConfig.WorkloadEncryptionTDXEnabled() {
+				d.updateNodeTDXLabel(virtutil.TDXParameterPath)
+			}
+			// Label the node if its kernel allows realtime workloads
+			if d.clusterConfig.VMRealtimeEnabled() {
+				d.updateNodeRealtimeLabel(virtutil.RealtimeRuntimePath)
+			}
+			// Label the node if interface firewalls which deny by default can be enforced on it
+			d.updateNodeConntrackBridgeLabel(virtutil.ConntrackBridgeModulePath)
+			// Enable, tune or disable KSM according to the cluster configuration
+			d.updateNodeKSM()
+			// Mount the ephemeral disk backing of the node according to the cluster configuration
+			d.updateEphemeralDiskBacking()
+			// Reclaim the overcommitted memory of the VMIs while the node runs low on memory
+			if d.clusterConfig.GetMemoryOvercommit() > 100 {
+				d.reclaimOvercommittedMemory()
+			}
 		}, interval, 1.2, true, stopCh)
 	}
 }
@@ -2430,6 +2937,201 @@ func (d *VirtualMachineController) updateNodeCpuManagerLabel(cpuManagerPath stri
 
 }
 
+func (d *VirtualMachineController) updateNodeSEVLabels(sevPath string, sevESPath string, sevSNPPath string) {
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%t", "%s": "%t", "%s": "%t"}}}`,
+		v1.SEVLabel, isKVMParameterEnabled(sevPath), v1.SEVESLabel, isKVMParameterEnabled(sevESPath),
+		v1.SEVSNPLabel, isKVMParameterEnabled(sevSNPPath)))
+	_, err := d.clientset.CoreV1().Nodes().Patch(context.Background(), d.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the SEV labels on host %s", d.host)
+	}
+}
+
+func (d *VirtualMachineController) updateNodeTDXLabel(tdxPath string) {
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%t"}}}`, v1.TDXLabel, isKVMParameterEnabled(tdxPath)))
+	_, err := d.clientset.CoreV1().Nodes().Patch(context.Background(), d.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the TDX label on host %s", d.host)
+	}
+}
+
+func (d *VirtualMachineController) updateNodeRealtimeLabel(rtRuntimePath string) {
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%t"}}}`, v1.RealtimeLabel, isRealtimeUnthrottled(rtRuntimePath)))
+	_, err := d.clientset.CoreV1().Nodes().Patch(context.Background(), d.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the realtime label on host %s", d.host)
+	}
+}
+
+func (d *VirtualMachineController) updateNodeConntrackBridgeLabel(modulePath string) {
+	_, err := os.Stat(modulePath)
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%t"}}}`, v1.ConntrackBridgeLabel, err == nil))
+	_, err = d.clientset.CoreV1().Nodes().Patch(context.Background(), d.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the nf_conntrack_bridge label on host %s", d.host)
+	}
+}
+
+func (d *VirtualMachineController) nodeLabels() (map[string]string, error) {
+	node, err := d.clientset.CoreV1().Nodes().Get(context.Background(), d.host, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return node.Labels, nil
+}
+
+func (d *VirtualMachineController) updateEphemeralDiskBacking() {
+	backings := d.clusterConfig.GetEphemeralDiskBackings()
+	nodeLabels, err := d.nodeLabels()
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to get host %s to mount the ephemeral disk backing", d.host)
+		return
+	}
+	if err := d.ephemeralBackingHandler.Reconcile(nodeLabels, backings); err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to mount the ephemeral disk backing on host %s", d.host)
+	}
+	// The pods storing their ephemeral disk data on the node are only scheduled once the backing is mounted
+	mounted, err := d.ephemeralBackingHandler.Mounted()
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to check the ephemeral disk backing on host %s", d.host)
+		return
+	}
+	if nodeLabels[v1.EphemeralDiskBackingLabel] == strconv.FormatBool(mounted) {
+		return
+	}
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%t"}}}`, v1.EphemeralDiskBackingLabel, mounted))
+	_, err = d.clientset.CoreV1().Nodes().Patch(context.Background(), d.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the ephemeral disk backing label on host %s", d.host)
+	}
+}
+
+func (d *VirtualMachineController) updateNodeKSM() {
+	node, err := d.clientset.CoreV1().Nodes().Get(context.Background(), d.host, metav1.GetOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to get host %s to manage KSM", d.host)
+		return
+	}
+	enabled, managed, err := d.ksmHandler.Reconcile(node, d.clusterConfig.GetKSMConfiguration())
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to manage KSM on host %s", d.host)
+		return
+	}
+	if node.Labels[v1.KSMEnabledLabel] == strconv.FormatBool(enabled) &&
+		node.Annotations[v1.KSMHandlerManagedAnnotation] == strconv.FormatBool(managed) {
+		return
+	}
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%t"}, "annotations": {"%s": "%t"}}}`,
+		v1.KSMEnabledLabel, enabled, v1.KSMHandlerManagedAnnotation, managed))
+	_, err = d.clientset.CoreV1().Nodes().Patch(context.Background(), d.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the KSM label on host %s", d.host)
+	}
+}
+
+// overcommittedMemory returns the guest memory and the memory request of the VMI in bytes,
+// and whether the guest memory exceeds the request and can be reclaimed by the memory balloon
+func overcommittedMemory(vmi *v1.VirtualMachineInstance) (int64, int64, bool) {
+	request := vmi.Spec.Domain.Resources.Requests.Memory().Value()
+	guest := request
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		guest = vmi.Spec.Domain.Memory.Guest.Value()
+	}
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Hugepages != nil {
+		return guest, request, false
+	}
+	if balloon := vmi.Spec.Domain.Devices.AutoattachMemBalloon; balloon != nil && !*balloon {
+		return guest, request, false
+	}
+	return guest, request, request > 0 && guest > request
+}
+
+// reclaimOvercommittedMemory inflates the memory balloons of the overcommitted VMIs down to their
+// memory request once the available memory of the node drops below memoryReclaimThresholdPercent,
+// and deflates them again once it is back above memoryReleaseThresholdPercent.
+func (d *VirtualMachineController) reclaimOvercommittedMemory() {
+	total, available, err := hardware.GetMemInfo()
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to read the memory information of host %s", d.host)
+		return
+	}
+
+	reclaim := false
+	if available*100 < total*memoryReclaimThresholdPercent {
+		reclaim = true
+	} else if available*100 <= total*memoryReleaseThresholdPercent {
+		// Keep the balloons as they are until enough memory is available again
+		return
+	}
+
+	running := map[types.UID]bool{}
+	for _, obj := range d.vmiSourceInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if vmi.Status.NodeName != d.host || !vmi.IsRunning() {
+			continue
+		}
+		running[vmi.UID] = true
+
+		guest, request, overcommitted := overcommittedMemory(vmi)
+		if !overcommitted || d.memoryReclaimed[vmi.UID] == reclaim {
+			continue
+		}
+		target := guest
+		if reclaim {
+			target = request
+		}
+
+		client, err := d.getVerifiedLauncherClient(vmi)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Error("failed to get the launcher client to set the memory balloon")
+			continue
+		}
+		if err := client.SetVirtualMachineMemoryBalloon(vmi, uint64(target)); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("failed to set the memory balloon")
+			continue
+		}
+
+		targetQuantity := resource.NewQuantity(target, resource.BinarySI)
+		if reclaim {
+			d.memoryReclaimed[vmi.UID] = true
+			d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.MemoryReclaimed.String(),
+				fmt.Sprintf("Host %s is low on memory, reclaimed the guest memory down to %s", d.host, targetQuantity.String()))
+		} else {
+			delete(d.memoryReclaimed, vmi.UID)
+			d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.MemoryReleased.String(),
+				fmt.Sprintf("Released the guest memory back to %s", targetQuantity.String()))
+		}
+	}
+
+	for uid := range d.memoryReclaimed {
+		if !running[uid] {
+			delete(d.memoryReclaimed, uid)
+		}
+	}
+}
+
+// isRealtimeUnthrottled returns true if the kernel lets realtime scheduled processes use all the CPU time,
+// which is required to run the vCPUs of realtime VMIs with FIFO scheduling
+func isRealtimeUnthrottled(rtRuntimePath string) bool {
+	// #nosec No risk for path injection. rtRuntimePath is composed of static values from pkg/util
+	content, err := ioutil.ReadFile(rtRuntimePath)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(content)) == "-1"
+}
+
+// isKVMParameterEnabled returns true if the boolean kvm module parameter at path is enabled
+func isKVMParameterEnabled(path string) bool {
+	// #nosec No risk for path injection. path is composed of static values from pkg/util
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	value := strings.TrimSpace(string(content))
+	return value == "1" || value == "Y"
+}
+
 func (d *VirtualMachineController) setVMIGuestTime(vmi *v1.VirtualMachineInstance) error {
 	// update the vmi guest with the current time
 	client, err := d.getVerifiedLauncherClient(vmi)
@@ -2457,9 +3159,33 @@ func setMissingSRIOVInterfacesNames(interfacesSpecByName map[string]v1.Interface
 		if ifaceSpec.SRIOV == nil || ifaceSpec.MacAddress == "" {
 			continue
 		}
-		if domainIfaceStatus, exists := interfacesStatusByMac[ifaceSpec.MacAddress]; exists {
+		mac := normalizeMac(ifaceSpec.MacAddress)
+		if domainIfaceStatus, exists := interfacesStatusByMac[mac]; exists {
 			domainIfaceStatus.Name = name
-			interfacesStatusByMac[ifaceSpec.MacAddress] = domainIfaceStatus
+			interfacesStatusByMac[mac] = domainIfaceStatus
 		}
 	}
 }
+
+// mapInterfaceStatusesByMac maps the interfaces reported by the guest agent by their normalized MAC address.
+// Several guest interfaces may share a MAC address, e.g. VLAN interfaces and their parent, in which case
+// the first one with an IP address is kept.
+func mapInterfaceStatusesByMac(interfaceStatuses []api.InterfaceStatus) map[string]api.InterfaceStatus {
+	interfaceStatusByMac := map[string]api.InterfaceStatus{}
+	for _, interfaceStatus := range interfaceStatuses {
+		mac := normalizeMac(interfaceStatus.Mac)
+		if existing, exists := interfaceStatusByMac[mac]; exists && (len(existing.IPs) > 0 || len(interfaceStatus.IPs) == 0) {
+			continue
+		}
+		interfaceStatusByMac[mac] = interfaceStatus
+	}
+	return interfaceStatusByMac
+}
+
+// normalizeMac makes MAC addresses comparable, regardless of the case and separators they were written with
+func normalizeMac(mac string) string {
+	if hwAddr, err := net.ParseMAC(mac); err == nil {
+		return hwAddr.String()
+	}
+	return strings.ToLower(mac)
+}
@@ -840,6 +840,164 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		Context("with a vCPU change", func() {
+			var vmi *v1.VirtualMachineInstance
+			var domain *api.Domain
+
+			BeforeEach(func() {
+				vmi = v1.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.ObjectMeta.ResourceVersion = "1"
+				vmi.Status.Phase = v1.Running
+				vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2, Cores: 1, Threads: 1, MaxSockets: 4}
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstanceVCPUChange,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+				vmi = addActivePods(vmi, podTestUUID, host)
+
+				mockWatchdog.CreateFile(vmi)
+
+				domain = api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+				domain.Spec.CPU.Topology = &api.CPUTopology{Sockets: 4, Cores: 1, Threads: 1}
+				domain.Spec.VCPU = &api.VCPU{Placement: "static", CPUs: 4, Current: 1}
+			})
+
+			It("should hotplug the requested vCPUs and report the plugged topology", func() {
+				domain.Spec.VCPU.Current = 2
+
+				vmiFeeder.Add(vmi)
+				domainFeeder.Add(domain)
+
+				client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+				client.EXPECT().SyncVirtualMachineCPUs(vmi).Return(nil)
+				mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+				mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+					Expect(vmi.Status.CurrentCPUTopology).To(Equal(&v1.CPUTopology{Sockets: 2, Cores: 1, Threads: 1}))
+					for _, cond := range vmi.Status.Conditions {
+						Expect(cond.Type).ToNot(Equal(v1.VirtualMachineInstanceVCPUChange))
+					}
+				})
+
+				controller.Execute()
+			})
+
+			It("should mark the vCPU change as failed when the hotplug fails", func() {
+				vmiFeeder.Add(vmi)
+				domainFeeder.Add(domain)
+
+				client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+				client.EXPECT().SyncVirtualMachineCPUs(vmi).Return(fmt.Errorf("unsupported"))
+				mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+				mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+					Expect(vmi.Status.CurrentCPUTopology).To(Equal(&v1.CPUTopology{Sockets: 1, Cores: 1, Threads: 1}))
+					var cond *v1.VirtualMachineInstanceCondition
+					for i := range vmi.Status.Conditions {
+						if vmi.Status.Conditions[i].Type == v1.VirtualMachineInstanceVCPUChange {
+							cond = &vmi.Status.Conditions[i]
+						}
+					}
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+					Expect(cond.Reason).To(Equal(v1.VirtualMachineInstanceReasonCPUHotplugFailed))
+				})
+
+				controller.Execute()
+			})
+		})
+
+		Context("with a memory change", func() {
+			var vmi *v1.VirtualMachineInstance
+			var domain *api.Domain
+
+			BeforeEach(func() {
+				vmi = v1.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.ObjectMeta.ResourceVersion = "1"
+				vmi.Status.Phase = v1.Running
+				guest := resource.MustParse("2Gi")
+				maxGuest := resource.MustParse("4Gi")
+				vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstanceMemoryChange,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+				vmi = addActivePods(vmi, podTestUUID, host)
+
+				mockWatchdog.CreateFile(vmi)
+
+				domain = api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+				domain.Spec.CPU.NUMA = &api.NUMA{
+					Cells: []api.NUMACell{{ID: "0", CPUs: "0", Memory: "1048576", Unit: "KiB"}},
+				}
+				domain.Spec.Devices.Memory = &api.MemoryDevice{
+					Model: "virtio-mem",
+					Target: &api.MemoryTarget{
+						Size:      api.Memory{Value: 3145728, Unit: "KiB"},
+						Node:      "0",
+						Block:     api.Memory{Value: 2048, Unit: "KiB"},
+						Requested: api.Memory{Value: 0, Unit: "KiB"},
+						Current:   &api.Memory{Value: 0, Unit: "KiB"},
+					},
+				}
+			})
+
+			It("should hotplug the requested memory and report the plugged memory", func() {
+				domain.Spec.Devices.Memory.Target.Requested.Value = 1048576
+				domain.Spec.Devices.Memory.Target.Current.Value = 1048576
+
+				vmiFeeder.Add(vmi)
+				domainFeeder.Add(domain)
+
+				client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+				client.EXPECT().SyncVirtualMachineMemory(vmi).Return(nil)
+				mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+				mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+					Expect(vmi.Status.Memory.GuestAtBoot.Value()).To(Equal(int64(1024 * 1024 * 1024)))
+					Expect(vmi.Status.Memory.GuestRequested.Value()).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+					Expect(vmi.Status.Memory.GuestCurrent.Value()).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+					for _, cond := range vmi.Status.Conditions {
+						Expect(cond.Type).ToNot(Equal(v1.VirtualMachineInstanceMemoryChange))
+					}
+				})
+
+				controller.Execute()
+			})
+
+			It("should mark the memory change as failed when the hotplug fails", func() {
+				vmiFeeder.Add(vmi)
+				domainFeeder.Add(domain)
+
+				client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+				client.EXPECT().SyncVirtualMachineMemory(vmi).Return(fmt.Errorf("unsupported"))
+				mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+				mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+					Expect(vmi.Status.Memory.GuestCurrent.Value()).To(Equal(int64(1024 * 1024 * 1024)))
+					var cond *v1.VirtualMachineInstanceCondition
+					for i := range vmi.Status.Conditions {
+						if vmi.Status.Conditions[i].Type == v1.VirtualMachineInstanceMemoryChange {
+							cond = &vmi.Status.Conditions[i]
+						}
+					}
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+					Expect(cond.Reason).To(Equal(v1.VirtualMachineInstanceReasonMemoryHotplugFailed))
+				})
+
+				controller.Execute()
+			})
+		})
+
 		It("should add access credential synced condition when credentials report success", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -1036,6 +1194,96 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should add the watchdog expired condition", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Status.WatchdogAction = string(v1.WatchdogActionReset)
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstanceWatchdogExpired,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+
+			controller.Execute()
+		})
+
+		It("should add the guest panicked condition", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Status.GuestPanicked = true
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstanceGuestPanicked,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+
+			controller.Execute()
+		})
+
+		table.DescribeTable("should set the phase of a VMI stopped by its watchdog", func(action v1.WatchdogAction, reason api.StateChangeReason, expectedPhase v1.VirtualMachineInstancePhase) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Shutoff
+			domain.Status.Reason = reason
+			domain.Status.WatchdogAction = string(action)
+
+			phase, err := controller.calculateVmPhaseForStatusReason(domain, vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(phase).To(Equal(expectedPhase))
+		},
+			table.Entry("to Failed on poweroff", v1.WatchdogActionPoweroff, api.ReasonDestroyed, v1.Failed),
+			table.Entry("to Failed on shutdown", v1.WatchdogActionShutdown, api.ReasonShutdown, v1.Failed),
+			table.Entry("to Succeeded on a guest shutdown after a reset", v1.WatchdogActionReset, api.ReasonShutdown, v1.Succeeded),
+		)
+
 		It("should move VirtualMachineInstance from Scheduled to Failed if watchdog file is missing", func() {
 			cmdclient.MarkSocketUnresponsive(sockFile)
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -1885,6 +2133,24 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
 		})
 
+		It("should not be live migratable when SEV is used", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SEV: &v1.SEV{}}
+
+			condition, _ := controller.calculateLiveMigrationCondition(vmi, false)
+			Expect(condition.Status).To(Equal(k8sv1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonSEVNotMigratable))
+		})
+
+		It("should not be live migratable when TDX is used", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+
+			condition, _ := controller.calculateLiveMigrationCondition(vmi, false)
+			Expect(condition.Status).To(Equal(k8sv1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonTDXNotMigratable))
+		})
+
 		Context("with network configuration", func() {
 			It("should block migration for bridge binding assigned to the pod network", func() {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -2403,6 +2669,68 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			controller.Execute()
 		})
+
+		It("should report the guest IPs of the interface with the MAC in another case", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			const sriovInterfaceName = "sriov_network"
+			const MAC = "1C:CE:C0:01:BE:E7"
+			const guestMAC = "1c:ce:c0:01:be:e7"
+
+			vmi.Spec.Networks = []v1.Network{
+				{
+					Name: sriovInterfaceName,
+					NetworkSource: v1.NetworkSource{
+						Multus: &v1.MultusNetwork{
+							NetworkName: sriovInterfaceName,
+						},
+					},
+				},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{
+					Name: sriovInterfaceName,
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{
+						SRIOV: &v1.InterfaceSRIOV{},
+					},
+					MacAddress: MAC,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			// a VLAN interface shares the MAC address of its parent
+			domain.Status.Interfaces = []api.InterfaceStatus{
+				{
+					Mac:           guestMAC,
+					InterfaceName: "eth1",
+				},
+				{
+					Mac:           guestMAC,
+					Ip:            "192.168.1.10",
+					IPs:           []string{"192.168.1.10"},
+					InterfaceName: "eth1.100",
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				interfaces := arg.(*v1.VirtualMachineInstance).Status.Interfaces
+				Expect(interfaces).To(HaveLen(1))
+				Expect(interfaces[0].Name).To(Equal(sriovInterfaceName))
+				Expect(interfaces[0].MAC).To(Equal(guestMAC))
+				Expect(interfaces[0].IP).To(Equal("192.168.1.10"))
+				Expect(interfaces[0].IPs).To(ConsistOf("192.168.1.10"))
+				Expect(interfaces[0].InterfaceName).To(Equal("eth1.100"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
 	})
 
 	Context("VirtualMachineInstance controller gets informed about disk information", func() {
@@ -2495,6 +2823,55 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 	})
+
+	table.DescribeTable("should detect interfaces pending hotplug", func(specInterfaces []v1.Interface, statusInterfaces []v1.VirtualMachineInstanceNetworkInterface, expected bool) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = specInterfaces
+		vmi.Status.Interfaces = statusInterfaces
+		Expect(hasPendingHotplugInterfaces(vmi)).To(Equal(expected))
+	},
+		table.Entry("when all interfaces are reported",
+			[]v1.Interface{{Name: "default"}, {Name: "blue"}},
+			[]v1.VirtualMachineInstanceNetworkInterface{{Name: "default"}, {Name: "blue"}}, false),
+		table.Entry("when an interface is not reported yet",
+			[]v1.Interface{{Name: "default"}, {Name: "blue"}},
+			[]v1.VirtualMachineInstanceNetworkInterface{{Name: "default"}}, true),
+		table.Entry("ignoring SR-IOV interfaces",
+			[]v1.Interface{{Name: "default"}, {Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}}},
+			[]v1.VirtualMachineInstanceNetworkInterface{{Name: "default"}}, false),
+		table.Entry("ignoring unplugged interfaces",
+			[]v1.Interface{{Name: "default"}, {Name: "blue", State: v1.InterfaceStateAbsent}},
+			[]v1.VirtualMachineInstanceNetworkInterface{{Name: "default"}}, false),
+	)
+
+	Context("interface unplug", func() {
+		newVMIAndDomain := func() (*v1.VirtualMachineInstance, *api.Domain) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default"}, {Name: "blue", State: v1.InterfaceStateAbsent}}
+			domain := api.NewMinimalDomain("testvmi")
+			domain.Spec.Devices.Interfaces = []api.Interface{{Alias: api.NewUserDefinedAlias("default")}}
+			return vmi, domain
+		}
+
+		It("should report interfaces the guest did not release yet", func() {
+			vmi, domain := newVMIAndDomain()
+			domain.Spec.Devices.Interfaces = append(domain.Spec.Devices.Interfaces, api.Interface{Alias: api.NewUserDefinedAlias("blue")})
+
+			updateInterfaceUnplugStatus(vmi, domain)
+			Expect(vmi.Status.Conditions).To(HaveLen(1))
+			Expect(vmi.Status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceInterfaceUnplugPending))
+			Expect(vmi.Status.Conditions[0].Reason).To(Equal(v1.VirtualMachineInstanceReasonGuestInterfaceUnplugPending))
+			Expect(vmi.Status.Conditions[0].Message).To(ContainSubstring("blue"))
+		})
+
+		It("should remove the condition once the interfaces are detached", func() {
+			vmi, domain := newVMIAndDomain()
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{Type: v1.VirtualMachineInstanceInterfaceUnplugPending, Status: k8sv1.ConditionTrue}}
+
+			updateInterfaceUnplugStatus(vmi, domain)
+			Expect(vmi.Status.Conditions).To(BeEmpty())
+		})
+	})
 })
 
 var _ = Describe("DomainNotifyServerRestarts", func() {
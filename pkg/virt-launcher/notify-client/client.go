@@ -47,9 +47,10 @@ type Notifier struct {
 }
 
 type libvirtEvent struct {
-	Domain     string
-	Event      *libvirt.DomainEventLifecycle
-	AgentEvent *libvirt.DomainEventAgentLifecycle
+	Domain        string
+	Event         *libvirt.DomainEventLifecycle
+	AgentEvent    *libvirt.DomainEventAgentLifecycle
+	WatchdogEvent *libvirt.DomainEventWatchdog
 }
 
 func NewNotifier(virtShareDir string) *Notifier {
@@ -289,6 +290,32 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 	}
 }
 
+func watchdogActionToString(action libvirt.DomainEventWatchdogAction) string {
+	switch action {
+	case libvirt.DOMAIN_EVENT_WATCHDOG_NONE:
+		return "none"
+	case libvirt.DOMAIN_EVENT_WATCHDOG_PAUSE:
+		return "pause"
+	case libvirt.DOMAIN_EVENT_WATCHDOG_RESET:
+		return string(v1.WatchdogActionReset)
+	case libvirt.DOMAIN_EVENT_WATCHDOG_POWEROFF:
+		return string(v1.WatchdogActionPoweroff)
+	case libvirt.DOMAIN_EVENT_WATCHDOG_SHUTDOWN:
+		return string(v1.WatchdogActionShutdown)
+	case libvirt.DOMAIN_EVENT_WATCHDOG_DEBUG:
+		return "dump"
+	case libvirt.DOMAIN_EVENT_WATCHDOG_INJECTNMI:
+		return "inject-nmi"
+	default:
+		return "unknown"
+	}
+}
+
+func isGuestPanicEvent(event libvirtEvent) bool {
+	return event.Event != nil && event.Event.Event == libvirt.DOMAIN_EVENT_CRASHED &&
+		libvirt.DomainEventCrashedDetailType(event.Event.Detail) == libvirt.DOMAIN_EVENT_CRASHED_PANICKED
+}
+
 var updateEvents = updateEventsClosure()
 
 func updateEventsClosure() func(event watch.Event, domain *api.Domain, events chan watch.Event) {
@@ -341,10 +368,28 @@ func (n *Notifier) StartDomainNotifier(
 	go func() {
 		var interfaceStatuses []api.InterfaceStatus
 		var guestOsInfo *api.GuestOSInfo
+		var watchdogAction string
+		var guestPanicked bool
 		for {
 			select {
 			case event := <-eventChan:
+				if isGuestPanicEvent(event) {
+					guestPanicked = true
+					err := n.SendK8sEvent(vmi, "Warning", "GuestPanicked", "The guest kernel panicked")
+					if err != nil {
+						log.Log.Reason(err).Error("Could not send k8s event")
+					}
+				}
+				if event.WatchdogEvent != nil {
+					watchdogAction = watchdogActionToString(event.WatchdogEvent.Action)
+					err := n.SendK8sEvent(vmi, "Warning", "WatchdogExpired", fmt.Sprintf("The watchdog expired, the hypervisor performed the %s action", watchdogAction))
+					if err != nil {
+						log.Log.Reason(err).Error("Could not send k8s event")
+					}
+				}
 				domainCache = util.NewDomainFromName(event.Domain, vmi.UID)
+				domainCache.Status.WatchdogAction = watchdogAction
+				domainCache.Status.GuestPanicked = guestPanicked
 				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo, vmi)
 				log.Log.Infof("Domain name event: %v", domainCache.Spec.Name)
 				if event.AgentEvent != nil {
@@ -426,6 +471,24 @@ func (n *Notifier) StartDomainNotifier(
 		return err
 	}
 
+	domainEventWatchdogCallback := func(c *libvirt.Connect, d *libvirt.Domain, event *libvirt.DomainEventWatchdog) {
+		log.Log.Infof("Domain Watchdog event with action %d received", event.Action)
+		name, err := d.GetName()
+		if err != nil {
+			log.Log.Reason(err).Info("Could not determine name of libvirt domain in event callback.")
+		}
+		select {
+		case eventChan <- libvirtEvent{WatchdogEvent: event, Domain: name}:
+		default:
+			log.Log.Infof("Libvirt event channel is full, dropping event.")
+		}
+	}
+	err = domainConn.DomainEventWatchdogRegister(domainEventWatchdogCallback)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to register watchdog event callback with libvirt")
+		return err
+	}
+
 	agentEventLifecycleCallback := func(c *libvirt.Connect, d *libvirt.Domain, event *libvirt.DomainEventAgentLifecycle) {
 		log.Log.Infof("GuestAgentLifecycle event state %d with reason %d received", event.State, event.Reason)
 		name, err := d.GetName()
@@ -0,0 +1,217 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package serialconsolelog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// LogFileName is the name of the current serial console log file on the PVC
+	LogFileName = "serial-console.log"
+	// DefaultMaxFileSize is the size after which the log file on the PVC is rotated
+	DefaultMaxFileSize = 10 * 1024 * 1024
+	// DefaultMaxFiles is the number of log files which are kept on the PVC
+	DefaultMaxFiles = 3
+)
+
+// RotatingFile is a writer which appends to the serial console log file in dir.
+// Once the file exceeds maxSize, it is rotated and only the newest maxFiles files are kept.
+type RotatingFile struct {
+	dir      string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+func NewRotatingFile(dir string, maxSize int64, maxFiles int) (*RotatingFile, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid maximum log file size %d", maxSize)
+	}
+	if maxFiles < 1 {
+		return nil, fmt.Errorf("invalid number of log files %d", maxFiles)
+	}
+	r := &RotatingFile{
+		dir:      dir,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) open() error {
+	// Keep appending to the log of previous runs of the VMI
+	file, err := os.OpenFile(filepath.Join(r.dir, LogFileName), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	current := filepath.Join(r.dir, LogFileName)
+	if r.maxFiles == 1 {
+		if err := os.Remove(current); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return r.open()
+	}
+	// serial-console.log.1 is the newest rotated file
+	for i := r.maxFiles - 2; i >= 1; i-- {
+		err := os.Rename(rotatedFileName(current, i), rotatedFileName(current, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(current, rotatedFileName(current, 1)); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *RotatingFile) Close() error {
+	return r.file.Close()
+}
+
+func rotatedFileName(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+// LogFiles returns the serial console log files in dir, from the oldest to the newest one
+func LogFiles(dir string) []string {
+	var files []string
+	current := filepath.Join(dir, LogFileName)
+	for i := 1; ; i++ {
+		if _, err := os.Stat(rotatedFileName(current, i)); err != nil {
+			break
+		}
+		files = append([]string{rotatedFileName(current, i)}, files...)
+	}
+	if _, err := os.Stat(current); err == nil {
+		files = append(files, current)
+	}
+	return files
+}
+
+// LineLogger writes every complete line of the serial console output to the virt-launcher log
+type LineLogger struct {
+	buf []byte
+}
+
+func (l *LineLogger) Write(p []byte) (int, error) {
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		log.LogSerialConsoleLine(log.Log, string(bytes.TrimRight(l.buf[:i], "\r")))
+		l.buf = l.buf[i+1:]
+	}
+}
+
+// Follow copies everything which is written to the file at path to out, until stop is closed.
+// The file does not have to exist yet, and it may be replaced when virtlogd rotates it.
+func Follow(path string, out io.Writer, interval time.Duration, stop <-chan struct{}) {
+	var file *os.File
+	var offset int64
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	for {
+		if file == nil {
+			if f, err := os.Open(path); err == nil {
+				file = f
+				offset = 0
+			}
+		}
+		if file != nil {
+			offset += copyAvailable(file, out)
+			if replaced(path, file, offset) {
+				// Pick up what was written before the file was replaced
+				copyAvailable(file, out)
+				file.Close()
+				file = nil
+				continue
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func copyAvailable(file *os.File, out io.Writer) int64 {
+	n, err := io.Copy(out, file)
+	if err != nil {
+		log.Log.Reason(err).Error("failed to copy the serial console log")
+	}
+	return n
+}
+
+func replaced(path string, file *os.File, offset int64) bool {
+	current, err := os.Stat(path)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	opened, err := file.Stat()
+	if err != nil {
+		return true
+	}
+	return !os.SameFile(current, opened) || current.Size() < offset
+}
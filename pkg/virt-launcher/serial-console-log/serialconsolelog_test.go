@@ -0,0 +1,175 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package serialconsolelog
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+var _ = Describe("Serial console log", func() {
+
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "serial-console-log")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	readFile := func(path string) string {
+		content, err := ioutil.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		return string(content)
+	}
+
+	Context("RotatingFile", func() {
+		It("should append to the log of a previous run", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, LogFileName), []byte("old\n"), 0640)).To(Succeed())
+			r, err := NewRotatingFile(dir, 1024, 3)
+			Expect(err).ToNot(HaveOccurred())
+			defer r.Close()
+
+			_, err = r.Write([]byte("new\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(readFile(filepath.Join(dir, LogFileName))).To(Equal("old\nnew\n"))
+		})
+
+		It("should rotate the log file and keep only the newest files", func() {
+			r, err := NewRotatingFile(dir, 4, 3)
+			Expect(err).ToNot(HaveOccurred())
+			defer r.Close()
+
+			for _, line := range []string{"aaa\n", "bbb\n", "ccc\n", "ddd\n"} {
+				_, err = r.Write([]byte(line))
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			current := filepath.Join(dir, LogFileName)
+			Expect(LogFiles(dir)).To(Equal([]string{current + ".2", current + ".1", current}))
+			Expect(readFile(current + ".2")).To(Equal("bbb\n"))
+			Expect(readFile(current + ".1")).To(Equal("ccc\n"))
+			Expect(readFile(current)).To(Equal("ddd\n"))
+		})
+
+		It("should only keep the current file if a single file is allowed", func() {
+			r, err := NewRotatingFile(dir, 4, 1)
+			Expect(err).ToNot(HaveOccurred())
+			defer r.Close()
+
+			for _, line := range []string{"aaa\n", "bbb\n"} {
+				_, err = r.Write([]byte(line))
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Expect(LogFiles(dir)).To(Equal([]string{filepath.Join(dir, LogFileName)}))
+			Expect(readFile(filepath.Join(dir, LogFileName))).To(Equal("bbb\n"))
+		})
+
+		It("should reject invalid limits", func() {
+			_, err := NewRotatingFile(dir, 0, 3)
+			Expect(err).To(HaveOccurred())
+			_, err = NewRotatingFile(dir, 1024, 0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Follow", func() {
+		var stop chan struct{}
+		var done chan struct{}
+		var out *syncBuffer
+		var path string
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			done = make(chan struct{})
+			out = &syncBuffer{}
+			path = filepath.Join(dir, "virt-serial0-log")
+		})
+
+		AfterEach(func() {
+			close(stop)
+			Eventually(done).Should(BeClosed())
+		})
+
+		follow := func() {
+			go func() {
+				defer close(done)
+				Follow(path, out, 10*time.Millisecond, stop)
+			}()
+		}
+
+		appendToFile := func(content string) {
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+			Expect(err).ToNot(HaveOccurred())
+			defer f.Close()
+			_, err = f.WriteString(content)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("should wait for the file to be created", func() {
+			follow()
+			Consistently(out.String, 50*time.Millisecond).Should(BeEmpty())
+
+			appendToFile("booting\n")
+			Eventually(out.String).Should(Equal("booting\n"))
+			appendToFile("login:")
+			Eventually(out.String).Should(Equal("booting\nlogin:"))
+		})
+
+		It("should follow the file after it was rotated", func() {
+			appendToFile("first\n")
+			follow()
+			Eventually(out.String).Should(Equal("first\n"))
+
+			Expect(os.Rename(path, path+".0")).To(Succeed())
+			appendToFile("second\n")
+			Eventually(out.String).Should(Equal("first\nsecond\n"))
+		})
+	})
+})
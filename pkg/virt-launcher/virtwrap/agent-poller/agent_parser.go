@@ -2,7 +2,10 @@ package agentpoller
 
 import (
 	"encoding/json"
+	"net"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
@@ -62,11 +65,31 @@ type User struct {
 
 // Filesystem of the host
 type Filesystem struct {
-	Name       string `json:"name"`
-	Mountpoint string `json:"mountpoint"`
-	Type       string `json:"type"`
-	UsedBytes  int    `json:"used-bytes,omitempty"`
-	TotalBytes int    `json:"total-bytes,omitempty"`
+	Name       string           `json:"name"`
+	Mountpoint string           `json:"mountpoint"`
+	Type       string           `json:"type"`
+	UsedBytes  int              `json:"used-bytes,omitempty"`
+	TotalBytes int              `json:"total-bytes,omitempty"`
+	Disks      []FilesystemDisk `json:"disk,omitempty"`
+}
+
+// FilesystemDisk is a disk backing a filesystem of the host
+type FilesystemDisk struct {
+	Serial        string     `json:"serial,omitempty"`
+	Dev           string     `json:"dev,omitempty"`
+	BusType       string     `json:"bus-type"`
+	Bus           int        `json:"bus"`
+	Target        int        `json:"target"`
+	Unit          int        `json:"unit"`
+	PCIController PCIAddress `json:"pci-controller"`
+}
+
+// PCIAddress of a disk or its controller
+type PCIAddress struct {
+	Domain   int `json:"domain"`
+	Bus      int `json:"bus"`
+	Slot     int `json:"slot"`
+	Function int `json:"function"`
 }
 
 // AgentInfo from the guest VM serves the purpose
@@ -255,7 +278,7 @@ func convertInterfaceStatusesFromAgentJSON(agentResult []Interface) []api.Interf
 
 		interfaceIP, interfaceIPs := extractIPs(ifc.IPs)
 		interfaceStatuses = append(interfaceStatuses, api.InterfaceStatus{
-			Mac:           ifc.MAC,
+			Mac:           normalizeMAC(ifc.MAC),
 			Ip:            interfaceIP,
 			IPs:           interfaceIPs,
 			InterfaceName: ifc.Name,
@@ -264,6 +287,15 @@ func convertInterfaceStatusesFromAgentJSON(agentResult []Interface) []api.Interf
 	return interfaceStatuses
 }
 
+// normalizeMAC formats the MAC addresses reported by the guest like libvirt does, so they can be matched
+// with the domain interfaces
+func normalizeMAC(mac string) string {
+	if hwAddr, err := net.ParseMAC(mac); err == nil {
+		return hwAddr.String()
+	}
+	return strings.ToLower(mac)
+}
+
 func extractIPs(ipAddresses []IP) (string, []string) {
 	interfaceIPs := []string{}
 	var interfaceIP string
@@ -281,3 +313,79 @@ func extractIPs(ipAddresses []IP) (string, []string) {
 	}
 	return interfaceIP, interfaceIPs
 }
+
+// MountpointsWithoutDisks returns the mountpoints of the filesystems in the 'guest-get-fsinfo' reply
+// which are not backed by any of the given domain disks. Virtio disks are matched by their PCI address,
+// the others by their drive address, and all of them by their serial if it is set.
+func MountpointsWithoutDisks(agentReply string, disks []api.Disk) ([]string, error) {
+	filesystems := []Filesystem{}
+	if err := json.Unmarshal([]byte(stripAgentResponse(agentReply)), &filesystems); err != nil {
+		return nil, err
+	}
+
+	mountpoints := []string{}
+	for _, fs := range filesystems {
+		onDisk := false
+		for _, guestDisk := range fs.Disks {
+			for _, disk := range disks {
+				if isSameDisk(guestDisk, disk) {
+					onDisk = true
+				}
+			}
+		}
+		if !onDisk {
+			mountpoints = append(mountpoints, fs.Mountpoint)
+		}
+	}
+	return mountpoints, nil
+}
+
+// FilesystemsOnDisk returns the filesystems in the 'guest-get-fsinfo' reply which are backed by the given domain disk
+func FilesystemsOnDisk(agentReply string, disk api.Disk) ([]Filesystem, error) {
+	filesystems := []Filesystem{}
+	if err := json.Unmarshal([]byte(stripAgentResponse(agentReply)), &filesystems); err != nil {
+		return nil, err
+	}
+
+	onDisk := []Filesystem{}
+	for _, fs := range filesystems {
+		for _, guestDisk := range fs.Disks {
+			if isSameDisk(guestDisk, disk) {
+				onDisk = append(onDisk, fs)
+				break
+			}
+		}
+	}
+	return onDisk, nil
+}
+
+func isSameDisk(guestDisk FilesystemDisk, disk api.Disk) bool {
+	if disk.Serial != "" && guestDisk.Serial != "" {
+		return disk.Serial == guestDisk.Serial
+	}
+	if disk.Address == nil || guestDisk.BusType != disk.Target.Bus {
+		return false
+	}
+
+	switch disk.Address.Type {
+	case "pci":
+		return addressEquals(disk.Address.Domain, guestDisk.PCIController.Domain) &&
+			addressEquals(disk.Address.Bus, guestDisk.PCIController.Bus) &&
+			addressEquals(disk.Address.Slot, guestDisk.PCIController.Slot) &&
+			addressEquals(disk.Address.Function, guestDisk.PCIController.Function)
+	case "drive":
+		return addressEquals(disk.Address.Bus, guestDisk.Bus) &&
+			addressEquals(disk.Address.Target, guestDisk.Target) &&
+			addressEquals(disk.Address.Unit, guestDisk.Unit)
+	}
+	return false
+}
+
+// addressEquals compares a libvirt address attribute, like 0x0a, with the value reported by the guest
+func addressEquals(attr string, value int) bool {
+	if attr == "" {
+		return value == 0
+	}
+	n, err := strconv.ParseInt(attr, 0, 64)
+	return err == nil && n == int64(value)
+}
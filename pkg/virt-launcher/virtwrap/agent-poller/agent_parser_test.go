@@ -150,6 +150,26 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(interfaceStatuses).To(Equal(expectedStatuses))
 		})
 
+		It("should normalize the MAC addresses reported by the guest", func() {
+			interfaceStatuses := convertInterfaceStatusesFromAgentJSON([]Interface{
+				{
+					Name: "net1",
+					MAC:  "0A:58:0A:F4:00:52",
+					IPs: []IP{
+						{IP: "192.168.1.10", Type: "ipv4", Prefix: 24},
+					},
+				},
+			})
+			Expect(interfaceStatuses).To(Equal([]api.InterfaceStatus{
+				{
+					Mac:           "0a:58:0a:f4:00:52",
+					Ip:            "192.168.1.10",
+					IPs:           []string{"192.168.1.10"},
+					InterfaceName: "net1",
+				},
+			}))
+		})
+
 		It("should merge QEMU info and agent info", func() {
 			interfaceStatuses, err := parseInterfaces(JSONInput)
 			Expect(err).ToNot(HaveOccurred(), "should parse network inferfaces")
@@ -366,5 +386,105 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(err).ToNot(HaveOccurred(), "users should be parsed normally")
 			Expect(users).To(Equal(expectedUsers))
 		})
+
+		It("should return the mountpoints of the filesystems not backed by the given disks", func() {
+
+			jsonInput := `{
+                "return":[
+                    {
+                        "name":"vda1",
+                        "mountpoint":"/",
+                        "type":"xfs",
+                        "disk":[{"bus-type":"virtio","bus":0,"target":0,"unit":0,
+                            "pci-controller":{"domain":0,"bus":7,"slot":0,"function":0}}]
+                    },
+                    {
+                        "name":"vdb",
+                        "mountpoint":"/scratch",
+                        "type":"ext4",
+                        "disk":[{"bus-type":"virtio","bus":0,"target":0,"unit":0,
+                            "pci-controller":{"domain":0,"bus":8,"slot":0,"function":0}}]
+                    },
+                    {
+                        "name":"sda",
+                        "mountpoint":"/data",
+                        "type":"ext4",
+                        "disk":[{"bus-type":"scsi","bus":0,"target":0,"unit":1,
+                            "pci-controller":{"domain":0,"bus":2,"slot":0,"function":0}}]
+                    },
+                    {
+                        "name":"vdc",
+                        "mountpoint":"/logs",
+                        "type":"ext4",
+                        "disk":[{"serial":"logs","bus-type":"virtio","bus":0,"target":0,"unit":0,
+                            "pci-controller":{"domain":0,"bus":9,"slot":0,"function":0}}]
+                    }
+                ]
+            }`
+
+			disks := []api.Disk{
+				{
+					Target:  api.DiskTarget{Bus: "virtio", Device: "vdb"},
+					Address: &api.Address{Type: "pci", Domain: "0x0000", Bus: "0x08", Slot: "0x00", Function: "0x0"},
+				},
+				{
+					Target:  api.DiskTarget{Bus: "scsi", Device: "sda"},
+					Address: &api.Address{Type: "drive", Controller: "0", Bus: "0", Target: "0", Unit: "1"},
+				},
+				{
+					Target: api.DiskTarget{Bus: "virtio", Device: "vdc"},
+					Serial: "logs",
+				},
+			}
+
+			mountpoints, err := MountpointsWithoutDisks(jsonInput, disks)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mountpoints).To(Equal([]string{"/"}))
+
+			mountpoints, err = MountpointsWithoutDisks(jsonInput, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mountpoints).To(Equal([]string{"/", "/scratch", "/data", "/logs"}))
+		})
+
+		It("should return the filesystems backed by the given disk", func() {
+
+			jsonInput := `{
+                "return":[
+                    {
+                        "name":"vda1",
+                        "mountpoint":"/",
+                        "type":"xfs",
+                        "disk":[{"bus-type":"virtio","bus":0,"target":0,"unit":0,"dev":"/dev/vda",
+                            "pci-controller":{"domain":0,"bus":7,"slot":0,"function":0}}]
+                    },
+                    {
+                        "name":"vdb1",
+                        "mountpoint":"/boot",
+                        "type":"ext4",
+                        "disk":[{"bus-type":"virtio","bus":0,"target":0,"unit":0,"dev":"/dev/vdb",
+                            "pci-controller":{"domain":0,"bus":8,"slot":0,"function":0}}]
+                    },
+                    {
+                        "name":"vdb2",
+                        "mountpoint":"/data",
+                        "type":"ext4",
+                        "disk":[{"bus-type":"virtio","bus":0,"target":0,"unit":0,"dev":"/dev/vdb",
+                            "pci-controller":{"domain":0,"bus":8,"slot":0,"function":0}}]
+                    }
+                ]
+            }`
+
+			disk := api.Disk{
+				Target:  api.DiskTarget{Bus: "virtio", Device: "vdb"},
+				Address: &api.Address{Type: "pci", Domain: "0x0000", Bus: "0x08", Slot: "0x00", Function: "0x0"},
+			}
+
+			filesystems, err := FilesystemsOnDisk(jsonInput, disk)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filesystems).To(HaveLen(2))
+			Expect(filesystems[0].Name).To(Equal("vdb1"))
+			Expect(filesystems[1].Name).To(Equal("vdb2"))
+			Expect(filesystems[1].Disks[0].Dev).To(Equal("/dev/vdb"))
+		})
 	})
 })
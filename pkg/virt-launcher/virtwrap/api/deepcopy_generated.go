@@ -146,9 +146,45 @@ func (in *BackingStoreFormat) DeepCopy() *BackingStoreFormat {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupMetadata) DeepCopyInto(out *BackupMetadata) {
+	*out = *in
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupMetadata.
+func (in *BackupMetadata) DeepCopy() *BackupMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BandWidth) DeepCopyInto(out *BandWidth) {
 	*out = *in
+	if in.Inbound != nil {
+		in, out := &in.Inbound, &out.Inbound
+		*out = new(BandWidthLimit)
+		**out = **in
+	}
+	if in.Outbound != nil {
+		in, out := &in.Outbound, &out.Outbound
+		*out = new(BandWidthLimit)
+		**out = **in
+	}
 	return
 }
 
@@ -162,6 +198,51 @@ func (in *BandWidth) DeepCopy() *BandWidth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandWidthLimit) DeepCopyInto(out *BandWidthLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandWidthLimit.
+func (in *BandWidthLimit) DeepCopy() *BandWidthLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(BandWidthLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockCommitMetadata) DeepCopyInto(out *BlockCommitMetadata) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockCommitMetadata.
+func (in *BlockCommitMetadata) DeepCopy() *BlockCommitMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockCommitMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Boot) DeepCopyInto(out *Boot) {
 	*out = *in
@@ -215,6 +296,22 @@ func (in *BootOrder) DeepCopy() *BootOrder {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CID) DeepCopyInto(out *CID) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CID.
+func (in *CID) DeepCopy() *CID {
+	if in == nil {
+		return nil
+	}
+	out := new(CID)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CPU) DeepCopyInto(out *CPU) {
 	*out = *in
@@ -312,6 +409,11 @@ func (in *CPUTune) DeepCopyInto(out *CPUTune) {
 		*out = new(CPUEmulatorPin)
 		**out = **in
 	}
+	if in.VCPUScheduler != nil {
+		in, out := &in.VCPUScheduler, &out.VCPUScheduler
+		*out = make([]VCPUScheduler, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -698,6 +800,26 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(MemoryDevice)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TPMs != nil {
+		in, out := &in.TPMs, &out.TPMs
+		*out = make([]TPM, len(*in))
+		copy(*out, *in)
+	}
+	if in.VSOCK != nil {
+		in, out := &in.VSOCK, &out.VSOCK
+		*out = new(VSOCK)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Panic != nil {
+		in, out := &in.Panic, &out.Panic
+		*out = new(PanicDevice)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -751,6 +873,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(Address)
 		**out = **in
 	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(IOTune)
+		**out = **in
+	}
 	return
 }
 
@@ -811,6 +938,27 @@ func (in *DiskDriver) DeepCopy() *DiskDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskEncryption) DeepCopyInto(out *DiskEncryption) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(DiskSecret)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskEncryption.
+func (in *DiskEncryption) DeepCopy() *DiskEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskSecret) DeepCopyInto(out *DiskSecret) {
 	*out = *in
@@ -827,6 +975,22 @@ func (in *DiskSecret) DeepCopy() *DiskSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskSizeMetadata) DeepCopyInto(out *DiskSizeMetadata) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskSizeMetadata.
+func (in *DiskSizeMetadata) DeepCopy() *DiskSizeMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskSizeMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskSource) DeepCopyInto(out *DiskSource) {
 	*out = *in
@@ -835,6 +999,21 @@ func (in *DiskSource) DeepCopyInto(out *DiskSource) {
 		*out = new(DiskSourceHost)
 		**out = **in
 	}
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = new(Reservations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(Address)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(DiskEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -974,6 +1153,11 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 	*out = *in
 	out.XMLName = in.XMLName
 	out.Memory = in.Memory
+	if in.MaxMemory != nil {
+		in, out := &in.MaxMemory, &out.MaxMemory
+		*out = new(MaxMemory)
+		**out = **in
+	}
 	if in.MemoryBacking != nil {
 		in, out := &in.MemoryBacking, &out.MemoryBacking
 		*out = new(MemoryBacking)
@@ -1018,11 +1202,21 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 		*out = new(CPUTune)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NUMATune != nil {
+		in, out := &in.NUMATune, &out.NUMATune
+		*out = new(NUMATune)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IOThreads != nil {
 		in, out := &in.IOThreads, &out.IOThreads
 		*out = new(IOThreads)
 		**out = **in
 	}
+	if in.LaunchSecurity != nil {
+		in, out := &in.LaunchSecurity, &out.LaunchSecurity
+		*out = new(LaunchSecurity)
+		**out = **in
+	}
 	return
 }
 
@@ -1110,6 +1304,27 @@ func (in *Env) DeepCopy() *Env {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSnapshotMetadata) DeepCopyInto(out *ExternalSnapshotMetadata) {
+	*out = *in
+	if in.BackingChain != nil {
+		in, out := &in.BackingChain, &out.BackingChain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSnapshotMetadata.
+func (in *ExternalSnapshotMetadata) DeepCopy() *ExternalSnapshotMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSnapshotMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureEnabled) DeepCopyInto(out *FeatureEnabled) {
 	*out = *in
@@ -1445,6 +1660,11 @@ func (in *FilesystemDevice) DeepCopyInto(out *FilesystemDevice) {
 		*out = new(FilesystemBinary)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IDMap != nil {
+		in, out := &in.IDMap, &out.IDMap
+		*out = new(FilesystemIDMap)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1474,6 +1694,48 @@ func (in *FilesystemDriver) DeepCopy() *FilesystemDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemIDMap) DeepCopyInto(out *FilesystemIDMap) {
+	*out = *in
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = make([]FilesystemIDMapRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.GID != nil {
+		in, out := &in.GID, &out.GID
+		*out = make([]FilesystemIDMapRange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemIDMap.
+func (in *FilesystemIDMap) DeepCopy() *FilesystemIDMap {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemIDMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemIDMapRange) DeepCopyInto(out *FilesystemIDMapRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemIDMapRange.
+func (in *FilesystemIDMapRange) DeepCopy() *FilesystemIDMapRange {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemIDMapRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilesystemSource) DeepCopyInto(out *FilesystemSource) {
 	*out = *in
@@ -1620,6 +1882,11 @@ func (in *HostDevice) DeepCopyInto(out *HostDevice) {
 		*out = new(Alias)
 		**out = **in
 	}
+	if in.Teaming != nil {
+		in, out := &in.Teaming, &out.Teaming
+		*out = new(Teaming)
+		**out = **in
+	}
 	return
 }
 
@@ -1707,6 +1974,22 @@ func (in *IOThreads) DeepCopy() *IOThreads {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOTune) DeepCopyInto(out *IOTune) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOTune.
+func (in *IOTune) DeepCopy() *IOTune {
+	if in == nil {
+		return nil
+	}
+	out := new(IOTune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -1765,7 +2048,7 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 	if in.BandWidth != nil {
 		in, out := &in.BandWidth, &out.BandWidth
 		*out = new(BandWidth)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.BootOrder != nil {
 		in, out := &in.BootOrder, &out.BootOrder
@@ -1797,6 +2080,23 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 		*out = new(Rom)
 		**out = **in
 	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(InterfaceBackend)
+		**out = **in
+	}
+	if in.PortForward != nil {
+		in, out := &in.PortForward, &out.PortForward
+		*out = make([]InterfacePortForward, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Teaming != nil {
+		in, out := &in.Teaming, &out.Teaming
+		*out = new(Teaming)
+		**out = **in
+	}
 	return
 }
 
@@ -1810,6 +2110,22 @@ func (in *Interface) DeepCopy() *Interface {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceBackend) DeepCopyInto(out *InterfaceBackend) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceBackend.
+func (in *InterfaceBackend) DeepCopy() *InterfaceBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceDriver) DeepCopyInto(out *InterfaceDriver) {
 	*out = *in
@@ -1818,6 +2134,16 @@ func (in *InterfaceDriver) DeepCopyInto(out *InterfaceDriver) {
 		*out = new(uint)
 		**out = **in
 	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(InterfaceDriverHost)
+		**out = **in
+	}
+	if in.Guest != nil {
+		in, out := &in.Guest, &out.Guest
+		*out = new(InterfaceDriverGuest)
+		**out = **in
+	}
 	return
 }
 
@@ -1832,70 +2158,139 @@ func (in *InterfaceDriver) DeepCopy() *InterfaceDriver {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InterfaceSource) DeepCopyInto(out *InterfaceSource) {
+func (in *InterfaceDriverGuest) DeepCopyInto(out *InterfaceDriverGuest) {
 	*out = *in
-	if in.Address != nil {
-		in, out := &in.Address, &out.Address
-		*out = new(Address)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceDriverGuest.
+func (in *InterfaceDriverGuest) DeepCopy() *InterfaceDriverGuest {
+	if in == nil {
+		return nil
 	}
+	out := new(InterfaceDriverGuest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceDriverHost) DeepCopyInto(out *InterfaceDriverHost) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceSource.
-func (in *InterfaceSource) DeepCopy() *InterfaceSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceDriverHost.
+func (in *InterfaceDriverHost) DeepCopy() *InterfaceDriverHost {
 	if in == nil {
 		return nil
 	}
-	out := new(InterfaceSource)
+	out := new(InterfaceDriverHost)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InterfaceStatus) DeepCopyInto(out *InterfaceStatus) {
+func (in *InterfacePortForward) DeepCopyInto(out *InterfacePortForward) {
 	*out = *in
-	if in.IPs != nil {
-		in, out := &in.IPs, &out.IPs
-		*out = make([]string, len(*in))
+	if in.Ranges != nil {
+		in, out := &in.Ranges, &out.Ranges
+		*out = make([]InterfacePortForwardRange, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceStatus.
-func (in *InterfaceStatus) DeepCopy() *InterfaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfacePortForward.
+func (in *InterfacePortForward) DeepCopy() *InterfacePortForward {
 	if in == nil {
 		return nil
 	}
-	out := new(InterfaceStatus)
+	out := new(InterfacePortForward)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InterfaceTarget) DeepCopyInto(out *InterfaceTarget) {
+func (in *InterfacePortForwardRange) DeepCopyInto(out *InterfacePortForwardRange) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceTarget.
-func (in *InterfaceTarget) DeepCopy() *InterfaceTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfacePortForwardRange.
+func (in *InterfacePortForwardRange) DeepCopy() *InterfacePortForwardRange {
 	if in == nil {
 		return nil
 	}
-	out := new(InterfaceTarget)
+	out := new(InterfacePortForwardRange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeVirtMetadata) DeepCopyInto(out *KubeVirtMetadata) {
+func (in *InterfaceSource) DeepCopyInto(out *InterfaceSource) {
 	*out = *in
-	if in.GracePeriod != nil {
-		in, out := &in.GracePeriod, &out.GracePeriod
-		*out = new(GracePeriodMetadata)
-		(*in).DeepCopyInto(*out)
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(Address)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceSource.
+func (in *InterfaceSource) DeepCopy() *InterfaceSource {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceStatus) DeepCopyInto(out *InterfaceStatus) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceStatus.
+func (in *InterfaceStatus) DeepCopy() *InterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceTarget) DeepCopyInto(out *InterfaceTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceTarget.
+func (in *InterfaceTarget) DeepCopy() *InterfaceTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtMetadata) DeepCopyInto(out *KubeVirtMetadata) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(GracePeriodMetadata)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Migration != nil {
 		in, out := &in.Migration, &out.Migration
@@ -1907,6 +2302,45 @@ func (in *KubeVirtMetadata) DeepCopyInto(out *KubeVirtMetadata) {
 		*out = new(AccessCredentialMetadata)
 		**out = **in
 	}
+	if in.DiskSizes != nil {
+		in, out := &in.DiskSizes, &out.DiskSizes
+		*out = make([]DiskSizeMetadata, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quiesce != nil {
+		in, out := &in.Quiesce, &out.Quiesce
+		*out = new(QuiesceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryDump != nil {
+		in, out := &in.MemoryDump, &out.MemoryDump
+		*out = new(MemoryDumpMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlockCommit != nil {
+		in, out := &in.BlockCommit, &out.BlockCommit
+		*out = new(BlockCommitMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Multipath != nil {
+		in, out := &in.Multipath, &out.Multipath
+		*out = make([]MultipathMetadata, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalSnapshots != nil {
+		in, out := &in.ExternalSnapshots, &out.ExternalSnapshots
+		*out = make([]ExternalSnapshotMetadata, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1920,6 +2354,22 @@ func (in *KubeVirtMetadata) DeepCopy() *KubeVirtMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchSecurity) DeepCopyInto(out *LaunchSecurity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchSecurity.
+func (in *LaunchSecurity) DeepCopy() *LaunchSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkState) DeepCopyInto(out *LinkState) {
 	*out = *in
@@ -1984,6 +2434,22 @@ func (in *MTU) DeepCopy() *MTU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxMemory) DeepCopyInto(out *MaxMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxMemory.
+func (in *MaxMemory) DeepCopy() *MaxMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemBalloon) DeepCopyInto(out *MemBalloon) {
 	*out = *in
@@ -2010,6 +2476,22 @@ func (in *MemBalloon) DeepCopy() *MemBalloon {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemNode) DeepCopyInto(out *MemNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemNode.
+func (in *MemNode) DeepCopy() *MemNode {
+	if in == nil {
+		return nil
+	}
+	out := new(MemNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Memory) DeepCopyInto(out *Memory) {
 	*out = *in
@@ -2044,6 +2526,11 @@ func (in *MemoryBacking) DeepCopyInto(out *MemoryBacking) {
 		*out = new(MemoryBackingAccess)
 		**out = **in
 	}
+	if in.Locked != nil {
+		in, out := &in.Locked, &out.Locked
+		*out = new(MemoryBackingLocked)
+		**out = **in
+	}
 	return
 }
 
@@ -2073,6 +2560,22 @@ func (in *MemoryBackingAccess) DeepCopy() *MemoryBackingAccess {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryBackingLocked) DeepCopyInto(out *MemoryBackingLocked) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryBackingLocked.
+func (in *MemoryBackingLocked) DeepCopy() *MemoryBackingLocked {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryBackingLocked)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemoryBackingSource) DeepCopyInto(out *MemoryBackingSource) {
 	*out = *in
@@ -2089,6 +2592,80 @@ func (in *MemoryBackingSource) DeepCopy() *MemoryBackingSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryDevice) DeepCopyInto(out *MemoryDevice) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(MemoryTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alias != nil {
+		in, out := &in.Alias, &out.Alias
+		*out = new(Alias)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryDevice.
+func (in *MemoryDevice) DeepCopy() *MemoryDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryTarget) DeepCopyInto(out *MemoryTarget) {
+	*out = *in
+	out.Size = in.Size
+	out.Block = in.Block
+	out.Requested = in.Requested
+	if in.Current != nil {
+		in, out := &in.Current, &out.Current
+		*out = new(Memory)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryTarget.
+func (in *MemoryTarget) DeepCopy() *MemoryTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryDumpMetadata) DeepCopyInto(out *MemoryDumpMetadata) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryDumpMetadata.
+func (in *MemoryDumpMetadata) DeepCopy() *MemoryDumpMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryDumpMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
@@ -2146,6 +2723,27 @@ func (in *Model) DeepCopy() *Model {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipathMetadata) DeepCopyInto(out *MultipathMetadata) {
+	*out = *in
+	if in.FailedPaths != nil {
+		in, out := &in.FailedPaths, &out.FailedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipathMetadata.
+func (in *MultipathMetadata) DeepCopy() *MultipathMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipathMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NUMA) DeepCopyInto(out *NUMA) {
 	*out = *in
@@ -2183,6 +2781,28 @@ func (in *NUMACell) DeepCopy() *NUMACell {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMATune) DeepCopyInto(out *NUMATune) {
+	*out = *in
+	out.Memory = in.Memory
+	if in.MemNodes != nil {
+		in, out := &in.MemNodes, &out.MemNodes
+		*out = make([]MemNode, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMATune.
+func (in *NUMATune) DeepCopy() *NUMATune {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMATune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NVRam) DeepCopyInto(out *NVRam) {
 	*out = *in
@@ -2199,6 +2819,22 @@ func (in *NVRam) DeepCopy() *NVRam {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NumaTuneMemory) DeepCopyInto(out *NumaTuneMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NumaTuneMemory.
+func (in *NumaTuneMemory) DeepCopy() *NumaTuneMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(NumaTuneMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OS) DeepCopyInto(out *OS) {
 	*out = *in
@@ -2262,6 +2898,47 @@ func (in *OSType) DeepCopy() *OSType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PanicDevice) DeepCopyInto(out *PanicDevice) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(Address)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PanicDevice.
+func (in *PanicDevice) DeepCopy() *PanicDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(PanicDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuiesceMetadata) DeepCopyInto(out *QuiesceMetadata) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuiesceMetadata.
+func (in *QuiesceMetadata) DeepCopy() *QuiesceMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(QuiesceMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadOnly) DeepCopyInto(out *ReadOnly) {
 	*out = *in
@@ -2278,6 +2955,27 @@ func (in *ReadOnly) DeepCopy() *ReadOnly {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reservations) DeepCopyInto(out *Reservations) {
+	*out = *in
+	if in.SourceReservations != nil {
+		in, out := &in.SourceReservations, &out.SourceReservations
+		*out = new(SourceReservations)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reservations.
+func (in *Reservations) DeepCopy() *Reservations {
+	if in == nil {
+		return nil
+	}
+	out := new(Reservations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resource) DeepCopyInto(out *Resource) {
 	*out = *in
@@ -2302,6 +3000,11 @@ func (in *Rng) DeepCopyInto(out *Rng) {
 		*out = new(RngBackend)
 		**out = **in
 	}
+	if in.Driver != nil {
+		in, out := &in.Driver, &out.Driver
+		*out = new(RngDriver)
+		**out = **in
+	}
 	if in.Address != nil {
 		in, out := &in.Address, &out.Address
 		*out = new(Address)
@@ -2336,6 +3039,22 @@ func (in *RngBackend) DeepCopy() *RngBackend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RngDriver) DeepCopyInto(out *RngDriver) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RngDriver.
+func (in *RngDriver) DeepCopy() *RngDriver {
+	if in == nil {
+		return nil
+	}
+	out := new(RngDriver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RngRate) DeepCopyInto(out *RngRate) {
 	*out = *in
@@ -2431,6 +3150,11 @@ func (in *Serial) DeepCopyInto(out *Serial) {
 		*out = new(SerialSource)
 		**out = **in
 	}
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(SerialLog)
+		**out = **in
+	}
 	if in.Alias != nil {
 		in, out := &in.Alias, &out.Alias
 		*out = new(Alias)
@@ -2449,6 +3173,22 @@ func (in *Serial) DeepCopy() *Serial {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialLog) DeepCopyInto(out *SerialLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialLog.
+func (in *SerialLog) DeepCopy() *SerialLog {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SerialSource) DeepCopyInto(out *SerialSource) {
 	*out = *in
@@ -2486,6 +3226,22 @@ func (in *SerialTarget) DeepCopy() *SerialTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceReservations) DeepCopyInto(out *SourceReservations) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceReservations.
+func (in *SourceReservations) DeepCopy() *SourceReservations {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceReservations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Stats) DeepCopyInto(out *Stats) {
 	*out = *in
@@ -2559,6 +3315,55 @@ func (in *SysInfo) DeepCopy() *SysInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPM) DeepCopyInto(out *TPM) {
+	*out = *in
+	out.Backend = in.Backend
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPM.
+func (in *TPM) DeepCopy() *TPM {
+	if in == nil {
+		return nil
+	}
+	out := new(TPM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPMBackend) DeepCopyInto(out *TPMBackend) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPMBackend.
+func (in *TPMBackend) DeepCopy() *TPMBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(TPMBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Teaming) DeepCopyInto(out *Teaming) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Teaming.
+func (in *Teaming) DeepCopy() *Teaming {
+	if in == nil {
+		return nil
+	}
+	out := new(Teaming)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Timer) DeepCopyInto(out *Timer) {
 	*out = *in
@@ -2623,6 +3428,59 @@ func (in *VCPU) DeepCopy() *VCPU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VCPUScheduler) DeepCopyInto(out *VCPUScheduler) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VCPUScheduler.
+func (in *VCPUScheduler) DeepCopy() *VCPUScheduler {
+	if in == nil {
+		return nil
+	}
+	out := new(VCPUScheduler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSOCK) DeepCopyInto(out *VSOCK) {
+	*out = *in
+	if in.Driver != nil {
+		in, out := &in.Driver, &out.Driver
+		*out = new(VSOCKDriver)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSOCK.
+func (in *VSOCK) DeepCopy() *VSOCK {
+	if in == nil {
+		return nil
+	}
+	out := new(VSOCK)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSOCKDriver) DeepCopyInto(out *VSOCKDriver) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSOCKDriver.
+func (in *VSOCKDriver) DeepCopy() *VSOCKDriver {
+	if in == nil {
+		return nil
+	}
+	out := new(VSOCKDriver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Video) DeepCopyInto(out *Video) {
 	*out = *in
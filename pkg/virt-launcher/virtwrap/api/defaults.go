@@ -23,9 +23,14 @@ func (d *Defaulter) SetDefaults_OSType(ostype *OSType) {
 	ostype.OS = "hvm"
 
 	if ostype.Arch == "" {
-		if d.Architecture == "ppc64le" {
+		switch d.Architecture {
+		case "ppc64le":
 			ostype.Arch = "ppc64le"
-		} else {
+		case "arm64":
+			ostype.Arch = "aarch64"
+		case "s390x":
+			ostype.Arch = "s390x"
+		default:
 			ostype.Arch = "x86_64"
 		}
 	}
@@ -33,9 +38,14 @@ func (d *Defaulter) SetDefaults_OSType(ostype *OSType) {
 	// q35 is an alias of the newest q35 machine type.
 	// TODO: we probably want to select concrete type in the future for "future-backwards" compatibility.
 	if ostype.Machine == "" {
-		if d.Architecture == "ppc64le" {
+		switch d.Architecture {
+		case "ppc64le":
 			ostype.Machine = "pseries"
-		} else {
+		case "arm64":
+			ostype.Machine = "virt"
+		case "s390x":
+			ostype.Machine = "s390-ccw-virtio"
+		default:
 			ostype.Machine = "q35"
 		}
 	}
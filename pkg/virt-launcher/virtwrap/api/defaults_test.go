@@ -15,6 +15,8 @@ var _ = Describe("ArchSpecificDefaults", func() {
 	},
 		table.Entry("to ppc64le", "ppc64le", "ppc64le"),
 		table.Entry("to x86_64", "amd64", "x86_64"),
+		table.Entry("to aarch64", "arm64", "aarch64"),
+		table.Entry("to s390x", "s390x", "s390x"),
 	)
 
 	table.DescribeTable("should set machine type and hvm domain type", func(arch string, machineType string) {
@@ -24,6 +26,8 @@ var _ = Describe("ArchSpecificDefaults", func() {
 	},
 		table.Entry("to pseries", "ppc64le", "pseries"),
 		table.Entry("to q35", "amd64", "q35"),
+		table.Entry("to virt", "arm64", "virt"),
+		table.Entry("to s390-ccw-virtio", "s390x", "s390-ccw-virtio"),
 	)
 
 	table.DescribeTable("should set libvirt namespace and use QEMU as emulator", func(arch string) {
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"strconv"
 	"strings"
 
 	kubev1 "k8s.io/api/core/v1"
@@ -106,6 +107,10 @@ type DomainStatus struct {
 	Reason     StateChangeReason
 	Interfaces []InterfaceStatus
 	OSInfo     GuestOSInfo
+	// WatchdogAction is the action taken by the hypervisor when the watchdog last expired
+	WatchdogAction string
+	// GuestPanicked is true if the guest reported a kernel panic
+	GuestPanicked bool
 }
 
 type DomainSysInfo struct {
@@ -169,31 +174,67 @@ type DomainList struct {
 // tagged, and they must correspond to the libvirt domain as described in
 // https://libvirt.org/formatdomain.html.
 type DomainSpec struct {
-	XMLName       xml.Name       `xml:"domain"`
-	Type          string         `xml:"type,attr"`
-	XmlNS         string         `xml:"xmlns:qemu,attr,omitempty"`
-	Name          string         `xml:"name"`
-	UUID          string         `xml:"uuid,omitempty"`
-	Memory        Memory         `xml:"memory"`
-	MemoryBacking *MemoryBacking `xml:"memoryBacking,omitempty"`
-	OS            OS             `xml:"os"`
-	SysInfo       *SysInfo       `xml:"sysinfo,omitempty"`
-	Devices       Devices        `xml:"devices"`
-	Clock         *Clock         `xml:"clock,omitempty"`
-	Resource      *Resource      `xml:"resource,omitempty"`
-	QEMUCmd       *Commandline   `xml:"qemu:commandline,omitempty"`
-	Metadata      Metadata       `xml:"metadata,omitempty"`
-	Features      *Features      `xml:"features,omitempty"`
-	CPU           CPU            `xml:"cpu"`
-	VCPU          *VCPU          `xml:"vcpu"`
-	CPUTune       *CPUTune       `xml:"cputune"`
-	IOThreads     *IOThreads     `xml:"iothreads,omitempty"`
+	XMLName        xml.Name        `xml:"domain"`
+	Type           string          `xml:"type,attr"`
+	XmlNS          string          `xml:"xmlns:qemu,attr,omitempty"`
+	Name           string          `xml:"name"`
+	UUID           string          `xml:"uuid,omitempty"`
+	Memory         Memory          `xml:"memory"`
+	MaxMemory      *MaxMemory      `xml:"maxMemory,omitempty"`
+	MemoryBacking  *MemoryBacking  `xml:"memoryBacking,omitempty"`
+	OS             OS              `xml:"os"`
+	SysInfo        *SysInfo        `xml:"sysinfo,omitempty"`
+	Devices        Devices         `xml:"devices"`
+	Clock          *Clock          `xml:"clock,omitempty"`
+	Resource       *Resource       `xml:"resource,omitempty"`
+	QEMUCmd        *Commandline    `xml:"qemu:commandline,omitempty"`
+	Metadata       Metadata        `xml:"metadata,omitempty"`
+	Features       *Features       `xml:"features,omitempty"`
+	CPU            CPU             `xml:"cpu"`
+	VCPU           *VCPU           `xml:"vcpu"`
+	CPUTune        *CPUTune        `xml:"cputune"`
+	NUMATune       *NUMATune       `xml:"numatune,omitempty"`
+	IOThreads      *IOThreads      `xml:"iothreads,omitempty"`
+	LaunchSecurity *LaunchSecurity `xml:"launchSecurity,omitempty"`
+	OnCrash        string          `xml:"on_crash,omitempty"`
+}
+
+// LaunchSecurity represents the memory encryption settings of the domain
+type LaunchSecurity struct {
+	Type            string `xml:"type,attr"`
+	Cbitpos         string `xml:"cbitpos,omitempty"`
+	ReducedPhysBits string `xml:"reducedPhysBits,omitempty"`
+	Policy          string `xml:"policy,omitempty"`
 }
 
 type CPUTune struct {
-	VCPUPin     []CPUTuneVCPUPin     `xml:"vcpupin"`
-	IOThreadPin []CPUTuneIOThreadPin `xml:"iothreadpin,omitempty"`
-	EmulatorPin *CPUEmulatorPin      `xml:"emulatorpin"`
+	VCPUPin       []CPUTuneVCPUPin     `xml:"vcpupin"`
+	IOThreadPin   []CPUTuneIOThreadPin `xml:"iothreadpin,omitempty"`
+	EmulatorPin   *CPUEmulatorPin      `xml:"emulatorpin"`
+	VCPUScheduler []VCPUScheduler      `xml:"vcpusched,omitempty"`
+}
+
+type VCPUScheduler struct {
+	VCPUs     string `xml:"vcpus,attr"`
+	Scheduler string `xml:"scheduler,attr"`
+	Priority  uint   `xml:"priority,attr,omitempty"`
+}
+
+// NUMATune mirroring libvirt XML under https://libvirt.org/formatdomain.html#numa-node-tuning
+type NUMATune struct {
+	Memory   NumaTuneMemory `xml:"memory"`
+	MemNodes []MemNode      `xml:"memnode"`
+}
+
+type NumaTuneMemory struct {
+	Mode    string `xml:"mode,attr"`
+	NodeSet string `xml:"nodeset,attr"`
+}
+
+type MemNode struct {
+	CellID  uint32 `xml:"cellid,attr"`
+	Mode    string `xml:"mode,attr"`
+	NodeSet string `xml:"nodeset,attr"`
 }
 
 type CPUTuneVCPUPin struct {
@@ -212,6 +253,7 @@ type CPUEmulatorPin struct {
 
 type VCPU struct {
 	Placement string `xml:"placement,attr"`
+	Current   uint32 `xml:"current,attr,omitempty"`
 	CPUs      uint32 `xml:",chardata"`
 }
 
@@ -227,6 +269,23 @@ type NUMA struct {
 	Cells []NUMACell `xml:"cell"`
 }
 
+// TotalMemory returns the memory of all NUMA cells in bytes
+func (numa *NUMA) TotalMemory() (uint64, error) {
+	var total uint64
+	for _, cell := range numa.Cells {
+		value, err := strconv.ParseUint(cell.Memory, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid memory of NUMA cell %s: %v", cell.ID, err)
+		}
+		cellMemory, err := Memory{Value: value, Unit: cell.Unit}.Bytes()
+		if err != nil {
+			return 0, err
+		}
+		total += cellMemory
+	}
+	return total, nil
+}
+
 type NUMACell struct {
 	ID           string `xml:"id,attr"`
 	CPUs         string `xml:"cpus,attr"`
@@ -309,10 +368,77 @@ type Metadata struct {
 }
 
 type KubeVirtMetadata struct {
-	UID              types.UID                 `xml:"uid"`
-	GracePeriod      *GracePeriodMetadata      `xml:"graceperiod,omitempty"`
-	Migration        *MigrationMetadata        `xml:"migration,omitempty"`
-	AccessCredential *AccessCredentialMetadata `xml:"accessCredential,omitempty"`
+	UID               types.UID                  `xml:"uid"`
+	GracePeriod       *GracePeriodMetadata       `xml:"graceperiod,omitempty"`
+	Migration         *MigrationMetadata         `xml:"migration,omitempty"`
+	AccessCredential  *AccessCredentialMetadata  `xml:"accessCredential,omitempty"`
+	DiskSizes         []DiskSizeMetadata         `xml:"diskSize,omitempty"`
+	Backup            *BackupMetadata            `xml:"backup,omitempty"`
+	Quiesce           *QuiesceMetadata           `xml:"quiesce,omitempty"`
+	MemoryDump        *MemoryDumpMetadata        `xml:"memoryDump,omitempty"`
+	BlockCommit       *BlockCommitMetadata       `xml:"blockCommit,omitempty"`
+	Multipath         []MultipathMetadata        `xml:"multipath,omitempty"`
+	ExternalSnapshots []ExternalSnapshotMetadata `xml:"externalSnapshot,omitempty"`
+}
+
+// BackupMetadata records the checkpoints of the domain, oldest first, and the backup which is running
+type BackupMetadata struct {
+	Checkpoints []string `xml:"checkpoints>name,omitempty"`
+	Checkpoint  string   `xml:"checkpoint,omitempty"`
+	Incremental string   `xml:"incremental,omitempty"`
+	Disks       []string `xml:"disks>name,omitempty"`
+}
+
+// DiskSizeMetadata records the size of a disk after it was expanded while the domain was running
+type DiskSizeMetadata struct {
+	Name string `xml:"name,attr"`
+	Size int64  `xml:"size,attr"`
+}
+
+// MultipathMetadata records the health of the paths of the multipath map backing the block device of a disk
+type MultipathMetadata struct {
+	Name        string   `xml:"name,attr"`
+	Paths       int32    `xml:"paths,attr"`
+	ActivePaths int32    `xml:"activePaths,attr"`
+	FailedPaths []string `xml:"failedPath,omitempty"`
+}
+
+// ExternalSnapshotMetadata records the qcow2 overlay the disk of a volume was switched to by external snapshots,
+// and the files backing it, from its backing file down to the image of the volume
+type ExternalSnapshotMetadata struct {
+	Name         string   `xml:"name,attr"`
+	ActiveFile   string   `xml:"activeFile"`
+	BackingChain []string `xml:"backingFile,omitempty"`
+}
+
+// QuiesceMetadata records whether the guest filesystems are frozen and why they were last frozen or thawed
+type QuiesceMetadata struct {
+	Frozen    bool         `xml:"frozen,omitempty"`
+	Reason    string       `xml:"reason,omitempty"`
+	Message   string       `xml:"message,omitempty"`
+	Timestamp *metav1.Time `xml:"timestamp,omitempty"`
+}
+
+// MemoryDumpMetadata records the last memory dump of the domain and whether it is still running
+type MemoryDumpMetadata struct {
+	FileName       string       `xml:"fileName,omitempty"`
+	StartTimestamp *metav1.Time `xml:"startTimestamp,omitempty"`
+	EndTimestamp   *metav1.Time `xml:"endTimestamp,omitempty"`
+	Completed      bool         `xml:"completed,omitempty"`
+	Failed         bool         `xml:"failed,omitempty"`
+	FailureReason  string       `xml:"failureReason,omitempty"`
+}
+
+// BlockCommitMetadata records the last commit of the overlays of the disks of volumes into their images,
+// its progress in percent and whether it is still running
+type BlockCommitMetadata struct {
+	Volumes        []string     `xml:"volumes>name,omitempty"`
+	StartTimestamp *metav1.Time `xml:"startTimestamp,omitempty"`
+	EndTimestamp   *metav1.Time `xml:"endTimestamp,omitempty"`
+	Progress       int32        `xml:"progress,omitempty"`
+	Completed      bool         `xml:"completed,omitempty"`
+	Failed         bool         `xml:"failed,omitempty"`
+	FailureReason  string       `xml:"failureReason,omitempty"`
 }
 
 type AccessCredentialMetadata struct {
@@ -360,13 +486,48 @@ type Memory struct {
 	Unit  string `xml:"unit,attr"`
 }
 
+// Bytes returns the amount of memory in bytes, following the unit semantics of libvirt
+func (m Memory) Bytes() (uint64, error) {
+	switch m.Unit {
+	case "", "b", "bytes":
+		return m.Value, nil
+	case "KB":
+		return m.Value * 1000, nil
+	case "k", "KiB":
+		return m.Value * 1024, nil
+	case "MB":
+		return m.Value * 1000 * 1000, nil
+	case "M", "MiB":
+		return m.Value * 1024 * 1024, nil
+	case "GB":
+		return m.Value * 1000 * 1000 * 1000, nil
+	case "G", "GiB":
+		return m.Value * 1024 * 1024 * 1024, nil
+	case "TB":
+		return m.Value * 1000 * 1000 * 1000 * 1000, nil
+	case "T", "TiB":
+		return m.Value * 1024 * 1024 * 1024 * 1024, nil
+	}
+	return 0, fmt.Errorf("unknown memory unit %s", m.Unit)
+}
+
+type MaxMemory struct {
+	Value uint64 `xml:",chardata"`
+	Unit  string `xml:"unit,attr"`
+	Slots uint64 `xml:"slots,attr"`
+}
+
 // MemoryBacking mirroring libvirt XML under https://libvirt.org/formatdomain.html#elementsMemoryBacking
 type MemoryBacking struct {
 	HugePages *HugePages           `xml:"hugepages,omitempty"`
 	Source    *MemoryBackingSource `xml:"source,omitempty"`
 	Access    *MemoryBackingAccess `xml:"access,omitempty"`
+	Locked    *MemoryBackingLocked `xml:"locked,omitempty"`
 }
 
+// MemoryBackingLocked prevents the host from swapping out the guest memory
+type MemoryBackingLocked struct{}
+
 type MemoryBackingSource struct {
 	Type string `xml:"type,attr"`
 }
@@ -378,8 +539,9 @@ type HugePages struct {
 
 // HugePage mirroring libvirt XML under hugepages
 type HugePage struct {
-	Size string `xml:"size,attr"`
-	Unit string `xml:"unit,attr"`
+	Size    string `xml:"size,attr"`
+	Unit    string `xml:"unit,attr"`
+	NodeSet string `xml:"nodeset,attr,omitempty"`
 }
 
 type MemoryBackingAccess struct {
@@ -402,6 +564,25 @@ type Devices struct {
 	Watchdog    *Watchdog          `xml:"watchdog,omitempty"`
 	Rng         *Rng               `xml:"rng,omitempty"`
 	Filesystems []FilesystemDevice `xml:"filesystem,omitempty"`
+	Memory      *MemoryDevice      `xml:"memory,omitempty"`
+	TPMs        []TPM              `xml:"tpm,omitempty"`
+	VSOCK       *VSOCK             `xml:"vsock,omitempty"`
+	Panic       *PanicDevice       `xml:"panic,omitempty"`
+}
+
+// MemoryDevice mirroring libvirt XML under https://libvirt.org/formatdomain.html#memory-devices
+type MemoryDevice struct {
+	Model  string        `xml:"model,attr"`
+	Target *MemoryTarget `xml:"target"`
+	Alias  *Alias        `xml:"alias,omitempty"`
+}
+
+type MemoryTarget struct {
+	Size      Memory  `xml:"size"`
+	Node      string  `xml:"node"`
+	Block     Memory  `xml:"block"`
+	Requested Memory  `xml:"requested"`
+	Current   *Memory `xml:"current,omitempty"`
 }
 
 type FilesystemDevice struct {
@@ -411,6 +592,19 @@ type FilesystemDevice struct {
 	Target     *FilesystemTarget `xml:"target,omitempty"`
 	Driver     *FilesystemDriver `xml:"driver,omitempty"`
 	Binary     *FilesystemBinary `xml:"binary,omitempty"`
+	IDMap      *FilesystemIDMap  `xml:"idmap,omitempty"`
+}
+
+// FilesystemIDMap maps the user and group IDs of the guest to the ones of the shared directory
+type FilesystemIDMap struct {
+	UID []FilesystemIDMapRange `xml:"uid"`
+	GID []FilesystemIDMapRange `xml:"gid"`
+}
+
+type FilesystemIDMapRange struct {
+	Start  uint32 `xml:"start,attr"`
+	Target uint32 `xml:"target,attr"`
+	Count  uint32 `xml:"count,attr"`
 }
 
 type FilesystemTarget struct {
@@ -462,10 +656,12 @@ type HostDevice struct {
 	Model     string           `xml:"model,attr,omitempty"`
 	Address   *Address         `xml:"address,emitempty"`
 	Alias     *Alias           `xml:"alias,omitempty"`
+	Teaming   *Teaming         `xml:"teaming,omitempty"`
 }
 
 type HostDeviceSource struct {
-	Address *Address `xml:"address,omitempty"`
+	GuestReset string   `xml:"guestReset,attr,omitempty"`
+	Address    *Address `xml:"address,omitempty"`
 }
 
 // END HostDevice -----------------------------
@@ -486,7 +682,8 @@ type Controller struct {
 
 // BEGIN ControllerDriver
 type ControllerDriver struct {
-	IOThread *uint `xml:"iothread,attr,omitempty"`
+	IOThread *uint  `xml:"iothread,attr,omitempty"`
+	IOMMU    string `xml:"iommu,attr,omitempty"`
 }
 
 // END ControllerDriver
@@ -508,6 +705,29 @@ type Disk struct {
 	BootOrder    *BootOrder    `xml:"boot,omitempty"`
 	Address      *Address      `xml:"address,omitempty"`
 	Model        string        `xml:"model,attr,omitempty"`
+	IOTune       *IOTune       `xml:"iotune,omitempty"`
+}
+
+// IOTune makes QEMU throttle the I/O of a disk
+type IOTune struct {
+	TotalBytesSec          uint64 `xml:"total_bytes_sec,omitempty"`
+	ReadBytesSec           uint64 `xml:"read_bytes_sec,omitempty"`
+	WriteBytesSec          uint64 `xml:"write_bytes_sec,omitempty"`
+	TotalIopsSec           uint64 `xml:"total_iops_sec,omitempty"`
+	ReadIopsSec            uint64 `xml:"read_iops_sec,omitempty"`
+	WriteIopsSec           uint64 `xml:"write_iops_sec,omitempty"`
+	TotalBytesSecMax       uint64 `xml:"total_bytes_sec_max,omitempty"`
+	ReadBytesSecMax        uint64 `xml:"read_bytes_sec_max,omitempty"`
+	WriteBytesSecMax       uint64 `xml:"write_bytes_sec_max,omitempty"`
+	TotalIopsSecMax        uint64 `xml:"total_iops_sec_max,omitempty"`
+	ReadIopsSecMax         uint64 `xml:"read_iops_sec_max,omitempty"`
+	WriteIopsSecMax        uint64 `xml:"write_iops_sec_max,omitempty"`
+	TotalBytesSecMaxLength uint64 `xml:"total_bytes_sec_max_length,omitempty"`
+	ReadBytesSecMaxLength  uint64 `xml:"read_bytes_sec_max_length,omitempty"`
+	WriteBytesSecMaxLength uint64 `xml:"write_bytes_sec_max_length,omitempty"`
+	TotalIopsSecMaxLength  uint64 `xml:"total_iops_sec_max_length,omitempty"`
+	ReadIopsSecMaxLength   uint64 `xml:"read_iops_sec_max_length,omitempty"`
+	WriteIopsSecMaxLength  uint64 `xml:"write_iops_sec_max_length,omitempty"`
 }
 
 type DiskAuth struct {
@@ -530,6 +750,18 @@ type DiskSource struct {
 	Protocol      string          `xml:"protocol,attr,omitempty"`
 	Name          string          `xml:"name,attr,omitempty"`
 	Host          *DiskSourceHost `xml:"host,omitempty"`
+	Reservations  *Reservations   `xml:"reservations,omitempty"`
+	Type          string          `xml:"type,attr,omitempty"`
+	Managed       string          `xml:"managed,attr,omitempty"`
+	Namespace     string          `xml:"namespace,attr,omitempty"`
+	Address       *Address        `xml:"address,omitempty"`
+	Encryption    *DiskEncryption `xml:"encryption,omitempty"`
+}
+
+// DiskEncryption mirroring libvirt XML under https://libvirt.org/formatstorageencryption.html
+type DiskEncryption struct {
+	Format string      `xml:"format,attr"`
+	Secret *DiskSecret `xml:"secret,omitempty"`
 }
 
 type DiskTarget struct {
@@ -539,13 +771,16 @@ type DiskTarget struct {
 }
 
 type DiskDriver struct {
-	Cache       string `xml:"cache,attr,omitempty"`
-	ErrorPolicy string `xml:"error_policy,attr,omitempty"`
-	IO          string `xml:"io,attr,omitempty"`
-	Name        string `xml:"name,attr"`
-	Type        string `xml:"type,attr"`
-	IOThread    *uint  `xml:"iothread,attr,omitempty"`
-	Queues      *uint  `xml:"queues,attr,omitempty"`
+	Cache        string `xml:"cache,attr,omitempty"`
+	ErrorPolicy  string `xml:"error_policy,attr,omitempty"`
+	IO           string `xml:"io,attr,omitempty"`
+	Name         string `xml:"name,attr"`
+	Type         string `xml:"type,attr"`
+	IOThread     *uint  `xml:"iothread,attr,omitempty"`
+	Queues       *uint  `xml:"queues,attr,omitempty"`
+	IOMMU        string `xml:"iommu,attr,omitempty"`
+	Discard      string `xml:"discard,attr,omitempty"`
+	DetectZeroes string `xml:"detect_zeroes,attr,omitempty"`
 }
 
 type DiskSourceHost struct {
@@ -553,6 +788,18 @@ type DiskSourceHost struct {
 	Port string `xml:"port,attr,omitempty"`
 }
 
+// Reservations makes QEMU send the persistent reservation commands of a LUN disk to an external helper
+type Reservations struct {
+	Managed            string              `xml:"managed,attr,omitempty"`
+	SourceReservations *SourceReservations `xml:"source,omitempty"`
+}
+
+type SourceReservations struct {
+	Type string `xml:"type,attr"`
+	Path string `xml:"path,attr,omitempty"`
+	Mode string `xml:"mode,attr,omitempty"`
+}
+
 type BackingStore struct {
 	Type   string              `xml:"type,attr,omitempty"`
 	Format *BackingStoreFormat `xml:"format,omitempty"`
@@ -571,6 +818,7 @@ type Serial struct {
 	Type   string        `xml:"type,attr"`
 	Target *SerialTarget `xml:"target,omitempty"`
 	Source *SerialSource `xml:"source,omitempty"`
+	Log    *SerialLog    `xml:"log,omitempty"`
 	Alias  *Alias        `xml:"alias,omitempty"`
 }
 
@@ -583,6 +831,11 @@ type SerialSource struct {
 	Path string `xml:"path,attr,omitempty"`
 }
 
+type SerialLog struct {
+	File   string `xml:"file,attr"`
+	Append string `xml:"append,attr,omitempty"`
+}
+
 // END Serial -----------------------------
 
 // BEGIN Console -----------------------------
@@ -609,26 +862,56 @@ type ConsoleSource struct {
 // BEGIN Inteface -----------------------------
 
 type Interface struct {
-	Address             *Address         `xml:"address,omitempty"`
-	Type                string           `xml:"type,attr"`
-	TrustGuestRxFilters string           `xml:"trustGuestRxFilters,attr,omitempty"`
-	Source              InterfaceSource  `xml:"source"`
-	Target              *InterfaceTarget `xml:"target,omitempty"`
-	Model               *Model           `xml:"model,omitempty"`
-	MAC                 *MAC             `xml:"mac,omitempty"`
-	MTU                 *MTU             `xml:"mtu,omitempty"`
-	BandWidth           *BandWidth       `xml:"bandwidth,omitempty"`
-	BootOrder           *BootOrder       `xml:"boot,omitempty"`
-	LinkState           *LinkState       `xml:"link,omitempty"`
-	FilterRef           *FilterRef       `xml:"filterref,omitempty"`
-	Alias               *Alias           `xml:"alias,omitempty"`
-	Driver              *InterfaceDriver `xml:"driver,omitempty"`
-	Rom                 *Rom             `xml:"rom,omitempty"`
+	Address             *Address               `xml:"address,omitempty"`
+	Type                string                 `xml:"type,attr"`
+	TrustGuestRxFilters string                 `xml:"trustGuestRxFilters,attr,omitempty"`
+	Source              InterfaceSource        `xml:"source"`
+	Target              *InterfaceTarget       `xml:"target,omitempty"`
+	Model               *Model                 `xml:"model,omitempty"`
+	MAC                 *MAC                   `xml:"mac,omitempty"`
+	MTU                 *MTU                   `xml:"mtu,omitempty"`
+	BandWidth           *BandWidth             `xml:"bandwidth,omitempty"`
+	BootOrder           *BootOrder             `xml:"boot,omitempty"`
+	LinkState           *LinkState             `xml:"link,omitempty"`
+	FilterRef           *FilterRef             `xml:"filterref,omitempty"`
+	Alias               *Alias                 `xml:"alias,omitempty"`
+	Driver              *InterfaceDriver       `xml:"driver,omitempty"`
+	Rom                 *Rom                   `xml:"rom,omitempty"`
+	Backend             *InterfaceBackend      `xml:"backend,omitempty"`
+	PortForward         []InterfacePortForward `xml:"portForward,omitempty"`
+	Teaming             *Teaming               `xml:"teaming,omitempty"`
+}
+
+// Teaming pairs a transient passthrough device with a persistent virtio interface, which the guest
+// bonds with its net_failover driver https://libvirt.org/formatdomain.html#teaming-a-virtio-hostdev-nic-pair
+type Teaming struct {
+	Type       string `xml:"type,attr"`
+	Persistent string `xml:"persistent,attr,omitempty"`
 }
 
 type InterfaceDriver struct {
-	Name   string `xml:"name,attr"`
-	Queues *uint  `xml:"queues,attr,omitempty"`
+	Name          string                `xml:"name,attr,omitempty"`
+	Queues        *uint                 `xml:"queues,attr,omitempty"`
+	IOMMU         string                `xml:"iommu,attr,omitempty"`
+	RSS           string                `xml:"rss,attr,omitempty"`
+	RSSHashReport string                `xml:"rss_hash_report,attr,omitempty"`
+	Host          *InterfaceDriverHost  `xml:"host,omitempty"`
+	Guest         *InterfaceDriverGuest `xml:"guest,omitempty"`
+}
+
+// InterfaceDriverHost toggles the offloads of the packets sent by the guest
+type InterfaceDriverHost struct {
+	CSum string `xml:"csum,attr,omitempty"`
+	GSO  string `xml:"gso,attr,omitempty"`
+	TSO4 string `xml:"tso4,attr,omitempty"`
+	TSO6 string `xml:"tso6,attr,omitempty"`
+}
+
+// InterfaceDriverGuest toggles the offloads of the packets received by the guest
+type InterfaceDriverGuest struct {
+	CSum string `xml:"csum,attr,omitempty"`
+	TSO4 string `xml:"tso4,attr,omitempty"`
+	TSO6 string `xml:"tso6,attr,omitempty"`
 }
 
 type LinkState struct {
@@ -636,6 +919,15 @@ type LinkState struct {
 }
 
 type BandWidth struct {
+	Inbound  *BandWidthLimit `xml:"inbound,omitempty"`
+	Outbound *BandWidthLimit `xml:"outbound,omitempty"`
+}
+
+// BandWidthLimit rates are in KiB per second, and the burst in KiB
+type BandWidthLimit struct {
+	Average string `xml:"average,attr"`
+	Peak    string `xml:"peak,attr,omitempty"`
+	Burst   string `xml:"burst,attr,omitempty"`
 }
 
 type BootOrder struct {
@@ -655,6 +947,8 @@ type FilterRef struct {
 }
 
 type InterfaceSource struct {
+	Type    string   `xml:"type,attr,omitempty"`
+	Path    string   `xml:"path,attr,omitempty"`
 	Network string   `xml:"network,attr,omitempty"`
 	Device  string   `xml:"dev,attr,omitempty"`
 	Bridge  string   `xml:"bridge,attr,omitempty"`
@@ -666,6 +960,22 @@ type Model struct {
 	Type string `xml:"type,attr"`
 }
 
+type InterfaceBackend struct {
+	Type    string `xml:"type,attr,omitempty"`
+	LogFile string `xml:"logFile,attr,omitempty"`
+}
+
+type InterfacePortForward struct {
+	Proto  string                      `xml:"proto,attr"`
+	Ranges []InterfacePortForwardRange `xml:"range,omitempty"`
+}
+
+type InterfacePortForwardRange struct {
+	Start uint `xml:"start,attr"`
+	End   uint `xml:"end,attr,omitempty"`
+	To    uint `xml:"to,attr,omitempty"`
+}
+
 type InterfaceTarget struct {
 	Device  string `xml:"dev,attr"`
 	Managed string `xml:"managed,attr,omitempty"`
@@ -775,10 +1085,11 @@ type BootMenu struct {
 }
 
 type Loader struct {
-	ReadOnly string `xml:"readonly,attr,omitempty"`
-	Secure   string `xml:"secure,attr,omitempty"`
-	Type     string `xml:"type,attr,omitempty"`
-	Path     string `xml:",chardata"`
+	ReadOnly  string `xml:"readonly,attr,omitempty"`
+	Secure    string `xml:"secure,attr,omitempty"`
+	Type      string `xml:"type,attr,omitempty"`
+	Stateless string `xml:"stateless,attr,omitempty"`
+	Path      string `xml:",chardata"`
 }
 
 // TODO <bios rebootTimeout='0'/>
@@ -886,6 +1197,7 @@ type Address struct {
 	Target     string `xml:"target,attr,omitempty"`
 	Unit       string `xml:"unit,attr,omitempty"`
 	UUID       string `xml:"uuid,attr,omitempty"`
+	Device     string `xml:"device,attr,omitempty"`
 }
 
 //END Video -------------------
@@ -895,9 +1207,10 @@ type Stats struct {
 }
 
 type MemBalloon struct {
-	Model   string   `xml:"model,attr"`
-	Stats   *Stats   `xml:"stats,omitempty"`
-	Address *Address `xml:"address,emitempty"`
+	Model             string   `xml:"model,attr"`
+	FreePageReporting string   `xml:"freePageReporting,attr,omitempty"`
+	Stats             *Stats   `xml:"stats,omitempty"`
+	Address           *Address `xml:"address,emitempty"`
 }
 
 type Watchdog struct {
@@ -913,7 +1226,38 @@ type Rng struct {
 	Model string `xml:"model,attr"`
 	// Backend specifies the source of entropy to be used
 	Backend *RngBackend `xml:"backend,omitempty"`
-	Address *Address    `xml:"address,emitempty"`
+	// Driver specifies the virtio options of the device
+	Driver  *RngDriver `xml:"driver,omitempty"`
+	Address *Address   `xml:"address,emitempty"`
+}
+
+// RngDriver represents the virtio options of the RNG device
+type RngDriver struct {
+	IOMMU string `xml:"iommu,attr,omitempty"`
+}
+
+// VSOCK represents a virtio socket device for host-guest communication
+type VSOCK struct {
+	Model string `xml:"model,attr,omitempty"`
+	// CID is the guest address of the device, it must be unique on the host
+	CID    CID          `xml:"cid"`
+	Driver *VSOCKDriver `xml:"driver,omitempty"`
+}
+
+type CID struct {
+	Auto    string `xml:"auto,attr"`
+	Address uint32 `xml:"address,attr,omitempty"`
+}
+
+// VSOCKDriver represents the virtio options of the VSOCK device
+type VSOCKDriver struct {
+	IOMMU string `xml:"iommu,attr,omitempty"`
+}
+
+// PanicDevice represents the device through which the guest reports kernel panics
+type PanicDevice struct {
+	Model   string   `xml:"model,attr,omitempty"`
+	Address *Address `xml:"address,omitempty"`
 }
 
 // RngRate sets the limiting factor how to read from entropy source
@@ -932,6 +1276,18 @@ type RngBackend struct {
 	Source string `xml:",chardata"`
 }
 
+// TPM mirroring libvirt XML under https://libvirt.org/formatdomain.html#tpm-device
+type TPM struct {
+	Model   string     `xml:"model,attr"`
+	Backend TPMBackend `xml:"backend"`
+}
+
+type TPMBackend struct {
+	Type            string `xml:"type,attr"`
+	Version         string `xml:"version,attr"`
+	PersistentState string `xml:"persistent_state,attr,omitempty"`
+}
+
 type IOThreads struct {
 	IOThreads uint `xml:",chardata"`
 }
@@ -941,6 +1297,7 @@ type IOThreads struct {
 type SecretUsage struct {
 	Type   string `xml:"type,attr"`
 	Target string `xml:"target,omitempty"`
+	Volume string `xml:"volume,omitempty"`
 }
 
 type SecretSpec struct {
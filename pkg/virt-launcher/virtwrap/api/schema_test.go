@@ -414,6 +414,54 @@ var _ = Describe("Schema", func() {
 			Expect(newCpuTune).To(Equal(exampleCpuTune))
 		})
 	})
+	Context("With a virtio-mem device", func() {
+		var testXML = `<memory model="virtio-mem">
+<target>
+<size unit="KiB">3145728</size>
+<node>0</node>
+<block unit="KiB">2048</block>
+<requested unit="KiB">1048576</requested>
+<current unit="KiB">1048576</current>
+</target>
+</memory>`
+
+		It("Unmarshal into struct", func() {
+			memory := MemoryDevice{}
+			Expect(xml.Unmarshal([]byte(testXML), &memory)).To(Succeed())
+			Expect(memory.Model).To(Equal("virtio-mem"))
+			Expect(memory.Target.Node).To(Equal("0"))
+			Expect(memory.Target.Requested).To(Equal(Memory{Value: 1048576, Unit: "KiB"}))
+			Expect(memory.Target.Current).To(Equal(&Memory{Value: 1048576, Unit: "KiB"}))
+		})
+
+		table.DescribeTable("should convert memory to bytes", func(memory Memory, expected uint64) {
+			bytes, err := memory.Bytes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(Equal(expected))
+		},
+			table.Entry("without unit", Memory{Value: 1024}, uint64(1024)),
+			table.Entry("in KiB", Memory{Value: 2048, Unit: "KiB"}, uint64(2*1024*1024)),
+			table.Entry("in MB", Memory{Value: 2, Unit: "MB"}, uint64(2*1000*1000)),
+			table.Entry("in GiB", Memory{Value: 1, Unit: "G"}, uint64(1024*1024*1024)),
+		)
+
+		It("should reject an unknown unit", func() {
+			_, err := Memory{Value: 1, Unit: "pages"}.Bytes()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should sum up the memory of all NUMA cells", func() {
+			numa := &NUMA{
+				Cells: []NUMACell{
+					{ID: "0", Memory: "1048576", Unit: "KiB"},
+					{ID: "1", Memory: "1", Unit: "GiB"},
+				},
+			}
+			total, err := numa.TotalMemory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(Equal(uint64(2 * 1024 * 1024 * 1024)))
+		})
+	})
 })
 
 var testAliasName = "alias0"
@@ -0,0 +1,233 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+)
+
+type domainBackup struct {
+	XMLName     xml.Name           `xml:"domainbackup"`
+	Mode        string             `xml:"mode,attr"`
+	Incremental string             `xml:"incremental,omitempty"`
+	Server      domainBackupServer `xml:"server"`
+	Disks       []domainBackupDisk `xml:"disks>disk"`
+}
+
+type domainBackupServer struct {
+	Transport string `xml:"transport,attr"`
+	Socket    string `xml:"socket,attr"`
+}
+
+type domainBackupDisk struct {
+	Name         string `xml:"name,attr"`
+	Backup       string `xml:"backup,attr"`
+	ExportName   string `xml:"exportname,attr,omitempty"`
+	ExportBitmap string `xml:"exportbitmap,attr,omitempty"`
+}
+
+type domainCheckpoint struct {
+	XMLName xml.Name               `xml:"domaincheckpoint"`
+	Name    string                 `xml:"name"`
+	Disks   []domainCheckpointDisk `xml:"disks>disk"`
+}
+
+type domainCheckpointDisk struct {
+	Name       string `xml:"name,attr"`
+	Checkpoint string `xml:"checkpoint,attr"`
+}
+
+// Only qcow2 images can track the blocks written since a checkpoint in persistent bitmaps
+func isBackupDisk(disk api.Disk) bool {
+	return disk.Driver != nil && disk.Driver.Type == "qcow2" && disk.ReadOnly == nil
+}
+
+func containsCheckpoint(checkpoints []string, name string) bool {
+	for _, checkpoint := range checkpoints {
+		if checkpoint == name {
+			return true
+		}
+	}
+	return false
+}
+
+// StartBackupVMI begins a pull mode backup of the domain, whose disks libvirt exports over NBD on a unix
+// socket in the private directory of the VMI, and creates the checkpoint the next incremental backup starts from.
+func (l *LibvirtDomainManager) StartBackupVMI(vmi *v1.VirtualMachineInstance, options *v1.BackupOptions) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain for the backup failed.")
+		return err
+	}
+	defer dom.Free()
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+
+	backupMetadata := domainSpec.Metadata.KubeVirt.Backup
+	if backupMetadata == nil {
+		backupMetadata = &api.BackupMetadata{}
+	}
+	if backupMetadata.Checkpoint != "" {
+		return fmt.Errorf("backup to checkpoint %s is still running", backupMetadata.Checkpoint)
+	}
+	if containsCheckpoint(backupMetadata.Checkpoints, options.Checkpoint) {
+		return fmt.Errorf("checkpoint %s already exists", options.Checkpoint)
+	}
+	if options.Incremental != "" && !containsCheckpoint(backupMetadata.Checkpoints, options.Incremental) {
+		return fmt.Errorf("checkpoint %s does not exist", options.Incremental)
+	}
+
+	backup := domainBackup{
+		Mode:        "pull",
+		Incremental: options.Incremental,
+		Server: domainBackupServer{
+			Transport: "unix",
+			Socket:    filepath.Join(util.VirtPrivateDir, string(vmi.UID), util.BackupSocketName),
+		},
+	}
+	checkpoint := domainCheckpoint{Name: options.Checkpoint}
+	var disks []string
+	for _, disk := range domainSpec.Devices.Disks {
+		if !isBackupDisk(disk) {
+			backup.Disks = append(backup.Disks, domainBackupDisk{Name: disk.Target.Device, Backup: "no"})
+			checkpoint.Disks = append(checkpoint.Disks, domainCheckpointDisk{Name: disk.Target.Device, Checkpoint: "no"})
+			continue
+		}
+		name := disk.Alias.GetName()
+		backupDisk := domainBackupDisk{Name: disk.Target.Device, Backup: "yes", ExportName: name}
+		if options.Incremental != "" {
+			backupDisk.ExportBitmap = util.BackupBitmapName(name)
+		}
+		backup.Disks = append(backup.Disks, backupDisk)
+		checkpoint.Disks = append(checkpoint.Disks, domainCheckpointDisk{Name: disk.Target.Device, Checkpoint: "bitmap"})
+		disks = append(disks, name)
+	}
+	if len(disks) == 0 {
+		return fmt.Errorf("the VMI has no qcow2 disks which can be backed up")
+	}
+
+	backupXML, err := xml.Marshal(backup)
+	if err != nil {
+		return err
+	}
+	checkpointXML, err := xml.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := dom.BackupBegin(string(backupXML), string(checkpointXML), 0); err != nil {
+		logger.Reason(err).Error("Beginning the backup failed.")
+		return err
+	}
+	logger.Infof("Backup to checkpoint %s of disks %v began", options.Checkpoint, disks)
+
+	backupMetadata.Checkpoints = append(backupMetadata.Checkpoints, options.Checkpoint)
+	backupMetadata.Checkpoint = options.Checkpoint
+	backupMetadata.Incremental = options.Incremental
+	backupMetadata.Disks = disks
+	domainSpec.Metadata.KubeVirt.Backup = backupMetadata
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
+// StopBackupVMI ends the running backup. The checkpoints older than the one the backup started from are
+// deleted, a later backup starts either from that checkpoint or from the one the backup created.
+func (l *LibvirtDomainManager) StopBackupVMI(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain for the backup failed.")
+		return err
+	}
+	defer dom.Free()
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+
+	backupMetadata := domainSpec.Metadata.KubeVirt.Backup
+	if backupMetadata == nil || backupMetadata.Checkpoint == "" {
+		return nil
+	}
+
+	// the job is gone already if the backup failed
+	if err := dom.AbortJob(); err != nil && !domainerrors.IsInvalidOperation(err) {
+		logger.Reason(err).Error("Stopping the backup failed.")
+		return err
+	}
+	logger.Infof("Backup to checkpoint %s stopped", backupMetadata.Checkpoint)
+
+	oldest := backupMetadata.Incremental
+	if oldest == "" {
+		oldest = backupMetadata.Checkpoint
+	}
+	for len(backupMetadata.Checkpoints) > 0 && backupMetadata.Checkpoints[0] != oldest {
+		if err := deleteCheckpoint(dom, backupMetadata.Checkpoints[0]); err != nil {
+			logger.Reason(err).Errorf("Deleting checkpoint %s failed.", backupMetadata.Checkpoints[0])
+			return err
+		}
+		backupMetadata.Checkpoints = backupMetadata.Checkpoints[1:]
+	}
+
+	backupMetadata.Checkpoint = ""
+	backupMetadata.Incremental = ""
+	backupMetadata.Disks = nil
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
+func deleteCheckpoint(dom cli.VirDomain, name string) error {
+	checkpoint, err := dom.CheckpointLookupByName(name, 0)
+	if err != nil {
+		return err
+	}
+	defer checkpoint.Free()
+	return checkpoint.Delete(0)
+}
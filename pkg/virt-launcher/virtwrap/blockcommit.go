@@ -0,0 +1,367 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	libvirt "libvirt.org/libvirt-go"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+)
+
+const blockCommitPollInterval = time.Second
+
+// blockCommitDisk is a disk whose overlays files[top:base] are committed into files[base]. The files are
+// the file the guest writes to followed by its backing chain.
+type blockCommitDisk struct {
+	name      string
+	target    string
+	volumeDir string
+	files     []string
+	top       int
+	base      int
+}
+
+type domainBackingChain struct {
+	Disks []domainBackingChainDisk `xml:"devices>disk"`
+}
+
+type domainBackingChainDisk struct {
+	Target       domainBackingChainTarget `xml:"target"`
+	BackingStore *domainBackingStore      `xml:"backingStore"`
+}
+
+type domainBackingChainTarget struct {
+	Device string `xml:"dev,attr"`
+}
+
+type domainBackingStore struct {
+	Source       *domainSnapshotDiskSource `xml:"source"`
+	BackingStore *domainBackingStore       `xml:"backingStore"`
+}
+
+func isBlockCommitRunning(metadata *api.BlockCommitMetadata) bool {
+	return metadata != nil && !metadata.Completed && !metadata.Failed
+}
+
+// BlockCommitVMI starts merging the qcow2 overlays of the disks of the given volumes, which were created by
+// external snapshots, into the images of the volumes. The commit runs in the background while the guest keeps
+// writing, the disks are pivoted to the images once they caught up with the overlays and the overlays are
+// removed. If an overlay is given, only it is merged into the file below it. Its progress and its result are
+// recorded in the domain metadata.
+func (l *LibvirtDomainManager) BlockCommitVMI(vmi *v1.VirtualMachineInstance, options *v1.BlockCommitOptions) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain for the block commit failed.")
+		return err
+	}
+	defer dom.Free()
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+
+	blockCommitMetadata := domainSpec.Metadata.KubeVirt.BlockCommit
+	if isBlockCommitRunning(blockCommitMetadata) {
+		return fmt.Errorf("block commit of volumes %v is still running", blockCommitMetadata.Volumes)
+	}
+
+	if len(options.ActiveFiles) != 0 && len(options.ActiveFiles) != len(options.Volumes) {
+		return fmt.Errorf("expected the active files of %d volumes, got %d", len(options.Volumes), len(options.ActiveFiles))
+	}
+
+	var disks []blockCommitDisk
+	for i, volume := range options.Volumes {
+		var disk *api.Disk
+		for i := range domainSpec.Devices.Disks {
+			if domainSpec.Devices.Disks[i].Alias.GetName() == volume {
+				disk = &domainSpec.Devices.Disks[i]
+				break
+			}
+		}
+		if disk == nil {
+			return fmt.Errorf("volume %s has no disk", volume)
+		}
+		volumeDir := filepath.Dir(converter.GetFilesystemVolumePath(volume))
+		if disk.Type != "file" || filepath.Dir(disk.Source.File) != volumeDir {
+			return fmt.Errorf("disk %s is not an image on a filesystem volume", volume)
+		}
+		chain, err := readExternalSnapshotChain(volumeDir)
+		if err != nil {
+			return err
+		}
+		if chain == nil || len(chain.BackingChain) == 0 {
+			return fmt.Errorf("disk %s has no overlays to commit", volume)
+		}
+		if activeFile := filepath.Base(disk.Source.File); activeFile != chain.ActiveFile {
+			return fmt.Errorf("disk %s is on %s, not on %s recorded in its chain", volume, activeFile, chain.ActiveFile)
+		}
+		// external snapshots are serialized with the commit by the domain lock, a snapshot taken since virt-api
+		// checked which VirtualMachineSnapshotContents hold files of the chain has switched the disk to a new overlay
+		if len(options.ActiveFiles) != 0 && options.ActiveFiles[i] != chain.ActiveFile {
+			return fmt.Errorf("disk %s was switched from %s to %s by a snapshot since the commit was requested", volume, options.ActiveFiles[i], chain.ActiveFile)
+		}
+		files := append([]string{chain.ActiveFile}, chain.BackingChain...)
+		top, base := 0, len(files)-1
+		if options.Overlay != "" {
+			top = -1
+			for j := 0; j < len(files)-1; j++ {
+				if files[j] == options.Overlay {
+					top = j
+				}
+			}
+			if top < 0 {
+				return fmt.Errorf("disk %s has no overlay %s to commit", volume, options.Overlay)
+			}
+			base = top + 1
+		}
+		disks = append(disks, blockCommitDisk{
+			name:      volume,
+			target:    disk.Target.Device,
+			volumeDir: volumeDir,
+			files:     files,
+			top:       top,
+			base:      base,
+		})
+	}
+
+	now := metav1.Now()
+	domainSpec.Metadata.KubeVirt.BlockCommit = &api.BlockCommitMetadata{
+		Volumes:        options.Volumes,
+		StartTimestamp: &now,
+	}
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+
+	go func() {
+		err := l.commitDisks(vmi, domName, disks)
+		if err != nil {
+			logger.Reason(err).Errorf("Committing the overlays of volumes %v failed.", options.Volumes)
+		} else {
+			logger.Infof("Committed the overlays of volumes %v", options.Volumes)
+		}
+		l.updateBlockCommitMetadata(vmi, func(metadata *api.KubeVirtMetadata) {
+			now := metav1.Now()
+			metadata.BlockCommit.EndTimestamp = &now
+			if err != nil {
+				metadata.BlockCommit.Failed = true
+				metadata.BlockCommit.FailureReason = err.Error()
+			} else {
+				metadata.BlockCommit.Progress = 100
+				metadata.BlockCommit.Completed = true
+			}
+		})
+	}()
+
+	return nil
+}
+
+// commitDisks commits the overlays of the disks one after the other, the progress is the share of the
+// overlay data of all disks which is committed
+func (l *LibvirtDomainManager) commitDisks(vmi *v1.VirtualMachineInstance, domName string, disks []blockCommitDisk) error {
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	var progress int32
+	for i, disk := range disks {
+		reportProgress := func(info *libvirt.DomainBlockJobInfo) {
+			if info.End == 0 {
+				return
+			}
+			current := int32((uint64(i)*100 + info.Cur*100/info.End) / uint64(len(disks)))
+			if current != progress {
+				progress = current
+				l.updateBlockCommitMetadata(vmi, func(metadata *api.KubeVirtMetadata) {
+					metadata.BlockCommit.Progress = current
+				})
+			}
+		}
+
+		top := filepath.Join(disk.volumeDir, disk.files[disk.top])
+		base := filepath.Join(disk.volumeDir, disk.files[disk.base])
+		if disk.top == 0 {
+			if err := dom.BlockCommit(disk.target, base, "", 0, libvirt.DOMAIN_BLOCK_COMMIT_ACTIVE); err != nil {
+				return err
+			}
+			for {
+				info, err := dom.GetBlockJobInfo(disk.target, 0)
+				if err != nil {
+					return err
+				}
+				if info.Type != libvirt.DOMAIN_BLOCK_JOB_TYPE_ACTIVE_COMMIT {
+					return fmt.Errorf("block commit of disk %s ended before it caught up with its overlay", disk.name)
+				}
+				reportProgress(info)
+				if info.End > 0 && info.Cur == info.End {
+					break
+				}
+				time.Sleep(blockCommitPollInterval)
+			}
+			if err := dom.BlockJobAbort(disk.target, libvirt.DOMAIN_BLOCK_JOB_ABORT_PIVOT); err != nil {
+				return err
+			}
+		} else {
+			// the guest does not write to the overlay, the job ends by itself once it is committed and the
+			// file above it is backed by the base then
+			if err := dom.BlockCommit(disk.target, base, top, 0, 0); err != nil {
+				return err
+			}
+			for {
+				info, err := dom.GetBlockJobInfo(disk.target, 0)
+				if err != nil {
+					return err
+				}
+				if info.Type != libvirt.DOMAIN_BLOCK_JOB_TYPE_COMMIT {
+					break
+				}
+				reportProgress(info)
+				time.Sleep(blockCommitPollInterval)
+			}
+			backingFiles, err := diskBackingFiles(dom, disk.target)
+			if err != nil {
+				return err
+			}
+			for _, file := range backingFiles {
+				if file == disk.files[disk.top] {
+					return fmt.Errorf("block commit of overlay %s of disk %s ended before the overlay was dropped from its chain", file, disk.name)
+				}
+			}
+		}
+
+		chain, err := commitExternalSnapshotChain(disk.volumeDir, disk.files, disk.top, disk.base)
+		if err != nil {
+			return err
+		}
+		l.updateBlockCommitMetadata(vmi, func(metadata *api.KubeVirtMetadata) {
+			var snapshotMetadata []api.ExternalSnapshotMetadata
+			for _, snapshot := range metadata.ExternalSnapshots {
+				if snapshot.Name != disk.name {
+					snapshotMetadata = append(snapshotMetadata, snapshot)
+				}
+			}
+			if chain != nil {
+				snapshotMetadata = append(snapshotMetadata, externalSnapshotMetadata(disk.name, chain))
+			}
+			metadata.ExternalSnapshots = snapshotMetadata
+		})
+	}
+	return nil
+}
+
+// diskBackingFiles returns the names of the files backing the disk with the given target on the running domain
+func diskBackingFiles(dom cli.VirDomain, target string) ([]string, error) {
+	domainXML, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return nil, err
+	}
+	domain := &domainBackingChain{}
+	if err := xml.Unmarshal([]byte(domainXML), domain); err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, disk := range domain.Disks {
+		if disk.Target.Device != target {
+			continue
+		}
+		for backingStore := disk.BackingStore; backingStore != nil; backingStore = backingStore.BackingStore {
+			if backingStore.Source != nil {
+				files = append(files, filepath.Base(backingStore.Source.File))
+			}
+		}
+	}
+	return files, nil
+}
+
+// commitExternalSnapshotChain records the chain left once files[top:base] were committed into files[base] and
+// removes them. The chain is recorded first, so that the disk is started from the file it was pivoted to or is
+// still on, the committed files are not written anymore. It returns nil if the disk is on the image of the
+// volume again, the chain file is removed then. virt-api only requests the commit if no
+// VirtualMachineSnapshotContent holds a snapshot in a file whose content is lost by it.
+func commitExternalSnapshotChain(volumeDir string, files []string, top, base int) (*externalSnapshotChain, error) {
+	remaining := append(append([]string{}, files[:top]...), files[base:]...)
+	var chain *externalSnapshotChain
+	if len(remaining) > 1 {
+		chain = &externalSnapshotChain{ActiveFile: remaining[0], BackingChain: remaining[1:]}
+		if err := writeExternalSnapshotChain(volumeDir, chain); err != nil {
+			return nil, err
+		}
+	} else if err := os.Remove(filepath.Join(volumeDir, externalSnapshotChainFile)); err != nil {
+		return nil, err
+	}
+	for _, file := range files[top:base] {
+		if err := os.Remove(filepath.Join(volumeDir, file)); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return chain, nil
+}
+
+func (l *LibvirtDomainManager) updateBlockCommitMetadata(vmi *v1.VirtualMachineInstance, update func(metadata *api.KubeVirtMetadata)) {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	dom, err := l.virConn.LookupDomainByName(api.VMINamespaceKeyFunc(vmi))
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain to record the block commit failed.")
+		return
+	}
+	defer dom.Free()
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec to record the block commit failed.")
+		return
+	}
+
+	if domainSpec.Metadata.KubeVirt.BlockCommit == nil {
+		domainSpec.Metadata.KubeVirt.BlockCommit = &api.BlockCommitMetadata{}
+	}
+	update(&domainSpec.Metadata.KubeVirt)
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		logger.Reason(err).Error("Recording the block commit failed.")
+		return
+	}
+	defer d.Free()
+}
@@ -104,6 +104,16 @@ func (_mr *_MockConnectionRecorder) AgentEventLifecycleRegister(arg0 interface{}
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "AgentEventLifecycleRegister", arg0)
 }
 
+func (_m *MockConnection) DomainEventWatchdogRegister(callback libvirt_go.DomainEventWatchdogCallback) error {
+	ret := _m.ctrl.Call(_m, "DomainEventWatchdogRegister", callback)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) DomainEventWatchdogRegister(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DomainEventWatchdogRegister", arg0)
+}
+
 func (_m *MockConnection) VolatileDomainEventDeviceRemovedRegister(domain VirDomain, callback libvirt_go.DomainEventDeviceRemovedCallback) (int, error) {
 	ret := _m.ctrl.Call(_m, "VolatileDomainEventDeviceRemovedRegister", domain, callback)
 	ret0, _ := ret[0].(int)
@@ -188,6 +198,27 @@ func (_mr *_MockConnectionRecorder) GetDomainStats(arg0, arg1 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDomainStats", arg0, arg1)
 }
 
+func (_m *MockConnection) GetSEVInfo() (*libvirt_go.NodeSEVParameters, error) {
+	ret := _m.ctrl.Call(_m, "GetSEVInfo")
+	ret0, _ := ret[0].(*libvirt_go.NodeSEVParameters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockConnectionRecorder) GetSEVInfo() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSEVInfo")
+}
+
+func (_m *MockConnection) DefineVolumeSecret(usageID string, xml string, value []byte) error {
+	ret := _m.ctrl.Call(_m, "DefineVolumeSecret", usageID, xml, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) DefineVolumeSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DefineVolumeSecret", arg0, arg1, arg2)
+}
+
 // Mock of Stream interface
 type MockStream struct {
 	ctrl     *gomock.Controller
@@ -294,6 +325,16 @@ func (_mr *_MockVirDomainRecorder) Create() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create")
 }
 
+func (_m *MockVirDomain) CreateWithFlags(flags libvirt_go.DomainCreateFlags) error {
+	ret := _m.ctrl.Call(_m, "CreateWithFlags", flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) CreateWithFlags(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateWithFlags", arg0)
+}
+
 func (_m *MockVirDomain) Suspend() error {
 	ret := _m.ctrl.Call(_m, "Suspend")
 	ret0, _ := ret[0].(error)
@@ -523,6 +564,152 @@ func (_mr *_MockVirDomainRecorder) AbortJob() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "AbortJob")
 }
 
+func (_m *MockVirDomain) GetLaunchSecurityInfo(flags uint32) (*libvirt_go.DomainLaunchSecurityParameters, error) {
+	ret := _m.ctrl.Call(_m, "GetLaunchSecurityInfo", flags)
+	ret0, _ := ret[0].(*libvirt_go.DomainLaunchSecurityParameters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) GetLaunchSecurityInfo(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetLaunchSecurityInfo", arg0)
+}
+
+func (_m *MockVirDomain) QemuMonitorCommand(command string, flags libvirt_go.DomainQemuMonitorCommandFlags) (string, error) {
+	ret := _m.ctrl.Call(_m, "QemuMonitorCommand", command, flags)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) QemuMonitorCommand(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "QemuMonitorCommand", arg0, arg1)
+}
+
+func (_m *MockVirDomain) SetVcpusFlags(vcpu uint, flags libvirt_go.DomainVcpuFlags) error {
+	ret := _m.ctrl.Call(_m, "SetVcpusFlags", vcpu, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) SetVcpusFlags(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetVcpusFlags", arg0, arg1)
+}
+
+func (_m *MockVirDomain) SetMemoryFlags(memory uint64, flags libvirt_go.DomainMemoryModFlags) error {
+	ret := _m.ctrl.Call(_m, "SetMemoryFlags", memory, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) SetMemoryFlags(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetMemoryFlags", arg0, arg1)
+}
+
+func (_m *MockVirDomain) GetBlockInfo(disk string, flags uint) (*libvirt_go.DomainBlockInfo, error) {
+	ret := _m.ctrl.Call(_m, "GetBlockInfo", disk, flags)
+	ret0, _ := ret[0].(*libvirt_go.DomainBlockInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) GetBlockInfo(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBlockInfo", arg0, arg1)
+}
+
+func (_m *MockVirDomain) BlockResize(disk string, size uint64, flags libvirt_go.DomainBlockResizeFlags) error {
+	ret := _m.ctrl.Call(_m, "BlockResize", disk, size, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) BlockResize(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BlockResize", arg0, arg1, arg2)
+}
+
+func (_m *MockVirDomain) BackupBegin(backupXML string, checkpointXML string, flags libvirt_go.DomainBackupBeginFlags) error {
+	ret := _m.ctrl.Call(_m, "BackupBegin", backupXML, checkpointXML, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) BackupBegin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BackupBegin", arg0, arg1, arg2)
+}
+
+func (_m *MockVirDomain) CoreDumpWithFormat(to string, format libvirt_go.DomainCoreDumpFormat, flags libvirt_go.DomainCoreDumpFlags) error {
+	ret := _m.ctrl.Call(_m, "CoreDumpWithFormat", to, format, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) CoreDumpWithFormat(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CoreDumpWithFormat", arg0, arg1, arg2)
+}
+
+func (_m *MockVirDomain) CheckpointLookupByName(name string, flags uint32) (*libvirt_go.DomainCheckpoint, error) {
+	ret := _m.ctrl.Call(_m, "CheckpointLookupByName", name, flags)
+	ret0, _ := ret[0].(*libvirt_go.DomainCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) CheckpointLookupByName(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CheckpointLookupByName", arg0, arg1)
+}
+
+func (_m *MockVirDomain) CreateSnapshotXML(xml string, flags libvirt_go.DomainSnapshotCreateFlags) (*libvirt_go.DomainSnapshot, error) {
+	ret := _m.ctrl.Call(_m, "CreateSnapshotXML", xml, flags)
+	ret0, _ := ret[0].(*libvirt_go.DomainSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) CreateSnapshotXML(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateSnapshotXML", arg0, arg1)
+}
+
+func (_m *MockVirDomain) BlockCommit(disk string, base string, top string, bandwidth uint64, flags libvirt_go.DomainBlockCommitFlags) error {
+	ret := _m.ctrl.Call(_m, "BlockCommit", disk, base, top, bandwidth, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) BlockCommit(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BlockCommit", arg0, arg1, arg2, arg3, arg4)
+}
+
+func (_m *MockVirDomain) GetBlockJobInfo(disk string, flags libvirt_go.DomainBlockJobInfoFlags) (*libvirt_go.DomainBlockJobInfo, error) {
+	ret := _m.ctrl.Call(_m, "GetBlockJobInfo", disk, flags)
+	ret0, _ := ret[0].(*libvirt_go.DomainBlockJobInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) GetBlockJobInfo(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBlockJobInfo", arg0, arg1)
+}
+
+func (_m *MockVirDomain) BlockJobAbort(disk string, flags libvirt_go.DomainBlockJobAbortFlags) error {
+	ret := _m.ctrl.Call(_m, "BlockJobAbort", disk, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) BlockJobAbort(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BlockJobAbort", arg0, arg1)
+}
+
+func (_m *MockVirDomain) UpdateDeviceFlags(xml string, flags libvirt_go.DomainDeviceModifyFlags) error {
+	ret := _m.ctrl.Call(_m, "UpdateDeviceFlags", xml, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) UpdateDeviceFlags(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateDeviceFlags", arg0, arg1)
+}
+
 func (_m *MockVirDomain) Free() error {
 	ret := _m.ctrl.Call(_m, "Free")
 	ret0, _ := ret[0].(error)
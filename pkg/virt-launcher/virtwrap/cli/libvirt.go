@@ -50,6 +50,7 @@ type Connection interface {
 	DomainEventDeviceAddedRegister(callback libvirt.DomainEventDeviceAddedCallback) error
 	DomainEventDeviceRemovedRegister(callback libvirt.DomainEventDeviceRemovedCallback) error
 	AgentEventLifecycleRegister(callback libvirt.DomainEventAgentLifecycleCallback) error
+	DomainEventWatchdogRegister(callback libvirt.DomainEventWatchdogCallback) error
 	VolatileDomainEventDeviceRemovedRegister(domain VirDomain, callback libvirt.DomainEventDeviceRemovedCallback) (int, error)
 	DomainEventDeregister(registrationID int) error
 	ListAllDomains(flags libvirt.ConnectListAllDomainsFlags) ([]VirDomain, error)
@@ -62,6 +63,10 @@ type Connection interface {
 	// 1. avoid to expose to the client code the libvirt-specific return type, see docs in stats/ subpackage
 	// 2. transparently handling the addition of the memory stats, currently (libvirt 4.9) not handled by the bulk stats API
 	GetDomainStats(statsTypes libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]*stats.DomainStats, error)
+	GetSEVInfo() (*libvirt.NodeSEVParameters, error)
+	// helper method, not found in libvirt
+	// Defines the volume secret with the given usage, unless it already exists, and sets its value
+	DefineVolumeSecret(usageID string, xml string, value []byte) error
 }
 
 type Stream interface {
@@ -86,6 +91,7 @@ type LibvirtConnection struct {
 	domainEventCallbacks                   []libvirt.DomainEventLifecycleCallback
 	domainDeviceAddedEventCallbacks        []libvirt.DomainEventDeviceAddedCallback
 	domainDeviceRemovedEventCallbacks      []libvirt.DomainEventDeviceRemovedCallback
+	domainWatchdogEventCallbacks           []libvirt.DomainEventWatchdogCallback
 	domainEventMigrationIterationCallbacks []libvirt.DomainEventMigrationIterationCallback
 	agentEventCallbacks                    []libvirt.DomainEventAgentLifecycleCallback
 }
@@ -185,6 +191,17 @@ func (l *LibvirtConnection) AgentEventLifecycleRegister(callback libvirt.DomainE
 	return
 }
 
+func (l *LibvirtConnection) DomainEventWatchdogRegister(callback libvirt.DomainEventWatchdogCallback) (err error) {
+	if err = l.reconnectIfNecessary(); err != nil {
+		return
+	}
+
+	l.domainWatchdogEventCallbacks = append(l.domainWatchdogEventCallbacks, callback)
+	_, err = l.Connect.DomainEventWatchdogRegister(nil, callback)
+	l.checkConnectionLost(err)
+	return
+}
+
 func (l *LibvirtConnection) VolatileDomainEventDeviceRemovedRegister(domain VirDomain, callback libvirt.DomainEventDeviceRemovedCallback) (int, error) {
 	var dom *libvirt.Domain
 	if domain != nil {
@@ -308,6 +325,43 @@ func (l *LibvirtConnection) GetDomainStats(statsTypes libvirt.DomainStatsTypes,
 	return list, nil
 }
 
+func (l *LibvirtConnection) GetSEVInfo() (*libvirt.NodeSEVParameters, error) {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return nil, err
+	}
+
+	sevParameters, err := l.Connect.GetSEVInfo(0)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return nil, err
+	}
+	return sevParameters, nil
+}
+
+func (l *LibvirtConnection) DefineVolumeSecret(usageID string, xml string, value []byte) error {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return err
+	}
+
+	secret, err := l.Connect.LookupSecretByUsage(libvirt.SECRET_USAGE_TYPE_VOLUME, usageID)
+	if err != nil {
+		if !errors.IsSecretNotFound(err) {
+			l.checkConnectionLost(err)
+			return err
+		}
+		secret, err = l.Connect.SecretDefineXML(xml, 0)
+		if err != nil {
+			l.checkConnectionLost(err)
+			return err
+		}
+	}
+	defer secret.Free()
+
+	err = secret.SetValue(value, 0)
+	l.checkConnectionLost(err)
+	return err
+}
+
 func (l *LibvirtConnection) GetDeviceAliasMap(domain *libvirt.Domain) (map[string]string, error) {
 	devAliasMap := make(map[string]string)
 
@@ -399,6 +453,10 @@ func (l *LibvirtConnection) reconnectIfNecessary() (err error) {
 			log.Log.Info("Re-registered domain device removed callback")
 			_, err = l.Connect.DomainEventDeviceRemovedRegister(nil, callback)
 		}
+		for _, callback := range l.domainWatchdogEventCallbacks {
+			log.Log.Info("Re-registered domain watchdog callback")
+			_, err = l.Connect.DomainEventWatchdogRegister(nil, callback)
+		}
 
 		log.Log.Error("Re-registered domain and agent callbacks for new connection")
 
@@ -442,6 +500,7 @@ func (l *LibvirtConnection) checkConnectionLost(err error) {
 type VirDomain interface {
 	GetState() (libvirt.DomainState, int, error)
 	Create() error
+	CreateWithFlags(flags libvirt.DomainCreateFlags) error
 	Suspend() error
 	Resume() error
 	AttachDevice(xml string) error
@@ -464,6 +523,20 @@ type VirDomain interface {
 	SetTime(secs int64, nsecs uint, flags libvirt.DomainSetTimeFlags) error
 	IsPersistent() (bool, error)
 	AbortJob() error
+	GetLaunchSecurityInfo(flags uint32) (*libvirt.DomainLaunchSecurityParameters, error)
+	QemuMonitorCommand(command string, flags libvirt.DomainQemuMonitorCommandFlags) (string, error)
+	SetVcpusFlags(vcpu uint, flags libvirt.DomainVcpuFlags) error
+	UpdateDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error
+	SetMemoryFlags(memory uint64, flags libvirt.DomainMemoryModFlags) error
+	GetBlockInfo(disk string, flags uint) (*libvirt.DomainBlockInfo, error)
+	BlockResize(disk string, size uint64, flags libvirt.DomainBlockResizeFlags) error
+	BackupBegin(backupXML string, checkpointXML string, flags libvirt.DomainBackupBeginFlags) error
+	CoreDumpWithFormat(to string, format libvirt.DomainCoreDumpFormat, flags libvirt.DomainCoreDumpFlags) error
+	CheckpointLookupByName(name string, flags uint32) (*libvirt.DomainCheckpoint, error)
+	CreateSnapshotXML(xml string, flags libvirt.DomainSnapshotCreateFlags) (*libvirt.DomainSnapshot, error)
+	BlockCommit(disk string, base string, top string, bandwidth uint64, flags libvirt.DomainBlockCommitFlags) error
+	GetBlockJobInfo(disk string, flags libvirt.DomainBlockJobInfoFlags) (*libvirt.DomainBlockJobInfo, error)
+	BlockJobAbort(disk string, flags libvirt.DomainBlockJobAbortFlags) error
 	Free() error
 }
 
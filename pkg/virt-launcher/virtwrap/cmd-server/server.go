@@ -202,6 +202,135 @@ func (l *Launcher) UnpauseVirtualMachine(ctx context.Context, request *cmdv1.VMI
 	return response, nil
 }
 
+func (l *Launcher) FreezeVirtualMachine(ctx context.Context, request *cmdv1.FreezeRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.FreezeVMI(vmi, request.UnfreezeTimeoutSeconds); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to freeze vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Froze vmi")
+	return response, nil
+}
+
+func (l *Launcher) UnfreezeVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.UnfreezeVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to unfreeze vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Unfroze vmi")
+	return response, nil
+}
+
+func (l *Launcher) SyncVirtualMachineCPUs(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.UpdateVCPUs(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to update vcpus")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Synced vcpus")
+	return response, nil
+}
+
+func (l *Launcher) SyncVirtualMachineMemory(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.UpdateGuestMemory(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to update guest memory")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Synced guest memory")
+	return response, nil
+}
+
+func (l *Launcher) SetVirtualMachineMemoryBalloon(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.SetGuestMemoryBalloon(vmi, request.Options.GetBalloonTarget()); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to set the memory balloon target")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Set the memory balloon target")
+	return response, nil
+}
+
+func (l *Launcher) Exec(ctx context.Context, request *cmdv1.ExecRequest) (*cmdv1.ExecResponse, error) {
+	resp := &cmdv1.ExecResponse{
+		Response: &cmdv1.Response{
+			Success: true,
+		},
+	}
+
+	exitCode, stdOut, err := l.domainManager.Exec(request.DomainName, request.Command, request.Args, request.TimeoutSeconds)
+	if err != nil {
+		resp.Response.Success = false
+		resp.Response.Message = getErrorMessage(err)
+		return resp, nil
+	}
+
+	resp.ExitCode = int32(exitCode)
+	resp.StdOut = stdOut
+	return resp, nil
+}
+
+func (l *Launcher) GuestPing(ctx context.Context, request *cmdv1.GuestPingRequest) (*cmdv1.GuestPingResponse, error) {
+	resp := &cmdv1.GuestPingResponse{
+		Response: &cmdv1.Response{
+			Success: true,
+		},
+	}
+
+	pinged := make(chan error, 1)
+	go func() {
+		pinged <- l.domainManager.GuestPing(request.DomainName)
+	}()
+
+	var err error
+	select {
+	case err = <-pinged:
+	case <-time.After(time.Duration(request.TimeoutSeconds) * time.Second):
+		err = fmt.Errorf("timed out waiting for the guest agent to respond")
+	}
+	if err != nil {
+		resp.Response.Success = false
+		resp.Response.Message = getErrorMessage(err)
+	}
+	return resp, nil
+}
+
 func (l *Launcher) KillVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
@@ -471,6 +600,199 @@ func RunServer(socketPath string,
 	return done, nil
 }
 
+func (l *Launcher) GetSEVInfo(ctx context.Context, request *cmdv1.EmptyRequest) (*cmdv1.SEVInfoResponse, error) {
+	response := &cmdv1.SEVInfoResponse{
+		Response: &cmdv1.Response{
+			Success: true,
+		},
+	}
+
+	sevPlatformInfo, err := l.domainManager.GetSEVInfo()
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to get SEV platform info")
+		response.Response.Success = false
+		response.Response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	if sevPlatformInfoJson, err := json.Marshal(sevPlatformInfo); err != nil {
+		log.Log.Reason(err).Errorf("Failed to marshal SEV platform info")
+		response.Response.Success = false
+		response.Response.Message = getErrorMessage(err)
+		return response, nil
+	} else {
+		response.SevInfo = sevPlatformInfoJson
+	}
+
+	return response, nil
+}
+
+func (l *Launcher) GetLaunchMeasurement(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.LaunchMeasurementResponse, error) {
+	vmi, vmiResponse := getVMIFromRequest(request.Vmi)
+	response := &cmdv1.LaunchMeasurementResponse{
+		Response: vmiResponse,
+	}
+	if !vmiResponse.Success {
+		return response, nil
+	}
+
+	sevMeasurementInfo, err := l.domainManager.GetLaunchMeasurement(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get launch measurement")
+		response.Response.Success = false
+		response.Response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	if sevMeasurementInfoJson, err := json.Marshal(sevMeasurementInfo); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to marshal launch measurement info")
+		response.Response.Success = false
+		response.Response.Message = getErrorMessage(err)
+		return response, nil
+	} else {
+		response.LaunchMeasurement = sevMeasurementInfoJson
+	}
+
+	return response, nil
+}
+
+func (l *Launcher) InjectLaunchSecret(ctx context.Context, request *cmdv1.InjectLaunchSecretRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	var sevSecretOptions v1.SEVSecretOptions
+	if err := json.Unmarshal(request.Options, &sevSecretOptions); err != nil {
+		response.Success = false
+		response.Message = "No valid secret options present in command server request"
+		return response, nil
+	}
+
+	if err := l.domainManager.InjectLaunchSecret(vmi, &sevSecretOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to inject SEV launch secret")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Injected SEV launch secret")
+	return response, nil
+}
+
+func (l *Launcher) StartVirtualMachineBackup(ctx context.Context, request *cmdv1.BackupRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	var backupOptions v1.BackupOptions
+	if err := json.Unmarshal(request.Options, &backupOptions); err != nil {
+		response.Success = false
+		response.Message = "No valid backup options present in command server request"
+		return response, nil
+	}
+
+	if err := l.domainManager.StartBackupVMI(vmi, &backupOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to start backup")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Started backup")
+	return response, nil
+}
+
+func (l *Launcher) StopVirtualMachineBackup(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.StopBackupVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to stop backup")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Stopped backup")
+	return response, nil
+}
+
+func (l *Launcher) VirtualMachineMemoryDump(ctx context.Context, request *cmdv1.MemoryDumpRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	var memoryDumpOptions v1.MemoryDumpOptions
+	if err := json.Unmarshal(request.Options, &memoryDumpOptions); err != nil {
+		response.Success = false
+		response.Message = "No valid memory dump options present in command server request"
+		return response, nil
+	}
+
+	if err := l.domainManager.MemoryDumpVMI(vmi, &memoryDumpOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to start memory dump")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Started memory dump")
+	return response, nil
+}
+
+func (l *Launcher) VirtualMachineExternalSnapshot(ctx context.Context, request *cmdv1.ExternalSnapshotRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	var snapshotOptions v1.ExternalSnapshotOptions
+	if err := json.Unmarshal(request.Options, &snapshotOptions); err != nil {
+		response.Success = false
+		response.Message = "No valid external snapshot options present in command server request"
+		return response, nil
+	}
+
+	if err := l.domainManager.ExternalSnapshotVMI(vmi, &snapshotOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to take external snapshot")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Infof("Took external snapshot %s", snapshotOptions.Name)
+	return response, nil
+}
+
+func (l *Launcher) VirtualMachineBlockCommit(ctx context.Context, request *cmdv1.BlockCommitRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	var commitOptions v1.BlockCommitOptions
+	if err := json.Unmarshal(request.Options, &commitOptions); err != nil {
+		response.Success = false
+		response.Message = "No valid block commit options present in command server request"
+		return response, nil
+	}
+
+	if err := l.domainManager.BlockCommitVMI(vmi, &commitOptions); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to start block commit")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Infof("Started block commit of volumes %v", commitOptions.Volumes)
+	return response, nil
+}
+
 func (l *Launcher) Ping(ctx context.Context, request *cmdv1.EmptyRequest) (*cmdv1.Response, error) {
 	response := &cmdv1.Response{
 		Success: true,
@@ -20,6 +20,7 @@
 package cmdserver
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -120,6 +121,41 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should freeze a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().FreezeVMI(vmi, int32(300))
+			err := client.FreezeVirtualMachine(vmi, 300)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should unfreeze a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UnfreezeVMI(vmi)
+			err := client.UnfreezeVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should sync the vcpus of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UpdateVCPUs(vmi)
+			err := client.SyncVirtualMachineCPUs(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should sync the guest memory of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UpdateGuestMemory(vmi)
+			err := client.SyncVirtualMachineMemory(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should set the memory balloon target of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().SetGuestMemoryBalloon(vmi, uint64(1073741824))
+			err := client.SetVirtualMachineMemoryBalloon(vmi, 1073741824)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("should list domains", func() {
 			var list []*api.Domain
 			list = append(list, api.NewMinimalDomain("testvmi1"))
@@ -211,6 +247,164 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred(), "should fetch filesystems without any issue")
 			Expect(fetchedList.Items).To(Equal(fsList), "fetched list should be the same")
 		})
+
+		It("should return SEV platform info", func() {
+			sevPlatformInfo := &v1.SEVPlatformInfo{
+				PDH:       "AAABBB",
+				CertChain: "CCCDDD",
+			}
+
+			domainManager.EXPECT().GetSEVInfo().Return(sevPlatformInfo, nil)
+
+			fetchedInfo, err := client.GetSEVInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetchedInfo).To(Equal(sevPlatformInfo))
+		})
+
+		It("should return the launch measurement", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			sevMeasurementInfo := &v1.SEVMeasurementInfo{
+				Measurement: "AAABBB",
+				APIMajor:    1,
+				APIMinor:    2,
+				BuildID:     3,
+				Policy:      4,
+				LoaderSHA:   "CCCDDD",
+			}
+
+			domainManager.EXPECT().GetLaunchMeasurement(vmi).Return(sevMeasurementInfo, nil)
+
+			fetchedInfo, err := client.GetLaunchMeasurement(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetchedInfo).To(Equal(sevMeasurementInfo))
+		})
+
+		It("should inject the launch secret", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			sevSecretOptions := &v1.SEVSecretOptions{
+				Header: "AAABBB",
+				Secret: "CCCDDD",
+			}
+
+			domainManager.EXPECT().InjectLaunchSecret(vmi, sevSecretOptions)
+
+			err := client.InjectLaunchSecret(vmi, sevSecretOptions)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should exec a command on the guest", func() {
+			domainManager.EXPECT().Exec("default_testvmi", "cat", []string{"/tmp/ready"}, int32(5)).Return(1, "not ready", nil)
+
+			exitCode, stdOut, err := client.Exec("default_testvmi", "cat", []string{"/tmp/ready"}, 5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exitCode).To(Equal(1))
+			Expect(stdOut).To(Equal("not ready"))
+		})
+
+		It("should fail to exec a command on the guest without a guest agent", func() {
+			domainManager.EXPECT().Exec("default_testvmi", "cat", gomock.Any(), int32(5)).Return(-1, "", fmt.Errorf("guest agent is not connected"))
+
+			exitCode, _, err := client.Exec("default_testvmi", "cat", nil, 5)
+			Expect(err).To(HaveOccurred())
+			Expect(exitCode).To(Equal(-1))
+		})
+
+		It("should ping the guest agent", func() {
+			domainManager.EXPECT().GuestPing("default_testvmi")
+
+			err := client.GuestPing("default_testvmi", 5)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail to ping an unresponsive guest agent", func() {
+			domainManager.EXPECT().GuestPing("default_testvmi").Return(fmt.Errorf("guest agent is not connected"))
+
+			err := client.GuestPing("default_testvmi", 5)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should start a backup", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			backupOptions := &v1.BackupOptions{
+				Checkpoint:  "backup-2",
+				Incremental: "backup-1",
+			}
+
+			domainManager.EXPECT().StartBackupVMI(vmi, backupOptions)
+
+			err := client.StartVirtualMachineBackup(vmi, backupOptions)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail to start a backup which is refused", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().StartBackupVMI(vmi, gomock.Any()).Return(fmt.Errorf("checkpoint backup-1 does not exist"))
+
+			err := client.StartVirtualMachineBackup(vmi, &v1.BackupOptions{Checkpoint: "backup-2", Incremental: "backup-1"})
+			Expect(err).To(MatchError(ContainSubstring("checkpoint backup-1 does not exist")))
+		})
+
+		It("should stop a backup", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().StopBackupVMI(vmi)
+
+			err := client.StopVirtualMachineBackup(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should start a memory dump", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			memoryDumpOptions := &v1.MemoryDumpOptions{FileName: "testvmi.dump"}
+
+			domainManager.EXPECT().MemoryDumpVMI(vmi, memoryDumpOptions)
+
+			err := client.VirtualMachineMemoryDump(vmi, memoryDumpOptions)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail to start a memory dump which is refused", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().MemoryDumpVMI(vmi, gomock.Any()).Return(fmt.Errorf("memory dump to old.dump is still running"))
+
+			err := client.VirtualMachineMemoryDump(vmi, &v1.MemoryDumpOptions{FileName: "testvmi.dump"})
+			Expect(err).To(MatchError(ContainSubstring("memory dump to old.dump is still running")))
+		})
+
+		It("should take an external snapshot", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			snapshotOptions := &v1.ExternalSnapshotOptions{Name: "snap-1", Volumes: []string{"rootdisk"}}
+
+			domainManager.EXPECT().ExternalSnapshotVMI(vmi, snapshotOptions)
+
+			err := client.VirtualMachineExternalSnapshot(vmi, snapshotOptions)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail to take an external snapshot of a block volume", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().ExternalSnapshotVMI(vmi, gomock.Any()).Return(fmt.Errorf("disk rootdisk is not an image on a filesystem volume"))
+
+			err := client.VirtualMachineExternalSnapshot(vmi, &v1.ExternalSnapshotOptions{Name: "snap-1", Volumes: []string{"rootdisk"}})
+			Expect(err).To(MatchError(ContainSubstring("disk rootdisk is not an image on a filesystem volume")))
+		})
+
+		It("should start a block commit", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			commitOptions := &v1.BlockCommitOptions{Volumes: []string{"rootdisk"}}
+
+			domainManager.EXPECT().BlockCommitVMI(vmi, commitOptions)
+
+			err := client.VirtualMachineBlockCommit(vmi, commitOptions)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail to start a block commit which is refused", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().BlockCommitVMI(vmi, gomock.Any()).Return(fmt.Errorf("block commit of volumes [rootdisk] is still running"))
+
+			err := client.VirtualMachineBlockCommit(vmi, &v1.BlockCommitOptions{Volumes: []string{"rootdisk"}})
+			Expect(err).To(MatchError(ContainSubstring("block commit of volumes [rootdisk] is still running")))
+		})
 	})
 
 	Describe("Version mismatch", func() {
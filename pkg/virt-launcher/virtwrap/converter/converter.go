@@ -40,10 +40,12 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
+	backendstorage "kubevirt.io/kubevirt/pkg/backend-storage"
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	"kubevirt.io/kubevirt/pkg/config"
 
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
 	"kubevirt.io/kubevirt/pkg/emptydisk"
 	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
@@ -60,12 +62,29 @@ const (
 	EFIVars                          = "OVMF_VARS.fd"
 	EFICodeSecureBoot                = "OVMF_CODE.secboot.fd"
 	EFIVarsSecureBoot                = "OVMF_VARS.secboot.fd"
+	EFICodeSEVSNP                    = "OVMF.amdsev.fd"
+	EFICodeTDX                       = "OVMF.inteltdx.fd"
+	EFICodeAARCH64                   = "AAVMF_CODE.fd"
+	EFIVarsAARCH64                   = "AAVMF_VARS.fd"
 	HostDevicePCI     HostDeviceType = "pci"
 	HostDeviceMDEV    HostDeviceType = "mdev"
+	HostDeviceUSB     HostDeviceType = "usb"
 	resolvConf                       = "/etc/resolv.conf"
 )
 const (
 	multiQueueMaxQueues = uint32(256)
+	// MemoryHotplugBlockSize is the granularity in which virtio-mem plugs memory
+	MemoryHotplugBlockSize = 2 * 1024 * 1024
+)
+const (
+	// AMD SEV guest policy bits, see the AMD SEV API specification
+	SEVPolicyNoDebug        = 1 << 0
+	SEVPolicyEncryptedState = 1 << 2
+	// AMD SEV-SNP guest policy bits, see the SEV Secure Nested Paging Firmware ABI specification
+	SNPPolicySMT      = 1 << 16
+	SNPPolicyReserved = 1 << 17
+	// Intel TDX guest policy bits, see the Intel TDX module specification
+	TDXPolicySeptVEDisable = 1 << 28
 )
 
 type deviceNamer struct {
@@ -90,6 +109,7 @@ type ConverterContext struct {
 	PermanentVolumes      map[string]v1.VolumeStatus
 	DiskType              map[string]*containerdisk.DiskInfo
 	SRIOVDevices          []api.HostDevice
+	VdpaDevices           map[string]string
 	SMBios                *cmdv1.SMBios
 	GpuDevices            []string
 	VgpuDevices           []string
@@ -98,6 +118,17 @@ type ConverterContext struct {
 	OVMFPath              string
 	MemBalloonStatsPeriod uint
 	UseVirtioTransitional bool
+	SEVNodeParameters     *SEVNodeParameters
+	EVMCSAvailable        bool
+	CPUNUMANodes          map[int]int
+	FreePageReporting     bool
+}
+
+// SEVNodeParameters holds the SEV capabilities of the host needed to describe
+// the launch security of a domain
+type SEVNodeParameters struct {
+	CBitPos         uint
+	ReducedPhysBits uint
 }
 
 // pop next device ID or address from a list
@@ -124,9 +155,73 @@ func getHostDeviceByResourceName(c *ConverterContext, resourceName string, name
 	return api.HostDevice{}, fmt.Errorf("failed to allocated a host device for resource: %s", resourceName)
 }
 
+// getNVMeDiskByResourceName attaches a namespace of an allocated NVMe controller as a virtio disk
+func getNVMeDiskByResourceName(c *ConverterContext, hostDev v1.HostDevice, domain *api.Domain) (api.Disk, error) {
+	nvmeDevice, exist := c.HostDevices[hostDev.DeviceName]
+	if !exist || len(nvmeDevice.AddrList) == 0 {
+		return api.Disk{}, fmt.Errorf("failed to allocated a host device for resource: %s", hostDev.DeviceName)
+	}
+	if nvmeDevice.Type != HostDevicePCI {
+		return api.Disk{}, fmt.Errorf("NVMe host device %s requires a PCI device, got %s", hostDev.Name, nvmeDevice.Type)
+	}
+	addr, remainingAddresses := popDeviceIDFromList(nvmeDevice.AddrList)
+	address, err := device.NewPciAddressField(addr)
+	if err != nil {
+		return api.Disk{}, err
+	}
+	nvmeDevice.AddrList = remainingAddresses
+	c.HostDevices[hostDev.DeviceName] = nvmeDevice
+
+	namespace := uint32(1)
+	if hostDev.NVMe.Namespace != 0 {
+		namespace = hostDev.NVMe.Namespace
+	}
+	disk := api.Disk{
+		Type:   "nvme",
+		Device: "disk",
+		Source: api.DiskSource{
+			Type:      "pci",
+			Managed:   "no",
+			Namespace: strconv.FormatUint(uint64(namespace), 10),
+			Address:   address,
+		},
+		Target: api.DiskTarget{
+			Bus:    "virtio",
+			Device: nextFreeDeviceName("vd", domain.Spec.Devices.Disks),
+		},
+		Driver: &api.DiskDriver{
+			Name: "qemu",
+			Type: "raw",
+		},
+		Alias: api.NewUserDefinedAlias(hostDev.Name),
+	}
+	return disk, nil
+}
+
+func nextFreeDeviceName(prefix string, disks []api.Disk) string {
+	used := make(map[string]bool, len(disks))
+	for _, disk := range disks {
+		used[disk.Target.Device] = true
+	}
+	for i := 0; i < 26*26*26; i++ {
+		if name := FormatDeviceName(prefix, i); !used[name] {
+			return name
+		}
+	}
+	return ""
+}
+
 // Both HostDevices and GPUs can allocate PCI devices or a MDEVs
 func Convert_HostDevices_And_GPU(devices v1.Devices, domain *api.Domain, c *ConverterContext) error {
 	for _, hostDev := range devices.HostDevices {
+		if hostDev.NVMe != nil {
+			disk, err := getNVMeDiskByResourceName(c, hostDev, domain)
+			if err != nil {
+				return err
+			}
+			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, disk)
+			continue
+		}
 		hostDevice, err := getHostDeviceByResourceName(c, hostDev.DeviceName, hostDev.Name)
 		if err != nil {
 			return err
@@ -184,6 +279,16 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 		disk.Target.Bus = diskDevice.LUN.Bus
 		disk.Target.Device, _ = makeDeviceName(diskDevice.Name, diskDevice.LUN.Bus, prefixMap)
 		disk.ReadOnly = toApiReadOnly(diskDevice.LUN.ReadOnly)
+		if diskDevice.LUN.Reservation {
+			disk.Source.Reservations = &api.Reservations{
+				Managed: "no",
+				SourceReservations: &api.SourceReservations{
+					Type: "unix",
+					Path: util.PrHelperSocketPath,
+					Mode: "client",
+				},
+			}
+		}
 	} else if diskDevice.Floppy != nil {
 		disk.Device = "floppy"
 		disk.Target.Bus = "fdc"
@@ -202,10 +307,12 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 		}
 	}
 	disk.Driver = &api.DiskDriver{
-		Name:        "qemu",
-		Cache:       string(diskDevice.Cache),
-		IO:          string(diskDevice.IO),
-		ErrorPolicy: "stop",
+		Name:         "qemu",
+		Cache:        string(diskDevice.Cache),
+		IO:           string(diskDevice.IO),
+		Discard:      string(diskDevice.Discard),
+		DetectZeroes: string(diskDevice.DetectZeroes),
+		ErrorPolicy:  "stop",
 	}
 
 	if numQueues != nil && disk.Target.Bus == "virtio" {
@@ -215,10 +322,92 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 	if diskDevice.BootOrder != nil {
 		disk.BootOrder = &api.BootOrder{Order: *diskDevice.BootOrder}
 	}
+	if diskDevice.IOTune != nil {
+		disk.IOTune = Convert_v1_DiskIOTune_To_api_IOTune(diskDevice.IOTune)
+	}
 
 	return nil
 }
 
+// burstLength returns the length of a burst, which only applies if the burst is set
+func burstLength(burst, length uint64) uint64 {
+	if burst == 0 {
+		return 0
+	}
+	return length
+}
+
+func Convert_v1_DiskIOTune_To_api_IOTune(ioTune *v1.DiskIOTune) *api.IOTune {
+	apiIOTune := &api.IOTune{}
+	if iops := ioTune.IOPS; iops != nil {
+		apiIOTune.TotalIopsSec = iops.Total
+		apiIOTune.ReadIopsSec = iops.Read
+		apiIOTune.WriteIopsSec = iops.Write
+		apiIOTune.TotalIopsSecMax = iops.TotalBurst
+		apiIOTune.ReadIopsSecMax = iops.ReadBurst
+		apiIOTune.WriteIopsSecMax = iops.WriteBurst
+		apiIOTune.TotalIopsSecMaxLength = burstLength(iops.TotalBurst, iops.BurstLength)
+		apiIOTune.ReadIopsSecMaxLength = burstLength(iops.ReadBurst, iops.BurstLength)
+		apiIOTune.WriteIopsSecMaxLength = burstLength(iops.WriteBurst, iops.BurstLength)
+	}
+	if bandwidth := ioTune.Bandwidth; bandwidth != nil {
+		apiIOTune.TotalBytesSec = bandwidth.Total
+		apiIOTune.ReadBytesSec = bandwidth.Read
+		apiIOTune.WriteBytesSec = bandwidth.Write
+		apiIOTune.TotalBytesSecMax = bandwidth.TotalBurst
+		apiIOTune.ReadBytesSecMax = bandwidth.ReadBurst
+		apiIOTune.WriteBytesSecMax = bandwidth.WriteBurst
+		apiIOTune.TotalBytesSecMaxLength = burstLength(bandwidth.TotalBurst, bandwidth.BurstLength)
+		apiIOTune.ReadBytesSecMaxLength = burstLength(bandwidth.ReadBurst, bandwidth.BurstLength)
+		apiIOTune.WriteBytesSecMaxLength = burstLength(bandwidth.WriteBurst, bandwidth.BurstLength)
+	}
+	return apiIOTune
+}
+
+// Convert_api_IOTune_To_v1_DiskIOTune reports the limits in effect in the domain. The burst length is
+// reported as the longest one of the bursts.
+func Convert_api_IOTune_To_v1_DiskIOTune(apiIOTune *api.IOTune) *v1.DiskIOTune {
+	ioTune := &v1.DiskIOTune{}
+	iops := v1.DiskIOLimits{
+		Total:       apiIOTune.TotalIopsSec,
+		Read:        apiIOTune.ReadIopsSec,
+		Write:       apiIOTune.WriteIopsSec,
+		TotalBurst:  apiIOTune.TotalIopsSecMax,
+		ReadBurst:   apiIOTune.ReadIopsSecMax,
+		WriteBurst:  apiIOTune.WriteIopsSecMax,
+		BurstLength: maxUint64(apiIOTune.TotalIopsSecMaxLength, apiIOTune.ReadIopsSecMaxLength, apiIOTune.WriteIopsSecMaxLength),
+	}
+	if iops != (v1.DiskIOLimits{}) {
+		ioTune.IOPS = &iops
+	}
+	bandwidth := v1.DiskIOLimits{
+		Total:       apiIOTune.TotalBytesSec,
+		Read:        apiIOTune.ReadBytesSec,
+		Write:       apiIOTune.WriteBytesSec,
+		TotalBurst:  apiIOTune.TotalBytesSecMax,
+		ReadBurst:   apiIOTune.ReadBytesSecMax,
+		WriteBurst:  apiIOTune.WriteBytesSecMax,
+		BurstLength: maxUint64(apiIOTune.TotalBytesSecMaxLength, apiIOTune.ReadBytesSecMaxLength, apiIOTune.WriteBytesSecMaxLength),
+	}
+	if bandwidth != (v1.DiskIOLimits{}) {
+		ioTune.Bandwidth = &bandwidth
+	}
+	if ioTune.IOPS == nil && ioTune.Bandwidth == nil {
+		return nil
+	}
+	return ioTune
+}
+
+func maxUint64(values ...uint64) uint64 {
+	var max uint64
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+	return max
+}
+
 func checkDirectIOFlag(path string) bool {
 	// check if fs where disk.img file is located or block device
 	// support direct i/o
@@ -231,11 +420,19 @@ func checkDirectIOFlag(path string) bool {
 	return true
 }
 
-func SetDriverCacheMode(disk *api.Disk) error {
+// SetDriverCacheMode sets the cache mode of the disk, if it is not set yet. None is used if the storage
+// supports direct I/O, so that nothing is cached on the host. Otherwise writethrough keeps shared volumes
+// consistent for other hosts, e.g. during a migration, and writeback is used for volumes only this host uses.
+func SetDriverCacheMode(disk *api.Disk, shared bool) error {
 	var path string
 	supportDirectIO := true
 	mode := v1.DriverCache(disk.Driver.Cache)
 
+	// NVMe disks are driven by QEMU directly and bypass the host page cache
+	if disk.Type == "nvme" {
+		return nil
+	}
+
 	if disk.Source.File != "" {
 		path = disk.Source.File
 	} else if disk.Source.Dev != "" {
@@ -266,12 +463,8 @@ func SetDriverCacheMode(disk *api.Disk) error {
 		return fmt.Errorf("Unable to use '%s' cache mode, file system where %s is stored does not support direct I/O", mode, path)
 	}
 
-	// if user did not set a cache mode and fs supports direct I/O then set cache = 'none'
-	// else set cache = 'writethrough
-	if mode == "" && supportDirectIO {
-		mode = v1.CacheNone
-	} else if mode == "" && !supportDirectIO {
-		mode = v1.CacheWriteThrough
+	if mode == "" {
+		mode = autoCacheMode(supportDirectIO, shared)
 	}
 
 	disk.Driver.Cache = string(mode)
@@ -280,6 +473,17 @@ func SetDriverCacheMode(disk *api.Disk) error {
 	return nil
 }
 
+// autoCacheMode picks the cache mode of a disk the user did not set one for
+func autoCacheMode(supportDirectIO bool, shared bool) v1.DriverCache {
+	if supportDirectIO {
+		return v1.CacheNone
+	}
+	if shared {
+		return v1.CacheWriteThrough
+	}
+	return v1.CacheWriteBack
+}
+
 func isPreAllocated(path string) bool {
 	diskInf, err := GetImageInfo(path)
 	if err != nil {
@@ -321,6 +525,27 @@ func SetOptimalIOMode(disk *api.Disk) error {
 	return nil
 }
 
+// SetOptimalDiscardMode passes the discard requests of the guest down to the storage, so that
+// deleted data frees the space of thin provisioned volumes. Preallocated images keep their space.
+func SetOptimalDiscardMode(disk *api.Disk) {
+	// If the user explicitly set the discard mode do nothing
+	if v1.DriverDiscard(disk.Driver.Discard) != "" {
+		return
+	}
+	// Freeing the space of zeroes requires unmapping them
+	if v1.DriverDetectZeroes(disk.Driver.DetectZeroes) == v1.DetectZeroesUnmap {
+		disk.Driver.Discard = string(v1.DiscardUnmap)
+		return
+	}
+	// Read only disks do not write and LUNs pass the SCSI commands through
+	if disk.ReadOnly != nil || disk.Device == "cdrom" || disk.Device == "lun" {
+		return
+	}
+	if disk.Source.Dev != "" || (disk.Source.File != "" && !isPreAllocated(disk.Source.File)) {
+		disk.Driver.Discard = string(v1.DiscardUnmap)
+	}
+}
+
 func (n *deviceNamer) getExistingVolumeValue(key string) (string, bool) {
 	if _, ok := n.existingNameMap[key]; ok {
 		return n.existingNameMap[key], true
@@ -387,6 +612,15 @@ func toApiReadOnly(src bool) *api.ReadOnly {
 }
 
 // Add_Agent_To_api_Channel creates the channel for guest agent communication
+// addIgnitionConfig passes the Ignition config to the guest through fw_cfg, where Ignition looks for it on QEMU
+func addIgnitionConfig(domain *api.Domain, path string) {
+	initializeQEMUCmdAndQEMUArg(domain)
+	domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg,
+		api.Arg{Value: "-fw_cfg"},
+		api.Arg{Value: fmt.Sprintf("name=opt/com.coreos/config,file=%s", path)},
+	)
+}
+
 func Add_Agent_To_api_Channel() (channel api.Channel) {
 	channel.Type = "unix"
 	// let libvirt decide which path to use
@@ -431,6 +665,9 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *Convert
 	if source.EmptyDisk != nil {
 		return Convert_v1_EmptyDiskSource_To_api_Disk(source.Name, source.EmptyDisk, disk, c)
 	}
+	if source.SwapDisk != nil {
+		return Convert_v1_SwapDiskSource_To_api_Disk(source.Name, source.SwapDisk, disk, c)
+	}
 	if source.ConfigMap != nil {
 		return Convert_v1_Config_To_api_Disk(source.Name, disk, config.ConfigMap)
 	}
@@ -443,6 +680,9 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *Convert
 	if source.ServiceAccount != nil {
 		return Convert_v1_Config_To_api_Disk(source.Name, disk, config.ServiceAccount)
 	}
+	if source.DownwardMetrics != nil {
+		return Convert_v1_DownwardMetricSource_To_api_Disk(disk)
+	}
 
 	return fmt.Errorf("disk %s references an unsupported source", disk.Alias.GetName())
 }
@@ -465,6 +705,26 @@ func Convert_v1_Hotplug_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c
 	return fmt.Errorf("hotplug disk %s references an unsupported source", disk.Alias.GetName())
 }
 
+// setDiskEncryption lets qemu open the LUKS layer of the volume with the passphrase libvirt holds for the disk
+func setDiskEncryption(vmi *v1.VirtualMachineInstance, diskDevice *v1.Disk, disk *api.Disk) {
+	if diskDevice.Encryption.Format == v1.DiskEncryptionFormatQcow2 {
+		disk.Driver.Type = "qcow2"
+	}
+	disk.Source.Encryption = &api.DiskEncryption{
+		Format: "luks",
+		Secret: &api.DiskSecret{
+			Type:  "passphrase",
+			Usage: diskencryption.SecretUsage(vmi, diskDevice.Name),
+		},
+	}
+}
+
+func setEmptyCDRom(disk *api.Disk) {
+	disk.Type = "file"
+	disk.Source = api.DiskSource{}
+	disk.Driver.Type = "raw"
+}
+
 func Convert_v1_Config_To_api_Disk(volumeName string, disk *api.Disk, configType config.Type) error {
 	disk.Type = "file"
 	disk.Driver.Type = "raw"
@@ -489,6 +749,49 @@ func Convert_v1_Config_To_api_Disk(volumeName string, disk *api.Disk, configType
 	return nil
 }
 
+// Convert_v1_DownwardMetricSource_To_api_Disk attaches the vhostmd compatible metrics disk read-only
+func Convert_v1_DownwardMetricSource_To_api_Disk(disk *api.Disk) error {
+	if disk.Type == "lun" {
+		return fmt.Errorf("device %s is of type lun. Not compatible with a file based disk", disk.Alias.GetName())
+	}
+
+	disk.Type = "file"
+	disk.ReadOnly = toApiReadOnly(true)
+	disk.Driver.Type = "raw"
+	disk.Source.File = config.DownwardMetricDisk
+	return nil
+}
+
+// getFilesystemSourceDir returns the directory of the pod which is shared into the guest by a virtiofs filesystem.
+// Config volumes are shared from where they are mounted, so that updates of their content reach the guest.
+func getFilesystemSourceDir(volume *v1.Volume) string {
+	switch {
+	case volume.ConfigMap != nil:
+		return config.GetConfigMapSourcePath(volume.Name)
+	case volume.Secret != nil:
+		return config.GetSecretSourcePath(volume.Name)
+	case volume.DownwardAPI != nil:
+		return config.GetDownwardAPISourcePath(volume.Name)
+	}
+	volDir, _ := filepath.Split(GetFilesystemVolumePath(volume.Name))
+	return volDir
+}
+
+// convertFilesystemIDMap returns the mapping of the guest IDs to the IDs of the shared directory, or nil if none is requested.
+func convertFilesystemIDMap(virtiofs *v1.FilesystemVirtiofs) *api.FilesystemIDMap {
+	if virtiofs.UIDMap == nil && virtiofs.GIDMap == nil {
+		return nil
+	}
+	idMap := &api.FilesystemIDMap{}
+	if virtiofs.UIDMap != nil {
+		idMap.UID = []api.FilesystemIDMapRange{{Start: virtiofs.UIDMap.GuestID, Target: virtiofs.UIDMap.HostID, Count: virtiofs.UIDMap.Count}}
+	}
+	if virtiofs.GIDMap != nil {
+		idMap.GID = []api.FilesystemIDMapRange{{Start: virtiofs.GIDMap.GuestID, Target: virtiofs.GIDMap.HostID, Count: virtiofs.GIDMap.Count}}
+	}
+	return idMap
+}
+
 func GetFilesystemVolumePath(volumeName string) string {
 	return filepath.Join(string(filepath.Separator), "var", "run", "kubevirt-private", "vmi-disks", volumeName, "disk.img")
 }
@@ -625,6 +928,23 @@ func Convert_v1_EmptyDiskSource_To_api_Disk(volumeName string, _ *v1.EmptyDiskSo
 	return nil
 }
 
+func Convert_v1_SwapDiskSource_To_api_Disk(volumeName string, _ *v1.SwapDiskSource, disk *api.Disk, c *ConverterContext) error {
+	if disk.Type == "lun" {
+		return fmt.Errorf("device %s is of type lun. Not compatible with a file based disk", disk.Alias.GetName())
+	}
+
+	disk.Type = "file"
+	disk.Driver.Type = "qcow2"
+	disk.Source.File = emptydisk.FilePathForVolumeName(volumeName)
+	disk.Driver.ErrorPolicy = "stop"
+	// the cloud-init vendor data finds the swap disk by its serial
+	if disk.Serial == "" {
+		disk.Serial = cloudinit.SwapDiskSerial(volumeName)
+	}
+
+	return nil
+}
+
 func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, _ *v1.ContainerDiskSource, disk *api.Disk, c *ConverterContext, diskIndex int) error {
 	if disk.Type == "lun" {
 		return fmt.Errorf("device %s is of type lun. Not compatible with a file based disk", disk.Alias.GetName())
@@ -677,6 +997,11 @@ func Convert_v1_Watchdog_To_api_Watchdog(source *v1.Watchdog, watchdog *api.Watc
 		watchdog.Action = string(source.I6300ESB.Action)
 		return nil
 	}
+	if source.Diag288 != nil {
+		watchdog.Model = "diag288"
+		watchdog.Action = string(source.Diag288.Action)
+		return nil
+	}
 	return fmt.Errorf("watchdog %s can't be mapped, no watchdog type specified", source.Name)
 }
 
@@ -696,6 +1021,44 @@ func Convert_v1_Rng_To_api_Rng(_ *v1.Rng, rng *api.Rng, c *ConverterContext) err
 	return nil
 }
 
+func Convert_v1_TPMDevice_To_api_TPM(source *v1.TPMDevice, tpm *api.TPM, _ *ConverterContext) error {
+	tpm.Model = "tpm-tis"
+	tpm.Backend = api.TPMBackend{
+		Type:    "emulator",
+		Version: "2.0",
+	}
+	if source.Persistent != nil && *source.Persistent {
+		tpm.Backend.PersistentState = "yes"
+	}
+	return nil
+}
+
+func Convert_v1_VSOCK_To_api_VSOCK(source *v1.VirtualMachineInstance, vsock *api.VSOCK, c *ConverterContext) error {
+	if source.Status.VSOCKCID == nil {
+		return fmt.Errorf("VSOCK is requested but no CID has been assigned to the VMI yet")
+	}
+	vsock.Model = translateModel(c, "virtio")
+	vsock.CID = api.CID{
+		Auto:    "no",
+		Address: *source.Status.VSOCKCID,
+	}
+	return nil
+}
+
+func Convert_v1_VirtualMachineInstance_To_api_PanicDevice(_ *v1.VirtualMachineInstance, panicDevice *api.PanicDevice, c *ConverterContext) error {
+	switch c.Architecture {
+	case "ppc64le":
+		panicDevice.Model = "pseries"
+	case "s390x":
+		panicDevice.Model = "s390"
+	case "arm64":
+		panicDevice.Model = "pvpanic"
+	default:
+		panicDevice.Model = "isa"
+	}
+	return nil
+}
+
 func Convert_v1_Input_To_api_InputDevice(input *v1.Input, inputDevice *api.Input, c *ConverterContext) error {
 	if input.Bus != "virtio" && input.Bus != "usb" && input.Bus != "" {
 		return fmt.Errorf("input contains unsupported bus %s", input.Bus)
@@ -844,6 +1207,11 @@ func Convert_v1_FeatureHyperv_To_api_FeatureHyperv(source *v1.FeatureHyperv, hyp
 	hyperv.TLBFlush = convertFeatureState(source.TLBFlush)
 	hyperv.IPI = convertFeatureState(source.IPI)
 	hyperv.EVMCS = convertFeatureState(source.EVMCS)
+	// Windows guests get the enlightened VMCS on hosts supporting it, unless it is explicitly configured
+	if source.EVMCS == nil && c.EVMCSAvailable && util.IsWindowsVMI(c.VirtualMachine) &&
+		hyperv.VAPIC != nil && hyperv.VAPIC.State == "on" {
+		hyperv.EVMCS = &api.FeatureState{State: "on"}
+	}
 	return nil
 }
 
@@ -864,6 +1232,103 @@ func convertV1ToAPISyNICTimer(syNICTimer *v1.SyNICTimer) *api.SyNICTimer {
 	return result
 }
 
+func Convert_v1_SEV_To_api_LaunchSecurity(source *v1.SEV, launchSecurity *api.LaunchSecurity, c *ConverterContext) error {
+	// guest debugging is never allowed for encrypted guests
+	policy := SEVPolicyNoDebug
+	if source.Policy != nil && source.Policy.EncryptedState != nil && *source.Policy.EncryptedState {
+		policy |= SEVPolicyEncryptedState
+	}
+
+	launchSecurity.Type = "sev"
+	launchSecurity.Policy = "0x" + strconv.FormatUint(uint64(policy), 16)
+	setSEVNodeParameters(launchSecurity, c)
+	return nil
+}
+
+func Convert_v1_SEVSNP_To_api_LaunchSecurity(_ *v1.SEVSNP, launchSecurity *api.LaunchSecurity, c *ConverterContext) error {
+	// debugging and migration agents are never allowed, SMT is allowed since
+	// the guest can not control the scheduling of its vCPUs anyway
+	policy := SNPPolicyReserved | SNPPolicySMT
+
+	launchSecurity.Type = "sev-snp"
+	launchSecurity.Policy = "0x" + strconv.FormatUint(uint64(policy), 16)
+	setSEVNodeParameters(launchSecurity, c)
+	return nil
+}
+
+func Convert_v1_TDX_To_api_LaunchSecurity(_ *v1.TDX, launchSecurity *api.LaunchSecurity, _ *ConverterContext) error {
+	// EPT violations are never reflected to the guest as #VE, which would
+	// allow the host to inject exceptions at arbitrary points
+	policy := TDXPolicySeptVEDisable
+
+	launchSecurity.Type = "tdx"
+	launchSecurity.Policy = "0x" + strconv.FormatUint(uint64(policy), 16)
+	return nil
+}
+
+func setSEVNodeParameters(launchSecurity *api.LaunchSecurity, c *ConverterContext) {
+	if c.SEVNodeParameters != nil {
+		launchSecurity.Cbitpos = strconv.FormatUint(uint64(c.SEVNodeParameters.CBitPos), 10)
+		launchSecurity.ReducedPhysBits = strconv.FormatUint(uint64(c.SEVNodeParameters.ReducedPhysBits), 10)
+	}
+}
+
+// getStatelessEFICode returns the firmware of the confidential guests which
+// boot without EFI variables, so that the firmware can be fully measured
+func getStatelessEFICode(vmi *v1.VirtualMachineInstance) string {
+	if util.IsTDXVMI(vmi) {
+		return EFICodeTDX
+	}
+	return EFICodeSEVSNP
+}
+
+// getInsecureEFIFiles returns the firmware code and variables template used
+// to boot the guest of the given architecture without secure boot
+func getInsecureEFIFiles(arch string) (code string, vars string) {
+	if arch == "arm64" {
+		return EFICodeAARCH64, EFIVarsAARCH64
+	}
+	return EFICode, EFIVars
+}
+
+// setIOMMUForVirtioDevices makes the virtio devices use the platform IOMMU,
+// which is required for them to access the encrypted memory of a confidential guest
+func setIOMMUForVirtioDevices(devices *api.Devices) {
+	for i := range devices.Disks {
+		if devices.Disks[i].Target.Bus == "virtio" && devices.Disks[i].Driver != nil {
+			devices.Disks[i].Driver.IOMMU = "on"
+		}
+	}
+	for i := range devices.Interfaces {
+		iface := &devices.Interfaces[i]
+		if iface.Model == nil || !strings.HasPrefix(iface.Model.Type, "virtio") {
+			continue
+		}
+		if iface.Driver == nil {
+			iface.Driver = &api.InterfaceDriver{}
+		}
+		iface.Driver.IOMMU = "on"
+		// iPXE is not aware of SEV
+		iface.Rom = &api.Rom{Enabled: "no"}
+	}
+	for i := range devices.Controllers {
+		controller := &devices.Controllers[i]
+		if !strings.HasPrefix(controller.Model, "virtio") {
+			continue
+		}
+		if controller.Driver == nil {
+			controller.Driver = &api.ControllerDriver{}
+		}
+		controller.Driver.IOMMU = "on"
+	}
+	if devices.Rng != nil && strings.HasPrefix(devices.Rng.Model, "virtio") {
+		devices.Rng.Driver = &api.RngDriver{IOMMU: "on"}
+	}
+	if devices.VSOCK != nil {
+		devices.VSOCK.Driver = &api.VSOCKDriver{IOMMU: "on"}
+	}
+}
+
 func ConvertV1ToAPIBalloning(source *v1.Devices, ballooning *api.MemBalloon, c *ConverterContext) {
 	if source != nil && source.AutoattachMemBalloon != nil && *source.AutoattachMemBalloon == false {
 		ballooning.Model = "none"
@@ -873,8 +1338,19 @@ func ConvertV1ToAPIBalloning(source *v1.Devices, ballooning *api.MemBalloon, c *
 		if c.MemBalloonStatsPeriod != 0 {
 			ballooning.Stats = &api.Stats{Period: c.MemBalloonStatsPeriod}
 		}
+		if c.FreePageReporting {
+			ballooning.FreePageReporting = "on"
+		}
+	}
+}
 
+// IsFreePageReportingEnabled returns true unless the VMI opted out of free page reporting.
+// Guest memory backed by hugepages can not be returned to the host page by page.
+func IsFreePageReportingEnabled(vmi *v1.VirtualMachineInstance) bool {
+	if freePageReporting := vmi.Spec.Domain.Devices.FreePageReporting; freePageReporting != nil && !*freePageReporting {
+		return false
 	}
+	return vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Hugepages == nil
 }
 
 func initializeQEMUCmdAndQEMUArg(domain *api.Domain) {
@@ -908,6 +1384,14 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		CPUs:      cpuCount,
 	}
 
+	// Expose the maximum amount of sockets to the guest, but only enable the
+	// requested ones. The remaining vCPUs can be hotplugged later on.
+	if vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.MaxSockets > cpuTopology.Sockets {
+		cpuTopology.Sockets = vmi.Spec.Domain.CPU.MaxSockets
+		domain.Spec.VCPU.Current = cpuCount
+		domain.Spec.VCPU.CPUs = calculateRequestedVCPUs(cpuTopology)
+	}
+
 	if _, err := os.Stat("/dev/kvm"); os.IsNotExist(err) {
 		if c.UseEmulation {
 			logger := log.DefaultLogger()
@@ -956,7 +1440,19 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		}
 
 		if vmi.Spec.Domain.Firmware.Bootloader != nil && vmi.Spec.Domain.Firmware.Bootloader.EFI != nil {
-			if vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot {
+			nvramPath := filepath.Join("/tmp", domain.Spec.Name)
+			if backendstorage.HasPersistentEFI(vmi) {
+				nvramPath = filepath.Join(backendstorage.NVRAMStateDir, domain.Spec.Name)
+			}
+
+			if util.IsSEVSNPVMI(vmi) || util.IsTDXVMI(vmi) {
+				domain.Spec.OS.BootLoader = &api.Loader{
+					Path:      filepath.Join(c.OVMFPath, getStatelessEFICode(vmi)),
+					ReadOnly:  "yes",
+					Type:      "rom",
+					Stateless: "yes",
+				}
+			} else if vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot {
 				domain.Spec.OS.BootLoader = &api.Loader{
 					Path:     filepath.Join(c.OVMFPath, EFICodeSecureBoot),
 					ReadOnly: "yes",
@@ -965,20 +1461,21 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				}
 
 				domain.Spec.OS.NVRam = &api.NVRam{
-					NVRam:    filepath.Join("/tmp", domain.Spec.Name),
+					NVRam:    nvramPath,
 					Template: filepath.Join(c.OVMFPath, EFIVarsSecureBoot),
 				}
 			} else {
+				efiCode, efiVars := getInsecureEFIFiles(c.Architecture)
 				domain.Spec.OS.BootLoader = &api.Loader{
-					Path:     filepath.Join(c.OVMFPath, EFICode),
+					Path:     filepath.Join(c.OVMFPath, efiCode),
 					ReadOnly: "yes",
 					Secure:   "no",
 					Type:     "pflash",
 				}
 
 				domain.Spec.OS.NVRam = &api.NVRam{
-					NVRam:    filepath.Join("/tmp", domain.Spec.Name),
-					Template: filepath.Join(c.OVMFPath, EFIVars),
+					NVRam:    nvramPath,
+					Template: filepath.Join(c.OVMFPath, efiVars),
 				}
 			}
 		}
@@ -1022,8 +1519,9 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 
 	// Take SMBios values from the VirtualMachineOptions
 	// SMBios option does not work in Power, attempting to set it will result in the following error message:
-	// "Option not supported for this target" issued by qemu-system-ppc64, so don't set it in case GOARCH is ppc64le
-	if c.Architecture != "ppc64le" {
+	// "Option not supported for this target" issued by qemu-system-ppc64, so don't set it in case GOARCH is ppc64le.
+	// The same applies to qemu-system-s390x.
+	if c.Architecture != "ppc64le" && c.Architecture != "s390x" {
 		domain.Spec.OS.SMBios = &api.SMBios{
 			Mode: "sysinfo",
 		}
@@ -1067,8 +1565,8 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			isMemfdRequired = true
 		}
 	}
-	// virtiofs require shared access
-	if util.IsVMIVirtiofsEnabled(vmi) {
+	// virtiofs and vhost-user require shared access
+	if util.IsVMIVirtiofsEnabled(vmi) || util.IsVhostUserVmi(vmi) {
 		if domain.Spec.MemoryBacking == nil {
 			domain.Spec.MemoryBacking = &api.MemoryBacking{}
 		}
@@ -1095,6 +1593,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		}
 	}
 
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.MaxGuest != nil {
+		if err := configureMemoryHotplug(vmi, domain); err != nil {
+			return err
+		}
+	}
+
 	volumeIndices := map[string]int{}
 	volumes := map[string]*v1.Volume{}
 	for i, volume := range vmi.Spec.Volumes {
@@ -1141,7 +1645,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		}
 	}
 
-	if (autoThreads + dedicatedThreads) > threadPoolLimit {
+	if ioThreads := vmi.Spec.Domain.IOThreads; ioThreads != nil && ioThreads.SharedPoolSize != nil {
+		// the size of the shared pool is given explicitly, independent of the dedicated threads
+		if autoThreads > int(*ioThreads.SharedPoolSize) {
+			autoThreads = int(*ioThreads.SharedPoolSize)
+		}
+	} else if (autoThreads + dedicatedThreads) > threadPoolLimit {
 		autoThreads = threadPoolLimit - dedicatedThreads
 		// We need at least one shared thread
 		if autoThreads < 1 {
@@ -1180,11 +1689,14 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			return err
 		}
 		volume := volumes[disk.Name]
-		if volume == nil {
+		if volume == nil && disk.CDRom == nil {
 			return fmt.Errorf("No matching volume with name %s found", disk.Name)
 		}
 
-		if _, ok := c.HotplugVolumes[disk.Name]; !ok {
+		if volume == nil {
+			// A CD-ROM drive without media
+			setEmptyCDRom(&newDisk)
+		} else if _, ok := c.HotplugVolumes[disk.Name]; !ok {
 			err = Convert_v1_Volume_To_api_Disk(volume, &newDisk, c, volumeIndices[disk.Name])
 		} else {
 			err = Convert_v1_Hotplug_Volume_To_api_Disk(volume, &newDisk, c)
@@ -1192,6 +1704,9 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		if err != nil {
 			return err
 		}
+		if disk.Encryption != nil && volume != nil {
+			setDiskEncryption(vmi, &vmi.Spec.Domain.Devices.Disks[i], &newDisk)
+		}
 
 		if useIOThreads {
 			ioThreadId := defaultIOThread
@@ -1216,6 +1731,10 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		// if len(c.PermanentVolumes) == 0, it means the vmi is not ready yet, add all disks
 		if _, ok := c.PermanentVolumes[disk.Name]; ok || len(c.PermanentVolumes) == 0 || (hpOk && (hpStatus.Phase == v1.HotplugVolumeMounted || hpStatus.Phase == v1.VolumeReady)) {
 			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, newDisk)
+		} else if disk.CDRom != nil {
+			// The CD-ROM drive is always attached, it stays empty until its media is mounted
+			setEmptyCDRom(&newDisk)
+			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, newDisk)
 		}
 	}
 	// Handle virtioFS
@@ -1248,9 +1767,9 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			if volume == nil {
 				return fmt.Errorf("No matching volume with name %s found", fs.Name)
 			}
-			volDir, _ := filepath.Split(GetFilesystemVolumePath(volume.Name))
 			newFS.Source = &api.FilesystemSource{}
-			newFS.Source.Dir = volDir
+			newFS.Source.Dir = getFilesystemSourceDir(volume)
+			newFS.IDMap = convertFilesystemIDMap(fs.Virtiofs)
 			domain.Spec.Devices.Filesystems = append(domain.Spec.Devices.Filesystems, newFS)
 		}
 	}
@@ -1273,6 +1792,37 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.Devices.Rng = newRng
 	}
 
+	if vmi.Spec.Domain.Devices.TPM != nil {
+		newTPM := api.TPM{}
+		err := Convert_v1_TPMDevice_To_api_TPM(vmi.Spec.Domain.Devices.TPM, &newTPM, c)
+		if err != nil {
+			return err
+		}
+		domain.Spec.Devices.TPMs = []api.TPM{newTPM}
+	}
+
+	if util.IsAutoAttachVSOCK(vmi) {
+		newVSOCK := &api.VSOCK{}
+		err := Convert_v1_VSOCK_To_api_VSOCK(vmi, newVSOCK, c)
+		if err != nil {
+			return err
+		}
+		domain.Spec.Devices.VSOCK = newVSOCK
+	}
+
+	if util.IsAutoAttachPanicDevice(vmi) {
+		newPanic := &api.PanicDevice{}
+		err := Convert_v1_VirtualMachineInstance_To_api_PanicDevice(vmi, newPanic, c)
+		if err != nil {
+			return err
+		}
+		domain.Spec.Devices.Panic = newPanic
+		// Dump the guest memory before destroying the domain if a place for the dumps is configured
+		if vmi.Spec.Domain.Devices.PanicMemoryDump != nil {
+			domain.Spec.OnCrash = "coredump-destroy"
+		}
+	}
+
 	isUSBDevicePresent := false
 	if vmi.Spec.Domain.Devices.Inputs != nil {
 		inputDevices := make([]api.Input, 0)
@@ -1293,7 +1843,11 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 	domain.Spec.Devices.Ballooning = &api.MemBalloon{}
 	ConvertV1ToAPIBalloning(&vmi.Spec.Domain.Devices, domain.Spec.Devices.Ballooning, c)
 
-	//usb controller is turned on, only when user specify input device with usb bus,
+	if hasUSBDisk(vmi) {
+		isUSBDevicePresent = true
+	}
+
+	//usb controller is turned on, only when user specify input device or disk with usb bus,
 	//otherwise it is turned off
 	//In ppc64le usb devices like mouse / keyboard are set by default,
 	//so we can't disable the controller otherwise we run into the following error:
@@ -1387,12 +1941,27 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				}
 
 			}
+			if vmi.Spec.Domain.CPU.NUMA != nil && vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough != nil {
+				if err := numaMapping(vmi, &domain.Spec, c); err != nil {
+					log.Log.Reason(err).Error("failed to calculate passed through NUMA topology.")
+					return err
+				}
+			}
+			if vmi.Spec.Domain.CPU.Realtime != nil {
+				formatDomainRealtime(vmi, domain)
+			}
 		}
 	}
 	err = Convert_HostDevices_And_GPU(vmi.Spec.Domain.Devices, domain, c)
 	if err != nil {
 		log.Log.Reason(err).Error("Unable to prepare host devices, fall back to legacy")
 	}
+	for _, hostDevice := range domain.Spec.Devices.HostDevices {
+		if hostDevice.Type == string(HostDeviceUSB) {
+			enableUSBController(domain)
+			break
+		}
+	}
 
 	// This is needed to support a legacy approach to device assignment
 	// Append HostDevices to DomXML if GPU is requested
@@ -1449,18 +2018,64 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				},
 			},
 		}
+
+		if util.IsSerialConsoleLogEnabled(vmi) {
+			// virtlogd mirrors the serial console output to the log file, virt-launcher follows it from there
+			domain.Spec.Devices.Serials[0].Log = &api.SerialLog{
+				File:   filepath.Join(util.VirtPrivateDir, string(vmi.ObjectMeta.UID), util.SerialConsoleLogFile),
+				Append: "on",
+			}
+		}
+	}
+
+	for i, serial := range vmi.Spec.Domain.Devices.Serials {
+		// Port 0 is reserved for the default serial console
+		port := uint(i + 1)
+		domain.Spec.Devices.Serials = append(domain.Spec.Devices.Serials, api.Serial{
+			Type: "unix",
+			Target: &api.SerialTarget{
+				Port: &port,
+			},
+			Source: &api.SerialSource{
+				Mode: "bind",
+				Path: filepath.Join(util.VirtPrivateDir, string(vmi.ObjectMeta.UID), util.SerialPortSocketName(i)),
+			},
+			Alias: api.NewUserDefinedAlias(serial.Name),
+		})
+	}
+
+	for i, channel := range vmi.Spec.Domain.Devices.Channels {
+		domain.Spec.Devices.Channels = append(domain.Spec.Devices.Channels, api.Channel{
+			Type: "unix",
+			Source: &api.ChannelSource{
+				Mode: "bind",
+				Path: filepath.Join(util.VirtPrivateDir, string(vmi.ObjectMeta.UID), util.ChannelSocketName(i)),
+			},
+			Target: &api.ChannelTarget{
+				Name: channel.TargetName,
+				Type: "virtio",
+			},
+		})
 	}
 
 	if vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == nil || *vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == true {
 		var heads uint = 1
 		var vram uint = 16384
+		videoModel := api.VideoModel{
+			Type:  "vga",
+			Heads: &heads,
+			VRam:  &vram,
+		}
+		// arm64 and s390x guests have no VGA device, they use a virtio-gpu instead
+		if c.Architecture == "arm64" || c.Architecture == "s390x" {
+			videoModel = api.VideoModel{
+				Type:  "virtio",
+				Heads: &heads,
+			}
+		}
 		domain.Spec.Devices.Video = []api.Video{
 			{
-				Model: api.VideoModel{
-					Type:  "vga",
-					Heads: &heads,
-					VRam:  &vram,
-				},
+				Model: videoModel,
 			},
 		}
 		domain.Spec.Devices.Graphics = []api.Graphics{
@@ -1481,13 +2096,32 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 	domain.Spec.Devices.Interfaces = append(domain.Spec.Devices.Interfaces, domainInterfaces...)
 	domain.Spec.Devices.HostDevices = append(domain.Spec.Devices.HostDevices, c.SRIOVDevices...)
 
+	if util.IsConfidentialVMI(vmi) {
+		launchSecurity := vmi.Spec.Domain.LaunchSecurity
+		domain.Spec.LaunchSecurity = &api.LaunchSecurity{}
+		switch {
+		case launchSecurity.SEV != nil:
+			err = Convert_v1_SEV_To_api_LaunchSecurity(launchSecurity.SEV, domain.Spec.LaunchSecurity, c)
+		case launchSecurity.SNP != nil:
+			err = Convert_v1_SEVSNP_To_api_LaunchSecurity(launchSecurity.SNP, domain.Spec.LaunchSecurity, c)
+		case launchSecurity.TDX != nil:
+			err = Convert_v1_TDX_To_api_LaunchSecurity(launchSecurity.TDX, domain.Spec.LaunchSecurity, c)
+		}
+		if err != nil {
+			return err
+		}
+		// the host can not access the encrypted guest memory to balloon it
+		domain.Spec.Devices.Ballooning = &api.MemBalloon{Model: "none"}
+		setIOMMUForVirtioDevices(&domain.Spec.Devices)
+	}
+
 	// Add Ignition Command Line if present
 	ignitiondata, _ := vmi.Annotations[v1.IgnitionAnnotation]
-	if ignitiondata != "" && strings.Contains(ignitiondata, "ignition") {
-		initializeQEMUCmdAndQEMUArg(domain)
-		domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg, api.Arg{Value: "-fw_cfg"})
+	if vmi.Spec.Ignition != nil {
+		addIgnitionConfig(domain, ignition.GetIgnitionSourceConfigPath())
+	} else if ignitiondata != "" && strings.Contains(ignitiondata, "ignition") {
 		ignitionpath := fmt.Sprintf("%s/%s", ignition.GetDomainBasePath(c.VirtualMachine.Name, c.VirtualMachine.Namespace), ignition.IgnitionFile)
-		domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg, api.Arg{Value: fmt.Sprintf("name=opt/com.coreos/config,file=%s", ignitionpath)})
+		addIgnitionConfig(domain, ignitionpath)
 	}
 
 	if val := vmi.Annotations[v1.PlacePCIDevicesOnRootComplex]; val == "true" {
@@ -1513,7 +2147,13 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 func CheckEFI_OVMFRoms(vmi *v1.VirtualMachineInstance, c *ConverterContext) (err error) {
 	if vmi.Spec.Domain.Firmware != nil {
 		if vmi.Spec.Domain.Firmware.Bootloader != nil && vmi.Spec.Domain.Firmware.Bootloader.EFI != nil {
-			if vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot {
+			if util.IsSEVSNPVMI(vmi) || util.IsTDXVMI(vmi) {
+				_, err1 := os.Stat(filepath.Join(c.OVMFPath, getStatelessEFICode(vmi)))
+				if os.IsNotExist(err1) {
+					log.Log.Reason(err1).Error("EFI OVMF rom missing for confidential computing")
+					return fmt.Errorf("EFI OVMF rom missing for confidential computing")
+				}
+			} else if vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot {
 				_, err1 := os.Stat(filepath.Join(c.OVMFPath, EFICodeSecureBoot))
 				_, err2 := os.Stat(filepath.Join(c.OVMFPath, EFIVarsSecureBoot))
 				if os.IsNotExist(err1) || os.IsNotExist(err2) {
@@ -1521,8 +2161,9 @@ func CheckEFI_OVMFRoms(vmi *v1.VirtualMachineInstance, c *ConverterContext) (err
 					return fmt.Errorf("EFI OVMF roms missing for secure boot")
 				}
 			} else {
-				_, err1 := os.Stat(filepath.Join(c.OVMFPath, EFICode))
-				_, err2 := os.Stat(filepath.Join(c.OVMFPath, EFIVars))
+				efiCode, efiVars := getInsecureEFIFiles(c.Architecture)
+				_, err1 := os.Stat(filepath.Join(c.OVMFPath, efiCode))
+				_, err2 := os.Stat(filepath.Join(c.OVMFPath, efiVars))
 				if os.IsNotExist(err1) || os.IsNotExist(err2) {
 					log.Log.Reason(err).Error("EFI OVMF roms missing for insecure boot")
 					return fmt.Errorf("EFI OVMF roms missing for insecure boot")
@@ -1533,6 +2174,59 @@ func CheckEFI_OVMFRoms(vmi *v1.VirtualMachineInstance, c *ConverterContext) (err
 	return nil
 }
 
+// configureMemoryHotplug boots the domain with the guest memory at boot and backs the
+// remaining memory up to maxGuest with a virtio-mem device on NUMA node 0.
+func configureMemoryHotplug(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
+	bootMemory := getVirtualMemory(vmi)
+	if vmi.Status.Memory != nil && vmi.Status.Memory.GuestAtBoot != nil {
+		bootMemory = vmi.Status.Memory.GuestAtBoot
+	}
+
+	maxGuest := vmi.Spec.Domain.Memory.MaxGuest.Value()
+	if maxGuest <= bootMemory.Value() {
+		return nil
+	}
+
+	domain.Spec.MaxMemory = &api.MaxMemory{
+		Value: uint64(maxGuest),
+		Unit:  "b",
+		Slots: 1,
+	}
+	domain.Spec.Memory = api.Memory{
+		Value: uint64(bootMemory.Value()),
+		Unit:  "b",
+	}
+
+	// virtio-mem devices must be assigned to a NUMA node
+	if domain.Spec.CPU.NUMA == nil {
+		domain.Spec.CPU.NUMA = &api.NUMA{
+			Cells: []api.NUMACell{
+				{
+					ID:   "0",
+					CPUs: fmt.Sprintf("0-%d", domain.Spec.VCPU.CPUs-1),
+				},
+			},
+		}
+	}
+	domain.Spec.CPU.NUMA.Cells[0].Memory = fmt.Sprintf("%d", bootMemory.Value()/int64(1024))
+	domain.Spec.CPU.NUMA.Cells[0].Unit = "KiB"
+
+	requested := getVirtualMemory(vmi).Value() - bootMemory.Value()
+	if requested < 0 {
+		requested = 0
+	}
+	domain.Spec.Devices.Memory = &api.MemoryDevice{
+		Model: "virtio-mem",
+		Target: &api.MemoryTarget{
+			Size:      api.Memory{Value: uint64(maxGuest - bootMemory.Value()), Unit: "b"},
+			Node:      "0",
+			Block:     api.Memory{Value: MemoryHotplugBlockSize, Unit: "b"},
+			Requested: api.Memory{Value: uint64(requested), Unit: "b"},
+		},
+	}
+	return nil
+}
+
 func getVirtualMemory(vmi *v1.VirtualMachineInstance) *resource.Quantity {
 	// In case that guest memory is explicitly set, return it
 	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
@@ -1591,6 +2285,26 @@ func calculateRequestedVCPUs(cpuTopology *api.CPUTopology) uint32 {
 	return cpuTopology.Cores * cpuTopology.Sockets * cpuTopology.Threads
 }
 
+// formatDomainRealtime runs the realtime vCPUs with FIFO scheduling and locks the guest memory,
+// so that the vCPUs are neither preempted by regular host processes nor stalled by page faults
+func formatDomainRealtime(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	vcpus := vmi.Spec.Domain.CPU.Realtime.Mask
+	if vcpus == "" {
+		vcpus = fmt.Sprintf("0-%d", calculateRequestedVCPUs(domain.Spec.CPU.Topology)-1)
+	}
+	domain.Spec.CPUTune.VCPUScheduler = []api.VCPUScheduler{
+		{
+			VCPUs:     vcpus,
+			Scheduler: "fifo",
+			Priority:  1,
+		},
+	}
+	if domain.Spec.MemoryBacking == nil {
+		domain.Spec.MemoryBacking = &api.MemoryBacking{}
+	}
+	domain.Spec.MemoryBacking.Locked = &api.MemoryBackingLocked{}
+}
+
 func formatDomainCPUTune(vmi *v1.VirtualMachineInstance, domain *api.Domain, c *ConverterContext) error {
 	if len(c.CPUSet) == 0 {
 		return fmt.Errorf("failed for get pods pinned cpus")
@@ -1614,6 +2328,16 @@ func appendDomainEmulatorThreadPin(domain *api.Domain, allocatedCpu int) {
 	domain.Spec.CPUTune.EmulatorPin = &emulatorThread
 }
 
+func countDedicatedIOThreads(vmi *v1.VirtualMachineInstance) int {
+	dedicatedThreads := 0
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.DedicatedIOThread != nil && *disk.DedicatedIOThread {
+			dedicatedThreads++
+		}
+	}
+	return dedicatedThreads
+}
+
 func appendDomainIOThreadPin(domain *api.Domain, thread uint, cpuset string) {
 	iothreadPin := api.CPUTuneIOThreadPin{}
 	iothreadPin.IOThread = thread
@@ -1626,9 +2350,22 @@ func formatDomainIOThreadPin(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 	vcpus := int(calculateRequestedVCPUs(domain.Spec.CPU.Topology))
 
 	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread {
-		// pin the IOThread on the same pCPU as the emulator thread
+		// the dedicated IOThreads of the disks follow the shared ones
+		sharedThreads := iothreads
+		if ioThreads := vmi.Spec.Domain.IOThreads; ioThreads != nil && ioThreads.DedicatedPinning == v1.DedicatedIOThreadsPinningVCPUs {
+			sharedThreads -= countDedicatedIOThreads(vmi)
+		}
+		// pin the shared IOThreads, and by default the dedicated ones of the disks, on the same pCPU as the
+		// emulator thread, so that they never compete with the vCPUs
 		cpuset := fmt.Sprintf("%d", *c.EmulatorThreadCpu)
-		appendDomainIOThreadPin(domain, uint(1), cpuset)
+		for thread := 1; thread <= sharedThreads; thread++ {
+			appendDomainIOThreadPin(domain, uint(thread), cpuset)
+		}
+		// spread the remaining dedicated IOThreads over the pCPUs of the vCPUs
+		for thread := sharedThreads + 1; thread <= iothreads; thread++ {
+			cpuset := fmt.Sprintf("%d", c.CPUSet[(thread-sharedThreads-1)%len(c.CPUSet)])
+			appendDomainIOThreadPin(domain, uint(thread), cpuset)
+		}
 	} else if iothreads >= vcpus {
 		// pin an IOThread on a CPU
 		for thread := 1; thread <= iothreads; thread++ {
@@ -1709,6 +2446,8 @@ func createHostDevicesFromAddress(devType HostDeviceType, deviceID string, name
 		return createHostDevicesFromPCIAddress(deviceID, name)
 	case HostDeviceMDEV:
 		return createHostDevicesFromMdevUUID(deviceID, name)
+	case HostDeviceUSB:
+		return createHostDevicesFromUSBAddress(deviceID, name)
 	}
 	return api.HostDevice{}, fmt.Errorf("failed to create host devices for invalid type %s", devType)
 }
@@ -1749,6 +2488,49 @@ func createHostDevicesFromMdevUUID(mdevUUID string, name string) (api.HostDevice
 	return hostDev, nil
 }
 
+// createHostDevicesFromUSBAddress creates a USB host device from its bus and device number, e.g. 001:004.
+// The guest is not allowed to reset the device, USB devices like license dongles re-enumerate on a
+// reset and would be lost to the guest on every reboot.
+func createHostDevicesFromUSBAddress(usbAddr string, name string) (api.HostDevice, error) {
+	busDevice := strings.Split(usbAddr, ":")
+	if len(busDevice) != 2 {
+		return api.HostDevice{}, fmt.Errorf("invalid USB address %s", usbAddr)
+	}
+	bus, err := strconv.Atoi(busDevice[0])
+	if err != nil {
+		return api.HostDevice{}, fmt.Errorf("invalid bus of USB address %s: %v", usbAddr, err)
+	}
+	device, err := strconv.Atoi(busDevice[1])
+	if err != nil {
+		return api.HostDevice{}, fmt.Errorf("invalid device of USB address %s: %v", usbAddr, err)
+	}
+
+	hostDev := api.HostDevice{
+		Source: api.HostDeviceSource{
+			GuestReset: "off",
+			Address: &api.Address{
+				Bus:    strconv.Itoa(bus),
+				Device: strconv.Itoa(device),
+			},
+		},
+		Type:    "usb",
+		Mode:    "subsystem",
+		Managed: "no",
+	}
+	hostDev.Alias = api.NewUserDefinedAlias(name)
+
+	return hostDev, nil
+}
+
+// enableUSBController turns on the USB controller if it was disabled for lack of USB devices
+func enableUSBController(domain *api.Domain) {
+	for i, controller := range domain.Spec.Devices.Controllers {
+		if controller.Type == "usb" && controller.Model == "none" {
+			domain.Spec.Devices.Controllers[i].Model = "qemu-xhci"
+		}
+	}
+}
+
 func createHostDevicesFromPCIAddresses(pcis []string) ([]api.HostDevice, error) {
 	var hds []api.HostDevice
 	for _, pciAddr := range pcis {
@@ -1824,11 +2606,24 @@ func needsSCSIControler(vmi *v1.VirtualMachineInstance) bool {
 	return !vmi.Spec.Domain.Devices.DisableHotplug
 }
 
+// hasUSBDisk returns true if any disk or cdrom of the VMI is attached to the usb bus
+func hasUSBDisk(vmi *v1.VirtualMachineInstance) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Disk != nil && disk.Disk.Bus == "usb" {
+			return true
+		}
+		if disk.CDRom != nil && disk.CDRom.Bus == "usb" {
+			return true
+		}
+	}
+	return false
+}
+
 func getPrefixFromBus(bus string) string {
 	switch bus {
 	case "virtio":
 		return "vd"
-	case "sata", "scsi":
+	case "sata", "scsi", "usb":
 		return "sd"
 	case "fdc":
 		return "fd"
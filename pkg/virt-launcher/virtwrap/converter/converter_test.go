@@ -36,6 +36,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -136,6 +137,47 @@ var _ = Describe("Converter", func() {
 			Expect(xml).To(Equal(convertedDisk))
 		})
 
+		It("Should send the persistent reservations of a LUN disk to the pr-helper", func() {
+			kubevirtDisk := &v1.Disk{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					LUN: &v1.LunTarget{
+						Bus:         "scsi",
+						Reservation: true,
+					},
+				},
+			}
+			var convertedDisk = `<Disk device="lun" type="">
+  <source>
+    <reservations managed="no">
+      <source type="unix" path="/var/run/kubevirt/daemons/pr/pr-helper.sock" mode="client"></source>
+    </reservations>
+  </source>
+  <target bus="scsi" dev="sda"></target>
+  <driver error_policy="stop" name="qemu" type=""></driver>
+  <alias name="ua-mydisk"></alias>
+</Disk>`
+			xml := diskToDiskXML(kubevirtDisk)
+			Expect(xml).To(Equal(convertedDisk))
+		})
+
+		It("Should give a swap disk without serial the serial the guest finds it by", func() {
+			volume := &v1.Volume{
+				Name: "swap",
+				VolumeSource: v1.VolumeSource{
+					SwapDisk: &v1.SwapDiskSource{
+						Capacity: resource.MustParse("1Gi"),
+					},
+				},
+			}
+			disk := &api.Disk{Driver: &api.DiskDriver{}}
+			Expect(Convert_v1_Volume_To_api_Disk(volume, disk, &ConverterContext{}, 0)).To(Succeed())
+			Expect(disk.Type).To(Equal("file"))
+			Expect(disk.Driver.Type).To(Equal("qcow2"))
+			Expect(disk.Source.File).To(Equal("/var/run/libvirt/empty-disks/swap.qcow2"))
+			Expect(disk.Serial).To(Equal("swap"))
+		})
+
 	})
 
 	Context("with v1.VirtualMachineInstance", func() {
@@ -630,6 +672,7 @@ var _ = Describe("Converter", func() {
     <rng model="virtio-non-transitional">
       <backend model="random">/dev/urandom</backend>
     </rng>
+    <panic model="isa"></panic>
   </devices>
   <clock offset="utc" adjustment="reset">
     <timer name="rtc" tickpolicy="catchup" present="yes" track="guest"></timer>
@@ -845,6 +888,7 @@ var _ = Describe("Converter", func() {
     <rng model="virtio-non-transitional">
       <backend model="random">/dev/urandom</backend>
     </rng>
+    <panic model="pseries"></panic>
   </devices>
   <clock offset="utc" adjustment="reset">
     <timer name="rtc" tickpolicy="catchup" present="yes" track="guest"></timer>
@@ -1076,6 +1120,7 @@ var _ = Describe("Converter", func() {
       <backend model="random">/dev/urandom</backend>
       <address type="pci" domain="0x0000" bus="0x00" slot="0x09" function="0x0"></address>
     </rng>
+    <panic model="isa"></panic>
   </devices>
   <clock offset="utc" adjustment="reset">
     <timer name="rtc" tickpolicy="catchup" present="yes" track="guest"></timer>
@@ -1200,6 +1245,74 @@ var _ = Describe("Converter", func() {
 			table.Entry("when Autoattach memballoon device is false for ppc64le", "ppc64le", convertedDomainppc64leWithFalseAutoattach),
 		)
 
+		table.DescribeTable("should use the architecture specific defaults", func(arch, osArch, machine, videoType string, smbios bool) {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c.Architecture = arch
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.OS.Type.Arch).To(Equal(osArch))
+			Expect(domain.Spec.OS.Type.Machine).To(Equal(machine))
+			Expect(domain.Spec.Devices.Video).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Video[0].Model.Type).To(Equal(videoType))
+			Expect(domain.Spec.OS.SMBios != nil).To(Equal(smbios))
+		},
+			table.Entry("for amd64", "amd64", "x86_64", "q35", "vga", true),
+			table.Entry("for arm64", "arm64", "aarch64", "virt", "virtio", true),
+			table.Entry("for s390x", "s390x", "s390x", "s390-ccw-virtio", "virtio", false),
+		)
+
+		It("should enable free page reporting on the memballoon device if requested by the context", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c.FreePageReporting = true
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Ballooning.FreePageReporting).To(Equal("on"))
+		})
+
+		It("should not enable free page reporting without a memballoon device", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.AutoattachMemBalloon = &_false
+			c.FreePageReporting = true
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Ballooning.Model).To(Equal("none"))
+			Expect(domain.Spec.Devices.Ballooning.FreePageReporting).To(BeEmpty())
+		})
+
+		table.DescribeTable("should decide whether free page reporting is enabled", func(freePageReporting *bool, memory *v1.Memory, expected bool) {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.FreePageReporting = freePageReporting
+			vmi.Spec.Domain.Memory = memory
+			Expect(IsFreePageReportingEnabled(vmi)).To(Equal(expected))
+		},
+			table.Entry("enabled by default", nil, nil, true),
+			table.Entry("enabled if requested", &_true, nil, true),
+			table.Entry("disabled if the VMI opted out", &_false, nil, false),
+			table.Entry("disabled with hugepages", nil, &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}, false),
+			table.Entry("disabled with hugepages even if requested", &_true, &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}, false),
+		)
+
+		It("should attach the downward metrics disk read-only", func() {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "metrics",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "virtio"},
+				},
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "metrics",
+				VolumeSource: v1.VolumeSource{
+					DownwardMetrics: &v1.DownwardMetricsVolumeSource{},
+				},
+			})
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			domain := vmiToDomain(vmi, c)
+			disk := domain.Spec.Devices.Disks[len(domain.Spec.Devices.Disks)-1]
+			Expect(disk.Alias.GetName()).To(Equal("metrics"))
+			Expect(disk.Type).To(Equal("file"))
+			Expect(disk.Target.Bus).To(Equal("virtio"))
+			Expect(disk.Driver.Type).To(Equal("raw"))
+			Expect(disk.ReadOnly).ToNot(BeNil())
+			Expect(disk.Source.File).To(Equal("/var/run/kubevirt-private/downwardmetrics-disk/vhostmd0"))
+		})
+
 		It("should use kvm if present", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			Expect(vmiToDomainXMLToDomainSpec(vmi, c).Type).To(Equal(domainType))
@@ -1217,6 +1330,144 @@ var _ = Describe("Converter", func() {
 			})
 		})
 
+		Context("when a diag288 watchdog is configured", func() {
+			It("should be converted to a diag288 watchdog without a PCI address", func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.Devices.Watchdog = &v1.Watchdog{
+					Name: "mywatchdog",
+					WatchdogDevice: v1.WatchdogDevice{
+						Diag288: &v1.Diag288Watchdog{
+							Action: v1.WatchdogActionShutdown,
+						},
+					},
+				}
+				spec := vmiToDomain(vmi, c).Spec.DeepCopy()
+				Expect(spec.Devices.Watchdog.Model).To(Equal("diag288"))
+				Expect(spec.Devices.Watchdog.Action).To(Equal("shutdown"))
+				Expect(PlacePCIDevicesOnRootComplex(spec)).To(Succeed())
+				Expect(spec.Devices.Watchdog.Address).To(BeNil())
+			})
+		})
+
+		Context("when the guest is marked as Windows", func() {
+			BeforeEach(func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Annotations = map[string]string{v1.GuestOSAnnotation: v1.GuestOSWindows}
+				vmi.Spec.Domain.Features = &v1.Features{
+					Hyperv: &v1.FeatureHyperv{VAPIC: &v1.FeatureState{}},
+				}
+				c.VirtualMachine = vmi
+			})
+
+			It("should enable the enlightened VMCS if the host supports it", func() {
+				c.EVMCSAvailable = true
+				domain := vmiToDomain(vmi, c)
+				Expect(domain.Spec.Features.Hyperv.EVMCS).To(Equal(&api.FeatureState{State: "on"}))
+			})
+
+			It("should not enable the enlightened VMCS if the host does not support it", func() {
+				domain := vmiToDomain(vmi, c)
+				Expect(domain.Spec.Features.Hyperv.EVMCS).To(BeNil())
+			})
+
+			It("should keep an explicitly disabled enlightened VMCS", func() {
+				c.EVMCSAvailable = true
+				vmi.Spec.Domain.Features.Hyperv.EVMCS = &v1.FeatureState{Enabled: pointer.BoolPtr(false)}
+				domain := vmiToDomain(vmi, c)
+				Expect(domain.Spec.Features.Hyperv.EVMCS).To(Equal(&api.FeatureState{State: "off"}))
+			})
+		})
+
+		Context("when a guest panic device is configured", func() {
+			BeforeEach(func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			})
+
+			It("should not add a panic device if it is disabled", func() {
+				vmi.Spec.Domain.Devices.AutoattachPanicDevice = pointer.BoolPtr(false)
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.Devices.Panic).To(BeNil())
+				Expect(domainSpec.OnCrash).To(BeEmpty())
+			})
+
+			It("should dump the guest memory on a crash if a dump PVC is configured", func() {
+				vmi.Spec.Domain.Devices.PanicMemoryDump = &v1.PanicMemoryDump{ClaimName: "dumps"}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.Devices.Panic).ToNot(BeNil())
+				Expect(domainSpec.OnCrash).To(Equal("coredump-destroy"))
+			})
+		})
+
+		Context("when the serial console log is enabled", func() {
+			BeforeEach(func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			})
+
+			It("should not log the serial console by default", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.Devices.Serials).To(HaveLen(1))
+				Expect(domainSpec.Devices.Serials[0].Log).To(BeNil())
+			})
+
+			It("should mirror the serial console to the log file", func() {
+				vmi.Spec.Domain.Devices.LogSerialConsole = pointer.BoolPtr(true)
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.Devices.Serials).To(HaveLen(1))
+				Expect(domainSpec.Devices.Serials[0].Log).To(Equal(&api.SerialLog{
+					File:   fmt.Sprintf("/var/run/kubevirt-private/%s/virt-serial0-log", vmi.UID),
+					Append: "on",
+				}))
+			})
+		})
+
+		Context("when additional serial ports and channels are defined", func() {
+			BeforeEach(func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.Devices.Serials = []v1.SerialPort{{Name: "management"}}
+				vmi.Spec.Domain.Devices.Channels = []v1.Channel{{Name: "agent", TargetName: "org.example.agent.0"}}
+			})
+
+			It("should add the serial ports after the serial console", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.Devices.Serials).To(HaveLen(2))
+				serial := domainSpec.Devices.Serials[1]
+				Expect(*serial.Target.Port).To(Equal(uint(1)))
+				Expect(serial.Source).To(Equal(&api.SerialSource{
+					Mode: "bind",
+					Path: fmt.Sprintf("/var/run/kubevirt-private/%s/virt-serial1", vmi.UID),
+				}))
+				Expect(serial.Alias.GetName()).To(Equal("management"))
+			})
+
+			It("should add the channels with a unix socket", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.Devices.Channels).To(ContainElement(api.Channel{
+					Type: "unix",
+					Source: &api.ChannelSource{
+						Mode: "bind",
+						Path: fmt.Sprintf("/var/run/kubevirt-private/%s/virt-channel0", vmi.UID),
+					},
+					Target: &api.ChannelTarget{
+						Name: "org.example.agent.0",
+						Type: "virtio",
+					},
+				}))
+			})
+		})
+
+		Context("when an Ignition config is referenced", func() {
+			It("should pass the mounted Ignition config through fw_cfg", func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Ignition = &v1.IgnitionSource{SecretRef: &k8sv1.LocalObjectReference{Name: "ignition"}}
+				domain := vmiToDomain(vmi, c)
+				Expect(domain.Spec.QEMUCmd).ToNot(BeNil())
+				Expect(domain.Spec.QEMUCmd.QEMUArg).To(Equal([]api.Arg{
+					{Value: "-fw_cfg"},
+					{Value: "name=opt/com.coreos/config,file=/var/run/kubevirt-private/ignition/config.ign"},
+				}))
+			})
+		})
+
 		Context("when CPU spec defined", func() {
 			It("should convert CPU cores, model and features", func() {
 				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
@@ -1290,6 +1541,22 @@ var _ = Describe("Converter", func() {
 				Expect(domainSpec.VCPU.CPUs).To(Equal(uint32(3)), "Expect vcpus")
 			})
 
+			It("should expose max sockets and enable only the requested vCPUs", func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.CPU = &v1.CPU{
+					Cores:      2,
+					Sockets:    2,
+					MaxSockets: 4,
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.CPU.Topology.Cores).To(Equal(uint32(2)), "Expect cores")
+				Expect(domainSpec.CPU.Topology.Sockets).To(Equal(uint32(4)), "Expect sockets")
+				Expect(domainSpec.CPU.Topology.Threads).To(Equal(uint32(1)), "Expect threads")
+				Expect(domainSpec.VCPU.CPUs).To(Equal(uint32(8)), "Expect vcpus")
+				Expect(domainSpec.VCPU.Current).To(Equal(uint32(4)), "Expect current vcpus")
+			})
+
 			It("should convert CPU requests to sockets", func() {
 				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 				vmi.Spec.Domain.CPU = nil
@@ -1378,6 +1645,280 @@ var _ = Describe("Converter", func() {
 			})
 		})
 
+		Context("when memory hotplug is configured", func() {
+			BeforeEach(func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				guest := resource.MustParse("1Gi")
+				maxGuest := resource.MustParse("4Gi")
+				vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+			})
+
+			It("should back the hotpluggable memory with a virtio-mem device", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.MaxMemory).To(Equal(&api.MaxMemory{Value: 4 * 1024 * 1024 * 1024, Unit: "b", Slots: 1}))
+				Expect(domainSpec.Memory.Value).To(Equal(uint64(1024 * 1024 * 1024)))
+				Expect(domainSpec.CPU.NUMA.Cells).To(HaveLen(1))
+				Expect(domainSpec.CPU.NUMA.Cells[0].Memory).To(Equal("1048576"))
+				Expect(domainSpec.Devices.Memory.Model).To(Equal("virtio-mem"))
+				Expect(domainSpec.Devices.Memory.Target.Size.Value).To(Equal(uint64(3 * 1024 * 1024 * 1024)))
+				Expect(domainSpec.Devices.Memory.Target.Requested.Value).To(BeZero())
+			})
+
+			It("should request the memory plugged in after boot", func() {
+				bootMemory := resource.MustParse("1Gi")
+				guest := resource.MustParse("2Gi")
+				vmi.Spec.Domain.Memory.Guest = &guest
+				vmi.Status.Memory = &v1.MemoryStatus{GuestAtBoot: &bootMemory}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.Memory.Value).To(Equal(uint64(1024 * 1024 * 1024)))
+				Expect(domainSpec.Devices.Memory.Target.Requested.Value).To(Equal(uint64(1024 * 1024 * 1024)))
+			})
+		})
+
+		Context("when a TPM device is configured", func() {
+			BeforeEach(func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{}
+			})
+
+			It("should add an emulated TPM 2.0 device", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.Devices.TPMs).To(HaveLen(1))
+				Expect(domainSpec.Devices.TPMs[0].Model).To(Equal("tpm-tis"))
+				Expect(domainSpec.Devices.TPMs[0].Backend.Type).To(Equal("emulator"))
+				Expect(domainSpec.Devices.TPMs[0].Backend.Version).To(Equal("2.0"))
+				Expect(domainSpec.Devices.TPMs[0].Backend.PersistentState).To(BeEmpty())
+			})
+
+			It("should keep the TPM state when it is persistent", func() {
+				vmi.Spec.Domain.Devices.TPM.Persistent = pointer.BoolPtr(true)
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.Devices.TPMs).To(HaveLen(1))
+				Expect(domainSpec.Devices.TPMs[0].Backend.PersistentState).To(Equal("yes"))
+			})
+		})
+
+		Context("when VSOCK is requested", func() {
+			BeforeEach(func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.Devices.AutoattachVSOCK = pointer.BoolPtr(true)
+			})
+
+			It("should add a virtio socket device with the CID from the status", func() {
+				cid := uint32(100)
+				vmi.Status.VSOCKCID = &cid
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.Devices.VSOCK).ToNot(BeNil())
+				Expect(domainSpec.Devices.VSOCK.Model).To(Equal("virtio-non-transitional"))
+				Expect(domainSpec.Devices.VSOCK.CID.Auto).To(Equal("no"))
+				Expect(domainSpec.Devices.VSOCK.CID.Address).To(Equal(uint32(100)))
+			})
+
+			It("should fail if no CID has been assigned", func() {
+				domain := &api.Domain{}
+				Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+			})
+		})
+
+		Context("when virtiofs filesystems are configured", func() {
+			BeforeEach(func() {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes,
+					v1.Volume{
+						Name: "pvc-fs",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"},
+						},
+					},
+					v1.Volume{
+						Name: "configmap-fs",
+						VolumeSource: v1.VolumeSource{
+							ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: k8sv1.LocalObjectReference{Name: "configmap"}},
+						},
+					},
+					v1.Volume{
+						Name: "secret-fs",
+						VolumeSource: v1.VolumeSource{
+							Secret: &v1.SecretVolumeSource{SecretName: "secret"},
+						},
+					},
+					v1.Volume{
+						Name: "downwardapi-fs",
+						VolumeSource: v1.VolumeSource{
+							DownwardAPI: &v1.DownwardAPIVolumeSource{},
+						},
+					},
+				)
+				for _, name := range []string{"pvc-fs", "configmap-fs", "secret-fs", "downwardapi-fs"} {
+					vmi.Spec.Domain.Devices.Filesystems = append(vmi.Spec.Domain.Devices.Filesystems, v1.Filesystem{
+						Name:     name,
+						Virtiofs: &v1.FilesystemVirtiofs{},
+					})
+				}
+			})
+
+			It("should share the directories of the volumes into the guest", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.Devices.Filesystems).To(HaveLen(4))
+				sourceDirs := map[string]string{}
+				for _, fs := range domainSpec.Devices.Filesystems {
+					Expect(fs.Driver.Type).To(Equal("virtiofs"))
+					sourceDirs[fs.Target.Dir] = fs.Source.Dir
+				}
+				Expect(sourceDirs).To(Equal(map[string]string{
+					"pvc-fs":         "/var/run/kubevirt-private/vmi-disks/pvc-fs/",
+					"configmap-fs":   "/var/run/kubevirt-private/config-map/configmap-fs",
+					"secret-fs":      "/var/run/kubevirt-private/secret/secret-fs",
+					"downwardapi-fs": "/var/run/kubevirt-private/downwardapi/downwardapi-fs",
+				}))
+			})
+
+			It("should map the guest ids to the ids of the shared directory", func() {
+				vmi.Spec.Domain.Devices.Filesystems[0].Virtiofs = &v1.FilesystemVirtiofs{
+					SubPath: "dataset",
+					UIDMap:  &v1.FilesystemIDMap{GuestID: 0, HostID: 107, Count: 1},
+					GIDMap:  &v1.FilesystemIDMap{GuestID: 1000, HostID: 107, Count: 10},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.Devices.Filesystems[0].Target.Dir).To(Equal("pvc-fs"))
+				Expect(domainSpec.Devices.Filesystems[0].Source.Dir).To(Equal("/var/run/kubevirt-private/vmi-disks/pvc-fs/"))
+				Expect(domainSpec.Devices.Filesystems[0].IDMap).To(Equal(&api.FilesystemIDMap{
+					UID: []api.FilesystemIDMapRange{{Start: 0, Target: 107, Count: 1}},
+					GID: []api.FilesystemIDMapRange{{Start: 1000, Target: 107, Count: 10}},
+				}))
+				Expect(domainSpec.Devices.Filesystems[1].IDMap).To(BeNil())
+			})
+		})
+
+		Context("when a disk is encrypted", func() {
+			BeforeEach(func() {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "encrypted",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"},
+					},
+				})
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+					Name:       "encrypted",
+					Encryption: &v1.DiskEncryption{SecretName: "luks"},
+				})
+			})
+
+			getDisk := func(domainSpec *api.DomainSpec, name string) api.Disk {
+				for _, disk := range domainSpec.Devices.Disks {
+					if disk.Alias.GetName() == name {
+						return disk
+					}
+				}
+				Fail("disk " + name + " not found")
+				return api.Disk{}
+			}
+
+			It("should open the LUKS layer with the passphrase of the disk", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				disk := getDisk(domainSpec, "encrypted")
+				Expect(disk.Driver.Type).To(Equal("raw"))
+				Expect(disk.Source.Encryption).To(Equal(&api.DiskEncryption{
+					Format: "luks",
+					Secret: &api.DiskSecret{
+						Type:  "passphrase",
+						Usage: fmt.Sprintf("kubevirt/%s/%s/encrypted", vmi.Namespace, vmi.Name),
+					},
+				}))
+				Expect(getDisk(domainSpec, "pvc_block_test").Source.Encryption).To(BeNil())
+			})
+
+			It("should open qcow2 images with LUKS encryption", func() {
+				vmi.Spec.Domain.Devices.Disks[len(vmi.Spec.Domain.Devices.Disks)-1].Encryption.Format = v1.DiskEncryptionFormatQcow2
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				disk := getDisk(domainSpec, "encrypted")
+				Expect(disk.Driver.Type).To(Equal("qcow2"))
+				Expect(disk.Source.Encryption.Format).To(Equal("luks"))
+			})
+		})
+
+		Context("when SEV is configured", func() {
+			BeforeEach(func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SEV: &v1.SEV{}}
+				c.SEVNodeParameters = &SEVNodeParameters{CBitPos: 47, ReducedPhysBits: 1}
+			})
+
+			It("should set the launch security with guest debugging disabled", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.LaunchSecurity).To(Equal(&api.LaunchSecurity{
+					Type:            "sev",
+					Cbitpos:         "47",
+					ReducedPhysBits: "1",
+					Policy:          "0x1",
+				}))
+			})
+
+			It("should set the encrypted state policy bit for SEV-ES", func() {
+				vmi.Spec.Domain.LaunchSecurity.SEV.Policy = &v1.SEVPolicy{EncryptedState: pointer.BoolPtr(true)}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.LaunchSecurity.Policy).To(Equal("0x5"))
+			})
+
+			It("should disable ballooning and make virtio devices use the IOMMU", func() {
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.Devices.Ballooning.Model).To(Equal("none"))
+				virtioDisks := 0
+				for _, disk := range domainSpec.Devices.Disks {
+					if disk.Target.Bus == "virtio" {
+						virtioDisks++
+						Expect(disk.Driver.IOMMU).To(Equal("on"))
+					}
+				}
+				Expect(virtioDisks).ToNot(BeZero())
+			})
+
+			It("should boot SEV-SNP guests from stateless firmware", func() {
+				vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					Bootloader: &v1.Bootloader{EFI: &v1.EFI{SecureBoot: pointer.BoolPtr(false)}},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.LaunchSecurity).To(Equal(&api.LaunchSecurity{
+					Type:            "sev-snp",
+					Cbitpos:         "47",
+					ReducedPhysBits: "1",
+					Policy:          "0x30000",
+				}))
+				Expect(domainSpec.OS.BootLoader.Path).To(HaveSuffix(EFICodeSEVSNP))
+				Expect(domainSpec.OS.BootLoader.Stateless).To(Equal("yes"))
+				Expect(domainSpec.OS.NVRam).To(BeNil())
+			})
+
+			It("should boot TDX guests from stateless firmware", func() {
+				vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					Bootloader: &v1.Bootloader{EFI: &v1.EFI{SecureBoot: pointer.BoolPtr(false)}},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.LaunchSecurity).To(Equal(&api.LaunchSecurity{
+					Type:   "tdx",
+					Policy: "0x10000000",
+				}))
+				Expect(domainSpec.OS.BootLoader.Path).To(HaveSuffix(EFICodeTDX))
+				Expect(domainSpec.OS.NVRam).To(BeNil())
+				Expect(domainSpec.Devices.Ballooning.Model).To(Equal("none"))
+			})
+		})
+
 		It("should set disk pci address when specified", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Disks[0].Disk.PciAddress = "0000:81:01.0"
@@ -1423,6 +1964,43 @@ var _ = Describe("Converter", func() {
 			}))
 		})
 
+		It("should enable the usb controller and name the device like a scsi disk if a usb disk is present", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Inputs[0].Bus = "virtio"
+			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "usb"
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(api.Controller{
+				Type:  "usb",
+				Index: "0",
+				Model: "qemu-xhci",
+			}))
+			Expect(domain.Spec.Devices.Disks[0].Target.Bus).To(Equal("usb"))
+			Expect(domain.Spec.Devices.Disks[0].Target.Device).To(HavePrefix("sd"))
+			Expect(domain.Spec.Devices.Disks[0].Model).To(BeEmpty())
+		})
+
+		It("should add an empty CD-ROM drive for a CD-ROM without a volume", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "empty-cdrom",
+				DiskDevice: v1.DiskDevice{
+					CDRom: &v1.CDRomTarget{Bus: "sata"},
+				},
+			})
+			domain := vmiToDomain(vmi, c)
+			emptyCDRom := domain.Spec.Devices.Disks[len(domain.Spec.Devices.Disks)-1]
+			Expect(emptyCDRom.Alias.GetName()).To(Equal("empty-cdrom"))
+			Expect(emptyCDRom.Device).To(Equal("cdrom"))
+			Expect(emptyCDRom.Type).To(Equal("file"))
+			Expect(emptyCDRom.Source).To(Equal(api.DiskSource{}))
+		})
+
+		It("should fail to convert a disk without a volume which is not a CD-ROM", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: "no-volume"})
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, c)).ToNot(Succeed())
+		})
+
 		It("should not disable usb controller when usb device is present", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Inputs[0].Bus = "usb"
@@ -1810,23 +2388,46 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.Interfaces[1].Type).To(Equal("ethernet"))
 			Expect(domain.Spec.Devices.Interfaces[2].Type).To(Equal("ethernet"))
 		})
-		It("Should set domain interface source correctly for default multus", func() {
+		It("Should not create a domain interface for an unplugged interface", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
 				*v1.DefaultBridgeNetworkInterface(),
 				*v1.DefaultBridgeNetworkInterface(),
 			}
-			vmi.Spec.Domain.Devices.Interfaces[0].Name = "red1"
-			vmi.Spec.Domain.Devices.Interfaces[1].Name = "red2"
+			vmi.Spec.Domain.Devices.Interfaces[1].Name = "red"
+			vmi.Spec.Domain.Devices.Interfaces[1].State = v1.InterfaceStateAbsent
 			vmi.Spec.Networks = []v1.Network{
-				v1.Network{
-					Name: "red1",
+				*v1.DefaultPodNetwork(),
+				{
+					Name: "red",
 					NetworkSource: v1.NetworkSource{
-						Multus: &v1.MultusNetwork{NetworkName: "red", Default: true},
+						Multus: &v1.MultusNetwork{NetworkName: "red"},
 					},
 				},
-				v1.Network{
-					Name: "red2",
+			}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(Equal(nil))
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].Alias.GetName()).To(Equal("default"))
+		})
+		It("Should set domain interface source correctly for default multus", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				*v1.DefaultBridgeNetworkInterface(),
+			}
+			vmi.Spec.Domain.Devices.Interfaces[0].Name = "red1"
+			vmi.Spec.Domain.Devices.Interfaces[1].Name = "red2"
+			vmi.Spec.Networks = []v1.Network{
+				v1.Network{
+					Name: "red1",
+					NetworkSource: v1.NetworkSource{
+						Multus: &v1.MultusNetwork{NetworkName: "red", Default: true},
+					},
+				},
+				v1.Network{
+					Name: "red2",
 					NetworkSource: v1.NetworkSource{
 						Multus: &v1.MultusNetwork{NetworkName: "red"},
 					},
@@ -1860,7 +2461,23 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(2))
 			Expect(domain.Spec.Devices.Interfaces[0].BootOrder).NotTo(BeNil())
 			Expect(domain.Spec.Devices.Interfaces[0].BootOrder.Order).To(Equal(uint(bootOrder)))
+			Expect(domain.Spec.Devices.Interfaces[0].Rom).To(BeNil())
 			Expect(domain.Spec.Devices.Interfaces[1].BootOrder).To(BeNil())
+			Expect(domain.Spec.Devices.Interfaces[1].Rom).To(Equal(&api.Rom{Enabled: "no"}))
+		})
+		It("should allow disabling the option ROM of a bootable interface", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			iface := v1.DefaultBridgeNetworkInterface()
+			bootOrder := uint(1)
+			iface.BootOrder = &bootOrder
+			iface.ROMDisabled = true
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(Equal(nil))
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].BootOrder.Order).To(Equal(bootOrder))
+			Expect(domain.Spec.Devices.Interfaces[0].Rom).To(Equal(&api.Rom{Enabled: "no"}))
 		})
 		It("Should create network configuration for masquerade interface", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
@@ -1905,6 +2522,71 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("ethernet"))
 			Expect(domain.Spec.Devices.Interfaces[1].Type).To(Equal("ethernet"))
 		})
+		It("Should create a passt user interface forwarding all ports when none are listed", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultPasstNetworkInterface()}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("user"))
+			Expect(domain.Spec.Devices.Interfaces[0].Backend).To(Equal(&api.InterfaceBackend{Type: "passt"}))
+			Expect(domain.Spec.Devices.Interfaces[0].PortForward).To(Equal([]api.InterfacePortForward{{Proto: "tcp"}, {Proto: "udp"}}))
+		})
+		It("Should create a passt user interface forwarding the listed ports by protocol", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			iface := v1.DefaultPasstNetworkInterface()
+			iface.Ports = []v1.Port{{Port: 80}, {Port: 53, Protocol: "UDP"}, {Port: 443, Protocol: "TCP"}}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].PortForward).To(Equal([]api.InterfacePortForward{
+				{Proto: "tcp", Ranges: []api.InterfacePortForwardRange{{Start: 80}, {Start: 443}}},
+				{Proto: "udp", Ranges: []api.InterfacePortForwardRange{{Start: 53}}},
+			}))
+		})
+		It("Should set the bandwidth limits of a masquerade interface in KiB", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			peak := resource.MustParse("2Mi")
+			burst := resource.MustParse("1500")
+			iface := v1.DefaultMasqueradeNetworkInterface()
+			iface.Bandwidth = &v1.InterfaceBandwidth{
+				Inbound: &v1.BandwidthLimit{Average: resource.MustParse("1Mi"), Peak: &peak, Burst: &burst},
+			}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].BandWidth).To(Equal(&api.BandWidth{
+				Inbound: &api.BandWidthLimit{Average: "1024", Peak: "2048", Burst: "2"},
+			}))
+		})
+		It("Should report the bandwidth limits of a domain interface", func() {
+			bandwidth := InterfaceBandwidthFromDomain(&api.BandWidth{
+				Outbound: &api.BandWidthLimit{Average: "1024", Burst: "2"},
+			})
+			Expect(bandwidth.Inbound).To(BeNil())
+			Expect(bandwidth.Outbound.Average.String()).To(Equal("1Mi"))
+			Expect(bandwidth.Outbound.Peak).To(BeNil())
+			Expect(bandwidth.Outbound.Burst.String()).To(Equal("2Ki"))
+			Expect(InterfaceBandwidthFromDomain(&api.BandWidth{})).To(BeNil())
+		})
+		It("Should report the queue count of a domain interface", func() {
+			queues := uint(4)
+			Expect(InterfaceQueueCountFromDomain(&api.InterfaceDriver{Name: "vhost", Queues: &queues})).To(Equal(int32(4)))
+			Expect(InterfaceQueueCountFromDomain(nil)).To(Equal(int32(1)))
+		})
+		It("Should leave interfaces bound by a network binding plugin to the plugin", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", Binding: &v1.PluginBinding{Name: "mybinding"}}}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(BeEmpty())
+		})
 		It("Should create network configuration for macvtap interface and a multus network", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			multusNetworkName := "multusNet"
@@ -1926,6 +2608,73 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1), "should have a single interface")
 			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("ethernet"), "Macvtap interfaces must be of type `ethernet`")
 		})
+		It("Should create network configuration for a vDPA interface", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{{
+				Name:          "net1",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "vdpa-net"}},
+			}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "net1", InterfaceBindingMethod: v1.InterfaceBindingMethod{Vdpa: &v1.InterfaceVdpa{}}},
+			}
+			c.VdpaDevices = map[string]string{"net1": "/dev/vhost-vdpa-0"}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("vdpa"))
+			Expect(domain.Spec.Devices.Interfaces[0].Source.Device).To(Equal("/dev/vhost-vdpa-0"))
+			Expect(domain.Spec.Devices.Interfaces[0].Model.Type).To(Equal("virtio-non-transitional"))
+		})
+		It("Should fail to convert a vDPA interface without an allocated device", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{{
+				Name:          "net1",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "vdpa-net"}},
+			}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "net1", InterfaceBindingMethod: v1.InterfaceBindingMethod{Vdpa: &v1.InterfaceVdpa{}}},
+			}
+
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, c)).ToNot(Succeed())
+		})
+		It("Should create network configuration for a vhost-user interface", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{{
+				Name:          "dpdk",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "ovs-dpdk"}},
+			}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "dpdk", InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}}},
+			}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("vhostuser"))
+			Expect(domain.Spec.Devices.Interfaces[0].Source).To(Equal(api.InterfaceSource{
+				Type: "unix",
+				Path: "/var/run/vhostuser/dpdk.sock",
+				Mode: "server",
+			}))
+			Expect(domain.Spec.MemoryBacking.Access).To(Equal(&api.MemoryBackingAccess{Mode: "shared"}))
+			Expect(domain.Spec.MemoryBacking.Source).To(Equal(&api.MemoryBackingSource{Type: "memfd"}))
+		})
+		It("Should not use vhost-net for the queues of a vhost-user interface", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{{
+				Name:          "dpdk",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "ovs-dpdk"}},
+			}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "dpdk", InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}}},
+			}
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = pointer.BoolPtr(true)
+			vmi.Spec.Domain.CPU = &v1.CPU{Cores: 2}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].Driver.Name).To(BeEmpty())
+			Expect(*domain.Spec.Devices.Interfaces[0].Driver.Queues).To(Equal(uint(2)))
+		})
 		It("Should create network configuration for the default pod network plus a secondary macvtap network interface using multus", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			secondaryNetworkName := "net1"
@@ -2464,6 +3213,208 @@ var _ = Describe("Converter", func() {
 			isExpectedThreadsLayout := reflect.DeepEqual(expectedLayout, domain.Spec.CPUTune.IOThreadPin)
 			Expect(isExpectedThreadsLayout).To(BeTrue())
 		})
+		It("should pin the emulator thread and all iothreads on the isolated pcpu", func() {
+			vmi.Spec.Domain.CPU.Cores = 2
+			vmi.Spec.Domain.CPU.IsolateEmulatorThread = true
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "disk0", DedicatedIOThread: True(), DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				{Name: "disk1", DedicatedIOThread: True(), DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "disk0", VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc0"}}}},
+				{Name: "disk1", VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc1"}}}},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			emulatorThreadCpu := 7
+			c := &ConverterContext{CPUSet: []int{5, 6}, EmulatorThreadCpu: &emulatorThreadCpu, UseEmulation: true, SMBios: &cmdv1.SMBios{}}
+			domain := vmiToDomain(vmi, c)
+
+			Expect(domain.Spec.CPUTune.EmulatorPin.CPUSet).To(Equal("7"))
+			Expect(domain.Spec.CPUTune.IOThreadPin).To(HaveLen(int(domain.Spec.IOThreads.IOThreads)))
+			for _, pin := range domain.Spec.CPUTune.IOThreadPin {
+				Expect(pin.CPUSet).To(Equal("7"))
+			}
+			for _, disk := range domain.Spec.Devices.Disks {
+				Expect(disk.Driver.IOThread).ToNot(BeNil())
+			}
+		})
+		It("should pin the dedicated iothreads on the vcpus if requested", func() {
+			vmi.Spec.Domain.CPU.Cores = 2
+			vmi.Spec.Domain.CPU.IsolateEmulatorThread = true
+			sharedPolicy := v1.IOThreadsPolicyShared
+			vmi.Spec.Domain.IOThreadsPolicy = &sharedPolicy
+			vmi.Spec.Domain.IOThreads = &v1.DiskIOThreads{DedicatedPinning: v1.DedicatedIOThreadsPinningVCPUs}
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				{Name: "disk1", DedicatedIOThread: True(), DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				{Name: "disk2", DedicatedIOThread: True(), DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "disk0", VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc0"}}}},
+				{Name: "disk1", VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc1"}}}},
+				{Name: "disk2", VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc2"}}}},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			emulatorThreadCpu := 7
+			c := &ConverterContext{CPUSet: []int{5, 6}, EmulatorThreadCpu: &emulatorThreadCpu, UseEmulation: true, SMBios: &cmdv1.SMBios{}}
+			domain := vmiToDomain(vmi, c)
+
+			Expect(domain.Spec.IOThreads.IOThreads).To(Equal(uint(3)))
+			Expect(domain.Spec.CPUTune.IOThreadPin).To(Equal([]api.CPUTuneIOThreadPin{
+				{IOThread: 1, CPUSet: "7"},
+				{IOThread: 2, CPUSet: "5"},
+				{IOThread: 3, CPUSet: "6"},
+			}))
+		})
+		It("should share the given number of iothreads between the disks without a dedicated iothread", func() {
+			autoPolicy := v1.IOThreadsPolicyAuto
+			vmi.Spec.Domain.IOThreadsPolicy = &autoPolicy
+			poolSize := uint32(2)
+			vmi.Spec.Domain.IOThreads = &v1.DiskIOThreads{SharedPoolSize: &poolSize}
+			vmi.Spec.Domain.Devices.Disks = nil
+			vmi.Spec.Volumes = nil
+			for i := 0; i < 4; i++ {
+				name := fmt.Sprintf("disk%d", i)
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: name, DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}})
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{Name: name, VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: name}}}})
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c := &ConverterContext{CPUSet: []int{5, 6, 7, 8}, UseEmulation: true, SMBios: &cmdv1.SMBios{}}
+			domain := vmiToDomain(vmi, c)
+
+			Expect(domain.Spec.IOThreads.IOThreads).To(Equal(uint(2)))
+			var threads []uint
+			for _, disk := range domain.Spec.Devices.Disks {
+				threads = append(threads, *disk.Driver.IOThread)
+			}
+			Expect(threads).To(Equal([]uint{1, 2, 1, 2}))
+		})
+	})
+	Context("with guest NUMA mapping passthrough", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{
+							Cores:                 4,
+							DedicatedCPUPlacement: true,
+							NUMA:                  &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}},
+						},
+						Memory: &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}},
+						Resources: v1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{
+								k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+							},
+						},
+					},
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		})
+
+		It("should create a guest NUMA node for every host NUMA node of the pinned CPUs", func() {
+			c := &ConverterContext{
+				CPUSet:       []int{2, 3, 10, 11},
+				CPUNUMANodes: map[int]int{2: 0, 3: 0, 10: 1, 11: 1},
+				UseEmulation: true,
+				SMBios:       &cmdv1.SMBios{},
+			}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]api.NUMACell{
+				{ID: "0", CPUs: "0,1", Memory: "32768", Unit: "KiB"},
+				{ID: "1", CPUs: "2,3", Memory: "32768", Unit: "KiB"},
+			}))
+			Expect(domain.Spec.NUMATune).To(Equal(&api.NUMATune{
+				Memory: api.NumaTuneMemory{Mode: "strict", NodeSet: "0,1"},
+				MemNodes: []api.MemNode{
+					{CellID: 0, Mode: "strict", NodeSet: "0"},
+					{CellID: 1, Mode: "strict", NodeSet: "1"},
+				},
+			}))
+			Expect(domain.Spec.MemoryBacking.HugePages.HugePage).To(Equal([]api.HugePage{
+				{Size: "2048", Unit: "KiB", NodeSet: "0-1"},
+			}))
+		})
+
+		It("should split the memory proportionally to the vCPUs of each guest NUMA node", func() {
+			c := &ConverterContext{
+				CPUSet:       []int{2, 3, 10, 11},
+				CPUNUMANodes: map[int]int{2: 3, 3: 3, 10: 3, 11: 1},
+				UseEmulation: true,
+				SMBios:       &cmdv1.SMBios{},
+			}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]api.NUMACell{
+				{ID: "0", CPUs: "3", Memory: "16384", Unit: "KiB"},
+				{ID: "1", CPUs: "0,1,2", Memory: "49152", Unit: "KiB"},
+			}))
+			Expect(domain.Spec.NUMATune.Memory.NodeSet).To(Equal("1,3"))
+			Expect(domain.Spec.NUMATune.MemNodes[0].NodeSet).To(Equal("1"))
+			Expect(domain.Spec.NUMATune.MemNodes[1].NodeSet).To(Equal("3"))
+		})
+
+		It("should fail if the NUMA topology of the host is unknown", func() {
+			c := &ConverterContext{
+				CPUSet:       []int{2, 3, 10, 11},
+				UseEmulation: true,
+				SMBios:       &cmdv1.SMBios{},
+			}
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+		})
+	})
+	Context("with realtime", func() {
+		var vmi *v1.VirtualMachineInstance
+		var c *ConverterContext
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{
+							Cores:                 4,
+							DedicatedCPUPlacement: true,
+							Realtime:              &v1.Realtime{},
+						},
+						Resources: v1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{
+								k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+							},
+						},
+					},
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c = &ConverterContext{CPUSet: []int{2, 3, 4, 5}, UseEmulation: true, SMBios: &cmdv1.SMBios{}}
+		})
+
+		It("should run all vCPUs with FIFO scheduling and lock the memory", func() {
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.CPUTune.VCPUScheduler).To(Equal([]api.VCPUScheduler{
+				{VCPUs: "0-3", Scheduler: "fifo", Priority: 1},
+			}))
+			Expect(domain.Spec.MemoryBacking.Locked).ToNot(BeNil())
+		})
+
+		It("should only run the vCPUs of the mask with FIFO scheduling", func() {
+			vmi.Spec.Domain.CPU.Realtime.Mask = "0-3,^1"
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.CPUTune.VCPUScheduler).To(Equal([]api.VCPUScheduler{
+				{VCPUs: "0-3,^1", Scheduler: "fifo", Priority: 1},
+			}))
+		})
 	})
 	Context("virtio-net multi-queue", func() {
 		var vmi *v1.VirtualMachineInstance
@@ -2527,6 +3478,125 @@ var _ = Describe("Converter", func() {
 			Expect(*(domain.Spec.Devices.Interfaces[0].Driver.Queues)).To(Equal(expectedNumberQueues),
 				"should be capped to the maximum number of queues on tap devices")
 		})
+
+		It("should enable RSS on vhost-net if requested", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].RSS = &v1.InterfaceRSS{}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			driver := domain.Spec.Devices.Interfaces[0].Driver
+			Expect(driver.Name).To(Equal("vhost"))
+			Expect(driver.RSS).To(Equal("on"))
+			Expect(driver.RSSHashReport).To(BeEmpty())
+		})
+
+		It("should emulate the device in QEMU to report the RSS hash", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].RSS = &v1.InterfaceRSS{HashReport: true}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			driver := domain.Spec.Devices.Interfaces[0].Driver
+			Expect(driver.Name).To(Equal("qemu"))
+			Expect(driver.RSS).To(Equal("on"))
+			Expect(driver.RSSHashReport).To(Equal("on"))
+		})
+	})
+
+	Context("virtio-net offloads", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "mynamespace",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		})
+
+		It("should not toggle offloads by default", func() {
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			Expect(domain.Spec.Devices.Interfaces[0].Driver).To(BeNil())
+		})
+
+		It("should disable the segmentation offloads with the checksum offload", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].Offloads = &v1.InterfaceOffloads{Checksum: False()}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			driver := domain.Spec.Devices.Interfaces[0].Driver
+			Expect(driver.Host).To(Equal(&api.InterfaceDriverHost{CSum: "off", GSO: "off", TSO4: "off", TSO6: "off"}))
+			Expect(driver.Guest).To(Equal(&api.InterfaceDriverGuest{CSum: "off", TSO4: "off", TSO6: "off"}))
+		})
+
+		It("should only toggle the requested offloads", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].Offloads = &v1.InterfaceOffloads{TSO: False()}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			driver := domain.Spec.Devices.Interfaces[0].Driver
+			Expect(driver.Host).To(Equal(&api.InterfaceDriverHost{TSO4: "off", TSO6: "off"}))
+			Expect(driver.Guest).To(Equal(&api.InterfaceDriverGuest{TSO4: "off", TSO6: "off"}))
+		})
+
+		It("should keep the queues of a multi-queue device", func() {
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = True()
+			vmi.Spec.Domain.Devices.Interfaces[0].Offloads = &v1.InterfaceOffloads{GSO: False()}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			driver := domain.Spec.Devices.Interfaces[0].Driver
+			Expect(driver.Name).To(Equal("vhost"))
+			Expect(driver.Queues).ToNot(BeNil())
+			Expect(driver.Host).To(Equal(&api.InterfaceDriverHost{GSO: "off"}))
+			Expect(driver.Guest).To(BeNil())
+		})
+
+		It("should not toggle the offloads of a non-virtio device", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+			vmi.Spec.Domain.Devices.Interfaces[0].Offloads = &v1.InterfaceOffloads{Checksum: False()}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			Expect(domain.Spec.Devices.Interfaces[0].Driver).To(BeNil())
+		})
+	})
+
+	table.DescribeTable("should set the link state of an interface", func(state v1.InterfaceState, expectedLinkState *api.LinkState) {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: k8smeta.ObjectMeta{
+				Name:      "testvmi",
+				Namespace: "mynamespace",
+			},
+		}
+		v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+		vmi.Spec.Domain.Devices.Interfaces[0].State = state
+
+		domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+		Expect(domain.Spec.Devices.Interfaces[0].LinkState).To(Equal(expectedLinkState))
+	},
+		table.Entry("leaving the link up by default", v1.InterfaceState(""), nil),
+		table.Entry("leaving the link up when requested", v1.InterfaceStateLinkUp, nil),
+		table.Entry("taking the link down when requested", v1.InterfaceStateLinkDown, &api.LinkState{State: "down"}),
+	)
+
+	It("should make the failover interface of an SR-IOV interface the persistent device of the team", func() {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: k8smeta.ObjectMeta{
+				Name:      "testvmi",
+				Namespace: "mynamespace",
+			},
+		}
+		v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		vmi.Spec.Networks = []v1.Network{
+			*v1.DefaultPodNetwork(),
+			{Name: "backup", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "bridge-net"}}},
+			{Name: "sriov", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "sriov-net"}}},
+		}
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+			*v1.DefaultMasqueradeNetworkInterface(),
+			{Name: "backup", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{Failover: "backup"}}},
+		}
+
+		domainInterfaces, err := createDomainInterfaces(vmi, &api.Domain{}, &ConverterContext{UseEmulation: true}, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(domainInterfaces).To(HaveLen(2))
+		Expect(domainInterfaces[0].Teaming).To(BeNil())
+		Expect(domainInterfaces[1].Teaming).To(Equal(&api.Teaming{Type: "persistent"}))
 	})
 
 	Context("Bootloader", func() {
@@ -2597,6 +3667,21 @@ var _ = Describe("Converter", func() {
 				Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/tmp/mynamespace_testvmi"))
 			})
 
+			It("should configure the AAVMF firmware if EFI insecure option on arm64", func() {
+				c.Architecture = "arm64"
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					Bootloader: &v1.Bootloader{
+						EFI: &v1.EFI{
+							SecureBoot: False(),
+						},
+					},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.OS.BootLoader.Secure).To(Equal("no"))
+				Expect(path.Base(domainSpec.OS.BootLoader.Path)).To(Equal(EFICodeAARCH64))
+				Expect(path.Base(domainSpec.OS.NVRam.Template)).To(Equal(EFIVarsAARCH64))
+			})
+
 			It("should configure the EFI bootloader if EFI secure option", func() {
 				vmi.Spec.Domain.Firmware = &v1.Firmware{
 					Bootloader: &v1.Bootloader{
@@ -2611,6 +3696,19 @@ var _ = Describe("Converter", func() {
 				Expect(path.Base(domainSpec.OS.NVRam.Template)).To(Equal(EFIVarsSecureBoot))
 				Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/tmp/mynamespace_testvmi"))
 			})
+
+			It("should keep the NVRAM on the backend storage if EFI is persistent", func() {
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					Bootloader: &v1.Bootloader{
+						EFI: &v1.EFI{
+							Persistent: True(),
+						},
+					},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(path.Base(domainSpec.OS.NVRam.Template)).To(Equal(EFIVarsSecureBoot))
+				Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/var/lib/libvirt/qemu/nvram/mynamespace_testvmi"))
+			})
 		})
 	})
 
@@ -2805,6 +3903,98 @@ var _ = Describe("Converter", func() {
 		})
 	})
 
+	table.DescribeTable("should pick the cache mode of a disk", func(supportDirectIO bool, shared bool, expectedMode v1.DriverCache) {
+		Expect(autoCacheMode(supportDirectIO, shared)).To(Equal(expectedMode))
+	},
+		table.Entry("none with direct I/O", true, false, v1.CacheNone),
+		table.Entry("none with direct I/O on a shared volume", true, true, v1.CacheNone),
+		table.Entry("writethrough without direct I/O on a shared volume", false, true, v1.CacheWriteThrough),
+		table.Entry("writeback without direct I/O on a local volume", false, false, v1.CacheWriteBack),
+	)
+
+	It("should not set a cache mode on NVMe disks", func() {
+		disk := &api.Disk{Type: "nvme", Driver: &api.DiskDriver{}}
+		Expect(SetDriverCacheMode(disk, false)).To(Succeed())
+		Expect(disk.Driver.Cache).To(BeEmpty())
+	})
+
+	table.DescribeTable("should pick the discard mode of a disk", func(disk *api.Disk, expectedMode string) {
+		SetOptimalDiscardMode(disk)
+		Expect(disk.Driver.Discard).To(Equal(expectedMode))
+	},
+		table.Entry("unmap for a block device",
+			&api.Disk{Source: api.DiskSource{Dev: "/dev/disk0"}, Driver: &api.DiskDriver{}}, "unmap"),
+		table.Entry("the explicitly set mode",
+			&api.Disk{Source: api.DiskSource{Dev: "/dev/disk0"}, Driver: &api.DiskDriver{Discard: "ignore"}}, "ignore"),
+		table.Entry("unmap to unmap zeroes of a read only disk",
+			&api.Disk{Source: api.DiskSource{Dev: "/dev/disk0"}, ReadOnly: &api.ReadOnly{}, Driver: &api.DiskDriver{DetectZeroes: "unmap"}}, "unmap"),
+		table.Entry("none for a read only disk",
+			&api.Disk{Source: api.DiskSource{Dev: "/dev/disk0"}, ReadOnly: &api.ReadOnly{}, Driver: &api.DiskDriver{}}, ""),
+		table.Entry("none for a CD-ROM",
+			&api.Disk{Device: "cdrom", Source: api.DiskSource{File: "/var/run/kubevirt-private/vmi-disks/cdrom/disk.img"}, Driver: &api.DiskDriver{}}, ""),
+		table.Entry("none for a LUN",
+			&api.Disk{Device: "lun", Source: api.DiskSource{Dev: "/dev/disk0"}, Driver: &api.DiskDriver{}}, ""),
+		table.Entry("none for a disk without a source",
+			&api.Disk{Driver: &api.DiskDriver{}}, ""),
+	)
+
+	It("should pass the discard and detect zeroes modes of a disk to the driver", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+			Name:         "mydisk",
+			Discard:      v1.DiscardUnmap,
+			DetectZeroes: v1.DetectZeroesUnmap,
+			DiskDevice:   v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+		}}
+		disk := &api.Disk{}
+		Expect(Convert_v1_Disk_To_api_Disk(&ConverterContext{}, &vmi.Spec.Domain.Devices.Disks[0], disk, map[string]deviceNamer{}, nil)).To(Succeed())
+		Expect(disk.Driver.Discard).To(Equal("unmap"))
+		Expect(disk.Driver.DetectZeroes).To(Equal("unmap"))
+	})
+
+	Context("disk I/O limits", func() {
+		It("should convert the I/O limits of a disk into an iotune", func() {
+			ioTune := &v1.DiskIOTune{
+				IOPS:      &v1.DiskIOLimits{Read: 100, Write: 50, ReadBurst: 200, BurstLength: 10},
+				Bandwidth: &v1.DiskIOLimits{Total: 1048576},
+			}
+			apiIOTune := Convert_v1_DiskIOTune_To_api_IOTune(ioTune)
+			Expect(apiIOTune).To(Equal(&api.IOTune{
+				ReadIopsSec:          100,
+				WriteIopsSec:         50,
+				ReadIopsSecMax:       200,
+				ReadIopsSecMaxLength: 10,
+				TotalBytesSec:        1048576,
+			}))
+			Expect(Convert_api_IOTune_To_v1_DiskIOTune(apiIOTune)).To(Equal(ioTune))
+		})
+
+		It("should add the iotune to the disk", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "virtio"},
+				},
+				IOTune: &v1.DiskIOTune{IOPS: &v1.DiskIOLimits{Total: 500}},
+			}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "mydisk",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "mypvc"},
+				},
+			}}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Disks[0].IOTune).To(Equal(&api.IOTune{TotalIopsSec: 500}))
+		})
+
+		It("should not report empty limits", func() {
+			Expect(Convert_api_IOTune_To_v1_DiskIOTune(&api.IOTune{})).To(BeNil())
+		})
+	})
+
 	Context("HostDevices resource request", func() {
 		vmi := &v1.VirtualMachineInstance{
 			ObjectMeta: k8smeta.ObjectMeta{
@@ -2861,6 +4051,83 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.HostDevices[1].Model).To(Equal("vfio-pci"))
 			Expect(domain.Spec.Devices.HostDevices[1].Alias.GetName()).To(Equal("mdev_name"))
 		})
+
+		It("should convert HostDevices resources request into USB host devices and enable the USB controller", func() {
+			usbVMI := vmi.DeepCopy()
+			usbVMI.Spec.Domain.Devices.HostDevices = []v1.HostDevice{
+				{
+					DeviceName: "vendor.com/usb_name",
+					Name:       "usb_name",
+				},
+			}
+			c := &ConverterContext{
+				UseEmulation: true,
+				HostDevices: map[string]HostDevicesList{
+					"vendor.com/usb_name": HostDevicesList{
+						Type:     HostDeviceUSB,
+						AddrList: []string{"001:012"},
+					},
+				},
+			}
+			domain := vmiToDomain(usbVMI, c)
+
+			Expect(domain.Spec.Devices.HostDevices).To(Equal([]api.HostDevice{
+				{
+					Source: api.HostDeviceSource{
+						GuestReset: "off",
+						Address: &api.Address{
+							Bus:    "1",
+							Device: "12",
+						},
+					},
+					Type:    "usb",
+					Mode:    "subsystem",
+					Managed: "no",
+					Alias:   api.NewUserDefinedAlias("usb_name"),
+				},
+			}))
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(api.Controller{
+				Type:  "usb",
+				Index: "0",
+				Model: "qemu-xhci",
+			}))
+		})
+
+		It("should convert NVMe HostDevices into virtio disks backed by the namespace of the controller", func() {
+			nvmeVMI := vmi.DeepCopy()
+			nvmeVMI.Spec.Domain.Devices.HostDevices = []v1.HostDevice{
+				{
+					DeviceName: "vendor.com/nvme_name",
+					Name:       "nvme_name",
+					NVMe:       &v1.HostDeviceNVMe{Namespace: 2},
+				},
+			}
+			c := &ConverterContext{
+				UseEmulation: true,
+				HostDevices: map[string]HostDevicesList{
+					"vendor.com/nvme_name": HostDevicesList{
+						Type:     HostDevicePCI,
+						AddrList: []string{"0000:81:00.0"},
+					},
+				},
+			}
+			domain := vmiToDomain(nvmeVMI, c)
+
+			Expect(domain.Spec.Devices.HostDevices).To(BeEmpty())
+			Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+			disk := domain.Spec.Devices.Disks[0]
+			Expect(disk.Type).To(Equal("nvme"))
+			Expect(disk.Device).To(Equal("disk"))
+			Expect(disk.Source.Type).To(Equal("pci"))
+			Expect(disk.Source.Managed).To(Equal("no"))
+			Expect(disk.Source.Namespace).To(Equal("2"))
+			Expect(disk.Source.Address.Domain).To(Equal("0x0000"))
+			Expect(disk.Source.Address.Bus).To(Equal("0x81"))
+			Expect(disk.Source.Address.Slot).To(Equal("0x00"))
+			Expect(disk.Source.Address.Function).To(Equal("0x0"))
+			Expect(disk.Target).To(Equal(api.DiskTarget{Bus: "virtio", Device: "vda"}))
+			Expect(disk.Alias.GetName()).To(Equal("nvme_name"))
+		})
 	})
 
 	Context("hotplug", func() {
@@ -24,11 +24,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 
+	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device"
@@ -42,6 +47,7 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 	var domainInterfaces []api.Interface
 
 	networks := indexNetworksByName(vmi.Spec.Networks)
+	failoverInterfaces := sriovFailoverInterfaces(vmi.Spec.Domain.Devices.Interfaces)
 
 	for i, iface := range vmi.Spec.Domain.Devices.Interfaces {
 		net, isExist := networks[iface.Name]
@@ -49,7 +55,9 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 			return nil, fmt.Errorf("failed to find network %s", iface.Name)
 		}
 
-		if iface.SRIOV != nil {
+		// SR-IOV devices are not interfaces, and hot unplugged interfaces are detached from the domain.
+		// Interfaces bound by a network binding plugin are added to the domain by the plugin sidecar.
+		if iface.SRIOV != nil || iface.Binding != nil || iface.State == v1.InterfaceStateAbsent {
 			continue
 		}
 
@@ -60,6 +68,12 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 			},
 			Alias: api.NewUserDefinedAlias(iface.Name),
 		}
+		if iface.State == v1.InterfaceStateLinkDown {
+			domainIface.LinkState = &api.LinkState{State: "down"}
+		}
+		if failoverInterfaces[iface.Name] {
+			domainIface.Teaming = &api.Teaming{Type: "persistent"}
+		}
 
 		// if UseEmulation unset and at least one NIC model is virtio,
 		// /dev/vhost-net must be present as we should have asked for it.
@@ -67,11 +81,29 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 		if mq := vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue; mq != nil {
 			virtioNetMQRequested = *mq
 		}
-		if ifaceType == "virtio" && virtioNetProhibited {
+		if ifaceType == "virtio" && virtioNetProhibited && iface.VhostUser == nil {
 			return nil, fmt.Errorf("In-kernel virtio-net device emulation '/dev/vhost-net' not present")
 		} else if ifaceType == "virtio" && virtioNetMQRequested {
 			queueCount := uint(CalculateNetworkQueues(vmi))
 			domainIface.Driver = &api.InterfaceDriver{Name: "vhost", Queues: &queueCount}
+			if iface.VhostUser != nil {
+				// the queues are served by the userspace switch, not by vhost-net
+				domainIface.Driver.Name = ""
+			}
+			if iface.RSS != nil {
+				domainIface.Driver.RSS = "on"
+				if iface.RSS.HashReport {
+					// vhost-net can't report the hash of the packets, QEMU processes them instead
+					domainIface.Driver.Name = "qemu"
+					domainIface.Driver.RSSHashReport = "on"
+				}
+			}
+		}
+		if ifaceType == "virtio" && iface.Offloads != nil {
+			if domainIface.Driver == nil {
+				domainIface.Driver = &api.InterfaceDriver{}
+			}
+			domainIface.Driver.Host, domainIface.Driver.Guest = generateOffloads(iface.Offloads)
 		}
 
 		// Add a pciAddress if specified
@@ -90,9 +122,11 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 			// use "ethernet" interface type, since we're using pre-configured tap devices
 			// https://libvirt.org/formatdomain.html#elementsNICSEthernet
 			domainIface.Type = "ethernet"
+			domainIface.BandWidth = convertInterfaceBandwidth(iface.Bandwidth)
 			if iface.BootOrder != nil {
 				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
-			} else {
+			}
+			if iface.BootOrder == nil || iface.ROMDisabled {
 				domainIface.Rom = &api.Rom{Enabled: "no"}
 			}
 		} else if iface.Slirp != nil {
@@ -113,9 +147,50 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 			}
 
 			domainIface.Type = "ethernet"
+			domainIface.BandWidth = convertInterfaceBandwidth(iface.Bandwidth)
+			if iface.BootOrder != nil {
+				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+			}
+			if iface.BootOrder == nil || iface.ROMDisabled {
+				domainIface.Rom = &api.Rom{Enabled: "no"}
+			}
+		} else if iface.Vdpa != nil {
+			devicePath, exists := c.VdpaDevices[iface.Name]
+			if !exists {
+				return nil, fmt.Errorf("no vDPA device was allocated to interface %s", iface.Name)
+			}
+
+			domainIface.Type = "vdpa"
+			domainIface.Source = api.InterfaceSource{Device: devicePath}
 			if iface.BootOrder != nil {
 				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
-			} else {
+			}
+			if iface.BootOrder == nil || iface.ROMDisabled {
+				domainIface.Rom = &api.Rom{Enabled: "no"}
+			}
+		} else if iface.VhostUser != nil {
+			// QEMU listens on the socket, so the userspace switch can reconnect to it after a restart
+			domainIface.Type = "vhostuser"
+			domainIface.Source = api.InterfaceSource{
+				Type: "unix",
+				Path: VhostUserSocketPath(iface.Name),
+				Mode: "server",
+			}
+			if iface.BootOrder != nil {
+				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+			}
+			if iface.BootOrder == nil || iface.ROMDisabled {
+				domainIface.Rom = &api.Rom{Enabled: "no"}
+			}
+		} else if iface.Passt != nil {
+			// passt runs as the user-mode network backend of libvirt, bound to the pod network
+			domainIface.Type = "user"
+			domainIface.Backend = &api.InterfaceBackend{Type: "passt"}
+			domainIface.PortForward = generatePasstPortForward(iface.Ports)
+			if iface.BootOrder != nil {
+				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+			}
+			if iface.BootOrder == nil || iface.ROMDisabled {
 				domainIface.Rom = &api.Rom{Enabled: "no"}
 			}
 		}
@@ -125,6 +200,86 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 	return domainInterfaces, nil
 }
 
+// sriovFailoverInterfaces returns the names of the interfaces which SR-IOV interfaces fail over to
+func sriovFailoverInterfaces(ifaces []v1.Interface) map[string]bool {
+	failoverInterfaces := map[string]bool{}
+	for _, iface := range ifaces {
+		if iface.SRIOV != nil && iface.SRIOV.Failover != "" {
+			failoverInterfaces[iface.SRIOV.Failover] = true
+		}
+	}
+	return failoverInterfaces
+}
+
+// VhostUserSocketPath returns the path of the vhost-user socket which the userspace switch connects to,
+// in order to serve the interface.
+func VhostUserSocketPath(ifaceName string) string {
+	return filepath.Join(util.VhostUserSocketDir, ifaceName+".sock")
+}
+
+// convertInterfaceBandwidth converts the bandwidth limits of an interface to the libvirt units,
+// KiB per second for the rates and KiB for the burst.
+func convertInterfaceBandwidth(bandwidth *v1.InterfaceBandwidth) *api.BandWidth {
+	if bandwidth == nil {
+		return nil
+	}
+	return &api.BandWidth{
+		Inbound:  convertBandwidthLimit(bandwidth.Inbound),
+		Outbound: convertBandwidthLimit(bandwidth.Outbound),
+	}
+}
+
+func convertBandwidthLimit(limit *v1.BandwidthLimit) *api.BandWidthLimit {
+	if limit == nil {
+		return nil
+	}
+	domainLimit := &api.BandWidthLimit{Average: quantityToKibibytes(limit.Average)}
+	if limit.Peak != nil {
+		domainLimit.Peak = quantityToKibibytes(*limit.Peak)
+	}
+	if limit.Burst != nil {
+		domainLimit.Burst = quantityToKibibytes(*limit.Burst)
+	}
+	return domainLimit
+}
+
+// quantityToKibibytes rounds up, so that a limit is never converted to zero, which libvirt rejects
+func quantityToKibibytes(quantity resource.Quantity) string {
+	return strconv.FormatInt((quantity.Value()+1023)/1024, 10)
+}
+
+// InterfaceBandwidthFromDomain returns the bandwidth limits applied to a domain interface.
+func InterfaceBandwidthFromDomain(bandwidth *api.BandWidth) *v1.InterfaceBandwidth {
+	if bandwidth == nil || (bandwidth.Inbound == nil && bandwidth.Outbound == nil) {
+		return nil
+	}
+	return &v1.InterfaceBandwidth{
+		Inbound:  bandwidthLimitFromDomain(bandwidth.Inbound),
+		Outbound: bandwidthLimitFromDomain(bandwidth.Outbound),
+	}
+}
+
+func bandwidthLimitFromDomain(limit *api.BandWidthLimit) *v1.BandwidthLimit {
+	if limit == nil {
+		return nil
+	}
+	bandwidthLimit := &v1.BandwidthLimit{Average: kibibytesToQuantity(limit.Average)}
+	if limit.Peak != "" {
+		peak := kibibytesToQuantity(limit.Peak)
+		bandwidthLimit.Peak = &peak
+	}
+	if limit.Burst != "" {
+		burst := kibibytesToQuantity(limit.Burst)
+		bandwidthLimit.Burst = &burst
+	}
+	return bandwidthLimit
+}
+
+func kibibytesToQuantity(value string) resource.Quantity {
+	kib, _ := strconv.ParseInt(value, 10, 64)
+	return *resource.NewQuantity(kib*1024, resource.BinarySI)
+}
+
 func getInterfaceType(iface *v1.Interface) string {
 	if iface.Slirp != nil {
 		// Slirp configuration works only with e1000 or rtl8139
@@ -184,6 +339,76 @@ func createSlirpNetwork(iface v1.Interface, network v1.Network, domain *api.Doma
 	return nil
 }
 
+// generateOffloads returns the virtio-net features of the offloads, which are toggled in both directions.
+// Disabling the checksum offload disables the segmentation offloads too, since they rely on it.
+func generateOffloads(offloads *v1.InterfaceOffloads) (*api.InterfaceDriverHost, *api.InterfaceDriverGuest) {
+	tso, gso := offloads.TSO, offloads.GSO
+	if offloads.Checksum != nil && !*offloads.Checksum {
+		disabled := false
+		if tso == nil {
+			tso = &disabled
+		}
+		if gso == nil {
+			gso = &disabled
+		}
+	}
+
+	host := &api.InterfaceDriverHost{
+		CSum: toOnOff(offloads.Checksum),
+		GSO:  toOnOff(gso),
+		TSO4: toOnOff(tso),
+		TSO6: toOnOff(tso),
+	}
+	guest := &api.InterfaceDriverGuest{
+		CSum: toOnOff(offloads.Checksum),
+		TSO4: toOnOff(tso),
+		TSO6: toOnOff(tso),
+	}
+	// the guest has no generic segmentation offload
+	if *guest == (api.InterfaceDriverGuest{}) {
+		guest = nil
+	}
+	return host, guest
+}
+
+// toOnOff returns the libvirt value of an optional toggle, or an empty string to keep the default
+func toOnOff(enabled *bool) string {
+	if enabled == nil {
+		return ""
+	}
+	if *enabled {
+		return "on"
+	}
+	return "off"
+}
+
+// generatePasstPortForward returns the ports passt forwards to the guest, grouped by protocol.
+// All TCP and UDP ports are forwarded when the interface does not list any.
+func generatePasstPortForward(ports []v1.Port) []api.InterfacePortForward {
+	if len(ports) == 0 {
+		return []api.InterfacePortForward{{Proto: "tcp"}, {Proto: "udp"}}
+	}
+
+	var tcpRanges, udpRanges []api.InterfacePortForwardRange
+	for _, port := range ports {
+		portRange := api.InterfacePortForwardRange{Start: uint(port.Port)}
+		if strings.EqualFold(port.Protocol, "UDP") {
+			udpRanges = append(udpRanges, portRange)
+		} else {
+			tcpRanges = append(tcpRanges, portRange)
+		}
+	}
+
+	var portForwards []api.InterfacePortForward
+	if len(tcpRanges) > 0 {
+		portForwards = append(portForwards, api.InterfacePortForward{Proto: "tcp", Ranges: tcpRanges})
+	}
+	if len(udpRanges) > 0 {
+		portForwards = append(portForwards, api.InterfacePortForward{Proto: "udp", Ranges: udpRanges})
+	}
+	return portForwards
+}
+
 func CalculateNetworkQueues(vmi *v1.VirtualMachineInstance) uint32 {
 	cpuTopology := getCPUTopology(vmi)
 	queueNumber := calculateRequestedVCPUs(cpuTopology)
@@ -195,6 +420,15 @@ func CalculateNetworkQueues(vmi *v1.VirtualMachineInstance) uint32 {
 	return queueNumber
 }
 
+// InterfaceQueueCountFromDomain returns the number of queues allocated to a domain interface.
+// The queues are sized by CalculateNetworkQueues when the domain is converted, this only reads them back.
+func InterfaceQueueCountFromDomain(driver *api.InterfaceDriver) int32 {
+	if driver == nil || driver.Queues == nil {
+		return 1
+	}
+	return int32(*driver.Queues)
+}
+
 func configPortForward(qemuArg *api.Arg, iface v1.Interface) error {
 	if iface.Ports == nil {
 		return nil
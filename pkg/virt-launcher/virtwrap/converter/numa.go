@@ -0,0 +1,135 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// numaMapping creates a guest NUMA node for every host NUMA node the vCPUs are pinned to.
+// The guest memory is split across the guest NUMA nodes proportionally to their vCPUs and
+// is backed by hugepages of the matching host NUMA node only.
+func numaMapping(vmi *v1.VirtualMachineInstance, domain *api.DomainSpec, c *ConverterContext) error {
+	if domain.CPUTune == nil || len(domain.CPUTune.VCPUPin) == 0 {
+		return fmt.Errorf("guest NUMA mapping passthrough requires pinned vCPUs")
+	}
+	if len(c.CPUNUMANodes) == 0 {
+		return fmt.Errorf("the NUMA topology of the host is unknown")
+	}
+	if vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Hugepages == nil || domain.MemoryBacking == nil {
+		return fmt.Errorf("guest NUMA mapping passthrough requires hugepages")
+	}
+
+	var hostNodes []int
+	vcpusByHostNode := map[int][]uint{}
+	for _, pin := range domain.CPUTune.VCPUPin {
+		hostCPU, err := strconv.Atoi(pin.CPUSet)
+		if err != nil {
+			return fmt.Errorf("unexpected cpuset %s of vCPU %d: %v", pin.CPUSet, pin.VCPU, err)
+		}
+		hostNode, exists := c.CPUNUMANodes[hostCPU]
+		if !exists {
+			return fmt.Errorf("no NUMA node found for host CPU %d", hostCPU)
+		}
+		if _, seen := vcpusByHostNode[hostNode]; !seen {
+			hostNodes = append(hostNodes, hostNode)
+		}
+		vcpusByHostNode[hostNode] = append(vcpusByHostNode[hostNode], pin.VCPU)
+	}
+	sort.Ints(hostNodes)
+
+	pageSize, err := resource.ParseQuantity(vmi.Spec.Domain.Memory.Hugepages.PageSize)
+	if err != nil {
+		return fmt.Errorf("invalid hugepage size %s: %v", vmi.Spec.Domain.Memory.Hugepages.PageSize, err)
+	}
+	pageBytes := uint64(pageSize.Value())
+	memoryBytes, err := domain.Memory.Bytes()
+	if err != nil {
+		return err
+	}
+
+	numa := &api.NUMA{}
+	numaTune := &api.NUMATune{
+		Memory: api.NumaTuneMemory{
+			Mode:    "strict",
+			NodeSet: joinInts(hostNodes),
+		},
+	}
+	totalVCPUs := uint64(len(domain.CPUTune.VCPUPin))
+	var assignedBytes uint64
+	for cellID, hostNode := range hostNodes {
+		vcpus := vcpusByHostNode[hostNode]
+		// The last cell gets what is left over by rounding the other cells down to full hugepages
+		cellBytes := memoryBytes - assignedBytes
+		if cellID < len(hostNodes)-1 {
+			cellBytes = memoryBytes * uint64(len(vcpus)) / totalVCPUs / pageBytes * pageBytes
+		}
+		if cellBytes == 0 {
+			return fmt.Errorf("not enough memory to back guest NUMA node %d with hugepages of size %s", cellID, pageSize.String())
+		}
+		assignedBytes += cellBytes
+
+		cpus := make([]string, 0, len(vcpus))
+		for _, vcpu := range vcpus {
+			cpus = append(cpus, strconv.FormatUint(uint64(vcpu), 10))
+		}
+		numa.Cells = append(numa.Cells, api.NUMACell{
+			ID:     strconv.Itoa(cellID),
+			CPUs:   strings.Join(cpus, ","),
+			Memory: strconv.FormatUint(cellBytes/1024, 10),
+			Unit:   "KiB",
+		})
+		numaTune.MemNodes = append(numaTune.MemNodes, api.MemNode{
+			CellID:  uint32(cellID),
+			Mode:    "strict",
+			NodeSet: strconv.Itoa(hostNode),
+		})
+	}
+
+	domain.CPU.NUMA = numa
+	domain.NUMATune = numaTune
+	domain.MemoryBacking.HugePages = &api.HugePages{
+		HugePage: []api.HugePage{
+			{
+				Size:    strconv.FormatUint(pageBytes/1024, 10),
+				Unit:    "KiB",
+				NodeSet: fmt.Sprintf("0-%d", len(hostNodes)-1),
+			},
+		},
+	}
+	return nil
+}
+
+func joinInts(values []int) string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, strconv.Itoa(v))
+	}
+	return strings.Join(s, ",")
+}
@@ -51,7 +51,8 @@ func PlacePCIDevicesOnRootComplex(spec *api.DomainSpec) (err error) {
 			return err
 		}
 	}
-	if spec.Devices.Watchdog != nil {
+	// The diag288 watchdog is not a PCI device
+	if spec.Devices.Watchdog != nil && spec.Devices.Watchdog.Model != "diag288" {
 		spec.Devices.Watchdog.Address, err = assigner.PlacePCIDeviceAtNextSlot(spec.Devices.Watchdog.Address)
 		if err != nil {
 			return err
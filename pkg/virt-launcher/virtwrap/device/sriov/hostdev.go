@@ -92,6 +92,14 @@ func createHostDevice(iface v1.Interface, hostPCIAddress string) (*api.HostDevic
 		hostDev.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
 	}
 
+	// The guest keeps its connectivity over the persistent virtio interface while the VF is detached
+	if iface.SRIOV.Failover != "" {
+		hostDev.Teaming = &api.Teaming{
+			Type:       "transient",
+			Persistent: api.UserAliasPrefix + iface.SRIOV.Failover,
+		}
+	}
+
 	return hostDev, nil
 }
 
@@ -154,6 +162,30 @@ func detachHostDevices(dom deviceDetacher, hostDevices []api.HostDevice) error {
 	return nil
 }
 
+type deviceAttacher interface {
+	AttachDevice(xml string) error
+}
+
+// AttachHostDevices attaches the given SR-IOV host-devices which are missing from the domain,
+// e.g. the devices which were detached from it before it was migrated.
+func AttachHostDevices(domainSpec *api.DomainSpec, hostDevices []api.HostDevice, dom deviceAttacher) error {
+	attachedHostDevices := FilterHostDevices(domainSpec)
+	for _, hostDev := range hostDevices {
+		if deviceLookup(attachedHostDevices, hostDev.Alias.GetName()) != nil {
+			continue
+		}
+		devXML, err := xml.Marshal(hostDev)
+		if err != nil {
+			return fmt.Errorf("failed to encode (xml) hostdev %v, err: %v", hostDev, err)
+		}
+		if err := dom.AttachDevice(string(devXML)); err != nil {
+			return fmt.Errorf("failed to attach hostdev %s, err: %v", devXML, err)
+		}
+		log.Log.Infof("Successfully hot-plug hostdev: %s (%v)", hostDev.Alias.GetName(), hostDev.Source.Address)
+	}
+	return nil
+}
+
 func waitHostDevicesToDetach(eventDetach eventRegistrar, hostDevices []api.HostDevice, timeout time.Duration) error {
 	var detachedHostDevices []string
 	var desiredDetachCount = len(hostDevices)
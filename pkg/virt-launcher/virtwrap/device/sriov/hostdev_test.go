@@ -179,6 +179,24 @@ var _ = Describe("SRIOV HostDevice", func() {
 			}
 			Expect(devices, err).To(Equal([]api.HostDevice{expectHostDevice1}))
 		})
+
+		It("creates 1 device that is the transient device of a failover team", func() {
+			iface := newSRIOVInterface(netname1)
+			iface.SRIOV.Failover = "backup"
+			pool := newPCIAddressPoolStub("0000:81:01.0")
+
+			devices, err := sriov.CreateHostDevicesFromIfacesAndPool([]v1.Interface{iface}, pool)
+
+			hostPCIAddress1 := api.Address{Type: "pci", Domain: "0x0000", Bus: "0x81", Slot: "0x01", Function: "0x0"}
+			expectHostDevice1 := api.HostDevice{
+				Alias:   newSRIOVAlias(netname1),
+				Source:  api.HostDeviceSource{Address: &hostPCIAddress1},
+				Type:    "pci",
+				Managed: "no",
+				Teaming: &api.Teaming{Type: "transient", Persistent: "ua-backup"},
+			}
+			Expect(devices, err).To(Equal([]api.HostDevice{expectHostDevice1}))
+		})
 	})
 
 	Context("filter", func() {
@@ -281,6 +299,34 @@ var _ = Describe("SRIOV HostDevice", func() {
 			Expect(sriov.SafelyDetachHostDevices(domainSpec, c, d, 10*time.Millisecond)).To(Succeed())
 		})
 	})
+
+	Context("attachment", func() {
+		hostDevice := api.HostDevice{Alias: api.NewUserDefinedAlias(sriov.AliasPrefix + "net1")}
+		hostDevice2 := api.HostDevice{Alias: api.NewUserDefinedAlias(sriov.AliasPrefix + "net2")}
+
+		It("attaches the sriov devices which are missing from the domain", func() {
+			domainSpec := newDomainSpec(hostDevice)
+
+			a := &deviceAttacherStub{}
+			Expect(sriov.AttachHostDevices(domainSpec, []api.HostDevice{hostDevice, hostDevice2}, a)).To(Succeed())
+			Expect(a.attachedCount).To(Equal(1))
+		})
+
+		It("attaches nothing when all sriov devices are part of the domain", func() {
+			domainSpec := newDomainSpec(hostDevice, hostDevice2)
+
+			a := &deviceAttacherStub{}
+			Expect(sriov.AttachHostDevices(domainSpec, []api.HostDevice{hostDevice, hostDevice2}, a)).To(Succeed())
+			Expect(a.attachedCount).To(Equal(0))
+		})
+
+		It("fails to attach a device", func() {
+			domainSpec := newDomainSpec()
+
+			a := &deviceAttacherStub{fail: true}
+			Expect(sriov.AttachHostDevices(domainSpec, []api.HostDevice{hostDevice}, a)).To(HaveOccurred())
+		})
+	})
 })
 
 func newDomainSpec(hostDevices ...api.HostDevice) *api.DomainSpec {
@@ -323,6 +369,19 @@ func (d deviceDetacherStub) DetachDeviceFlags(data string, flags libvirt.DomainD
 	return nil
 }
 
+type deviceAttacherStub struct {
+	fail          bool
+	attachedCount int
+}
+
+func (d *deviceAttacherStub) AttachDevice(data string) error {
+	if d.fail {
+		return fmt.Errorf("attach device error")
+	}
+	d.attachedCount++
+	return nil
+}
+
 func newCallbackerStub(failRegister, failDeregister bool) *callbackerStub {
 	return &callbackerStub{
 		failRegister:   failRegister,
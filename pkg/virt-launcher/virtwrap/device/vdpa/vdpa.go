@@ -0,0 +1,81 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package vdpa
+
+import (
+	"fmt"
+	"path/filepath"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const vhostVdpaPrefix = "vhost-vdpa-"
+
+// pciBasePath is where the PCI devices of the host are described in sysfs
+var pciBasePath = "/sys/bus/pci/devices"
+
+type pool interface {
+	Pop(key string) (value string, err error)
+}
+
+// FilterInterfaces returns the interfaces of the VMI which are backed by a vDPA device.
+func FilterInterfaces(vmi *v1.VirtualMachineInstance) []v1.Interface {
+	var interfaces []v1.Interface
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Vdpa != nil {
+			interfaces = append(interfaces, iface)
+		}
+	}
+	return interfaces
+}
+
+// CreateDevicePaths maps the vDPA interfaces to the vhost-vdpa character devices of the
+// PCI devices allocated to their networks.
+func CreateDevicePaths(vdpaInterfaces []v1.Interface, pciAddrPool pool) (map[string]string, error) {
+	devicePaths := map[string]string{}
+	for _, iface := range vdpaInterfaces {
+		pciAddress, err := pciAddrPool.Pop(iface.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate a vDPA device for %s: %v", iface.Name, err)
+		}
+
+		devicePath, err := vhostVdpaDevicePath(pciAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate a vDPA device for %s: %v", iface.Name, err)
+		}
+		devicePaths[iface.Name] = devicePath
+		log.Log.Infof("vDPA device %s of PCI device %s allocated to %s", devicePath, pciAddress, iface.Name)
+	}
+	return devicePaths, nil
+}
+
+// vhostVdpaDevicePath finds the vhost-vdpa character device bound to the vDPA device of a PCI device.
+// e.g. /sys/bus/pci/devices/0000:65:00.2/vdpa0/vhost-vdpa-0 -> /dev/vhost-vdpa-0
+func vhostVdpaDevicePath(pciAddress string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(pciBasePath, pciAddress, "vdpa*", vhostVdpaPrefix+"*"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no vhost-vdpa device found for PCI device %s", pciAddress)
+	}
+	return filepath.Join("/dev", filepath.Base(matches[0])), nil
+}
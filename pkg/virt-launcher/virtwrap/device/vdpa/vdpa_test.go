@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+package vdpa
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+type poolStub struct {
+	addresses map[string]string
+}
+
+func (p *poolStub) Pop(networkName string) (string, error) {
+	address, exists := p.addresses[networkName]
+	if !exists {
+		return "", fmt.Errorf("no more PCI addresses to allocate for network %s", networkName)
+	}
+	delete(p.addresses, networkName)
+	return address, nil
+}
+
+var _ = Describe("vDPA devices", func() {
+	var originalPCIBasePath string
+
+	BeforeEach(func() {
+		originalPCIBasePath = pciBasePath
+		tmpDir, err := ioutil.TempDir("", "vdpa")
+		Expect(err).ToNot(HaveOccurred())
+		pciBasePath = tmpDir
+
+		Expect(os.MkdirAll(filepath.Join(tmpDir, "0000:65:00.2", "vdpa0", "vhost-vdpa-0"), 0755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(tmpDir, "0000:65:00.3", "vdpa1", "vhost-vdpa-1"), 0755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(tmpDir, "0000:65:00.4"), 0755)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(pciBasePath)
+		pciBasePath = originalPCIBasePath
+	})
+
+	It("filters the vDPA interfaces of a VMI", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+			*v1.DefaultMasqueradeNetworkInterface(),
+			{Name: "vdpa1", InterfaceBindingMethod: v1.InterfaceBindingMethod{Vdpa: &v1.InterfaceVdpa{}}},
+		}
+		interfaces := FilterInterfaces(vmi)
+		Expect(interfaces).To(HaveLen(1))
+		Expect(interfaces[0].Name).To(Equal("vdpa1"))
+	})
+
+	It("maps the interfaces to the vhost-vdpa devices of their PCI devices", func() {
+		interfaces := []v1.Interface{{Name: "net1"}, {Name: "net2"}}
+		pool := &poolStub{addresses: map[string]string{"net1": "0000:65:00.2", "net2": "0000:65:00.3"}}
+
+		devicePaths, err := CreateDevicePaths(interfaces, pool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(devicePaths).To(Equal(map[string]string{"net1": "/dev/vhost-vdpa-0", "net2": "/dev/vhost-vdpa-1"}))
+	})
+
+	It("fails when no PCI device is allocated to the network", func() {
+		pool := &poolStub{addresses: map[string]string{}}
+
+		_, err := CreateDevicePaths([]v1.Interface{{Name: "net1"}}, pool)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when the PCI device has no vhost-vdpa device", func() {
+		pool := &poolStub{addresses: map[string]string{"net1": "0000:65:00.4"}}
+
+		_, err := CreateDevicePaths([]v1.Interface{{Name: "net1"}}, pool)
+		Expect(err).To(HaveOccurred())
+	})
+})
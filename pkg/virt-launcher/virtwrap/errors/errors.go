@@ -42,6 +42,11 @@ func IsNotFound(err error) bool {
 	return checkError(err, libvirt.ERR_NO_DOMAIN)
 }
 
+// IsSecretNotFound detects libvirt's ERR_NO_SECRET. It accepts both error and libvirt.Error (as returned by GetLastError function).
+func IsSecretNotFound(err error) bool {
+	return checkError(err, libvirt.ERR_NO_SECRET)
+}
+
 // IsInvalidOperation detects libvirt's VIR_ERR_OPERATION_INVALID. It accepts both error and libvirt.Error (as returned by GetLastError function).
 func IsInvalidOperation(err error) bool {
 	return checkError(err, libvirt.ERR_OPERATION_INVALID)
@@ -0,0 +1,267 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	libvirt "libvirt.org/libvirt-go"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+)
+
+// externalSnapshotChainFile is written next to the image of a volume once its disk was switched to an overlay,
+// it records the overlay and its backing chain, so that the disk is started from the overlay again.
+const externalSnapshotChainFile = "disk.img.chain"
+
+type domainSnapshot struct {
+	XMLName xml.Name             `xml:"domainsnapshot"`
+	Name    string               `xml:"name"`
+	Disks   []domainSnapshotDisk `xml:"disks>disk"`
+}
+
+type domainSnapshotDisk struct {
+	Name     string                    `xml:"name,attr"`
+	Snapshot string                    `xml:"snapshot,attr"`
+	Type     string                    `xml:"type,attr,omitempty"`
+	Driver   *domainSnapshotDiskDriver `xml:"driver,omitempty"`
+	Source   *domainSnapshotDiskSource `xml:"source,omitempty"`
+}
+
+type domainSnapshotDiskDriver struct {
+	Type string `xml:"type,attr"`
+}
+
+type domainSnapshotDiskSource struct {
+	File string `xml:"file,attr"`
+}
+
+// externalSnapshotChain holds the file names of the overlay the guest writes to and of its backing files,
+// from its backing file down to the image of the volume
+type externalSnapshotChain struct {
+	ActiveFile   string   `json:"activeFile"`
+	BackingChain []string `json:"backingChain,omitempty"`
+}
+
+// readExternalSnapshotChain returns the chain recorded in the volume directory, or nil if the volume was never snapshotted
+func readExternalSnapshotChain(volumeDir string) (*externalSnapshotChain, error) {
+	data, err := ioutil.ReadFile(filepath.Join(volumeDir, externalSnapshotChainFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	chain := &externalSnapshotChain{}
+	if err := json.Unmarshal(data, chain); err != nil {
+		return nil, fmt.Errorf("invalid external snapshot chain in %s: %v", volumeDir, err)
+	}
+	return chain, nil
+}
+
+func writeExternalSnapshotChain(volumeDir string, chain *externalSnapshotChain) error {
+	data, err := json.Marshal(chain)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(volumeDir, externalSnapshotChainFile)
+	// replace the file atomically, a truncated chain file would prevent the VMI from starting
+	if err := ioutil.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func isExternalSnapshotVolume(options *v1.ExternalSnapshotOptions, name string) bool {
+	for _, volume := range options.Volumes {
+		if volume == name {
+			return true
+		}
+	}
+	return false
+}
+
+func externalSnapshotMetadata(name string, chain *externalSnapshotChain) api.ExternalSnapshotMetadata {
+	return api.ExternalSnapshotMetadata{
+		Name:         name,
+		ActiveFile:   chain.ActiveFile,
+		BackingChain: chain.BackingChain,
+	}
+}
+
+// ExternalSnapshotVMI switches the disks of the given filesystem volumes to new qcow2 overlays, which are
+// created next to their images. The images the guest wrote to until then are not written anymore and
+// hold the disks at the time of the snapshot. The new chains are recorded in the volumes and in the
+// domain metadata. Disks which are on the overlay already are skipped, so that failed requests can be retried.
+func (l *LibvirtDomainManager) ExternalSnapshotVMI(vmi *v1.VirtualMachineInstance, options *v1.ExternalSnapshotOptions) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain for the external snapshot failed.")
+		return err
+	}
+	defer dom.Free()
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+
+	if blockCommitMetadata := domainSpec.Metadata.KubeVirt.BlockCommit; isBlockCommitRunning(blockCommitMetadata) {
+		return fmt.Errorf("block commit of volumes %v is still running", blockCommitMetadata.Volumes)
+	}
+
+	overlay := util.ExternalSnapshotOverlay(options.Name)
+	snapshot := domainSnapshot{Name: options.Name}
+	chains := make(map[string]*externalSnapshotChain)
+	var snapshotDisks []string
+	for _, disk := range domainSpec.Devices.Disks {
+		name := disk.Alias.GetName()
+		if !isExternalSnapshotVolume(options, name) {
+			snapshot.Disks = append(snapshot.Disks, domainSnapshotDisk{Name: disk.Target.Device, Snapshot: "no"})
+			continue
+		}
+		image := converter.GetFilesystemVolumePath(name)
+		volumeDir := filepath.Dir(image)
+		if disk.Type != "file" || filepath.Dir(disk.Source.File) != volumeDir {
+			return fmt.Errorf("disk %s is not an image on a filesystem volume", name)
+		}
+		chain, err := readExternalSnapshotChain(volumeDir)
+		if err != nil {
+			return err
+		}
+		if chain == nil {
+			chain = &externalSnapshotChain{ActiveFile: filepath.Base(image)}
+		}
+		activeFile := filepath.Base(disk.Source.File)
+		if chain.ActiveFile != overlay {
+			if activeFile != chain.ActiveFile && activeFile != overlay {
+				return fmt.Errorf("disk %s is on %s, not on %s recorded in its chain", name, activeFile, chain.ActiveFile)
+			}
+			chain = &externalSnapshotChain{
+				ActiveFile:   overlay,
+				BackingChain: append([]string{chain.ActiveFile}, chain.BackingChain...),
+			}
+		}
+		chains[name] = chain
+		if activeFile == overlay {
+			snapshot.Disks = append(snapshot.Disks, domainSnapshotDisk{Name: disk.Target.Device, Snapshot: "no"})
+			continue
+		}
+		snapshot.Disks = append(snapshot.Disks, domainSnapshotDisk{
+			Name:     disk.Target.Device,
+			Snapshot: "external",
+			Type:     "file",
+			Driver:   &domainSnapshotDiskDriver{Type: "qcow2"},
+			Source:   &domainSnapshotDiskSource{File: filepath.Join(volumeDir, overlay)},
+		})
+		snapshotDisks = append(snapshotDisks, name)
+	}
+	for _, volume := range options.Volumes {
+		if _, exists := chains[volume]; !exists {
+			return fmt.Errorf("volume %s has no disk", volume)
+		}
+	}
+
+	if len(snapshotDisks) > 0 {
+		snapshotXML, err := xml.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		flags := libvirt.DOMAIN_SNAPSHOT_CREATE_DISK_ONLY | libvirt.DOMAIN_SNAPSHOT_CREATE_NO_METADATA | libvirt.DOMAIN_SNAPSHOT_CREATE_ATOMIC
+		domSnapshot, err := dom.CreateSnapshotXML(string(snapshotXML), flags)
+		if err != nil {
+			logger.Reason(err).Error("Taking the external snapshot failed.")
+			return err
+		}
+		defer domSnapshot.Free()
+		logger.Infof("Switched disks %v to overlay %s", snapshotDisks, overlay)
+	}
+
+	for name, chain := range chains {
+		if err := writeExternalSnapshotChain(filepath.Dir(converter.GetFilesystemVolumePath(name)), chain); err != nil {
+			logger.Reason(err).Errorf("Recording the external snapshot chain of disk %s failed.", name)
+			return err
+		}
+	}
+
+	// the disks are switched on the running domain, read it again to record the chains along with them
+	domainSpec, err = l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+	var snapshotMetadata []api.ExternalSnapshotMetadata
+	for _, metadata := range domainSpec.Metadata.KubeVirt.ExternalSnapshots {
+		if _, exists := chains[metadata.Name]; !exists {
+			snapshotMetadata = append(snapshotMetadata, metadata)
+		}
+	}
+	for _, volume := range options.Volumes {
+		snapshotMetadata = append(snapshotMetadata, externalSnapshotMetadata(volume, chains[volume]))
+	}
+	domainSpec.Metadata.KubeVirt.ExternalSnapshots = snapshotMetadata
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
+// setExternalSnapshotDisks starts the disks of volumes which were snapshotted with external snapshots from
+// the overlay they were last switched to, and records their chains in the domain metadata.
+func setExternalSnapshotDisks(domain *api.Domain) error {
+	var snapshotMetadata []api.ExternalSnapshotMetadata
+	for i := range domain.Spec.Devices.Disks {
+		disk := &domain.Spec.Devices.Disks[i]
+		name := disk.Alias.GetName()
+		if disk.Type != "file" || disk.Source.File != converter.GetFilesystemVolumePath(name) {
+			continue
+		}
+		volumeDir := filepath.Dir(disk.Source.File)
+		chain, err := readExternalSnapshotChain(volumeDir)
+		if err != nil {
+			return err
+		}
+		if chain == nil {
+			continue
+		}
+		disk.Source.File = filepath.Join(volumeDir, chain.ActiveFile)
+		if disk.Driver != nil {
+			disk.Driver.Type = "qcow2"
+		}
+		snapshotMetadata = append(snapshotMetadata, externalSnapshotMetadata(name, chain))
+	}
+	domain.Spec.Metadata.KubeVirt.ExternalSnapshots = snapshotMetadata
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// guestFilesystemCommandTimeout bounds each of the commands growing a partition or a filesystem on the guest
+const guestFilesystemCommandTimeout = 30
+
+// growpart exits with 1 and reports NOCHANGE when the partition already fills the disk
+const growpartNoChange = "NOCHANGE"
+
+// wantsGuestFilesystemExpansion returns whether the guest filesystem on the disk with the given name
+// should be grown after the disk was expanded
+func wantsGuestFilesystemExpansion(vmi *v1.VirtualMachineInstance, name string) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name == name {
+			return disk.ExpandGuestFilesystem != nil && *disk.ExpandGuestFilesystem
+		}
+	}
+	return false
+}
+
+// expandGuestFilesystem grows the last partition of the expanded disk and the filesystem on it through the guest agent.
+// It is best effort: the disk expansion succeeded already, so failures are logged and the guest is left as it is.
+func (l *LibvirtDomainManager) expandGuestFilesystem(vmi *v1.VirtualMachineInstance, disk api.Disk) {
+	logger := log.Log.Object(vmi)
+	name := disk.Alias.GetName()
+	domName := util.VMINamespaceKeyFunc(vmi)
+
+	fsInfo, err := l.virConn.QemuAgentCommand(`{"execute":"guest-get-fsinfo"}`, domName)
+	if err != nil {
+		logger.Reason(err).Warningf("failed to get the guest filesystems, the filesystem on disk %s is not expanded", name)
+		return
+	}
+	filesystems, err := agentpoller.FilesystemsOnDisk(fsInfo, disk)
+	if err != nil {
+		logger.Reason(err).Warningf("failed to parse the guest filesystems, the filesystem on disk %s is not expanded", name)
+		return
+	}
+
+	fs, diskDevice, partition := lastPartitionFilesystem(filesystems)
+	if fs == nil {
+		logger.Warningf("no filesystem to expand found on disk %s in the guest", name)
+		return
+	}
+
+	if partition != "" {
+		exitCode, stdOut, err := l.Exec(domName, "growpart", []string{diskDevice, partition}, guestFilesystemCommandTimeout)
+		if err != nil {
+			logger.Reason(err).Warningf("failed to grow partition %s of disk %s in the guest", partition, name)
+			return
+		}
+		if exitCode != 0 && !strings.Contains(stdOut, growpartNoChange) {
+			logger.Warningf("growing partition %s of disk %s in the guest failed with exit code %d: %s", partition, name, exitCode, stdOut)
+			return
+		}
+	}
+
+	command, args, err := growFilesystemCommand(fs)
+	if err != nil {
+		logger.Reason(err).Warningf("the filesystem on disk %s is not expanded", name)
+		return
+	}
+	exitCode, stdOut, err := l.Exec(domName, command, args, guestFilesystemCommandTimeout)
+	if err != nil {
+		logger.Reason(err).Warningf("failed to grow the filesystem on disk %s in the guest", name)
+		return
+	}
+	if exitCode != 0 {
+		logger.Warningf("growing the filesystem on disk %s in the guest failed with exit code %d: %s", name, exitCode, stdOut)
+		return
+	}
+	logger.Infof("Expanded the %s filesystem mounted at %s on disk %s in the guest", fs.Type, fs.Mountpoint, name)
+}
+
+// lastPartitionFilesystem picks the filesystem on the partition with the highest number, or on the whole disk,
+// and returns it together with the guest device of the disk and the number of the partition.
+// The partition is empty when the filesystem is on the whole disk. Filesystems on device mapper devices are skipped.
+func lastPartitionFilesystem(filesystems []agentpoller.Filesystem) (*agentpoller.Filesystem, string, string) {
+	var last *agentpoller.Filesystem
+	var lastDevice string
+	lastPartition := -1
+	for i := range filesystems {
+		fs := &filesystems[i]
+		if strings.HasPrefix(fs.Name, "dm-") {
+			continue
+		}
+		diskName, partition := splitPartitionName(fs)
+		if partition > lastPartition {
+			last = fs
+			lastDevice = "/dev/" + diskName
+			lastPartition = partition
+		}
+	}
+	if last == nil || lastPartition == 0 {
+		return last, lastDevice, ""
+	}
+	return last, lastDevice, strconv.Itoa(lastPartition)
+}
+
+// splitPartitionName splits the name of the block device of a filesystem, like vda1 or nvme0n1p2, into the name
+// of the disk and the partition number. The number is 0 when the filesystem is on the whole disk.
+func splitPartitionName(fs *agentpoller.Filesystem) (string, int) {
+	if len(fs.Disks) > 0 && fs.Disks[0].Dev != "" {
+		diskName := filepath.Base(fs.Disks[0].Dev)
+		if fs.Name == diskName || !strings.HasPrefix(fs.Name, diskName) {
+			return diskName, 0
+		}
+		partition, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(fs.Name, diskName), "p"))
+		if err != nil {
+			return diskName, 0
+		}
+		return diskName, partition
+	}
+
+	diskName := strings.TrimRight(fs.Name, "0123456789")
+	partition, err := strconv.Atoi(strings.TrimPrefix(fs.Name, diskName))
+	if err != nil {
+		return fs.Name, 0
+	}
+	// the partitions of disks whose name ends with a digit are separated by a p, like nvme0n1p2
+	if trimmed := strings.TrimSuffix(diskName, "p"); trimmed != diskName && strings.TrimRight(trimmed, "0123456789") != trimmed {
+		diskName = trimmed
+	}
+	return diskName, partition
+}
+
+// growFilesystemCommand returns the guest command growing the filesystem to the size of its partition
+func growFilesystemCommand(fs *agentpoller.Filesystem) (string, []string, error) {
+	switch fs.Type {
+	case "ext2", "ext3", "ext4":
+		return "resize2fs", []string{"/dev/" + fs.Name}, nil
+	case "xfs":
+		return "xfs_growfs", []string{fs.Mountpoint}, nil
+	case "btrfs":
+		return "btrfs", []string{"filesystem", "resize", "max", fs.Mountpoint}, nil
+	}
+	return "", nil, fmt.Errorf("growing %s filesystems is not supported", fs.Type)
+}
@@ -28,12 +28,18 @@ package virtwrap
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strconv"
 	"strings"
@@ -54,9 +60,13 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
+	backendstorage "kubevirt.io/kubevirt/pkg/backend-storage"
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	"kubevirt.io/kubevirt/pkg/config"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	diskencryption "kubevirt.io/kubevirt/pkg/disk-encryption"
+	"kubevirt.io/kubevirt/pkg/downwardmetrics"
+	"kubevirt.io/kubevirt/pkg/efi"
 	"kubevirt.io/kubevirt/pkg/emptydisk"
 	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
@@ -64,13 +74,18 @@ import (
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
 	kutil "kubevirt.io/kubevirt/pkg/util"
+	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
+	"kubevirt.io/kubevirt/pkg/util/multipath"
 	"kubevirt.io/kubevirt/pkg/util/net/ip"
+	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	accesscredentials "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/access-credentials"
 	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/sriov"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/vdpa"
 	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
@@ -83,6 +98,7 @@ const (
 	vgpuEnvPrefix              = "VGPU_PASSTHROUGH_DEVICES"
 	PCI_RESOURCE_PREFIX        = "PCI_RESOURCE"
 	MDEV_RESOURCE_PREFIX       = "MDEV_PCI_RESOURCE"
+	USB_RESOURCE_PREFIX        = "USB_RESOURCE"
 )
 
 type contextStore struct {
@@ -107,6 +123,21 @@ type DomainManager interface {
 	GetUsers() ([]v1.VirtualMachineInstanceGuestOSUser, error)
 	GetFilesystems() ([]v1.VirtualMachineInstanceFileSystem, error)
 	SetGuestTime(*v1.VirtualMachineInstance) error
+	GetSEVInfo() (*v1.SEVPlatformInfo, error)
+	GetLaunchMeasurement(*v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
+	InjectLaunchSecret(*v1.VirtualMachineInstance, *v1.SEVSecretOptions) error
+	FreezeVMI(*v1.VirtualMachineInstance, int32) error
+	UnfreezeVMI(*v1.VirtualMachineInstance) error
+	UpdateVCPUs(*v1.VirtualMachineInstance) error
+	UpdateGuestMemory(*v1.VirtualMachineInstance) error
+	SetGuestMemoryBalloon(*v1.VirtualMachineInstance, uint64) error
+	Exec(string, string, []string, int32) (int, string, error)
+	GuestPing(string) error
+	StartBackupVMI(*v1.VirtualMachineInstance, *v1.BackupOptions) error
+	StopBackupVMI(*v1.VirtualMachineInstance) error
+	MemoryDumpVMI(*v1.VirtualMachineInstance, *v1.MemoryDumpOptions) error
+	ExternalSnapshotVMI(*v1.VirtualMachineInstance, *v1.ExternalSnapshotOptions) error
+	BlockCommitVMI(*v1.VirtualMachineInstance, *v1.BlockCommitOptions) error
 }
 
 type LibvirtDomainManager struct {
@@ -116,6 +147,8 @@ type LibvirtDomainManager struct {
 	domainModifyLock sync.Mutex
 	// mutex to control access to the guest time context
 	setGuestTimeLock sync.Mutex
+	// mutex to control access to the automatic unfreeze timer
+	unfreezeLock sync.Mutex
 
 	credManager *accesscredentials.AccessCredentialManager
 
@@ -126,6 +159,7 @@ type LibvirtDomainManager struct {
 	agentData                *agentpoller.AsyncAgentStore
 	cloudInitDataStore       *cloudinit.CloudInitData
 	setGuestTimeContextPtr   *contextStore
+	unfreezeTimer            *time.Timer
 	ovmfPath                 string
 	networkCacheStoreFactory cache.InterfaceCacheFactory
 }
@@ -357,14 +391,24 @@ func classifyVolumesForMigration(vmi *v1.VirtualMachineInstance) *migrationDisks
 	// live migration. It also collects all generated disks suck as cloudinit, secrets, ServiceAccount and ConfigMaps
 	// to make sure that these are being copied during migration.
 	// Persistent volume claims without ReadWriteMany access mode
-	// should be filtered out earlier in the process
+	// should be filtered out earlier in the process, unless the migration
+	// moves them to other PVCs, which copies them as well.
 
 	disks := &migrationDisks{
 		shared:    make(map[string]bool),
 		generated: make(map[string]bool),
 	}
+	migratedVolumes := map[string]bool{}
+	if vmi.Status.MigrationState != nil {
+		for _, migratedVolume := range vmi.Status.MigrationState.MigratedVolumes {
+			migratedVolumes[migratedVolume.VolumeName] = true
+		}
+	}
 	for _, volume := range vmi.Spec.Volumes {
 		volSrc := volume.VolumeSource
+		if migratedVolumes[volume.Name] {
+			continue
+		}
 		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil ||
 			(volSrc.HostDisk != nil && *volSrc.HostDisk.Shared) {
 			disks.shared[volume.Name] = true
@@ -493,7 +537,7 @@ func (l *LibvirtDomainManager) asyncMigrate(vmi *v1.VirtualMachineInstance, opti
 		// This also creates a tcp server for each additional direct migration connections
 		// that will be proxied to the destination pod
 
-		isBlockMigration := (vmi.Status.MigrationMethod == v1.BlockMigration)
+		isBlockMigration := migrations.IsBlockMigration(vmi)
 		migrationPortsRange := migrationproxy.GetMigrationPortsList(isBlockMigration)
 
 		loopbackAddress := ip.GetLoopbackAddress()
@@ -540,6 +584,7 @@ func (l *LibvirtDomainManager) asyncMigrate(vmi *v1.VirtualMachineInstance, opti
 		if err := detachHostDevices(l.virConn, dom); err != nil {
 			log.Log.Object(vmi).Reason(err).Error(fmt.Sprintf("Live migration failed."))
 			l.setMigrationResult(vmi, true, fmt.Sprintf("%v", err), "")
+			return
 		}
 
 		xmlstr, err := domXMLWithoutKubevirtMetadata(dom, vmi)
@@ -690,7 +735,7 @@ func getVMIMigrationDataSize(vmi *v1.VirtualMachineInstance) int64 {
 	}
 
 	//get total data Size
-	if vmi.Status.MigrationMethod == v1.BlockMigration {
+	if migrations.IsBlockMigration(vmi) {
 		disksSize := getVMIEphemeralDisksTotalSize()
 		memory.Add(*disksSize)
 	}
@@ -940,7 +985,7 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 	// reserve the last cpu for the emulator thread
 	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread {
 		if len(podCPUSet) > 0 {
-			emulatorThreadCpu = &podCPUSet[len(podCPUSet)]
+			emulatorThreadCpu = &podCPUSet[len(podCPUSet)-1]
 			podCPUSet = podCPUSet[:len(podCPUSet)-1]
 		}
 	}
@@ -990,6 +1035,13 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 		EmulatorThreadCpu:     emulatorThreadCpu,
 		OVMFPath:              l.ovmfPath,
 		UseVirtioTransitional: vmi.Spec.Domain.Devices.UseVirtioTransitional != nil && *vmi.Spec.Domain.Devices.UseVirtioTransitional,
+		FreePageReporting:     converter.IsFreePageReportingEnabled(vmi),
+	}
+	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.NUMA != nil && vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough != nil {
+		c.CPUNUMANodes, err = hwutil.GetCPUNUMANodes()
+		if err != nil {
+			return fmt.Errorf("failed to read the NUMA topology of the host: %v", err)
+		}
 	}
 	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c); err != nil {
 		return fmt.Errorf("conversion failed: %v", err)
@@ -1018,7 +1070,7 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 		return fmt.Errorf("failed to update the hosts file: %v", err)
 	}
 
-	isBlockMigration := (vmi.Status.MigrationMethod == v1.BlockMigration)
+	isBlockMigration := migrations.IsBlockMigration(vmi)
 	migrationPortsRange := migrationproxy.GetMigrationPortsList(isBlockMigration)
 	for _, port := range migrationPortsRange {
 		// Prepare the direct migration proxy
@@ -1039,6 +1091,7 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 
 func (l *LibvirtDomainManager) generateCloudInitISO(vmi *v1.VirtualMachineInstance, domPtr *cli.VirDomain) error {
 	var devicesMetadata []cloudinit.DeviceData
+	var networkData string
 	// this is the point where we need to build the devices metadata if it was requested.
 	// This metadata maps the user provided tag to the hypervisor assigned device address.
 	if domPtr != nil {
@@ -1048,6 +1101,13 @@ func (l *LibvirtDomainManager) generateCloudInitISO(vmi *v1.VirtualMachineInstan
 		}
 		devicesMetadata = data
 	}
+	if cloudinit.IsNetworkDataGenerated(vmi) {
+		data, err := l.buildNetworkData(vmi, domPtr)
+		if err != nil {
+			return err
+		}
+		networkData = data
+	}
 	// build condif drive iso file, that includes devices metadata if available
 	// get stored cloud init data
 	var cloudInitDataStore *cloudinit.CloudInitData
@@ -1058,6 +1118,9 @@ func (l *LibvirtDomainManager) generateCloudInitISO(vmi *v1.VirtualMachineInstan
 		if devicesMetadata != nil {
 			cloudInitDataStore.DevicesData = &devicesMetadata
 		}
+		if networkData != "" {
+			cloudInitDataStore.NetworkData = networkData
+		}
 		err := cloudinit.GenerateLocalData(vmi.Name, vmi.Namespace, cloudInitDataStore)
 		if err != nil {
 			return fmt.Errorf("generating local cloud-init data failed: %v", err)
@@ -1161,14 +1224,41 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 	if err := config.CreateServiceAccountDisk(vmi); err != nil {
 		return domain, fmt.Errorf("creating service account disk failed: %v", err)
 	}
+	// create DownwardMetric disk if exists
+	if err := downwardmetrics.CreateDownwardMetricDisk(vmi); err != nil {
+		return domain, fmt.Errorf("creating downward metrics disk failed: %v", err)
+	}
+
+	// enroll custom secure boot keys into the NVRAM on first boot
+	if domain.Spec.OS.NVRam != nil {
+		if err := efi.EnrollSecureBootKeys(vmi, domain.Spec.OS.NVRam.NVRam, domain.Spec.OS.NVRam.Template); err != nil {
+			return domain, fmt.Errorf("enrolling secure boot keys failed: %v", err)
+		}
+	}
+
+	// hand the passphrases of the encrypted disks over to libvirt
+	if err := l.defineDiskEncryptionSecrets(vmi); err != nil {
+		return domain, fmt.Errorf("defining disk encryption secrets failed: %v", err)
+	}
+
+	// start the disks of snapshotted volumes from their overlays, before the cache mode of their files is set
+	if err := setExternalSnapshotDisks(domain); err != nil {
+		return domain, fmt.Errorf("restoring external snapshot chains failed: %v", err)
+	}
 
 	// set drivers cache mode
+	sharedVolumes := make(map[string]bool)
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		sharedVolumes[volumeStatus.Name] = kubevirttypes.IsSharedPVCInfo(volumeStatus.PersistentVolumeClaimInfo)
+	}
 	for i := range domain.Spec.Devices.Disks {
-		err := converter.SetDriverCacheMode(&domain.Spec.Devices.Disks[i])
+		disk := &domain.Spec.Devices.Disks[i]
+		err := converter.SetDriverCacheMode(disk, sharedVolumes[disk.Alias.GetName()])
 		if err != nil {
 			return domain, err
 		}
 		converter.SetOptimalIOMode(&domain.Spec.Devices.Disks[i])
+		converter.SetOptimalDiscardMode(&domain.Spec.Devices.Disks[i])
 	}
 
 	if err := l.credManager.HandleQemuAgentAccessCredentials(vmi); err != nil {
@@ -1178,6 +1268,35 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 	return domain, err
 }
 
+// defineDiskEncryptionSecrets defines a private ephemeral libvirt secret with the passphrase
+// of each encrypted disk, so that the passphrase is only kept in the memory of libvirt.
+func (l *LibvirtDomainManager) defineDiskEncryptionSecrets(vmi *v1.VirtualMachineInstance) error {
+	for _, disk := range diskencryption.EncryptedDisks(vmi) {
+		passphrase, err := diskencryption.ReadPassphrase(disk.Name)
+		if err != nil {
+			return err
+		}
+		usage := diskencryption.SecretUsage(vmi, disk.Name)
+		secretSpec := api.SecretSpec{
+			Ephemeral:   "yes",
+			Private:     "yes",
+			Description: fmt.Sprintf("Passphrase of disk %s", disk.Name),
+			Usage: api.SecretUsage{
+				Type:   "volume",
+				Volume: usage,
+			},
+		}
+		xmlBytes, err := xml.Marshal(&secretSpec)
+		if err != nil {
+			return err
+		}
+		if err := l.virConn.DefineVolumeSecret(usage, string(xmlBytes), passphrase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // This function parses variables that are set by SR-IOV device plugin listing
 // PCI IDs for devices allocated to the pod. It also parses variables that
 // virt-controller sets mapping network names to their respective resource
@@ -1225,6 +1344,10 @@ func getDevicesForAssignment(devices v1.Devices) map[string]converter.HostDevice
 			Type:   converter.HostDeviceMDEV,
 			Prefix: MDEV_RESOURCE_PREFIX,
 		},
+		{
+			Type:   converter.HostDeviceUSB,
+			Prefix: USB_RESOURCE_PREFIX,
+		},
 	}
 	resourceToAddressesMap := make(map[string]converter.HostDevicesList)
 
@@ -1350,6 +1473,12 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		return nil, err
 	}
 
+	vdpaInterfaces := vdpa.FilterInterfaces(vmi)
+	vdpaDevices, err := vdpa.CreateDevicePaths(vdpaInterfaces, sriov.NewPCIAddressPool(vdpaInterfaces))
+	if err != nil {
+		return nil, err
+	}
+
 	// Map the VirtualMachineInstance to the Domain
 	c := &converter.ConverterContext{
 		Architecture:          runtime.GOARCH,
@@ -1362,12 +1491,14 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		PermanentVolumes:      permanentVolumes,
 		DiskType:              diskInfo,
 		SRIOVDevices:          sriovDevices,
+		VdpaDevices:           vdpaDevices,
 		GpuDevices:            getEnvAddressListByPrefix(gpuEnvPrefix),
 		VgpuDevices:           getEnvAddressListByPrefix(vgpuEnvPrefix),
 		HostDevices:           getDevicesForAssignment(vmi.Spec.Domain.Devices),
 		EmulatorThreadCpu:     emulatorThreadCpu,
 		OVMFPath:              l.ovmfPath,
 		UseVirtioTransitional: vmi.Spec.Domain.Devices.UseVirtioTransitional != nil && *vmi.Spec.Domain.Devices.UseVirtioTransitional,
+		FreePageReporting:     converter.IsFreePageReportingEnabled(vmi),
 	}
 	if options != nil {
 		if options.VirtualMachineSMBios != nil {
@@ -1375,6 +1506,27 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		}
 		c.MemBalloonStatsPeriod = uint(options.MemBalloonStatsPeriod)
 	}
+	if kutil.IsWindowsVMI(vmi) {
+		c.EVMCSAvailable = util.IsEVMCSAvailable()
+	}
+	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.NUMA != nil && vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough != nil {
+		c.CPUNUMANodes, err = hwutil.GetCPUNUMANodes()
+		if err != nil {
+			logger.Reason(err).Error("failed to read the NUMA topology of the host.")
+			return nil, err
+		}
+	}
+	if kutil.IsSEVVMI(vmi) || kutil.IsSEVSNPVMI(vmi) {
+		sevNodeParameters, err := l.virConn.GetSEVInfo()
+		if err != nil {
+			logger.Reason(err).Error("Getting SEV platform info failed.")
+			return nil, err
+		}
+		c.SEVNodeParameters = &converter.SEVNodeParameters{
+			CBitPos:         sevNodeParameters.CBitPos,
+			ReducedPhysBits: sevNodeParameters.ReducedPhysBits,
+		}
+	}
 	if err := converter.CheckEFI_OVMFRoms(vmi, c); err != nil {
 		logger.Error("EFI OVMF roms missing")
 		return nil, err
@@ -1411,7 +1563,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		}
 	}
 	defer dom.Free()
-	domState, _, err := dom.GetState()
+	domState, domReason, err := dom.GetState()
 	if err != nil {
 		logger.Reason(err).Error("Getting the domain state failed.")
 		return nil, err
@@ -1435,14 +1587,28 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		if err != nil {
 			return nil, err
 		}
-		err = dom.Create()
+		if kutil.IsSEVAttestationRequested(vmi) {
+			// The guest must not run before the launch measurement was verified and the launch secret injected
+			err = dom.CreateWithFlags(libvirt.DOMAIN_START_PAUSED)
+		} else {
+			err = dom.Create()
+		}
 		if err != nil {
 			logger.Reason(err).Error("Starting the VirtualMachineInstance failed.")
 			return nil, err
 		}
-		logger.Info("Domain started.")
+		if kutil.IsSEVAttestationRequested(vmi) {
+			l.paused.add(vmi.UID)
+			logger.Info("Domain started paused, waiting for the SEV launch secret.")
+		} else {
+			logger.Info("Domain started.")
+		}
 	} else if cli.IsPaused(domState) && !l.paused.contains(vmi.UID) {
 		// TODO: if state change reason indicates a system error, we could try something smarter
+		if domReason == int(libvirt.DOMAIN_PAUSED_IOERROR) && hasMultipathDiskWithoutActivePath(domain.Spec.Devices.Disks) {
+			// Resuming would fail the pending I/O again, the guest stays paused until a path recovers
+			return nil, fmt.Errorf("all paths of a multipath disk failed")
+		}
 		err := dom.Resume()
 		if err != nil {
 			logger.Reason(err).Error("unpausing the VirtualMachineInstance failed.")
@@ -1488,6 +1654,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		if !allowAttach {
 			continue
 		}
+		converter.SetOptimalDiscardMode(&attachDisk)
 		logger.V(1).Infof("Attaching disk %s, target %s", attachDisk.Alias.GetName(), attachDisk.Target.Device)
 		attachBytes, err := xml.Marshal(attachDisk)
 		if err != nil {
@@ -1501,10 +1668,346 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		}
 	}
 
+	//Look up all the CD-ROMs whose media got inserted or ejected
+	for _, changedCDRom := range getChangedMedia(oldSpec.Devices.Disks, domain.Spec.Devices.Disks) {
+		if file := getSourceFile(changedCDRom); file != "" {
+			ready, err := checkIfDiskReadyToUse(file)
+			if err != nil {
+				return nil, err
+			}
+			if !ready {
+				continue
+			}
+		}
+		logger.V(1).Infof("Changing the media of CD-ROM %s to %q", changedCDRom.Alias.GetName(), getSourceFile(changedCDRom))
+		cdromBytes, err := xml.Marshal(changedCDRom)
+		if err != nil {
+			logger.Reason(err).Error("marshalling CD-ROM failed")
+			return nil, err
+		}
+		err = dom.UpdateDeviceFlags(string(cdromBytes), libvirt.DOMAIN_DEVICE_MODIFY_LIVE)
+		if err != nil {
+			logger.Reason(err).Error("changing CD-ROM media")
+			return nil, err
+		}
+	}
+
+	if !newDomain && vmi.IsRunning() {
+		detachUnpluggedInterfaces(vmi, &oldSpec, dom)
+		if err := l.attachHotplugInterfaces(vmi, domain, &oldSpec, dom); err != nil {
+			return nil, err
+		}
+		if err := updateInterfaceLinkStates(vmi, &oldSpec, &domain.Spec, dom); err != nil {
+			return nil, err
+		}
+		// SR-IOV host devices are detached before the domain is migrated. They are attached back
+		// once the migration is over: on the target when it succeeded, on the source when it failed.
+		if !isMigrationInProgress(vmi) {
+			if err := sriov.AttachHostDevices(&oldSpec, sriov.FilterHostDevices(&domain.Spec), dom); err != nil {
+				return nil, err
+			}
+			if options != nil && options.ExpandDisksEnabled {
+				if err := l.expandDisks(vmi, &oldSpec, dom); err != nil {
+					return nil, err
+				}
+			}
+			if err := l.updateMultipathStatus(vmi, &oldSpec, dom); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// TODO: check if VirtualMachineInstance Spec and Domain Spec are equal or if we have to sync
 	return &oldSpec, nil
 }
 
+func isMigrationInProgress(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed
+}
+
+// expandDisks grows the disks of the running domain up to the capacity of their PVCs, so that the guest sees
+// the new size of an expanded PVC without a restart. The sizes are recorded in the domain metadata, from where
+// virt-handler reports them in the volume status.
+func (l *LibvirtDomainManager) expandDisks(vmi *v1.VirtualMachineInstance, oldSpec *api.DomainSpec, dom cli.VirDomain) error {
+	logger := log.Log.Object(vmi)
+
+	capacities := make(map[string]int64)
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if capacity := kubevirttypes.GetDiskCapacity(volumeStatus.PersistentVolumeClaimInfo); capacity != nil {
+			capacities[volumeStatus.Name] = *capacity
+		}
+	}
+
+	var diskSizes []api.DiskSizeMetadata
+	for _, disk := range oldSpec.Devices.Disks {
+		name := disk.Alias.GetName()
+		capacity, ok := capacities[name]
+		if !ok || disk.ReadOnly != nil {
+			continue
+		}
+		blockInfo, err := dom.GetBlockInfo(disk.Target.Device, 0)
+		if err != nil {
+			logger.Reason(err).Errorf("getting the size of disk %s failed", name)
+			return err
+		}
+		size := int64(blockInfo.Capacity)
+		if capacity > size {
+			logger.Infof("Expanding disk %s from %d to %d bytes", name, size, capacity)
+			if err := dom.BlockResize(disk.Target.Device, uint64(capacity), libvirt.DOMAIN_BLOCK_RESIZE_BYTES); err != nil {
+				logger.Reason(err).Errorf("expanding disk %s failed", name)
+				return err
+			}
+			size = capacity
+			if wantsGuestFilesystemExpansion(vmi, name) {
+				l.expandGuestFilesystem(vmi, disk)
+			}
+		}
+		diskSizes = append(diskSizes, api.DiskSizeMetadata{Name: name, Size: size})
+	}
+
+	// Metadata is updated on the offline config only, so it is compared with the inactive XML
+	inactiveSpec, err := util.GetDomainSpecWithFlags(dom, libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(inactiveSpec.Metadata.KubeVirt.DiskSizes, diskSizes) {
+		return nil
+	}
+
+	state, _, err := dom.GetState()
+	if err != nil {
+		return err
+	}
+	domainSpec, err := util.GetDomainSpec(state, dom)
+	if err != nil {
+		return err
+	}
+	domainSpec.Metadata.KubeVirt.DiskSizes = diskSizes
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
+// updateMultipathStatus records the health of the paths of the multipath maps backing the block disks
+// in the domain metadata, virt-handler reports it in the volume status.
+func (l *LibvirtDomainManager) updateMultipathStatus(vmi *v1.VirtualMachineInstance, oldSpec *api.DomainSpec, dom cli.VirDomain) error {
+	logger := log.Log.Object(vmi)
+
+	var multipaths []api.MultipathMetadata
+	statuses := make(map[string]*multipath.Status)
+	for _, disk := range oldSpec.Devices.Disks {
+		if disk.Type != "block" || disk.Source.Dev == "" {
+			continue
+		}
+		name := disk.Alias.GetName()
+		status, err := getMultipathStatus(disk.Source.Dev)
+		if err != nil {
+			logger.Reason(err).Warningf("getting the multipath status of disk %s failed", name)
+			continue
+		}
+		if status == nil {
+			continue
+		}
+		statuses[name] = status
+		multipaths = append(multipaths, api.MultipathMetadata{
+			Name:        name,
+			Paths:       int32(len(status.Paths)),
+			ActivePaths: int32(status.ActivePaths()),
+			FailedPaths: status.FailedPaths,
+		})
+	}
+	if len(multipaths) == 0 {
+		return nil
+	}
+
+	// Metadata is updated on the offline config only, so it is compared with the inactive XML
+	inactiveSpec, err := util.GetDomainSpecWithFlags(dom, libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(inactiveSpec.Metadata.KubeVirt.Multipath, multipaths) {
+		return nil
+	}
+	for name, status := range statuses {
+		if status.PathMember {
+			logger.Warningf("Disk %s uses a single path of multipath map %s, it fails when that path fails. "+
+				"The persistent volume should reference the multipath device instead.", name, status.Map)
+		}
+		if len(status.FailedPaths) > 0 {
+			logger.Warningf("Paths %v of multipath map %s of disk %s failed", status.FailedPaths, status.Map, name)
+		}
+	}
+
+	state, _, err := dom.GetState()
+	if err != nil {
+		return err
+	}
+	domainSpec, err := util.GetDomainSpec(state, dom)
+	if err != nil {
+		return err
+	}
+	domainSpec.Metadata.KubeVirt.Multipath = multipaths
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+	return nil
+}
+
+// hasMultipathDiskWithoutActivePath returns true if a block disk is backed by a multipath map whose paths all failed
+func hasMultipathDiskWithoutActivePath(disks []api.Disk) bool {
+	for _, disk := range disks {
+		if disk.Type != "block" || disk.Source.Dev == "" {
+			continue
+		}
+		if status, err := getMultipathStatus(disk.Source.Dev); err == nil && status != nil && status.ActivePaths() == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// attachHotplugInterfaces attaches the interfaces which were hotplugged to the running VMI.
+// An interface is only attached once virt-handler configured its pod interface in phase1.
+func (l *LibvirtDomainManager) attachHotplugInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain, oldSpec *api.DomainSpec, dom cli.VirDomain) error {
+	logger := log.Log.Object(vmi)
+
+	var ifaceNames []string
+	for _, iface := range getAttachedInterfaces(oldSpec.Devices.Interfaces, domain.Spec.Devices.Interfaces) {
+		if network.HasCachedVIF(iface.Alias.GetName()) {
+			ifaceNames = append(ifaceNames, iface.Alias.GetName())
+		}
+	}
+	if len(ifaceNames) == 0 {
+		return nil
+	}
+
+	if err := network.SetupHotplugPodNetworkPhase2(vmi, domain, l.networkCacheStoreFactory, ifaceNames); err != nil {
+		return fmt.Errorf("preparing the hotplugged pod network failed: %v", err)
+	}
+
+	for _, attachIface := range getAttachedInterfaces(oldSpec.Devices.Interfaces, domain.Spec.Devices.Interfaces) {
+		if !network.HasCachedVIF(attachIface.Alias.GetName()) {
+			continue
+		}
+		logger.V(1).Infof("Attaching interface %s", attachIface.Alias.GetName())
+		attachBytes, err := xml.Marshal(attachIface)
+		if err != nil {
+			logger.Reason(err).Error("marshalling attached interface failed")
+			return err
+		}
+		err = dom.AttachDevice(string(attachBytes))
+		if err != nil {
+			logger.Reason(err).Error("attaching interface")
+			return err
+		}
+	}
+	return nil
+}
+
+// detachUnpluggedInterfaces requests the detach of the interfaces which were hot unplugged from the VMI.
+// The guest has to release them, which may take a while or never happen, so a failed request is only
+// logged; virt-handler reports the interfaces which are still attached.
+func detachUnpluggedInterfaces(vmi *v1.VirtualMachineInstance, oldSpec *api.DomainSpec, dom cli.VirDomain) {
+	logger := log.Log.Object(vmi)
+
+	for _, detachIface := range getUnpluggedInterfaces(vmi, oldSpec.Devices.Interfaces) {
+		logger.V(1).Infof("Detaching interface %s", detachIface.Alias.GetName())
+		detachBytes, err := xml.Marshal(detachIface)
+		if err != nil {
+			logger.Reason(err).Error("marshalling detached interface failed")
+			continue
+		}
+		if err := dom.DetachDevice(string(detachBytes)); err != nil {
+			logger.Reason(err).Warningf("detaching interface %s", detachIface.Alias.GetName())
+		}
+	}
+}
+
+// updateInterfaceLinkStates takes the links of the attached interfaces down or up, as requested by the VMI.
+func updateInterfaceLinkStates(vmi *v1.VirtualMachineInstance, oldSpec *api.DomainSpec, newSpec *api.DomainSpec, dom cli.VirDomain) error {
+	logger := log.Log.Object(vmi)
+
+	requestedLinkStates := make(map[string]string)
+	for _, iface := range newSpec.Devices.Interfaces {
+		if iface.Alias != nil {
+			requestedLinkStates[iface.Alias.GetName()] = linkStateOf(iface)
+		}
+	}
+
+	for _, iface := range oldSpec.Devices.Interfaces {
+		if iface.Alias == nil {
+			continue
+		}
+		linkState, exists := requestedLinkStates[iface.Alias.GetName()]
+		if !exists || linkState == linkStateOf(iface) {
+			continue
+		}
+		logger.V(1).Infof("Setting the link of interface %s %s", iface.Alias.GetName(), linkState)
+		iface.LinkState = &api.LinkState{State: linkState}
+		ifaceBytes, err := xml.Marshal(iface)
+		if err != nil {
+			logger.Reason(err).Error("marshalling interface failed")
+			return err
+		}
+		if err := dom.UpdateDeviceFlags(string(ifaceBytes), libvirt.DOMAIN_DEVICE_MODIFY_LIVE); err != nil {
+			return fmt.Errorf("setting the link of interface %s %s failed: %v", iface.Alias.GetName(), linkState, err)
+		}
+	}
+	return nil
+}
+
+// linkStateOf returns the link state of the interface, libvirt leaves it out while the link is up.
+func linkStateOf(iface api.Interface) string {
+	if iface.LinkState == nil || iface.LinkState.State == "" {
+		return "up"
+	}
+	return iface.LinkState.State
+}
+
+func getUnpluggedInterfaces(vmi *v1.VirtualMachineInstance, domainInterfaces []api.Interface) []api.Interface {
+	absentInterfaces := make(map[string]struct{})
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.State == v1.InterfaceStateAbsent {
+			absentInterfaces[iface.Name] = struct{}{}
+		}
+	}
+	res := make([]api.Interface, 0)
+	for _, domainIface := range domainInterfaces {
+		if domainIface.Alias == nil {
+			continue
+		}
+		if _, ok := absentInterfaces[domainIface.Alias.GetName()]; ok {
+			res = append(res, domainIface)
+		}
+	}
+	return res
+}
+
+func getAttachedInterfaces(oldInterfaces, newInterfaces []api.Interface) []api.Interface {
+	oldInterfaceMap := make(map[string]struct{})
+	for _, iface := range oldInterfaces {
+		if iface.Alias != nil {
+			oldInterfaceMap[iface.Alias.GetName()] = struct{}{}
+		}
+	}
+	res := make([]api.Interface, 0)
+	for _, newIface := range newInterfaces {
+		if newIface.Alias == nil {
+			continue
+		}
+		if _, ok := oldInterfaceMap[newIface.Alias.GetName()]; !ok {
+			// This interface got hotplugged, add it to the list
+			res = append(res, newIface)
+		}
+	}
+	return res
+}
+
 func getSourceFile(disk api.Disk) string {
 	file := disk.Source.File
 	if disk.Source.File == "" {
@@ -1556,6 +2059,10 @@ func getDetachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	}
 	res := make([]api.Disk, 0)
 	for _, oldDisk := range oldDisks {
+		if oldDisk.Device == "cdrom" {
+			// CD-ROM drives stay attached, only their media changes
+			continue
+		}
 		if _, ok := newDiskMap[getSourceFile(oldDisk)]; !ok {
 			// This disk got detached, add it to the list
 			res = append(res, oldDisk)
@@ -1574,6 +2081,10 @@ func getAttachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	}
 	res := make([]api.Disk, 0)
 	for _, newDisk := range newDisks {
+		if newDisk.Device == "cdrom" {
+			// CD-ROM drives stay attached, only their media changes
+			continue
+		}
 		if _, ok := oldDiskMap[getSourceFile(newDisk)]; !ok {
 			// This disk got attached, add it to the list
 			res = append(res, newDisk)
@@ -1582,6 +2093,27 @@ func getAttachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	return res
 }
 
+// getChangedMedia returns the CD-ROMs of the new disks whose media differs from the same drive in the old disks
+func getChangedMedia(oldDisks, newDisks []api.Disk) []api.Disk {
+	oldCDRomMap := make(map[string]api.Disk)
+	for _, disk := range oldDisks {
+		if disk.Device == "cdrom" && disk.Alias != nil {
+			oldCDRomMap[disk.Alias.GetName()] = disk
+		}
+	}
+	res := make([]api.Disk, 0)
+	for _, newDisk := range newDisks {
+		if newDisk.Device != "cdrom" || newDisk.Alias == nil {
+			continue
+		}
+		oldDisk, ok := oldCDRomMap[newDisk.Alias.GetName()]
+		if ok && getSourceFile(oldDisk) != getSourceFile(newDisk) {
+			res = append(res, newDisk)
+		}
+	}
+	return res
+}
+
 var isHotplugBlockDeviceVolume = isHotplugBlockDeviceVolumeFunc
 
 func isHotplugBlockDeviceVolumeFunc(volumeName string) bool {
@@ -1598,6 +2130,8 @@ func isHotplugBlockDeviceVolumeFunc(volumeName string) bool {
 
 var isBlockDeviceVolume = isBlockDeviceVolumeFunc
 
+var getMultipathStatus = multipath.GetStatus
+
 func isBlockDeviceVolumeFunc(volumeName string) (bool, error) {
 	path := converter.GetBlockDeviceVolumePath(volumeName)
 	fileInfo, err := os.Stat(path)
@@ -1872,7 +2406,12 @@ func (l *LibvirtDomainManager) DeleteVMI(vmi *v1.VirtualMachineInstance) error {
 	}
 	defer dom.Free()
 
-	err = dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_NVRAM)
+	undefineFlags := libvirt.DOMAIN_UNDEFINE_NVRAM
+	if backendstorage.HasPersistentEFI(vmi) {
+		// the NVRAM lives on the backend storage and is reused on the next start
+		undefineFlags = libvirt.DOMAIN_UNDEFINE_KEEP_NVRAM
+	}
+	err = dom.UndefineFlags(undefineFlags)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Undefining the domain failed.")
 		return err
@@ -1975,7 +2514,29 @@ func (l *LibvirtDomainManager) buildDevicesMetadata(vmi *v1.VirtualMachineInstan
 
 }
 
-// GetGuestInfo queries the agent store and return the aggregated data from Guest agent
+// buildNetworkData generates the cloud-init networkdata from the addressing of the VMI's networks.
+// The interfaces are matched by the hypervisor assigned MAC addresses, when the domain is available.
+func (l *LibvirtDomainManager) buildNetworkData(vmi *v1.VirtualMachineInstance, domPtr *cli.VirDomain) (string, error) {
+	var domainInterfaces []api.Interface
+	if domPtr != nil {
+		devices, err := getAllDomainDevices(*domPtr)
+		if err != nil {
+			return "", err
+		}
+		domainInterfaces = devices.Interfaces
+	}
+	nameservers, searchDomains, err := converter.GetResolvConfDetailsFromPod()
+	if err != nil {
+		return "", fmt.Errorf("failed to get DNS servers from resolv.conf: %v", err)
+	}
+	interfaces, err := network.GetCloudInitNetworkInterfaces(vmi, domainInterfaces, nameservers, searchDomains)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the network interfaces addressing: %v", err)
+	}
+	return cloudinit.GenerateNetworkData(interfaces)
+}
+
+// GetGuestInfo queries the agent store and return the aggregated data from Guest agent
 func (l *LibvirtDomainManager) GetGuestInfo() (v1.VirtualMachineInstanceGuestAgentInfo, error) {
 	sysInfo := l.agentData.GetSysInfo()
 	fsInfo := l.agentData.GetFS(10)
@@ -2052,6 +2613,601 @@ func (l *LibvirtDomainManager) GetFilesystems() ([]v1.VirtualMachineInstanceFile
 	return fsList, nil
 }
 
+func (l *LibvirtDomainManager) GetSEVInfo() (*v1.SEVPlatformInfo, error) {
+	sevNodeParameters, err := l.virConn.GetSEVInfo()
+	if err != nil {
+		log.Log.Reason(err).Error("Getting SEV platform info failed")
+		return nil, err
+	}
+
+	return &v1.SEVPlatformInfo{
+		PDH:       sevNodeParameters.PDH,
+		CertChain: sevNodeParameters.CertChain,
+	}, nil
+}
+
+// qemuSEVInfo is the subset of the QMP query-sev reply needed for the launch measurement
+type qemuSEVInfo struct {
+	Return struct {
+		APIMajor uint `json:"api-major"`
+		APIMinor uint `json:"api-minor"`
+		BuildID  uint `json:"build-id"`
+		Policy   uint `json:"policy"`
+	} `json:"return"`
+}
+
+func (l *LibvirtDomainManager) GetLaunchMeasurement(vmi *v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error) {
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain failed.")
+		return nil, err
+	}
+	defer dom.Free()
+
+	launchSecurityParameters, err := dom.GetLaunchSecurityInfo(0)
+	if err != nil {
+		logger.Reason(err).Error("Getting the launch security info failed.")
+		return nil, err
+	}
+
+	result, err := dom.QemuMonitorCommand(`{"execute":"query-sev"}`, libvirt.DOMAIN_QEMU_MONITOR_COMMAND_DEFAULT)
+	if err != nil {
+		logger.Reason(err).Error("Querying SEV info from the monitor failed.")
+		return nil, err
+	}
+	sevInfo := qemuSEVInfo{}
+	if err := json.Unmarshal([]byte(result), &sevInfo); err != nil {
+		logger.Reason(err).Error("Parsing SEV info failed.")
+		return nil, err
+	}
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec failed.")
+		return nil, err
+	}
+	loaderSHA := ""
+	if domainSpec.OS.BootLoader != nil && domainSpec.OS.BootLoader.Path != "" {
+		loader, err := ioutil.ReadFile(domainSpec.OS.BootLoader.Path)
+		if err != nil {
+			logger.Reason(err).Error("Reading the loader binary failed.")
+			return nil, err
+		}
+		loaderSum := sha256.Sum256(loader)
+		loaderSHA = hex.EncodeToString(loaderSum[:])
+	}
+
+	return &v1.SEVMeasurementInfo{
+		Measurement: launchSecurityParameters.SEVMeasurement,
+		APIMajor:    sevInfo.Return.APIMajor,
+		APIMinor:    sevInfo.Return.APIMinor,
+		BuildID:     sevInfo.Return.BuildID,
+		Policy:      sevInfo.Return.Policy,
+		LoaderSHA:   loaderSHA,
+	}, nil
+}
+
+// InjectLaunchSecret injects the launch secret into the memory of the SEV guest. A guest which requested the
+// attestation was started paused, it is resumed once the secret was injected.
+func (l *LibvirtDomainManager) InjectLaunchSecret(vmi *v1.VirtualMachineInstance, sevSecretOptions *v1.SEVSecretOptions) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain failed.")
+		return err
+	}
+	defer dom.Free()
+
+	command, err := json.Marshal(map[string]interface{}{
+		"execute": "sev-inject-launch-secret",
+		"arguments": map[string]string{
+			"packet-header": sevSecretOptions.Header,
+			"secret":        sevSecretOptions.Secret,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := dom.QemuMonitorCommand(string(command), libvirt.DOMAIN_QEMU_MONITOR_COMMAND_DEFAULT); err != nil {
+		logger.Reason(err).Error("Injecting the SEV launch secret failed.")
+		return err
+	}
+
+	if !kutil.IsSEVAttestationRequested(vmi) {
+		return nil
+	}
+	domState, _, err := dom.GetState()
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain state failed.")
+		return err
+	}
+	if domState == libvirt.DOMAIN_PAUSED {
+		if err := dom.Resume(); err != nil {
+			logger.Reason(err).Error("Resuming the domain after injecting the SEV launch secret failed.")
+			return err
+		}
+		l.paused.remove(vmi.UID)
+		logger.Info("Domain resumed after injecting the SEV launch secret.")
+	}
+
+	return nil
+}
+
+const (
+	fsFrozen = "frozen"
+	fsThawed = "thawed"
+)
+
+type qemuAgentFSFreezeStatus struct {
+	Return string `json:"return"`
+}
+
+func (l *LibvirtDomainManager) getFSFreezeStatus(domName string) (string, error) {
+	result, err := l.virConn.QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, domName)
+	if err != nil {
+		return "", err
+	}
+	status := qemuAgentFSFreezeStatus{}
+	if err := json.Unmarshal([]byte(result), &status); err != nil {
+		return "", err
+	}
+	return status.Return, nil
+}
+
+// FreezeVMI runs the pre backup hook of the VMI on the guest and freezes the guest filesystems through
+// the guest agent. When unfreezeTimeoutSeconds is positive, the filesystems are thawed again automatically
+// after that time, in case the caller never comes back to unfreeze them.
+func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error {
+	l.unfreezeLock.Lock()
+	defer l.unfreezeLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	status, err := l.getFSFreezeStatus(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the guest filesystem freeze status failed.")
+		return err
+	}
+
+	if status != fsFrozen {
+		if err := l.runBackupHook(vmi, v1.PreBackupHookAnnotation); err != nil {
+			logger.Reason(err).Error("The pre backup hook failed.")
+			l.setQuiesceMetadata(vmi, false, v1.VirtualMachineInstanceReasonBackupHookFailed, err.Error())
+			return err
+		}
+		freezeCommand, err := l.getFSFreezeCommand(vmi, domName)
+		if err != nil {
+			logger.Reason(err).Error("Getting the guest filesystems to freeze failed.")
+			return err
+		}
+		if _, err := l.virConn.QemuAgentCommand(freezeCommand, domName); err != nil {
+			logger.Reason(err).Error("Freezing the guest filesystems failed.")
+			return err
+		}
+		logger.Info("Guest filesystems were frozen")
+		l.setQuiesceMetadata(vmi, true, v1.VirtualMachineInstanceReasonFrozen, "The guest filesystems were frozen")
+	}
+
+	if l.unfreezeTimer != nil {
+		l.unfreezeTimer.Stop()
+		l.unfreezeTimer = nil
+	}
+	if unfreezeTimeoutSeconds > 0 {
+		l.unfreezeTimer = time.AfterFunc(time.Duration(unfreezeTimeoutSeconds)*time.Second, func() {
+			logger.Warning("Unfreeze timeout expired, thawing the guest filesystems")
+			if err := l.unfreezeVMI(vmi, v1.VirtualMachineInstanceReasonUnfreezeTimeoutExpired); err != nil {
+				logger.Reason(err).Error("Automatic unfreeze of the guest filesystems failed.")
+			}
+		})
+	}
+
+	return nil
+}
+
+// getFSFreezeCommand returns the guest agent command freezing the guest filesystems. When disks of the VMI
+// are excluded from snapshots, only the filesystems which are not on these disks are frozen.
+func (l *LibvirtDomainManager) getFSFreezeCommand(vmi *v1.VirtualMachineInstance, domName string) (string, error) {
+	excluded := map[string]bool{}
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.ExcludeFromSnapshot != nil && *disk.ExcludeFromSnapshot {
+			excluded[disk.Name] = true
+		}
+	}
+	if len(excluded) == 0 {
+		return `{"execute":"guest-fsfreeze-freeze"}`, nil
+	}
+
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return "", err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		return "", err
+	}
+
+	var excludedDisks []api.Disk
+	for _, disk := range domainSpec.Devices.Disks {
+		if disk.Alias != nil && excluded[disk.Alias.GetName()] {
+			excludedDisks = append(excludedDisks, disk)
+		}
+	}
+
+	fsInfo, err := l.virConn.QemuAgentCommand(`{"execute":"guest-get-fsinfo"}`, domName)
+	if err != nil {
+		return "", err
+	}
+	mountpoints, err := agentpoller.MountpointsWithoutDisks(fsInfo, excludedDisks)
+	if err != nil {
+		return "", err
+	}
+
+	command, err := json.Marshal(map[string]interface{}{
+		"execute": "guest-fsfreeze-freeze-list",
+		"arguments": map[string]interface{}{
+			"mountpoints": mountpoints,
+		},
+	})
+	return string(command), err
+}
+
+// UnfreezeVMI thaws the guest filesystems if they are frozen and runs the post backup hook of the VMI on the guest
+func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error {
+	return l.unfreezeVMI(vmi, v1.VirtualMachineInstanceReasonThawed)
+}
+
+func (l *LibvirtDomainManager) unfreezeVMI(vmi *v1.VirtualMachineInstance, reason string) error {
+	l.unfreezeLock.Lock()
+	defer l.unfreezeLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	if l.unfreezeTimer != nil {
+		l.unfreezeTimer.Stop()
+		l.unfreezeTimer = nil
+	}
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	status, err := l.getFSFreezeStatus(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the guest filesystem freeze status failed.")
+		return err
+	}
+
+	if status == fsThawed {
+		return nil
+	}
+
+	if _, err := l.virConn.QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, domName); err != nil {
+		logger.Reason(err).Error("Thawing the guest filesystems failed.")
+		return err
+	}
+	logger.Info("Guest filesystems were thawed")
+
+	if err := l.runBackupHook(vmi, v1.PostBackupHookAnnotation); err != nil {
+		logger.Reason(err).Error("The post backup hook failed.")
+		l.setQuiesceMetadata(vmi, false, v1.VirtualMachineInstanceReasonBackupHookFailed, err.Error())
+		return err
+	}
+	message := "The guest filesystems were thawed"
+	if reason == v1.VirtualMachineInstanceReasonUnfreezeTimeoutExpired {
+		message = "The guest filesystems were thawed because the unfreeze timeout expired"
+	}
+	l.setQuiesceMetadata(vmi, false, reason, message)
+
+	return nil
+}
+
+// runBackupHook runs the command of the given backup hook annotation of the VMI on the guest, if it is set
+func (l *LibvirtDomainManager) runBackupHook(vmi *v1.VirtualMachineInstance, annotation string) error {
+	command, err := kutil.GetBackupHook(vmi.Annotations, annotation)
+	if err != nil || command == nil {
+		return err
+	}
+	timeout, err := kutil.GetBackupHookTimeout(vmi.Annotations)
+	if err != nil {
+		return err
+	}
+
+	exitCode, stdOut, err := l.Exec(util.VMINamespaceKeyFunc(vmi), command[0], command[1:], int32(timeout.Seconds()))
+	if err != nil {
+		return fmt.Errorf("running the backup hook %s failed: %v", command[0], err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("the backup hook %s exited with code %d: %s", command[0], exitCode, strings.TrimSpace(stdOut))
+	}
+	log.Log.Object(vmi).Infof("The backup hook %s succeeded", command[0])
+	return nil
+}
+
+// setQuiesceMetadata records the freeze state of the guest filesystems in the domain metadata,
+// virt-handler reports it in the Frozen condition of the VMI
+func (l *LibvirtDomainManager) setQuiesceMetadata(vmi *v1.VirtualMachineInstance, frozen bool, reason string, message string) {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	dom, err := l.virConn.LookupDomainByName(util.VMINamespaceKeyFunc(vmi))
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain to record the freeze state failed.")
+		return
+	}
+	defer dom.Free()
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec to record the freeze state failed.")
+		return
+	}
+
+	now := metav1.Now()
+	domainSpec.Metadata.KubeVirt.Quiesce = &api.QuiesceMetadata{
+		Frozen:    frozen,
+		Reason:    reason,
+		Message:   message,
+		Timestamp: &now,
+	}
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		logger.Reason(err).Error("Recording the freeze state failed.")
+		return
+	}
+	defer d.Free()
+}
+
+type qemuAgentExecRequest struct {
+	Execute   string                 `json:"execute"`
+	Arguments qemuAgentExecArguments `json:"arguments"`
+}
+
+type qemuAgentExecArguments struct {
+	Path          string   `json:"path,omitempty"`
+	Arg           []string `json:"arg,omitempty"`
+	CaptureOutput bool     `json:"capture-output,omitempty"`
+	Pid           int      `json:"pid,omitempty"`
+}
+
+type qemuAgentExec struct {
+	Return struct {
+		Pid int `json:"pid"`
+	} `json:"return"`
+}
+
+type qemuAgentExecStatus struct {
+	Return struct {
+		Exited   bool   `json:"exited"`
+		ExitCode int    `json:"exitcode"`
+		OutData  string `json:"out-data"`
+	} `json:"return"`
+}
+
+const guestExecStatusInterval = 100 * time.Millisecond
+
+func (l *LibvirtDomainManager) qemuAgentExec(domainName string, request qemuAgentExecRequest, response interface{}) error {
+	cmd, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	result, err := l.virConn.QemuAgentCommand(string(cmd), domainName)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(result), response)
+}
+
+// Exec runs the command with its arguments on the guest through the guest agent and waits
+// up to timeoutSeconds for it to exit. It returns the exit code and the standard output of the command.
+func (l *LibvirtDomainManager) Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error) {
+	execResult := qemuAgentExec{}
+	err := l.qemuAgentExec(domainName, qemuAgentExecRequest{
+		Execute: "guest-exec",
+		Arguments: qemuAgentExecArguments{
+			Path:          command,
+			Arg:           args,
+			CaptureOutput: true,
+		},
+	}, &execResult)
+	if err != nil {
+		return -1, "", err
+	}
+	if execResult.Return.Pid <= 0 {
+		return -1, "", fmt.Errorf("invalid pid %d returned by the guest agent for command %s", execResult.Return.Pid, command)
+	}
+
+	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+	for {
+		status := qemuAgentExecStatus{}
+		err := l.qemuAgentExec(domainName, qemuAgentExecRequest{
+			Execute:   "guest-exec-status",
+			Arguments: qemuAgentExecArguments{Pid: execResult.Return.Pid},
+		}, &status)
+		if err != nil {
+			return -1, "", err
+		}
+		if status.Return.Exited {
+			stdOut, err := base64.StdEncoding.DecodeString(status.Return.OutData)
+			if err != nil {
+				return -1, "", err
+			}
+			return status.Return.ExitCode, string(stdOut), nil
+		}
+		if time.Now().After(deadline) {
+			return -1, "", fmt.Errorf("timed out waiting for command %s with pid %d to exit on the guest", command, execResult.Return.Pid)
+		}
+		time.Sleep(guestExecStatusInterval)
+	}
+}
+
+// GuestPing checks whether the guest agent of the domain responds
+func (l *LibvirtDomainManager) GuestPing(domainName string) error {
+	_, err := l.virConn.QemuAgentCommand(`{"execute":"guest-ping"}`, domainName)
+	return err
+}
+
+// UpdateVCPUs enables as many of the hotpluggable vCPUs of the running domain
+// as are requested by the CPU topology of the VMI
+func (l *LibvirtDomainManager) UpdateVCPUs(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during vCPU update.")
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec failed during vCPU update.")
+		return err
+	}
+	if domainSpec.VCPU == nil {
+		return fmt.Errorf("domain has no vCPU configuration")
+	}
+
+	requested := uint32(hwutil.GetNumberOfVCPUs(vmi.Spec.Domain.CPU))
+	current := domainSpec.VCPU.Current
+	if current == 0 {
+		current = domainSpec.VCPU.CPUs
+	}
+
+	switch {
+	case requested == current:
+		return nil
+	case requested < current:
+		return fmt.Errorf("unplugging vCPUs is not supported, %d vCPUs are enabled but %d were requested", current, requested)
+	case requested > domainSpec.VCPU.CPUs:
+		return fmt.Errorf("%d vCPUs were requested but the domain supports at most %d vCPUs", requested, domainSpec.VCPU.CPUs)
+	}
+
+	if err := dom.SetVcpusFlags(uint(requested), libvirt.DOMAIN_VCPU_LIVE); err != nil {
+		logger.Reason(err).Error("Hotplugging vCPUs failed.")
+		return err
+	}
+	logger.Infof("Hotplugged vCPUs, %d of %d vCPUs are enabled", requested, domainSpec.VCPU.CPUs)
+
+	return nil
+}
+
+// UpdateGuestMemory resizes the virtio-mem device of the running domain so that
+// the guest sees the memory requested in the VMI spec.
+func (l *LibvirtDomainManager) UpdateGuestMemory(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	if vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Guest == nil {
+		return fmt.Errorf("no guest memory specified")
+	}
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during memory update.")
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec failed during memory update.")
+		return err
+	}
+	memoryDevice := domainSpec.Devices.Memory
+	if memoryDevice == nil || memoryDevice.Target == nil {
+		return fmt.Errorf("domain has no virtio-mem device")
+	}
+
+	if domainSpec.CPU.NUMA == nil {
+		return fmt.Errorf("domain has no NUMA configuration")
+	}
+	// memory devices are not part of the memory assigned to the NUMA cells
+	bootMemory, err := domainSpec.CPU.NUMA.TotalMemory()
+	if err != nil {
+		return err
+	}
+	size, err := memoryDevice.Target.Size.Bytes()
+	if err != nil {
+		return err
+	}
+
+	guest := uint64(vmi.Spec.Domain.Memory.Guest.Value())
+	if guest < bootMemory {
+		return fmt.Errorf("%d bytes of guest memory were requested but the domain booted with %d bytes", guest, bootMemory)
+	}
+	requested := guest - bootMemory
+	if requested > size {
+		return fmt.Errorf("%d bytes of guest memory were requested but the domain supports at most %d bytes", guest, bootMemory+size)
+	}
+
+	memoryDevice.Target.Requested = api.Memory{Value: requested, Unit: "b"}
+	memoryDevice.Target.Current = nil
+	memoryDeviceXML, err := xml.Marshal(memoryDevice)
+	if err != nil {
+		return err
+	}
+
+	if err := dom.UpdateDeviceFlags(string(memoryDeviceXML), libvirt.DOMAIN_DEVICE_MODIFY_LIVE); err != nil {
+		logger.Reason(err).Error("Hotplugging memory failed.")
+		return err
+	}
+	logger.Infof("Requested %d bytes of hotplugged memory", requested)
+
+	return nil
+}
+
+// SetGuestMemoryBalloon sets the target of the memory balloon of the running domain.
+// The guest returns the memory above the target to the host.
+func (l *LibvirtDomainManager) SetGuestMemoryBalloon(vmi *v1.VirtualMachineInstance, target uint64) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during memory balloon update.")
+		return err
+	}
+	defer dom.Free()
+
+	// libvirt expects the target in KiB
+	if err := dom.SetMemoryFlags(target/1024, libvirt.DOMAIN_MEM_LIVE); err != nil {
+		logger.Reason(err).Error("Setting the memory balloon target failed.")
+		return err
+	}
+	logger.Infof("Set the memory balloon target to %d bytes", target)
+
+	return nil
+}
+
 func detachHostDevices(virConn cli.Connection, dom cli.VirDomain) error {
 	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
 	if err != nil {
@@ -36,11 +36,13 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	libvirt "libvirt.org/libvirt-go"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 
 	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	"kubevirt.io/kubevirt/pkg/util/multipath"
 	"kubevirt.io/kubevirt/pkg/util/net/ip"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -48,6 +50,7 @@ import (
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
@@ -132,6 +135,42 @@ var _ = Describe("Manager", func() {
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
 		})
+		It("should start a SEV VirtualMachineInstance which requested attestation paused", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SEV: &v1.SEV{Attestation: &v1.SEVAttestation{}}}
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
+
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).To(BeNil())
+			mockConn.EXPECT().DomainDefineXML(string(xml)).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTDOWN, 1, nil)
+			mockDomain.EXPECT().CreateWithFlags(libvirt.DOMAIN_START_PAUSED).Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xml), nil)
+			mockDomain.EXPECT().Free()
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).To(BeNil())
+			Expect(newspec).ToNot(BeNil())
+			Expect(manager.(*LibvirtDomainManager).paused.contains(vmi.UID)).To(BeTrue())
+		})
+		It("should resume a SEV VirtualMachineInstance which requested attestation once the launch secret is injected", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SEV: &v1.SEV{Attestation: &v1.SEVAttestation{}}}
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().QemuMonitorCommand(gomock.Any(), libvirt.DOMAIN_QEMU_MONITOR_COMMAND_DEFAULT).Return("{}", nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
+			mockDomain.EXPECT().Resume().Return(nil)
+			mockDomain.EXPECT().Free()
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager.(*LibvirtDomainManager).paused.add(vmi.UID)
+
+			Expect(manager.InjectLaunchSecret(vmi, &v1.SEVSecretOptions{Header: "header", Secret: "secret"})).To(Succeed())
+			Expect(manager.(*LibvirtDomainManager).paused.contains(vmi.UID)).To(BeFalse())
+		})
 		It("should define and start a new VirtualMachineInstance with userData", func() {
 			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free()
@@ -272,6 +311,381 @@ var _ = Describe("Manager", func() {
 			err := manager.PauseVMI(vmi)
 			Expect(err).To(BeNil())
 		})
+		It("should freeze a VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"thawed"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, testDomainName).Return(`{"return":1}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			err := manager.FreezeVMI(vmi, 0)
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should not freeze the filesystems on disks excluded from snapshots", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			excluded := true
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "rootdisk"},
+				{Name: "scratch", ExcludeFromSnapshot: &excluded},
+			}
+			domainXML := `<domain><devices>
+				<disk type="file" device="disk">
+					<target dev="vda" bus="virtio"></target>
+					<alias name="ua-rootdisk"></alias>
+					<address type="pci" domain="0x0000" bus="0x07" slot="0x00" function="0x0"></address>
+				</disk>
+				<disk type="file" device="disk">
+					<target dev="vdb" bus="virtio"></target>
+					<alias name="ua-scratch"></alias>
+					<address type="pci" domain="0x0000" bus="0x08" slot="0x00" function="0x0"></address>
+				</disk>
+			</devices></domain>`
+			fsInfo := `{"return":[
+				{"name":"vda1","mountpoint":"/","type":"xfs","disk":[{"bus-type":"virtio","pci-controller":{"domain":0,"bus":7,"slot":0,"function":0}}]},
+				{"name":"vdb","mountpoint":"/scratch","type":"ext4","disk":[{"bus-type":"virtio","pci-controller":{"domain":0,"bus":8,"slot":0,"function":0}}]}
+			]}`
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"thawed"}`, nil)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().Free()
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(domainXML, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-get-fsinfo"}`, testDomainName).Return(fsInfo, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"arguments":{"mountpoints":["/"]},"execute":"guest-fsfreeze-freeze-list"}`, testDomainName).Return(`{"return":1}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			err := manager.FreezeVMI(vmi, 0)
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should not freeze a frozen VirtualMachineInstance again", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"frozen"}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			err := manager.FreezeVMI(vmi, 0)
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should unfreeze a frozen VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"frozen"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, testDomainName).Return(`{"return":1}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			err := manager.UnfreezeVMI(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should automatically unfreeze a VirtualMachineInstance after the unfreeze timeout", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			thawed := make(chan struct{})
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"thawed"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, testDomainName).Return(`{"return":1}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"frozen"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, testDomainName).DoAndReturn(func(string, string) (string, error) {
+				close(thawed)
+				return `{"return":1}`, nil
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			err := manager.FreezeVMI(vmi, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(thawed, 5*time.Second).Should(BeClosed())
+		})
+		It("should exec a command on the guest and return its exit code and output", func() {
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-exec","arguments":{"path":"cat","arg":["/tmp/ready"],"capture-output":true}}`, testDomainName).Return(`{"return":{"pid":789}}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-exec-status","arguments":{"pid":789}}`, testDomainName).Return(`{"return":{"exited":false}}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-exec-status","arguments":{"pid":789}}`, testDomainName).Return(`{"return":{"exitcode":1,"out-data":"bm90IHJlYWR5","exited":true}}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			exitCode, stdOut, err := manager.Exec(testDomainName, "cat", []string{"/tmp/ready"}, 5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exitCode).To(Equal(1))
+			Expect(stdOut).To(Equal("not ready"))
+		})
+		It("should ping the guest agent", func() {
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-ping"}`, testDomainName).Return(`{"return":{}}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+			Expect(manager.GuestPing(testDomainName)).To(Succeed())
+		})
+		Context("on vCPU hotplug", func() {
+			newDomainXML := func(current, max uint32) string {
+				domainSpec := api.NewMinimalDomainSpec(testDomainName)
+				domainSpec.VCPU = &api.VCPU{Placement: "static", Current: current, CPUs: max}
+				xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+				Expect(err).NotTo(HaveOccurred())
+				return string(xml)
+			}
+
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vmi = newVMI(testNamespace, testVmName)
+				vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 3, MaxSockets: 4}
+				mockDomain.EXPECT().Free()
+				mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			})
+
+			It("should enable the requested vCPUs", func() {
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainXML(2, 4), nil)
+				mockDomain.EXPECT().SetVcpusFlags(uint(3), libvirt.DOMAIN_VCPU_LIVE).Return(nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.UpdateVCPUs(vmi)).To(Succeed())
+			})
+
+			It("should do nothing when the requested vCPUs are already enabled", func() {
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainXML(3, 4), nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.UpdateVCPUs(vmi)).To(Succeed())
+			})
+
+			It("should refuse to unplug vCPUs", func() {
+				vmi.Spec.Domain.CPU.Sockets = 1
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainXML(2, 4), nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.UpdateVCPUs(vmi)).ToNot(Succeed())
+			})
+
+			It("should refuse to enable more vCPUs than the domain supports", func() {
+				vmi.Spec.Domain.CPU.Sockets = 6
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainXML(2, 4), nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.UpdateVCPUs(vmi)).ToNot(Succeed())
+			})
+		})
+		Context("on memory hotplug", func() {
+			newDomainXML := func() string {
+				domainSpec := api.NewMinimalDomainSpec(testDomainName)
+				domainSpec.CPU.NUMA = &api.NUMA{
+					Cells: []api.NUMACell{{ID: "0", CPUs: "0", Memory: "1048576", Unit: "KiB"}},
+				}
+				domainSpec.Devices.Memory = &api.MemoryDevice{
+					Model: "virtio-mem",
+					Target: &api.MemoryTarget{
+						Size:      api.Memory{Value: 3145728, Unit: "KiB"},
+						Node:      "0",
+						Block:     api.Memory{Value: 2048, Unit: "KiB"},
+						Requested: api.Memory{Value: 0, Unit: "KiB"},
+						Current:   &api.Memory{Value: 0, Unit: "KiB"},
+					},
+				}
+				xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+				Expect(err).NotTo(HaveOccurred())
+				return string(xml)
+			}
+
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vmi = newVMI(testNamespace, testVmName)
+				guest := resource.MustParse("2Gi")
+				maxGuest := resource.MustParse("4Gi")
+				vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+				mockDomain.EXPECT().Free()
+				mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainXML(), nil)
+			})
+
+			It("should request the additional memory from the virtio-mem device", func() {
+				mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE).Do(func(deviceXML string, _ libvirt.DomainDeviceModifyFlags) {
+					memoryDevice := api.MemoryDevice{}
+					Expect(xml.Unmarshal([]byte(deviceXML), &memoryDevice)).To(Succeed())
+					Expect(memoryDevice.Target.Requested).To(Equal(api.Memory{Value: 1024 * 1024 * 1024, Unit: "b"}))
+				}).Return(nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.UpdateGuestMemory(vmi)).To(Succeed())
+			})
+
+			It("should refuse to request more memory than the device provides", func() {
+				guest := resource.MustParse("8Gi")
+				vmi.Spec.Domain.Memory.Guest = &guest
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.UpdateGuestMemory(vmi)).ToNot(Succeed())
+			})
+		})
+		Context("on disk expansion", func() {
+			var vmi *v1.VirtualMachineInstance
+			var domainSpec *api.DomainSpec
+
+			domainXML := func() string {
+				xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+				Expect(err).NotTo(HaveOccurred())
+				return string(xml)
+			}
+
+			BeforeEach(func() {
+				vmi = newVMI(testNamespace, testVmName)
+				blockMode := k8sv1.PersistentVolumeBlock
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+					Name: "disk0",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						Capacity:   k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("2Gi")},
+						VolumeMode: &blockMode,
+					},
+				}}
+				domainSpec = api.NewMinimalDomainSpec(testDomainName)
+				domainSpec.Devices.Disks = []api.Disk{{
+					Alias:  api.NewUserDefinedAlias("disk0"),
+					Target: api.DiskTarget{Device: "vda"},
+				}}
+				mockDomain.EXPECT().Free().AnyTimes()
+			})
+
+			It("should expand a disk whose PVC was expanded and record its new size", func() {
+				mockDomain.EXPECT().GetBlockInfo("vda", uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+				mockDomain.EXPECT().BlockResize("vda", uint64(2<<30), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).AnyTimes().Return(domainXML(), nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE).Return(domainXML(), nil)
+				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+				mockConn.EXPECT().DomainDefineXML(gomock.Any()).DoAndReturn(func(xml string) (cli.VirDomain, error) {
+					Expect(xml).To(ContainSubstring(`<diskSize name="disk0" size="2147483648"></diskSize>`))
+					return mockDomain, nil
+				})
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.(*LibvirtDomainManager).expandDisks(vmi, domainSpec, mockDomain)).To(Succeed())
+			})
+
+			It("should grow the last partition and its filesystem in the guest when asked to", func() {
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "disk0", ExpandGuestFilesystem: pointer.BoolPtr(true)}}
+				domainSpec.Devices.Disks[0].Target.Bus = "virtio"
+				domainSpec.Devices.Disks[0].Address = &api.Address{Type: "pci", Domain: "0x0000", Bus: "0x07", Slot: "0x00", Function: "0x0"}
+				fsInfo := `{"return":[
+					{"name":"vda1","mountpoint":"/boot","type":"ext4","disk":[{"bus-type":"virtio","bus":0,"target":0,"unit":0,"dev":"/dev/vda","pci-controller":{"domain":0,"bus":7,"slot":0,"function":0}}]},
+					{"name":"vda2","mountpoint":"/","type":"ext4","disk":[{"bus-type":"virtio","bus":0,"target":0,"unit":0,"dev":"/dev/vda","pci-controller":{"domain":0,"bus":7,"slot":0,"function":0}}]}
+				]}`
+				mockDomain.EXPECT().GetBlockInfo("vda", uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+				mockDomain.EXPECT().BlockResize("vda", uint64(2<<30), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-get-fsinfo"}`, testDomainName).Return(fsInfo, nil)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-exec","arguments":{"path":"growpart","arg":["/dev/vda","2"],"capture-output":true}}`, testDomainName).Return(`{"return":{"pid":10}}`, nil)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-exec-status","arguments":{"pid":10}}`, testDomainName).Return(`{"return":{"exitcode":0,"exited":true}}`, nil)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-exec","arguments":{"path":"resize2fs","arg":["/dev/vda2"],"capture-output":true}}`, testDomainName).Return(`{"return":{"pid":11}}`, nil)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-exec-status","arguments":{"pid":11}}`, testDomainName).Return(`{"return":{"exitcode":0,"exited":true}}`, nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).AnyTimes().Return(domainXML(), nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE).Return(domainXML(), nil)
+				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+				mockConn.EXPECT().DomainDefineXML(gomock.Any()).Return(mockDomain, nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.(*LibvirtDomainManager).expandDisks(vmi, domainSpec, mockDomain)).To(Succeed())
+			})
+
+			It("should not fail the expansion when growing the guest filesystem fails", func() {
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "disk0", ExpandGuestFilesystem: pointer.BoolPtr(true)}}
+				mockDomain.EXPECT().GetBlockInfo("vda", uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+				mockDomain.EXPECT().BlockResize("vda", uint64(2<<30), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-get-fsinfo"}`, testDomainName).Return("", fmt.Errorf("guest agent is not connected"))
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).AnyTimes().Return(domainXML(), nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE).Return(domainXML(), nil)
+				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+				mockConn.EXPECT().DomainDefineXML(gomock.Any()).Return(mockDomain, nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.(*LibvirtDomainManager).expandDisks(vmi, domainSpec, mockDomain)).To(Succeed())
+			})
+
+			table.DescribeTable("should find the partition of a guest filesystem", func(name string, dev string, expectedDisk string, expectedPartition int) {
+				fs := &agentpoller.Filesystem{Name: name}
+				if dev != "" {
+					fs.Disks = []agentpoller.FilesystemDisk{{Dev: dev}}
+				}
+				diskName, partition := splitPartitionName(fs)
+				Expect(diskName).To(Equal(expectedDisk))
+				Expect(partition).To(Equal(expectedPartition))
+			},
+				table.Entry("on a virtio disk", "vda2", "", "vda", 2),
+				table.Entry("on a scsi disk", "sdb1", "", "sdb", 1),
+				table.Entry("on a disk whose name ends with a digit", "nvme0n1p3", "", "nvme0n1", 3),
+				table.Entry("on the whole disk", "vdb", "", "vdb", 0),
+				table.Entry("reported with the device of the disk", "nvme0n1p3", "/dev/nvme0n1", "nvme0n1", 3),
+				table.Entry("on the whole disk reported with the device of the disk", "nvme0n1", "/dev/nvme0n1", "nvme0n1", 0),
+			)
+
+			It("should do nothing when the disk already has the capacity of its PVC", func() {
+				domainSpec.Metadata.KubeVirt.DiskSizes = []api.DiskSizeMetadata{{Name: "disk0", Size: 2 << 30}}
+				mockDomain.EXPECT().GetBlockInfo("vda", uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 2 << 30}, nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(domainXML(), nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.(*LibvirtDomainManager).expandDisks(vmi, domainSpec, mockDomain)).To(Succeed())
+			})
+		})
+		Context("on multipath status", func() {
+			var vmi *v1.VirtualMachineInstance
+			var domainSpec *api.DomainSpec
+
+			domainXML := func() string {
+				xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+				Expect(err).NotTo(HaveOccurred())
+				return string(xml)
+			}
+
+			BeforeEach(func() {
+				vmi = newVMI(testNamespace, testVmName)
+				domainSpec = api.NewMinimalDomainSpec(testDomainName)
+				domainSpec.Devices.Disks = []api.Disk{{
+					Type:   "block",
+					Alias:  api.NewUserDefinedAlias("disk0"),
+					Source: api.DiskSource{Dev: "/dev/disk0"},
+					Target: api.DiskTarget{Device: "vda"},
+				}}
+				getMultipathStatus = func(devicePath string) (*multipath.Status, error) {
+					Expect(devicePath).To(Equal("/dev/disk0"))
+					return &multipath.Status{Map: "mpatha", Paths: []string{"sdb", "sdc"}, FailedPaths: []string{"sdc"}}, nil
+				}
+				mockDomain.EXPECT().Free().AnyTimes()
+			})
+
+			AfterEach(func() {
+				getMultipathStatus = multipath.GetStatus
+			})
+
+			It("should record the health of the paths of a multipath disk", func() {
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).AnyTimes().Return(domainXML(), nil)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE).Return(domainXML(), nil)
+				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+				mockConn.EXPECT().DomainDefineXML(gomock.Any()).DoAndReturn(func(xml string) (cli.VirDomain, error) {
+					Expect(xml).To(ContainSubstring(`<multipath name="disk0" paths="2" activePaths="1">`))
+					Expect(xml).To(ContainSubstring(`<failedPath>sdc</failedPath>`))
+					return mockDomain, nil
+				})
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.(*LibvirtDomainManager).updateMultipathStatus(vmi, domainSpec, mockDomain)).To(Succeed())
+			})
+
+			It("should do nothing when the health of the paths did not change", func() {
+				domainSpec.Metadata.KubeVirt.Multipath = []api.MultipathMetadata{{Name: "disk0", Paths: 2, ActivePaths: 1, FailedPaths: []string{"sdc"}}}
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(domainXML(), nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.(*LibvirtDomainManager).updateMultipathStatus(vmi, domainSpec, mockDomain)).To(Succeed())
+			})
+
+			It("should ignore disks which are not backed by a multipath map", func() {
+				getMultipathStatus = func(devicePath string) (*multipath.Status, error) {
+					return nil, nil
+				}
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+
+				Expect(manager.(*LibvirtDomainManager).updateMultipathStatus(vmi, domainSpec, mockDomain)).To(Succeed())
+			})
+
+			It("should detect a multipath disk whose paths all failed", func() {
+				Expect(hasMultipathDiskWithoutActivePath(domainSpec.Devices.Disks)).To(BeFalse())
+				getMultipathStatus = func(devicePath string) (*multipath.Status, error) {
+					return &multipath.Status{Map: "mpatha", Paths: []string{"sdb"}, FailedPaths: []string{"sdb"}}, nil
+				}
+				Expect(hasMultipathDiskWithoutActivePath(domainSpec.Devices.Disks)).To(BeTrue())
+			})
+		})
 		It("should not try to pause a paused VirtualMachineInstance", func() {
 			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free()
@@ -1211,6 +1625,51 @@ var _ = Describe("Manager", func() {
 			copyDisks := getDiskTargetsForMigration(mockDomain, vmi)
 			Expect(copyDisks).Should(ConsistOf("vdb", "vdd"))
 		})
+		It("should copy the disks of volumes moved to other PVCs", func() {
+			var convertedDomain = `<domain type="kvm" xmlns:qemu="http://libvirt.org/schemas/domain/qemu/1.0">
+  <devices>
+    <disk device="disk" type="block">
+      <source dev="/dev/myvolume"></source>
+      <target bus="virtio" dev="vda"></target>
+      <driver cache="none" name="qemu" type="raw" iothread="1"></driver>
+      <alias name="ua-myvolume"></alias>
+    </disk>
+    <disk device="disk" type="block">
+      <source dev="/dev/sharedvolume"></source>
+      <target bus="virtio" dev="vdb"></target>
+      <driver cache="none" name="qemu" type="raw" iothread="2"></driver>
+      <alias name="ua-sharedvolume"></alias>
+    </disk>
+  </devices>
+</domain>`
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testblock",
+						},
+					},
+				},
+				{
+					Name: "sharedvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testshared",
+						},
+					},
+				},
+			}
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigratedVolumes: []v1.MigratedVolume{{VolumeName: "myvolume", DestinationClaimName: "destination"}},
+			}
+
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(convertedDomain), nil)
+
+			copyDisks := getDiskTargetsForMigration(mockDomain, vmi)
+			Expect(copyDisks).Should(ConsistOf("vda"))
+		})
 		AfterEach(func() {
 			ip.GetLoopbackAddress = funcPreviousValue
 		})
@@ -1470,9 +1929,63 @@ var _ = Describe("getAttachedDisks", func() {
 					},
 				},
 			}),
+		table.Entry("be empty with new having media inserted into a CD-ROM",
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom")}},
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom"), Source: api.DiskSource{File: "iso"}}},
+			[]api.Disk{}),
 	)
 })
 
+var _ = Describe("getChangedMedia", func() {
+	table.DescribeTable("should return the correct values", func(oldDisks, newDisks, expected []api.Disk) {
+		res := getChangedMedia(oldDisks, newDisks)
+		Expect(res).To(Equal(expected))
+	},
+		table.Entry("be empty with old and new being identical",
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom"), Source: api.DiskSource{File: "iso"}}},
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom"), Source: api.DiskSource{File: "iso"}}},
+			[]api.Disk{}),
+		table.Entry("be empty with a changed disk which is not a CD-ROM",
+			[]api.Disk{{Device: "disk", Alias: api.NewUserDefinedAlias("disk"), Source: api.DiskSource{File: "file"}}},
+			[]api.Disk{{Device: "disk", Alias: api.NewUserDefinedAlias("disk"), Source: api.DiskSource{File: "file2"}}},
+			[]api.Disk{}),
+		table.Entry("contain the CD-ROM with media inserted",
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom")}},
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom"), Source: api.DiskSource{Dev: "dev"}}},
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom"), Source: api.DiskSource{Dev: "dev"}}}),
+		table.Entry("contain the CD-ROM with media ejected",
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom"), Source: api.DiskSource{File: "iso"}}},
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom")}},
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom")}}),
+	)
+})
+
+var _ = Describe("getAttachedInterfaces", func() {
+	table.DescribeTable("should return the correct values", func(oldInterfaces, newInterfaces, expected []api.Interface) {
+		res := getAttachedInterfaces(oldInterfaces, newInterfaces)
+		Expect(res).To(Equal(expected))
+	},
+		table.Entry("be empty with old and new being identical",
+			[]api.Interface{{Alias: api.NewUserDefinedAlias("default")}},
+			[]api.Interface{{Alias: api.NewUserDefinedAlias("default")}},
+			[]api.Interface{}),
+		table.Entry("contain a new interface with new having a hotplugged interface compared to old",
+			[]api.Interface{{Alias: api.NewUserDefinedAlias("default")}},
+			[]api.Interface{{Alias: api.NewUserDefinedAlias("default")}, {Alias: api.NewUserDefinedAlias("blue")}},
+			[]api.Interface{{Alias: api.NewUserDefinedAlias("blue")}}),
+	)
+})
+
+var _ = Describe("getUnpluggedInterfaces", func() {
+	It("should return the domain interfaces which are absent from the VMI", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default"}, {Name: "blue", State: v1.InterfaceStateAbsent}}
+		domainInterfaces := []api.Interface{{Alias: api.NewUserDefinedAlias("default")}, {Alias: api.NewUserDefinedAlias("blue")}}
+
+		Expect(getUnpluggedInterfaces(vmi, domainInterfaces)).To(Equal([]api.Interface{{Alias: api.NewUserDefinedAlias("blue")}}))
+	})
+})
+
 var _ = Describe("getDetachedDisks", func() {
 	table.DescribeTable("should return the correct values", func(oldDisks, newDisks, expected []api.Disk) {
 		res := getDetachedDisks(oldDisks, newDisks)
@@ -1531,6 +2044,10 @@ var _ = Describe("getDetachedDisks", func() {
 					},
 				},
 			}),
+		table.Entry("be empty with new having the media of a CD-ROM ejected",
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom"), Source: api.DiskSource{File: "iso"}}},
+			[]api.Disk{{Device: "cdrom", Alias: api.NewUserDefinedAlias("cdrom")}},
+			[]api.Disk{}),
 	)
 })
 
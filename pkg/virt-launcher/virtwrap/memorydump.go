@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"fmt"
+	"path/filepath"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	libvirt "libvirt.org/libvirt-go"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// MemoryDumpVMI starts writing the guest memory to a file on the memory dump volume. The dump runs in the
+// background and pauses the vCPUs until it completes, its result is recorded in the domain metadata.
+func (l *LibvirtDomainManager) MemoryDumpVMI(vmi *v1.VirtualMachineInstance, options *v1.MemoryDumpOptions) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain for the memory dump failed.")
+		return err
+	}
+	defer dom.Free()
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+
+	memoryDumpMetadata := domainSpec.Metadata.KubeVirt.MemoryDump
+	if memoryDumpMetadata != nil && !memoryDumpMetadata.Completed && !memoryDumpMetadata.Failed {
+		return fmt.Errorf("memory dump to %s is still running", memoryDumpMetadata.FileName)
+	}
+
+	now := metav1.Now()
+	domainSpec.Metadata.KubeVirt.MemoryDump = &api.MemoryDumpMetadata{
+		FileName:       options.FileName,
+		StartTimestamp: &now,
+	}
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+
+	go func() {
+		to := filepath.Join(util.MemoryDumpDir, options.FileName)
+		err := l.dumpMemory(domName, to)
+		if err != nil {
+			logger.Reason(err).Errorf("Dumping the memory to %s failed.", to)
+		} else {
+			logger.Infof("Dumped the memory to %s", to)
+		}
+		l.setMemoryDumpResult(vmi, err)
+	}()
+
+	return nil
+}
+
+func (l *LibvirtDomainManager) dumpMemory(domName string, to string) error {
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+	return dom.CoreDumpWithFormat(to, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+}
+
+func (l *LibvirtDomainManager) setMemoryDumpResult(vmi *v1.VirtualMachineInstance, dumpErr error) {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	dom, err := l.virConn.LookupDomainByName(api.VMINamespaceKeyFunc(vmi))
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain to record the memory dump result failed.")
+		return
+	}
+	defer dom.Free()
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec to record the memory dump result failed.")
+		return
+	}
+
+	memoryDumpMetadata := domainSpec.Metadata.KubeVirt.MemoryDump
+	if memoryDumpMetadata == nil {
+		memoryDumpMetadata = &api.MemoryDumpMetadata{}
+		domainSpec.Metadata.KubeVirt.MemoryDump = memoryDumpMetadata
+	}
+	now := metav1.Now()
+	memoryDumpMetadata.EndTimestamp = &now
+	if dumpErr != nil {
+		memoryDumpMetadata.Failed = true
+		memoryDumpMetadata.FailureReason = dumpErr.Error()
+	} else {
+		memoryDumpMetadata.Completed = true
+	}
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		logger.Reason(err).Error("Recording the memory dump result failed.")
+		return
+	}
+	defer d.Free()
+}
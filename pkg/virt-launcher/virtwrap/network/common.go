@@ -27,6 +27,7 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/coreos/go-iptables/iptables"
 	lmf "github.com/subgraph/libmacouflage"
@@ -43,6 +44,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-handler/selinux"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/dhcp"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/dhcpv6"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/ndp"
 )
 
 const (
@@ -113,6 +115,8 @@ type NetworkHandler interface {
 	NftablesNewChain(proto iptables.Protocol, table, chain string) error
 	NftablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error
 	NftablesLoad(proto iptables.Protocol) error
+	NftablesApply(ruleset string) error
+	HasConntrackBridge() bool
 	GetNFTIPString(proto iptables.Protocol) string
 	CreateTapDevice(tapName string, queueNumber uint32, launcherPID int, mtu int, tapOwner string) error
 	BindTapDeviceToBridge(tapName string, bridgeName string) error
@@ -261,6 +265,25 @@ func (h *NetworkUtilsHandler) NftablesAppendRule(proto iptables.Protocol, table,
 	return nil
 }
 
+// NftablesApply loads a ruleset, in the syntax of the nft scripts, in one transaction
+func (h *NetworkUtilsHandler) NftablesApply(ruleset string) error {
+	cmd := exec.Command("nft", "-f", "-")
+	cmd.Stdin = strings.NewReader(ruleset)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to apply the nftables ruleset: %s", string(output))
+	}
+
+	return nil
+}
+
+// HasConntrackBridge returns whether the nf_conntrack_bridge module is loaded, without it the tables of the
+// bridge family see no connection state
+func (h *NetworkUtilsHandler) HasConntrackBridge() bool {
+	_, err := os.Stat("/sys/module/nf_conntrack_bridge")
+	return err == nil
+}
+
 func (h *NetworkUtilsHandler) GetNFTIPString(proto iptables.Protocol) string {
 	if proto == iptables.ProtocolIPv6 {
 		return "ip6"
@@ -397,6 +420,11 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *VIF, serverAddr net.IP, bridgeInter
 	}()
 
 	if nic.IPv6.IPNet != nil {
+		// the router advertisements announce the same MTU as the DHCP server
+		mtu := nic.Mtu
+		if dhcpOptions != nil && dhcpOptions.MTU != nil {
+			mtu = *dhcpOptions.MTU
+		}
 		go func() {
 			if err = DHCPv6Server(
 				nic.IPv6.IP,
@@ -406,6 +434,16 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *VIF, serverAddr net.IP, bridgeInter
 				panic(err)
 			}
 		}()
+		go func() {
+			if err = RouterAdvertiser(
+				bridgeInterfaceName,
+				nic.IPv6.IPNet,
+				mtu,
+			); err != nil {
+				log.Log.Reason(err).Error("failed to run the router advertiser")
+				panic(err)
+			}
+		}()
 	}
 
 	return nil
@@ -487,6 +525,7 @@ func (h *NetworkUtilsHandler) DisableTXOffloadChecksum(ifaceName string) error {
 // Allow mocking for tests
 var DHCPServer = dhcp.SingleClientDHCPServer
 var DHCPv6Server = dhcpv6.SingleClientDHCPv6Server
+var RouterAdvertiser = ndp.SingleClientRouterAdvertiser
 
 func initHandler() {
 	if Handler == nil {
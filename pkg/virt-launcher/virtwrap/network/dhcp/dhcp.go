@@ -41,6 +41,7 @@ const (
 	errorSearchDomainNotValid = "Search domain is not valid"
 	errorSearchDomainTooLong  = "Search domains length exceeded allowable size"
 	errorNTPConfiguration     = "Could not parse NTP server as IPv4 address: %s"
+	errorRouteConfiguration   = "Could not parse static route to %s via %s"
 )
 
 // simple domain validation regex. Put it here to avoid compiling each time.
@@ -163,6 +164,22 @@ func prepareDHCPOptions(
 			dhcpOptions[dhcp.OptionNetworkTimeProtocolServers] = bytes.Join(ntpServers, nil)
 		}
 
+		if customDHCPOptions.MTU != nil {
+			log.Log.Infof("Setting dhcp option MTU to %d", *customDHCPOptions.MTU)
+			binary.BigEndian.PutUint16(mtuArray, *customDHCPOptions.MTU)
+			dhcpOptions[dhcp.OptionInterfaceMTU] = mtuArray
+		}
+
+		if len(customDHCPOptions.Routes) > 0 {
+			log.Log.Infof("Setting dhcp option static routes to %v", customDHCPOptions.Routes)
+
+			staticRoutes, err := appendStaticRoutes(routes, routerIP, customDHCPOptions.Routes)
+			if err != nil {
+				return nil, err
+			}
+			dhcpOptions[dhcp.OptionClasslessRouteFormat] = formClasslessRoutes(&staticRoutes)
+		}
+
 		if customDHCPOptions.PrivateOptions != nil {
 			for _, privateOptions := range customDHCPOptions.PrivateOptions {
 				if privateOptions.Option >= 224 && privateOptions.Option <= 254 {
@@ -231,6 +248,35 @@ func sortRoutes(routes []netlink.Route) []netlink.Route {
 	return sortedRoutes
 }
 
+// appendStaticRoutes adds the user defined static routes to the routes of the pod interface.
+// Clients ignore the router option when classless routes are offered (RFC 3442), so a default
+// route through the router is kept when the pod interface has none.
+func appendStaticRoutes(routes *[]netlink.Route, routerIP net.IP, staticRoutes []v1.DHCPRoute) ([]netlink.Route, error) {
+	var allRoutes []netlink.Route
+	hasDefaultRoute := false
+	if routes != nil {
+		for _, route := range *routes {
+			if route.Dst == nil {
+				hasDefaultRoute = true
+			}
+			allRoutes = append(allRoutes, route)
+		}
+	}
+	if !hasDefaultRoute && routerIP.To4() != nil {
+		allRoutes = append(allRoutes, netlink.Route{Gw: routerIP})
+	}
+
+	for _, staticRoute := range staticRoutes {
+		_, dst, err := net.ParseCIDR(staticRoute.Destination)
+		gateway := net.ParseIP(staticRoute.Gateway).To4()
+		if err != nil || dst.IP.To4() == nil || gateway == nil {
+			return nil, fmt.Errorf(errorRouteConfiguration, staticRoute.Destination, staticRoute.Gateway)
+		}
+		allRoutes = append(allRoutes, netlink.Route{Dst: dst, Gw: gateway})
+	}
+	return allRoutes, nil
+}
+
 func formClasslessRoutes(routes *[]netlink.Route) (formattedRoutes []byte) {
 	// See RFC4332 for additional information
 	// (https://tools.ietf.org/html/rfc3442)
@@ -244,5 +244,62 @@ var _ = Describe("DHCP", func() {
 			}))
 			Expect(options[240]).To(Equal([]byte("private.options.kubevirt.io")))
 		})
+
+		It("should override the MTU", func() {
+			ip := net.ParseIP("192.168.2.1")
+			mtu := uint16(9000)
+			dhcpOptions := &v1.DHCPOptions{MTU: &mtu}
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, 1500, "myhost", dhcpOptions)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionInterfaceMTU]).To(Equal([]byte{0x23, 0x28}))
+		})
+
+		It("should add static routes to the pod routes", func() {
+			ip := net.ParseIP("192.168.2.1")
+			_, podNet, _ := net.ParseCIDR("192.168.2.0/24")
+			routes := []netlink.Route{
+				{Dst: podNet},
+				{Gw: ip},
+			}
+			dhcpOptions := &v1.DHCPOptions{
+				Routes: []v1.DHCPRoute{{Destination: "10.0.0.0/8", Gateway: "192.168.2.5"}},
+			}
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, &routes, nil, 1500, "myhost", dhcpOptions)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionClasslessRouteFormat]).To(Equal([]byte{
+				24, 192, 168, 2, 0, 0, 0, 0,
+				8, 10, 192, 168, 2, 5,
+				0, 192, 168, 2, 1,
+			}))
+		})
+
+		It("should keep a default route through the router when the pod has no routes", func() {
+			ip := net.ParseIP("192.168.2.1")
+			dhcpOptions := &v1.DHCPOptions{
+				Routes: []v1.DHCPRoute{{Destination: "10.0.0.0/8", Gateway: "192.168.2.5"}},
+			}
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, 1500, "myhost", dhcpOptions)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionClasslessRouteFormat]).To(Equal([]byte{
+				8, 10, 192, 168, 2, 5,
+				0, 192, 168, 2, 1,
+			}))
+		})
+
+		It("should reject invalid static routes", func() {
+			ip := net.ParseIP("192.168.2.1")
+			dhcpOptions := &v1.DHCPOptions{
+				Routes: []v1.DHCPRoute{{Destination: "fd10::/64", Gateway: "192.168.2.5"}},
+			}
+
+			_, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, 1500, "myhost", dhcpOptions)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
@@ -0,0 +1,153 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	firewallIngressChain = "ingress"
+	firewallEgressChain  = "egress"
+)
+
+// applyInterfaceFirewall filters the traffic of the tap device which connects the pod interface to the guest
+func applyInterfaceFirewall(firewall *v1.InterfaceFirewall, podInterfaceName string) error {
+	// The replies of the connections of the guest are only let through by the established connection rule, which
+	// never matches in the bridge family when the connections are not tracked there
+	if firewall.DefaultAction == v1.FirewallActionDeny && !Handler.HasConntrackBridge() {
+		return fmt.Errorf("the firewall default action %s needs the nf_conntrack_bridge kernel module, which is not loaded on the node", v1.FirewallActionDeny)
+	}
+	ruleset, err := generateFirewallRuleset(firewall, generateTapDeviceName(podInterfaceName))
+	if err != nil {
+		return err
+	}
+	return Handler.NftablesApply(ruleset)
+}
+
+// generateFirewallRuleset renders the nftables ruleset which filters the traffic of the tap device of an interface.
+// The tables of the bridge family see the traffic of the tap device both when it is bridged to the pod interface,
+// with the bridge binding, and when it is routed through the bridge, with the masquerade binding.
+func generateFirewallRuleset(firewall *v1.InterfaceFirewall, tapDeviceName string) (string, error) {
+	ingressRules := []string{
+		"ct state established,related accept",
+		"udp dport { 68, 546 } accept",
+		"icmpv6 type { nd-router-advert, nd-neighbor-solicit, nd-neighbor-advert } accept",
+	}
+	egressRules := []string{
+		"ct state established,related accept",
+		"udp dport { 67, 547 } accept",
+		"icmpv6 type { nd-router-solicit, nd-neighbor-solicit, nd-neighbor-advert } accept",
+	}
+	for _, rule := range firewall.Rules {
+		if rule.Direction != v1.FirewallDirectionEgress {
+			ingressRule, err := renderFirewallRule(rule, "saddr")
+			if err != nil {
+				return "", err
+			}
+			ingressRules = append(ingressRules, ingressRule)
+		}
+		if rule.Direction != v1.FirewallDirectionIngress {
+			egressRule, err := renderFirewallRule(rule, "daddr")
+			if err != nil {
+				return "", err
+			}
+			egressRules = append(egressRules, egressRule)
+		}
+	}
+	if firewall.DefaultAction == v1.FirewallActionDeny {
+		ingressRules = append(ingressRules, "meta protocol { ip, ip6 } drop")
+		egressRules = append(egressRules, "meta protocol { ip, ip6 } drop")
+	}
+
+	table := "kubevirt_firewall_" + tapDeviceName
+	device := fmt.Sprintf("%q", tapDeviceName)
+	var ruleset strings.Builder
+	// the table is replaced as a whole, so the ruleset can be applied again
+	fmt.Fprintf(&ruleset, "add table bridge %s\n", table)
+	fmt.Fprintf(&ruleset, "delete table bridge %s\n", table)
+	fmt.Fprintf(&ruleset, "table bridge %s {\n", table)
+	writeFirewallChain(&ruleset, firewallIngressChain, "", ingressRules)
+	writeFirewallChain(&ruleset, firewallEgressChain, "", egressRules)
+	writeFirewallChain(&ruleset, "forward", "type filter hook forward priority 0; policy accept;", []string{
+		"oifname " + device + " jump " + firewallIngressChain,
+		"iifname " + device + " jump " + firewallEgressChain,
+	})
+	writeFirewallChain(&ruleset, "input", "type filter hook input priority 0; policy accept;", []string{
+		"iifname " + device + " jump " + firewallEgressChain,
+	})
+	writeFirewallChain(&ruleset, "output", "type filter hook output priority 0; policy accept;", []string{
+		"oifname " + device + " jump " + firewallIngressChain,
+	})
+	ruleset.WriteString("}\n")
+	return ruleset.String(), nil
+}
+
+func writeFirewallChain(ruleset *strings.Builder, name, hook string, rules []string) {
+	fmt.Fprintf(ruleset, "\tchain %s {\n", name)
+	if hook != "" {
+		fmt.Fprintf(ruleset, "\t\t%s\n", hook)
+	}
+	for _, rule := range rules {
+		fmt.Fprintf(ruleset, "\t\t%s\n", rule)
+	}
+	ruleset.WriteString("\t}\n")
+}
+
+// renderFirewallRule renders a rule which matches the remote peer with the address selector, saddr or daddr
+func renderFirewallRule(rule v1.FirewallRule, addressSelector string) (string, error) {
+	var matches []string
+	if rule.CIDR != "" {
+		_, network, err := net.ParseCIDR(rule.CIDR)
+		if err != nil {
+			return "", fmt.Errorf("invalid firewall rule CIDR %s: %v", rule.CIDR, err)
+		}
+		family := "ip"
+		if network.IP.To4() == nil {
+			family = "ip6"
+		}
+		matches = append(matches, fmt.Sprintf("%s %s %s", family, addressSelector, network.String()))
+	}
+
+	switch rule.Protocol {
+	case "":
+		if len(matches) == 0 {
+			// leave ARP alone
+			matches = append(matches, "meta protocol { ip, ip6 }")
+		}
+	case "ICMP":
+		matches = append(matches, "meta l4proto { icmp, ipv6-icmp }")
+	default:
+		matches = append(matches, "meta l4proto "+strings.ToLower(rule.Protocol))
+		if rule.Port != 0 {
+			matches = append(matches, fmt.Sprintf("th dport %d", rule.Port))
+		}
+	}
+
+	verdict := "accept"
+	if rule.Action == v1.FirewallActionDeny {
+		verdict = "drop"
+	}
+	return strings.Join(append(matches, verdict), " "), nil
+}
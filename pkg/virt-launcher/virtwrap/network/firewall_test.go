@@ -0,0 +1,181 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Interface firewall", func() {
+	table.DescribeTable("should render a rule", func(rule v1.FirewallRule, addressSelector, expectedRule string) {
+		renderedRule, err := renderFirewallRule(rule, addressSelector)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(renderedRule).To(Equal(expectedRule))
+	},
+		table.Entry("matching all the IP traffic",
+			v1.FirewallRule{Action: v1.FirewallActionDeny}, "saddr",
+			"meta protocol { ip, ip6 } drop"),
+		table.Entry("matching an IPv4 network",
+			v1.FirewallRule{Action: v1.FirewallActionAllow, CIDR: "10.0.0.1/8"}, "saddr",
+			"ip saddr 10.0.0.0/8 accept"),
+		table.Entry("matching an IPv6 network",
+			v1.FirewallRule{Action: v1.FirewallActionAllow, CIDR: "fd10::/64"}, "daddr",
+			"ip6 daddr fd10::/64 accept"),
+		table.Entry("matching ICMP and ICMPv6",
+			v1.FirewallRule{Action: v1.FirewallActionDeny, Protocol: "ICMP"}, "saddr",
+			"meta l4proto { icmp, ipv6-icmp } drop"),
+		table.Entry("matching a network, a protocol and a port",
+			v1.FirewallRule{Action: v1.FirewallActionAllow, CIDR: "192.168.1.0/24", Protocol: "TCP", Port: 22}, "saddr",
+			"ip saddr 192.168.1.0/24 meta l4proto tcp th dport 22 accept"),
+	)
+
+	It("should fail to render a rule with an invalid CIDR", func() {
+		_, err := renderFirewallRule(v1.FirewallRule{Action: v1.FirewallActionAllow, CIDR: "10.0.0.1"}, "saddr")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should filter both directions of the tap device", func() {
+		firewall := &v1.InterfaceFirewall{
+			Rules: []v1.FirewallRule{
+				{Action: v1.FirewallActionAllow, Direction: v1.FirewallDirectionIngress, Protocol: "TCP", Port: 80},
+				{Action: v1.FirewallActionAllow, Direction: v1.FirewallDirectionEgress, CIDR: "10.0.0.0/8"},
+			},
+			DefaultAction: v1.FirewallActionDeny,
+		}
+
+		ruleset, err := generateFirewallRuleset(firewall, "tap1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleset).To(Equal(`add table bridge kubevirt_firewall_tap1
+delete table bridge kubevirt_firewall_tap1
+table bridge kubevirt_firewall_tap1 {
+	chain ingress {
+		ct state established,related accept
+		udp dport { 68, 546 } accept
+		icmpv6 type { nd-router-advert, nd-neighbor-solicit, nd-neighbor-advert } accept
+		meta l4proto tcp th dport 80 accept
+		meta protocol { ip, ip6 } drop
+	}
+	chain egress {
+		ct state established,related accept
+		udp dport { 67, 547 } accept
+		icmpv6 type { nd-router-solicit, nd-neighbor-solicit, nd-neighbor-advert } accept
+		ip daddr 10.0.0.0/8 accept
+		meta protocol { ip, ip6 } drop
+	}
+	chain forward {
+		type filter hook forward priority 0; policy accept;
+		oifname "tap1" jump ingress
+		iifname "tap1" jump egress
+	}
+	chain input {
+		type filter hook input priority 0; policy accept;
+		iifname "tap1" jump egress
+	}
+	chain output {
+		type filter hook output priority 0; policy accept;
+		oifname "tap1" jump ingress
+	}
+}
+`))
+	})
+
+	It("should only let the established connections, DHCP and neighbor discovery of a bridge binding interface pass by default", func() {
+		firewall := &v1.InterfaceFirewall{DefaultAction: v1.FirewallActionDeny}
+
+		ruleset, err := generateFirewallRuleset(firewall, generateTapDeviceName("eth0"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleset).To(Equal(`add table bridge kubevirt_firewall_tap0
+delete table bridge kubevirt_firewall_tap0
+table bridge kubevirt_firewall_tap0 {
+	chain ingress {
+		ct state established,related accept
+		udp dport { 68, 546 } accept
+		icmpv6 type { nd-router-advert, nd-neighbor-solicit, nd-neighbor-advert } accept
+		meta protocol { ip, ip6 } drop
+	}
+	chain egress {
+		ct state established,related accept
+		udp dport { 67, 547 } accept
+		icmpv6 type { nd-router-solicit, nd-neighbor-solicit, nd-neighbor-advert } accept
+		meta protocol { ip, ip6 } drop
+	}
+	chain forward {
+		type filter hook forward priority 0; policy accept;
+		oifname "tap0" jump ingress
+		iifname "tap0" jump egress
+	}
+	chain input {
+		type filter hook input priority 0; policy accept;
+		iifname "tap0" jump egress
+	}
+	chain output {
+		type filter hook output priority 0; policy accept;
+		oifname "tap0" jump ingress
+	}
+}
+`))
+	})
+
+	Context("applying the ruleset", func() {
+		var ctrl *gomock.Controller
+		var mockNetwork *MockNetworkHandler
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			mockNetwork = NewMockNetworkHandler(ctrl)
+			Handler = mockNetwork
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should apply the ruleset of the tap device of the pod interface", func() {
+			firewall := &v1.InterfaceFirewall{DefaultAction: v1.FirewallActionDeny}
+			expectedRuleset, err := generateFirewallRuleset(firewall, "tap1")
+			Expect(err).ToNot(HaveOccurred())
+			mockNetwork.EXPECT().HasConntrackBridge().Return(true)
+			mockNetwork.EXPECT().NftablesApply(expectedRuleset).Return(nil)
+
+			Expect(applyInterfaceFirewall(firewall, "net1")).To(Succeed())
+		})
+
+		It("should fail to deny by default without nf_conntrack_bridge", func() {
+			mockNetwork.EXPECT().HasConntrackBridge().Return(false)
+
+			err := applyInterfaceFirewall(&v1.InterfaceFirewall{DefaultAction: v1.FirewallActionDeny}, "net1")
+			Expect(err).To(MatchError(ContainSubstring("nf_conntrack_bridge")))
+		})
+
+		It("should not need nf_conntrack_bridge to allow by default", func() {
+			firewall := &v1.InterfaceFirewall{DefaultAction: v1.FirewallActionAllow}
+			expectedRuleset, err := generateFirewallRuleset(firewall, "tap1")
+			Expect(err).ToNot(HaveOccurred())
+			mockNetwork.EXPECT().NftablesApply(expectedRuleset).Return(nil)
+
+			Expect(applyInterfaceFirewall(firewall, "net1")).To(Succeed())
+		})
+	})
+})
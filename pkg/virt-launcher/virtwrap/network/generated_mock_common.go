@@ -335,6 +335,26 @@ func (_mr *_MockNetworkHandlerRecorder) NftablesLoad(arg0 interface{}) *gomock.C
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesLoad", arg0)
 }
 
+func (_m *MockNetworkHandler) NftablesApply(ruleset string) error {
+	ret := _m.ctrl.Call(_m, "NftablesApply", ruleset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) NftablesApply(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesApply", arg0)
+}
+
+func (_m *MockNetworkHandler) HasConntrackBridge() bool {
+	ret := _m.ctrl.Call(_m, "HasConntrackBridge")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) HasConntrackBridge() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "HasConntrackBridge")
+}
+
 func (_m *MockNetworkHandler) GetNFTIPString(proto iptables.Protocol) string {
 	ret := _m.ctrl.Call(_m, "GetNFTIPString", proto)
 	ret0, _ := ret[0].(string)
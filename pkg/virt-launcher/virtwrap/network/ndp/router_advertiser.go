@@ -0,0 +1,203 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package ndp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/net/ipv6"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	routerAdvertisementPeriod = 5 * time.Minute
+	// routerLifetime is three times the advertisement period, as recommended by RFC 4861
+	routerLifetime = 3 * routerAdvertisementPeriod
+	// infiniteLifetime marks the advertised prefix as valid for as long as the router is up
+	infiniteLifetime = 0xffffffff
+
+	managedAddressConfigurationFlag    = 0x80
+	onLinkFlag                         = 0x80
+	autonomousAddressConfigurationFlag = 0x40
+
+	// SLAACPrefixLength is the only prefix length for which Ethernet hosts autoconfigure their addresses (RFC 4862)
+	SLAACPrefixLength = 64
+
+	optionTypeSourceLinkLayerAddress = 1
+	optionTypePrefixInformation      = 3
+	optionTypeMTU                    = 5
+
+	routerAdvertisementHeaderLength = 16
+	prefixInformationOptionLength   = 32
+	sourceLinkLayerOptionLength     = 8
+	mtuOptionLength                 = 8
+)
+
+// SingleClientRouterAdvertiser advertises the server interface as the default IPv6 router of the guest.
+// The advertisement sets the managed address configuration flag, so the guest gets its address through
+// DHCPv6. A /64 prefix is announced for autonomous address configuration too, so that the guests which
+// only implement SLAAC configure an address of the prefix, other prefixes are announced as on-link only.
+// It is sent periodically and whenever the guest solicits it.
+func SingleClientRouterAdvertiser(serverIfaceName string, prefix *net.IPNet, mtu uint16) error {
+	log.Log.Info("Starting SingleClientRouterAdvertiser")
+
+	iface, err := net.InterfaceByName(serverIfaceName)
+	if err != nil {
+		return fmt.Errorf("couldn't create router advertiser, couldn't get the server interface: %v", err)
+	}
+
+	routerAdvertisement := prepareRouterAdvertisement(prefix, iface.HardwareAddr, uint32(mtu))
+
+	conn, err := newConnection(iface)
+	if err != nil {
+		return fmt.Errorf("couldn't create router advertiser: %v", err)
+	}
+	defer conn.Close()
+
+	allNodes := &net.IPAddr{IP: net.IPv6linklocalallnodes, Zone: iface.Name}
+	controlMessage := &ipv6.ControlMessage{IfIndex: iface.Index, HopLimit: 255}
+	buf := make([]byte, iface.MTU)
+	for {
+		if _, err := conn.WriteTo(routerAdvertisement, controlMessage, allNodes); err != nil {
+			return fmt.Errorf("failed to send router advertisement: %v", err)
+		}
+
+		if err := waitForRouterSolicitation(conn, iface.Index, buf); err != nil {
+			return err
+		}
+	}
+}
+
+// waitForRouterSolicitation returns when a router solicitation is received on the interface,
+// or when the next periodic advertisement is due.
+func waitForRouterSolicitation(conn *ipv6.PacketConn, ifIndex int, buf []byte) error {
+	if err := conn.SetReadDeadline(time.Now().Add(routerAdvertisementPeriod)); err != nil {
+		return fmt.Errorf("failed to set the router advertisement period: %v", err)
+	}
+	for {
+		_, cm, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				return nil
+			}
+			return fmt.Errorf("failed to receive router solicitation: %v", err)
+		}
+		if cm == nil || cm.IfIndex == ifIndex {
+			log.Log.V(4).Info("router solicitation received")
+			return nil
+		}
+	}
+}
+
+func newConnection(iface *net.Interface) (*ipv6.PacketConn, error) {
+	icmpConn, err := net.ListenPacket("ip6:ipv6-icmp", "::")
+	if err != nil {
+		return nil, err
+	}
+	conn := ipv6.NewPacketConn(icmpConn)
+
+	var filter ipv6.ICMPFilter
+	filter.SetAll(true)
+	filter.Accept(ipv6.ICMPTypeRouterSolicitation)
+	if err := conn.SetICMPFilter(&filter); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.SetControlMessage(ipv6.FlagInterface, true); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	allRouters := &net.IPAddr{IP: net.IPv6linklocalallrouters}
+	if err := conn.JoinGroup(iface, allRouters); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// prepareRouterAdvertisement builds the ICMPv6 router advertisement message (RFC 4861, section 4.2).
+// The checksum is left empty, the kernel computes it for ICMPv6 raw sockets.
+func prepareRouterAdvertisement(prefix *net.IPNet, serverMac net.HardwareAddr, mtu uint32) []byte {
+	msg := make([]byte, routerAdvertisementHeaderLength)
+	msg[0] = byte(ipv6.ICMPTypeRouterAdvertisement)
+	msg[5] = managedAddressConfigurationFlag
+	binary.BigEndian.PutUint16(msg[6:8], uint16(routerLifetime.Seconds()))
+
+	msg = append(msg, prefixInformationOption(prefix)...)
+	if len(serverMac) == 6 {
+		msg = append(msg, sourceLinkLayerOption(serverMac)...)
+	}
+	if mtu > 0 {
+		msg = append(msg, mtuOption(mtu)...)
+	}
+	return msg
+}
+
+func prefixInformationOption(prefix *net.IPNet) []byte {
+	prefixLength, _ := prefix.Mask.Size()
+	option := make([]byte, prefixInformationOptionLength)
+	option[0] = optionTypePrefixInformation
+	option[1] = prefixInformationOptionLength / 8
+	option[2] = byte(prefixLength)
+	option[3] = onLinkFlag
+	if prefixLength == SLAACPrefixLength {
+		option[3] |= autonomousAddressConfigurationFlag
+	}
+	binary.BigEndian.PutUint32(option[4:8], infiniteLifetime)
+	binary.BigEndian.PutUint32(option[8:12], infiniteLifetime)
+	copy(option[16:], prefix.IP.Mask(prefix.Mask).To16())
+	return option
+}
+
+func sourceLinkLayerOption(mac net.HardwareAddr) []byte {
+	option := make([]byte, sourceLinkLayerOptionLength)
+	option[0] = optionTypeSourceLinkLayerAddress
+	option[1] = sourceLinkLayerOptionLength / 8
+	copy(option[2:], mac)
+	return option
+}
+
+func mtuOption(mtu uint32) []byte {
+	option := make([]byte, mtuOptionLength)
+	option[0] = optionTypeMTU
+	option[1] = mtuOptionLength / 8
+	binary.BigEndian.PutUint32(option[4:], mtu)
+	return option
+}
+
+// EUI64Address returns the address of the /64 prefix which a host with the MAC address autoconfigures
+// with a modified EUI-64 interface identifier (RFC 4291, appendix A).
+func EUI64Address(prefix net.IP, mac net.HardwareAddr) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, prefix.To16()[:8])
+	ip[8] = mac[0] ^ 0x02
+	ip[9] = mac[1]
+	ip[10] = mac[2]
+	ip[11] = 0xff
+	ip[12] = 0xfe
+	ip[13] = mac[3]
+	ip[14] = mac[4]
+	ip[15] = mac[5]
+	return ip
+}
@@ -0,0 +1,88 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package ndp
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Router advertiser", func() {
+	Context("prepareRouterAdvertisement", func() {
+		var prefix *net.IPNet
+		var serverMac net.HardwareAddr
+
+		BeforeEach(func() {
+			var err error
+			_, prefix, err = net.ParseCIDR("fd10:0:2::/120")
+			Expect(err).ToNot(HaveOccurred())
+			serverMac, err = net.ParseMAC("12:34:56:78:9a:bc")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should request address configuration through DHCPv6", func() {
+			msg := prepareRouterAdvertisement(prefix, serverMac, 1480)
+			Expect(msg[0]).To(Equal(byte(134)), "should be a router advertisement")
+			Expect(msg[5]).To(Equal(byte(managedAddressConfigurationFlag)))
+			Expect(msg[6:8]).To(Equal([]byte{0x03, 0x84}), "should advertise a router lifetime of 900 seconds")
+		})
+
+		It("should advertise the prefix as on-link only", func() {
+			msg := prepareRouterAdvertisement(prefix, serverMac, 1480)
+			option := msg[routerAdvertisementHeaderLength : routerAdvertisementHeaderLength+prefixInformationOptionLength]
+			Expect(option[0]).To(Equal(byte(optionTypePrefixInformation)))
+			Expect(option[1]).To(Equal(byte(4)))
+			Expect(option[2]).To(Equal(byte(120)))
+			Expect(option[3]).To(Equal(byte(onLinkFlag)))
+			Expect(net.IP(option[16:])).To(Equal(net.ParseIP("fd10:0:2::")))
+		})
+
+		It("should advertise the server link layer address and the MTU", func() {
+			msg := prepareRouterAdvertisement(prefix, serverMac, 1480)
+			Expect(msg).To(HaveLen(routerAdvertisementHeaderLength + prefixInformationOptionLength + sourceLinkLayerOptionLength + mtuOptionLength))
+			options := msg[routerAdvertisementHeaderLength+prefixInformationOptionLength:]
+			Expect(options[:sourceLinkLayerOptionLength]).To(Equal([]byte{optionTypeSourceLinkLayerAddress, 1, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc}))
+			Expect(options[sourceLinkLayerOptionLength:]).To(Equal([]byte{optionTypeMTU, 1, 0, 0, 0, 0, 0x05, 0xc8}))
+		})
+
+		It("should omit the MTU option when the MTU is unknown", func() {
+			msg := prepareRouterAdvertisement(prefix, serverMac, 0)
+			Expect(msg).To(HaveLen(routerAdvertisementHeaderLength + prefixInformationOptionLength + sourceLinkLayerOptionLength))
+		})
+
+		It("should advertise a /64 prefix for autonomous address configuration", func() {
+			_, slaacPrefix, err := net.ParseCIDR("fd10:0:2::/64")
+			Expect(err).ToNot(HaveOccurred())
+			msg := prepareRouterAdvertisement(slaacPrefix, serverMac, 1480)
+			Expect(msg[5]).To(Equal(byte(managedAddressConfigurationFlag)), "should still offer DHCPv6")
+			option := msg[routerAdvertisementHeaderLength : routerAdvertisementHeaderLength+prefixInformationOptionLength]
+			Expect(option[2]).To(Equal(byte(64)))
+			Expect(option[3]).To(Equal(byte(onLinkFlag | autonomousAddressConfigurationFlag)))
+		})
+	})
+
+	It("should compute the modified EUI-64 address of a MAC address", func() {
+		mac, err := net.ParseMAC("12:34:56:78:9a:bc")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(EUI64Address(net.ParseIP("fd10:0:2::"), mac)).To(Equal(net.ParseIP("fd10:0:2::1034:56ff:fe78:9abc")))
+	})
+})
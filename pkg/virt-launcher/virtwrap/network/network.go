@@ -26,9 +26,14 @@ package network
 */
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache"
 )
@@ -73,6 +78,9 @@ func SetupPodNetworkPhase1(vmi *v1.VirtualMachineInstance, pid int, cacheFactory
 	secondaryNets := filterSecondaryMultusNetworks(vmi.Spec.Networks)
 	podInterfaceNames := mapPodInterfaceNameByNetwork(primaryNet, secondaryNets)
 	for i, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.State == v1.InterfaceStateAbsent {
+			continue
+		}
 		network, ok := networks[iface.Name]
 		if !ok {
 			return fmt.Errorf("failed to find a network %s", iface.Name)
@@ -88,11 +96,31 @@ func SetupPodNetworkPhase1(vmi *v1.VirtualMachineInstance, pid int, cacheFactory
 }
 
 func SetupPodNetworkPhase2(vmi *v1.VirtualMachineInstance, domain *api.Domain, cacheFactory cache.InterfaceCacheFactory) error {
+	return setupPodNetworkPhase2(vmi, domain, cacheFactory, func(*v1.Interface) bool { return true })
+}
+
+// SetupHotplugPodNetworkPhase2 runs phase2 only for the given interfaces, which were hotplugged
+// to the running VMI.
+func SetupHotplugPodNetworkPhase2(vmi *v1.VirtualMachineInstance, domain *api.Domain, cacheFactory cache.InterfaceCacheFactory, ifaceNames []string) error {
+	names := map[string]struct{}{}
+	for _, name := range ifaceNames {
+		names[name] = struct{}{}
+	}
+	return setupPodNetworkPhase2(vmi, domain, cacheFactory, func(iface *v1.Interface) bool {
+		_, exists := names[iface.Name]
+		return exists
+	})
+}
+
+func setupPodNetworkPhase2(vmi *v1.VirtualMachineInstance, domain *api.Domain, cacheFactory cache.InterfaceCacheFactory, filter func(*v1.Interface) bool) error {
 	networks := mapNetworksByName(vmi.Spec.Networks)
 	primaryNet := lookupPrimaryNetwork(vmi.Spec.Networks)
 	secondaryNets := filterSecondaryMultusNetworks(vmi.Spec.Networks)
 	podInterfaceNames := mapPodInterfaceNameByNetwork(primaryNet, secondaryNets)
 	for i, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.State == v1.InterfaceStateAbsent || !filter(&vmi.Spec.Domain.Devices.Interfaces[i]) {
+			continue
+		}
 		network, ok := networks[iface.Name]
 		if !ok {
 			return fmt.Errorf("failed to find a network %s", iface.Name)
@@ -107,6 +135,165 @@ func SetupPodNetworkPhase2(vmi *v1.VirtualMachineInstance, domain *api.Domain, c
 	return nil
 }
 
+// GetCloudInitNetworkInterfaces returns the addressing of the bridge and masquerade interfaces,
+// as discovered in phase1, for the generation of the cloud-init networkdata.
+// The MAC addresses are taken from the domain interfaces, since they may be assigned by libvirt.
+// The gateway and the DNS servers are only passed for the primary network, to not override the
+// default route of the guest by secondary networks.
+func GetCloudInitNetworkInterfaces(vmi *v1.VirtualMachineInstance, domainInterfaces []api.Interface, nameservers [][]byte, searchDomains []string) ([]cloudinit.NetworkInterfaceData, error) {
+	macs := map[string]string{}
+	for _, iface := range domainInterfaces {
+		if iface.MAC != nil {
+			macs[iface.Alias.GetName()] = iface.MAC.MAC
+		}
+	}
+
+	networks := mapNetworksByName(vmi.Spec.Networks)
+	var interfaces []cloudinit.NetworkInterfaceData
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if (iface.Bridge == nil && iface.Masquerade == nil) || iface.State == v1.InterfaceStateAbsent {
+			continue
+		}
+		network, ok := networks[iface.Name]
+		if !ok {
+			return nil, fmt.Errorf("failed to find a network %s", iface.Name)
+		}
+		vif, err := readCachedVIF("self", iface.Name)
+		if err != nil {
+			return nil, err
+		}
+		if vif.IPAMDisabled {
+			continue
+		}
+
+		data := cloudinit.NetworkInterfaceData{
+			Name: iface.Name,
+			MAC:  macs[iface.Name],
+			MTU:  vif.Mtu,
+		}
+		if data.MAC == "" && vif.MAC != nil {
+			data.MAC = vif.MAC.String()
+		}
+		if vif.IP.IPNet != nil {
+			data.Addresses = append(data.Addresses, vif.IP.IPNet.String())
+		}
+		if vif.IPv6.IPNet != nil {
+			data.Addresses = append(data.Addresses, vif.IPv6.IPNet.String())
+		}
+		if vif.Routes != nil {
+			for _, route := range *vif.Routes {
+				if route.Dst != nil && route.Gw != nil {
+					data.Routes = append(data.Routes, cloudinit.NetworkRouteData{To: route.Dst.String(), Via: route.Gw.String()})
+				}
+			}
+		}
+		if !isSecondaryMultusNetwork(network) {
+			if vif.Gateway != nil {
+				data.Gateway4 = vif.Gateway.String()
+			}
+			if vif.GatewayIpv6 != nil {
+				data.Gateway6 = vif.GatewayIpv6.String()
+			}
+			for _, nameserver := range nameservers {
+				data.Nameservers = append(data.Nameservers, net.IP(nameserver).String())
+			}
+			data.SearchDomains = searchDomains
+		}
+		interfaces = append(interfaces, data)
+	}
+	return interfaces, nil
+}
+
+// GetCaptureDeviceName returns the device in the pod network namespace which carries the traffic of the VMI
+// interface: the tap device of bridge and masquerade interfaces, and the macvtap device of macvtap interfaces.
+// SR-IOV and vDPA devices are passed to the guest and can't be captured from the pod.
+func GetCaptureDeviceName(vmi *v1.VirtualMachineInstance, ifaceName string) (string, error) {
+	networks := mapNetworksByName(vmi.Spec.Networks)
+	primaryNet := lookupPrimaryNetwork(vmi.Spec.Networks)
+	secondaryNets := filterSecondaryMultusNetworks(vmi.Spec.Networks)
+	podInterfaceNames := mapPodInterfaceNameByNetwork(primaryNet, secondaryNets)
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Name != ifaceName {
+			continue
+		}
+		if iface.State == v1.InterfaceStateAbsent {
+			return "", fmt.Errorf("interface %s is unplugged", ifaceName)
+		}
+		network, ok := networks[iface.Name]
+		if !ok {
+			return "", fmt.Errorf("failed to find a network %s", iface.Name)
+		}
+		podInterfaceName := podInterfaceNames[network.Name]
+		switch {
+		case iface.Bridge != nil, iface.Masquerade != nil:
+			return generateTapDeviceName(podInterfaceName), nil
+		case iface.Macvtap != nil:
+			return podInterfaceName, nil
+		default:
+			return "", fmt.Errorf("packet capture is not supported for the binding of interface %s", ifaceName)
+		}
+	}
+	return "", fmt.Errorf("interface %s does not exist", ifaceName)
+}
+
+// GetPortForwardAddress returns the address, in the pod network namespace, to which the ports of the VMI are
+// forwarded: the guest address of a masquerade interface, or the loopback address on which slirp and passt listen.
+// The pod IP of a bridge interface is held by a dummy device in the pod, so the guest can't be reached from there.
+func GetPortForwardAddress(vmi *v1.VirtualMachineInstance) (string, error) {
+	primaryNet := lookupPrimaryNetwork(vmi.Spec.Networks)
+	if primaryNet == nil || primaryNet.Pod == nil {
+		return "", fmt.Errorf("the VMI is not connected to the pod network")
+	}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Name != primaryNet.Name {
+			continue
+		}
+		switch {
+		case iface.Masquerade != nil:
+			vmNetworkCIDR := primaryNet.Pod.VMNetworkCIDR
+			if vmNetworkCIDR == "" {
+				vmNetworkCIDR = api.DefaultVMCIDR
+			}
+			// the handler is stateless, so it is not taken from the package, which may not be initialized
+			_, guestAddr, err := (&NetworkUtilsHandler{}).GetHostAndGwAddressesFromCIDR(vmNetworkCIDR)
+			if err != nil {
+				return "", err
+			}
+			guestIP, _, err := net.ParseCIDR(guestAddr)
+			if err != nil {
+				return "", err
+			}
+			return guestIP.String(), nil
+		case iface.Slirp != nil, iface.Passt != nil:
+			return "127.0.0.1", nil
+		default:
+			return "", fmt.Errorf("port forwarding is not supported for the binding of interface %s", iface.Name)
+		}
+	}
+	return "", fmt.Errorf("failed to find the interface of network %s", primaryNet.Name)
+}
+
+// HasCachedVIF tells if phase1 already stored the configuration of the interface.
+func HasCachedVIF(name string) bool {
+	_, err := os.Stat(getVifFilePath("self", name))
+	return err == nil
+}
+
+func readCachedVIF(pid, name string) (*VIF, error) {
+	buf, err := ioutil.ReadFile(getVifFilePath(pid, name))
+	if err != nil {
+		return nil, err
+	}
+	vif := &VIF{}
+	err = json.Unmarshal(buf, vif)
+	if err != nil {
+		return nil, err
+	}
+	vif.Gateway = vif.Gateway.To4()
+	vif.GatewayIpv6 = vif.GatewayIpv6.To16()
+	return vif, nil
+}
+
 func mapNetworksByName(nets []v1.Network) map[string]v1.Network {
 	networks := map[string]v1.Network{}
 	for _, net := range nets {
@@ -20,12 +20,19 @@
 package network
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
 	"os"
+	"path/filepath"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
 
+	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache/fake"
@@ -143,5 +150,209 @@ var _ = Describe("Network", func() {
 			err := SetupPodNetworkPhase1(vm, pid, cacheFactory)
 			Expect(err).To(BeNil())
 		})
+		It("should not configure unplugged interfaces", func() {
+			podNICFactory = func(cacheFactory cache.InterfaceCacheFactory) podNIC {
+				return mockpodNIC
+			}
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			vm.Spec.Domain.Devices.Interfaces = append(vm.Spec.Domain.Devices.Interfaces, v1.Interface{
+				Name:  "unplugged",
+				State: v1.InterfaceStateAbsent,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Bridge: &v1.InterfaceBridge{},
+				},
+			})
+			vm.Spec.Networks = append(vm.Spec.Networks, v1.Network{
+				Name: "unplugged",
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{NetworkName: "unplugged"},
+				},
+			})
+
+			mockpodNIC.EXPECT().PlugPhase1(vm, &vm.Spec.Domain.Devices.Interfaces[0], v1.DefaultPodNetwork(), primaryPodInterfaceName, pid)
+			err := SetupPodNetworkPhase1(vm, pid, cacheFactory)
+			Expect(err).To(BeNil())
+		})
+		It("should run phase2 only for hotplugged interfaces", func() {
+			podNICFactory = func(cacheFactory cache.InterfaceCacheFactory) podNIC {
+				return mockpodNIC
+			}
+
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			vm.Spec.Domain.Devices.Interfaces = append(vm.Spec.Domain.Devices.Interfaces, v1.Interface{
+				Name: "hotplugged",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Bridge: &v1.InterfaceBridge{},
+				},
+			})
+			hotpluggedNet := &v1.Network{
+				Name: "hotplugged",
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{NetworkName: "hotplugged"},
+				},
+			}
+			vm.Spec.Networks = append(vm.Spec.Networks, *hotpluggedNet)
+			domain := &api.Domain{}
+
+			mockpodNIC.EXPECT().PlugPhase2(vm, &vm.Spec.Domain.Devices.Interfaces[1], hotpluggedNet, domain, "net1")
+			err := SetupHotplugPodNetworkPhase2(vm, domain, cacheFactory, []string{"hotplugged"})
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("cloud-init network interfaces", func() {
+		var tmpDir string
+		var origVifCacheFile string
+
+		writeCachedVIF := func(name string, vif *VIF) {
+			buf, err := json.Marshal(vif)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(writeVifFile(buf, "self", name)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "networktest")
+			Expect(err).ToNot(HaveOccurred())
+			origVifCacheFile = vifCacheFile
+			setVifCacheFile(filepath.Join(tmpDir, "vif-cache-%s-%s.json"))
+		})
+
+		AfterEach(func() {
+			setVifCacheFile(origVifCacheFile)
+			os.RemoveAll(tmpDir)
+		})
+
+		It("should pass the gateway and DNS servers only for the primary network", func() {
+			vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+			vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, v1.Interface{
+				Name: "secondary",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Bridge: &v1.InterfaceBridge{},
+				},
+			})
+			vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+				Name: "secondary",
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{NetworkName: "secondary"},
+				},
+			})
+
+			writeCachedVIF("default", &VIF{
+				IP:      netlink.Addr{IPNet: &net.IPNet{IP: net.IPv4(10, 35, 0, 6), Mask: net.CIDRMask(24, 32)}},
+				Gateway: net.IPv4(10, 35, 0, 1),
+				Mtu:     1410,
+			})
+			writeCachedVIF("secondary", &VIF{
+				IP:      netlink.Addr{IPNet: &net.IPNet{IP: net.IPv4(192, 168, 1, 10), Mask: net.CIDRMask(24, 32)}},
+				MAC:     net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+				Gateway: net.IPv4(192, 168, 1, 1),
+				Routes: &[]netlink.Route{
+					{Dst: &net.IPNet{IP: net.IPv4(192, 168, 2, 0), Mask: net.CIDRMask(24, 32)}, Gw: net.IPv4(192, 168, 1, 1)},
+					{Dst: &net.IPNet{IP: net.IPv4(192, 168, 1, 0), Mask: net.CIDRMask(24, 32)}},
+				},
+				Mtu: 1500,
+			})
+			domainInterfaces := []api.Interface{
+				{MAC: &api.MAC{MAC: "02:00:00:00:00:01"}, Alias: api.NewUserDefinedAlias("default")},
+			}
+
+			interfaces, err := GetCloudInitNetworkInterfaces(vmi, domainInterfaces, [][]byte{net.IPv4(10, 96, 0, 10).To4()}, []string{"cluster.local"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(interfaces).To(Equal([]cloudinit.NetworkInterfaceData{
+				{
+					Name:          "default",
+					MAC:           "02:00:00:00:00:01",
+					Addresses:     []string{"10.35.0.6/24"},
+					Gateway4:      "10.35.0.1",
+					MTU:           1410,
+					Nameservers:   []string{"10.96.0.10"},
+					SearchDomains: []string{"cluster.local"},
+				},
+				{
+					Name:      "secondary",
+					MAC:       "02:00:00:00:00:02",
+					Addresses: []string{"192.168.1.10/24"},
+					Routes:    []cloudinit.NetworkRouteData{{To: "192.168.2.0/24", Via: "192.168.1.1"}},
+					MTU:       1500,
+				},
+			}))
+		})
+
+		It("should skip interfaces without IPAM", func() {
+			vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+			writeCachedVIF("default", &VIF{IPAMDisabled: true})
+
+			interfaces, err := GetCloudInitNetworkInterfaces(vmi, nil, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(interfaces).To(BeEmpty())
+		})
+	})
+
+	Context("packet capture device", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = newVMIBridgeInterface("testnamespace", "testVmName")
+			vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces,
+				v1.Interface{Name: "macvtap", InterfaceBindingMethod: v1.InterfaceBindingMethod{Macvtap: &v1.InterfaceMacvtap{}}},
+				v1.Interface{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+			)
+			for _, name := range []string{"macvtap", "sriov"} {
+				vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+					Name:          name,
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: name}},
+				})
+			}
+		})
+
+		It("should capture on the tap device of bridge interfaces", func() {
+			Expect(GetCaptureDeviceName(vmi, "default")).To(Equal("tap0"))
+		})
+
+		It("should capture on the macvtap device", func() {
+			Expect(GetCaptureDeviceName(vmi, "macvtap")).To(Equal("net1"))
+		})
+
+		It("should reject SR-IOV interfaces", func() {
+			_, err := GetCaptureDeviceName(vmi, "sriov")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject unknown interfaces", func() {
+			_, err := GetCaptureDeviceName(vmi, "unknown")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("port forward address", func() {
+		It("should forward to the guest address of masquerade interfaces", func() {
+			vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+			Expect(GetPortForwardAddress(vmi)).To(Equal("10.0.2.2"))
+		})
+
+		It("should forward to the guest address in the VM network CIDR", func() {
+			vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+			vmi.Spec.Networks[0].Pod.VMNetworkCIDR = "10.11.12.0/24"
+			Expect(GetPortForwardAddress(vmi)).To(Equal("10.11.12.2"))
+		})
+
+		It("should forward to the loopback address with passt", func() {
+			vmi := newVMIPasstInterface("testnamespace", "testVmName")
+			Expect(GetPortForwardAddress(vmi)).To(Equal("127.0.0.1"))
+		})
+
+		It("should reject bridge interfaces", func() {
+			vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+			_, err := GetPortForwardAddress(vmi)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject VMIs without pod network", func() {
+			vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+			vmi.Spec.Networks[0].NetworkSource = v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "default", Default: true}}
+			_, err := GetPortForwardAddress(vmi)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
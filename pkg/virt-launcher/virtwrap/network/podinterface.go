@@ -38,9 +38,11 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
+	"kubevirt.io/kubevirt/pkg/util/net/istio"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/cache"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/ndp"
 )
 
 var bridgeFakeIP = "169.254.75.1%d/32"
@@ -167,8 +169,8 @@ func sortIPsBasedOnPrimaryIP(ipv4, ipv6 string) ([]string, error) {
 func (l *podNICImpl) PlugPhase1(vmi *v1.VirtualMachineInstance, iface *v1.Interface, network *v1.Network, podInterfaceName string, pid int) error {
 	initHandler()
 
-	// There is nothing to plug for SR-IOV devices
-	if iface.SRIOV != nil {
+	// There is nothing to plug for SR-IOV, vDPA and vhost-user devices, and binding plugins plug their interfaces themselves
+	if iface.SRIOV != nil || iface.Vdpa != nil || iface.VhostUser != nil || iface.Binding != nil {
 		return nil
 	}
 
@@ -183,8 +185,8 @@ func (l *podNICImpl) PlugPhase1(vmi *v1.VirtualMachineInstance, iface *v1.Interf
 		return err
 	}
 
-	// ignore the bindMechanism.loadCachedInterface for slirp and set the Pod interface cache
-	if !isExist || iface.Slirp != nil {
+	// ignore the bindMechanism.loadCachedInterface for slirp and passt and set the Pod interface cache
+	if !isExist || iface.Slirp != nil || iface.Passt != nil {
 		err := setPodInterfaceCache(iface, podInterfaceName, vmi, l.cacheFactory)
 		if err != nil {
 			return err
@@ -206,6 +208,13 @@ func (l *podNICImpl) PlugPhase1(vmi *v1.VirtualMachineInstance, iface *v1.Interf
 			return createCriticalNetworkError(err)
 		}
 
+		if iface.Firewall != nil && (iface.Bridge != nil || iface.Masquerade != nil) {
+			if err := applyInterfaceFirewall(iface.Firewall, podInterfaceName); err != nil {
+				log.Log.Reason(err).Error("failed to apply the firewall rules of the interface")
+				return createCriticalNetworkError(err)
+			}
+		}
+
 		err = bindMechanism.setCachedInterface(pidStr, iface.Name)
 		if err != nil {
 			log.Log.Reason(err).Error("failed to save interface configuration")
@@ -246,8 +255,8 @@ func (l *podNICImpl) PlugPhase2(vmi *v1.VirtualMachineInstance, iface *v1.Interf
 	precond.MustNotBeNil(domain)
 	initHandler()
 
-	// There is nothing to plug for SR-IOV devices
-	if iface.SRIOV != nil {
+	// There is nothing to plug for SR-IOV, vDPA and vhost-user devices, and binding plugins plug their interfaces themselves
+	if iface.SRIOV != nil || iface.Vdpa != nil || iface.VhostUser != nil || iface.Binding != nil {
 		return nil
 	}
 
@@ -345,7 +354,7 @@ func getPhase2Binding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, netwo
 			domain:              domain,
 			podInterfaceName:    podInterfaceName,
 			vmNetworkCIDR:       network.Pod.VMNetworkCIDR,
-			vmIpv6NetworkCIDR:   "", // TODO add ipv6 cidr to PodNetwork schema
+			vmIpv6NetworkCIDR:   network.Pod.VMIPv6NetworkCIDR,
 			bridgeInterfaceName: fmt.Sprintf("k6t-%s", podInterfaceName),
 			storeFactory:        storeFactory,
 		}, nil
@@ -353,6 +362,9 @@ func getPhase2Binding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, netwo
 	if iface.Slirp != nil {
 		return &SlirpBindMechanism{vmi: vmi, iface: iface, domain: domain}, nil
 	}
+	if iface.Passt != nil {
+		return &PasstBindMechanism{vmi: vmi, iface: iface, domain: domain, podInterfaceName: podInterfaceName}, nil
+	}
 	if iface.Macvtap != nil {
 		mac, err := retrieveMacAddress(iface)
 		if err != nil {
@@ -786,10 +798,23 @@ func configureVifV6Addresses(b *MasqueradeBindMechanism, err error) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse vm ipv6 address %s err %v", vmIpv6, err)
 	}
+	if isSLAACNetwork(vmAddr.IPNet) && len(b.vif.MAC) == 6 {
+		// the guest autoconfigures this address from the router advertisement, DHCPv6 leases the same one
+		vmAddr.IP = ndp.EUI64Address(vmAddr.IP, b.vif.MAC)
+	}
 	b.vif.IPv6 = *vmAddr
 	return nil
 }
 
+// isSLAACNetwork returns whether the router advertisement lets the guest autoconfigure addresses of the network
+func isSLAACNetwork(network *net.IPNet) bool {
+	if network == nil {
+		return false
+	}
+	ones, bits := network.Mask.Size()
+	return bits == 8*net.IPv6len && ones == ndp.SLAACPrefixLength
+}
+
 func (b *MasqueradeBindMechanism) startDHCP(vmi *v1.VirtualMachineInstance) error {
 	return Handler.StartDHCP(b.vif, b.vif.Gateway, b.bridgeInterfaceName, b.iface.DHCPOptions, false)
 }
@@ -996,7 +1021,7 @@ func (b *MasqueradeBindMechanism) createNatRulesUsingIptables(protocol iptables.
 		return err
 	}
 
-	err = Handler.IptablesAppendRule(protocol, "nat", "POSTROUTING", "-s", b.getVifIpByProtocol(protocol), "-j", "MASQUERADE")
+	err = Handler.IptablesAppendRule(protocol, "nat", "POSTROUTING", "-s", b.getMasqueradeSourceByProtocol(protocol), "-j", "MASQUERADE")
 	if err != nil {
 		return err
 	}
@@ -1012,6 +1037,10 @@ func (b *MasqueradeBindMechanism) createNatRulesUsingIptables(protocol iptables.
 	}
 
 	if len(b.iface.Ports) == 0 {
+		if istio.ProxyInjectionEnabled(b.vmi) {
+			return b.createIstioNatRulesUsingIptables(protocol)
+		}
+
 		err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
 			"-j",
 			"DNAT",
@@ -1020,22 +1049,29 @@ func (b *MasqueradeBindMechanism) createNatRulesUsingIptables(protocol iptables.
 		return err
 	}
 
+	dstAddresses, err := b.getDstAddressesToDNAT(protocol)
+	if err != nil {
+		return err
+	}
+
 	for _, port := range b.iface.Ports {
 		if port.Protocol == "" {
 			port.Protocol = "tcp"
 		}
 
-		err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_POSTINBOUND",
-			"-p",
-			strings.ToLower(port.Protocol),
-			"--dport",
-			strconv.Itoa(int(port.Port)),
-			"--source", getLoopbackAdrress(protocol),
-			"-j",
-			"SNAT",
-			"--to-source", b.getGatewayByProtocol(protocol))
-		if err != nil {
-			return err
+		for _, srcAddress := range b.getSrcAddressesToSNAT(protocol) {
+			err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_POSTINBOUND",
+				"-p",
+				strings.ToLower(port.Protocol),
+				"--dport",
+				strconv.Itoa(int(port.Port)),
+				"--source", srcAddress,
+				"-j",
+				"SNAT",
+				"--to-source", b.getGatewayByProtocol(protocol))
+			if err != nil {
+				return err
+			}
 		}
 
 		err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
@@ -1050,23 +1086,69 @@ func (b *MasqueradeBindMechanism) createNatRulesUsingIptables(protocol iptables.
 			return err
 		}
 
-		err = Handler.IptablesAppendRule(protocol, "nat", "OUTPUT",
-			"-p",
-			strings.ToLower(port.Protocol),
-			"--dport",
-			strconv.Itoa(int(port.Port)),
-			"--destination", getLoopbackAdrress(protocol),
-			"-j",
-			"DNAT",
-			"--to-destination", b.getVifIpByProtocol(protocol))
-		if err != nil {
-			return err
+		for _, dstAddress := range dstAddresses {
+			err = Handler.IptablesAppendRule(protocol, "nat", "OUTPUT",
+				"-p",
+				strings.ToLower(port.Protocol),
+				"--dport",
+				strconv.Itoa(int(port.Port)),
+				"--destination", dstAddress,
+				"-j",
+				"DNAT",
+				"--to-destination", b.getVifIpByProtocol(protocol))
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// createIstioNatRulesUsingIptables forwards all the traffic to the VM, except the traffic of the istio proxy.
+// The inbound traffic is forwarded by the proxy to the pod IP, with the envoy loopback address as its source.
+func (b *MasqueradeBindMechanism) createIstioNatRulesUsingIptables(protocol iptables.Protocol) error {
+	err := Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
+		"-p", "tcp",
+		"-m", "multiport",
+		"--dports", istio.JoinReservedPorts(","),
+		"-j", "RETURN")
+	if err != nil {
+		return err
+	}
+
+	err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
+		"-j",
+		"DNAT",
+		"--to-destination", b.getVifIpByProtocol(protocol))
+	if err != nil {
+		return err
+	}
+
+	err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_POSTINBOUND",
+		"--source", getEnvoyLoopbackAddress(protocol),
+		"-j",
+		"SNAT",
+		"--to-source", b.getGatewayByProtocol(protocol))
+	if err != nil {
+		return err
+	}
+
+	podIP, err := b.getPodIpByProtocol(protocol)
+	if err != nil || podIP == "" {
+		return err
+	}
+
+	return Handler.IptablesAppendRule(protocol, "nat", "OUTPUT",
+		"-p", "tcp",
+		"-m", "multiport",
+		"!", "--dports", istio.JoinReservedPorts(","),
+		"--destination", podIP,
+		"-j",
+		"DNAT",
+		"--to-destination", b.getVifIpByProtocol(protocol))
+}
+
 func (b *MasqueradeBindMechanism) getGatewayByProtocol(proto iptables.Protocol) string {
 	if proto == iptables.ProtocolIPv4 {
 		return b.gatewayAddr.IP.String()
@@ -1083,6 +1165,16 @@ func (b *MasqueradeBindMechanism) getVifIpByProtocol(proto iptables.Protocol) st
 	}
 }
 
+// getMasqueradeSourceByProtocol returns the source of the traffic which is masqueraded. A guest autoconfiguring its
+// IPv6 addresses may use any address of the VM network, temporary addresses included, so the whole network is masqueraded.
+func (b *MasqueradeBindMechanism) getMasqueradeSourceByProtocol(proto iptables.Protocol) string {
+	if proto == iptables.ProtocolIPv6 && isSLAACNetwork(b.vif.IPv6.IPNet) {
+		network := &net.IPNet{IP: b.vif.IPv6.IP.Mask(b.vif.IPv6.Mask), Mask: b.vif.IPv6.Mask}
+		return network.String()
+	}
+	return b.getVifIpByProtocol(proto)
+}
+
 func getLoopbackAdrress(proto iptables.Protocol) string {
 	if proto == iptables.ProtocolIPv4 {
 		return "127.0.0.1"
@@ -1091,6 +1183,55 @@ func getLoopbackAdrress(proto iptables.Protocol) string {
 	}
 }
 
+func getEnvoyLoopbackAddress(proto iptables.Protocol) string {
+	if proto == iptables.ProtocolIPv4 {
+		return istio.EnvoyLoopbackAddress
+	}
+	return istio.EnvoyLoopbackAddressIPv6
+}
+
+// getSrcAddressesToSNAT returns the sources of the traffic, generated in the pod, which is forwarded to the VM.
+func (b *MasqueradeBindMechanism) getSrcAddressesToSNAT(proto iptables.Protocol) []string {
+	addresses := []string{getLoopbackAdrress(proto)}
+	if istio.ProxyInjectionEnabled(b.vmi) {
+		addresses = append(addresses, getEnvoyLoopbackAddress(proto))
+	}
+	return addresses
+}
+
+// getDstAddressesToDNAT returns the destinations of the traffic, generated in the pod, which is forwarded to the VM.
+// The istio proxy forwards the inbound traffic to the pod IP.
+func (b *MasqueradeBindMechanism) getDstAddressesToDNAT(proto iptables.Protocol) ([]string, error) {
+	addresses := []string{getLoopbackAdrress(proto)}
+	if istio.ProxyInjectionEnabled(b.vmi) {
+		podIP, err := b.getPodIpByProtocol(proto)
+		if err != nil {
+			return nil, err
+		}
+		if podIP != "" {
+			addresses = append(addresses, podIP)
+		}
+	}
+	return addresses, nil
+}
+
+func (b *MasqueradeBindMechanism) getPodIpByProtocol(proto iptables.Protocol) (string, error) {
+	family := netlink.FAMILY_V4
+	if proto == iptables.ProtocolIPv6 {
+		family = netlink.FAMILY_V6
+	}
+	addrList, err := Handler.AddrList(b.podNicLink, family)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the addresses of interface %s: %v", b.podInterfaceName, err)
+	}
+	for _, addr := range addrList {
+		if addr.IP.IsGlobalUnicast() {
+			return addr.IP.String(), nil
+		}
+	}
+	return "", nil
+}
+
 func (b *MasqueradeBindMechanism) createNatRulesUsingNftables(proto iptables.Protocol) error {
 	err := Handler.NftablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND")
 	if err != nil {
@@ -1102,7 +1243,7 @@ func (b *MasqueradeBindMechanism) createNatRulesUsingNftables(proto iptables.Pro
 		return err
 	}
 
-	err = Handler.NftablesAppendRule(proto, "nat", "postrouting", Handler.GetNFTIPString(proto), "saddr", b.getVifIpByProtocol(proto), "counter", "masquerade")
+	err = Handler.NftablesAppendRule(proto, "nat", "postrouting", Handler.GetNFTIPString(proto), "saddr", b.getMasqueradeSourceByProtocol(proto), "counter", "masquerade")
 	if err != nil {
 		return err
 	}
@@ -1118,25 +1259,36 @@ func (b *MasqueradeBindMechanism) createNatRulesUsingNftables(proto iptables.Pro
 	}
 
 	if len(b.iface.Ports) == 0 {
+		if istio.ProxyInjectionEnabled(b.vmi) {
+			return b.createIstioNatRulesUsingNftables(proto)
+		}
+
 		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
 			"counter", "dnat", "to", b.getVifIpByProtocol(proto))
 
 		return err
 	}
 
+	dstAddresses, err := b.getDstAddressesToDNAT(proto)
+	if err != nil {
+		return err
+	}
+
 	for _, port := range b.iface.Ports {
 		if port.Protocol == "" {
 			port.Protocol = "tcp"
 		}
 
-		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
-			strings.ToLower(port.Protocol),
-			"dport",
-			strconv.Itoa(int(port.Port)),
-			Handler.GetNFTIPString(proto), "saddr", getLoopbackAdrress(proto),
-			"counter", "snat", "to", b.getGatewayByProtocol(proto))
-		if err != nil {
-			return err
+		for _, srcAddress := range b.getSrcAddressesToSNAT(proto) {
+			err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
+				strings.ToLower(port.Protocol),
+				"dport",
+				strconv.Itoa(int(port.Port)),
+				Handler.GetNFTIPString(proto), "saddr", srcAddress,
+				"counter", "snat", "to", b.getGatewayByProtocol(proto))
+			if err != nil {
+				return err
+			}
 		}
 
 		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
@@ -1148,20 +1300,57 @@ func (b *MasqueradeBindMechanism) createNatRulesUsingNftables(proto iptables.Pro
 			return err
 		}
 
-		err = Handler.NftablesAppendRule(proto, "nat", "output",
-			Handler.GetNFTIPString(proto), "daddr", getLoopbackAdrress(proto),
-			strings.ToLower(port.Protocol),
-			"dport",
-			strconv.Itoa(int(port.Port)),
-			"counter", "dnat", "to", b.getVifIpByProtocol(proto))
-		if err != nil {
-			return err
+		for _, dstAddress := range dstAddresses {
+			err = Handler.NftablesAppendRule(proto, "nat", "output",
+				Handler.GetNFTIPString(proto), "daddr", dstAddress,
+				strings.ToLower(port.Protocol),
+				"dport",
+				strconv.Itoa(int(port.Port)),
+				"counter", "dnat", "to", b.getVifIpByProtocol(proto))
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// createIstioNatRulesUsingNftables forwards all the traffic to the VM, except the traffic of the istio proxy.
+// The inbound traffic is forwarded by the proxy to the pod IP, with the envoy loopback address as its source.
+func (b *MasqueradeBindMechanism) createIstioNatRulesUsingNftables(proto iptables.Protocol) error {
+	reservedPorts := "{ " + istio.JoinReservedPorts(", ") + " }"
+
+	err := Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+		"tcp", "dport", reservedPorts, "counter", "return")
+	if err != nil {
+		return err
+	}
+
+	err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+		"counter", "dnat", "to", b.getVifIpByProtocol(proto))
+	if err != nil {
+		return err
+	}
+
+	err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
+		Handler.GetNFTIPString(proto), "saddr", getEnvoyLoopbackAddress(proto),
+		"counter", "snat", "to", b.getGatewayByProtocol(proto))
+	if err != nil {
+		return err
+	}
+
+	podIP, err := b.getPodIpByProtocol(proto)
+	if err != nil || podIP == "" {
+		return err
+	}
+
+	return Handler.NftablesAppendRule(proto, "nat", "output",
+		Handler.GetNFTIPString(proto), "daddr", podIP,
+		"tcp", "dport", "!=", reservedPorts,
+		"counter", "dnat", "to", b.getVifIpByProtocol(proto))
+}
+
 type SlirpBindMechanism struct {
 	vmi       *v1.VirtualMachineInstance
 	iface     *v1.Interface
@@ -1225,6 +1414,65 @@ func (s *SlirpBindMechanism) setCachedInterface(pid, name string) error {
 	return nil
 }
 
+// PasstBindMechanism leaves the pod network untouched, passt binds to the pod interface
+// on behalf of the guest when libvirt starts it as the backend of the domain interface.
+type PasstBindMechanism struct {
+	vmi              *v1.VirtualMachineInstance
+	iface            *v1.Interface
+	domain           *api.Domain
+	podInterfaceName string
+}
+
+func (p *PasstBindMechanism) discoverPodNetworkInterface() error {
+	return nil
+}
+
+func (p *PasstBindMechanism) preparePodNetworkInterfaces(queueNumber uint32, launcherPID int) error {
+	return nil
+}
+
+func (p *PasstBindMechanism) startDHCP(vmi *v1.VirtualMachineInstance) error {
+	// passt serves DHCP, NDP and DHCPv6 to the guest by itself
+	return nil
+}
+
+func (p *PasstBindMechanism) decorateConfig() error {
+	// libvirt passes the MTU of the pod interface to passt, which advertises it to the guest over DHCP and NDP
+	podNicLink, err := Handler.LinkByName(p.podInterfaceName)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to get a link for interface: %s", p.podInterfaceName)
+		return err
+	}
+	ifaces := p.domain.Spec.Devices.Interfaces
+	for i, iface := range ifaces {
+		if iface.Alias.GetName() == p.iface.Name {
+			ifaces[i].MTU = &api.MTU{Size: strconv.Itoa(podNicLink.Attrs().MTU)}
+			if p.iface.MacAddress != "" {
+				// We assume address was already validated in API layer so just pass it to libvirt as-is.
+				ifaces[i].MAC = &api.MAC{MAC: p.iface.MacAddress}
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (p *PasstBindMechanism) loadCachedInterface(pid, name string) (bool, error) {
+	return true, nil
+}
+
+func (p *PasstBindMechanism) loadCachedVIF(pid, name string) (bool, error) {
+	return true, nil
+}
+
+func (p *PasstBindMechanism) setCachedVIF(pid, name string) error {
+	return nil
+}
+
+func (p *PasstBindMechanism) setCachedInterface(pid, name string) error {
+	return nil
+}
+
 type MacvtapBindMechanism struct {
 	vmi              *v1.VirtualMachineInstance
 	iface            *v1.Interface
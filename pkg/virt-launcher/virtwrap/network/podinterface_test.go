@@ -43,6 +43,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/net/istio"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
@@ -533,7 +534,114 @@ var _ = Describe("Pod Network", func() {
 				api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
 				TestPodInterfaceIPBinding(vm, domain)
 			})
+			It("should define a new VIF bind to a bridge and create a specific SCTP nat rule using nftables", func() {
+				mockNetwork.EXPECT().IsIpv6Enabled(primaryPodInterfaceName).Return(true, nil).Times(3)
+				mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+
+				for _, proto := range ipProtocols() {
+					mockNetwork.EXPECT().NftablesLoad(proto).Return(nil)
+
+					mockNetwork.EXPECT().NftablesAppendRule(proto, "nat",
+						"KUBEVIRT_POSTINBOUND",
+						"sctp",
+						"dport",
+						"2905",
+						GetNFTIPString(proto), "saddr", getLoopbackAdrress(proto),
+						"counter", "snat", "to", GetMasqueradeGwIp(proto)).Return(nil)
+					mockNetwork.EXPECT().NftablesAppendRule(proto, "nat",
+						"KUBEVIRT_PREINBOUND",
+						"sctp",
+						"dport",
+						"2905",
+						"counter", "dnat", "to", GetMasqueradeVmIp(proto)).Return(nil)
+					mockNetwork.EXPECT().NftablesAppendRule(proto, "nat",
+						"output",
+						GetNFTIPString(proto), "daddr", getLoopbackAdrress(proto),
+						"sctp",
+						"dport",
+						"2905",
+						"counter", "dnat", "to", GetMasqueradeVmIp(proto)).Return(nil)
+				}
 
+				domain := NewDomainWithBridgeInterface()
+				vm := newVMIMasqueradeInterface("testnamespace", "testVmName")
+				vm.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "sigtran", Port: 2905, Protocol: "SCTP"}}
+
+				api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+				TestPodInterfaceIPBinding(vm, domain)
+			})
+			Context("with the istio proxy", func() {
+				const reservedPorts = "{ 15000, 15001, 15004, 15006, 15008, 15020, 15021, 15090 }"
+				var podIPv6Addr netlink.Addr
+
+				GetPodIp := func(protocol iptables.Protocol) string {
+					if protocol == iptables.ProtocolIPv4 {
+						return fakeAddr.IP.String()
+					}
+					return podIPv6Addr.IP.String()
+				}
+
+				BeforeEach(func() {
+					podIPv6Addr = netlink.Addr{IPNet: &net.IPNet{IP: net.ParseIP("fd10:244::8c4c"), Mask: net.CIDRMask(64, 128)}}
+					mockNetwork.EXPECT().AddrList(primaryPodInterface, netlink.FAMILY_V6).Return([]netlink.Addr{podIPv6Addr}, nil)
+					mockNetwork.EXPECT().IsIpv6Enabled(primaryPodInterfaceName).Return(true, nil).Times(3)
+					mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+				})
+
+				It("should forward all the traffic, except the proxy ports, using nftables", func() {
+					for _, proto := range ipProtocols() {
+						mockNetwork.EXPECT().NftablesLoad(proto).Return(nil)
+						mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+							"tcp", "dport", reservedPorts, "counter", "return").Return(nil)
+						mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
+							GetNFTIPString(proto), "saddr", getEnvoyLoopbackAddress(proto),
+							"counter", "snat", "to", GetMasqueradeGwIp(proto)).Return(nil)
+						mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "output",
+							GetNFTIPString(proto), "daddr", GetPodIp(proto),
+							"tcp", "dport", "!=", reservedPorts,
+							"counter", "dnat", "to", GetMasqueradeVmIp(proto)).Return(nil)
+					}
+
+					domain := NewDomainWithBridgeInterface()
+					vm := newVMIMasqueradeInterface("testnamespace", "testVmName")
+					vm.Annotations = map[string]string{istio.InjectSidecarAnnotation: "true"}
+
+					api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+					TestPodInterfaceIPBinding(vm, domain)
+				})
+				It("should forward the specific ports from the proxy using iptables", func() {
+					for _, proto := range ipProtocols() {
+						mockNetwork.EXPECT().NftablesLoad(proto).Return(fmt.Errorf("no nft"))
+						mockNetwork.EXPECT().HasNatIptables(proto).Return(true).Times(2)
+						for _, srcAddress := range []string{getLoopbackAdrress(proto), getEnvoyLoopbackAddress(proto)} {
+							mockNetwork.EXPECT().IptablesAppendRule(proto, "nat",
+								"KUBEVIRT_POSTINBOUND",
+								"-p", "tcp", "--dport", "80",
+								"--source", srcAddress,
+								"-j", "SNAT", "--to-source", GetMasqueradeGwIp(proto)).Return(nil)
+						}
+						mockNetwork.EXPECT().IptablesAppendRule(proto, "nat",
+							"KUBEVIRT_PREINBOUND",
+							"-p", "tcp", "--dport", "80",
+							"-j", "DNAT", "--to-destination", GetMasqueradeVmIp(proto)).Return(nil)
+						for _, dstAddress := range []string{getLoopbackAdrress(proto), GetPodIp(proto)} {
+							mockNetwork.EXPECT().IptablesAppendRule(proto, "nat",
+								"OUTPUT",
+								"-p", "tcp", "--dport", "80",
+								"--destination", dstAddress,
+								"-j", "DNAT", "--to-destination", GetMasqueradeVmIp(proto)).Return(nil)
+						}
+					}
+
+					domain := NewDomainWithBridgeInterface()
+					vm := newVMIMasqueradeInterface("testnamespace", "testVmName")
+					vm.Annotations = map[string]string{istio.InjectSidecarAnnotation: "true"}
+					vm.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "test", Port: 80, Protocol: "TCP"}}
+
+					api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+					TestPodInterfaceIPBinding(vm, domain)
+				})
+			})
 		})
 		Context("Slirp Plug", func() {
 			It("Should create an interface in the qemu command line and remove it from the interfaces", func() {
@@ -610,6 +718,25 @@ var _ = Describe("Pod Network", func() {
 				Expect(domain.Spec.Devices.Interfaces[0].MTU).To(Equal(&api.MTU{Size: "1410"}), "should have the expected MTU")
 			})
 		})
+		Context("Passt plug", func() {
+			It("Should keep the passt interface in the domain and set the requested MAC address and the pod MTU", func() {
+				domain := NewDomainWithPasstInterface()
+				vmi := newVMIPasstInterface("testnamespace", "testVmName")
+				vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "de:ad:00:00:be:af"
+
+				api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+
+				driver, err := getPhase2Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, primaryPodInterfaceName, cacheFactory)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(driver).To(BeAssignableToTypeOf(&PasstBindMechanism{}))
+				mockNetwork.EXPECT().LinkByName(primaryPodInterfaceName).Return(primaryPodInterface, nil)
+				TestRunPlug(driver)
+				Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+				Expect(domain.Spec.Devices.Interfaces[0].Backend).To(Equal(&api.InterfaceBackend{Type: "passt"}))
+				Expect(domain.Spec.Devices.Interfaces[0].MAC).To(Equal(&api.MAC{MAC: "de:ad:00:00:be:af"}))
+				Expect(domain.Spec.Devices.Interfaces[0].MTU).To(Equal(&api.MTU{Size: "1410"}))
+			})
+		})
 	})
 
 	Context("Masquerade startDHCP", func() {
@@ -650,6 +777,48 @@ var _ = Describe("Pod Network", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+	Context("Masquerade IPv6 addresses", func() {
+		It("should be allocated from the IPv6 CIDR of the pod network", func() {
+			const vmIpv6NetworkCIDR = "fd10:10:10::/120"
+			vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+			vmi.Spec.Networks[0].Pod.VMIPv6NetworkCIDR = vmIpv6NetworkCIDR
+			driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], primaryPodInterfaceName, cacheFactory)
+			Expect(err).ToNot(HaveOccurred())
+			masq, ok := driver.(*MasqueradeBindMechanism)
+			Expect(ok).To(BeTrue())
+
+			gwAddr, _ := netlink.ParseAddr("fd10:10:10::1/120")
+			vmAddr, _ := netlink.ParseAddr("fd10:10:10::2/120")
+			mockNetwork.EXPECT().GetHostAndGwAddressesFromCIDR(vmIpv6NetworkCIDR).Return("fd10:10:10::1/120", "fd10:10:10::2/120", nil)
+			mockNetwork.EXPECT().ParseAddr("fd10:10:10::1/120").Return(gwAddr, nil)
+			mockNetwork.EXPECT().ParseAddr("fd10:10:10::2/120").Return(vmAddr, nil)
+
+			Expect(configureVifV6Addresses(masq, nil)).To(Succeed())
+			Expect(masq.vif.GatewayIpv6).To(Equal(gwAddr.IP.To16()))
+			Expect(masq.vif.IPv6).To(Equal(*vmAddr))
+		})
+		It("should be autoconfigured from the MAC address on a /64 IPv6 CIDR", func() {
+			const vmIpv6NetworkCIDR = "fd10:10:10::/64"
+			vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+			vmi.Spec.Networks[0].Pod.VMIPv6NetworkCIDR = vmIpv6NetworkCIDR
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "de-ad-00-00-be-af"
+			driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], primaryPodInterfaceName, cacheFactory)
+			Expect(err).ToNot(HaveOccurred())
+			masq, ok := driver.(*MasqueradeBindMechanism)
+			Expect(ok).To(BeTrue())
+
+			gwAddr, _ := netlink.ParseAddr("fd10:10:10::1/64")
+			vmAddr, _ := netlink.ParseAddr("fd10:10:10::2/64")
+			mockNetwork.EXPECT().GetHostAndGwAddressesFromCIDR(vmIpv6NetworkCIDR).Return("fd10:10:10::1/64", "fd10:10:10::2/64", nil)
+			mockNetwork.EXPECT().ParseAddr("fd10:10:10::1/64").Return(gwAddr, nil)
+			mockNetwork.EXPECT().ParseAddr("fd10:10:10::2/64").Return(vmAddr, nil)
+
+			Expect(configureVifV6Addresses(masq, nil)).To(Succeed())
+			Expect(masq.vif.IPv6.String()).To(Equal("fd10:10:10:0:dcad:ff:fe00:beaf/64"))
+			Expect(masq.getMasqueradeSourceByProtocol(iptables.ProtocolIPv6)).To(Equal(vmIpv6NetworkCIDR))
+			Expect(masq.getVifIpByProtocol(iptables.ProtocolIPv6)).To(Equal("fd10:10:10:0:dcad:ff:fe00:beaf"))
+		})
+	})
 	Context("Bridge startDHCP", func() {
 		It("should succeed when DHCP server started", func() {
 			domain := NewDomainWithBridgeInterface()
@@ -862,6 +1031,26 @@ func newVMIMacvtapInterface(namespace string, vmiName string, ifaceName string)
 	return vmi
 }
 
+func newVMIPasstInterface(namespace string, vmiName string) *v1.VirtualMachineInstance {
+	vmi := newVMI(namespace, vmiName)
+	vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultPasstNetworkInterface()}
+	v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+	return vmi
+}
+
+func NewDomainWithPasstInterface() *api.Domain {
+	domain := &api.Domain{}
+	domain.Spec.Devices.Interfaces = []api.Interface{{
+		Alias: api.NewUserDefinedAlias("default"),
+		Model: &api.Model{
+			Type: "virtio",
+		},
+		Type:    "user",
+		Backend: &api.InterfaceBackend{Type: "passt"},
+	}}
+	return domain
+}
+
 func NewDomainWithBridgeInterface() *api.Domain {
 	domain := &api.Domain{}
 	domain.Spec.Devices.Interfaces = []api.Interface{{
@@ -22,12 +22,28 @@ package util
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 )
 
+// KVMIntelNestedPath tells whether nested virtualization is enabled on an Intel host
+var KVMIntelNestedPath = "/sys/module/kvm_intel/parameters/nested"
+
+// IsEVMCSAvailable checks if the host can provide the enlightened VMCS to Hyper-V guests,
+// which requires an Intel host with nested virtualization enabled
+func IsEVMCSAvailable() bool {
+	nested, err := ioutil.ReadFile(KVMIntelNestedPath)
+	if err != nil {
+		return false
+	}
+	value := strings.TrimSpace(string(nested))
+	return value == "Y" || value == "1"
+}
+
 func GetPodCPUSet() ([]int, error) {
 	var cpuset string
 	file, err := os.Open(hardware.CPUSET_PATH)
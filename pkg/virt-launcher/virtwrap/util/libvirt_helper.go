@@ -408,6 +408,17 @@ func SetupLibvirt() (err error) {
 		return err
 	}
 
+	// If a PVC for memory dumps of panicked guests is mounted, tell libvirt to store the dumps there
+	_, err = os.Stat(util.PanicMemoryDumpDir)
+	if err == nil {
+		_, err = qemuConf.WriteString(fmt.Sprintf("auto_dump_path = \"%s\"\n", util.PanicMemoryDumpDir))
+		if err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
 	// Let libvirt log to stderr
 	libvirtConf, err := os.OpenFile("/etc/libvirt/libvirtd.conf", os.O_APPEND|os.O_WRONLY, 0644)
 	if err != nil {
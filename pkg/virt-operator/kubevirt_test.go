@@ -144,8 +144,8 @@ var _ = Describe("KubeVirt Operator", func() {
 	var totalDeletions int
 	var resourceChanges map[string]map[string]int
 
-	resourceCount := 53
-	patchCount := 34
+	resourceCount := 62
+	patchCount := 43
 	updateCount := 20
 
 	deleteFromCache := true
@@ -844,7 +844,12 @@ var _ = Describe("KubeVirt Operator", func() {
 			components.NewVirtualMachineInstanceCrd, components.NewPresetCrd, components.NewReplicaSetCrd,
 			components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 			components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
-			components.NewVirtualMachineRestoreCrd,
+			components.NewVirtualMachineRestoreCrd, components.NewVirtualMachineExportCrd,
+			components.NewVirtualMachineCloneCrd, components.NewVirtualMachineInstancetypeCrd,
+			components.NewVirtualMachineClusterInstancetypeCrd, components.NewVirtualMachinePreferenceCrd,
+			components.NewVirtualMachineClusterPreferenceCrd,
+			components.NewVirtualMachinePoolCrd, components.NewVirtualMachineSnapshotScheduleCrd,
+			components.NewStorageCheckupCrd,
 		}
 		for _, f := range functions {
 			crd, err := f()
@@ -1927,7 +1932,7 @@ var _ = Describe("KubeVirt Operator", func() {
 			Expect(len(controller.stores.ClusterRoleBindingCache.List())).To(Equal(5))
 			Expect(len(controller.stores.RoleCache.List())).To(Equal(3))
 			Expect(len(controller.stores.RoleBindingCache.List())).To(Equal(3))
-			Expect(len(controller.stores.CrdCache.List())).To(Equal(8))
+			Expect(len(controller.stores.CrdCache.List())).To(Equal(17))
 			Expect(len(controller.stores.ServiceCache.List())).To(Equal(3))
 			Expect(len(controller.stores.DeploymentCache.List())).To(Equal(1))
 			Expect(len(controller.stores.DaemonSetCache.List())).To(Equal(0))
@@ -87,6 +87,7 @@ func (r *Reconciler) syncDaemonSet(daemonSet *appsv1.DaemonSet) error {
 	injectOperatorMetadata(kv, &daemonSet.ObjectMeta, imageTag, imageRegistry, id, true)
 	injectOperatorMetadata(kv, &daemonSet.Spec.Template.ObjectMeta, imageTag, imageRegistry, id, false)
 	injectPlacementMetadata(kv.Spec.Workloads, &daemonSet.Spec.Template.Spec)
+	injectMigrationNetwork(kv, &daemonSet.Spec.Template.ObjectMeta)
 
 	kvkey, err := controller.KeyFunc(kv)
 	if err != nil {
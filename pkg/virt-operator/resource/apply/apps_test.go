@@ -330,6 +330,28 @@ var _ = Describe("Apply Apps", func() {
 		})
 	})
 
+	Context("on calling injectMigrationNetwork", func() {
+
+		It("should attach the pods to the migration network", func() {
+			kv := &v1.KubeVirt{}
+			network := "default/migration"
+			kv.Spec.Configuration.MigrationConfiguration = &v1.MigrationConfiguration{Network: &network}
+
+			daemonSet := appsv1.DaemonSet{}
+			injectMigrationNetwork(kv, &daemonSet.Spec.Template.ObjectMeta)
+			Expect(daemonSet.Spec.Template.Annotations).To(HaveKeyWithValue("k8s.v1.cni.cncf.io/networks", "default/migration@migration0"))
+		})
+
+		It("should not attach the pods to a network by default", func() {
+			kv := &v1.KubeVirt{}
+			kv.Spec.Configuration.MigrationConfiguration = &v1.MigrationConfiguration{}
+
+			daemonSet := appsv1.DaemonSet{}
+			injectMigrationNetwork(kv, &daemonSet.Spec.Template.ObjectMeta)
+			Expect(daemonSet.Spec.Template.Annotations).ToNot(HaveKey("k8s.v1.cni.cncf.io/networks"))
+		})
+	})
+
 	Context("on calling injectPlacementMetadata", func() {
 		var componentConfig *v1.ComponentConfig
 		var nodePlacement *v1.NodePlacement
@@ -46,6 +46,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/certificates/triple"
 	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/install"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
@@ -102,6 +103,20 @@ func injectOperatorMetadata(kv *v1.KubeVirt, objectMeta *metav1.ObjectMeta, vers
 	}
 }
 
+const multusNetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
+
+// Attach the virt-handler pods to the migration network, if one is configured, under a well known interface name
+func injectMigrationNetwork(kv *v1.KubeVirt, objectMeta *metav1.ObjectMeta) {
+	migrationConfig := kv.Spec.Configuration.MigrationConfiguration
+	if migrationConfig == nil || migrationConfig.Network == nil || *migrationConfig.Network == "" {
+		return
+	}
+	if objectMeta.Annotations == nil {
+		objectMeta.Annotations = make(map[string]string)
+	}
+	objectMeta.Annotations[multusNetworksAnnotation] = fmt.Sprintf("%s@%s", *migrationConfig.Network, migrations.MigrationInterfaceName)
+}
+
 const (
 	kubernetesOSLabel = "kubernetes.io/os"
 	kubernetesOSLinux = "linux"
@@ -32,6 +32,12 @@ import (
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	instancetypeapi "kubevirt.io/client-go/apis/instancetype"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 )
 
@@ -40,15 +46,23 @@ const (
 )
 
 var (
-	VIRTUALMACHINE                   = "virtualmachines." + virtv1.VirtualMachineInstanceGroupVersionKind.Group
-	VIRTUALMACHINEINSTANCE           = "virtualmachineinstances." + virtv1.VirtualMachineInstanceGroupVersionKind.Group
-	VIRTUALMACHINEINSTANCEPRESET     = "virtualmachineinstancepresets." + virtv1.VirtualMachineInstancePresetGroupVersionKind.Group
-	VIRTUALMACHINEINSTANCEREPLICASET = "virtualmachineinstancereplicasets." + virtv1.VirtualMachineInstanceReplicaSetGroupVersionKind.Group
-	VIRTUALMACHINEINSTANCEMIGRATION  = "virtualmachineinstancemigrations." + virtv1.VirtualMachineInstanceMigrationGroupVersionKind.Group
-	KUBEVIRT                         = "kubevirts." + virtv1.KubeVirtGroupVersionKind.Group
-	VIRTUALMACHINESNAPSHOT           = "virtualmachinesnapshots." + snapshotv1.SchemeGroupVersion.Group
-	VIRTUALMACHINESNAPSHOTCONTENT    = "virtualmachinesnapshotcontents." + snapshotv1.SchemeGroupVersion.Group
-	PreserveUnknownFieldsFalse       = false
+	VIRTUALMACHINE                    = "virtualmachines." + virtv1.VirtualMachineInstanceGroupVersionKind.Group
+	VIRTUALMACHINEINSTANCE            = "virtualmachineinstances." + virtv1.VirtualMachineInstanceGroupVersionKind.Group
+	VIRTUALMACHINEINSTANCEPRESET      = "virtualmachineinstancepresets." + virtv1.VirtualMachineInstancePresetGroupVersionKind.Group
+	VIRTUALMACHINEINSTANCEREPLICASET  = "virtualmachineinstancereplicasets." + virtv1.VirtualMachineInstanceReplicaSetGroupVersionKind.Group
+	VIRTUALMACHINEINSTANCEMIGRATION   = "virtualmachineinstancemigrations." + virtv1.VirtualMachineInstanceMigrationGroupVersionKind.Group
+	KUBEVIRT                          = "kubevirts." + virtv1.KubeVirtGroupVersionKind.Group
+	VIRTUALMACHINESNAPSHOT            = "virtualmachinesnapshots." + snapshotv1.SchemeGroupVersion.Group
+	VIRTUALMACHINESNAPSHOTCONTENT     = "virtualmachinesnapshotcontents." + snapshotv1.SchemeGroupVersion.Group
+	VIRTUALMACHINEEXPORT              = "virtualmachineexports." + exportv1.SchemeGroupVersion.Group
+	VIRTUALMACHINECLONE               = "virtualmachineclones." + clonev1.SchemeGroupVersion.Group
+	VIRTUALMACHINEINSTANCETYPE        = instancetypeapi.PluralResourceName + "." + instancetypev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINECLUSTERINSTANCETYPE = instancetypeapi.ClusterPluralResourceName + "." + instancetypev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINEPREFERENCE          = instancetypeapi.PluralPreferenceResourceName + "." + instancetypev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINECLUSTERPREFERENCE   = instancetypeapi.ClusterPluralPreferenceResourceName + "." + instancetypev1alpha1.SchemeGroupVersion.Group
+	VIRTUALMACHINEPOOL                = "virtualmachinepools." + poolv1.SchemeGroupVersion.Group
+	STORAGECHECKUP                    = "storagecheckups." + checkupv1.SchemeGroupVersion.Group
+	PreserveUnknownFieldsFalse        = false
 )
 
 func patchValidation(crd *extv1beta1.CustomResourceDefinition) error {
@@ -418,6 +432,336 @@ func NewVirtualMachineRestoreCrd() (*extv1beta1.CustomResourceDefinition, error)
 	return crd, nil
 }
 
+func NewVirtualMachineSnapshotScheduleCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachinesnapshotschedules." + snapshotv1.SchemeGroupVersion.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   snapshotv1.SchemeGroupVersion.Group,
+		Version: snapshotv1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    snapshotv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinesnapshotschedules",
+			Singular:   "virtualmachinesnapshotschedule",
+			Kind:       "VirtualMachineSnapshotSchedule",
+			ShortNames: []string{"vmsnapshotschedule", "vmsnapshotschedules"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "SourceKind", Type: "string", JSONPath: ".spec.source.kind"},
+			{Name: "SourceName", Type: "string", JSONPath: ".spec.source.name"},
+			{Name: "Schedule", Type: "string", JSONPath: ".spec.schedule"},
+			{Name: "LastScheduleTime", Type: "date", JSONPath: ".status.lastScheduleTime"},
+			{Name: "Error", Type: "string", JSONPath: ".status.error.message"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineExportCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEEXPORT
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   exportv1.SchemeGroupVersion.Group,
+		Version: exportv1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    exportv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineexports",
+			Singular:   "virtualmachineexport",
+			Kind:       "VirtualMachineExport",
+			ShortNames: []string{"vmexport", "vmexports"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "SourceKind", Type: "string", JSONPath: ".spec.source.kind"},
+			{Name: "SourceName", Type: "string", JSONPath: ".spec.source.name"},
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineCloneCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINECLONE
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   clonev1.SchemeGroupVersion.Group,
+		Version: clonev1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    clonev1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineclones",
+			Singular:   "virtualmachineclone",
+			Kind:       "VirtualMachineClone",
+			ShortNames: []string{"vmclone", "vmclones"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+			{Name: "SourceVirtualMachine", Type: "string", JSONPath: ".spec.source.name"},
+			{Name: "TargetVirtualMachine", Type: "string", JSONPath: ".status.targetName"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineInstancetypeCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEINSTANCETYPE
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   instancetypev1alpha1.SchemeGroupVersion.Group,
+		Version: instancetypev1alpha1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    instancetypev1alpha1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     instancetypeapi.PluralResourceName,
+			Singular:   instancetypeapi.SingularResourceName,
+			Kind:       "VirtualMachineInstancetype",
+			ShortNames: []string{"vminstancetype", "vminstancetypes"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineClusterInstancetypeCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINECLUSTERINSTANCETYPE
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   instancetypev1alpha1.SchemeGroupVersion.Group,
+		Version: instancetypev1alpha1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    instancetypev1alpha1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Cluster",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     instancetypeapi.ClusterPluralResourceName,
+			Singular:   instancetypeapi.ClusterSingularResourceName,
+			Kind:       "VirtualMachineClusterInstancetype",
+			ShortNames: []string{"vmclusterinstancetype", "vmclusterinstancetypes"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachinePreferenceCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEPREFERENCE
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   instancetypev1alpha1.SchemeGroupVersion.Group,
+		Version: instancetypev1alpha1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    instancetypev1alpha1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     instancetypeapi.PluralPreferenceResourceName,
+			Singular:   instancetypeapi.SingularPreferenceResourceName,
+			Kind:       "VirtualMachinePreference",
+			ShortNames: []string{"vmpref", "vmprefs"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineClusterPreferenceCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINECLUSTERPREFERENCE
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   instancetypev1alpha1.SchemeGroupVersion.Group,
+		Version: instancetypev1alpha1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    instancetypev1alpha1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Cluster",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     instancetypeapi.ClusterPluralPreferenceResourceName,
+			Singular:   instancetypeapi.ClusterSingularPreferenceResourceName,
+			Kind:       "VirtualMachineClusterPreference",
+			ShortNames: []string{"vmclusterpref", "vmclusterprefs"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachinePoolCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+	labelSelector := ".status.labelSelector"
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEPOOL
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   poolv1.SchemeGroupVersion.Group,
+		Version: poolv1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    poolv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinepools",
+			Singular:   "virtualmachinepool",
+			Kind:       "VirtualMachinePool",
+			ShortNames: []string{"vmpool", "vmpools"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "Desired", Type: "integer", JSONPath: ".spec.replicas",
+				Description: "Number of desired VirtualMachines"},
+			{Name: "Current", Type: "integer", JSONPath: ".status.replicas",
+				Description: "Number of managed and not deleted VirtualMachines"},
+			{Name: "Ready", Type: "integer", JSONPath: ".status.readyReplicas",
+				Description: "Number of managed VirtualMachines which are ready"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+		Subresources: &extv1beta1.CustomResourceSubresources{
+			Scale: &extv1beta1.CustomResourceSubresourceScale{
+				SpecReplicasPath:   ".spec.replicas",
+				StatusReplicasPath: ".status.replicas",
+				LabelSelectorPath:  &labelSelector,
+			},
+			Status: &extv1beta1.CustomResourceSubresourceStatus{},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewStorageCheckupCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = STORAGECHECKUP
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   checkupv1.SchemeGroupVersion.Group,
+		Version: checkupv1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    checkupv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:   "storagecheckups",
+			Singular: "storagecheckup",
+			Kind:     "StorageCheckup",
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "StorageClass", Type: "string", JSONPath: ".spec.storageClassName"},
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+		Subresources: &extv1beta1.CustomResourceSubresources{
+			Status: &extv1beta1.CustomResourceSubresourceStatus{},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
 func NewServiceMonitorCR(namespace string, monitorNamespace string, insecureSkipVerify bool) *promv1.ServiceMonitor {
 	return &promv1.ServiceMonitor{
 		TypeMeta: metav1.TypeMeta{
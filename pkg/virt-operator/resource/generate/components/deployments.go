@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/rbac"
 	operatorutil "kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
@@ -530,10 +531,62 @@ func NewHandlerDaemonSet(namespace string, repository string, imagePrefix string
 		})
 	}
 
+	pod.Containers = append(pod.Containers, newPrHelperContainer(container.Image, pullPolicy))
+	prHelperSocketDirType := corev1.HostPathDirectoryOrCreate
+	pod.Volumes = append(pod.Volumes,
+		corev1.Volume{
+			Name: "pr-helper-socket",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: util.PrHelperSocketDir,
+					Type: &prHelperSocketDirType,
+				},
+			},
+		},
+		corev1.Volume{
+			Name: "dev",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: "/dev",
+				},
+			},
+		},
+	)
+
 	return daemonset, nil
 
 }
 
+// newPrHelperContainer runs the qemu-pr-helper daemon, which executes the SCSI persistent reservation
+// commands of the LUN disks on behalf of the unprivileged QEMU processes on the node
+func newPrHelperContainer(image string, pullPolicy corev1.PullPolicy) corev1.Container {
+	bidi := corev1.MountPropagationBidirectional
+	return corev1.Container{
+		Name:            "pr-helper",
+		Image:           image,
+		ImagePullPolicy: pullPolicy,
+		Command: []string{
+			"/usr/bin/qemu-pr-helper",
+			"-k",
+			util.PrHelperSocketPath,
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: boolPtr(true),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "pr-helper-socket",
+				MountPath: util.PrHelperSocketDir,
+			},
+			{
+				Name:             "dev",
+				MountPath:        "/dev",
+				MountPropagation: &bidi,
+			},
+		},
+	}
+}
+
 // Used for manifest generation only
 func NewOperatorDeployment(namespace string, repository string, imagePrefix string, version string,
 	pullPolicy corev1.PullPolicy, verbosity string,
@@ -277,6 +277,94 @@ var CRDsValidation map[string]string = map[string]string{
         configuration:
           description: holds kubevirt configurations. same as the virt-configMap
           properties:
+            architectureConfiguration:
+              description: ArchConfiguration holds the architecture specific defaults of VMIs. The deprecated machineType, emulatedMachines and ovmfPath of the KubeVirt configuration take precedence over the architecture specific values if set.
+              properties:
+                amd64:
+                  description: ArchSpecificConfiguration holds the defaults of VMIs of a single architecture
+                  properties:
+                    emulatedMachines:
+                      items:
+                        type: string
+                      type: array
+                    machineType:
+                      type: string
+                    ovmfPath:
+                      type: string
+                  type: object
+                arm64:
+                  description: ArchSpecificConfiguration holds the defaults of VMIs of a single architecture
+                  properties:
+                    emulatedMachines:
+                      items:
+                        type: string
+                      type: array
+                    machineType:
+                      type: string
+                    ovmfPath:
+                      type: string
+                  type: object
+                defaultArchitecture:
+                  description: DefaultArchitecture is the architecture of VMIs which do not request one. Defaults to the architecture of the KubeVirt control plane.
+                  type: string
+                ppc64le:
+                  description: ArchSpecificConfiguration holds the defaults of VMIs of a single architecture
+                  properties:
+                    emulatedMachines:
+                      items:
+                        type: string
+                      type: array
+                    machineType:
+                      type: string
+                    ovmfPath:
+                      type: string
+                  type: object
+                s390x:
+                  description: ArchSpecificConfiguration holds the defaults of VMIs of a single architecture
+                  properties:
+                    emulatedMachines:
+                      items:
+                        type: string
+                      type: array
+                    machineType:
+                      type: string
+                    ovmfPath:
+                      type: string
+                  type: object
+              type: object
+            clusterCommonCPU:
+              description: ClusterCommonCPUConfiguration holds the options of the cluster-common CPU model
+              properties:
+                nodeSelector:
+                  additionalProperties:
+                    type: string
+                  description: NodeSelector restricts the nodes whose common CPU model and features are used for VMIs requesting the cluster-common CPU model. Defaults to all schedulable nodes.
+                  type: object
+              type: object
+            containerDiskCache:
+              description: ContainerDiskCacheConfiguration enables the node cache of containerDisk images. VMIs on the same node which use the same image share a single copy of it.
+              properties:
+                sizeLimit:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: SizeLimit is the size of the cache on each node, beyond which the least recently used images which are not in use are removed. Defaults to 20Gi.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+              type: object
+            containerDisks:
+              description: ContainerDiskConfiguration makes virt-controller resolve the tags of containerDisk images to digests in their registries when a VMI starts, and optionally verify the cosign signatures of the images.
+              properties:
+                resolveDigests:
+                  description: ResolveDigests makes virt-controller resolve the tags of containerDisk images to digests when a VMI starts, so that its virt-launcher pods pull exactly the resolved images.
+                  type: boolean
+                signatureKeys:
+                  description: SignatureKeys are PEM encoded ECDSA public keys of cosign. If any is set, the digests are resolved and a VMI only starts if each of its containerDisk images has a cosign signature made with one of the keys.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+              type: object
             cpuModel:
               type: string
             cpuRequest:
@@ -328,14 +416,117 @@ var CRDsValidation map[string]string = map[string]string{
               items:
                 type: string
               type: array
+            ephemeralDiskBackings:
+              items:
+                description: EphemeralDiskBacking stores the ephemeral disk data of the VMIs, like the overlays of their containerDisks and ephemeral volumes, on the nodes matching its node selector outside of the ephemeral storage of their pods. virt-handler mounts the first backing selecting the node on /var/lib/kubevirt/ephemeral-disks and labels the node with kubevirt.io/ephemeral-disk-backing=true. The VMIs whose node selector only matches nodes selected by a backing store their ephemeral disk data there and are only scheduled to nodes carrying the label. Exactly one of hostPath, blockDevice and tmpfs must be set.
+                properties:
+                  blockDevice:
+                    description: BlockDevice is a dedicated block device on the node, formatted with a filesystem, which is mounted to store the ephemeral disk data.
+                    type: string
+                  hostPath:
+                    description: HostPath is a directory on the node which is bind mounted to store the ephemeral disk data.
+                    type: string
+                  nodeSelector:
+                    additionalProperties:
+                      type: string
+                    description: NodeSelector selects the nodes using the backing. An empty selector selects all nodes.
+                    type: object
+                  tmpfs:
+                    description: Tmpfs stores the ephemeral disk data in the memory of the node.
+                    properties:
+                      sizeLimit:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: SizeLimit is the size of the tmpfs. Defaults to half of the memory of the node.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                    type: object
+                type: object
+              type: array
             imagePullPolicy:
               description: PullPolicy describes a policy for if/when to pull a container image
               type: string
+            ksmConfiguration:
+              description: KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).
+              properties:
+                nodeLabelSelector:
+                  description: NodeLabelSelector is a selector that filters in which nodes the KSM will be enabled. Empty NodeLabelSelector will enable ksm for every node.
+                  properties:
+                    matchExpressions:
+                      description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                      items:
+                        description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                        properties:
+                          key:
+                            description: key is the label key that the selector applies to.
+                            type: string
+                          operator:
+                            description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                            type: string
+                          values:
+                            description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                            items:
+                              type: string
+                            type: array
+                        required:
+                        - key
+                        - operator
+                        type: object
+                      type: array
+                    matchLabels:
+                      additionalProperties:
+                        type: string
+                      description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                      type: object
+                  type: object
+              type: object
             machineType:
               type: string
+            mediatedDevicesConfiguration:
+              description: MediatedDevicesConfiguration holds the mediated device types which virt-handler creates on the nodes. Mediated devices of other types are removed from the nodes once a configuration is present.
+              properties:
+                mediatedDevicesTypes:
+                  description: MediatedDevicesTypes are the mediated device types created on all nodes. Every parent device is configured with the first type it supports.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+                nodeMediatedDeviceTypes:
+                  description: NodeMediatedDeviceTypes override the mediated device types on the nodes matching their node selector.
+                  items:
+                    description: NodeMediatedDeviceTypesConfig holds the mediated device types of the nodes matching the node selector
+                    properties:
+                      mediatedDevicesTypes:
+                        items:
+                          type: string
+                        type: array
+                        x-kubernetes-list-type: atomic
+                      nodeSelector:
+                        additionalProperties:
+                          type: string
+                        description: NodeSelector selects the nodes the mediated device types are created on.
+                        type: object
+                    required:
+                    - nodeSelector
+                    - mediatedDevicesTypes
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+              type: object
             memBalloonStatsPeriod:
               format: int32
               type: integer
+            memoryOvercommitPolicy:
+              description: MemoryOvercommitPolicy defines how the memory of VMIs is overcommitted on the nodes.
+              properties:
+                overcommitGuestOverhead:
+                  description: OvercommitGuestOverhead excludes the memory overhead of all VMIs from the memory request of their pods.
+                  type: boolean
+                ratio:
+                  description: Ratio is the percentage of the guest memory relative to the memory request of VMIs which do not request memory explicitly. A ratio of 150 results in a memory request of two thirds of the guest memory. Values below 100 are ignored. Takes precedence over developerConfiguration.memoryOvercommit.
+                  type: integer
+              type: object
             migrations:
               description: MigrationConfiguration holds migration options
               properties:
@@ -352,6 +543,9 @@ var CRDsValidation map[string]string = map[string]string{
                 completionTimeoutPerGiB:
                   format: int64
                   type: integer
+                network:
+                  description: Network is the name of a Multus NetworkAttachmentDefinition, in <namespace>/<name> or <name> format, through which the migration traffic goes. By default the pod network is used.
+                  type: string
                 nodeDrainTaintKey:
                   type: string
                 parallelMigrationsPerCluster:
@@ -369,8 +563,60 @@ var CRDsValidation map[string]string = map[string]string{
             network:
               description: NetworkConfiguration holds network options
               properties:
+                attachmentPolicy:
+                  description: AttachmentPolicy restricts the NetworkAttachmentDefinitions which the VMIs of each namespace can reference. VMIs can reference any NetworkAttachmentDefinition if it is not set.
+                  properties:
+                    rules:
+                      description: Rules grant access to NetworkAttachmentDefinitions. A VMI can only reference the NetworkAttachmentDefinitions allowed by the rules which apply to its namespace.
+                      items:
+                        description: NetworkAttachmentPolicyRule allows the VMIs of namespaces to reference NetworkAttachmentDefinitions.
+                        properties:
+                          namespaces:
+                            description: Namespaces the rule applies to. The names may be shell patterns, e.g. "tenant-*" or "*".
+                            items:
+                              type: string
+                            type: array
+                          networkAttachmentDefinitions:
+                            description: NetworkAttachmentDefinitions allowed by the rule, in <namespace>/<name> format. The namespace and the name may be shell patterns, e.g. "tenant-a/*". A name without namespace refers to the namespace of the VMI.
+                            items:
+                              type: string
+                            type: array
+                        required:
+                        - namespaces
+                        - networkAttachmentDefinitions
+                        type: object
+                      type: array
+                  required:
+                  - rules
+                  type: object
+                binding:
+                  additionalProperties:
+                    description: InterfaceBindingPlugin describes how a network binding plugin is deployed with the virt-launcher pod.
+                    properties:
+                      networkAttachmentDefinition:
+                        description: NetworkAttachmentDefinition references, in <namespace>/<name> format, the NetworkAttachmentDefinition whose CNI plugin configures the pod network of the interface.
+                        type: string
+                      sidecarImage:
+                        description: SidecarImage references a container image that runs in the virt-launcher pod. The sidecar adjusts the domain of the VMI through the hooks API.
+                        type: string
+                    type: object
+                  description: Binding registers the network binding plugins, by name, which VMI interfaces can reference.
+                  type: object
                 defaultNetworkInterface:
                   type: string
+                macPool:
+                  description: MacPool configures the range of MAC addresses which are assigned to the interfaces of VirtualMachines which don't request a specific address.
+                  properties:
+                    rangeEnd:
+                      description: RangeEnd is the last MAC address of the pool, e.g. 02:00:00:ff:ff:ff.
+                      type: string
+                    rangeStart:
+                      description: RangeStart is the first MAC address of the pool, e.g. 02:00:00:00:00:00.
+                      type: string
+                  required:
+                  - rangeStart
+                  - rangeEnd
+                  type: object
                 permitBridgeInterfaceOnPodNetwork:
                   type: boolean
                 permitSlirpInterface:
@@ -413,6 +659,25 @@ var CRDsValidation map[string]string = map[string]string{
                     type: object
                   type: array
                   x-kubernetes-list-type: atomic
+                usb:
+                  items:
+                    description: USBHostDevice represents a host USB device allowed for passthrough
+                    properties:
+                      externalResourceProvider:
+                        type: boolean
+                      resourceName:
+                        type: string
+                      usbPortSelector:
+                        description: USBPortSelector selects the USB device connected to a bus and port, as named in /sys/bus/usb/devices, e.g. "1-2.3". Exactly one of the selectors has to be set.
+                        type: string
+                      usbVendorSelector:
+                        description: USBVendorSelector selects the USB devices by their vendor:product ID, e.g. "0529:0001".
+                        type: string
+                    required:
+                    - resourceName
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
               type: object
             selinuxLauncherType:
               type: string
@@ -433,6 +698,8 @@ var CRDsValidation map[string]string = map[string]string{
               items:
                 type: string
               type: array
+            vmStateStorageClass:
+              type: string
           type: object
         customizeComponents:
           properties:
@@ -1308,6 +1575,95 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"storagecheckup": `openAPIV3Schema:
+  description: StorageCheckup is a CRD that runs a short diagnostic of a storage class. It provisions a scratch volume, measures it, attaches it to a scratch VirtualMachineInstance and takes and restores a snapshot of it, then reports the results in its status.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: StorageCheckupSpec is the spec for a StorageCheckup resource
+      properties:
+        size:
+          anyOf:
+          - type: integer
+          - type: string
+          description: Size is the size of the scratch volume. Defaults to 1Gi.
+          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+          x-kubernetes-int-or-string: true
+        storageClassName:
+          description: StorageClassName is the name of the storage class to check
+          type: string
+        timeout:
+          description: Timeout bounds the duration of the whole checkup. Defaults to 10m.
+          type: string
+        volumeMode:
+          description: VolumeMode is the volume mode of the scratch volume. The default of the storage class is used if not set.
+          type: string
+        volumeSnapshotClassName:
+          description: VolumeSnapshotClassName is the name of the VolumeSnapshotClass used for the snapshot and restore round-trip. If not set, the VolumeSnapshotClass matching the provisioner of the storage class is used, and the round-trip is skipped if there is none.
+          type: string
+      required:
+      - storageClassName
+      type: object
+    status:
+      description: StorageCheckupStatus is the status for a StorageCheckup resource
+      properties:
+        completionTimestamp:
+          format: date-time
+          type: string
+        message:
+          description: Message describes the step the checkup is running or why it failed
+          type: string
+        phase:
+          description: StorageCheckupPhase is the current phase of the StorageCheckup
+          type: string
+        results:
+          description: StorageCheckupResults are the measurements of a StorageCheckup. The measurements of the steps which did not run are not set.
+          properties:
+            attachDuration:
+              description: AttachDuration is the time it took for a VirtualMachineInstance using the scratch volume to be running
+              type: string
+            provisionDuration:
+              description: ProvisionDuration is the time it took for the scratch volume to be bound
+              type: string
+            readLatency:
+              description: ReadLatency is the average latency of a 4KiB read on the scratch volume
+              type: string
+            readThroughput:
+              anyOf:
+              - type: integer
+              - type: string
+              description: ReadThroughput is the sequential read throughput of the scratch volume, in bytes per second
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            snapshotRestoreDuration:
+              description: SnapshotRestoreDuration is the time it took to snapshot the scratch volume and to restore the snapshot to a new volume
+              type: string
+            writeLatency:
+              description: WriteLatency is the average latency of a 4KiB write on the scratch volume
+              type: string
+            writeThroughput:
+              anyOf:
+              - type: integer
+              - type: string
+              description: WriteThroughput is the sequential write throughput of the scratch volume, in bytes per second
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+          type: object
+        startTimestamp:
+          format: date-time
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"virtualmachine": `openAPIV3Schema:
   description: VirtualMachine handles the VirtualMachines that are not running or are in a stopped state The VirtualMachine contains the template to create the VirtualMachineInstance. It also mirrors the running state of the created VirtualMachineInstance in its status.
@@ -1573,6 +1929,36 @@ var CRDsValidation map[string]string = map[string]string{
             - spec
             type: object
           type: array
+        instancetype:
+          description: InstancetypeMatcher references a instancetype that is used to fill fields in Template
+          properties:
+            kind:
+              description: 'Kind specifies which instancetype resource is referenced. Allowed values are: "VirtualMachineInstancetype" and "VirtualMachineClusterInstancetype". If not specified, "VirtualMachineClusterInstancetype" is used by default.'
+              type: string
+            name:
+              description: Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype
+              type: string
+            revisionName:
+              description: RevisionName specifies a ControllerRevision containing a specific copy of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype to be used. This is initially captured the first time the instancetype is applied to the VirtualMachineInstance.
+              type: string
+          required:
+          - name
+          type: object
+        preference:
+          description: PreferenceMatcher references a set of preference that is used to fill fields in Template
+          properties:
+            kind:
+              description: 'Kind specifies which preference resource is referenced. Allowed values are: "VirtualMachinePreference" and "VirtualMachineClusterPreference". If not specified, "VirtualMachineClusterPreference" is used by default.'
+              type: string
+            name:
+              description: Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference
+              type: string
+            revisionName:
+              description: RevisionName specifies a ControllerRevision containing a specific copy of the VirtualMachinePreference or VirtualMachineClusterPreference to be used. This is initially captured the first time the instancetype is applied to the VirtualMachineInstance.
+              type: string
+          required:
+          - name
+          type: object
         runStrategy:
           description: Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running
           type: string
@@ -1603,6 +1989,9 @@ var CRDsValidation map[string]string = map[string]string{
                               configDrive:
                                 description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
                                 type: object
+                              noCloud:
+                                description: NoCloudPropagation means that the ssh public keys are injected into the VM using metadata using the noCloud cloud-init provider
+                                type: object
                               qemuGuestAgent:
                                 description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
                                 properties:
@@ -2004,6 +2393,9 @@ var CRDsValidation map[string]string = map[string]string{
                           type: array
                       type: object
                   type: object
+                architecture:
+                  description: Specifies the architecture of the vm guest you are attempting to run. Defaults to the default architecture of the cluster.
+                  type: string
                 dnsConfig:
                   description: Specifies the DNS parameters of a pod. Parameters specified here will be merged to the generated DNS configuration based on DNSPolicy.
                   properties:
@@ -2143,9 +2535,27 @@ var CRDsValidation map[string]string = map[string]string{
                         isolateEmulatorThread:
                           description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                           type: boolean
+                        maxSockets:
+                          description: MaxSockets specifies the maximum amount of sockets that can be hotplugged into a running vmi. Must be a value greater or equal to Sockets.
+                          format: int32
+                          type: integer
                         model:
                           description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                           type: string
+                        numa:
+                          description: NUMA allows specifying settings for the guest NUMA topology
+                          properties:
+                            guestMappingPassthrough:
+                              description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+                              type: object
+                          type: object
+                        realtime:
+                          description: Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
+                          properties:
+                            mask:
+                              description: 'Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt''s expressions. Example: "0-3,^1","0,2,3","2-3"'
+                              type: string
+                          type: object
                         sockets:
                           description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                           format: int32
@@ -2164,15 +2574,38 @@ var CRDsValidation map[string]string = map[string]string{
                         autoattachMemBalloon:
                           description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                           type: boolean
+                        autoattachPanicDevice:
+                          description: Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor. Defaults to true.
+                          type: boolean
                         autoattachPodInterface:
                           description: Whether to attach a pod network interface. Defaults to true.
                           type: boolean
                         autoattachSerialConsole:
                           description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                           type: boolean
+                        autoattachVSOCK:
+                          description: Whether to attach the VSOCK CID to the VM or not. VSOCK access will be available if set to true. Defaults to false.
+                          type: boolean
                         blockMultiQueue:
                           description: Whether or not to enable virtio multi-queue for block devices
                           type: boolean
+                        channels:
+                          description: Virtio channels of the vmi, e.g. for custom guest agents. Each channel can be connected to through the channel subresource.
+                          items:
+                            description: Channel represents a virtio channel of the vmi
+                            properties:
+                              name:
+                                description: Name of the channel, used to connect to it through the channel subresource. Must be a DNS_LABEL and unique within the vmi.
+                                type: string
+                              targetName:
+                                description: TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.
+                                type: string
+                            required:
+                            - name
+                            - targetName
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         disableHotplug:
                           description: DisableHotplug disabled the ability to hotplug disks.
                           type: boolean
@@ -2184,13 +2617,13 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
                                 type: integer
                               cache:
-                                description: Cache specifies which kvm disk cache mode should be used.
+                                description: 'Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.'
                                 type: string
                               cdrom:
                                 description: Attach a volume as a cdrom to the vmi.
                                 properties:
                                   bus:
-                                    description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                    description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                                     type: string
                                   readonly:
                                     description: ReadOnly. Defaults to true.
@@ -2202,11 +2635,17 @@ var CRDsValidation map[string]string = map[string]string{
                               dedicatedIOThread:
                                 description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
                                 type: boolean
+                              detectZeroes:
+                                description: 'DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.'
+                                type: string
+                              discard:
+                                description: 'Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.'
+                                type: string
                               disk:
                                 description: Attach a volume as a disk to the vmi.
                                 properties:
                                   bus:
-                                    description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                    description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                                     type: string
                                   pciAddress:
                                     description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
@@ -2215,6 +2654,24 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: ReadOnly. Defaults to false.
                                     type: boolean
                                 type: object
+                              encryption:
+                                description: Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+                                properties:
+                                  format:
+                                    description: Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.
+                                    type: string
+                                  secretName:
+                                    description: SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+                                    type: string
+                                required:
+                                - secretName
+                                type: object
+                              excludeFromSnapshot:
+                                description: ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.
+                                type: boolean
+                              expandGuestFilesystem:
+                                description: ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.
+                                type: boolean
                               floppy:
                                 description: Attach a volume as a floppy to the vmi.
                                 properties:
@@ -2228,6 +2685,74 @@ var CRDsValidation map[string]string = map[string]string{
                               io:
                                 description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                                 type: string
+                              ioTune:
+                                description: IOTune limits the I/O operations and the bandwidth of the disk.
+                                properties:
+                                  bandwidth:
+                                    description: Bandwidth limits the bytes per second of the disk.
+                                    properties:
+                                      burstLength:
+                                        description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                        format: int64
+                                        type: integer
+                                      read:
+                                        description: Read limits the reads.
+                                        format: int64
+                                        type: integer
+                                      readBurst:
+                                        description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                      total:
+                                        description: Total limits the sum of reads and writes.
+                                        format: int64
+                                        type: integer
+                                      totalBurst:
+                                        description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                      write:
+                                        description: Write limits the writes.
+                                        format: int64
+                                        type: integer
+                                      writeBurst:
+                                        description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                  iops:
+                                    description: IOPS limits the I/O operations per second of the disk.
+                                    properties:
+                                      burstLength:
+                                        description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                        format: int64
+                                        type: integer
+                                      read:
+                                        description: Read limits the reads.
+                                        format: int64
+                                        type: integer
+                                      readBurst:
+                                        description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                      total:
+                                        description: Total limits the sum of reads and writes.
+                                        format: int64
+                                        type: integer
+                                      totalBurst:
+                                        description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                      write:
+                                        description: Write limits the writes.
+                                        format: int64
+                                        type: integer
+                                      writeBurst:
+                                        description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -2237,6 +2762,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   readonly:
                                     description: ReadOnly. Defaults to false.
                                     type: boolean
+                                  reservation:
+                                    description: Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.
+                                    type: boolean
                                 type: object
                               name:
                                 description: Name is the device name
@@ -2252,7 +2780,7 @@ var CRDsValidation map[string]string = map[string]string{
                             type: object
                           type: array
                         filesystems:
-                          description: Filesystems describes filesystem which is connected to the vmi.
+                          description: Filesystems describes filesystem which is connected to the vmi. The volume with the name of the filesystem is shared into the guest with virtiofs, it can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.
                           items:
                             properties:
                               name:
@@ -2260,13 +2788,60 @@ var CRDsValidation map[string]string = map[string]string{
                                 type: string
                               virtiofs:
                                 description: Virtiofs is supported
-                                type: object
-                            required:
-                            - name
-                            - virtiofs
-                            type: object
-                          type: array
-                          x-kubernetes-list-type: atomic
+                                properties:
+                                  gidMap:
+                                    description: GIDMap maps the group IDs of the guest to the group IDs of the shared directory.
+                                    properties:
+                                      count:
+                                        description: Count is the number of IDs in the range, it must be greater than 0.
+                                        format: int32
+                                        type: integer
+                                      guestID:
+                                        description: GuestID is the first ID of the range in the guest.
+                                        format: int32
+                                        type: integer
+                                      hostID:
+                                        description: HostID is the first ID of the range in the shared directory.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - guestID
+                                    - hostID
+                                    - count
+                                    type: object
+                                  subPath:
+                                    description: SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root. It allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.
+                                    type: string
+                                  uidMap:
+                                    description: UIDMap maps the user IDs of the guest to the user IDs of the shared directory.
+                                    properties:
+                                      count:
+                                        description: Count is the number of IDs in the range, it must be greater than 0.
+                                        format: int32
+                                        type: integer
+                                      guestID:
+                                        description: GuestID is the first ID of the range in the guest.
+                                        format: int32
+                                        type: integer
+                                      hostID:
+                                        description: HostID is the first ID of the range in the shared directory.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - guestID
+                                    - hostID
+                                    - count
+                                    type: object
+                                type: object
+                            required:
+                            - name
+                            - virtiofs
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                        freePageReporting:
+                          description: Whether to enable free page reporting on the memory balloon device, so that memory freed by the guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.
+                          type: boolean
                         gpus:
                           description: Whether to attach a GPU device to the vmi.
                           items:
@@ -2291,6 +2866,14 @@ var CRDsValidation map[string]string = map[string]string{
                                 type: string
                               name:
                                 type: string
+                              nvme:
+                                description: NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.
+                                properties:
+                                  namespace:
+                                    description: Namespace is the ID of the namespace. Defaults to 1.
+                                    format: int32
+                                    type: integer
+                                type: object
                             required:
                             - deviceName
                             - name
@@ -2319,6 +2902,73 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Interfaces describe network interfaces which are added to the vmi.
                           items:
                             properties:
+                              bandwidth:
+                                description: Bandwidth limits the traffic of the interface. It is only supported with the bridge, masquerade and macvtap bindings.
+                                properties:
+                                  inbound:
+                                    description: Inbound limits the traffic received by the guest (rx).
+                                    properties:
+                                      average:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes which can be sent at the peak rate.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      peak:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - average
+                                    type: object
+                                  outbound:
+                                    description: Outbound limits the traffic sent by the guest (tx).
+                                    properties:
+                                      average:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes which can be sent at the peak rate.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      peak:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - average
+                                    type: object
+                                type: object
+                              binding:
+                                description: Binding specifies the binding plugin that will be used to connect the interface to the guest. It provides an alternative to InterfaceBindingMethod.
+                                properties:
+                                  name:
+                                    description: Name references the binding plugin name as registered in the KubeVirt CR.
+                                    type: string
+                                required:
+                                - name
+                                type: object
                               bootOrder:
                                 description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                 type: integer
@@ -2330,6 +2980,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   bootFileName:
                                     description: If specified will pass option 67 to interface's DHCP server
                                     type: string
+                                  mtu:
+                                    description: If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.
+                                    type: integer
                                   ntpServers:
                                     description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                                     items:
@@ -2351,10 +3004,58 @@ var CRDsValidation map[string]string = map[string]string{
                                       - value
                                       type: object
                                     type: array
+                                  routes:
+                                    description: If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes of the pod interface.
+                                    items:
+                                      description: DHCPRoute defines a static route passed to the VM through DHCP.
+                                      properties:
+                                        destination:
+                                          description: Destination is the IPv4 CIDR of the routed network. Required.
+                                          type: string
+                                        gateway:
+                                          description: Gateway is the IPv4 address of the next hop. Required.
+                                          type: string
+                                      required:
+                                      - destination
+                                      - gateway
+                                      type: object
+                                    type: array
                                   tftpServerName:
                                     description: If specified will pass option 66 to interface's DHCP server
                                     type: string
                                 type: object
+                              firewall:
+                                description: Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod, so it applies even where NetworkPolicies don't, like on bridged secondary networks. It is only supported with the bridge and masquerade bindings.
+                                properties:
+                                  defaultAction:
+                                    description: DefaultAction applies to the packets which match no rule. Defaults to Allow. Deny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass. VMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label, which virt-handler sets where it is loaded.
+                                    type: string
+                                  rules:
+                                    description: Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.
+                                    items:
+                                      description: FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port. The packets match when all the criteria which are set match.
+                                      properties:
+                                        action:
+                                          description: Action is Allow or Deny.
+                                          type: string
+                                        cidr:
+                                          description: CIDR is the network of the remote peer, IPv4 or IPv6.
+                                          type: string
+                                        direction:
+                                          description: Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest. The rule applies to both directions if it is not set.
+                                          type: string
+                                        port:
+                                          description: Port is the destination port, on the guest for Ingress and on the remote peer for Egress. It requires the TCP, UDP or SCTP protocol.
+                                          format: int32
+                                          type: integer
+                                        protocol:
+                                          description: Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.
+                                          type: string
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                type: object
                               macAddress:
                                 description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                 type: string
@@ -2368,6 +3069,22 @@ var CRDsValidation map[string]string = map[string]string{
                               name:
                                 description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                                 type: string
+                              offloads:
+                                description: Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default. It requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.
+                                properties:
+                                  checksum:
+                                    description: Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled with it and can't be enabled without it.
+                                    type: boolean
+                                  gso:
+                                    description: GSO toggles the generic segmentation offload of the host.
+                                    type: boolean
+                                  tso:
+                                    description: TSO toggles the TCP segmentation offload, for IPv4 and IPv6.
+                                    type: boolean
+                                type: object
+                              passt:
+                                description: InterfacePasst connects the guest to the pod network through passt, a user-mode network stack which forwards TCP and UDP traffic over both IPv4 and IPv6.
+                                type: object
                               pciAddress:
                                 description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                                 type: string
@@ -2384,35 +3101,126 @@ var CRDsValidation map[string]string = map[string]string{
                                       format: int32
                                       type: integer
                                     protocol:
-                                      description: Protocol for port. Must be UDP or TCP. Defaults to "TCP".
+                                      description: Protocol for port. Must be UDP, TCP or SCTP. Defaults to "TCP".
                                       type: string
                                   required:
                                   - port
                                   type: object
                                 type: array
+                              romDisabled:
+                                description: If set, no option ROM is exposed to the guest for the interface. Interfaces with a boot order have an option ROM by default, which is needed to boot from the network with BIOS firmware. EFI firmware can boot from the network without it.
+                                type: boolean
+                              rss:
+                                description: RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a multi-queue guest across its queues, and thus across its vCPUs. It requires the virtio model and networkInterfaceMultiqueue, and is only supported with the bridge, masquerade and macvtap bindings.
+                                properties:
+                                  hashReport:
+                                    description: HashReport makes the device report the RSS hash of every received packet to the guest, which saves the guest from computing it again. The device is emulated by QEMU instead of vhost-net then.
+                                    type: boolean
+                                type: object
                               slirp:
                                 type: object
                               sriov:
+                                properties:
+                                  failover:
+                                    description: Failover names a virtio interface of the vmi which the guest teams with the VF through its net_failover driver. The traffic fails over to that interface while the VF is detached for a live migration. Both interfaces must have the same MAC address and be connected to the same L2 network.
+                                    type: string
                                 type: object
+                              state:
+                                description: State represents the requested operational state of the interface. The link of the interface is set "down" or "up", "up" being the default, and the guest sees a link which is down as an unplugged cable. "absent" is set when the interface is hot unplugged.
+                                type: string
                               tag:
                                 description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                                 type: string
+                              vdpa:
+                                description: InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface. The device is allocated by a device plugin, through the resource of the Multus network.
+                                type: object
+                              vhostuser:
+                                description: InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP, through a vhost-user socket. The guest memory is shared with the switch.
+                                type: object
                             required:
                             - name
                             type: object
                           type: array
+                        logSerialConsole:
+                          description: Whether to mirror the output of the serial console to the virt-launcher pod log. The log can be retrieved later through the console/log subresource. Defaults to false.
+                          type: boolean
+                        memoryDump:
+                          description: If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource, for example by VirtualMachineSnapshots which include a memory dump.
+                          properties:
+                            claimName:
+                              description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                              type: string
+                          required:
+                          - claimName
+                          type: object
                         networkInterfaceMultiqueue:
                           description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                           type: boolean
+                        panicMemoryDump:
+                          description: If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.
+                          properties:
+                            claimName:
+                              description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                              type: string
+                          required:
+                          - claimName
+                          type: object
                         rng:
                           description: Whether to have random number generator from host
                           type: object
+                        serialConsoleLog:
+                          description: If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.
+                          properties:
+                            claimName:
+                              description: ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.
+                              type: string
+                            maxFileSize:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            maxFiles:
+                              description: MaxFiles is the number of rotated log files which are kept. Defaults to 3.
+                              format: int32
+                              type: integer
+                          required:
+                          - claimName
+                          type: object
+                        serials:
+                          description: Additional serial ports of the vmi, next to the default serial console. Each serial port can be connected to through the serial subresource.
+                          items:
+                            description: SerialPort represents an additional serial port of the vmi
+                            properties:
+                              name:
+                                description: Name of the serial port, used to connect to it through the serial subresource. Must be a DNS_LABEL and unique within the vmi.
+                                type: string
+                            required:
+                            - name
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                        tpm:
+                          description: Whether to emulate a TPM device.
+                          properties:
+                            persistent:
+                              description: Persistent indicates the state of the TPM device should be kept across reboots and migrations. The state is stored in a per-VM backend storage volume. Defaults to false.
+                              type: boolean
+                          type: object
                         useVirtioTransitional:
                           description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                           type: boolean
                         watchdog:
                           description: Watchdog describes a watchdog device which can be added to the vmi.
                           properties:
+                            diag288:
+                              description: diag288 watchdog device, only available on s390x.
+                              properties:
+                                action:
+                                  description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
+                                  type: string
+                              type: object
                             i6300esb:
                               description: i6300esb watchdog device.
                               properties:
@@ -2600,9 +3408,43 @@ var CRDsValidation map[string]string = map[string]string{
                             efi:
                               description: If set, EFI will be used instead of BIOS.
                               properties:
+                                persistent:
+                                  description: Persistent indicates the EFI NVRAM should be kept across reboots and migrations. The NVRAM is stored in a per-VM backend storage volume. Defaults to false.
+                                  type: boolean
                                 secureBoot:
                                   description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                                   type: boolean
+                                secureBootKeys:
+                                  description: SecureBootKeys references Secrets with custom certificates which are enrolled into the NVRAM at first boot. Requires SecureBoot to be enabled.
+                                  properties:
+                                    db:
+                                      description: DB references a Secret with certificates which are allowed to sign boot loaders and kernels, added to the default ones.
+                                      properties:
+                                        secretName:
+                                          description: SecretName is the name of the Secret in the namespace of the vmi.
+                                          type: string
+                                      required:
+                                      - secretName
+                                      type: object
+                                    kek:
+                                      description: KEK references a Secret with Key Exchange Key certificates, added to the default ones.
+                                      properties:
+                                        secretName:
+                                          description: SecretName is the name of the Secret in the namespace of the vmi.
+                                          type: string
+                                      required:
+                                      - secretName
+                                      type: object
+                                    pk:
+                                      description: PK references a Secret with the Platform Key certificate, replacing the default one.
+                                      properties:
+                                        secretName:
+                                          description: SecretName is the name of the Secret in the namespace of the vmi.
+                                          type: string
+                                      required:
+                                      - secretName
+                                      type: object
+                                  type: object
                               type: object
                           type: object
                         serial:
@@ -2612,9 +3454,44 @@ var CRDsValidation map[string]string = map[string]string{
                           description: UUID reported by the vmi bios. Defaults to a random generated uid.
                           type: string
                       type: object
+                    ioThreads:
+                      description: IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.
+                      properties:
+                        dedicatedPinning:
+                          description: 'DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated. emulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads. vcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads. One of: emulator, vcpus. Defaults to emulator.'
+                          type: string
+                        sharedPoolSize:
+                          description: SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread. Defaults to the number of IOThreads determined by the ioThreadsPolicy.
+                          format: int32
+                          type: integer
+                      type: object
                     ioThreadsPolicy:
                       description: 'Controls whether or not disks will share IOThreads. Omitting IOThreadsPolicy disables use of IOThreads. One of: shared, auto'
                       type: string
+                    launchSecurity:
+                      description: Launch Security setting of the vmi.
+                      properties:
+                        sev:
+                          description: AMD Secure Encrypted Virtualization (SEV).
+                          properties:
+                            attestation:
+                              description: If specified, run the attestation process for a vmi.
+                              type: object
+                            policy:
+                              description: 'Guest policy flags as defined in AMD SEV API specification. Note: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.'
+                              properties:
+                                encryptedState:
+                                  description: SEV-ES is required. Defaults to false.
+                                  type: boolean
+                              type: object
+                          type: object
+                        snp:
+                          description: AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.
+                          type: object
+                        tdx:
+                          description: Intel Trust Domain Extensions (TDX).
+                          type: object
+                      type: object
                     machine:
                       description: Machine type.
                       properties:
@@ -2641,6 +3518,13 @@ var CRDsValidation map[string]string = map[string]string{
                               description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
                               type: string
                           type: object
+                        maxGuest:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS. The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
                       type: object
                     resources:
                       description: Resources describes the Compute Resources required by this vmi.
@@ -2676,13 +3560,43 @@ var CRDsValidation map[string]string = map[string]string{
                 hostname:
                   description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                   type: string
+                ignition:
+                  description: If specified, the Ignition config is passed to the guest through the fw_cfg device. Used to provision guests like Fedora CoreOS or Flatcar, which do not use cloud-init.
+                  properties:
+                    configMapRef:
+                      description: ConfigMapRef references a ConfigMap that contains the Ignition config under the config.ign key.
+                      properties:
+                        name:
+                          description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                          type: string
+                      type: object
+                    secretRef:
+                      description: SecretRef references a k8s Secret that contains the Ignition config under the config.ign key.
+                      properties:
+                        name:
+                          description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                          type: string
+                      type: object
+                  type: object
                 livenessProbe:
                   description: 'Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
                   properties:
+                    exec:
+                      description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+                      properties:
+                        command:
+                          description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                          items:
+                            type: string
+                          type: array
+                      type: object
                     failureThreshold:
                       description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
                       format: int32
                       type: integer
+                    guestAgentPing:
+                      description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+                      type: object
                     httpGet:
                       description: HTTPGet specifies the http request to perform.
                       properties:
@@ -2766,6 +3680,9 @@ var CRDsValidation map[string]string = map[string]string{
                           networkName:
                             description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
                             type: string
+                          persistentIPs:
+                            description: PersistentIPs keeps the addresses allocated by the IPAM of the network across live migrations and restarts of the VM. The addresses are requested through the ips field of the multus network selection element, so the IPAM plugin has to support static IP requests. Only bridge interfaces are supported.
+                            type: boolean
                         required:
                         - networkName
                         type: object
@@ -2775,6 +3692,9 @@ var CRDsValidation map[string]string = map[string]string{
                       pod:
                         description: Represents the stock pod network interface.
                         properties:
+                          vmIPv6NetworkCIDR:
+                            description: IPv6 CIDR for the vm network. Defaults to fd10:0:2::/120 if not specified.
+                            type: string
                           vmNetworkCIDR:
                             description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
                             type: string
@@ -2794,10 +3714,22 @@ var CRDsValidation map[string]string = map[string]string{
                 readinessProbe:
                   description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
                   properties:
+                    exec:
+                      description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+                      properties:
+                        command:
+                          description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                          items:
+                            type: string
+                          type: array
+                      type: object
                     failureThreshold:
                       description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
                       format: int32
                       type: integer
+                    guestAgentPing:
+                      description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+                      type: object
                     httpGet:
                       description: HTTPGet specifies the http request to perform.
                       properties:
@@ -2938,6 +3870,9 @@ var CRDsValidation map[string]string = map[string]string{
                       cloudInitNoCloud:
                         description: 'CloudInitNoCloud represents a cloud-init NoCloud user-data source. The NoCloud data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html'
                         properties:
+                          generateNetworkData:
+                            description: GenerateNetworkData generates NoCloud cloud-init networkdata with the static addresses, routes and DNS servers of the VMI's pod and multus networks. Can't be used together with the other networkdata fields.
+                            type: boolean
                           networkData:
                             description: NetworkData contains NoCloud inline cloud-init networkdata.
                             type: string
@@ -3059,6 +3994,9 @@ var CRDsValidation map[string]string = map[string]string{
                             description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
                             type: string
                         type: object
+                      downwardMetrics:
+                        description: DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.
+                        type: object
                       emptyDisk:
                         description: 'EmptyDisk represents a temporary disk which shares the vmis lifecycle. More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html'
                         properties:
@@ -3146,6 +4084,19 @@ var CRDsValidation map[string]string = map[string]string{
                             description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                             type: string
                         type: object
+                      swapDisk:
+                        description: SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle. The guest is told to enable the swap through the cloud-init vendor data.
+                        properties:
+                          capacity:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: Capacity of the sparse swap disk.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                        required:
+                        - capacity
+                        type: object
                       sysprep:
                         description: Represents a Sysprep volume source.
                         properties:
@@ -3172,6 +4123,10 @@ var CRDsValidation map[string]string = map[string]string{
               - domain
               type: object
           type: object
+        ttlSecondsAfterCompletion:
+          description: TTLSecondsAfterCompletion limits the lifetime of a VirtualMachine with RunStrategy Once which completed. The VirtualMachine is deleted after the given number of seconds since its completion. Only valid with RunStrategy Once.
+          format: int32
+          type: integer
       required:
       - template
       type: object
@@ -3246,13 +4201,13 @@ var CRDsValidation map[string]string = map[string]string{
                         description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
                         type: integer
                       cache:
-                        description: Cache specifies which kvm disk cache mode should be used.
+                        description: 'Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.'
                         type: string
                       cdrom:
                         description: Attach a volume as a cdrom to the vmi.
                         properties:
                           bus:
-                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           readonly:
                             description: ReadOnly. Defaults to true.
@@ -3264,11 +4219,17 @@ var CRDsValidation map[string]string = map[string]string{
                       dedicatedIOThread:
                         description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
                         type: boolean
+                      detectZeroes:
+                        description: 'DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.'
+                        type: string
+                      discard:
+                        description: 'Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.'
+                        type: string
                       disk:
                         description: Attach a volume as a disk to the vmi.
                         properties:
                           bus:
-                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
@@ -3277,6 +4238,24 @@ var CRDsValidation map[string]string = map[string]string{
                             description: ReadOnly. Defaults to false.
                             type: boolean
                         type: object
+                      encryption:
+                        description: Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+                        properties:
+                          format:
+                            description: Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.
+                            type: string
+                          secretName:
+                            description: SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+                            type: string
+                        required:
+                        - secretName
+                        type: object
+                      excludeFromSnapshot:
+                        description: ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.
+                        type: boolean
+                      expandGuestFilesystem:
+                        description: ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.
+                        type: boolean
                       floppy:
                         description: Attach a volume as a floppy to the vmi.
                         properties:
@@ -3290,6 +4269,74 @@ var CRDsValidation map[string]string = map[string]string{
                       io:
                         description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the I/O operations and the bandwidth of the disk.
+                        properties:
+                          bandwidth:
+                            description: Bandwidth limits the bytes per second of the disk.
+                            properties:
+                              burstLength:
+                                description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                format: int64
+                                type: integer
+                              read:
+                                description: Read limits the reads.
+                                format: int64
+                                type: integer
+                              readBurst:
+                                description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              total:
+                                description: Total limits the sum of reads and writes.
+                                format: int64
+                                type: integer
+                              totalBurst:
+                                description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              write:
+                                description: Write limits the writes.
+                                format: int64
+                                type: integer
+                              writeBurst:
+                                description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                            type: object
+                          iops:
+                            description: IOPS limits the I/O operations per second of the disk.
+                            properties:
+                              burstLength:
+                                description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                format: int64
+                                type: integer
+                              read:
+                                description: Read limits the reads.
+                                format: int64
+                                type: integer
+                              readBurst:
+                                description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              total:
+                                description: Total limits the sum of reads and writes.
+                                format: int64
+                                type: integer
+                              totalBurst:
+                                description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              write:
+                                description: Write limits the writes.
+                                format: int64
+                                type: integer
+                              writeBurst:
+                                description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                            type: object
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -3299,6 +4346,9 @@ var CRDsValidation map[string]string = map[string]string{
                           readonly:
                             description: ReadOnly. Defaults to false.
                             type: boolean
+                          reservation:
+                            description: Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.
+                            type: boolean
                         type: object
                       name:
                         description: Name is the device name
@@ -3380,8 +4430,8 @@ var CRDsValidation map[string]string = map[string]string{
   - spec
   type: object
 `,
-	"virtualmachineinstance": `openAPIV3Schema:
-  description: VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.
+	"virtualmachineclone": `openAPIV3Schema:
+  description: VirtualMachineClone is a CRD that clones one VM into another.
   properties:
     apiVersion:
       description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
@@ -3392,874 +4442,1915 @@ var CRDsValidation map[string]string = map[string]string{
     metadata:
       type: object
     spec:
-      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
+      description: VirtualMachineCloneSpec is the spec for a VirtualMachineClone resource
       properties:
-        accessCredentials:
-          description: Specifies a set of public keys to inject into the vm guest
+        annotationFilters:
+          description: 'AnnotationFilters selects the annotations of the source that are copied to the target. Entries are glob patterns, a leading "!" excludes the matching keys and the last matching entry wins. All annotations are copied if empty. Example use: "!some/key*".'
           items:
-            description: AccessCredential represents a credential source that can be used to authorize remote access to the vm guest Only one of its members may be specified.
-            properties:
-              sshPublicKey:
-                description: SSHPublicKey represents the source and method of applying a ssh public key into a guest virtual machine.
-                properties:
-                  propagationMethod:
-                    description: PropagationMethod represents how the public key is injected into the vm guest.
-                    properties:
-                      configDrive:
-                        description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
-                        type: object
-                      qemuGuestAgent:
-                        description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
-                        properties:
-                          users:
-                            description: Users represents a list of guest users that should have the ssh public keys added to their authorized_keys file.
-                            items:
-                              type: string
-                            type: array
-                            x-kubernetes-list-type: set
-                        required:
-                        - users
-                        type: object
-                    type: object
-                  source:
-                    description: Source represents where the public keys are pulled from
-                    properties:
-                      secret:
-                        description: Secret means that the access credential is pulled from a kubernetes secret
-                        properties:
-                          secretName:
-                            description: SecretName represents the name of the secret in the VMI's namespace
-                            type: string
-                        required:
-                        - secretName
-                        type: object
-                    type: object
-                required:
-                - propagationMethod
-                - source
-                type: object
-              userPassword:
-                description: UserPassword represents the source and method for applying a guest user's password
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
+        labelFilters:
+          description: 'LabelFilters selects the labels of the source that are copied to the target. Entries are glob patterns, a leading "!" excludes the matching keys and the last matching entry wins. All labels are copied if empty. Example use: "!some/key*".'
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
+        newMacAddresses:
+          additionalProperties:
+            type: string
+          description: NewMacAddresses manually sets that target interfaces' mac addresses. The key is the interface name and the value is the new mac address. If this field is not specified, a new MAC address will be generated automatically, as for any interface that is not part of this map.
+          type: object
+        newSMBiosSerial:
+          description: NewSMBiosSerial manually sets that target's SMbios serial. If this field is not specified, a new serial will be generated automatically.
+          type: string
+        source:
+          description: 'Source is the object that would be cloned. Currently supported source types are: VirtualMachine of kubevirt.io API group and VirtualMachineSnapshot of snapshot.kubevirt.io API group'
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+        target:
+          description: Target is the VirtualMachine to create. If the name is empty, a name is generated.
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+      required:
+      - source
+      type: object
+    status:
+      description: VirtualMachineCloneStatus is the status for a VirtualMachineClone resource
+      properties:
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for Conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        creationTime:
+          format: date-time
+          type: string
+        phase:
+          description: VirtualMachineClonePhase is the current phase of the VirtualMachineClone
+          type: string
+        targetName:
+          description: TargetName is the name of the VirtualMachine the clone creates
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineclusterinstancetype": `openAPIV3Schema:
+  description: VirtualMachineClusterInstancetype is a cluster scoped version of VirtualMachineInstancetype resource.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Required spec describing the instancetype
+      properties:
+        cpu:
+          description: Required CPU related attributes of the instancetype.
+          properties:
+            dedicatedCPUPlacement:
+              description: DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node with enough dedicated pCPUs and pin the vCPUs to it.
+              type: boolean
+            guest:
+              description: Required number of vCPUs to expose to the guest. The resulting CPU topology is derived from the optional PreferredCPUTopology attribute of CPUPreferences.
+              format: int32
+              type: integer
+            isolateEmulatorThread:
+              description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
+              type: boolean
+            model:
+              description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.
+              type: string
+          required:
+          - guest
+          type: object
+        gpus:
+          description: Optionally defines any GPU devices associated with the instancetype.
+          items:
+            properties:
+              deviceName:
+                type: string
+              name:
+                description: Name of the GPU device as exposed by a device plugin
+                type: string
+            required:
+            - deviceName
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        hostDevices:
+          description: Optionally defines any HostDevices associated with the instancetype.
+          items:
+            properties:
+              deviceName:
+                description: DeviceName is the resource name of the host device exposed by a device plugin
+                type: string
+              name:
+                type: string
+              nvme:
+                description: NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.
                 properties:
-                  propagationMethod:
-                    description: propagationMethod represents how the user passwords are injected into the vm guest.
-                    properties:
-                      qemuGuestAgent:
-                        description: QemuGuestAgentAccessCredentailPropagation means passwords are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
-                        type: object
-                    type: object
-                  source:
-                    description: Source represents where the user passwords are pulled from
-                    properties:
-                      secret:
-                        description: Secret means that the access credential is pulled from a kubernetes secret
-                        properties:
-                          secretName:
-                            description: SecretName represents the name of the secret in the VMI's namespace
-                            type: string
-                        required:
-                        - secretName
-                        type: object
-                    type: object
-                required:
-                - propagationMethod
-                - source
+                  namespace:
+                    description: Namespace is the ID of the namespace. Defaults to 1.
+                    format: int32
+                    type: integer
                 type: object
+            required:
+            - deviceName
+            - name
             type: object
           type: array
           x-kubernetes-list-type: atomic
-        affinity:
-          description: If affinity is specifies, obey all the affinity rules
+        ioThreadsPolicy:
+          description: Optionally defines the IOThreadsPolicy to be used by the instancetype.
+          type: string
+        memory:
+          description: Required Memory related attributes of the instancetype.
           properties:
-            nodeAffinity:
-              description: Describes node affinity scheduling rules for the pod.
+            guest:
+              anyOf:
+              - type: integer
+              - type: string
+              description: Required amount of memory which is visible inside the guest OS.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            hugepages:
+              description: Optionally enables the use of hugepages for the VirtualMachineInstance instead of regular memory.
               properties:
-                preferredDuringSchedulingIgnoredDuringExecution:
-                  description: The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node matches the corresponding matchExpressions; the node(s) with the highest sum are the most preferred.
-                  items:
-                    description: An empty preferred scheduling term matches all objects with implicit weight 0 (i.e. it's a no-op). A null preferred scheduling term matches no objects (i.e. is also a no-op).
-                    properties:
-                      preference:
-                        description: A node selector term, associated with the corresponding weight.
-                        properties:
-                          matchExpressions:
-                            description: A list of node selector requirements by node's labels.
-                            items:
-                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
-                              properties:
-                                key:
-                                  description: The label key that the selector applies to.
-                                  type: string
-                                operator:
-                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
-                                  type: string
-                                values:
-                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
-                                  items:
-                                    type: string
-                                  type: array
-                              required:
-                              - key
-                              - operator
-                              type: object
-                            type: array
-                          matchFields:
-                            description: A list of node selector requirements by node's fields.
-                            items:
-                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
-                              properties:
-                                key:
-                                  description: The label key that the selector applies to.
-                                  type: string
-                                operator:
-                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
-                                  type: string
-                                values:
-                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
-                                  items:
-                                    type: string
-                                  type: array
-                              required:
-                              - key
-                              - operator
-                              type: object
-                            type: array
-                        type: object
-                      weight:
-                        description: Weight associated with matching the corresponding nodeSelectorTerm, in the range 1-100.
-                        format: int32
-                        type: integer
-                    required:
-                    - preference
-                    - weight
-                    type: object
-                  type: array
-                requiredDuringSchedulingIgnoredDuringExecution:
-                  description: If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to an update), the system may or may not try to eventually evict the pod from its node.
-                  properties:
-                    nodeSelectorTerms:
-                      description: Required. A list of node selector terms. The terms are ORed.
-                      items:
-                        description: A null or empty node selector term matches no objects. The requirements of them are ANDed. The TopologySelectorTerm type implements a subset of the NodeSelectorTerm.
-                        properties:
-                          matchExpressions:
-                            description: A list of node selector requirements by node's labels.
-                            items:
-                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
-                              properties:
-                                key:
-                                  description: The label key that the selector applies to.
-                                  type: string
-                                operator:
-                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
-                                  type: string
-                                values:
-                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
-                                  items:
-                                    type: string
-                                  type: array
-                              required:
-                              - key
-                              - operator
-                              type: object
-                            type: array
-                          matchFields:
-                            description: A list of node selector requirements by node's fields.
-                            items:
-                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
-                              properties:
-                                key:
-                                  description: The label key that the selector applies to.
-                                  type: string
-                                operator:
-                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
-                                  type: string
-                                values:
-                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
-                                  items:
-                                    type: string
-                                  type: array
-                              required:
-                              - key
-                              - operator
-                              type: object
-                            type: array
-                        type: object
-                      type: array
-                  required:
-                  - nodeSelectorTerms
-                  type: object
+                pageSize:
+                  description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
+                  type: string
               type: object
-            podAffinity:
-              description: Describes pod affinity scheduling rules (e.g. co-locate this pod in the same node, zone, etc. as some other pod(s)).
+            overcommitPercent:
+              description: Optionally defines the percentage of the guest memory which is not requested by the pod of the VirtualMachineInstance. A value of 25 results in a memory request of 75% of the guest memory. It is ignored together with hugepages.
+              type: integer
+          required:
+          - guest
+          type: object
+      required:
+      - cpu
+      - memory
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineclusterpreference": `openAPIV3Schema:
+  description: VirtualMachineClusterPreference is a cluster scoped version of the VirtualMachinePreference resource.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Required spec describing the preferences
+      properties:
+        cpu:
+          description: CPU optionally defines preferences associated with the CPU attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredCPUTopology:
+              description: PreferredCPUTopology optionally defines the preferred guest visible CPU topology, defaults to PreferSockets.
+              type: string
+          type: object
+        devices:
+          description: Devices optionally defines preferences associated with the Devices attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredAutoattachGraphicsDevice:
+              description: PreferredAutoattachGraphicsDevice optionally defines the preferred value of Devices.AutoattachGraphicsDevice
+              type: boolean
+            preferredAutoattachMemBalloon:
+              description: PreferredAutoattachMemBalloon optionally defines the preferred value of Devices.AutoattachMemBalloon
+              type: boolean
+            preferredAutoattachPodInterface:
+              description: PreferredAutoattachPodInterface optionally defines the preferred value of Devices.AutoattachPodInterface
+              type: boolean
+            preferredAutoattachSerialConsole:
+              description: PreferredAutoattachSerialConsole optionally defines the preferred value of Devices.AutoattachSerialConsole
+              type: boolean
+            preferredBlockMultiQueue:
+              description: PreferredBlockMultiQueue optionally enables the vhost multiqueue feature for virtio disks.
+              type: boolean
+            preferredCdromBus:
+              description: PreferredCdromBus optionally defines the preferred bus for Cdrom Disk devices.
+              type: string
+            preferredDisableHotplug:
+              description: PreferredDisableHotplug optionally defines the preferred value of Devices.DisableHotplug
+              type: boolean
+            preferredDiskBus:
+              description: PreferredDiskBus optionally defines the preferred bus for Disk Disk devices.
+              type: string
+            preferredInterfaceModel:
+              description: PreferredInterfaceModel optionally defines the preferred model to be used by Interface devices.
+              type: string
+            preferredNetworkInterfaceMultiQueue:
+              description: PreferredNetworkInterfaceMultiQueue optionally enables the vhost multiqueue feature for virtio interfaces.
+              type: boolean
+            preferredRng:
+              description: PreferredRng optionally defines the preferred Random Number Generator device to use.
+              type: object
+            preferredUseVirtioTransitional:
+              description: PreferredUseVirtioTransitional optionally defines the preferred value of Devices.UseVirtioTransitional
+              type: boolean
+          type: object
+        features:
+          description: Features optionally defines preferences associated with the Features attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredAcpi:
+              description: Represents if a feature is enabled or disabled.
               properties:
-                preferredDuringSchedulingIgnoredDuringExecution:
-                  description: The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.
-                  items:
-                    description: The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)
-                    properties:
-                      podAffinityTerm:
-                        description: Required. A pod affinity term, associated with the corresponding weight.
-                        properties:
-                          labelSelector:
-                            description: A label query over a set of resources, in this case pods.
-                            properties:
-                              matchExpressions:
-                                description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
-                                items:
-                                  description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
-                                  properties:
-                                    key:
-                                      description: key is the label key that the selector applies to.
-                                      type: string
-                                    operator:
-                                      description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
-                                      type: string
-                                    values:
-                                      description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
-                                      items:
-                                        type: string
-                                      type: array
-                                  required:
-                                  - key
-                                  - operator
-                                  type: object
-                                type: array
-                              matchLabels:
-                                additionalProperties:
-                                  type: string
-                                description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
-                                type: object
-                            type: object
-                          namespaces:
-                            description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
-                            items:
-                              type: string
-                            type: array
-                          topologyKey:
-                            description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
-                            type: string
-                        required:
-                        - topologyKey
-                        type: object
-                      weight:
-                        description: weight associated with matching the corresponding podAffinityTerm, in the range 1-100.
-                        format: int32
-                        type: integer
-                    required:
-                    - podAffinityTerm
-                    - weight
-                    type: object
-                  type: array
-                requiredDuringSchedulingIgnoredDuringExecution:
-                  description: If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.
-                  items:
-                    description: Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running
-                    properties:
-                      labelSelector:
-                        description: A label query over a set of resources, in this case pods.
-                        properties:
-                          matchExpressions:
-                            description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
-                            items:
-                              description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
-                              properties:
-                                key:
-                                  description: key is the label key that the selector applies to.
-                                  type: string
-                                operator:
-                                  description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
-                                  type: string
-                                values:
-                                  description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
-                                  items:
-                                    type: string
-                                  type: array
-                              required:
-                              - key
-                              - operator
-                              type: object
-                            type: array
-                          matchLabels:
-                            additionalProperties:
-                              type: string
-                            description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
-                            type: object
-                        type: object
-                      namespaces:
-                        description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
-                        items:
-                          type: string
-                        type: array
-                      topologyKey:
-                        description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
-                        type: string
-                    required:
-                    - topologyKey
-                    type: object
-                  type: array
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
               type: object
-            podAntiAffinity:
-              description: Describes pod anti-affinity scheduling rules (e.g. avoid putting this pod in the same node, zone, etc. as some other pod(s)).
+            preferredApic:
               properties:
-                preferredDuringSchedulingIgnoredDuringExecution:
-                  description: The scheduler will prefer to schedule pods to nodes that satisfy the anti-affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling anti-affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.
-                  items:
-                    description: The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)
-                    properties:
-                      podAffinityTerm:
-                        description: Required. A pod affinity term, associated with the corresponding weight.
-                        properties:
-                          labelSelector:
-                            description: A label query over a set of resources, in this case pods.
-                            properties:
-                              matchExpressions:
-                                description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
-                                items:
-                                  description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
-                                  properties:
-                                    key:
-                                      description: key is the label key that the selector applies to.
-                                      type: string
-                                    operator:
-                                      description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
-                                      type: string
-                                    values:
-                                      description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
-                                      items:
-                                        type: string
-                                      type: array
-                                  required:
-                                  - key
-                                  - operator
-                                  type: object
-                                type: array
-                              matchLabels:
-                                additionalProperties:
-                                  type: string
-                                description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
-                                type: object
-                            type: object
-                          namespaces:
-                            description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
-                            items:
-                              type: string
-                            type: array
-                          topologyKey:
-                            description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
-                            type: string
-                        required:
-                        - topologyKey
-                        type: object
-                      weight:
-                        description: weight associated with matching the corresponding podAffinityTerm, in the range 1-100.
-                        format: int32
-                        type: integer
-                    required:
-                    - podAffinityTerm
-                    - weight
-                    type: object
-                  type: array
-                requiredDuringSchedulingIgnoredDuringExecution:
-                  description: If the anti-affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the anti-affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.
-                  items:
-                    description: Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running
-                    properties:
-                      labelSelector:
-                        description: A label query over a set of resources, in this case pods.
-                        properties:
-                          matchExpressions:
-                            description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
-                            items:
-                              description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
-                              properties:
-                                key:
-                                  description: key is the label key that the selector applies to.
-                                  type: string
-                                operator:
-                                  description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
-                                  type: string
-                                values:
-                                  description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
-                                  items:
-                                    type: string
-                                  type: array
-                              required:
-                              - key
-                              - operator
-                              type: object
-                            type: array
-                          matchLabels:
-                            additionalProperties:
-                              type: string
-                            description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
-                            type: object
-                        type: object
-                      namespaces:
-                        description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
-                        items:
-                          type: string
-                        type: array
-                      topologyKey:
-                        description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
-                        type: string
-                    required:
-                    - topologyKey
-                    type: object
-                  type: array
-              type: object
-          type: object
-        dnsConfig:
-          description: Specifies the DNS parameters of a pod. Parameters specified here will be merged to the generated DNS configuration based on DNSPolicy.
-          properties:
-            nameservers:
-              description: A list of DNS name server IP addresses. This will be appended to the base nameservers generated from DNSPolicy. Duplicated nameservers will be removed.
-              items:
-                type: string
-              type: array
-            options:
-              description: A list of DNS resolver options. This will be merged with the base options generated from DNSPolicy. Duplicated entries will be removed. Resolution options given in Options will override those that appear in the base DNSPolicy.
-              items:
-                description: PodDNSConfigOption defines DNS resolver options of a pod.
-                properties:
-                  name:
-                    description: Required.
-                    type: string
-                  value:
-                    type: string
-                type: object
-              type: array
-            searches:
-              description: A list of DNS search domains for host-name lookup. This will be appended to the base search paths generated from DNSPolicy. Duplicated search paths will be removed.
-              items:
-                type: string
-              type: array
-          type: object
-        dnsPolicy:
-          description: Set DNS policy for the pod. Defaults to "ClusterFirst". Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'. DNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy. To have DNS options set along with hostNetwork, you have to specify DNS policy explicitly to 'ClusterFirstWithHostNet'.
-          type: string
-        domain:
-          description: Specification of the desired behavior of the VirtualMachineInstance on the host.
-          properties:
-            chassis:
-              description: Chassis specifies the chassis info passed to the domain.
-              properties:
-                asset:
-                  type: string
-                manufacturer:
-                  type: string
-                serial:
-                  type: string
-                sku:
-                  type: string
-                version:
-                  type: string
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+                endOfInterrupt:
+                  description: EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.
+                  type: boolean
               type: object
-            clock:
-              description: Clock sets the clock and timers of the vmi.
+            preferredHyperv:
+              description: Hyperv specific features.
               properties:
-                timer:
-                  description: Timer specifies whih timers are attached to the vmi.
+                evmcs:
+                  description: EVMCS Speeds up L2 vmexits, but disables other virtualization features. Requires vapic. Defaults to the machine type setting.
                   properties:
-                    hpet:
-                      description: HPET (High Precision Event Timer) - multiple timers with periodic interrupts.
-                      properties:
-                        present:
-                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
-                          type: boolean
-                        tickPolicy:
-                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup", "merge", "discard".
-                          type: string
-                      type: object
-                    hyperv:
-                      description: Hyperv (Hypervclock) - lets guests read the host’s wall clock time (paravirtualized). For windows guests.
-                      properties:
-                        present:
-                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
-                          type: boolean
-                      type: object
-                    kvm:
-                      description: "KVM \t(KVM clock) - lets guests read the host’s wall clock time (paravirtualized). For linux guests."
-                      properties:
-                        present:
-                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
-                          type: boolean
-                      type: object
-                    pit:
-                      description: PIT (Programmable Interval Timer) - a timer with periodic interrupts.
-                      properties:
-                        present:
-                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
-                          type: boolean
-                        tickPolicy:
-                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup", "discard".
-                          type: string
-                      type: object
-                    rtc:
-                      description: RTC (Real Time Clock) - a continuously running timer with periodic interrupts.
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                frequencies:
+                  description: Frequencies improves the TSC clock source handling for Hyper-V on KVM. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                ipi:
+                  description: IPI improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                reenlightenment:
+                  description: Reenlightenment enables the notifications on TSC frequency changes. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                relaxed:
+                  description: Relaxed instructs the guest OS to disable watchdog timeouts. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                reset:
+                  description: Reset enables Hyperv reboot/reset for the vmi. Requires synic. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                runtime:
+                  description: Runtime improves the time accounting to improve scheduling in the guest. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                spinlocks:
+                  description: Spinlocks allows to configure the spinlock retry attempts.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    spinlocks:
+                      description: Retries indicates the number of retries. Must be a value greater or equal 4096. Defaults to 4096.
+                      format: int32
+                      type: integer
+                  type: object
+                synic:
+                  description: SyNIC enables the Synthetic Interrupt Controller. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                synictimer:
+                  description: SyNICTimer enables Synthetic Interrupt Controller Timers, reducing CPU load. Defaults to the machine type setting.
+                  properties:
+                    direct:
+                      description: Represents if a feature is enabled or disabled.
                       properties:
-                        present:
-                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                        enabled:
+                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
                           type: boolean
-                        tickPolicy:
-                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup".
-                          type: string
-                        track:
-                          description: Track the guest or the wall clock.
-                          type: string
                       type: object
+                    enabled:
+                      type: boolean
                   type: object
-                timezone:
-                  description: Timezone sets the guest clock to the specified timezone. Zone name follows the TZ environment variable format (e.g. 'America/New_York').
-                  type: string
-                utc:
-                  description: UTC sets the guest clock to UTC on each boot. If an offset is specified, guest changes to the clock will be kept during reboots and are not reset.
+                tlbflush:
+                  description: TLBFlush improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
                   properties:
-                    offsetSeconds:
-                      description: OffsetSeconds specifies an offset in seconds, relative to UTC. If set, guest changes to the clock will be kept during reboots and not reset.
-                      type: integer
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                vapic:
+                  description: VAPIC improves the paravirtualized handling of interrupts. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                vendorid:
+                  description: VendorID allows setting the hypervisor vendor id. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    vendorid:
+                      description: VendorID sets the hypervisor vendor id, visible to the vmi. String up to twelve characters.
+                      type: string
+                  type: object
+                vpindex:
+                  description: VPIndex enables the Virtual Processor Index to help windows identifying virtual processors. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
                   type: object
               type: object
-            cpu:
-              description: CPU allow specified the detailed CPU topology inside the vmi.
+            preferredKvm:
               properties:
-                cores:
-                  description: Cores specifies the number of cores inside the vmi. Must be a value greater or equal 1.
-                  format: int32
-                  type: integer
-                dedicatedCpuPlacement:
-                  description: DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node with enough dedicated pCPUs and pin the vCPUs to it.
+                hidden:
+                  description: Hide the KVM hypervisor from standard MSR based discovery. Defaults to false
                   type: boolean
-                features:
-                  description: Features specifies the CPU features list inside the VMI.
-                  items:
-                    description: CPUFeature allows specifying a CPU feature.
-                    properties:
-                      name:
-                        description: Name of the CPU feature
-                        type: string
-                      policy:
-                        description: 'Policy is the CPU feature attribute which can have the following attributes: force    - The virtual CPU will claim the feature is supported regardless of it being supported by host CPU. require  - Guest creation will fail unless the feature is supported by the host CPU or the hypervisor is able to emulate it. optional - The feature will be supported by virtual CPU if and only if it is supported by host CPU. disable  - The feature will not be supported by virtual CPU. forbid   - Guest creation will fail if the feature is supported by host CPU. Defaults to require'
-                        type: string
-                    required:
-                    - name
-                    type: object
-                  type: array
-                isolateEmulatorThread:
-                  description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
+              type: object
+            preferredPvspinlock:
+              description: Represents if a feature is enabled or disabled.
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
                   type: boolean
-                model:
-                  description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
-                  type: string
-                sockets:
-                  description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
-                  format: int32
-                  type: integer
-                threads:
-                  description: Threads specifies the number of threads inside the vmi. Must be a value greater or equal 1.
-                  format: int32
-                  type: integer
               type: object
-            devices:
-              description: Devices allows adding disks, network interfaces, and others
+            preferredSmm:
+              description: Represents if a feature is enabled or disabled.
               properties:
-                autoattachGraphicsDevice:
-                  description: Whether to attach the default graphics device or not. VNC will not be available if set to false. Defaults to true.
-                  type: boolean
-                autoattachMemBalloon:
-                  description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
-                  type: boolean
-                autoattachPodInterface:
-                  description: Whether to attach a pod network interface. Defaults to true.
-                  type: boolean
-                autoattachSerialConsole:
-                  description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
-                  type: boolean
-                blockMultiQueue:
-                  description: Whether or not to enable virtio multi-queue for block devices
-                  type: boolean
-                disableHotplug:
-                  description: DisableHotplug disabled the ability to hotplug disks.
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
                   type: boolean
-                disks:
-                  description: Disks describes disks, cdroms, floppy and luns which are connected to the vmi.
+              type: object
+          type: object
+        firmware:
+          description: Firmware optionally defines preferences associated with the Firmware attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredUseBios:
+              description: PreferredUseBios optionally enables BIOS
+              type: boolean
+            preferredUseEfi:
+              description: PreferredUseEfi optionally enables EFI
+              type: boolean
+            preferredUseSecureBoot:
+              description: PreferredUseSecureBoot optionally enables SecureBoot and the OVMF roms will be swapped for SecureBoot-enabled ones.
+              type: boolean
+          type: object
+        guestOS:
+          description: GuestOS optionally defines preferences associated with the operating system of the guest
+          properties:
+            preferredType:
+              description: PreferredType optionally marks the type of the guest operating system, e.g. windows. It is copied to the kubevirt.io/guest-os annotation of the VirtualMachineInstance.
+              type: string
+          type: object
+        machine:
+          description: Machine optionally defines preferences associated with the Machine attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredMachineType:
+              description: PreferredMachineType optionally defines the preferred machine type to use.
+              type: string
+          type: object
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineexport": `openAPIV3Schema:
+  description: VirtualMachineExport defines the operation of exporting a VM source
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineExportSpec is the spec for a VirtualMachineExport resource
+      properties:
+        containerDisk:
+          description: ContainerDisk packages the volumes of the source into containerDisk images and pushes them to a registry, instead of serving them. The token is not used in this mode.
+          properties:
+            image:
+              description: Image is the reference the volume is pushed to, e.g. registry.example.com/images/fedora:36. If the source has more than one volume, the tag of each image is suffixed with the name of its volume.
+              type: string
+            insecureSkipTLSVerify:
+              description: InsecureSkipTLSVerify skips the verification of the certificate of the registry
+              type: boolean
+            secretRef:
+              description: SecretRef is the name of the secret of type kubernetes.io/dockerconfigjson holding the credentials of the registry
+              type: string
+          required:
+          - image
+          type: object
+        source:
+          description: Source is the object to export, one of PersistentVolumeClaim, VirtualMachine or VirtualMachineSnapshot
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+        tokenSecretRef:
+          description: TokenSecretRef is the name of the secret that contains the token clients have to present to the export server
+          type: string
+      required:
+      - source
+      - tokenSecretRef
+      type: object
+    status:
+      description: VirtualMachineExportStatus is the status for a VirtualMachineExport resource
+      properties:
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for Conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        containerDisks:
+          description: ContainerDisks are the containerDisk images the volumes were pushed to
+          items:
+            description: VirtualMachineExportContainerDiskImage is the containerDisk image a volume was pushed to
+            properties:
+              image:
+                description: Image is the reference of the pushed image including its digest
+                type: string
+              name:
+                type: string
+            required:
+            - name
+            - image
+            type: object
+          type: array
+          x-kubernetes-list-map-keys: ['name']
+          x-kubernetes-list-type: map
+        links:
+          description: VirtualMachineExportLinks contains the links that point to the export server
+          properties:
+            internal:
+              description: Internal contains the links reachable from within the cluster
+              properties:
+                cert:
+                  description: Cert is the PEM encoded certificate the export server presents
+                  type: string
+                volumes:
                   items:
+                    description: VirtualMachineExportVolume contains the urls of a single exported volume
                     properties:
-                      bootOrder:
-                        description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
-                        type: integer
-                      cache:
-                        description: Cache specifies which kvm disk cache mode should be used.
-                        type: string
-                      cdrom:
-                        description: Attach a volume as a cdrom to the vmi.
-                        properties:
-                          bus:
-                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
-                            type: string
-                          readonly:
-                            description: ReadOnly. Defaults to true.
-                            type: boolean
-                          tray:
-                            description: Tray indicates if the tray of the device is open or closed. Allowed values are "open" and "closed". Defaults to closed.
-                            type: string
-                        type: object
-                      dedicatedIOThread:
-                        description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
-                        type: boolean
-                      disk:
-                        description: Attach a volume as a disk to the vmi.
-                        properties:
-                          bus:
-                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
-                            type: string
-                          pciAddress:
-                            description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
-                            type: string
-                          readonly:
-                            description: ReadOnly. Defaults to false.
-                            type: boolean
-                        type: object
-                      floppy:
-                        description: Attach a volume as a floppy to the vmi.
-                        properties:
-                          readonly:
-                            description: ReadOnly. Defaults to false.
-                            type: boolean
-                          tray:
-                            description: Tray indicates if the tray of the device is open or closed. Allowed values are "open" and "closed". Defaults to closed.
-                            type: string
-                        type: object
-                      io:
-                        description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
-                        type: string
-                      lun:
-                        description: Attach a volume as a LUN to the vmi.
-                        properties:
-                          bus:
-                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
-                            type: string
-                          readonly:
-                            description: ReadOnly. Defaults to false.
-                            type: boolean
-                        type: object
+                      formats:
+                        items:
+                          description: VirtualMachineExportVolumeFormat contains the url of a volume in a given format
+                          properties:
+                            format:
+                              description: ExportVolumeFormat is the format a volume is served in
+                              type: string
+                            url:
+                              type: string
+                          required:
+                          - format
+                          - url
+                          type: object
+                        type: array
+                        x-kubernetes-list-map-keys:
+                        - format
+                        x-kubernetes-list-type: map
                       name:
-                        description: Name is the device name
-                        type: string
-                      serial:
-                        description: Serial provides the ability to specify a serial number for the disk device.
-                        type: string
-                      tag:
-                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata
                         type: string
                     required:
                     - name
                     type: object
                   type: array
-                filesystems:
-                  description: Filesystems describes filesystem which is connected to the vmi.
-                  items:
+                  x-kubernetes-list-map-keys:
+                  - name
+                  x-kubernetes-list-type: map
+              required:
+              - cert
+              type: object
+          type: object
+        phase:
+          description: VirtualMachineExportPhase is the current phase of the VirtualMachineExport
+          type: string
+        serviceName:
+          description: ServiceName is the name of the service created for the export server
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineinstance": `openAPIV3Schema:
+  description: VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
+      properties:
+        accessCredentials:
+          description: Specifies a set of public keys to inject into the vm guest
+          items:
+            description: AccessCredential represents a credential source that can be used to authorize remote access to the vm guest Only one of its members may be specified.
+            properties:
+              sshPublicKey:
+                description: SSHPublicKey represents the source and method of applying a ssh public key into a guest virtual machine.
+                properties:
+                  propagationMethod:
+                    description: PropagationMethod represents how the public key is injected into the vm guest.
                     properties:
-                      name:
-                        description: Name is the device name
-                        type: string
-                      virtiofs:
-                        description: Virtiofs is supported
+                      configDrive:
+                        description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
+                        type: object
+                      noCloud:
+                        description: NoCloudPropagation means that the ssh public keys are injected into the VM using metadata using the noCloud cloud-init provider
+                        type: object
+                      qemuGuestAgent:
+                        description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
+                        properties:
+                          users:
+                            description: Users represents a list of guest users that should have the ssh public keys added to their authorized_keys file.
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: set
+                        required:
+                        - users
                         type: object
-                    required:
-                    - name
-                    - virtiofs
                     type: object
-                  type: array
-                  x-kubernetes-list-type: atomic
-                gpus:
-                  description: Whether to attach a GPU device to the vmi.
-                  items:
+                  source:
+                    description: Source represents where the public keys are pulled from
                     properties:
-                      deviceName:
-                        type: string
-                      name:
-                        description: Name of the GPU device as exposed by a device plugin
-                        type: string
-                    required:
-                    - deviceName
-                    - name
+                      secret:
+                        description: Secret means that the access credential is pulled from a kubernetes secret
+                        properties:
+                          secretName:
+                            description: SecretName represents the name of the secret in the VMI's namespace
+                            type: string
+                        required:
+                        - secretName
+                        type: object
                     type: object
-                  type: array
-                  x-kubernetes-list-type: atomic
-                hostDevices:
-                  description: Whether to attach a host device to the vmi.
-                  items:
+                required:
+                - propagationMethod
+                - source
+                type: object
+              userPassword:
+                description: UserPassword represents the source and method for applying a guest user's password
+                properties:
+                  propagationMethod:
+                    description: propagationMethod represents how the user passwords are injected into the vm guest.
                     properties:
-                      deviceName:
-                        description: DeviceName is the resource name of the host device exposed by a device plugin
-                        type: string
-                      name:
-                        type: string
-                    required:
-                    - deviceName
-                    - name
+                      qemuGuestAgent:
+                        description: QemuGuestAgentAccessCredentailPropagation means passwords are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
+                        type: object
                     type: object
-                  type: array
-                  x-kubernetes-list-type: atomic
-                inputs:
-                  description: Inputs describe input devices
-                  items:
+                  source:
+                    description: Source represents where the user passwords are pulled from
                     properties:
-                      bus:
-                        description: 'Bus indicates the bus of input device to emulate. Supported values: virtio, usb.'
-                        type: string
-                      name:
-                        description: Name is the device name
-                        type: string
-                      type:
-                        description: 'Type indicated the type of input device. Supported values: tablet.'
-                        type: string
-                    required:
-                    - name
-                    - type
+                      secret:
+                        description: Secret means that the access credential is pulled from a kubernetes secret
+                        properties:
+                          secretName:
+                            description: SecretName represents the name of the secret in the VMI's namespace
+                            type: string
+                        required:
+                        - secretName
+                        type: object
                     type: object
-                  type: array
-                interfaces:
-                  description: Interfaces describe network interfaces which are added to the vmi.
+                required:
+                - propagationMethod
+                - source
+                type: object
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        affinity:
+          description: If affinity is specifies, obey all the affinity rules
+          properties:
+            nodeAffinity:
+              description: Describes node affinity scheduling rules for the pod.
+              properties:
+                preferredDuringSchedulingIgnoredDuringExecution:
+                  description: The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node matches the corresponding matchExpressions; the node(s) with the highest sum are the most preferred.
                   items:
+                    description: An empty preferred scheduling term matches all objects with implicit weight 0 (i.e. it's a no-op). A null preferred scheduling term matches no objects (i.e. is also a no-op).
                     properties:
-                      bootOrder:
-                        description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
-                        type: integer
-                      bridge:
-                        type: object
-                      dhcpOptions:
-                        description: If specified the network interface will pass additional DHCP options to the VMI
+                      preference:
+                        description: A node selector term, associated with the corresponding weight.
                         properties:
-                          bootFileName:
-                            description: If specified will pass option 67 to interface's DHCP server
-                            type: string
-                          ntpServers:
-                            description: If specified will pass the configured NTP server to the VM via DHCP option 042.
+                          matchExpressions:
+                            description: A list of node selector requirements by node's labels.
                             items:
-                              type: string
+                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                              properties:
+                                key:
+                                  description: The label key that the selector applies to.
+                                  type: string
+                                operator:
+                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
+                                  type: string
+                                values:
+                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
                             type: array
-                          privateOptions:
-                            description: 'If specified will pass extra DHCP options for private use, range: 224-254'
+                          matchFields:
+                            description: A list of node selector requirements by node's fields.
                             items:
-                              description: DHCPExtraOptions defines Extra DHCP options for a VM.
+                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
                               properties:
-                                option:
-                                  description: Option is an Integer value from 224-254 Required.
-                                  type: integer
-                                value:
-                                  description: Value is a String value for the Option provided Required.
+                                key:
+                                  description: The label key that the selector applies to.
+                                  type: string
+                                operator:
+                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
                                   type: string
+                                values:
+                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
+                                  items:
+                                    type: string
+                                  type: array
                               required:
-                              - option
-                              - value
+                              - key
+                              - operator
                               type: object
                             type: array
-                          tftpServerName:
-                            description: If specified will pass option 66 to interface's DHCP server
-                            type: string
-                        type: object
-                      macAddress:
-                        description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
-                        type: string
-                      macvtap:
-                        type: object
-                      masquerade:
                         type: object
-                      model:
-                        description: 'Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio. TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51'
-                        type: string
-                      name:
-                        description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
-                        type: string
-                      pciAddress:
-                        description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
-                        type: string
-                      ports:
-                        description: List of ports to be forwarded to the virtual machine.
-                        items:
-                          description: Port repesents a port to expose from the virtual machine. Default protocol TCP. The port field is mandatory
-                          properties:
-                            name:
-                              description: If specified, this must be an IANA_SVC_NAME and unique within the pod. Each named port in a pod must have a unique name. Name for the port that can be referred to by services.
-                              type: string
-                            port:
-                              description: Number of port to expose for the virtual machine. This must be a valid port number, 0 < x < 65536.
-                              format: int32
-                              type: integer
-                            protocol:
-                              description: Protocol for port. Must be UDP or TCP. Defaults to "TCP".
-                              type: string
-                          required:
-                          - port
-                          type: object
-                        type: array
-                      slirp:
-                        type: object
-                      sriov:
-                        type: object
-                      tag:
-                        description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
-                        type: string
+                      weight:
+                        description: Weight associated with matching the corresponding nodeSelectorTerm, in the range 1-100.
+                        format: int32
+                        type: integer
                     required:
-                    - name
+                    - preference
+                    - weight
                     type: object
                   type: array
-                networkInterfaceMultiqueue:
-                  description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
-                  type: boolean
-                rng:
-                  description: Whether to have random number generator from host
-                  type: object
-                useVirtioTransitional:
-                  description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
-                  type: boolean
-                watchdog:
-                  description: Watchdog describes a watchdog device which can be added to the vmi.
-                  properties:
-                    i6300esb:
-                      description: i6300esb watchdog device.
-                      properties:
-                        action:
-                          description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
-                          type: string
-                      type: object
-                    name:
-                      description: Name of the watchdog.
-                      type: string
-                  required:
-                  - name
-                  type: object
-              type: object
-            features:
-              description: Features like acpi, apic, hyperv, smm.
-              properties:
-                acpi:
-                  description: ACPI enables/disables ACPI inside the guest. Defaults to enabled.
-                  properties:
-                    enabled:
-                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
-                      type: boolean
-                  type: object
-                apic:
-                  description: Defaults to the machine type setting.
-                  properties:
-                    enabled:
-                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
-                      type: boolean
-                    endOfInterrupt:
-                      description: EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.
-                      type: boolean
-                  type: object
-                hyperv:
-                  description: Defaults to the machine type setting.
+                requiredDuringSchedulingIgnoredDuringExecution:
+                  description: If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to an update), the system may or may not try to eventually evict the pod from its node.
                   properties:
-                    evmcs:
-                      description: EVMCS Speeds up L2 vmexits, but disables other virtualization features. Requires vapic. Defaults to the machine type setting.
-                      properties:
-                        enabled:
-                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                    nodeSelectorTerms:
+                      description: Required. A list of node selector terms. The terms are ORed.
+                      items:
+                        description: A null or empty node selector term matches no objects. The requirements of them are ANDed. The TopologySelectorTerm type implements a subset of the NodeSelectorTerm.
+                        properties:
+                          matchExpressions:
+                            description: A list of node selector requirements by node's labels.
+                            items:
+                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                              properties:
+                                key:
+                                  description: The label key that the selector applies to.
+                                  type: string
+                                operator:
+                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
+                                  type: string
+                                values:
+                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
+                            type: array
+                          matchFields:
+                            description: A list of node selector requirements by node's fields.
+                            items:
+                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                              properties:
+                                key:
+                                  description: The label key that the selector applies to.
+                                  type: string
+                                operator:
+                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
+                                  type: string
+                                values:
+                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
+                            type: array
+                        type: object
+                      type: array
+                  required:
+                  - nodeSelectorTerms
+                  type: object
+              type: object
+            podAffinity:
+              description: Describes pod affinity scheduling rules (e.g. co-locate this pod in the same node, zone, etc. as some other pod(s)).
+              properties:
+                preferredDuringSchedulingIgnoredDuringExecution:
+                  description: The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.
+                  items:
+                    description: The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)
+                    properties:
+                      podAffinityTerm:
+                        description: Required. A pod affinity term, associated with the corresponding weight.
+                        properties:
+                          labelSelector:
+                            description: A label query over a set of resources, in this case pods.
+                            properties:
+                              matchExpressions:
+                                description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                                items:
+                                  description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                  properties:
+                                    key:
+                                      description: key is the label key that the selector applies to.
+                                      type: string
+                                    operator:
+                                      description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                      type: string
+                                    values:
+                                      description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                      items:
+                                        type: string
+                                      type: array
+                                  required:
+                                  - key
+                                  - operator
+                                  type: object
+                                type: array
+                              matchLabels:
+                                additionalProperties:
+                                  type: string
+                                description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                                type: object
+                            type: object
+                          namespaces:
+                            description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
+                            items:
+                              type: string
+                            type: array
+                          topologyKey:
+                            description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
+                            type: string
+                        required:
+                        - topologyKey
+                        type: object
+                      weight:
+                        description: weight associated with matching the corresponding podAffinityTerm, in the range 1-100.
+                        format: int32
+                        type: integer
+                    required:
+                    - podAffinityTerm
+                    - weight
+                    type: object
+                  type: array
+                requiredDuringSchedulingIgnoredDuringExecution:
+                  description: If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.
+                  items:
+                    description: Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running
+                    properties:
+                      labelSelector:
+                        description: A label query over a set of resources, in this case pods.
+                        properties:
+                          matchExpressions:
+                            description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                            items:
+                              description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                              properties:
+                                key:
+                                  description: key is the label key that the selector applies to.
+                                  type: string
+                                operator:
+                                  description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                  type: string
+                                values:
+                                  description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
+                            type: array
+                          matchLabels:
+                            additionalProperties:
+                              type: string
+                            description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                            type: object
+                        type: object
+                      namespaces:
+                        description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
+                        items:
+                          type: string
+                        type: array
+                      topologyKey:
+                        description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
+                        type: string
+                    required:
+                    - topologyKey
+                    type: object
+                  type: array
+              type: object
+            podAntiAffinity:
+              description: Describes pod anti-affinity scheduling rules (e.g. avoid putting this pod in the same node, zone, etc. as some other pod(s)).
+              properties:
+                preferredDuringSchedulingIgnoredDuringExecution:
+                  description: The scheduler will prefer to schedule pods to nodes that satisfy the anti-affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling anti-affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.
+                  items:
+                    description: The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)
+                    properties:
+                      podAffinityTerm:
+                        description: Required. A pod affinity term, associated with the corresponding weight.
+                        properties:
+                          labelSelector:
+                            description: A label query over a set of resources, in this case pods.
+                            properties:
+                              matchExpressions:
+                                description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                                items:
+                                  description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                  properties:
+                                    key:
+                                      description: key is the label key that the selector applies to.
+                                      type: string
+                                    operator:
+                                      description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                      type: string
+                                    values:
+                                      description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                      items:
+                                        type: string
+                                      type: array
+                                  required:
+                                  - key
+                                  - operator
+                                  type: object
+                                type: array
+                              matchLabels:
+                                additionalProperties:
+                                  type: string
+                                description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                                type: object
+                            type: object
+                          namespaces:
+                            description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
+                            items:
+                              type: string
+                            type: array
+                          topologyKey:
+                            description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
+                            type: string
+                        required:
+                        - topologyKey
+                        type: object
+                      weight:
+                        description: weight associated with matching the corresponding podAffinityTerm, in the range 1-100.
+                        format: int32
+                        type: integer
+                    required:
+                    - podAffinityTerm
+                    - weight
+                    type: object
+                  type: array
+                requiredDuringSchedulingIgnoredDuringExecution:
+                  description: If the anti-affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the anti-affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.
+                  items:
+                    description: Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running
+                    properties:
+                      labelSelector:
+                        description: A label query over a set of resources, in this case pods.
+                        properties:
+                          matchExpressions:
+                            description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                            items:
+                              description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                              properties:
+                                key:
+                                  description: key is the label key that the selector applies to.
+                                  type: string
+                                operator:
+                                  description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                  type: string
+                                values:
+                                  description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
+                            type: array
+                          matchLabels:
+                            additionalProperties:
+                              type: string
+                            description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                            type: object
+                        type: object
+                      namespaces:
+                        description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
+                        items:
+                          type: string
+                        type: array
+                      topologyKey:
+                        description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
+                        type: string
+                    required:
+                    - topologyKey
+                    type: object
+                  type: array
+              type: object
+          type: object
+        architecture:
+          description: Specifies the architecture of the vm guest you are attempting to run. Defaults to the default architecture of the cluster.
+          type: string
+        dnsConfig:
+          description: Specifies the DNS parameters of a pod. Parameters specified here will be merged to the generated DNS configuration based on DNSPolicy.
+          properties:
+            nameservers:
+              description: A list of DNS name server IP addresses. This will be appended to the base nameservers generated from DNSPolicy. Duplicated nameservers will be removed.
+              items:
+                type: string
+              type: array
+            options:
+              description: A list of DNS resolver options. This will be merged with the base options generated from DNSPolicy. Duplicated entries will be removed. Resolution options given in Options will override those that appear in the base DNSPolicy.
+              items:
+                description: PodDNSConfigOption defines DNS resolver options of a pod.
+                properties:
+                  name:
+                    description: Required.
+                    type: string
+                  value:
+                    type: string
+                type: object
+              type: array
+            searches:
+              description: A list of DNS search domains for host-name lookup. This will be appended to the base search paths generated from DNSPolicy. Duplicated search paths will be removed.
+              items:
+                type: string
+              type: array
+          type: object
+        dnsPolicy:
+          description: Set DNS policy for the pod. Defaults to "ClusterFirst". Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'. DNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy. To have DNS options set along with hostNetwork, you have to specify DNS policy explicitly to 'ClusterFirstWithHostNet'.
+          type: string
+        domain:
+          description: Specification of the desired behavior of the VirtualMachineInstance on the host.
+          properties:
+            chassis:
+              description: Chassis specifies the chassis info passed to the domain.
+              properties:
+                asset:
+                  type: string
+                manufacturer:
+                  type: string
+                serial:
+                  type: string
+                sku:
+                  type: string
+                version:
+                  type: string
+              type: object
+            clock:
+              description: Clock sets the clock and timers of the vmi.
+              properties:
+                timer:
+                  description: Timer specifies whih timers are attached to the vmi.
+                  properties:
+                    hpet:
+                      description: HPET (High Precision Event Timer) - multiple timers with periodic interrupts.
+                      properties:
+                        present:
+                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                          type: boolean
+                        tickPolicy:
+                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup", "merge", "discard".
+                          type: string
+                      type: object
+                    hyperv:
+                      description: Hyperv (Hypervclock) - lets guests read the host’s wall clock time (paravirtualized). For windows guests.
+                      properties:
+                        present:
+                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                          type: boolean
+                      type: object
+                    kvm:
+                      description: "KVM \t(KVM clock) - lets guests read the host’s wall clock time (paravirtualized). For linux guests."
+                      properties:
+                        present:
+                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                          type: boolean
+                      type: object
+                    pit:
+                      description: PIT (Programmable Interval Timer) - a timer with periodic interrupts.
+                      properties:
+                        present:
+                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                          type: boolean
+                        tickPolicy:
+                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup", "discard".
+                          type: string
+                      type: object
+                    rtc:
+                      description: RTC (Real Time Clock) - a continuously running timer with periodic interrupts.
+                      properties:
+                        present:
+                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                          type: boolean
+                        tickPolicy:
+                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup".
+                          type: string
+                        track:
+                          description: Track the guest or the wall clock.
+                          type: string
+                      type: object
+                  type: object
+                timezone:
+                  description: Timezone sets the guest clock to the specified timezone. Zone name follows the TZ environment variable format (e.g. 'America/New_York').
+                  type: string
+                utc:
+                  description: UTC sets the guest clock to UTC on each boot. If an offset is specified, guest changes to the clock will be kept during reboots and are not reset.
+                  properties:
+                    offsetSeconds:
+                      description: OffsetSeconds specifies an offset in seconds, relative to UTC. If set, guest changes to the clock will be kept during reboots and not reset.
+                      type: integer
+                  type: object
+              type: object
+            cpu:
+              description: CPU allow specified the detailed CPU topology inside the vmi.
+              properties:
+                cores:
+                  description: Cores specifies the number of cores inside the vmi. Must be a value greater or equal 1.
+                  format: int32
+                  type: integer
+                dedicatedCpuPlacement:
+                  description: DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node with enough dedicated pCPUs and pin the vCPUs to it.
+                  type: boolean
+                features:
+                  description: Features specifies the CPU features list inside the VMI.
+                  items:
+                    description: CPUFeature allows specifying a CPU feature.
+                    properties:
+                      name:
+                        description: Name of the CPU feature
+                        type: string
+                      policy:
+                        description: 'Policy is the CPU feature attribute which can have the following attributes: force    - The virtual CPU will claim the feature is supported regardless of it being supported by host CPU. require  - Guest creation will fail unless the feature is supported by the host CPU or the hypervisor is able to emulate it. optional - The feature will be supported by virtual CPU if and only if it is supported by host CPU. disable  - The feature will not be supported by virtual CPU. forbid   - Guest creation will fail if the feature is supported by host CPU. Defaults to require'
+                        type: string
+                    required:
+                    - name
+                    type: object
+                  type: array
+                isolateEmulatorThread:
+                  description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
+                  type: boolean
+                maxSockets:
+                  description: MaxSockets specifies the maximum amount of sockets that can be hotplugged into a running vmi. Must be a value greater or equal to Sockets.
+                  format: int32
+                  type: integer
+                model:
+                  description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
+                  type: string
+                numa:
+                  description: NUMA allows specifying settings for the guest NUMA topology
+                  properties:
+                    guestMappingPassthrough:
+                      description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+                      type: object
+                  type: object
+                realtime:
+                  description: Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
+                  properties:
+                    mask:
+                      description: 'Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt''s expressions. Example: "0-3,^1","0,2,3","2-3"'
+                      type: string
+                  type: object
+                sockets:
+                  description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
+                  format: int32
+                  type: integer
+                threads:
+                  description: Threads specifies the number of threads inside the vmi. Must be a value greater or equal 1.
+                  format: int32
+                  type: integer
+              type: object
+            devices:
+              description: Devices allows adding disks, network interfaces, and others
+              properties:
+                autoattachGraphicsDevice:
+                  description: Whether to attach the default graphics device or not. VNC will not be available if set to false. Defaults to true.
+                  type: boolean
+                autoattachMemBalloon:
+                  description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
+                  type: boolean
+                autoattachPanicDevice:
+                  description: Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor. Defaults to true.
+                  type: boolean
+                autoattachPodInterface:
+                  description: Whether to attach a pod network interface. Defaults to true.
+                  type: boolean
+                autoattachSerialConsole:
+                  description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
+                  type: boolean
+                autoattachVSOCK:
+                  description: Whether to attach the VSOCK CID to the VM or not. VSOCK access will be available if set to true. Defaults to false.
+                  type: boolean
+                blockMultiQueue:
+                  description: Whether or not to enable virtio multi-queue for block devices
+                  type: boolean
+                channels:
+                  description: Virtio channels of the vmi, e.g. for custom guest agents. Each channel can be connected to through the channel subresource.
+                  items:
+                    description: Channel represents a virtio channel of the vmi
+                    properties:
+                      name:
+                        description: Name of the channel, used to connect to it through the channel subresource. Must be a DNS_LABEL and unique within the vmi.
+                        type: string
+                      targetName:
+                        description: TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.
+                        type: string
+                    required:
+                    - name
+                    - targetName
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                disableHotplug:
+                  description: DisableHotplug disabled the ability to hotplug disks.
+                  type: boolean
+                disks:
+                  description: Disks describes disks, cdroms, floppy and luns which are connected to the vmi.
+                  items:
+                    properties:
+                      bootOrder:
+                        description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
+                        type: integer
+                      cache:
+                        description: 'Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.'
+                        type: string
+                      cdrom:
+                        description: Attach a volume as a cdrom to the vmi.
+                        properties:
+                          bus:
+                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
+                            type: string
+                          readonly:
+                            description: ReadOnly. Defaults to true.
+                            type: boolean
+                          tray:
+                            description: Tray indicates if the tray of the device is open or closed. Allowed values are "open" and "closed". Defaults to closed.
+                            type: string
+                        type: object
+                      dedicatedIOThread:
+                        description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
+                        type: boolean
+                      detectZeroes:
+                        description: 'DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.'
+                        type: string
+                      discard:
+                        description: 'Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.'
+                        type: string
+                      disk:
+                        description: Attach a volume as a disk to the vmi.
+                        properties:
+                          bus:
+                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
+                            type: string
+                          pciAddress:
+                            description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
+                            type: string
+                          readonly:
+                            description: ReadOnly. Defaults to false.
+                            type: boolean
+                        type: object
+                      encryption:
+                        description: Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+                        properties:
+                          format:
+                            description: Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.
+                            type: string
+                          secretName:
+                            description: SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+                            type: string
+                        required:
+                        - secretName
+                        type: object
+                      excludeFromSnapshot:
+                        description: ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.
+                        type: boolean
+                      expandGuestFilesystem:
+                        description: ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.
+                        type: boolean
+                      floppy:
+                        description: Attach a volume as a floppy to the vmi.
+                        properties:
+                          readonly:
+                            description: ReadOnly. Defaults to false.
+                            type: boolean
+                          tray:
+                            description: Tray indicates if the tray of the device is open or closed. Allowed values are "open" and "closed". Defaults to closed.
+                            type: string
+                        type: object
+                      io:
+                        description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
+                        type: string
+                      ioTune:
+                        description: IOTune limits the I/O operations and the bandwidth of the disk.
+                        properties:
+                          bandwidth:
+                            description: Bandwidth limits the bytes per second of the disk.
+                            properties:
+                              burstLength:
+                                description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                format: int64
+                                type: integer
+                              read:
+                                description: Read limits the reads.
+                                format: int64
+                                type: integer
+                              readBurst:
+                                description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              total:
+                                description: Total limits the sum of reads and writes.
+                                format: int64
+                                type: integer
+                              totalBurst:
+                                description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              write:
+                                description: Write limits the writes.
+                                format: int64
+                                type: integer
+                              writeBurst:
+                                description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                            type: object
+                          iops:
+                            description: IOPS limits the I/O operations per second of the disk.
+                            properties:
+                              burstLength:
+                                description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                format: int64
+                                type: integer
+                              read:
+                                description: Read limits the reads.
+                                format: int64
+                                type: integer
+                              readBurst:
+                                description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              total:
+                                description: Total limits the sum of reads and writes.
+                                format: int64
+                                type: integer
+                              totalBurst:
+                                description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              write:
+                                description: Write limits the writes.
+                                format: int64
+                                type: integer
+                              writeBurst:
+                                description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                            type: object
+                        type: object
+                      lun:
+                        description: Attach a volume as a LUN to the vmi.
+                        properties:
+                          bus:
+                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                            type: string
+                          readonly:
+                            description: ReadOnly. Defaults to false.
+                            type: boolean
+                          reservation:
+                            description: Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.
+                            type: boolean
+                        type: object
+                      name:
+                        description: Name is the device name
+                        type: string
+                      serial:
+                        description: Serial provides the ability to specify a serial number for the disk device.
+                        type: string
+                      tag:
+                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                        type: string
+                    required:
+                    - name
+                    type: object
+                  type: array
+                filesystems:
+                  description: Filesystems describes filesystem which is connected to the vmi. The volume with the name of the filesystem is shared into the guest with virtiofs, it can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.
+                  items:
+                    properties:
+                      name:
+                        description: Name is the device name
+                        type: string
+                      virtiofs:
+                        description: Virtiofs is supported
+                        properties:
+                          gidMap:
+                            description: GIDMap maps the group IDs of the guest to the group IDs of the shared directory.
+                            properties:
+                              count:
+                                description: Count is the number of IDs in the range, it must be greater than 0.
+                                format: int32
+                                type: integer
+                              guestID:
+                                description: GuestID is the first ID of the range in the guest.
+                                format: int32
+                                type: integer
+                              hostID:
+                                description: HostID is the first ID of the range in the shared directory.
+                                format: int32
+                                type: integer
+                            required:
+                            - guestID
+                            - hostID
+                            - count
+                            type: object
+                          subPath:
+                            description: SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root. It allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.
+                            type: string
+                          uidMap:
+                            description: UIDMap maps the user IDs of the guest to the user IDs of the shared directory.
+                            properties:
+                              count:
+                                description: Count is the number of IDs in the range, it must be greater than 0.
+                                format: int32
+                                type: integer
+                              guestID:
+                                description: GuestID is the first ID of the range in the guest.
+                                format: int32
+                                type: integer
+                              hostID:
+                                description: HostID is the first ID of the range in the shared directory.
+                                format: int32
+                                type: integer
+                            required:
+                            - guestID
+                            - hostID
+                            - count
+                            type: object
+                        type: object
+                    required:
+                    - name
+                    - virtiofs
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                freePageReporting:
+                  description: Whether to enable free page reporting on the memory balloon device, so that memory freed by the guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.
+                  type: boolean
+                gpus:
+                  description: Whether to attach a GPU device to the vmi.
+                  items:
+                    properties:
+                      deviceName:
+                        type: string
+                      name:
+                        description: Name of the GPU device as exposed by a device plugin
+                        type: string
+                    required:
+                    - deviceName
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                hostDevices:
+                  description: Whether to attach a host device to the vmi.
+                  items:
+                    properties:
+                      deviceName:
+                        description: DeviceName is the resource name of the host device exposed by a device plugin
+                        type: string
+                      name:
+                        type: string
+                      nvme:
+                        description: NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.
+                        properties:
+                          namespace:
+                            description: Namespace is the ID of the namespace. Defaults to 1.
+                            format: int32
+                            type: integer
+                        type: object
+                    required:
+                    - deviceName
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                inputs:
+                  description: Inputs describe input devices
+                  items:
+                    properties:
+                      bus:
+                        description: 'Bus indicates the bus of input device to emulate. Supported values: virtio, usb.'
+                        type: string
+                      name:
+                        description: Name is the device name
+                        type: string
+                      type:
+                        description: 'Type indicated the type of input device. Supported values: tablet.'
+                        type: string
+                    required:
+                    - name
+                    - type
+                    type: object
+                  type: array
+                interfaces:
+                  description: Interfaces describe network interfaces which are added to the vmi.
+                  items:
+                    properties:
+                      bandwidth:
+                        description: Bandwidth limits the traffic of the interface. It is only supported with the bridge, masquerade and macvtap bindings.
+                        properties:
+                          inbound:
+                            description: Inbound limits the traffic received by the guest (rx).
+                            properties:
+                              average:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can be sent at the peak rate.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              peak:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - average
+                            type: object
+                          outbound:
+                            description: Outbound limits the traffic sent by the guest (tx).
+                            properties:
+                              average:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can be sent at the peak rate.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              peak:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - average
+                            type: object
+                        type: object
+                      binding:
+                        description: Binding specifies the binding plugin that will be used to connect the interface to the guest. It provides an alternative to InterfaceBindingMethod.
+                        properties:
+                          name:
+                            description: Name references the binding plugin name as registered in the KubeVirt CR.
+                            type: string
+                        required:
+                        - name
+                        type: object
+                      bootOrder:
+                        description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
+                        type: integer
+                      bridge:
+                        type: object
+                      dhcpOptions:
+                        description: If specified the network interface will pass additional DHCP options to the VMI
+                        properties:
+                          bootFileName:
+                            description: If specified will pass option 67 to interface's DHCP server
+                            type: string
+                          mtu:
+                            description: If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.
+                            type: integer
+                          ntpServers:
+                            description: If specified will pass the configured NTP server to the VM via DHCP option 042.
+                            items:
+                              type: string
+                            type: array
+                          privateOptions:
+                            description: 'If specified will pass extra DHCP options for private use, range: 224-254'
+                            items:
+                              description: DHCPExtraOptions defines Extra DHCP options for a VM.
+                              properties:
+                                option:
+                                  description: Option is an Integer value from 224-254 Required.
+                                  type: integer
+                                value:
+                                  description: Value is a String value for the Option provided Required.
+                                  type: string
+                              required:
+                              - option
+                              - value
+                              type: object
+                            type: array
+                          routes:
+                            description: If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes of the pod interface.
+                            items:
+                              description: DHCPRoute defines a static route passed to the VM through DHCP.
+                              properties:
+                                destination:
+                                  description: Destination is the IPv4 CIDR of the routed network. Required.
+                                  type: string
+                                gateway:
+                                  description: Gateway is the IPv4 address of the next hop. Required.
+                                  type: string
+                              required:
+                              - destination
+                              - gateway
+                              type: object
+                            type: array
+                          tftpServerName:
+                            description: If specified will pass option 66 to interface's DHCP server
+                            type: string
+                        type: object
+                      firewall:
+                        description: Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod, so it applies even where NetworkPolicies don't, like on bridged secondary networks. It is only supported with the bridge and masquerade bindings.
+                        properties:
+                          defaultAction:
+                            description: DefaultAction applies to the packets which match no rule. Defaults to Allow. Deny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass. VMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label, which virt-handler sets where it is loaded.
+                            type: string
+                          rules:
+                            description: Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.
+                            items:
+                              description: FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port. The packets match when all the criteria which are set match.
+                              properties:
+                                action:
+                                  description: Action is Allow or Deny.
+                                  type: string
+                                cidr:
+                                  description: CIDR is the network of the remote peer, IPv4 or IPv6.
+                                  type: string
+                                direction:
+                                  description: Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest. The rule applies to both directions if it is not set.
+                                  type: string
+                                port:
+                                  description: Port is the destination port, on the guest for Ingress and on the remote peer for Egress. It requires the TCP, UDP or SCTP protocol.
+                                  format: int32
+                                  type: integer
+                                protocol:
+                                  description: Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.
+                                  type: string
+                              required:
+                              - action
+                              type: object
+                            type: array
+                        type: object
+                      macAddress:
+                        description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
+                        type: string
+                      macvtap:
+                        type: object
+                      masquerade:
+                        type: object
+                      model:
+                        description: 'Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio. TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51'
+                        type: string
+                      name:
+                        description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
+                        type: string
+                      offloads:
+                        description: Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default. It requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.
+                        properties:
+                          checksum:
+                            description: Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled with it and can't be enabled without it.
+                            type: boolean
+                          gso:
+                            description: GSO toggles the generic segmentation offload of the host.
+                            type: boolean
+                          tso:
+                            description: TSO toggles the TCP segmentation offload, for IPv4 and IPv6.
+                            type: boolean
+                        type: object
+                      passt:
+                        description: InterfacePasst connects the guest to the pod network through passt, a user-mode network stack which forwards TCP and UDP traffic over both IPv4 and IPv6.
+                        type: object
+                      pciAddress:
+                        description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
+                        type: string
+                      ports:
+                        description: List of ports to be forwarded to the virtual machine.
+                        items:
+                          description: Port repesents a port to expose from the virtual machine. Default protocol TCP. The port field is mandatory
+                          properties:
+                            name:
+                              description: If specified, this must be an IANA_SVC_NAME and unique within the pod. Each named port in a pod must have a unique name. Name for the port that can be referred to by services.
+                              type: string
+                            port:
+                              description: Number of port to expose for the virtual machine. This must be a valid port number, 0 < x < 65536.
+                              format: int32
+                              type: integer
+                            protocol:
+                              description: Protocol for port. Must be UDP, TCP or SCTP. Defaults to "TCP".
+                              type: string
+                          required:
+                          - port
+                          type: object
+                        type: array
+                      romDisabled:
+                        description: If set, no option ROM is exposed to the guest for the interface. Interfaces with a boot order have an option ROM by default, which is needed to boot from the network with BIOS firmware. EFI firmware can boot from the network without it.
+                        type: boolean
+                      rss:
+                        description: RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a multi-queue guest across its queues, and thus across its vCPUs. It requires the virtio model and networkInterfaceMultiqueue, and is only supported with the bridge, masquerade and macvtap bindings.
+                        properties:
+                          hashReport:
+                            description: HashReport makes the device report the RSS hash of every received packet to the guest, which saves the guest from computing it again. The device is emulated by QEMU instead of vhost-net then.
+                            type: boolean
+                        type: object
+                      slirp:
+                        type: object
+                      sriov:
+                        properties:
+                          failover:
+                            description: Failover names a virtio interface of the vmi which the guest teams with the VF through its net_failover driver. The traffic fails over to that interface while the VF is detached for a live migration. Both interfaces must have the same MAC address and be connected to the same L2 network.
+                            type: string
+                        type: object
+                      state:
+                        description: State represents the requested operational state of the interface. The link of the interface is set "down" or "up", "up" being the default, and the guest sees a link which is down as an unplugged cable. "absent" is set when the interface is hot unplugged.
+                        type: string
+                      tag:
+                        description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
+                        type: string
+                      vdpa:
+                        description: InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface. The device is allocated by a device plugin, through the resource of the Multus network.
+                        type: object
+                      vhostuser:
+                        description: InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP, through a vhost-user socket. The guest memory is shared with the switch.
+                        type: object
+                    required:
+                    - name
+                    type: object
+                  type: array
+                logSerialConsole:
+                  description: Whether to mirror the output of the serial console to the virt-launcher pod log. The log can be retrieved later through the console/log subresource. Defaults to false.
+                  type: boolean
+                memoryDump:
+                  description: If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource, for example by VirtualMachineSnapshots which include a memory dump.
+                  properties:
+                    claimName:
+                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                      type: string
+                  required:
+                  - claimName
+                  type: object
+                networkInterfaceMultiqueue:
+                  description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
+                  type: boolean
+                panicMemoryDump:
+                  description: If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.
+                  properties:
+                    claimName:
+                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                      type: string
+                  required:
+                  - claimName
+                  type: object
+                rng:
+                  description: Whether to have random number generator from host
+                  type: object
+                serialConsoleLog:
+                  description: If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.
+                  properties:
+                    claimName:
+                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.
+                      type: string
+                    maxFileSize:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      description: MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                    maxFiles:
+                      description: MaxFiles is the number of rotated log files which are kept. Defaults to 3.
+                      format: int32
+                      type: integer
+                  required:
+                  - claimName
+                  type: object
+                serials:
+                  description: Additional serial ports of the vmi, next to the default serial console. Each serial port can be connected to through the serial subresource.
+                  items:
+                    description: SerialPort represents an additional serial port of the vmi
+                    properties:
+                      name:
+                        description: Name of the serial port, used to connect to it through the serial subresource. Must be a DNS_LABEL and unique within the vmi.
+                        type: string
+                    required:
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                tpm:
+                  description: Whether to emulate a TPM device.
+                  properties:
+                    persistent:
+                      description: Persistent indicates the state of the TPM device should be kept across reboots and migrations. The state is stored in a per-VM backend storage volume. Defaults to false.
+                      type: boolean
+                  type: object
+                useVirtioTransitional:
+                  description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
+                  type: boolean
+                watchdog:
+                  description: Watchdog describes a watchdog device which can be added to the vmi.
+                  properties:
+                    diag288:
+                      description: diag288 watchdog device, only available on s390x.
+                      properties:
+                        action:
+                          description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
+                          type: string
+                      type: object
+                    i6300esb:
+                      description: i6300esb watchdog device.
+                      properties:
+                        action:
+                          description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
+                          type: string
+                      type: object
+                    name:
+                      description: Name of the watchdog.
+                      type: string
+                  required:
+                  - name
+                  type: object
+              type: object
+            features:
+              description: Features like acpi, apic, hyperv, smm.
+              properties:
+                acpi:
+                  description: ACPI enables/disables ACPI inside the guest. Defaults to enabled.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                apic:
+                  description: Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    endOfInterrupt:
+                      description: EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.
+                      type: boolean
+                  type: object
+                hyperv:
+                  description: Defaults to the machine type setting.
+                  properties:
+                    evmcs:
+                      description: EVMCS Speeds up L2 vmexits, but disables other virtualization features. Requires vapic. Defaults to the machine type setting.
+                      properties:
+                        enabled:
+                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
                           type: boolean
                       type: object
                     frequencies:
@@ -4405,9 +6496,43 @@ var CRDsValidation map[string]string = map[string]string{
                     efi:
                       description: If set, EFI will be used instead of BIOS.
                       properties:
+                        persistent:
+                          description: Persistent indicates the EFI NVRAM should be kept across reboots and migrations. The NVRAM is stored in a per-VM backend storage volume. Defaults to false.
+                          type: boolean
                         secureBoot:
                           description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                           type: boolean
+                        secureBootKeys:
+                          description: SecureBootKeys references Secrets with custom certificates which are enrolled into the NVRAM at first boot. Requires SecureBoot to be enabled.
+                          properties:
+                            db:
+                              description: DB references a Secret with certificates which are allowed to sign boot loaders and kernels, added to the default ones.
+                              properties:
+                                secretName:
+                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
+                            kek:
+                              description: KEK references a Secret with Key Exchange Key certificates, added to the default ones.
+                              properties:
+                                secretName:
+                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
+                            pk:
+                              description: PK references a Secret with the Platform Key certificate, replacing the default one.
+                              properties:
+                                secretName:
+                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
+                          type: object
                       type: object
                   type: object
                 serial:
@@ -4417,9 +6542,44 @@ var CRDsValidation map[string]string = map[string]string{
                   description: UUID reported by the vmi bios. Defaults to a random generated uid.
                   type: string
               type: object
+            ioThreads:
+              description: IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.
+              properties:
+                dedicatedPinning:
+                  description: 'DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated. emulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads. vcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads. One of: emulator, vcpus. Defaults to emulator.'
+                  type: string
+                sharedPoolSize:
+                  description: SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread. Defaults to the number of IOThreads determined by the ioThreadsPolicy.
+                  format: int32
+                  type: integer
+              type: object
             ioThreadsPolicy:
               description: 'Controls whether or not disks will share IOThreads. Omitting IOThreadsPolicy disables use of IOThreads. One of: shared, auto'
               type: string
+            launchSecurity:
+              description: Launch Security setting of the vmi.
+              properties:
+                sev:
+                  description: AMD Secure Encrypted Virtualization (SEV).
+                  properties:
+                    attestation:
+                      description: If specified, run the attestation process for a vmi.
+                      type: object
+                    policy:
+                      description: 'Guest policy flags as defined in AMD SEV API specification. Note: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.'
+                      properties:
+                        encryptedState:
+                          description: SEV-ES is required. Defaults to false.
+                          type: boolean
+                      type: object
+                  type: object
+                snp:
+                  description: AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.
+                  type: object
+                tdx:
+                  description: Intel Trust Domain Extensions (TDX).
+                  type: object
+              type: object
             machine:
               description: Machine type.
               properties:
@@ -4446,6 +6606,13 @@ var CRDsValidation map[string]string = map[string]string{
                       description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
                       type: string
                   type: object
+                maxGuest:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS. The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
               type: object
             resources:
               description: Resources describes the Compute Resources required by this vmi.
@@ -4481,13 +6648,43 @@ var CRDsValidation map[string]string = map[string]string{
         hostname:
           description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
           type: string
+        ignition:
+          description: If specified, the Ignition config is passed to the guest through the fw_cfg device. Used to provision guests like Fedora CoreOS or Flatcar, which do not use cloud-init.
+          properties:
+            configMapRef:
+              description: ConfigMapRef references a ConfigMap that contains the Ignition config under the config.ign key.
+              properties:
+                name:
+                  description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                  type: string
+              type: object
+            secretRef:
+              description: SecretRef references a k8s Secret that contains the Ignition config under the config.ign key.
+              properties:
+                name:
+                  description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                  type: string
+              type: object
+          type: object
         livenessProbe:
           description: 'Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
           properties:
+            exec:
+              description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+              properties:
+                command:
+                  description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                  items:
+                    type: string
+                  type: array
+              type: object
             failureThreshold:
               description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
               format: int32
               type: integer
+            guestAgentPing:
+              description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+              type: object
             httpGet:
               description: HTTPGet specifies the http request to perform.
               properties:
@@ -4571,6 +6768,9 @@ var CRDsValidation map[string]string = map[string]string{
                   networkName:
                     description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
                     type: string
+                  persistentIPs:
+                    description: PersistentIPs keeps the addresses allocated by the IPAM of the network across live migrations and restarts of the VM. The addresses are requested through the ips field of the multus network selection element, so the IPAM plugin has to support static IP requests. Only bridge interfaces are supported.
+                    type: boolean
                 required:
                 - networkName
                 type: object
@@ -4580,6 +6780,9 @@ var CRDsValidation map[string]string = map[string]string{
               pod:
                 description: Represents the stock pod network interface.
                 properties:
+                  vmIPv6NetworkCIDR:
+                    description: IPv6 CIDR for the vm network. Defaults to fd10:0:2::/120 if not specified.
+                    type: string
                   vmNetworkCIDR:
                     description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
                     type: string
@@ -4599,10 +6802,22 @@ var CRDsValidation map[string]string = map[string]string{
         readinessProbe:
           description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
           properties:
+            exec:
+              description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+              properties:
+                command:
+                  description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                  items:
+                    type: string
+                  type: array
+              type: object
             failureThreshold:
               description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
               format: int32
               type: integer
+            guestAgentPing:
+              description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+              type: object
             httpGet:
               description: HTTPGet specifies the http request to perform.
               properties:
@@ -4743,6 +6958,9 @@ var CRDsValidation map[string]string = map[string]string{
               cloudInitNoCloud:
                 description: 'CloudInitNoCloud represents a cloud-init NoCloud user-data source. The NoCloud data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html'
                 properties:
+                  generateNetworkData:
+                    description: GenerateNetworkData generates NoCloud cloud-init networkdata with the static addresses, routes and DNS servers of the VMI's pod and multus networks. Can't be used together with the other networkdata fields.
+                    type: boolean
                   networkData:
                     description: NetworkData contains NoCloud inline cloud-init networkdata.
                     type: string
@@ -4864,6 +7082,9 @@ var CRDsValidation map[string]string = map[string]string{
                     description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
                     type: string
                 type: object
+              downwardMetrics:
+                description: DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.
+                type: object
               emptyDisk:
                 description: 'EmptyDisk represents a temporary disk which shares the vmis lifecycle. More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html'
                 properties:
@@ -4951,6 +7172,19 @@ var CRDsValidation map[string]string = map[string]string{
                     description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                     type: string
                 type: object
+              swapDisk:
+                description: SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle. The guest is told to enable the swap through the cloud-init vendor data.
+                properties:
+                  capacity:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: Capacity of the sparse swap disk.
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                required:
+                - capacity
+                type: object
               sysprep:
                 description: Represents a Sysprep volume source.
                 properties:
@@ -4979,11 +7213,67 @@ var CRDsValidation map[string]string = map[string]string{
     status:
       description: Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.
       properties:
+        VSOCKCID:
+          description: VSOCKCID is used to track the allocated VSOCK CID in the VM.
+          format: int32
+          type: integer
         activePods:
           additionalProperties:
             type: string
           description: ActivePods is a mapping of pod UID to node name. It is possible for multiple pods to be running for a single VMI during migration.
           type: object
+        backup:
+          description: Backup reports the checkpoints the changed blocks of the disks are tracked from, and the running backup.
+          properties:
+            checkpoint:
+              description: Checkpoint is the checkpoint created by the running backup, it is empty if no backup is running.
+              type: string
+            checkpoints:
+              description: Checkpoints are the names of the checkpoints an incremental backup can be taken from, oldest first. They are lost when the VirtualMachineInstance stops.
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
+            disks:
+              description: Disks are the names of the disks included in the running backup.
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
+            incremental:
+              description: Incremental is the checkpoint the running backup reports the changed blocks since, it is empty if the backup is a full one.
+              type: string
+          type: object
+        blockCommit:
+          description: BlockCommit reports the last block commit of volumes snapshotted with external snapshots.
+          properties:
+            endTimestamp:
+              description: EndTimestamp is when the block commit completed or failed.
+              format: date-time
+              nullable: true
+              type: string
+            message:
+              description: Message explains why the block commit failed.
+              type: string
+            phase:
+              description: Phase is the state of the block commit.
+              type: string
+            progress:
+              description: Progress is the percentage of the data of the overlays which is committed.
+              format: int32
+              type: integer
+            startTimestamp:
+              description: StartTimestamp is when the block commit started.
+              format: date-time
+              nullable: true
+              type: string
+            volumes:
+              description: Volumes are the volumes whose overlays are committed.
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
+          type: object
         conditions:
           description: Conditions are specific points in VirtualMachineInstance's pod runtime.
           items:
@@ -5009,6 +7299,22 @@ var CRDsValidation map[string]string = map[string]string{
             - type
             type: object
           type: array
+        currentCPUTopology:
+          description: CurrentCPUTopology specifies the current CPU topology used by the VM workload. Current topology may differ from the desired topology in the spec while CPU hotplug takes place.
+          properties:
+            cores:
+              description: Cores specifies the number of cores inside the vmi.
+              format: int32
+              type: integer
+            sockets:
+              description: Sockets specifies the number of sockets inside the vmi.
+              format: int32
+              type: integer
+            threads:
+              description: Threads specifies the number of threads inside the vmi.
+              format: int32
+              type: integer
+          type: object
         evacuationNodeName:
           description: EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want to evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.
           type: string
@@ -5044,6 +7350,64 @@ var CRDsValidation map[string]string = map[string]string{
           description: Interfaces represent the details of available network interfaces.
           items:
             properties:
+              bandwidth:
+                description: Bandwidth reports the traffic limits applied to the interface
+                properties:
+                  inbound:
+                    description: Inbound limits the traffic received by the guest (rx).
+                    properties:
+                      average:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Average is the rate, in bytes per second, the traffic is shaped to.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      burst:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Burst is the amount of bytes which can be sent at the peak rate.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      peak:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                    required:
+                    - average
+                    type: object
+                  outbound:
+                    description: Outbound limits the traffic sent by the guest (tx).
+                    properties:
+                      average:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Average is the rate, in bytes per second, the traffic is shaped to.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      burst:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Burst is the amount of bytes which can be sent at the peak rate.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      peak:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                    required:
+                    - average
+                    type: object
+                type: object
               interfaceName:
                 description: The interface name inside the Virtual Machine
                 type: string
@@ -5061,11 +7425,63 @@ var CRDsValidation map[string]string = map[string]string{
               name:
                 description: 'Name of the interface, corresponds to name of the network assigned to the interface TODO: remove omitempty, when api breaking changes are allowed'
                 type: string
+              queueCount:
+                description: Specifies how many queues are allocated by MultiQueue
+                format: int32
+                type: integer
             type: object
           type: array
         launcherContainerImageVersion:
           description: LauncherContainerImageVersion indicates what container image is currently active for the vmi.
           type: string
+        memory:
+          description: Memory reports the guest memory at boot and the memory plugged into the running domain.
+          properties:
+            guestAtBoot:
+              anyOf:
+              - type: integer
+              - type: string
+              description: GuestAtBoot specifies with how much memory the VirtualMachine initially booted with.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            guestCurrent:
+              anyOf:
+              - type: integer
+              - type: string
+              description: GuestCurrent specifies how much memory is currently available for the VirtualMachine.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            guestRequested:
+              anyOf:
+              - type: integer
+              - type: string
+              description: GuestRequested specifies how much memory was requested (hotplug) for the VirtualMachine.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+          type: object
+        memoryDump:
+          description: MemoryDump reports the last memory dump of the guest.
+          properties:
+            endTimestamp:
+              description: EndTimestamp is when the memory dump completed or failed.
+              format: date-time
+              nullable: true
+              type: string
+            fileName:
+              description: FileName is the name of the dump file on the memory dump PVC.
+              type: string
+            message:
+              description: Message explains why the memory dump failed.
+              type: string
+            phase:
+              description: Phase is the state of the memory dump.
+              type: string
+            startTimestamp:
+              description: StartTimestamp is when the memory dump started.
+              format: date-time
+              nullable: true
+              type: string
+          type: object
         migrationMethod:
           description: 'Represents the method using which the vmi can be migrated: live migration or block migration'
           type: string
@@ -5089,6 +7505,21 @@ var CRDsValidation map[string]string = map[string]string{
             failed:
               description: Indicates that the migration failed
               type: boolean
+            migratedVolumes:
+              description: The volumes which are copied to other PersistentVolumeClaims during the migration
+              items:
+                properties:
+                  destinationClaimName:
+                    description: DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to. It must have the same volume mode as the source and be at least as large.
+                    type: string
+                  volumeName:
+                    description: VolumeName is the name of a PersistentVolumeClaim or DataVolume volume of the VMI
+                    type: string
+                required:
+                - volumeName
+                - destinationClaimName
+                type: object
+              type: array
             migrationUid:
               description: The VirtualMachineInstanceMigration object associated with this migration
               type: string
@@ -5138,6 +7569,21 @@ var CRDsValidation map[string]string = map[string]string{
           items:
             description: VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.
             properties:
+              externalSnapshot:
+                description: ExternalSnapshot shows the qcow2 overlay the guest writes to and its backing chain, if the volume was snapshotted with external snapshots.
+                properties:
+                  activeFile:
+                    description: ActiveFile is the qcow2 overlay on the volume the guest writes to.
+                    type: string
+                  backingChain:
+                    description: BackingChain are the files backing the active file, from its backing file down to the base image. The guest does not write to them anymore.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                required:
+                - activeFile
+                type: object
               hotplugVolume:
                 description: If the volume is hotplug, this will contain the hotplug status.
                 properties:
@@ -5190,6 +7636,21 @@ var CRDsValidation map[string]string = map[string]string{
         vmiName:
           description: The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace
           type: string
+        volumes:
+          description: Volumes lists the volumes of the VMI which are copied to other PersistentVolumeClaims during the migration, for instance to move them to another storage class. The VMI and its VirtualMachine use the destination claims once the migration succeeded.
+          items:
+            properties:
+              destinationClaimName:
+                description: DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to. It must have the same volume mode as the source and be at least as large.
+                type: string
+              volumeName:
+                description: VolumeName is the name of a PersistentVolumeClaim or DataVolume volume of the VMI
+                type: string
+            required:
+            - volumeName
+            - destinationClaimName
+            type: object
+          type: array
       type: object
     status:
       description: VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.
@@ -5350,9 +7811,27 @@ var CRDsValidation map[string]string = map[string]string{
                 isolateEmulatorThread:
                   description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                   type: boolean
+                maxSockets:
+                  description: MaxSockets specifies the maximum amount of sockets that can be hotplugged into a running vmi. Must be a value greater or equal to Sockets.
+                  format: int32
+                  type: integer
                 model:
                   description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                   type: string
+                numa:
+                  description: NUMA allows specifying settings for the guest NUMA topology
+                  properties:
+                    guestMappingPassthrough:
+                      description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+                      type: object
+                  type: object
+                realtime:
+                  description: Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
+                  properties:
+                    mask:
+                      description: 'Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt''s expressions. Example: "0-3,^1","0,2,3","2-3"'
+                      type: string
+                  type: object
                 sockets:
                   description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                   format: int32
@@ -5371,15 +7850,38 @@ var CRDsValidation map[string]string = map[string]string{
                 autoattachMemBalloon:
                   description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                   type: boolean
+                autoattachPanicDevice:
+                  description: Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor. Defaults to true.
+                  type: boolean
                 autoattachPodInterface:
                   description: Whether to attach a pod network interface. Defaults to true.
                   type: boolean
                 autoattachSerialConsole:
                   description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                   type: boolean
+                autoattachVSOCK:
+                  description: Whether to attach the VSOCK CID to the VM or not. VSOCK access will be available if set to true. Defaults to false.
+                  type: boolean
                 blockMultiQueue:
                   description: Whether or not to enable virtio multi-queue for block devices
                   type: boolean
+                channels:
+                  description: Virtio channels of the vmi, e.g. for custom guest agents. Each channel can be connected to through the channel subresource.
+                  items:
+                    description: Channel represents a virtio channel of the vmi
+                    properties:
+                      name:
+                        description: Name of the channel, used to connect to it through the channel subresource. Must be a DNS_LABEL and unique within the vmi.
+                        type: string
+                      targetName:
+                        description: TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.
+                        type: string
+                    required:
+                    - name
+                    - targetName
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 disableHotplug:
                   description: DisableHotplug disabled the ability to hotplug disks.
                   type: boolean
@@ -5391,13 +7893,13 @@ var CRDsValidation map[string]string = map[string]string{
                         description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
                         type: integer
                       cache:
-                        description: Cache specifies which kvm disk cache mode should be used.
+                        description: 'Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.'
                         type: string
                       cdrom:
                         description: Attach a volume as a cdrom to the vmi.
                         properties:
                           bus:
-                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           readonly:
                             description: ReadOnly. Defaults to true.
@@ -5409,11 +7911,17 @@ var CRDsValidation map[string]string = map[string]string{
                       dedicatedIOThread:
                         description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
                         type: boolean
+                      detectZeroes:
+                        description: 'DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.'
+                        type: string
+                      discard:
+                        description: 'Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.'
+                        type: string
                       disk:
                         description: Attach a volume as a disk to the vmi.
                         properties:
                           bus:
-                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
@@ -5422,6 +7930,24 @@ var CRDsValidation map[string]string = map[string]string{
                             description: ReadOnly. Defaults to false.
                             type: boolean
                         type: object
+                      encryption:
+                        description: Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+                        properties:
+                          format:
+                            description: Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.
+                            type: string
+                          secretName:
+                            description: SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+                            type: string
+                        required:
+                        - secretName
+                        type: object
+                      excludeFromSnapshot:
+                        description: ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.
+                        type: boolean
+                      expandGuestFilesystem:
+                        description: ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.
+                        type: boolean
                       floppy:
                         description: Attach a volume as a floppy to the vmi.
                         properties:
@@ -5435,6 +7961,74 @@ var CRDsValidation map[string]string = map[string]string{
                       io:
                         description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the I/O operations and the bandwidth of the disk.
+                        properties:
+                          bandwidth:
+                            description: Bandwidth limits the bytes per second of the disk.
+                            properties:
+                              burstLength:
+                                description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                format: int64
+                                type: integer
+                              read:
+                                description: Read limits the reads.
+                                format: int64
+                                type: integer
+                              readBurst:
+                                description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              total:
+                                description: Total limits the sum of reads and writes.
+                                format: int64
+                                type: integer
+                              totalBurst:
+                                description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              write:
+                                description: Write limits the writes.
+                                format: int64
+                                type: integer
+                              writeBurst:
+                                description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                            type: object
+                          iops:
+                            description: IOPS limits the I/O operations per second of the disk.
+                            properties:
+                              burstLength:
+                                description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                format: int64
+                                type: integer
+                              read:
+                                description: Read limits the reads.
+                                format: int64
+                                type: integer
+                              readBurst:
+                                description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              total:
+                                description: Total limits the sum of reads and writes.
+                                format: int64
+                                type: integer
+                              totalBurst:
+                                description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                              write:
+                                description: Write limits the writes.
+                                format: int64
+                                type: integer
+                              writeBurst:
+                                description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                format: int64
+                                type: integer
+                            type: object
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -5444,6 +8038,9 @@ var CRDsValidation map[string]string = map[string]string{
                           readonly:
                             description: ReadOnly. Defaults to false.
                             type: boolean
+                          reservation:
+                            description: Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.
+                            type: boolean
                         type: object
                       name:
                         description: Name is the device name
@@ -5459,7 +8056,7 @@ var CRDsValidation map[string]string = map[string]string{
                     type: object
                   type: array
                 filesystems:
-                  description: Filesystems describes filesystem which is connected to the vmi.
+                  description: Filesystems describes filesystem which is connected to the vmi. The volume with the name of the filesystem is shared into the guest with virtiofs, it can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.
                   items:
                     properties:
                       name:
@@ -5467,6 +8064,50 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                       virtiofs:
                         description: Virtiofs is supported
+                        properties:
+                          gidMap:
+                            description: GIDMap maps the group IDs of the guest to the group IDs of the shared directory.
+                            properties:
+                              count:
+                                description: Count is the number of IDs in the range, it must be greater than 0.
+                                format: int32
+                                type: integer
+                              guestID:
+                                description: GuestID is the first ID of the range in the guest.
+                                format: int32
+                                type: integer
+                              hostID:
+                                description: HostID is the first ID of the range in the shared directory.
+                                format: int32
+                                type: integer
+                            required:
+                            - guestID
+                            - hostID
+                            - count
+                            type: object
+                          subPath:
+                            description: SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root. It allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.
+                            type: string
+                          uidMap:
+                            description: UIDMap maps the user IDs of the guest to the user IDs of the shared directory.
+                            properties:
+                              count:
+                                description: Count is the number of IDs in the range, it must be greater than 0.
+                                format: int32
+                                type: integer
+                              guestID:
+                                description: GuestID is the first ID of the range in the guest.
+                                format: int32
+                                type: integer
+                              hostID:
+                                description: HostID is the first ID of the range in the shared directory.
+                                format: int32
+                                type: integer
+                            required:
+                            - guestID
+                            - hostID
+                            - count
+                            type: object
                         type: object
                     required:
                     - name
@@ -5474,6 +8115,9 @@ var CRDsValidation map[string]string = map[string]string{
                     type: object
                   type: array
                   x-kubernetes-list-type: atomic
+                freePageReporting:
+                  description: Whether to enable free page reporting on the memory balloon device, so that memory freed by the guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.
+                  type: boolean
                 gpus:
                   description: Whether to attach a GPU device to the vmi.
                   items:
@@ -5498,6 +8142,14 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                       name:
                         type: string
+                      nvme:
+                        description: NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.
+                        properties:
+                          namespace:
+                            description: Namespace is the ID of the namespace. Defaults to 1.
+                            format: int32
+                            type: integer
+                        type: object
                     required:
                     - deviceName
                     - name
@@ -5526,6 +8178,73 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Interfaces describe network interfaces which are added to the vmi.
                   items:
                     properties:
+                      bandwidth:
+                        description: Bandwidth limits the traffic of the interface. It is only supported with the bridge, masquerade and macvtap bindings.
+                        properties:
+                          inbound:
+                            description: Inbound limits the traffic received by the guest (rx).
+                            properties:
+                              average:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can be sent at the peak rate.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              peak:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - average
+                            type: object
+                          outbound:
+                            description: Outbound limits the traffic sent by the guest (tx).
+                            properties:
+                              average:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can be sent at the peak rate.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              peak:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - average
+                            type: object
+                        type: object
+                      binding:
+                        description: Binding specifies the binding plugin that will be used to connect the interface to the guest. It provides an alternative to InterfaceBindingMethod.
+                        properties:
+                          name:
+                            description: Name references the binding plugin name as registered in the KubeVirt CR.
+                            type: string
+                        required:
+                        - name
+                        type: object
                       bootOrder:
                         description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                         type: integer
@@ -5537,6 +8256,9 @@ var CRDsValidation map[string]string = map[string]string{
                           bootFileName:
                             description: If specified will pass option 67 to interface's DHCP server
                             type: string
+                          mtu:
+                            description: If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.
+                            type: integer
                           ntpServers:
                             description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                             items:
@@ -5558,10 +8280,58 @@ var CRDsValidation map[string]string = map[string]string{
                               - value
                               type: object
                             type: array
+                          routes:
+                            description: If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes of the pod interface.
+                            items:
+                              description: DHCPRoute defines a static route passed to the VM through DHCP.
+                              properties:
+                                destination:
+                                  description: Destination is the IPv4 CIDR of the routed network. Required.
+                                  type: string
+                                gateway:
+                                  description: Gateway is the IPv4 address of the next hop. Required.
+                                  type: string
+                              required:
+                              - destination
+                              - gateway
+                              type: object
+                            type: array
                           tftpServerName:
                             description: If specified will pass option 66 to interface's DHCP server
                             type: string
                         type: object
+                      firewall:
+                        description: Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod, so it applies even where NetworkPolicies don't, like on bridged secondary networks. It is only supported with the bridge and masquerade bindings.
+                        properties:
+                          defaultAction:
+                            description: DefaultAction applies to the packets which match no rule. Defaults to Allow. Deny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass. VMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label, which virt-handler sets where it is loaded.
+                            type: string
+                          rules:
+                            description: Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.
+                            items:
+                              description: FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port. The packets match when all the criteria which are set match.
+                              properties:
+                                action:
+                                  description: Action is Allow or Deny.
+                                  type: string
+                                cidr:
+                                  description: CIDR is the network of the remote peer, IPv4 or IPv6.
+                                  type: string
+                                direction:
+                                  description: Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest. The rule applies to both directions if it is not set.
+                                  type: string
+                                port:
+                                  description: Port is the destination port, on the guest for Ingress and on the remote peer for Egress. It requires the TCP, UDP or SCTP protocol.
+                                  format: int32
+                                  type: integer
+                                protocol:
+                                  description: Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.
+                                  type: string
+                              required:
+                              - action
+                              type: object
+                            type: array
+                        type: object
                       macAddress:
                         description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                         type: string
@@ -5575,6 +8345,22 @@ var CRDsValidation map[string]string = map[string]string{
                       name:
                         description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                         type: string
+                      offloads:
+                        description: Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default. It requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.
+                        properties:
+                          checksum:
+                            description: Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled with it and can't be enabled without it.
+                            type: boolean
+                          gso:
+                            description: GSO toggles the generic segmentation offload of the host.
+                            type: boolean
+                          tso:
+                            description: TSO toggles the TCP segmentation offload, for IPv4 and IPv6.
+                            type: boolean
+                        type: object
+                      passt:
+                        description: InterfacePasst connects the guest to the pod network through passt, a user-mode network stack which forwards TCP and UDP traffic over both IPv4 and IPv6.
+                        type: object
                       pciAddress:
                         description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                         type: string
@@ -5591,35 +8377,126 @@ var CRDsValidation map[string]string = map[string]string{
                               format: int32
                               type: integer
                             protocol:
-                              description: Protocol for port. Must be UDP or TCP. Defaults to "TCP".
+                              description: Protocol for port. Must be UDP, TCP or SCTP. Defaults to "TCP".
                               type: string
                           required:
                           - port
                           type: object
                         type: array
+                      romDisabled:
+                        description: If set, no option ROM is exposed to the guest for the interface. Interfaces with a boot order have an option ROM by default, which is needed to boot from the network with BIOS firmware. EFI firmware can boot from the network without it.
+                        type: boolean
+                      rss:
+                        description: RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a multi-queue guest across its queues, and thus across its vCPUs. It requires the virtio model and networkInterfaceMultiqueue, and is only supported with the bridge, masquerade and macvtap bindings.
+                        properties:
+                          hashReport:
+                            description: HashReport makes the device report the RSS hash of every received packet to the guest, which saves the guest from computing it again. The device is emulated by QEMU instead of vhost-net then.
+                            type: boolean
+                        type: object
                       slirp:
                         type: object
                       sriov:
+                        properties:
+                          failover:
+                            description: Failover names a virtio interface of the vmi which the guest teams with the VF through its net_failover driver. The traffic fails over to that interface while the VF is detached for a live migration. Both interfaces must have the same MAC address and be connected to the same L2 network.
+                            type: string
                         type: object
+                      state:
+                        description: State represents the requested operational state of the interface. The link of the interface is set "down" or "up", "up" being the default, and the guest sees a link which is down as an unplugged cable. "absent" is set when the interface is hot unplugged.
+                        type: string
                       tag:
                         description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                         type: string
+                      vdpa:
+                        description: InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface. The device is allocated by a device plugin, through the resource of the Multus network.
+                        type: object
+                      vhostuser:
+                        description: InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP, through a vhost-user socket. The guest memory is shared with the switch.
+                        type: object
                     required:
                     - name
                     type: object
                   type: array
+                logSerialConsole:
+                  description: Whether to mirror the output of the serial console to the virt-launcher pod log. The log can be retrieved later through the console/log subresource. Defaults to false.
+                  type: boolean
+                memoryDump:
+                  description: If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource, for example by VirtualMachineSnapshots which include a memory dump.
+                  properties:
+                    claimName:
+                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                      type: string
+                  required:
+                  - claimName
+                  type: object
                 networkInterfaceMultiqueue:
                   description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                   type: boolean
+                panicMemoryDump:
+                  description: If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.
+                  properties:
+                    claimName:
+                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                      type: string
+                  required:
+                  - claimName
+                  type: object
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                serialConsoleLog:
+                  description: If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.
+                  properties:
+                    claimName:
+                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.
+                      type: string
+                    maxFileSize:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      description: MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                    maxFiles:
+                      description: MaxFiles is the number of rotated log files which are kept. Defaults to 3.
+                      format: int32
+                      type: integer
+                  required:
+                  - claimName
+                  type: object
+                serials:
+                  description: Additional serial ports of the vmi, next to the default serial console. Each serial port can be connected to through the serial subresource.
+                  items:
+                    description: SerialPort represents an additional serial port of the vmi
+                    properties:
+                      name:
+                        description: Name of the serial port, used to connect to it through the serial subresource. Must be a DNS_LABEL and unique within the vmi.
+                        type: string
+                    required:
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                tpm:
+                  description: Whether to emulate a TPM device.
+                  properties:
+                    persistent:
+                      description: Persistent indicates the state of the TPM device should be kept across reboots and migrations. The state is stored in a per-VM backend storage volume. Defaults to false.
+                      type: boolean
+                  type: object
                 useVirtioTransitional:
                   description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                   type: boolean
                 watchdog:
                   description: Watchdog describes a watchdog device which can be added to the vmi.
                   properties:
+                    diag288:
+                      description: diag288 watchdog device, only available on s390x.
+                      properties:
+                        action:
+                          description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
+                          type: string
+                      type: object
                     i6300esb:
                       description: i6300esb watchdog device.
                       properties:
@@ -5807,9 +8684,43 @@ var CRDsValidation map[string]string = map[string]string{
                     efi:
                       description: If set, EFI will be used instead of BIOS.
                       properties:
+                        persistent:
+                          description: Persistent indicates the EFI NVRAM should be kept across reboots and migrations. The NVRAM is stored in a per-VM backend storage volume. Defaults to false.
+                          type: boolean
                         secureBoot:
                           description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                           type: boolean
+                        secureBootKeys:
+                          description: SecureBootKeys references Secrets with custom certificates which are enrolled into the NVRAM at first boot. Requires SecureBoot to be enabled.
+                          properties:
+                            db:
+                              description: DB references a Secret with certificates which are allowed to sign boot loaders and kernels, added to the default ones.
+                              properties:
+                                secretName:
+                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
+                            kek:
+                              description: KEK references a Secret with Key Exchange Key certificates, added to the default ones.
+                              properties:
+                                secretName:
+                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
+                            pk:
+                              description: PK references a Secret with the Platform Key certificate, replacing the default one.
+                              properties:
+                                secretName:
+                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
+                          type: object
                       type: object
                   type: object
                 serial:
@@ -5819,9 +8730,44 @@ var CRDsValidation map[string]string = map[string]string{
                   description: UUID reported by the vmi bios. Defaults to a random generated uid.
                   type: string
               type: object
+            ioThreads:
+              description: IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.
+              properties:
+                dedicatedPinning:
+                  description: 'DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated. emulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads. vcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads. One of: emulator, vcpus. Defaults to emulator.'
+                  type: string
+                sharedPoolSize:
+                  description: SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread. Defaults to the number of IOThreads determined by the ioThreadsPolicy.
+                  format: int32
+                  type: integer
+              type: object
             ioThreadsPolicy:
               description: 'Controls whether or not disks will share IOThreads. Omitting IOThreadsPolicy disables use of IOThreads. One of: shared, auto'
               type: string
+            launchSecurity:
+              description: Launch Security setting of the vmi.
+              properties:
+                sev:
+                  description: AMD Secure Encrypted Virtualization (SEV).
+                  properties:
+                    attestation:
+                      description: If specified, run the attestation process for a vmi.
+                      type: object
+                    policy:
+                      description: 'Guest policy flags as defined in AMD SEV API specification. Note: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.'
+                      properties:
+                        encryptedState:
+                          description: SEV-ES is required. Defaults to false.
+                          type: boolean
+                      type: object
+                  type: object
+                snp:
+                  description: AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.
+                  type: object
+                tdx:
+                  description: Intel Trust Domain Extensions (TDX).
+                  type: object
+              type: object
             machine:
               description: Machine type.
               properties:
@@ -5848,6 +8794,13 @@ var CRDsValidation map[string]string = map[string]string{
                       description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
                       type: string
                   type: object
+                maxGuest:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS. The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
               type: object
             resources:
               description: Resources describes the Compute Resources required by this vmi.
@@ -5987,6 +8940,9 @@ var CRDsValidation map[string]string = map[string]string{
                               configDrive:
                                 description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
                                 type: object
+                              noCloud:
+                                description: NoCloudPropagation means that the ssh public keys are injected into the VM using metadata using the noCloud cloud-init provider
+                                type: object
                               qemuGuestAgent:
                                 description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
                                 properties:
@@ -6388,6 +9344,9 @@ var CRDsValidation map[string]string = map[string]string{
                           type: array
                       type: object
                   type: object
+                architecture:
+                  description: Specifies the architecture of the vm guest you are attempting to run. Defaults to the default architecture of the cluster.
+                  type: string
                 dnsConfig:
                   description: Specifies the DNS parameters of a pod. Parameters specified here will be merged to the generated DNS configuration based on DNSPolicy.
                   properties:
@@ -6527,9 +9486,27 @@ var CRDsValidation map[string]string = map[string]string{
                         isolateEmulatorThread:
                           description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                           type: boolean
+                        maxSockets:
+                          description: MaxSockets specifies the maximum amount of sockets that can be hotplugged into a running vmi. Must be a value greater or equal to Sockets.
+                          format: int32
+                          type: integer
                         model:
                           description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                           type: string
+                        numa:
+                          description: NUMA allows specifying settings for the guest NUMA topology
+                          properties:
+                            guestMappingPassthrough:
+                              description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+                              type: object
+                          type: object
+                        realtime:
+                          description: Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
+                          properties:
+                            mask:
+                              description: 'Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt''s expressions. Example: "0-3,^1","0,2,3","2-3"'
+                              type: string
+                          type: object
                         sockets:
                           description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                           format: int32
@@ -6548,15 +9525,38 @@ var CRDsValidation map[string]string = map[string]string{
                         autoattachMemBalloon:
                           description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                           type: boolean
+                        autoattachPanicDevice:
+                          description: Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor. Defaults to true.
+                          type: boolean
                         autoattachPodInterface:
                           description: Whether to attach a pod network interface. Defaults to true.
                           type: boolean
                         autoattachSerialConsole:
                           description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                           type: boolean
+                        autoattachVSOCK:
+                          description: Whether to attach the VSOCK CID to the VM or not. VSOCK access will be available if set to true. Defaults to false.
+                          type: boolean
                         blockMultiQueue:
                           description: Whether or not to enable virtio multi-queue for block devices
                           type: boolean
+                        channels:
+                          description: Virtio channels of the vmi, e.g. for custom guest agents. Each channel can be connected to through the channel subresource.
+                          items:
+                            description: Channel represents a virtio channel of the vmi
+                            properties:
+                              name:
+                                description: Name of the channel, used to connect to it through the channel subresource. Must be a DNS_LABEL and unique within the vmi.
+                                type: string
+                              targetName:
+                                description: TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.
+                                type: string
+                            required:
+                            - name
+                            - targetName
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         disableHotplug:
                           description: DisableHotplug disabled the ability to hotplug disks.
                           type: boolean
@@ -6568,13 +9568,13 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
                                 type: integer
                               cache:
-                                description: Cache specifies which kvm disk cache mode should be used.
+                                description: 'Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.'
                                 type: string
                               cdrom:
                                 description: Attach a volume as a cdrom to the vmi.
                                 properties:
                                   bus:
-                                    description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                    description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                                     type: string
                                   readonly:
                                     description: ReadOnly. Defaults to true.
@@ -6586,11 +9586,17 @@ var CRDsValidation map[string]string = map[string]string{
                               dedicatedIOThread:
                                 description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
                                 type: boolean
+                              detectZeroes:
+                                description: 'DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.'
+                                type: string
+                              discard:
+                                description: 'Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.'
+                                type: string
                               disk:
                                 description: Attach a volume as a disk to the vmi.
                                 properties:
                                   bus:
-                                    description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                    description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                                     type: string
                                   pciAddress:
                                     description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
@@ -6599,6 +9605,24 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: ReadOnly. Defaults to false.
                                     type: boolean
                                 type: object
+                              encryption:
+                                description: Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+                                properties:
+                                  format:
+                                    description: Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.
+                                    type: string
+                                  secretName:
+                                    description: SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+                                    type: string
+                                required:
+                                - secretName
+                                type: object
+                              excludeFromSnapshot:
+                                description: ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.
+                                type: boolean
+                              expandGuestFilesystem:
+                                description: ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.
+                                type: boolean
                               floppy:
                                 description: Attach a volume as a floppy to the vmi.
                                 properties:
@@ -6612,6 +9636,74 @@ var CRDsValidation map[string]string = map[string]string{
                               io:
                                 description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                                 type: string
+                              ioTune:
+                                description: IOTune limits the I/O operations and the bandwidth of the disk.
+                                properties:
+                                  bandwidth:
+                                    description: Bandwidth limits the bytes per second of the disk.
+                                    properties:
+                                      burstLength:
+                                        description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                        format: int64
+                                        type: integer
+                                      read:
+                                        description: Read limits the reads.
+                                        format: int64
+                                        type: integer
+                                      readBurst:
+                                        description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                      total:
+                                        description: Total limits the sum of reads and writes.
+                                        format: int64
+                                        type: integer
+                                      totalBurst:
+                                        description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                      write:
+                                        description: Write limits the writes.
+                                        format: int64
+                                        type: integer
+                                      writeBurst:
+                                        description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                  iops:
+                                    description: IOPS limits the I/O operations per second of the disk.
+                                    properties:
+                                      burstLength:
+                                        description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                        format: int64
+                                        type: integer
+                                      read:
+                                        description: Read limits the reads.
+                                        format: int64
+                                        type: integer
+                                      readBurst:
+                                        description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                      total:
+                                        description: Total limits the sum of reads and writes.
+                                        format: int64
+                                        type: integer
+                                      totalBurst:
+                                        description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                      write:
+                                        description: Write limits the writes.
+                                        format: int64
+                                        type: integer
+                                      writeBurst:
+                                        description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -6621,6 +9713,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   readonly:
                                     description: ReadOnly. Defaults to false.
                                     type: boolean
+                                  reservation:
+                                    description: Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.
+                                    type: boolean
                                 type: object
                               name:
                                 description: Name is the device name
@@ -6636,7 +9731,7 @@ var CRDsValidation map[string]string = map[string]string{
                             type: object
                           type: array
                         filesystems:
-                          description: Filesystems describes filesystem which is connected to the vmi.
+                          description: Filesystems describes filesystem which is connected to the vmi. The volume with the name of the filesystem is shared into the guest with virtiofs, it can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.
                           items:
                             properties:
                               name:
@@ -6644,6 +9739,50 @@ var CRDsValidation map[string]string = map[string]string{
                                 type: string
                               virtiofs:
                                 description: Virtiofs is supported
+                                properties:
+                                  gidMap:
+                                    description: GIDMap maps the group IDs of the guest to the group IDs of the shared directory.
+                                    properties:
+                                      count:
+                                        description: Count is the number of IDs in the range, it must be greater than 0.
+                                        format: int32
+                                        type: integer
+                                      guestID:
+                                        description: GuestID is the first ID of the range in the guest.
+                                        format: int32
+                                        type: integer
+                                      hostID:
+                                        description: HostID is the first ID of the range in the shared directory.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - guestID
+                                    - hostID
+                                    - count
+                                    type: object
+                                  subPath:
+                                    description: SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root. It allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.
+                                    type: string
+                                  uidMap:
+                                    description: UIDMap maps the user IDs of the guest to the user IDs of the shared directory.
+                                    properties:
+                                      count:
+                                        description: Count is the number of IDs in the range, it must be greater than 0.
+                                        format: int32
+                                        type: integer
+                                      guestID:
+                                        description: GuestID is the first ID of the range in the guest.
+                                        format: int32
+                                        type: integer
+                                      hostID:
+                                        description: HostID is the first ID of the range in the shared directory.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - guestID
+                                    - hostID
+                                    - count
+                                    type: object
                                 type: object
                             required:
                             - name
@@ -6651,6 +9790,9 @@ var CRDsValidation map[string]string = map[string]string{
                             type: object
                           type: array
                           x-kubernetes-list-type: atomic
+                        freePageReporting:
+                          description: Whether to enable free page reporting on the memory balloon device, so that memory freed by the guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.
+                          type: boolean
                         gpus:
                           description: Whether to attach a GPU device to the vmi.
                           items:
@@ -6675,6 +9817,14 @@ var CRDsValidation map[string]string = map[string]string{
                                 type: string
                               name:
                                 type: string
+                              nvme:
+                                description: NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.
+                                properties:
+                                  namespace:
+                                    description: Namespace is the ID of the namespace. Defaults to 1.
+                                    format: int32
+                                    type: integer
+                                type: object
                             required:
                             - deviceName
                             - name
@@ -6703,6 +9853,73 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Interfaces describe network interfaces which are added to the vmi.
                           items:
                             properties:
+                              bandwidth:
+                                description: Bandwidth limits the traffic of the interface. It is only supported with the bridge, masquerade and macvtap bindings.
+                                properties:
+                                  inbound:
+                                    description: Inbound limits the traffic received by the guest (rx).
+                                    properties:
+                                      average:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes which can be sent at the peak rate.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      peak:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - average
+                                    type: object
+                                  outbound:
+                                    description: Outbound limits the traffic sent by the guest (tx).
+                                    properties:
+                                      average:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes which can be sent at the peak rate.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      peak:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - average
+                                    type: object
+                                type: object
+                              binding:
+                                description: Binding specifies the binding plugin that will be used to connect the interface to the guest. It provides an alternative to InterfaceBindingMethod.
+                                properties:
+                                  name:
+                                    description: Name references the binding plugin name as registered in the KubeVirt CR.
+                                    type: string
+                                required:
+                                - name
+                                type: object
                               bootOrder:
                                 description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                 type: integer
@@ -6714,6 +9931,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   bootFileName:
                                     description: If specified will pass option 67 to interface's DHCP server
                                     type: string
+                                  mtu:
+                                    description: If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.
+                                    type: integer
                                   ntpServers:
                                     description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                                     items:
@@ -6735,10 +9955,58 @@ var CRDsValidation map[string]string = map[string]string{
                                       - value
                                       type: object
                                     type: array
+                                  routes:
+                                    description: If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes of the pod interface.
+                                    items:
+                                      description: DHCPRoute defines a static route passed to the VM through DHCP.
+                                      properties:
+                                        destination:
+                                          description: Destination is the IPv4 CIDR of the routed network. Required.
+                                          type: string
+                                        gateway:
+                                          description: Gateway is the IPv4 address of the next hop. Required.
+                                          type: string
+                                      required:
+                                      - destination
+                                      - gateway
+                                      type: object
+                                    type: array
                                   tftpServerName:
                                     description: If specified will pass option 66 to interface's DHCP server
                                     type: string
                                 type: object
+                              firewall:
+                                description: Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod, so it applies even where NetworkPolicies don't, like on bridged secondary networks. It is only supported with the bridge and masquerade bindings.
+                                properties:
+                                  defaultAction:
+                                    description: DefaultAction applies to the packets which match no rule. Defaults to Allow. Deny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass. VMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label, which virt-handler sets where it is loaded.
+                                    type: string
+                                  rules:
+                                    description: Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.
+                                    items:
+                                      description: FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port. The packets match when all the criteria which are set match.
+                                      properties:
+                                        action:
+                                          description: Action is Allow or Deny.
+                                          type: string
+                                        cidr:
+                                          description: CIDR is the network of the remote peer, IPv4 or IPv6.
+                                          type: string
+                                        direction:
+                                          description: Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest. The rule applies to both directions if it is not set.
+                                          type: string
+                                        port:
+                                          description: Port is the destination port, on the guest for Ingress and on the remote peer for Egress. It requires the TCP, UDP or SCTP protocol.
+                                          format: int32
+                                          type: integer
+                                        protocol:
+                                          description: Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.
+                                          type: string
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                type: object
                               macAddress:
                                 description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                 type: string
@@ -6752,6 +10020,22 @@ var CRDsValidation map[string]string = map[string]string{
                               name:
                                 description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                                 type: string
+                              offloads:
+                                description: Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default. It requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.
+                                properties:
+                                  checksum:
+                                    description: Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled with it and can't be enabled without it.
+                                    type: boolean
+                                  gso:
+                                    description: GSO toggles the generic segmentation offload of the host.
+                                    type: boolean
+                                  tso:
+                                    description: TSO toggles the TCP segmentation offload, for IPv4 and IPv6.
+                                    type: boolean
+                                type: object
+                              passt:
+                                description: InterfacePasst connects the guest to the pod network through passt, a user-mode network stack which forwards TCP and UDP traffic over both IPv4 and IPv6.
+                                type: object
                               pciAddress:
                                 description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                                 type: string
@@ -6768,35 +10052,126 @@ var CRDsValidation map[string]string = map[string]string{
                                       format: int32
                                       type: integer
                                     protocol:
-                                      description: Protocol for port. Must be UDP or TCP. Defaults to "TCP".
+                                      description: Protocol for port. Must be UDP, TCP or SCTP. Defaults to "TCP".
                                       type: string
                                   required:
                                   - port
                                   type: object
                                 type: array
+                              romDisabled:
+                                description: If set, no option ROM is exposed to the guest for the interface. Interfaces with a boot order have an option ROM by default, which is needed to boot from the network with BIOS firmware. EFI firmware can boot from the network without it.
+                                type: boolean
+                              rss:
+                                description: RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a multi-queue guest across its queues, and thus across its vCPUs. It requires the virtio model and networkInterfaceMultiqueue, and is only supported with the bridge, masquerade and macvtap bindings.
+                                properties:
+                                  hashReport:
+                                    description: HashReport makes the device report the RSS hash of every received packet to the guest, which saves the guest from computing it again. The device is emulated by QEMU instead of vhost-net then.
+                                    type: boolean
+                                type: object
                               slirp:
                                 type: object
                               sriov:
+                                properties:
+                                  failover:
+                                    description: Failover names a virtio interface of the vmi which the guest teams with the VF through its net_failover driver. The traffic fails over to that interface while the VF is detached for a live migration. Both interfaces must have the same MAC address and be connected to the same L2 network.
+                                    type: string
                                 type: object
+                              state:
+                                description: State represents the requested operational state of the interface. The link of the interface is set "down" or "up", "up" being the default, and the guest sees a link which is down as an unplugged cable. "absent" is set when the interface is hot unplugged.
+                                type: string
                               tag:
                                 description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                                 type: string
+                              vdpa:
+                                description: InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface. The device is allocated by a device plugin, through the resource of the Multus network.
+                                type: object
+                              vhostuser:
+                                description: InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP, through a vhost-user socket. The guest memory is shared with the switch.
+                                type: object
                             required:
                             - name
                             type: object
                           type: array
+                        logSerialConsole:
+                          description: Whether to mirror the output of the serial console to the virt-launcher pod log. The log can be retrieved later through the console/log subresource. Defaults to false.
+                          type: boolean
+                        memoryDump:
+                          description: If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource, for example by VirtualMachineSnapshots which include a memory dump.
+                          properties:
+                            claimName:
+                              description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                              type: string
+                          required:
+                          - claimName
+                          type: object
                         networkInterfaceMultiqueue:
                           description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                           type: boolean
+                        panicMemoryDump:
+                          description: If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.
+                          properties:
+                            claimName:
+                              description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                              type: string
+                          required:
+                          - claimName
+                          type: object
                         rng:
                           description: Whether to have random number generator from host
                           type: object
+                        serialConsoleLog:
+                          description: If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.
+                          properties:
+                            claimName:
+                              description: ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.
+                              type: string
+                            maxFileSize:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            maxFiles:
+                              description: MaxFiles is the number of rotated log files which are kept. Defaults to 3.
+                              format: int32
+                              type: integer
+                          required:
+                          - claimName
+                          type: object
+                        serials:
+                          description: Additional serial ports of the vmi, next to the default serial console. Each serial port can be connected to through the serial subresource.
+                          items:
+                            description: SerialPort represents an additional serial port of the vmi
+                            properties:
+                              name:
+                                description: Name of the serial port, used to connect to it through the serial subresource. Must be a DNS_LABEL and unique within the vmi.
+                                type: string
+                            required:
+                            - name
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                        tpm:
+                          description: Whether to emulate a TPM device.
+                          properties:
+                            persistent:
+                              description: Persistent indicates the state of the TPM device should be kept across reboots and migrations. The state is stored in a per-VM backend storage volume. Defaults to false.
+                              type: boolean
+                          type: object
                         useVirtioTransitional:
                           description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                           type: boolean
                         watchdog:
                           description: Watchdog describes a watchdog device which can be added to the vmi.
                           properties:
+                            diag288:
+                              description: diag288 watchdog device, only available on s390x.
+                              properties:
+                                action:
+                                  description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
+                                  type: string
+                              type: object
                             i6300esb:
                               description: i6300esb watchdog device.
                               properties:
@@ -6984,9 +10359,43 @@ var CRDsValidation map[string]string = map[string]string{
                             efi:
                               description: If set, EFI will be used instead of BIOS.
                               properties:
+                                persistent:
+                                  description: Persistent indicates the EFI NVRAM should be kept across reboots and migrations. The NVRAM is stored in a per-VM backend storage volume. Defaults to false.
+                                  type: boolean
                                 secureBoot:
                                   description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                                   type: boolean
+                                secureBootKeys:
+                                  description: SecureBootKeys references Secrets with custom certificates which are enrolled into the NVRAM at first boot. Requires SecureBoot to be enabled.
+                                  properties:
+                                    db:
+                                      description: DB references a Secret with certificates which are allowed to sign boot loaders and kernels, added to the default ones.
+                                      properties:
+                                        secretName:
+                                          description: SecretName is the name of the Secret in the namespace of the vmi.
+                                          type: string
+                                      required:
+                                      - secretName
+                                      type: object
+                                    kek:
+                                      description: KEK references a Secret with Key Exchange Key certificates, added to the default ones.
+                                      properties:
+                                        secretName:
+                                          description: SecretName is the name of the Secret in the namespace of the vmi.
+                                          type: string
+                                      required:
+                                      - secretName
+                                      type: object
+                                    pk:
+                                      description: PK references a Secret with the Platform Key certificate, replacing the default one.
+                                      properties:
+                                        secretName:
+                                          description: SecretName is the name of the Secret in the namespace of the vmi.
+                                          type: string
+                                      required:
+                                      - secretName
+                                      type: object
+                                  type: object
                               type: object
                           type: object
                         serial:
@@ -6996,9 +10405,44 @@ var CRDsValidation map[string]string = map[string]string{
                           description: UUID reported by the vmi bios. Defaults to a random generated uid.
                           type: string
                       type: object
+                    ioThreads:
+                      description: IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.
+                      properties:
+                        dedicatedPinning:
+                          description: 'DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated. emulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads. vcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads. One of: emulator, vcpus. Defaults to emulator.'
+                          type: string
+                        sharedPoolSize:
+                          description: SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread. Defaults to the number of IOThreads determined by the ioThreadsPolicy.
+                          format: int32
+                          type: integer
+                      type: object
                     ioThreadsPolicy:
                       description: 'Controls whether or not disks will share IOThreads. Omitting IOThreadsPolicy disables use of IOThreads. One of: shared, auto'
                       type: string
+                    launchSecurity:
+                      description: Launch Security setting of the vmi.
+                      properties:
+                        sev:
+                          description: AMD Secure Encrypted Virtualization (SEV).
+                          properties:
+                            attestation:
+                              description: If specified, run the attestation process for a vmi.
+                              type: object
+                            policy:
+                              description: 'Guest policy flags as defined in AMD SEV API specification. Note: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.'
+                              properties:
+                                encryptedState:
+                                  description: SEV-ES is required. Defaults to false.
+                                  type: boolean
+                              type: object
+                          type: object
+                        snp:
+                          description: AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.
+                          type: object
+                        tdx:
+                          description: Intel Trust Domain Extensions (TDX).
+                          type: object
+                      type: object
                     machine:
                       description: Machine type.
                       properties:
@@ -7006,566 +10450,3316 @@ var CRDsValidation map[string]string = map[string]string{
                           description: QEMU machine type is the actual chipset of the VirtualMachineInstance.
                           type: string
                       required:
-                      - type
+                      - type
+                      type: object
+                    memory:
+                      description: Memory allow specifying the VMI memory features.
+                      properties:
+                        guest:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Guest allows to specifying the amount of memory which is visible inside the Guest OS. The Guest must lie between Requests and Limits from the resources section. Defaults to the requested memory in the resources section if not specified.
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                        hugepages:
+                          description: Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.
+                          properties:
+                            pageSize:
+                              description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
+                              type: string
+                          type: object
+                        maxGuest:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS. The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                      type: object
+                    resources:
+                      description: Resources describes the Compute Resources required by this vmi.
+                      properties:
+                        limits:
+                          additionalProperties:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          description: Limits describes the maximum amount of compute resources allowed. Valid resource keys are "memory" and "cpu".
+                          type: object
+                        overcommitGuestOverhead:
+                          description: Don't ask the scheduler to take the guest-management overhead into account. Instead put the overhead only into the container's memory limit. This can lead to crashes if all memory is in use on a node. Defaults to false.
+                          type: boolean
+                        requests:
+                          additionalProperties:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          description: Requests is a description of the initial vmi resources. Valid resource keys are "memory" and "cpu".
+                          type: object
+                      type: object
+                  required:
+                  - devices
+                  type: object
+                evictionStrategy:
+                  description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.
+                  type: string
+                hostname:
+                  description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
+                  type: string
+                ignition:
+                  description: If specified, the Ignition config is passed to the guest through the fw_cfg device. Used to provision guests like Fedora CoreOS or Flatcar, which do not use cloud-init.
+                  properties:
+                    configMapRef:
+                      description: ConfigMapRef references a ConfigMap that contains the Ignition config under the config.ign key.
+                      properties:
+                        name:
+                          description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                          type: string
+                      type: object
+                    secretRef:
+                      description: SecretRef references a k8s Secret that contains the Ignition config under the config.ign key.
+                      properties:
+                        name:
+                          description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                          type: string
+                      type: object
+                  type: object
+                livenessProbe:
+                  description: 'Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                  properties:
+                    exec:
+                      description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+                      properties:
+                        command:
+                          description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                          items:
+                            type: string
+                          type: array
+                      type: object
+                    failureThreshold:
+                      description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    guestAgentPing:
+                      description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+                      type: object
+                    httpGet:
+                      description: HTTPGet specifies the http request to perform.
+                      properties:
+                        host:
+                          description: Host name to connect to, defaults to the pod IP. You probably want to set "Host" in httpHeaders instead.
+                          type: string
+                        httpHeaders:
+                          description: Custom headers to set in the request. HTTP allows repeated headers.
+                          items:
+                            description: HTTPHeader describes a custom header to be used in HTTP probes
+                            properties:
+                              name:
+                                description: The header field name
+                                type: string
+                              value:
+                                description: The header field value
+                                type: string
+                            required:
+                            - name
+                            - value
+                            type: object
+                          type: array
+                        path:
+                          description: Path to access on the HTTP server.
+                          type: string
+                        port:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Name or number of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                          x-kubernetes-int-or-string: true
+                        scheme:
+                          description: Scheme to use for connecting to the host. Defaults to HTTP.
+                          type: string
+                      required:
+                      - port
+                      type: object
+                    initialDelaySeconds:
+                      description: 'Number of seconds after the VirtualMachineInstance has started before liveness probes are initiated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                      format: int32
+                      type: integer
+                    periodSeconds:
+                      description: How often (in seconds) to perform the probe. Default to 10 seconds. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    successThreshold:
+                      description: Minimum consecutive successes for the probe to be considered successful after having failed. Defaults to 1. Must be 1 for liveness. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    tcpSocket:
+                      description: 'TCPSocket specifies an action involving a TCP port. TCP hooks not yet supported TODO: implement a realistic TCP lifecycle hook'
+                      properties:
+                        host:
+                          description: 'Optional: Host name to connect to, defaults to the pod IP.'
+                          type: string
+                        port:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Number or name of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                          x-kubernetes-int-or-string: true
+                      required:
+                      - port
+                      type: object
+                    timeoutSeconds:
+                      description: 'Number of seconds after which the probe times out. Defaults to 1 second. Minimum value is 1. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                      format: int32
+                      type: integer
+                  type: object
+                networks:
+                  description: List of networks that can be attached to a vm's virtual interface.
+                  items:
+                    description: Network represents a network type and a resource that should be connected to the vm.
+                    properties:
+                      multus:
+                        description: Represents the multus cni network.
+                        properties:
+                          default:
+                            description: Select the default network and add it to the multus-cni.io/default-network annotation.
+                            type: boolean
+                          networkName:
+                            description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                            type: string
+                          persistentIPs:
+                            description: PersistentIPs keeps the addresses allocated by the IPAM of the network across live migrations and restarts of the VM. The addresses are requested through the ips field of the multus network selection element, so the IPAM plugin has to support static IP requests. Only bridge interfaces are supported.
+                            type: boolean
+                        required:
+                        - networkName
+                        type: object
+                      name:
+                        description: 'Network name. Must be a DNS_LABEL and unique within the vm. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                        type: string
+                      pod:
+                        description: Represents the stock pod network interface.
+                        properties:
+                          vmIPv6NetworkCIDR:
+                            description: IPv6 CIDR for the vm network. Defaults to fd10:0:2::/120 if not specified.
+                            type: string
+                          vmNetworkCIDR:
+                            description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
+                            type: string
+                        type: object
+                    required:
+                    - name
+                    type: object
+                  type: array
+                nodeSelector:
+                  additionalProperties:
+                    type: string
+                  description: 'NodeSelector is a selector which must be true for the vmi to fit on a node. Selector which must match a node''s labels for the vmi to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/'
+                  type: object
+                priorityClassName:
+                  description: If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.
+                  type: string
+                readinessProbe:
+                  description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                  properties:
+                    exec:
+                      description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+                      properties:
+                        command:
+                          description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                          items:
+                            type: string
+                          type: array
+                      type: object
+                    failureThreshold:
+                      description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    guestAgentPing:
+                      description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+                      type: object
+                    httpGet:
+                      description: HTTPGet specifies the http request to perform.
+                      properties:
+                        host:
+                          description: Host name to connect to, defaults to the pod IP. You probably want to set "Host" in httpHeaders instead.
+                          type: string
+                        httpHeaders:
+                          description: Custom headers to set in the request. HTTP allows repeated headers.
+                          items:
+                            description: HTTPHeader describes a custom header to be used in HTTP probes
+                            properties:
+                              name:
+                                description: The header field name
+                                type: string
+                              value:
+                                description: The header field value
+                                type: string
+                            required:
+                            - name
+                            - value
+                            type: object
+                          type: array
+                        path:
+                          description: Path to access on the HTTP server.
+                          type: string
+                        port:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Name or number of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                          x-kubernetes-int-or-string: true
+                        scheme:
+                          description: Scheme to use for connecting to the host. Defaults to HTTP.
+                          type: string
+                      required:
+                      - port
                       type: object
-                    memory:
-                      description: Memory allow specifying the VMI memory features.
+                    initialDelaySeconds:
+                      description: 'Number of seconds after the VirtualMachineInstance has started before liveness probes are initiated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                      format: int32
+                      type: integer
+                    periodSeconds:
+                      description: How often (in seconds) to perform the probe. Default to 10 seconds. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    successThreshold:
+                      description: Minimum consecutive successes for the probe to be considered successful after having failed. Defaults to 1. Must be 1 for liveness. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    tcpSocket:
+                      description: 'TCPSocket specifies an action involving a TCP port. TCP hooks not yet supported TODO: implement a realistic TCP lifecycle hook'
                       properties:
-                        guest:
+                        host:
+                          description: 'Optional: Host name to connect to, defaults to the pod IP.'
+                          type: string
+                        port:
                           anyOf:
                           - type: integer
                           - type: string
-                          description: Guest allows to specifying the amount of memory which is visible inside the Guest OS. The Guest must lie between Requests and Limits from the resources section. Defaults to the requested memory in the resources section if not specified.
-                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          description: Number or name of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
                           x-kubernetes-int-or-string: true
-                        hugepages:
-                          description: Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.
-                          properties:
-                            pageSize:
-                              description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
-                              type: string
-                          type: object
+                      required:
+                      - port
                       type: object
-                    resources:
-                      description: Resources describes the Compute Resources required by this vmi.
-                      properties:
-                        limits:
-                          additionalProperties:
+                    timeoutSeconds:
+                      description: 'Number of seconds after which the probe times out. Defaults to 1 second. Minimum value is 1. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                      format: int32
+                      type: integer
+                  type: object
+                schedulerName:
+                  description: If specified, the VMI will be dispatched by specified scheduler. If not specified, the VMI will be dispatched by default scheduler.
+                  type: string
+                subdomain:
+                  description: If specified, the fully qualified vmi hostname will be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>". If not specified, the vmi will not have a domainname at all. The DNS entry will resolve to the vmi, no matter if the vmi itself can pick up a hostname.
+                  type: string
+                terminationGracePeriodSeconds:
+                  description: Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.
+                  format: int64
+                  type: integer
+                tolerations:
+                  description: If toleration is specified, obey all the toleration rules.
+                  items:
+                    description: The pod this Toleration is attached to tolerates any taint that matches the triple <key,value,effect> using the matching operator <operator>.
+                    properties:
+                      effect:
+                        description: Effect indicates the taint effect to match. Empty means match all taint effects. When specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.
+                        type: string
+                      key:
+                        description: Key is the taint key that the toleration applies to. Empty means match all taint keys. If the key is empty, operator must be Exists; this combination means to match all values and all keys.
+                        type: string
+                      operator:
+                        description: Operator represents a key's relationship to the value. Valid operators are Exists and Equal. Defaults to Equal. Exists is equivalent to wildcard for value, so that a pod can tolerate all taints of a particular category.
+                        type: string
+                      tolerationSeconds:
+                        description: TolerationSeconds represents the period of time the toleration (which must be of effect NoExecute, otherwise this field is ignored) tolerates the taint. By default, it is not set, which means tolerate the taint forever (do not evict). Zero and negative values will be treated as 0 (evict immediately) by the system.
+                        format: int64
+                        type: integer
+                      value:
+                        description: Value is the taint value the toleration matches to. If the operator is Exists, the value should be empty, otherwise just a regular string.
+                        type: string
+                    type: object
+                  type: array
+                volumes:
+                  description: List of volumes that can be mounted by disks belonging to the vmi.
+                  items:
+                    description: Volume represents a named volume in a vmi.
+                    properties:
+                      cloudInitConfigDrive:
+                        description: 'CloudInitConfigDrive represents a cloud-init Config Drive user-data source. The Config Drive data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: https://cloudinit.readthedocs.io/en/latest/topics/datasources/configdrive.html'
+                        properties:
+                          networkData:
+                            description: NetworkData contains config drive inline cloud-init networkdata.
+                            type: string
+                          networkDataBase64:
+                            description: NetworkDataBase64 contains config drive cloud-init networkdata as a base64 encoded string.
+                            type: string
+                          networkDataSecretRef:
+                            description: NetworkDataSecretRef references a k8s secret that contains config drive networkdata.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                type: string
+                            type: object
+                          secretRef:
+                            description: UserDataSecretRef references a k8s secret that contains config drive userdata.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                type: string
+                            type: object
+                          userData:
+                            description: UserData contains config drive inline cloud-init userdata.
+                            type: string
+                          userDataBase64:
+                            description: UserDataBase64 contains config drive cloud-init userdata as a base64 encoded string.
+                            type: string
+                        type: object
+                      cloudInitNoCloud:
+                        description: 'CloudInitNoCloud represents a cloud-init NoCloud user-data source. The NoCloud data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html'
+                        properties:
+                          generateNetworkData:
+                            description: GenerateNetworkData generates NoCloud cloud-init networkdata with the static addresses, routes and DNS servers of the VMI's pod and multus networks. Can't be used together with the other networkdata fields.
+                            type: boolean
+                          networkData:
+                            description: NetworkData contains NoCloud inline cloud-init networkdata.
+                            type: string
+                          networkDataBase64:
+                            description: NetworkDataBase64 contains NoCloud cloud-init networkdata as a base64 encoded string.
+                            type: string
+                          networkDataSecretRef:
+                            description: NetworkDataSecretRef references a k8s secret that contains NoCloud networkdata.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                type: string
+                            type: object
+                          secretRef:
+                            description: UserDataSecretRef references a k8s secret that contains NoCloud userdata.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                type: string
+                            type: object
+                          userData:
+                            description: UserData contains NoCloud inline cloud-init userdata.
+                            type: string
+                          userDataBase64:
+                            description: UserDataBase64 contains NoCloud cloud-init userdata as a base64 encoded string.
+                            type: string
+                        type: object
+                      configMap:
+                        description: 'ConfigMapSource represents a reference to a ConfigMap in the same namespace. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
+                        properties:
+                          name:
+                            description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                            type: string
+                          optional:
+                            description: Specify whether the ConfigMap or it's keys must be defined
+                            type: boolean
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                            type: string
+                        type: object
+                      containerDisk:
+                        description: 'ContainerDisk references a docker image, embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
+                        properties:
+                          image:
+                            description: Image is the name of the image with the embedded disk.
+                            type: string
+                          imagePullPolicy:
+                            description: 'Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                            type: string
+                          imagePullSecret:
+                            description: ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.
+                            type: string
+                          path:
+                            description: Path defines the path to disk file in the container
+                            type: string
+                        required:
+                        - image
+                        type: object
+                      dataVolume:
+                        description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
+                        properties:
+                          name:
+                            description: Name represents the name of the DataVolume in the same namespace
+                            type: string
+                        required:
+                        - name
+                        type: object
+                      downwardAPI:
+                        description: DownwardAPI represents downward API about the pod that should populate this volume
+                        properties:
+                          fields:
+                            description: Fields is a list of downward API volume file
+                            items:
+                              description: DownwardAPIVolumeFile represents information to create the file containing the pod field
+                              properties:
+                                fieldRef:
+                                  description: 'Required: Selects a field of the pod: only annotations, labels, name and namespace are supported.'
+                                  properties:
+                                    apiVersion:
+                                      description: Version of the schema the FieldPath is written in terms of, defaults to "v1".
+                                      type: string
+                                    fieldPath:
+                                      description: Path of the field to select in the specified API version.
+                                      type: string
+                                  required:
+                                  - fieldPath
+                                  type: object
+                                mode:
+                                  description: 'Optional: mode bits used to set permissions on this file, must be an octal value between 0000 and 0777 or a decimal value between 0 and 511. YAML accepts both octal and decimal values, JSON requires decimal values for mode bits. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.'
+                                  format: int32
+                                  type: integer
+                                path:
+                                  description: 'Required: Path is  the relative path name of the file to be created. Must not be absolute or contain the ''..'' path. Must be utf-8 encoded. The first item of the relative path must not start with ''..'''
+                                  type: string
+                                resourceFieldRef:
+                                  description: 'Selects a resource of the container: only resources limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.'
+                                  properties:
+                                    containerName:
+                                      description: 'Container name: required for volumes, optional for env vars'
+                                      type: string
+                                    divisor:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      description: Specifies the output format of the exposed resources, defaults to "1"
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    resource:
+                                      description: 'Required: resource to select'
+                                      type: string
+                                  required:
+                                  - resource
+                                  type: object
+                              required:
+                              - path
+                              type: object
+                            type: array
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                            type: string
+                        type: object
+                      downwardMetrics:
+                        description: DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.
+                        type: object
+                      emptyDisk:
+                        description: 'EmptyDisk represents a temporary disk which shares the vmis lifecycle. More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html'
+                        properties:
+                          capacity:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: Capacity of the sparse disk.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                        required:
+                        - capacity
+                        type: object
+                      ephemeral:
+                        description: Ephemeral is a special volume source that "wraps" specified source and provides copy-on-write image on top of it.
+                        properties:
+                          persistentVolumeClaim:
+                            description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            properties:
+                              claimName:
+                                description: 'ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                type: string
+                              readOnly:
+                                description: Will force the ReadOnly setting in VolumeMounts. Default false.
+                                type: boolean
+                            required:
+                            - claimName
+                            type: object
+                        type: object
+                      hostDisk:
+                        description: HostDisk represents a disk created on the cluster level
+                        properties:
+                          capacity:
                             anyOf:
                             - type: integer
                             - type: string
+                            description: Capacity of the sparse disk
                             pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                             x-kubernetes-int-or-string: true
-                          description: Limits describes the maximum amount of compute resources allowed. Valid resource keys are "memory" and "cpu".
-                          type: object
-                        overcommitGuestOverhead:
-                          description: Don't ask the scheduler to take the guest-management overhead into account. Instead put the overhead only into the container's memory limit. This can lead to crashes if all memory is in use on a node. Defaults to false.
-                          type: boolean
-                        requests:
-                          additionalProperties:
+                          path:
+                            description: The path to HostDisk image located on the cluster
+                            type: string
+                          shared:
+                            description: Shared indicate whether the path is shared between nodes
+                            type: boolean
+                          type:
+                            description: Contains information if disk.img exists or should be created allowed options are 'Disk' and 'DiskOrCreate'
+                            type: string
+                        required:
+                        - path
+                        - type
+                        type: object
+                      name:
+                        description: 'Volume''s name. Must be a DNS_LABEL and unique within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                        type: string
+                      persistentVolumeClaim:
+                        description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                        properties:
+                          claimName:
+                            description: 'ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            type: string
+                          readOnly:
+                            description: Will force the ReadOnly setting in VolumeMounts. Default false.
+                            type: boolean
+                        required:
+                        - claimName
+                        type: object
+                      secret:
+                        description: 'SecretVolumeSource represents a reference to a secret data in the same namespace. More info: https://kubernetes.io/docs/concepts/configuration/secret/'
+                        properties:
+                          optional:
+                            description: Specify whether the Secret or it's keys must be defined
+                            type: boolean
+                          secretName:
+                            description: 'Name of the secret in the pod''s namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                            type: string
+                          volumeLabel:
+                            description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                            type: string
+                        type: object
+                      serviceAccount:
+                        description: 'ServiceAccountVolumeSource represents a reference to a service account. There can only be one volume of this type! More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                        properties:
+                          serviceAccountName:
+                            description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                            type: string
+                        type: object
+                      swapDisk:
+                        description: SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle. The guest is told to enable the swap through the cloud-init vendor data.
+                        properties:
+                          capacity:
                             anyOf:
                             - type: integer
                             - type: string
+                            description: Capacity of the sparse swap disk.
                             pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                             x-kubernetes-int-or-string: true
-                          description: Requests is a description of the initial vmi resources. Valid resource keys are "memory" and "cpu".
-                          type: object
-                      type: object
-                  required:
-                  - devices
-                  type: object
-                evictionStrategy:
-                  description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.
-                  type: string
-                hostname:
-                  description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
-                  type: string
-                livenessProbe:
-                  description: 'Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
-                  properties:
-                    failureThreshold:
-                      description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
-                      format: int32
-                      type: integer
-                    httpGet:
-                      description: HTTPGet specifies the http request to perform.
-                      properties:
-                        host:
-                          description: Host name to connect to, defaults to the pod IP. You probably want to set "Host" in httpHeaders instead.
-                          type: string
-                        httpHeaders:
-                          description: Custom headers to set in the request. HTTP allows repeated headers.
-                          items:
-                            description: HTTPHeader describes a custom header to be used in HTTP probes
+                        required:
+                        - capacity
+                        type: object
+                      sysprep:
+                        description: Represents a Sysprep volume source.
+                        properties:
+                          configMap:
+                            description: ConfigMap references a ConfigMap that contains Sysprep answer file named autounattend.xml that should be attached as disk of CDROM type.
                             properties:
                               name:
-                                description: The header field name
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
                                 type: string
-                              value:
-                                description: The header field value
+                            type: object
+                          secret:
+                            description: Secret references a k8s Secret that contains Sysprep answer file named autounattend.xml that should be attached as disk of CDROM type.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
                                 type: string
-                            required:
-                            - name
-                            - value
                             type: object
-                          type: array
-                        path:
-                          description: Path to access on the HTTP server.
-                          type: string
-                        port:
-                          anyOf:
-                          - type: integer
-                          - type: string
-                          description: Name or number of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
-                          x-kubernetes-int-or-string: true
-                        scheme:
-                          description: Scheme to use for connecting to the host. Defaults to HTTP.
-                          type: string
-                      required:
-                      - port
-                      type: object
-                    initialDelaySeconds:
-                      description: 'Number of seconds after the VirtualMachineInstance has started before liveness probes are initiated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
-                      format: int32
-                      type: integer
-                    periodSeconds:
-                      description: How often (in seconds) to perform the probe. Default to 10 seconds. Minimum value is 1.
-                      format: int32
-                      type: integer
-                    successThreshold:
-                      description: Minimum consecutive successes for the probe to be considered successful after having failed. Defaults to 1. Must be 1 for liveness. Minimum value is 1.
-                      format: int32
-                      type: integer
-                    tcpSocket:
-                      description: 'TCPSocket specifies an action involving a TCP port. TCP hooks not yet supported TODO: implement a realistic TCP lifecycle hook'
-                      properties:
-                        host:
-                          description: 'Optional: Host name to connect to, defaults to the pod IP.'
-                          type: string
-                        port:
-                          anyOf:
-                          - type: integer
-                          - type: string
-                          description: Number or name of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
-                          x-kubernetes-int-or-string: true
-                      required:
-                      - port
-                      type: object
-                    timeoutSeconds:
-                      description: 'Number of seconds after which the probe times out. Defaults to 1 second. Minimum value is 1. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
-                      format: int32
-                      type: integer
-                  type: object
-                networks:
-                  description: List of networks that can be attached to a vm's virtual interface.
+                        type: object
+                    required:
+                    - name
+                    type: object
+                  type: array
+              required:
+              - domain
+              type: object
+          type: object
+      required:
+      - selector
+      - template
+      type: object
+    status:
+      description: Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.
+      nullable: true
+      properties:
+        conditions:
+          items:
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        labelSelector:
+          description: Canonical form of the label selector for HPA which consumes it through the scale subresource.
+          type: string
+        readyReplicas:
+          description: The number of ready replicas for this replica set.
+          format: int32
+          type: integer
+        replicas:
+          description: Total number of non-terminated pods targeted by this deployment (their labels match the selector).
+          format: int32
+          type: integer
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineinstancetype": `openAPIV3Schema:
+  description: VirtualMachineInstancetype resource contains quantitative and resource related VirtualMachine configuration that can be used by multiple VirtualMachine resources.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Required spec describing the instancetype
+      properties:
+        cpu:
+          description: Required CPU related attributes of the instancetype.
+          properties:
+            dedicatedCPUPlacement:
+              description: DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node with enough dedicated pCPUs and pin the vCPUs to it.
+              type: boolean
+            guest:
+              description: Required number of vCPUs to expose to the guest. The resulting CPU topology is derived from the optional PreferredCPUTopology attribute of CPUPreferences.
+              format: int32
+              type: integer
+            isolateEmulatorThread:
+              description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
+              type: boolean
+            model:
+              description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.
+              type: string
+          required:
+          - guest
+          type: object
+        gpus:
+          description: Optionally defines any GPU devices associated with the instancetype.
+          items:
+            properties:
+              deviceName:
+                type: string
+              name:
+                description: Name of the GPU device as exposed by a device plugin
+                type: string
+            required:
+            - deviceName
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        hostDevices:
+          description: Optionally defines any HostDevices associated with the instancetype.
+          items:
+            properties:
+              deviceName:
+                description: DeviceName is the resource name of the host device exposed by a device plugin
+                type: string
+              name:
+                type: string
+              nvme:
+                description: NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.
+                properties:
+                  namespace:
+                    description: Namespace is the ID of the namespace. Defaults to 1.
+                    format: int32
+                    type: integer
+                type: object
+            required:
+            - deviceName
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        ioThreadsPolicy:
+          description: Optionally defines the IOThreadsPolicy to be used by the instancetype.
+          type: string
+        memory:
+          description: Required Memory related attributes of the instancetype.
+          properties:
+            guest:
+              anyOf:
+              - type: integer
+              - type: string
+              description: Required amount of memory which is visible inside the guest OS.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            hugepages:
+              description: Optionally enables the use of hugepages for the VirtualMachineInstance instead of regular memory.
+              properties:
+                pageSize:
+                  description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
+                  type: string
+              type: object
+            overcommitPercent:
+              description: Optionally defines the percentage of the guest memory which is not requested by the pod of the VirtualMachineInstance. A value of 25 results in a memory request of 75% of the guest memory. It is ignored together with hugepages.
+              type: integer
+          required:
+          - guest
+          type: object
+      required:
+      - cpu
+      - memory
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinepool": `openAPIV3Schema:
+  description: VirtualMachinePool resource contains a VirtualMachine configuration that can be used to replicate multiple VirtualMachine resources.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachinePoolSpec is the spec for a VirtualMachinePool resource
+      properties:
+        nameGeneration:
+          description: Options for the name generation of the objects referenced by the template
+          properties:
+            appendIndexToConfigMapRefs:
+              description: AppendIndexToConfigMapRefs appends the VirtualMachine's sequence index to the names of referenced ConfigMaps, so each replica can consume its own ConfigMap.
+              type: boolean
+            appendIndexToSecretRefs:
+              description: AppendIndexToSecretRefs appends the VirtualMachine's sequence index to the names of referenced Secrets, including cloud-init user and network data secrets, so each replica can consume its own Secret.
+              type: boolean
+          type: object
+        paused:
+          description: Indicates that the pool is paused.
+          type: boolean
+        replicas:
+          description: Number of desired VirtualMachines. This is a pointer to distinguish between explicit zero and not specified. Defaults to 1.
+          format: int32
+          type: integer
+        selector:
+          description: Label selector for VirtualMachines. Existing VirtualMachines selected by this will be the ones affected by the pool.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+              items:
+                description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                    items:
+                      type: string
+                    type: array
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+        virtualMachineTemplate:
+          description: Template describes the VirtualMachines that will be created.
+          properties:
+            metadata:
+              nullable: true
+              type: object
+              x-kubernetes-preserve-unknown-fields: true
+            spec:
+              description: VirtualMachineSpec contains the VirtualMachine specification.
+              properties:
+                dataVolumeTemplates:
+                  description: dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference. DataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.
                   items:
-                    description: Network represents a network type and a resource that should be connected to the vm.
+                    nullable: true
                     properties:
-                      multus:
-                        description: Represents the multus cni network.
+                      apiVersion:
+                        description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+                        type: string
+                      kind:
+                        description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+                        type: string
+                      metadata:
+                        nullable: true
+                        type: object
+                        x-kubernetes-preserve-unknown-fields: true
+                      spec:
+                        description: DataVolumeSpec contains the DataVolume specification.
                         properties:
-                          default:
-                            description: Select the default network and add it to the multus-cni.io/default-network annotation.
-                            type: boolean
-                          networkName:
-                            description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                          checkpoints:
+                            description: Checkpoints is a list of DataVolumeCheckpoints, representing stages in a multistage import.
+                            items:
+                              description: DataVolumeCheckpoint defines a stage in a warm migration.
+                              properties:
+                                current:
+                                  description: Current is the identifier of the snapshot created for this checkpoint.
+                                  type: string
+                                previous:
+                                  description: Previous is the identifier of the snapshot from the previous checkpoint.
+                                  type: string
+                              required:
+                              - current
+                              - previous
+                              type: object
+                            type: array
+                          contentType:
+                            description: 'DataVolumeContentType options: "kubevirt", "archive"'
+                            enum:
+                            - kubevirt
+                            - archive
                             type: string
+                          finalCheckpoint:
+                            description: FinalCheckpoint indicates whether the current DataVolumeCheckpoint is the final checkpoint.
+                            type: boolean
+                          preallocation:
+                            description: Preallocation controls whether storage for DataVolumes should be allocated in advance.
+                            type: boolean
+                          pvc:
+                            description: PVC is the PVC specification
+                            properties:
+                              accessModes:
+                                description: 'AccessModes contains the desired access modes the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1'
+                                items:
+                                  type: string
+                                type: array
+                              dataSource:
+                                description: 'This field can be used to specify either: * An existing VolumeSnapshot object (snapshot.storage.k8s.io/VolumeSnapshot) * An existing PVC (PersistentVolumeClaim) * An existing custom resource that implements data population (Alpha) In order to use custom resource types that implement data population, the AnyVolumeDataSource feature gate must be enabled. If the provisioner or an external controller can support the specified data source, it will create a new volume based on the contents of the specified data source.'
+                                properties:
+                                  apiGroup:
+                                    description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+                                    type: string
+                                  kind:
+                                    description: Kind is the type of resource being referenced
+                                    type: string
+                                  name:
+                                    description: Name is the name of resource being referenced
+                                    type: string
+                                required:
+                                - kind
+                                - name
+                                type: object
+                              resources:
+                                description: 'Resources represents the minimum resources the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#resources'
+                                properties:
+                                  limits:
+                                    additionalProperties:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    description: 'Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                                    type: object
+                                  requests:
+                                    additionalProperties:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    description: 'Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                                    type: object
+                                type: object
+                              selector:
+                                description: A label query over volumes to consider for binding.
+                                properties:
+                                  matchExpressions:
+                                    description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                                    items:
+                                      description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                      properties:
+                                        key:
+                                          description: key is the label key that the selector applies to.
+                                          type: string
+                                        operator:
+                                          description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                          type: string
+                                        values:
+                                          description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                          items:
+                                            type: string
+                                          type: array
+                                      required:
+                                      - key
+                                      - operator
+                                      type: object
+                                    type: array
+                                  matchLabels:
+                                    additionalProperties:
+                                      type: string
+                                    description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                                    type: object
+                                type: object
+                              storageClassName:
+                                description: 'Name of the StorageClass required by the claim. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1'
+                                type: string
+                              volumeMode:
+                                description: volumeMode defines what type of volume is required by the claim. Value of Filesystem is implied when not included in claim spec.
+                                type: string
+                              volumeName:
+                                description: VolumeName is the binding reference to the PersistentVolume backing this claim.
+                                type: string
+                            type: object
+                          source:
+                            description: Source is the src of the data for the requested DataVolume
+                            properties:
+                              blank:
+                                description: DataVolumeBlankImage provides the parameters to create a new raw blank image for the PVC
+                                type: object
+                              http:
+                                description: DataVolumeSourceHTTP can be either an http or https endpoint, with an optional basic auth user name and password, and an optional configmap containing additional CAs
+                                properties:
+                                  certConfigMap:
+                                    description: CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef A Secret reference, the secret should contain accessKeyId (user name) base64 encoded, and secretKey (password) also base64 encoded
+                                    type: string
+                                  url:
+                                    description: URL is the URL of the http(s) endpoint
+                                    type: string
+                                required:
+                                - url
+                                type: object
+                              imageio:
+                                description: DataVolumeSourceImageIO provides the parameters to create a Data Volume from an imageio source
+                                properties:
+                                  certConfigMap:
+                                    description: CertConfigMap provides a reference to the CA cert
+                                    type: string
+                                  diskId:
+                                    description: DiskID provides id of a disk to be imported
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef provides the secret reference needed to access the ovirt-engine
+                                    type: string
+                                  url:
+                                    description: URL is the URL of the ovirt-engine
+                                    type: string
+                                required:
+                                - diskId
+                                - url
+                                type: object
+                              pvc:
+                                description: DataVolumeSourcePVC provides the parameters to create a Data Volume from an existing PVC
+                                properties:
+                                  name:
+                                    description: The name of the source PVC
+                                    type: string
+                                  namespace:
+                                    description: The namespace of the source PVC
+                                    type: string
+                                required:
+                                - name
+                                - namespace
+                                type: object
+                              registry:
+                                description: DataVolumeSourceRegistry provides the parameters to create a Data Volume from an registry source
+                                properties:
+                                  certConfigMap:
+                                    description: CertConfigMap provides a reference to the Registry certs
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef provides the secret reference needed to access the Registry source
+                                    type: string
+                                  url:
+                                    description: URL is the url of the Docker registry source
+                                    type: string
+                                required:
+                                - url
+                                type: object
+                              s3:
+                                description: DataVolumeSourceS3 provides the parameters to create a Data Volume from an S3 source
+                                properties:
+                                  certConfigMap:
+                                    description: CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef provides the secret reference needed to access the S3 source
+                                    type: string
+                                  url:
+                                    description: URL is the url of the S3 source
+                                    type: string
+                                required:
+                                - url
+                                type: object
+                              upload:
+                                description: DataVolumeSourceUpload provides the parameters to create a Data Volume by uploading the source
+                                type: object
+                              vddk:
+                                description: DataVolumeSourceVDDK provides the parameters to create a Data Volume from a Vmware source
+                                properties:
+                                  backingFile:
+                                    description: BackingFile is the path to the virtual hard disk to migrate from vCenter/ESXi
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef provides a reference to a secret containing the username and password needed to access the vCenter or ESXi host
+                                    type: string
+                                  thumbprint:
+                                    description: Thumbprint is the certificate thumbprint of the vCenter or ESXi host
+                                    type: string
+                                  url:
+                                    description: URL is the URL of the vCenter or ESXi host with the VM to migrate
+                                    type: string
+                                  uuid:
+                                    description: UUID is the UUID of the virtual machine that the backing file is attached to in vCenter/ESXi
+                                    type: string
+                                type: object
+                            type: object
                         required:
-                        - networkName
+                        - pvc
+                        - source
                         type: object
-                      name:
-                        description: 'Network name. Must be a DNS_LABEL and unique within the vm. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
-                        type: string
-                      pod:
-                        description: Represents the stock pod network interface.
-                        properties:
-                          vmNetworkCIDR:
-                            description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
-                            type: string
+                      status:
+                        description: DataVolumeTemplateDummyStatus is here simply for backwards compatibility with a previous API.
+                        nullable: true
                         type: object
                     required:
-                    - name
+                    - spec
                     type: object
                   type: array
-                nodeSelector:
-                  additionalProperties:
-                    type: string
-                  description: 'NodeSelector is a selector which must be true for the vmi to fit on a node. Selector which must match a node''s labels for the vmi to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/'
+                instancetype:
+                  description: InstancetypeMatcher references a instancetype that is used to fill fields in Template
+                  properties:
+                    kind:
+                      description: 'Kind specifies which instancetype resource is referenced. Allowed values are: "VirtualMachineInstancetype" and "VirtualMachineClusterInstancetype". If not specified, "VirtualMachineClusterInstancetype" is used by default.'
+                      type: string
+                    name:
+                      description: Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype
+                      type: string
+                    revisionName:
+                      description: RevisionName specifies a ControllerRevision containing a specific copy of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype to be used. This is initially captured the first time the instancetype is applied to the VirtualMachineInstance.
+                      type: string
+                  required:
+                  - name
+                  type: object
+                preference:
+                  description: PreferenceMatcher references a set of preference that is used to fill fields in Template
+                  properties:
+                    kind:
+                      description: 'Kind specifies which preference resource is referenced. Allowed values are: "VirtualMachinePreference" and "VirtualMachineClusterPreference". If not specified, "VirtualMachineClusterPreference" is used by default.'
+                      type: string
+                    name:
+                      description: Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference
+                      type: string
+                    revisionName:
+                      description: RevisionName specifies a ControllerRevision containing a specific copy of the VirtualMachinePreference or VirtualMachineClusterPreference to be used. This is initially captured the first time the instancetype is applied to the VirtualMachineInstance.
+                      type: string
+                  required:
+                  - name
                   type: object
-                priorityClassName:
-                  description: If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.
+                runStrategy:
+                  description: Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running
                   type: string
-                readinessProbe:
-                  description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                running:
+                  description: Running controls whether the associatied VirtualMachineInstance is created or not Mutually exclusive with RunStrategy
+                  type: boolean
+                template:
+                  description: Template is the direct specification of VirtualMachineInstance
                   properties:
-                    failureThreshold:
-                      description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
-                      format: int32
-                      type: integer
-                    httpGet:
-                      description: HTTPGet specifies the http request to perform.
+                    metadata:
+                      nullable: true
+                      type: object
+                      x-kubernetes-preserve-unknown-fields: true
+                    spec:
+                      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
                       properties:
-                        host:
-                          description: Host name to connect to, defaults to the pod IP. You probably want to set "Host" in httpHeaders instead.
-                          type: string
-                        httpHeaders:
-                          description: Custom headers to set in the request. HTTP allows repeated headers.
+                        accessCredentials:
+                          description: Specifies a set of public keys to inject into the vm guest
                           items:
-                            description: HTTPHeader describes a custom header to be used in HTTP probes
+                            description: AccessCredential represents a credential source that can be used to authorize remote access to the vm guest Only one of its members may be specified.
                             properties:
-                              name:
-                                description: The header field name
-                                type: string
-                              value:
-                                description: The header field value
-                                type: string
-                            required:
-                            - name
-                            - value
+                              sshPublicKey:
+                                description: SSHPublicKey represents the source and method of applying a ssh public key into a guest virtual machine.
+                                properties:
+                                  propagationMethod:
+                                    description: PropagationMethod represents how the public key is injected into the vm guest.
+                                    properties:
+                                      configDrive:
+                                        description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
+                                        type: object
+                                      noCloud:
+                                        description: NoCloudPropagation means that the ssh public keys are injected into the VM using metadata using the noCloud cloud-init provider
+                                        type: object
+                                      qemuGuestAgent:
+                                        description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
+                                        properties:
+                                          users:
+                                            description: Users represents a list of guest users that should have the ssh public keys added to their authorized_keys file.
+                                            items:
+                                              type: string
+                                            type: array
+                                            x-kubernetes-list-type: set
+                                        required:
+                                        - users
+                                        type: object
+                                    type: object
+                                  source:
+                                    description: Source represents where the public keys are pulled from
+                                    properties:
+                                      secret:
+                                        description: Secret means that the access credential is pulled from a kubernetes secret
+                                        properties:
+                                          secretName:
+                                            description: SecretName represents the name of the secret in the VMI's namespace
+                                            type: string
+                                        required:
+                                        - secretName
+                                        type: object
+                                    type: object
+                                required:
+                                - propagationMethod
+                                - source
+                                type: object
+                              userPassword:
+                                description: UserPassword represents the source and method for applying a guest user's password
+                                properties:
+                                  propagationMethod:
+                                    description: propagationMethod represents how the user passwords are injected into the vm guest.
+                                    properties:
+                                      qemuGuestAgent:
+                                        description: QemuGuestAgentAccessCredentailPropagation means passwords are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
+                                        type: object
+                                    type: object
+                                  source:
+                                    description: Source represents where the user passwords are pulled from
+                                    properties:
+                                      secret:
+                                        description: Secret means that the access credential is pulled from a kubernetes secret
+                                        properties:
+                                          secretName:
+                                            description: SecretName represents the name of the secret in the VMI's namespace
+                                            type: string
+                                        required:
+                                        - secretName
+                                        type: object
+                                    type: object
+                                required:
+                                - propagationMethod
+                                - source
+                                type: object
                             type: object
                           type: array
-                        path:
-                          description: Path to access on the HTTP server.
-                          type: string
-                        port:
-                          anyOf:
-                          - type: integer
-                          - type: string
-                          description: Name or number of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
-                          x-kubernetes-int-or-string: true
-                        scheme:
-                          description: Scheme to use for connecting to the host. Defaults to HTTP.
-                          type: string
-                      required:
-                      - port
-                      type: object
-                    initialDelaySeconds:
-                      description: 'Number of seconds after the VirtualMachineInstance has started before liveness probes are initiated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
-                      format: int32
-                      type: integer
-                    periodSeconds:
-                      description: How often (in seconds) to perform the probe. Default to 10 seconds. Minimum value is 1.
-                      format: int32
-                      type: integer
-                    successThreshold:
-                      description: Minimum consecutive successes for the probe to be considered successful after having failed. Defaults to 1. Must be 1 for liveness. Minimum value is 1.
-                      format: int32
-                      type: integer
-                    tcpSocket:
-                      description: 'TCPSocket specifies an action involving a TCP port. TCP hooks not yet supported TODO: implement a realistic TCP lifecycle hook'
-                      properties:
-                        host:
-                          description: 'Optional: Host name to connect to, defaults to the pod IP.'
+                          x-kubernetes-list-type: atomic
+                        affinity:
+                          description: If affinity is specifies, obey all the affinity rules
+                          properties:
+                            nodeAffinity:
+                              description: Describes node affinity scheduling rules for the pod.
+                              properties:
+                                preferredDuringSchedulingIgnoredDuringExecution:
+                                  description: The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node matches the corresponding matchExpressions; the node(s) with the highest sum are the most preferred.
+                                  items:
+                                    description: An empty preferred scheduling term matches all objects with implicit weight 0 (i.e. it's a no-op). A null preferred scheduling term matches no objects (i.e. is also a no-op).
+                                    properties:
+                                      preference:
+                                        description: A node selector term, associated with the corresponding weight.
+                                        properties:
+                                          matchExpressions:
+                                            description: A list of node selector requirements by node's labels.
+                                            items:
+                                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                              properties:
+                                                key:
+                                                  description: The label key that the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
+                                                  type: string
+                                                values:
+                                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                          matchFields:
+                                            description: A list of node selector requirements by node's fields.
+                                            items:
+                                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                              properties:
+                                                key:
+                                                  description: The label key that the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
+                                                  type: string
+                                                values:
+                                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                        type: object
+                                      weight:
+                                        description: Weight associated with matching the corresponding nodeSelectorTerm, in the range 1-100.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - preference
+                                    - weight
+                                    type: object
+                                  type: array
+                                requiredDuringSchedulingIgnoredDuringExecution:
+                                  description: If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to an update), the system may or may not try to eventually evict the pod from its node.
+                                  properties:
+                                    nodeSelectorTerms:
+                                      description: Required. A list of node selector terms. The terms are ORed.
+                                      items:
+                                        description: A null or empty node selector term matches no objects. The requirements of them are ANDed. The TopologySelectorTerm type implements a subset of the NodeSelectorTerm.
+                                        properties:
+                                          matchExpressions:
+                                            description: A list of node selector requirements by node's labels.
+                                            items:
+                                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                              properties:
+                                                key:
+                                                  description: The label key that the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
+                                                  type: string
+                                                values:
+                                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                          matchFields:
+                                            description: A list of node selector requirements by node's fields.
+                                            items:
+                                              description: A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                              properties:
+                                                key:
+                                                  description: The label key that the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.
+                                                  type: string
+                                                values:
+                                                  description: An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                        type: object
+                                      type: array
+                                  required:
+                                  - nodeSelectorTerms
+                                  type: object
+                              type: object
+                            podAffinity:
+                              description: Describes pod affinity scheduling rules (e.g. co-locate this pod in the same node, zone, etc. as some other pod(s)).
+                              properties:
+                                preferredDuringSchedulingIgnoredDuringExecution:
+                                  description: The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.
+                                  items:
+                                    description: The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)
+                                    properties:
+                                      podAffinityTerm:
+                                        description: Required. A pod affinity term, associated with the corresponding weight.
+                                        properties:
+                                          labelSelector:
+                                            description: A label query over a set of resources, in this case pods.
+                                            properties:
+                                              matchExpressions:
+                                                description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                                                items:
+                                                  description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                                  properties:
+                                                    key:
+                                                      description: key is the label key that the selector applies to.
+                                                      type: string
+                                                    operator:
+                                                      description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                                      type: string
+                                                    values:
+                                                      description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                                      items:
+                                                        type: string
+                                                      type: array
+                                                  required:
+                                                  - key
+                                                  - operator
+                                                  type: object
+                                                type: array
+                                              matchLabels:
+                                                additionalProperties:
+                                                  type: string
+                                                description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                                                type: object
+                                            type: object
+                                          namespaces:
+                                            description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
+                                            items:
+                                              type: string
+                                            type: array
+                                          topologyKey:
+                                            description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
+                                            type: string
+                                        required:
+                                        - topologyKey
+                                        type: object
+                                      weight:
+                                        description: weight associated with matching the corresponding podAffinityTerm, in the range 1-100.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - podAffinityTerm
+                                    - weight
+                                    type: object
+                                  type: array
+                                requiredDuringSchedulingIgnoredDuringExecution:
+                                  description: If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.
+                                  items:
+                                    description: Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running
+                                    properties:
+                                      labelSelector:
+                                        description: A label query over a set of resources, in this case pods.
+                                        properties:
+                                          matchExpressions:
+                                            description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                                            items:
+                                              description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                              properties:
+                                                key:
+                                                  description: key is the label key that the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                                  type: string
+                                                values:
+                                                  description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                          matchLabels:
+                                            additionalProperties:
+                                              type: string
+                                            description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                                            type: object
+                                        type: object
+                                      namespaces:
+                                        description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
+                                        items:
+                                          type: string
+                                        type: array
+                                      topologyKey:
+                                        description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
+                                        type: string
+                                    required:
+                                    - topologyKey
+                                    type: object
+                                  type: array
+                              type: object
+                            podAntiAffinity:
+                              description: Describes pod anti-affinity scheduling rules (e.g. avoid putting this pod in the same node, zone, etc. as some other pod(s)).
+                              properties:
+                                preferredDuringSchedulingIgnoredDuringExecution:
+                                  description: The scheduler will prefer to schedule pods to nodes that satisfy the anti-affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling anti-affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding "weight" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.
+                                  items:
+                                    description: The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)
+                                    properties:
+                                      podAffinityTerm:
+                                        description: Required. A pod affinity term, associated with the corresponding weight.
+                                        properties:
+                                          labelSelector:
+                                            description: A label query over a set of resources, in this case pods.
+                                            properties:
+                                              matchExpressions:
+                                                description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                                                items:
+                                                  description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                                  properties:
+                                                    key:
+                                                      description: key is the label key that the selector applies to.
+                                                      type: string
+                                                    operator:
+                                                      description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                                      type: string
+                                                    values:
+                                                      description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                                      items:
+                                                        type: string
+                                                      type: array
+                                                  required:
+                                                  - key
+                                                  - operator
+                                                  type: object
+                                                type: array
+                                              matchLabels:
+                                                additionalProperties:
+                                                  type: string
+                                                description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                                                type: object
+                                            type: object
+                                          namespaces:
+                                            description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
+                                            items:
+                                              type: string
+                                            type: array
+                                          topologyKey:
+                                            description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
+                                            type: string
+                                        required:
+                                        - topologyKey
+                                        type: object
+                                      weight:
+                                        description: weight associated with matching the corresponding podAffinityTerm, in the range 1-100.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - podAffinityTerm
+                                    - weight
+                                    type: object
+                                  type: array
+                                requiredDuringSchedulingIgnoredDuringExecution:
+                                  description: If the anti-affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the anti-affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.
+                                  items:
+                                    description: Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running
+                                    properties:
+                                      labelSelector:
+                                        description: A label query over a set of resources, in this case pods.
+                                        properties:
+                                          matchExpressions:
+                                            description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                                            items:
+                                              description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                                              properties:
+                                                key:
+                                                  description: key is the label key that the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                                  type: string
+                                                values:
+                                                  description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                          matchLabels:
+                                            additionalProperties:
+                                              type: string
+                                            description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                                            type: object
+                                        type: object
+                                      namespaces:
+                                        description: namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means "this pod's namespace"
+                                        items:
+                                          type: string
+                                        type: array
+                                      topologyKey:
+                                        description: This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.
+                                        type: string
+                                    required:
+                                    - topologyKey
+                                    type: object
+                                  type: array
+                              type: object
+                          type: object
+                        architecture:
+                          description: Specifies the architecture of the vm guest you are attempting to run. Defaults to the default architecture of the cluster.
                           type: string
-                        port:
-                          anyOf:
-                          - type: integer
-                          - type: string
-                          description: Number or name of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
-                          x-kubernetes-int-or-string: true
-                      required:
-                      - port
-                      type: object
-                    timeoutSeconds:
-                      description: 'Number of seconds after which the probe times out. Defaults to 1 second. Minimum value is 1. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
-                      format: int32
-                      type: integer
-                  type: object
-                schedulerName:
-                  description: If specified, the VMI will be dispatched by specified scheduler. If not specified, the VMI will be dispatched by default scheduler.
-                  type: string
-                subdomain:
-                  description: If specified, the fully qualified vmi hostname will be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>". If not specified, the vmi will not have a domainname at all. The DNS entry will resolve to the vmi, no matter if the vmi itself can pick up a hostname.
-                  type: string
-                terminationGracePeriodSeconds:
-                  description: Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.
-                  format: int64
-                  type: integer
-                tolerations:
-                  description: If toleration is specified, obey all the toleration rules.
-                  items:
-                    description: The pod this Toleration is attached to tolerates any taint that matches the triple <key,value,effect> using the matching operator <operator>.
-                    properties:
-                      effect:
-                        description: Effect indicates the taint effect to match. Empty means match all taint effects. When specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.
-                        type: string
-                      key:
-                        description: Key is the taint key that the toleration applies to. Empty means match all taint keys. If the key is empty, operator must be Exists; this combination means to match all values and all keys.
-                        type: string
-                      operator:
-                        description: Operator represents a key's relationship to the value. Valid operators are Exists and Equal. Defaults to Equal. Exists is equivalent to wildcard for value, so that a pod can tolerate all taints of a particular category.
-                        type: string
-                      tolerationSeconds:
-                        description: TolerationSeconds represents the period of time the toleration (which must be of effect NoExecute, otherwise this field is ignored) tolerates the taint. By default, it is not set, which means tolerate the taint forever (do not evict). Zero and negative values will be treated as 0 (evict immediately) by the system.
-                        format: int64
-                        type: integer
-                      value:
-                        description: Value is the taint value the toleration matches to. If the operator is Exists, the value should be empty, otherwise just a regular string.
-                        type: string
-                    type: object
-                  type: array
-                volumes:
-                  description: List of volumes that can be mounted by disks belonging to the vmi.
-                  items:
-                    description: Volume represents a named volume in a vmi.
-                    properties:
-                      cloudInitConfigDrive:
-                        description: 'CloudInitConfigDrive represents a cloud-init Config Drive user-data source. The Config Drive data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: https://cloudinit.readthedocs.io/en/latest/topics/datasources/configdrive.html'
-                        properties:
-                          networkData:
-                            description: NetworkData contains config drive inline cloud-init networkdata.
-                            type: string
-                          networkDataBase64:
-                            description: NetworkDataBase64 contains config drive cloud-init networkdata as a base64 encoded string.
-                            type: string
-                          networkDataSecretRef:
-                            description: NetworkDataSecretRef references a k8s secret that contains config drive networkdata.
-                            properties:
-                              name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
-                                type: string
-                            type: object
-                          secretRef:
-                            description: UserDataSecretRef references a k8s secret that contains config drive userdata.
-                            properties:
-                              name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
-                                type: string
-                            type: object
-                          userData:
-                            description: UserData contains config drive inline cloud-init userdata.
-                            type: string
-                          userDataBase64:
-                            description: UserDataBase64 contains config drive cloud-init userdata as a base64 encoded string.
-                            type: string
-                        type: object
-                      cloudInitNoCloud:
-                        description: 'CloudInitNoCloud represents a cloud-init NoCloud user-data source. The NoCloud data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html'
-                        properties:
-                          networkData:
-                            description: NetworkData contains NoCloud inline cloud-init networkdata.
-                            type: string
-                          networkDataBase64:
-                            description: NetworkDataBase64 contains NoCloud cloud-init networkdata as a base64 encoded string.
-                            type: string
-                          networkDataSecretRef:
-                            description: NetworkDataSecretRef references a k8s secret that contains NoCloud networkdata.
-                            properties:
-                              name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                        dnsConfig:
+                          description: Specifies the DNS parameters of a pod. Parameters specified here will be merged to the generated DNS configuration based on DNSPolicy.
+                          properties:
+                            nameservers:
+                              description: A list of DNS name server IP addresses. This will be appended to the base nameservers generated from DNSPolicy. Duplicated nameservers will be removed.
+                              items:
                                 type: string
-                            type: object
-                          secretRef:
-                            description: UserDataSecretRef references a k8s secret that contains NoCloud userdata.
-                            properties:
-                              name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                              type: array
+                            options:
+                              description: A list of DNS resolver options. This will be merged with the base options generated from DNSPolicy. Duplicated entries will be removed. Resolution options given in Options will override those that appear in the base DNSPolicy.
+                              items:
+                                description: PodDNSConfigOption defines DNS resolver options of a pod.
+                                properties:
+                                  name:
+                                    description: Required.
+                                    type: string
+                                  value:
+                                    type: string
+                                type: object
+                              type: array
+                            searches:
+                              description: A list of DNS search domains for host-name lookup. This will be appended to the base search paths generated from DNSPolicy. Duplicated search paths will be removed.
+                              items:
                                 type: string
-                            type: object
-                          userData:
-                            description: UserData contains NoCloud inline cloud-init userdata.
-                            type: string
-                          userDataBase64:
-                            description: UserDataBase64 contains NoCloud cloud-init userdata as a base64 encoded string.
-                            type: string
-                        type: object
-                      configMap:
-                        description: 'ConfigMapSource represents a reference to a ConfigMap in the same namespace. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
-                        properties:
-                          name:
-                            description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
-                            type: string
-                          optional:
-                            description: Specify whether the ConfigMap or it's keys must be defined
-                            type: boolean
-                          volumeLabel:
-                            description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
-                            type: string
-                        type: object
-                      containerDisk:
-                        description: 'ContainerDisk references a docker image, embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
-                        properties:
-                          image:
-                            description: Image is the name of the image with the embedded disk.
-                            type: string
-                          imagePullPolicy:
-                            description: 'Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: https://kubernetes.io/docs/concepts/containers/images#updating-images'
-                            type: string
-                          imagePullSecret:
-                            description: ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.
-                            type: string
-                          path:
-                            description: Path defines the path to disk file in the container
-                            type: string
-                        required:
-                        - image
-                        type: object
-                      dataVolume:
-                        description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
-                        properties:
-                          name:
-                            description: Name represents the name of the DataVolume in the same namespace
-                            type: string
-                        required:
-                        - name
-                        type: object
-                      downwardAPI:
-                        description: DownwardAPI represents downward API about the pod that should populate this volume
-                        properties:
-                          fields:
-                            description: Fields is a list of downward API volume file
-                            items:
-                              description: DownwardAPIVolumeFile represents information to create the file containing the pod field
+                              type: array
+                          type: object
+                        dnsPolicy:
+                          description: Set DNS policy for the pod. Defaults to "ClusterFirst". Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'. DNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy. To have DNS options set along with hostNetwork, you have to specify DNS policy explicitly to 'ClusterFirstWithHostNet'.
+                          type: string
+                        domain:
+                          description: Specification of the desired behavior of the VirtualMachineInstance on the host.
+                          properties:
+                            chassis:
+                              description: Chassis specifies the chassis info passed to the domain.
+                              properties:
+                                asset:
+                                  type: string
+                                manufacturer:
+                                  type: string
+                                serial:
+                                  type: string
+                                sku:
+                                  type: string
+                                version:
+                                  type: string
+                              type: object
+                            clock:
+                              description: Clock sets the clock and timers of the vmi.
+                              properties:
+                                timer:
+                                  description: Timer specifies whih timers are attached to the vmi.
+                                  properties:
+                                    hpet:
+                                      description: HPET (High Precision Event Timer) - multiple timers with periodic interrupts.
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                                          type: boolean
+                                        tickPolicy:
+                                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup", "merge", "discard".
+                                          type: string
+                                      type: object
+                                    hyperv:
+                                      description: Hyperv (Hypervclock) - lets guests read the host’s wall clock time (paravirtualized). For windows guests.
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    kvm:
+                                      description: "KVM \t(KVM clock) - lets guests read the host’s wall clock time (paravirtualized). For linux guests."
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    pit:
+                                      description: PIT (Programmable Interval Timer) - a timer with periodic interrupts.
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                                          type: boolean
+                                        tickPolicy:
+                                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup", "discard".
+                                          type: string
+                                      type: object
+                                    rtc:
+                                      description: RTC (Real Time Clock) - a continuously running timer with periodic interrupts.
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.
+                                          type: boolean
+                                        tickPolicy:
+                                          description: TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of "delay", "catchup".
+                                          type: string
+                                        track:
+                                          description: Track the guest or the wall clock.
+                                          type: string
+                                      type: object
+                                  type: object
+                                timezone:
+                                  description: Timezone sets the guest clock to the specified timezone. Zone name follows the TZ environment variable format (e.g. 'America/New_York').
+                                  type: string
+                                utc:
+                                  description: UTC sets the guest clock to UTC on each boot. If an offset is specified, guest changes to the clock will be kept during reboots and are not reset.
+                                  properties:
+                                    offsetSeconds:
+                                      description: OffsetSeconds specifies an offset in seconds, relative to UTC. If set, guest changes to the clock will be kept during reboots and not reset.
+                                      type: integer
+                                  type: object
+                              type: object
+                            cpu:
+                              description: CPU allow specified the detailed CPU topology inside the vmi.
+                              properties:
+                                cores:
+                                  description: Cores specifies the number of cores inside the vmi. Must be a value greater or equal 1.
+                                  format: int32
+                                  type: integer
+                                dedicatedCpuPlacement:
+                                  description: DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node with enough dedicated pCPUs and pin the vCPUs to it.
+                                  type: boolean
+                                features:
+                                  description: Features specifies the CPU features list inside the VMI.
+                                  items:
+                                    description: CPUFeature allows specifying a CPU feature.
+                                    properties:
+                                      name:
+                                        description: Name of the CPU feature
+                                        type: string
+                                      policy:
+                                        description: 'Policy is the CPU feature attribute which can have the following attributes: force    - The virtual CPU will claim the feature is supported regardless of it being supported by host CPU. require  - Guest creation will fail unless the feature is supported by the host CPU or the hypervisor is able to emulate it. optional - The feature will be supported by virtual CPU if and only if it is supported by host CPU. disable  - The feature will not be supported by virtual CPU. forbid   - Guest creation will fail if the feature is supported by host CPU. Defaults to require'
+                                        type: string
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
+                                isolateEmulatorThread:
+                                  description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
+                                  type: boolean
+                                maxSockets:
+                                  description: MaxSockets specifies the maximum amount of sockets that can be hotplugged into a running vmi. Must be a value greater or equal to Sockets.
+                                  format: int32
+                                  type: integer
+                                model:
+                                  description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
+                                  type: string
+                                numa:
+                                  description: NUMA allows specifying settings for the guest NUMA topology
+                                  properties:
+                                    guestMappingPassthrough:
+                                      description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+                                      type: object
+                                  type: object
+                                realtime:
+                                  description: Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
+                                  properties:
+                                    mask:
+                                      description: 'Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt''s expressions. Example: "0-3,^1","0,2,3","2-3"'
+                                      type: string
+                                  type: object
+                                sockets:
+                                  description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
+                                  format: int32
+                                  type: integer
+                                threads:
+                                  description: Threads specifies the number of threads inside the vmi. Must be a value greater or equal 1.
+                                  format: int32
+                                  type: integer
+                              type: object
+                            devices:
+                              description: Devices allows adding disks, network interfaces, and others
                               properties:
-                                fieldRef:
-                                  description: 'Required: Selects a field of the pod: only annotations, labels, name and namespace are supported.'
+                                autoattachGraphicsDevice:
+                                  description: Whether to attach the default graphics device or not. VNC will not be available if set to false. Defaults to true.
+                                  type: boolean
+                                autoattachMemBalloon:
+                                  description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
+                                  type: boolean
+                                autoattachPanicDevice:
+                                  description: Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor. Defaults to true.
+                                  type: boolean
+                                autoattachPodInterface:
+                                  description: Whether to attach a pod network interface. Defaults to true.
+                                  type: boolean
+                                autoattachSerialConsole:
+                                  description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
+                                  type: boolean
+                                autoattachVSOCK:
+                                  description: Whether to attach the VSOCK CID to the VM or not. VSOCK access will be available if set to true. Defaults to false.
+                                  type: boolean
+                                blockMultiQueue:
+                                  description: Whether or not to enable virtio multi-queue for block devices
+                                  type: boolean
+                                channels:
+                                  description: Virtio channels of the vmi, e.g. for custom guest agents. Each channel can be connected to through the channel subresource.
+                                  items:
+                                    description: Channel represents a virtio channel of the vmi
+                                    properties:
+                                      name:
+                                        description: Name of the channel, used to connect to it through the channel subresource. Must be a DNS_LABEL and unique within the vmi.
+                                        type: string
+                                      targetName:
+                                        description: TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.
+                                        type: string
+                                    required:
+                                    - name
+                                    - targetName
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                disableHotplug:
+                                  description: DisableHotplug disabled the ability to hotplug disks.
+                                  type: boolean
+                                disks:
+                                  description: Disks describes disks, cdroms, floppy and luns which are connected to the vmi.
+                                  items:
+                                    properties:
+                                      bootOrder:
+                                        description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
+                                        type: integer
+                                      cache:
+                                        description: 'Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.'
+                                        type: string
+                                      cdrom:
+                                        description: Attach a volume as a cdrom to the vmi.
+                                        properties:
+                                          bus:
+                                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
+                                            type: string
+                                          readonly:
+                                            description: ReadOnly. Defaults to true.
+                                            type: boolean
+                                          tray:
+                                            description: Tray indicates if the tray of the device is open or closed. Allowed values are "open" and "closed". Defaults to closed.
+                                            type: string
+                                        type: object
+                                      dedicatedIOThread:
+                                        description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
+                                        type: boolean
+                                      detectZeroes:
+                                        description: 'DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.'
+                                        type: string
+                                      discard:
+                                        description: 'Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.'
+                                        type: string
+                                      disk:
+                                        description: Attach a volume as a disk to the vmi.
+                                        properties:
+                                          bus:
+                                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
+                                            type: string
+                                          pciAddress:
+                                            description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
+                                            type: string
+                                          readonly:
+                                            description: ReadOnly. Defaults to false.
+                                            type: boolean
+                                        type: object
+                                      encryption:
+                                        description: Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+                                        properties:
+                                          format:
+                                            description: Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.
+                                            type: string
+                                          secretName:
+                                            description: SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+                                            type: string
+                                        required:
+                                        - secretName
+                                        type: object
+                                      excludeFromSnapshot:
+                                        description: ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.
+                                        type: boolean
+                                      expandGuestFilesystem:
+                                        description: ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.
+                                        type: boolean
+                                      floppy:
+                                        description: Attach a volume as a floppy to the vmi.
+                                        properties:
+                                          readonly:
+                                            description: ReadOnly. Defaults to false.
+                                            type: boolean
+                                          tray:
+                                            description: Tray indicates if the tray of the device is open or closed. Allowed values are "open" and "closed". Defaults to closed.
+                                            type: string
+                                        type: object
+                                      io:
+                                        description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
+                                        type: string
+                                      ioTune:
+                                        description: IOTune limits the I/O operations and the bandwidth of the disk.
+                                        properties:
+                                          bandwidth:
+                                            description: Bandwidth limits the bytes per second of the disk.
+                                            properties:
+                                              burstLength:
+                                                description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                                format: int64
+                                                type: integer
+                                              read:
+                                                description: Read limits the reads.
+                                                format: int64
+                                                type: integer
+                                              readBurst:
+                                                description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                                format: int64
+                                                type: integer
+                                              total:
+                                                description: Total limits the sum of reads and writes.
+                                                format: int64
+                                                type: integer
+                                              totalBurst:
+                                                description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                                format: int64
+                                                type: integer
+                                              write:
+                                                description: Write limits the writes.
+                                                format: int64
+                                                type: integer
+                                              writeBurst:
+                                                description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                                format: int64
+                                                type: integer
+                                            type: object
+                                          iops:
+                                            description: IOPS limits the I/O operations per second of the disk.
+                                            properties:
+                                              burstLength:
+                                                description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                                format: int64
+                                                type: integer
+                                              read:
+                                                description: Read limits the reads.
+                                                format: int64
+                                                type: integer
+                                              readBurst:
+                                                description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                                format: int64
+                                                type: integer
+                                              total:
+                                                description: Total limits the sum of reads and writes.
+                                                format: int64
+                                                type: integer
+                                              totalBurst:
+                                                description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                                format: int64
+                                                type: integer
+                                              write:
+                                                description: Write limits the writes.
+                                                format: int64
+                                                type: integer
+                                              writeBurst:
+                                                description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                                format: int64
+                                                type: integer
+                                            type: object
+                                        type: object
+                                      lun:
+                                        description: Attach a volume as a LUN to the vmi.
+                                        properties:
+                                          bus:
+                                            description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                            type: string
+                                          readonly:
+                                            description: ReadOnly. Defaults to false.
+                                            type: boolean
+                                          reservation:
+                                            description: Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.
+                                            type: boolean
+                                        type: object
+                                      name:
+                                        description: Name is the device name
+                                        type: string
+                                      serial:
+                                        description: Serial provides the ability to specify a serial number for the disk device.
+                                        type: string
+                                      tag:
+                                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                                        type: string
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
+                                filesystems:
+                                  description: Filesystems describes filesystem which is connected to the vmi. The volume with the name of the filesystem is shared into the guest with virtiofs, it can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.
+                                  items:
+                                    properties:
+                                      name:
+                                        description: Name is the device name
+                                        type: string
+                                      virtiofs:
+                                        description: Virtiofs is supported
+                                        properties:
+                                          gidMap:
+                                            description: GIDMap maps the group IDs of the guest to the group IDs of the shared directory.
+                                            properties:
+                                              count:
+                                                description: Count is the number of IDs in the range, it must be greater than 0.
+                                                format: int32
+                                                type: integer
+                                              guestID:
+                                                description: GuestID is the first ID of the range in the guest.
+                                                format: int32
+                                                type: integer
+                                              hostID:
+                                                description: HostID is the first ID of the range in the shared directory.
+                                                format: int32
+                                                type: integer
+                                            required:
+                                            - guestID
+                                            - hostID
+                                            - count
+                                            type: object
+                                          subPath:
+                                            description: SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root. It allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.
+                                            type: string
+                                          uidMap:
+                                            description: UIDMap maps the user IDs of the guest to the user IDs of the shared directory.
+                                            properties:
+                                              count:
+                                                description: Count is the number of IDs in the range, it must be greater than 0.
+                                                format: int32
+                                                type: integer
+                                              guestID:
+                                                description: GuestID is the first ID of the range in the guest.
+                                                format: int32
+                                                type: integer
+                                              hostID:
+                                                description: HostID is the first ID of the range in the shared directory.
+                                                format: int32
+                                                type: integer
+                                            required:
+                                            - guestID
+                                            - hostID
+                                            - count
+                                            type: object
+                                        type: object
+                                    required:
+                                    - name
+                                    - virtiofs
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                freePageReporting:
+                                  description: Whether to enable free page reporting on the memory balloon device, so that memory freed by the guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.
+                                  type: boolean
+                                gpus:
+                                  description: Whether to attach a GPU device to the vmi.
+                                  items:
+                                    properties:
+                                      deviceName:
+                                        type: string
+                                      name:
+                                        description: Name of the GPU device as exposed by a device plugin
+                                        type: string
+                                    required:
+                                    - deviceName
+                                    - name
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                hostDevices:
+                                  description: Whether to attach a host device to the vmi.
+                                  items:
+                                    properties:
+                                      deviceName:
+                                        description: DeviceName is the resource name of the host device exposed by a device plugin
+                                        type: string
+                                      name:
+                                        type: string
+                                      nvme:
+                                        description: NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.
+                                        properties:
+                                          namespace:
+                                            description: Namespace is the ID of the namespace. Defaults to 1.
+                                            format: int32
+                                            type: integer
+                                        type: object
+                                    required:
+                                    - deviceName
+                                    - name
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                inputs:
+                                  description: Inputs describe input devices
+                                  items:
+                                    properties:
+                                      bus:
+                                        description: 'Bus indicates the bus of input device to emulate. Supported values: virtio, usb.'
+                                        type: string
+                                      name:
+                                        description: Name is the device name
+                                        type: string
+                                      type:
+                                        description: 'Type indicated the type of input device. Supported values: tablet.'
+                                        type: string
+                                    required:
+                                    - name
+                                    - type
+                                    type: object
+                                  type: array
+                                interfaces:
+                                  description: Interfaces describe network interfaces which are added to the vmi.
+                                  items:
+                                    properties:
+                                      bandwidth:
+                                        description: Bandwidth limits the traffic of the interface. It is only supported with the bridge, masquerade and macvtap bindings.
+                                        properties:
+                                          inbound:
+                                            description: Inbound limits the traffic received by the guest (rx).
+                                            properties:
+                                              average:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              burst:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Burst is the amount of bytes which can be sent at the peak rate.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              peak:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                            required:
+                                            - average
+                                            type: object
+                                          outbound:
+                                            description: Outbound limits the traffic sent by the guest (tx).
+                                            properties:
+                                              average:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              burst:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Burst is the amount of bytes which can be sent at the peak rate.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              peak:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                            required:
+                                            - average
+                                            type: object
+                                        type: object
+                                      binding:
+                                        description: Binding specifies the binding plugin that will be used to connect the interface to the guest. It provides an alternative to InterfaceBindingMethod.
+                                        properties:
+                                          name:
+                                            description: Name references the binding plugin name as registered in the KubeVirt CR.
+                                            type: string
+                                        required:
+                                        - name
+                                        type: object
+                                      bootOrder:
+                                        description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
+                                        type: integer
+                                      bridge:
+                                        type: object
+                                      dhcpOptions:
+                                        description: If specified the network interface will pass additional DHCP options to the VMI
+                                        properties:
+                                          bootFileName:
+                                            description: If specified will pass option 67 to interface's DHCP server
+                                            type: string
+                                          mtu:
+                                            description: If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.
+                                            type: integer
+                                          ntpServers:
+                                            description: If specified will pass the configured NTP server to the VM via DHCP option 042.
+                                            items:
+                                              type: string
+                                            type: array
+                                          privateOptions:
+                                            description: 'If specified will pass extra DHCP options for private use, range: 224-254'
+                                            items:
+                                              description: DHCPExtraOptions defines Extra DHCP options for a VM.
+                                              properties:
+                                                option:
+                                                  description: Option is an Integer value from 224-254 Required.
+                                                  type: integer
+                                                value:
+                                                  description: Value is a String value for the Option provided Required.
+                                                  type: string
+                                              required:
+                                              - option
+                                              - value
+                                              type: object
+                                            type: array
+                                          routes:
+                                            description: If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes of the pod interface.
+                                            items:
+                                              description: DHCPRoute defines a static route passed to the VM through DHCP.
+                                              properties:
+                                                destination:
+                                                  description: Destination is the IPv4 CIDR of the routed network. Required.
+                                                  type: string
+                                                gateway:
+                                                  description: Gateway is the IPv4 address of the next hop. Required.
+                                                  type: string
+                                              required:
+                                              - destination
+                                              - gateway
+                                              type: object
+                                            type: array
+                                          tftpServerName:
+                                            description: If specified will pass option 66 to interface's DHCP server
+                                            type: string
+                                        type: object
+                                      firewall:
+                                        description: Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod, so it applies even where NetworkPolicies don't, like on bridged secondary networks. It is only supported with the bridge and masquerade bindings.
+                                        properties:
+                                          defaultAction:
+                                            description: DefaultAction applies to the packets which match no rule. Defaults to Allow. Deny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass. VMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label, which virt-handler sets where it is loaded.
+                                            type: string
+                                          rules:
+                                            description: Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.
+                                            items:
+                                              description: FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port. The packets match when all the criteria which are set match.
+                                              properties:
+                                                action:
+                                                  description: Action is Allow or Deny.
+                                                  type: string
+                                                cidr:
+                                                  description: CIDR is the network of the remote peer, IPv4 or IPv6.
+                                                  type: string
+                                                direction:
+                                                  description: Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest. The rule applies to both directions if it is not set.
+                                                  type: string
+                                                port:
+                                                  description: Port is the destination port, on the guest for Ingress and on the remote peer for Egress. It requires the TCP, UDP or SCTP protocol.
+                                                  format: int32
+                                                  type: integer
+                                                protocol:
+                                                  description: Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.
+                                                  type: string
+                                              required:
+                                              - action
+                                              type: object
+                                            type: array
+                                        type: object
+                                      macAddress:
+                                        description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
+                                        type: string
+                                      macvtap:
+                                        type: object
+                                      masquerade:
+                                        type: object
+                                      model:
+                                        description: 'Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio. TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51'
+                                        type: string
+                                      name:
+                                        description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
+                                        type: string
+                                      offloads:
+                                        description: Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default. It requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.
+                                        properties:
+                                          checksum:
+                                            description: Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled with it and can't be enabled without it.
+                                            type: boolean
+                                          gso:
+                                            description: GSO toggles the generic segmentation offload of the host.
+                                            type: boolean
+                                          tso:
+                                            description: TSO toggles the TCP segmentation offload, for IPv4 and IPv6.
+                                            type: boolean
+                                        type: object
+                                      passt:
+                                        description: InterfacePasst connects the guest to the pod network through passt, a user-mode network stack which forwards TCP and UDP traffic over both IPv4 and IPv6.
+                                        type: object
+                                      pciAddress:
+                                        description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
+                                        type: string
+                                      ports:
+                                        description: List of ports to be forwarded to the virtual machine.
+                                        items:
+                                          description: Port repesents a port to expose from the virtual machine. Default protocol TCP. The port field is mandatory
+                                          properties:
+                                            name:
+                                              description: If specified, this must be an IANA_SVC_NAME and unique within the pod. Each named port in a pod must have a unique name. Name for the port that can be referred to by services.
+                                              type: string
+                                            port:
+                                              description: Number of port to expose for the virtual machine. This must be a valid port number, 0 < x < 65536.
+                                              format: int32
+                                              type: integer
+                                            protocol:
+                                              description: Protocol for port. Must be UDP, TCP or SCTP. Defaults to "TCP".
+                                              type: string
+                                          required:
+                                          - port
+                                          type: object
+                                        type: array
+                                      romDisabled:
+                                        description: If set, no option ROM is exposed to the guest for the interface. Interfaces with a boot order have an option ROM by default, which is needed to boot from the network with BIOS firmware. EFI firmware can boot from the network without it.
+                                        type: boolean
+                                      rss:
+                                        description: RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a multi-queue guest across its queues, and thus across its vCPUs. It requires the virtio model and networkInterfaceMultiqueue, and is only supported with the bridge, masquerade and macvtap bindings.
+                                        properties:
+                                          hashReport:
+                                            description: HashReport makes the device report the RSS hash of every received packet to the guest, which saves the guest from computing it again. The device is emulated by QEMU instead of vhost-net then.
+                                            type: boolean
+                                        type: object
+                                      slirp:
+                                        type: object
+                                      sriov:
+                                        properties:
+                                          failover:
+                                            description: Failover names a virtio interface of the vmi which the guest teams with the VF through its net_failover driver. The traffic fails over to that interface while the VF is detached for a live migration. Both interfaces must have the same MAC address and be connected to the same L2 network.
+                                            type: string
+                                        type: object
+                                      state:
+                                        description: State represents the requested operational state of the interface. The link of the interface is set "down" or "up", "up" being the default, and the guest sees a link which is down as an unplugged cable. "absent" is set when the interface is hot unplugged.
+                                        type: string
+                                      tag:
+                                        description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
+                                        type: string
+                                      vdpa:
+                                        description: InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface. The device is allocated by a device plugin, through the resource of the Multus network.
+                                        type: object
+                                      vhostuser:
+                                        description: InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP, through a vhost-user socket. The guest memory is shared with the switch.
+                                        type: object
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
+                                logSerialConsole:
+                                  description: Whether to mirror the output of the serial console to the virt-launcher pod log. The log can be retrieved later through the console/log subresource. Defaults to false.
+                                  type: boolean
+                                memoryDump:
+                                  description: If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource, for example by VirtualMachineSnapshots which include a memory dump.
                                   properties:
-                                    apiVersion:
-                                      description: Version of the schema the FieldPath is written in terms of, defaults to "v1".
+                                    claimName:
+                                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
                                       type: string
-                                    fieldPath:
-                                      description: Path of the field to select in the specified API version.
+                                  required:
+                                  - claimName
+                                  type: object
+                                networkInterfaceMultiqueue:
+                                  description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
+                                  type: boolean
+                                panicMemoryDump:
+                                  description: If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.
+                                  properties:
+                                    claimName:
+                                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                                      type: string
+                                  required:
+                                  - claimName
+                                  type: object
+                                rng:
+                                  description: Whether to have random number generator from host
+                                  type: object
+                                serialConsoleLog:
+                                  description: If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.
+                                  properties:
+                                    claimName:
+                                      description: ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.
+                                      type: string
+                                    maxFileSize:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      description: MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    maxFiles:
+                                      description: MaxFiles is the number of rotated log files which are kept. Defaults to 3.
+                                      format: int32
+                                      type: integer
+                                  required:
+                                  - claimName
+                                  type: object
+                                serials:
+                                  description: Additional serial ports of the vmi, next to the default serial console. Each serial port can be connected to through the serial subresource.
+                                  items:
+                                    description: SerialPort represents an additional serial port of the vmi
+                                    properties:
+                                      name:
+                                        description: Name of the serial port, used to connect to it through the serial subresource. Must be a DNS_LABEL and unique within the vmi.
+                                        type: string
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                tpm:
+                                  description: Whether to emulate a TPM device.
+                                  properties:
+                                    persistent:
+                                      description: Persistent indicates the state of the TPM device should be kept across reboots and migrations. The state is stored in a per-VM backend storage volume. Defaults to false.
+                                      type: boolean
+                                  type: object
+                                useVirtioTransitional:
+                                  description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
+                                  type: boolean
+                                watchdog:
+                                  description: Watchdog describes a watchdog device which can be added to the vmi.
+                                  properties:
+                                    diag288:
+                                      description: diag288 watchdog device, only available on s390x.
+                                      properties:
+                                        action:
+                                          description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
+                                          type: string
+                                      type: object
+                                    i6300esb:
+                                      description: i6300esb watchdog device.
+                                      properties:
+                                        action:
+                                          description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
+                                          type: string
+                                      type: object
+                                    name:
+                                      description: Name of the watchdog.
                                       type: string
                                   required:
-                                  - fieldPath
+                                  - name
                                   type: object
-                                mode:
-                                  description: 'Optional: mode bits used to set permissions on this file, must be an octal value between 0000 and 0777 or a decimal value between 0 and 511. YAML accepts both octal and decimal values, JSON requires decimal values for mode bits. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.'
+                              type: object
+                            features:
+                              description: Features like acpi, apic, hyperv, smm.
+                              properties:
+                                acpi:
+                                  description: ACPI enables/disables ACPI inside the guest. Defaults to enabled.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                      type: boolean
+                                  type: object
+                                apic:
+                                  description: Defaults to the machine type setting.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                      type: boolean
+                                    endOfInterrupt:
+                                      description: EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.
+                                      type: boolean
+                                  type: object
+                                hyperv:
+                                  description: Defaults to the machine type setting.
+                                  properties:
+                                    evmcs:
+                                      description: EVMCS Speeds up L2 vmexits, but disables other virtualization features. Requires vapic. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    frequencies:
+                                      description: Frequencies improves the TSC clock source handling for Hyper-V on KVM. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    ipi:
+                                      description: IPI improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    reenlightenment:
+                                      description: Reenlightenment enables the notifications on TSC frequency changes. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    relaxed:
+                                      description: Relaxed instructs the guest OS to disable watchdog timeouts. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    reset:
+                                      description: Reset enables Hyperv reboot/reset for the vmi. Requires synic. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    runtime:
+                                      description: Runtime improves the time accounting to improve scheduling in the guest. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    spinlocks:
+                                      description: Spinlocks allows to configure the spinlock retry attempts.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                        spinlocks:
+                                          description: Retries indicates the number of retries. Must be a value greater or equal 4096. Defaults to 4096.
+                                          format: int32
+                                          type: integer
+                                      type: object
+                                    synic:
+                                      description: SyNIC enables the Synthetic Interrupt Controller. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    synictimer:
+                                      description: SyNICTimer enables Synthetic Interrupt Controller Timers, reducing CPU load. Defaults to the machine type setting.
+                                      properties:
+                                        direct:
+                                          description: Represents if a feature is enabled or disabled.
+                                          properties:
+                                            enabled:
+                                              description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                              type: boolean
+                                          type: object
+                                        enabled:
+                                          type: boolean
+                                      type: object
+                                    tlbflush:
+                                      description: TLBFlush improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    vapic:
+                                      description: VAPIC improves the paravirtualized handling of interrupts. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    vendorid:
+                                      description: VendorID allows setting the hypervisor vendor id. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                        vendorid:
+                                          description: VendorID sets the hypervisor vendor id, visible to the vmi. String up to twelve characters.
+                                          type: string
+                                      type: object
+                                    vpindex:
+                                      description: VPIndex enables the Virtual Processor Index to help windows identifying virtual processors. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                  type: object
+                                kvm:
+                                  description: Configure how KVM presence is exposed to the guest.
+                                  properties:
+                                    hidden:
+                                      description: Hide the KVM hypervisor from standard MSR based discovery. Defaults to false
+                                      type: boolean
+                                  type: object
+                                pvspinlock:
+                                  description: Notify the guest that the host supports paravirtual spinlocks. For older kernels this feature should be explicitly disabled.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                      type: boolean
+                                  type: object
+                                smm:
+                                  description: SMM enables/disables System Management Mode. TSEG not yet implemented.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                                      type: boolean
+                                  type: object
+                              type: object
+                            firmware:
+                              description: Firmware.
+                              properties:
+                                bootloader:
+                                  description: Settings to control the bootloader that is used.
+                                  properties:
+                                    bios:
+                                      description: If set (default), BIOS will be used.
+                                      properties:
+                                        useSerial:
+                                          description: If set, the BIOS output will be transmitted over serial
+                                          type: boolean
+                                      type: object
+                                    efi:
+                                      description: If set, EFI will be used instead of BIOS.
+                                      properties:
+                                        persistent:
+                                          description: Persistent indicates the EFI NVRAM should be kept across reboots and migrations. The NVRAM is stored in a per-VM backend storage volume. Defaults to false.
+                                          type: boolean
+                                        secureBoot:
+                                          description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
+                                          type: boolean
+                                        secureBootKeys:
+                                          description: SecureBootKeys references Secrets with custom certificates which are enrolled into the NVRAM at first boot. Requires SecureBoot to be enabled.
+                                          properties:
+                                            db:
+                                              description: DB references a Secret with certificates which are allowed to sign boot loaders and kernels, added to the default ones.
+                                              properties:
+                                                secretName:
+                                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                                  type: string
+                                              required:
+                                              - secretName
+                                              type: object
+                                            kek:
+                                              description: KEK references a Secret with Key Exchange Key certificates, added to the default ones.
+                                              properties:
+                                                secretName:
+                                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                                  type: string
+                                              required:
+                                              - secretName
+                                              type: object
+                                            pk:
+                                              description: PK references a Secret with the Platform Key certificate, replacing the default one.
+                                              properties:
+                                                secretName:
+                                                  description: SecretName is the name of the Secret in the namespace of the vmi.
+                                                  type: string
+                                              required:
+                                              - secretName
+                                              type: object
+                                          type: object
+                                      type: object
+                                  type: object
+                                serial:
+                                  description: The system-serial-number in SMBIOS
+                                  type: string
+                                uuid:
+                                  description: UUID reported by the vmi bios. Defaults to a random generated uid.
+                                  type: string
+                              type: object
+                            ioThreads:
+                              description: IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.
+                              properties:
+                                dedicatedPinning:
+                                  description: 'DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated. emulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads. vcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads. One of: emulator, vcpus. Defaults to emulator.'
+                                  type: string
+                                sharedPoolSize:
+                                  description: SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread. Defaults to the number of IOThreads determined by the ioThreadsPolicy.
                                   format: int32
                                   type: integer
-                                path:
-                                  description: 'Required: Path is  the relative path name of the file to be created. Must not be absolute or contain the ''..'' path. Must be utf-8 encoded. The first item of the relative path must not start with ''..'''
+                              type: object
+                            ioThreadsPolicy:
+                              description: 'Controls whether or not disks will share IOThreads. Omitting IOThreadsPolicy disables use of IOThreads. One of: shared, auto'
+                              type: string
+                            launchSecurity:
+                              description: Launch Security setting of the vmi.
+                              properties:
+                                sev:
+                                  description: AMD Secure Encrypted Virtualization (SEV).
+                                  properties:
+                                    attestation:
+                                      description: If specified, run the attestation process for a vmi.
+                                      type: object
+                                    policy:
+                                      description: 'Guest policy flags as defined in AMD SEV API specification. Note: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.'
+                                      properties:
+                                        encryptedState:
+                                          description: SEV-ES is required. Defaults to false.
+                                          type: boolean
+                                      type: object
+                                  type: object
+                                snp:
+                                  description: AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.
+                                  type: object
+                                tdx:
+                                  description: Intel Trust Domain Extensions (TDX).
+                                  type: object
+                              type: object
+                            machine:
+                              description: Machine type.
+                              properties:
+                                type:
+                                  description: QEMU machine type is the actual chipset of the VirtualMachineInstance.
                                   type: string
-                                resourceFieldRef:
-                                  description: 'Selects a resource of the container: only resources limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.'
+                              required:
+                              - type
+                              type: object
+                            memory:
+                              description: Memory allow specifying the VMI memory features.
+                              properties:
+                                guest:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Guest allows to specifying the amount of memory which is visible inside the Guest OS. The Guest must lie between Requests and Limits from the resources section. Defaults to the requested memory in the resources section if not specified.
+                                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                  x-kubernetes-int-or-string: true
+                                hugepages:
+                                  description: Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.
                                   properties:
-                                    containerName:
-                                      description: 'Container name: required for volumes, optional for env vars'
-                                      type: string
-                                    divisor:
-                                      anyOf:
-                                      - type: integer
-                                      - type: string
-                                      description: Specifies the output format of the exposed resources, defaults to "1"
-                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
-                                      x-kubernetes-int-or-string: true
-                                    resource:
-                                      description: 'Required: resource to select'
+                                    pageSize:
+                                      description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
                                       type: string
-                                  required:
-                                  - resource
                                   type: object
+                                maxGuest:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS. The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+                                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                  x-kubernetes-int-or-string: true
+                              type: object
+                            resources:
+                              description: Resources describes the Compute Resources required by this vmi.
+                              properties:
+                                limits:
+                                  additionalProperties:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  description: Limits describes the maximum amount of compute resources allowed. Valid resource keys are "memory" and "cpu".
+                                  type: object
+                                overcommitGuestOverhead:
+                                  description: Don't ask the scheduler to take the guest-management overhead into account. Instead put the overhead only into the container's memory limit. This can lead to crashes if all memory is in use on a node. Defaults to false.
+                                  type: boolean
+                                requests:
+                                  additionalProperties:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  description: Requests is a description of the initial vmi resources. Valid resource keys are "memory" and "cpu".
+                                  type: object
+                              type: object
+                          required:
+                          - devices
+                          type: object
+                        evictionStrategy:
+                          description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.
+                          type: string
+                        hostname:
+                          description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
+                          type: string
+                        ignition:
+                          description: If specified, the Ignition config is passed to the guest through the fw_cfg device. Used to provision guests like Fedora CoreOS or Flatcar, which do not use cloud-init.
+                          properties:
+                            configMapRef:
+                              description: ConfigMapRef references a ConfigMap that contains the Ignition config under the config.ign key.
+                              properties:
+                                name:
+                                  description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                  type: string
+                              type: object
+                            secretRef:
+                              description: SecretRef references a k8s Secret that contains the Ignition config under the config.ign key.
+                              properties:
+                                name:
+                                  description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                  type: string
+                              type: object
+                          type: object
+                        livenessProbe:
+                          description: 'Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                          properties:
+                            exec:
+                              description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+                              properties:
+                                command:
+                                  description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                                  items:
+                                    type: string
+                                  type: array
+                              type: object
+                            failureThreshold:
+                              description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            guestAgentPing:
+                              description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+                              type: object
+                            httpGet:
+                              description: HTTPGet specifies the http request to perform.
+                              properties:
+                                host:
+                                  description: Host name to connect to, defaults to the pod IP. You probably want to set "Host" in httpHeaders instead.
+                                  type: string
+                                httpHeaders:
+                                  description: Custom headers to set in the request. HTTP allows repeated headers.
+                                  items:
+                                    description: HTTPHeader describes a custom header to be used in HTTP probes
+                                    properties:
+                                      name:
+                                        description: The header field name
+                                        type: string
+                                      value:
+                                        description: The header field value
+                                        type: string
+                                    required:
+                                    - name
+                                    - value
+                                    type: object
+                                  type: array
+                                path:
+                                  description: Path to access on the HTTP server.
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Name or number of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                                scheme:
+                                  description: Scheme to use for connecting to the host. Defaults to HTTP.
+                                  type: string
                               required:
-                              - path
+                              - port
                               type: object
-                            type: array
-                          volumeLabel:
-                            description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
-                            type: string
-                        type: object
-                      emptyDisk:
-                        description: 'EmptyDisk represents a temporary disk which shares the vmis lifecycle. More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html'
-                        properties:
-                          capacity:
-                            anyOf:
-                            - type: integer
-                            - type: string
-                            description: Capacity of the sparse disk.
-                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
-                            x-kubernetes-int-or-string: true
-                        required:
-                        - capacity
-                        type: object
-                      ephemeral:
-                        description: Ephemeral is a special volume source that "wraps" specified source and provides copy-on-write image on top of it.
-                        properties:
-                          persistentVolumeClaim:
-                            description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            initialDelaySeconds:
+                              description: 'Number of seconds after the VirtualMachineInstance has started before liveness probes are initiated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                            periodSeconds:
+                              description: How often (in seconds) to perform the probe. Default to 10 seconds. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            successThreshold:
+                              description: Minimum consecutive successes for the probe to be considered successful after having failed. Defaults to 1. Must be 1 for liveness. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            tcpSocket:
+                              description: 'TCPSocket specifies an action involving a TCP port. TCP hooks not yet supported TODO: implement a realistic TCP lifecycle hook'
+                              properties:
+                                host:
+                                  description: 'Optional: Host name to connect to, defaults to the pod IP.'
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Number or name of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                              required:
+                              - port
+                              type: object
+                            timeoutSeconds:
+                              description: 'Number of seconds after which the probe times out. Defaults to 1 second. Minimum value is 1. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                          type: object
+                        networks:
+                          description: List of networks that can be attached to a vm's virtual interface.
+                          items:
+                            description: Network represents a network type and a resource that should be connected to the vm.
                             properties:
-                              claimName:
-                                description: 'ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                              multus:
+                                description: Represents the multus cni network.
+                                properties:
+                                  default:
+                                    description: Select the default network and add it to the multus-cni.io/default-network annotation.
+                                    type: boolean
+                                  networkName:
+                                    description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                                    type: string
+                                  persistentIPs:
+                                    description: PersistentIPs keeps the addresses allocated by the IPAM of the network across live migrations and restarts of the VM. The addresses are requested through the ips field of the multus network selection element, so the IPAM plugin has to support static IP requests. Only bridge interfaces are supported.
+                                    type: boolean
+                                required:
+                                - networkName
+                                type: object
+                              name:
+                                description: 'Network name. Must be a DNS_LABEL and unique within the vm. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                                 type: string
-                              readOnly:
-                                description: Will force the ReadOnly setting in VolumeMounts. Default false.
-                                type: boolean
+                              pod:
+                                description: Represents the stock pod network interface.
+                                properties:
+                                  vmIPv6NetworkCIDR:
+                                    description: IPv6 CIDR for the vm network. Defaults to fd10:0:2::/120 if not specified.
+                                    type: string
+                                  vmNetworkCIDR:
+                                    description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
+                                    type: string
+                                type: object
                             required:
-                            - claimName
+                            - name
                             type: object
-                        type: object
-                      hostDisk:
-                        description: HostDisk represents a disk created on the cluster level
-                        properties:
-                          capacity:
-                            anyOf:
-                            - type: integer
-                            - type: string
-                            description: Capacity of the sparse disk
-                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
-                            x-kubernetes-int-or-string: true
-                          path:
-                            description: The path to HostDisk image located on the cluster
-                            type: string
-                          shared:
-                            description: Shared indicate whether the path is shared between nodes
-                            type: boolean
-                          type:
-                            description: Contains information if disk.img exists or should be created allowed options are 'Disk' and 'DiskOrCreate'
-                            type: string
-                        required:
-                        - path
-                        - type
-                        type: object
-                      name:
-                        description: 'Volume''s name. Must be a DNS_LABEL and unique within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
-                        type: string
-                      persistentVolumeClaim:
-                        description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
-                        properties:
-                          claimName:
-                            description: 'ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
-                            type: string
-                          readOnly:
-                            description: Will force the ReadOnly setting in VolumeMounts. Default false.
-                            type: boolean
-                        required:
-                        - claimName
-                        type: object
-                      secret:
-                        description: 'SecretVolumeSource represents a reference to a secret data in the same namespace. More info: https://kubernetes.io/docs/concepts/configuration/secret/'
-                        properties:
-                          optional:
-                            description: Specify whether the Secret or it's keys must be defined
-                            type: boolean
-                          secretName:
-                            description: 'Name of the secret in the pod''s namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
-                            type: string
-                          volumeLabel:
-                            description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
-                            type: string
-                        type: object
-                      serviceAccount:
-                        description: 'ServiceAccountVolumeSource represents a reference to a service account. There can only be one volume of this type! More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
-                        properties:
-                          serviceAccountName:
-                            description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                          type: array
+                        nodeSelector:
+                          additionalProperties:
                             type: string
-                        type: object
-                      sysprep:
-                        description: Represents a Sysprep volume source.
-                        properties:
-                          configMap:
-                            description: ConfigMap references a ConfigMap that contains Sysprep answer file named autounattend.xml that should be attached as disk of CDROM type.
+                          description: 'NodeSelector is a selector which must be true for the vmi to fit on a node. Selector which must match a node''s labels for the vmi to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/'
+                          type: object
+                        priorityClassName:
+                          description: If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.
+                          type: string
+                        readinessProbe:
+                          description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                          properties:
+                            exec:
+                              description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+                              properties:
+                                command:
+                                  description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                                  items:
+                                    type: string
+                                  type: array
+                              type: object
+                            failureThreshold:
+                              description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            guestAgentPing:
+                              description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+                              type: object
+                            httpGet:
+                              description: HTTPGet specifies the http request to perform.
+                              properties:
+                                host:
+                                  description: Host name to connect to, defaults to the pod IP. You probably want to set "Host" in httpHeaders instead.
+                                  type: string
+                                httpHeaders:
+                                  description: Custom headers to set in the request. HTTP allows repeated headers.
+                                  items:
+                                    description: HTTPHeader describes a custom header to be used in HTTP probes
+                                    properties:
+                                      name:
+                                        description: The header field name
+                                        type: string
+                                      value:
+                                        description: The header field value
+                                        type: string
+                                    required:
+                                    - name
+                                    - value
+                                    type: object
+                                  type: array
+                                path:
+                                  description: Path to access on the HTTP server.
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Name or number of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                                scheme:
+                                  description: Scheme to use for connecting to the host. Defaults to HTTP.
+                                  type: string
+                              required:
+                              - port
+                              type: object
+                            initialDelaySeconds:
+                              description: 'Number of seconds after the VirtualMachineInstance has started before liveness probes are initiated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                            periodSeconds:
+                              description: How often (in seconds) to perform the probe. Default to 10 seconds. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            successThreshold:
+                              description: Minimum consecutive successes for the probe to be considered successful after having failed. Defaults to 1. Must be 1 for liveness. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            tcpSocket:
+                              description: 'TCPSocket specifies an action involving a TCP port. TCP hooks not yet supported TODO: implement a realistic TCP lifecycle hook'
+                              properties:
+                                host:
+                                  description: 'Optional: Host name to connect to, defaults to the pod IP.'
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Number or name of the port to access on the container. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                              required:
+                              - port
+                              type: object
+                            timeoutSeconds:
+                              description: 'Number of seconds after which the probe times out. Defaults to 1 second. Minimum value is 1. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                          type: object
+                        schedulerName:
+                          description: If specified, the VMI will be dispatched by specified scheduler. If not specified, the VMI will be dispatched by default scheduler.
+                          type: string
+                        subdomain:
+                          description: If specified, the fully qualified vmi hostname will be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>". If not specified, the vmi will not have a domainname at all. The DNS entry will resolve to the vmi, no matter if the vmi itself can pick up a hostname.
+                          type: string
+                        terminationGracePeriodSeconds:
+                          description: Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.
+                          format: int64
+                          type: integer
+                        tolerations:
+                          description: If toleration is specified, obey all the toleration rules.
+                          items:
+                            description: The pod this Toleration is attached to tolerates any taint that matches the triple <key,value,effect> using the matching operator <operator>.
                             properties:
-                              name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                              effect:
+                                description: Effect indicates the taint effect to match. Empty means match all taint effects. When specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.
+                                type: string
+                              key:
+                                description: Key is the taint key that the toleration applies to. Empty means match all taint keys. If the key is empty, operator must be Exists; this combination means to match all values and all keys.
+                                type: string
+                              operator:
+                                description: Operator represents a key's relationship to the value. Valid operators are Exists and Equal. Defaults to Equal. Exists is equivalent to wildcard for value, so that a pod can tolerate all taints of a particular category.
+                                type: string
+                              tolerationSeconds:
+                                description: TolerationSeconds represents the period of time the toleration (which must be of effect NoExecute, otherwise this field is ignored) tolerates the taint. By default, it is not set, which means tolerate the taint forever (do not evict). Zero and negative values will be treated as 0 (evict immediately) by the system.
+                                format: int64
+                                type: integer
+                              value:
+                                description: Value is the taint value the toleration matches to. If the operator is Exists, the value should be empty, otherwise just a regular string.
                                 type: string
                             type: object
-                          secret:
-                            description: Secret references a k8s Secret that contains Sysprep answer file named autounattend.xml that should be attached as disk of CDROM type.
+                          type: array
+                        volumes:
+                          description: List of volumes that can be mounted by disks belonging to the vmi.
+                          items:
+                            description: Volume represents a named volume in a vmi.
                             properties:
+                              cloudInitConfigDrive:
+                                description: 'CloudInitConfigDrive represents a cloud-init Config Drive user-data source. The Config Drive data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: https://cloudinit.readthedocs.io/en/latest/topics/datasources/configdrive.html'
+                                properties:
+                                  networkData:
+                                    description: NetworkData contains config drive inline cloud-init networkdata.
+                                    type: string
+                                  networkDataBase64:
+                                    description: NetworkDataBase64 contains config drive cloud-init networkdata as a base64 encoded string.
+                                    type: string
+                                  networkDataSecretRef:
+                                    description: NetworkDataSecretRef references a k8s secret that contains config drive networkdata.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                        type: string
+                                    type: object
+                                  secretRef:
+                                    description: UserDataSecretRef references a k8s secret that contains config drive userdata.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                        type: string
+                                    type: object
+                                  userData:
+                                    description: UserData contains config drive inline cloud-init userdata.
+                                    type: string
+                                  userDataBase64:
+                                    description: UserDataBase64 contains config drive cloud-init userdata as a base64 encoded string.
+                                    type: string
+                                type: object
+                              cloudInitNoCloud:
+                                description: 'CloudInitNoCloud represents a cloud-init NoCloud user-data source. The NoCloud data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html'
+                                properties:
+                                  generateNetworkData:
+                                    description: GenerateNetworkData generates NoCloud cloud-init networkdata with the static addresses, routes and DNS servers of the VMI's pod and multus networks. Can't be used together with the other networkdata fields.
+                                    type: boolean
+                                  networkData:
+                                    description: NetworkData contains NoCloud inline cloud-init networkdata.
+                                    type: string
+                                  networkDataBase64:
+                                    description: NetworkDataBase64 contains NoCloud cloud-init networkdata as a base64 encoded string.
+                                    type: string
+                                  networkDataSecretRef:
+                                    description: NetworkDataSecretRef references a k8s secret that contains NoCloud networkdata.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                        type: string
+                                    type: object
+                                  secretRef:
+                                    description: UserDataSecretRef references a k8s secret that contains NoCloud userdata.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                        type: string
+                                    type: object
+                                  userData:
+                                    description: UserData contains NoCloud inline cloud-init userdata.
+                                    type: string
+                                  userDataBase64:
+                                    description: UserDataBase64 contains NoCloud cloud-init userdata as a base64 encoded string.
+                                    type: string
+                                type: object
+                              configMap:
+                                description: 'ConfigMapSource represents a reference to a ConfigMap in the same namespace. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
+                                properties:
+                                  name:
+                                    description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                    type: string
+                                  optional:
+                                    description: Specify whether the ConfigMap or it's keys must be defined
+                                    type: boolean
+                                  volumeLabel:
+                                    description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                                    type: string
+                                type: object
+                              containerDisk:
+                                description: 'ContainerDisk references a docker image, embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
+                                properties:
+                                  image:
+                                    description: Image is the name of the image with the embedded disk.
+                                    type: string
+                                  imagePullPolicy:
+                                    description: 'Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                                    type: string
+                                  imagePullSecret:
+                                    description: ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.
+                                    type: string
+                                  path:
+                                    description: Path defines the path to disk file in the container
+                                    type: string
+                                required:
+                                - image
+                                type: object
+                              dataVolume:
+                                description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
+                                properties:
+                                  name:
+                                    description: Name represents the name of the DataVolume in the same namespace
+                                    type: string
+                                required:
+                                - name
+                                type: object
+                              downwardAPI:
+                                description: DownwardAPI represents downward API about the pod that should populate this volume
+                                properties:
+                                  fields:
+                                    description: Fields is a list of downward API volume file
+                                    items:
+                                      description: DownwardAPIVolumeFile represents information to create the file containing the pod field
+                                      properties:
+                                        fieldRef:
+                                          description: 'Required: Selects a field of the pod: only annotations, labels, name and namespace are supported.'
+                                          properties:
+                                            apiVersion:
+                                              description: Version of the schema the FieldPath is written in terms of, defaults to "v1".
+                                              type: string
+                                            fieldPath:
+                                              description: Path of the field to select in the specified API version.
+                                              type: string
+                                          required:
+                                          - fieldPath
+                                          type: object
+                                        mode:
+                                          description: 'Optional: mode bits used to set permissions on this file, must be an octal value between 0000 and 0777 or a decimal value between 0 and 511. YAML accepts both octal and decimal values, JSON requires decimal values for mode bits. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.'
+                                          format: int32
+                                          type: integer
+                                        path:
+                                          description: 'Required: Path is  the relative path name of the file to be created. Must not be absolute or contain the ''..'' path. Must be utf-8 encoded. The first item of the relative path must not start with ''..'''
+                                          type: string
+                                        resourceFieldRef:
+                                          description: 'Selects a resource of the container: only resources limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.'
+                                          properties:
+                                            containerName:
+                                              description: 'Container name: required for volumes, optional for env vars'
+                                              type: string
+                                            divisor:
+                                              anyOf:
+                                              - type: integer
+                                              - type: string
+                                              description: Specifies the output format of the exposed resources, defaults to "1"
+                                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                              x-kubernetes-int-or-string: true
+                                            resource:
+                                              description: 'Required: resource to select'
+                                              type: string
+                                          required:
+                                          - resource
+                                          type: object
+                                      required:
+                                      - path
+                                      type: object
+                                    type: array
+                                  volumeLabel:
+                                    description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                                    type: string
+                                type: object
+                              downwardMetrics:
+                                description: DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.
+                                type: object
+                              emptyDisk:
+                                description: 'EmptyDisk represents a temporary disk which shares the vmis lifecycle. More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html'
+                                properties:
+                                  capacity:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Capacity of the sparse disk.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                required:
+                                - capacity
+                                type: object
+                              ephemeral:
+                                description: Ephemeral is a special volume source that "wraps" specified source and provides copy-on-write image on top of it.
+                                properties:
+                                  persistentVolumeClaim:
+                                    description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                    properties:
+                                      claimName:
+                                        description: 'ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                        type: string
+                                      readOnly:
+                                        description: Will force the ReadOnly setting in VolumeMounts. Default false.
+                                        type: boolean
+                                    required:
+                                    - claimName
+                                    type: object
+                                type: object
+                              hostDisk:
+                                description: HostDisk represents a disk created on the cluster level
+                                properties:
+                                  capacity:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Capacity of the sparse disk
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  path:
+                                    description: The path to HostDisk image located on the cluster
+                                    type: string
+                                  shared:
+                                    description: Shared indicate whether the path is shared between nodes
+                                    type: boolean
+                                  type:
+                                    description: Contains information if disk.img exists or should be created allowed options are 'Disk' and 'DiskOrCreate'
+                                    type: string
+                                required:
+                                - path
+                                - type
+                                type: object
                               name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                description: 'Volume''s name. Must be a DNS_LABEL and unique within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                                 type: string
+                              persistentVolumeClaim:
+                                description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                properties:
+                                  claimName:
+                                    description: 'ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                    type: string
+                                  readOnly:
+                                    description: Will force the ReadOnly setting in VolumeMounts. Default false.
+                                    type: boolean
+                                required:
+                                - claimName
+                                type: object
+                              secret:
+                                description: 'SecretVolumeSource represents a reference to a secret data in the same namespace. More info: https://kubernetes.io/docs/concepts/configuration/secret/'
+                                properties:
+                                  optional:
+                                    description: Specify whether the Secret or it's keys must be defined
+                                    type: boolean
+                                  secretName:
+                                    description: 'Name of the secret in the pod''s namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                                    type: string
+                                  volumeLabel:
+                                    description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
+                                    type: string
+                                type: object
+                              serviceAccount:
+                                description: 'ServiceAccountVolumeSource represents a reference to a service account. There can only be one volume of this type! More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                                properties:
+                                  serviceAccountName:
+                                    description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                                    type: string
+                                type: object
+                              swapDisk:
+                                description: SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle. The guest is told to enable the swap through the cloud-init vendor data.
+                                properties:
+                                  capacity:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Capacity of the sparse swap disk.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                required:
+                                - capacity
+                                type: object
+                              sysprep:
+                                description: Represents a Sysprep volume source.
+                                properties:
+                                  configMap:
+                                    description: ConfigMap references a ConfigMap that contains Sysprep answer file named autounattend.xml that should be attached as disk of CDROM type.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                        type: string
+                                    type: object
+                                  secret:
+                                    description: Secret references a k8s Secret that contains Sysprep answer file named autounattend.xml that should be attached as disk of CDROM type.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                        type: string
+                                    type: object
+                                type: object
+                            required:
+                            - name
                             type: object
-                        type: object
-                    required:
-                    - name
-                    type: object
-                  type: array
+                          type: array
+                      required:
+                      - domain
+                      type: object
+                  type: object
+                ttlSecondsAfterCompletion:
+                  description: TTLSecondsAfterCompletion limits the lifetime of a VirtualMachine with RunStrategy Once which completed. The VirtualMachine is deleted after the given number of seconds since its completion. Only valid with RunStrategy Once.
+                  format: int32
+                  type: integer
               required:
-              - domain
+              - template
               type: object
           type: object
       required:
       - selector
-      - template
+      - virtualMachineTemplate
       type: object
     status:
-      description: Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.
-      nullable: true
+      description: VirtualMachinePoolStatus is the status for a VirtualMachinePool resource
       properties:
         conditions:
           items:
+            description: VirtualMachinePoolCondition defines pool conditions
             properties:
               lastProbeTime:
                 format: date-time
@@ -7582,27 +13776,277 @@ var CRDsValidation map[string]string = map[string]string{
               status:
                 type: string
               type:
+                description: VirtualMachinePoolConditionType is the const type for VirtualMachinePool conditions
                 type: string
             required:
             - status
             - type
             type: object
           type: array
+          x-kubernetes-list-type: atomic
         labelSelector:
           description: Canonical form of the label selector for HPA which consumes it through the scale subresource.
           type: string
         readyReplicas:
-          description: The number of ready replicas for this replica set.
+          description: The number of ready VirtualMachines of this pool.
           format: int32
           type: integer
         replicas:
-          description: Total number of non-terminated pods targeted by this deployment (their labels match the selector).
+          description: Total number of VirtualMachines targeted by this pool (their labels match the selector).
           format: int32
           type: integer
       type: object
   required:
   - spec
   type: object
+`,
+	"virtualmachinepreference": `openAPIV3Schema:
+  description: VirtualMachinePreference resource contains optional preferences related to the VirtualMachine.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Required spec describing the preferences
+      properties:
+        cpu:
+          description: CPU optionally defines preferences associated with the CPU attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredCPUTopology:
+              description: PreferredCPUTopology optionally defines the preferred guest visible CPU topology, defaults to PreferSockets.
+              type: string
+          type: object
+        devices:
+          description: Devices optionally defines preferences associated with the Devices attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredAutoattachGraphicsDevice:
+              description: PreferredAutoattachGraphicsDevice optionally defines the preferred value of Devices.AutoattachGraphicsDevice
+              type: boolean
+            preferredAutoattachMemBalloon:
+              description: PreferredAutoattachMemBalloon optionally defines the preferred value of Devices.AutoattachMemBalloon
+              type: boolean
+            preferredAutoattachPodInterface:
+              description: PreferredAutoattachPodInterface optionally defines the preferred value of Devices.AutoattachPodInterface
+              type: boolean
+            preferredAutoattachSerialConsole:
+              description: PreferredAutoattachSerialConsole optionally defines the preferred value of Devices.AutoattachSerialConsole
+              type: boolean
+            preferredBlockMultiQueue:
+              description: PreferredBlockMultiQueue optionally enables the vhost multiqueue feature for virtio disks.
+              type: boolean
+            preferredCdromBus:
+              description: PreferredCdromBus optionally defines the preferred bus for Cdrom Disk devices.
+              type: string
+            preferredDisableHotplug:
+              description: PreferredDisableHotplug optionally defines the preferred value of Devices.DisableHotplug
+              type: boolean
+            preferredDiskBus:
+              description: PreferredDiskBus optionally defines the preferred bus for Disk Disk devices.
+              type: string
+            preferredInterfaceModel:
+              description: PreferredInterfaceModel optionally defines the preferred model to be used by Interface devices.
+              type: string
+            preferredNetworkInterfaceMultiQueue:
+              description: PreferredNetworkInterfaceMultiQueue optionally enables the vhost multiqueue feature for virtio interfaces.
+              type: boolean
+            preferredRng:
+              description: PreferredRng optionally defines the preferred Random Number Generator device to use.
+              type: object
+            preferredUseVirtioTransitional:
+              description: PreferredUseVirtioTransitional optionally defines the preferred value of Devices.UseVirtioTransitional
+              type: boolean
+          type: object
+        features:
+          description: Features optionally defines preferences associated with the Features attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredAcpi:
+              description: Represents if a feature is enabled or disabled.
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+              type: object
+            preferredApic:
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+                endOfInterrupt:
+                  description: EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.
+                  type: boolean
+              type: object
+            preferredHyperv:
+              description: Hyperv specific features.
+              properties:
+                evmcs:
+                  description: EVMCS Speeds up L2 vmexits, but disables other virtualization features. Requires vapic. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                frequencies:
+                  description: Frequencies improves the TSC clock source handling for Hyper-V on KVM. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                ipi:
+                  description: IPI improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                reenlightenment:
+                  description: Reenlightenment enables the notifications on TSC frequency changes. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                relaxed:
+                  description: Relaxed instructs the guest OS to disable watchdog timeouts. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                reset:
+                  description: Reset enables Hyperv reboot/reset for the vmi. Requires synic. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                runtime:
+                  description: Runtime improves the time accounting to improve scheduling in the guest. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                spinlocks:
+                  description: Spinlocks allows to configure the spinlock retry attempts.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    spinlocks:
+                      description: Retries indicates the number of retries. Must be a value greater or equal 4096. Defaults to 4096.
+                      format: int32
+                      type: integer
+                  type: object
+                synic:
+                  description: SyNIC enables the Synthetic Interrupt Controller. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                synictimer:
+                  description: SyNICTimer enables Synthetic Interrupt Controller Timers, reducing CPU load. Defaults to the machine type setting.
+                  properties:
+                    direct:
+                      description: Represents if a feature is enabled or disabled.
+                      properties:
+                        enabled:
+                          description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                          type: boolean
+                      type: object
+                    enabled:
+                      type: boolean
+                  type: object
+                tlbflush:
+                  description: TLBFlush improves performances in overcommited environments. Requires vpindex. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                vapic:
+                  description: VAPIC improves the paravirtualized handling of interrupts. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+                vendorid:
+                  description: VendorID allows setting the hypervisor vendor id. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                    vendorid:
+                      description: VendorID sets the hypervisor vendor id, visible to the vmi. String up to twelve characters.
+                      type: string
+                  type: object
+                vpindex:
+                  description: VPIndex enables the Virtual Processor Index to help windows identifying virtual processors. Defaults to the machine type setting.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                      type: boolean
+                  type: object
+              type: object
+            preferredKvm:
+              properties:
+                hidden:
+                  description: Hide the KVM hypervisor from standard MSR based discovery. Defaults to false
+                  type: boolean
+              type: object
+            preferredPvspinlock:
+              description: Represents if a feature is enabled or disabled.
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+              type: object
+            preferredSmm:
+              description: Represents if a feature is enabled or disabled.
+              properties:
+                enabled:
+                  description: Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.
+                  type: boolean
+              type: object
+          type: object
+        firmware:
+          description: Firmware optionally defines preferences associated with the Firmware attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredUseBios:
+              description: PreferredUseBios optionally enables BIOS
+              type: boolean
+            preferredUseEfi:
+              description: PreferredUseEfi optionally enables EFI
+              type: boolean
+            preferredUseSecureBoot:
+              description: PreferredUseSecureBoot optionally enables SecureBoot and the OVMF roms will be swapped for SecureBoot-enabled ones.
+              type: boolean
+          type: object
+        guestOS:
+          description: GuestOS optionally defines preferences associated with the operating system of the guest
+          properties:
+            preferredType:
+              description: PreferredType optionally marks the type of the guest operating system, e.g. windows. It is copied to the kubevirt.io/guest-os annotation of the VirtualMachineInstance.
+              type: string
+          type: object
+        machine:
+          description: Machine optionally defines preferences associated with the Machine attribute of a VirtualMachineInstance DomainSpec
+          properties:
+            preferredMachineType:
+              description: PreferredMachineType optionally defines the preferred machine type to use.
+              type: string
+          type: object
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"virtualmachinerestore": `openAPIV3Schema:
   description: VirtualMachineRestore defines the operation of restoring a VM
@@ -7693,7 +14137,6 @@ var CRDsValidation map[string]string = map[string]string{
             required:
             - persistentVolumeClaim
             - volumeName
-            - volumeSnapshotName
             type: object
           type: array
       type: object
@@ -7718,6 +14161,9 @@ var CRDsValidation map[string]string = map[string]string{
         deletionPolicy:
           description: DeletionPolicy defines that to do with VirtualMachineSnapshot when VirtualMachineSnapshot is deleted
           type: string
+        includeMemoryDump:
+          description: IncludeMemoryDump dumps the memory of the running guest to the PVC of the memoryDump device of the VirtualMachine before its volumes are snapshotted
+          type: boolean
         source:
           description: TypedLocalObjectReference contains enough information to let you locate the typed referenced object inside the same namespace.
           properties:
@@ -7779,6 +14225,12 @@ var CRDsValidation map[string]string = map[string]string{
               format: date-time
               type: string
           type: object
+        indications:
+          items:
+            description: Indication is a way to indicate the state of the vm when taking the snapshot
+            type: string
+          type: array
+          x-kubernetes-list-type: set
         readyToUse:
           type: boolean
         sourceUID:
@@ -7805,6 +14257,24 @@ var CRDsValidation map[string]string = map[string]string{
     spec:
       description: VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent resource
       properties:
+        excludedVolumes:
+          description: ExcludedVolumes are the volumes of the source that were excluded from the snapshot
+          items:
+            type: string
+          type: array
+        memoryDump:
+          description: MemoryDump is the memory dump of the guest taken with the snapshot
+          properties:
+            claimName:
+              description: ClaimName is the name of the PVC storing the memory dump
+              type: string
+            fileName:
+              description: FileName is the name of the dump file on the PVC
+              type: string
+          required:
+          - claimName
+          - fileName
+          type: object
         source:
           description: SourceSpec contains the appropriate spec for the resource being snapshotted
           properties:
@@ -8072,6 +14542,36 @@ var CRDsValidation map[string]string = map[string]string{
                         - spec
                         type: object
                       type: array
+                    instancetype:
+                      description: InstancetypeMatcher references a instancetype that is used to fill fields in Template
+                      properties:
+                        kind:
+                          description: 'Kind specifies which instancetype resource is referenced. Allowed values are: "VirtualMachineInstancetype" and "VirtualMachineClusterInstancetype". If not specified, "VirtualMachineClusterInstancetype" is used by default.'
+                          type: string
+                        name:
+                          description: Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype
+                          type: string
+                        revisionName:
+                          description: RevisionName specifies a ControllerRevision containing a specific copy of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype to be used. This is initially captured the first time the instancetype is applied to the VirtualMachineInstance.
+                          type: string
+                      required:
+                      - name
+                      type: object
+                    preference:
+                      description: PreferenceMatcher references a set of preference that is used to fill fields in Template
+                      properties:
+                        kind:
+                          description: 'Kind specifies which preference resource is referenced. Allowed values are: "VirtualMachinePreference" and "VirtualMachineClusterPreference". If not specified, "VirtualMachineClusterPreference" is used by default.'
+                          type: string
+                        name:
+                          description: Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference
+                          type: string
+                        revisionName:
+                          description: RevisionName specifies a ControllerRevision containing a specific copy of the VirtualMachinePreference or VirtualMachineClusterPreference to be used. This is initially captured the first time the instancetype is applied to the VirtualMachineInstance.
+                          type: string
+                      required:
+                      - name
+                      type: object
                     runStrategy:
                       description: Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running
                       type: string
@@ -8102,6 +14602,9 @@ var CRDsValidation map[string]string = map[string]string{
                                           configDrive:
                                             description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
                                             type: object
+                                          noCloud:
+                                            description: NoCloudPropagation means that the ssh public keys are injected into the VM using metadata using the noCloud cloud-init provider
+                                            type: object
                                           qemuGuestAgent:
                                             description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
                                             properties:
@@ -8503,6 +15006,9 @@ var CRDsValidation map[string]string = map[string]string{
                                       type: array
                                   type: object
                               type: object
+                            architecture:
+                              description: Specifies the architecture of the vm guest you are attempting to run. Defaults to the default architecture of the cluster.
+                              type: string
                             dnsConfig:
                               description: Specifies the DNS parameters of a pod. Parameters specified here will be merged to the generated DNS configuration based on DNSPolicy.
                               properties:
@@ -8642,9 +15148,27 @@ var CRDsValidation map[string]string = map[string]string{
                                     isolateEmulatorThread:
                                       description: IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place the emulator thread on it.
                                       type: boolean
+                                    maxSockets:
+                                      description: MaxSockets specifies the maximum amount of sockets that can be hotplugged into a running vmi. Must be a value greater or equal to Sockets.
+                                      format: int32
+                                      type: integer
                                     model:
                                       description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                                       type: string
+                                    numa:
+                                      description: NUMA allows specifying settings for the guest NUMA topology
+                                      properties:
+                                        guestMappingPassthrough:
+                                          description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+                                          type: object
+                                      type: object
+                                    realtime:
+                                      description: Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
+                                      properties:
+                                        mask:
+                                          description: 'Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt''s expressions. Example: "0-3,^1","0,2,3","2-3"'
+                                          type: string
+                                      type: object
                                     sockets:
                                       description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                                       format: int32
@@ -8663,15 +15187,38 @@ var CRDsValidation map[string]string = map[string]string{
                                     autoattachMemBalloon:
                                       description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                                       type: boolean
+                                    autoattachPanicDevice:
+                                      description: Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor. Defaults to true.
+                                      type: boolean
                                     autoattachPodInterface:
                                       description: Whether to attach a pod network interface. Defaults to true.
                                       type: boolean
                                     autoattachSerialConsole:
                                       description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                                       type: boolean
+                                    autoattachVSOCK:
+                                      description: Whether to attach the VSOCK CID to the VM or not. VSOCK access will be available if set to true. Defaults to false.
+                                      type: boolean
                                     blockMultiQueue:
                                       description: Whether or not to enable virtio multi-queue for block devices
                                       type: boolean
+                                    channels:
+                                      description: Virtio channels of the vmi, e.g. for custom guest agents. Each channel can be connected to through the channel subresource.
+                                      items:
+                                        description: Channel represents a virtio channel of the vmi
+                                        properties:
+                                          name:
+                                            description: Name of the channel, used to connect to it through the channel subresource. Must be a DNS_LABEL and unique within the vmi.
+                                            type: string
+                                          targetName:
+                                            description: TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.
+                                            type: string
+                                        required:
+                                        - name
+                                        - targetName
+                                        type: object
+                                      type: array
+                                      x-kubernetes-list-type: atomic
                                     disableHotplug:
                                       description: DisableHotplug disabled the ability to hotplug disks.
                                       type: boolean
@@ -8683,13 +15230,13 @@ var CRDsValidation map[string]string = map[string]string{
                                             description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
                                             type: integer
                                           cache:
-                                            description: Cache specifies which kvm disk cache mode should be used.
+                                            description: 'Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.'
                                             type: string
                                           cdrom:
                                             description: Attach a volume as a cdrom to the vmi.
                                             properties:
                                               bus:
-                                                description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                                description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                                                 type: string
                                               readonly:
                                                 description: ReadOnly. Defaults to true.
@@ -8701,11 +15248,17 @@ var CRDsValidation map[string]string = map[string]string{
                                           dedicatedIOThread:
                                             description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
                                             type: boolean
+                                          detectZeroes:
+                                            description: 'DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.'
+                                            type: string
+                                          discard:
+                                            description: 'Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.'
+                                            type: string
                                           disk:
                                             description: Attach a volume as a disk to the vmi.
                                             properties:
                                               bus:
-                                                description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                                description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                                                 type: string
                                               pciAddress:
                                                 description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
@@ -8714,6 +15267,24 @@ var CRDsValidation map[string]string = map[string]string{
                                                 description: ReadOnly. Defaults to false.
                                                 type: boolean
                                             type: object
+                                          encryption:
+                                            description: Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+                                            properties:
+                                              format:
+                                                description: Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.
+                                                type: string
+                                              secretName:
+                                                description: SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+                                                type: string
+                                            required:
+                                            - secretName
+                                            type: object
+                                          excludeFromSnapshot:
+                                            description: ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.
+                                            type: boolean
+                                          expandGuestFilesystem:
+                                            description: ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.
+                                            type: boolean
                                           floppy:
                                             description: Attach a volume as a floppy to the vmi.
                                             properties:
@@ -8727,6 +15298,74 @@ var CRDsValidation map[string]string = map[string]string{
                                           io:
                                             description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                                             type: string
+                                          ioTune:
+                                            description: IOTune limits the I/O operations and the bandwidth of the disk.
+                                            properties:
+                                              bandwidth:
+                                                description: Bandwidth limits the bytes per second of the disk.
+                                                properties:
+                                                  burstLength:
+                                                    description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                                    format: int64
+                                                    type: integer
+                                                  read:
+                                                    description: Read limits the reads.
+                                                    format: int64
+                                                    type: integer
+                                                  readBurst:
+                                                    description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                                    format: int64
+                                                    type: integer
+                                                  total:
+                                                    description: Total limits the sum of reads and writes.
+                                                    format: int64
+                                                    type: integer
+                                                  totalBurst:
+                                                    description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                                    format: int64
+                                                    type: integer
+                                                  write:
+                                                    description: Write limits the writes.
+                                                    format: int64
+                                                    type: integer
+                                                  writeBurst:
+                                                    description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                                    format: int64
+                                                    type: integer
+                                                type: object
+                                              iops:
+                                                description: IOPS limits the I/O operations per second of the disk.
+                                                properties:
+                                                  burstLength:
+                                                    description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                                    format: int64
+                                                    type: integer
+                                                  read:
+                                                    description: Read limits the reads.
+                                                    format: int64
+                                                    type: integer
+                                                  readBurst:
+                                                    description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                                    format: int64
+                                                    type: integer
+                                                  total:
+                                                    description: Total limits the sum of reads and writes.
+                                                    format: int64
+                                                    type: integer
+                                                  totalBurst:
+                                                    description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                                    format: int64
+                                                    type: integer
+                                                  write:
+                                                    description: Write limits the writes.
+                                                    format: int64
+                                                    type: integer
+                                                  writeBurst:
+                                                    description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                                    format: int64
+                                                    type: integer
+                                                type: object
+                                            type: object
                                           lun:
                                             description: Attach a volume as a LUN to the vmi.
                                             properties:
@@ -8736,6 +15375,9 @@ var CRDsValidation map[string]string = map[string]string{
                                               readonly:
                                                 description: ReadOnly. Defaults to false.
                                                 type: boolean
+                                              reservation:
+                                                description: Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.
+                                                type: boolean
                                             type: object
                                           name:
                                             description: Name is the device name
@@ -8751,7 +15393,7 @@ var CRDsValidation map[string]string = map[string]string{
                                         type: object
                                       type: array
                                     filesystems:
-                                      description: Filesystems describes filesystem which is connected to the vmi.
+                                      description: Filesystems describes filesystem which is connected to the vmi. The volume with the name of the filesystem is shared into the guest with virtiofs, it can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.
                                       items:
                                         properties:
                                           name:
@@ -8759,6 +15401,50 @@ var CRDsValidation map[string]string = map[string]string{
                                             type: string
                                           virtiofs:
                                             description: Virtiofs is supported
+                                            properties:
+                                              gidMap:
+                                                description: GIDMap maps the group IDs of the guest to the group IDs of the shared directory.
+                                                properties:
+                                                  count:
+                                                    description: Count is the number of IDs in the range, it must be greater than 0.
+                                                    format: int32
+                                                    type: integer
+                                                  guestID:
+                                                    description: GuestID is the first ID of the range in the guest.
+                                                    format: int32
+                                                    type: integer
+                                                  hostID:
+                                                    description: HostID is the first ID of the range in the shared directory.
+                                                    format: int32
+                                                    type: integer
+                                                required:
+                                                - guestID
+                                                - hostID
+                                                - count
+                                                type: object
+                                              subPath:
+                                                description: SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root. It allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.
+                                                type: string
+                                              uidMap:
+                                                description: UIDMap maps the user IDs of the guest to the user IDs of the shared directory.
+                                                properties:
+                                                  count:
+                                                    description: Count is the number of IDs in the range, it must be greater than 0.
+                                                    format: int32
+                                                    type: integer
+                                                  guestID:
+                                                    description: GuestID is the first ID of the range in the guest.
+                                                    format: int32
+                                                    type: integer
+                                                  hostID:
+                                                    description: HostID is the first ID of the range in the shared directory.
+                                                    format: int32
+                                                    type: integer
+                                                required:
+                                                - guestID
+                                                - hostID
+                                                - count
+                                                type: object
                                             type: object
                                         required:
                                         - name
@@ -8766,6 +15452,9 @@ var CRDsValidation map[string]string = map[string]string{
                                         type: object
                                       type: array
                                       x-kubernetes-list-type: atomic
+                                    freePageReporting:
+                                      description: Whether to enable free page reporting on the memory balloon device, so that memory freed by the guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.
+                                      type: boolean
                                     gpus:
                                       description: Whether to attach a GPU device to the vmi.
                                       items:
@@ -8790,6 +15479,14 @@ var CRDsValidation map[string]string = map[string]string{
                                             type: string
                                           name:
                                             type: string
+                                          nvme:
+                                            description: NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.
+                                            properties:
+                                              namespace:
+                                                description: Namespace is the ID of the namespace. Defaults to 1.
+                                                format: int32
+                                                type: integer
+                                            type: object
                                         required:
                                         - deviceName
                                         - name
@@ -8818,6 +15515,73 @@ var CRDsValidation map[string]string = map[string]string{
                                       description: Interfaces describe network interfaces which are added to the vmi.
                                       items:
                                         properties:
+                                          bandwidth:
+                                            description: Bandwidth limits the traffic of the interface. It is only supported with the bridge, masquerade and macvtap bindings.
+                                            properties:
+                                              inbound:
+                                                description: Inbound limits the traffic received by the guest (rx).
+                                                properties:
+                                                  average:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  burst:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Burst is the amount of bytes which can be sent at the peak rate.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  peak:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                required:
+                                                - average
+                                                type: object
+                                              outbound:
+                                                description: Outbound limits the traffic sent by the guest (tx).
+                                                properties:
+                                                  average:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Average is the rate, in bytes per second, the traffic is shaped to.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  burst:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Burst is the amount of bytes which can be sent at the peak rate.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  peak:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                required:
+                                                - average
+                                                type: object
+                                            type: object
+                                          binding:
+                                            description: Binding specifies the binding plugin that will be used to connect the interface to the guest. It provides an alternative to InterfaceBindingMethod.
+                                            properties:
+                                              name:
+                                                description: Name references the binding plugin name as registered in the KubeVirt CR.
+                                                type: string
+                                            required:
+                                            - name
+                                            type: object
                                           bootOrder:
                                             description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                             type: integer
@@ -8829,6 +15593,9 @@ var CRDsValidation map[string]string = map[string]string{
                                               bootFileName:
                                                 description: If specified will pass option 67 to interface's DHCP server
                                                 type: string
+                                              mtu:
+                                                description: If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.
+                                                type: integer
                                               ntpServers:
                                                 description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                                                 items:
@@ -8850,10 +15617,58 @@ var CRDsValidation map[string]string = map[string]string{
                                                   - value
                                                   type: object
                                                 type: array
+                                              routes:
+                                                description: If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes of the pod interface.
+                                                items:
+                                                  description: DHCPRoute defines a static route passed to the VM through DHCP.
+                                                  properties:
+                                                    destination:
+                                                      description: Destination is the IPv4 CIDR of the routed network. Required.
+                                                      type: string
+                                                    gateway:
+                                                      description: Gateway is the IPv4 address of the next hop. Required.
+                                                      type: string
+                                                  required:
+                                                  - destination
+                                                  - gateway
+                                                  type: object
+                                                type: array
                                               tftpServerName:
                                                 description: If specified will pass option 66 to interface's DHCP server
                                                 type: string
                                             type: object
+                                          firewall:
+                                            description: Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod, so it applies even where NetworkPolicies don't, like on bridged secondary networks. It is only supported with the bridge and masquerade bindings.
+                                            properties:
+                                              defaultAction:
+                                                description: DefaultAction applies to the packets which match no rule. Defaults to Allow. Deny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass. VMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label, which virt-handler sets where it is loaded.
+                                                type: string
+                                              rules:
+                                                description: Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.
+                                                items:
+                                                  description: FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port. The packets match when all the criteria which are set match.
+                                                  properties:
+                                                    action:
+                                                      description: Action is Allow or Deny.
+                                                      type: string
+                                                    cidr:
+                                                      description: CIDR is the network of the remote peer, IPv4 or IPv6.
+                                                      type: string
+                                                    direction:
+                                                      description: Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest. The rule applies to both directions if it is not set.
+                                                      type: string
+                                                    port:
+                                                      description: Port is the destination port, on the guest for Ingress and on the remote peer for Egress. It requires the TCP, UDP or SCTP protocol.
+                                                      format: int32
+                                                      type: integer
+                                                    protocol:
+                                                      description: Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.
+                                                      type: string
+                                                  required:
+                                                  - action
+                                                  type: object
+                                                type: array
+                                            type: object
                                           macAddress:
                                             description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                             type: string
@@ -8867,6 +15682,22 @@ var CRDsValidation map[string]string = map[string]string{
                                           name:
                                             description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                                             type: string
+                                          offloads:
+                                            description: Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default. It requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.
+                                            properties:
+                                              checksum:
+                                                description: Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled with it and can't be enabled without it.
+                                                type: boolean
+                                              gso:
+                                                description: GSO toggles the generic segmentation offload of the host.
+                                                type: boolean
+                                              tso:
+                                                description: TSO toggles the TCP segmentation offload, for IPv4 and IPv6.
+                                                type: boolean
+                                            type: object
+                                          passt:
+                                            description: InterfacePasst connects the guest to the pod network through passt, a user-mode network stack which forwards TCP and UDP traffic over both IPv4 and IPv6.
+                                            type: object
                                           pciAddress:
                                             description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                                             type: string
@@ -8883,35 +15714,126 @@ var CRDsValidation map[string]string = map[string]string{
                                                   format: int32
                                                   type: integer
                                                 protocol:
-                                                  description: Protocol for port. Must be UDP or TCP. Defaults to "TCP".
+                                                  description: Protocol for port. Must be UDP, TCP or SCTP. Defaults to "TCP".
                                                   type: string
                                               required:
                                               - port
                                               type: object
                                             type: array
+                                          romDisabled:
+                                            description: If set, no option ROM is exposed to the guest for the interface. Interfaces with a boot order have an option ROM by default, which is needed to boot from the network with BIOS firmware. EFI firmware can boot from the network without it.
+                                            type: boolean
+                                          rss:
+                                            description: RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a multi-queue guest across its queues, and thus across its vCPUs. It requires the virtio model and networkInterfaceMultiqueue, and is only supported with the bridge, masquerade and macvtap bindings.
+                                            properties:
+                                              hashReport:
+                                                description: HashReport makes the device report the RSS hash of every received packet to the guest, which saves the guest from computing it again. The device is emulated by QEMU instead of vhost-net then.
+                                                type: boolean
+                                            type: object
                                           slirp:
                                             type: object
                                           sriov:
+                                            properties:
+                                              failover:
+                                                description: Failover names a virtio interface of the vmi which the guest teams with the VF through its net_failover driver. The traffic fails over to that interface while the VF is detached for a live migration. Both interfaces must have the same MAC address and be connected to the same L2 network.
+                                                type: string
                                             type: object
+                                          state:
+                                            description: State represents the requested operational state of the interface. The link of the interface is set "down" or "up", "up" being the default, and the guest sees a link which is down as an unplugged cable. "absent" is set when the interface is hot unplugged.
+                                            type: string
                                           tag:
                                             description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
                                             type: string
+                                          vdpa:
+                                            description: InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface. The device is allocated by a device plugin, through the resource of the Multus network.
+                                            type: object
+                                          vhostuser:
+                                            description: InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP, through a vhost-user socket. The guest memory is shared with the switch.
+                                            type: object
                                         required:
                                         - name
                                         type: object
                                       type: array
+                                    logSerialConsole:
+                                      description: Whether to mirror the output of the serial console to the virt-launcher pod log. The log can be retrieved later through the console/log subresource. Defaults to false.
+                                      type: boolean
+                                    memoryDump:
+                                      description: If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource, for example by VirtualMachineSnapshots which include a memory dump.
+                                      properties:
+                                        claimName:
+                                          description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                                          type: string
+                                      required:
+                                      - claimName
+                                      type: object
                                     networkInterfaceMultiqueue:
                                       description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                                       type: boolean
+                                    panicMemoryDump:
+                                      description: If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.
+                                      properties:
+                                        claimName:
+                                          description: ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+                                          type: string
+                                      required:
+                                      - claimName
+                                      type: object
                                     rng:
                                       description: Whether to have random number generator from host
                                       type: object
+                                    serialConsoleLog:
+                                      description: If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.
+                                      properties:
+                                        claimName:
+                                          description: ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.
+                                          type: string
+                                        maxFileSize:
+                                          anyOf:
+                                          - type: integer
+                                          - type: string
+                                          description: MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.
+                                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                          x-kubernetes-int-or-string: true
+                                        maxFiles:
+                                          description: MaxFiles is the number of rotated log files which are kept. Defaults to 3.
+                                          format: int32
+                                          type: integer
+                                      required:
+                                      - claimName
+                                      type: object
+                                    serials:
+                                      description: Additional serial ports of the vmi, next to the default serial console. Each serial port can be connected to through the serial subresource.
+                                      items:
+                                        description: SerialPort represents an additional serial port of the vmi
+                                        properties:
+                                          name:
+                                            description: Name of the serial port, used to connect to it through the serial subresource. Must be a DNS_LABEL and unique within the vmi.
+                                            type: string
+                                        required:
+                                        - name
+                                        type: object
+                                      type: array
+                                      x-kubernetes-list-type: atomic
+                                    tpm:
+                                      description: Whether to emulate a TPM device.
+                                      properties:
+                                        persistent:
+                                          description: Persistent indicates the state of the TPM device should be kept across reboots and migrations. The state is stored in a per-VM backend storage volume. Defaults to false.
+                                          type: boolean
+                                      type: object
                                     useVirtioTransitional:
                                       description: Fall back to legacy virtio 0.9 support if virtio bus is selected on devices. This is helpful for old machines like CentOS6 or RHEL6 which do not understand virtio_non_transitional (virtio 1.0).
                                       type: boolean
                                     watchdog:
                                       description: Watchdog describes a watchdog device which can be added to the vmi.
                                       properties:
+                                        diag288:
+                                          description: diag288 watchdog device, only available on s390x.
+                                          properties:
+                                            action:
+                                              description: The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.
+                                              type: string
+                                          type: object
                                         i6300esb:
                                           description: i6300esb watchdog device.
                                           properties:
@@ -9099,9 +16021,43 @@ var CRDsValidation map[string]string = map[string]string{
                                         efi:
                                           description: If set, EFI will be used instead of BIOS.
                                           properties:
+                                            persistent:
+                                              description: Persistent indicates the EFI NVRAM should be kept across reboots and migrations. The NVRAM is stored in a per-VM backend storage volume. Defaults to false.
+                                              type: boolean
                                             secureBoot:
                                               description: If set, SecureBoot will be enabled and the OVMF roms will be swapped for SecureBoot-enabled ones. Requires SMM to be enabled. Defaults to true
                                               type: boolean
+                                            secureBootKeys:
+                                              description: SecureBootKeys references Secrets with custom certificates which are enrolled into the NVRAM at first boot. Requires SecureBoot to be enabled.
+                                              properties:
+                                                db:
+                                                  description: DB references a Secret with certificates which are allowed to sign boot loaders and kernels, added to the default ones.
+                                                  properties:
+                                                    secretName:
+                                                      description: SecretName is the name of the Secret in the namespace of the vmi.
+                                                      type: string
+                                                  required:
+                                                  - secretName
+                                                  type: object
+                                                kek:
+                                                  description: KEK references a Secret with Key Exchange Key certificates, added to the default ones.
+                                                  properties:
+                                                    secretName:
+                                                      description: SecretName is the name of the Secret in the namespace of the vmi.
+                                                      type: string
+                                                  required:
+                                                  - secretName
+                                                  type: object
+                                                pk:
+                                                  description: PK references a Secret with the Platform Key certificate, replacing the default one.
+                                                  properties:
+                                                    secretName:
+                                                      description: SecretName is the name of the Secret in the namespace of the vmi.
+                                                      type: string
+                                                  required:
+                                                  - secretName
+                                                  type: object
+                                              type: object
                                           type: object
                                       type: object
                                     serial:
@@ -9111,9 +16067,44 @@ var CRDsValidation map[string]string = map[string]string{
                                       description: UUID reported by the vmi bios. Defaults to a random generated uid.
                                       type: string
                                   type: object
+                                ioThreads:
+                                  description: IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.
+                                  properties:
+                                    dedicatedPinning:
+                                      description: 'DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated. emulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads. vcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads. One of: emulator, vcpus. Defaults to emulator.'
+                                      type: string
+                                    sharedPoolSize:
+                                      description: SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread. Defaults to the number of IOThreads determined by the ioThreadsPolicy.
+                                      format: int32
+                                      type: integer
+                                  type: object
                                 ioThreadsPolicy:
                                   description: 'Controls whether or not disks will share IOThreads. Omitting IOThreadsPolicy disables use of IOThreads. One of: shared, auto'
                                   type: string
+                                launchSecurity:
+                                  description: Launch Security setting of the vmi.
+                                  properties:
+                                    sev:
+                                      description: AMD Secure Encrypted Virtualization (SEV).
+                                      properties:
+                                        attestation:
+                                          description: If specified, run the attestation process for a vmi.
+                                          type: object
+                                        policy:
+                                          description: 'Guest policy flags as defined in AMD SEV API specification. Note: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.'
+                                          properties:
+                                            encryptedState:
+                                              description: SEV-ES is required. Defaults to false.
+                                              type: boolean
+                                          type: object
+                                      type: object
+                                    snp:
+                                      description: AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.
+                                      type: object
+                                    tdx:
+                                      description: Intel Trust Domain Extensions (TDX).
+                                      type: object
+                                  type: object
                                 machine:
                                   description: Machine type.
                                   properties:
@@ -9140,6 +16131,13 @@ var CRDsValidation map[string]string = map[string]string{
                                           description: PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
                                           type: string
                                       type: object
+                                    maxGuest:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      description: MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS. The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
                                   type: object
                                 resources:
                                   description: Resources describes the Compute Resources required by this vmi.
@@ -9175,13 +16173,43 @@ var CRDsValidation map[string]string = map[string]string{
                             hostname:
                               description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                               type: string
+                            ignition:
+                              description: If specified, the Ignition config is passed to the guest through the fw_cfg device. Used to provision guests like Fedora CoreOS or Flatcar, which do not use cloud-init.
+                              properties:
+                                configMapRef:
+                                  description: ConfigMapRef references a ConfigMap that contains the Ignition config under the config.ign key.
+                                  properties:
+                                    name:
+                                      description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                      type: string
+                                  type: object
+                                secretRef:
+                                  description: SecretRef references a k8s Secret that contains the Ignition config under the config.ign key.
+                                  properties:
+                                    name:
+                                      description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                      type: string
+                                  type: object
+                              type: object
                             livenessProbe:
                               description: 'Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
                               properties:
+                                exec:
+                                  description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+                                  properties:
+                                    command:
+                                      description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                                      items:
+                                        type: string
+                                      type: array
+                                  type: object
                                 failureThreshold:
                                   description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
                                   format: int32
                                   type: integer
+                                guestAgentPing:
+                                  description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+                                  type: object
                                 httpGet:
                                   description: HTTPGet specifies the http request to perform.
                                   properties:
@@ -9265,6 +16293,9 @@ var CRDsValidation map[string]string = map[string]string{
                                       networkName:
                                         description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
                                         type: string
+                                      persistentIPs:
+                                        description: PersistentIPs keeps the addresses allocated by the IPAM of the network across live migrations and restarts of the VM. The addresses are requested through the ips field of the multus network selection element, so the IPAM plugin has to support static IP requests. Only bridge interfaces are supported.
+                                        type: boolean
                                     required:
                                     - networkName
                                     type: object
@@ -9274,6 +16305,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   pod:
                                     description: Represents the stock pod network interface.
                                     properties:
+                                      vmIPv6NetworkCIDR:
+                                        description: IPv6 CIDR for the vm network. Defaults to fd10:0:2::/120 if not specified.
+                                        type: string
                                       vmNetworkCIDR:
                                         description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
                                         type: string
@@ -9293,10 +16327,22 @@ var CRDsValidation map[string]string = map[string]string{
                             readinessProbe:
                               description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
                               properties:
+                                exec:
+                                  description: Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.
+                                  properties:
+                                    command:
+                                      description: Command is the command line to execute inside the container, the working directory for the command  is root ('/') in the container's filesystem. The command is simply exec'd, it is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                                      items:
+                                        type: string
+                                      type: array
+                                  type: object
                                 failureThreshold:
                                   description: Minimum consecutive failures for the probe to be considered failed after having succeeded. Defaults to 3. Minimum value is 1.
                                   format: int32
                                   type: integer
+                                guestAgentPing:
+                                  description: GuestAgentPing contacts the qemu-guest-agent for availability checks.
+                                  type: object
                                 httpGet:
                                   description: HTTPGet specifies the http request to perform.
                                   properties:
@@ -9437,6 +16483,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   cloudInitNoCloud:
                                     description: 'CloudInitNoCloud represents a cloud-init NoCloud user-data source. The NoCloud data will be added as a disk to the vmi. A proper cloud-init installation is required inside the guest. More info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html'
                                     properties:
+                                      generateNetworkData:
+                                        description: GenerateNetworkData generates NoCloud cloud-init networkdata with the static addresses, routes and DNS servers of the VMI's pod and multus networks. Can't be used together with the other networkdata fields.
+                                        type: boolean
                                       networkData:
                                         description: NetworkData contains NoCloud inline cloud-init networkdata.
                                         type: string
@@ -9558,6 +16607,9 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are "cidata" (cloud-init), "config-2" (cloud-init) or "OEMDRV" (kickstart).
                                         type: string
                                     type: object
+                                  downwardMetrics:
+                                    description: DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.
+                                    type: object
                                   emptyDisk:
                                     description: 'EmptyDisk represents a temporary disk which shares the vmis lifecycle. More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html'
                                     properties:
@@ -9645,6 +16697,19 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                                         type: string
                                     type: object
+                                  swapDisk:
+                                    description: SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle. The guest is told to enable the swap through the cloud-init vendor data.
+                                    properties:
+                                      capacity:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Capacity of the sparse swap disk.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - capacity
+                                    type: object
                                   sysprep:
                                     description: Represents a Sysprep volume source.
                                     properties:
@@ -9671,6 +16736,10 @@ var CRDsValidation map[string]string = map[string]string{
                           - domain
                           type: object
                       type: object
+                    ttlSecondsAfterCompletion:
+                      description: TTLSecondsAfterCompletion limits the lifetime of a VirtualMachine with RunStrategy Once which completed. The VirtualMachine is deleted after the given number of seconds since its completion. Only valid with RunStrategy Once.
+                      format: int32
+                      type: integer
                   required:
                   - template
                   type: object
@@ -9745,13 +16814,13 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each disk or interface that has a boot order must have a unique value. Disks without a boot order are not tried if a disk with a boot order exists.
                                     type: integer
                                   cache:
-                                    description: Cache specifies which kvm disk cache mode should be used.
+                                    description: 'Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.'
                                     type: string
                                   cdrom:
                                     description: Attach a volume as a cdrom to the vmi.
                                     properties:
                                       bus:
-                                        description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                        description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                                         type: string
                                       readonly:
                                         description: ReadOnly. Defaults to true.
@@ -9763,11 +16832,17 @@ var CRDsValidation map[string]string = map[string]string{
                                   dedicatedIOThread:
                                     description: dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.
                                     type: boolean
+                                  detectZeroes:
+                                    description: 'DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.'
+                                    type: string
+                                  discard:
+                                    description: 'Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.'
+                                    type: string
                                   disk:
                                     description: Attach a volume as a disk to the vmi.
                                     properties:
                                       bus:
-                                        description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.'
+                                        description: 'Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.'
                                         type: string
                                       pciAddress:
                                         description: 'If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
@@ -9776,6 +16851,24 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: ReadOnly. Defaults to false.
                                         type: boolean
                                     type: object
+                                  encryption:
+                                    description: Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+                                    properties:
+                                      format:
+                                        description: Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.
+                                        type: string
+                                      secretName:
+                                        description: SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+                                        type: string
+                                    required:
+                                    - secretName
+                                    type: object
+                                  excludeFromSnapshot:
+                                    description: ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.
+                                    type: boolean
+                                  expandGuestFilesystem:
+                                    description: ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.
+                                    type: boolean
                                   floppy:
                                     description: Attach a volume as a floppy to the vmi.
                                     properties:
@@ -9789,6 +16882,74 @@ var CRDsValidation map[string]string = map[string]string{
                                   io:
                                     description: 'IO specifies which QEMU disk IO mode should be used. Supported values are: native, default, threads.'
                                     type: string
+                                  ioTune:
+                                    description: IOTune limits the I/O operations and the bandwidth of the disk.
+                                    properties:
+                                      bandwidth:
+                                        description: Bandwidth limits the bytes per second of the disk.
+                                        properties:
+                                          burstLength:
+                                            description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                            format: int64
+                                            type: integer
+                                          read:
+                                            description: Read limits the reads.
+                                            format: int64
+                                            type: integer
+                                          readBurst:
+                                            description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                            format: int64
+                                            type: integer
+                                          total:
+                                            description: Total limits the sum of reads and writes.
+                                            format: int64
+                                            type: integer
+                                          totalBurst:
+                                            description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                            format: int64
+                                            type: integer
+                                          write:
+                                            description: Write limits the writes.
+                                            format: int64
+                                            type: integer
+                                          writeBurst:
+                                            description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                            format: int64
+                                            type: integer
+                                        type: object
+                                      iops:
+                                        description: IOPS limits the I/O operations per second of the disk.
+                                        properties:
+                                          burstLength:
+                                            description: BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.
+                                            format: int64
+                                            type: integer
+                                          read:
+                                            description: Read limits the reads.
+                                            format: int64
+                                            type: integer
+                                          readBurst:
+                                            description: ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+                                            format: int64
+                                            type: integer
+                                          total:
+                                            description: Total limits the sum of reads and writes.
+                                            format: int64
+                                            type: integer
+                                          totalBurst:
+                                            description: TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.
+                                            format: int64
+                                            type: integer
+                                          write:
+                                            description: Write limits the writes.
+                                            format: int64
+                                            type: integer
+                                          writeBurst:
+                                            description: WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+                                            format: int64
+                                            type: integer
+                                        type: object
+                                    type: object
                                   lun:
                                     description: Attach a volume as a LUN to the vmi.
                                     properties:
@@ -9798,6 +16959,9 @@ var CRDsValidation map[string]string = map[string]string{
                                       readonly:
                                         description: ReadOnly. Defaults to false.
                                         type: boolean
+                                      reservation:
+                                        description: Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.
+                                        type: boolean
                                     type: object
                                   name:
                                     description: Name is the device name
@@ -9885,6 +17049,24 @@ var CRDsValidation map[string]string = map[string]string{
           items:
             description: VolumeBackup contains the data neeed to restore a PVC
             properties:
+              externalSnapshot:
+                description: ExternalSnapshot is set instead of VolumeSnapshotName if the volume was snapshotted by switching the disk of the running VirtualMachine to a qcow2 overlay
+                properties:
+                  backingChain:
+                    description: BackingChain are the files backing File, from its backing file down to the base image
+                    items:
+                      type: string
+                    type: array
+                  file:
+                    description: File is the image on the PVC which holds the disk at the time of the snapshot, the guest no longer writes to it
+                    type: string
+                  overlay:
+                    description: Overlay is the qcow2 overlay the guest writes to since the snapshot
+                    type: string
+                required:
+                - file
+                - overlay
+                type: object
               persistentVolumeClaim:
                 properties:
                   metadata:
@@ -10037,5 +17219,82 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinesnapshotschedule": `openAPIV3Schema:
+  description: VirtualMachineSnapshotSchedule creates VirtualMachineSnapshots of a VM on a schedule and deletes the oldest of them beyond the retention count
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource
+      properties:
+        deletionPolicy:
+          description: DeletionPolicy is passed on to the created snapshots
+          type: string
+        quiescePolicy:
+          description: QuiescePolicy defaults to BestEffort
+          type: string
+        retention:
+          description: Retention is the number of ready snapshots kept, older ones are deleted. Defaults to 7. Snapshots are kept when the schedule is deleted.
+          format: int32
+          type: integer
+        schedule:
+          description: Schedule is a cron expression evaluated in UTC, e.g. "0 3 * * *", or one of @hourly, @daily, @weekly and @monthly
+          type: string
+        source:
+          description: TypedLocalObjectReference contains enough information to let you locate the typed referenced object inside the same namespace.
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+      required:
+      - schedule
+      - source
+      type: object
+    status:
+      description: VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource
+      properties:
+        error:
+          description: Error is set if the schedule is invalid or the last snapshot was skipped
+          properties:
+            message:
+              type: string
+            time:
+              format: date-time
+              type: string
+          type: object
+        lastScheduleTime:
+          description: LastScheduleTime is the last time a snapshot was due
+          format: date-time
+          nullable: true
+          type: string
+        lastSnapshotName:
+          description: LastSnapshotName is the name of the last snapshot created by the schedule
+          type: string
+        nextScheduleTime:
+          description: NextScheduleTime is the next time a snapshot is due
+          format: date-time
+          nullable: true
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
 `,
 }
@@ -7,6 +7,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
+	checkupv1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	clonev1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 )
 
@@ -220,6 +223,10 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *v1beta1.
 	migrationUpdatePath := MigrationUpdateValidatePath
 	vmSnapshotValidatePath := VMSnapshotValidatePath
 	vmRestoreValidatePath := VMRestoreValidatePath
+	vmCloneValidatePath := VMCloneValidatePath
+	vmPoolValidatePath := VMPoolValidatePath
+	vmSnapshotScheduleValidatePath := VMSnapshotScheduleValidatePath
+	storageCheckupValidatePath := StorageCheckupValidatePath
 	launcherEvictionValidatePath := LauncherEvictionValidatePath
 	statusValidatePath := StatusValidatePath
 	failurePolicy := v1beta1.Fail
@@ -468,6 +475,98 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *v1beta1.
 					},
 				},
 			},
+			{
+				Name:          "virtualmachineclone-validator.clone.kubevirt.io",
+				SideEffects:   &sideEffectNone,
+				FailurePolicy: &failurePolicy,
+				Rules: []v1beta1.RuleWithOperations{{
+					Operations: []v1beta1.OperationType{
+						v1beta1.Create,
+						v1beta1.Update,
+					},
+					Rule: v1beta1.Rule{
+						APIGroups:   []string{clonev1.SchemeGroupVersion.Group},
+						APIVersions: []string{clonev1.SchemeGroupVersion.Version},
+						Resources:   []string{"virtualmachineclones"},
+					},
+				}},
+				ClientConfig: v1beta1.WebhookClientConfig{
+					Service: &v1beta1.ServiceReference{
+						Namespace: installNamespace,
+						Name:      VirtApiServiceName,
+						Path:      &vmCloneValidatePath,
+					},
+				},
+			},
+			{
+				Name:          "virtualmachinepool-validator.pool.kubevirt.io",
+				SideEffects:   &sideEffectNone,
+				FailurePolicy: &failurePolicy,
+				Rules: []v1beta1.RuleWithOperations{{
+					Operations: []v1beta1.OperationType{
+						v1beta1.Create,
+						v1beta1.Update,
+					},
+					Rule: v1beta1.Rule{
+						APIGroups:   []string{poolv1.SchemeGroupVersion.Group},
+						APIVersions: []string{poolv1.SchemeGroupVersion.Version},
+						Resources:   []string{"virtualmachinepools"},
+					},
+				}},
+				ClientConfig: v1beta1.WebhookClientConfig{
+					Service: &v1beta1.ServiceReference{
+						Namespace: installNamespace,
+						Name:      VirtApiServiceName,
+						Path:      &vmPoolValidatePath,
+					},
+				},
+			},
+			{
+				Name:          "virtualmachinesnapshotschedule-validator.snapshot.kubevirt.io",
+				SideEffects:   &sideEffectNone,
+				FailurePolicy: &failurePolicy,
+				Rules: []v1beta1.RuleWithOperations{{
+					Operations: []v1beta1.OperationType{
+						v1beta1.Create,
+						v1beta1.Update,
+					},
+					Rule: v1beta1.Rule{
+						APIGroups:   []string{snapshotv1.SchemeGroupVersion.Group},
+						APIVersions: []string{snapshotv1.SchemeGroupVersion.Version},
+						Resources:   []string{"virtualmachinesnapshotschedules"},
+					},
+				}},
+				ClientConfig: v1beta1.WebhookClientConfig{
+					Service: &v1beta1.ServiceReference{
+						Namespace: installNamespace,
+						Name:      VirtApiServiceName,
+						Path:      &vmSnapshotScheduleValidatePath,
+					},
+				},
+			},
+			{
+				Name:          "storagecheckup-validator.checkup.kubevirt.io",
+				SideEffects:   &sideEffectNone,
+				FailurePolicy: &failurePolicy,
+				Rules: []v1beta1.RuleWithOperations{{
+					Operations: []v1beta1.OperationType{
+						v1beta1.Create,
+						v1beta1.Update,
+					},
+					Rule: v1beta1.Rule{
+						APIGroups:   []string{checkupv1.SchemeGroupVersion.Group},
+						APIVersions: []string{checkupv1.SchemeGroupVersion.Version},
+						Resources:   []string{"storagecheckups"},
+					},
+				}},
+				ClientConfig: v1beta1.WebhookClientConfig{
+					Service: &v1beta1.ServiceReference{
+						Namespace: installNamespace,
+						Name:      VirtApiServiceName,
+						Path:      &storageCheckupValidatePath,
+					},
+				},
+			},
 			{
 				Name:          "kubevirt-crd-status-validator.kubevirt.io",
 				FailurePolicy: &failurePolicy,
@@ -541,6 +640,14 @@ const VMSnapshotValidatePath = "/virtualmachinesnapshots-validate"
 
 const VMRestoreValidatePath = "/virtualmachinerestores-validate"
 
+const VMCloneValidatePath = "/virtualmachineclones-validate"
+
+const VMPoolValidatePath = "/virtualmachinepools-validate"
+
+const VMSnapshotScheduleValidatePath = "/virtualmachinesnapshotschedules-validate"
+
+const StorageCheckupValidatePath = "/storagecheckups-validate"
+
 const StatusValidatePath = "/status-validate"
 
 const LauncherEvictionValidatePath = "/launcher-eviction-validate"
@@ -315,7 +315,12 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 		components.NewVirtualMachineInstanceCrd, components.NewPresetCrd, components.NewReplicaSetCrd,
 		components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 		components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
-		components.NewVirtualMachineRestoreCrd,
+		components.NewVirtualMachineRestoreCrd, components.NewVirtualMachineExportCrd,
+		components.NewVirtualMachineCloneCrd, components.NewVirtualMachineInstancetypeCrd,
+		components.NewVirtualMachineClusterInstancetypeCrd, components.NewVirtualMachinePreferenceCrd,
+		components.NewVirtualMachineClusterPreferenceCrd,
+		components.NewVirtualMachinePoolCrd, components.NewVirtualMachineSnapshotScheduleCrd,
+		components.NewStorageCheckupCrd,
 	}
 	for _, f := range functions {
 		crd, err := f()
@@ -178,12 +178,38 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachinesnapshots",
+					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstancetypes",
+					"virtualmachinepreferences",
+					"virtualmachineclusterinstancetypes",
+					"virtualmachineclusterpreferences",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"apps",
+				},
+				Resources: []string{
+					"controllerrevisions",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 		},
 	}
 }
@@ -267,6 +293,31 @@ func newApiServerRole(namespace string) *rbacv1.Role {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"configmaps",
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"configmaps",
+				},
+				ResourceNames: []string{
+					"kubevirt-mac-pool-reservations",
+				},
+				Verbs: []string{
+					"patch",
+				},
+			},
 		},
 	}
 }
@@ -122,7 +122,13 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachineinstances/console",
+					"virtualmachineinstances/console/log",
 					"virtualmachineinstances/vnc",
+					"virtualmachineinstances/serial",
+					"virtualmachineinstances/channel",
+					"virtualmachineinstances/pcap",
+					"virtualmachineinstances/portforward",
+					"virtualmachineinstances/backup",
 				},
 				Verbs: []string{
 					"get",
@@ -135,6 +141,18 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachineinstances/pause",
 					"virtualmachineinstances/unpause",
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/memorydump",
+					"virtualmachineinstances/externalsnapshot",
+					"virtualmachineinstances/blockcommit",
+					"virtualmachineinstances/startbackup",
+					"virtualmachineinstances/stopbackup",
+					"virtualmachineinstances/addinterface",
+					"virtualmachineinstances/removeinterface",
+					"virtualmachineinstances/setlinkstate",
+					"virtualmachineinstances/insertmedia",
+					"virtualmachineinstances/ejectmedia",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
 				},
@@ -143,6 +161,29 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"update",
 				},
 			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/sev/fetchcertchain",
+					"virtualmachineinstances/sev/querylaunchmeasurement",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/sev/injectlaunchsecret",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
 			{
 				APIGroups: []string{
 					"subresources.kubevirt.io",
@@ -151,6 +192,8 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachines/restart",
+					"virtualmachines/networkboot",
+					"virtualmachines/removeinterface",
 				},
 				Verbs: []string{
 					"update",
@@ -179,11 +222,80 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
 				},
 			},
+			{
+				APIGroups: []string{
+					"export.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineexports",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
+			{
+				APIGroups: []string{
+					"clone.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineclones",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
+			{
+				APIGroups: []string{
+					"pool.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinepools",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
+			{
+				APIGroups: []string{
+					"checkup.kubevirt.io",
+				},
+				Resources: []string{
+					"storagecheckups",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstancetypes",
+					"virtualmachinepreferences",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineclusterinstancetypes",
+					"virtualmachineclusterpreferences",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 		},
 	}
 }
@@ -208,7 +320,13 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachineinstances/console",
+					"virtualmachineinstances/console/log",
 					"virtualmachineinstances/vnc",
+					"virtualmachineinstances/serial",
+					"virtualmachineinstances/channel",
+					"virtualmachineinstances/pcap",
+					"virtualmachineinstances/portforward",
+					"virtualmachineinstances/backup",
 				},
 				Verbs: []string{
 					"get",
@@ -221,6 +339,18 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachineinstances/pause",
 					"virtualmachineinstances/unpause",
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/memorydump",
+					"virtualmachineinstances/externalsnapshot",
+					"virtualmachineinstances/blockcommit",
+					"virtualmachineinstances/startbackup",
+					"virtualmachineinstances/stopbackup",
+					"virtualmachineinstances/addinterface",
+					"virtualmachineinstances/removeinterface",
+					"virtualmachineinstances/setlinkstate",
+					"virtualmachineinstances/insertmedia",
+					"virtualmachineinstances/ejectmedia",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
 				},
@@ -229,6 +359,29 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"update",
 				},
 			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/sev/fetchcertchain",
+					"virtualmachineinstances/sev/querylaunchmeasurement",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/sev/injectlaunchsecret",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
 			{
 				APIGroups: []string{
 					"subresources.kubevirt.io",
@@ -237,6 +390,8 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachines/restart",
+					"virtualmachines/networkboot",
+					"virtualmachines/removeinterface",
 				},
 				Verbs: []string{
 					"update",
@@ -265,11 +420,80 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"export.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineexports",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"clone.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineclones",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"pool.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinepools",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"checkup.kubevirt.io",
+				},
+				Resources: []string{
+					"storagecheckups",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstancetypes",
+					"virtualmachinepreferences",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineclusterinstancetypes",
+					"virtualmachineclusterpreferences",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -322,6 +546,75 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"export.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineexports",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"clone.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineclones",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"pool.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinepools",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"checkup.kubevirt.io",
+				},
+				Resources: []string{
+					"storagecheckups",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstancetypes",
+					"virtualmachinepreferences",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineclusterinstancetypes",
+					"virtualmachineclusterpreferences",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
@@ -153,6 +153,17 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch", "create", "update", "delete", "patch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"services", "secrets",
+				},
+				Verbs: []string{
+					"get", "create", "delete",
+				},
+			},
 			{
 				APIGroups: []string{
 					"snapshot.kubevirt.io",
@@ -164,6 +175,75 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"*",
 				},
 			},
+			{
+				APIGroups: []string{
+					"export.kubevirt.io",
+				},
+				Resources: []string{
+					"*",
+				},
+				Verbs: []string{
+					"*",
+				},
+			},
+			{
+				APIGroups: []string{
+					"clone.kubevirt.io",
+				},
+				Resources: []string{
+					"*",
+				},
+				Verbs: []string{
+					"*",
+				},
+			},
+			{
+				APIGroups: []string{
+					"pool.kubevirt.io",
+				},
+				Resources: []string{
+					"*",
+				},
+				Verbs: []string{
+					"*",
+				},
+			},
+			{
+				APIGroups: []string{
+					"checkup.kubevirt.io",
+				},
+				Resources: []string{
+					"*",
+				},
+				Verbs: []string{
+					"*",
+				},
+			},
+			{
+				APIGroups: []string{
+					"instancetype.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstancetypes",
+					"virtualmachinepreferences",
+					"virtualmachineclusterinstancetypes",
+					"virtualmachineclusterpreferences",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"apps",
+				},
+				Resources: []string{
+					"controllerrevisions",
+				},
+				Verbs: []string{
+					"create", "get", "list", "watch", "delete",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -182,6 +262,10 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/memorydump",
+					"virtualmachineinstances/externalsnapshot",
 				},
 				Verbs: []string{
 					"get",
@@ -276,6 +360,19 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"scheduling.k8s.io",
+				},
+				Resources: []string{
+					"priorityclasses",
+				},
+				Verbs: []string{
+					"get",
+					"list",
+					"watch",
+				},
+			},
 		},
 	}
 }
@@ -98,6 +98,7 @@ func newHandlerClusterRole() *rbacv1.ClusterRole {
 					"nodes",
 				},
 				Verbs: []string{
+					"get",
 					"patch",
 				},
 			},
@@ -86,7 +86,7 @@ func (c *Console) Run(cmd *cobra.Command, args []string) error {
 	// in -> stdinWriter | stdinReader -> console
 	// out <- stdoutReader | stdoutWriter <- console
 
-	resChan := make(chan error)
+	resChan := make(chan error, 1)
 	stopChan := make(chan struct{}, 1)
 	writeStop := make(chan error)
 	readStop := make(chan error)
@@ -177,6 +177,13 @@ func (c *Console) Run(cmd *cobra.Command, args []string) error {
 
 	terminal.Restore(int(os.Stdin.Fd()), state)
 
+	// Closing the input ends the session of the console, rather than leaving it to be resumed
+	stdinWriter.Close()
+	select {
+	case <-resChan:
+	case <-time.After(time.Second):
+	}
+
 	if err != nil {
 		if e, ok := err.(*websocket.CloseError); ok && e.Code == websocket.CloseAbnormalClosure {
 			fmt.Fprint(os.Stderr, "\nYou were disconnected from the console. This has one of the following reasons:"+
@@ -71,7 +71,7 @@ virtualmachineinstance (vmi), virtualmachine (vm), virtualmachineinstancereplica
 	cmd.Flags().StringVar(&externalIP, "external-ip", "", "Additional external IP address (not managed by the cluster) to accept for the service. If this IP is routed to a node, the service can be accessed by this IP in addition to its generated service IP. Optional.")
 	cmd.Flags().StringVar(&loadBalancerIP, "load-balancer-ip", "", "IP to assign to the Load Balancer. If empty, an ephemeral IP will be created and used.")
 	cmd.Flags().Int32Var(&port, "port", 0, "The port that the service should serve on.")
-	cmd.Flags().StringVar(&strProtocol, "protocol", "TCP", "The network protocol for the service to be created.")
+	cmd.Flags().StringVar(&strProtocol, "protocol", "TCP", "The network protocol for the service to be created: TCP, UDP or SCTP. A comma separated list exposes the port for each of the protocols.")
 	cmd.Flags().StringVar(&strTargetPort, "target-port", "", "Name or number for the port on the VM that the service should direct traffic to. Optional.")
 	cmd.Flags().StringVar(&strServiceType, "type", "ClusterIP", "Type for this service: ClusterIP, NodePort, or LoadBalancer.")
 	cmd.Flags().StringVar(&portName, "port-name", "", "Name of the port. Optional.")
@@ -89,7 +89,10 @@ func usage() string {
   {{ProgramName}} expose vmirs myvmirs --name=vmirs-service
 
   # Expose port 8080 as port 80 from a virtual machine instance replicaset on a service:
-  {{ProgramName}} expose vmirs myvmirs --port=80 --target-port=8080 --name=vmirs-service`
+  {{ProgramName}} expose vmirs myvmirs --port=80 --target-port=8080 --name=vmirs-service
+
+  # Expose DNS over both TCP and UDP from a virtual machine instance:
+  {{ProgramName}} expose vmi myvm --port=53 --protocol=TCP,UDP --name=myvm-dns`
 	return usage
 }
 
@@ -101,7 +104,6 @@ func (o *Command) RunE(cmd *cobra.Command, args []string) error {
 	vmName := args[1]
 
 	// these are used to convert the flag values into service spec values
-	var protocol v1.Protocol
 	var targetPort intstr.IntOrString
 	var serviceType v1.ServiceType
 	var ipFamily v1.IPFamily
@@ -109,14 +111,10 @@ func (o *Command) RunE(cmd *cobra.Command, args []string) error {
 	// convert from integer to the IntOrString type
 	targetPort = intstr.Parse(strTargetPort)
 
-	// convert from string to the protocol enum
-	switch strProtocol {
-	case "TCP":
-		protocol = v1.ProtocolTCP
-	case "UDP":
-		protocol = v1.ProtocolUDP
-	default:
-		return fmt.Errorf("unknown protocol: %s", strProtocol)
+	// convert from the comma separated string to the protocol enums
+	protocols, err := convertProtocols(strProtocol)
+	if err != nil {
+		return err
 	}
 
 	// convert from string to the service type enum
@@ -133,7 +131,7 @@ func (o *Command) RunE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unknown service type: %s", strServiceType)
 	}
 
-	ipFamily, err := convertIPFamily(strIPFamily)
+	ipFamily, err = convertIPFamily(strIPFamily)
 	if err != nil {
 		return err
 	}
@@ -200,7 +198,7 @@ func (o *Command) RunE(cmd *cobra.Command, args []string) error {
 	if port == 0 && len(ports) == 0 {
 		return fmt.Errorf("couldn't find port via --port flag or introspection")
 	} else if port != 0 {
-		ports = []v1.ServicePort{{Name: portName, Protocol: protocol, Port: port, TargetPort: targetPort}}
+		ports = servicePorts(protocols, targetPort)
 	}
 
 	// actually create the service
@@ -259,6 +257,47 @@ func (o *Command) RunE(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func convertProtocols(strProtocols string) ([]v1.Protocol, error) {
+	var protocols []v1.Protocol
+	seen := map[v1.Protocol]bool{}
+	for _, strProtocol := range strings.Split(strProtocols, ",") {
+		var protocol v1.Protocol
+		switch strProtocol {
+		case "TCP":
+			protocol = v1.ProtocolTCP
+		case "UDP":
+			protocol = v1.ProtocolUDP
+		case "SCTP":
+			protocol = v1.ProtocolSCTP
+		default:
+			return nil, fmt.Errorf("unknown protocol: %s", strProtocol)
+		}
+		if seen[protocol] {
+			return nil, fmt.Errorf("duplicate protocol: %s", strProtocol)
+		}
+		seen[protocol] = true
+		protocols = append(protocols, protocol)
+	}
+	return protocols, nil
+}
+
+// servicePorts returns a service port for each of the protocols. Since the ports of a service with several of them
+// must be named, the ports are named after their protocol, prefixed by the port name if given.
+func servicePorts(protocols []v1.Protocol, targetPort intstr.IntOrString) []v1.ServicePort {
+	if len(protocols) == 1 {
+		return []v1.ServicePort{{Name: portName, Protocol: protocols[0], Port: port, TargetPort: targetPort}}
+	}
+	var ports []v1.ServicePort
+	for _, protocol := range protocols {
+		name := strings.ToLower(string(protocol))
+		if portName != "" {
+			name = portName + "-" + name
+		}
+		ports = append(ports, v1.ServicePort{Name: name, Protocol: protocol, Port: port, TargetPort: targetPort})
+	}
+	return ports
+}
+
 func convertIPFamily(strIPFamily string) (v1.IPFamily, error) {
 	switch strings.ToLower(strIPFamily) {
 	case "ipv4":
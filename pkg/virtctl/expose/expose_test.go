@@ -10,6 +10,7 @@ import (
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	fakedynamic "k8s.io/client-go/dynamic/fake"
@@ -187,6 +188,13 @@ var _ = Describe("Expose", func() {
 					Expect(cmd()).NotTo(BeNil())
 				})
 			})
+			Context("With a duplicate protocol", func() {
+				It("should fail", func() {
+					cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vmi", vmName, "--name", "my-service",
+						"--port", "9999", "--protocol", "TCP,TCP")
+					Expect(cmd()).NotTo(BeNil())
+				})
+			})
 			Context("With unknown resource type", func() {
 				It("should fail", func() {
 					cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "kaboom", vmName, "--name", "my-service",
@@ -289,6 +297,28 @@ var _ = Describe("Expose", func() {
 
 				})
 			})
+			Context("With parametrized protocol", func() {
+				It("should succeed with SCTP", func() {
+					cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vmi", vmName, "--name", "my-service",
+						"--port", "2905", "--target-port", "2905", "--protocol", "SCTP", "--port-name", "sigtran")
+					Expect(cmd()).To(Succeed())
+					Expect(obtainedService).ToNot(BeNil())
+					Expect(obtainedService.Spec.Ports).To(ConsistOf(
+						k8sv1.ServicePort{Name: "sigtran", Protocol: k8sv1.ProtocolSCTP, Port: 2905, TargetPort: intstr.FromInt(2905)},
+					))
+				})
+
+				It("should expose the port for each of several protocols", func() {
+					cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vmi", vmName, "--name", "my-service",
+						"--port", "53", "--target-port", "53", "--protocol", "TCP,UDP", "--port-name", "dns")
+					Expect(cmd()).To(Succeed())
+					Expect(obtainedService).ToNot(BeNil())
+					Expect(obtainedService.Spec.Ports).To(ConsistOf(
+						k8sv1.ServicePort{Name: "dns-tcp", Protocol: k8sv1.ProtocolTCP, Port: 53, TargetPort: intstr.FromInt(53)},
+						k8sv1.ServicePort{Name: "dns-udp", Protocol: k8sv1.ProtocolUDP, Port: 53, TargetPort: intstr.FromInt(53)},
+					))
+				})
+			})
 		})
 		Context("with k8s <= 1.19", func() {
 			var obtainedUnstructured *unstructured.Unstructured
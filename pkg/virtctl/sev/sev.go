@@ -0,0 +1,170 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package sev
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_SEV                      = "sev"
+	COMMAND_FETCH_CERT_CHAIN         = "fetch-cert-chain"
+	COMMAND_QUERY_LAUNCH_MEASUREMENT = "query-launch-measurement"
+	COMMAND_INJECT_LAUNCH_SECRET     = "inject-launch-secret"
+
+	headerFlag = "header"
+	secretFlag = "secret"
+)
+
+func NewSEVCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   COMMAND_SEV,
+		Short: "Perform the AMD SEV attestation of a virtual machine instance.",
+		Long: `Drives the SEV attestation handshake of a virtual machine instance:
+fetch the platform certificate chain, query the launch measurement of the guest and inject a launch secret into it.
+A VMI which sets spec.domain.launchSecurity.sev.attestation is started paused and resumed once the secret is injected.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Printf(cmd.UsageString())
+		},
+	}
+	cmd.AddCommand(
+		NewFetchCertChainCommand(clientConfig),
+		NewQueryLaunchMeasurementCommand(clientConfig),
+		NewInjectLaunchSecretCommand(clientConfig),
+	)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewFetchCertChainCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "fetch-cert-chain (VMI)",
+		Short:   "Print the SEV platform Diffie-Hellman key and certificate chain of the node running the VMI.",
+		Example: usage(COMMAND_FETCH_CERT_CHAIN),
+		Args:    templates.ExactArgs(COMMAND_FETCH_CERT_CHAIN, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_FETCH_CERT_CHAIN, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewQueryLaunchMeasurementCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "query-launch-measurement (VMI)",
+		Short:   "Print the SEV launch measurement of the VMI.",
+		Example: usage(COMMAND_QUERY_LAUNCH_MEASUREMENT),
+		Args:    templates.ExactArgs(COMMAND_QUERY_LAUNCH_MEASUREMENT, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_QUERY_LAUNCH_MEASUREMENT, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewInjectLaunchSecretCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := Command{command: COMMAND_INJECT_LAUNCH_SECRET, clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:     "inject-launch-secret (VMI)",
+		Short:   "Inject an encrypted launch secret into the VMI and resume its guest, which waits for it.",
+		Example: usage(COMMAND_INJECT_LAUNCH_SECRET),
+		Args:    templates.ExactArgs(COMMAND_INJECT_LAUNCH_SECRET, 1),
+		RunE:    c.Run,
+	}
+	cmd.Flags().StringVar(&c.secretOptions.Header, headerFlag, "", "Base64 encoded header needed to decrypt the secret.")
+	cmd.Flags().StringVar(&c.secretOptions.Secret, secretFlag, "", "Base64 encoded encrypted launch secret.")
+	cmd.MarkFlagRequired(headerFlag)
+	cmd.MarkFlagRequired(secretFlag)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage(cmd string) string {
+	switch cmd {
+	case COMMAND_INJECT_LAUNCH_SECRET:
+		usage := "  # Inject a launch secret into the virtual machine instance 'myvmi':\n"
+		usage += fmt.Sprintf("  {{ProgramName}} %s %s myvmi --%s <header> --%s <secret>", COMMAND_SEV, cmd, headerFlag, secretFlag)
+		return usage
+	default:
+		usage := fmt.Sprintf("  # Run %s on the virtual machine instance 'myvmi':\n", cmd)
+		usage += fmt.Sprintf("  {{ProgramName}} %s %s myvmi", COMMAND_SEV, cmd)
+		return usage
+	}
+}
+
+type Command struct {
+	clientConfig  clientcmd.ClientConfig
+	command       string
+	secretOptions v1.SEVSecretOptions
+}
+
+func (o *Command) Run(cmd *cobra.Command, args []string) error {
+	vmiName := args[0]
+
+	namespace, _, err := o.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(o.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	var result interface{}
+	switch o.command {
+	case COMMAND_FETCH_CERT_CHAIN:
+		result, err = virtClient.VirtualMachineInstance(namespace).SEVFetchCertChain(vmiName)
+		if err != nil {
+			return fmt.Errorf("Error fetching SEV certificate chain of VirtualMachineInstance %s: %v", vmiName, err)
+		}
+	case COMMAND_QUERY_LAUNCH_MEASUREMENT:
+		result, err = virtClient.VirtualMachineInstance(namespace).SEVQueryLaunchMeasurement(vmiName)
+		if err != nil {
+			return fmt.Errorf("Error querying SEV launch measurement of VirtualMachineInstance %s: %v", vmiName, err)
+		}
+	case COMMAND_INJECT_LAUNCH_SECRET:
+		err = virtClient.VirtualMachineInstance(namespace).SEVInjectLaunchSecret(vmiName, &o.secretOptions)
+		if err != nil {
+			return fmt.Errorf("Error injecting SEV launch secret into VirtualMachineInstance %s: %v", vmiName, err)
+		}
+		fmt.Printf("Launch secret was injected into VMI %s\n", vmiName)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Cannot marshal %s result: %v", o.command, err)
+	}
+	fmt.Printf("%s\n", string(data))
+	return nil
+}
@@ -0,0 +1,90 @@
+package sev_test
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/sev"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("SEV", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("With missing input parameters", func() {
+		table := []string{
+			sev.COMMAND_FETCH_CERT_CHAIN,
+			sev.COMMAND_QUERY_LAUNCH_MEASUREMENT,
+			sev.COMMAND_INJECT_LAUNCH_SECRET,
+		}
+		for _, command := range table {
+			command := command
+			It(fmt.Sprintf("%s should fail without the VMI name", command), func() {
+				cmd := tests.NewRepeatableVirtctlCommand(sev.COMMAND_SEV, command)
+				Expect(cmd()).NotTo(Succeed())
+			})
+		}
+
+		It("inject-launch-secret should fail without the secret", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(sev.COMMAND_SEV, sev.COMMAND_INJECT_LAUNCH_SECRET, vmiName, "--header", "aGVhZGVy")
+			Expect(cmd()).NotTo(Succeed())
+		})
+	})
+
+	It("should fetch the certificate chain", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().SEVFetchCertChain(vmiName).Return(v1.SEVPlatformInfo{PDH: "pdh", CertChain: "certChain"}, nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand(sev.COMMAND_SEV, sev.COMMAND_FETCH_CERT_CHAIN, vmiName)
+		Expect(cmd.Execute()).To(Succeed())
+	})
+
+	It("should query the launch measurement", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().SEVQueryLaunchMeasurement(vmiName).Return(v1.SEVMeasurementInfo{Measurement: "measurement"}, nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand(sev.COMMAND_SEV, sev.COMMAND_QUERY_LAUNCH_MEASUREMENT, vmiName)
+		Expect(cmd.Execute()).To(Succeed())
+	})
+
+	It("should inject the launch secret", func() {
+		options := &v1.SEVSecretOptions{
+			Header: "aGVhZGVy",
+			Secret: "c2VjcmV0",
+		}
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().SEVInjectLaunchSecret(vmiName, options).Return(nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand(sev.COMMAND_SEV, sev.COMMAND_INJECT_LAUNCH_SECRET, vmiName, "--header", options.Header, "--secret", options.Secret)
+		Expect(cmd.Execute()).To(Succeed())
+	})
+
+	It("should return an error when the subresource call fails", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().SEVFetchCertChain(vmiName).Return(v1.SEVPlatformInfo{}, fmt.Errorf("error")).Times(1)
+
+		cmd := tests.NewVirtctlCommand(sev.COMMAND_SEV, sev.COMMAND_FETCH_CERT_CHAIN, vmiName)
+		Expect(cmd.Execute()).NotTo(Succeed())
+	})
+})
@@ -45,6 +45,7 @@ const (
 )
 
 var (
+	networkBoot  bool
 	forceRestart bool
 	gracePeriod  int = -1
 )
@@ -60,6 +61,7 @@ func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 			return c.Run(cmd, args)
 		},
 	}
+	cmd.Flags().BoolVar(&networkBoot, "network-boot", false, "--network-boot=false: If true, the virtual machine boots from the network first on this start.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -204,6 +206,12 @@ func (o *Command) Run(cmd *cobra.Command, args []string) error {
 
 	switch o.command {
 	case COMMAND_START:
+		if networkBoot {
+			err = virtClient.VirtualMachine(namespace).NetworkBoot(vmiName)
+			if err != nil {
+				return fmt.Errorf("Error requesting network boot of VirtualMachine %v", err)
+			}
+		}
 		err = virtClient.VirtualMachine(namespace).Start(vmiName)
 		if err != nil {
 			return fmt.Errorf("Error starting VirtualMachine %v", err)
@@ -80,6 +80,20 @@ var _ = Describe("VirtualMachine", func() {
 			Expect(cmd.Execute()).To(BeNil())
 		})
 
+		It("with spec:running:true and a network boot request", func() {
+			vm := kubecli.NewMinimalVM(vmName)
+			vm.Spec.Running = &notRunning
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(2)
+			gomock.InOrder(
+				vmInterface.EXPECT().NetworkBoot(vm.Name).Return(nil).Times(1),
+				vmInterface.EXPECT().Start(vm.Name).Return(nil).Times(1),
+			)
+
+			cmd := tests.NewVirtctlCommand("start", vmName, "--network-boot")
+			Expect(cmd.Execute()).To(BeNil())
+		})
+
 		It("with spec:running:false", func() {
 			vm := kubecli.NewMinimalVM(vmName)
 			vm.Spec.Running = &running
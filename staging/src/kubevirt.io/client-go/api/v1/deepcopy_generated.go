@@ -69,6 +69,22 @@ func (in *AccessCredentialSecretSource) DeepCopy() *AccessCredentialSecretSource
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddInterfaceOptions) DeepCopyInto(out *AddInterfaceOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddInterfaceOptions.
+func (in *AddInterfaceOptions) DeepCopy() *AddInterfaceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(AddInterfaceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddVolumeOptions) DeepCopyInto(out *AddVolumeOptions) {
 	*out = *in
@@ -95,6 +111,63 @@ func (in *AddVolumeOptions) DeepCopy() *AddVolumeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchConfiguration) DeepCopyInto(out *ArchConfiguration) {
+	*out = *in
+	if in.Amd64 != nil {
+		in, out := &in.Amd64, &out.Amd64
+		*out = new(ArchSpecificConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Arm64 != nil {
+		in, out := &in.Arm64, &out.Arm64
+		*out = new(ArchSpecificConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ppc64le != nil {
+		in, out := &in.Ppc64le, &out.Ppc64le
+		*out = new(ArchSpecificConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S390x != nil {
+		in, out := &in.S390x, &out.S390x
+		*out = new(ArchSpecificConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchConfiguration.
+func (in *ArchConfiguration) DeepCopy() *ArchConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchSpecificConfiguration) DeepCopyInto(out *ArchSpecificConfiguration) {
+	*out = *in
+	if in.EmulatedMachines != nil {
+		in, out := &in.EmulatedMachines, &out.EmulatedMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchSpecificConfiguration.
+func (in *ArchSpecificConfiguration) DeepCopy() *ArchSpecificConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchSpecificConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthorizedKeysFile) DeepCopyInto(out *AuthorizedKeysFile) {
 	*out = *in
@@ -132,6 +205,75 @@ func (in *BIOS) DeepCopy() *BIOS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupOptions) DeepCopyInto(out *BackupOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupOptions.
+func (in *BackupOptions) DeepCopy() *BackupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandwidthLimit) DeepCopyInto(out *BandwidthLimit) {
+	*out = *in
+	out.Average = in.Average.DeepCopy()
+	if in.Peak != nil {
+		in, out := &in.Peak, &out.Peak
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthLimit.
+func (in *BandwidthLimit) DeepCopy() *BandwidthLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(BandwidthLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockCommitOptions) DeepCopyInto(out *BlockCommitOptions) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActiveFiles != nil {
+		in, out := &in.ActiveFiles, &out.ActiveFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockCommitOptions.
+func (in *BlockCommitOptions) DeepCopy() *BlockCommitOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockCommitOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bootloader) DeepCopyInto(out *Bootloader) {
 	*out = *in
@@ -187,6 +329,16 @@ func (in *CPU) DeepCopyInto(out *CPU) {
 		*out = make([]CPUFeature, len(*in))
 		copy(*out, *in)
 	}
+	if in.NUMA != nil {
+		in, out := &in.NUMA, &out.NUMA
+		*out = new(NUMA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Realtime != nil {
+		in, out := &in.Realtime, &out.Realtime
+		*out = new(Realtime)
+		**out = **in
+	}
 	return
 }
 
@@ -216,6 +368,38 @@ func (in *CPUFeature) DeepCopy() *CPUFeature {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUTopology) DeepCopyInto(out *CPUTopology) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUTopology.
+func (in *CPUTopology) DeepCopy() *CPUTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Channel) DeepCopyInto(out *Channel) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Channel.
+func (in *Channel) DeepCopy() *Channel {
+	if in == nil {
+		return nil
+	}
+	out := new(Channel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Chassis) DeepCopyInto(out *Chassis) {
 	*out = *in
@@ -353,6 +537,29 @@ func (in *CloudInitNoCloudSource) DeepCopy() *CloudInitNoCloudSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCommonCPUConfiguration) DeepCopyInto(out *ClusterCommonCPUConfiguration) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCommonCPUConfiguration.
+func (in *ClusterCommonCPUConfiguration) DeepCopy() *ClusterCommonCPUConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCommonCPUConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentConfig) DeepCopyInto(out *ComponentConfig) {
 	*out = *in
@@ -412,6 +619,64 @@ func (in *ConfigMapVolumeSource) DeepCopy() *ConfigMapVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskCacheConfiguration) DeepCopyInto(out *ContainerDiskCacheConfiguration) {
+	*out = *in
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskCacheConfiguration.
+func (in *ContainerDiskCacheConfiguration) DeepCopy() *ContainerDiskCacheConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskCacheConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskConfiguration) DeepCopyInto(out *ContainerDiskConfiguration) {
+	*out = *in
+	if in.SignatureKeys != nil {
+		in, out := &in.SignatureKeys, &out.SignatureKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskConfiguration.
+func (in *ContainerDiskConfiguration) DeepCopy() *ContainerDiskConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskInfo) DeepCopyInto(out *ContainerDiskInfo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskInfo.
+func (in *ContainerDiskInfo) DeepCopy() *ContainerDiskInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerDiskSource) DeepCopyInto(out *ContainerDiskSource) {
 	*out = *in
@@ -478,6 +743,16 @@ func (in *DHCPOptions) DeepCopyInto(out *DHCPOptions) {
 		*out = make([]DHCPPrivateOptions, len(*in))
 		copy(*out, *in)
 	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(uint16)
+		**out = **in
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]DHCPRoute, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -507,6 +782,22 @@ func (in *DHCPPrivateOptions) DeepCopy() *DHCPPrivateOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPRoute) DeepCopyInto(out *DHCPRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPRoute.
+func (in *DHCPRoute) DeepCopy() *DHCPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 	*out = *in
@@ -648,6 +939,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FreePageReporting != nil {
+		in, out := &in.FreePageReporting, &out.FreePageReporting
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Rng != nil {
 		in, out := &in.Rng, &out.Rng
 		*out = new(Rng)
@@ -678,6 +974,53 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 	if in.HostDevices != nil {
 		in, out := &in.HostDevices, &out.HostDevices
 		*out = make([]HostDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TPM != nil {
+		in, out := &in.TPM, &out.TPM
+		*out = new(TPMDevice)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoattachVSOCK != nil {
+		in, out := &in.AutoattachVSOCK, &out.AutoattachVSOCK
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutoattachPanicDevice != nil {
+		in, out := &in.AutoattachPanicDevice, &out.AutoattachPanicDevice
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PanicMemoryDump != nil {
+		in, out := &in.PanicMemoryDump, &out.PanicMemoryDump
+		*out = new(PanicMemoryDump)
+		**out = **in
+	}
+	if in.MemoryDump != nil {
+		in, out := &in.MemoryDump, &out.MemoryDump
+		*out = new(MemoryDump)
+		**out = **in
+	}
+	if in.LogSerialConsole != nil {
+		in, out := &in.LogSerialConsole, &out.LogSerialConsole
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SerialConsoleLog != nil {
+		in, out := &in.SerialConsoleLog, &out.SerialConsoleLog
+		*out = new(SerialConsoleLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Serials != nil {
+		in, out := &in.Serials, &out.Serials
+		*out = make([]SerialPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]Channel, len(*in))
 		copy(*out, *in)
 	}
 	return
@@ -693,6 +1036,22 @@ func (in *Devices) DeepCopy() *Devices {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Diag288Watchdog) DeepCopyInto(out *Diag288Watchdog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Diag288Watchdog.
+func (in *Diag288Watchdog) DeepCopy() *Diag288Watchdog {
+	if in == nil {
+		return nil
+	}
+	out := new(Diag288Watchdog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Disk) DeepCopyInto(out *Disk) {
 	*out = *in
@@ -707,6 +1066,26 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(DiskIOTune)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeFromSnapshot != nil {
+		in, out := &in.ExcludeFromSnapshot, &out.ExcludeFromSnapshot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(DiskEncryption)
+		**out = **in
+	}
+	if in.ExpandGuestFilesystem != nil {
+		in, out := &in.ExpandGuestFilesystem, &out.ExpandGuestFilesystem
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -757,17 +1136,96 @@ func (in *DiskDevice) DeepCopy() *DiskDevice {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DiskTarget) DeepCopyInto(out *DiskTarget) {
+func (in *DiskEncryption) DeepCopyInto(out *DiskEncryption) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskTarget.
-func (in *DiskTarget) DeepCopy() *DiskTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskEncryption.
+func (in *DiskEncryption) DeepCopy() *DiskEncryption {
 	if in == nil {
 		return nil
 	}
-	out := new(DiskTarget)
+	out := new(DiskEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskIOLimits) DeepCopyInto(out *DiskIOLimits) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskIOLimits.
+func (in *DiskIOLimits) DeepCopy() *DiskIOLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskIOLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskIOThreads) DeepCopyInto(out *DiskIOThreads) {
+	*out = *in
+	if in.SharedPoolSize != nil {
+		in, out := &in.SharedPoolSize, &out.SharedPoolSize
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskIOThreads.
+func (in *DiskIOThreads) DeepCopy() *DiskIOThreads {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskIOThreads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskIOTune) DeepCopyInto(out *DiskIOTune) {
+	*out = *in
+	if in.IOPS != nil {
+		in, out := &in.IOPS, &out.IOPS
+		*out = new(DiskIOLimits)
+		**out = **in
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(DiskIOLimits)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskIOTune.
+func (in *DiskIOTune) DeepCopy() *DiskIOTune {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskIOTune)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskTarget) DeepCopyInto(out *DiskTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskTarget.
+func (in *DiskTarget) DeepCopy() *DiskTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskTarget)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -808,11 +1266,21 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 		*out = new(IOThreadsPolicy)
 		**out = **in
 	}
+	if in.IOThreads != nil {
+		in, out := &in.IOThreads, &out.IOThreads
+		*out = new(DiskIOThreads)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Chassis != nil {
 		in, out := &in.Chassis, &out.Chassis
 		*out = new(Chassis)
 		**out = **in
 	}
+	if in.LaunchSecurity != nil {
+		in, out := &in.LaunchSecurity, &out.LaunchSecurity
+		*out = new(LaunchSecurity)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -849,6 +1317,22 @@ func (in *DownwardAPIVolumeSource) DeepCopy() *DownwardAPIVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownwardMetricsVolumeSource) DeepCopyInto(out *DownwardMetricsVolumeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownwardMetricsVolumeSource.
+func (in *DownwardMetricsVolumeSource) DeepCopy() *DownwardMetricsVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DownwardMetricsVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EFI) DeepCopyInto(out *EFI) {
 	*out = *in
@@ -857,6 +1341,16 @@ func (in *EFI) DeepCopyInto(out *EFI) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SecureBootKeys != nil {
+		in, out := &in.SecureBootKeys, &out.SecureBootKeys
+		*out = new(SecureBootKeys)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistent != nil {
+		in, out := &in.Persistent, &out.Persistent
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -870,6 +1364,22 @@ func (in *EFI) DeepCopy() *EFI {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EjectMediaOptions) DeepCopyInto(out *EjectMediaOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EjectMediaOptions.
+func (in *EjectMediaOptions) DeepCopy() *EjectMediaOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EjectMediaOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmptyDiskSource) DeepCopyInto(out *EmptyDiskSource) {
 	*out = *in
@@ -887,6 +1397,55 @@ func (in *EmptyDiskSource) DeepCopy() *EmptyDiskSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralDiskBacking) DeepCopyInto(out *EphemeralDiskBacking) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tmpfs != nil {
+		in, out := &in.Tmpfs, &out.Tmpfs
+		*out = new(EphemeralDiskTmpfs)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralDiskBacking.
+func (in *EphemeralDiskBacking) DeepCopy() *EphemeralDiskBacking {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralDiskBacking)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralDiskTmpfs) DeepCopyInto(out *EphemeralDiskTmpfs) {
+	*out = *in
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralDiskTmpfs.
+func (in *EphemeralDiskTmpfs) DeepCopy() *EphemeralDiskTmpfs {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralDiskTmpfs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EphemeralVolumeSource) DeepCopyInto(out *EphemeralVolumeSource) {
 	*out = *in
@@ -908,6 +1467,48 @@ func (in *EphemeralVolumeSource) DeepCopy() *EphemeralVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSnapshotOptions) DeepCopyInto(out *ExternalSnapshotOptions) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSnapshotOptions.
+func (in *ExternalSnapshotOptions) DeepCopy() *ExternalSnapshotOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSnapshotOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSnapshotVolumeStatus) DeepCopyInto(out *ExternalSnapshotVolumeStatus) {
+	*out = *in
+	if in.BackingChain != nil {
+		in, out := &in.BackingChain, &out.BackingChain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSnapshotVolumeStatus.
+func (in *ExternalSnapshotVolumeStatus) DeepCopy() *ExternalSnapshotVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSnapshotVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureAPIC) DeepCopyInto(out *FeatureAPIC) {
 	*out = *in
@@ -1147,7 +1748,7 @@ func (in *Filesystem) DeepCopyInto(out *Filesystem) {
 	if in.Virtiofs != nil {
 		in, out := &in.Virtiofs, &out.Virtiofs
 		*out = new(FilesystemVirtiofs)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -1162,9 +1763,35 @@ func (in *Filesystem) DeepCopy() *Filesystem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemIDMap) DeepCopyInto(out *FilesystemIDMap) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemIDMap.
+func (in *FilesystemIDMap) DeepCopy() *FilesystemIDMap {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemIDMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilesystemVirtiofs) DeepCopyInto(out *FilesystemVirtiofs) {
 	*out = *in
+	if in.UIDMap != nil {
+		in, out := &in.UIDMap, &out.UIDMap
+		*out = new(FilesystemIDMap)
+		**out = **in
+	}
+	if in.GIDMap != nil {
+		in, out := &in.GIDMap, &out.GIDMap
+		*out = new(FilesystemIDMap)
+		**out = **in
+	}
 	return
 }
 
@@ -1178,6 +1805,22 @@ func (in *FilesystemVirtiofs) DeepCopy() *FilesystemVirtiofs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Firmware) DeepCopyInto(out *Firmware) {
 	*out = *in
@@ -1215,6 +1858,27 @@ func (in *FloppyTarget) DeepCopy() *FloppyTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeUnfreezeTimeout) DeepCopyInto(out *FreezeUnfreezeTimeout) {
+	*out = *in
+	if in.UnfreezeTimeout != nil {
+		in, out := &in.UnfreezeTimeout, &out.UnfreezeTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeUnfreezeTimeout.
+func (in *FreezeUnfreezeTimeout) DeepCopy() *FreezeUnfreezeTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeUnfreezeTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPU) DeepCopyInto(out *GPU) {
 	*out = *in
@@ -1231,6 +1895,22 @@ func (in *GPU) DeepCopy() *GPU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestAgentPing) DeepCopyInto(out *GuestAgentPing) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestAgentPing.
+func (in *GuestAgentPing) DeepCopy() *GuestAgentPing {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestAgentPing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPETTimer) DeepCopyInto(out *HPETTimer) {
 	*out = *in
@@ -1265,6 +1945,16 @@ func (in *Handler) DeepCopyInto(out *Handler) {
 		*out = new(corev1.TCPSocketAction)
 		**out = **in
 	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(corev1.ExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestAgentPing != nil {
+		in, out := &in.GuestAgentPing, &out.GuestAgentPing
+		*out = new(GuestAgentPing)
+		**out = **in
+	}
 	return
 }
 
@@ -1281,6 +1971,11 @@ func (in *Handler) DeepCopy() *Handler {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostDevice) DeepCopyInto(out *HostDevice) {
 	*out = *in
+	if in.NVMe != nil {
+		in, out := &in.NVMe, &out.NVMe
+		*out = new(HostDeviceNVMe)
+		**out = **in
+	}
 	return
 }
 
@@ -1294,6 +1989,22 @@ func (in *HostDevice) DeepCopy() *HostDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostDeviceNVMe) DeepCopyInto(out *HostDeviceNVMe) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostDeviceNVMe.
+func (in *HostDeviceNVMe) DeepCopy() *HostDeviceNVMe {
+	if in == nil {
+		return nil
+	}
+	out := new(HostDeviceNVMe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostDisk) DeepCopyInto(out *HostDisk) {
 	*out = *in
@@ -1411,6 +2122,32 @@ func (in *I6300ESBWatchdog) DeepCopy() *I6300ESBWatchdog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnitionSource) DeepCopyInto(out *IgnitionSource) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionSource.
+func (in *IgnitionSource) DeepCopy() *IgnitionSource {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnitionSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -1428,10 +2165,47 @@ func (in *Input) DeepCopy() *Input {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Interface) DeepCopyInto(out *Interface) {
+func (in *InsertMediaOptions) DeepCopyInto(out *InsertMediaOptions) {
 	*out = *in
-	in.InterfaceBindingMethod.DeepCopyInto(&out.InterfaceBindingMethod)
-	if in.Ports != nil {
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(HotplugVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InsertMediaOptions.
+func (in *InsertMediaOptions) DeepCopy() *InsertMediaOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InsertMediaOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancetypeMatcher) DeepCopyInto(out *InstancetypeMatcher) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancetypeMatcher.
+func (in *InstancetypeMatcher) DeepCopy() *InstancetypeMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancetypeMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Interface) DeepCopyInto(out *Interface) {
+	*out = *in
+	in.InterfaceBindingMethod.DeepCopyInto(&out.InterfaceBindingMethod)
+	if in.Ports != nil {
 		in, out := &in.Ports, &out.Ports
 		*out = make([]Port, len(*in))
 		copy(*out, *in)
@@ -1446,6 +2220,31 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 		*out = new(DHCPOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(PluginBinding)
+		**out = **in
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(InterfaceBandwidth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RSS != nil {
+		in, out := &in.RSS, &out.RSS
+		*out = new(InterfaceRSS)
+		**out = **in
+	}
+	if in.Offloads != nil {
+		in, out := &in.Offloads, &out.Offloads
+		*out = new(InterfaceOffloads)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(InterfaceFirewall)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1459,6 +2258,32 @@ func (in *Interface) DeepCopy() *Interface {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceBandwidth) DeepCopyInto(out *InterfaceBandwidth) {
+	*out = *in
+	if in.Inbound != nil {
+		in, out := &in.Inbound, &out.Inbound
+		*out = new(BandwidthLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outbound != nil {
+		in, out := &in.Outbound, &out.Outbound
+		*out = new(BandwidthLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceBandwidth.
+func (in *InterfaceBandwidth) DeepCopy() *InterfaceBandwidth {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceBandwidth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 	*out = *in
@@ -1487,6 +2312,21 @@ func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 		*out = new(InterfaceMacvtap)
 		**out = **in
 	}
+	if in.Passt != nil {
+		in, out := &in.Passt, &out.Passt
+		*out = new(InterfacePasst)
+		**out = **in
+	}
+	if in.Vdpa != nil {
+		in, out := &in.Vdpa, &out.Vdpa
+		*out = new(InterfaceVdpa)
+		**out = **in
+	}
+	if in.VhostUser != nil {
+		in, out := &in.VhostUser, &out.VhostUser
+		*out = new(InterfaceVhostUser)
+		**out = **in
+	}
 	return
 }
 
@@ -1500,6 +2340,22 @@ func (in *InterfaceBindingMethod) DeepCopy() *InterfaceBindingMethod {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceBindingPlugin) DeepCopyInto(out *InterfaceBindingPlugin) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceBindingPlugin.
+func (in *InterfaceBindingPlugin) DeepCopy() *InterfaceBindingPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceBindingPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceBridge) DeepCopyInto(out *InterfaceBridge) {
 	*out = *in
@@ -1516,6 +2372,27 @@ func (in *InterfaceBridge) DeepCopy() *InterfaceBridge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceFirewall) DeepCopyInto(out *InterfaceFirewall) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FirewallRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceFirewall.
+func (in *InterfaceFirewall) DeepCopy() *InterfaceFirewall {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceFirewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceMacvtap) DeepCopyInto(out *InterfaceMacvtap) {
 	*out = *in
@@ -1548,6 +2425,69 @@ func (in *InterfaceMasquerade) DeepCopy() *InterfaceMasquerade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceOffloads) DeepCopyInto(out *InterfaceOffloads) {
+	*out = *in
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TSO != nil {
+		in, out := &in.TSO, &out.TSO
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GSO != nil {
+		in, out := &in.GSO, &out.GSO
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceOffloads.
+func (in *InterfaceOffloads) DeepCopy() *InterfaceOffloads {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceOffloads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfacePasst) DeepCopyInto(out *InterfacePasst) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfacePasst.
+func (in *InterfacePasst) DeepCopy() *InterfacePasst {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfacePasst)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceRSS) DeepCopyInto(out *InterfaceRSS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceRSS.
+func (in *InterfaceRSS) DeepCopy() *InterfaceRSS {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceRSS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceSRIOV) DeepCopyInto(out *InterfaceSRIOV) {
 	*out = *in
@@ -1580,6 +2520,59 @@ func (in *InterfaceSlirp) DeepCopy() *InterfaceSlirp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceVdpa) DeepCopyInto(out *InterfaceVdpa) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceVdpa.
+func (in *InterfaceVdpa) DeepCopy() *InterfaceVdpa {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceVdpa)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceVhostUser) DeepCopyInto(out *InterfaceVhostUser) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceVhostUser.
+func (in *InterfaceVhostUser) DeepCopy() *InterfaceVhostUser {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceVhostUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KSMConfiguration) DeepCopyInto(out *KSMConfiguration) {
+	*out = *in
+	if in.NodeLabelSelector != nil {
+		in, out := &in.NodeLabelSelector, &out.NodeLabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KSMConfiguration.
+func (in *KSMConfiguration) DeepCopy() *KSMConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KSMConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KVMTimer) DeepCopyInto(out *KVMTimer) {
 	*out = *in
@@ -1716,6 +2709,48 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(PermittedHostDevices)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClusterCommonCPU != nil {
+		in, out := &in.ClusterCommonCPU, &out.ClusterCommonCPU
+		*out = new(ClusterCommonCPUConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KSMConfiguration != nil {
+		in, out := &in.KSMConfiguration, &out.KSMConfiguration
+		*out = new(KSMConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryOvercommitPolicy != nil {
+		in, out := &in.MemoryOvercommitPolicy, &out.MemoryOvercommitPolicy
+		*out = new(MemoryOvercommitPolicy)
+		**out = **in
+	}
+	if in.MediatedDevicesConfiguration != nil {
+		in, out := &in.MediatedDevicesConfiguration, &out.MediatedDevicesConfiguration
+		*out = new(MediatedDevicesConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArchitectureConfiguration != nil {
+		in, out := &in.ArchitectureConfiguration, &out.ArchitectureConfiguration
+		*out = new(ArchConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerDiskCache != nil {
+		in, out := &in.ContainerDiskCache, &out.ContainerDiskCache
+		*out = new(ContainerDiskCacheConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EphemeralDiskBackings != nil {
+		in, out := &in.EphemeralDiskBackings, &out.EphemeralDiskBackings
+		*out = make([]EphemeralDiskBacking, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContainerDisks != nil {
+		in, out := &in.ContainerDisks, &out.ContainerDisks
+		*out = new(ContainerDiskConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1882,6 +2917,37 @@ func (in *KubeVirtWorkloadUpdateStrategy) DeepCopy() *KubeVirtWorkloadUpdateStra
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchSecurity) DeepCopyInto(out *LaunchSecurity) {
+	*out = *in
+	if in.SEV != nil {
+		in, out := &in.SEV, &out.SEV
+		*out = new(SEV)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SNP != nil {
+		in, out := &in.SNP, &out.SNP
+		*out = new(SEVSNP)
+		**out = **in
+	}
+	if in.TDX != nil {
+		in, out := &in.TDX, &out.TDX
+		*out = new(TDX)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchSecurity.
+func (in *LaunchSecurity) DeepCopy() *LaunchSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogVerbosity) DeepCopyInto(out *LogVerbosity) {
 	*out = *in
@@ -1921,6 +2987,22 @@ func (in *LunTarget) DeepCopy() *LunTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MacPoolConfiguration) DeepCopyInto(out *MacPoolConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MacPoolConfiguration.
+func (in *MacPoolConfiguration) DeepCopy() *MacPoolConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MacPoolConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Machine) DeepCopyInto(out *Machine) {
 	*out = *in
@@ -1937,6 +3019,34 @@ func (in *Machine) DeepCopy() *Machine {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MediatedDevicesConfiguration) DeepCopyInto(out *MediatedDevicesConfiguration) {
+	*out = *in
+	if in.MediatedDevicesTypes != nil {
+		in, out := &in.MediatedDevicesTypes, &out.MediatedDevicesTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeMediatedDeviceTypes != nil {
+		in, out := &in.NodeMediatedDeviceTypes, &out.NodeMediatedDeviceTypes
+		*out = make([]NodeMediatedDeviceTypesConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MediatedDevicesConfiguration.
+func (in *MediatedDevicesConfiguration) DeepCopy() *MediatedDevicesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MediatedDevicesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MediatedHostDevice) DeepCopyInto(out *MediatedHostDevice) {
 	*out = *in
@@ -1966,6 +3076,11 @@ func (in *Memory) DeepCopyInto(out *Memory) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.MaxGuest != nil {
+		in, out := &in.MaxGuest, &out.MaxGuest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
@@ -1980,15 +3095,110 @@ func (in *Memory) DeepCopy() *Memory {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
+func (in *MemoryDump) DeepCopyInto(out *MemoryDump) {
 	*out = *in
-	if in.NodeDrainTaintKey != nil {
-		in, out := &in.NodeDrainTaintKey, &out.NodeDrainTaintKey
-		*out = new(string)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryDump.
+func (in *MemoryDump) DeepCopy() *MemoryDump {
+	if in == nil {
+		return nil
 	}
-	if in.ParallelOutboundMigrationsPerNode != nil {
-		in, out := &in.ParallelOutboundMigrationsPerNode, &out.ParallelOutboundMigrationsPerNode
+	out := new(MemoryDump)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryDumpOptions) DeepCopyInto(out *MemoryDumpOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryDumpOptions.
+func (in *MemoryDumpOptions) DeepCopy() *MemoryDumpOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryDumpOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryOvercommitPolicy) DeepCopyInto(out *MemoryOvercommitPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryOvercommitPolicy.
+func (in *MemoryOvercommitPolicy) DeepCopy() *MemoryOvercommitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryOvercommitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryStatus) DeepCopyInto(out *MemoryStatus) {
+	*out = *in
+	if in.GuestAtBoot != nil {
+		in, out := &in.GuestAtBoot, &out.GuestAtBoot
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.GuestCurrent != nil {
+		in, out := &in.GuestCurrent, &out.GuestCurrent
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.GuestRequested != nil {
+		in, out := &in.GuestRequested, &out.GuestRequested
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryStatus.
+func (in *MemoryStatus) DeepCopy() *MemoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigratedVolume) DeepCopyInto(out *MigratedVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigratedVolume.
+func (in *MigratedVolume) DeepCopy() *MigratedVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(MigratedVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
+	*out = *in
+	if in.NodeDrainTaintKey != nil {
+		in, out := &in.NodeDrainTaintKey, &out.NodeDrainTaintKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.ParallelOutboundMigrationsPerNode != nil {
+		in, out := &in.ParallelOutboundMigrationsPerNode, &out.ParallelOutboundMigrationsPerNode
 		*out = new(uint32)
 		**out = **in
 	}
@@ -2027,6 +3237,11 @@ func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -2056,6 +3271,43 @@ func (in *MultusNetwork) DeepCopy() *MultusNetwork {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMA) DeepCopyInto(out *NUMA) {
+	*out = *in
+	if in.GuestMappingPassthrough != nil {
+		in, out := &in.GuestMappingPassthrough, &out.GuestMappingPassthrough
+		*out = new(NUMAGuestMappingPassthrough)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMA.
+func (in *NUMA) DeepCopy() *NUMA {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMAGuestMappingPassthrough) DeepCopyInto(out *NUMAGuestMappingPassthrough) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMAGuestMappingPassthrough.
+func (in *NUMAGuestMappingPassthrough) DeepCopy() *NUMAGuestMappingPassthrough {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMAGuestMappingPassthrough)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -2073,6 +3325,55 @@ func (in *Network) DeepCopy() *Network {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAttachmentPolicy) DeepCopyInto(out *NetworkAttachmentPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]NetworkAttachmentPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAttachmentPolicy.
+func (in *NetworkAttachmentPolicy) DeepCopy() *NetworkAttachmentPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAttachmentPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAttachmentPolicyRule) DeepCopyInto(out *NetworkAttachmentPolicyRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkAttachmentDefinitions != nil {
+		in, out := &in.NetworkAttachmentDefinitions, &out.NetworkAttachmentDefinitions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAttachmentPolicyRule.
+func (in *NetworkAttachmentPolicyRule) DeepCopy() *NetworkAttachmentPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAttachmentPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 	*out = *in
@@ -2086,6 +3387,23 @@ func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = make(map[string]InterfaceBindingPlugin, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MacPool != nil {
+		in, out := &in.MacPool, &out.MacPool
+		*out = new(MacPoolConfiguration)
+		**out = **in
+	}
+	if in.AttachmentPolicy != nil {
+		in, out := &in.AttachmentPolicy, &out.AttachmentPolicy
+		*out = new(NetworkAttachmentPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2125,6 +3443,50 @@ func (in *NetworkSource) DeepCopy() *NetworkSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NoCloudSSHPublicKeyAccessCredentialPropagation) DeepCopyInto(out *NoCloudSSHPublicKeyAccessCredentialPropagation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoCloudSSHPublicKeyAccessCredentialPropagation.
+func (in *NoCloudSSHPublicKeyAccessCredentialPropagation) DeepCopy() *NoCloudSSHPublicKeyAccessCredentialPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(NoCloudSSHPublicKeyAccessCredentialPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMediatedDeviceTypesConfig) DeepCopyInto(out *NodeMediatedDeviceTypesConfig) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MediatedDevicesTypes != nil {
+		in, out := &in.MediatedDevicesTypes, &out.MediatedDevicesTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMediatedDeviceTypesConfig.
+func (in *NodeMediatedDeviceTypesConfig) DeepCopy() *NodeMediatedDeviceTypesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMediatedDeviceTypesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
 	*out = *in
@@ -2181,6 +3543,22 @@ func (in *PITTimer) DeepCopy() *PITTimer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PanicMemoryDump) DeepCopyInto(out *PanicMemoryDump) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PanicMemoryDump.
+func (in *PanicMemoryDump) DeepCopy() *PanicMemoryDump {
+	if in == nil {
+		return nil
+	}
+	out := new(PanicMemoryDump)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PciHostDevice) DeepCopyInto(out *PciHostDevice) {
 	*out = *in
@@ -2210,6 +3588,11 @@ func (in *PermittedHostDevices) DeepCopyInto(out *PermittedHostDevices) {
 		*out = make([]MediatedHostDevice, len(*in))
 		copy(*out, *in)
 	}
+	if in.USB != nil {
+		in, out := &in.USB, &out.USB
+		*out = make([]USBHostDevice, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2223,6 +3606,55 @@ func (in *PermittedHostDevices) DeepCopy() *PermittedHostDevices {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimInfo) DeepCopyInto(out *PersistentVolumeClaimInfo) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(corev1.PersistentVolumeMode)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimInfo.
+func (in *PersistentVolumeClaimInfo) DeepCopy() *PersistentVolumeClaimInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginBinding) DeepCopyInto(out *PluginBinding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginBinding.
+func (in *PluginBinding) DeepCopy() *PluginBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodNetwork) DeepCopyInto(out *PodNetwork) {
 	*out = *in
@@ -2255,6 +3687,22 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreferenceMatcher) DeepCopyInto(out *PreferenceMatcher) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreferenceMatcher.
+func (in *PreferenceMatcher) DeepCopy() *PreferenceMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(PreferenceMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Probe) DeepCopyInto(out *Probe) {
 	*out = *in
@@ -2330,6 +3778,38 @@ func (in *RTCTimer) DeepCopy() *RTCTimer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Realtime) DeepCopyInto(out *Realtime) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Realtime.
+func (in *Realtime) DeepCopy() *Realtime {
+	if in == nil {
+		return nil
+	}
+	out := new(Realtime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveInterfaceOptions) DeepCopyInto(out *RemoveInterfaceOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveInterfaceOptions.
+func (in *RemoveInterfaceOptions) DeepCopy() *RemoveInterfaceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoveInterfaceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoveVolumeOptions) DeepCopyInto(out *RemoveVolumeOptions) {
 	*out = *in
@@ -2431,7 +3911,134 @@ func (in *Rng) DeepCopy() *Rng {
 	if in == nil {
 		return nil
 	}
-	out := new(Rng)
+	out := new(Rng)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEV) DeepCopyInto(out *SEV) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(SEVPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attestation != nil {
+		in, out := &in.Attestation, &out.Attestation
+		*out = new(SEVAttestation)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEV.
+func (in *SEV) DeepCopy() *SEV {
+	if in == nil {
+		return nil
+	}
+	out := new(SEV)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVAttestation) DeepCopyInto(out *SEVAttestation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVAttestation.
+func (in *SEVAttestation) DeepCopy() *SEVAttestation {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVAttestation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVMeasurementInfo) DeepCopyInto(out *SEVMeasurementInfo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVMeasurementInfo.
+func (in *SEVMeasurementInfo) DeepCopy() *SEVMeasurementInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVMeasurementInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVPlatformInfo) DeepCopyInto(out *SEVPlatformInfo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVPlatformInfo.
+func (in *SEVPlatformInfo) DeepCopy() *SEVPlatformInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVPlatformInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVPolicy) DeepCopyInto(out *SEVPolicy) {
+	*out = *in
+	if in.EncryptedState != nil {
+		in, out := &in.EncryptedState, &out.EncryptedState
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVPolicy.
+func (in *SEVPolicy) DeepCopy() *SEVPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVSNP) DeepCopyInto(out *SEVSNP) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVSNP.
+func (in *SEVSNP) DeepCopy() *SEVSNP {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVSNP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVSecretOptions) DeepCopyInto(out *SEVSecretOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVSecretOptions.
+func (in *SEVSecretOptions) DeepCopy() *SEVSecretOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVSecretOptions)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2473,6 +4080,11 @@ func (in *SSHPublicKeyAccessCredential) DeepCopy() *SSHPublicKeyAccessCredential
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHPublicKeyAccessCredentialPropagationMethod) DeepCopyInto(out *SSHPublicKeyAccessCredentialPropagationMethod) {
 	*out = *in
+	if in.NoCloud != nil {
+		in, out := &in.NoCloud, &out.NoCloud
+		*out = new(NoCloudSSHPublicKeyAccessCredentialPropagation)
+		**out = **in
+	}
 	if in.ConfigDrive != nil {
 		in, out := &in.ConfigDrive, &out.ConfigDrive
 		*out = new(ConfigDriveSSHPublicKeyAccessCredentialPropagation)
@@ -2538,6 +4150,95 @@ func (in *SecretVolumeSource) DeepCopy() *SecretVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecureBootKeySource) DeepCopyInto(out *SecureBootKeySource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecureBootKeySource.
+func (in *SecureBootKeySource) DeepCopy() *SecureBootKeySource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecureBootKeySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecureBootKeys) DeepCopyInto(out *SecureBootKeys) {
+	*out = *in
+	if in.PK != nil {
+		in, out := &in.PK, &out.PK
+		*out = new(SecureBootKeySource)
+		**out = **in
+	}
+	if in.KEK != nil {
+		in, out := &in.KEK, &out.KEK
+		*out = new(SecureBootKeySource)
+		**out = **in
+	}
+	if in.DB != nil {
+		in, out := &in.DB, &out.DB
+		*out = new(SecureBootKeySource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecureBootKeys.
+func (in *SecureBootKeys) DeepCopy() *SecureBootKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(SecureBootKeys)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialConsoleLog) DeepCopyInto(out *SerialConsoleLog) {
+	*out = *in
+	if in.MaxFileSize != nil {
+		in, out := &in.MaxFileSize, &out.MaxFileSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxFiles != nil {
+		in, out := &in.MaxFiles, &out.MaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialConsoleLog.
+func (in *SerialConsoleLog) DeepCopy() *SerialConsoleLog {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialConsoleLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialPort) DeepCopyInto(out *SerialPort) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialPort.
+func (in *SerialPort) DeepCopy() *SerialPort {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountVolumeSource) DeepCopyInto(out *ServiceAccountVolumeSource) {
 	*out = *in
@@ -2554,6 +4255,39 @@ func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SetLinkStateOptions) DeepCopyInto(out *SetLinkStateOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SetLinkStateOptions.
+func (in *SetLinkStateOptions) DeepCopy() *SetLinkStateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SetLinkStateOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwapDiskSource) DeepCopyInto(out *SwapDiskSource) {
+	*out = *in
+	out.Capacity = in.Capacity.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwapDiskSource.
+func (in *SwapDiskSource) DeepCopy() *SwapDiskSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SwapDiskSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyNICTimer) DeepCopyInto(out *SyNICTimer) {
 	*out = *in
@@ -2606,6 +4340,43 @@ func (in *SysprepSource) DeepCopy() *SysprepSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TDX) DeepCopyInto(out *TDX) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TDX.
+func (in *TDX) DeepCopy() *TDX {
+	if in == nil {
+		return nil
+	}
+	out := new(TDX)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPMDevice) DeepCopyInto(out *TPMDevice) {
+	*out = *in
+	if in.Persistent != nil {
+		in, out := &in.Persistent, &out.Persistent
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPMDevice.
+func (in *TPMDevice) DeepCopy() *TPMDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(TPMDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Timer) DeepCopyInto(out *Timer) {
 	*out = *in
@@ -2647,6 +4418,22 @@ func (in *Timer) DeepCopy() *Timer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *USBHostDevice) DeepCopyInto(out *USBHostDevice) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USBHostDevice.
+func (in *USBHostDevice) DeepCopy() *USBHostDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(USBHostDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserPasswordAccessCredential) DeepCopyInto(out *UserPasswordAccessCredential) {
 	*out = *in
@@ -2797,6 +4584,61 @@ func (in *VirtualMachineInstance) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceBackupStatus) DeepCopyInto(out *VirtualMachineInstanceBackupStatus) {
+	*out = *in
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceBackupStatus.
+func (in *VirtualMachineInstanceBackupStatus) DeepCopy() *VirtualMachineInstanceBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceBlockCommitStatus) DeepCopyInto(out *VirtualMachineInstanceBlockCommitStatus) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceBlockCommitStatus.
+func (in *VirtualMachineInstanceBlockCommitStatus) DeepCopy() *VirtualMachineInstanceBlockCommitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceBlockCommitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceCondition) DeepCopyInto(out *VirtualMachineInstanceCondition) {
 	*out = *in
@@ -3011,12 +4853,36 @@ func (in *VirtualMachineInstanceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceMemoryDumpStatus) DeepCopyInto(out *VirtualMachineInstanceMemoryDumpStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceMemoryDumpStatus.
+func (in *VirtualMachineInstanceMemoryDumpStatus) DeepCopy() *VirtualMachineInstanceMemoryDumpStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceMemoryDumpStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceMigration) DeepCopyInto(out *VirtualMachineInstanceMigration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -3093,6 +4959,11 @@ func (in *VirtualMachineInstanceMigrationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceMigrationSpec) DeepCopyInto(out *VirtualMachineInstanceMigrationSpec) {
 	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]MigratedVolume, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3124,6 +4995,11 @@ func (in *VirtualMachineInstanceMigrationState) DeepCopyInto(out *VirtualMachine
 			(*out)[key] = val
 		}
 	}
+	if in.MigratedVolumes != nil {
+		in, out := &in.MigratedVolumes, &out.MigratedVolumes
+		*out = make([]MigratedVolume, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3168,6 +5044,11 @@ func (in *VirtualMachineInstanceNetworkInterface) DeepCopyInto(out *VirtualMachi
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(InterfaceBandwidth)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3465,6 +5346,11 @@ func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Ignition != nil {
+		in, out := &in.Ignition, &out.Ignition
+		*out = new(IgnitionSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3520,6 +5406,36 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CurrentCPUTopology != nil {
+		in, out := &in.CurrentCPUTopology, &out.CurrentCPUTopology
+		*out = new(CPUTopology)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(MemoryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSOCKCID != nil {
+		in, out := &in.VSOCKCID, &out.VSOCKCID
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(VirtualMachineInstanceBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryDump != nil {
+		in, out := &in.MemoryDump, &out.MemoryDump
+		*out = new(VirtualMachineInstanceMemoryDumpStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlockCommit != nil {
+		in, out := &in.BlockCommit, &out.BlockCommit
+		*out = new(VirtualMachineInstanceBlockCommitStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3597,6 +5513,21 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineRunStrategy)
 		**out = **in
 	}
+	if in.TTLSecondsAfterCompletion != nil {
+		in, out := &in.TTLSecondsAfterCompletion, &out.TTLSecondsAfterCompletion
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Instancetype != nil {
+		in, out := &in.Instancetype, &out.Instancetype
+		*out = new(InstancetypeMatcher)
+		**out = **in
+	}
+	if in.Preference != nil {
+		in, out := &in.Preference, &out.Preference
+		*out = new(PreferenceMatcher)
+		**out = **in
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(VirtualMachineInstanceTemplateSpec)
@@ -3740,6 +5671,27 @@ func (in *Volume) DeepCopy() *Volume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMultipathStatus) DeepCopyInto(out *VolumeMultipathStatus) {
+	*out = *in
+	if in.FailedPaths != nil {
+		in, out := &in.FailedPaths, &out.FailedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMultipathStatus.
+func (in *VolumeMultipathStatus) DeepCopy() *VolumeMultipathStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMultipathStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotStatus) DeepCopyInto(out *VolumeSnapshotStatus) {
 	*out = *in
@@ -3799,6 +5751,11 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 		*out = new(EmptyDiskSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SwapDisk != nil {
+		in, out := &in.SwapDisk, &out.SwapDisk
+		*out = new(SwapDiskSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DataVolume != nil {
 		in, out := &in.DataVolume, &out.DataVolume
 		*out = new(DataVolumeSource)
@@ -3824,6 +5781,11 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 		*out = new(ServiceAccountVolumeSource)
 		**out = **in
 	}
+	if in.DownwardMetrics != nil {
+		in, out := &in.DownwardMetrics, &out.DownwardMetrics
+		*out = new(DownwardMetricsVolumeSource)
+		**out = **in
+	}
 	return
 }
 
@@ -3845,6 +5807,31 @@ func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
 		*out = new(HotplugVolumeStatus)
 		**out = **in
 	}
+	if in.PersistentVolumeClaimInfo != nil {
+		in, out := &in.PersistentVolumeClaimInfo, &out.PersistentVolumeClaimInfo
+		*out = new(PersistentVolumeClaimInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(DiskIOTune)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Multipath != nil {
+		in, out := &in.Multipath, &out.Multipath
+		*out = new(VolumeMultipathStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerDiskVolume != nil {
+		in, out := &in.ContainerDiskVolume, &out.ContainerDiskVolume
+		*out = new(ContainerDiskInfo)
+		**out = **in
+	}
+	if in.ExternalSnapshot != nil {
+		in, out := &in.ExternalSnapshot, &out.ExternalSnapshot
+		*out = new(ExternalSnapshotVolumeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3883,6 +5870,11 @@ func (in *WatchdogDevice) DeepCopyInto(out *WatchdogDevice) {
 		*out = new(I6300ESBWatchdog)
 		**out = **in
 	}
+	if in.Diag288 != nil {
+		in, out := &in.Diag288, &out.Diag288
+		*out = new(Diag288Watchdog)
+		**out = **in
+	}
 	return
 }
 
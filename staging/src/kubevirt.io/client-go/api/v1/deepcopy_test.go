@@ -29,6 +29,7 @@ var _ = Describe("Generated deepcopy functions", func() {
 			&Volume{},
 			&VolumeSource{},
 			&ContainerDiskSource{},
+			&DownwardMetricsVolumeSource{},
 			&ClockOffset{},
 			&ClockOffsetUTC{},
 			&Clock{},
@@ -45,9 +46,29 @@ var _ = Describe("Generated deepcopy functions", func() {
 			&FeatureVendorID{},
 			&FeatureHyperv{},
 			&CPU{},
+			&NUMA{},
+			&NUMAGuestMappingPassthrough{},
+			&Realtime{},
 			&Watchdog{},
 			&WatchdogDevice{},
 			&I6300ESBWatchdog{},
+			&Diag288Watchdog{},
+			&PanicMemoryDump{},
+			&MemoryDump{},
+			&SerialConsoleLog{},
+			&SerialPort{},
+			&IgnitionSource{},
+			&ClusterCommonCPUConfiguration{},
+			&KSMConfiguration{},
+			&ContainerDiskCacheConfiguration{},
+			&EphemeralDiskBacking{},
+			&ContainerDiskConfiguration{},
+			&EphemeralDiskTmpfs{},
+			&MemoryOvercommitPolicy{},
+			&MediatedDevicesConfiguration{},
+			&NodeMediatedDeviceTypesConfig{},
+			&USBHostDevice{},
+			&Channel{},
 			&VirtualMachineInstance{},
 			&VirtualMachineInstanceList{},
 			&VirtualMachineInstanceSpec{},
@@ -76,7 +76,7 @@ func SetDefaults_DiskDevice(obj *DiskDevice) {
 }
 
 func SetDefaults_Watchdog(obj *Watchdog) {
-	if obj.I6300ESB == nil {
+	if obj.I6300ESB == nil && obj.Diag288 == nil {
 		obj.I6300ESB = &I6300ESBWatchdog{}
 	}
 }
@@ -111,6 +111,12 @@ func SetDefaults_I6300ESBWatchdog(obj *I6300ESBWatchdog) {
 	}
 }
 
+func SetDefaults_Diag288Watchdog(obj *Diag288Watchdog) {
+	if obj.Action == "" {
+		obj.Action = WatchdogActionReset
+	}
+}
+
 func SetDefaults_Firmware(obj *Firmware) {
 	if obj.UUID == "" {
 		obj.UUID = types.UID(uuid.NewRandom().String())
@@ -205,6 +211,16 @@ func DefaultMacvtapNetworkInterface(ifaceName string) *Interface {
 	return iface
 }
 
+func DefaultPasstNetworkInterface() *Interface {
+	iface := &Interface{
+		Name: "default",
+		InterfaceBindingMethod: InterfaceBindingMethod{
+			Passt: &InterfacePasst{},
+		},
+	}
+	return iface
+}
+
 func DefaultPodNetwork() *Network {
 	defaultNet := &Network{
 		Name: "default",
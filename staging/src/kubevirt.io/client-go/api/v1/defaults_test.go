@@ -264,6 +264,25 @@ var _ = Describe("Defaults", func() {
 		Expect(vmi.Spec.Domain.Devices.Watchdog.I6300ESB.Action).To(Equal(WatchdogActionReset))
 	})
 
+	It("should not add an i6300esb watchdog if a diag288 watchdog is set", func() {
+		vmi := &VirtualMachineInstance{
+			Spec: VirtualMachineInstanceSpec{
+				Domain: DomainSpec{
+					Devices: Devices{
+						Watchdog: &Watchdog{
+							WatchdogDevice: WatchdogDevice{
+								Diag288: &Diag288Watchdog{},
+							},
+						},
+					},
+				},
+			},
+		}
+		SetObjectDefaults_VirtualMachineInstance(vmi)
+		Expect(vmi.Spec.Domain.Devices.Watchdog.I6300ESB).To(BeNil())
+		Expect(vmi.Spec.Domain.Devices.Watchdog.Diag288.Action).To(Equal(WatchdogActionReset))
+	})
+
 	It("should set timer defaults", func() {
 		vmi := &VirtualMachineInstance{
 			Spec: VirtualMachineInstanceSpec{
@@ -32,6 +32,9 @@ const (
 	IOThreadsPolicyAuto    IOThreadsPolicy = "auto"
 	CPUModeHostPassthrough                 = "host-passthrough"
 	CPUModeHostModel                       = "host-model"
+	// CPUModelClusterCommon is replaced by virt-controller with the widest CPU model
+	// and feature set supported by all the nodes of the cluster
+	CPUModelClusterCommon = "cluster-common"
 )
 
 //go:generate swagger-doc
@@ -105,6 +108,10 @@ type DownwardAPIVolumeSource struct {
 	VolumeLabel string `json:"volumeLabel,omitempty"`
 }
 
+// +k8s:openapi-gen=true
+type DownwardMetricsVolumeSource struct {
+}
+
 // ServiceAccountVolumeSource adapts a ServiceAccount into a volume.
 //
 // +k8s:openapi-gen=true
@@ -126,6 +133,18 @@ type SysprepSource struct {
 	ConfigMap *v1.LocalObjectReference `json:"configMap,omitempty"`
 }
 
+// Represents the source of an Ignition config.
+//
+// +k8s:openapi-gen=true
+type IgnitionSource struct {
+	// SecretRef references a k8s Secret that contains the Ignition config under the config.ign key.
+	// +optional
+	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
+	// ConfigMapRef references a ConfigMap that contains the Ignition config under the config.ign key.
+	// +optional
+	ConfigMapRef *v1.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
 // Represents a cloud-init nocloud user data source.
 // More info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html
 //
@@ -149,6 +168,11 @@ type CloudInitNoCloudSource struct {
 	// NetworkData contains NoCloud inline cloud-init networkdata.
 	// + optional
 	NetworkData string `json:"networkData,omitempty"`
+	// GenerateNetworkData generates NoCloud cloud-init networkdata with the static addresses,
+	// routes and DNS servers of the VMI's pod and multus networks.
+	// Can't be used together with the other networkdata fields.
+	// + optional
+	GenerateNetworkData bool `json:"generateNetworkData,omitempty"`
 }
 
 // Represents a cloud-init config drive user data source.
@@ -206,11 +230,41 @@ type DomainSpec struct {
 	// One of: shared, auto
 	// +optional
 	IOThreadsPolicy *IOThreadsPolicy `json:"ioThreadsPolicy,omitempty"`
+	// IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.
+	// +optional
+	IOThreads *DiskIOThreads `json:"ioThreads,omitempty"`
 	// Chassis specifies the chassis info passed to the domain.
 	// +optional
 	Chassis *Chassis `json:"chassis,omitempty"`
+	// Launch Security setting of the vmi.
+	// +optional
+	LaunchSecurity *LaunchSecurity `json:"launchSecurity,omitempty"`
+}
+
+// DiskIOThreads sets how the disks share the IOThreads.
+//
+// +k8s:openapi-gen=true
+type DiskIOThreads struct {
+	// SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread.
+	// Defaults to the number of IOThreads determined by the ioThreadsPolicy.
+	// +optional
+	SharedPoolSize *uint32 `json:"sharedPoolSize,omitempty"`
+	// DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated.
+	// emulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads.
+	// vcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads.
+	// One of: emulator, vcpus. Defaults to emulator.
+	// +optional
+	DedicatedPinning DedicatedIOThreadsPinning `json:"dedicatedPinning,omitempty"`
 }
 
+// DedicatedIOThreadsPinning sets where the dedicated IOThreads of the disks are pinned
+type DedicatedIOThreadsPinning string
+
+const (
+	DedicatedIOThreadsPinningEmulator DedicatedIOThreadsPinning = "emulator"
+	DedicatedIOThreadsPinningVCPUs    DedicatedIOThreadsPinning = "vcpus"
+)
+
 // Chassis specifies the chassis info passed to the domain.
 //
 // +k8s:openapi-gen=true
@@ -222,6 +276,51 @@ type Chassis struct {
 	Sku          string `json:"sku,omitempty"`
 }
 
+//
+// +k8s:openapi-gen=true
+type LaunchSecurity struct {
+	// AMD Secure Encrypted Virtualization (SEV).
+	SEV *SEV `json:"sev,omitempty"`
+	// AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.
+	SNP *SEVSNP `json:"snp,omitempty"`
+	// Intel Trust Domain Extensions (TDX).
+	TDX *TDX `json:"tdx,omitempty"`
+}
+
+//
+// +k8s:openapi-gen=true
+type SEV struct {
+	// Guest policy flags as defined in AMD SEV API specification.
+	// Note: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.
+	Policy *SEVPolicy `json:"policy,omitempty"`
+	// If specified, run the attestation process for a vmi.
+	// +optional
+	Attestation *SEVAttestation `json:"attestation,omitempty"`
+}
+
+// SEVAttestation requests the attestation of the guest: the domain is started paused and is only
+// resumed once a launch secret was injected.
+//
+// +k8s:openapi-gen=true
+type SEVAttestation struct{}
+
+//
+// +k8s:openapi-gen=true
+type SEVPolicy struct {
+	// SEV-ES is required.
+	// Defaults to false.
+	// +optional
+	EncryptedState *bool `json:"encryptedState,omitempty"`
+}
+
+//
+// +k8s:openapi-gen=true
+type SEVSNP struct{}
+
+//
+// +k8s:openapi-gen=true
+type TDX struct{}
+
 // Represents the firmware blob used to assist in the domain creation process.
 // Used for setting the QEMU BIOS file path for the libvirt domain.
 //
@@ -254,6 +353,41 @@ type EFI struct {
 	// Defaults to true
 	// +optional
 	SecureBoot *bool `json:"secureBoot,omitempty"`
+	// SecureBootKeys references Secrets with custom certificates which are
+	// enrolled into the NVRAM at first boot.
+	// Requires SecureBoot to be enabled.
+	// +optional
+	SecureBootKeys *SecureBootKeys `json:"secureBootKeys,omitempty"`
+	// Persistent indicates the EFI NVRAM should be kept across reboots and migrations.
+	// The NVRAM is stored in a per-VM backend storage volume.
+	// Defaults to false.
+	// +optional
+	Persistent *bool `json:"persistent,omitempty"`
+}
+
+// SecureBootKeys references the certificates which are enrolled into the
+// Secure Boot databases. Every referenced Secret can hold one or more PEM encoded certificates.
+//
+// +k8s:openapi-gen=true
+type SecureBootKeys struct {
+	// PK references a Secret with the Platform Key certificate, replacing the default one.
+	// +optional
+	PK *SecureBootKeySource `json:"pk,omitempty"`
+	// KEK references a Secret with Key Exchange Key certificates, added to the default ones.
+	// +optional
+	KEK *SecureBootKeySource `json:"kek,omitempty"`
+	// DB references a Secret with certificates which are allowed to sign boot loaders and kernels,
+	// added to the default ones.
+	// +optional
+	DB *SecureBootKeySource `json:"db,omitempty"`
+}
+
+// SecureBootKeySource references a Secret holding Secure Boot certificates.
+//
+// +k8s:openapi-gen=true
+type SecureBootKeySource struct {
+	// SecretName is the name of the Secret in the namespace of the vmi.
+	SecretName string `json:"secretName"`
 }
 
 //
@@ -283,6 +417,11 @@ type CPU struct {
 	// Sockets specifies the number of sockets inside the vmi.
 	// Must be a value greater or equal 1.
 	Sockets uint32 `json:"sockets,omitempty"`
+	// MaxSockets specifies the maximum amount of sockets that can
+	// be hotplugged into a running vmi.
+	// Must be a value greater or equal to Sockets.
+	// +optional
+	MaxSockets uint32 `json:"maxSockets,omitempty"`
 	// Threads specifies the number of threads inside the vmi.
 	// Must be a value greater or equal 1.
 	Threads uint32 `json:"threads,omitempty"`
@@ -304,8 +443,41 @@ type CPU struct {
 	// the emulator thread on it.
 	// +optional
 	IsolateEmulatorThread bool `json:"isolateEmulatorThread,omitempty"`
+	// NUMA allows specifying settings for the guest NUMA topology
+	// +optional
+	NUMA *NUMA `json:"numa,omitempty"`
+	// Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
+	// +optional
+	Realtime *Realtime `json:"realtime,omitempty"`
+}
+
+// Realtime holds the tuning knobs specific for realtime workloads.
+//
+// +k8s:openapi-gen=true
+type Realtime struct {
+	// Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt's expressions.
+	// Example: "0-3,^1","0,2,3","2-3"
+	// +optional
+	Mask string `json:"mask,omitempty"`
 }
 
+// NUMA allows specifying settings for the guest NUMA topology.
+//
+// +k8s:openapi-gen=true
+type NUMA struct {
+	// GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod.
+	// The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+	// +optional
+	GuestMappingPassthrough *NUMAGuestMappingPassthrough `json:"guestMappingPassthrough,omitempty"`
+}
+
+// NUMAGuestMappingPassthrough instructs kubevirt to model numa topology which is compatible with the CPU pinning on the guest.
+// This will result in a subset of the node numa topology being passed through, ensuring that virtual numa nodes and their memory
+// never cross boundaries coming from the node numa mapping.
+//
+// +k8s:openapi-gen=true
+type NUMAGuestMappingPassthrough struct{}
+
 // CPUFeature allows specifying a CPU feature.
 //
 // +k8s:openapi-gen=true
@@ -335,6 +507,10 @@ type Memory struct {
 	// Defaults to the requested memory in the resources section if not specified.
 	// + optional
 	Guest *resource.Quantity `json:"guest,omitempty"`
+	// MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS.
+	// The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+	// + optional
+	MaxGuest *resource.Quantity `json:"maxGuest,omitempty"`
 }
 
 // Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.
@@ -395,6 +571,10 @@ type Devices struct {
 	// Defaults to true.
 	// +optional
 	AutoattachMemBalloon *bool `json:"autoattachMemBalloon,omitempty"`
+	// Whether to enable free page reporting on the memory balloon device, so that memory freed by
+	// the guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.
+	// +optional
+	FreePageReporting *bool `json:"freePageReporting,omitempty"`
 	// Whether to have random number generator from host
 	// +optional
 	Rng *Rng `json:"rng,omitempty"`
@@ -409,6 +589,8 @@ type Devices struct {
 	// +listType=atomic
 	GPUs []GPU `json:"gpus,omitempty"`
 	// Filesystems describes filesystem which is connected to the vmi.
+	// The volume with the name of the filesystem is shared into the guest with virtiofs,
+	// it can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.
 	// +optional
 	// +listType=atomic
 	Filesystems []Filesystem `json:"filesystems,omitempty"`
@@ -416,6 +598,102 @@ type Devices struct {
 	// +optional
 	// +listType=atomic
 	HostDevices []HostDevice `json:"hostDevices,omitempty"`
+	// Whether to emulate a TPM device.
+	// +optional
+	TPM *TPMDevice `json:"tpm,omitempty"`
+	// Whether to attach the VSOCK CID to the VM or not.
+	// VSOCK access will be available if set to true. Defaults to false.
+	// +optional
+	AutoattachVSOCK *bool `json:"autoattachVSOCK,omitempty"`
+	// Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor.
+	// Defaults to true.
+	// +optional
+	AutoattachPanicDevice *bool `json:"autoattachPanicDevice,omitempty"`
+	// If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.
+	// +optional
+	PanicMemoryDump *PanicMemoryDump `json:"panicMemoryDump,omitempty"`
+	// If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource,
+	// for example by VirtualMachineSnapshots which include a memory dump.
+	// +optional
+	MemoryDump *MemoryDump `json:"memoryDump,omitempty"`
+	// Whether to mirror the output of the serial console to the virt-launcher pod log.
+	// The log can be retrieved later through the console/log subresource. Defaults to false.
+	// +optional
+	LogSerialConsole *bool `json:"logSerialConsole,omitempty"`
+	// If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.
+	// +optional
+	SerialConsoleLog *SerialConsoleLog `json:"serialConsoleLog,omitempty"`
+	// Additional serial ports of the vmi, next to the default serial console.
+	// Each serial port can be connected to through the serial subresource.
+	// +optional
+	// +listType=atomic
+	Serials []SerialPort `json:"serials,omitempty"`
+	// Virtio channels of the vmi, e.g. for custom guest agents.
+	// Each channel can be connected to through the channel subresource.
+	// +optional
+	// +listType=atomic
+	Channels []Channel `json:"channels,omitempty"`
+}
+
+// PanicMemoryDump configures where the memory of a panicked guest is dumped to
+//
+// +k8s:openapi-gen=true
+type PanicMemoryDump struct {
+	// ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+	ClaimName string `json:"claimName"`
+}
+
+// MemoryDump configures where the memory of the running guest is dumped to
+//
+// +k8s:openapi-gen=true
+type MemoryDump struct {
+	// ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.
+	ClaimName string `json:"claimName"`
+}
+
+// SerialConsoleLog configures where the serial console log of the guest is stored
+//
+// +k8s:openapi-gen=true
+type SerialConsoleLog struct {
+	// ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.
+	ClaimName string `json:"claimName"`
+	// MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.
+	// +optional
+	MaxFileSize *resource.Quantity `json:"maxFileSize,omitempty"`
+	// MaxFiles is the number of rotated log files which are kept. Defaults to 3.
+	// +optional
+	MaxFiles *int32 `json:"maxFiles,omitempty"`
+}
+
+// SerialPort represents an additional serial port of the vmi
+//
+// +k8s:openapi-gen=true
+type SerialPort struct {
+	// Name of the serial port, used to connect to it through the serial subresource.
+	// Must be a DNS_LABEL and unique within the vmi.
+	Name string `json:"name"`
+}
+
+// Channel represents a virtio channel of the vmi
+//
+// +k8s:openapi-gen=true
+type Channel struct {
+	// Name of the channel, used to connect to it through the channel subresource.
+	// Must be a DNS_LABEL and unique within the vmi.
+	Name string `json:"name"`
+	// TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.
+	TargetName string `json:"targetName"`
+}
+
+// TPMDevice represents the emulated TPM device passed to the vmi
+//
+// +k8s:openapi-gen=true
+type TPMDevice struct {
+	// Persistent indicates the state of the TPM device should be kept across reboots and migrations.
+	// The state is stored in a per-VM backend storage volume.
+	// Defaults to false.
+	// +optional
+	Persistent *bool `json:"persistent,omitempty"`
 }
 
 //
@@ -442,7 +720,30 @@ type Filesystem struct {
 
 //
 // +k8s:openapi-gen=true
-type FilesystemVirtiofs struct{}
+type FilesystemVirtiofs struct {
+	// SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root.
+	// It allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+	// UIDMap maps the user IDs of the guest to the user IDs of the shared directory.
+	// +optional
+	UIDMap *FilesystemIDMap `json:"uidMap,omitempty"`
+	// GIDMap maps the group IDs of the guest to the group IDs of the shared directory.
+	// +optional
+	GIDMap *FilesystemIDMap `json:"gidMap,omitempty"`
+}
+
+// FilesystemIDMap maps a range of consecutive IDs of the guest to a range of IDs of the shared directory.
+//
+// +k8s:openapi-gen=true
+type FilesystemIDMap struct {
+	// GuestID is the first ID of the range in the guest.
+	GuestID uint32 `json:"guestID"`
+	// HostID is the first ID of the range in the shared directory.
+	HostID uint32 `json:"hostID"`
+	// Count is the number of IDs in the range, it must be greater than 0.
+	Count uint32 `json:"count"`
+}
 
 //
 // +k8s:openapi-gen=true
@@ -458,6 +759,19 @@ type HostDevice struct {
 	Name string `json:"name"`
 	// DeviceName is the resource name of the host device exposed by a device plugin
 	DeviceName string `json:"deviceName"`
+	// NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole
+	// controller through. QEMU drives the controller with its user space NVMe driver then.
+	// +optional
+	NVMe *HostDeviceNVMe `json:"nvme,omitempty"`
+}
+
+// HostDeviceNVMe selects the namespace of a NVMe controller which is attached as a disk.
+//
+// +k8s:openapi-gen=true
+type HostDeviceNVMe struct {
+	// Namespace is the ID of the namespace. Defaults to 1.
+	// +optional
+	Namespace uint32 `json:"namespace,omitempty"`
 }
 
 //
@@ -483,15 +797,110 @@ type Disk struct {
 	// +optional
 	DedicatedIOThread *bool `json:"dedicatedIOThread,omitempty"`
 	// Cache specifies which kvm disk cache mode should be used.
+	// Supported values are: none, writethrough, writeback.
+	// If not set, none is used if the storage of the volume supports direct I/O. Otherwise
+	// writethrough is used on shared volumes and writeback on the others.
 	// +optional
 	Cache DriverCache `json:"cache,omitempty"`
 	// IO specifies which QEMU disk IO mode should be used.
 	// Supported values are: native, default, threads.
 	// +optional
 	IO DriverIO `json:"io,omitempty"`
+	// Discard specifies whether discard requests of the guest are passed down to the storage.
+	// Supported values are: unmap, ignore.
+	// If not set, unmap is used unless the volume is a preallocated image, so that data deleted
+	// in the guest frees the space of thin provisioned storage.
+	// +optional
+	Discard DriverDiscard `json:"discard,omitempty"`
+	// DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them.
+	// Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes
+	// and requires discard to be unmap.
+	// Defaults to off.
+	// +optional
+	DetectZeroes DriverDetectZeroes `json:"detectZeroes,omitempty"`
+	// IOTune limits the I/O operations and the bandwidth of the disk.
+	// +optional
+	IOTune *DiskIOTune `json:"ioTune,omitempty"`
 	// If specified, disk address and its tag will be provided to the guest via config drive metadata
 	// +optional
 	Tag string `json:"tag,omitempty"`
+	// ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots.
+	// The volume is not snapshotted and the guest filesystems on the disk are not frozen.
+	// Restores keep the current volume.
+	// Defaults to false.
+	// +optional
+	ExcludeFromSnapshot *bool `json:"excludeFromSnapshot,omitempty"`
+	// Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret.
+	// The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.
+	// +optional
+	Encryption *DiskEncryption `json:"encryption,omitempty"`
+	// ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it
+	// after the disk was expanded online, so that the new capacity is usable in the guest.
+	// It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest.
+	// Defaults to false.
+	// +optional
+	ExpandGuestFilesystem *bool `json:"expandGuestFilesystem,omitempty"`
+}
+
+// DiskEncryption references the Secret with the passphrase of a LUKS encrypted volume.
+//
+// +k8s:openapi-gen=true
+type DiskEncryption struct {
+	// SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.
+	SecretName string `json:"secretName"`
+	// Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption.
+	// Defaults to raw.
+	// +optional
+	Format DiskEncryptionFormat `json:"format,omitempty"`
+}
+
+type DiskEncryptionFormat string
+
+const (
+	DiskEncryptionFormatRaw   DiskEncryptionFormat = "raw"
+	DiskEncryptionFormatQcow2 DiskEncryptionFormat = "qcow2"
+)
+
+// DiskIOTune limits the I/O of a disk, so that it can not starve other disks sharing the same storage backend.
+//
+// +k8s:openapi-gen=true
+type DiskIOTune struct {
+	// IOPS limits the I/O operations per second of the disk.
+	// +optional
+	IOPS *DiskIOLimits `json:"iops,omitempty"`
+	// Bandwidth limits the bytes per second of the disk.
+	// +optional
+	Bandwidth *DiskIOLimits `json:"bandwidth,omitempty"`
+}
+
+// DiskIOLimits holds the limits of one kind of I/O of a disk.
+// The total limits can not be combined with the read and write limits.
+//
+// +k8s:openapi-gen=true
+type DiskIOLimits struct {
+	// Total limits the sum of reads and writes.
+	// +optional
+	Total uint64 `json:"total,omitempty"`
+	// Read limits the reads.
+	// +optional
+	Read uint64 `json:"read,omitempty"`
+	// Write limits the writes.
+	// +optional
+	Write uint64 `json:"write,omitempty"`
+	// TotalBurst allows the sum of reads and writes to exceed the total limit
+	// up to this value for the burst length.
+	// +optional
+	TotalBurst uint64 `json:"totalBurst,omitempty"`
+	// ReadBurst allows the reads to exceed the read limit up to this value for the burst length.
+	// +optional
+	ReadBurst uint64 `json:"readBurst,omitempty"`
+	// WriteBurst allows the writes to exceed the write limit up to this value for the burst length.
+	// +optional
+	WriteBurst uint64 `json:"writeBurst,omitempty"`
+	// BurstLength is the duration in seconds for which a burst is allowed.
+	// Defaults to 1.
+	// +optional
+	BurstLength uint64 `json:"burstLength,omitempty"`
 }
 
 // Represents the target of a volume to mount.
@@ -513,7 +922,7 @@ type DiskDevice struct {
 // +k8s:openapi-gen=true
 type DiskTarget struct {
 	// Bus indicates the type of disk device to emulate.
-	// supported values: virtio, sata, scsi.
+	// supported values: virtio, sata, scsi, usb.
 	Bus string `json:"bus,omitempty"`
 	// ReadOnly.
 	// Defaults to false.
@@ -532,6 +941,11 @@ type LunTarget struct {
 	// ReadOnly.
 	// Defaults to false.
 	ReadOnly bool `json:"readonly,omitempty"`
+	// Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk.
+	// The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler.
+	// It requires the scsi bus.
+	// +optional
+	Reservation bool `json:"reservation,omitempty"`
 }
 
 //
@@ -563,7 +977,7 @@ const (
 // +k8s:openapi-gen=true
 type CDRomTarget struct {
 	// Bus indicates the type of disk device to emulate.
-	// supported values: virtio, sata, scsi.
+	// supported values: virtio, sata, scsi, usb.
 	Bus string `json:"bus,omitempty"`
 	// ReadOnly.
 	// Defaults to true.
@@ -625,6 +1039,10 @@ type VolumeSource struct {
 	// More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html
 	// +optional
 	EmptyDisk *EmptyDiskSource `json:"emptyDisk,omitempty"`
+	// SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle.
+	// The guest is told to enable the swap through the cloud-init vendor data.
+	// +optional
+	SwapDisk *SwapDiskSource `json:"swapDisk,omitempty"`
 	// DataVolume represents the dynamic creation a PVC for this volume as well as
 	// the process of populating that PVC with a disk image.
 	// +optional
@@ -645,6 +1063,10 @@ type VolumeSource struct {
 	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/
 	// +optional
 	ServiceAccount *ServiceAccountVolumeSource `json:"serviceAccount,omitempty"`
+	// DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest
+	// metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.
+	// +optional
+	DownwardMetrics *DownwardMetricsVolumeSource `json:"downwardMetrics,omitempty"`
 }
 
 // HotplugVolumeSource Represents the source of a volume to mount which are capable
@@ -689,6 +1111,14 @@ type EmptyDiskSource struct {
 	Capacity resource.Quantity `json:"capacity"`
 }
 
+// SwapDisk represents a temporary disk the guest uses as encrypted swap space.
+//
+// +k8s:openapi-gen=true
+type SwapDiskSource struct {
+	// Capacity of the sparse swap disk.
+	Capacity resource.Quantity `json:"capacity"`
+}
+
 // Represents a docker image with an embedded disk.
 //
 // +k8s:openapi-gen=true
@@ -1060,6 +1490,9 @@ type WatchdogDevice struct {
 	// i6300esb watchdog device.
 	// +optional
 	I6300ESB *I6300ESBWatchdog `json:"i6300esb,omitempty"`
+	// diag288 watchdog device, only available on s390x.
+	// +optional
+	Diag288 *Diag288Watchdog `json:"diag288,omitempty"`
 }
 
 // i6300esb watchdog device.
@@ -1071,6 +1504,15 @@ type I6300ESBWatchdog struct {
 	Action WatchdogAction `json:"action,omitempty"`
 }
 
+// diag288 watchdog device.
+//
+// +k8s:openapi-gen=true
+type Diag288Watchdog struct {
+	// The action to take. Valid values are poweroff, reset, shutdown.
+	// Defaults to reset.
+	Action WatchdogAction `json:"action,omitempty"`
+}
+
 //
 // +k8s:openapi-gen=true
 type Interface struct {
@@ -1095,6 +1537,11 @@ type Interface struct {
 	// Interfaces without a boot order are not tried.
 	// +optional
 	BootOrder *uint `json:"bootOrder,omitempty"`
+	// If set, no option ROM is exposed to the guest for the interface.
+	// Interfaces with a boot order have an option ROM by default, which is needed to boot from
+	// the network with BIOS firmware. EFI firmware can boot from the network without it.
+	// +optional
+	ROMDisabled bool `json:"romDisabled,omitempty"`
 	// If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10
 	// +optional
 	PciAddress string `json:"pciAddress,omitempty"`
@@ -1104,8 +1551,164 @@ type Interface struct {
 	// If specified, the virtual network interface address and its tag will be provided to the guest via config drive
 	// +optional
 	Tag string `json:"tag,omitempty"`
+	// State represents the requested operational state of the interface.
+	// The link of the interface is set "down" or "up", "up" being the default, and the guest sees a link
+	// which is down as an unplugged cable. "absent" is set when the interface is hot unplugged.
+	// +optional
+	State InterfaceState `json:"state,omitempty"`
+	// Binding specifies the binding plugin that will be used to connect the interface to the guest.
+	// It provides an alternative to InterfaceBindingMethod.
+	// +optional
+	Binding *PluginBinding `json:"binding,omitempty"`
+	// Bandwidth limits the traffic of the interface.
+	// It is only supported with the bridge, masquerade and macvtap bindings.
+	// +optional
+	Bandwidth *InterfaceBandwidth `json:"bandwidth,omitempty"`
+	// RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a
+	// multi-queue guest across its queues, and thus across its vCPUs.
+	// It requires the virtio model and networkInterfaceMultiqueue, and is only supported with the
+	// bridge, masquerade and macvtap bindings.
+	// +optional
+	RSS *InterfaceRSS `json:"rss,omitempty"`
+	// Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default.
+	// It requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.
+	// +optional
+	Offloads *InterfaceOffloads `json:"offloads,omitempty"`
+	// Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod,
+	// so it applies even where NetworkPolicies don't, like on bridged secondary networks.
+	// It is only supported with the bridge and masquerade bindings.
+	// +optional
+	Firewall *InterfaceFirewall `json:"firewall,omitempty"`
 }
 
+// InterfaceBandwidth limits the traffic of an interface, from the point of view of the guest.
+//
+// +k8s:openapi-gen=true
+type InterfaceBandwidth struct {
+	// Inbound limits the traffic received by the guest (rx).
+	// +optional
+	Inbound *BandwidthLimit `json:"inbound,omitempty"`
+	// Outbound limits the traffic sent by the guest (tx).
+	// +optional
+	Outbound *BandwidthLimit `json:"outbound,omitempty"`
+}
+
+// InterfaceRSS configures receive side scaling (RSS) of a virtio-net interface.
+//
+// +k8s:openapi-gen=true
+type InterfaceRSS struct {
+	// HashReport makes the device report the RSS hash of every received packet to the guest, which saves
+	// the guest from computing it again. The device is emulated by QEMU instead of vhost-net then.
+	// +optional
+	HashReport bool `json:"hashReport,omitempty"`
+}
+
+// InterfaceOffloads toggles the offloads of a virtio-net interface, both from the guest to the host and
+// from the host to the guest.
+//
+// +k8s:openapi-gen=true
+type InterfaceOffloads struct {
+	// Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled
+	// with it and can't be enabled without it.
+	// +optional
+	Checksum *bool `json:"checksum,omitempty"`
+	// TSO toggles the TCP segmentation offload, for IPv4 and IPv6.
+	// +optional
+	TSO *bool `json:"tso,omitempty"`
+	// GSO toggles the generic segmentation offload of the host.
+	// +optional
+	GSO *bool `json:"gso,omitempty"`
+}
+
+// InterfaceFirewall allows or denies the IP traffic of an interface. The traffic of established connections
+// is always allowed, as well as DHCP and IPv6 neighbor discovery.
+//
+// +k8s:openapi-gen=true
+type InterfaceFirewall struct {
+	// Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.
+	// +optional
+	Rules []FirewallRule `json:"rules,omitempty"`
+	// DefaultAction applies to the packets which match no rule. Defaults to Allow.
+	// Deny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass.
+	// VMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label,
+	// which virt-handler sets where it is loaded.
+	// +optional
+	DefaultAction FirewallAction `json:"defaultAction,omitempty"`
+}
+
+// FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port.
+// The packets match when all the criteria which are set match.
+//
+// +k8s:openapi-gen=true
+type FirewallRule struct {
+	// Action is Allow or Deny.
+	Action FirewallAction `json:"action"`
+	// Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest.
+	// The rule applies to both directions if it is not set.
+	// +optional
+	Direction FirewallDirection `json:"direction,omitempty"`
+	// CIDR is the network of the remote peer, IPv4 or IPv6.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+	// Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// Port is the destination port, on the guest for Ingress and on the remote peer for Egress.
+	// It requires the TCP, UDP or SCTP protocol.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+type FirewallAction string
+
+const (
+	FirewallActionAllow FirewallAction = "Allow"
+	FirewallActionDeny  FirewallAction = "Deny"
+)
+
+type FirewallDirection string
+
+const (
+	FirewallDirectionIngress FirewallDirection = "Ingress"
+	FirewallDirectionEgress  FirewallDirection = "Egress"
+)
+
+// BandwidthLimit shapes the traffic in one direction of an interface.
+//
+// +k8s:openapi-gen=true
+type BandwidthLimit struct {
+	// Average is the rate, in bytes per second, the traffic is shaped to.
+	Average resource.Quantity `json:"average"`
+	// Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.
+	// +optional
+	Peak *resource.Quantity `json:"peak,omitempty"`
+	// Burst is the amount of bytes which can be sent at the peak rate.
+	// +optional
+	Burst *resource.Quantity `json:"burst,omitempty"`
+}
+
+// PluginBinding references a network binding plugin registered in the KubeVirt CR.
+//
+// +k8s:openapi-gen=true
+type PluginBinding struct {
+	// Name references the binding plugin name as registered in the KubeVirt CR.
+	Name string `json:"name"`
+}
+
+// InterfaceState represents the requested operational state of an interface.
+//
+// +k8s:openapi-gen=true
+type InterfaceState string
+
+const (
+	// InterfaceStateAbsent indicates that the interface is hot unplugged from the VMI.
+	InterfaceStateAbsent InterfaceState = "absent"
+	// InterfaceStateLinkUp indicates that the link of the interface is up.
+	InterfaceStateLinkUp InterfaceState = "up"
+	// InterfaceStateLinkDown indicates that the link of the interface is down, as if its cable was unplugged.
+	InterfaceStateLinkDown InterfaceState = "down"
+)
+
 // Extra DHCP options to use in the interface.
 //
 // +k8s:openapi-gen=true
@@ -1122,6 +1725,25 @@ type DHCPOptions struct {
 	// If specified will pass extra DHCP options for private use, range: 224-254
 	// +optional
 	PrivateOptions []DHCPPrivateOptions `json:"privateOptions,omitempty"`
+	// If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.
+	// +optional
+	MTU *uint16 `json:"mtu,omitempty"`
+	// If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes
+	// of the pod interface.
+	// +optional
+	Routes []DHCPRoute `json:"routes,omitempty"`
+}
+
+// DHCPRoute defines a static route passed to the VM through DHCP.
+//
+// +k8s:openapi-gen=true
+type DHCPRoute struct {
+	// Destination is the IPv4 CIDR of the routed network.
+	// Required.
+	Destination string `json:"destination"`
+	// Gateway is the IPv4 address of the next hop.
+	// Required.
+	Gateway string `json:"gateway"`
 }
 
 // DHCPExtraOptions defines Extra DHCP options for a VM.
@@ -1146,6 +1768,9 @@ type InterfaceBindingMethod struct {
 	Masquerade *InterfaceMasquerade `json:"masquerade,omitempty"`
 	SRIOV      *InterfaceSRIOV      `json:"sriov,omitempty"`
 	Macvtap    *InterfaceMacvtap    `json:"macvtap,omitempty"`
+	Passt      *InterfacePasst      `json:"passt,omitempty"`
+	Vdpa       *InterfaceVdpa       `json:"vdpa,omitempty"`
+	VhostUser  *InterfaceVhostUser  `json:"vhostuser,omitempty"`
 }
 
 //
@@ -1162,12 +1787,36 @@ type InterfaceMasquerade struct{}
 
 //
 // +k8s:openapi-gen=true
-type InterfaceSRIOV struct{}
+type InterfaceSRIOV struct {
+	// Failover names a virtio interface of the vmi which the guest teams with the VF through its
+	// net_failover driver. The traffic fails over to that interface while the VF is detached for a
+	// live migration. Both interfaces must have the same MAC address and be connected to the same L2 network.
+	// +optional
+	Failover string `json:"failover,omitempty"`
+}
 
 //
 // +k8s:openapi-gen=true
 type InterfaceMacvtap struct{}
 
+// InterfacePasst connects the guest to the pod network through passt, a user-mode network stack
+// which forwards TCP and UDP traffic over both IPv4 and IPv6.
+//
+// +k8s:openapi-gen=true
+type InterfacePasst struct{}
+
+// InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface.
+// The device is allocated by a device plugin, through the resource of the Multus network.
+//
+// +k8s:openapi-gen=true
+type InterfaceVdpa struct{}
+
+// InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP,
+// through a vhost-user socket. The guest memory is shared with the switch.
+//
+// +k8s:openapi-gen=true
+type InterfaceVhostUser struct{}
+
 // Port repesents a port to expose from the virtual machine.
 // Default protocol TCP.
 // The port field is mandatory
@@ -1179,7 +1828,7 @@ type Port struct {
 	// referred to by services.
 	// +optional
 	Name string `json:"name,omitempty"`
-	// Protocol for port. Must be UDP or TCP.
+	// Protocol for port. Must be UDP, TCP or SCTP.
 	// Defaults to "TCP".
 	// +optional
 	Protocol string `json:"protocol,omitempty"`
@@ -1195,6 +1844,10 @@ type AccessCredentialSecretSource struct {
 	SecretName string `json:"secretName"`
 }
 
+//
+// +k8s:openapi-gen=true
+type NoCloudSSHPublicKeyAccessCredentialPropagation struct{}
+
 //
 // +k8s:openapi-gen=true
 type ConfigDriveSSHPublicKeyAccessCredentialPropagation struct{}
@@ -1240,6 +1893,11 @@ type SSHPublicKeyAccessCredentialSource struct {
 //
 // +k8s:openapi-gen=true
 type SSHPublicKeyAccessCredentialPropagationMethod struct {
+	// NoCloudPropagation means that the ssh public keys are injected
+	// into the VM using metadata using the noCloud cloud-init provider
+	// +optional
+	NoCloud *NoCloudSSHPublicKeyAccessCredentialPropagation `json:"noCloud,omitempty"`
+
 	// ConfigDrivePropagation means that the ssh public keys are injected
 	// into the VM using metadata using the configDrive cloud-init provider
 	// +optional
@@ -1346,6 +2004,10 @@ type PodNetwork struct {
 	// CIDR for vm network.
 	// Default 10.0.2.0/24 if not specified.
 	VMNetworkCIDR string `json:"vmNetworkCIDR,omitempty"`
+
+	// IPv6 CIDR for the vm network.
+	// Defaults to fd10:0:2::/120 if not specified.
+	VMIPv6NetworkCIDR string `json:"vmIPv6NetworkCIDR,omitempty"`
 }
 
 // Rng represents the random device passed from host
@@ -1366,4 +2028,11 @@ type MultusNetwork struct {
 	// Select the default network and add it to the
 	// multus-cni.io/default-network annotation.
 	Default bool `json:"default,omitempty"`
+
+	// PersistentIPs keeps the addresses allocated by the IPAM of the network across live migrations
+	// and restarts of the VM. The addresses are requested through the ips field of the multus network
+	// selection element, so the IPAM plugin has to support static IP requests. Only bridge interfaces
+	// are supported.
+	// +optional
+	PersistentIPs bool `json:"persistentIPs,omitempty"`
 }
@@ -52,6 +52,14 @@ func (SysprepSource) SwaggerDoc() map[string]string {
 	}
 }
 
+func (IgnitionSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "Represents the source of an Ignition config.\n\n+k8s:openapi-gen=true",
+		"secretRef":    "SecretRef references a k8s Secret that contains the Ignition config under the config.ign key.\n+optional",
+		"configMapRef": "ConfigMapRef references a ConfigMap that contains the Ignition config under the config.ign key.\n+optional",
+	}
+}
+
 func (CloudInitNoCloudSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                     "Represents a cloud-init nocloud user data source.\nMore info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html\n\n+k8s:openapi-gen=true",
@@ -61,6 +69,7 @@ func (CloudInitNoCloudSource) SwaggerDoc() map[string]string {
 		"networkDataSecretRef": "NetworkDataSecretRef references a k8s secret that contains NoCloud networkdata.\n+ optional",
 		"networkDataBase64":    "NetworkDataBase64 contains NoCloud cloud-init networkdata as a base64 encoded string.\n+ optional",
 		"networkData":          "NetworkData contains NoCloud inline cloud-init networkdata.\n+ optional",
+		"generateNetworkData":  "GenerateNetworkData generates NoCloud cloud-init networkdata with the static addresses,\nroutes and DNS servers of the VMI's pod and multus networks.\nCan't be used together with the other networkdata fields.\n+ optional",
 	}
 }
 
@@ -89,6 +98,16 @@ func (DomainSpec) SwaggerDoc() map[string]string {
 		"devices":         "Devices allows adding disks, network interfaces, and others",
 		"ioThreadsPolicy": "Controls whether or not disks will share IOThreads.\nOmitting IOThreadsPolicy disables use of IOThreads.\nOne of: shared, auto\n+optional",
 		"chassis":         "Chassis specifies the chassis info passed to the domain.\n+optional",
+		"ioThreads":       "IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.\n+optional",
+		"launchSecurity":  "Launch Security setting of the vmi.\n+optional",
+	}
+}
+
+func (DiskIOThreads) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DiskIOThreads sets how the disks share the IOThreads.\n\n+k8s:openapi-gen=true",
+		"sharedPoolSize":   "SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread.\nDefaults to the number of IOThreads determined by the ioThreadsPolicy.\n+optional",
+		"dedicatedPinning": "DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated.\nemulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads.\nvcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads.\nOne of: emulator, vcpus. Defaults to emulator.\n+optional",
 	}
 }
 
@@ -98,6 +117,48 @@ func (Chassis) SwaggerDoc() map[string]string {
 	}
 }
 
+func (LaunchSecurity) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":    "+k8s:openapi-gen=true",
+		"sev": "AMD Secure Encrypted Virtualization (SEV).",
+		"snp": "AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.",
+		"tdx": "Intel Trust Domain Extensions (TDX).",
+	}
+}
+
+func (SEV) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "+k8s:openapi-gen=true",
+		"policy":      "Guest policy flags as defined in AMD SEV API specification.\nNote: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.",
+		"attestation": "If specified, run the attestation process for a vmi.\n+optional",
+	}
+}
+
+func (SEVAttestation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "SEVAttestation requests the attestation of the guest: the domain is started paused and is only\nresumed once a launch secret was injected.\n\n+k8s:openapi-gen=true",
+	}
+}
+
+func (SEVPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "+k8s:openapi-gen=true",
+		"encryptedState": "SEV-ES is required.\nDefaults to false.\n+optional",
+	}
+}
+
+func (SEVSNP) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "+k8s:openapi-gen=true",
+	}
+}
+
+func (TDX) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "+k8s:openapi-gen=true",
+	}
+}
+
 func (Bootloader) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":     "Represents the firmware blob used to assist in the domain creation process.\nUsed for setting the QEMU BIOS file path for the libvirt domain.\n\n+k8s:openapi-gen=true",
@@ -115,8 +176,26 @@ func (BIOS) SwaggerDoc() map[string]string {
 
 func (EFI) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":           "If set, EFI will be used instead of BIOS.\n\n+k8s:openapi-gen=true",
-		"secureBoot": "If set, SecureBoot will be enabled and the OVMF roms will be swapped for\nSecureBoot-enabled ones.\nRequires SMM to be enabled.\nDefaults to true\n+optional",
+		"":               "If set, EFI will be used instead of BIOS.\n\n+k8s:openapi-gen=true",
+		"secureBoot":     "If set, SecureBoot will be enabled and the OVMF roms will be swapped for\nSecureBoot-enabled ones.\nRequires SMM to be enabled.\nDefaults to true\n+optional",
+		"secureBootKeys": "SecureBootKeys references Secrets with custom certificates which are\nenrolled into the NVRAM at first boot.\nRequires SecureBoot to be enabled.\n+optional",
+		"persistent":     "Persistent indicates the EFI NVRAM should be kept across reboots and migrations.\nThe NVRAM is stored in a per-VM backend storage volume.\nDefaults to false.\n+optional",
+	}
+}
+
+func (SecureBootKeys) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":    "SecureBootKeys references the certificates which are enrolled into the\nSecure Boot databases. Every referenced Secret can hold one or more PEM encoded certificates.\n\n+k8s:openapi-gen=true",
+		"pk":  "PK references a Secret with the Platform Key certificate, replacing the default one.\n+optional",
+		"kek": "KEK references a Secret with Key Exchange Key certificates, added to the default ones.\n+optional",
+		"db":  "DB references a Secret with certificates which are allowed to sign boot loaders and kernels,\nadded to the default ones.\n+optional",
+	}
+}
+
+func (SecureBootKeySource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "SecureBootKeySource references a Secret holding Secure Boot certificates.\n\n+k8s:openapi-gen=true",
+		"secretName": "SecretName is the name of the Secret in the namespace of the vmi.",
 	}
 }
 
@@ -134,11 +213,34 @@ func (CPU) SwaggerDoc() map[string]string {
 		"":                      "CPU allows specifying the CPU topology.\n\n+k8s:openapi-gen=true",
 		"cores":                 "Cores specifies the number of cores inside the vmi.\nMust be a value greater or equal 1.",
 		"sockets":               "Sockets specifies the number of sockets inside the vmi.\nMust be a value greater or equal 1.",
+		"maxSockets":            "MaxSockets specifies the maximum amount of sockets that can\nbe hotplugged into a running vmi.\nMust be a value greater or equal to Sockets.\n+optional",
 		"threads":               "Threads specifies the number of threads inside the vmi.\nMust be a value greater or equal 1.",
 		"model":                 "Model specifies the CPU model inside the VMI.\nList of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.\nIt is possible to specify special cases like \"host-passthrough\" to get the same CPU as the node\nand \"host-model\" to get CPU closest to the node one.\nDefaults to host-model.\n+optional",
 		"features":              "Features specifies the CPU features list inside the VMI.\n+optional",
 		"dedicatedCpuPlacement": "DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node\nwith enough dedicated pCPUs and pin the vCPUs to it.\n+optional",
 		"isolateEmulatorThread": "IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place\nthe emulator thread on it.\n+optional",
+		"numa":                  "NUMA allows specifying settings for the guest NUMA topology\n+optional",
+		"realtime":              "Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads\n+optional",
+	}
+}
+
+func (NUMA) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "NUMA allows specifying settings for the guest NUMA topology.\n\n+k8s:openapi-gen=true",
+		"guestMappingPassthrough": "GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod.\nThe created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.\n+optional",
+	}
+}
+
+func (NUMAGuestMappingPassthrough) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "NUMAGuestMappingPassthrough instructs kubevirt to model numa topology which is compatible with the CPU pinning on the guest.\nThis will result in a subset of the node numa topology being passed through, ensuring that virtual numa nodes and their memory\nnever cross boundaries coming from the node numa mapping.\n\n+k8s:openapi-gen=true",
+	}
+}
+
+func (Realtime) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "Realtime holds the tuning knobs specific for realtime workloads.\n\n+k8s:openapi-gen=true",
+		"mask": "Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt's expressions.\nExample: \"0-3,^1\",\"0,2,3\",\"2-3\"\n+optional",
 	}
 }
 
@@ -155,6 +257,7 @@ func (Memory) SwaggerDoc() map[string]string {
 		"":          "Memory allows specifying the VirtualMachineInstance memory features.\n\n+k8s:openapi-gen=true",
 		"hugepages": "Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.\n+optional",
 		"guest":     "Guest allows to specifying the amount of memory which is visible inside the Guest OS.\nThe Guest must lie between Requests and Limits from the resources section.\nDefaults to the requested memory in the resources section if not specified.\n+ optional",
+		"maxGuest":  "MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS.\nThe delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.\n+ optional",
 	}
 }
 
@@ -194,12 +297,67 @@ func (Devices) SwaggerDoc() map[string]string {
 		"autoattachGraphicsDevice":   "Whether to attach the default graphics device or not.\nVNC will not be available if set to false. Defaults to true.",
 		"autoattachSerialConsole":    "Whether to attach the default serial console or not.\nSerial console access will not be available if set to false. Defaults to true.",
 		"autoattachMemBalloon":       "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
+		"freePageReporting":          "Whether to enable free page reporting on the memory balloon device, so that memory freed by\nthe guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.\n+optional",
 		"rng":                        "Whether to have random number generator from host\n+optional",
 		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices\n+optional",
 		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
 		"gpus":                       "Whether to attach a GPU device to the vmi.\n+optional\n+listType=atomic",
-		"filesystems":                "Filesystems describes filesystem which is connected to the vmi.\n+optional\n+listType=atomic",
+		"filesystems":                "Filesystems describes filesystem which is connected to the vmi.\nThe volume with the name of the filesystem is shared into the guest with virtiofs,\nit can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.\n+optional\n+listType=atomic",
 		"hostDevices":                "Whether to attach a host device to the vmi.\n+optional\n+listType=atomic",
+		"tpm":                        "Whether to emulate a TPM device.\n+optional",
+		"autoattachVSOCK":            "Whether to attach the VSOCK CID to the VM or not.\nVSOCK access will be available if set to true. Defaults to false.\n+optional",
+		"autoattachPanicDevice":      "Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor.\nDefaults to true.\n+optional",
+		"panicMemoryDump":            "If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.\n+optional",
+		"memoryDump":                 "If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource,\nfor example by VirtualMachineSnapshots which include a memory dump.\n+optional",
+		"logSerialConsole":           "Whether to mirror the output of the serial console to the virt-launcher pod log.\nThe log can be retrieved later through the console/log subresource. Defaults to false.\n+optional",
+		"serialConsoleLog":           "If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.\n+optional",
+		"serials":                    "Additional serial ports of the vmi, next to the default serial console.\nEach serial port can be connected to through the serial subresource.\n+optional\n+listType=atomic",
+		"channels":                   "Virtio channels of the vmi, e.g. for custom guest agents.\nEach channel can be connected to through the channel subresource.\n+optional\n+listType=atomic",
+	}
+}
+
+func (PanicMemoryDump) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "PanicMemoryDump configures where the memory of a panicked guest is dumped to\n\n+k8s:openapi-gen=true",
+		"claimName": "ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.",
+	}
+}
+
+func (MemoryDump) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "MemoryDump configures where the memory of the running guest is dumped to\n\n+k8s:openapi-gen=true",
+		"claimName": "ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.",
+	}
+}
+
+func (SerialConsoleLog) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "SerialConsoleLog configures where the serial console log of the guest is stored\n\n+k8s:openapi-gen=true",
+		"claimName":   "ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.",
+		"maxFileSize": "MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.\n+optional",
+		"maxFiles":    "MaxFiles is the number of rotated log files which are kept. Defaults to 3.\n+optional",
+	}
+}
+
+func (SerialPort) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "SerialPort represents an additional serial port of the vmi\n\n+k8s:openapi-gen=true",
+		"name": "Name of the serial port, used to connect to it through the serial subresource.\nMust be a DNS_LABEL and unique within the vmi.",
+	}
+}
+
+func (Channel) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "Channel represents a virtio channel of the vmi\n\n+k8s:openapi-gen=true",
+		"name":       "Name of the channel, used to connect to it through the channel subresource.\nMust be a DNS_LABEL and unique within the vmi.",
+		"targetName": "TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.",
+	}
+}
+
+func (TPMDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "TPMDevice represents the emulated TPM device passed to the vmi\n\n+k8s:openapi-gen=true",
+		"persistent": "Persistent indicates the state of the TPM device should be kept across reboots and migrations.\nThe state is stored in a per-VM backend storage volume.\nDefaults to false.\n+optional",
 	}
 }
 
@@ -222,7 +380,19 @@ func (Filesystem) SwaggerDoc() map[string]string {
 
 func (FilesystemVirtiofs) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "+k8s:openapi-gen=true",
+		"":        "+k8s:openapi-gen=true",
+		"subPath": "SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root.\nIt allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.\n+optional",
+		"uidMap":  "UIDMap maps the user IDs of the guest to the user IDs of the shared directory.\n+optional",
+		"gidMap":  "GIDMap maps the group IDs of the guest to the group IDs of the shared directory.\n+optional",
+	}
+}
+
+func (FilesystemIDMap) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "FilesystemIDMap maps a range of consecutive IDs of the guest to a range of IDs of the shared directory.\n\n+k8s:openapi-gen=true",
+		"guestID": "GuestID is the first ID of the range in the guest.",
+		"hostID":  "HostID is the first ID of the range in the shared directory.",
+		"count":   "Count is the number of IDs in the range, it must be greater than 0.",
 	}
 }
 
@@ -237,19 +407,62 @@ func (HostDevice) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":           "+k8s:openapi-gen=true",
 		"deviceName": "DeviceName is the resource name of the host device exposed by a device plugin",
+		"nvme":       "NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole\ncontroller through. QEMU drives the controller with its user space NVMe driver then.\n+optional",
+	}
+}
+
+func (HostDeviceNVMe) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "HostDeviceNVMe selects the namespace of a NVMe controller which is attached as a disk.\n\n+k8s:openapi-gen=true",
+		"namespace": "Namespace is the ID of the namespace. Defaults to 1.\n+optional",
 	}
 }
 
 func (Disk) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                  "+k8s:openapi-gen=true",
-		"name":              "Name is the device name",
-		"bootOrder":         "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach disk or interface that has a boot order must have a unique value.\nDisks without a boot order are not tried if a disk with a boot order exists.\n+optional",
-		"serial":            "Serial provides the ability to specify a serial number for the disk device.\n+optional",
-		"dedicatedIOThread": "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
-		"cache":             "Cache specifies which kvm disk cache mode should be used.\n+optional",
-		"io":                "IO specifies which QEMU disk IO mode should be used.\nSupported values are: native, default, threads.\n+optional",
-		"tag":               "If specified, disk address and its tag will be provided to the guest via config drive metadata\n+optional",
+		"":                      "+k8s:openapi-gen=true",
+		"name":                  "Name is the device name",
+		"bootOrder":             "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach disk or interface that has a boot order must have a unique value.\nDisks without a boot order are not tried if a disk with a boot order exists.\n+optional",
+		"serial":                "Serial provides the ability to specify a serial number for the disk device.\n+optional",
+		"dedicatedIOThread":     "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
+		"cache":                 "Cache specifies which kvm disk cache mode should be used.\nSupported values are: none, writethrough, writeback.\nIf not set, none is used if the storage of the volume supports direct I/O. Otherwise\nwritethrough is used on shared volumes and writeback on the others.\n+optional",
+		"io":                    "IO specifies which QEMU disk IO mode should be used.\nSupported values are: native, default, threads.\n+optional",
+		"discard":               "Discard specifies whether discard requests of the guest are passed down to the storage.\nSupported values are: unmap, ignore.\nIf not set, unmap is used unless the volume is a preallocated image, so that data deleted\nin the guest frees the space of thin provisioned storage.\n+optional",
+		"detectZeroes":          "DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them.\nSupported values are: off, on, unmap. unmap additionally frees the space of the zeroes\nand requires discard to be unmap.\nDefaults to off.\n+optional",
+		"ioTune":                "IOTune limits the I/O operations and the bandwidth of the disk.\n+optional",
+		"tag":                   "If specified, disk address and its tag will be provided to the guest via config drive metadata\n+optional",
+		"excludeFromSnapshot":   "ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots.\nThe volume is not snapshotted and the guest filesystems on the disk are not frozen.\nRestores keep the current volume.\nDefaults to false.\n+optional",
+		"encryption":            "Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret.\nThe passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.\n+optional",
+		"expandGuestFilesystem": "ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it\nafter the disk was expanded online, so that the new capacity is usable in the guest.\nIt requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest.\nDefaults to false.\n+optional",
+	}
+}
+
+func (DiskEncryption) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "DiskEncryption references the Secret with the passphrase of a LUKS encrypted volume.\n\n+k8s:openapi-gen=true",
+		"secretName": "SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.",
+		"format":     "Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption.\nDefaults to raw.\n+optional",
+	}
+}
+
+func (DiskIOTune) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DiskIOTune limits the I/O of a disk, so that it can not starve other disks sharing the same storage backend.\n\n+k8s:openapi-gen=true",
+		"iops":      "IOPS limits the I/O operations per second of the disk.\n+optional",
+		"bandwidth": "Bandwidth limits the bytes per second of the disk.\n+optional",
+	}
+}
+
+func (DiskIOLimits) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "DiskIOLimits holds the limits of one kind of I/O of a disk.\nThe total limits can not be combined with the read and write limits.\n\n+k8s:openapi-gen=true",
+		"total":       "Total limits the sum of reads and writes.\n+optional",
+		"read":        "Read limits the reads.\n+optional",
+		"write":       "Write limits the writes.\n+optional",
+		"totalBurst":  "TotalBurst allows the sum of reads and writes to exceed the total limit\nup to this value for the burst length.\n+optional",
+		"readBurst":   "ReadBurst allows the reads to exceed the read limit up to this value for the burst length.\n+optional",
+		"writeBurst":  "WriteBurst allows the writes to exceed the write limit up to this value for the burst length.\n+optional",
+		"burstLength": "BurstLength is the duration in seconds for which a burst is allowed.\nDefaults to 1.\n+optional",
 	}
 }
 
@@ -266,7 +479,7 @@ func (DiskDevice) SwaggerDoc() map[string]string {
 func (DiskTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":           "+k8s:openapi-gen=true",
-		"bus":        "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
+		"bus":        "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi, usb.",
 		"readonly":   "ReadOnly.\nDefaults to false.",
 		"pciAddress": "If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
 	}
@@ -274,9 +487,10 @@ func (DiskTarget) SwaggerDoc() map[string]string {
 
 func (LunTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "+k8s:openapi-gen=true",
-		"bus":      "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
-		"readonly": "ReadOnly.\nDefaults to false.",
+		"":            "+k8s:openapi-gen=true",
+		"bus":         "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
+		"readonly":    "ReadOnly.\nDefaults to false.",
+		"reservation": "Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk.\nThe reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler.\nIt requires the scsi bus.\n+optional",
 	}
 }
 
@@ -291,7 +505,7 @@ func (FloppyTarget) SwaggerDoc() map[string]string {
 func (CDRomTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "+k8s:openapi-gen=true",
-		"bus":      "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
+		"bus":      "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi, usb.",
 		"readonly": "ReadOnly.\nDefaults to true.",
 		"tray":     "Tray indicates if the tray of the device is open or closed.\nAllowed values are \"open\" and \"closed\".\nDefaults to closed.\n+optional",
 	}
@@ -315,11 +529,13 @@ func (VolumeSource) SwaggerDoc() map[string]string {
 		"containerDisk":         "ContainerDisk references a docker image, embedding a qcow or raw disk.\nMore info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html\n+optional",
 		"ephemeral":             "Ephemeral is a special volume source that \"wraps\" specified source and provides copy-on-write image on top of it.\n+optional",
 		"emptyDisk":             "EmptyDisk represents a temporary disk which shares the vmis lifecycle.\nMore info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html\n+optional",
+		"swapDisk":              "SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle.\nThe guest is told to enable the swap through the cloud-init vendor data.\n+optional",
 		"dataVolume":            "DataVolume represents the dynamic creation a PVC for this volume as well as\nthe process of populating that PVC with a disk image.\n+optional",
 		"configMap":             "ConfigMapSource represents a reference to a ConfigMap in the same namespace.\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/\n+optional",
 		"secret":                "SecretVolumeSource represents a reference to a secret data in the same namespace.\nMore info: https://kubernetes.io/docs/concepts/configuration/secret/\n+optional",
 		"downwardAPI":           "DownwardAPI represents downward API about the pod that should populate this volume\n+optional",
 		"serviceAccount":        "ServiceAccountVolumeSource represents a reference to a service account.\nThere can only be one volume of this type!\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/\n+optional",
+		"downwardMetrics":       "DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest\nmetrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.\n+optional",
 	}
 }
 
@@ -352,6 +568,13 @@ func (EmptyDiskSource) SwaggerDoc() map[string]string {
 	}
 }
 
+func (SwapDiskSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "SwapDisk represents a temporary disk the guest uses as encrypted swap space.\n\n+k8s:openapi-gen=true",
+		"capacity": "Capacity of the sparse swap disk.",
+	}
+}
+
 func (ContainerDiskSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                "Represents a docker image with an embedded disk.\n\n+k8s:openapi-gen=true",
@@ -521,6 +744,7 @@ func (WatchdogDevice) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Hardware watchdog device.\nExactly one of its members must be set.\n\n+k8s:openapi-gen=true",
 		"i6300esb": "i6300esb watchdog device.\n+optional",
+		"diag288":  "diag288 watchdog device, only available on s390x.\n+optional",
 	}
 }
 
@@ -531,6 +755,13 @@ func (I6300ESBWatchdog) SwaggerDoc() map[string]string {
 	}
 }
 
+func (Diag288Watchdog) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "diag288 watchdog device.\n\n+k8s:openapi-gen=true",
+		"action": "The action to take. Valid values are poweroff, reset, shutdown.\nDefaults to reset.",
+	}
+}
+
 func (Interface) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "+k8s:openapi-gen=true",
@@ -539,9 +770,75 @@ func (Interface) SwaggerDoc() map[string]string {
 		"ports":       "List of ports to be forwarded to the virtual machine.",
 		"macAddress":  "Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.",
 		"bootOrder":   "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach interface or disk that has a boot order must have a unique value.\nInterfaces without a boot order are not tried.\n+optional",
+		"romDisabled": "If set, no option ROM is exposed to the guest for the interface.\nInterfaces with a boot order have an option ROM by default, which is needed to boot from\nthe network with BIOS firmware. EFI firmware can boot from the network without it.\n+optional",
 		"pciAddress":  "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
 		"dhcpOptions": "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
 		"tag":         "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
+		"state":       "State represents the requested operational state of the interface.\nThe link of the interface is set \"down\" or \"up\", \"up\" being the default, and the guest sees a link\nwhich is down as an unplugged cable. \"absent\" is set when the interface is hot unplugged.\n+optional",
+		"binding":     "Binding specifies the binding plugin that will be used to connect the interface to the guest.\nIt provides an alternative to InterfaceBindingMethod.\n+optional",
+		"bandwidth":   "Bandwidth limits the traffic of the interface.\nIt is only supported with the bridge, masquerade and macvtap bindings.\n+optional",
+		"rss":         "RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a\nmulti-queue guest across its queues, and thus across its vCPUs.\nIt requires the virtio model and networkInterfaceMultiqueue, and is only supported with the\nbridge, masquerade and macvtap bindings.\n+optional",
+		"offloads":    "Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default.\nIt requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.\n+optional",
+		"firewall":    "Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod,\nso it applies even where NetworkPolicies don't, like on bridged secondary networks.\nIt is only supported with the bridge and masquerade bindings.\n+optional",
+	}
+}
+
+func (InterfaceRSS) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "InterfaceRSS configures receive side scaling (RSS) of a virtio-net interface.\n\n+k8s:openapi-gen=true",
+		"hashReport": "HashReport makes the device report the RSS hash of every received packet to the guest, which saves\nthe guest from computing it again. The device is emulated by QEMU instead of vhost-net then.\n+optional",
+	}
+}
+
+func (InterfaceOffloads) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "InterfaceOffloads toggles the offloads of a virtio-net interface, both from the guest to the host and\nfrom the host to the guest.\n\n+k8s:openapi-gen=true",
+		"checksum": "Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled\nwith it and can't be enabled without it.\n+optional",
+		"tso":      "TSO toggles the TCP segmentation offload, for IPv4 and IPv6.\n+optional",
+		"gso":      "GSO toggles the generic segmentation offload of the host.\n+optional",
+	}
+}
+
+func (InterfaceBandwidth) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "InterfaceBandwidth limits the traffic of an interface, from the point of view of the guest.\n\n+k8s:openapi-gen=true",
+		"inbound":  "Inbound limits the traffic received by the guest (rx).\n+optional",
+		"outbound": "Outbound limits the traffic sent by the guest (tx).\n+optional",
+	}
+}
+
+func (InterfaceFirewall) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "InterfaceFirewall allows or denies the IP traffic of an interface. The traffic of established connections\nis always allowed, as well as DHCP and IPv6 neighbor discovery.\n\n+k8s:openapi-gen=true",
+		"rules":         "Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.\n+optional",
+		"defaultAction": "DefaultAction applies to the packets which match no rule. Defaults to Allow.\nDeny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass.\nVMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label,\nwhich virt-handler sets where it is loaded.\n+optional",
+	}
+}
+
+func (FirewallRule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port.\nThe packets match when all the criteria which are set match.\n\n+k8s:openapi-gen=true",
+		"action":    "Action is Allow or Deny.",
+		"direction": "Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest.\nThe rule applies to both directions if it is not set.\n+optional",
+		"cidr":      "CIDR is the network of the remote peer, IPv4 or IPv6.\n+optional",
+		"protocol":  "Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.\n+optional",
+		"port":      "Port is the destination port, on the guest for Ingress and on the remote peer for Egress.\nIt requires the TCP, UDP or SCTP protocol.\n+optional",
+	}
+}
+
+func (BandwidthLimit) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "BandwidthLimit shapes the traffic in one direction of an interface.\n\n+k8s:openapi-gen=true",
+		"average": "Average is the rate, in bytes per second, the traffic is shaped to.",
+		"peak":    "Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.\n+optional",
+		"burst":   "Burst is the amount of bytes which can be sent at the peak rate.\n+optional",
+	}
+}
+
+func (PluginBinding) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "PluginBinding references a network binding plugin registered in the KubeVirt CR.\n\n+k8s:openapi-gen=true",
+		"name": "Name references the binding plugin name as registered in the KubeVirt CR.",
 	}
 }
 
@@ -552,6 +849,16 @@ func (DHCPOptions) SwaggerDoc() map[string]string {
 		"tftpServerName": "If specified will pass option 66 to interface's DHCP server\n+optional",
 		"ntpServers":     "If specified will pass the configured NTP server to the VM via DHCP option 042.\n+optional",
 		"privateOptions": "If specified will pass extra DHCP options for private use, range: 224-254\n+optional",
+		"mtu":            "If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.\n+optional",
+		"routes":         "If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes\nof the pod interface.\n+optional",
+	}
+}
+
+func (DHCPRoute) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "DHCPRoute defines a static route passed to the VM through DHCP.\n\n+k8s:openapi-gen=true",
+		"destination": "Destination is the IPv4 CIDR of the routed network.\nRequired.",
+		"gateway":     "Gateway is the IPv4 address of the next hop.\nRequired.",
 	}
 }
 
@@ -589,7 +896,8 @@ func (InterfaceMasquerade) SwaggerDoc() map[string]string {
 
 func (InterfaceSRIOV) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "+k8s:openapi-gen=true",
+		"":         "+k8s:openapi-gen=true",
+		"failover": "Failover names a virtio interface of the vmi which the guest teams with the VF through its\nnet_failover driver. The traffic fails over to that interface while the VF is detached for a\nlive migration. Both interfaces must have the same MAC address and be connected to the same L2 network.\n+optional",
 	}
 }
 
@@ -599,11 +907,29 @@ func (InterfaceMacvtap) SwaggerDoc() map[string]string {
 	}
 }
 
+func (InterfacePasst) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "InterfacePasst connects the guest to the pod network through passt, a user-mode network stack\nwhich forwards TCP and UDP traffic over both IPv4 and IPv6.\n\n+k8s:openapi-gen=true",
+	}
+}
+
+func (InterfaceVdpa) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface.\nThe device is allocated by a device plugin, through the resource of the Multus network.\n\n+k8s:openapi-gen=true",
+	}
+}
+
+func (InterfaceVhostUser) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP,\nthrough a vhost-user socket. The guest memory is shared with the switch.\n\n+k8s:openapi-gen=true",
+	}
+}
+
 func (Port) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Port repesents a port to expose from the virtual machine.\nDefault protocol TCP.\nThe port field is mandatory\n\n+k8s:openapi-gen=true",
 		"name":     "If specified, this must be an IANA_SVC_NAME and unique within the pod. Each\nnamed port in a pod must have a unique name. Name for the port that can be\nreferred to by services.\n+optional",
-		"protocol": "Protocol for port. Must be UDP or TCP.\nDefaults to \"TCP\".\n+optional",
+		"protocol": "Protocol for port. Must be UDP, TCP or SCTP.\nDefaults to \"TCP\".\n+optional",
 		"port":     "Number of port to expose for the virtual machine.\nThis must be a valid port number, 0 < x < 65536.",
 	}
 }
@@ -615,6 +941,12 @@ func (AccessCredentialSecretSource) SwaggerDoc() map[string]string {
 	}
 }
 
+func (NoCloudSSHPublicKeyAccessCredentialPropagation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "+k8s:openapi-gen=true",
+	}
+}
+
 func (ConfigDriveSSHPublicKeyAccessCredentialPropagation) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "+k8s:openapi-gen=true",
@@ -651,6 +983,7 @@ func (SSHPublicKeyAccessCredentialSource) SwaggerDoc() map[string]string {
 func (SSHPublicKeyAccessCredentialPropagationMethod) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":               "SSHPublicKeyAccessCredentialPropagationMethod represents the method used to\ninject a ssh public key into the vm guest.\nOnly one of its members may be specified.\n\n+k8s:openapi-gen=true",
+		"noCloud":        "NoCloudPropagation means that the ssh public keys are injected\ninto the VM using metadata using the noCloud cloud-init provider\n+optional",
 		"configDrive":    "ConfigDrivePropagation means that the ssh public keys are injected\ninto the VM using metadata using the configDrive cloud-init provider\n+optional",
 		"qemuGuestAgent": "QemuGuestAgentAccessCredentailPropagation means ssh public keys are\ndynamically injected into the vm at runtime via the qemu guest agent.\nThis feature requires the qemu guest agent to be running within the guest.\n+optional",
 	}
@@ -709,8 +1042,9 @@ func (NetworkSource) SwaggerDoc() map[string]string {
 
 func (PodNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "Represents the stock pod network interface.\n\n+k8s:openapi-gen=true",
-		"vmNetworkCIDR": "CIDR for vm network.\nDefault 10.0.2.0/24 if not specified.",
+		"":                  "Represents the stock pod network interface.\n\n+k8s:openapi-gen=true",
+		"vmNetworkCIDR":     "CIDR for vm network.\nDefault 10.0.2.0/24 if not specified.",
+		"vmIPv6NetworkCIDR": "IPv6 CIDR for the vm network.\nDefaults to fd10:0:2::/120 if not specified.",
 	}
 }
 
@@ -722,8 +1056,9 @@ func (Rng) SwaggerDoc() map[string]string {
 
 func (MultusNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":            "Represents the multus cni network.\n\n+k8s:openapi-gen=true",
-		"networkName": "References to a NetworkAttachmentDefinition CRD object. Format:\n<networkName>, <namespace>/<networkName>. If namespace is not\nspecified, VMI namespace is assumed.",
-		"default":     "Select the default network and add it to the\nmultus-cni.io/default-network annotation.",
+		"":              "Represents the multus cni network.\n\n+k8s:openapi-gen=true",
+		"networkName":   "References to a NetworkAttachmentDefinition CRD object. Format:\n<networkName>, <namespace>/<networkName>. If namespace is not\nspecified, VMI namespace is assumed.",
+		"default":       "Select the default network and add it to the\nmultus-cni.io/default-network annotation.",
+		"persistentIPs": "PersistentIPs keeps the addresses allocated by the IPAM of the network across live migrations\nand restarts of the VM. The addresses are requested through the ips field of the multus network\nselection element, so the IPAM plugin has to support static IP requests. Only bridge interfaces\nare supported.\n+optional",
 	}
 }
@@ -88,6 +88,11 @@ type VirtualMachineInstanceSpec struct {
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
 
+	// Specifies the architecture of the vm guest you are attempting to run.
+	// Defaults to the default architecture of the cluster.
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+
 	// Specification of the desired behavior of the VirtualMachineInstance on the host.
 	Domain DomainSpec `json:"domain"`
 	// NodeSelector is a selector which must be true for the vmi to fit on a node.
@@ -154,6 +159,10 @@ type VirtualMachineInstanceSpec struct {
 	// +listType=atomic
 	// +optional
 	AccessCredentials []AccessCredential `json:"accessCredentials,omitempty"`
+	// If specified, the Ignition config is passed to the guest through the fw_cfg device.
+	// Used to provision guests like Fedora CoreOS or Flatcar, which do not use cloud-init.
+	// +optional
+	Ignition *IgnitionSource `json:"ignition,omitempty"`
 }
 
 // VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual
@@ -200,6 +209,150 @@ type VirtualMachineInstanceStatus struct {
 	// +optional
 	// +listType=atomic
 	VolumeStatus []VolumeStatus `json:"volumeStatus,omitempty"`
+
+	// CurrentCPUTopology specifies the current CPU topology used by the VM workload.
+	// Current topology may differ from the desired topology in the spec while CPU hotplug
+	// takes place.
+	// +optional
+	CurrentCPUTopology *CPUTopology `json:"currentCPUTopology,omitempty"`
+
+	// Memory reports the guest memory at boot and the memory plugged into the running domain.
+	// +optional
+	Memory *MemoryStatus `json:"memory,omitempty"`
+
+	// VSOCKCID is used to track the allocated VSOCK CID in the VM.
+	// +optional
+	VSOCKCID *uint32 `json:"VSOCKCID,omitempty"`
+
+	// Backup reports the checkpoints the changed blocks of the disks are tracked from, and the running backup.
+	// +optional
+	Backup *VirtualMachineInstanceBackupStatus `json:"backup,omitempty"`
+
+	// MemoryDump reports the last memory dump of the guest.
+	// +optional
+	MemoryDump *VirtualMachineInstanceMemoryDumpStatus `json:"memoryDump,omitempty"`
+
+	// BlockCommit reports the last block commit of volumes snapshotted with external snapshots.
+	// +optional
+	BlockCommit *VirtualMachineInstanceBlockCommitStatus `json:"blockCommit,omitempty"`
+}
+
+// VirtualMachineInstanceBackupStatus reports the changed block tracking of a VirtualMachineInstance.
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceBackupStatus struct {
+	// Checkpoints are the names of the checkpoints an incremental backup can be taken from, oldest first.
+	// They are lost when the VirtualMachineInstance stops.
+	// +optional
+	// +listType=atomic
+	Checkpoints []string `json:"checkpoints,omitempty"`
+	// Checkpoint is the checkpoint created by the running backup, it is empty if no backup is running.
+	// +optional
+	Checkpoint string `json:"checkpoint,omitempty"`
+	// Incremental is the checkpoint the running backup reports the changed blocks since,
+	// it is empty if the backup is a full one.
+	// +optional
+	Incremental string `json:"incremental,omitempty"`
+	// Disks are the names of the disks included in the running backup.
+	// +optional
+	// +listType=atomic
+	Disks []string `json:"disks,omitempty"`
+}
+
+// VirtualMachineInstanceMemoryDumpStatus reports a memory dump of a VirtualMachineInstance.
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceMemoryDumpStatus struct {
+	// FileName is the name of the dump file on the memory dump PVC.
+	// +optional
+	FileName string `json:"fileName,omitempty"`
+	// Phase is the state of the memory dump.
+	// +optional
+	Phase MemoryDumpPhase `json:"phase,omitempty"`
+	// StartTimestamp is when the memory dump started.
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// EndTimestamp is when the memory dump completed or failed.
+	// +optional
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// Message explains why the memory dump failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// MemoryDumpPhase is the state of a memory dump
+type MemoryDumpPhase string
+
+const (
+	// MemoryDumpInProgress means the memory of the guest is being dumped
+	MemoryDumpInProgress MemoryDumpPhase = "InProgress"
+	// MemoryDumpCompleted means the dump file is complete
+	MemoryDumpCompleted MemoryDumpPhase = "Completed"
+	// MemoryDumpFailed means the dump failed, the dump file may be incomplete
+	MemoryDumpFailed MemoryDumpPhase = "Failed"
+)
+
+// VirtualMachineInstanceBlockCommitStatus reports a block commit, which merges the qcow2 overlays of volumes
+// snapshotted with external snapshots back into their images.
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceBlockCommitStatus struct {
+	// Volumes are the volumes whose overlays are committed.
+	// +optional
+	// +listType=atomic
+	Volumes []string `json:"volumes,omitempty"`
+	// Phase is the state of the block commit.
+	// +optional
+	Phase BlockCommitPhase `json:"phase,omitempty"`
+	// Progress is the percentage of the data of the overlays which is committed.
+	// +optional
+	Progress int32 `json:"progress,omitempty"`
+	// StartTimestamp is when the block commit started.
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// EndTimestamp is when the block commit completed or failed.
+	// +optional
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// Message explains why the block commit failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BlockCommitPhase is the state of a block commit
+type BlockCommitPhase string
+
+const (
+	// BlockCommitInProgress means the overlays are being committed into the images of the volumes
+	BlockCommitInProgress BlockCommitPhase = "InProgress"
+	// BlockCommitCompleted means the disks were switched to the images of the volumes and the overlays removed
+	BlockCommitCompleted BlockCommitPhase = "Completed"
+	// BlockCommitFailed means the block commit failed, the disks of the volumes which were not switched yet
+	// are still on their overlays
+	BlockCommitFailed BlockCommitPhase = "Failed"
+)
+
+// MemoryStatus reports the memory of a VirtualMachineInstance, including the memory
+// hotplugged while it was running.
+// +k8s:openapi-gen=true
+type MemoryStatus struct {
+	// GuestAtBoot specifies with how much memory the VirtualMachine initially booted with.
+	// +optional
+	GuestAtBoot *resource.Quantity `json:"guestAtBoot,omitempty"`
+	// GuestCurrent specifies how much memory is currently available for the VirtualMachine.
+	// +optional
+	GuestCurrent *resource.Quantity `json:"guestCurrent,omitempty"`
+	// GuestRequested specifies how much memory was requested (hotplug) for the VirtualMachine.
+	// +optional
+	GuestRequested *resource.Quantity `json:"guestRequested,omitempty"`
+}
+
+// CPUTopology allows specifying the amount of cores, sockets
+// and threads.
+// +k8s:openapi-gen=true
+type CPUTopology struct {
+	// Cores specifies the number of cores inside the vmi.
+	Cores uint32 `json:"cores,omitempty"`
+	// Sockets specifies the number of sockets inside the vmi.
+	Sockets uint32 `json:"sockets,omitempty"`
+	// Threads specifies the number of threads inside the vmi.
+	Threads uint32 `json:"threads,omitempty"`
 }
 
 // VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.
@@ -217,6 +370,72 @@ type VolumeStatus struct {
 	Message string `json:"message,omitempty"`
 	// If the volume is hotplug, this will contain the hotplug status.
 	HotplugVolume *HotplugVolumeStatus `json:"hotplugVolume,omitempty"`
+	// PersistentVolumeClaimInfo contains the capacity and the volume mode of the PVC backing the volume,
+	// if it is backed by a PVC or a DataVolume.
+	PersistentVolumeClaimInfo *PersistentVolumeClaimInfo `json:"persistentVolumeClaimInfo,omitempty"`
+	// Size is the size in bytes of the disk as seen by the guest. It grows when the PVC backing
+	// the disk is expanded while the VirtualMachineInstance is running.
+	Size int64 `json:"size,omitempty"`
+	// IOTune holds the I/O limits of the disk of the volume which are in effect in the domain.
+	IOTune *DiskIOTune `json:"ioTune,omitempty"`
+	// Multipath holds the health of the paths of the multipath map backing the block volume,
+	// if it is backed by one.
+	Multipath *VolumeMultipathStatus `json:"multipath,omitempty"`
+	// ContainerDiskVolume shows the image of a containerDisk volume.
+	// +optional
+	ContainerDiskVolume *ContainerDiskInfo `json:"containerDiskVolume,omitempty"`
+	// ExternalSnapshot shows the qcow2 overlay the guest writes to and its backing chain, if the volume
+	// was snapshotted with external snapshots.
+	// +optional
+	ExternalSnapshot *ExternalSnapshotVolumeStatus `json:"externalSnapshot,omitempty"`
+}
+
+// ContainerDiskInfo shows the image a containerDisk volume was started from.
+// +k8s:openapi-gen=true
+type ContainerDiskInfo struct {
+	// ImageDigest is the digest of the image, like sha256:<hex>. Once it is set, the virt-launcher pods
+	// of the VMI, including the ones of migration targets, pull the image by this digest.
+	ImageDigest string `json:"imageDigest"`
+	// SignatureVerified tells whether the cosign signature of the image was verified with one of the
+	// signature keys of the KubeVirt configuration.
+	// +optional
+	SignatureVerified bool `json:"signatureVerified,omitempty"`
+}
+
+// ExternalSnapshotVolumeStatus shows the files of a volume snapshotted with external snapshots.
+// +k8s:openapi-gen=true
+type ExternalSnapshotVolumeStatus struct {
+	// ActiveFile is the qcow2 overlay on the volume the guest writes to.
+	ActiveFile string `json:"activeFile"`
+	// BackingChain are the files backing the active file, from its backing file down to the base image.
+	// The guest does not write to them anymore.
+	// +listType=atomic
+	BackingChain []string `json:"backingChain,omitempty"`
+}
+
+// VolumeMultipathStatus represents the health of the paths of the multipath map backing a block volume.
+// +k8s:openapi-gen=true
+type VolumeMultipathStatus struct {
+	// Paths is the number of paths of the multipath map.
+	Paths int32 `json:"paths"`
+	// ActivePaths is the number of paths of the multipath map which are able to serve I/O.
+	// The guest is paused on I/O errors while no path is active.
+	ActivePaths int32 `json:"activePaths"`
+	// FailedPaths are the names of the block devices of the paths which are not able to serve I/O.
+	// +listType=atomic
+	FailedPaths []string `json:"failedPaths,omitempty"`
+}
+
+// PersistentVolumeClaimInfo contains the relevant information virt-handler needs cached about a PVC
+// +k8s:openapi-gen=true
+type PersistentVolumeClaimInfo struct {
+	// Capacity represents the capacity set on the corresponding PVC status
+	Capacity k8sv1.ResourceList `json:"capacity,omitempty"`
+	// VolumeMode defines what type of volume is required by the claim.
+	// Value of Filesystem is implied when not included in claim spec.
+	VolumeMode *k8sv1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+	// AccessModes contains the desired access modes the volume should have.
+	AccessModes []k8sv1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
 }
 
 // HotplugVolumeStatus represents the hotplug status of the volume
@@ -341,6 +560,44 @@ const (
 	VirtualMachineInstanceReasonInterfaceNotMigratable = "InterfaceNotLiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's network interfaces collection
 	VirtualMachineInstanceReasonHotplugNotMigratable = "HotplugNotLiveMigratable"
+	// Reason means that VMI is not live migratable because it uses AMD SEV memory encryption
+	VirtualMachineInstanceReasonSEVNotMigratable = "SEVNotLiveMigratable"
+	// Reason means that VMI is not live migratable because it is an Intel TDX trust domain
+	VirtualMachineInstanceReasonTDXNotMigratable = "TDXNotLiveMigratable"
+
+	// Indicates that a change of the amount of vCPU sockets of the running VMI was requested
+	VirtualMachineInstanceVCPUChange VirtualMachineInstanceConditionType = "HotVCPUChange"
+	// Reason means that plugging the vCPUs into the running domain failed
+	VirtualMachineInstanceReasonCPUHotplugFailed = "CPUHotplugFailed"
+	// Reason means that the VMI is live migrated to complete the vCPU hotplug on the target
+	VirtualMachineInstanceReasonCPUHotplugMigration = "CPUHotplugMigration"
+
+	// Indicates that a change of the guest memory of the running VMI was requested
+	VirtualMachineInstanceMemoryChange VirtualMachineInstanceConditionType = "HotMemoryChange"
+	// Reason means that plugging the memory into the running domain failed
+	VirtualMachineInstanceReasonMemoryHotplugFailed = "MemoryHotplugFailed"
+
+	// Indicates that the watchdog of the VMI expired and the hypervisor took the configured action
+	VirtualMachineInstanceWatchdogExpired VirtualMachineInstanceConditionType = "WatchdogExpired"
+
+	// Indicates that the guest kernel panicked
+	VirtualMachineInstanceGuestPanicked VirtualMachineInstanceConditionType = "GuestPanicked"
+
+	// Indicates that hot unplugged interfaces were not released by the guest yet
+	VirtualMachineInstanceInterfaceUnplugPending VirtualMachineInstanceConditionType = "InterfaceUnplugPending"
+	// Reason means that the guest did not release the hot unplugged interfaces
+	VirtualMachineInstanceReasonGuestInterfaceUnplugPending = "GuestInterfaceUnplugPending"
+
+	// Reflects whether the guest filesystems are frozen, e.g. for an application-consistent backup
+	VirtualMachineInstanceFrozen VirtualMachineInstanceConditionType = "Frozen"
+	// Reason means that the guest filesystems were frozen on request
+	VirtualMachineInstanceReasonFrozen = "Frozen"
+	// Reason means that the guest filesystems were thawed on request
+	VirtualMachineInstanceReasonThawed = "Thawed"
+	// Reason means that the guest filesystems were thawed because the unfreeze timeout expired
+	VirtualMachineInstanceReasonUnfreezeTimeoutExpired = "UnfreezeTimeoutExpired"
+	// Reason means that a backup hook failed on the guest
+	VirtualMachineInstanceReasonBackupHookFailed = "BackupHookFailed"
 )
 
 const (
@@ -425,6 +682,10 @@ type VirtualMachineInstanceNetworkInterface struct {
 	IPs []string `json:"ipAddresses,omitempty"`
 	// The interface name inside the Virtual Machine
 	InterfaceName string `json:"interfaceName,omitempty"`
+	// Bandwidth reports the traffic limits applied to the interface
+	Bandwidth *InterfaceBandwidth `json:"bandwidth,omitempty"`
+	// Specifies how many queues are allocated by MultiQueue
+	QueueCount int32 `json:"queueCount,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -479,6 +740,8 @@ type VirtualMachineInstanceMigrationState struct {
 	MigrationUID types.UID `json:"migrationUid,omitempty"`
 	// Lets us know if the vmi is currently running pre or post copy migration
 	Mode MigrationMode `json:"mode,omitempty"`
+	// The volumes which are copied to other PersistentVolumeClaims during the migration
+	MigratedVolumes []MigratedVolume `json:"migratedVolumes,omitempty"`
 }
 
 //
@@ -576,6 +839,15 @@ const (
 	// This annotation indicates that a migration is the result of an
 	// automated workload update
 	WorkloadUpdateMigrationAnnotation string = "kubevirt.io/workloadUpdateMigration"
+	// This annotation requests the next start of a virtual machine to boot
+	// from the network first. It is removed once the virtual machine instance
+	// is created. Used on VirtualMachine.
+	NetworkBootNextStartAnnotation string = "kubevirt.io/network-boot-next-start"
+	// This annotation holds the addresses allocated to the pods of a virtual
+	// machine on its networks with persistent IPs, as a JSON object of IP
+	// lists by network name. They are requested again by the next pods of the
+	// virtual machine. Used on VirtualMachine and VirtualMachineInstance.
+	IPClaimsAnnotation string = "kubevirt.io/ip-claims"
 	// This label declares whether a particular node is available for
 	// scheduling virtual machine instances on it. Used on Node.
 	NodeSchedulable string = "kubevirt.io/schedulable"
@@ -625,10 +897,49 @@ const (
 	VirtualMachineInstanceFinalizer          string = "foregroundDeleteVirtualMachine"
 	VirtualMachineInstanceMigrationFinalizer string = "kubevirt.io/migrationJobFinalize"
 	CPUManager                               string = "cpumanager"
+	// This label is set on nodes which support AMD SEV memory encryption
+	SEVLabel string = "kubevirt.io/sev"
+	// This label is set on nodes which support AMD SEV-ES memory and CPU state encryption
+	SEVESLabel string = "kubevirt.io/sev-es"
+	// This label is set on nodes which support AMD SEV-SNP
+	SEVSNPLabel string = "kubevirt.io/sev-snp"
+	// This label is set on nodes which support Intel TDX
+	TDXLabel string = "kubevirt.io/tdx"
+	// This label is set on nodes whose kernel does not throttle realtime scheduled processes
+	RealtimeLabel string = "kubevirt.io/realtime"
+	// This label is set on nodes which load the nf_conntrack_bridge kernel module, which interface firewalls
+	// denying by default need
+	ConntrackBridgeLabel string = "kubevirt.io/nf-conntrack-bridge"
+	// This label reports whether virt-handler enabled kernel samepage merging on the node
+	KSMEnabledLabel string = "kubevirt.io/ksm-enabled"
+	// This annotation is set on nodes where virt-handler took over the control of kernel samepage merging
+	KSMHandlerManagedAnnotation string = "kubevirt.io/ksm-handler-managed"
+	// This label reports whether virt-handler mounted an ephemeral disk backing on the node
+	EphemeralDiskBackingLabel string = "kubevirt.io/ephemeral-disk-backing"
+	// This annotation marks VMIs which are scheduled on nodes with kernel samepage merging enabled
+	// Used on VirtualMachineInstance.
+	KSMEnabledAnnotation string = "kubevirt.io/ksm-enabled"
 	// This annotation is used to inject ignition data
 	// Used on VirtualMachineInstance.
 	IgnitionAnnotation           string = "kubevirt.io/ignitiondata"
 	PlacePCIDevicesOnRootComplex string = "kubevirt.io/placePCIDevicesOnRootComplex"
+	// This annotation marks the operating system of the guest. Windows guests get
+	// the recommended Hyper-V enlightenments enabled automatically.
+	// Used on VirtualMachineInstance.
+	GuestOSAnnotation string = "kubevirt.io/guest-os"
+	GuestOSWindows    string = "windows"
+	// This annotation holds the command which is run on the guest through the
+	// guest agent before its filesystems are frozen, as a JSON array of the
+	// command and its arguments. The freeze fails if the command fails.
+	// Used on VirtualMachineInstance.
+	PreBackupHookAnnotation string = "kubevirt.io/pre-backup-hook"
+	// This annotation holds the command which is run on the guest through the
+	// guest agent after its filesystems are thawed, as a JSON array of the
+	// command and its arguments. Used on VirtualMachineInstance.
+	PostBackupHookAnnotation string = "kubevirt.io/post-backup-hook"
+	// This annotation limits how long the backup hooks may run, as a duration.
+	// It defaults to 30s. Used on VirtualMachineInstance.
+	BackupHookTimeoutAnnotation string = "kubevirt.io/backup-hook-timeout"
 
 	VirtualMachineLabel        = AppLabel + "/vm"
 	MemfdMemoryBackend  string = "kubevirt.io/memfd"
@@ -669,6 +980,8 @@ const (
 	Resumed                      SyncEvent = "Resumed"
 	AccessCredentialsSyncFailed  SyncEvent = "AccessCredentialsSyncFailed"
 	AccessCredentialsSyncSuccess SyncEvent = "AccessCredentialsSyncSuccess"
+	MemoryReclaimed              SyncEvent = "MemoryReclaimed"
+	MemoryReleased               SyncEvent = "MemoryReleased"
 )
 
 func (s SyncEvent) String() string {
@@ -899,6 +1212,22 @@ type VirtualMachineInstanceMigrationList struct {
 type VirtualMachineInstanceMigrationSpec struct {
 	// The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace
 	VMIName string `json:"vmiName,omitempty" valid:"required"`
+	// Volumes lists the volumes of the VMI which are copied to other PersistentVolumeClaims during the migration,
+	// for instance to move them to another storage class. The VMI and its VirtualMachine use the
+	// destination claims once the migration succeeded.
+	// +optional
+	Volumes []MigratedVolume `json:"volumes,omitempty"`
+}
+
+// MigratedVolume names a volume of the VMI and the PersistentVolumeClaim it is copied to.
+//
+// +k8s:openapi-gen=true
+type MigratedVolume struct {
+	// VolumeName is the name of a PersistentVolumeClaim or DataVolume volume of the VMI
+	VolumeName string `json:"volumeName"`
+	// DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to. It must have
+	// the same volume mode as the source and be at least as large.
+	DestinationClaimName string `json:"destinationClaimName"`
 }
 
 // VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.
@@ -1049,6 +1378,9 @@ const (
 	// VMI will initially be running--and restarted if a failure occurs.
 	// It will not be restarted upon successful completion.
 	RunStrategyRerunOnFailure VirtualMachineRunStrategy = "RerunOnFailure"
+	// VMI will run once and not be restarted upon completion regardless
+	// if the completion is of phase Failure or Success.
+	RunStrategyOnce VirtualMachineRunStrategy = "Once"
 )
 
 // VirtualMachineSpec describes how the proper VirtualMachine
@@ -1064,6 +1396,17 @@ type VirtualMachineSpec struct {
 	// mutually exclusive with Running
 	RunStrategy *VirtualMachineRunStrategy `json:"runStrategy,omitempty" optional:"true"`
 
+	// TTLSecondsAfterCompletion limits the lifetime of a VirtualMachine with RunStrategy Once
+	// which completed. The VirtualMachine is deleted after the given number of seconds since its completion.
+	// Only valid with RunStrategy Once.
+	TTLSecondsAfterCompletion *int32 `json:"ttlSecondsAfterCompletion,omitempty" optional:"true"`
+
+	// InstancetypeMatcher references a instancetype that is used to fill fields in Template
+	Instancetype *InstancetypeMatcher `json:"instancetype,omitempty" optional:"true"`
+
+	// PreferenceMatcher references a set of preference that is used to fill fields in Template
+	Preference *PreferenceMatcher `json:"preference,omitempty" optional:"true"`
+
 	// Template is the direct specification of VirtualMachineInstance
 	Template *VirtualMachineInstanceTemplateSpec `json:"template"`
 
@@ -1072,6 +1415,50 @@ type VirtualMachineSpec struct {
 	DataVolumeTemplates []DataVolumeTemplateSpec `json:"dataVolumeTemplates,omitempty"`
 }
 
+// InstancetypeMatcher references a instancetype that is used to fill fields in the VMI template.
+//
+// +k8s:openapi-gen=true
+type InstancetypeMatcher struct {
+	// Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype
+	Name string `json:"name"`
+
+	// Kind specifies which instancetype resource is referenced.
+	// Allowed values are: "VirtualMachineInstancetype" and "VirtualMachineClusterInstancetype".
+	// If not specified, "VirtualMachineClusterInstancetype" is used by default.
+	//
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// RevisionName specifies a ControllerRevision containing a specific copy of the
+	// VirtualMachineInstancetype or VirtualMachineClusterInstancetype to be used. This is initially
+	// captured the first time the instancetype is applied to the VirtualMachineInstance.
+	//
+	// +optional
+	RevisionName string `json:"revisionName,omitempty"`
+}
+
+// PreferenceMatcher references a set of preference that is used to fill fields in the VMI template.
+//
+// +k8s:openapi-gen=true
+type PreferenceMatcher struct {
+	// Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference
+	Name string `json:"name"`
+
+	// Kind specifies which preference resource is referenced.
+	// Allowed values are: "VirtualMachinePreference" and "VirtualMachineClusterPreference".
+	// If not specified, "VirtualMachineClusterPreference" is used by default.
+	//
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// RevisionName specifies a ControllerRevision containing a specific copy of the
+	// VirtualMachinePreference or VirtualMachineClusterPreference to be used. This is
+	// initially captured the first time the instancetype is applied to the VirtualMachineInstance.
+	//
+	// +optional
+	RevisionName string `json:"revisionName,omitempty"`
+}
+
 // StateChangeRequestType represents the existing state change requests that are possible
 //
 // +k8s:openapi-gen=true
@@ -1173,6 +1560,10 @@ const (
 
 	// This condition indicates that the VM was renamed
 	RenameConditionType VirtualMachineConditionType = "RenameOperation"
+
+	// VirtualMachineCompleted is added in a virtual machine with RunStrategy Once
+	// when its vmi completed. The reason tells if the vmi Succeeded or Failed.
+	VirtualMachineCompleted VirtualMachineConditionType = "Completed"
 )
 
 //
@@ -1208,11 +1599,21 @@ type DriverCache string
 // +k8s:openapi-gen=true
 type DriverIO string
 
+//
+// +k8s:openapi-gen=true
+type DriverDiscard string
+
+//
+// +k8s:openapi-gen=true
+type DriverDetectZeroes string
+
 const (
 	// CacheNone - I/O from the guest is not cached on the host, but may be kept in a writeback disk cache.
 	CacheNone DriverCache = "none"
 	// CacheWriteThrough - I/O from the guest is cached on the host but written through to the physical medium.
 	CacheWriteThrough DriverCache = "writethrough"
+	// CacheWriteBack - I/O from the guest is cached on the host and written to the physical medium when the guest flushes.
+	CacheWriteBack DriverCache = "writeback"
 
 	// IOThreads - User mode based threads with a shared lock that perform I/O tasks. Can impact performance but offers
 	// more predictable behaviour. This method is also takes fewer CPU cycles to submit I/O requests.
@@ -1223,6 +1624,18 @@ const (
 	// IODefault - Fallback to the default value from the kernel. With recent Kernel versions (for example RHEL-7) the
 	// default is AIO.
 	IODefault DriverIO = "default"
+
+	// DiscardUnmap - Discard requests of the guest are passed down to the storage, freeing the space of deleted data.
+	DiscardUnmap DriverDiscard = "unmap"
+	// DiscardIgnore - Discard requests of the guest are ignored.
+	DiscardIgnore DriverDiscard = "ignore"
+
+	// DetectZeroesOff - Writes of zeroes are not detected.
+	DetectZeroesOff DriverDetectZeroes = "off"
+	// DetectZeroesOn - Writes of zeroes are turned into write zeroes operations.
+	DetectZeroesOn DriverDetectZeroes = "on"
+	// DetectZeroesUnmap - Writes of zeroes additionally unmap the space of the zeroes, which requires discard to be unmap.
+	DetectZeroesUnmap DriverDetectZeroes = "unmap"
 )
 
 // Handler defines a specific action that should be taken
@@ -1236,6 +1649,19 @@ type Handler struct {
 	// TODO: implement a realistic TCP lifecycle hook
 	// +optional
 	TCPSocket *k8sv1.TCPSocketAction `json:"tcpSocket,omitempty"`
+	// Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent.
+	// If the guest agent is not available, this probe will fail.
+	// +optional
+	Exec *k8sv1.ExecAction `json:"exec,omitempty"`
+	// GuestAgentPing contacts the qemu-guest-agent for availability checks.
+	// +optional
+	GuestAgentPing *GuestAgentPing `json:"guestAgentPing,omitempty"`
+}
+
+// GuestAgentPing configures the guest-agent based ping probe
+//
+// +k8s:openapi-gen=true
+type GuestAgentPing struct {
 }
 
 // Probe describes a health check to be performed against a VirtualMachineInstance to determine whether it is
@@ -1622,23 +2048,278 @@ type RemoveVolumeOptions struct {
 	Name string `json:"name"`
 }
 
+// InsertMediaOptions is provided when inserting media into a CD-ROM of a running VMI
+// +k8s:openapi-gen=true
+type InsertMediaOptions struct {
+	// Name of the CD-ROM disk, the media is inserted as the volume of the same name.
+	Name string `json:"name"`
+	// VolumeSource represents the source of the media, like an ISO image stored on a PVC.
+	VolumeSource *HotplugVolumeSource `json:"volumeSource"`
+}
+
+// EjectMediaOptions is provided when ejecting the media from a CD-ROM of a running VMI
+// +k8s:openapi-gen=true
+type EjectMediaOptions struct {
+	// Name of the CD-ROM disk whose media is ejected.
+	Name string `json:"name"`
+}
+
+// RemoveInterfaceOptions is provided when dynamically hot unplugging a network interface
+// +k8s:openapi-gen=true
+type RemoveInterfaceOptions struct {
+	// Name indicates the logical name of the interface to be removed.
+	Name string `json:"name"`
+}
+
+// SetLinkStateOptions is provided when setting the link state of a network interface
+// +k8s:openapi-gen=true
+type SetLinkStateOptions struct {
+	// Name indicates the logical name of the interface.
+	Name string `json:"name"`
+	// State is the requested link state of the interface, either "up" or "down".
+	State InterfaceState `json:"state"`
+}
+
+// AddInterfaceOptions is provided when dynamically hot plugging a network interface
+// +k8s:openapi-gen=true
+type AddInterfaceOptions struct {
+	// NetworkAttachmentDefinitionName references a NetworkAttachmentDefinition CRD object. Format:
+	// <networkAttachmentDefinitionName>, <namespace>/<networkAttachmentDefinitionName>. If namespace is not
+	// specified, VMI namespace is assumed.
+	NetworkAttachmentDefinitionName string `json:"networkAttachmentDefinitionName"`
+	// Name indicates the logical name of the interface and of the network it is connected to.
+	Name string `json:"name"`
+}
+
+// SEVPlatformInfo contains information about the AMD SEV features for the node.
+// +k8s:openapi-gen=true
+type SEVPlatformInfo struct {
+	// Base64 encoded platform Diffie-Hellman key.
+	PDH string `json:"pdh,omitempty"`
+	// Base64 encoded SEV certificate chain.
+	CertChain string `json:"certChain,omitempty"`
+}
+
+// SEVMeasurementInfo contains information about the guest launch measurement.
+// +k8s:openapi-gen=true
+type SEVMeasurementInfo struct {
+	// Base64 encoded launch measurement of the SEV guest.
+	Measurement string `json:"measurement,omitempty"`
+	// API major version of the SEV host.
+	APIMajor uint `json:"apiMajor,omitempty"`
+	// API minor version of the SEV host.
+	APIMinor uint `json:"apiMinor,omitempty"`
+	// Build ID of the SEV host.
+	BuildID uint `json:"buildID,omitempty"`
+	// Policy of the SEV guest.
+	Policy uint `json:"policy,omitempty"`
+	// SHA256 of the loader binary
+	LoaderSHA string `json:"loaderSHA,omitempty"`
+}
+
+// SEVSecretOptions is used to provide a secret for a running guest.
+// +k8s:openapi-gen=true
+type SEVSecretOptions struct {
+	// Base64 encoded header needed to decrypt the secret.
+	Header string `json:"header,omitempty"`
+	// Base64 encoded encrypted launch secret.
+	Secret string `json:"secret,omitempty"`
+}
+
+// BackupOptions are provided when starting a backup of a running VirtualMachineInstance.
+// +k8s:openapi-gen=true
+type BackupOptions struct {
+	// Checkpoint is the name of the checkpoint created when the backup starts, the changed blocks of the next
+	// incremental backup are tracked from it.
+	Checkpoint string `json:"checkpoint"`
+	// Incremental is the name of the checkpoint the backup reports the changed blocks since. All the blocks
+	// are reported if it is empty. Older checkpoints are deleted, since the backups they were taken for are superseded.
+	// +optional
+	Incremental string `json:"incremental,omitempty"`
+}
+
+// MemoryDumpOptions are provided when dumping the memory of a running VirtualMachineInstance.
+// +k8s:openapi-gen=true
+type MemoryDumpOptions struct {
+	// FileName is the name of the file on the memory dump PVC the memory is dumped to.
+	// It must not contain a path separator.
+	FileName string `json:"fileName"`
+}
+
+// ExternalSnapshotOptions are provided when taking an external snapshot of volumes of a running VirtualMachineInstance.
+// +k8s:openapi-gen=true
+type ExternalSnapshotOptions struct {
+	// Name of the snapshot. The disk of each volume is switched to a new qcow2 overlay named <name>.qcow2,
+	// which is created next to its image. It must not contain a path separator.
+	Name string `json:"name"`
+	// Volumes are the names of the volumes to snapshot, they must be filesystem PVCs or DataVolumes.
+	// +listType=atomic
+	Volumes []string `json:"volumes"`
+}
+
+// BlockCommitOptions are provided when committing the qcow2 overlays of volumes of a running VirtualMachineInstance
+// into their images.
+// +k8s:openapi-gen=true
+type BlockCommitOptions struct {
+	// Volumes are the names of the volumes whose overlays are committed, they must have been snapshotted with
+	// external snapshots.
+	// +listType=atomic
+	Volumes []string `json:"volumes"`
+	// Overlay is the overlay of an external snapshot, only it is committed into the file below it in the chains
+	// of the volumes. All overlays are committed into the images of the volumes if it is not set.
+	// +optional
+	Overlay string `json:"overlay,omitempty"`
+	// ActiveFiles are the overlays the disks of the volumes were on when the commit was accepted, in the order of
+	// Volumes. They are set by virt-api, the commit is refused if a disk was switched to another overlay since then.
+	// +optional
+	// +listType=atomic
+	ActiveFiles []string `json:"activeFiles,omitempty"`
+}
+
+// FreezeUnfreezeTimeout represent the time unfreeze will be triggered if guest was not unfrozen by unfreeze command
+// +k8s:openapi-gen=true
+type FreezeUnfreezeTimeout struct {
+	UnfreezeTimeout *metav1.Duration `json:"unfreezeTimeout"`
+}
+
 // KubeVirtConfiguration holds all kubevirt configurations
 // +k8s:openapi-gen=true
 type KubeVirtConfiguration struct {
-	CPUModel                    string                  `json:"cpuModel,omitempty"`
-	CPURequest                  *resource.Quantity      `json:"cpuRequest,omitempty"`
-	DeveloperConfiguration      *DeveloperConfiguration `json:"developerConfiguration,omitempty"`
-	EmulatedMachines            []string                `json:"emulatedMachines,omitempty"`
-	ImagePullPolicy             k8sv1.PullPolicy        `json:"imagePullPolicy,omitempty"`
-	MigrationConfiguration      *MigrationConfiguration `json:"migrations,omitempty"`
-	MachineType                 string                  `json:"machineType,omitempty"`
-	NetworkConfiguration        *NetworkConfiguration   `json:"network,omitempty"`
-	OVMFPath                    string                  `json:"ovmfPath,omitempty"`
-	SELinuxLauncherType         string                  `json:"selinuxLauncherType,omitempty"`
-	SMBIOSConfig                *SMBiosConfiguration    `json:"smbios,omitempty"`
-	SupportedGuestAgentVersions []string                `json:"supportedGuestAgentVersions,omitempty"`
-	MemBalloonStatsPeriod       *uint32                 `json:"memBalloonStatsPeriod,omitempty"`
-	PermittedHostDevices        *PermittedHostDevices   `json:"permittedHostDevices,omitempty"`
+	CPUModel                     string                           `json:"cpuModel,omitempty"`
+	CPURequest                   *resource.Quantity               `json:"cpuRequest,omitempty"`
+	DeveloperConfiguration       *DeveloperConfiguration          `json:"developerConfiguration,omitempty"`
+	EmulatedMachines             []string                         `json:"emulatedMachines,omitempty"`
+	ImagePullPolicy              k8sv1.PullPolicy                 `json:"imagePullPolicy,omitempty"`
+	MigrationConfiguration       *MigrationConfiguration          `json:"migrations,omitempty"`
+	MachineType                  string                           `json:"machineType,omitempty"`
+	NetworkConfiguration         *NetworkConfiguration            `json:"network,omitempty"`
+	OVMFPath                     string                           `json:"ovmfPath,omitempty"`
+	SELinuxLauncherType          string                           `json:"selinuxLauncherType,omitempty"`
+	SMBIOSConfig                 *SMBiosConfiguration             `json:"smbios,omitempty"`
+	SupportedGuestAgentVersions  []string                         `json:"supportedGuestAgentVersions,omitempty"`
+	MemBalloonStatsPeriod        *uint32                          `json:"memBalloonStatsPeriod,omitempty"`
+	PermittedHostDevices         *PermittedHostDevices            `json:"permittedHostDevices,omitempty"`
+	VMStateStorageClass          string                           `json:"vmStateStorageClass,omitempty"`
+	ClusterCommonCPU             *ClusterCommonCPUConfiguration   `json:"clusterCommonCPU,omitempty"`
+	KSMConfiguration             *KSMConfiguration                `json:"ksmConfiguration,omitempty"`
+	MemoryOvercommitPolicy       *MemoryOvercommitPolicy          `json:"memoryOvercommitPolicy,omitempty"`
+	MediatedDevicesConfiguration *MediatedDevicesConfiguration    `json:"mediatedDevicesConfiguration,omitempty"`
+	ArchitectureConfiguration    *ArchConfiguration               `json:"architectureConfiguration,omitempty"`
+	ContainerDiskCache           *ContainerDiskCacheConfiguration `json:"containerDiskCache,omitempty"`
+	EphemeralDiskBackings        []EphemeralDiskBacking           `json:"ephemeralDiskBackings,omitempty"`
+	ContainerDisks               *ContainerDiskConfiguration      `json:"containerDisks,omitempty"`
+}
+
+// ArchConfiguration holds the architecture specific defaults of VMIs.
+// The deprecated machineType, emulatedMachines and ovmfPath of the KubeVirt
+// configuration take precedence over the architecture specific values if set.
+// +k8s:openapi-gen=true
+type ArchConfiguration struct {
+	Amd64   *ArchSpecificConfiguration `json:"amd64,omitempty"`
+	Arm64   *ArchSpecificConfiguration `json:"arm64,omitempty"`
+	Ppc64le *ArchSpecificConfiguration `json:"ppc64le,omitempty"`
+	S390x   *ArchSpecificConfiguration `json:"s390x,omitempty"`
+	// DefaultArchitecture is the architecture of VMIs which do not request one.
+	// Defaults to the architecture of the KubeVirt control plane.
+	// +optional
+	DefaultArchitecture string `json:"defaultArchitecture,omitempty"`
+}
+
+// ArchSpecificConfiguration holds the defaults of VMIs of a single architecture
+// +k8s:openapi-gen=true
+type ArchSpecificConfiguration struct {
+	OVMFPath         string   `json:"ovmfPath,omitempty"`
+	EmulatedMachines []string `json:"emulatedMachines,omitempty"`
+	MachineType      string   `json:"machineType,omitempty"`
+}
+
+// MemoryOvercommitPolicy defines how the memory of VMIs is overcommitted on the nodes.
+// +k8s:openapi-gen=true
+type MemoryOvercommitPolicy struct {
+	// Ratio is the percentage of the guest memory relative to the memory request of VMIs
+	// which do not request memory explicitly. A ratio of 150 results in a memory request
+	// of two thirds of the guest memory. Values below 100 are ignored.
+	// Takes precedence over developerConfiguration.memoryOvercommit.
+	// +optional
+	Ratio int `json:"ratio,omitempty"`
+	// OvercommitGuestOverhead excludes the memory overhead of all VMIs from the memory
+	// request of their pods.
+	// +optional
+	OvercommitGuestOverhead bool `json:"overcommitGuestOverhead,omitempty"`
+}
+
+// ContainerDiskCacheConfiguration enables the node cache of containerDisk images.
+// VMIs on the same node which use the same image share a single copy of it.
+// +k8s:openapi-gen=true
+type ContainerDiskCacheConfiguration struct {
+	// SizeLimit is the size of the cache on each node, beyond which the least recently
+	// used images which are not in use are removed. Defaults to 20Gi.
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+}
+
+// ContainerDiskConfiguration makes virt-controller resolve the tags of containerDisk images to digests
+// in their registries when a VMI starts, and optionally verify the cosign signatures of the images.
+// +k8s:openapi-gen=true
+type ContainerDiskConfiguration struct {
+	// ResolveDigests makes virt-controller resolve the tags of containerDisk images to digests when a VMI starts,
+	// so that its virt-launcher pods pull exactly the resolved images.
+	// +optional
+	ResolveDigests bool `json:"resolveDigests,omitempty"`
+	// SignatureKeys are PEM encoded ECDSA public keys of cosign. If any is set, the digests are resolved and a VMI
+	// only starts if each of its containerDisk images has a cosign signature made with one of the keys.
+	// +optional
+	// +listType=atomic
+	SignatureKeys []string `json:"signatureKeys,omitempty"`
+}
+
+// EphemeralDiskBacking stores the ephemeral disk data of the VMIs, like the overlays of their containerDisks
+// and ephemeral volumes, on the nodes matching its node selector outside of the ephemeral storage of their pods.
+// virt-handler mounts the first backing selecting the node on /var/lib/kubevirt/ephemeral-disks and labels the node
+// with kubevirt.io/ephemeral-disk-backing=true. The VMIs whose node selector only matches nodes selected by a backing
+// store their ephemeral disk data there and are only scheduled to nodes carrying the label.
+// Exactly one of hostPath, blockDevice and tmpfs must be set.
+// +k8s:openapi-gen=true
+type EphemeralDiskBacking struct {
+	// NodeSelector selects the nodes using the backing. An empty selector selects all nodes.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// HostPath is a directory on the node which is bind mounted to store the ephemeral disk data.
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+	// BlockDevice is a dedicated block device on the node, formatted with a filesystem,
+	// which is mounted to store the ephemeral disk data.
+	// +optional
+	BlockDevice string `json:"blockDevice,omitempty"`
+	// Tmpfs stores the ephemeral disk data in the memory of the node.
+	// +optional
+	Tmpfs *EphemeralDiskTmpfs `json:"tmpfs,omitempty"`
+}
+
+// EphemeralDiskTmpfs stores the ephemeral disk data in the memory of the node
+// +k8s:openapi-gen=true
+type EphemeralDiskTmpfs struct {
+	// SizeLimit is the size of the tmpfs. Defaults to half of the memory of the node.
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+}
+
+// KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).
+// +k8s:openapi-gen=true
+type KSMConfiguration struct {
+	// NodeLabelSelector is a selector that filters in which nodes the KSM will be enabled.
+	// Empty NodeLabelSelector will enable ksm for every node.
+	// +optional
+	NodeLabelSelector *metav1.LabelSelector `json:"nodeLabelSelector,omitempty"`
+}
+
+// ClusterCommonCPUConfiguration holds the options of the cluster-common CPU model
+// +k8s:openapi-gen=true
+type ClusterCommonCPUConfiguration struct {
+	// NodeSelector restricts the nodes whose common CPU model and features are used
+	// for VMIs requesting the cluster-common CPU model. Defaults to all schedulable nodes.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 //
@@ -1663,6 +2344,10 @@ type MigrationConfiguration struct {
 	ProgressTimeout                   *int64             `json:"progressTimeout,omitempty"`
 	UnsafeMigrationOverride           *bool              `json:"unsafeMigrationOverride,omitempty"`
 	AllowPostCopy                     *bool              `json:"allowPostCopy,omitempty"`
+	// Network is the name of a Multus NetworkAttachmentDefinition, in <namespace>/<name> or <name> format,
+	// through which the migration traffic goes. By default the pod network is used.
+	// +optional
+	Network *string `json:"network,omitempty"`
 }
 
 // DeveloperConfiguration holds developer options
@@ -1696,6 +2381,8 @@ type PermittedHostDevices struct {
 	PciHostDevices []PciHostDevice `json:"pciHostDevices,omitempty"`
 	// +listType=atomic
 	MediatedDevices []MediatedHostDevice `json:"mediatedDevices,omitempty"`
+	// +listType=atomic
+	USB []USBHostDevice `json:"usb,omitempty"`
 }
 
 // PciHostDevice represents a host PCI device allowed for passthrough
@@ -1714,10 +2401,99 @@ type MediatedHostDevice struct {
 	ExternalResourceProvider bool   `json:"externalResourceProvider,omitempty"`
 }
 
+// MediatedDevicesConfiguration holds the mediated device types which virt-handler creates on the nodes.
+// Mediated devices of other types are removed from the nodes once a configuration is present.
+// +k8s:openapi-gen=true
+type MediatedDevicesConfiguration struct {
+	// MediatedDevicesTypes are the mediated device types created on all nodes.
+	// Every parent device is configured with the first type it supports.
+	// +optional
+	// +listType=atomic
+	MediatedDevicesTypes []string `json:"mediatedDevicesTypes,omitempty"`
+	// NodeMediatedDeviceTypes override the mediated device types on the nodes matching their node selector.
+	// +optional
+	// +listType=atomic
+	NodeMediatedDeviceTypes []NodeMediatedDeviceTypesConfig `json:"nodeMediatedDeviceTypes,omitempty"`
+}
+
+// NodeMediatedDeviceTypesConfig holds the mediated device types of the nodes matching the node selector
+// +k8s:openapi-gen=true
+type NodeMediatedDeviceTypesConfig struct {
+	// NodeSelector selects the nodes the mediated device types are created on.
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// +listType=atomic
+	MediatedDevicesTypes []string `json:"mediatedDevicesTypes"`
+}
+
+// USBHostDevice represents a host USB device allowed for passthrough
+// +k8s:openapi-gen=true
+type USBHostDevice struct {
+	// USBVendorSelector selects the USB devices by their vendor:product ID, e.g. "0529:0001".
+	// +optional
+	USBVendorSelector string `json:"usbVendorSelector,omitempty"`
+	// USBPortSelector selects the USB device connected to a bus and port, as named in
+	// /sys/bus/usb/devices, e.g. "1-2.3". Exactly one of the selectors has to be set.
+	// +optional
+	USBPortSelector          string `json:"usbPortSelector,omitempty"`
+	ResourceName             string `json:"resourceName"`
+	ExternalResourceProvider bool   `json:"externalResourceProvider,omitempty"`
+}
+
 // NetworkConfiguration holds network options
 // +k8s:openapi-gen=true
 type NetworkConfiguration struct {
 	NetworkInterface                  string `json:"defaultNetworkInterface,omitempty"`
 	PermitSlirpInterface              *bool  `json:"permitSlirpInterface,omitempty"`
 	PermitBridgeInterfaceOnPodNetwork *bool  `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
+	// Binding registers the network binding plugins, by name, which VMI interfaces can reference.
+	// +optional
+	Binding map[string]InterfaceBindingPlugin `json:"binding,omitempty"`
+	// MacPool configures the range of MAC addresses which are assigned to the interfaces of VirtualMachines
+	// which don't request a specific address.
+	// +optional
+	MacPool *MacPoolConfiguration `json:"macPool,omitempty"`
+	// AttachmentPolicy restricts the NetworkAttachmentDefinitions which the VMIs of each namespace can reference.
+	// VMIs can reference any NetworkAttachmentDefinition if it is not set.
+	// +optional
+	AttachmentPolicy *NetworkAttachmentPolicy `json:"attachmentPolicy,omitempty"`
+}
+
+// InterfaceBindingPlugin describes how a network binding plugin is deployed with the virt-launcher pod.
+// +k8s:openapi-gen=true
+type InterfaceBindingPlugin struct {
+	// SidecarImage references a container image that runs in the virt-launcher pod.
+	// The sidecar adjusts the domain of the VMI through the hooks API.
+	// +optional
+	SidecarImage string `json:"sidecarImage,omitempty"`
+	// NetworkAttachmentDefinition references, in <namespace>/<name> format, the NetworkAttachmentDefinition
+	// whose CNI plugin configures the pod network of the interface.
+	// +optional
+	NetworkAttachmentDefinition string `json:"networkAttachmentDefinition,omitempty"`
+}
+
+// MacPoolConfiguration defines an inclusive range of MAC addresses.
+// +k8s:openapi-gen=true
+type MacPoolConfiguration struct {
+	// RangeStart is the first MAC address of the pool, e.g. 02:00:00:00:00:00.
+	RangeStart string `json:"rangeStart"`
+	// RangeEnd is the last MAC address of the pool, e.g. 02:00:00:ff:ff:ff.
+	RangeEnd string `json:"rangeEnd"`
+}
+
+// NetworkAttachmentPolicy grants namespaces access to NetworkAttachmentDefinitions.
+// +k8s:openapi-gen=true
+type NetworkAttachmentPolicy struct {
+	// Rules grant access to NetworkAttachmentDefinitions. A VMI can only reference the NetworkAttachmentDefinitions
+	// allowed by the rules which apply to its namespace.
+	Rules []NetworkAttachmentPolicyRule `json:"rules"`
+}
+
+// NetworkAttachmentPolicyRule allows the VMIs of namespaces to reference NetworkAttachmentDefinitions.
+// +k8s:openapi-gen=true
+type NetworkAttachmentPolicyRule struct {
+	// Namespaces the rule applies to. The names may be shell patterns, e.g. "tenant-*" or "*".
+	Namespaces []string `json:"namespaces"`
+	// NetworkAttachmentDefinitions allowed by the rule, in <namespace>/<name> format. The namespace and the name
+	// may be shell patterns, e.g. "tenant-a/*". A name without namespace refers to the namespace of the VMI.
+	NetworkAttachmentDefinitions []string `json:"networkAttachmentDefinitions"`
 }
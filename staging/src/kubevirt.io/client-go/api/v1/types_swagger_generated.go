@@ -20,6 +20,7 @@ func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                              "VirtualMachineInstanceSpec is a description of a VirtualMachineInstance.\n\n+k8s:openapi-gen=true",
 		"priorityClassName":             "If specified, indicates the pod's priority.\nIf not specified, the pod priority will be default or zero if there is no\ndefault.\n+optional",
+		"architecture":                  "Specifies the architecture of the vm guest you are attempting to run.\nDefaults to the default architecture of the cluster.\n+optional",
 		"domain":                        "Specification of the desired behavior of the VirtualMachineInstance on the host.",
 		"nodeSelector":                  "NodeSelector is a selector which must be true for the vmi to fit on a node.\nSelector which must match a node's labels for the vmi to be scheduled on that node.\nMore info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/\n+optional",
 		"affinity":                      "If affinity is specifies, obey all the affinity rules",
@@ -36,6 +37,7 @@ func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 		"dnsPolicy":                     "Set DNS policy for the pod.\nDefaults to \"ClusterFirst\".\nValid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'.\nDNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy.\nTo have DNS options set along with hostNetwork, you have to specify DNS policy\nexplicitly to 'ClusterFirstWithHostNet'.\n+optional",
 		"dnsConfig":                     "Specifies the DNS parameters of a pod.\nParameters specified here will be merged to the generated DNS\nconfiguration based on DNSPolicy.\n+optional",
 		"accessCredentials":             "Specifies a set of public keys to inject into the vm guest\n+listType=atomic\n+optional",
+		"ignition":                      "If specified, the Ignition config is passed to the guest through the fw_cfg device.\nUsed to provision guests like Fedora CoreOS or Flatcar, which do not use cloud-init.\n+optional",
 	}
 }
 
@@ -55,18 +57,115 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"evacuationNodeName":            "EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want\nto evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.\n+optional",
 		"activePods":                    "ActivePods is a mapping of pod UID to node name.\nIt is possible for multiple pods to be running for a single VMI during migration.",
 		"volumeStatus":                  "VolumeStatus contains the statuses of all the volumes\n+optional\n+listType=atomic",
+		"currentCPUTopology":            "CurrentCPUTopology specifies the current CPU topology used by the VM workload.\nCurrent topology may differ from the desired topology in the spec while CPU hotplug\ntakes place.\n+optional",
+		"memory":                        "Memory reports the guest memory at boot and the memory plugged into the running domain.\n+optional",
+		"VSOCKCID":                      "VSOCKCID is used to track the allocated VSOCK CID in the VM.\n+optional",
+		"backup":                        "Backup reports the checkpoints the changed blocks of the disks are tracked from, and the running backup.\n+optional",
+		"memoryDump":                    "MemoryDump reports the last memory dump of the guest.\n+optional",
+		"blockCommit":                   "BlockCommit reports the last block commit of volumes snapshotted with external snapshots.\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceBackupStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachineInstanceBackupStatus reports the changed block tracking of a VirtualMachineInstance.\n+k8s:openapi-gen=true",
+		"checkpoints": "Checkpoints are the names of the checkpoints an incremental backup can be taken from, oldest first.\nThey are lost when the VirtualMachineInstance stops.\n+optional\n+listType=atomic",
+		"checkpoint":  "Checkpoint is the checkpoint created by the running backup, it is empty if no backup is running.\n+optional",
+		"incremental": "Incremental is the checkpoint the running backup reports the changed blocks since,\nit is empty if the backup is a full one.\n+optional",
+		"disks":       "Disks are the names of the disks included in the running backup.\n+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineInstanceMemoryDumpStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineInstanceMemoryDumpStatus reports a memory dump of a VirtualMachineInstance.\n+k8s:openapi-gen=true",
+		"fileName":       "FileName is the name of the dump file on the memory dump PVC.\n+optional",
+		"phase":          "Phase is the state of the memory dump.\n+optional",
+		"startTimestamp": "StartTimestamp is when the memory dump started.\n+optional",
+		"endTimestamp":   "EndTimestamp is when the memory dump completed or failed.\n+optional",
+		"message":        "Message explains why the memory dump failed.\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceBlockCommitStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineInstanceBlockCommitStatus reports a block commit, which merges the qcow2 overlays of volumes\nsnapshotted with external snapshots back into their images.\n+k8s:openapi-gen=true",
+		"volumes":        "Volumes are the volumes whose overlays are committed.\n+optional\n+listType=atomic",
+		"phase":          "Phase is the state of the block commit.\n+optional",
+		"progress":       "Progress is the percentage of the data of the overlays which is committed.\n+optional",
+		"startTimestamp": "StartTimestamp is when the block commit started.\n+optional",
+		"endTimestamp":   "EndTimestamp is when the block commit completed or failed.\n+optional",
+		"message":        "Message explains why the block commit failed.\n+optional",
+	}
+}
+
+func (MemoryStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "MemoryStatus reports the memory of a VirtualMachineInstance, including the memory\nhotplugged while it was running.\n+k8s:openapi-gen=true",
+		"guestAtBoot":    "GuestAtBoot specifies with how much memory the VirtualMachine initially booted with.\n+optional",
+		"guestCurrent":   "GuestCurrent specifies how much memory is currently available for the VirtualMachine.\n+optional",
+		"guestRequested": "GuestRequested specifies how much memory was requested (hotplug) for the VirtualMachine.\n+optional",
+	}
+}
+
+func (CPUTopology) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "CPUTopology allows specifying the amount of cores, sockets\nand threads.\n+k8s:openapi-gen=true",
+		"cores":   "Cores specifies the number of cores inside the vmi.",
+		"sockets": "Sockets specifies the number of sockets inside the vmi.",
+		"threads": "Threads specifies the number of threads inside the vmi.",
 	}
 }
 
 func (VolumeStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.\n+k8s:openapi-gen=true",
-		"name":          "Name is the name of the volume",
-		"target":        "Target is the target name used when adding the volume to the VM, eg: vda",
-		"phase":         "Phase is the phase",
-		"reason":        "Reason is a brief description of why we are in the current hotplug volume phase",
-		"message":       "Message is a detailed message about the current hotplug volume phase",
-		"hotplugVolume": "If the volume is hotplug, this will contain the hotplug status.",
+		"":                          "VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.\n+k8s:openapi-gen=true",
+		"name":                      "Name is the name of the volume",
+		"target":                    "Target is the target name used when adding the volume to the VM, eg: vda",
+		"phase":                     "Phase is the phase",
+		"reason":                    "Reason is a brief description of why we are in the current hotplug volume phase",
+		"message":                   "Message is a detailed message about the current hotplug volume phase",
+		"hotplugVolume":             "If the volume is hotplug, this will contain the hotplug status.",
+		"persistentVolumeClaimInfo": "PersistentVolumeClaimInfo contains the capacity and the volume mode of the PVC backing the volume,\nif it is backed by a PVC or a DataVolume.",
+		"size":                      "Size is the size in bytes of the disk as seen by the guest. It grows when the PVC backing\nthe disk is expanded while the VirtualMachineInstance is running.",
+		"ioTune":                    "IOTune holds the I/O limits of the disk of the volume which are in effect in the domain.",
+		"multipath":                 "Multipath holds the health of the paths of the multipath map backing the block volume,\nif it is backed by one.",
+		"containerDiskVolume":       "ContainerDiskVolume shows the image of a containerDisk volume.\n+optional",
+		"externalSnapshot":          "ExternalSnapshot shows the qcow2 overlay the guest writes to and its backing chain, if the volume\nwas snapshotted with external snapshots.\n+optional",
+	}
+}
+
+func (ContainerDiskInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "ContainerDiskInfo shows the image a containerDisk volume was started from.\n+k8s:openapi-gen=true",
+		"imageDigest":       "ImageDigest is the digest of the image, like sha256:<hex>. Once it is set, the virt-launcher pods\nof the VMI, including the ones of migration targets, pull the image by this digest.",
+		"signatureVerified": "SignatureVerified tells whether the cosign signature of the image was verified with one of the\nsignature keys of the KubeVirt configuration.\n+optional",
+	}
+}
+
+func (ExternalSnapshotVolumeStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "ExternalSnapshotVolumeStatus shows the files of a volume snapshotted with external snapshots.\n+k8s:openapi-gen=true",
+		"activeFile":   "ActiveFile is the qcow2 overlay on the volume the guest writes to.",
+		"backingChain": "BackingChain are the files backing the active file, from its backing file down to the base image.\nThe guest does not write to them anymore.\n+listType=atomic",
+	}
+}
+
+func (VolumeMultipathStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VolumeMultipathStatus represents the health of the paths of the multipath map backing a block volume.\n+k8s:openapi-gen=true",
+		"paths":       "Paths is the number of paths of the multipath map.",
+		"activePaths": "ActivePaths is the number of paths of the multipath map which are able to serve I/O.\nThe guest is paused on I/O errors while no path is active.",
+		"failedPaths": "FailedPaths are the names of the block devices of the paths which are not able to serve I/O.\n+listType=atomic",
+	}
+}
+
+func (PersistentVolumeClaimInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "PersistentVolumeClaimInfo contains the relevant information virt-handler needs cached about a PVC\n+k8s:openapi-gen=true",
+		"capacity":    "Capacity represents the capacity set on the corresponding PVC status",
+		"volumeMode":  "VolumeMode defines what type of volume is required by the claim.\nValue of Filesystem is implied when not included in claim spec.",
+		"accessModes": "AccessModes contains the desired access modes the volume should have.",
 	}
 }
 
@@ -102,6 +201,8 @@ func (VirtualMachineInstanceNetworkInterface) SwaggerDoc() map[string]string {
 		"name":          "Name of the interface, corresponds to name of the network assigned to the interface",
 		"ipAddresses":   "List of all IP addresses of a Virtual Machine interface",
 		"interfaceName": "The interface name inside the Virtual Machine",
+		"bandwidth":     "Bandwidth reports the traffic limits applied to the interface",
+		"queueCount":    "Specifies how many queues are allocated by MultiQueue",
 	}
 }
 
@@ -136,6 +237,7 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"abortStatus":                    "Indicates the final status of the live migration abortion",
 		"migrationUid":                   "The VirtualMachineInstanceMigration object associated with this migration",
 		"mode":                           "Lets us know if the vmi is currently running pre or post copy migration",
+		"migratedVolumes":                "The volumes which are copied to other PersistentVolumeClaims during the migration",
 	}
 }
 
@@ -224,6 +326,15 @@ func (VirtualMachineInstanceMigrationSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":        "+k8s:openapi-gen=true",
 		"vmiName": "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"volumes": "Volumes lists the volumes of the VMI which are copied to other PersistentVolumeClaims during the migration,\nfor instance to move them to another storage class. The VMI and its VirtualMachine use the\ndestination claims once the migration succeeded.\n+optional",
+	}
+}
+
+func (MigratedVolume) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "MigratedVolume names a volume of the VMI and the PersistentVolumeClaim it is copied to.\n\n+k8s:openapi-gen=true",
+		"volumeName":           "VolumeName is the name of a PersistentVolumeClaim or DataVolume volume of the VMI",
+		"destinationClaimName": "DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to. It must have\nthe same volume mode as the source and be at least as large.",
 	}
 }
 
@@ -270,11 +381,32 @@ func (VirtualMachineList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                    "VirtualMachineSpec describes how the proper VirtualMachine\nshould look like\n\n+k8s:openapi-gen=true",
-		"running":             "Running controls whether the associatied VirtualMachineInstance is created or not\nMutually exclusive with RunStrategy",
-		"runStrategy":         "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
-		"template":            "Template is the direct specification of VirtualMachineInstance",
-		"dataVolumeTemplates": "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+		"":                          "VirtualMachineSpec describes how the proper VirtualMachine\nshould look like\n\n+k8s:openapi-gen=true",
+		"running":                   "Running controls whether the associatied VirtualMachineInstance is created or not\nMutually exclusive with RunStrategy",
+		"runStrategy":               "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
+		"ttlSecondsAfterCompletion": "TTLSecondsAfterCompletion limits the lifetime of a VirtualMachine with RunStrategy Once\nwhich completed. The VirtualMachine is deleted after the given number of seconds since its completion.\nOnly valid with RunStrategy Once.",
+		"instancetype":              "InstancetypeMatcher references a instancetype that is used to fill fields in Template",
+		"preference":                "PreferenceMatcher references a set of preference that is used to fill fields in Template",
+		"template":                  "Template is the direct specification of VirtualMachineInstance",
+		"dataVolumeTemplates":       "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+	}
+}
+
+func (InstancetypeMatcher) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "InstancetypeMatcher references a instancetype that is used to fill fields in the VMI template.\n\n+k8s:openapi-gen=true",
+		"name":         "Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype",
+		"kind":         "Kind specifies which instancetype resource is referenced.\nAllowed values are: \"VirtualMachineInstancetype\" and \"VirtualMachineClusterInstancetype\".\nIf not specified, \"VirtualMachineClusterInstancetype\" is used by default.\n\n+optional",
+		"revisionName": "RevisionName specifies a ControllerRevision containing a specific copy of the\nVirtualMachineInstancetype or VirtualMachineClusterInstancetype to be used. This is initially\ncaptured the first time the instancetype is applied to the VirtualMachineInstance.\n\n+optional",
+	}
+}
+
+func (PreferenceMatcher) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "PreferenceMatcher references a set of preference that is used to fill fields in the VMI template.\n\n+k8s:openapi-gen=true",
+		"name":         "Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference",
+		"kind":         "Kind specifies which preference resource is referenced.\nAllowed values are: \"VirtualMachinePreference\" and \"VirtualMachineClusterPreference\".\nIf not specified, \"VirtualMachineClusterPreference\" is used by default.\n\n+optional",
+		"revisionName": "RevisionName specifies a ControllerRevision containing a specific copy of the\nVirtualMachinePreference or VirtualMachineClusterPreference to be used. This is\ninitially captured the first time the instancetype is applied to the VirtualMachineInstance.\n\n+optional",
 	}
 }
 
@@ -327,9 +459,17 @@ func (VirtualMachineCondition) SwaggerDoc() map[string]string {
 
 func (Handler) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":          "Handler defines a specific action that should be taken",
-		"httpGet":   "HTTPGet specifies the http request to perform.\n+optional",
-		"tcpSocket": "TCPSocket specifies an action involving a TCP port.\nTCP hooks not yet supported\n+optional",
+		"":               "Handler defines a specific action that should be taken",
+		"httpGet":        "HTTPGet specifies the http request to perform.\n+optional",
+		"tcpSocket":      "TCPSocket specifies an action involving a TCP port.\nTCP hooks not yet supported\n+optional",
+		"exec":           "Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent.\nIf the guest agent is not available, this probe will fail.\n+optional",
+		"guestAgentPing": "GuestAgentPing contacts the qemu-guest-agent for availability checks.\n+optional",
+	}
+}
+
+func (GuestAgentPing) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "GuestAgentPing configures the guest-agent based ping probe\n\n+k8s:openapi-gen=true",
 	}
 }
 
@@ -495,12 +635,183 @@ func (RemoveVolumeOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (InsertMediaOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "InsertMediaOptions is provided when inserting media into a CD-ROM of a running VMI\n+k8s:openapi-gen=true",
+		"name":         "Name of the CD-ROM disk, the media is inserted as the volume of the same name.",
+		"volumeSource": "VolumeSource represents the source of the media, like an ISO image stored on a PVC.",
+	}
+}
+
+func (EjectMediaOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "EjectMediaOptions is provided when ejecting the media from a CD-ROM of a running VMI\n+k8s:openapi-gen=true",
+		"name": "Name of the CD-ROM disk whose media is ejected.",
+	}
+}
+
+func (RemoveInterfaceOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "RemoveInterfaceOptions is provided when dynamically hot unplugging a network interface\n+k8s:openapi-gen=true",
+		"name": "Name indicates the logical name of the interface to be removed.",
+	}
+}
+
+func (SetLinkStateOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "SetLinkStateOptions is provided when setting the link state of a network interface\n+k8s:openapi-gen=true",
+		"name":  "Name indicates the logical name of the interface.",
+		"state": "State is the requested link state of the interface, either \"up\" or \"down\".",
+	}
+}
+
+func (AddInterfaceOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                "AddInterfaceOptions is provided when dynamically hot plugging a network interface\n+k8s:openapi-gen=true",
+		"networkAttachmentDefinitionName": "NetworkAttachmentDefinitionName references a NetworkAttachmentDefinition CRD object. Format:\n<networkAttachmentDefinitionName>, <namespace>/<networkAttachmentDefinitionName>. If namespace is not\nspecified, VMI namespace is assumed.",
+		"name":                            "Name indicates the logical name of the interface and of the network it is connected to.",
+	}
+}
+
+func (SEVPlatformInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "SEVPlatformInfo contains information about the AMD SEV features for the node.\n+k8s:openapi-gen=true",
+		"pdh":       "Base64 encoded platform Diffie-Hellman key.",
+		"certChain": "Base64 encoded SEV certificate chain.",
+	}
+}
+
+func (SEVMeasurementInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "SEVMeasurementInfo contains information about the guest launch measurement.\n+k8s:openapi-gen=true",
+		"measurement": "Base64 encoded launch measurement of the SEV guest.",
+		"apiMajor":    "API major version of the SEV host.",
+		"apiMinor":    "API minor version of the SEV host.",
+		"buildID":     "Build ID of the SEV host.",
+		"policy":      "Policy of the SEV guest.",
+		"loaderSHA":   "SHA256 of the loader binary",
+	}
+}
+
+func (SEVSecretOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "SEVSecretOptions is used to provide a secret for a running guest.\n+k8s:openapi-gen=true",
+		"header": "Base64 encoded header needed to decrypt the secret.",
+		"secret": "Base64 encoded encrypted launch secret.",
+	}
+}
+
+func (BackupOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "BackupOptions are provided when starting a backup of a running VirtualMachineInstance.\n+k8s:openapi-gen=true",
+		"checkpoint":  "Checkpoint is the name of the checkpoint created when the backup starts, the changed blocks of the next\nincremental backup are tracked from it.",
+		"incremental": "Incremental is the name of the checkpoint the backup reports the changed blocks since. All the blocks\nare reported if it is empty. Older checkpoints are deleted, since the backups they were taken for are superseded.\n+optional",
+	}
+}
+
+func (MemoryDumpOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "MemoryDumpOptions are provided when dumping the memory of a running VirtualMachineInstance.\n+k8s:openapi-gen=true",
+		"fileName": "FileName is the name of the file on the memory dump PVC the memory is dumped to.\nIt must not contain a path separator.",
+	}
+}
+
+func (ExternalSnapshotOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "ExternalSnapshotOptions are provided when taking an external snapshot of volumes of a running VirtualMachineInstance.\n+k8s:openapi-gen=true",
+		"name":    "Name of the snapshot. The disk of each volume is switched to a new qcow2 overlay named <name>.qcow2,\nwhich is created next to its image. It must not contain a path separator.",
+		"volumes": "Volumes are the names of the volumes to snapshot, they must be filesystem PVCs or DataVolumes.\n+listType=atomic",
+	}
+}
+
+func (BlockCommitOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "BlockCommitOptions are provided when committing the qcow2 overlays of volumes of a running VirtualMachineInstance\ninto their images.\n+k8s:openapi-gen=true",
+		"volumes":     "Volumes are the names of the volumes whose overlays are committed, they must have been snapshotted with\nexternal snapshots.\n+listType=atomic",
+		"overlay":     "Overlay is the overlay of an external snapshot, only it is committed into the file below it in the chains\nof the volumes. All overlays are committed into the images of the volumes if it is not set.\n+optional",
+		"activeFiles": "ActiveFiles are the overlays the disks of the volumes were on when the commit was accepted, in the order of\nVolumes. They are set by virt-api, the commit is refused if a disk was switched to another overlay since then.\n+optional\n+listType=atomic",
+	}
+}
+
+func (FreezeUnfreezeTimeout) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "FreezeUnfreezeTimeout represent the time unfreeze will be triggered if guest was not unfrozen by unfreeze command\n+k8s:openapi-gen=true",
+	}
+}
+
 func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "KubeVirtConfiguration holds all kubevirt configurations\n+k8s:openapi-gen=true",
 	}
 }
 
+func (ArchConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "ArchConfiguration holds the architecture specific defaults of VMIs.\nThe deprecated machineType, emulatedMachines and ovmfPath of the KubeVirt\nconfiguration take precedence over the architecture specific values if set.\n+k8s:openapi-gen=true",
+		"defaultArchitecture": "DefaultArchitecture is the architecture of VMIs which do not request one.\nDefaults to the architecture of the KubeVirt control plane.\n+optional",
+	}
+}
+
+func (ArchSpecificConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "ArchSpecificConfiguration holds the defaults of VMIs of a single architecture\n+k8s:openapi-gen=true",
+	}
+}
+
+func (ClusterCommonCPUConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "ClusterCommonCPUConfiguration holds the options of the cluster-common CPU model\n+k8s:openapi-gen=true",
+		"nodeSelector": "NodeSelector restricts the nodes whose common CPU model and features are used\nfor VMIs requesting the cluster-common CPU model. Defaults to all schedulable nodes.\n+optional",
+	}
+}
+
+func (ContainerDiskCacheConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "ContainerDiskCacheConfiguration enables the node cache of containerDisk images.\nVMIs on the same node which use the same image share a single copy of it.\n+k8s:openapi-gen=true",
+		"sizeLimit": "SizeLimit is the size of the cache on each node, beyond which the least recently\nused images which are not in use are removed. Defaults to 20Gi.\n+optional",
+	}
+}
+
+func (ContainerDiskConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "ContainerDiskConfiguration makes virt-controller resolve the tags of containerDisk images to digests\nin their registries when a VMI starts, and optionally verify the cosign signatures of the images.\n+k8s:openapi-gen=true",
+		"resolveDigests": "ResolveDigests makes virt-controller resolve the tags of containerDisk images to digests when a VMI starts,\nso that its virt-launcher pods pull exactly the resolved images.\n+optional",
+		"signatureKeys":  "SignatureKeys are PEM encoded ECDSA public keys of cosign. If any is set, the digests are resolved and a VMI\nonly starts if each of its containerDisk images has a cosign signature made with one of the keys.\n+optional\n+listType=atomic",
+	}
+}
+
+func (EphemeralDiskBacking) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "EphemeralDiskBacking stores the ephemeral disk data of the VMIs, like the overlays of their containerDisks\nand ephemeral volumes, on the nodes matching its node selector outside of the ephemeral storage of their pods.\nvirt-handler mounts the first backing selecting the node on /var/lib/kubevirt/ephemeral-disks and labels the node\nwith kubevirt.io/ephemeral-disk-backing=true. The VMIs whose node selector only matches nodes selected by a backing\nstore their ephemeral disk data there and are only scheduled to nodes carrying the label.\nExactly one of hostPath, blockDevice and tmpfs must be set.\n+k8s:openapi-gen=true",
+		"nodeSelector": "NodeSelector selects the nodes using the backing. An empty selector selects all nodes.\n+optional",
+		"hostPath":     "HostPath is a directory on the node which is bind mounted to store the ephemeral disk data.\n+optional",
+		"blockDevice":  "BlockDevice is a dedicated block device on the node, formatted with a filesystem,\nwhich is mounted to store the ephemeral disk data.\n+optional",
+		"tmpfs":        "Tmpfs stores the ephemeral disk data in the memory of the node.\n+optional",
+	}
+}
+
+func (EphemeralDiskTmpfs) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "EphemeralDiskTmpfs stores the ephemeral disk data in the memory of the node\n+k8s:openapi-gen=true",
+		"sizeLimit": "SizeLimit is the size of the tmpfs. Defaults to half of the memory of the node.\n+optional",
+	}
+}
+
+func (KSMConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).\n+k8s:openapi-gen=true",
+		"nodeLabelSelector": "NodeLabelSelector is a selector that filters in which nodes the KSM will be enabled.\nEmpty NodeLabelSelector will enable ksm for every node.\n+optional",
+	}
+}
+
+func (MemoryOvercommitPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "MemoryOvercommitPolicy defines how the memory of VMIs is overcommitted on the nodes.\n+k8s:openapi-gen=true",
+		"ratio":                   "Ratio is the percentage of the guest memory relative to the memory request of VMIs\nwhich do not request memory explicitly. A ratio of 150 results in a memory request\nof two thirds of the guest memory. Values below 100 are ignored.\nTakes precedence over developerConfiguration.memoryOvercommit.\n+optional",
+		"overcommitGuestOverhead": "OvercommitGuestOverhead excludes the memory overhead of all VMIs from the memory\nrequest of their pods.\n+optional",
+	}
+}
+
 func (SMBiosConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "+k8s:openapi-gen=true",
@@ -509,7 +820,8 @@ func (SMBiosConfiguration) SwaggerDoc() map[string]string {
 
 func (MigrationConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "MigrationConfiguration holds migration options\n+k8s:openapi-gen=true",
+		"":        "MigrationConfiguration holds migration options\n+k8s:openapi-gen=true",
+		"network": "Network is the name of a Multus NetworkAttachmentDefinition, in <namespace>/<name> or <name> format,\nthrough which the migration traffic goes. By default the pod network is used.\n+optional",
 	}
 }
 
@@ -531,6 +843,7 @@ func (PermittedHostDevices) SwaggerDoc() map[string]string {
 		"":                "PermittedHostDevices holds inforamtion about devices allowed for passthrough\n+k8s:openapi-gen=true",
 		"pciHostDevices":  "+listType=atomic",
 		"mediatedDevices": "+listType=atomic",
+		"usb":             "+listType=atomic",
 	}
 }
 
@@ -546,8 +859,66 @@ func (MediatedHostDevice) SwaggerDoc() map[string]string {
 	}
 }
 
+func (USBHostDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "USBHostDevice represents a host USB device allowed for passthrough\n+k8s:openapi-gen=true",
+		"usbVendorSelector": "USBVendorSelector selects the USB devices by their vendor:product ID, e.g. \"0529:0001\".\n+optional",
+		"usbPortSelector":   "USBPortSelector selects the USB device connected to a bus and port, as named in\n/sys/bus/usb/devices, e.g. \"1-2.3\". Exactly one of the selectors has to be set.\n+optional",
+	}
+}
+
+func (MediatedDevicesConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "MediatedDevicesConfiguration holds the mediated device types which virt-handler creates on the nodes.\nMediated devices of other types are removed from the nodes once a configuration is present.\n+k8s:openapi-gen=true",
+		"mediatedDevicesTypes":    "MediatedDevicesTypes are the mediated device types created on all nodes.\nEvery parent device is configured with the first type it supports.\n+optional\n+listType=atomic",
+		"nodeMediatedDeviceTypes": "NodeMediatedDeviceTypes override the mediated device types on the nodes matching their node selector.\n+optional\n+listType=atomic",
+	}
+}
+
+func (NodeMediatedDeviceTypesConfig) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "NodeMediatedDeviceTypesConfig holds the mediated device types of the nodes matching the node selector\n+k8s:openapi-gen=true",
+		"nodeSelector":         "NodeSelector selects the nodes the mediated device types are created on.",
+		"mediatedDevicesTypes": "+listType=atomic",
+	}
+}
+
 func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"":                 "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"binding":          "Binding registers the network binding plugins, by name, which VMI interfaces can reference.\n+optional",
+		"macPool":          "MacPool configures the range of MAC addresses which are assigned to the interfaces of VirtualMachines\nwhich don't request a specific address.\n+optional",
+		"attachmentPolicy": "AttachmentPolicy restricts the NetworkAttachmentDefinitions which the VMIs of each namespace can reference.\nVMIs can reference any NetworkAttachmentDefinition if it is not set.\n+optional",
+	}
+}
+
+func (InterfaceBindingPlugin) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                            "InterfaceBindingPlugin describes how a network binding plugin is deployed with the virt-launcher pod.\n+k8s:openapi-gen=true",
+		"sidecarImage":                "SidecarImage references a container image that runs in the virt-launcher pod.\nThe sidecar adjusts the domain of the VMI through the hooks API.\n+optional",
+		"networkAttachmentDefinition": "NetworkAttachmentDefinition references, in <namespace>/<name> format, the NetworkAttachmentDefinition\nwhose CNI plugin configures the pod network of the interface.\n+optional",
+	}
+}
+
+func (MacPoolConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "MacPoolConfiguration defines an inclusive range of MAC addresses.\n+k8s:openapi-gen=true",
+		"rangeStart": "RangeStart is the first MAC address of the pool, e.g. 02:00:00:00:00:00.",
+		"rangeEnd":   "RangeEnd is the last MAC address of the pool, e.g. 02:00:00:ff:ff:ff.",
+	}
+}
+
+func (NetworkAttachmentPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "NetworkAttachmentPolicy grants namespaces access to NetworkAttachmentDefinitions.\n+k8s:openapi-gen=true",
+		"rules": "Rules grant access to NetworkAttachmentDefinitions. A VMI can only reference the NetworkAttachmentDefinitions\nallowed by the rules which apply to its namespace.",
+	}
+}
+
+func (NetworkAttachmentPolicyRule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                             "NetworkAttachmentPolicyRule allows the VMIs of namespaces to reference NetworkAttachmentDefinitions.\n+k8s:openapi-gen=true",
+		"namespaces":                   "Namespaces the rule applies to. The names may be shell patterns, e.g. \"tenant-*\" or \"*\".",
+		"networkAttachmentDefinitions": "NetworkAttachmentDefinitions allowed by the rule, in <namespace>/<name> format. The namespace and the name\nmay be shell patterns, e.g. \"tenant-a/*\". A name without namespace refers to the namespace of the VMI.",
 	}
 }
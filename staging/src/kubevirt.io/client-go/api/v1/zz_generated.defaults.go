@@ -145,6 +145,9 @@ func SetObjectDefaults_VirtualMachine(in *VirtualMachine) {
 			if in.Spec.Template.Spec.Domain.Devices.Watchdog.WatchdogDevice.I6300ESB != nil {
 				SetDefaults_I6300ESBWatchdog(in.Spec.Template.Spec.Domain.Devices.Watchdog.WatchdogDevice.I6300ESB)
 			}
+			if in.Spec.Template.Spec.Domain.Devices.Watchdog.WatchdogDevice.Diag288 != nil {
+				SetDefaults_Diag288Watchdog(in.Spec.Template.Spec.Domain.Devices.Watchdog.WatchdogDevice.Diag288)
+			}
 		}
 	}
 	for i := range in.Status.VolumeRequests {
@@ -261,6 +264,9 @@ func SetObjectDefaults_VirtualMachineInstance(in *VirtualMachineInstance) {
 		if in.Spec.Domain.Devices.Watchdog.WatchdogDevice.I6300ESB != nil {
 			SetDefaults_I6300ESBWatchdog(in.Spec.Domain.Devices.Watchdog.WatchdogDevice.I6300ESB)
 		}
+		if in.Spec.Domain.Devices.Watchdog.WatchdogDevice.Diag288 != nil {
+			SetDefaults_Diag288Watchdog(in.Spec.Domain.Devices.Watchdog.WatchdogDevice.Diag288)
+		}
 	}
 }
 
@@ -369,6 +375,9 @@ func SetObjectDefaults_VirtualMachineInstancePreset(in *VirtualMachineInstancePr
 			if in.Spec.Domain.Devices.Watchdog.WatchdogDevice.I6300ESB != nil {
 				SetDefaults_I6300ESBWatchdog(in.Spec.Domain.Devices.Watchdog.WatchdogDevice.I6300ESB)
 			}
+			if in.Spec.Domain.Devices.Watchdog.WatchdogDevice.Diag288 != nil {
+				SetDefaults_Diag288Watchdog(in.Spec.Domain.Devices.Watchdog.WatchdogDevice.Diag288)
+			}
 		}
 	}
 }
@@ -478,6 +487,9 @@ func SetObjectDefaults_VirtualMachineInstanceReplicaSet(in *VirtualMachineInstan
 			if in.Spec.Template.Spec.Domain.Devices.Watchdog.WatchdogDevice.I6300ESB != nil {
 				SetDefaults_I6300ESBWatchdog(in.Spec.Template.Spec.Domain.Devices.Watchdog.WatchdogDevice.I6300ESB)
 			}
+			if in.Spec.Template.Spec.Domain.Devices.Watchdog.WatchdogDevice.Diag288 != nil {
+				SetDefaults_Diag288Watchdog(in.Spec.Template.Spec.Domain.Devices.Watchdog.WatchdogDevice.Diag288)
+			}
 		}
 	}
 }
@@ -0,0 +1,142 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StorageCheckup is a CRD that runs a short diagnostic of a storage class.
+// It provisions a scratch volume, measures it, attaches it to a scratch VirtualMachineInstance
+// and takes and restores a snapshot of it, then reports the results in its status.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type StorageCheckup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StorageCheckupSpec `json:"spec"`
+
+	// +optional
+	Status *StorageCheckupStatus `json:"status,omitempty"`
+}
+
+// StorageCheckupList is a list of StorageCheckup resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type StorageCheckupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []StorageCheckup `json:"items"`
+}
+
+// StorageCheckupSpec is the spec for a StorageCheckup resource
+type StorageCheckupSpec struct {
+	// StorageClassName is the name of the storage class to check
+	StorageClassName string `json:"storageClassName"`
+
+	// VolumeMode is the volume mode of the scratch volume. The default of the storage class is used if not set.
+	// +optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+
+	// Size is the size of the scratch volume. Defaults to 1Gi.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// VolumeSnapshotClassName is the name of the VolumeSnapshotClass used for the snapshot and restore round-trip.
+	// If not set, the VolumeSnapshotClass matching the provisioner of the storage class is used,
+	// and the round-trip is skipped if there is none.
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+
+	// Timeout bounds the duration of the whole checkup. Defaults to 10m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// StorageCheckupPhase is the current phase of the StorageCheckup
+type StorageCheckupPhase string
+
+const (
+	// Pending means the checkup did not start yet
+	Pending StorageCheckupPhase = "Pending"
+
+	// Running means the checkup is in progress
+	Running StorageCheckupPhase = "Running"
+
+	// Succeeded means all the steps of the checkup completed
+	Succeeded StorageCheckupPhase = "Succeeded"
+
+	// Failed means one of the steps of the checkup failed or the checkup timed out
+	Failed StorageCheckupPhase = "Failed"
+)
+
+// StorageCheckupStatus is the status for a StorageCheckup resource
+type StorageCheckupStatus struct {
+	// +optional
+	Phase StorageCheckupPhase `json:"phase,omitempty"`
+
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Message describes the step the checkup is running or why it failed
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	Results *StorageCheckupResults `json:"results,omitempty"`
+}
+
+// StorageCheckupResults are the measurements of a StorageCheckup.
+// The measurements of the steps which did not run are not set.
+type StorageCheckupResults struct {
+	// ProvisionDuration is the time it took for the scratch volume to be bound
+	// +optional
+	ProvisionDuration *metav1.Duration `json:"provisionDuration,omitempty"`
+
+	// WriteThroughput is the sequential write throughput of the scratch volume, in bytes per second
+	// +optional
+	WriteThroughput *resource.Quantity `json:"writeThroughput,omitempty"`
+
+	// ReadThroughput is the sequential read throughput of the scratch volume, in bytes per second
+	// +optional
+	ReadThroughput *resource.Quantity `json:"readThroughput,omitempty"`
+
+	// WriteLatency is the average latency of a 4KiB write on the scratch volume
+	// +optional
+	WriteLatency *metav1.Duration `json:"writeLatency,omitempty"`
+
+	// ReadLatency is the average latency of a 4KiB read on the scratch volume
+	// +optional
+	ReadLatency *metav1.Duration `json:"readLatency,omitempty"`
+
+	// AttachDuration is the time it took for a VirtualMachineInstance using the scratch volume to be running
+	// +optional
+	AttachDuration *metav1.Duration `json:"attachDuration,omitempty"`
+
+	// SnapshotRestoreDuration is the time it took to snapshot the scratch volume and to restore the snapshot to a new volume
+	// +optional
+	SnapshotRestoreDuration *metav1.Duration `json:"snapshotRestoreDuration,omitempty"`
+}
@@ -0,0 +1,150 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineClone is a CRD that clones one VM into another.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineClone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineCloneSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineCloneStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineCloneList is a list of VirtualMachineClone resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineCloneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineClone `json:"items"`
+}
+
+// VirtualMachineCloneSpec is the spec for a VirtualMachineClone resource
+type VirtualMachineCloneSpec struct {
+	// Source is the object that would be cloned. Currently supported source types are:
+	// VirtualMachine of kubevirt.io API group and
+	// VirtualMachineSnapshot of snapshot.kubevirt.io API group
+	Source corev1.TypedLocalObjectReference `json:"source"`
+
+	// Target is the VirtualMachine to create. If the name is empty,
+	// a name is generated.
+	// +optional
+	Target *corev1.TypedLocalObjectReference `json:"target,omitempty"`
+
+	// AnnotationFilters selects the annotations of the source that are copied to the target.
+	// Entries are glob patterns, a leading "!" excludes the matching keys and
+	// the last matching entry wins. All annotations are copied if empty.
+	// Example use: "!some/key*".
+	// +optional
+	// +listType=atomic
+	AnnotationFilters []string `json:"annotationFilters,omitempty"`
+
+	// LabelFilters selects the labels of the source that are copied to the target.
+	// Entries are glob patterns, a leading "!" excludes the matching keys and
+	// the last matching entry wins. All labels are copied if empty.
+	// Example use: "!some/key*".
+	// +optional
+	// +listType=atomic
+	LabelFilters []string `json:"labelFilters,omitempty"`
+
+	// NewMacAddresses manually sets that target interfaces' mac addresses. The key is the interface name and the
+	// value is the new mac address. If this field is not specified, a new MAC address will
+	// be generated automatically, as for any interface that is not part of this map.
+	// +optional
+	NewMacAddresses map[string]string `json:"newMacAddresses,omitempty"`
+
+	// NewSMBiosSerial manually sets that target's SMbios serial. If this field is not specified, a new serial will
+	// be generated automatically.
+	// +optional
+	NewSMBiosSerial *string `json:"newSMBiosSerial,omitempty"`
+}
+
+// VirtualMachineClonePhase is the current phase of the VirtualMachineClone
+type VirtualMachineClonePhase string
+
+const (
+	// Pending means the clone is waiting for the source to become available
+	Pending VirtualMachineClonePhase = "Pending"
+
+	// Succeeded means the target VirtualMachine was created
+	Succeeded VirtualMachineClonePhase = "Succeeded"
+
+	// Failed means the clone can't be completed
+	Failed VirtualMachineClonePhase = "Failed"
+)
+
+// VirtualMachineCloneStatus is the status for a VirtualMachineClone resource
+type VirtualMachineCloneStatus struct {
+	// +optional
+	Phase VirtualMachineClonePhase `json:"phase,omitempty"`
+
+	// TargetName is the name of the VirtualMachine the clone creates
+	// +optional
+	TargetName *string `json:"targetName,omitempty"`
+
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// ConditionType is the const type for Conditions
+type ConditionType string
+
+const (
+	// ConditionReady is the "ready" condition type
+	ConditionReady ConditionType = "Ready"
+
+	// ConditionProgressing is the "progressing" condition type
+	ConditionProgressing ConditionType = "Progressing"
+)
+
+// Condition defines conditions
+type Condition struct {
+	Type ConditionType `json:"type"`
+
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+	// +nullable
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
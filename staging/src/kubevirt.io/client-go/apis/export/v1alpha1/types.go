@@ -0,0 +1,213 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineExport defines the operation of exporting a VM source
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineExportSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineExportStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineExportList is a list of VirtualMachineExport resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineExport `json:"items"`
+}
+
+// VirtualMachineExportSpec is the spec for a VirtualMachineExport resource
+type VirtualMachineExportSpec struct {
+	// Source is the object to export, one of PersistentVolumeClaim,
+	// VirtualMachine or VirtualMachineSnapshot
+	Source corev1.TypedLocalObjectReference `json:"source"`
+
+	// TokenSecretRef is the name of the secret that contains the token
+	// clients have to present to the export server
+	TokenSecretRef string `json:"tokenSecretRef"`
+
+	// ContainerDisk packages the volumes of the source into containerDisk images and pushes
+	// them to a registry, instead of serving them. The token is not used in this mode.
+	// +optional
+	ContainerDisk *VirtualMachineExportContainerDisk `json:"containerDisk,omitempty"`
+}
+
+// VirtualMachineExportContainerDisk describes the registry the volumes are pushed to as containerDisk images
+type VirtualMachineExportContainerDisk struct {
+	// Image is the reference the volume is pushed to, e.g. registry.example.com/images/fedora:36.
+	// If the source has more than one volume, the tag of each image is suffixed with the name of its volume.
+	Image string `json:"image"`
+
+	// SecretRef is the name of the secret of type kubernetes.io/dockerconfigjson
+	// holding the credentials of the registry
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// InsecureSkipTLSVerify skips the verification of the certificate of the registry
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// VirtualMachineExportPhase is the current phase of the VirtualMachineExport
+type VirtualMachineExportPhase string
+
+const (
+	// Pending means the export server is not available yet
+	Pending VirtualMachineExportPhase = "Pending"
+
+	// Ready means the export server is serving the volumes
+	Ready VirtualMachineExportPhase = "Ready"
+
+	// Skipped means the source cannot be exported right now,
+	// for example because it is in use by a running VM
+	Skipped VirtualMachineExportPhase = "Skipped"
+
+	// Succeeded means the volumes have been pushed as containerDisk images
+	Succeeded VirtualMachineExportPhase = "Succeeded"
+
+	// Failed means pushing the volumes as containerDisk images failed
+	Failed VirtualMachineExportPhase = "Failed"
+)
+
+// VirtualMachineExportStatus is the status for a VirtualMachineExport resource
+type VirtualMachineExportStatus struct {
+	// +optional
+	Phase VirtualMachineExportPhase `json:"phase,omitempty"`
+
+	// +optional
+	Links *VirtualMachineExportLinks `json:"links,omitempty"`
+
+	// ServiceName is the name of the service created for the export server
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// ContainerDisks are the containerDisk images the volumes were pushed to
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ContainerDisks []VirtualMachineExportContainerDiskImage `json:"containerDisks,omitempty"`
+}
+
+// VirtualMachineExportContainerDiskImage is the containerDisk image a volume was pushed to
+type VirtualMachineExportContainerDiskImage struct {
+	Name string `json:"name"`
+
+	// Image is the reference of the pushed image including its digest
+	Image string `json:"image"`
+}
+
+// VirtualMachineExportLinks contains the links that point to the export server
+type VirtualMachineExportLinks struct {
+	// Internal contains the links reachable from within the cluster
+	// +optional
+	Internal *VirtualMachineExportLink `json:"internal,omitempty"`
+}
+
+// VirtualMachineExportLink contains the certificate of the export server
+// and the urls of the exported volumes
+type VirtualMachineExportLink struct {
+	// Cert is the PEM encoded certificate the export server presents
+	Cert string `json:"cert"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Volumes []VirtualMachineExportVolume `json:"volumes,omitempty"`
+}
+
+// VirtualMachineExportVolume contains the urls of a single exported volume
+type VirtualMachineExportVolume struct {
+	Name string `json:"name"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=format
+	Formats []VirtualMachineExportVolumeFormat `json:"formats,omitempty"`
+}
+
+// ExportVolumeFormat is the format a volume is served in
+type ExportVolumeFormat string
+
+const (
+	// KubeVirtRaw is the raw disk image
+	KubeVirtRaw ExportVolumeFormat = "raw"
+
+	// KubeVirtGz is the gzip compressed raw disk image
+	KubeVirtGz ExportVolumeFormat = "gzip"
+
+	// KubeVirtQcow2 is the disk image converted to qcow2
+	KubeVirtQcow2 ExportVolumeFormat = "qcow2"
+)
+
+// VirtualMachineExportVolumeFormat contains the url of a volume in a given format
+type VirtualMachineExportVolumeFormat struct {
+	Format ExportVolumeFormat `json:"format"`
+
+	Url string `json:"url"`
+}
+
+// ConditionType is the const type for Conditions
+type ConditionType string
+
+const (
+	// ConditionReady is the "ready" condition type
+	ConditionReady ConditionType = "Ready"
+
+	// ConditionPVC is the condition type reporting the state of the exported PVCs
+	ConditionPVC ConditionType = "PVCReady"
+)
+
+// Condition defines conditions
+type Condition struct {
+	Type ConditionType `json:"type"`
+
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+	// +nullable
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,152 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2022 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+)
+
+// VirtualMachinePool resource contains a VirtualMachine configuration
+// that can be used to replicate multiple VirtualMachine resources.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachinePoolSpec `json:"spec" valid:"required"`
+
+	// +optional
+	Status VirtualMachinePoolStatus `json:"status,omitempty"`
+}
+
+// VirtualMachinePoolList is a list of VirtualMachinePool resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachinePool `json:"items"`
+}
+
+// VirtualMachineTemplateSpec describes the VirtualMachines created by a pool
+type VirtualMachineTemplateSpec struct {
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +nullable
+	ObjectMeta metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// VirtualMachineSpec contains the VirtualMachine specification.
+	Spec virtv1.VirtualMachineSpec `json:"spec,omitempty" valid:"required"`
+}
+
+// VirtualMachinePoolNameGeneration controls how the names of objects referenced
+// by the template are made unique for every VirtualMachine of the pool
+type VirtualMachinePoolNameGeneration struct {
+	// AppendIndexToConfigMapRefs appends the VirtualMachine's sequence index to the
+	// names of referenced ConfigMaps, so each replica can consume its own ConfigMap.
+	// +optional
+	AppendIndexToConfigMapRefs *bool `json:"appendIndexToConfigMapRefs,omitempty"`
+
+	// AppendIndexToSecretRefs appends the VirtualMachine's sequence index to the
+	// names of referenced Secrets, including cloud-init user and network data
+	// secrets, so each replica can consume its own Secret.
+	// +optional
+	AppendIndexToSecretRefs *bool `json:"appendIndexToSecretRefs,omitempty"`
+}
+
+// VirtualMachinePoolSpec is the spec for a VirtualMachinePool resource
+type VirtualMachinePoolSpec struct {
+	// Number of desired VirtualMachines. This is a pointer to distinguish between explicit
+	// zero and not specified. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Label selector for VirtualMachines. Existing VirtualMachines selected by
+	// this will be the ones affected by the pool.
+	Selector *metav1.LabelSelector `json:"selector" valid:"required"`
+
+	// Template describes the VirtualMachines that will be created.
+	VirtualMachineTemplate *VirtualMachineTemplateSpec `json:"virtualMachineTemplate" valid:"required"`
+
+	// Indicates that the pool is paused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Options for the name generation of the objects referenced by the template
+	// +optional
+	NameGeneration *VirtualMachinePoolNameGeneration `json:"nameGeneration,omitempty"`
+}
+
+// VirtualMachinePoolConditionType is the const type for VirtualMachinePool conditions
+type VirtualMachinePoolConditionType string
+
+const (
+	// VirtualMachinePoolReplicaFailure is added in a pool when one of its VirtualMachines
+	// fails to be created or deleted.
+	VirtualMachinePoolReplicaFailure VirtualMachinePoolConditionType = "ReplicaFailure"
+
+	// VirtualMachinePoolReplicaPaused is added in a pool when the pool got paused by the controller.
+	// After this condition was added, it is safe to remove or add VirtualMachines by hand and adjust
+	// the replica count by hand.
+	VirtualMachinePoolReplicaPaused VirtualMachinePoolConditionType = "ReplicaPaused"
+)
+
+// VirtualMachinePoolCondition defines pool conditions
+type VirtualMachinePoolCondition struct {
+	Type VirtualMachinePoolConditionType `json:"type"`
+
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+	// +nullable
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VirtualMachinePoolStatus is the status for a VirtualMachinePool resource
+type VirtualMachinePoolStatus struct {
+	// Total number of VirtualMachines targeted by this pool (their labels match the selector).
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// The number of ready VirtualMachines of this pool.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []VirtualMachinePoolCondition `json:"conditions,omitempty"`
+
+	// Canonical form of the label selector for HPA which consumes it through the scale subresource.
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
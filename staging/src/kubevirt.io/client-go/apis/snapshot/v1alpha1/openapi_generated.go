@@ -287,40 +287,64 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                    schema_pkg_apis_meta_v1_WatchEvent(ref),
 		"kubevirt.io/client-go/api/v1.AccessCredential":                                      schema_kubevirtio_client_go_api_v1_AccessCredential(ref),
 		"kubevirt.io/client-go/api/v1.AccessCredentialSecretSource":                          schema_kubevirtio_client_go_api_v1_AccessCredentialSecretSource(ref),
+		"kubevirt.io/client-go/api/v1.AddInterfaceOptions":                                   schema_kubevirtio_client_go_api_v1_AddInterfaceOptions(ref),
 		"kubevirt.io/client-go/api/v1.AddVolumeOptions":                                      schema_kubevirtio_client_go_api_v1_AddVolumeOptions(ref),
+		"kubevirt.io/client-go/api/v1.ArchConfiguration":                                     schema_kubevirtio_client_go_api_v1_ArchConfiguration(ref),
+		"kubevirt.io/client-go/api/v1.ArchSpecificConfiguration":                             schema_kubevirtio_client_go_api_v1_ArchSpecificConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.AuthorizedKeysFile":                                    schema_kubevirtio_client_go_api_v1_AuthorizedKeysFile(ref),
 		"kubevirt.io/client-go/api/v1.BIOS":                                                  schema_kubevirtio_client_go_api_v1_BIOS(ref),
+		"kubevirt.io/client-go/api/v1.BackupOptions":                                         schema_kubevirtio_client_go_api_v1_BackupOptions(ref),
+		"kubevirt.io/client-go/api/v1.BandwidthLimit":                                        schema_kubevirtio_client_go_api_v1_BandwidthLimit(ref),
+		"kubevirt.io/client-go/api/v1.BlockCommitOptions":                                    schema_kubevirtio_client_go_api_v1_BlockCommitOptions(ref),
 		"kubevirt.io/client-go/api/v1.Bootloader":                                            schema_kubevirtio_client_go_api_v1_Bootloader(ref),
 		"kubevirt.io/client-go/api/v1.CDRomTarget":                                           schema_kubevirtio_client_go_api_v1_CDRomTarget(ref),
 		"kubevirt.io/client-go/api/v1.CPU":                                                   schema_kubevirtio_client_go_api_v1_CPU(ref),
 		"kubevirt.io/client-go/api/v1.CPUFeature":                                            schema_kubevirtio_client_go_api_v1_CPUFeature(ref),
+		"kubevirt.io/client-go/api/v1.CPUTopology":                                           schema_kubevirtio_client_go_api_v1_CPUTopology(ref),
+		"kubevirt.io/client-go/api/v1.Channel":                                               schema_kubevirtio_client_go_api_v1_Channel(ref),
 		"kubevirt.io/client-go/api/v1.Chassis":                                               schema_kubevirtio_client_go_api_v1_Chassis(ref),
 		"kubevirt.io/client-go/api/v1.Clock":                                                 schema_kubevirtio_client_go_api_v1_Clock(ref),
 		"kubevirt.io/client-go/api/v1.ClockOffset":                                           schema_kubevirtio_client_go_api_v1_ClockOffset(ref),
 		"kubevirt.io/client-go/api/v1.ClockOffsetUTC":                                        schema_kubevirtio_client_go_api_v1_ClockOffsetUTC(ref),
 		"kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource":                            schema_kubevirtio_client_go_api_v1_CloudInitConfigDriveSource(ref),
 		"kubevirt.io/client-go/api/v1.CloudInitNoCloudSource":                                schema_kubevirtio_client_go_api_v1_CloudInitNoCloudSource(ref),
+		"kubevirt.io/client-go/api/v1.ClusterCommonCPUConfiguration":                         schema_kubevirtio_client_go_api_v1_ClusterCommonCPUConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.ComponentConfig":                                       schema_kubevirtio_client_go_api_v1_ComponentConfig(ref),
 		"kubevirt.io/client-go/api/v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation":    schema_kubevirtio_client_go_api_v1_ConfigDriveSSHPublicKeyAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.ConfigMapVolumeSource":                                 schema_kubevirtio_client_go_api_v1_ConfigMapVolumeSource(ref),
+		"kubevirt.io/client-go/api/v1.ContainerDiskCacheConfiguration":                       schema_kubevirtio_client_go_api_v1_ContainerDiskCacheConfiguration(ref),
+		"kubevirt.io/client-go/api/v1.ContainerDiskConfiguration":                            schema_kubevirtio_client_go_api_v1_ContainerDiskConfiguration(ref),
+		"kubevirt.io/client-go/api/v1.ContainerDiskInfo":                                     schema_kubevirtio_client_go_api_v1_ContainerDiskInfo(ref),
 		"kubevirt.io/client-go/api/v1.ContainerDiskSource":                                   schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref),
 		"kubevirt.io/client-go/api/v1.CustomizeComponents":                                   schema_kubevirtio_client_go_api_v1_CustomizeComponents(ref),
 		"kubevirt.io/client-go/api/v1.CustomizeComponentsPatch":                              schema_kubevirtio_client_go_api_v1_CustomizeComponentsPatch(ref),
 		"kubevirt.io/client-go/api/v1.DHCPOptions":                                           schema_kubevirtio_client_go_api_v1_DHCPOptions(ref),
 		"kubevirt.io/client-go/api/v1.DHCPPrivateOptions":                                    schema_kubevirtio_client_go_api_v1_DHCPPrivateOptions(ref),
+		"kubevirt.io/client-go/api/v1.DHCPRoute":                                             schema_kubevirtio_client_go_api_v1_DHCPRoute(ref),
 		"kubevirt.io/client-go/api/v1.DataVolumeSource":                                      schema_kubevirtio_client_go_api_v1_DataVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.DataVolumeTemplateDummyStatus":                         schema_kubevirtio_client_go_api_v1_DataVolumeTemplateDummyStatus(ref),
 		"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec":                                schema_kubevirtio_client_go_api_v1_DataVolumeTemplateSpec(ref),
 		"kubevirt.io/client-go/api/v1.DeveloperConfiguration":                                schema_kubevirtio_client_go_api_v1_DeveloperConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.Devices":                                               schema_kubevirtio_client_go_api_v1_Devices(ref),
+		"kubevirt.io/client-go/api/v1.Diag288Watchdog":                                       schema_kubevirtio_client_go_api_v1_Diag288Watchdog(ref),
 		"kubevirt.io/client-go/api/v1.Disk":                                                  schema_kubevirtio_client_go_api_v1_Disk(ref),
 		"kubevirt.io/client-go/api/v1.DiskDevice":                                            schema_kubevirtio_client_go_api_v1_DiskDevice(ref),
+		"kubevirt.io/client-go/api/v1.DiskEncryption":                                        schema_kubevirtio_client_go_api_v1_DiskEncryption(ref),
+		"kubevirt.io/client-go/api/v1.DiskIOLimits":                                          schema_kubevirtio_client_go_api_v1_DiskIOLimits(ref),
+		"kubevirt.io/client-go/api/v1.DiskIOThreads":                                         schema_kubevirtio_client_go_api_v1_DiskIOThreads(ref),
+		"kubevirt.io/client-go/api/v1.DiskIOTune":                                            schema_kubevirtio_client_go_api_v1_DiskIOTune(ref),
 		"kubevirt.io/client-go/api/v1.DiskTarget":                                            schema_kubevirtio_client_go_api_v1_DiskTarget(ref),
 		"kubevirt.io/client-go/api/v1.DomainSpec":                                            schema_kubevirtio_client_go_api_v1_DomainSpec(ref),
 		"kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource":                               schema_kubevirtio_client_go_api_v1_DownwardAPIVolumeSource(ref),
+		"kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource":                           schema_kubevirtio_client_go_api_v1_DownwardMetricsVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.EFI":                                                   schema_kubevirtio_client_go_api_v1_EFI(ref),
+		"kubevirt.io/client-go/api/v1.EjectMediaOptions":                                     schema_kubevirtio_client_go_api_v1_EjectMediaOptions(ref),
 		"kubevirt.io/client-go/api/v1.EmptyDiskSource":                                       schema_kubevirtio_client_go_api_v1_EmptyDiskSource(ref),
+		"kubevirt.io/client-go/api/v1.EphemeralDiskBacking":                                  schema_kubevirtio_client_go_api_v1_EphemeralDiskBacking(ref),
+		"kubevirt.io/client-go/api/v1.EphemeralDiskTmpfs":                                    schema_kubevirtio_client_go_api_v1_EphemeralDiskTmpfs(ref),
 		"kubevirt.io/client-go/api/v1.EphemeralVolumeSource":                                 schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref),
+		"kubevirt.io/client-go/api/v1.ExternalSnapshotOptions":                               schema_kubevirtio_client_go_api_v1_ExternalSnapshotOptions(ref),
+		"kubevirt.io/client-go/api/v1.ExternalSnapshotVolumeStatus":                          schema_kubevirtio_client_go_api_v1_ExternalSnapshotVolumeStatus(ref),
 		"kubevirt.io/client-go/api/v1.FeatureAPIC":                                           schema_kubevirtio_client_go_api_v1_FeatureAPIC(ref),
 		"kubevirt.io/client-go/api/v1.FeatureHyperv":                                         schema_kubevirtio_client_go_api_v1_FeatureHyperv(ref),
 		"kubevirt.io/client-go/api/v1.FeatureKVM":                                            schema_kubevirtio_client_go_api_v1_FeatureKVM(ref),
@@ -329,26 +353,43 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.FeatureVendorID":                                       schema_kubevirtio_client_go_api_v1_FeatureVendorID(ref),
 		"kubevirt.io/client-go/api/v1.Features":                                              schema_kubevirtio_client_go_api_v1_Features(ref),
 		"kubevirt.io/client-go/api/v1.Filesystem":                                            schema_kubevirtio_client_go_api_v1_Filesystem(ref),
+		"kubevirt.io/client-go/api/v1.FilesystemIDMap":                                       schema_kubevirtio_client_go_api_v1_FilesystemIDMap(ref),
 		"kubevirt.io/client-go/api/v1.FilesystemVirtiofs":                                    schema_kubevirtio_client_go_api_v1_FilesystemVirtiofs(ref),
+		"kubevirt.io/client-go/api/v1.FirewallRule":                                          schema_kubevirtio_client_go_api_v1_FirewallRule(ref),
 		"kubevirt.io/client-go/api/v1.Firmware":                                              schema_kubevirtio_client_go_api_v1_Firmware(ref),
 		"kubevirt.io/client-go/api/v1.FloppyTarget":                                          schema_kubevirtio_client_go_api_v1_FloppyTarget(ref),
+		"kubevirt.io/client-go/api/v1.FreezeUnfreezeTimeout":                                 schema_kubevirtio_client_go_api_v1_FreezeUnfreezeTimeout(ref),
 		"kubevirt.io/client-go/api/v1.GPU":                                                   schema_kubevirtio_client_go_api_v1_GPU(ref),
+		"kubevirt.io/client-go/api/v1.GuestAgentPing":                                        schema_kubevirtio_client_go_api_v1_GuestAgentPing(ref),
 		"kubevirt.io/client-go/api/v1.HPETTimer":                                             schema_kubevirtio_client_go_api_v1_HPETTimer(ref),
 		"kubevirt.io/client-go/api/v1.HostDevice":                                            schema_kubevirtio_client_go_api_v1_HostDevice(ref),
+		"kubevirt.io/client-go/api/v1.HostDeviceNVMe":                                        schema_kubevirtio_client_go_api_v1_HostDeviceNVMe(ref),
 		"kubevirt.io/client-go/api/v1.HostDisk":                                              schema_kubevirtio_client_go_api_v1_HostDisk(ref),
 		"kubevirt.io/client-go/api/v1.HotplugVolumeSource":                                   schema_kubevirtio_client_go_api_v1_HotplugVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.HotplugVolumeStatus":                                   schema_kubevirtio_client_go_api_v1_HotplugVolumeStatus(ref),
 		"kubevirt.io/client-go/api/v1.Hugepages":                                             schema_kubevirtio_client_go_api_v1_Hugepages(ref),
 		"kubevirt.io/client-go/api/v1.HypervTimer":                                           schema_kubevirtio_client_go_api_v1_HypervTimer(ref),
 		"kubevirt.io/client-go/api/v1.I6300ESBWatchdog":                                      schema_kubevirtio_client_go_api_v1_I6300ESBWatchdog(ref),
+		"kubevirt.io/client-go/api/v1.IgnitionSource":                                        schema_kubevirtio_client_go_api_v1_IgnitionSource(ref),
 		"kubevirt.io/client-go/api/v1.Input":                                                 schema_kubevirtio_client_go_api_v1_Input(ref),
+		"kubevirt.io/client-go/api/v1.InsertMediaOptions":                                    schema_kubevirtio_client_go_api_v1_InsertMediaOptions(ref),
+		"kubevirt.io/client-go/api/v1.InstancetypeMatcher":                                   schema_kubevirtio_client_go_api_v1_InstancetypeMatcher(ref),
 		"kubevirt.io/client-go/api/v1.Interface":                                             schema_kubevirtio_client_go_api_v1_Interface(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceBandwidth":                                    schema_kubevirtio_client_go_api_v1_InterfaceBandwidth(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceBindingMethod":                                schema_kubevirtio_client_go_api_v1_InterfaceBindingMethod(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceBindingPlugin":                                schema_kubevirtio_client_go_api_v1_InterfaceBindingPlugin(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceBridge":                                       schema_kubevirtio_client_go_api_v1_InterfaceBridge(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceFirewall":                                     schema_kubevirtio_client_go_api_v1_InterfaceFirewall(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceMacvtap":                                      schema_kubevirtio_client_go_api_v1_InterfaceMacvtap(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceMasquerade":                                   schema_kubevirtio_client_go_api_v1_InterfaceMasquerade(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceOffloads":                                     schema_kubevirtio_client_go_api_v1_InterfaceOffloads(ref),
+		"kubevirt.io/client-go/api/v1.InterfacePasst":                                        schema_kubevirtio_client_go_api_v1_InterfacePasst(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceRSS":                                          schema_kubevirtio_client_go_api_v1_InterfaceRSS(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceSRIOV":                                        schema_kubevirtio_client_go_api_v1_InterfaceSRIOV(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceSlirp":                                        schema_kubevirtio_client_go_api_v1_InterfaceSlirp(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceVdpa":                                         schema_kubevirtio_client_go_api_v1_InterfaceVdpa(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceVhostUser":                                    schema_kubevirtio_client_go_api_v1_InterfaceVhostUser(ref),
+		"kubevirt.io/client-go/api/v1.KSMConfiguration":                                      schema_kubevirtio_client_go_api_v1_KSMConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.KVMTimer":                                              schema_kubevirtio_client_go_api_v1_KVMTimer(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirt":                                              schema_kubevirtio_client_go_api_v1_KubeVirt(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtCertificateRotateStrategy":                     schema_kubevirtio_client_go_api_v1_KubeVirtCertificateRotateStrategy(ref),
@@ -359,45 +400,83 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.KubeVirtSpec":                                          schema_kubevirtio_client_go_api_v1_KubeVirtSpec(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtStatus":                                        schema_kubevirtio_client_go_api_v1_KubeVirtStatus(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtWorkloadUpdateStrategy":                        schema_kubevirtio_client_go_api_v1_KubeVirtWorkloadUpdateStrategy(ref),
+		"kubevirt.io/client-go/api/v1.LaunchSecurity":                                        schema_kubevirtio_client_go_api_v1_LaunchSecurity(ref),
 		"kubevirt.io/client-go/api/v1.LogVerbosity":                                          schema_kubevirtio_client_go_api_v1_LogVerbosity(ref),
 		"kubevirt.io/client-go/api/v1.LunTarget":                                             schema_kubevirtio_client_go_api_v1_LunTarget(ref),
+		"kubevirt.io/client-go/api/v1.MacPoolConfiguration":                                  schema_kubevirtio_client_go_api_v1_MacPoolConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.Machine":                                               schema_kubevirtio_client_go_api_v1_Machine(ref),
+		"kubevirt.io/client-go/api/v1.MediatedDevicesConfiguration":                          schema_kubevirtio_client_go_api_v1_MediatedDevicesConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.MediatedHostDevice":                                    schema_kubevirtio_client_go_api_v1_MediatedHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.Memory":                                                schema_kubevirtio_client_go_api_v1_Memory(ref),
+		"kubevirt.io/client-go/api/v1.MemoryDump":                                            schema_kubevirtio_client_go_api_v1_MemoryDump(ref),
+		"kubevirt.io/client-go/api/v1.MemoryDumpOptions":                                     schema_kubevirtio_client_go_api_v1_MemoryDumpOptions(ref),
+		"kubevirt.io/client-go/api/v1.MemoryOvercommitPolicy":                                schema_kubevirtio_client_go_api_v1_MemoryOvercommitPolicy(ref),
+		"kubevirt.io/client-go/api/v1.MemoryStatus":                                          schema_kubevirtio_client_go_api_v1_MemoryStatus(ref),
+		"kubevirt.io/client-go/api/v1.MigratedVolume":                                        schema_kubevirtio_client_go_api_v1_MigratedVolume(ref),
 		"kubevirt.io/client-go/api/v1.MigrationConfiguration":                                schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.MultusNetwork":                                         schema_kubevirtio_client_go_api_v1_MultusNetwork(ref),
+		"kubevirt.io/client-go/api/v1.NUMA":                                                  schema_kubevirtio_client_go_api_v1_NUMA(ref),
+		"kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough":                           schema_kubevirtio_client_go_api_v1_NUMAGuestMappingPassthrough(ref),
 		"kubevirt.io/client-go/api/v1.Network":                                               schema_kubevirtio_client_go_api_v1_Network(ref),
+		"kubevirt.io/client-go/api/v1.NetworkAttachmentPolicy":                               schema_kubevirtio_client_go_api_v1_NetworkAttachmentPolicy(ref),
+		"kubevirt.io/client-go/api/v1.NetworkAttachmentPolicyRule":                           schema_kubevirtio_client_go_api_v1_NetworkAttachmentPolicyRule(ref),
 		"kubevirt.io/client-go/api/v1.NetworkConfiguration":                                  schema_kubevirtio_client_go_api_v1_NetworkConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.NetworkSource":                                         schema_kubevirtio_client_go_api_v1_NetworkSource(ref),
+		"kubevirt.io/client-go/api/v1.NoCloudSSHPublicKeyAccessCredentialPropagation":        schema_kubevirtio_client_go_api_v1_NoCloudSSHPublicKeyAccessCredentialPropagation(ref),
+		"kubevirt.io/client-go/api/v1.NodeMediatedDeviceTypesConfig":                         schema_kubevirtio_client_go_api_v1_NodeMediatedDeviceTypesConfig(ref),
 		"kubevirt.io/client-go/api/v1.NodePlacement":                                         schema_kubevirtio_client_go_api_v1_NodePlacement(ref),
 		"kubevirt.io/client-go/api/v1.PITTimer":                                              schema_kubevirtio_client_go_api_v1_PITTimer(ref),
+		"kubevirt.io/client-go/api/v1.PanicMemoryDump":                                       schema_kubevirtio_client_go_api_v1_PanicMemoryDump(ref),
 		"kubevirt.io/client-go/api/v1.PciHostDevice":                                         schema_kubevirtio_client_go_api_v1_PciHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.PermittedHostDevices":                                  schema_kubevirtio_client_go_api_v1_PermittedHostDevices(ref),
+		"kubevirt.io/client-go/api/v1.PersistentVolumeClaimInfo":                             schema_kubevirtio_client_go_api_v1_PersistentVolumeClaimInfo(ref),
+		"kubevirt.io/client-go/api/v1.PluginBinding":                                         schema_kubevirtio_client_go_api_v1_PluginBinding(ref),
 		"kubevirt.io/client-go/api/v1.PodNetwork":                                            schema_kubevirtio_client_go_api_v1_PodNetwork(ref),
 		"kubevirt.io/client-go/api/v1.Port":                                                  schema_kubevirtio_client_go_api_v1_Port(ref),
+		"kubevirt.io/client-go/api/v1.PreferenceMatcher":                                     schema_kubevirtio_client_go_api_v1_PreferenceMatcher(ref),
 		"kubevirt.io/client-go/api/v1.Probe":                                                 schema_kubevirtio_client_go_api_v1_Probe(ref),
 		"kubevirt.io/client-go/api/v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation": schema_kubevirtio_client_go_api_v1_QemuGuestAgentSSHPublicKeyAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.QemuGuestAgentUserPasswordAccessCredentialPropagation": schema_kubevirtio_client_go_api_v1_QemuGuestAgentUserPasswordAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.RTCTimer":                                              schema_kubevirtio_client_go_api_v1_RTCTimer(ref),
+		"kubevirt.io/client-go/api/v1.Realtime":                                              schema_kubevirtio_client_go_api_v1_Realtime(ref),
+		"kubevirt.io/client-go/api/v1.RemoveInterfaceOptions":                                schema_kubevirtio_client_go_api_v1_RemoveInterfaceOptions(ref),
 		"kubevirt.io/client-go/api/v1.RemoveVolumeOptions":                                   schema_kubevirtio_client_go_api_v1_RemoveVolumeOptions(ref),
 		"kubevirt.io/client-go/api/v1.ResourceRequirements":                                  schema_kubevirtio_client_go_api_v1_ResourceRequirements(ref),
 		"kubevirt.io/client-go/api/v1.RestartOptions":                                        schema_kubevirtio_client_go_api_v1_RestartOptions(ref),
 		"kubevirt.io/client-go/api/v1.Rng":                                                   schema_kubevirtio_client_go_api_v1_Rng(ref),
+		"kubevirt.io/client-go/api/v1.SEV":                                                   schema_kubevirtio_client_go_api_v1_SEV(ref),
+		"kubevirt.io/client-go/api/v1.SEVAttestation":                                        schema_kubevirtio_client_go_api_v1_SEVAttestation(ref),
+		"kubevirt.io/client-go/api/v1.SEVMeasurementInfo":                                    schema_kubevirtio_client_go_api_v1_SEVMeasurementInfo(ref),
+		"kubevirt.io/client-go/api/v1.SEVPlatformInfo":                                       schema_kubevirtio_client_go_api_v1_SEVPlatformInfo(ref),
+		"kubevirt.io/client-go/api/v1.SEVPolicy":                                             schema_kubevirtio_client_go_api_v1_SEVPolicy(ref),
+		"kubevirt.io/client-go/api/v1.SEVSNP":                                                schema_kubevirtio_client_go_api_v1_SEVSNP(ref),
+		"kubevirt.io/client-go/api/v1.SEVSecretOptions":                                      schema_kubevirtio_client_go_api_v1_SEVSecretOptions(ref),
 		"kubevirt.io/client-go/api/v1.SMBiosConfiguration":                                   schema_kubevirtio_client_go_api_v1_SMBiosConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredential":                          schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredential(ref),
 		"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialPropagationMethod":         schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialPropagationMethod(ref),
 		"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialSource":                    schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialSource(ref),
 		"kubevirt.io/client-go/api/v1.SecretVolumeSource":                                    schema_kubevirtio_client_go_api_v1_SecretVolumeSource(ref),
+		"kubevirt.io/client-go/api/v1.SecureBootKeySource":                                   schema_kubevirtio_client_go_api_v1_SecureBootKeySource(ref),
+		"kubevirt.io/client-go/api/v1.SecureBootKeys":                                        schema_kubevirtio_client_go_api_v1_SecureBootKeys(ref),
+		"kubevirt.io/client-go/api/v1.SerialConsoleLog":                                      schema_kubevirtio_client_go_api_v1_SerialConsoleLog(ref),
+		"kubevirt.io/client-go/api/v1.SerialPort":                                            schema_kubevirtio_client_go_api_v1_SerialPort(ref),
 		"kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource":                            schema_kubevirtio_client_go_api_v1_ServiceAccountVolumeSource(ref),
+		"kubevirt.io/client-go/api/v1.SetLinkStateOptions":                                   schema_kubevirtio_client_go_api_v1_SetLinkStateOptions(ref),
+		"kubevirt.io/client-go/api/v1.SwapDiskSource":                                        schema_kubevirtio_client_go_api_v1_SwapDiskSource(ref),
 		"kubevirt.io/client-go/api/v1.SyNICTimer":                                            schema_kubevirtio_client_go_api_v1_SyNICTimer(ref),
 		"kubevirt.io/client-go/api/v1.SysprepSource":                                         schema_kubevirtio_client_go_api_v1_SysprepSource(ref),
+		"kubevirt.io/client-go/api/v1.TDX":                                                   schema_kubevirtio_client_go_api_v1_TDX(ref),
+		"kubevirt.io/client-go/api/v1.TPMDevice":                                             schema_kubevirtio_client_go_api_v1_TPMDevice(ref),
 		"kubevirt.io/client-go/api/v1.Timer":                                                 schema_kubevirtio_client_go_api_v1_Timer(ref),
+		"kubevirt.io/client-go/api/v1.USBHostDevice":                                         schema_kubevirtio_client_go_api_v1_USBHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredential":                          schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredential(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredentialPropagationMethod":         schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialPropagationMethod(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredentialSource":                    schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialSource(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachine":                                        schema_kubevirtio_client_go_api_v1_VirtualMachine(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineCondition":                               schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstance":                                schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceBackupStatus":                    schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceBackupStatus(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceBlockCommitStatus":               schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceBlockCommitStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition":                       schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceCondition(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem":                      schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystem(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemInfo":                  schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemInfo(ref),
@@ -407,6 +486,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser":                     schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUser(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUserList":                 schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUserList(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceList":                            schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMemoryDumpStatus":                schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMemoryDumpStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigration":                       schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigration(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationCondition":              schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationCondition(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationList":                   schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationList(ref),
@@ -431,6 +511,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.VirtualMachineStatus":                                  schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest":                           schema_kubevirtio_client_go_api_v1_VirtualMachineVolumeRequest(ref),
 		"kubevirt.io/client-go/api/v1.Volume":                                                schema_kubevirtio_client_go_api_v1_Volume(ref),
+		"kubevirt.io/client-go/api/v1.VolumeMultipathStatus":                                 schema_kubevirtio_client_go_api_v1_VolumeMultipathStatus(ref),
 		"kubevirt.io/client-go/api/v1.VolumeSnapshotStatus":                                  schema_kubevirtio_client_go_api_v1_VolumeSnapshotStatus(ref),
 		"kubevirt.io/client-go/api/v1.VolumeSource":                                          schema_kubevirtio_client_go_api_v1_VolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.VolumeStatus":                                          schema_kubevirtio_client_go_api_v1_VolumeStatus(ref),
@@ -438,6 +519,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.WatchdogDevice":                                        schema_kubevirtio_client_go_api_v1_WatchdogDevice(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.Condition":                             schema_client_go_apis_snapshot_v1alpha1_Condition(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.Error":                                 schema_client_go_apis_snapshot_v1alpha1_Error(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.ExternalSnapshotBackup":                schema_client_go_apis_snapshot_v1alpha1_ExternalSnapshotBackup(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.MemoryDumpBackup":                      schema_client_go_apis_snapshot_v1alpha1_MemoryDumpBackup(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.PersistentVolumeClaim":                 schema_client_go_apis_snapshot_v1alpha1_PersistentVolumeClaim(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.SourceSpec":                            schema_client_go_apis_snapshot_v1alpha1_SourceSpec(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineRestore":                 schema_client_go_apis_snapshot_v1alpha1_VirtualMachineRestore(ref),
@@ -450,6 +533,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotContentSpec":     schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotContentSpec(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotContentStatus":   schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotContentStatus(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotList":            schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotList(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotSchedule":        schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSchedule(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleList":    schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleList(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleSpec":    schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleSpec(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleStatus":  schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleStatus(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotSpec":            schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSpec(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotStatus":          schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotStatus(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VolumeBackup":                          schema_client_go_apis_snapshot_v1alpha1_VolumeBackup(ref),
@@ -13777,6 +13864,34 @@ func schema_kubevirtio_client_go_api_v1_AccessCredentialSecretSource(ref common.
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_AddInterfaceOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AddInterfaceOptions is provided when dynamically hot plugging a network interface",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"networkAttachmentDefinitionName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkAttachmentDefinitionName references a NetworkAttachmentDefinition CRD object. Format: <networkAttachmentDefinitionName>, <namespace>/<networkAttachmentDefinitionName>. If namespace is not specified, VMI namespace is assumed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the logical name of the interface and of the network it is connected to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"networkAttachmentDefinitionName", "name"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_AddVolumeOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -13812,6 +13927,86 @@ func schema_kubevirtio_client_go_api_v1_AddVolumeOptions(ref common.ReferenceCal
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_ArchConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ArchConfiguration holds the architecture specific defaults of VMIs. The deprecated machineType, emulatedMachines and ovmfPath of the KubeVirt configuration take precedence over the architecture specific values if set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"amd64": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ArchSpecificConfiguration"),
+						},
+					},
+					"arm64": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ArchSpecificConfiguration"),
+						},
+					},
+					"ppc64le": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ArchSpecificConfiguration"),
+						},
+					},
+					"s390x": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ArchSpecificConfiguration"),
+						},
+					},
+					"defaultArchitecture": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultArchitecture is the architecture of VMIs which do not request one. Defaults to the architecture of the KubeVirt control plane.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.ArchSpecificConfiguration"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_ArchSpecificConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ArchSpecificConfiguration holds the defaults of VMIs of a single architecture",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ovmfPath": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"emulatedMachines": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"machineType": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_AuthorizedKeysFile(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -13853,6 +14048,120 @@ func schema_kubevirtio_client_go_api_v1_BIOS(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_BackupOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupOptions are provided when starting a backup of a running VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"checkpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checkpoint is the name of the checkpoint created when the backup starts, the changed blocks of the next incremental backup are tracked from it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"incremental": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Incremental is the name of the checkpoint the backup reports the changed blocks since. All the blocks are reported if it is empty. Older checkpoints are deleted, since the backups they were taken for are superseded.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"checkpoint"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_BandwidthLimit(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BandwidthLimit shapes the traffic in one direction of an interface.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"average": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Average is the rate, in bytes per second, the traffic is shaped to.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"peak": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Peak is the maximal rate, in bytes per second, the traffic can reach while bursting.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"burst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Burst is the amount of bytes which can be sent at the peak rate.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"average"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_BlockCommitOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BlockCommitOptions are provided when committing the qcow2 overlays of volumes of a running VirtualMachineInstance into their images.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"volumes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes are the names of the volumes whose overlays are committed, they must have been snapshotted with external snapshots.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"activeFiles": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveFiles are the overlays the disks of the volumes were on when the commit was accepted, in the order of Volumes. They are set by virt-api, the commit is refused if a disk was switched to another overlay since then.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"volumes"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Bootloader(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -13888,7 +14197,7 @@ func schema_kubevirtio_client_go_api_v1_CDRomTarget(ref common.ReferenceCallback
 				Properties: map[string]spec.Schema{
 					"bus": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.",
+							Description: "Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -13934,6 +14243,13 @@ func schema_kubevirtio_client_go_api_v1_CPU(ref common.ReferenceCallback) common
 							Format:      "int64",
 						},
 					},
+					"maxSockets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSockets specifies the maximum amount of sockets that can be hotplugged into a running vmi. Must be a value greater or equal to Sockets.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"threads": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Threads specifies the number of threads inside the vmi. Must be a value greater or equal 1.",
@@ -13975,11 +14291,23 @@ func schema_kubevirtio_client_go_api_v1_CPU(ref common.ReferenceCallback) common
 							Format:      "",
 						},
 					},
+					"numa": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NUMA allows specifying settings for the guest NUMA topology",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NUMA"),
+						},
+					},
+					"realtime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads",
+							Ref:         ref("kubevirt.io/client-go/api/v1.Realtime"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.CPUFeature"},
+			"kubevirt.io/client-go/api/v1.CPUFeature", "kubevirt.io/client-go/api/v1.NUMA", "kubevirt.io/client-go/api/v1.Realtime"},
 	}
 }
 
@@ -14011,6 +14339,68 @@ func schema_kubevirtio_client_go_api_v1_CPUFeature(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_CPUTopology(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CPUTopology allows specifying the amount of cores, sockets and threads.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cores": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cores specifies the number of cores inside the vmi.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"sockets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sockets specifies the number of sockets inside the vmi.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"threads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Threads specifies the number of threads inside the vmi.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_Channel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Channel represents a virtio channel of the vmi",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the channel, used to connect to it through the channel subresource. Must be a DNS_LABEL and unique within the vmi.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targetName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetName is the name under which the channel is exposed to the guest, e.g. org.example.agent.0.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "targetName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Chassis(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14238,6 +14628,13 @@ func schema_kubevirtio_client_go_api_v1_CloudInitNoCloudSource(ref common.Refere
 							Format:      "",
 						},
 					},
+					"generateNetworkData": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GenerateNetworkData generates NoCloud cloud-init networkdata with the static addresses, routes and DNS servers of the VMI's pod and multus networks. Can't be used together with the other networkdata fields.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -14246,18 +14643,46 @@ func schema_kubevirtio_client_go_api_v1_CloudInitNoCloudSource(ref common.Refere
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_ComponentConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_ClusterCommonCPUConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "ClusterCommonCPUConfiguration holds the options of the cluster-common CPU model",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"nodePlacement": {
+					"nodeSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "nodePlacement decsribes scheduling confiuguration for specific KubeVirt components",
-							Ref:         ref("kubevirt.io/client-go/api/v1.NodePlacement"),
-						},
-					},
+							Description: "NodeSelector restricts the nodes whose common CPU model and features are used for VMIs requesting the cluster-common CPU model. Defaults to all schedulable nodes.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_ComponentConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodePlacement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "nodePlacement decsribes scheduling confiuguration for specific KubeVirt components",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NodePlacement"),
+						},
+					},
 				},
 			},
 		},
@@ -14310,6 +14735,94 @@ func schema_kubevirtio_client_go_api_v1_ConfigMapVolumeSource(ref common.Referen
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_ContainerDiskCacheConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ContainerDiskCacheConfiguration enables the node cache of containerDisk images. VMIs on the same node which use the same image share a single copy of it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sizeLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SizeLimit is the size of the cache on each node, beyond which the least recently used images which are not in use are removed. Defaults to 20Gi.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_ContainerDiskConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ContainerDiskConfiguration makes virt-controller resolve the tags of containerDisk images to digests in their registries when a VMI starts, and optionally verify the cosign signatures of the images.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resolveDigests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResolveDigests makes virt-controller resolve the tags of containerDisk images to digests when a VMI starts, so that its virt-launcher pods pull exactly the resolved images.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"signatureKeys": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SignatureKeys are PEM encoded ECDSA public keys of cosign. If any is set, the digests are resolved and a VMI only starts if each of its containerDisk images has a cosign signature made with one of the keys.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_ContainerDiskInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ContainerDiskInfo shows the image a containerDisk volume was started from.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"imageDigest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImageDigest is the digest of the image, like sha256:<hex>. Once it is set, the virt-launcher pods of the VMI, including the ones of migration targets, pull the image by this digest.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"signatureVerified": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SignatureVerified tells whether the cosign signature of the image was verified with one of the signature keys of the KubeVirt configuration.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"imageDigest"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14468,11 +14981,31 @@ func schema_kubevirtio_client_go_api_v1_DHCPOptions(ref common.ReferenceCallback
 							},
 						},
 					},
+					"mtu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will pass the MTU to the VM via DHCP option 26, instead of the MTU of the pod interface.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"routes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will pass the static routes to the VM via DHCP option 121, in addition to the routes of the pod interface.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.DHCPRoute"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.DHCPPrivateOptions"},
+			"kubevirt.io/client-go/api/v1.DHCPPrivateOptions", "kubevirt.io/client-go/api/v1.DHCPRoute"},
 	}
 }
 
@@ -14504,6 +15037,34 @@ func schema_kubevirtio_client_go_api_v1_DHCPPrivateOptions(ref common.ReferenceC
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_DHCPRoute(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DHCPRoute defines a static route passed to the VM through DHCP.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"destination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Destination is the IPv4 CIDR of the routed network. Required.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"gateway": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Gateway is the IPv4 address of the next hop. Required.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"destination", "gateway"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_DataVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14744,6 +15305,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"freePageReporting": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to enable free page reporting on the memory balloon device, so that memory freed by the guest is returned to the host. Defaults to true, unless the guest memory is backed by hugepages.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"rng": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to have random number generator from host",
@@ -14789,7 +15357,7 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Filesystems describes filesystem which is connected to the vmi.",
+							Description: "Filesystems describes filesystem which is connected to the vmi. The volume with the name of the filesystem is shared into the guest with virtiofs, it can be a PVC, a DataVolume, a ConfigMap, a Secret or a DownwardAPI volume.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -14818,11 +15386,112 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"tpm": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to emulate a TPM device.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.TPMDevice"),
+						},
+					},
+					"autoattachVSOCK": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to attach the VSOCK CID to the VM or not. VSOCK access will be available if set to true. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"autoattachPanicDevice": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to attach a pvpanic device which reports guest kernel panics to the hypervisor. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"panicMemoryDump": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, a memory dump of the guest is stored on the given PVC when the guest kernel panics.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.PanicMemoryDump"),
+						},
+					},
+					"memoryDump": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, the memory of the running guest can be dumped to the given PVC through the memorydump subresource, for example by VirtualMachineSnapshots which include a memory dump.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MemoryDump"),
+						},
+					},
+					"logSerialConsole": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to mirror the output of the serial console to the virt-launcher pod log. The log can be retrieved later through the console/log subresource. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"serialConsoleLog": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, the serial console log is additionally stored on the given PVC, so that it survives restarts of the VMI.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SerialConsoleLog"),
+						},
+					},
+					"serials": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional serial ports of the vmi, next to the default serial console. Each serial port can be connected to through the serial subresource.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.SerialPort"),
+									},
+								},
+							},
+						},
+					},
+					"channels": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Virtio channels of the vmi, e.g. for custom guest agents. Each channel can be connected to through the channel subresource.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.Channel"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.Disk", "kubevirt.io/client-go/api/v1.Filesystem", "kubevirt.io/client-go/api/v1.GPU", "kubevirt.io/client-go/api/v1.HostDevice", "kubevirt.io/client-go/api/v1.Input", "kubevirt.io/client-go/api/v1.Interface", "kubevirt.io/client-go/api/v1.Rng", "kubevirt.io/client-go/api/v1.Watchdog"},
+			"kubevirt.io/client-go/api/v1.Channel", "kubevirt.io/client-go/api/v1.Disk", "kubevirt.io/client-go/api/v1.Filesystem", "kubevirt.io/client-go/api/v1.GPU", "kubevirt.io/client-go/api/v1.HostDevice", "kubevirt.io/client-go/api/v1.Input", "kubevirt.io/client-go/api/v1.Interface", "kubevirt.io/client-go/api/v1.MemoryDump", "kubevirt.io/client-go/api/v1.PanicMemoryDump", "kubevirt.io/client-go/api/v1.Rng", "kubevirt.io/client-go/api/v1.SerialConsoleLog", "kubevirt.io/client-go/api/v1.SerialPort", "kubevirt.io/client-go/api/v1.TPMDevice", "kubevirt.io/client-go/api/v1.Watchdog"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_Diag288Watchdog(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "diag288 watchdog device.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The action to take. Valid values are poweroff, reset, shutdown. Defaults to reset.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -14886,7 +15555,7 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 					},
 					"cache": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Cache specifies which kvm disk cache mode should be used.",
+							Description: "Cache specifies which kvm disk cache mode should be used. Supported values are: none, writethrough, writeback. If not set, none is used if the storage of the volume supports direct I/O. Otherwise writethrough is used on shared volumes and writeback on the others.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -14898,6 +15567,26 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 							Format:      "",
 						},
 					},
+					"discard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Discard specifies whether discard requests of the guest are passed down to the storage. Supported values are: unmap, ignore. If not set, unmap is used unless the volume is a preallocated image, so that data deleted in the guest frees the space of thin provisioned storage.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"detectZeroes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them. Supported values are: off, on, unmap. unmap additionally frees the space of the zeroes and requires discard to be unmap. Defaults to off.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ioTune": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IOTune limits the I/O operations and the bandwidth of the disk.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DiskIOTune"),
+						},
+					},
 					"tag": {
 						SchemaProps: spec.SchemaProps{
 							Description: "If specified, disk address and its tag will be provided to the guest via config drive metadata",
@@ -14905,12 +15594,32 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 							Format:      "",
 						},
 					},
+					"excludeFromSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludeFromSnapshot excludes the volume of the disk from VirtualMachineSnapshots. The volume is not snapshotted and the guest filesystems on the disk are not frozen. Restores keep the current volume. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Encryption opens the LUKS encrypted volume of the disk with the passphrase from a Secret. The passphrase is only mounted into the virt-launcher pod and kept in the memory of libvirt.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DiskEncryption"),
+						},
+					},
+					"expandGuestFilesystem": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExpandGuestFilesystem makes the guest agent grow the last partition of the disk and the filesystem on it after the disk was expanded online, so that the new capacity is usable in the guest. It requires the ExpandDisks feature gate, and growpart and the resize tool of the filesystem in the guest. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.CDRomTarget", "kubevirt.io/client-go/api/v1.DiskTarget", "kubevirt.io/client-go/api/v1.FloppyTarget", "kubevirt.io/client-go/api/v1.LunTarget"},
+			"kubevirt.io/client-go/api/v1.CDRomTarget", "kubevirt.io/client-go/api/v1.DiskEncryption", "kubevirt.io/client-go/api/v1.DiskIOTune", "kubevirt.io/client-go/api/v1.DiskTarget", "kubevirt.io/client-go/api/v1.FloppyTarget", "kubevirt.io/client-go/api/v1.LunTarget"},
 	}
 }
 
@@ -14953,23 +15662,167 @@ func schema_kubevirtio_client_go_api_v1_DiskDevice(ref common.ReferenceCallback)
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_DiskTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_DiskEncryption(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "DiskEncryption references the Secret with the passphrase of a LUKS encrypted volume.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"bus": {
+					"secretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.",
+							Description: "SecretName is the name of the Secret in the namespace of the vmi, which holds the passphrase in its passphrase key.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"readonly": {
+					"format": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ReadOnly. Defaults to false.",
-							Type:        []string{"boolean"},
+							Description: "Format of the encrypted volume, raw for a volume in the LUKS format or qcow2 for a qcow2 image with LUKS encryption. Defaults to raw.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"secretName"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_DiskIOLimits(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DiskIOLimits holds the limits of one kind of I/O of a disk. The total limits can not be combined with the read and write limits.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"total": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total limits the sum of reads and writes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"read": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Read limits the reads.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"write": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Write limits the writes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"totalBurst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalBurst allows the sum of reads and writes to exceed the total limit up to this value for the burst length.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"readBurst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadBurst allows the reads to exceed the read limit up to this value for the burst length.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"writeBurst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WriteBurst allows the writes to exceed the write limit up to this value for the burst length.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"burstLength": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BurstLength is the duration in seconds for which a burst is allowed. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_DiskIOThreads(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DiskIOThreads sets how the disks share the IOThreads.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sharedPoolSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SharedPoolSize is the number of IOThreads shared by the disks without a dedicated IOThread. Defaults to the number of IOThreads determined by the ioThreadsPolicy.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dedicatedPinning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DedicatedPinning sets where the dedicated IOThreads of the disks are pinned, if the emulator thread is isolated. emulator pins them on the isolated pCPU of the emulator thread, next to the shared IOThreads. vcpus spreads them over the pCPUs of the vCPUs, so that they do not compete with the shared IOThreads. One of: emulator, vcpus. Defaults to emulator.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_DiskIOTune(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DiskIOTune limits the I/O of a disk, so that it can not starve other disks sharing the same storage backend.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"iops": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IOPS limits the I/O operations per second of the disk.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DiskIOLimits"),
+						},
+					},
+					"bandwidth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bandwidth limits the bytes per second of the disk.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DiskIOLimits"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.DiskIOLimits"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_DiskTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"bus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, usb.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"readonly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadOnly. Defaults to false.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
@@ -15047,18 +15900,30 @@ func schema_kubevirtio_client_go_api_v1_DomainSpec(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"ioThreads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IOThreads configures the IOThreads of the disks, in addition to the ioThreadsPolicy.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DiskIOThreads"),
+						},
+					},
 					"chassis": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Chassis specifies the chassis info passed to the domain.",
 							Ref:         ref("kubevirt.io/client-go/api/v1.Chassis"),
 						},
 					},
+					"launchSecurity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Launch Security setting of the vmi.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.LaunchSecurity"),
+						},
+					},
 				},
 				Required: []string{"devices"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.CPU", "kubevirt.io/client-go/api/v1.Chassis", "kubevirt.io/client-go/api/v1.Clock", "kubevirt.io/client-go/api/v1.Devices", "kubevirt.io/client-go/api/v1.Features", "kubevirt.io/client-go/api/v1.Firmware", "kubevirt.io/client-go/api/v1.Machine", "kubevirt.io/client-go/api/v1.Memory", "kubevirt.io/client-go/api/v1.ResourceRequirements"},
+			"kubevirt.io/client-go/api/v1.CPU", "kubevirt.io/client-go/api/v1.Chassis", "kubevirt.io/client-go/api/v1.Clock", "kubevirt.io/client-go/api/v1.Devices", "kubevirt.io/client-go/api/v1.DiskIOThreads", "kubevirt.io/client-go/api/v1.Features", "kubevirt.io/client-go/api/v1.Firmware", "kubevirt.io/client-go/api/v1.LaunchSecurity", "kubevirt.io/client-go/api/v1.Machine", "kubevirt.io/client-go/api/v1.Memory", "kubevirt.io/client-go/api/v1.ResourceRequirements"},
 	}
 }
 
@@ -15097,6 +15962,16 @@ func schema_kubevirtio_client_go_api_v1_DownwardAPIVolumeSource(ref common.Refer
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_DownwardMetricsVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_EFI(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15111,7 +15986,43 @@ func schema_kubevirtio_client_go_api_v1_EFI(ref common.ReferenceCallback) common
 							Format:      "",
 						},
 					},
+					"secureBootKeys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecureBootKeys references Secrets with custom certificates which are enrolled into the NVRAM at first boot. Requires SecureBoot to be enabled.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SecureBootKeys"),
+						},
+					},
+					"persistent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Persistent indicates the EFI NVRAM should be kept across reboots and migrations. The NVRAM is stored in a per-VM backend storage volume. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.SecureBootKeys"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_EjectMediaOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EjectMediaOptions is provided when ejecting the media from a CD-ROM of a running VMI",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the CD-ROM disk whose media is ejected.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"name"},
 			},
 		},
 	}
@@ -15139,6 +16050,77 @@ func schema_kubevirtio_client_go_api_v1_EmptyDiskSource(ref common.ReferenceCall
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_EphemeralDiskBacking(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EphemeralDiskBacking stores the ephemeral disk data of the VMIs, like the overlays of their containerDisks and ephemeral volumes, on the nodes matching its node selector outside of the ephemeral storage of their pods. virt-handler mounts the first backing selecting the node on /var/lib/kubevirt/ephemeral-disks and labels the node with kubevirt.io/ephemeral-disk-backing=true. The VMIs whose node selector only matches nodes selected by a backing store their ephemeral disk data there and are only scheduled to nodes carrying the label. Exactly one of hostPath, blockDevice and tmpfs must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector selects the nodes using the backing. An empty selector selects all nodes.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"hostPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HostPath is a directory on the node which is bind mounted to store the ephemeral disk data.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"blockDevice": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BlockDevice is a dedicated block device on the node, formatted with a filesystem, which is mounted to store the ephemeral disk data.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tmpfs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tmpfs stores the ephemeral disk data in the memory of the node.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.EphemeralDiskTmpfs"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.EphemeralDiskTmpfs"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_EphemeralDiskTmpfs(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EphemeralDiskTmpfs stores the ephemeral disk data in the memory of the node",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sizeLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SizeLimit is the size of the tmpfs. Defaults to half of the memory of the node.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15159,52 +16141,132 @@ func schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref common.Referen
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_FeatureAPIC(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_ExternalSnapshotOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "ExternalSnapshotOptions are provided when taking an external snapshot of volumes of a running VirtualMachineInstance.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.",
-							Type:        []string{"boolean"},
+							Description: "Name of the snapshot. The disk of each volume is switched to a new qcow2 overlay named <name>.qcow2, which is created next to its image. It must not contain a path separator.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"endOfInterrupt": {
+					"volumes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Volumes are the names of the volumes to snapshot, they must be filesystem PVCs or DataVolumes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
 				},
+				Required: []string{"name", "volumes"},
 			},
 		},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_FeatureHyperv(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_ExternalSnapshotVolumeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Hyperv specific features.",
+				Description: "ExternalSnapshotVolumeStatus shows the files of a volume snapshotted with external snapshots.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"relaxed": {
+					"activeFile": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Relaxed instructs the guest OS to disable watchdog timeouts. Defaults to the machine type setting.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.FeatureState"),
+							Description: "ActiveFile is the qcow2 overlay on the volume the guest writes to.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"vapic": {
-						SchemaProps: spec.SchemaProps{
-							Description: "VAPIC improves the paravirtualized handling of interrupts. Defaults to the machine type setting.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.FeatureState"),
+					"backingChain": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
 						},
-					},
-					"spinlocks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackingChain are the files backing the active file, from its backing file down to the base image. The guest does not write to them anymore.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"activeFile"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FeatureAPIC(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled determines if the feature should be enabled or disabled on the guest. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"endOfInterrupt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndOfInterrupt enables the end of interrupt notification in the guest. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FeatureHyperv(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Hyperv specific features.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"relaxed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Relaxed instructs the guest OS to disable watchdog timeouts. Defaults to the machine type setting.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.FeatureState"),
+						},
+					},
+					"vapic": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VAPIC improves the paravirtualized handling of interrupts. Defaults to the machine type setting.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.FeatureState"),
+						},
+					},
+					"spinlocks": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Spinlocks allows to configure the spinlock retry attempts.",
 							Ref:         ref("kubevirt.io/client-go/api/v1.FeatureSpinlocks"),
@@ -15453,11 +16515,118 @@ func schema_kubevirtio_client_go_api_v1_Filesystem(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_FilesystemIDMap(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FilesystemIDMap maps a range of consecutive IDs of the guest to a range of IDs of the shared directory.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"guestID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestID is the first ID of the range in the guest.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"hostID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HostID is the first ID of the range in the shared directory.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of IDs in the range, it must be greater than 0.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"guestID", "hostID", "count"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_FilesystemVirtiofs(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"subPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubPath is the path of a directory within the volume which is shared into the guest instead of the volume root. It allows several VMIs to share parts of a common volume. Only supported for PersistentVolumeClaim and DataVolume volumes.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"uidMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UIDMap maps the user IDs of the guest to the user IDs of the shared directory.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.FilesystemIDMap"),
+						},
+					},
+					"gidMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GIDMap maps the group IDs of the guest to the group IDs of the shared directory.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.FilesystemIDMap"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.FilesystemIDMap"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FirewallRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FirewallRule matches the packets of a direction, a remote network, a protocol and a destination port. The packets match when all the criteria which are set match.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action is Allow or Deny.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"direction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Direction is Ingress, for the traffic to the guest, or Egress, for the traffic from the guest. The rule applies to both directions if it is not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cidr": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CIDR is the network of the remote peer, IPv4 or IPv6.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol is TCP, UDP, SCTP or ICMP. ICMP matches ICMPv6 too.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is the destination port, on the guest for Ingress and on the remote peer for Egress. It requires the TCP, UDP or SCTP protocol.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"action"},
 			},
 		},
 	}
@@ -15523,6 +16692,27 @@ func schema_kubevirtio_client_go_api_v1_FloppyTarget(ref common.ReferenceCallbac
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_FreezeUnfreezeTimeout(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FreezeUnfreezeTimeout represent the time unfreeze will be triggered if guest was not unfrozen by unfreeze command",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"unfreezeTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"unfreezeTimeout"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_GPU(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15549,6 +16739,17 @@ func schema_kubevirtio_client_go_api_v1_GPU(ref common.ReferenceCallback) common
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_GuestAgentPing(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuestAgentPing configures the guest-agent based ping probe",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_HPETTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15594,10 +16795,38 @@ func schema_kubevirtio_client_go_api_v1_HostDevice(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"nvme": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NVMe attaches a namespace of the NVMe controller as a virtio disk, instead of passing the whole controller through. QEMU drives the controller with its user space NVMe driver then.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.HostDeviceNVMe"),
+						},
+					},
 				},
 				Required: []string{"name", "deviceName"},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.HostDeviceNVMe"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_HostDeviceNVMe(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HostDeviceNVMe selects the namespace of a NVMe controller which is attached as a disk.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is the ID of the namespace. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -15757,6 +16986,33 @@ func schema_kubevirtio_client_go_api_v1_I6300ESBWatchdog(ref common.ReferenceCal
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_IgnitionSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Represents the source of an Ignition config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef references a k8s Secret that contains the Ignition config under the config.ign key.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"configMapRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMapRef references a ConfigMap that contains the Ignition config under the config.ign key.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Input(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15791,51 +17047,130 @@ func schema_kubevirtio_client_go_api_v1_Input(ref common.ReferenceCallback) comm
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_Interface(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_InsertMediaOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "InsertMediaOptions is provided when inserting media into a CD-ROM of a running VMI",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.",
+							Description: "Name of the CD-ROM disk, the media is inserted as the volume of the same name.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"model": {
+					"volumeSource": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "VolumeSource represents the source of the media, like an ISO image stored on a PVC.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.HotplugVolumeSource"),
 						},
 					},
-					"bridge": {
+				},
+				Required: []string{"name", "volumeSource"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.HotplugVolumeSource"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InstancetypeMatcher(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InstancetypeMatcher references a instancetype that is used to fill fields in the VMI template.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceBridge"),
+							Description: "Name is the name of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"slirp": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceSlirp"),
+							Description: "Kind specifies which instancetype resource is referenced. Allowed values are: \"VirtualMachineInstancetype\" and \"VirtualMachineClusterInstancetype\". If not specified, \"VirtualMachineClusterInstancetype\" is used by default.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"masquerade": {
+					"revisionName": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceMasquerade"),
+							Description: "RevisionName specifies a ControllerRevision containing a specific copy of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype to be used. This is initially captured the first time the instancetype is applied to the VirtualMachineInstance.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"sriov": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceSRIOV"),
-						},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_Interface(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"model": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bridge": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceBridge"),
+						},
+					},
+					"slirp": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceSlirp"),
+						},
+					},
+					"masquerade": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceMasquerade"),
+						},
+					},
+					"sriov": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceSRIOV"),
+						},
 					},
 					"macvtap": {
 						SchemaProps: spec.SchemaProps{
 							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceMacvtap"),
 						},
 					},
+					"passt": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfacePasst"),
+						},
+					},
+					"vdpa": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceVdpa"),
+						},
+					},
+					"vhostuser": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceVhostUser"),
+						},
+					},
 					"ports": {
 						SchemaProps: spec.SchemaProps{
 							Description: "List of ports to be forwarded to the virtual machine.",
@@ -15863,6 +17198,13 @@ func schema_kubevirtio_client_go_api_v1_Interface(ref common.ReferenceCallback)
 							Format:      "int32",
 						},
 					},
+					"romDisabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If set, no option ROM is exposed to the guest for the interface. Interfaces with a boot order have an option ROM by default, which is needed to boot from the network with BIOS firmware. EFI firmware can boot from the network without it.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"pciAddress": {
 						SchemaProps: spec.SchemaProps{
 							Description: "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10",
@@ -15883,12 +17225,76 @@ func schema_kubevirtio_client_go_api_v1_Interface(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State represents the requested operational state of the interface. The link of the interface is set \"down\" or \"up\", \"up\" being the default, and the guest sees a link which is down as an unplugged cable. \"absent\" is set when the interface is hot unplugged.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"binding": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Binding specifies the binding plugin that will be used to connect the interface to the guest. It provides an alternative to InterfaceBindingMethod.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.PluginBinding"),
+						},
+					},
+					"bandwidth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bandwidth limits the traffic of the interface. It is only supported with the bridge, masquerade and macvtap bindings.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceBandwidth"),
+						},
+					},
+					"rss": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RSS enables receive side scaling of the virtio-net device, which spreads the flows received by a multi-queue guest across its queues, and thus across its vCPUs. It requires the virtio model and networkInterfaceMultiqueue, and is only supported with the bridge, masquerade and macvtap bindings.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceRSS"),
+						},
+					},
+					"offloads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Offloads toggles the offloads of the virtio-net device. The offloads which are not set keep their default. It requires the virtio model, and is only supported with the bridge, masquerade and macvtap bindings.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceOffloads"),
+						},
+					},
+					"firewall": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Firewall filters the IP traffic of the interface in the network namespace of the virt-launcher pod, so it applies even where NetworkPolicies don't, like on bridged secondary networks. It is only supported with the bridge and masquerade bindings.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceFirewall"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.DHCPOptions", "kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp", "kubevirt.io/client-go/api/v1.Port"},
+			"kubevirt.io/client-go/api/v1.DHCPOptions", "kubevirt.io/client-go/api/v1.InterfaceBandwidth", "kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceFirewall", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceOffloads", "kubevirt.io/client-go/api/v1.InterfacePasst", "kubevirt.io/client-go/api/v1.InterfaceRSS", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp", "kubevirt.io/client-go/api/v1.InterfaceVdpa", "kubevirt.io/client-go/api/v1.InterfaceVhostUser", "kubevirt.io/client-go/api/v1.PluginBinding", "kubevirt.io/client-go/api/v1.Port"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceBandwidth(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceBandwidth limits the traffic of an interface, from the point of view of the guest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"inbound": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Inbound limits the traffic received by the guest (rx).",
+							Ref:         ref("kubevirt.io/client-go/api/v1.BandwidthLimit"),
+						},
+					},
+					"outbound": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Outbound limits the traffic sent by the guest (tx).",
+							Ref:         ref("kubevirt.io/client-go/api/v1.BandwidthLimit"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.BandwidthLimit"},
 	}
 }
 
@@ -15924,11 +17330,53 @@ func schema_kubevirtio_client_go_api_v1_InterfaceBindingMethod(ref common.Refere
 							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceMacvtap"),
 						},
 					},
+					"passt": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfacePasst"),
+						},
+					},
+					"vdpa": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceVdpa"),
+						},
+					},
+					"vhostuser": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceVhostUser"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp"},
+			"kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfacePasst", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp", "kubevirt.io/client-go/api/v1.InterfaceVdpa", "kubevirt.io/client-go/api/v1.InterfaceVhostUser"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceBindingPlugin(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceBindingPlugin describes how a network binding plugin is deployed with the virt-launcher pod.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sidecarImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SidecarImage references a container image that runs in the virt-launcher pod. The sidecar adjusts the domain of the VMI through the hooks API.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"networkAttachmentDefinition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkAttachmentDefinition references, in <namespace>/<name> format, the NetworkAttachmentDefinition whose CNI plugin configures the pod network of the interface.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -15942,6 +17390,41 @@ func schema_kubevirtio_client_go_api_v1_InterfaceBridge(ref common.ReferenceCall
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_InterfaceFirewall(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceFirewall allows or denies the IP traffic of an interface. The traffic of established connections is always allowed, as well as DHCP and IPv6 neighbor discovery.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rules are evaluated in order, the first rule a packet matches decides whether it is allowed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.FirewallRule"),
+									},
+								},
+							},
+						},
+					},
+					"defaultAction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultAction applies to the packets which match no rule. Defaults to Allow. Deny needs the nf_conntrack_bridge kernel module on the node to let the replies of established connections pass. VMIs using it are only scheduled on nodes with the kubevirt.io/nf-conntrack-bridge label, which virt-handler sets where it is loaded.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.FirewallRule"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_InterfaceMacvtap(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15962,11 +17445,85 @@ func schema_kubevirtio_client_go_api_v1_InterfaceMasquerade(ref common.Reference
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_InterfaceOffloads(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceOffloads toggles the offloads of a virtio-net interface, both from the guest to the host and from the host to the guest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"checksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checksum toggles the checksum offload. The segmentation offloads rely on it, so they are disabled with it and can't be enabled without it.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"tso": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TSO toggles the TCP segmentation offload, for IPv4 and IPv6.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"gso": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GSO toggles the generic segmentation offload of the host.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfacePasst(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfacePasst connects the guest to the pod network through passt, a user-mode network stack which forwards TCP and UDP traffic over both IPv4 and IPv6.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceRSS(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceRSS configures receive side scaling (RSS) of a virtio-net interface.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hashReport": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HashReport makes the device report the RSS hash of every received packet to the guest, which saves the guest from computing it again. The device is emulated by QEMU instead of vhost-net then.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_InterfaceSRIOV(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"failover": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Failover names a virtio interface of the vmi which the guest teams with the VF through its net_failover driver. The traffic fails over to that interface while the VF is detached for a live migration. Both interfaces must have the same MAC address and be connected to the same L2 network.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -15982,6 +17539,49 @@ func schema_kubevirtio_client_go_api_v1_InterfaceSlirp(ref common.ReferenceCallb
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_InterfaceVdpa(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceVdpa passes a host vDPA device to the guest as a virtio-net interface. The device is allocated by a device plugin, through the resource of the Multus network.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceVhostUser(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceVhostUser connects the guest virtio-net interface to a userspace switch, like OVS-DPDK or VPP, through a vhost-user socket. The guest memory is shared with the switch.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_KSMConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeLabelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeLabelSelector is a selector that filters in which nodes the KSM will be enabled. Empty NodeLabelSelector will enable ksm for every node.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_KVMTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16214,14 +17814,67 @@ func schema_kubevirtio_client_go_api_v1_KubeVirtConfiguration(ref common.Referen
 							Ref: ref("kubevirt.io/client-go/api/v1.PermittedHostDevices"),
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/client-go/api/v1.DeveloperConfiguration", "kubevirt.io/client-go/api/v1.MigrationConfiguration", "kubevirt.io/client-go/api/v1.NetworkConfiguration", "kubevirt.io/client-go/api/v1.PermittedHostDevices", "kubevirt.io/client-go/api/v1.SMBiosConfiguration"},
-	}
-}
-
+					"vmStateStorageClass": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"clusterCommonCPU": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ClusterCommonCPUConfiguration"),
+						},
+					},
+					"ksmConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.KSMConfiguration"),
+						},
+					},
+					"memoryOvercommitPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.MemoryOvercommitPolicy"),
+						},
+					},
+					"mediatedDevicesConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.MediatedDevicesConfiguration"),
+						},
+					},
+					"architectureConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ArchConfiguration"),
+						},
+					},
+					"containerDiskCache": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ContainerDiskCacheConfiguration"),
+						},
+					},
+					"ephemeralDiskBackings": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.EphemeralDiskBacking"),
+									},
+								},
+							},
+						},
+					},
+					"containerDisks": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ContainerDiskConfiguration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/client-go/api/v1.ArchConfiguration", "kubevirt.io/client-go/api/v1.ClusterCommonCPUConfiguration", "kubevirt.io/client-go/api/v1.ContainerDiskCacheConfiguration", "kubevirt.io/client-go/api/v1.ContainerDiskConfiguration", "kubevirt.io/client-go/api/v1.DeveloperConfiguration", "kubevirt.io/client-go/api/v1.EphemeralDiskBacking", "kubevirt.io/client-go/api/v1.KSMConfiguration", "kubevirt.io/client-go/api/v1.MediatedDevicesConfiguration", "kubevirt.io/client-go/api/v1.MemoryOvercommitPolicy", "kubevirt.io/client-go/api/v1.MigrationConfiguration", "kubevirt.io/client-go/api/v1.NetworkConfiguration", "kubevirt.io/client-go/api/v1.PermittedHostDevices", "kubevirt.io/client-go/api/v1.SMBiosConfiguration"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_KubeVirtList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16542,6 +18195,38 @@ func schema_kubevirtio_client_go_api_v1_KubeVirtWorkloadUpdateStrategy(ref commo
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_LaunchSecurity(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sev": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AMD Secure Encrypted Virtualization (SEV).",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SEV"),
+						},
+					},
+					"snp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AMD SEV-SNP (Secure Nested Paging), which adds memory integrity protection to SEV.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SEVSNP"),
+						},
+					},
+					"tdx": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Intel Trust Domain Extensions (TDX).",
+							Ref:         ref("kubevirt.io/client-go/api/v1.TDX"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.SEV", "kubevirt.io/client-go/api/v1.SEVSNP", "kubevirt.io/client-go/api/v1.TDX"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_LogVerbosity(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16620,7 +18305,42 @@ func schema_kubevirtio_client_go_api_v1_LunTarget(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"reservation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reservation indicates if the disk needs to support the persistent reservation for the SCSI disk. The reservation commands are sent to the host through the qemu-pr-helper daemon of virt-handler. It requires the scsi bus.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_MacPoolConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MacPoolConfiguration defines an inclusive range of MAC addresses.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rangeStart": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RangeStart is the first MAC address of the pool, e.g. 02:00:00:00:00:00.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rangeEnd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RangeEnd is the last MAC address of the pool, e.g. 02:00:00:ff:ff:ff.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"rangeStart", "rangeEnd"},
 			},
 		},
 	}
@@ -16646,6 +18366,58 @@ func schema_kubevirtio_client_go_api_v1_Machine(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_MediatedDevicesConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MediatedDevicesConfiguration holds the mediated device types which virt-handler creates on the nodes. Mediated devices of other types are removed from the nodes once a configuration is present.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mediatedDevicesTypes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "MediatedDevicesTypes are the mediated device types created on all nodes. Every parent device is configured with the first type it supports.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"nodeMediatedDeviceTypes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeMediatedDeviceTypes override the mediated device types on the nodes matching their node selector.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.NodeMediatedDeviceTypesConfig"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.NodeMediatedDeviceTypesConfig"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_MediatedHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16697,6 +18469,12 @@ func schema_kubevirtio_client_go_api_v1_Memory(ref common.ReferenceCallback) com
 							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 						},
 					},
+					"maxGuest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS. The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
 				},
 			},
 		},
@@ -16705,6 +18483,136 @@ func schema_kubevirtio_client_go_api_v1_Memory(ref common.ReferenceCallback) com
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_MemoryDump(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MemoryDump configures where the memory of the running guest is dumped to",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"claimName"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_MemoryDumpOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MemoryDumpOptions are provided when dumping the memory of a running VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"fileName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FileName is the name of the file on the memory dump PVC the memory is dumped to. It must not contain a path separator.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"fileName"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_MemoryOvercommitPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MemoryOvercommitPolicy defines how the memory of VMIs is overcommitted on the nodes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ratio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ratio is the percentage of the guest memory relative to the memory request of VMIs which do not request memory explicitly. A ratio of 150 results in a memory request of two thirds of the guest memory. Values below 100 are ignored. Takes precedence over developerConfiguration.memoryOvercommit.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"overcommitGuestOverhead": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OvercommitGuestOverhead excludes the memory overhead of all VMIs from the memory request of their pods.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_MemoryStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MemoryStatus reports the memory of a VirtualMachineInstance, including the memory hotplugged while it was running.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"guestAtBoot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestAtBoot specifies with how much memory the VirtualMachine initially booted with.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"guestCurrent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestCurrent specifies how much memory is currently available for the VirtualMachine.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"guestRequested": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestRequested specifies how much memory was requested (hotplug) for the VirtualMachine.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_MigratedVolume(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MigratedVolume names a volume of the VMI and the PersistentVolumeClaim it is copied to.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"volumeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeName is the name of a PersistentVolumeClaim or DataVolume volume of the VMI",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"destinationClaimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to. It must have the same volume mode as the source and be at least as large.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"volumeName", "destinationClaimName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16765,6 +18673,13 @@ func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.Refere
 							Format: "",
 						},
 					},
+					"network": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Network is the name of a Multus NetworkAttachmentDefinition, in <namespace>/<name> or <name> format, through which the migration traffic goes. By default the pod network is used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -16794,6 +18709,13 @@ func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"persistentIPs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PersistentIPs keeps the addresses allocated by the IPAM of the network across live migrations and restarts of the VM. The addresses are requested through the ips field of the multus network selection element, so the IPAM plugin has to support static IP requests. Only bridge interfaces are supported.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"networkName"},
 			},
@@ -16801,16 +18723,48 @@ func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallba
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_Network(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_NUMA(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Network represents a network type and a resource that should be connected to the vm.",
+				Description: "NUMA allows specifying settings for the guest NUMA topology.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"guestMappingPassthrough": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Network name. Must be a DNS_LABEL and unique within the vm. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+							Description: "GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_NUMAGuestMappingPassthrough(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NUMAGuestMappingPassthrough instructs kubevirt to model numa topology which is compatible with the CPU pinning on the guest. This will result in a subset of the node numa topology being passed through, ensuring that virtual numa nodes and their memory never cross boundaries coming from the node numa mapping.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_Network(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Network represents a network type and a resource that should be connected to the vm.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Network name. Must be a DNS_LABEL and unique within the vm. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -16834,6 +18788,77 @@ func schema_kubevirtio_client_go_api_v1_Network(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_NetworkAttachmentPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkAttachmentPolicy grants namespaces access to NetworkAttachmentDefinitions.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rules grant access to NetworkAttachmentDefinitions. A VMI can only reference the NetworkAttachmentDefinitions allowed by the rules which apply to its namespace.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.NetworkAttachmentPolicyRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"rules"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.NetworkAttachmentPolicyRule"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_NetworkAttachmentPolicyRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkAttachmentPolicyRule allows the VMIs of namespaces to reference NetworkAttachmentDefinitions.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces the rule applies to. The names may be shell patterns, e.g. \"tenant-*\" or \"*\".",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"networkAttachmentDefinitions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkAttachmentDefinitions allowed by the rule, in <namespace>/<name> format. The namespace and the name may be shell patterns, e.g. \"tenant-a/*\". A name without namespace refers to the namespace of the VMI.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"namespaces", "networkAttachmentDefinitions"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_NetworkConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16859,9 +18884,37 @@ func schema_kubevirtio_client_go_api_v1_NetworkConfiguration(ref common.Referenc
 							Format: "",
 						},
 					},
+					"binding": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Binding registers the network binding plugins, by name, which VMI interfaces can reference.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.InterfaceBindingPlugin"),
+									},
+								},
+							},
+						},
+					},
+					"macPool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MacPool configures the range of MAC addresses which are assigned to the interfaces of VirtualMachines which don't request a specific address.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MacPoolConfiguration"),
+						},
+					},
+					"attachmentPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AttachmentPolicy restricts the NetworkAttachmentDefinitions which the VMIs of each namespace can reference. VMIs can reference any NetworkAttachmentDefinition if it is not set.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NetworkAttachmentPolicy"),
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.InterfaceBindingPlugin", "kubevirt.io/client-go/api/v1.MacPoolConfiguration", "kubevirt.io/client-go/api/v1.NetworkAttachmentPolicy"},
 	}
 }
 
@@ -16890,6 +18943,63 @@ func schema_kubevirtio_client_go_api_v1_NetworkSource(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_NoCloudSSHPublicKeyAccessCredentialPropagation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_NodeMediatedDeviceTypesConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeMediatedDeviceTypesConfig holds the mediated device types of the nodes matching the node selector",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector selects the nodes the mediated device types are created on.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"mediatedDevicesTypes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"nodeSelector", "mediatedDevicesTypes"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_NodePlacement(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16965,6 +19075,27 @@ func schema_kubevirtio_client_go_api_v1_PITTimer(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_PanicMemoryDump(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PanicMemoryDump configures where the memory of a panicked guest is dumped to",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of the PVC in the namespace of the VMI which stores the memory dumps.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"claimName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_PciHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17038,11 +19169,99 @@ func schema_kubevirtio_client_go_api_v1_PermittedHostDevices(ref common.Referenc
 							},
 						},
 					},
+					"usb": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.USBHostDevice"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.MediatedHostDevice", "kubevirt.io/client-go/api/v1.PciHostDevice", "kubevirt.io/client-go/api/v1.USBHostDevice"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_PersistentVolumeClaimInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PersistentVolumeClaimInfo contains the relevant information virt-handler needs cached about a PVC",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Capacity represents the capacity set on the corresponding PVC status",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"volumeMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeMode defines what type of volume is required by the claim. Value of Filesystem is implied when not included in claim spec.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"accessModes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AccessModes contains the desired access modes the volume should have.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.MediatedHostDevice", "kubevirt.io/client-go/api/v1.PciHostDevice"},
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_PluginBinding(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PluginBinding references a network binding plugin registered in the KubeVirt CR.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name references the binding plugin name as registered in the KubeVirt CR.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 	}
 }
 
@@ -17060,6 +19279,13 @@ func schema_kubevirtio_client_go_api_v1_PodNetwork(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"vmIPv6NetworkCIDR": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IPv6 CIDR for the vm network. Defaults to fd10:0:2::/120 if not specified.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -17082,7 +19308,7 @@ func schema_kubevirtio_client_go_api_v1_Port(ref common.ReferenceCallback) commo
 					},
 					"protocol": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Protocol for port. Must be UDP or TCP. Defaults to \"TCP\".",
+							Description: "Protocol for port. Must be UDP, TCP or SCTP. Defaults to \"TCP\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -17101,6 +19327,41 @@ func schema_kubevirtio_client_go_api_v1_Port(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_PreferenceMatcher(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PreferenceMatcher references a set of preference that is used to fill fields in the VMI template.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the VirtualMachinePreference or VirtualMachineClusterPreference",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind specifies which preference resource is referenced. Allowed values are: \"VirtualMachinePreference\" and \"VirtualMachineClusterPreference\". If not specified, \"VirtualMachineClusterPreference\" is used by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"revisionName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RevisionName specifies a ControllerRevision containing a specific copy of the VirtualMachinePreference or VirtualMachineClusterPreference to be used. This is initially captured the first time the instancetype is applied to the VirtualMachineInstance.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Probe(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17120,6 +19381,18 @@ func schema_kubevirtio_client_go_api_v1_Probe(ref common.ReferenceCallback) comm
 							Ref:         ref("k8s.io/api/core/v1.TCPSocketAction"),
 						},
 					},
+					"exec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exec specifies the action to take, it will be executed on the guest through the qemu-guest-agent. If the guest agent is not available, this probe will fail.",
+							Ref:         ref("k8s.io/api/core/v1.ExecAction"),
+						},
+					},
+					"guestAgentPing": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestAgentPing contacts the qemu-guest-agent for availability checks.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.GuestAgentPing"),
+						},
+					},
 					"initialDelaySeconds": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Number of seconds after the VirtualMachineInstance has started before liveness probes are initiated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes",
@@ -17159,7 +19432,7 @@ func schema_kubevirtio_client_go_api_v1_Probe(ref common.ReferenceCallback) comm
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.HTTPGetAction", "k8s.io/api/core/v1.TCPSocketAction"},
+			"k8s.io/api/core/v1.ExecAction", "k8s.io/api/core/v1.HTTPGetAction", "k8s.io/api/core/v1.TCPSocketAction", "kubevirt.io/client-go/api/v1.GuestAgentPing"},
 	}
 }
 
@@ -17238,6 +19511,47 @@ func schema_kubevirtio_client_go_api_v1_RTCTimer(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_Realtime(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Realtime holds the tuning knobs specific for realtime workloads.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mask": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt's expressions. Example: \"0-3,^1\",\"0,2,3\",\"2-3\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_RemoveInterfaceOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemoveInterfaceOptions is provided when dynamically hot unplugging a network interface",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the logical name of the interface to be removed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_RemoveVolumeOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17353,175 +19667,517 @@ func schema_kubevirtio_client_go_api_v1_Rng(ref common.ReferenceCallback) common
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_SMBiosConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_SEV(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"policy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Guest policy flags as defined in AMD SEV API specification. Note: due to security reasons it is not allowed to enable guest debugging. Therefore NoDebug flag is not exposed to users and is always true.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SEVPolicy"),
+						},
+					},
+					"attestation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, run the attestation process for a vmi.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SEVAttestation"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.SEVAttestation", "kubevirt.io/client-go/api/v1.SEVPolicy"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SEVAttestation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SEVAttestation requests the attestation of the guest: the domain is started paused and is only resumed once a launch secret was injected.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SEVMeasurementInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SEVMeasurementInfo contains information about the guest launch measurement.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"measurement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64 encoded launch measurement of the SEV guest.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiMajor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "API major version of the SEV host.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"apiMinor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "API minor version of the SEV host.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"buildID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Build ID of the SEV host.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"policy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Policy of the SEV guest.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"loaderSHA": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SHA256 of the loader binary",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SEVPlatformInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SEVPlatformInfo contains information about the AMD SEV features for the node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pdh": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64 encoded platform Diffie-Hellman key.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"certChain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64 encoded SEV certificate chain.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SEVPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"encryptedState": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SEV-ES is required. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SEVSNP(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SEVSecretOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SEVSecretOptions is used to provide a secret for a running guest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"header": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64 encoded header needed to decrypt the secret.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64 encoded encrypted launch secret.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SMBiosConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"manufacturer": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"product": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"sku": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"family": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredential(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SSHPublicKeyAccessCredential represents a source and propagation method for injecting ssh public keys into a vm guest",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Source represents where the public keys are pulled from",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialSource"),
+						},
+					},
+					"propagationMethod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PropagationMethod represents how the public key is injected into the vm guest.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialPropagationMethod"),
+						},
+					},
+				},
+				Required: []string{"source", "propagationMethod"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialPropagationMethod", "kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialSource"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialPropagationMethod(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SSHPublicKeyAccessCredentialPropagationMethod represents the method used to inject a ssh public key into the vm guest. Only one of its members may be specified.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"noCloud": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NoCloudPropagation means that the ssh public keys are injected into the VM using metadata using the noCloud cloud-init provider",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NoCloudSSHPublicKeyAccessCredentialPropagation"),
+						},
+					},
+					"configDrive": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider",
+							Ref:         ref("kubevirt.io/client-go/api/v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation"),
+						},
+					},
+					"qemuGuestAgent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation", "kubevirt.io/client-go/api/v1.NoCloudSSHPublicKeyAccessCredentialPropagation", "kubevirt.io/client-go/api/v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SSHPublicKeyAccessCredentialSource represents where to retrieve the ssh key credentials Only one of its members may be specified.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret means that the access credential is pulled from a kubernetes secret",
+							Ref:         ref("kubevirt.io/client-go/api/v1.AccessCredentialSecretSource"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.AccessCredentialSecretSource"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SecretVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SecretVolumeSource adapts a Secret into a volume.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the secret in the pod's namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"optional": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specify whether the Secret or it's keys must be defined",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"volumeLabel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are \"cidata\" (cloud-init), \"config-2\" (cloud-init) or \"OEMDRV\" (kickstart).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SecureBootKeySource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SecureBootKeySource references a Secret holding Secure Boot certificates.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretName is the name of the Secret in the namespace of the vmi.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"secretName"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SecureBootKeys(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "SecureBootKeys references the certificates which are enrolled into the Secure Boot databases. Every referenced Secret can hold one or more PEM encoded certificates.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"manufacturer": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"product": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"version": {
+					"pk": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "PK references a Secret with the Platform Key certificate, replacing the default one.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SecureBootKeySource"),
 						},
 					},
-					"sku": {
+					"kek": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "KEK references a Secret with Key Exchange Key certificates, added to the default ones.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SecureBootKeySource"),
 						},
 					},
-					"family": {
+					"db": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "DB references a Secret with certificates which are allowed to sign boot loaders and kernels, added to the default ones.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SecureBootKeySource"),
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.SecureBootKeySource"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredential(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_SerialConsoleLog(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SSHPublicKeyAccessCredential represents a source and propagation method for injecting ssh public keys into a vm guest",
+				Description: "SerialConsoleLog configures where the serial console log of the guest is stored",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"source": {
+					"claimName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Source represents where the public keys are pulled from",
-							Ref:         ref("kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialSource"),
+							Description: "ClaimName is the name of the PVC in the namespace of the VMI which stores the serial console log.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"propagationMethod": {
+					"maxFileSize": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PropagationMethod represents how the public key is injected into the vm guest.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialPropagationMethod"),
+							Description: "MaxFileSize is the size after which the log file is rotated. Defaults to 10Mi.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"maxFiles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxFiles is the number of rotated log files which are kept. Defaults to 3.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
-				Required: []string{"source", "propagationMethod"},
+				Required: []string{"claimName"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialPropagationMethod", "kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialSource"},
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialPropagationMethod(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_SerialPort(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SSHPublicKeyAccessCredentialPropagationMethod represents the method used to inject a ssh public key into the vm guest. Only one of its members may be specified.",
+				Description: "SerialPort represents an additional serial port of the vmi",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"configDrive": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider",
-							Ref:         ref("kubevirt.io/client-go/api/v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation"),
-						},
-					},
-					"qemuGuestAgent": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation"),
+							Description: "Name of the serial port, used to connect to it through the serial subresource. Must be a DNS_LABEL and unique within the vmi.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"name"},
 			},
 		},
-		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation", "kubevirt.io/client-go/api/v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_ServiceAccountVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SSHPublicKeyAccessCredentialSource represents where to retrieve the ssh key credentials Only one of its members may be specified.",
+				Description: "ServiceAccountVolumeSource adapts a ServiceAccount into a volume.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"secret": {
+					"serviceAccountName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Secret means that the access credential is pulled from a kubernetes secret",
-							Ref:         ref("kubevirt.io/client-go/api/v1.AccessCredentialSecretSource"),
+							Description: "Name of the service account in the pod's namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
 			},
 		},
-		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.AccessCredentialSecretSource"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_SecretVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_SetLinkStateOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SecretVolumeSource adapts a Secret into a volume.",
+				Description: "SetLinkStateOptions is provided when setting the link state of a network interface",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"secretName": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name of the secret in the pod's namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret",
+							Description: "Name indicates the logical name of the interface.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"optional": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Specify whether the Secret or it's keys must be defined",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"volumeLabel": {
+					"state": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The volume label of the resulting disk inside the VMI. Different bootstrapping mechanisms require different values. Typical values are \"cidata\" (cloud-init), \"config-2\" (cloud-init) or \"OEMDRV\" (kickstart).",
+							Description: "State is the requested link state of the interface, either \"up\" or \"down\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
+				Required: []string{"name", "state"},
 			},
 		},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_ServiceAccountVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_SwapDiskSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ServiceAccountVolumeSource adapts a ServiceAccount into a volume.",
+				Description: "SwapDisk represents a temporary disk the guest uses as encrypted swap space.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"serviceAccountName": {
+					"capacity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name of the service account in the pod's namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Capacity of the sparse swap disk.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 						},
 					},
 				},
+				Required: []string{"capacity"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -17577,6 +20233,36 @@ func schema_kubevirtio_client_go_api_v1_SysprepSource(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_TDX(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_TPMDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TPMDevice represents the emulated TPM device passed to the vmi",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"persistent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Persistent indicates the state of the TPM device should be kept across reboots and migrations. The state is stored in a per-VM backend storage volume. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Timer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17622,6 +20308,46 @@ func schema_kubevirtio_client_go_api_v1_Timer(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_USBHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "USBHostDevice represents a host USB device allowed for passthrough",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"usbVendorSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "USBVendorSelector selects the USB devices by their vendor:product ID, e.g. \"0529:0001\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"usbPortSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "USBPortSelector selects the USB device connected to a bus and port, as named in /sys/bus/usb/devices, e.g. \"1-2.3\". Exactly one of the selectors has to be set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"externalResourceProvider": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"resourceName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredential(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17774,65 +20500,197 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref common.Refer
 							Format: "",
 						},
 					},
-					"message": {
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceBackupStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceBackupStatus reports the changed block tracking of a VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"checkpoints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Checkpoints are the names of the checkpoints an incremental backup can be taken from, oldest first. They are lost when the VirtualMachineInstance stops.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"checkpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checkpoint is the checkpoint created by the running backup, it is empty if no backup is running.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"incremental": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Incremental is the checkpoint the running backup reports the changed blocks since, it is empty if the backup is a full one.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"disks": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Disks are the names of the disks included in the running backup.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"type", "status"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceBlockCommitStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.",
+				Description: "VirtualMachineInstanceBlockCommitStatus reports a block commit, which merges the qcow2 overlays of volumes snapshotted with external snapshots back into their images.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"volumes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Volumes are the volumes whose overlays are committed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"apiVersion": {
+					"phase": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Description: "Phase is the state of the block commit.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"progress": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "Progress is the percentage of the data of the overlays which is committed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"spec": {
+					"startTimestamp": {
 						SchemaProps: spec.SchemaProps{
-							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec"),
+							Description: "StartTimestamp is when the block commit started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"status": {
+					"endTimestamp": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus"),
+							Description: "EndTimestamp is when the block commit completed or failed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains why the block commit failed.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"spec"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -18273,6 +21131,54 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceList(ref common.Re
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMemoryDumpStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceMemoryDumpStatus reports a memory dump of a VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"fileName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FileName is the name of the dump file on the memory dump PVC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the state of the memory dump.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTimestamp is when the memory dump started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"endTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndTimestamp is when the memory dump completed or failed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains why the memory dump failed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -18427,9 +21333,24 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationSpec(ref
 							Format:      "",
 						},
 					},
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes lists the volumes of the VMI which are copied to other PersistentVolumeClaims during the migration, for instance to move them to another storage class. The VMI and its VirtualMachine use the destination claims once the migration succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.MigratedVolume"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.MigratedVolume"},
 	}
 }
 
@@ -18543,11 +21464,24 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationState(ref
 							Format:      "",
 						},
 					},
+					"migratedVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The volumes which are copied to other PersistentVolumeClaims during the migration",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.MigratedVolume"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/api/v1.MigratedVolume"},
 	}
 }
 
@@ -18632,9 +21566,24 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceNetworkInterface(r
 							Format:      "",
 						},
 					},
+					"bandwidth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bandwidth reports the traffic limits applied to the interface",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceBandwidth"),
+						},
+					},
+					"queueCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies how many queues are allocated by MultiQueue",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.InterfaceBandwidth"},
 	}
 }
 
@@ -18997,6 +21946,13 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.Re
 							Format:      "",
 						},
 					},
+					"architecture": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the architecture of the vm guest you are attempting to run. Defaults to the default architecture of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"domain": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Specification of the desired behavior of the VirtualMachineInstance on the host.",
@@ -19141,12 +22097,18 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.Re
 							},
 						},
 					},
+					"ignition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, the Ignition config is passed to the guest through the fw_cfg device. Used to provision guests like Fedora CoreOS or Flatcar, which do not use cloud-init.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.IgnitionSource"),
+						},
+					},
 				},
 				Required: []string{"domain"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.Toleration", "kubevirt.io/client-go/api/v1.AccessCredential", "kubevirt.io/client-go/api/v1.DomainSpec", "kubevirt.io/client-go/api/v1.Network", "kubevirt.io/client-go/api/v1.Probe", "kubevirt.io/client-go/api/v1.Volume"},
+			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.Toleration", "kubevirt.io/client-go/api/v1.AccessCredential", "kubevirt.io/client-go/api/v1.DomainSpec", "kubevirt.io/client-go/api/v1.IgnitionSource", "kubevirt.io/client-go/api/v1.Network", "kubevirt.io/client-go/api/v1.Probe", "kubevirt.io/client-go/api/v1.Volume"},
 	}
 }
 
@@ -19277,11 +22239,48 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref common.
 							},
 						},
 					},
+					"currentCPUTopology": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentCPUTopology specifies the current CPU topology used by the VM workload. Current topology may differ from the desired topology in the spec while CPU hotplug takes place.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.CPUTopology"),
+						},
+					},
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Memory reports the guest memory at boot and the memory plugged into the running domain.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MemoryStatus"),
+						},
+					},
+					"VSOCKCID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VSOCKCID is used to track the allocated VSOCK CID in the VM.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"backup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backup reports the checkpoints the changed blocks of the disks are tracked from, and the running backup.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceBackupStatus"),
+						},
+					},
+					"memoryDump": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryDump reports the last memory dump of the guest.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMemoryDumpStatus"),
+						},
+					},
+					"blockCommit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BlockCommit reports the last block commit of volumes snapshotted with external snapshots.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceBlockCommitStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/client-go/api/v1.VolumeStatus"},
+			"kubevirt.io/client-go/api/v1.CPUTopology", "kubevirt.io/client-go/api/v1.MemoryStatus", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceBackupStatus", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceBlockCommitStatus", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMemoryDumpStatus", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/client-go/api/v1.VolumeStatus"},
 	}
 }
 
@@ -19378,6 +22377,25 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceC
 							Format:      "",
 						},
 					},
+					"ttlSecondsAfterCompletion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TTLSecondsAfterCompletion limits the lifetime of a VirtualMachine with RunStrategy Once which completed. The VirtualMachine is deleted after the given number of seconds since its completion. Only valid with RunStrategy Once.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"instancetype": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InstancetypeMatcher references a instancetype that is used to fill fields in Template",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InstancetypeMatcher"),
+						},
+					},
+					"preference": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreferenceMatcher references a set of preference that is used to fill fields in Template",
+							Ref:         ref("kubevirt.io/client-go/api/v1.PreferenceMatcher"),
+						},
+					},
 					"template": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Template is the direct specification of VirtualMachineInstance",
@@ -19402,7 +22420,7 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceC
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
+			"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.InstancetypeMatcher", "kubevirt.io/client-go/api/v1.PreferenceMatcher", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
 	}
 }
 
@@ -19629,6 +22647,12 @@ func schema_kubevirtio_client_go_api_v1_Volume(ref common.ReferenceCallback) com
 							Ref:         ref("kubevirt.io/client-go/api/v1.EmptyDiskSource"),
 						},
 					},
+					"swapDisk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle. The guest is told to enable the swap through the cloud-init vendor data.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SwapDiskSource"),
+						},
+					},
 					"dataVolume": {
 						SchemaProps: spec.SchemaProps{
 							Description: "DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.",
@@ -19659,12 +22683,65 @@ func schema_kubevirtio_client_go_api_v1_Volume(ref common.ReferenceCallback) com
 							Ref:         ref("kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"),
 						},
 					},
+					"downwardMetrics": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.SysprepSource"},
+			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.SwapDiskSource", "kubevirt.io/client-go/api/v1.SysprepSource"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VolumeMultipathStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VolumeMultipathStatus represents the health of the paths of the multipath map backing a block volume.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"paths": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paths is the number of paths of the multipath map.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"activePaths": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActivePaths is the number of paths of the multipath map which are able to serve I/O. The guest is paused on I/O errors while no path is active.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failedPaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "FailedPaths are the names of the block devices of the paths which are not able to serve I/O.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"paths", "activePaths"},
+			},
+		},
 	}
 }
 
@@ -19757,6 +22834,12 @@ func schema_kubevirtio_client_go_api_v1_VolumeSource(ref common.ReferenceCallbac
 							Ref:         ref("kubevirt.io/client-go/api/v1.EmptyDiskSource"),
 						},
 					},
+					"swapDisk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SwapDisk represents a temporary disk the guest uses as encrypted swap space, it shares the vmis lifecycle. The guest is told to enable the swap through the cloud-init vendor data.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SwapDiskSource"),
+						},
+					},
 					"dataVolume": {
 						SchemaProps: spec.SchemaProps{
 							Description: "DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.",
@@ -19787,11 +22870,17 @@ func schema_kubevirtio_client_go_api_v1_VolumeSource(ref common.ReferenceCallbac
 							Ref:         ref("kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"),
 						},
 					},
+					"downwardMetrics": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.SysprepSource"},
+			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.SwapDiskSource", "kubevirt.io/client-go/api/v1.SysprepSource"},
 	}
 }
 
@@ -19839,8 +22928,45 @@ func schema_kubevirtio_client_go_api_v1_VolumeStatus(ref common.ReferenceCallbac
 					},
 					"hotplugVolume": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If the volume is hotplug, this will contain the hotplug status.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.HotplugVolumeStatus"),
+							Description: "If the volume is hotplug, this will contain the hotplug status.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.HotplugVolumeStatus"),
+						},
+					},
+					"persistentVolumeClaimInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PersistentVolumeClaimInfo contains the capacity and the volume mode of the PVC backing the volume, if it is backed by a PVC or a DataVolume.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.PersistentVolumeClaimInfo"),
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the size in bytes of the disk as seen by the guest. It grows when the PVC backing the disk is expanded while the VirtualMachineInstance is running.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"ioTune": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IOTune holds the I/O limits of the disk of the volume which are in effect in the domain.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DiskIOTune"),
+						},
+					},
+					"multipath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Multipath holds the health of the paths of the multipath map backing the block volume, if it is backed by one.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VolumeMultipathStatus"),
+						},
+					},
+					"containerDiskVolume": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerDiskVolume shows the image of a containerDisk volume.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.ContainerDiskInfo"),
+						},
+					},
+					"externalSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalSnapshot shows the qcow2 overlay the guest writes to and its backing chain, if the volume was snapshotted with external snapshots.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.ExternalSnapshotVolumeStatus"),
 						},
 					},
 				},
@@ -19848,7 +22974,7 @@ func schema_kubevirtio_client_go_api_v1_VolumeStatus(ref common.ReferenceCallbac
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.HotplugVolumeStatus"},
+			"kubevirt.io/client-go/api/v1.ContainerDiskInfo", "kubevirt.io/client-go/api/v1.DiskIOTune", "kubevirt.io/client-go/api/v1.ExternalSnapshotVolumeStatus", "kubevirt.io/client-go/api/v1.HotplugVolumeStatus", "kubevirt.io/client-go/api/v1.PersistentVolumeClaimInfo", "kubevirt.io/client-go/api/v1.VolumeMultipathStatus"},
 	}
 }
 
@@ -19872,12 +22998,18 @@ func schema_kubevirtio_client_go_api_v1_Watchdog(ref common.ReferenceCallback) c
 							Ref:         ref("kubevirt.io/client-go/api/v1.I6300ESBWatchdog"),
 						},
 					},
+					"diag288": {
+						SchemaProps: spec.SchemaProps{
+							Description: "diag288 watchdog device, only available on s390x.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.Diag288Watchdog"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.I6300ESBWatchdog"},
+			"kubevirt.io/client-go/api/v1.Diag288Watchdog", "kubevirt.io/client-go/api/v1.I6300ESBWatchdog"},
 	}
 }
 
@@ -19894,11 +23026,17 @@ func schema_kubevirtio_client_go_api_v1_WatchdogDevice(ref common.ReferenceCallb
 							Ref:         ref("kubevirt.io/client-go/api/v1.I6300ESBWatchdog"),
 						},
 					},
+					"diag288": {
+						SchemaProps: spec.SchemaProps{
+							Description: "diag288 watchdog device, only available on s390x.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.Diag288Watchdog"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.I6300ESBWatchdog"},
+			"kubevirt.io/client-go/api/v1.Diag288Watchdog", "kubevirt.io/client-go/api/v1.I6300ESBWatchdog"},
 	}
 }
 
@@ -19978,6 +23116,76 @@ func schema_client_go_apis_snapshot_v1alpha1_Error(ref common.ReferenceCallback)
 	}
 }
 
+func schema_client_go_apis_snapshot_v1alpha1_ExternalSnapshotBackup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExternalSnapshotBackup contains the files of an external snapshot of a volume",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"file": {
+						SchemaProps: spec.SchemaProps{
+							Description: "File is the image on the PVC which holds the disk at the time of the snapshot, the guest no longer writes to it",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"backingChain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackingChain are the files backing File, from its backing file down to the base image",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"overlay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overlay is the qcow2 overlay the guest writes to since the snapshot",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"file", "overlay"},
+			},
+		},
+	}
+}
+
+func schema_client_go_apis_snapshot_v1alpha1_MemoryDumpBackup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MemoryDumpBackup contains where the memory dump of a snapshot is stored",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of the PVC storing the memory dump",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fileName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FileName is the name of the dump file on the PVC",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"claimName", "fileName"},
+			},
+		},
+	}
+}
+
 func schema_client_go_apis_snapshot_v1alpha1_PersistentVolumeClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -20374,12 +23582,32 @@ func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotContentSpec(r
 							},
 						},
 					},
+					"excludedVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludedVolumes are the volumes of the source that were excluded from the snapshot",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"memoryDump": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryDump is the memory dump of the guest taken with the snapshot",
+							Ref:         ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.MemoryDumpBackup"),
+						},
+					},
 				},
 				Required: []string{"source"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/apis/snapshot/v1alpha1.SourceSpec", "kubevirt.io/client-go/apis/snapshot/v1alpha1.VolumeBackup"},
+			"kubevirt.io/client-go/apis/snapshot/v1alpha1.MemoryDumpBackup", "kubevirt.io/client-go/apis/snapshot/v1alpha1.SourceSpec", "kubevirt.io/client-go/apis/snapshot/v1alpha1.VolumeBackup"},
 	}
 }
 
@@ -20473,6 +23701,187 @@ func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotList(ref comm
 	}
 }
 
+func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSnapshotSchedule creates VirtualMachineSnapshots of a VM on a schedule and deletes the oldest of them beyond the retention count",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleSpec", "kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleStatus"},
+	}
+}
+
+func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotSchedule"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotSchedule"},
+	}
+}
+
+func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/api/core/v1.TypedLocalObjectReference"),
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule is a cron expression evaluated in UTC, e.g. \"0 3 * * *\", or one of @hourly, @daily, @weekly and @monthly",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retention is the number of ready snapshots kept, older ones are deleted. Defaults to 7. Snapshots are kept when the schedule is deleted.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"quiescePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QuiescePolicy defaults to BestEffort",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionPolicy is passed on to the created snapshots",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"source", "schedule"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.TypedLocalObjectReference"},
+	}
+}
+
+func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastScheduleTime is the last time a snapshot was due",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextScheduleTime is the next time a snapshot is due",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastSnapshotName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSnapshotName is the name of the last snapshot created by the schedule",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Error is set if the schedule is invalid or the last snapshot was skipped",
+							Ref:         ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.Error"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/apis/snapshot/v1alpha1.Error"},
+	}
+}
+
 func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -20491,6 +23900,13 @@ func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSpec(ref comm
 							Format: "",
 						},
 					},
+					"includeMemoryDump": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludeMemoryDump dumps the memory of the running guest to the PVC of the memoryDump device of the VirtualMachine before its volumes are snapshotted",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"source"},
 			},
@@ -20547,6 +23963,24 @@ func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotStatus(ref co
 							},
 						},
 					},
+					"indications": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -20579,12 +24013,18 @@ func schema_client_go_apis_snapshot_v1alpha1_VolumeBackup(ref common.ReferenceCa
 							Format: "",
 						},
 					},
+					"externalSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalSnapshot is set instead of VolumeSnapshotName if the volume was snapshotted by switching the disk of the running VirtualMachine to a qcow2 overlay",
+							Ref:         ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.ExternalSnapshotBackup"),
+						},
+					},
 				},
 				Required: []string{"volumeName", "persistentVolumeClaim"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/apis/snapshot/v1alpha1.PersistentVolumeClaim"},
+			"kubevirt.io/client-go/apis/snapshot/v1alpha1.ExternalSnapshotBackup", "kubevirt.io/client-go/apis/snapshot/v1alpha1.PersistentVolumeClaim"},
 	}
 }
 
@@ -20620,7 +24060,7 @@ func schema_client_go_apis_snapshot_v1alpha1_VolumeRestore(ref common.ReferenceC
 						},
 					},
 				},
-				Required: []string{"volumeName", "persistentVolumeClaim", "volumeSnapshotName"},
+				Required: []string{"volumeName", "persistentVolumeClaim"},
 			},
 		},
 	}
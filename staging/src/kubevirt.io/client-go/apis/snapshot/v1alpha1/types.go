@@ -60,6 +60,11 @@ type VirtualMachineSnapshotSpec struct {
 
 	// +optional
 	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// IncludeMemoryDump dumps the memory of the running guest to the PVC of the memoryDump device
+	// of the VirtualMachine before its volumes are snapshotted
+	// +optional
+	IncludeMemoryDump *bool `json:"includeMemoryDump,omitempty"`
 }
 
 // VirtualMachineSnapshotStatus is the status for a VirtualMachineSnapshot resource
@@ -82,8 +87,30 @@ type VirtualMachineSnapshotStatus struct {
 
 	// +optional
 	Conditions []Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// +listType=set
+	Indications []Indication `json:"indications,omitempty"`
 }
 
+// Indication is a way to indicate the state of the vm when taking the snapshot
+type Indication string
+
+const (
+	// VMSnapshotOnlineSnapshotIndication means the VM was running when the snapshot was taken
+	VMSnapshotOnlineSnapshotIndication Indication = "Online"
+
+	// VMSnapshotGuestAgentIndication means the guest filesystems were frozen by the guest agent
+	VMSnapshotGuestAgentIndication Indication = "GuestAgent"
+
+	// VMSnapshotNoGuestAgentIndication means the VM was running without a connected guest agent,
+	// so the snapshot is only crash consistent
+	VMSnapshotNoGuestAgentIndication Indication = "NoGuestAgent"
+
+	// VMSnapshotMemoryDumpIndication means the memory of the guest was dumped with the snapshot
+	VMSnapshotMemoryDumpIndication Indication = "MemoryDump"
+)
+
 // Error is the last error encountered during the snapshot/restore
 type Error struct {
 	// +optional
@@ -155,6 +182,14 @@ type VirtualMachineSnapshotContentSpec struct {
 
 	// +optional
 	VolumeBackups []VolumeBackup `json:"volumeBackups,omitempty"`
+
+	// ExcludedVolumes are the volumes of the source that were excluded from the snapshot
+	// +optional
+	ExcludedVolumes []string `json:"excludedVolumes,omitempty"`
+
+	// MemoryDump is the memory dump of the guest taken with the snapshot
+	// +optional
+	MemoryDump *MemoryDumpBackup `json:"memoryDump,omitempty"`
 }
 
 // SourceSpec contains the appropriate spec for the resource being snapshotted
@@ -184,6 +219,33 @@ type VolumeBackup struct {
 
 	// +optional
 	VolumeSnapshotName *string `json:"volumeSnapshotName,omitempty"`
+
+	// ExternalSnapshot is set instead of VolumeSnapshotName if the volume was snapshotted by switching
+	// the disk of the running VirtualMachine to a qcow2 overlay
+	// +optional
+	ExternalSnapshot *ExternalSnapshotBackup `json:"externalSnapshot,omitempty"`
+}
+
+// ExternalSnapshotBackup contains the files of an external snapshot of a volume
+type ExternalSnapshotBackup struct {
+	// File is the image on the PVC which holds the disk at the time of the snapshot, the guest no longer writes to it
+	File string `json:"file"`
+
+	// BackingChain are the files backing File, from its backing file down to the base image
+	// +optional
+	BackingChain []string `json:"backingChain,omitempty"`
+
+	// Overlay is the qcow2 overlay the guest writes to since the snapshot
+	Overlay string `json:"overlay"`
+}
+
+// MemoryDumpBackup contains where the memory dump of a snapshot is stored
+type MemoryDumpBackup struct {
+	// ClaimName is the name of the PVC storing the memory dump
+	ClaimName string `json:"claimName"`
+
+	// FileName is the name of the dump file on the PVC
+	FileName string `json:"fileName"`
 }
 
 // VirtualMachineSnapshotContentStatus is the status for a VirtualMachineSnapshotStatus resource
@@ -271,7 +333,8 @@ type VolumeRestore struct {
 
 	PersistentVolumeClaimName string `json:"persistentVolumeClaim"`
 
-	VolumeSnapshotName string `json:"volumeSnapshotName"`
+	// +optional
+	VolumeSnapshotName string `json:"volumeSnapshotName,omitempty"`
 
 	// +optional
 	DataVolumeName *string `json:"dataVolumeName,omitempty"`
@@ -285,3 +348,81 @@ type VirtualMachineRestoreList struct {
 
 	Items []VirtualMachineRestore `json:"items"`
 }
+
+// VirtualMachineSnapshotSchedule creates VirtualMachineSnapshots of a VM on a schedule
+// and deletes the oldest of them beyond the retention count
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineSnapshotScheduleSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineSnapshotScheduleStatus `json:"status,omitempty"`
+}
+
+// QuiescePolicy defines whether scheduled snapshots require the guest filesystems to be frozen
+type QuiescePolicy string
+
+const (
+	// QuiesceBestEffort freezes the guest filesystems if the guest agent is connected,
+	// otherwise the snapshot is only crash consistent
+	QuiesceBestEffort QuiescePolicy = "BestEffort"
+
+	// QuiesceRequired skips the snapshot if the VM is running without a connected guest agent
+	QuiesceRequired QuiescePolicy = "Required"
+)
+
+// VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource
+type VirtualMachineSnapshotScheduleSpec struct {
+	Source corev1.TypedLocalObjectReference `json:"source"`
+
+	// Schedule is a cron expression evaluated in UTC, e.g. "0 3 * * *",
+	// or one of @hourly, @daily, @weekly and @monthly
+	Schedule string `json:"schedule"`
+
+	// Retention is the number of ready snapshots kept, older ones are deleted. Defaults to 7.
+	// Snapshots are kept when the schedule is deleted.
+	// +optional
+	Retention *int32 `json:"retention,omitempty"`
+
+	// QuiescePolicy defaults to BestEffort
+	// +optional
+	QuiescePolicy *QuiescePolicy `json:"quiescePolicy,omitempty"`
+
+	// DeletionPolicy is passed on to the created snapshots
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource
+type VirtualMachineSnapshotScheduleStatus struct {
+	// LastScheduleTime is the last time a snapshot was due
+	// +optional
+	// +nullable
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is the next time a snapshot is due
+	// +optional
+	// +nullable
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// LastSnapshotName is the name of the last snapshot created by the schedule
+	// +optional
+	LastSnapshotName *string `json:"lastSnapshotName,omitempty"`
+
+	// Error is set if the schedule is invalid or the last snapshot was skipped
+	// +optional
+	Error *Error `json:"error,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineSnapshotSchedule `json:"items"`
+}
@@ -11,8 +11,9 @@ func (VirtualMachineSnapshot) SwaggerDoc() map[string]string {
 
 func (VirtualMachineSnapshotSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":               "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
-		"deletionPolicy": "+optional",
+		"":                  "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
+		"deletionPolicy":    "+optional",
+		"includeMemoryDump": "IncludeMemoryDump dumps the memory of the running guest to the PVC of the memoryDump device\nof the VirtualMachine before its volumes are snapshotted\n+optional",
 	}
 }
 
@@ -25,6 +26,7 @@ func (VirtualMachineSnapshotStatus) SwaggerDoc() map[string]string {
 		"readyToUse":                        "+optional",
 		"error":                             "+optional",
 		"conditions":                        "+optional",
+		"indications":                       "+optional\n+listType=set",
 	}
 }
 
@@ -61,8 +63,10 @@ func (VirtualMachineSnapshotContent) SwaggerDoc() map[string]string {
 
 func (VirtualMachineSnapshotContentSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent resource",
-		"volumeBackups": "+optional",
+		"":                "VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent resource",
+		"volumeBackups":   "+optional",
+		"excludedVolumes": "ExcludedVolumes are the volumes of the source that were excluded from the snapshot\n+optional",
+		"memoryDump":      "MemoryDump is the memory dump of the guest taken with the snapshot\n+optional",
 	}
 }
 
@@ -83,6 +87,24 @@ func (VolumeBackup) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                   "VolumeBackup contains the data neeed to restore a PVC",
 		"volumeSnapshotName": "+optional",
+		"externalSnapshot":   "ExternalSnapshot is set instead of VolumeSnapshotName if the volume was snapshotted by switching\nthe disk of the running VirtualMachine to a qcow2 overlay\n+optional",
+	}
+}
+
+func (ExternalSnapshotBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "ExternalSnapshotBackup contains the files of an external snapshot of a volume",
+		"file":         "File is the image on the PVC which holds the disk at the time of the snapshot, the guest no longer writes to it",
+		"backingChain": "BackingChain are the files backing File, from its backing file down to the base image\n+optional",
+		"overlay":      "Overlay is the qcow2 overlay the guest writes to since the snapshot",
+	}
+}
+
+func (MemoryDumpBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "MemoryDumpBackup contains where the memory dump of a snapshot is stored",
+		"claimName": "ClaimName is the name of the PVC storing the memory dump",
+		"fileName":  "FileName is the name of the dump file on the PVC",
 	}
 }
 
@@ -138,8 +160,9 @@ func (VirtualMachineRestoreStatus) SwaggerDoc() map[string]string {
 
 func (VolumeRestore) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":               "VolumeRestore contains the data neeed to restore a PVC",
-		"dataVolumeName": "+optional",
+		"":                   "VolumeRestore contains the data neeed to restore a PVC",
+		"volumeSnapshotName": "+optional",
+		"dataVolumeName":     "+optional",
 	}
 }
 
@@ -148,3 +171,36 @@ func (VirtualMachineRestoreList) SwaggerDoc() map[string]string {
 		"": "VirtualMachineRestoreList is a list of VirtualMachineRestore resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
 	}
 }
+
+func (VirtualMachineSnapshotSchedule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineSnapshotSchedule creates VirtualMachineSnapshots of a VM on a schedule\nand deletes the oldest of them beyond the retention count\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource",
+		"schedule":       "Schedule is a cron expression evaluated in UTC, e.g. \"0 3 * * *\",\nor one of @hourly, @daily, @weekly and @monthly",
+		"retention":      "Retention is the number of ready snapshots kept, older ones are deleted. Defaults to 7.\nSnapshots are kept when the schedule is deleted.\n+optional",
+		"quiescePolicy":  "QuiescePolicy defaults to BestEffort\n+optional",
+		"deletionPolicy": "DeletionPolicy is passed on to the created snapshots\n+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource",
+		"lastScheduleTime": "LastScheduleTime is the last time a snapshot was due\n+optional\n+nullable",
+		"nextScheduleTime": "NextScheduleTime is the next time a snapshot is due\n+optional\n+nullable",
+		"lastSnapshotName": "LastSnapshotName is the name of the last snapshot created by the schedule\n+optional",
+		"error":            "Error is set if the schedule is invalid or the last snapshot was skipped\n+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
@@ -25,11 +25,21 @@ import (
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
 
+	checkupv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/checkup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/export/v1alpha1"
+	instancetypev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/instancetype/v1alpha1"
+	poolv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
 	snapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 )
 
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
+	CheckupV1alpha1() checkupv1alpha1.CheckupV1alpha1Interface
+	CloneV1alpha1() clonev1alpha1.CloneV1alpha1Interface
+	ExportV1alpha1() exportv1alpha1.ExportV1alpha1Interface
+	InstancetypeV1alpha1() instancetypev1alpha1.InstancetypeV1alpha1Interface
+	PoolV1alpha1() poolv1alpha1.PoolV1alpha1Interface
 	SnapshotV1alpha1() snapshotv1alpha1.SnapshotV1alpha1Interface
 }
 
@@ -37,7 +47,37 @@ type Interface interface {
 // version included in a Clientset.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	snapshotV1alpha1 *snapshotv1alpha1.SnapshotV1alpha1Client
+	checkupV1alpha1      *checkupv1alpha1.CheckupV1alpha1Client
+	cloneV1alpha1        *clonev1alpha1.CloneV1alpha1Client
+	exportV1alpha1       *exportv1alpha1.ExportV1alpha1Client
+	instancetypeV1alpha1 *instancetypev1alpha1.InstancetypeV1alpha1Client
+	poolV1alpha1         *poolv1alpha1.PoolV1alpha1Client
+	snapshotV1alpha1     *snapshotv1alpha1.SnapshotV1alpha1Client
+}
+
+// CheckupV1alpha1 retrieves the CheckupV1alpha1Client
+func (c *Clientset) CheckupV1alpha1() checkupv1alpha1.CheckupV1alpha1Interface {
+	return c.checkupV1alpha1
+}
+
+// CloneV1alpha1 retrieves the CloneV1alpha1Client
+func (c *Clientset) CloneV1alpha1() clonev1alpha1.CloneV1alpha1Interface {
+	return c.cloneV1alpha1
+}
+
+// ExportV1alpha1 retrieves the ExportV1alpha1Client
+func (c *Clientset) ExportV1alpha1() exportv1alpha1.ExportV1alpha1Interface {
+	return c.exportV1alpha1
+}
+
+// InstancetypeV1alpha1 retrieves the InstancetypeV1alpha1Client
+func (c *Clientset) InstancetypeV1alpha1() instancetypev1alpha1.InstancetypeV1alpha1Interface {
+	return c.instancetypeV1alpha1
+}
+
+// PoolV1alpha1 retrieves the PoolV1alpha1Client
+func (c *Clientset) PoolV1alpha1() poolv1alpha1.PoolV1alpha1Interface {
+	return c.poolV1alpha1
 }
 
 // SnapshotV1alpha1 retrieves the SnapshotV1alpha1Client
@@ -66,6 +106,26 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	}
 	var cs Clientset
 	var err error
+	cs.checkupV1alpha1, err = checkupv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.cloneV1alpha1, err = clonev1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.exportV1alpha1, err = exportv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.instancetypeV1alpha1, err = instancetypev1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.poolV1alpha1, err = poolv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	cs.snapshotV1alpha1, err = snapshotv1alpha1.NewForConfig(&configShallowCopy)
 	if err != nil {
 		return nil, err
@@ -82,6 +142,11 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 // panics if there is an error in the config.
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
+	cs.checkupV1alpha1 = checkupv1alpha1.NewForConfigOrDie(c)
+	cs.cloneV1alpha1 = clonev1alpha1.NewForConfigOrDie(c)
+	cs.exportV1alpha1 = exportv1alpha1.NewForConfigOrDie(c)
+	cs.instancetypeV1alpha1 = instancetypev1alpha1.NewForConfigOrDie(c)
+	cs.poolV1alpha1 = poolv1alpha1.NewForConfigOrDie(c)
 	cs.snapshotV1alpha1 = snapshotv1alpha1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
@@ -91,6 +156,11 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 // New creates a new Clientset for the given RESTClient.
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
+	cs.checkupV1alpha1 = checkupv1alpha1.New(c)
+	cs.cloneV1alpha1 = clonev1alpha1.New(c)
+	cs.exportV1alpha1 = exportv1alpha1.New(c)
+	cs.instancetypeV1alpha1 = instancetypev1alpha1.New(c)
+	cs.poolV1alpha1 = poolv1alpha1.New(c)
 	cs.snapshotV1alpha1 = snapshotv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
@@ -26,6 +26,16 @@ import (
 	"k8s.io/client-go/testing"
 
 	clientset "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
+	checkupv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/checkup/v1alpha1"
+	fakecheckupv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/checkup/v1alpha1/fake"
+	clonev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/clone/v1alpha1"
+	fakeclonev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/clone/v1alpha1/fake"
+	exportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/export/v1alpha1"
+	fakeexportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/export/v1alpha1/fake"
+	instancetypev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/instancetype/v1alpha1"
+	fakeinstancetypev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/instancetype/v1alpha1/fake"
+	poolv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
+	fakepoolv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1/fake"
 	snapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	fakesnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1/fake"
 )
@@ -77,6 +87,31 @@ func (c *Clientset) Tracker() testing.ObjectTracker {
 
 var _ clientset.Interface = &Clientset{}
 
+// CheckupV1alpha1 retrieves the CheckupV1alpha1Client
+func (c *Clientset) CheckupV1alpha1() checkupv1alpha1.CheckupV1alpha1Interface {
+	return &fakecheckupv1alpha1.FakeCheckupV1alpha1{Fake: &c.Fake}
+}
+
+// CloneV1alpha1 retrieves the CloneV1alpha1Client
+func (c *Clientset) CloneV1alpha1() clonev1alpha1.CloneV1alpha1Interface {
+	return &fakeclonev1alpha1.FakeCloneV1alpha1{Fake: &c.Fake}
+}
+
+// ExportV1alpha1 retrieves the ExportV1alpha1Client
+func (c *Clientset) ExportV1alpha1() exportv1alpha1.ExportV1alpha1Interface {
+	return &fakeexportv1alpha1.FakeExportV1alpha1{Fake: &c.Fake}
+}
+
+// InstancetypeV1alpha1 retrieves the InstancetypeV1alpha1Client
+func (c *Clientset) InstancetypeV1alpha1() instancetypev1alpha1.InstancetypeV1alpha1Interface {
+	return &fakeinstancetypev1alpha1.FakeInstancetypeV1alpha1{Fake: &c.Fake}
+}
+
+// PoolV1alpha1 retrieves the PoolV1alpha1Client
+func (c *Clientset) PoolV1alpha1() poolv1alpha1.PoolV1alpha1Interface {
+	return &fakepoolv1alpha1.FakePoolV1alpha1{Fake: &c.Fake}
+}
+
 // SnapshotV1alpha1 retrieves the SnapshotV1alpha1Client
 func (c *Clientset) SnapshotV1alpha1() snapshotv1alpha1.SnapshotV1alpha1Interface {
 	return &fakesnapshotv1alpha1.FakeSnapshotV1alpha1{Fake: &c.Fake}
@@ -25,6 +25,11 @@ import (
 	serializer "k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
+	checkupv1alpha1 "kubevirt.io/client-go/apis/checkup/v1alpha1"
+	clonev1alpha1 "kubevirt.io/client-go/apis/clone/v1alpha1"
+	exportv1alpha1 "kubevirt.io/client-go/apis/export/v1alpha1"
+	instancetypev1alpha1 "kubevirt.io/client-go/apis/instancetype/v1alpha1"
+	poolv1alpha1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1alpha1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 )
 
@@ -32,20 +37,25 @@ var Scheme = runtime.NewScheme()
 var Codecs = serializer.NewCodecFactory(Scheme)
 var ParameterCodec = runtime.NewParameterCodec(Scheme)
 var localSchemeBuilder = runtime.SchemeBuilder{
+	checkupv1alpha1.AddToScheme,
+	clonev1alpha1.AddToScheme,
+	exportv1alpha1.AddToScheme,
+	instancetypev1alpha1.AddToScheme,
+	poolv1alpha1.AddToScheme,
 	snapshotv1alpha1.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
 // of clientsets, like in:
 //
-//   import (
-//     "k8s.io/client-go/kubernetes"
-//     clientsetscheme "k8s.io/client-go/kubernetes/scheme"
-//     aggregatorclientsetscheme "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset/scheme"
-//   )
+//	import (
+//	  "k8s.io/client-go/kubernetes"
+//	  clientsetscheme "k8s.io/client-go/kubernetes/scheme"
+//	  aggregatorclientsetscheme "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset/scheme"
+//	)
 //
-//   kclientset, _ := kubernetes.NewForConfig(c)
-//   _ = aggregatorclientsetscheme.AddToScheme(clientsetscheme.Scheme)
+//	kclientset, _ := kubernetes.NewForConfig(c)
+//	_ = aggregatorclientsetscheme.AddToScheme(clientsetscheme.Scheme)
 //
 // After this, RawExtensions in Kubernetes types will serialize kube-aggregator types
 // correctly.
@@ -4,6 +4,8 @@
 package kubecli
 
 import (
+	time "time"
+
 	gomock "github.com/golang/mock/gomock"
 	v1 "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	v10 "k8s.io/api/autoscaling/v1"
@@ -61,6 +63,11 @@ import (
 	versioned "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned"
 	versioned0 "kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned"
 	versioned1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
+	v1alpha111 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/checkup/v1alpha1"
+	v1alpha18 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/clone/v1alpha1"
+	v1alpha17 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/export/v1alpha1"
+	v1alpha19 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/instancetype/v1alpha1"
+	v1alpha110 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
 	v1alpha16 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	versioned2 "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	versioned3 "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
@@ -177,6 +184,96 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestore(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestore", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineSnapshotSchedule(namespace string) v1alpha16.VirtualMachineSnapshotScheduleInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineSnapshotSchedule", namespace)
+	ret0, _ := ret[0].(v1alpha16.VirtualMachineSnapshotScheduleInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineSnapshotSchedule(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineSnapshotSchedule", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineExport(namespace string) v1alpha17.VirtualMachineExportInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineExport", namespace)
+	ret0, _ := ret[0].(v1alpha17.VirtualMachineExportInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineExport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineExport", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineClone(namespace string) v1alpha18.VirtualMachineCloneInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineClone", namespace)
+	ret0, _ := ret[0].(v1alpha18.VirtualMachineCloneInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineClone(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineClone", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineInstancetype(namespace string) v1alpha19.VirtualMachineInstancetypeInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineInstancetype", namespace)
+	ret0, _ := ret[0].(v1alpha19.VirtualMachineInstancetypeInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineInstancetype(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineInstancetype", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineClusterInstancetype() v1alpha19.VirtualMachineClusterInstancetypeInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineClusterInstancetype")
+	ret0, _ := ret[0].(v1alpha19.VirtualMachineClusterInstancetypeInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineClusterInstancetype() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineClusterInstancetype")
+}
+
+func (_m *MockKubevirtClient) VirtualMachinePreference(namespace string) v1alpha19.VirtualMachinePreferenceInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachinePreference", namespace)
+	ret0, _ := ret[0].(v1alpha19.VirtualMachinePreferenceInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachinePreference(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachinePreference", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineClusterPreference() v1alpha19.VirtualMachineClusterPreferenceInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineClusterPreference")
+	ret0, _ := ret[0].(v1alpha19.VirtualMachineClusterPreferenceInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineClusterPreference() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineClusterPreference")
+}
+
+func (_m *MockKubevirtClient) VirtualMachinePool(namespace string) v1alpha110.VirtualMachinePoolInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachinePool", namespace)
+	ret0, _ := ret[0].(v1alpha110.VirtualMachinePoolInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachinePool(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachinePool", arg0)
+}
+
+func (_m *MockKubevirtClient) StorageCheckup(namespace string) v1alpha111.StorageCheckupInterface {
+	ret := _m.ctrl.Call(_m, "StorageCheckup", namespace)
+	ret0, _ := ret[0].(v1alpha111.StorageCheckupInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) StorageCheckup(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StorageCheckup", arg0)
+}
+
 func (_m *MockKubevirtClient) ServerVersion() *ServerVersion {
 	ret := _m.ctrl.Call(_m, "ServerVersion")
 	ret0, _ := ret[0].(*ServerVersion)
@@ -881,6 +978,72 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) VNC(arg0 interface{}) *
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VNC", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) VSOCK(name string, options *VSOCKOptions) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "VSOCK", name, options)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) VSOCK(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VSOCK", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) SerialPort(name string, options *SerialPortOptions) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "SerialPort", name, options)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SerialPort(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SerialPort", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Channel(name string, options *ChannelOptions) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "Channel", name, options)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Channel(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Channel", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Pcap(name string, options *PcapOptions) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "Pcap", name, options)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Pcap(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Pcap", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) PortForward(name string, options *PortForwardOptions) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "PortForward", name, options)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) PortForward(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PortForward", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Backup(name string, options *BackupStreamOptions) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "Backup", name, options)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Backup(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Backup", arg0, arg1)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) Pause(name string) error {
 	ret := _m.ctrl.Call(_m, "Pause", name)
 	ret0, _ := ret[0].(error)
@@ -954,6 +1117,169 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) RemoveVolume(arg0, arg1
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) AddInterface(name string, addInterfaceOptions *v117.AddInterfaceOptions) error {
+	ret := _m.ctrl.Call(_m, "AddInterface", name, addInterfaceOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) AddInterface(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddInterface", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) RemoveInterface(name string, removeInterfaceOptions *v117.RemoveInterfaceOptions) error {
+	ret := _m.ctrl.Call(_m, "RemoveInterface", name, removeInterfaceOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) RemoveInterface(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveInterface", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) SetLinkState(name string, setLinkStateOptions *v117.SetLinkStateOptions) error {
+	ret := _m.ctrl.Call(_m, "SetLinkState", name, setLinkStateOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SetLinkState(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetLinkState", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) InsertMedia(name string, insertMediaOptions *v117.InsertMediaOptions) error {
+	ret := _m.ctrl.Call(_m, "InsertMedia", name, insertMediaOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) InsertMedia(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "InsertMedia", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) EjectMedia(name string, ejectMediaOptions *v117.EjectMediaOptions) error {
+	ret := _m.ctrl.Call(_m, "EjectMedia", name, ejectMediaOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) EjectMedia(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "EjectMedia", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) SEVFetchCertChain(name string) (v117.SEVPlatformInfo, error) {
+	ret := _m.ctrl.Call(_m, "SEVFetchCertChain", name)
+	ret0, _ := ret[0].(v117.SEVPlatformInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SEVFetchCertChain(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SEVFetchCertChain", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) SEVQueryLaunchMeasurement(name string) (v117.SEVMeasurementInfo, error) {
+	ret := _m.ctrl.Call(_m, "SEVQueryLaunchMeasurement", name)
+	ret0, _ := ret[0].(v117.SEVMeasurementInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SEVQueryLaunchMeasurement(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SEVQueryLaunchMeasurement", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) SEVInjectLaunchSecret(name string, options *v117.SEVSecretOptions) error {
+	ret := _m.ctrl.Call(_m, "SEVInjectLaunchSecret", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SEVInjectLaunchSecret(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SEVInjectLaunchSecret", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) SerialConsoleLog(name string) (string, error) {
+	ret := _m.ctrl.Call(_m, "SerialConsoleLog", name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SerialConsoleLog(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SerialConsoleLog", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Freeze(name string, unfreezeTimeout time.Duration) error {
+	ret := _m.ctrl.Call(_m, "Freeze", name, unfreezeTimeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Freeze(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Freeze", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Unfreeze(name string) error {
+	ret := _m.ctrl.Call(_m, "Unfreeze", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unfreeze(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unfreeze", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) StartBackup(name string, options *v117.BackupOptions) error {
+	ret := _m.ctrl.Call(_m, "StartBackup", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) StartBackup(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartBackup", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) StopBackup(name string) error {
+	ret := _m.ctrl.Call(_m, "StopBackup", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) StopBackup(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StopBackup", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) MemoryDump(name string, options *v117.MemoryDumpOptions) error {
+	ret := _m.ctrl.Call(_m, "MemoryDump", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) MemoryDump(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MemoryDump", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) ExternalSnapshot(name string, options *v117.ExternalSnapshotOptions) error {
+	ret := _m.ctrl.Call(_m, "ExternalSnapshot", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) ExternalSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExternalSnapshot", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) BlockCommit(name string, options *v117.BlockCommitOptions) error {
+	ret := _m.ctrl.Call(_m, "BlockCommit", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) BlockCommit(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BlockCommit", arg0, arg1)
+}
+
 // Mock of ReplicaSetInterface interface
 type MockReplicaSetInterface struct {
 	ctrl     *gomock.Controller
@@ -1343,6 +1669,16 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) Migrate(arg0 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Migrate", arg0)
 }
 
+func (_m *MockVirtualMachineInterface) NetworkBoot(name string) error {
+	ret := _m.ctrl.Call(_m, "NetworkBoot", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) NetworkBoot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NetworkBoot", arg0)
+}
+
 func (_m *MockVirtualMachineInterface) Rename(name string, options *v117.RenameOptions) error {
 	ret := _m.ctrl.Call(_m, "Rename", name, options)
 	ret0, _ := ret[0].(error)
@@ -1373,6 +1709,16 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveVolume(arg0, arg1 interfa
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInterface) RemoveInterface(name string, removeInterfaceOptions *v117.RemoveInterfaceOptions) error {
+	ret := _m.ctrl.Call(_m, "RemoveInterface", name, removeInterfaceOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveInterface(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveInterface", arg0, arg1)
+}
+
 // Mock of VirtualMachineInstanceMigrationInterface interface
 type MockVirtualMachineInstanceMigrationInterface struct {
 	ctrl     *gomock.Controller
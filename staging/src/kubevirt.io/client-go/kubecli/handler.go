@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -19,13 +21,30 @@ import (
 )
 
 const (
-	consoleTemplateURI        = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/console"
-	vncTemplateURI            = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/vnc"
-	pauseTemplateURI          = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/pause"
-	unpauseTemplateURI        = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/unpause"
-	guestInfoTemplateURI      = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/guestosinfo"
-	userListTemplateURI       = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/userlist"
-	filesystemListTemplateURI = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/filesystemlist"
+	consoleTemplateURI                   = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/console"
+	vncTemplateURI                       = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/vnc"
+	vsockTemplateURI                     = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/vsock?port=%d"
+	serialTemplateURI                    = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/serial?device=%s"
+	channelTemplateURI                   = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/channel?device=%s"
+	pcapTemplateURI                      = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/pcap?%s"
+	portForwardTemplateURI               = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/portforward?%s"
+	backupTemplateURI                    = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/backup?%s"
+	pauseTemplateURI                     = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/pause"
+	unpauseTemplateURI                   = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/unpause"
+	freezeTemplateURI                    = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/freeze"
+	unfreezeTemplateURI                  = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/unfreeze"
+	startBackupTemplateURI               = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/startbackup"
+	stopBackupTemplateURI                = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/stopbackup"
+	memoryDumpTemplateURI                = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/memorydump"
+	externalSnapshotTemplateURI          = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/externalsnapshot"
+	blockCommitTemplateURI               = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/blockcommit"
+	guestInfoTemplateURI                 = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/guestosinfo"
+	userListTemplateURI                  = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/userlist"
+	filesystemListTemplateURI            = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/filesystemlist"
+	sevFetchCertChainTemplateURI         = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/sev/fetchcertchain"
+	sevQueryLaunchMeasurementTemplateURI = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/sev/querylaunchmeasurement"
+	sevInjectLaunchSecretTemplateURI     = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/sev/injectlaunchsecret"
+	serialConsoleLogTemplateURI          = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/console/log"
 )
 
 func NewVirtHandlerClient(client KubevirtClient) VirtHandlerClient {
@@ -46,14 +65,31 @@ type VirtHandlerConn interface {
 	ConnectionDetails() (ip string, port int, err error)
 	ConsoleURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	VNCURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	VSOCKURI(vmi *virtv1.VirtualMachineInstance, port uint32) (string, error)
+	SerialPortURI(vmi *virtv1.VirtualMachineInstance, device string) (string, error)
+	ChannelURI(vmi *virtv1.VirtualMachineInstance, device string) (string, error)
+	PcapURI(vmi *virtv1.VirtualMachineInstance, options *PcapOptions) (string, error)
+	PortForwardURI(vmi *virtv1.VirtualMachineInstance, options *PortForwardOptions) (string, error)
+	BackupURI(vmi *virtv1.VirtualMachineInstance, options *BackupStreamOptions) (string, error)
 	PauseURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	UnpauseURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	FreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	UnfreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	StartBackupURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	StopBackupURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	MemoryDumpURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	ExternalSnapshotURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	BlockCommitURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	Pod() (pod *v1.Pod, err error)
-	Put(url string, tlsConfig *tls.Config) error
+	Put(url string, tlsConfig *tls.Config, body io.ReadCloser) error
 	Get(url string, tlsConfig *tls.Config) (string, error)
 	GuestInfoURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	UserListURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	FilesystemListURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	SEVFetchCertChainURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	SEVQueryLaunchMeasurementURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	SEVInjectLaunchSecretURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	SerialConsoleLogURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 }
 
 type virtHandler struct {
@@ -140,7 +176,7 @@ func (v *virtHandlerConn) ConnectionDetails() (ip string, port int, err error) {
 	return
 }
 
-//TODO move the actual ws handling in here, and work with channels
+// TODO move the actual ws handling in here, and work with channels
 func (v *virtHandlerConn) ConsoleURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
 	ip, port, err := v.ConnectionDetails()
 	if err != nil {
@@ -159,6 +195,60 @@ func (v *virtHandlerConn) VNCURI(vmi *virtv1.VirtualMachineInstance) (string, er
 	return fmt.Sprintf(vncTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
 }
 
+func (v *virtHandlerConn) VSOCKURI(vmi *virtv1.VirtualMachineInstance, port uint32) (string, error) {
+	ip, handlerPort, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(vsockTemplateURI, formatIpForUri(ip), handlerPort, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name, port), nil
+}
+
+func (v *virtHandlerConn) SerialPortURI(vmi *virtv1.VirtualMachineInstance, device string) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(serialTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name, url.QueryEscape(device)), nil
+}
+
+func (v *virtHandlerConn) ChannelURI(vmi *virtv1.VirtualMachineInstance, device string) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(channelTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name, url.QueryEscape(device)), nil
+}
+
+func (v *virtHandlerConn) PcapURI(vmi *virtv1.VirtualMachineInstance, options *PcapOptions) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(pcapTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name, options.queryParams().Encode()), nil
+}
+
+func (v *virtHandlerConn) PortForwardURI(vmi *virtv1.VirtualMachineInstance, options *PortForwardOptions) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(portForwardTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name, options.queryParams().Encode()), nil
+}
+
+func (v *virtHandlerConn) BackupURI(vmi *virtv1.VirtualMachineInstance, options *BackupStreamOptions) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(backupTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name, options.queryParams().Encode()), nil
+}
+
 func (v *virtHandlerConn) PauseURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
 	ip, port, err := v.ConnectionDetails()
 	if err != nil {
@@ -177,6 +267,69 @@ func (v *virtHandlerConn) UnpauseURI(vmi *virtv1.VirtualMachineInstance) (string
 	return fmt.Sprintf(unpauseTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
 }
 
+func (v *virtHandlerConn) FreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(freezeTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) UnfreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(unfreezeTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) StartBackupURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(startBackupTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) StopBackupURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(stopBackupTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) MemoryDumpURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(memoryDumpTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) ExternalSnapshotURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(externalSnapshotTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) BlockCommitURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(blockCommitTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
 func (v *virtHandlerConn) Pod() (pod *v1.Pod, err error) {
 	if v.err != nil {
 		err = v.err
@@ -185,7 +338,7 @@ func (v *virtHandlerConn) Pod() (pod *v1.Pod, err error) {
 	return v.pod, err
 }
 
-func (v *virtHandlerConn) Put(url string, tlsConfig *tls.Config) error {
+func (v *virtHandlerConn) Put(url string, tlsConfig *tls.Config, body io.ReadCloser) error {
 
 	client := http.Client{
 		Transport: &http.Transport{
@@ -194,10 +347,13 @@ func (v *virtHandlerConn) Put(url string, tlsConfig *tls.Config) error {
 		Timeout: 10 * time.Second,
 	}
 
-	req, err := http.NewRequest(http.MethodPut, url, nil)
+	req, err := http.NewRequest(http.MethodPut, url, body)
 	if err != nil {
 		return err
 	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -277,3 +433,35 @@ func (v *virtHandlerConn) FilesystemListURI(vmi *virtv1.VirtualMachineInstance)
 	}
 	return fmt.Sprintf(filesystemListTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
 }
+
+func (v *virtHandlerConn) SEVFetchCertChainURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(sevFetchCertChainTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) SEVQueryLaunchMeasurementURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(sevQueryLaunchMeasurementTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) SEVInjectLaunchSecretURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(sevInjectLaunchSecretTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) SerialConsoleLogURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(serialConsoleLogTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
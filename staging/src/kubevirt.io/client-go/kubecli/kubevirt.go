@@ -27,6 +27,7 @@ package kubecli
 
 import (
 	"io"
+	"time"
 
 	secv1 "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	autov1 "k8s.io/api/autoscaling/v1"
@@ -42,6 +43,11 @@ import (
 	cdiclient "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned"
 	k8ssnapshotclient "kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned"
 	generatedclient "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
+	checkupv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/checkup/v1alpha1"
+	vmclonev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/clone/v1alpha1"
+	vmexportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/export/v1alpha1"
+	instancetypev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/instancetype/v1alpha1"
+	poolv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
 	vmsnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	networkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	promclient "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
@@ -57,6 +63,15 @@ type KubevirtClient interface {
 	VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface
 	VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface
 	VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface
+	VirtualMachineSnapshotSchedule(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotScheduleInterface
+	VirtualMachineExport(namespace string) vmexportv1alpha1.VirtualMachineExportInterface
+	VirtualMachineClone(namespace string) vmclonev1alpha1.VirtualMachineCloneInterface
+	VirtualMachineInstancetype(namespace string) instancetypev1alpha1.VirtualMachineInstancetypeInterface
+	VirtualMachineClusterInstancetype() instancetypev1alpha1.VirtualMachineClusterInstancetypeInterface
+	VirtualMachinePreference(namespace string) instancetypev1alpha1.VirtualMachinePreferenceInterface
+	VirtualMachineClusterPreference() instancetypev1alpha1.VirtualMachineClusterPreferenceInterface
+	VirtualMachinePool(namespace string) poolv1alpha1.VirtualMachinePoolInterface
+	StorageCheckup(namespace string) checkupv1alpha1.StorageCheckupInterface
 	ServerVersion() *ServerVersion
 	RestClient() *rest.RESTClient
 	GeneratedKubeVirtClient() generatedclient.Interface
@@ -137,6 +152,42 @@ func (k kubevirt) VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.Vir
 	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineRestores(namespace)
 }
 
+func (k kubevirt) VirtualMachineSnapshotSchedule(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotScheduleInterface {
+	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineSnapshotSchedules(namespace)
+}
+
+func (k kubevirt) VirtualMachineExport(namespace string) vmexportv1alpha1.VirtualMachineExportInterface {
+	return k.generatedKubeVirtClient.ExportV1alpha1().VirtualMachineExports(namespace)
+}
+
+func (k kubevirt) VirtualMachineClone(namespace string) vmclonev1alpha1.VirtualMachineCloneInterface {
+	return k.generatedKubeVirtClient.CloneV1alpha1().VirtualMachineClones(namespace)
+}
+
+func (k kubevirt) VirtualMachineInstancetype(namespace string) instancetypev1alpha1.VirtualMachineInstancetypeInterface {
+	return k.generatedKubeVirtClient.InstancetypeV1alpha1().VirtualMachineInstancetypes(namespace)
+}
+
+func (k kubevirt) VirtualMachineClusterInstancetype() instancetypev1alpha1.VirtualMachineClusterInstancetypeInterface {
+	return k.generatedKubeVirtClient.InstancetypeV1alpha1().VirtualMachineClusterInstancetypes()
+}
+
+func (k kubevirt) VirtualMachinePreference(namespace string) instancetypev1alpha1.VirtualMachinePreferenceInterface {
+	return k.generatedKubeVirtClient.InstancetypeV1alpha1().VirtualMachinePreferences(namespace)
+}
+
+func (k kubevirt) VirtualMachineClusterPreference() instancetypev1alpha1.VirtualMachineClusterPreferenceInterface {
+	return k.generatedKubeVirtClient.InstancetypeV1alpha1().VirtualMachineClusterPreferences()
+}
+
+func (k kubevirt) VirtualMachinePool(namespace string) poolv1alpha1.VirtualMachinePoolInterface {
+	return k.generatedKubeVirtClient.PoolV1alpha1().VirtualMachinePools(namespace)
+}
+
+func (k kubevirt) StorageCheckup(namespace string) checkupv1alpha1.StorageCheckupInterface {
+	return k.generatedKubeVirtClient.CheckupV1alpha1().StorageCheckups(namespace)
+}
+
 func (k kubevirt) KubernetesSnapshotClient() k8ssnapshotclient.Interface {
 	return k.snapshotClient
 }
@@ -163,6 +214,12 @@ type VirtualMachineInstanceInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstance, err error)
 	SerialConsole(name string, options *SerialConsoleOptions) (StreamInterface, error)
 	VNC(name string) (StreamInterface, error)
+	VSOCK(name string, options *VSOCKOptions) (StreamInterface, error)
+	SerialPort(name string, options *SerialPortOptions) (StreamInterface, error)
+	Channel(name string, options *ChannelOptions) (StreamInterface, error)
+	Pcap(name string, options *PcapOptions) (StreamInterface, error)
+	PortForward(name string, options *PortForwardOptions) (StreamInterface, error)
+	Backup(name string, options *BackupStreamOptions) (StreamInterface, error)
 	Pause(name string) error
 	Unpause(name string) error
 	GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
@@ -170,6 +227,22 @@ type VirtualMachineInstanceInterface interface {
 	FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemList, error)
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	AddInterface(name string, addInterfaceOptions *v1.AddInterfaceOptions) error
+	RemoveInterface(name string, removeInterfaceOptions *v1.RemoveInterfaceOptions) error
+	SetLinkState(name string, setLinkStateOptions *v1.SetLinkStateOptions) error
+	InsertMedia(name string, insertMediaOptions *v1.InsertMediaOptions) error
+	EjectMedia(name string, ejectMediaOptions *v1.EjectMediaOptions) error
+	SEVFetchCertChain(name string) (v1.SEVPlatformInfo, error)
+	SEVQueryLaunchMeasurement(name string) (v1.SEVMeasurementInfo, error)
+	SEVInjectLaunchSecret(name string, options *v1.SEVSecretOptions) error
+	SerialConsoleLog(name string) (string, error)
+	Freeze(name string, unfreezeTimeout time.Duration) error
+	Unfreeze(name string) error
+	StartBackup(name string, options *v1.BackupOptions) error
+	StopBackup(name string) error
+	MemoryDump(name string, options *v1.MemoryDumpOptions) error
+	ExternalSnapshot(name string, options *v1.ExternalSnapshotOptions) error
+	BlockCommit(name string, options *v1.BlockCommitOptions) error
 }
 
 type ReplicaSetInterface interface {
@@ -210,9 +283,11 @@ type VirtualMachineInterface interface {
 	Start(name string) error
 	Stop(name string) error
 	Migrate(name string) error
+	NetworkBoot(name string) error
 	Rename(name string, options *v1.RenameOptions) error
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	RemoveInterface(name string, removeInterfaceOptions *v1.RemoveInterfaceOptions) error
 }
 
 type VirtualMachineInstanceMigrationInterface interface {
@@ -24,9 +24,11 @@ import (
 
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -44,6 +46,14 @@ import (
 
 const vmiSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachineinstances/%s/%s"
 
+const (
+	// sessionResumeTimeout is how long a stream tries to resume its session after a connection loss,
+	// virt-handler keeps a detached session for 30 seconds
+	sessionResumeTimeout = 30 * time.Second
+	// sessionResumeInterval is the time between two attempts to resume a session
+	sessionResumeInterval = time.Second
+)
+
 func (k *kubevirt) VirtualMachineInstance(namespace string) VirtualMachineInstanceInterface {
 	return &vmis{
 		restClient: k.restClient,
@@ -82,6 +92,8 @@ func (d *WebsocketRoundTripper) RoundTrip(r *http.Request) (*http.Response, erro
 type asyncWSRoundTripper struct {
 	Done       chan struct{}
 	Connection chan *websocket.Conn
+	// SessionToken is the token of the session of the connection, it is set before the connection is passed on
+	SessionToken string
 }
 
 func (aws *asyncWSRoundTripper) WebsocketCallback(ws *websocket.Conn, resp *http.Response, err error) error {
@@ -92,6 +104,7 @@ func (aws *asyncWSRoundTripper) WebsocketCallback(ws *websocket.Conn, resp *http
 		}
 		return fmt.Errorf("Can't connect to websocket: %s\n", err.Error())
 	}
+	aws.SessionToken = resp.Header.Get(subresources.SessionTokenHeader)
 	aws.Connection <- ws
 
 	// Keep the roundtripper open until we are done with the stream
@@ -154,31 +167,252 @@ func RequestFromConfig(config *rest.Config, vmi string, namespace string, resour
 type wsStreamer struct {
 	conn *websocket.Conn
 	done chan struct{}
+	// token of the session of the stream, empty if the stream can't be resumed
+	token string
+	// resume opens a new connection to the session with the given token
+	resume func(token string) (*wsStreamer, error)
 }
 
 func (ws *wsStreamer) streamDone() {
 	close(ws.done)
 }
 
+// Stream copies the input to the stream and the stream to the output until either ends. When the connection
+// is lost and the server kept the stream as a session, which it only does for the serial console, the session
+// is resumed with a new connection and the stream goes on where it stopped. Other streams fail instead.
 func (ws *wsStreamer) Stream(options StreamOptions) error {
-	copyErr := make(chan error, 1)
+	stop := make(chan struct{})
+	defer close(stop)
 
+	// The input is read independently of the connections, so that the input read before a connection loss
+	// is sent over the next connection
+	input := make(chan []byte)
+	inputErr := make(chan error, 1)
 	go func() {
-		_, err := CopyTo(ws.conn, options.In)
-		copyErr <- err
+		buf := make([]byte, WebsocketMessageBufferSize)
+		for {
+			n, err := options.In.Read(buf)
+			if n > 0 {
+				data := make([]byte, n)
+				copy(data, buf[:n])
+				select {
+				case input <- data:
+				case <-stop:
+					return
+				}
+			}
+			if err != nil {
+				inputErr <- err
+				return
+			}
+		}
 	}()
 
+	var pending []byte
+	for {
+		ended, err := ws.streamConnection(options.Out, input, inputErr, &pending)
+		if ended || ws.token == "" || ws.resume == nil {
+			ws.streamDone()
+			return err
+		}
+		if resumeErr := ws.resumeSession(); resumeErr != nil {
+			return err
+		}
+	}
+}
+
+// streamConnection streams over the current connection. It reports whether the stream ended, rather than
+// the connection was lost. Input which could not be sent is left in pending.
+func (ws *wsStreamer) streamConnection(out io.Writer, input chan []byte, inputErr chan error, pending *[]byte) (bool, error) {
+	outputErr := make(chan error, 1)
 	go func() {
-		_, err := CopyFrom(options.Out, ws.conn)
-		copyErr <- err
+		_, err := CopyFrom(out, ws.conn)
+		outputErr <- err
 	}()
+	// the connection is closed before it is replaced, which ends the copy of its output
+	lost := func(err error) (bool, error) {
+		ws.conn.Close()
+		<-outputErr
+		return false, err
+	}
 
-	defer ws.streamDone()
-	return <-copyErr
+	writer := &binaryWriter{conn: ws.conn}
+	for {
+		if *pending != nil {
+			if _, err := writer.Write(*pending); err != nil {
+				return lost(err)
+			}
+			*pending = nil
+		}
+		select {
+		case *pending = <-input:
+		case err := <-inputErr:
+			if err == io.EOF {
+				// ends the session instead of leaving it for a reconnect
+				ws.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+				return true, nil
+			}
+			return true, err
+		case err := <-outputErr:
+			if err == nil {
+				return true, nil
+			}
+			ws.conn.Close()
+			return false, err
+		}
+	}
+}
+
+// resumeSession replaces the lost connection with a new connection to the same session
+func (ws *wsStreamer) resumeSession() error {
+	ws.streamDone()
+	deadline := time.Now().Add(sessionResumeTimeout)
+	for {
+		resumed, err := ws.resume(ws.token)
+		if err == nil {
+			ws.conn = resumed.conn
+			ws.done = resumed.done
+			return nil
+		}
+		if asyncErr, ok := err.(*AsyncSubresourceError); ok && asyncErr.GetStatusCode() == http.StatusNotFound {
+			// the session is gone, it expired or its stream ended
+			return err
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(sessionResumeInterval)
+	}
 }
 
 func (v *vmis) VNC(name string) (StreamInterface, error) {
-	return v.asyncSubresourceHelper(name, "vnc")
+	return v.asyncSubresourceHelper(name, "vnc", nil)
+}
+
+type VSOCKOptions struct {
+	// TargetPort is the vsock port inside the guest to connect to
+	TargetPort uint32
+}
+
+func (v *vmis) VSOCK(name string, options *VSOCKOptions) (StreamInterface, error) {
+	if options == nil || options.TargetPort == 0 {
+		return nil, fmt.Errorf("target port is required but not provided")
+	}
+	queryParams := url.Values{}
+	queryParams.Add("port", strconv.FormatUint(uint64(options.TargetPort), 10))
+	return v.asyncSubresourceHelper(name, "vsock", queryParams)
+}
+
+type SerialPortOptions struct {
+	// Name is the name of the additional serial port to connect to
+	Name string
+}
+
+func (v *vmis) SerialPort(name string, options *SerialPortOptions) (StreamInterface, error) {
+	if options == nil || options.Name == "" {
+		return nil, fmt.Errorf("serial port name is required but not provided")
+	}
+	queryParams := url.Values{}
+	queryParams.Add("device", options.Name)
+	return v.asyncSubresourceHelper(name, "serial", queryParams)
+}
+
+type ChannelOptions struct {
+	// Name is the name of the channel to connect to
+	Name string
+}
+
+func (v *vmis) Channel(name string, options *ChannelOptions) (StreamInterface, error) {
+	if options == nil || options.Name == "" {
+		return nil, fmt.Errorf("channel name is required but not provided")
+	}
+	queryParams := url.Values{}
+	queryParams.Add("device", options.Name)
+	return v.asyncSubresourceHelper(name, "channel", queryParams)
+}
+
+type PcapOptions struct {
+	// Interface is the name of the VMI interface to capture packets on
+	Interface string
+	// Filter is a BPF program, as printed by "tcpdump -ddd <expression>", which selects the captured packets
+	Filter string
+	// Count is the number of packets after which the capture stops, virt-handler applies a default if zero
+	Count uint32
+	// Duration is the time after which the capture stops, virt-handler applies a default if zero
+	Duration time.Duration
+}
+
+func (o *PcapOptions) queryParams() url.Values {
+	queryParams := url.Values{}
+	queryParams.Add("interface", o.Interface)
+	if o.Filter != "" {
+		queryParams.Add("filter", o.Filter)
+	}
+	if o.Count > 0 {
+		queryParams.Add("count", strconv.FormatUint(uint64(o.Count), 10))
+	}
+	if o.Duration > 0 {
+		queryParams.Add("duration", strconv.FormatInt(int64(o.Duration.Seconds()), 10))
+	}
+	return queryParams
+}
+
+// Pcap streams a packet capture, in the pcap format, of an interface of the VMI
+func (v *vmis) Pcap(name string, options *PcapOptions) (StreamInterface, error) {
+	if options == nil || options.Interface == "" {
+		return nil, fmt.Errorf("interface name is required but not provided")
+	}
+	return v.asyncSubresourceHelper(name, "pcap", options.queryParams())
+}
+
+type PortForwardOptions struct {
+	// Port is the port of the guest to connect to
+	Port uint16
+	// Protocol is the protocol of the port, either tcp or udp, tcp if empty
+	Protocol string
+}
+
+func (o *PortForwardOptions) queryParams() url.Values {
+	queryParams := url.Values{}
+	queryParams.Add("port", strconv.FormatUint(uint64(o.Port), 10))
+	if o.Protocol != "" {
+		queryParams.Add("protocol", o.Protocol)
+	}
+	return queryParams
+}
+
+// PortForward opens a connection to a TCP or UDP port of the VMI
+func (v *vmis) PortForward(name string, options *PortForwardOptions) (StreamInterface, error) {
+	if options == nil || options.Port == 0 {
+		return nil, fmt.Errorf("port is required but not provided")
+	}
+	return v.asyncSubresourceHelper(name, "portforward", options.queryParams())
+}
+
+type BackupStreamOptions struct {
+	// Disk is the name of the disk whose changed blocks are streamed
+	Disk string
+	// ExtentsOnly streams only the offsets and lengths of the changed extents, without their data
+	ExtentsOnly bool
+}
+
+func (o *BackupStreamOptions) queryParams() url.Values {
+	queryParams := url.Values{}
+	queryParams.Add("disk", o.Disk)
+	if o.ExtentsOnly {
+		queryParams.Add("extentsOnly", "true")
+	}
+	return queryParams
+}
+
+// Backup streams the blocks of a disk which the running backup of the VMI reports as changed. The stream
+// starts with the size of the disk, followed by the offset, the length and the data of every changed extent.
+// All numbers are unsigned 64 bit integers in network byte order.
+func (v *vmis) Backup(name string, options *BackupStreamOptions) (StreamInterface, error) {
+	if options == nil || options.Disk == "" {
+		return nil, fmt.Errorf("disk name is required but not provided")
+	}
+	return v.asyncSubresourceHelper(name, "backup", options.queryParams())
 }
 
 type connectionStruct struct {
@@ -209,7 +443,7 @@ func (v *vmis) SerialConsole(name string, options *SerialConsoleOptions) (Stream
 				default:
 				}
 
-				con, err := v.asyncSubresourceHelper(name, "console")
+				con, err := v.asyncSubresourceHelper(name, "console", nil)
 				if err != nil {
 					asyncSubresourceError, ok := err.(*AsyncSubresourceError)
 					// return if response status code does not equal to 400
@@ -229,7 +463,7 @@ func (v *vmis) SerialConsole(name string, options *SerialConsoleOptions) (Stream
 		conStruct := <-connectionChan
 		return conStruct.con, conStruct.err
 	} else {
-		return v.asyncSubresourceHelper(name, "console")
+		return v.asyncSubresourceHelper(name, "console", nil)
 	}
 }
 
@@ -246,7 +480,7 @@ func (a *AsyncSubresourceError) GetStatusCode() int {
 	return a.StatusCode
 }
 
-func (v *vmis) asyncSubresourceHelper(name string, resource string) (StreamInterface, error) {
+func (v *vmis) asyncSubresourceHelper(name string, resource string, queryParams url.Values) (StreamInterface, error) {
 
 	done := make(chan struct{})
 
@@ -265,6 +499,7 @@ func (v *vmis) asyncSubresourceHelper(name string, resource string) (StreamInter
 	if err != nil {
 		return nil, fmt.Errorf("unable to create request for remote execution: %v", err)
 	}
+	req.URL.RawQuery = queryParams.Encode()
 
 	errChan := make(chan error, 1)
 
@@ -302,8 +537,21 @@ func (v *vmis) asyncSubresourceHelper(name string, resource string) (StreamInter
 		return nil, err
 	case ws := <-aws.Connection:
 		return &wsStreamer{
-			conn: ws,
-			done: done,
+			conn:  ws,
+			done:  done,
+			token: aws.SessionToken,
+			resume: func(token string) (*wsStreamer, error) {
+				resumeParams := url.Values{}
+				for key, values := range queryParams {
+					resumeParams[key] = values
+				}
+				resumeParams.Set(subresources.SessionTokenParam, token)
+				stream, err := v.asyncSubresourceHelper(name, resource, resumeParams)
+				if err != nil {
+					return nil, err
+				}
+				return stream.(*wsStreamer), nil
+			},
 		}, nil
 	}
 }
@@ -318,6 +566,83 @@ func (v *vmis) Unpause(name string) error {
 	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
 }
 
+func (v *vmis) Freeze(name string, unfreezeTimeout time.Duration) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "freeze")
+
+	freezeUnfreezeTimeout := v1.FreezeUnfreezeTimeout{
+		UnfreezeTimeout: &k8smetav1.Duration{
+			Duration: unfreezeTimeout,
+		},
+	}
+
+	JSON, err := json.Marshal(freezeUnfreezeTimeout)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+func (v *vmis) Unfreeze(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "unfreeze")
+	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
+}
+
+func (v *vmis) StartBackup(name string, options *v1.BackupOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "startbackup")
+
+	JSON, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+func (v *vmis) StopBackup(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "stopbackup")
+	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
+}
+
+// MemoryDump starts dumping the memory of the guest to its memory dump PVC. The progress of the dump is
+// reported in the status of the VMI.
+func (v *vmis) MemoryDump(name string, options *v1.MemoryDumpOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "memorydump")
+
+	JSON, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+// ExternalSnapshot switches the disks of the given volumes to new qcow2 overlays, which freezes their current
+// images. The files of each volume are reported in the status of the VMI.
+func (v *vmis) ExternalSnapshot(name string, options *v1.ExternalSnapshotOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "externalsnapshot")
+
+	JSON, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+// BlockCommit starts merging the qcow2 overlays of the given volumes back into their images. The progress of the
+// commit is reported in the status of the VMI.
+func (v *vmis) BlockCommit(name string, options *v1.BlockCommitOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "blockcommit")
+
+	JSON, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
 func (v *vmis) Get(name string, options *k8smetav1.GetOptions) (vmi *v1.VirtualMachineInstance, err error) {
 	vmi = &v1.VirtualMachineInstance{}
 	err = v.restClient.Get().
@@ -497,3 +822,105 @@ func (v *vmis) RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOpt
 
 	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
 }
+
+func (v *vmis) AddInterface(name string, addInterfaceOptions *v1.AddInterfaceOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "addinterface")
+
+	JSON, err := json.Marshal(addInterfaceOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+func (v *vmis) RemoveInterface(name string, removeInterfaceOptions *v1.RemoveInterfaceOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "removeinterface")
+
+	JSON, err := json.Marshal(removeInterfaceOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+func (v *vmis) SetLinkState(name string, setLinkStateOptions *v1.SetLinkStateOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "setlinkstate")
+
+	JSON, err := json.Marshal(setLinkStateOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+func (v *vmis) InsertMedia(name string, insertMediaOptions *v1.InsertMediaOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "insertmedia")
+
+	JSON, err := json.Marshal(insertMediaOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+func (v *vmis) EjectMedia(name string, ejectMediaOptions *v1.EjectMediaOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "ejectmedia")
+
+	JSON, err := json.Marshal(ejectMediaOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+func (v *vmis) SEVFetchCertChain(name string) (v1.SEVPlatformInfo, error) {
+	sevPlatformInfo := v1.SEVPlatformInfo{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "sev/fetchcertchain")
+	rawInfo, err := v.restClient.Get().RequestURI(uri).Do(context.Background()).Raw()
+	if err != nil {
+		return sevPlatformInfo, err
+	}
+	err = json.Unmarshal(rawInfo, &sevPlatformInfo)
+	return sevPlatformInfo, err
+}
+
+func (v *vmis) SEVQueryLaunchMeasurement(name string) (v1.SEVMeasurementInfo, error) {
+	sevMeasurementInfo := v1.SEVMeasurementInfo{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "sev/querylaunchmeasurement")
+	rawInfo, err := v.restClient.Get().RequestURI(uri).Do(context.Background()).Raw()
+	if err != nil {
+		return sevMeasurementInfo, err
+	}
+	err = json.Unmarshal(rawInfo, &sevMeasurementInfo)
+	return sevMeasurementInfo, err
+}
+
+func (v *vmis) SEVInjectLaunchSecret(name string, options *v1.SEVSecretOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "sev/injectlaunchsecret")
+
+	JSON, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
+func (v *vmis) SerialConsoleLog(name string) (string, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "console/log")
+	rawLog, err := v.restClient.Get().RequestURI(uri).Do(context.Background()).Raw()
+	if err != nil {
+		return "", err
+	}
+	return string(rawLog), nil
+}
@@ -22,7 +22,9 @@ package kubecli
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	. "github.com/onsi/ginkgo"
@@ -34,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/subresources"
 )
 
 var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
@@ -144,6 +147,117 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should allow to connect a stream to a VSOCK port of a VM", func() {
+		vsockPath := "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm/vsock"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", vsockPath, "port=1234"),
+			func(w http.ResponseWriter, r *http.Request) {
+				_, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+			},
+		))
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).VSOCK("testvm", &VSOCKOptions{TargetPort: 1234})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should require a VSOCK port", func() {
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).VSOCK("testvm", &VSOCKOptions{})
+		Expect(err).To(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("should allow to connect a stream to a serial port of a VM", func() {
+		serialPath := "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm/serial"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", serialPath, "device=management"),
+			func(w http.ResponseWriter, r *http.Request) {
+				_, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+			},
+		))
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).SerialPort("testvm", &SerialPortOptions{Name: "management"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should allow to connect a stream to a channel of a VM", func() {
+		channelPath := "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm/channel"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", channelPath, "device=management"),
+			func(w http.ResponseWriter, r *http.Request) {
+				_, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+			},
+		))
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Channel("testvm", &ChannelOptions{Name: "management"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should allow to stream a packet capture of an interface of a VM", func() {
+		pcapPath := "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm/pcap"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", pcapPath, "count=10&duration=30&filter=6+0+0+262144&interface=default"),
+			func(w http.ResponseWriter, r *http.Request) {
+				_, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+			},
+		))
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Pcap("testvm", &PcapOptions{
+			Interface: "default",
+			Filter:    "6 0 0 262144",
+			Count:     10,
+			Duration:  30 * time.Second,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should require an interface name for a packet capture", func() {
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Pcap("testvm", &PcapOptions{Filter: "6 0 0 262144"})
+		Expect(err).To(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("should allow to forward a port of a VM", func() {
+		portForwardPath := "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm/portforward"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", portForwardPath, "port=53&protocol=udp"),
+			func(w http.ResponseWriter, r *http.Request) {
+				_, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+			},
+		))
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).PortForward("testvm", &PortForwardOptions{Port: 53, Protocol: "udp"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should require a port to forward", func() {
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).PortForward("testvm", &PortForwardOptions{Protocol: "udp"})
+		Expect(err).To(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("should require a device name", func() {
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).SerialPort("testvm", &SerialPortOptions{})
+		Expect(err).To(HaveOccurred())
+		_, err = client.VirtualMachineInstance(k8sv1.NamespaceDefault).Channel("testvm", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
 	It("should handle a failure connecting to the VM", func() {
 		vncPath := "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm/vnc"
 
@@ -219,6 +333,91 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(bufOut).To(Equal(bufIn))
 	})
 
+	Context("with a session", func() {
+		const token = "4f2a9c"
+		vncPath := subVMPath + "/vnc"
+
+		// dropConnection accepts a connection of the session and drops it once it received a message
+		dropConnection := func(w http.ResponseWriter, r *http.Request) {
+			c, err := upgrader.Upgrade(w, r, http.Header{subresources.SessionTokenHeader: []string{token}})
+			Expect(err).ToNot(HaveOccurred())
+			c.ReadMessage()
+			c.UnderlyingConn().Close()
+		}
+
+		It("should resume the session after a connection loss", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vncPath, ""),
+					dropConnection,
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vncPath, subresources.SessionTokenParam+"="+token),
+					func(w http.ResponseWriter, r *http.Request) {
+						c, err := upgrader.Upgrade(w, r, http.Header{subresources.SessionTokenHeader: []string{token}})
+						Expect(err).ToNot(HaveOccurred())
+						defer c.Close()
+						mt, message, err := c.ReadMessage()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(c.WriteMessage(mt, message)).To(Succeed())
+						c.ReadMessage()
+					},
+				),
+			)
+
+			vnc, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).VNC("testvm")
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeInReader, pipeInWriter := io.Pipe()
+			pipeOutReader, pipeOutWriter := io.Pipe()
+			go vnc.Stream(StreamOptions{
+				In:  pipeInReader,
+				Out: pipeOutWriter,
+			})
+
+			_, err = pipeInWriter.Write([]byte("lost"))
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(server.ReceivedRequests, 5*time.Second).Should(HaveLen(2))
+			_, err = pipeInWriter.Write([]byte("resumed"))
+			Expect(err).ToNot(HaveOccurred())
+
+			bufOut := make([]byte, 64)
+			n, err := pipeOutReader.Read(bufOut)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(bufOut[:n])).To(Equal("resumed"))
+		})
+
+		It("should fail the stream when the session is gone", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vncPath, ""),
+					dropConnection,
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vncPath, subresources.SessionTokenParam+"="+token),
+					ghttp.RespondWith(http.StatusNotFound, nil),
+				),
+			)
+
+			vnc, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).VNC("testvm")
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeInReader, pipeInWriter := io.Pipe()
+			streamErr := make(chan error, 1)
+			go func() {
+				streamErr <- vnc.Stream(StreamOptions{
+					In:  pipeInReader,
+					Out: ioutil.Discard,
+				})
+			}()
+
+			_, err = pipeInWriter.Write([]byte("lost"))
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(streamErr, 5*time.Second).Should(Receive(HaveOccurred()))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
 	It("should pause a VirtualMachineInstance", func() {
 		server.AppendHandlers(ghttp.CombineHandlers(
 			ghttp.VerifyRequest("PUT", subVMPath+"/pause"),
@@ -241,6 +440,29 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should freeze a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/freeze"),
+			ghttp.VerifyBody([]byte(`{"unfreezeTimeout":"1m0s"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Freeze("testvm", time.Minute)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should unfreeze a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/unfreeze"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Unfreeze("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	It("should fetch GuestOSInfo from VirtualMachineInstance via subresource", func() {
 		osInfo := v1.VirtualMachineInstanceGuestAgentInfo{
 			GAVersion: "4.1.1",
@@ -292,6 +514,19 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(fetchedInfo).To(Equal(fileSystemList), "fetched info should be the same as passed in")
 	})
 
+	It("should fetch the serial console log from VirtualMachineInstance via subresource", func() {
+		consoleLog := "Booting from Hard Disk...\n"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMPath+"/console/log"),
+			ghttp.RespondWith(http.StatusOK, consoleLog),
+		))
+		fetchedLog, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).SerialConsoleLog("testvm")
+
+		Expect(err).ToNot(HaveOccurred(), "should fetch the log normally")
+		Expect(fetchedLog).To(Equal(consoleLog), "fetched log should be the same as passed in")
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
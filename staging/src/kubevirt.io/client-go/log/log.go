@@ -432,3 +432,19 @@ func LogQemuLogLine(logger *FilteredLogger, line string) {
 		"msg", line,
 	)
 }
+
+func LogSerialConsoleLine(logger *FilteredLogger, line string) {
+
+	if len(strings.TrimSpace(line)) == 0 {
+		return
+	}
+
+	now := time.Now()
+	logger.logContext.Log(
+		"level", "info",
+		"timestamp", now.Format("2006-01-02T15:04:05.000000Z"),
+		"component", logger.component,
+		"subcomponent", "serial-console",
+		"msg", line,
+	)
+}
@@ -23,3 +23,12 @@ package subresources
 // Mostly useful for browser connections which need to use the websocket subprotocol
 // field to pass credentials. As a consequence they need to get a subprotocol back.
 const PlainStreamProtocolName = "plain.kubevirt.io"
+
+const (
+	// SessionTokenHeader is set on the websocket upgrade response of the streaming subresources.
+	// Its value can be passed back as the SessionTokenParam query parameter to reattach to the
+	// same stream after a connection loss.
+	SessionTokenHeader = "X-Kubevirt-Session-Token"
+	// SessionTokenParam is the query parameter used to resume a detached session
+	SessionTokenParam = "sessionToken"
+)
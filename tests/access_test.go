@@ -144,6 +144,13 @@ var _ = Describe("[rfe_id:500][crit:high][vendor:cnv-qe@redhat.com][level:compon
 			table.Entry("[test_id:5243]given a vmsnapshot", "virtualmachinesnapshots"),
 			table.Entry("[test_id:5244]given a vmsnapshotcontent", "virtualmachinesnapshotcontents"),
 			table.Entry("[test_id:5245]given a vmsrestore", "virtualmachinerestores"),
+			table.Entry("given a vmsnapshotschedule", "virtualmachinesnapshotschedules"),
+			table.Entry("given a vmexport", "virtualmachineexports"),
+			table.Entry("given a vmclone", "virtualmachineclones"),
+			table.Entry("given a vminstancetype", "virtualmachineinstancetypes"),
+			table.Entry("given a vmpreference", "virtualmachinepreferences"),
+			table.Entry("given a vmpool", "virtualmachinepools"),
+			table.Entry("given a storagecheckup", "storagecheckups"),
 		)
 
 		table.DescribeTable("should verify permissions on subresources are correct for view, edit, and admin", func(resource string, subresource string) {
@@ -297,6 +304,13 @@ var _ = Describe("[rfe_id:500][crit:high][vendor:cnv-qe@redhat.com][level:compon
 				table.Entry("[test_id:5246]given a vmsnapshot", "virtualmachinesnapshots"),
 				table.Entry("[test_id:5247]given a vmsnapshotcontent", "virtualmachinesnapshotcontents"),
 				table.Entry("[test_id:5248]given a vmsrestore", "virtualmachinerestores"),
+				table.Entry("given a vmsnapshotschedule", "virtualmachinesnapshotschedules"),
+				table.Entry("given a vmexport", "virtualmachineexports"),
+				table.Entry("given a vmclone", "virtualmachineclones"),
+				table.Entry("given a vminstancetype", "virtualmachineinstancetypes"),
+				table.Entry("given a vmpreference", "virtualmachinepreferences"),
+				table.Entry("given a vmpool", "virtualmachinepools"),
+				table.Entry("given a storagecheckup", "storagecheckups"),
 			)
 
 			table.DescribeTable("should verify permissions on resources are correct for subresources", func(resource string, action string) {
@@ -340,6 +354,13 @@ var _ = Describe("[rfe_id:500][crit:high][vendor:cnv-qe@redhat.com][level:compon
 				table.Entry("[test_id:5249]given a vmsnapshot", "virtualmachinesnapshots"),
 				table.Entry("[test_id:5250]given a vmsnapshotcontent", "virtualmachinesnapshotcontents"),
 				table.Entry("[test_id:5251]given a vmsrestore", "virtualmachinerestores"),
+				table.Entry("given a vmsnapshotschedule", "virtualmachinesnapshotschedules"),
+				table.Entry("given a vmexport", "virtualmachineexports"),
+				table.Entry("given a vmclone", "virtualmachineclones"),
+				table.Entry("given a vminstancetype", "virtualmachineinstancetypes"),
+				table.Entry("given a vmpreference", "virtualmachinepreferences"),
+				table.Entry("given a vmpool", "virtualmachinepools"),
+				table.Entry("given a storagecheckup", "storagecheckups"),
 			)
 
 			table.DescribeTable("should verify permissions on resources are correct for subresources", func(resource string, action string) {
@@ -184,6 +184,42 @@ var _ = Describe("[ref_id:1182]Probes", func() {
 			table.Entry("[test_id:1218][posneg:negative]with working HTTP probe and no running server", httpProbe),
 		)
 	})
+
+	Context("with guest agent", func() {
+		const (
+			period         = 5
+			initialSeconds = 5
+		)
+
+		It("should mark the VMI as ready with a guest agent ping readiness probe", func() {
+			By("Specifying a VMI with a guest agent ping readiness probe")
+			vmi = tests.NewRandomFedoraVMIWithGuestAgent()
+			vmi.Spec.ReadinessProbe = createGuestAgentPingProbe(period, initialSeconds)
+			vmi = createAndBlockUntilVMIHasStarted(virtClient, vmi)
+			tests.WaitAgentConnected(virtClient, vmi)
+
+			By("Checking that the VMI will be marked as ready to receive traffic")
+			Eventually(func() v1.ConditionStatus {
+				readVmi, err := virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &v13.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				return vmiReady(readVmi)
+			}, 120, 1).Should(Equal(v1.ConditionTrue))
+		})
+
+		It("should fail the VMI with a failing exec liveness probe", func() {
+			By("Specifying a VMI with an exec liveness probe which always fails")
+			vmi = tests.NewRandomFedoraVMIWithGuestAgent()
+			vmi.Spec.LivenessProbe = createExecProbe(period, initialSeconds, "/bin/false")
+			vmi = createAndBlockUntilVMIHasStarted(virtClient, vmi)
+
+			By("Checking that the VMI is in a final state")
+			Eventually(func() bool {
+				vmi, err := virtClient.VirtualMachineInstance(tests.NamespaceTestDefault).Get(vmi.Name, &v13.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				return vmi.IsFinal()
+			}, 300, 1).Should(BeTrue())
+		})
+	})
 })
 
 func createReadyCirrosVMIWithReadinessProbe(virtClient kubecli.KubevirtClient, probe *v12.Probe) *v12.VirtualMachineInstance {
@@ -260,6 +296,16 @@ func createHTTPProbe(period int32, initialSeconds int32, port int) *v12.Probe {
 	return createProbeSpecification(period, initialSeconds, httpHandler)
 }
 
+func createGuestAgentPingProbe(period int32, initialSeconds int32) *v12.Probe {
+	handler := v12.Handler{GuestAgentPing: &v12.GuestAgentPing{}}
+	return createProbeSpecification(period, initialSeconds, handler)
+}
+
+func createExecProbe(period int32, initialSeconds int32, command ...string) *v12.Probe {
+	handler := v12.Handler{Exec: &v1.ExecAction{Command: command}}
+	return createProbeSpecification(period, initialSeconds, handler)
+}
+
 func createProbeSpecification(period int32, initialSeconds int32, handler v12.Handler) *v12.Probe {
 	return &v12.Probe{
 		PeriodSeconds:       period,
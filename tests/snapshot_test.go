@@ -114,16 +114,32 @@ var _ = Describe("[Serial]VirtualMachineSnapshot Tests", func() {
 			Expect(content.Spec.VolumeBackups).To(BeEmpty())
 		})
 
-		It("[test_id:4610]should not create a snapshot when VM is running", func() {
+		It("should successfully create an online snapshot when VM is running", func() {
 			patch := []byte("[{ \"op\": \"replace\", \"path\": \"/spec/running\", \"value\": true }]")
-			vm, err := virtClient.VirtualMachine(vm.Namespace).Patch(vm.Name, types.JSONPatchType, patch)
+			vm, err = virtClient.VirtualMachine(vm.Namespace).Patch(vm.Name, types.JSONPatchType, patch)
 			Expect(err).ToNot(HaveOccurred())
 
+			Eventually(func() bool {
+				vmi, err := virtClient.VirtualMachineInstance(vm.Namespace).Get(vm.Name, &metav1.GetOptions{})
+				if errors.IsNotFound(err) {
+					return false
+				}
+				Expect(err).ToNot(HaveOccurred())
+				return vmi.Status.Phase == v1.Running
+			}, 180*time.Second, time.Second).Should(BeTrue())
+
 			snapshot = newSnapshot()
 
 			_, err = virtClient.VirtualMachineSnapshot(snapshot.Namespace).Create(context.Background(), snapshot, metav1.CreateOptions{})
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).Should(ContainSubstring(fmt.Sprintf("VirtualMachine \"%s\" is running", vm.Name)))
+			Expect(err).ToNot(HaveOccurred())
+
+			waitSnapshotReady()
+
+			// cirros has no guest agent, the snapshot is only crash consistent
+			Expect(snapshot.Status.Indications).To(ConsistOf(
+				snapshotv1.VMSnapshotOnlineSnapshotIndication,
+				snapshotv1.VMSnapshotNoGuestAgentIndication,
+			))
 		})
 
 		It("VM should contain snapshot status for all volumes", func() {